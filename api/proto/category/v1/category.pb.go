@@ -0,0 +1,494 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: category/v1/category.proto
+
+package categoryv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Category is a node of the catalog tree.
+type Category struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// parent_id is unset for a root category.
+	ParentId *string `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	Name     string  `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Slug     string  `protobuf:"bytes,4,opt,name=slug,proto3" json:"slug,omitempty"`
+	Position int32   `protobuf:"varint,5,opt,name=position,proto3" json:"position,omitempty"`
+	// created_at and updated_at are Unix milliseconds.
+	CreatedAt     int64  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *int64 `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_category_v1_category_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_category_v1_category_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_category_v1_category_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Category) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Category) GetParentId() string {
+	if x != nil && x.ParentId != nil {
+		return *x.ParentId
+	}
+	return ""
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Category) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Category) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Category) GetUpdatedAt() int64 {
+	if x != nil && x.UpdatedAt != nil {
+		return *x.UpdatedAt
+	}
+	return 0
+}
+
+type ListCategoriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// limit defaults to 50, at most 100.
+	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_category_v1_category_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_category_v1_category_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_category_v1_category_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListCategoriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCategoriesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_category_v1_category_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_category_v1_category_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_category_v1_category_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type GetCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryRequest) Reset() {
+	*x = GetCategoryRequest{}
+	mi := &file_category_v1_category_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryRequest) ProtoMessage() {}
+
+func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_category_v1_category_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_category_v1_category_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCategoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryResponse) Reset() {
+	*x = GetCategoryResponse{}
+	mi := &file_category_v1_category_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryResponse) ProtoMessage() {}
+
+func (x *GetCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_category_v1_category_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_category_v1_category_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCategoryResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type ListCategoryChildrenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ParentId      string                 `protobuf:"bytes,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoryChildrenRequest) Reset() {
+	*x = ListCategoryChildrenRequest{}
+	mi := &file_category_v1_category_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoryChildrenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoryChildrenRequest) ProtoMessage() {}
+
+func (x *ListCategoryChildrenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_category_v1_category_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoryChildrenRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoryChildrenRequest) Descriptor() ([]byte, []int) {
+	return file_category_v1_category_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListCategoryChildrenRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type ListCategoryChildrenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoryChildrenResponse) Reset() {
+	*x = ListCategoryChildrenResponse{}
+	mi := &file_category_v1_category_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoryChildrenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoryChildrenResponse) ProtoMessage() {}
+
+func (x *ListCategoryChildrenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_category_v1_category_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoryChildrenResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoryChildrenResponse) Descriptor() ([]byte, []int) {
+	return file_category_v1_category_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListCategoryChildrenResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+var File_category_v1_category_proto protoreflect.FileDescriptor
+
+const file_category_v1_category_proto_rawDesc = "" +
+	"\n" +
+	"\x1acategory/v1/category.proto\x12\vcategory.v1\"\xe0\x01\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\tparent_id\x18\x02 \x01(\tH\x00R\bparentId\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x04 \x01(\tR\x04slug\x12\x1a\n" +
+	"\bposition\x18\x05 \x01(\x05R\bposition\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\"\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03H\x01R\tupdatedAt\x88\x01\x01B\f\n" +
+	"\n" +
+	"_parent_idB\r\n" +
+	"\v_updated_at\"E\n" +
+	"\x15ListCategoriesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"O\n" +
+	"\x16ListCategoriesResponse\x125\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x15.category.v1.CategoryR\n" +
+	"categories\"$\n" +
+	"\x12GetCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x13GetCategoryResponse\x121\n" +
+	"\bcategory\x18\x01 \x01(\v2\x15.category.v1.CategoryR\bcategory\":\n" +
+	"\x1bListCategoryChildrenRequest\x12\x1b\n" +
+	"\tparent_id\x18\x01 \x01(\tR\bparentId\"U\n" +
+	"\x1cListCategoryChildrenResponse\x125\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x15.category.v1.CategoryR\n" +
+	"categories2\xab\x02\n" +
+	"\x0fCategoryService\x12Y\n" +
+	"\x0eListCategories\x12\".category.v1.ListCategoriesRequest\x1a#.category.v1.ListCategoriesResponse\x12P\n" +
+	"\vGetCategory\x12\x1f.category.v1.GetCategoryRequest\x1a .category.v1.GetCategoryResponse\x12k\n" +
+	"\x14ListCategoryChildren\x12(.category.v1.ListCategoryChildrenRequest\x1a).category.v1.ListCategoryChildrenResponseB2Z0voyago/core-api/api/proto/category/v1;categoryv1b\x06proto3"
+
+var (
+	file_category_v1_category_proto_rawDescOnce sync.Once
+	file_category_v1_category_proto_rawDescData []byte
+)
+
+func file_category_v1_category_proto_rawDescGZIP() []byte {
+	file_category_v1_category_proto_rawDescOnce.Do(func() {
+		file_category_v1_category_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_category_v1_category_proto_rawDesc), len(file_category_v1_category_proto_rawDesc)))
+	})
+	return file_category_v1_category_proto_rawDescData
+}
+
+var file_category_v1_category_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_category_v1_category_proto_goTypes = []any{
+	(*Category)(nil),                     // 0: category.v1.Category
+	(*ListCategoriesRequest)(nil),        // 1: category.v1.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),       // 2: category.v1.ListCategoriesResponse
+	(*GetCategoryRequest)(nil),           // 3: category.v1.GetCategoryRequest
+	(*GetCategoryResponse)(nil),          // 4: category.v1.GetCategoryResponse
+	(*ListCategoryChildrenRequest)(nil),  // 5: category.v1.ListCategoryChildrenRequest
+	(*ListCategoryChildrenResponse)(nil), // 6: category.v1.ListCategoryChildrenResponse
+}
+var file_category_v1_category_proto_depIdxs = []int32{
+	0, // 0: category.v1.ListCategoriesResponse.categories:type_name -> category.v1.Category
+	0, // 1: category.v1.GetCategoryResponse.category:type_name -> category.v1.Category
+	0, // 2: category.v1.ListCategoryChildrenResponse.categories:type_name -> category.v1.Category
+	1, // 3: category.v1.CategoryService.ListCategories:input_type -> category.v1.ListCategoriesRequest
+	3, // 4: category.v1.CategoryService.GetCategory:input_type -> category.v1.GetCategoryRequest
+	5, // 5: category.v1.CategoryService.ListCategoryChildren:input_type -> category.v1.ListCategoryChildrenRequest
+	2, // 6: category.v1.CategoryService.ListCategories:output_type -> category.v1.ListCategoriesResponse
+	4, // 7: category.v1.CategoryService.GetCategory:output_type -> category.v1.GetCategoryResponse
+	6, // 8: category.v1.CategoryService.ListCategoryChildren:output_type -> category.v1.ListCategoryChildrenResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_category_v1_category_proto_init() }
+func file_category_v1_category_proto_init() {
+	if File_category_v1_category_proto != nil {
+		return
+	}
+	file_category_v1_category_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_category_v1_category_proto_rawDesc), len(file_category_v1_category_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_category_v1_category_proto_goTypes,
+		DependencyIndexes: file_category_v1_category_proto_depIdxs,
+		MessageInfos:      file_category_v1_category_proto_msgTypes,
+	}.Build()
+	File_category_v1_category_proto = out.File
+	file_category_v1_category_proto_goTypes = nil
+	file_category_v1_category_proto_depIdxs = nil
+}
@@ -0,0 +1,13 @@
+// Command grpc runs voyago serve --transport grpc, for the deployments
+// started with go run ./cmd/grpc. It takes the flags of the command, e.g.
+// --config.
+package main
+
+import (
+	"os"
+	"voyago/core-api/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(append([]string{"serve", "--transport", "grpc"}, os.Args[1:]...), os.Stdout, os.Stderr))
+}
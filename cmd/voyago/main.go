@@ -0,0 +1,21 @@
+// Command voyago is the command line of the service: it starts the servers
+// and the worker and runs the operational tasks, see package cli.
+//
+// Usage:
+//
+//	go run ./cmd/voyago serve [--transport http|grpc]
+//	go run ./cmd/voyago worker
+//	go run ./cmd/voyago migrate [--domain booking] up|down [N]|force V|status
+//	go run ./cmd/voyago seed [--domain booking]
+//	go run ./cmd/voyago routes
+//	go run ./cmd/voyago doctor [--timeout 3s]
+package main
+
+import (
+	"os"
+	"voyago/core-api/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
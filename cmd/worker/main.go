@@ -0,0 +1,12 @@
+// Command worker runs voyago worker, for the deployments started with
+// go run ./cmd/worker. It takes the flags of the command, e.g. --config.
+package main
+
+import (
+	"os"
+	"voyago/core-api/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.Run(append([]string{"worker"}, os.Args[1:]...), os.Stdout, os.Stderr))
+}
@@ -0,0 +1,251 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"voyago/core-api/internal/infrastructure/cache"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/leader"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/lock"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/messaging"
+	"voyago/core-api/internal/infrastructure/messaging/amqp"
+	"voyago/core-api/internal/infrastructure/messaging/kafka"
+	"voyago/core-api/internal/infrastructure/scheduler"
+	"voyago/core-api/internal/infrastructure/taskqueue"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules"
+)
+
+// background is what the message consumers and the scheduled jobs run with.
+// They run in the HTTP server, or in the worker runtime alone with
+// worker.standalone.
+type background struct {
+	cfg       *config.Config
+	log       logger.Logger
+	tracer    tracer.Tracer
+	metrics   metrics.Metrics
+	bus       eventbus.Bus
+	transport kafka.Transport
+}
+
+// setupMessaging consumes the topics registered by the modules on the
+// enabled brokers, Kafka and/or RabbitMQ. The consumers stop with the event
+// bus, before the workers and the databases; the publishers of the failed
+// messages are closed with the resources.
+func (d *domainInfrastructure) setupMessaging(bg background) {
+	if bg.cfg == nil || (!bg.cfg.Kafka.Enabled && !bg.cfg.AMQP.Enabled) {
+		return
+	}
+
+	router := messaging.NewRouter()
+	for _, m := range modules.All() {
+		if m.Messaging != nil {
+			m.Messaging(modules.Messaging{Env: d.moduleEnv(m.Name, bg), Router: router})
+		}
+	}
+
+	if bg.cfg.Kafka.Enabled {
+		d.setupKafka(bg, router)
+	}
+	if bg.cfg.AMQP.Enabled {
+		d.setupAMQP(bg, router)
+	}
+}
+
+func (d *domainInfrastructure) setupKafka(bg background, router *messaging.Router) {
+	if bg.transport == nil {
+		panic(fmt.Errorf("invalid kafka configuration: enabled without a Kafka transport (kafka.NewClient)"))
+	}
+
+	consumerCfg := bg.cfg.Kafka.Consumer
+	if consumerCfg.GroupID == "" {
+		consumerCfg.GroupID = bg.cfg.App.Name
+	}
+	producer := kafka.NewProducer(bg.transport, bg.tracer)
+	runner, err := kafka.NewRunner(consumerCfg, router, bg.transport, producer, bg.log, bg.tracer, bg.metrics)
+	if err != nil {
+		panic(fmt.Errorf("invalid kafka configuration: %w", err))
+	}
+	runner.Start()
+	d.lifecycle.Register(lifecycle.PhaseConsumers, "kafka consumers", lifecycle.Func(runner.Stop))
+	d.lifecycle.Register(lifecycle.PhaseResources, "kafka producer", lifecycle.Closer(producer.Close))
+}
+
+// setupAMQP consumes the topics of router on RabbitMQ. The broker must be
+// reachable at startup; the connection is opened again when lost later.
+func (d *domainInfrastructure) setupAMQP(bg background, router *messaging.Router) {
+	cfg := bg.cfg.AMQP
+	if cfg.Consumer.Group == "" {
+		cfg.Consumer.Group = bg.cfg.App.Name
+	}
+
+	conn, err := amqp.Dial(&cfg, bg.log)
+	if err != nil {
+		panic(fmt.Errorf("failed to connect to rabbitmq: %w", err))
+	}
+	publisher, err := amqp.NewPublisher(conn, &cfg, bg.tracer)
+	if err != nil {
+		panic(err)
+	}
+	consumer, err := amqp.NewConsumer(conn, &cfg, router, publisher, bg.log, bg.tracer, bg.metrics)
+	if err != nil {
+		panic(fmt.Errorf("invalid amqp configuration: %w", err))
+	}
+	if err := consumer.Start(); err != nil {
+		panic(fmt.Errorf("failed to start the amqp consumers: %w", err))
+	}
+	d.lifecycle.Register(lifecycle.PhaseConsumers, "amqp consumers", lifecycle.Func(consumer.Stop))
+	// Closing the connection closes the channel of the publisher.
+	d.lifecycle.Register(lifecycle.PhaseResources, "amqp connection", lifecycle.Closer(conn.Close))
+}
+
+// setupJobs schedules the periodic jobs of the modules. The running jobs
+// complete with the workers, before the databases are closed.
+func (d *domainInfrastructure) setupJobs(bg background) {
+	s := scheduler.New(bg.log, bg.tracer, bg.metrics)
+	elector := d.leaderElector(bg)
+	if elector != nil {
+		s.RunOnLeader(elector)
+	}
+	for _, m := range modules.All() {
+		if m.Jobs != nil {
+			m.Jobs(modules.Jobs{Env: d.moduleEnv(m.Name, bg), Scheduler: s})
+		}
+	}
+
+	if elector == nil {
+		s.Start()
+		d.lifecycle.Register(lifecycle.PhaseWorkers, "scheduler", lifecycle.Func(s.Stop))
+		return
+	}
+	elector.Start()
+	s.Start()
+	// The leadership is resigned once the running jobs completed.
+	d.lifecycle.Register(lifecycle.PhaseWorkers, "scheduler", lifecycle.Func(func() {
+		s.Stop()
+		elector.Stop()
+	}))
+}
+
+// leaderElector returns the elector of the replica running the jobs, nil
+// when every replica runs them.
+func (d *domainInfrastructure) leaderElector(bg background) *leader.Elector {
+	if bg.cfg == nil || !bg.cfg.Scheduler.LeaderElection.Enabled {
+		return nil
+	}
+
+	cfg := bg.cfg.Scheduler.LeaderElection
+	if cfg.Key == "" {
+		cfg.Key = "scheduler:leader"
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 15
+	}
+
+	var locker lock.Locker
+	switch cfg.Driver {
+	case config.LeaderElectionDriverRedis, "":
+		locker = lock.NewRedisLocker(d.sharedRedis(bg).Client(), "voyago:lock:")
+	case config.LeaderElectionDriverPostgres:
+		if cfg.Domain == "" {
+			cfg.Domain = "booking"
+		}
+		db, ok := d.dbs[cfg.Domain]
+		if !ok {
+			panic(fmt.Errorf("invalid scheduler configuration: no database for domain %q", cfg.Domain))
+		}
+		sqlDB, err := db.GetDB().DB()
+		if err != nil {
+			panic(fmt.Errorf("invalid scheduler configuration: %w", err))
+		}
+		locker = lock.NewPostgresLocker(sqlDB)
+	default:
+		panic(fmt.Errorf("invalid scheduler configuration: unknown leader election driver %q", cfg.Driver))
+	}
+	return leader.New(locker, cfg.Key, time.Duration(cfg.TTL)*time.Second, bg.log, bg.metrics)
+}
+
+// sharedRedis returns the Redis client of the configuration, created on the
+// first call. Redis being unreachable at startup is logged, not fatal: the
+// features using it fail (or fail open) until it is back.
+func (d *domainInfrastructure) sharedRedis(bg background) *cache.Redis {
+	if d.redis != nil {
+		return d.redis
+	}
+	r, err := cache.NewRedis(&bg.cfg.Redis, bg.tracer)
+	if err != nil {
+		panic(fmt.Errorf("invalid redis configuration: %w", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := r.Ping(ctx); err != nil {
+		bg.log.WithFields(map[string]any{
+			"mode":  r.Mode(),
+			"error": err.Error(),
+		}).Warn("Failed to connect to Redis")
+	}
+
+	if bg.metrics != nil {
+		pool := cache.NewPoolReporter(r, bg.metrics, time.Duration(bg.cfg.Telemetry.DBStatsInterval)*time.Second)
+		pool.Start()
+		d.lifecycle.Register(lifecycle.PhaseWorkers, "redis pool metrics", lifecycle.Func(pool.Stop))
+	}
+	d.lifecycle.Register(lifecycle.PhaseResources, "redis", lifecycle.Closer(r.Close))
+	d.redis = r
+	return r
+}
+
+// setupTaskQueue creates the broker of the task queue, when enabled. The
+// memory broker is not shared: its tasks cannot be left to cmd/worker.
+func (d *domainInfrastructure) setupTaskQueue(bg background) {
+	if bg.cfg == nil || !bg.cfg.TaskQueue.Enabled {
+		return
+	}
+
+	cfg := bg.cfg.TaskQueue
+	switch cfg.Driver {
+	case config.TaskQueueDriverMemory, "":
+		if bg.cfg.Worker.Standalone {
+			panic(fmt.Errorf("invalid task_queue configuration: worker.standalone needs the redis driver"))
+		}
+		d.tasks = taskqueue.NewMemoryBroker()
+	case config.TaskQueueDriverRedis:
+		d.tasks = taskqueue.NewRedisBroker(d.sharedRedis(bg).Client(), cfg.Prefix)
+	default:
+		panic(fmt.Errorf("invalid task_queue configuration: unknown driver %q", cfg.Driver))
+	}
+}
+
+// taskEnqueuer returns the enqueuer of the use cases, nil when the task queue
+// is disabled.
+func (d *domainInfrastructure) taskEnqueuer(bg background) taskqueue.Enqueuer {
+	if d.tasks == nil {
+		return nil
+	}
+	return taskqueue.NewClient(d.tasks, bg.tracer)
+}
+
+// setupTaskProcessor runs the tasks of the modules. The running tasks
+// complete with the workers, before the databases are closed.
+func (d *domainInfrastructure) setupTaskProcessor(bg background) {
+	if d.tasks == nil {
+		return
+	}
+
+	mux := taskqueue.NewMux()
+	for _, m := range modules.All() {
+		if m.Tasks != nil {
+			m.Tasks(modules.Tasks{Env: d.moduleEnv(m.Name, bg), Mux: mux})
+		}
+	}
+
+	p := taskqueue.NewProcessor(d.tasks, mux, bg.cfg.TaskQueue, bg.log, bg.tracer, bg.metrics)
+	p.Start()
+	d.lifecycle.Register(lifecycle.PhaseWorkers, "task processor", lifecycle.Func(p.Stop))
+}
@@ -0,0 +1,299 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"time"
+	"voyago/core-api/internal/infrastructure/cache"
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/featureflag"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/taskqueue"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules"
+	"voyago/core-api/internal/pkg/utils"
+	"voyago/core-api/migrations"
+)
+
+// Domains returns the domains of the registered modules, in start order.
+func Domains() []string {
+	return modules.Names()
+}
+
+// ConfigPath returns the configuration file of the registered domain (see
+// modules.Module.ConfigPath).
+func ConfigPath(domain string) string {
+	m, ok := modules.Get(domain)
+	if !ok {
+		m = modules.Module{Name: domain}
+	}
+	return m.ConfigPath()
+}
+
+// domainInfrastructure holds the per-domain configuration, logger and database
+// shared by every transport bootstrap (HTTP, gRPC, worker), with the
+// infrastructure of the process they share.
+type domainInfrastructure struct {
+	configs map[string]*config.Config
+	// settings are the typed settings of the modules declaring them (see
+	// modules.Module.Settings).
+	settings map[string]any
+	loggers  map[string]logger.Logger
+	dbs      map[string]database.Database
+
+	// pools are the connection pools of the database connections shared by
+	// several domains (database.connection).
+	pools *database.Pools
+	// reportedPools are the connection pools recorded to the metrics, a
+	// shared pool being recorded once, under the first domain on it.
+	reportedPools map[*sql.DB]bool
+
+	// redis is the client shared by the features keeping their state in
+	// Redis, created by the first of them (see sharedRedis).
+	redis *cache.Redis
+	// tasks is the broker of the task queue, nil when disabled (see
+	// setupTaskQueue).
+	tasks taskqueue.Broker
+	// flags are the feature flags of the use cases, created by the first
+	// module (see featureFlags).
+	flags *featureflag.Flags
+	// flagProvider evaluates them, reloaded with feature_flags.flags by the
+	// config driver.
+	flagProvider featureflag.Provider
+
+	// lifecycle holds the shutdown hooks of the modules and of the domain
+	// databases.
+	lifecycle *lifecycle.Manager
+}
+
+// useLifecycle sets the manager receiving the shutdown hooks, a new one
+// created from cfg when lc is nil.
+func (d *domainInfrastructure) useLifecycle(lc *lifecycle.Manager, cfg config.ShutdownConfig, log logger.Logger) {
+	if lc == nil {
+		lc = lifecycle.New(cfg, log)
+	}
+	d.lifecycle = lc
+}
+
+// setup creates the infrastructure of every registered module, after decoding
+// and validating its typed settings (see modules.Module.Settings).
+// loadConfig and openDB default to reading the configuration file of the
+// module (see ConfigPath) and opening the configured database when nil, on
+// the pool shared by the domains naming the same connection. With
+// database.migrations.check_on_startup, a database not at the latest
+// embedded migration fails the startup. The databases fail fast while
+// unreachable (database.WithCircuitBreaker) and retry the transactions failing
+// with a transient error (database.WithRetry), as configured. The statements
+// and connection pool of the databases are recorded to m every statsInterval;
+// the databases are closed in the resources phase of the shutdown. fallback
+// logs when a domain logger is missing.
+func (d *domainInfrastructure) setup(
+	fallback logger.Logger,
+	trc tracer.Tracer,
+	m metrics.Metrics,
+	loadConfig func(domain string) *config.Config,
+	openDB func(domain string, cfg *config.Config, log logger.Logger) database.Database,
+) {
+	registered := modules.All()
+	domainCount := len(registered)
+	d.configs = make(map[string]*config.Config, domainCount)
+	d.settings = make(map[string]any, domainCount)
+	d.loggers = make(map[string]logger.Logger, domainCount)
+	d.dbs = make(map[string]database.Database, domainCount)
+	d.pools = database.NewPools()
+	d.reportedPools = make(map[*sql.DB]bool, domainCount)
+
+	if loadConfig == nil {
+		loadConfig = func(domain string) *config.Config {
+			return config.LoadDomainConfig(ConfigPath(domain))
+		}
+	}
+
+	if openDB == nil {
+		openDB = func(_ string, cfg *config.Config, log logger.Logger) database.Database {
+			return d.pools.NewDatabase(&cfg.Database, log, trc)
+		}
+	}
+
+	for _, module := range registered {
+		domain := module.Name
+		domainCfg := loadConfig(domain)
+		settings, err := module.DecodeSettings(domainCfg)
+		if err != nil {
+			panic(fmt.Errorf("invalid %s configuration: %w", domain, err))
+		}
+
+		// 1. Logger
+		log, err := logger.NewForDomain(domainCfg, trc, domain)
+		if err != nil {
+			panic(err)
+		}
+		domainLogger := log.
+			WithFields(map[string]any{
+				"service": domainCfg.App.Name,
+				"version": domainCfg.App.Version,
+				"env":     domainCfg.App.Env,
+				"port":    domainCfg.Http.Port,
+				"domain":  domain,
+			})
+
+		// 2. Database
+		if domainCfg.Database.Migrations.CheckOnStartup {
+			checkMigrations(domain, domainCfg, domainLogger)
+		}
+		db := openDB(domain, domainCfg, domainLogger)
+		db = database.WithCircuitBreaker(db, domainCfg.Database.CircuitBreaker, domain, m)
+		db = database.WithRetry(db, domainCfg.Database.Retry, domain, m)
+		d.useDatabaseMetrics(domain, db, m, time.Duration(domainCfg.Telemetry.DBStatsInterval)*time.Second)
+
+		d.configs[domain] = domainCfg
+		d.settings[domain] = settings
+		d.loggers[domain] = domainLogger
+		d.dbs[domain] = db
+		d.lifecycle.Register(lifecycle.PhaseResources, domain+" database", func(context.Context) error {
+			d.closeDatabase(domain, fallback)
+			return nil
+		})
+		if closer, ok := domainLogger.(io.Closer); ok {
+			d.lifecycle.Register(lifecycle.PhaseTelemetry, domain+" logger", lifecycle.Closer(closer.Close))
+		}
+	}
+}
+
+// moduleEnv returns the infrastructure of the module domain, set up by setup.
+// The task enqueuer is nil until setupTaskQueue ran.
+func (d *domainInfrastructure) moduleEnv(domain string, bg background) modules.Env {
+	return modules.Env{
+		Config:    d.configs[domain],
+		Settings:  d.settings[domain],
+		DB:        d.dbs[domain],
+		Log:       d.loggers[domain],
+		Tracer:    bg.tracer,
+		Metrics:   bg.metrics,
+		Bus:       bg.bus,
+		Tasks:     d.taskEnqueuer(bg),
+		Flags:     d.featureFlags(bg),
+		Lifecycle: d.lifecycle,
+	}
+}
+
+// featureFlags returns the feature flags of the configuration, created on the
+// first call, nil without configuration. The providers fetching the flags
+// stop with the resources.
+func (d *domainInfrastructure) featureFlags(bg background) *featureflag.Flags {
+	if d.flags != nil || bg.cfg == nil {
+		return d.flags
+	}
+	cfg := bg.cfg.FeatureFlags
+	if cfg.Unleash.AppName == "" {
+		cfg.Unleash.AppName = bg.cfg.App.Name
+	}
+	provider, err := featureflag.NewProvider(&cfg, bg.log)
+	if err != nil {
+		panic(fmt.Errorf("invalid feature_flags configuration: %w", err))
+	}
+	if closer, ok := provider.(io.Closer); ok {
+		d.lifecycle.Register(lifecycle.PhaseResources, "feature flags", lifecycle.Closer(closer.Close))
+	}
+	d.flagProvider = provider
+	d.flags = featureflag.New(provider, bg.log)
+	return d.flags
+}
+
+// logConfig logs at debug level the effective configuration of the process,
+// global and per domain, sensitive values redacted (see utils.MaskConfig),
+// with the files it was read from and the overrides of the command line: what
+// GET /admin/config returns, also for the processes serving no admin route.
+func (d *domainInfrastructure) logConfig(main logger.Logger, cfg *config.Config) {
+	if cfg == nil {
+		return
+	}
+	main.WithFields(map[string]any{
+		"component": "config",
+		"source":    cfg.Source(),
+		"overrides": config.Overridden(),
+		"config":    utils.MaskConfig(cfg),
+	}).Debug("Effective configuration")
+	for _, domain := range modules.Names() {
+		domainCfg, log := d.configs[domain], d.loggers[domain]
+		if domainCfg == nil || log == nil {
+			continue
+		}
+		log.WithFields(map[string]any{
+			"component": "config",
+			"source":    domainCfg.Source(),
+			"config":    utils.MaskConfig(domainCfg),
+		}).Debug("Effective domain configuration")
+	}
+}
+
+// checkMigrations fails the startup when the database of domain is dirty or
+// behind its latest embedded migration (see package migrations).
+func checkMigrations(domain string, cfg *config.Config, log logger.Logger) {
+	status, err := database.CheckMigrations(&cfg.Database, migrations.FS(domain))
+	if err != nil {
+		panic(fmt.Errorf("%s database: %w", domain, err))
+	}
+	if status.Version > status.Latest {
+		log.WithFields(map[string]any{
+			"version": status.Version,
+			"latest":  status.Latest,
+		}).Warn("database ahead of the embedded migrations, migrated by a newer release")
+	}
+}
+
+// useDatabaseMetrics records the statements and the connection pool of db,
+// unless the pool is shared with a domain already recording it.
+// The pool reporter stops with the workers, before the database is closed.
+func (d *domainInfrastructure) useDatabaseMetrics(domain string, db database.Database, m metrics.Metrics, interval time.Duration) {
+	if m == nil || db == nil {
+		return
+	}
+	database.UseMetrics(db.GetDB(), domain, m)
+
+	sqlDB, err := db.GetDB().DB()
+	if err != nil || d.reportedPools[sqlDB] {
+		return
+	}
+	d.reportedPools[sqlDB] = true
+	pool := database.NewPoolReporter(sqlDB, domain, m, interval)
+	pool.Start()
+	d.lifecycle.Register(lifecycle.PhaseWorkers, domain+" database pool metrics", lifecycle.Func(pool.Stop))
+}
+
+// closeDatabase closes the database of domain. fallback is used when the
+// domain logger is missing.
+func (d *domainInfrastructure) closeDatabase(domain string, fallback logger.Logger) {
+	log, okLog := d.loggers[domain]
+	db, okDb := d.dbs[domain]
+
+	if !okLog || log == nil {
+		log = fallback // Fallback to global logger
+	}
+
+	if !okDb || db == nil {
+		log.WithFields(map[string]any{
+			"domain":    domain,
+			"component": "database",
+		}).Warn("Database connection not found during shutdown")
+		return
+	}
+
+	if err := db.Close(); err != nil {
+		log.WithFields(map[string]any{
+			"domain":       domain,
+			"component":    "database",
+			"error_detail": err.Error(),
+		}).Error("Failed to close database connection")
+	} else {
+		log.WithFields(map[string]any{
+			"domain":    domain,
+			"component": "database",
+		}).Info("Database connection closed gracefully")
+	}
+}
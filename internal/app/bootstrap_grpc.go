@@ -0,0 +1,122 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/errorreport"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/grpc/interceptor"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/infrastructure/validator"
+	"voyago/core-api/internal/modules"
+	"voyago/core-api/internal/pkg/tenancy"
+
+	"google.golang.org/grpc"
+)
+
+type BootstrapGrpcConfig struct {
+	// Config is the global configuration, optional: without it, the task
+	// queue is disabled.
+	Config  *config.Config
+	Server  *grpc.Server
+	Val     validator.Validator
+	Log     logger.Logger
+	Tracer  tracer.Tracer
+	Metrics metrics.Metrics
+	Bus     eventbus.Bus
+
+	// LoadDomainConfig and OpenDomainDB override how per-domain infrastructure is
+	// created (see BootstrapHttpConfig).
+	LoadDomainConfig func(domain string) *config.Config
+	OpenDomainDB     func(domain string, cfg *config.Config, log logger.Logger) database.Database
+
+	// Lifecycle receives the shutdown hooks (see BootstrapHttpConfig).
+	Lifecycle *lifecycle.Manager
+
+	domainInfrastructure
+}
+
+// GrpcInterceptors returns the unary interceptor chain mirroring the HTTP
+// middleware stack. grpc-go only accepts interceptors at construction time,
+// so pass the result to grpcserver.NewServer before calling Run. cfg is the
+// global configuration and r the reporter of the unexpected failures, both
+// optional.
+func GrpcInterceptors(cfg *config.Config, log logger.Logger, trc tracer.Tracer, m metrics.Metrics, r errorreport.Reporter) []grpc.UnaryServerInterceptor {
+	t := interceptor.NewTelemetrist(log, trc, m)
+
+	var gw config.GatewayConfig
+	var tenancyCfg config.TenancyConfig
+	if cfg != nil {
+		gw, tenancyCfg = cfg.Security.Gateway, cfg.Tenancy
+	}
+	interceptors := []grpc.UnaryServerInterceptor{
+		interceptor.RequestID(),
+		interceptor.Locale(),
+		interceptor.Identity(gw, tenancyCfg),
+		interceptor.FeatureFlags(),
+	}
+	if cfg != nil && cfg.Tenancy.Enabled {
+		if err := tenancy.ValidateOverrides(cfg); err != nil {
+			panic(fmt.Errorf("invalid tenancy configuration: %w", err))
+		}
+		interceptors = append(interceptors, interceptor.Tenant(cfg.Tenancy, cfg.Security.Gateway))
+	}
+	interceptors = append(interceptors,
+		t.HandleMetrics(),
+		t.HandleTrace(),
+		t.HandleLog(),
+	)
+	if r != nil {
+		interceptors = append(interceptors, t.HandleErrorReport(r))
+	}
+	return interceptors
+}
+
+func (b *BootstrapGrpcConfig) Run() {
+	b.useLifecycle(b.Lifecycle, config.ShutdownConfig{}, b.Log)
+	b.setupInfrastructureModules()
+	b.toggleDebugOnHangup(b.Log)
+	b.logConfig(b.Log, b.Config)
+	bg := background{
+		cfg:     b.Config,
+		log:     b.Log,
+		tracer:  b.Tracer,
+		metrics: b.Metrics,
+		bus:     b.Bus,
+	}
+	b.setupTaskQueue(bg)
+	b.setupModules(bg)
+	if b.Config != nil && !b.Config.Worker.Standalone {
+		b.setupTaskProcessor(bg)
+	}
+	b.watchConfig(b.Config, b.Log, b.Tracer, b.LoadDomainConfig, nil)
+}
+
+// Stop runs the shutdown hooks (see Lifecycle).
+func (b *BootstrapGrpcConfig) Stop() {
+	_ = b.lifecycle.Shutdown(context.Background())
+}
+
+func (b *BootstrapGrpcConfig) setupInfrastructureModules() {
+	b.setup(b.Log, b.Tracer, b.Metrics, b.LoadDomainConfig, b.OpenDomainDB)
+}
+
+// setupModules registers the services of the registered modules on the gRPC
+// server. The modules without gRPC API run their worker, so that the events
+// published by the gRPC calls still reach their subscribers.
+func (b *BootstrapGrpcConfig) setupModules(bg background) {
+	for _, m := range modules.All() {
+		env := b.moduleEnv(m.Name, bg)
+		switch {
+		case m.GRPC != nil:
+			m.GRPC(modules.GRPC{Env: env, Server: b.Server, Val: b.Val})
+		case m.Worker != nil:
+			m.Worker(modules.Worker{Env: env})
+		}
+	}
+}
@@ -1,130 +1,441 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
+	"voyago/core-api/internal/infrastructure/admin"
 	"voyago/core-api/internal/infrastructure/config"
 	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/errorreport"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	gqlserver "voyago/core-api/internal/infrastructure/graphql"
 	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/infrastructure/http/versioning"
+	"voyago/core-api/internal/infrastructure/lifecycle"
 	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/maintenance"
+	"voyago/core-api/internal/infrastructure/messaging/kafka"
+	"voyago/core-api/internal/infrastructure/openapi"
+	"voyago/core-api/internal/infrastructure/ratelimit"
+	"voyago/core-api/internal/infrastructure/reload"
 	"voyago/core-api/internal/infrastructure/telemetry/metrics"
 	"voyago/core-api/internal/infrastructure/telemetry/tracer"
 	"voyago/core-api/internal/infrastructure/validator"
+	wsserver "voyago/core-api/internal/infrastructure/websocket"
+	"voyago/core-api/internal/modules"
 	"voyago/core-api/internal/modules/booking"
+	bookinggraphql "voyago/core-api/internal/modules/booking/delivery/graphql"
+	"voyago/core-api/internal/modules/category"
+	categorygraphql "voyago/core-api/internal/modules/category/delivery/graphql"
+	"voyago/core-api/internal/pkg/audit"
+	"voyago/core-api/internal/pkg/ipnet"
+	"voyago/core-api/internal/pkg/tenancy"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 )
 
-var domains = [1]string{
-	"booking",
-	// "merchant",
+// graphqlRoot is the root resolver of the GraphQL gateway.
+// Every module exposing GraphQL queries embeds its resolver here, wrapped in
+// a type of its own: the resolvers of the modules share their type name.
+type graphqlRoot struct {
+	gqlserver.BaseResolver
+	bookingQueries
+	categoryQueries
 }
 
+type (
+	bookingQueries  struct{ *bookinggraphql.Resolver }
+	categoryQueries struct{ *categorygraphql.Resolver }
+)
+
 type BootstrapHttpConfig struct {
+	// Config is the global configuration. It is optional; gateway defaults are used when nil.
+	Config  *config.Config
 	App     *fiber.App
 	Val     validator.Validator
 	Log     logger.Logger
 	Tracer  tracer.Tracer
 	Metrics metrics.Metrics
+	Bus     eventbus.Bus
+
+	// Reporter reports the internal errors and panics of the requests to an
+	// error tracker, none when nil.
+	Reporter errorreport.Reporter
+
+	// LoadDomainConfig and OpenDomainDB override how per-domain infrastructure is
+	// created. They default to reading the configuration file of the module
+	// (see ConfigPath) and opening
+	// the configured database; the in-memory test mode replaces both.
+	LoadDomainConfig func(domain string) *config.Config
+	OpenDomainDB     func(domain string, cfg *config.Config, log logger.Logger) database.Database
 
-	configs map[string]*config.Config
-	loggers map[string]logger.Logger
-	dbs     map[string]database.Database
+	// KafkaTransport is the Kafka client of the consumers and the producer,
+	// required when kafka.enabled is set.
+	KafkaTransport kafka.Transport
+
+	// Lifecycle receives the shutdown hooks of the modules and of the
+	// infrastructure they use. When nil, one is created from the shutdown
+	// configuration and Stop runs it.
+	Lifecycle *lifecycle.Manager
+
+	// docs collects the operations documented by the modules.
+	docs *openapi.Spec
+	// routes mounts the versioned REST API of the modules.
+	routes *versioning.Router
+	// maintenance is the maintenance mode, toggled by the admin routes.
+	maintenance *maintenance.Mode
+	// limiter throttles the clients, nil when the rate limit is disabled.
+	limiter *ratelimit.Limiter
+
+	domainInfrastructure
 }
 
 func (b *BootstrapHttpConfig) Run() {
+	shutdownCfg := config.ShutdownConfig{}
+	if b.Config != nil {
+		shutdownCfg = b.Config.Shutdown
+	}
+	b.useLifecycle(b.Lifecycle, shutdownCfg, b.Log)
+
 	b.setupMiddleware()
 	b.setupInfrastructureModules()
+	b.toggleDebugOnHangup(b.Log)
+	b.logConfig(b.Log, b.Config)
+	b.setupDocs()
+	b.setupRoutes()
+	b.setupTaskQueue(b.background())
 	b.setupModules()
+	if b.Config == nil || !b.Config.Worker.Standalone {
+		b.setupMessaging(b.background())
+		b.setupJobs(b.background())
+		b.setupTaskProcessor(b.background())
+	}
+	b.setupGraphql()
+	b.setupWebsocket()
 	b.setupHealthRoute()
+	b.setupMetricsRoute()
+	b.setupAdmin()
+	b.setupPprof()
+	b.mountDocs()
+	b.watchConfig(b.Config, b.Log, b.Tracer, b.LoadDomainConfig, b.subscribeReload)
 }
 
+// Stop runs the shutdown hooks (see Lifecycle).
 func (b *BootstrapHttpConfig) Stop() {
-	for _, domain := range domains {
-		log, okLog := b.loggers[domain]
-		db, okDb := b.dbs[domain]
-
-		if !okLog || log == nil {
-			log = b.Log // Fallback to global logger
-		}
-
-		if !okDb || db == nil {
-			log.WithFields(map[string]any{
-				"domain":    domain,
-				"component": "database",
-			}).Warn("Database connection not found during shutdown")
-			continue
-		}
+	_ = b.lifecycle.Shutdown(context.Background())
+}
 
-		if err := db.Close(); err != nil {
-			log.WithFields(map[string]any{
-				"domain":       domain,
-				"component":    "database",
-				"error_detail": err.Error(),
-			}).Error("Failed to close database connection")
-		} else {
-			log.WithFields(map[string]any{
-				"domain":    domain,
-				"component": "database",
-			}).Info("Database connection closed gracefully")
-		}
+func (b *BootstrapHttpConfig) background() background {
+	return background{
+		cfg:       b.Config,
+		log:       b.Log,
+		tracer:    b.Tracer,
+		metrics:   b.Metrics,
+		bus:       b.Bus,
+		transport: b.KafkaTransport,
 	}
 }
 
 func (b *BootstrapHttpConfig) setupMiddleware() {
 	t := middleware.NewTelemetrist(b.Log, b.Tracer, b.Metrics)
 
+	if b.Config != nil && b.Config.Http.Compression.Enabled {
+		b.App.Use(middleware.Compress(b.Config.Http.Compression))
+	}
 	b.App.Use(middleware.RequestID())
+	b.App.Use(middleware.Locale())
+	b.setupIdentity()
+	b.App.Use(middleware.FeatureFlags())
+	b.setupTenancy()
 	b.App.Use(t.HandleMetrics())
 	b.App.Use(t.HandleTrace())
 	b.App.Use(t.HandleLog())
+	if b.Reporter != nil {
+		b.App.Use(t.HandleErrorReport(b.Reporter))
+	}
+	b.App.Use(t.HandleRecover())
+	if b.Config != nil {
+		b.App.Use(middleware.BodyLimit(b.Config.Http.BodyLimit))
+	}
+	b.setupSecurity()
+	b.setupMaintenance()
+	b.setupRateLimit()
+	b.setupBulkheads()
+
+	if b.Config != nil {
+		b.App.Use(middleware.Timeout(b.Config.Http.RequestTimeout * time.Second))
+	}
 }
 
-func (b *BootstrapHttpConfig) setupInfrastructureModules() {
-	domainCount := len(domains)
-	b.configs = make(map[string]*config.Config, domainCount)
-	b.loggers = make(map[string]logger.Logger, domainCount)
-	b.dbs = make(map[string]database.Database, domainCount)
+// setupIdentity reads the caller of the requests of the gateway.
+// Without configuration, no request is trusted.
+func (b *BootstrapHttpConfig) setupIdentity() {
+	var gw config.GatewayConfig
+	var tenancyCfg config.TenancyConfig
+	if b.Config != nil {
+		gw, tenancyCfg = b.Config.Security.Gateway, b.Config.Tenancy
+	}
+	b.App.Use(middleware.Identity(gw, tenancyCfg))
+}
 
-	for _, domain := range domains {
-		path := fmt.Sprintf("config/%s/config.yaml", domain)
-		domainCfg := config.LoadDomainConfig(path)
+// setupTenancy resolves the tenant of the requests. It runs before the
+// telemetry so that the logs and spans carry the resolved tenant.
+func (b *BootstrapHttpConfig) setupTenancy() {
+	if b.Config == nil || !b.Config.Tenancy.Enabled {
+		return
+	}
+	if err := tenancy.ValidateOverrides(b.Config); err != nil {
+		panic(fmt.Errorf("invalid tenancy configuration: %w", err))
+	}
 
-		// 1. Logger
-		domainLogger := logger.
-			New(domainCfg, b.Tracer).
-			WithFields(map[string]any{
-				"service": domainCfg.App.Name,
-				"version": domainCfg.App.Version,
-				"env":     domainCfg.App.Env,
-				"port":    domainCfg.Http.Port,
-				"domain":  domain,
-			})
+	exempt := []string{admin.Path(b.Config.Admin)}
+	if servesMetricsRoute(b.Config, b.Metrics) {
+		exempt = append(exempt, metricsPath(b.Config.Telemetry.Prometheus))
+	}
+	b.App.Use(middleware.Tenant(b.Config.Tenancy, b.Config.Security.Gateway, exempt...))
+}
 
-		// 2. Database
-		db := database.NewDatabase(&domainCfg.Database, domainLogger, b.Tracer)
+// setupSecurity sets the security headers and the CORS and CSRF policies.
+// It runs before the rate limit so that throttled responses stay readable by
+// browsers.
+func (b *BootstrapHttpConfig) setupSecurity() {
+	if b.Config == nil {
+		return
+	}
+	cfg := b.Config.Security
 
-		b.configs[domain] = domainCfg
-		b.loggers[domain] = domainLogger
-		b.dbs[domain] = db
+	if cfg.Headers.Enabled {
+		b.App.Use(middleware.SecurityHeaders(cfg.Headers))
+	}
+	if cfg.CORS.Enabled {
+		b.App.Use(middleware.CORS(cfg.CORS))
 	}
+	if cfg.CSRF.Enabled {
+		b.App.Use(middleware.CSRF(cfg.CSRF))
+	}
+}
+
+// setupMaintenance rejects the requests while the maintenance mode is on. It
+// runs after the CORS policy so that browsers can read the 503 responses.
+func (b *BootstrapHttpConfig) setupMaintenance() {
+	if b.Config == nil {
+		return
+	}
+	cfg := b.Config.Maintenance
+
+	var store maintenance.Store
+	switch cfg.Store {
+	case config.MaintenanceStoreMemory, "":
+		store = maintenance.NewMemoryStore()
+	case config.MaintenanceStoreRedis:
+		store = maintenance.NewRedisStore(b.sharedRedis(b.background()).Client(), cfg.Key)
+	default:
+		panic(fmt.Errorf("invalid maintenance configuration: unknown store %q", cfg.Store))
+	}
+
+	exempt := []string{admin.Path(b.Config.Admin)}
+	if servesMetricsRoute(b.Config, b.Metrics) {
+		// Scrapes go on: the dashboards show the maintenance window.
+		exempt = append(exempt, metricsPath(b.Config.Telemetry.Prometheus))
+	}
+
+	b.maintenance = maintenance.NewMode(cfg, store, b.Log)
+	b.App.Use(middleware.Maintenance(b.maintenance, cfg, exempt...))
 }
 
+// setupRateLimit throttles clients with the configured rules. Counters are
+// kept in Redis unless the "memory" store is configured.
+func (b *BootstrapHttpConfig) setupRateLimit() {
+	if b.Config == nil || !b.Config.RateLimit.Enabled {
+		return
+	}
+	cfg := b.Config.RateLimit
+
+	var store ratelimit.Store
+	switch cfg.Store {
+	case config.RateLimitStoreMemory:
+		store = ratelimit.NewMemoryStore()
+	case config.RateLimitStoreRedis, "":
+		store = ratelimit.NewRedisStore(b.sharedRedis(b.background()).Client(), cfg.KeyPrefix)
+	default:
+		panic(fmt.Errorf("invalid rate limit configuration: unknown store %q", cfg.Store))
+	}
+
+	limiter, err := ratelimit.NewLimiter(cfg, store)
+	if err != nil {
+		panic(fmt.Errorf("invalid rate limit configuration: %w", err))
+	}
+	proxies, err := ipnet.Parse(b.Config.Http.TrustedProxies)
+	if err != nil {
+		panic(fmt.Errorf("invalid http trusted proxy: %w", err))
+	}
+	b.limiter = limiter
+	b.App.Use(middleware.RateLimit(limiter, proxies, b.Log, b.Metrics))
+}
+
+// subscribeReload applies the reloaded rate limits and maintenance mode.
+func (b *BootstrapHttpConfig) subscribeReload(r *reload.Reloader) {
+	if b.limiter != nil {
+		limits := func(_ context.Context, _ reload.Change, cfg *config.Config) error {
+			return b.limiter.Reload(cfg.RateLimit)
+		}
+		r.OnChange("rate_limit.default", limits)
+		r.OnChange("rate_limit.routes", limits)
+	}
+	if b.maintenance != nil {
+		mode := func(_ context.Context, _ reload.Change, cfg *config.Config) error {
+			b.maintenance.Reload(cfg.Maintenance)
+			return nil
+		}
+		r.OnChange("maintenance.enabled", mode)
+		r.OnChange("maintenance.message", mode)
+	}
+}
+
+// setupBulkheads caps the requests in flight of the configured route groups.
+func (b *BootstrapHttpConfig) setupBulkheads() {
+	if b.Config == nil || !b.Config.Bulkhead.Enabled {
+		return
+	}
+
+	for _, g := range b.Config.Bulkhead.Groups {
+		if !strings.HasPrefix(g.Path, "/") || g.MaxInFlight <= 0 {
+			panic(fmt.Errorf("invalid bulkhead configuration: group %q needs an absolute path and a positive max_in_flight", g.Path))
+		}
+		bulkhead := middleware.NewBulkhead(g.Path, g.MaxInFlight, g.RetryAfter, b.Metrics)
+		b.App.Use(g.Path, bulkhead.Handler())
+	}
+}
+
+func (b *BootstrapHttpConfig) setupInfrastructureModules() {
+	b.setup(b.Log, b.Tracer, b.Metrics, b.LoadDomainConfig, b.OpenDomainDB)
+}
+
+// setupRoutes mounts a route group per configured API version (e.g., /api/v1)
+// on which the modules register their routes.
+func (b *BootstrapHttpConfig) setupRoutes() {
+	apiCfg := config.ApiConfig{}
+	if b.Config != nil {
+		apiCfg = b.Config.Api
+	}
+
+	routes, err := versioning.NewRouter(b.App, apiCfg, b.docs)
+	if err != nil {
+		panic(fmt.Errorf("invalid api configuration: %w", err))
+	}
+	b.routes = routes
+}
+
+// setupModules mounts the registered modules on the HTTP server.
 func (b *BootstrapHttpConfig) setupModules() {
+	bg := b.background()
+	for _, m := range modules.All() {
+		if m.HTTP == nil {
+			continue
+		}
+		m.HTTP(modules.HTTP{
+			Env:    b.moduleEnv(m.Name, bg),
+			Routes: b.routes,
+			Val:    b.Val,
+			SSE:    b.sseConfig(),
+		})
+	}
+}
+
+// setupGraphql serves the GraphQL gateway. Unlike the other transports, it is
+// not driven by the registry: the root resolver embeds the resolvers of the
+// modules, a type fixed at compile time.
+func (b *BootstrapHttpConfig) setupGraphql() {
 	var m string
+	root := &graphqlRoot{}
+	var modules []gqlserver.Module
 
 	// --- Booking Module ---
 	m = "booking"
 	if cfg, ok := b.configs[m]; ok {
-		booking.RegisterHttpModule(booking.HttpModuleConfig{
-			Config: cfg,
-			Server: b.App,
-			DB:     b.dbs[m],
-			Log:    b.loggers[m],
-			Val:    b.Val,
-			Tracer: b.Tracer,
+		r, module := booking.RegisterGraphqlModule(booking.GraphqlModuleConfig{
+			Config:   cfg,
+			Settings: b.settings[m].(*booking.Settings),
+			DB:       b.dbs[m],
+			Log:      b.loggers[m],
+			Val:      b.Val,
+			Tracer:   b.Tracer,
+			Metrics:  b.Metrics,
+			Bus:      b.Bus,
 		})
+		root.bookingQueries = bookingQueries{r}
+		modules = append(modules, module)
 	}
+
+	// --- Category Module ---
+	m = "category"
+	if cfg, ok := b.configs[m]; ok {
+		r, module := category.RegisterGraphqlModule(category.GraphqlModuleConfig{
+			Config:  cfg,
+			DB:      b.dbs[m],
+			Log:     b.loggers[m],
+			Val:     b.Val,
+			Tracer:  b.Tracer,
+			Metrics: b.Metrics,
+		})
+		root.categoryQueries = categoryQueries{r}
+		modules = append(modules, module)
+	}
+
+	gqlCfg := config.GraphqlConfig{}
+	if b.Config != nil {
+		gqlCfg = b.Config.Graphql
+	}
+	path := gqlCfg.Path
+	if path == "" {
+		path = "/graphql"
+	}
+
+	schema, err := gqlserver.NewSchema(&gqlCfg, b.Log, b.Tracer, root, modules...)
+	if err != nil {
+		// The schema is static: failing here is a programming error.
+		panic(err)
+	}
+
+	h := schema.Handler()
+	b.App.Get(path, h)
+	b.App.Post(path, h)
+}
+
+// setupWebsocket mounts the notification hub and forwards domain events to
+// the sockets of their user. Open sockets are drained on shutdown.
+func (b *BootstrapHttpConfig) setupWebsocket() {
+	if b.Config == nil || !b.Config.Websocket.Enabled {
+		return
+	}
+	cfg := b.Config.Websocket
+
+	hub := wsserver.NewHub(cfg, b.Log, b.Metrics)
+	hub.Mount(b.App, wsserver.NewTokenAuthenticator(cfg.Secret))
+	hub.ForwardEvents(b.Bus)
+
+	b.lifecycle.Register(lifecycle.PhaseServers, "websocket hub", func(ctx context.Context) error {
+		if err := hub.Drain(ctx); err != nil {
+			b.Log.WithFields(map[string]any{
+				"component":    "websocket",
+				"error_detail": err.Error(),
+			}).Warn("websocket connections forced to close")
+		}
+		return nil
+	})
+
+	b.docs.Add(openapi.Operation{
+		Method:      fiber.MethodGet,
+		Path:        hub.Path(),
+		Summary:     "Open the real-time notification socket",
+		Description: "WebSocket upgrade. Authenticate with the `token` query parameter or an `Authorization: Bearer` header; domain events of the authenticated user are pushed as JSON messages.",
+		Tags:        []string{"websocket"},
+		Status:      fiber.StatusSwitchingProtocols,
+		Errors:      []int{fiber.StatusUnauthorized, fiber.StatusUpgradeRequired, fiber.StatusServiceUnavailable},
+	})
 }
 
 func (b *BootstrapHttpConfig) setupHealthRoute() {
@@ -137,4 +448,108 @@ func (b *BootstrapHttpConfig) setupHealthRoute() {
 
 	b.App.Get("/", h)
 	b.App.Get("/health", h)
+
+	b.docs.Add(openapi.Operation{
+		Method:  fiber.MethodGet,
+		Path:    "/health",
+		Summary: "Liveness probe",
+		Tags:    []string{"health"},
+	})
+}
+
+// setupMetricsRoute serves the scrape endpoint of the Prometheus metrics,
+// unless they have their own listener (see ServeMetrics).
+func (b *BootstrapHttpConfig) setupMetricsRoute() {
+	if !servesMetricsRoute(b.Config, b.Metrics) {
+		return
+	}
+	h := b.Metrics.(metrics.Handler).Handler()
+	b.App.Get(metricsPath(b.Config.Telemetry.Prometheus), adaptor.HTTPHandler(h))
+}
+
+// setupAdmin mounts the operational routes (log level, maintenance mode,
+// cache flush, audit log, configuration and build information) when an
+// admin token is configured. They are registered last: the security, rate limit and timeout
+// middlewares apply to them.
+func (b *BootstrapHttpConfig) setupAdmin() {
+	if b.Config == nil || b.Config.Admin.Token == "" {
+		return
+	}
+
+	var cache admin.Cache
+	if len(b.Config.Admin.CachePrefixes) > 0 {
+		cache = admin.NewRedisCache(b.sharedRedis(b.background()).Client(), b.Config.Admin.CachePrefixes)
+	}
+
+	auditLogs := make(map[string]audit.Reader, len(b.dbs))
+	for domain, db := range b.dbs {
+		auditLogs[domain] = audit.NewService(db)
+	}
+
+	admin.RegisterHttpModule(admin.HttpModuleConfig{
+		Config:      b.Config,
+		App:         b.App,
+		Log:         b.Log,
+		Docs:        b.docs,
+		Configs:     b.configs,
+		Loggers:     b.namedLoggers(b.Log),
+		Maintenance: b.maintenance,
+		Cache:       cache,
+		Audit:       auditLogs,
+	})
+}
+
+// setupPprof enables the sampling of the block and mutex profiles and, when
+// an address is configured, serves the profiles on their own listener (the
+// admin routes serve them otherwise).
+func (b *BootstrapHttpConfig) setupPprof() {
+	if b.Config == nil || !b.Config.Admin.Pprof.Enabled {
+		return
+	}
+	cfg := b.Config.Admin.Pprof
+	admin.ConfigureProfiling(cfg)
+
+	if cfg.Address == "" {
+		return
+	}
+	srv := admin.NewPprofServer(cfg.Address, b.Log)
+	go func() {
+		if err := srv.Start(); err != nil {
+			b.Log.WithFields(map[string]any{
+				"component":    "pprof",
+				"error_detail": err.Error(),
+			}).Error("pprof server failed")
+		}
+	}()
+	b.lifecycle.Register(lifecycle.PhaseServers, "pprof server", srv.Stop)
+}
+
+func (b *BootstrapHttpConfig) sseConfig() config.SSEConfig {
+	if b.Config == nil {
+		return config.SSEConfig{}
+	}
+	return b.Config.SSE
+}
+
+// setupDocs creates the OpenAPI document filled by the modules while their
+// routes are registered. It stays nil (and is ignored) when docs are disabled.
+func (b *BootstrapHttpConfig) setupDocs() {
+	if b.Config == nil || !b.Config.Docs.Enabled {
+		return
+	}
+
+	b.docs = openapi.New(openapi.Info{
+		Title:   b.Config.App.Name,
+		Version: b.Config.App.Version,
+	})
+	if b.Config.Http.ErrorFormat == config.ErrorFormatProblem {
+		b.docs.UseProblemErrors()
+	}
+}
+
+func (b *BootstrapHttpConfig) mountDocs() {
+	if b.docs == nil {
+		return
+	}
+	openapi.Mount(b.App, b.Config.Docs, b.docs)
 }
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/messaging/kafka"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules"
+)
+
+// BootstrapWorkerConfig bootstraps the background runtime (cmd/worker): the
+// message consumers, the webhook dispatcher and the scheduled jobs of the
+// modules, without any API. Its health endpoint is served by Handler.
+type BootstrapWorkerConfig struct {
+	// Config is the global configuration, required.
+	Config  *config.Config
+	Log     logger.Logger
+	Tracer  tracer.Tracer
+	Metrics metrics.Metrics
+	Bus     eventbus.Bus
+
+	// LoadDomainConfig and OpenDomainDB override how per-domain infrastructure is
+	// created (see BootstrapHttpConfig).
+	LoadDomainConfig func(domain string) *config.Config
+	OpenDomainDB     func(domain string, cfg *config.Config, log logger.Logger) database.Database
+
+	// KafkaTransport is the Kafka client of the consumers and the producer,
+	// required when kafka.enabled is set.
+	KafkaTransport kafka.Transport
+
+	// Lifecycle receives the shutdown hooks (see BootstrapHttpConfig).
+	Lifecycle *lifecycle.Manager
+
+	// draining is set once the shutdown started: the health endpoint then
+	// reports the worker down while the consumers and jobs complete.
+	draining atomic.Bool
+
+	domainInfrastructure
+}
+
+func (b *BootstrapWorkerConfig) Run() {
+	b.useLifecycle(b.Lifecycle, b.Config.Shutdown, b.Log)
+	b.setup(b.Log, b.Tracer, b.Metrics, b.LoadDomainConfig, b.OpenDomainDB)
+	b.toggleDebugOnHangup(b.Log)
+	b.logConfig(b.Log, b.Config)
+
+	// The first phase of the shutdown: the drain is reported before the
+	// consumers stop.
+	b.lifecycle.Register(lifecycle.PhaseServers, "worker health", lifecycle.Func(func() {
+		b.draining.Store(true)
+	}))
+
+	bg := background{
+		cfg:       b.Config,
+		log:       b.Log,
+		tracer:    b.Tracer,
+		metrics:   b.Metrics,
+		bus:       b.Bus,
+		transport: b.KafkaTransport,
+	}
+	b.setupModules(bg)
+	b.setupMessaging(bg)
+	b.setupJobs(bg)
+	b.setupTaskQueue(bg)
+	b.setupTaskProcessor(bg)
+	b.watchConfig(b.Config, b.Log, b.Tracer, b.LoadDomainConfig, nil)
+}
+
+// Stop runs the shutdown hooks (see Lifecycle).
+func (b *BootstrapWorkerConfig) Stop() {
+	_ = b.lifecycle.Shutdown(context.Background())
+}
+
+// setupModules subscribes the registered modules to the events of the
+// runtime.
+func (b *BootstrapWorkerConfig) setupModules(bg background) {
+	for _, m := range modules.All() {
+		if m.Worker == nil {
+			continue
+		}
+		m.Worker(modules.Worker{Env: b.moduleEnv(m.Name, bg), Dedicated: true})
+	}
+}
+
+// Handler serves the health endpoint of the worker on /health: 200 while it
+// runs, 503 once it drains on shutdown. It also serves the Prometheus scrape
+// endpoint when no dedicated metrics address is configured.
+func (b *BootstrapWorkerConfig) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, _ *http.Request) {
+		status, code := "UP", http.StatusOK
+		if b.draining.Load() {
+			status, code = "DRAINING", http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"status": status,
+			"time":   time.Now().Format(time.RFC3339),
+		})
+	})
+	if servesMetricsRoute(b.Config, b.Metrics) {
+		mux.Handle(metricsPath(b.Config.Telemetry.Prometheus), b.Metrics.(metrics.Handler).Handler())
+	}
+	return mux
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"voyago/core-api/internal/infrastructure/admin"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+)
+
+// namedLoggers returns main, named admin.MainLogger, and the domain loggers,
+// named after their domain.
+func (d *domainInfrastructure) namedLoggers(main logger.Logger) map[string]logger.Logger {
+	loggers := map[string]logger.Logger{admin.MainLogger: main}
+	for domain, log := range d.loggers {
+		loggers[domain] = log
+	}
+	return loggers
+}
+
+// toggleDebugOnHangup switches every logger to debug on SIGHUP, and back to
+// their levels on the next SIGHUP (see logger.DebugToggle), so that a single
+// instance can be debugged without the admin routes, e.g. the gRPC server:
+//
+//	kill -HUP <pid>
+//
+// It stops listening with the workers.
+func (d *domainInfrastructure) toggleDebugOnHangup(main logger.Logger) {
+	toggle := logger.NewDebugToggle(d.namedLoggers(main))
+	log := main.WithField("component", "log-level")
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-hangup:
+				if toggle.Toggle() {
+					log.Warn("Received SIGHUP, log level set to debug")
+				} else {
+					log.Warn("Received SIGHUP, log levels restored")
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	d.lifecycle.Register(lifecycle.PhaseWorkers, "log level signal", lifecycle.Func(func() {
+		signal.Stop(hangup)
+		close(done)
+	}))
+}
@@ -0,0 +1,9 @@
+package app
+
+// The modules register themselves on import (see package modules): a new
+// module is enabled by importing it here.
+import (
+	_ "voyago/core-api/internal/modules/booking"
+	_ "voyago/core-api/internal/modules/category"
+	_ "voyago/core-api/internal/modules/webhook"
+)
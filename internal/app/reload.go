@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/featureflag"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/reload"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/pkg/audit"
+)
+
+// watchConfig applies the edits of the configuration files while running
+// (see package reload), with reload.enabled: the log levels of main and of
+// the domain loggers, the trace sampling, the flags of the config driver of
+// the feature flags, and the settings subscribed by subscribe (e.g. the rate
+// limits of the HTTP server). The applied changes
+// are recorded in the audit log of the domains.
+//
+// The configuration is not watched when loadConfig overrides it (the in-memory
+// test mode), nor when it has neither global file nor remote store (see
+// config.Remote). The watch stops with the workers.
+func (d *domainInfrastructure) watchConfig(
+	cfg *config.Config,
+	main logger.Logger,
+	trc tracer.Tracer,
+	loadConfig func(domain string) *config.Config,
+	subscribe func(*reload.Reloader),
+) {
+	if cfg == nil || !cfg.Reload.Enabled || loadConfig != nil {
+		return
+	}
+	if provider, _ := config.Remote(); config.GlobalPath() == "" && provider == nil {
+		return
+	}
+
+	files := make(map[string]string, len(d.configs))
+	current := map[string]*config.Config{reload.Main: cfg}
+	recorders := make(map[string]audit.Recorder, len(d.dbs))
+	for domain, domainCfg := range d.configs {
+		files[domain] = ConfigPath(domain)
+		current[domain] = domainCfg
+		if db := d.dbs[domain]; db != nil {
+			recorders[domain] = audit.NewService(db)
+		}
+	}
+
+	r := reload.New(reload.Config{
+		GlobalPath: config.GlobalPath(),
+		Domains:    files,
+		Current:    current,
+		Log:        main,
+		Audit:      recorders,
+		Debounce:   time.Duration(cfg.Reload.Debounce) * time.Millisecond,
+	})
+
+	loggers := d.namedLoggers(main)
+	setLevel := func(_ context.Context, c reload.Change, cfg *config.Config) error {
+		leveler, ok := loggers[c.Domain].(logger.Leveler)
+		if !ok {
+			return nil
+		}
+		return leveler.SetLevel(logger.ConfiguredLevel(cfg.Log, c.Domain))
+	}
+	r.OnChange("log.level", setLevel)
+	r.OnChange("log.levels", setLevel)
+
+	if resampler, ok := trc.(tracer.Resampler); ok {
+		resample := func(_ context.Context, _ reload.Change, cfg *config.Config) error {
+			resampler.SetSampling(cfg.Telemetry.SampleRate, cfg.Telemetry.Sampling)
+			return nil
+		}
+		r.OnChange("telemetry.sample_rate", resample)
+		r.OnChange("telemetry.sampling.rules", resample)
+	}
+
+	if flags, ok := d.flagProvider.(*featureflag.Static); ok {
+		r.OnChange("feature_flags.flags", func(_ context.Context, _ reload.Change, cfg *config.Config) error {
+			flags.Reload(cfg.FeatureFlags.Flags)
+			return nil
+		})
+	}
+
+	if subscribe != nil {
+		subscribe(r)
+	}
+
+	log := main.WithField("component", "config-reload")
+	if err := r.Start(); err != nil {
+		log.WithField("error", err.Error()).Error("configuration not watched, restart to apply its changes")
+		return
+	}
+	d.lifecycle.Register(lifecycle.PhaseWorkers, "configuration watch", lifecycle.Func(r.Stop))
+}
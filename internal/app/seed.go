@@ -0,0 +1,18 @@
+package app
+
+import (
+	"voyago/core-api/internal/infrastructure/seed"
+	"voyago/core-api/internal/modules"
+)
+
+// Seeders returns the seeders of the registered modules, run by
+// `voyago seed`.
+func Seeders() *seed.Registry {
+	r := seed.NewRegistry()
+	for _, m := range modules.All() {
+		if m.Seeders != nil {
+			m.Seeders(r)
+		}
+	}
+	return r
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/startup"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+)
+
+// NewTelemetry creates the metrics and the tracer of cfg. An exporter failing
+// to start is returned as an unavailable startup.Error, or, with
+// telemetry.degraded_mode, replaced by its no-op (the StatsD fallback for the
+// metrics, when configured) and reported to s.
+func NewTelemetry(cfg *config.Config, s *startup.Pipeline) (metrics.Metrics, tracer.Tracer, error) {
+	m, err := metrics.New(&cfg.Telemetry, cfg.App.Env)
+	if err != nil {
+		if !cfg.Telemetry.DegradedMode {
+			return nil, nil, startup.Unavailable("metrics", err)
+		}
+		s.Degrade("metrics", err)
+		if m, err = metrics.NewFallback(&cfg.Telemetry, cfg.App.Env); err != nil {
+			s.Degrade("metrics fallback", err)
+			m = metrics.NewNoOpMetrics()
+		}
+	}
+
+	trc, err := tracer.New(&cfg.Telemetry, cfg.App.Env)
+	if err != nil {
+		if !cfg.Telemetry.DegradedMode {
+			_ = m.Close()
+			return nil, nil, startup.Unavailable("tracer", err)
+		}
+		s.Degrade("tracer", err)
+		trc = tracer.NewNoOpTracer()
+	}
+
+	return m, trc, nil
+}
+
+const defaultMetricsPath = "/metrics"
+
+// ServeMetrics serves the scrape endpoint of the Prometheus metrics on
+// telemetry.prometheus.address until the telemetry phase of lc. It does
+// nothing for the drivers pushing their metrics, or without an address: the
+// HTTP server then serves the endpoint on its own port.
+func ServeMetrics(cfg *config.Config, m metrics.Metrics, lc *lifecycle.Manager, log logger.Logger) {
+	h, ok := m.(metrics.Handler)
+	if !ok || cfg.Telemetry.Prometheus.Address == "" {
+		return
+	}
+	log = log.WithField("component", "metrics")
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath(cfg.Telemetry.Prometheus), h.Handler())
+	srv := &http.Server{
+		Addr:              cfg.Telemetry.Prometheus.Address,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		log.Info(fmt.Sprintf("Metrics server listening on %s", srv.Addr))
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			log.WithField("error_detail", err.Error()).Error("Metrics server failed")
+		}
+	}()
+	// Scraped until the end: stopped with the exporters of the other drivers.
+	lc.Register(lifecycle.PhaseTelemetry, "metrics server", func(ctx context.Context) error {
+		return srv.Shutdown(ctx)
+	})
+}
+
+// servesMetricsRoute reports whether the HTTP server serves the scrape
+// endpoint of m.
+func servesMetricsRoute(cfg *config.Config, m metrics.Metrics) bool {
+	_, ok := m.(metrics.Handler)
+	return ok && cfg != nil && cfg.Telemetry.Prometheus.Address == ""
+}
+
+func metricsPath(cfg config.PrometheusConfig) string {
+	if cfg.Path == "" {
+		return defaultMetricsPath
+	}
+	return cfg.Path
+}
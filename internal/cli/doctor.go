@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"time"
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/doctor"
+
+	"github.com/spf13/cobra"
+)
+
+func newDoctorCommand(opts *options) *cobra.Command {
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the local environment and print actionable fixes",
+		Long:  `Check the local environment and print actionable fixes. The exit code is 1 when a check failed.`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checks := doctor.DefaultChecks(opts.configPath, app.Domains(), app.ConfigPath, os.LookupEnv)
+			report := doctor.Run(context.Background(), timeout, checks)
+			doctor.Print(cmd.OutOrStdout(), report)
+
+			if report.Failed() {
+				return exit(1)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", 3*time.Second, "timeout of each check")
+	return cmd
+}
@@ -0,0 +1,282 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/scaffold"
+
+	"github.com/spf13/cobra"
+)
+
+func newGenCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gen",
+		Short: "Generate code following the conventions of the repository",
+		Long: `Generate code following the conventions of the repository. Run it from
+the repository root: the generated files never overwrite existing ones, and
+the mocks of test/mocks are regenerated with the contracts they follow.`,
+	}
+	cmd.AddCommand(
+		newGenModuleCommand(),
+		newGenUseCaseCommand(),
+		newGenRepoCommand(),
+		newGenMocksCommand(),
+		newGenErrorsCommand(),
+	)
+	return cmd
+}
+
+func newGenModuleCommand() *cobra.Command {
+	var (
+		entity string
+		dryRun bool
+	)
+	cmd := &cobra.Command{
+		Use:   "module NAME",
+		Short: "Generate the skeleton of a new domain module",
+		Long: `Generate the skeleton of the domain module NAME, following the booking
+module: entity, repository contracts with their command and query
+implementations, create and get use cases, HTTP handler and routes, module
+README, configuration example, initial migration, and unit and integration
+test stubs.`,
+		Example: "  voyago gen module loyalty --entity Reward",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modulePath, err := repositoryModulePath()
+			if err != nil {
+				return err
+			}
+			m, err := scaffold.New(args[0], entity, modulePath, time.Now())
+			if err != nil {
+				return err
+			}
+			files, err := m.Files()
+			if err != nil {
+				return err
+			}
+			if err := generate(cmd.OutOrStdout(), files, modulePath, dryRun); err != nil {
+				return err
+			}
+			printModuleSteps(cmd.OutOrStdout(), m)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&entity, "entity", "", "aggregate of the module in PascalCase, the module name by default")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the files without writing them")
+	return cmd
+}
+
+func newGenUseCaseCommand() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "usecase MODULE NAME",
+		Short: "Generate a use case of an existing module",
+		Long: `Generate the use case NAME, in PascalCase, of the module MODULE: its
+interface and DTOs appended to usecase/contract.go, its implementation with
+the tracing and logging of the architectural standards, and a unit test stub
+on the generated mocks.`,
+		Example: "  voyago gen usecase booking CancelBooking",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modulePath, err := repositoryModulePath()
+			if err != nil {
+				return err
+			}
+			m, err := scaffold.New(args[0], "", modulePath, time.Now())
+			if err != nil {
+				return err
+			}
+			files, err := m.UseCase(".", args[1])
+			if err != nil {
+				return err
+			}
+			if err := generate(cmd.OutOrStdout(), files, modulePath, dryRun); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "\nNext step: add its constructor to the use case providers of internal/modules/%s/module.go and call it from a handler.\n", m.Name)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the files without writing them")
+	return cmd
+}
+
+func newGenRepoCommand() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "repo MODULE ENTITY",
+		Short: "Generate the repositories of an entity of an existing module",
+		Long: `Generate the command and query repositories of the entity ENTITY of the
+module MODULE: their interfaces appended to repository/contract.go and their
+implementations, the query reading the columns of the entity. The entity must
+exist in the entity package of the module.`,
+		Example: "  voyago gen repo booking Invoice",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modulePath, err := repositoryModulePath()
+			if err != nil {
+				return err
+			}
+			m, err := scaffold.New(args[0], args[1], modulePath, time.Now())
+			if err != nil {
+				return err
+			}
+			files, err := m.Repository(".")
+			if err != nil {
+				return err
+			}
+			if err := generate(cmd.OutOrStdout(), files, modulePath, dryRun); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "\nNext step: add the repository constructors to the providers of internal/modules/%s/module.go.\n", m.Name)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the files without writing them")
+	return cmd
+}
+
+func newGenMocksCommand() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "mocks",
+		Short: "Regenerate the mocks of test/mocks",
+		Long: `Regenerate the testify mocks of test/mocks from the interfaces of the
+shared ports and of the repository and use case contracts of every module.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modulePath, err := repositoryModulePath()
+			if err != nil {
+				return err
+			}
+			return generate(cmd.OutOrStdout(), nil, modulePath, dryRun)
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the files without writing them")
+	return cmd
+}
+
+// repositoryModulePath returns the Go module of the repository, the current
+// directory.
+func repositoryModulePath() (string, error) {
+	modulePath, err := scaffold.ModulePath(".")
+	if err != nil {
+		return "", fmt.Errorf("run gen from the repository root: %w", err)
+	}
+	return modulePath, nil
+}
+
+// newGenErrorsCommand exports the error catalog as JSON or as a markdown table.
+func newGenErrorsCommand() *cobra.Command {
+	var (
+		format  string
+		output  string
+		docBase string
+	)
+	cmd := &cobra.Command{
+		Use:   "errors",
+		Short: "Export the error catalog for the client teams",
+		Long: `Export the error codes registered in the error catalog by the
+infrastructure and every module (apperror.Register): their HTTP status, gRPC
+code, kind, retryability, default message and documentation link.`,
+		Example: "  voyago gen errors --output docs/errors.json --doc-base https://docs.voyago.dev/errors/",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries := apperror.Catalog()
+			if docBase != "" {
+				for i := range entries {
+					if entries[i].DocURL == "" {
+						entries[i].DocURL = docBase + entries[i].Code
+					}
+				}
+			}
+
+			var buf bytes.Buffer
+			switch format {
+			case "json":
+				enc := json.NewEncoder(&buf)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(map[string]any{"domain": apperror.ErrorDomain, "errors": entries}); err != nil {
+					return err
+				}
+			case "markdown":
+				writeErrorCatalog(&buf, entries)
+			default:
+				return fmt.Errorf("unknown format %q, expected json or markdown", format)
+			}
+
+			if output == "" {
+				_, err := cmd.OutOrStdout().Write(buf.Bytes())
+				return err
+			}
+			if err := os.WriteFile(output, buf.Bytes(), 0o644); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "wrote %d error codes to %s\n", len(entries), output)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "json", "json or markdown")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file to write, the standard output by default")
+	cmd.Flags().StringVar(&docBase, "doc-base", "", "documentation link of the errors without one, followed by their code (e.g. http.problem_type_base)")
+	return cmd
+}
+
+// writeErrorCatalog writes entries as the error table of the module READMEs.
+func writeErrorCatalog(w io.Writer, entries []apperror.CatalogEntry) {
+	fmt.Fprintln(w, "| Module | Code | HTTP | gRPC | Retryable | Message |")
+	fmt.Fprintln(w, "|--------|------|------|------|-----------|---------|")
+	for _, e := range entries {
+		code := "`" + e.Code + "`"
+		if e.DocURL != "" {
+			code = "[" + code + "](" + e.DocURL + ")"
+		}
+		fmt.Fprintf(w, "| %s | %s | %d | %s | %t | %s |\n", e.Module, code, e.HttpStatus, e.GRPCCode, e.Retryable, e.Message)
+	}
+}
+
+// generate writes files and the mocks regenerated with them, unless dryRun,
+// then lists the files created or updated.
+func generate(w io.Writer, files []scaffold.File, modulePath string, dryRun bool) error {
+	mocks, err := scaffold.Mocks(".", modulePath, files)
+	if err != nil {
+		return err
+	}
+	var changed []scaffold.File
+	var existed []bool
+	for _, f := range append(files, mocks...) {
+		current, err := os.ReadFile(filepath.FromSlash(f.Path))
+		if err == nil && bytes.Equal(current, f.Content) {
+			continue
+		}
+		changed = append(changed, f)
+		existed = append(existed, err == nil)
+	}
+
+	verbs := map[bool]string{false: "created", true: "updated"}
+	if dryRun {
+		verbs = map[bool]string{false: "would create", true: "would update"}
+	} else if err := scaffold.Write(".", changed); err != nil {
+		return err
+	}
+	for i, f := range changed {
+		fmt.Fprintf(w, "%s %s\n", verbs[existed[i]], f.Path)
+	}
+	return nil
+}
+
+// printModuleSteps prints the wiring of the module m left to the developer.
+func printModuleSteps(w io.Writer, m scaffold.Module) {
+	fmt.Fprintf(w, `
+Next steps:
+  1. Enable the module with a blank import in internal/app/modules.go:
+       _ "%[2]s/internal/modules/%[1]s"
+  2. cp config/%[1]s/config.example.yaml config/%[1]s/config.yaml, then voyago migrate --domain %[1]s up.
+`, m.Name, m.ModulePath)
+}
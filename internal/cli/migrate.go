@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/migrations"
+
+	"github.com/spf13/cobra"
+)
+
+// migration is run on the migrator of a domain by a migrate subcommand.
+type migration func(*database.Migrator) error
+
+func newMigrateCommand(opts *options) *cobra.Command {
+	var domain string
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply the embedded SQL migrations of the domains",
+		Long: `Apply the embedded SQL migrations of the domains, then print the version of
+their databases. The exit code is 1 when the migrations of a domain failed.`,
+	}
+	cmd.PersistentFlags().StringVarP(&domain, "domain", "d", "", "domain to migrate, every registered domain when empty")
+
+	// run returns the RunE of a subcommand running m. Rolling back every
+	// domain at once is never intended: scoped requires --domain.
+	run := func(scoped bool, m func(args []string) (migration, error)) func(*cobra.Command, []string) error {
+		return func(cmd *cobra.Command, args []string) error {
+			if scoped && domain == "" {
+				return fmt.Errorf("migrate %s needs --domain", cmd.Name())
+			}
+			domains, err := domainsOf(domain)
+			if err != nil {
+				return err
+			}
+			mig, err := m(args)
+			if err != nil {
+				return err
+			}
+			return exit(opts.migrate(cmd.OutOrStdout(), cmd.ErrOrStderr(), domains, mig))
+		}
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply the pending migrations",
+			Args:  cobra.NoArgs,
+			RunE: run(false, func([]string) (migration, error) {
+				return (*database.Migrator).Up, nil
+			}),
+		},
+		&cobra.Command{
+			Use:   "down [N]",
+			Short: "Roll back the last N migrations (default 1)",
+			Args:  cobra.MaximumNArgs(1),
+			RunE: run(true, func(args []string) (migration, error) {
+				steps := 1
+				if len(args) == 1 {
+					n, err := strconv.Atoi(args[0])
+					if err != nil || n <= 0 {
+						return nil, fmt.Errorf("invalid number of migrations %q", args[0])
+					}
+					steps = n
+				}
+				return func(m *database.Migrator) error { return m.Down(steps) }, nil
+			}),
+		},
+		&cobra.Command{
+			Use:   "force V",
+			Short: "Record version V as clean, after a manual repair",
+			Args:  cobra.ExactArgs(1),
+			RunE: run(true, func(args []string) (migration, error) {
+				version, err := strconv.Atoi(args[0])
+				if err != nil {
+					return nil, fmt.Errorf("invalid version %q", args[0])
+				}
+				return func(m *database.Migrator) error { return m.Force(version) }, nil
+			}),
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "Print the version of every database",
+			Args:  cobra.NoArgs,
+			RunE: run(false, func([]string) (migration, error) {
+				return func(*database.Migrator) error { return nil }, nil
+			}),
+		},
+	)
+	return cmd
+}
+
+// migrate runs mig on the database of every domain and returns the exit
+// code: 1 when it failed on a domain.
+func (o *options) migrate(stdout, stderr io.Writer, domains []string, mig migration) int {
+	config.InitGlobalConfig(o.configPath)
+	code := 0
+	for _, d := range domains {
+		cfg := config.LoadDomainConfig(app.ConfigPath(d))
+		if err := migrateDomain(stdout, d, &cfg.Database, mig); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", d, err)
+			code = 1
+		}
+	}
+	return code
+}
+
+// migrateDomain runs mig on the database of domain, then prints its status.
+func migrateDomain(w io.Writer, domain string, cfg *config.DatabaseConfig, mig migration) error {
+	m, err := database.NewMigrator(cfg, migrations.FS(domain))
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := mig(m); err != nil {
+		return err
+	}
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	state := "up to date"
+	if err := status.Err(); err != nil {
+		state = err.Error()
+	} else if status.Version > status.Latest {
+		state = "ahead of the embedded migrations"
+	}
+	fmt.Fprintf(w, "%s: version %d, latest %d (%s)\n", domain, status.Version, status.Latest, state)
+	return nil
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/errorreport"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/messaging/kafka"
+	"voyago/core-api/internal/infrastructure/startup"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/i18n"
+	"voyago/core-api/internal/pkg/utils"
+	"voyago/core-api/locales"
+)
+
+// process is the runtime shared by the servers and the worker: the global
+// configuration, the logger, the shutdown lifecycle, the telemetry and the
+// event bus. Its failures are reported by the startup pipeline.
+type process struct {
+	startup *startup.Pipeline
+	cfg     *config.Config
+	log     logger.Logger
+	lc      *lifecycle.Manager
+	metrics metrics.Metrics
+	tracer  tracer.Tracer
+	bus     eventbus.Bus
+	// reporter reports the unexpected failures of the requests.
+	reporter errorreport.Reporter
+	// kafka is the client of the Kafka consumers and producer, nil unless
+	// kafka.enabled.
+	kafka kafka.Transport
+}
+
+// newProcess starts the runtime of a long-running command, address being
+// where it listens. On failure it returns the startup error to give to
+// s.Fail.
+func (o *options) newProcess(s *startup.Pipeline, address func(*config.Config) any) (*process, error) {
+	// ----- Load config -----
+	globalCfg, err := config.LoadGlobalConfig(o.configPath)
+	if err != nil {
+		return nil, startup.Config("config", err)
+	}
+	// ----- Load config -----
+
+	// ----- Initialize global logger -----
+	if err := utils.ConfigureMasking(globalCfg.Log.Masking); err != nil {
+		return nil, startup.Config("log", err)
+	}
+	apperror.CaptureStacks(globalCfg.Log.ErrorStacks)
+	if err := configureI18n(globalCfg.I18n); err != nil {
+		return nil, startup.Config("i18n", err)
+	}
+	log, err := logger.NewForDomain(globalCfg, nil, "main")
+	if err != nil {
+		return nil, startup.Config("log", err)
+	}
+	appLogger := log.WithFields(map[string]any{
+		"service": globalCfg.App.Name,
+		"version": globalCfg.App.Version,
+		"env":     globalCfg.App.Env,
+		"port":    address(globalCfg),
+		"domain":  "main",
+	})
+	s.SetLogger(appLogger)
+	// ----- Initialize global logger -----
+
+	// ----- Initialize lifecycle -----
+	// Shutdown hooks run by phase: servers, consumers, workers, resources, telemetry.
+	lc := lifecycle.New(globalCfg.Shutdown, appLogger)
+	if closer, ok := log.(io.Closer); ok {
+		lc.Register(lifecycle.PhaseTelemetry, "logger", lifecycle.Closer(closer.Close))
+	}
+	// ----- Initialize lifecycle -----
+
+	// ----- Initialize telemetry -----
+	m, trc, err := app.NewTelemetry(globalCfg, s)
+	if err != nil {
+		return nil, err
+	}
+	lc.Register(lifecycle.PhaseTelemetry, "metrics", lifecycle.Closer(m.Close))
+	lc.Register(lifecycle.PhaseTelemetry, "tracer", lifecycle.Closer(trc.Close))
+	app.ServeMetrics(globalCfg, m, lc, appLogger)
+
+	reporter, err := errorreport.NewReporter(globalCfg, appLogger)
+	if err != nil {
+		return nil, startup.Config("error_reporting", err)
+	}
+	lc.Register(lifecycle.PhaseTelemetry, "error reporter", lifecycle.Closer(reporter.Close))
+	// ----- Initialize telemetry -----
+
+	// ----- Initialize event bus -----
+	bus := eventbus.NewInMemoryBus(appLogger)
+	// Drained before the workers and databases used by its handlers.
+	lc.Register(lifecycle.PhaseConsumers, "event bus", lifecycle.Closer(bus.Close))
+	// ----- Initialize event bus -----
+
+	// ----- Initialize kafka client -----
+	var kafkaTransport kafka.Transport
+	if globalCfg.Kafka.Enabled {
+		client, err := kafka.NewClient(&globalCfg.Kafka)
+		if err != nil {
+			return nil, startup.Config("kafka", err)
+		}
+		kafkaTransport = client
+	}
+	// ----- Initialize kafka client -----
+
+	return &process{
+		startup: s,
+		cfg:     globalCfg,
+		log:     appLogger,
+		lc:      lc,
+		metrics: m,
+		tracer:  trc,
+		bus:     bus,
+
+		reporter: reporter,
+		kafka:    kafkaTransport,
+	}, nil
+}
+
+// starting logs that the process named what starts, with its telemetry
+// configuration, and returns the logger of the application.
+func (p *process) starting(what string) logger.Logger {
+	l := p.log.WithField("component", "app")
+	l.Info(what + " starting")
+
+	if p.cfg.Telemetry.Enabled {
+		l.Info(fmt.Sprintf("Telemetry config: metrics=%s, tracer=%s, sample_rate=%f",
+			p.cfg.Telemetry.MetricsAddress,
+			p.cfg.Telemetry.TracerAddress,
+			p.cfg.Telemetry.SampleRate))
+	}
+	return l
+}
+
+// started warns when the process started with degraded components.
+func (p *process) started(l logger.Logger) {
+	if degraded := p.startup.Degraded(); len(degraded) > 0 {
+		l.WithField("degraded", degraded).Warn("Application started in degraded mode")
+	}
+}
+
+// configureI18n translates the messages with the embedded catalogs.
+func configureI18n(cfg config.I18nConfig) error {
+	bundle, err := i18n.Load(locales.FS())
+	if err != nil {
+		return err
+	}
+	return i18n.Configure(bundle, cfg)
+}
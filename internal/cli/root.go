@@ -0,0 +1,158 @@
+// Package cli is the command line of the service, voyago. Its commands start
+// the servers and the worker and run the operational tasks (migrations,
+// seeders, diagnostics) on the same flags and configuration:
+//
+//	voyago serve [--transport http|grpc]
+//	voyago worker
+//	voyago migrate [--domain booking] up|down [N]|force V|status
+//	voyago seed [--domain booking]
+//	voyago routes
+//	voyago doctor [--timeout 3s]
+//	voyago gen module NAME [--entity Name]
+//	voyago gen usecase MODULE Name
+//	voyago gen repo MODULE Entity
+//	voyago gen mocks
+//
+// Every command reads the global configuration given by --config and the
+// configuration of the domains under config/<domain>/. The flags replace
+// their settings in every configuration:
+//
+//	voyago serve --config /etc/voyago/config.yaml --env staging --port 8080
+//	voyago worker --set log.level=5 --set database.pool.max=20
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/startup"
+
+	"github.com/spf13/cobra"
+)
+
+// DefaultConfigPath is the global configuration read without --config.
+const DefaultConfigPath = "config/config.yaml"
+
+// ExitUsage is the exit code of a command line that cannot be run: unknown
+// command, flag or domain, or missing argument.
+const ExitUsage = 2
+
+// options are the flags shared by every command.
+type options struct {
+	// configPath is the global configuration file.
+	configPath string
+	// env replaces app.env when set.
+	env string
+	// settings are the key=value settings of --set, replacing the ones of
+	// the configuration files.
+	settings []string
+}
+
+// useOverrides applies the settings of the flags over the configuration
+// files and the environment (see config.SetOverrides), extra being the
+// settings of the flags of the command, e.g. the port of serve.
+func (o *options) useOverrides(extra map[string]string) error {
+	settings := map[string]string{}
+	for _, kv := range o.settings {
+		key, value, ok := strings.Cut(kv, "=")
+		if key = strings.TrimSpace(key); !ok || key == "" {
+			return fmt.Errorf("invalid --set %q, expected key=value", kv)
+		}
+		settings[key] = value
+	}
+	if o.env != "" {
+		settings["app.env"] = o.env
+	}
+	maps.Copy(settings, extra)
+	if err := config.SetOverrides(settings); err != nil {
+		return fmt.Errorf("invalid --set: %w", err)
+	}
+	return nil
+}
+
+// exitError ends a command with the exit code of the process, once the
+// command has reported why.
+type exitError struct {
+	code int
+}
+
+func (e *exitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+// exit returns the error of a command ending with code, nil for
+// startup.ExitOK.
+func exit(code int) error {
+	if code == startup.ExitOK {
+		return nil
+	}
+	return &exitError{code: code}
+}
+
+// Run runs the command line args, without the program name, and returns the
+// exit code of the process. The commands write to stdout and report the
+// usage errors to stderr.
+func Run(args []string, stdout, stderr io.Writer) int {
+	root := NewRootCommand()
+	root.SetArgs(args)
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+
+	err := root.Execute()
+	var exitErr *exitError
+	switch {
+	case err == nil:
+		return startup.ExitOK
+	case errors.As(err, &exitErr):
+		return exitErr.code
+	}
+	fmt.Fprintf(stderr, "Error: %v\nRun 'voyago --help' for usage.\n", err)
+	return ExitUsage
+}
+
+// NewRootCommand returns the voyago command and its subcommands.
+func NewRootCommand() *cobra.Command {
+	opts := &options{}
+	root := &cobra.Command{
+		Use:   "voyago",
+		Short: "Voyago core API: servers, worker and operational tasks",
+		// Errors are reported once by Run, without the usage of the command.
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.useOverrides(nil)
+		},
+	}
+	root.PersistentFlags().StringVarP(&opts.configPath, "config", "c", DefaultConfigPath, "global configuration file")
+	root.PersistentFlags().StringVar(&opts.env, "env", "", "application environment, replaces app.env")
+	root.PersistentFlags().StringArrayVar(&opts.settings, "set", nil, "setting replacing the configuration, by YAML path: --set log.level=5 (repeatable)")
+
+	root.AddCommand(
+		newServeCommand(opts),
+		newWorkerCommand(opts),
+		newMigrateCommand(opts),
+		newSeedCommand(opts),
+		newRoutesCommand(opts),
+		newDoctorCommand(opts),
+		newGenCommand(),
+	)
+	return root
+}
+
+// domainsOf returns the domains selected by the --domain flag: every
+// registered domain when it is empty.
+func domainsOf(domain string) ([]string, error) {
+	domains := app.Domains()
+	if domain == "" {
+		return domains, nil
+	}
+	if !slices.Contains(domains, domain) {
+		return nil, fmt.Errorf("unknown domain %q, registered: %v", domain, domains)
+	}
+	return []string{domain}, nil
+}
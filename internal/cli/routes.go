@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	server "voyago/core-api/internal/infrastructure/http"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/startup"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/infrastructure/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/cobra"
+)
+
+func newRoutesCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "routes",
+		Short: "Print the HTTP routes of the configured application",
+		Long: `Print the HTTP routes mounted by serve with the current configuration.
+
+The application is bootstrapped without its infrastructure: the databases are
+in memory, the background work is not started and nothing is served. The
+metrics route of the Prometheus exporter is not listed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			routes, err := opts.routes()
+			if err != nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), err)
+				return exit(startup.ExitConfig)
+			}
+			printRoutes(cmd.OutOrStdout(), routes)
+			return nil
+		},
+	}
+}
+
+// routes bootstraps the HTTP application in dry mode and returns its routes,
+// sorted by path then method.
+func (o *options) routes() (routes []fiber.Route, err error) {
+	globalCfg, err := config.LoadGlobalConfig(o.configPath)
+	if err != nil {
+		return nil, err
+	}
+	// Only the API is mounted: the consumers and jobs are left to no one.
+	globalCfg.Worker.Standalone = true
+
+	log := logger.NewNoOpLogger()
+	srv := server.NewServer(globalCfg, log, nil)
+	bootstrap := app.BootstrapHttpConfig{
+		Config:  globalCfg,
+		App:     srv.App,
+		Val:     validator.NewPlaygroundValidator(),
+		Log:     log,
+		Tracer:  tracer.NewNoOpTracer(),
+		Metrics: metrics.NewNoOpMetrics(),
+		Bus:     eventbus.NewInMemoryBus(log),
+		LoadDomainConfig: func(domain string) *config.Config {
+			cfg := config.LoadDomainConfig(app.ConfigPath(domain))
+			cfg.Database.Migrations.CheckOnStartup = false
+			return cfg
+		},
+		OpenDomainDB: func(domain string, _ *config.Config, log logger.Logger) database.Database {
+			return database.NewSQLiteDatabase("voyago_routes_"+domain, log, nil)
+		},
+	}
+	if err := startup.Guard("bootstrap", startup.ExitConfig, bootstrap.Run); err != nil {
+		return nil, err
+	}
+	defer bootstrap.Stop()
+
+	for _, r := range srv.App.GetRoutes(true) {
+		// Fiber answers HEAD on every GET route.
+		if r.Method == fiber.MethodHead {
+			continue
+		}
+		routes = append(routes, r)
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	return routes, nil
+}
+
+// printRoutes writes one route per line: method and path.
+func printRoutes(w io.Writer, routes []fiber.Route) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH")
+	for _, r := range routes {
+		fmt.Fprintf(tw, "%s\t%s\n", r.Method, r.Path)
+	}
+	_ = tw.Flush()
+}
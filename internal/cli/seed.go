@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/seed"
+
+	"github.com/spf13/cobra"
+)
+
+func newSeedCommand(opts *options) *cobra.Command {
+	var domain string
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Run the pending seeders of the domains",
+		Long: `Run the pending seeders of the domains on their databases. Sample data is
+refused when app.env is production. The exit code is 1 when a seeder failed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domains, err := domainsOf(domain)
+			if err != nil {
+				return err
+			}
+			return exit(opts.seed(cmd.OutOrStdout(), cmd.ErrOrStderr(), domains))
+		},
+	}
+	cmd.Flags().StringVarP(&domain, "domain", "d", "", "domain to seed, every registered domain when empty")
+	return cmd
+}
+
+// seed runs the seeders of every domain and returns the exit code: 1 when a
+// seeder failed.
+func (o *options) seed(stdout, stderr io.Writer, domains []string) int {
+	config.InitGlobalConfig(o.configPath)
+	seeders := app.Seeders()
+	code := 0
+	for _, d := range domains {
+		if len(seeders.Seeders(d)) == 0 {
+			continue
+		}
+		cfg := config.LoadDomainConfig(app.ConfigPath(d))
+		if err := seedDomain(stdout, d, cfg, seeders.Seeders(d)); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", d, err)
+			code = 1
+		}
+	}
+	return code
+}
+
+// seedDomain runs seeders on the database of domain and prints their
+// outcome.
+func seedDomain(w io.Writer, domain string, cfg *config.Config, seeders []seed.Seeder) error {
+	db, err := database.OpenGormDatabase(&cfg.Database, logger.NewNoOpLogger(), nil)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	results, err := seed.Run(context.Background(), db, cfg.App.Env, seeders)
+	for _, r := range results {
+		fmt.Fprintf(w, "%s: %s %s\n", domain, r.Name, r.Outcome)
+	}
+	return err
+}
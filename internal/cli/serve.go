@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/config"
+	grpcserver "voyago/core-api/internal/infrastructure/grpc"
+	server "voyago/core-api/internal/infrastructure/http"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/startup"
+	"voyago/core-api/internal/infrastructure/validator"
+
+	"github.com/spf13/cobra"
+)
+
+// Transports served by the serve command.
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+func newServeCommand(opts *options) *cobra.Command {
+	var (
+		transport string
+		port      int
+	)
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the API of the modules over HTTP or gRPC",
+		Long: `Serve the API of the modules until SIGINT or SIGTERM, then drain and exit.
+
+The exit code tells a bad deployment (78, invalid configuration) from a
+transient failure (69, unreachable dependency), see package startup.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("port") {
+				return nil
+			}
+			key := "http.port"
+			if transport == TransportGRPC {
+				key = "grpc.port"
+			}
+			return opts.useOverrides(map[string]string{key: strconv.Itoa(port)})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch transport {
+			case TransportHTTP:
+				return exit(opts.serveHTTP())
+			case TransportGRPC:
+				return exit(opts.serveGRPC())
+			}
+			return fmt.Errorf("unknown transport %q, expected %s or %s", transport, TransportHTTP, TransportGRPC)
+		},
+	}
+	cmd.Flags().StringVar(&transport, "transport", TransportHTTP, "API transport: http or grpc")
+	cmd.Flags().IntVar(&port, "port", 0, "port of the transport, replaces http.port or grpc.port")
+	return cmd
+}
+
+// serveHTTP serves the REST API and returns the exit code of the process.
+func (o *options) serveHTTP() (code int) {
+	// Failures before the configured logger exists are logged to stdout.
+	s := startup.New(logger.NewStdoutLogger(&config.Config{}, nil))
+	defer s.Recover(&code)
+
+	p, err := o.newProcess(s, func(cfg *config.Config) any { return cfg.Http.Port })
+	if err != nil {
+		return s.Fail(err)
+	}
+	l := p.starting("Application")
+
+	srv := server.NewServer(p.cfg, p.log, p.metrics)
+	bootstrap := app.BootstrapHttpConfig{
+		Config:  p.cfg,
+		App:     srv.App,
+		Val:     validator.NewPlaygroundValidator(),
+		Log:     p.log,
+		Tracer:  p.tracer,
+		Metrics: p.metrics,
+		Bus:     p.bus,
+
+		Reporter: p.reporter,
+
+		KafkaTransport: p.kafka,
+
+		Lifecycle: p.lc,
+	}
+	// The bootstrap panics on invalid module or middleware configuration.
+	if err := startup.Guard("bootstrap", startup.ExitConfig, bootstrap.Run); err != nil {
+		_ = p.lc.Shutdown(context.Background())
+		return s.Fail(err)
+	}
+	p.lc.Register(lifecycle.PhaseServers, "http server", srv.Stop)
+	p.started(l)
+
+	err = p.lc.Run(func() error {
+		if err := srv.Start(); err != nil {
+			return startup.Unavailable("http server", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return s.Fail(err)
+	}
+	return startup.ExitOK
+}
+
+// serveGRPC serves the gRPC API and returns the exit code of the process.
+func (o *options) serveGRPC() (code int) {
+	s := startup.New(logger.NewStdoutLogger(&config.Config{}, nil))
+	defer s.Recover(&code)
+
+	p, err := o.newProcess(s, func(cfg *config.Config) any { return cfg.Grpc.Port })
+	if err != nil {
+		return s.Fail(err)
+	}
+	l := p.starting("Application")
+
+	srv := grpcserver.NewServer(p.cfg, p.log, app.GrpcInterceptors(p.cfg, p.log, p.tracer, p.metrics, p.reporter)...)
+	bootstrap := app.BootstrapGrpcConfig{
+		Config:  p.cfg,
+		Server:  srv.App,
+		Val:     validator.NewPlaygroundValidator(),
+		Log:     p.log,
+		Tracer:  p.tracer,
+		Metrics: p.metrics,
+		Bus:     p.bus,
+
+		Lifecycle: p.lc,
+	}
+	// The bootstrap panics on invalid module configuration.
+	if err := startup.Guard("bootstrap", startup.ExitConfig, bootstrap.Run); err != nil {
+		_ = p.lc.Shutdown(context.Background())
+		return s.Fail(err)
+	}
+	p.lc.Register(lifecycle.PhaseServers, "grpc server", srv.Stop)
+	p.started(l)
+
+	err = p.lc.Run(func() error {
+		if err := srv.Start(); err != nil {
+			return startup.Unavailable("grpc server", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return s.Fail(err)
+	}
+	return startup.ExitOK
+}
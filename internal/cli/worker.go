@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/startup"
+
+	"github.com/spf13/cobra"
+)
+
+func newWorkerCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "worker",
+		Short: "Run the background work of the modules, without any API",
+		Long: `Run the consumers, webhook deliveries, scheduled jobs and queued tasks of
+the modules, and serve /health on worker.health_address.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exit(opts.runWorker())
+		},
+	}
+}
+
+// runWorker runs the background runtime and returns the exit code of the
+// process.
+func (o *options) runWorker() (code int) {
+	s := startup.New(logger.NewStdoutLogger(&config.Config{}, nil))
+	defer s.Recover(&code)
+
+	p, err := o.newProcess(s, func(cfg *config.Config) any { return cfg.Worker.HealthAddress })
+	if err != nil {
+		return s.Fail(err)
+	}
+	l := p.starting("Worker")
+
+	bootstrap := &app.BootstrapWorkerConfig{
+		Config:  p.cfg,
+		Log:     p.log,
+		Tracer:  p.tracer,
+		Metrics: p.metrics,
+		Bus:     p.bus,
+
+		KafkaTransport: p.kafka,
+
+		Lifecycle: p.lc,
+	}
+	// The bootstrap panics on invalid module or broker configuration.
+	if err := startup.Guard("bootstrap", startup.ExitConfig, bootstrap.Run); err != nil {
+		_ = p.lc.Shutdown(context.Background())
+		return s.Fail(err)
+	}
+
+	srv := &http.Server{
+		Addr:              p.cfg.Worker.HealthAddress,
+		Handler:           bootstrap.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	// Probed until the end: the worker reports its drain until it exits.
+	p.lc.Register(lifecycle.PhaseTelemetry, "health server", func(ctx context.Context) error {
+		return srv.Shutdown(ctx)
+	})
+	p.started(l)
+
+	err = p.lc.Run(func() error {
+		l.Info(fmt.Sprintf("Health server listening on %s", srv.Addr))
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			return startup.Unavailable("health server", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return s.Fail(err)
+	}
+	return startup.ExitOK
+}
@@ -0,0 +1,455 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// -------- Configuration --------
+
+// ConfigFile checks that a configuration file exists under root.
+// example, when not empty, is the template the fix suggests copying.
+func ConfigFile(root, path, example string) Check {
+	return Check{
+		Name: "config: " + path,
+		Run: func(context.Context) Result {
+			if _, err := os.Stat(filepath.Join(root, path)); err == nil {
+				return Result{Status: StatusOK, Detail: "found"}
+			} else if !os.IsNotExist(err) {
+				return Result{Status: StatusFail, Detail: err.Error(), Fix: "check the file permissions of " + path}
+			}
+
+			fix := "create " + path
+			if example != "" {
+				if _, err := os.Stat(filepath.Join(root, example)); err == nil {
+					fix = fmt.Sprintf("cp %s %s", example, path)
+				}
+			}
+			return Result{Status: StatusFail, Detail: "not found", Fix: fix}
+		},
+	}
+}
+
+// ConfigValid reports the loading of the configuration file path: err is
+// the error of the loader, a *config.ValidationError listing the invalid
+// settings when the file was read.
+func ConfigValid(path string, err error) Check {
+	return Check{
+		Name: "settings: " + path,
+		Run: func(context.Context) Result {
+			if err == nil {
+				return Result{Status: StatusOK, Detail: "valid"}
+			}
+			var verr *config.ValidationError
+			if !errors.As(err, &verr) {
+				return Result{Status: StatusFail, Detail: err.Error(), Fix: "fix the syntax of " + path}
+			}
+			problems := make([]string, len(verr.Problems))
+			for i, p := range verr.Problems {
+				problems[i] = p.Path + " " + p.Message
+			}
+			return Result{Status: StatusFail, Detail: strings.Join(problems, "; "), Fix: "fix these settings in " + path}
+		},
+	}
+}
+
+// envPlaceholder matches the ${NAME} and ${NAME:default} placeholders expanded
+// by the config loader.
+var envPlaceholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:[^}]*)?\}`)
+
+// EnvPlaceholders returns the environment variables referenced by a config
+// file content, with whether a default value is provided.
+// A variable referenced both with and without default is reported as required.
+func EnvPlaceholders(content string) map[string]bool {
+	vars := make(map[string]bool)
+	for _, m := range envPlaceholder.FindAllStringSubmatch(content, -1) {
+		hasDefault := m[2] != ""
+		if prev, ok := vars[m[1]]; ok {
+			hasDefault = prev && hasDefault
+		}
+		vars[m[1]] = hasDefault
+	}
+	return vars
+}
+
+// ConfigEnv checks that every variable referenced without default by the
+// given config files is set. Missing files are ignored (see ConfigFile).
+func ConfigEnv(root string, paths []string, lookup func(string) (string, bool)) Check {
+	return Check{
+		Name: "env: config placeholders",
+		Run: func(context.Context) Result {
+			referenced := make(map[string]string) // variable -> first file requiring it
+			total := 0
+			for _, path := range paths {
+				content, err := os.ReadFile(filepath.Join(root, path))
+				if err != nil {
+					continue
+				}
+				for name, hasDefault := range EnvPlaceholders(string(content)) {
+					total++
+					if hasDefault {
+						continue
+					}
+					if _, ok := referenced[name]; !ok {
+						referenced[name] = path
+					}
+				}
+			}
+
+			var missing []string
+			for name := range referenced {
+				if v, ok := lookup(name); !ok || v == "" {
+					missing = append(missing, name)
+				}
+			}
+			if len(missing) == 0 {
+				return Result{Status: StatusOK, Detail: fmt.Sprintf("%d reference(s), all set or defaulted", total)}
+			}
+
+			sort.Strings(missing)
+			fixes := make([]string, 0, len(missing))
+			for _, name := range missing {
+				fixes = append(fixes, fmt.Sprintf("export %s=... (required by %s)", name, referenced[name]))
+			}
+			return Result{
+				Status: StatusFail,
+				Detail: "missing: " + strings.Join(missing, ", "),
+				Fix:    strings.Join(fixes, "; "),
+			}
+		},
+	}
+}
+
+// RequiredEnv checks a single variable that has no usable default.
+// A missing variable is a warning: it only blocks the workflow given in reason.
+func RequiredEnv(name, reason string, lookup func(string) (string, bool)) Check {
+	return Check{
+		Name: "env: " + name,
+		Run: func(context.Context) Result {
+			if v, ok := lookup(name); ok && v != "" {
+				return Result{Status: StatusOK, Detail: "set"}
+			}
+			return Result{
+				Status: StatusWarn,
+				Detail: "not set, " + reason,
+				Fix:    fmt.Sprintf("export %s=...", name),
+			}
+		},
+	}
+}
+
+// -------- Databases --------
+
+// TestDatabaseConfig returns the database used by the integration tests.
+// It mirrors the TEST_DB_* variables and defaults of test/helper.
+func TestDatabaseConfig(lookup func(string) (string, bool)) config.DatabaseConfig {
+	get := func(key, fallback string) string {
+		if v, ok := lookup(key); ok && v != "" {
+			return v
+		}
+		return fallback
+	}
+
+	port, err := strconv.Atoi(get("TEST_DB_PORT", "5432"))
+	if err != nil {
+		port = 5432
+	}
+
+	return config.DatabaseConfig{
+		Host:     get("TEST_DB_HOST", "localhost"),
+		Port:     port,
+		User:     get("TEST_DB_USER", "booking_user"),
+		Password: get("TEST_DB_PASSWORD", ""),
+		Name:     get("TEST_DB_NAME", "voyago_test"),
+		Schema:   get("TEST_DB_SCHEMA", "booking"),
+	}
+}
+
+// Database checks that the database described by cfg accepts connections.
+func Database(name string, cfg config.DatabaseConfig, fix string) Check {
+	return Check{
+		Name: "database: " + name,
+		Run: func(ctx context.Context) Result {
+			conn, err := connect(ctx, cfg)
+			if err != nil {
+				return Result{Status: StatusFail, Detail: err.Error(), Fix: fix}
+			}
+			defer conn.Close(context.Background())
+
+			return Result{Status: StatusOK, Detail: fmt.Sprintf("connected to %s@%s:%d/%s", cfg.User, cfg.Host, cfg.Port, cfg.Name)}
+		},
+	}
+}
+
+// LatestMigration returns the highest version among the golang-migrate
+// "<version>_<name>.up.sql" files of dir, or 0 when there is none.
+func LatestMigration(dir string) (uint64, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint64
+	for _, f := range files {
+		prefix, _, ok := strings.Cut(filepath.Base(f), "_")
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		if v > latest {
+			latest = v
+		}
+	}
+	return latest, nil
+}
+
+// Migrations compares the version recorded by golang-migrate in the domain
+// schema with the latest migration file of migrationsDir.
+func Migrations(domain string, cfg config.DatabaseConfig, migrationsDir string) Check {
+	fix := fmt.Sprintf("go run ./cmd/voyago migrate --domain %s up", domain)
+
+	return Check{
+		Name: "migrations: " + domain,
+		Run: func(ctx context.Context) Result {
+			latest, err := LatestMigration(migrationsDir)
+			if err != nil {
+				return Result{Status: StatusFail, Detail: err.Error(), Fix: "check " + migrationsDir}
+			}
+			if latest == 0 {
+				return Result{Status: StatusSkip, Detail: "no migration files in " + migrationsDir}
+			}
+
+			conn, err := connect(ctx, cfg)
+			if err != nil {
+				return Result{Status: StatusSkip, Detail: "database unreachable"}
+			}
+			defer conn.Close(context.Background())
+
+			var (
+				version int64
+				dirty   bool
+			)
+			err = conn.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+
+			var pgErr *pgconn.PgError
+			switch {
+			case errors.As(err, &pgErr) && pgErr.Code == "42P01", errors.Is(err, pgx.ErrNoRows):
+				return Result{Status: StatusFail, Detail: "no migration applied", Fix: fix}
+			case err != nil:
+				return Result{Status: StatusFail, Detail: err.Error(), Fix: fix}
+			case dirty:
+				return Result{
+					Status: StatusFail,
+					Detail: fmt.Sprintf("version %d is dirty (a migration failed halfway)", version),
+					Fix:    fmt.Sprintf("repair the schema manually, then: %s", strings.Replace(fix, " up", fmt.Sprintf(" force %d", version), 1)),
+				}
+			case uint64(version) < latest:
+				return Result{Status: StatusFail, Detail: fmt.Sprintf("at version %d, latest is %d", version, latest), Fix: fix}
+			case uint64(version) > latest:
+				return Result{
+					Status: StatusWarn,
+					Detail: fmt.Sprintf("at version %d, ahead of the latest local file %d", version, latest),
+					Fix:    "pull the latest changes of the repository",
+				}
+			}
+			return Result{Status: StatusOK, Detail: fmt.Sprintf("up to date (version %d)", version)}
+		},
+	}
+}
+
+// connect opens a single connection using the same DSN as the application.
+func connect(ctx context.Context, cfg config.DatabaseConfig) (*pgx.Conn, error) {
+	dsn := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		cfg.Host,
+		cfg.Port,
+		cfg.User,
+		cfg.Password,
+		cfg.Name,
+	)
+	if cfg.Schema != "" {
+		dsn += fmt.Sprintf(" search_path=%s", cfg.Schema)
+	}
+	return pgx.Connect(ctx, dsn)
+}
+
+// -------- Network --------
+
+// PortFree checks that a local TCP port can be bound by the application.
+func PortFree(name string, port int, fix string) Check {
+	return Check{
+		Name: fmt.Sprintf("port: %s (%d)", name, port),
+		Run: func(context.Context) Result {
+			l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+			if err != nil {
+				return Result{Status: StatusFail, Detail: err.Error(), Fix: fix}
+			}
+			_ = l.Close()
+			return Result{Status: StatusOK, Detail: "free"}
+		},
+	}
+}
+
+// Telemetry checks the telemetry endpoints. Telemetry is optional for local
+// development, so unreachable endpoints are only reported as warnings.
+func Telemetry(cfg config.TelemetryConfig) []Check {
+	skip := func(detail string) []Check {
+		return []Check{{
+			Name: "telemetry",
+			Run: func(context.Context) Result {
+				return Result{Status: StatusSkip, Detail: detail}
+			},
+		}}
+	}
+	switch {
+	case !cfg.Enabled:
+		return skip("disabled, NoOp metrics and tracer are used")
+	case cfg.Type == "prometheus":
+		return skip("metrics are scraped, NoOp tracer is used")
+	case cfg.Type == "statsd":
+		return skip(cfg.MetricsAddress + " is a UDP (StatsD) address, NoOp tracer is used")
+	case cfg.Type != "otel" && cfg.Type != "datadog":
+		return skip("disabled, NoOp metrics and tracer are used")
+	}
+
+	checks := []Check{endpoint("telemetry: tracer", cfg.TracerAddress)}
+
+	// DogStatsD listens on UDP, which cannot be probed reliably.
+	if cfg.Type == "datadog" {
+		checks = append(checks, Check{
+			Name: "telemetry: metrics",
+			Run: func(context.Context) Result {
+				return Result{Status: StatusSkip, Detail: cfg.MetricsAddress + " is a UDP (DogStatsD) address"}
+			},
+		})
+	} else if cfg.MetricsAddress != cfg.TracerAddress {
+		checks = append(checks, endpoint("telemetry: metrics", cfg.MetricsAddress))
+	}
+	return checks
+}
+
+func endpoint(name, addr string) Check {
+	return Check{
+		Name: name,
+		Run: func(ctx context.Context) Result {
+			var d net.Dialer
+			conn, err := d.DialContext(ctx, "tcp", addr)
+			if err != nil {
+				return Result{
+					Status: StatusWarn,
+					Detail: addr + " unreachable (optional)",
+					Fix:    "start the collector/agent locally or set telemetry.enabled: false in config/config.yaml",
+				}
+			}
+			_ = conn.Close()
+			return Result{Status: StatusOK, Detail: addr + " reachable"}
+		},
+	}
+}
+
+// -------- Assembly --------
+
+// testEnvVars are the variables without usable default needed by the
+// Postgres-backed integration tests (see test/helper).
+var testEnvVars = []string{"TEST_DB_PASSWORD"}
+
+// DefaultChecks builds the checks of `voyago doctor` for the given domains,
+// configured by the file configPath returns. Their example file sits next to
+// it, with the .example.yaml extension. Paths are resolved from the current
+// directory, which must be the repository root.
+func DefaultChecks(globalPath string, domains []string, configPath func(domain string) string, lookup func(string) (string, bool)) []Check {
+	checks := []Check{ConfigFile(".", globalPath, "")}
+
+	configPaths := []string{globalPath}
+	for _, domain := range domains {
+		path := configPath(domain)
+		configPaths = append(configPaths, path)
+		checks = append(checks, ConfigFile(".", path, strings.TrimSuffix(path, ".yaml")+".example.yaml"))
+	}
+
+	checks = append(checks, ConfigEnv(".", configPaths, lookup))
+	for _, name := range testEnvVars {
+		checks = append(checks, RequiredEnv(name, "integration tests cannot connect to the test database", lookup))
+	}
+
+	testDB := TestDatabaseConfig(lookup)
+	checks = append(checks, Database("test", testDB, fmt.Sprintf(
+		"start Postgres and create the test database: createdb -h %s -p %d -U %s %s (override with TEST_DB_* variables)",
+		testDB.Host, testDB.Port, testDB.User, testDB.Name,
+	)))
+
+	// The remaining checks read the configuration, which requires the global file.
+	if _, err := os.Stat(globalPath); err != nil {
+		return checks
+	}
+	globalCfg, err := config.LoadGlobalConfig(globalPath)
+	checks = append(checks, ConfigValid(globalPath, err))
+	if err != nil {
+		return checks
+	}
+
+	for i, domain := range domains {
+		path := configPaths[i+1]
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		domainCfg, err := config.ReadDomainConfig(path)
+		checks = append(checks, ConfigValid(path, err))
+		if err != nil {
+			continue
+		}
+		if driver := domainCfg.Database.Driver; driver != "" && driver != config.DatabaseDriverPostgres {
+			checks = append(checks, Check{
+				Name: "database: " + domain,
+				Run: func(context.Context) Result {
+					return Result{Status: StatusSkip, Detail: "only Postgres databases are checked, not " + driver}
+				},
+			})
+			continue
+		}
+		dbCfg, err := domainCfg.Database.ForConnection()
+		if err != nil {
+			checks = append(checks, Check{
+				Name: "database: " + domain,
+				Run: func(context.Context) Result {
+					return Result{Status: StatusFail, Detail: err.Error(), Fix: fmt.Sprintf(
+						"declare the connection in database.connections of %s or fix the database section of %s", globalPath, path,
+					)}
+				},
+			})
+			continue
+		}
+		checks = append(checks,
+			Database(domain, dbCfg, fmt.Sprintf(
+				"start Postgres at %s:%d or fix the database section of %s",
+				dbCfg.Host, dbCfg.Port, path,
+			)),
+			Migrations(domain, dbCfg, filepath.Join("migrations", domain)),
+		)
+	}
+
+	checks = append(checks,
+		PortFree("http", globalCfg.Http.Port, fmt.Sprintf(
+			"stop the process listening on :%d or change http.port in %s", globalCfg.Http.Port, globalPath,
+		)),
+		PortFree("grpc", globalCfg.Grpc.Port, fmt.Sprintf(
+			"stop the process listening on :%d or change grpc.port in %s", globalCfg.Grpc.Port, globalPath,
+		)),
+	)
+	return append(checks, Telemetry(globalCfg.Telemetry)...)
+}
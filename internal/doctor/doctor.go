@@ -0,0 +1,117 @@
+// Package doctor diagnoses the local development environment (configuration,
+// databases, migrations, ports and telemetry) and suggests actionable fixes.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusOK   Status = "OK"
+	StatusWarn Status = "WARN"
+	StatusFail Status = "FAIL"
+	StatusSkip Status = "SKIP"
+)
+
+// Result is the outcome of a single check.
+type Result struct {
+	Status Status
+	// Detail describes what was found (e.g., "connected", "port 4000 in use").
+	Detail string
+	// Fix is the suggested remediation, shown for WARN and FAIL results.
+	Fix string
+}
+
+// Check is a named diagnostic. Run must honor ctx cancellation.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) Result
+}
+
+// CheckResult pairs a check with its outcome.
+type CheckResult struct {
+	Name string
+	Result
+}
+
+// Report is the outcome of a doctor run.
+type Report struct {
+	Results []CheckResult
+}
+
+// Failed reports whether at least one check failed. Warnings do not fail the run.
+func (r Report) Failed() bool {
+	for _, res := range r.Results {
+		if res.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns how many checks ended with the given status.
+func (r Report) Count(status Status) int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Run executes the checks sequentially, bounding each one by timeout.
+func Run(ctx context.Context, timeout time.Duration, checks []Check) Report {
+	report := Report{Results: make([]CheckResult, 0, len(checks))}
+	for _, c := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		res := c.Run(checkCtx)
+		cancel()
+
+		report.Results = append(report.Results, CheckResult{Name: c.Name, Result: res})
+	}
+	return report
+}
+
+// Print writes a human-readable report to w.
+//
+// Example output:
+//
+//	[ OK ] config: config/config.yaml
+//	[FAIL] port: http (4000) - address already in use
+//	       fix: stop the process listening on :4000 or change http.port in config/config.yaml
+//
+//	4 ok, 0 warning(s), 1 failed, 0 skipped
+func Print(w io.Writer, report Report) {
+	for _, res := range report.Results {
+		line := fmt.Sprintf("[%s] %s", label(res.Status), res.Name)
+		if res.Detail != "" {
+			line += " - " + res.Detail
+		}
+		fmt.Fprintln(w, line)
+
+		if res.Fix != "" && (res.Status == StatusWarn || res.Status == StatusFail) {
+			fmt.Fprintf(w, "       fix: %s\n", res.Fix)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d ok, %d warning(s), %d failed, %d skipped\n",
+		report.Count(StatusOK),
+		report.Count(StatusWarn),
+		report.Count(StatusFail),
+		report.Count(StatusSkip),
+	)
+}
+
+// label pads a status to a fixed width so that check names line up.
+func label(s Status) string {
+	if len(s) == 2 {
+		return " " + string(s) + " "
+	}
+	return string(s)
+}
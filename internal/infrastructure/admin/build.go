@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// BuildInfo describes the running binary.
+type BuildInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Env     string `json:"env"`
+	// GoVersion is the toolchain that built the binary.
+	GoVersion string `json:"go_version"`
+	// Revision, CommitTime and Modified come from the VCS stamped by go
+	// build; they are empty for binaries built outside of a checkout.
+	Revision   string    `json:"revision,omitempty"`
+	CommitTime time.Time `json:"commit_time,omitzero"`
+	Modified   bool      `json:"modified"`
+	StartedAt  time.Time `json:"started_at"`
+	// Uptime is in seconds.
+	Uptime int64 `json:"uptime"`
+}
+
+// readBuildInfo fills the toolchain and VCS fields of info.
+func readBuildInfo(info BuildInfo) BuildInfo {
+	info.GoVersion = runtime.Version()
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Revision = s.Value
+		case "vcs.time":
+			info.CommitTime, _ = time.Parse(time.RFC3339, s.Value)
+		case "vcs.modified":
+			info.Modified = s.Value == "true"
+		}
+	}
+	return info
+}
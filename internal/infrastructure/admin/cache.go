@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"context"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// flushBatch is how many keys are scanned and deleted per round trip.
+const flushBatch = 500
+
+// Cache is emptied by the cache flush route.
+type Cache interface {
+	// Flush deletes the cached entries and returns how many were deleted.
+	Flush(ctx context.Context) (int, error)
+}
+
+type redisCache struct {
+	client   redis.Cmdable
+	prefixes []string
+}
+
+// NewRedisCache returns a Cache holding the Redis keys that start with one of
+// prefixes. Keys are scanned and unlinked in batches: Redis keeps serving
+// other clients during a flush.
+func NewRedisCache(client redis.Cmdable, prefixes []string) Cache {
+	return &redisCache{client: client, prefixes: prefixes}
+}
+
+func (r *redisCache) Flush(ctx context.Context) (int, error) {
+	deleted := 0
+	for _, prefix := range r.prefixes {
+		if prefix == "" {
+			// An empty prefix would flush the whole database.
+			continue
+		}
+		iter := r.client.Scan(ctx, 0, escapePattern(prefix)+"*", flushBatch).Iterator()
+		keys := make([]string, 0, flushBatch)
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+			if len(keys) == flushBatch {
+				n, err := r.client.Unlink(ctx, keys...).Result()
+				deleted += int(n)
+				if err != nil {
+					return deleted, err
+				}
+				keys = keys[:0]
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return deleted, err
+		}
+		if len(keys) > 0 {
+			n, err := r.client.Unlink(ctx, keys...).Result()
+			deleted += int(n)
+			if err != nil {
+				return deleted, err
+			}
+		}
+	}
+	return deleted, nil
+}
+
+// escapePattern escapes the glob characters of a SCAN pattern.
+func escapePattern(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
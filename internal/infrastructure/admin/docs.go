@@ -0,0 +1,128 @@
+package admin
+
+import (
+	"voyago/core-api/internal/infrastructure/maintenance"
+	"voyago/core-api/internal/infrastructure/openapi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const authDescription = "Requires the admin token (`Authorization: Bearer <token>`)."
+
+func operations(path string) []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method:      fiber.MethodGet,
+			Path:        path + "/log-level",
+			Summary:     "Get the log levels",
+			Description: authDescription,
+			Tags:        []string{"admin"},
+			Response:    []LogLevel{},
+			Errors:      []int{fiber.StatusUnauthorized},
+		},
+		{
+			Method:      fiber.MethodPut,
+			Path:        path + "/log-level",
+			Summary:     "Change the log level",
+			Description: authDescription + " Applies without restart, only on the instance receiving the request; every logger is changed when `logger` is empty.",
+			Tags:        []string{"admin"},
+			Request:     SetLogLevelRequest{},
+			Response:    []LogLevel{},
+			Errors:      []int{fiber.StatusBadRequest, fiber.StatusUnauthorized},
+		},
+		{
+			Method:      fiber.MethodGet,
+			Path:        path + "/config",
+			Summary:     "Get the configuration",
+			Description: authDescription + " The effective configuration, merged from the files, their profile and the command line overrides, with the sections of the modules and where each was read from. Passwords, tokens and secrets are redacted.",
+			Tags:        []string{"admin"},
+			Response:    ConfigDump{},
+			Errors:      []int{fiber.StatusUnauthorized},
+		},
+		{
+			Method:      fiber.MethodGet,
+			Path:        path + "/build",
+			Summary:     "Get the build information",
+			Description: authDescription,
+			Tags:        []string{"admin"},
+			Response:    BuildInfo{},
+			Errors:      []int{fiber.StatusUnauthorized},
+		},
+		{
+			Method:      fiber.MethodGet,
+			Path:        path + "/runtime",
+			Summary:     "Get the runtime statistics",
+			Description: authDescription + " Goroutines, heap and garbage collector of the instance receiving the request.",
+			Tags:        []string{"admin"},
+			Response:    RuntimeStats{},
+			Errors:      []int{fiber.StatusUnauthorized},
+		},
+	}
+}
+
+func maintenanceOperations(path string) []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method:      fiber.MethodGet,
+			Path:        path + "/maintenance",
+			Summary:     "Get the maintenance mode",
+			Description: authDescription,
+			Tags:        []string{"admin"},
+			Response:    maintenance.State{},
+			Errors:      []int{fiber.StatusUnauthorized},
+		},
+		{
+			Method:      fiber.MethodPut,
+			Path:        path + "/maintenance",
+			Summary:     "Toggle the maintenance mode",
+			Description: authDescription + " Applies without restart; with the memory store, only on the instance receiving the request.",
+			Tags:        []string{"admin"},
+			Request:     SetMaintenanceRequest{},
+			Response:    maintenance.State{},
+			Errors:      []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusServiceUnavailable},
+		},
+	}
+}
+
+func cacheOperations(path string) []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method:      fiber.MethodPost,
+			Path:        path + "/cache/flush",
+			Summary:     "Flush the cache",
+			Description: authDescription + " Deletes the Redis keys under the configured `admin.cache_prefixes`.",
+			Tags:        []string{"admin"},
+			Response:    FlushCacheResponse{},
+			Errors:      []int{fiber.StatusUnauthorized, fiber.StatusServiceUnavailable},
+		},
+	}
+}
+
+func auditOperations(path string) []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method:      fiber.MethodGet,
+			Path:        path + "/audit",
+			Summary:     "Get the audit log",
+			Description: authDescription + " Changes made by the command use cases (who, what, when, before, after), newest first; sensitive values are masked.",
+			Tags:        []string{"admin"},
+			Query:       AuditQuery{},
+			Response:    []AuditEntry{},
+			Errors:      []int{fiber.StatusBadRequest, fiber.StatusUnauthorized},
+		},
+	}
+}
+
+func pprofOperations(path string) []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method:      fiber.MethodGet,
+			Path:        path + PprofPath + "/",
+			Summary:     "List the runtime profiles",
+			Description: authDescription + " Index of the net/http/pprof profiles (`profile`, `heap`, `goroutine`, `block`, `mutex`, `allocs`, `trace`...), read with `go tool pprof`.",
+			Tags:        []string{"admin"},
+			ContentType: fiber.MIMETextHTMLCharsetUTF8,
+			Errors:      []int{fiber.StatusUnauthorized},
+		},
+	}
+}
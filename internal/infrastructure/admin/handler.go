@@ -0,0 +1,290 @@
+package admin
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/audit"
+	"voyago/core-api/internal/pkg/bind"
+	"voyago/core-api/internal/pkg/response"
+	"voyago/core-api/internal/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LogLevel is the level of a logger.
+type LogLevel struct {
+	Logger string `json:"logger"`
+	Level  string `json:"level"`
+}
+
+// SetLogLevelRequest is the body of PUT /log-level.
+type SetLogLevelRequest struct {
+	// Level is one of trace, debug, info, warn and error.
+	Level string `json:"level"`
+	// Logger is the logger to change, every logger when empty.
+	Logger string `json:"logger"`
+}
+
+// SetMaintenanceRequest is the body of PUT /maintenance.
+type SetMaintenanceRequest struct {
+	Enabled *bool  `json:"enabled"`
+	Message string `json:"message"`
+}
+
+// FlushCacheResponse reports a cache flush.
+type FlushCacheResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// ConfigDump is the configuration in use, as merged from the files, their
+// profile and the overrides, sensitive values redacted (see utils.MaskConfig).
+type ConfigDump struct {
+	Global  any            `json:"global"`
+	Domains map[string]any `json:"domains"`
+	// Sources are where each configuration was read from, the global one
+	// under MainLogger: "config/config.yaml + config/config.production.yaml".
+	Sources map[string]string `json:"sources"`
+	// Overrides are the paths of the settings replaced by the command line
+	// (--set, --port, see config.SetOverrides).
+	Overrides []string `json:"overrides"`
+}
+
+// AuditQuery filters GET /audit; empty fields match every entry.
+type AuditQuery struct {
+	// Domain is the module whose audit log is read, every module when empty.
+	Domain     string `query:"domain"`
+	Actor      string `query:"actor"`
+	TenantID   string `query:"tenant_id"`
+	Action     string `query:"action"`
+	EntityType string `query:"entity_type"`
+	EntityID   string `query:"entity_id"`
+	// Since and Until bound the creation time, in Unix milliseconds.
+	Since int64 `query:"since"`
+	Until int64 `query:"until"`
+	Limit int   `query:"limit"`
+}
+
+// AuditEntry is an audit entry of a module.
+type AuditEntry struct {
+	Domain string `json:"domain"`
+	audit.Entry
+}
+
+type handler struct {
+	cfg      HttpModuleConfig
+	log      logger.Logger
+	levelers map[string]logger.Leveler
+	build    BuildInfo
+}
+
+func newHandler(cfg HttpModuleConfig) *handler {
+	levelers := make(map[string]logger.Leveler, len(cfg.Loggers))
+	for name, l := range cfg.Loggers {
+		if leveler, ok := l.(logger.Leveler); ok {
+			levelers[name] = leveler
+		}
+	}
+
+	return &handler{
+		cfg:      cfg,
+		log:      cfg.Log.WithField("component", "admin"),
+		levelers: levelers,
+		build: readBuildInfo(BuildInfo{
+			Name:      cfg.Config.App.Name,
+			Version:   cfg.Config.App.Version,
+			Env:       cfg.Config.App.Env,
+			StartedAt: cfg.StartedAt,
+		}),
+	}
+}
+
+func (h *handler) GetLogLevels(c *fiber.Ctx) error {
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Log levels retrieved successfully",
+		Data:    h.levels(slices.Sorted(maps.Keys(h.levelers))),
+	})
+}
+
+func (h *handler) SetLogLevel(c *fiber.Ctx) error {
+	request := new(SetLogLevelRequest)
+	if err := bind.Request(c, request); err != nil {
+		return err
+	}
+
+	names := slices.Sorted(maps.Keys(h.levelers))
+	if request.Logger != "" {
+		if _, ok := h.levelers[request.Logger]; !ok {
+			return apperror.NewPersistance(apperror.CodeValidation, "Validation error").
+				AddValidationError("logger", "logger must be one of "+strings.Join(names, ", "))
+		}
+		names = []string{request.Logger}
+	}
+
+	for _, name := range names {
+		if err := h.levelers[name].SetLevel(request.Level); err != nil {
+			return apperror.NewPersistance(apperror.CodeValidation, "Validation error").
+				AddValidationError("level", "level must be one of trace, debug, info, warn, error")
+		}
+	}
+
+	h.log.WithFields(map[string]any{
+		"loggers": names,
+		"level":   request.Level,
+	}).Warn("Log level changed")
+
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Log level updated successfully",
+		Data:    h.levels(names),
+	})
+}
+
+func (h *handler) levels(names []string) []LogLevel {
+	out := make([]LogLevel, 0, len(names))
+	for _, name := range names {
+		out = append(out, LogLevel{Logger: name, Level: h.levelers[name].Level()})
+	}
+	return out
+}
+
+func (h *handler) GetMaintenance(c *fiber.Ctx) error {
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Maintenance mode retrieved successfully",
+		Data:    h.cfg.Maintenance.State(c.UserContext()),
+	})
+}
+
+func (h *handler) SetMaintenance(c *fiber.Ctx) error {
+	request := new(SetMaintenanceRequest)
+	if err := bind.Request(c, request); err != nil {
+		return err
+	}
+	if request.Enabled == nil {
+		return apperror.NewPersistance(apperror.CodeValidation, "Validation error").
+			AddValidationError("enabled", "enabled is required")
+	}
+
+	state, err := h.cfg.Maintenance.Set(c.UserContext(), *request.Enabled, request.Message)
+	if err != nil {
+		return apperror.NewTransient(apperror.CodeServiceUnavailable, "maintenance state unavailable", err)
+	}
+
+	h.log.WithField("enabled", state.Enabled).Warn("Maintenance mode changed")
+
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Maintenance mode updated successfully",
+		Data:    state,
+	})
+}
+
+func (h *handler) FlushCache(c *fiber.Ctx) error {
+	deleted, err := h.cfg.Cache.Flush(c.UserContext())
+	if err != nil {
+		return apperror.NewTransient(apperror.CodeServiceUnavailable, "cache unavailable", err)
+	}
+
+	h.log.WithField("deleted", deleted).Warn("Cache flushed")
+
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Cache flushed successfully",
+		Data:    FlushCacheResponse{Deleted: deleted},
+	})
+}
+
+func (h *handler) GetAudit(c *fiber.Ctx) error {
+	query := new(AuditQuery)
+	if err := bind.Request(c, query); err != nil {
+		return err
+	}
+
+	domains := slices.Sorted(maps.Keys(h.cfg.Audit))
+	if query.Domain != "" {
+		if _, ok := h.cfg.Audit[query.Domain]; !ok {
+			return apperror.NewPersistance(apperror.CodeValidation, "Validation error").
+				AddValidationError("domain", "domain must be one of "+strings.Join(domains, ", "))
+		}
+		domains = []string{query.Domain}
+	}
+	if query.Limit < 0 || query.Limit > audit.MaxLimit {
+		return apperror.NewPersistance(apperror.CodeValidation, "Validation error").
+			AddValidationError("limit", fmt.Sprintf("limit must be between 1 and %d", audit.MaxLimit))
+	}
+	filter := audit.Filter{
+		Actor:      query.Actor,
+		TenantID:   query.TenantID,
+		Action:     query.Action,
+		EntityType: query.EntityType,
+		EntityID:   query.EntityID,
+		Since:      query.Since,
+		Until:      query.Until,
+		Limit:      query.Limit,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = audit.DefaultLimit
+	}
+
+	// Each domain returns its newest entries: the newest of them all are kept.
+	entries := make([]AuditEntry, 0, filter.Limit)
+	for _, domain := range domains {
+		found, err := h.cfg.Audit[domain].Find(c.UserContext(), filter)
+		if err != nil {
+			return err
+		}
+		for _, e := range found {
+			entries = append(entries, AuditEntry{Domain: domain, Entry: e})
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b AuditEntry) int {
+		return cmp.Compare(b.CreatedAt, a.CreatedAt)
+	})
+	if len(entries) > filter.Limit {
+		entries = entries[:filter.Limit]
+	}
+
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Audit entries retrieved successfully",
+		Data:    entries,
+	})
+}
+
+func (h *handler) GetConfig(c *fiber.Ctx) error {
+	domains := make(map[string]any, len(h.cfg.Configs))
+	sources := map[string]string{MainLogger: h.cfg.Config.Source()}
+	for name, cfg := range h.cfg.Configs {
+		domains[name] = utils.MaskConfig(cfg)
+		sources[name] = cfg.Source()
+	}
+
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Configuration retrieved successfully",
+		Data: ConfigDump{
+			Global:    utils.MaskConfig(h.cfg.Config),
+			Domains:   domains,
+			Sources:   sources,
+			Overrides: config.Overridden(),
+		},
+	})
+}
+
+func (h *handler) GetBuild(c *fiber.Ctx) error {
+	info := h.build
+	info.Uptime = int64(time.Since(info.StartedAt).Seconds())
+
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Build information retrieved successfully",
+		Data:    info,
+	})
+}
+
+func (h *handler) GetRuntime(c *fiber.Ctx) error {
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Runtime statistics retrieved successfully",
+		Data:    readRuntimeStats(),
+	})
+}
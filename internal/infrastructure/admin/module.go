@@ -0,0 +1,125 @@
+// Package admin mounts the operational routes of the API under a protected
+// group (/admin by default): runtime log level, maintenance mode, cache
+// flush, audit log, masked configuration, build information, runtime
+// statistics and, when enabled, the pprof profiles. Every route requires
+// "Authorization: Bearer <admin.token>"; nothing is mounted without a token:
+//
+//	admin.RegisterHttpModule(admin.HttpModuleConfig{
+//		Config:      cfg,
+//		App:         app,
+//		Loggers:     map[string]logger.Logger{admin.MainLogger: log},
+//		Maintenance: mode,
+//	})
+//
+// Changes made through these routes apply to the instance receiving them,
+// except the maintenance mode with its Redis store.
+package admin
+
+import (
+	"crypto/subtle"
+	"strings"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/maintenance"
+	"voyago/core-api/internal/infrastructure/openapi"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/audit"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/pprof"
+)
+
+const (
+	// DefaultPath is the prefix of the admin routes when none is configured.
+	DefaultPath = "/admin"
+	// MainLogger names the global logger in the log level routes; the domain
+	// loggers are named after their domain.
+	MainLogger = "main"
+)
+
+var ErrInvalidToken = apperror.NewPersistance(apperror.CodeUnauthorized, "invalid admin token")
+
+type HttpModuleConfig struct {
+	// Config is the global configuration, dumped by GET /config.
+	Config *config.Config
+	App    fiber.Router
+	Log    logger.Logger
+	Docs   *openapi.Spec
+	// Configs are the domain configurations, dumped next to the global one.
+	Configs map[string]*config.Config
+	// Loggers are the loggers whose level can be changed, by name. Loggers
+	// that do not implement logger.Leveler are left out.
+	Loggers map[string]logger.Logger
+	// Maintenance is toggled by /maintenance, not mounted when nil.
+	Maintenance *maintenance.Mode
+	// Cache is emptied by /cache/flush, not mounted when nil.
+	Cache Cache
+	// Audit are the audit logs of the domains, read by /audit, by domain;
+	// /audit is not mounted when empty.
+	Audit map[string]audit.Reader
+	// StartedAt is reported by /build, the registration time when zero.
+	StartedAt time.Time
+}
+
+// Path returns the prefix of the admin routes.
+func Path(cfg config.AdminConfig) string {
+	if cfg.Path == "" {
+		return DefaultPath
+	}
+	return strings.TrimSuffix(cfg.Path, "/")
+}
+
+// RegisterHttpModule mounts the admin routes when an admin token is
+// configured.
+func RegisterHttpModule(cfg HttpModuleConfig) {
+	if cfg.Config == nil || cfg.Config.Admin.Token == "" {
+		return
+	}
+	if cfg.StartedAt.IsZero() {
+		cfg.StartedAt = time.Now()
+	}
+
+	h := newHandler(cfg)
+	path := Path(cfg.Config.Admin)
+	group := cfg.App.Group(path, authenticate(cfg.Config.Admin.Token))
+
+	group.Get("/log-level", h.GetLogLevels)
+	group.Put("/log-level", h.SetLogLevel)
+	group.Get("/config", h.GetConfig)
+	group.Get("/build", h.GetBuild)
+	group.Get("/runtime", h.GetRuntime)
+	docs := operations(path)
+	if cfg.Maintenance != nil {
+		group.Get("/maintenance", h.GetMaintenance)
+		group.Put("/maintenance", h.SetMaintenance)
+		docs = append(docs, maintenanceOperations(path)...)
+	}
+	if cfg.Cache != nil {
+		group.Post("/cache/flush", h.FlushCache)
+		docs = append(docs, cacheOperations(path)...)
+	}
+	if len(cfg.Audit) > 0 {
+		group.Get("/audit", h.GetAudit)
+		docs = append(docs, auditOperations(path)...)
+	}
+	if pprofCfg := cfg.Config.Admin.Pprof; pprofCfg.Enabled && pprofCfg.Address == "" {
+		group.Use(pprof.New(pprof.Config{Prefix: path}))
+		docs = append(docs, pprofOperations(path)...)
+	}
+	cfg.Docs.Add(docs...)
+}
+
+// authenticate checks the admin token of every request of the group.
+func authenticate(token string) fiber.Handler {
+	expected := []byte(token)
+	return func(c *fiber.Ctx) error {
+		bearer, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(bearer), expected) != 1 {
+			return ErrInvalidToken
+		}
+		// The answers reflect the runtime state: never serve them from a cache.
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		return c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+)
+
+// PprofPath is where the profiles are served, under the admin routes or on
+// the pprof listener.
+const PprofPath = "/debug/pprof"
+
+// ConfigureProfiling sets the sampling of the block and mutex profiles, which
+// record nothing by default.
+func ConfigureProfiling(cfg config.AdminPprofConfig) {
+	runtime.SetBlockProfileRate(cfg.BlockProfileRate)
+	runtime.SetMutexProfileFraction(cfg.MutexProfileFraction)
+}
+
+// PprofServer serves the profiles on a separate listener, for deployments
+// keeping them off the public port.
+type PprofServer struct {
+	srv *http.Server
+	log logger.Logger
+}
+
+// NewPprofServer creates a PprofServer listening on address.
+func NewPprofServer(address string, log logger.Logger) *PprofServer {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PprofPath+"/", pprof.Index)
+	mux.HandleFunc(PprofPath+"/cmdline", pprof.Cmdline)
+	mux.HandleFunc(PprofPath+"/profile", pprof.Profile)
+	mux.HandleFunc(PprofPath+"/symbol", pprof.Symbol)
+	mux.HandleFunc(PprofPath+"/trace", pprof.Trace)
+
+	return &PprofServer{
+		// No write timeout: CPU profiles and traces stream for their
+		// requested duration.
+		srv: &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		log: log.WithField("component", "pprof"),
+	}
+}
+
+// Start listens until Stop is called.
+func (s *PprofServer) Start() error {
+	s.log.Info(fmt.Sprintf("pprof server listening on %s", s.srv.Addr))
+	if err := s.srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Stop closes the listener, waiting for the profiles being served until ctx
+// is done.
+func (s *PprofServer) Stop(ctx context.Context) error {
+	if err := s.srv.Shutdown(ctx); err != nil {
+		// A long CPU profile is not worth delaying the shutdown.
+		return s.srv.Close()
+	}
+	return nil
+}
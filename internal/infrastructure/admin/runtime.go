@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"runtime"
+	"time"
+)
+
+// RuntimeStats is a snapshot of the Go runtime of the instance.
+type RuntimeStats struct {
+	Goroutines int `json:"goroutines"`
+	GOMAXPROCS int `json:"gomaxprocs"`
+	NumCPU     int `json:"num_cpu"`
+	// Memory figures are in bytes.
+	HeapAlloc   uint64 `json:"heap_alloc"`
+	HeapInuse   uint64 `json:"heap_inuse"`
+	HeapObjects uint64 `json:"heap_objects"`
+	StackInuse  uint64 `json:"stack_inuse"`
+	Sys         uint64 `json:"sys"`
+	NumGC       uint32 `json:"num_gc"`
+	// GCPauseTotal is in milliseconds.
+	GCPauseTotal float64   `json:"gc_pause_total"`
+	LastGC       time.Time `json:"last_gc,omitzero"`
+}
+
+// readRuntimeStats reads the runtime statistics. It briefly stops the world,
+// as runtime.ReadMemStats does.
+func readRuntimeStats() RuntimeStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := RuntimeStats{
+		Goroutines:   runtime.NumGoroutine(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		NumCPU:       runtime.NumCPU(),
+		HeapAlloc:    mem.HeapAlloc,
+		HeapInuse:    mem.HeapInuse,
+		HeapObjects:  mem.HeapObjects,
+		StackInuse:   mem.StackInuse,
+		Sys:          mem.Sys,
+		NumGC:        mem.NumGC,
+		GCPauseTotal: float64(mem.PauseTotalNs) / float64(time.Millisecond),
+	}
+	if mem.LastGC > 0 {
+		stats.LastGC = time.Unix(0, int64(mem.LastGC))
+	}
+	return stats
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+)
+
+// ErrMiss is returned by Get when the key is not cached or expired.
+var ErrMiss = errors.New("cache: miss")
+
+// Cache stores values by key for a TTL. The memory and Redis caches are
+// interchangeable: the values are bytes, encode them (see Fetch).
+type Cache interface {
+	// Get returns the value of key, ErrMiss when absent.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores value under key for ttl; a ttl of 0 keeps it until it is
+	// deleted or evicted.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes keys, absent ones being ignored.
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// New returns the cache of cfg. r is used by the redis driver only.
+func New(cfg *config.CacheConfig, r *Redis) (Cache, error) {
+	switch cfg.Driver {
+	case config.CacheDriverMemory, "":
+		return NewMemory(cfg.MaxEntries), nil
+	case config.CacheDriverRedis:
+		if r == nil {
+			return nil, errors.New("the redis driver needs a Redis client")
+		}
+		return NewRedisCache(r.Client(), cfg.Prefix), nil
+	default:
+		return nil, fmt.Errorf("unknown driver %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Loader reads through a Cache: a missing key is loaded once for all the
+// concurrent callers asking for it (e.g., the first requests after the
+// category tree expired run a single query), then cached.
+type Loader struct {
+	cache Cache
+	group singleflight.Group
+}
+
+func NewLoader(c Cache) *Loader {
+	return &Loader{cache: c}
+}
+
+// Get returns the value of key, calling load on a miss and caching its value
+// for ttl. The cache being unavailable degrades to calling load: its errors
+// are not returned. A caller whose ctx is done stops waiting, the load goes
+// on for the other callers.
+func (l *Loader) Get(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if v, err := l.cache.Get(ctx, key); err == nil {
+		return v, nil
+	}
+
+	ch := l.group.DoChan(key, func() (any, error) {
+		// Detached from the first caller, whose cancellation would fail the
+		// others.
+		loadCtx := context.WithoutCancel(ctx)
+		v, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		_ = l.cache.Set(loadCtx, key, v, ttl)
+		return v, nil
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.([]byte), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Delete removes keys from the cache, e.g., once the data they hold changed.
+func (l *Loader) Delete(ctx context.Context, keys ...string) error {
+	return l.cache.Delete(ctx, keys...)
+}
+
+// Fetch is Get for a value of type T, cached as JSON. An entry that does not
+// decode (e.g., cached by an older release with another shape) is loaded and
+// cached again.
+func Fetch[T any](ctx context.Context, l *Loader, key string, ttl time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	var out T
+	raw, err := l.Get(ctx, key, ttl, func(ctx context.Context) ([]byte, error) {
+		v, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(v)
+	})
+	if err != nil {
+		return out, err
+	}
+	if err := json.Unmarshal(raw, &out); err == nil {
+		return out, nil
+	}
+
+	v, err := load(ctx)
+	if err != nil {
+		return v, err
+	}
+	if raw, err := json.Marshal(v); err == nil {
+		_ = l.cache.Set(ctx, key, raw, ttl)
+	}
+	return v, nil
+}
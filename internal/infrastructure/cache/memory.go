@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultMaxEntries = 10000
+
+// Memory is a process-local LRU cache: once full, storing a key evicts the
+// least recently used one. Each instance has its own entries, so a value
+// deleted on one instance may still be served by the others until it
+// expires: keep the TTLs short for data that changes.
+type Memory struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List // of *entry, most recently used first
+	entries    map[string]*list.Element
+	now        func() time.Time
+}
+
+type entry struct {
+	key     string
+	value   []byte
+	expires time.Time // zero when the entry does not expire
+}
+
+// NewMemory returns a Memory cache holding up to maxEntries (default 10000).
+func NewMemory(maxEntries int) *Memory {
+	return newMemory(maxEntries, time.Now)
+}
+
+func newMemory(maxEntries int, now func() time.Time) *Memory {
+	if maxEntries <= 0 {
+		maxEntries = defaultMaxEntries
+	}
+	return &Memory{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		now:        now,
+	}
+}
+
+func (m *Memory) Get(_ context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+	e := el.Value.(*entry)
+	if !e.expires.IsZero() && !m.now().Before(e.expires) {
+		m.remove(el)
+		return nil, ErrMiss
+	}
+	m.order.MoveToFront(el)
+	return bytes.Clone(e.value), nil
+}
+
+func (m *Memory) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	var expires time.Time
+	if ttl > 0 {
+		expires = m.now().Add(ttl)
+	}
+	e := &entry{key: key, value: bytes.Clone(value), expires: expires}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		el.Value = e
+		m.order.MoveToFront(el)
+		return nil
+	}
+	m.entries[key] = m.order.PushFront(e)
+	for m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+	return nil
+}
+
+func (m *Memory) Delete(_ context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := m.entries[key]; ok {
+			m.remove(el)
+		}
+	}
+	return nil
+}
+
+// Flush deletes every entry and returns how many were held, expired ones
+// included. It makes Memory an admin.Cache.
+func (m *Memory) Flush(context.Context) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := m.order.Len()
+	m.order.Init()
+	clear(m.entries)
+	return n, nil
+}
+
+// Len returns the number of entries, expired ones included until they are
+// read or evicted.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+func (m *Memory) remove(el *list.Element) {
+	m.order.Remove(el)
+	delete(m.entries, el.Value.(*entry).key)
+}
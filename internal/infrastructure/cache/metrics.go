@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+)
+
+const (
+	defaultStatsInterval = 15 * time.Second
+
+	metricPoolTotal        = "redis_pool_total_connections"
+	metricPoolIdle         = "redis_pool_idle_connections"
+	metricPoolStale        = "redis_pool_stale_connections"
+	metricPoolHits         = "redis_pool_hits"
+	metricPoolMisses       = "redis_pool_misses"
+	metricPoolTimeouts     = "redis_pool_timeouts"
+	metricPoolWaitCount    = "redis_pool_wait_count"
+	metricPoolWaitDuration = "redis_pool_wait_duration_seconds"
+)
+
+// PoolReporter records the connection pool statistics of a Redis client as
+// gauges tagged with its mode. Timeouts growing mean the pool is too small
+// for the load, or Redis too slow to answer.
+type PoolReporter struct {
+	redis    *Redis
+	tags     []string
+	metrics  metrics.Metrics
+	interval time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// NewPoolReporter creates a PoolReporter recording every interval (default
+// 15s).
+func NewPoolReporter(r *Redis, m metrics.Metrics, interval time.Duration) *PoolReporter {
+	if interval <= 0 {
+		interval = defaultStatsInterval
+	}
+	return &PoolReporter{
+		redis:    r,
+		tags:     []string{"mode:" + r.Mode()},
+		metrics:  m,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start records the statistics until Stop is called.
+func (p *PoolReporter) Start() {
+	go func() {
+		defer close(p.done)
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		p.Report()
+		for {
+			select {
+			case <-ticker.C:
+				p.Report()
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the recording started by Start.
+func (p *PoolReporter) Stop() {
+	p.once.Do(func() {
+		close(p.stop)
+		<-p.done
+	})
+}
+
+// Report records the current statistics, summed over the nodes in cluster
+// mode. The hits, misses, timeouts and waits are cumulative since the client
+// was created.
+func (p *PoolReporter) Report() {
+	s := p.redis.Client().PoolStats()
+	p.metrics.Gauge(metricPoolTotal, float64(s.TotalConns), p.tags)
+	p.metrics.Gauge(metricPoolIdle, float64(s.IdleConns), p.tags)
+	p.metrics.Gauge(metricPoolStale, float64(s.StaleConns), p.tags)
+	p.metrics.Gauge(metricPoolHits, float64(s.Hits), p.tags)
+	p.metrics.Gauge(metricPoolMisses, float64(s.Misses), p.tags)
+	p.metrics.Gauge(metricPoolTimeouts, float64(s.Timeouts), p.tags)
+	p.metrics.Gauge(metricPoolWaitCount, float64(s.WaitCount), p.tags)
+	p.metrics.Gauge(metricPoolWaitDuration, time.Duration(s.WaitDurationNs).Seconds(), p.tags)
+}
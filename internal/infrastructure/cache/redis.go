@@ -0,0 +1,112 @@
+// Package cache provides the Redis client shared by the features keeping
+// state outside the process (rate limiting, maintenance mode, locks,
+// sessions), and the Cache of the hot reads, kept in memory or in Redis
+// (see Loader).
+//
+// The client connects to a standalone server, to a master through its
+// sentinels or to a cluster (config.RedisConfig.Mode), traces its commands
+// and records its connection pool (see PoolReporter).
+package cache
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis is a Redis client. Client is accepted wherever the features expect a
+// redis.Cmdable or redis.Scripter.
+type Redis struct {
+	client redis.UniversalClient
+	mode   string
+}
+
+// NewRedis creates the client of cfg, tracing its commands with trc (nil
+// disables tracing). It does not connect: see Ping. The client must be
+// closed.
+func NewRedis(cfg *config.RedisConfig, trc tracer.Tracer) (*Redis, error) {
+	opts, err := universalOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	client := redis.NewUniversalClient(opts)
+	if trc != nil {
+		client.AddHook(tracingHook{tracer: trc})
+	}
+	mode := cfg.Mode
+	if mode == "" {
+		mode = config.RedisModeStandalone
+	}
+	return &Redis{client: client, mode: mode}, nil
+}
+
+// universalOptions maps cfg to the options of the client of its mode.
+func universalOptions(cfg *config.RedisConfig) (*redis.UniversalOptions, error) {
+	opts := &redis.UniversalOptions{
+		Username:     cfg.Username,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.Pool.Size,
+		MinIdleConns: cfg.Pool.MinIdle,
+		PoolTimeout:  time.Duration(cfg.Pool.Timeout) * time.Millisecond,
+		DialTimeout:  time.Duration(cfg.Timeouts.Dial) * time.Millisecond,
+		ReadTimeout:  time.Duration(cfg.Timeouts.Read) * time.Millisecond,
+		WriteTimeout: time.Duration(cfg.Timeouts.Write) * time.Millisecond,
+	}
+
+	switch cfg.Mode {
+	case config.RedisModeStandalone, "":
+		opts.Addrs = []string{net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))}
+	case config.RedisModeSentinel:
+		if len(cfg.Addrs) == 0 || cfg.MasterName == "" {
+			return nil, fmt.Errorf("sentinel mode needs the addrs of the sentinels and the master_name")
+		}
+		opts.Addrs = cfg.Addrs
+		opts.MasterName = cfg.MasterName
+		opts.SentinelPassword = cfg.SentinelPassword
+	case config.RedisModeCluster:
+		if len(cfg.Addrs) == 0 {
+			return nil, fmt.Errorf("cluster mode needs the addrs of cluster nodes")
+		}
+		if cfg.DB != 0 {
+			return nil, fmt.Errorf("cluster mode only has the database 0, got db %d", cfg.DB)
+		}
+		opts.Addrs = cfg.Addrs
+		opts.IsClusterMode = true
+	default:
+		return nil, fmt.Errorf("unknown mode %q", cfg.Mode)
+	}
+	return opts, nil
+}
+
+// Client returns the underlying client.
+func (r *Redis) Client() redis.UniversalClient {
+	return r.client
+}
+
+// Mode returns the mode of the client: standalone, sentinel or cluster.
+func (r *Redis) Mode() string {
+	return r.mode
+}
+
+// Ping checks that Redis answers, connecting when no connection is open. In
+// cluster mode, every master is checked.
+func (r *Redis) Ping(ctx context.Context) error {
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return node.Ping(ctx).Err()
+		})
+	}
+	return r.client.Ping(ctx).Err()
+}
+
+// Close closes the connections. The commands in flight fail.
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisCache struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewRedisCache returns a Cache storing the values in Redis under prefix,
+// shared by every instance. List the prefix in admin.cache_prefixes to flush
+// it from the admin routes.
+func NewRedisCache(client redis.Cmdable, prefix string) Cache {
+	return &redisCache{client: client, prefix: prefix}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	v, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrMiss
+	}
+	return v, err
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, c.prefix+key, value, ttl).Err()
+}
+
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+	return c.client.Del(ctx, prefixed...).Err()
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tracingHook starts a span per command ("redis get") and per pipeline
+// ("redis pipeline"). The arguments are not recorded: keys and values may
+// hold personal data or secrets.
+type tracingHook struct {
+	tracer tracer.Tracer
+}
+
+func (h tracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h tracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		span, ctx := h.tracer.StartSpan(ctx, "redis "+cmd.Name())
+		defer span.Finish()
+		span.SetTag("db.system", "redis")
+		span.SetTag("db.operation", cmd.Name())
+
+		err := next(ctx, cmd)
+		recordError(span, err)
+		return err
+	}
+}
+
+func (h tracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		span, ctx := h.tracer.StartSpan(ctx, "redis pipeline")
+		defer span.Finish()
+		span.SetTag("db.system", "redis")
+		span.SetTag("db.operation", "pipeline")
+		span.SetTag("db.redis.pipeline_length", len(cmds))
+
+		err := next(ctx, cmds)
+		recordError(span, err)
+		return err
+	}
+}
+
+// recordError marks span as failed, unless err is nil or redis.Nil (a
+// missing key is not a failure).
+func recordError(span tracer.Span, err error) {
+	if err != nil && !errors.Is(err, redis.Nil) {
+		span.RecordError(err)
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+type AdminConfig struct {
+	Path string `mapstructure:"path"` // prefix of the admin route group (default "/admin")
+	// Token authenticates the admin routes (Authorization: Bearer <token>);
+	// the group is not mounted without it.
+	Token string `mapstructure:"token"`
+	// CachePrefixes are the Redis key prefixes deleted by the cache flush
+	// endpoint (uses the redis section); the endpoint is not mounted without
+	// them.
+	CachePrefixes []string `mapstructure:"cache_prefixes"`
+
+	Pprof AdminPprofConfig `mapstructure:"pprof"`
+}
+
+// AdminPprofConfig serves the runtime profiles of net/http/pprof (CPU, heap,
+// goroutine, block, mutex...).
+type AdminPprofConfig struct {
+	// Enabled serves the profiles under <admin.path>/debug/pprof, behind the
+	// admin token.
+	Enabled bool `mapstructure:"enabled"`
+	// Address serves them on a separate listener instead (e.g.,
+	// "127.0.0.1:6060"), without authentication: bind it to an interface
+	// unreachable from outside.
+	Address string `mapstructure:"address"`
+
+	BlockProfileRate     int `mapstructure:"block_profile_rate"`     // in nanoseconds, a blocking event is sampled per rate (0 disables the block profile)
+	MutexProfileFraction int `mapstructure:"mutex_profile_fraction"` // 1 in n mutex contention events is sampled (0 disables the mutex profile)
+}
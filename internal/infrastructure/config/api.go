@@ -0,0 +1,13 @@
+package config
+
+type ApiConfig struct {
+	Prefix   string             `mapstructure:"prefix"`   // defaults to /api
+	Versions []ApiVersionConfig `mapstructure:"versions"` // served versions, defaults to v1 only
+}
+
+type ApiVersionConfig struct {
+	Name       string `mapstructure:"name"`       // path segment, e.g. v1
+	Deprecated string `mapstructure:"deprecated"` // YYYY-MM-DD, empty while the version is supported
+	Sunset     string `mapstructure:"sunset"`     // YYYY-MM-DD, date the version stops being served
+	Link       string `mapstructure:"link"`       // migration guide advertised to clients of a deprecated version
+}
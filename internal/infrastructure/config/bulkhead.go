@@ -0,0 +1,13 @@
+package config
+
+type BulkheadConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Groups cap the requests in flight under a path prefix (a route group).
+	Groups []BulkheadGroupConfig `mapstructure:"groups"`
+}
+
+type BulkheadGroupConfig struct {
+	Path        string `mapstructure:"path"`          // route group prefix (e.g., "/api/v1/bookings")
+	MaxInFlight int    `mapstructure:"max_in_flight"` // requests handled at once, excess requests are shed
+	RetryAfter  int    `mapstructure:"retry_after"`   // in seconds, hint sent to shed clients (default 1)
+}
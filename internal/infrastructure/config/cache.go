@@ -0,0 +1,17 @@
+package config
+
+type CacheConfig struct {
+	// Driver is "memory" (default, per instance, for deployments without
+	// Redis) or "redis" (shared by every instance, uses the redis section).
+	Driver string `mapstructure:"driver"`
+	// Prefix is prepended to the keys stored in Redis (e.g., "voyago:booking:cache:").
+	Prefix string `mapstructure:"prefix"`
+	// MaxEntries bounds the memory cache, the least recently used entries
+	// being evicted first (default 10000).
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+const (
+	CacheDriverMemory = "memory"
+	CacheDriverRedis  = "redis"
+)
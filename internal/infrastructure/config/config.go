@@ -2,12 +2,42 @@ package config
 
 type Config struct {
 	// Global configuration
-	App       AppConfig       `mapstructure:"app"`
-	Http      HttpConfig      `mapstructure:"http"`
-	Telemetry TelemetryConfig `mapstructure:"telemetry"`
+	App            AppConfig            `mapstructure:"app"`
+	Http           HttpConfig           `mapstructure:"http"`
+	Api            ApiConfig            `mapstructure:"api"`
+	Grpc           GrpcConfig           `mapstructure:"grpc"`
+	Graphql        GraphqlConfig        `mapstructure:"graphql"`
+	Docs           DocsConfig           `mapstructure:"docs"`
+	SSE            SSEConfig            `mapstructure:"sse"`
+	Websocket      WebsocketConfig      `mapstructure:"websocket"`
+	RateLimit      RateLimitConfig      `mapstructure:"rate_limit"`
+	Bulkhead       BulkheadConfig       `mapstructure:"bulkhead"`
+	Security       SecurityConfig       `mapstructure:"security"`
+	HttpClient     HttpClientConfig     `mapstructure:"http_client"`
+	Maintenance    MaintenanceConfig    `mapstructure:"maintenance"`
+	Admin          AdminConfig          `mapstructure:"admin"`
+	Shutdown       ShutdownConfig       `mapstructure:"shutdown"`
+	Telemetry      TelemetryConfig      `mapstructure:"telemetry"`
+	Tenancy        TenancyConfig        `mapstructure:"tenancy"`
+	Kafka          KafkaConfig          `mapstructure:"kafka"`
+	AMQP           AMQPConfig           `mapstructure:"amqp"`
+	Worker         WorkerConfig         `mapstructure:"worker"`
+	TaskQueue      TaskQueueConfig      `mapstructure:"task_queue"`
+	Scheduler      SchedulerConfig      `mapstructure:"scheduler"`
+	FeatureFlags   FeatureFlagsConfig   `mapstructure:"feature_flags"`
+	I18n           I18nConfig           `mapstructure:"i18n"`
+	ErrorReporting ErrorReportingConfig `mapstructure:"error_reporting"`
+	Reload         ReloadConfig         `mapstructure:"reload"`
+	Remote         RemoteConfig         `mapstructure:"remote"`
 
 	// Domain configuration
 	Database DatabaseConfig `mapstructure:"database"`
 	Redis    RedisConfig    `mapstructure:"redis"`
+	Cache    CacheConfig    `mapstructure:"cache"`
 	Log      LogConfig      `mapstructure:"log"`
+	Webhook  WebhookConfig  `mapstructure:"webhook"`
+	IDs      IDsConfig      `mapstructure:"ids"`
+
+	// sections are the other settings, the modules ones (see Section).
+	sections sections
 }
@@ -1,14 +1,186 @@
 package config
 
+import (
+	"fmt"
+	"strings"
+)
+
+// Database drivers (DatabaseConfig.Driver).
+const (
+	DatabaseDriverPostgres = "postgres"
+	DatabaseDriverMySQL    = "mysql"
+	DatabaseDriverSQLite   = "sqlite"
+)
+
 type DatabaseConfig struct {
+	// Driver is postgres (default), mysql or sqlite. With sqlite, Name is the
+	// path of the database file and the connection settings are unused.
+	Driver   string `mapstructure:"driver"`
 	Host     string `mapstructure:"host"`
 	Port     int    `mapstructure:"port"`
 	User     string `mapstructure:"user"`
 	Password string `mapstructure:"password"`
 	Name     string `mapstructure:"name"`
-	Pool     struct {
-		Idle     int `mapstructure:"idle"`
-		Max      int `mapstructure:"max"`
-		Lifetime int `mapstructure:"lifetime"`
-	} `mapstructure:"pool"`
+	// Schema is the Postgres schema owned by the domain. When set, the connection
+	// search_path is pinned to it and statements targeting other schemas are rejected.
+	// With mysql, where a schema is a database, it is the database connected to.
+	// On a shared connection (see Connection), the tables are qualified with it
+	// instead of pinning the search_path.
+	Schema string             `mapstructure:"schema"`
+	Pool   DatabasePoolConfig `mapstructure:"pool"`
+	// Retry retries the transactions (Atomic) failing with a transient error:
+	// deadlock, lock timeout or lost connection.
+	Retry DatabaseRetryConfig `mapstructure:"retry"`
+	// CircuitBreaker fails the statements fast while the database keeps
+	// failing to connect.
+	CircuitBreaker DatabaseCircuitBreakerConfig `mapstructure:"circuit_breaker"`
+	// Timeouts bound the statements and lock waits of the transactions
+	// (Atomic), Postgres only.
+	Timeouts DatabaseTimeoutsConfig `mapstructure:"timeouts"`
+	// Migrations configures the embedded SQL migrations of the domain.
+	Migrations DatabaseMigrationsConfig `mapstructure:"migrations"`
+
+	// Replicas serve the reads of the query repositories, the database above
+	// being the primary. The empty settings of a replica default to the ones
+	// of the primary.
+	Replicas []DatabaseEndpointConfig `mapstructure:"replicas"`
+
+	// Tenants route the statements of a tenant (see package tenancy) to its
+	// own database, keyed by tenant ID (lowercased). The empty settings of a
+	// tenant default to the ones above.
+	Tenants     map[string]DatabaseEndpointConfig `mapstructure:"tenants"`
+	TenantPools DatabaseTenantPoolsConfig         `mapstructure:"tenant_pools"`
+
+	// Connection names the connection of Connections the domain opens its
+	// database on, instead of the settings above. The domains naming the same
+	// connection share its pool, sized by its own pool settings; Schema is
+	// then required, the statements being qualified with it (see
+	// ForConnection).
+	Connection string `mapstructure:"connection"`
+	// Connections are the named connections the domains may share, declared
+	// once in the global configuration file.
+	Connections map[string]DatabaseEndpointConfig `mapstructure:"connections"`
+}
+
+type DatabasePoolConfig struct {
+	Idle     int `mapstructure:"idle"`
+	Max      int `mapstructure:"max"`
+	Lifetime int `mapstructure:"lifetime"` // in seconds
+}
+
+type DatabaseRetryConfig struct {
+	MaxAttempts int `mapstructure:"max_attempts"` // attempts per transaction, including the first; 0 or 1 disables retries
+	BaseBackoff int `mapstructure:"base_backoff"` // in milliseconds, doubled on every attempt (default 100)
+	MaxBackoff  int `mapstructure:"max_backoff"`  // in milliseconds (default 2000)
+}
+
+type DatabaseCircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive connection failures
+	// opening the circuit, 0 disables the breaker.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	OpenTimeout      int `mapstructure:"open_timeout"` // in seconds, before a trial statement is let through (default 30)
+}
+
+type DatabaseTimeoutsConfig struct {
+	Statement int `mapstructure:"statement"` // in milliseconds, per statement of a transaction; 0 disables
+	Lock      int `mapstructure:"lock"`      // in milliseconds, per lock wait of a transaction; 0 disables
+}
+
+type DatabaseMigrationsConfig struct {
+	// CheckOnStartup fails the startup when the database is dirty or behind
+	// the latest embedded migration, Postgres only.
+	CheckOnStartup bool `mapstructure:"check_on_startup"`
+}
+
+// DatabaseEndpointConfig is a database other than the default one, a replica
+// or the database of a tenant.
+type DatabaseEndpointConfig struct {
+	Host     string             `mapstructure:"host"`
+	Port     int                `mapstructure:"port"`
+	User     string             `mapstructure:"user"`
+	Password string             `mapstructure:"password"`
+	Name     string             `mapstructure:"name"`
+	Schema   string             `mapstructure:"schema"`
+	Pool     DatabasePoolConfig `mapstructure:"pool"`
+}
+
+// DatabaseTenantPoolsConfig limits the connection pools of the tenant
+// databases, opened on the first statement of the tenant.
+type DatabaseTenantPoolsConfig struct {
+	MaxOpen     int `mapstructure:"max_open"`     // pools open at once (default 10), beyond the statements of a new tenant fail
+	IdleTimeout int `mapstructure:"idle_timeout"` // in seconds, a pool unused that long is closed (default 600)
+}
+
+// ForTenant returns the configuration of the database of tenant, false when
+// the tenant has no database of its own. The replicas of the default database
+// are not the ones of the tenant: its reads go to its database.
+func (c DatabaseConfig) ForTenant(tenant string) (DatabaseConfig, bool) {
+	t, ok := c.Tenants[strings.ToLower(tenant)]
+	if !ok {
+		return DatabaseConfig{}, false
+	}
+	return c.with(t), true
+}
+
+// ForConnection returns the configuration of the connection named by
+// Connection: c with the non-empty settings of the connection, keeping the
+// schema, replicas and tenants of the domain, and naming no connection. It
+// returns c when the domain names no connection.
+func (c DatabaseConfig) ForConnection() (DatabaseConfig, error) {
+	if c.Connection == "" {
+		return c, nil
+	}
+	e, ok := c.Connections[c.Connection]
+	if !ok {
+		return DatabaseConfig{}, fmt.Errorf("unknown database connection %q", c.Connection)
+	}
+	if c.Driver == DatabaseDriverSQLite {
+		return DatabaseConfig{}, fmt.Errorf("sqlite databases cannot share the connection %q", c.Connection)
+	}
+	if c.Schema == "" {
+		return DatabaseConfig{}, fmt.Errorf("database.schema is required on the shared connection %q", c.Connection)
+	}
+	cfg := c.with(e)
+	cfg.Schema, cfg.Replicas, cfg.Tenants = c.Schema, c.Replicas, c.Tenants
+	cfg.Connection, cfg.Connections = "", nil
+	return cfg, nil
+}
+
+// ForReplicas returns the configurations of the replicas.
+func (c DatabaseConfig) ForReplicas() []DatabaseConfig {
+	replicas := make([]DatabaseConfig, 0, len(c.Replicas))
+	for _, r := range c.Replicas {
+		replicas = append(replicas, c.with(r))
+	}
+	return replicas
+}
+
+// with returns c with the non-empty settings of e, without replicas nor
+// tenants.
+func (c DatabaseConfig) with(e DatabaseEndpointConfig) DatabaseConfig {
+	cfg := c
+	cfg.Replicas = nil
+	cfg.Tenants = nil
+	if e.Host != "" {
+		cfg.Host = e.Host
+	}
+	if e.Port != 0 {
+		cfg.Port = e.Port
+	}
+	if e.User != "" {
+		cfg.User = e.User
+	}
+	if e.Password != "" {
+		cfg.Password = e.Password
+	}
+	if e.Name != "" {
+		cfg.Name = e.Name
+	}
+	if e.Schema != "" {
+		cfg.Schema = e.Schema
+	}
+	if e.Pool != (DatabasePoolConfig{}) {
+		cfg.Pool = e.Pool
+	}
+	return cfg
 }
@@ -0,0 +1,7 @@
+package config
+
+type DocsConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`   // serves the OpenAPI document and Swagger UI
+	SpecPath string `mapstructure:"spec_path"` // defaults to /openapi.json
+	UIPath   string `mapstructure:"ui_path"`   // defaults to /docs
+}
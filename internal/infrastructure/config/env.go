@@ -0,0 +1,290 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// SourceEnvVar selects where the configuration is read from: the YAML files
+// (default), or the environment variables only when set to SourceEnv, for
+// the container platforms forbidding configuration files (see EnvOnly).
+const SourceEnvVar = "CONFIG_SOURCE"
+
+// SourceEnv is the value of SourceEnvVar reading the environment only.
+const SourceEnv = "env"
+
+// domainEnvSeparator separates the domain prefix of the domain settings from
+// the setting: BOOKING__DATABASE_HOST. A single underscore would be ambiguous
+// with the sections named after a domain (booking, webhook).
+const domainEnvSeparator = "__"
+
+// EnvOnly reports whether the configuration is read from the environment
+// variables only (CONFIG_SOURCE=env). The loaders then read no file:
+//
+//   - every setting is read from the variable of its YAML path, uppercased,
+//     the dots and the map keys joined by underscores: HTTP_PORT,
+//     LOG_LEVELS_BOOKING, DATABASE_CONNECTIONS_MAIN_HOST;
+//   - the slices of values are comma-separated (TENANCY_RESOLVERS=header,claim)
+//     and the elements of the other slices are indexed (LOG_SINKS_0_DRIVER);
+//   - a map, slice or section may be given as a whole JSON document instead
+//     (RATE_LIMIT_ROUTES='[{"path": "/api/v1/bookings", "limit": 10}]');
+//   - the settings of a domain override the global ones with the domain
+//     prefix: BOOKING__DATABASE_NAME, the domain being the directory of its
+//     configuration file (config/booking/config.yaml).
+//
+// The ${VAR:default} defaults of the YAML files do not apply: the settings
+// left unset take their documented default, or fail the validation.
+func EnvOnly() bool {
+	return strings.EqualFold(os.Getenv(SourceEnvVar), SourceEnv)
+}
+
+// envSource is the source of the validation errors in env mode.
+const envSource = "environment"
+
+// loadGlobalEnv is LoadGlobalConfig reading the environment only.
+func loadGlobalEnv() (*viper.Viper, *Config, error) {
+	v := viper.New()
+	if err := v.MergeConfigMap(envSettings(environ(), "")); err != nil {
+		return nil, nil, fmt.Errorf("error reading global config from the environment: %w", err)
+	}
+	remote, err := mergeRemoteWithOverrides(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, nil, fmt.Errorf("unable to decode global config from the environment: %w", err)
+	}
+	source := sourceOf(envSource, remote)
+	if err := withSource(cfg.Validate(), source); err != nil {
+		return nil, nil, err
+	}
+	cfg.keepSections(v, source, "")
+	return v, &cfg, nil
+}
+
+// readDomainEnv is ReadDomainConfig reading the environment variables of the
+// domain of domainPath over the global settings.
+func readDomainEnv(domainPath string) (*Config, error) {
+	domainViper := viper.New()
+	if err := domainViper.MergeConfigMap(globalViper.AllSettings()); err != nil {
+		return nil, fmt.Errorf("Error merging global settings: %v", err)
+	}
+
+	source, prefixes := envSource, []string{""}
+	domain := domainOf(domainPath)
+	if domain != "" {
+		prefix := strings.ToUpper(strings.ReplaceAll(domain, "-", "_")) + domainEnvSeparator
+		source = fmt.Sprintf("%s (%s*)", envSource, prefix)
+		prefixes = append(prefixes, prefix)
+		if err := domainViper.MergeConfigMap(envSettings(environ(), prefix)); err != nil {
+			return nil, fmt.Errorf("error reading domain config from the environment: %w", err)
+		}
+	}
+	remote, err := mergeRemoteDomain(domainViper, domain)
+	if err != nil {
+		return nil, err
+	}
+	source = sourceOf(source, remote)
+	if err := applyOverrides(domainViper); err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := domainViper.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode domain config from the environment: %v", err)
+	}
+	if err := withSource(cfg.ValidateDomain(), source); err != nil {
+		return nil, err
+	}
+	cfg.keepSections(domainViper, source, prefixes...)
+	return &cfg, nil
+}
+
+// domainOf returns the domain of a domain configuration file, its directory.
+func domainOf(domainPath string) string {
+	if domainPath == "" {
+		return ""
+	}
+	return filepath.Base(filepath.Dir(filepath.Clean(domainPath)))
+}
+
+// environ returns the environment variables by name.
+func environ() map[string]string {
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		if name, value, ok := strings.Cut(kv, "="); ok {
+			env[name] = value
+		}
+	}
+	return env
+}
+
+// envSettings returns the settings of Config found in env under prefix, as
+// nested sections keyed like the YAML file.
+func envSettings(env map[string]string, prefix string) map[string]any {
+	return envSection(env, prefix, reflect.TypeFor[Config]())
+}
+
+// envSection returns the settings of the struct t found in env, the
+// variables of its fields being prefix followed by their uppercased key.
+func envSection(env map[string]string, prefix string, t reflect.Type) map[string]any {
+	out := map[string]any{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		key, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		if opts == "squash" {
+			for k, v := range envSection(env, prefix, f.Type) {
+				out[k] = v
+			}
+			continue
+		}
+		if key == "" || key == "-" || !f.IsExported() {
+			continue
+		}
+		if v := envValue(env, prefix+strings.ToUpper(key), f.Type); v != nil {
+			out[key] = v
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// envValue returns the setting of type t read from the variable name and the
+// variables under it, nil when none is set.
+func envValue(env map[string]string, name string, t reflect.Type) any {
+	raw, set := env[name]
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice:
+		if set && isJSON(raw) {
+			var v any
+			if err := json.Unmarshal([]byte(raw), &v); err == nil {
+				return v
+			}
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if section := envSection(env, name+"_", t); section != nil {
+			return section
+		}
+		return nil
+	case reflect.Map:
+		if m := envMap(env, name+"_", t.Elem()); m != nil {
+			return m
+		}
+		return nil
+	case reflect.Slice:
+		if set {
+			// Split on commas by the decoder.
+			return raw
+		}
+		var items []any
+		for i := 0; ; i++ {
+			item := envValue(env, name+"_"+strconv.Itoa(i), t.Elem())
+			if item == nil {
+				break
+			}
+			items = append(items, item)
+		}
+		if items == nil {
+			return nil
+		}
+		return items
+	}
+	if !set {
+		return nil
+	}
+	return scalar(raw, t)
+}
+
+// envMap returns the map of elem values whose keys follow prefix.
+func envMap(env map[string]string, prefix string, elem reflect.Type) map[string]any {
+	out := map[string]any{}
+	for name := range env {
+		rest, ok := strings.CutPrefix(name, prefix)
+		if !ok || rest == "" {
+			continue
+		}
+		key := rest
+		if elem.Kind() == reflect.Struct && !isJSON(env[name]) {
+			// The key is followed by a field of the element:
+			// DATABASE_TENANTS_<KEY>_HOST.
+			key = structKey(rest, elem)
+		}
+		if key == "" || strings.HasPrefix(key, "_") {
+			continue
+		}
+		lower := strings.ToLower(key)
+		if _, done := out[lower]; done {
+			continue
+		}
+		if v := envValue(env, prefix+key, elem); v != nil {
+			out[lower] = v
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// structKey returns the shortest prefix of rest followed by a field of the
+// struct t, empty when there is none.
+func structKey(rest string, t reflect.Type) string {
+	for i := 1; i < len(rest); i++ {
+		if rest[i] != '_' {
+			continue
+		}
+		if envSection(map[string]string{rest[i+1:]: ""}, "", t) != nil {
+			return rest[:i]
+		}
+	}
+	return ""
+}
+
+// scalar converts the variable raw to the kind of t, raw itself when it does
+// not parse: the decoder then reports the invalid setting.
+func scalar(raw string, t reflect.Type) any {
+	switch t.Kind() {
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Durations are numbers like in the YAML files, or Go durations.
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return n
+		}
+		if t == durationType {
+			return raw
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, err := strconv.ParseUint(raw, 10, 64); err == nil {
+			return n
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			return f
+		}
+	}
+	return raw
+}
+
+func isJSON(raw string) bool {
+	raw = strings.TrimSpace(raw)
+	return strings.HasPrefix(raw, "{") || strings.HasPrefix(raw, "[")
+}
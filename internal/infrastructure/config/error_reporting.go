@@ -0,0 +1,26 @@
+package config
+
+// ErrorReportingConfig reports the unexpected failures of the requests (the
+// internal errors and the panics) to an error tracker (see package
+// errorreport).
+type ErrorReportingConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Driver is "sentry" (default).
+	Driver string `mapstructure:"driver"`
+	// Release is the version the failures are reported for (default
+	// app.version), Environment the deployment (default app.env).
+	Release     string `mapstructure:"release"`
+	Environment string `mapstructure:"environment"`
+	// QueueSize bounds the reports waiting to be sent (default 100): the
+	// failures beyond it are not reported.
+	QueueSize int `mapstructure:"queue_size"`
+	// Timeout bounds the sending of a report, in seconds (default 5).
+	Timeout int `mapstructure:"timeout"`
+	Sentry  struct {
+		// DSN is the client key of the project
+		// (https://<public key>@<host>/<project ID>).
+		DSN string `mapstructure:"dsn"`
+	} `mapstructure:"sentry"`
+}
+
+const ErrorReportingDriverSentry = "sentry"
@@ -0,0 +1,50 @@
+package config
+
+// FeatureFlagsConfig selects where the feature flags gating the new behaviors
+// of the use cases are defined (see package featureflag).
+type FeatureFlagsConfig struct {
+	// Driver is "config" (default, the flags below), "unleash" or
+	// "launchdarkly".
+	Driver string `mapstructure:"driver"`
+	// Flags are the flags of the config driver, by name. A flag absent from
+	// the map is disabled.
+	Flags map[string]FeatureFlagConfig `mapstructure:"flags"`
+	// RefreshInterval is how often the unleash and launchdarkly drivers
+	// fetch the flags, in seconds (default 30).
+	RefreshInterval int `mapstructure:"refresh_interval"`
+	Unleash         struct {
+		// URL is the API of the Unleash server or proxy
+		// (https://unleash.example.com/api).
+		URL string `mapstructure:"url"`
+		// APIToken is a client (backend) token.
+		APIToken string `mapstructure:"api_token"`
+		// AppName identifies the application to Unleash, for its metrics and
+		// the appName constraints (default app.name).
+		AppName string `mapstructure:"app_name"`
+	} `mapstructure:"unleash"`
+	LaunchDarkly struct {
+		// SDKKey is the server-side SDK key of the environment.
+		SDKKey string `mapstructure:"sdk_key"`
+		// BaseURL is the polling endpoint (default
+		// https://sdk.launchdarkly.com), or a Relay Proxy.
+		BaseURL string `mapstructure:"base_url"`
+	} `mapstructure:"launchdarkly"`
+}
+
+// FeatureFlagConfig is a flag of the config driver. An enabled flag without
+// users nor tenants is on for every request, within its percentage.
+type FeatureFlagConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Users and Tenants restrict the flag to these users or tenants.
+	Users   []string `mapstructure:"users"`
+	Tenants []string `mapstructure:"tenants"`
+	// Percentage rolls the flag out to this share of the users (the tenants
+	// for the requests without user), picked by a stable hash (default 100).
+	Percentage int `mapstructure:"percentage"`
+}
+
+const (
+	FeatureFlagDriverConfig       = "config"
+	FeatureFlagDriverUnleash      = "unleash"
+	FeatureFlagDriverLaunchDarkly = "launchdarkly"
+)
@@ -0,0 +1,10 @@
+package config
+
+type GraphqlConfig struct {
+	Path           string `mapstructure:"path"`
+	MaxDepth       int    `mapstructure:"max_depth"`
+	MaxParallelism int    `mapstructure:"max_parallelism"`
+	Introspection  bool   `mapstructure:"introspection"`
+	BatchWait      int    `mapstructure:"batch_wait"` // dataloader batching window in milliseconds
+	MaxBatch       int    `mapstructure:"max_batch"`  // keys per dataloader batch, 0 = unlimited
+}
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+type GrpcConfig struct {
+	Port              int           `mapstructure:"port"`
+	MaxRecvMsgSize    int           `mapstructure:"max_recv_msg_size"`  // in bytes
+	ConnectionTimeout time.Duration `mapstructure:"connection_timeout"` // in seconds
+	Reflection        bool          `mapstructure:"reflection"`
+}
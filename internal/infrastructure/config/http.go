@@ -8,4 +8,50 @@ type HttpConfig struct {
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
 	IdleTimeout  time.Duration `mapstructure:"idle_timeout"`
+	// RequestTimeout is the default deadline of a request context, in
+	// seconds (0 disables it). Routes may override it with middleware.Timeout.
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+	// BodyLimit is the maximum size of a request body in bytes, decompressed
+	// (defaults to 4MB). Larger requests are answered with PAYLOAD_TOO_LARGE.
+	BodyLimit   int               `mapstructure:"body_limit"`
+	Compression CompressionConfig `mapstructure:"compression"`
+
+	// ErrorFormat selects the error response body: "envelope" (default, the
+	// standard response.Http) or "problem" (RFC 7807 application/problem+json).
+	ErrorFormat string `mapstructure:"error_format"`
+	// ProblemTypeBase prefixes the error code to build the "type" URI of
+	// problem responses (e.g., "https://docs.voyago.com/errors/"). When empty,
+	// the type is "about:blank".
+	ProblemTypeBase string `mapstructure:"problem_type_base"`
+	// RetryAfter is the Retry-After hint, in seconds, of the retryable errors
+	// and of the 429 and 503 responses whose middleware set none (the rate
+	// limiter, the bulkhead and the maintenance mode set their own). Defaults
+	// to 1.
+	RetryAfter int `mapstructure:"retry_after"`
+	// TrustedProxies are the IPs or CIDRs of the load balancers in front of
+	// the API. The client IP of their requests is read from X-Forwarded-For
+	// (see middleware.ClientIP); the header of the other requests is ignored.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+// CompressionConfig enables gzip/brotli compression of the responses whose
+// content type is allowed.
+type CompressionConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Level   string `mapstructure:"level"`    // "default", "best_speed" or "best_compression"
+	MinSize int    `mapstructure:"min_size"` // in bytes, smaller responses are sent as is (default 1024)
+	// ContentTypes are the media type prefixes compressed (e.g., "text/"),
+	// defaults to JSON, problem+JSON, XML, JavaScript and text.
+	ContentTypes []string `mapstructure:"content_types"`
 }
+
+const (
+	ErrorFormatEnvelope = "envelope"
+	ErrorFormatProblem  = "problem"
+)
+
+const (
+	CompressionLevelDefault         = "default"
+	CompressionLevelBestSpeed       = "best_speed"
+	CompressionLevelBestCompression = "best_compression"
+)
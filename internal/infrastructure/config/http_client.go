@@ -0,0 +1,20 @@
+package config
+
+type HttpClientConfig struct {
+	Timeout        int                            `mapstructure:"timeout"` // per attempt, in seconds (default 10)
+	Retry          HttpClientRetryConfig          `mapstructure:"retry"`
+	CircuitBreaker HttpClientCircuitBreakerConfig `mapstructure:"circuit_breaker"`
+}
+
+type HttpClientRetryConfig struct {
+	MaxAttempts int `mapstructure:"max_attempts"` // attempts per request, including the first; 0 or 1 disables retries
+	BaseBackoff int `mapstructure:"base_backoff"` // in milliseconds, doubled on every attempt (default 100)
+	MaxBackoff  int `mapstructure:"max_backoff"`  // in milliseconds, also caps the Retry-After of the upstream (default 2000)
+}
+
+type HttpClientCircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures (transport
+	// errors and 5xx) opening the circuit of a host, 0 disables the breaker.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	OpenTimeout      int `mapstructure:"open_timeout"` // in seconds, before a trial request is let through (default 30)
+}
@@ -0,0 +1,10 @@
+package config
+
+// I18nConfig configures the localization of the error and validation
+// messages (see package i18n).
+type I18nConfig struct {
+	// DefaultLocale is the locale of the requests without Accept-Language,
+	// or accepting no locale with messages (default "en", the messages of
+	// the code).
+	DefaultLocale string `mapstructure:"default_locale"`
+}
@@ -0,0 +1,8 @@
+package config
+
+// IDsConfig configures the primary keys generated by a domain.
+type IDsConfig struct {
+	// Generator is "uuidv7" (default) or "ulid", see uid.NewGenerator. ULIDs
+	// need text ID columns: the booking and webhook tables use uuid ones.
+	Generator string `mapstructure:"generator"`
+}
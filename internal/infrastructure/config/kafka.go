@@ -0,0 +1,25 @@
+package config
+
+type KafkaConfig struct {
+	// Enabled starts the consumers registered by the modules.
+	Enabled  bool                `mapstructure:"enabled"`
+	Brokers  []string            `mapstructure:"brokers"` // "host:port" of the bootstrap brokers
+	ClientID string              `mapstructure:"client_id"`
+	Consumer KafkaConsumerConfig `mapstructure:"consumer"`
+}
+
+type KafkaConsumerConfig struct {
+	// GroupID is the consumer group of the application: the partitions of a
+	// topic are shared by its instances (default app.name).
+	GroupID string `mapstructure:"group_id"`
+	// MaxAttempts is the number of times a message is handled, the first
+	// included, before it is sent to the dead letter topic (default 3, 1
+	// disables the retry topics).
+	MaxAttempts     int `mapstructure:"max_attempts"`
+	RetryBackoff    int `mapstructure:"retry_backoff"`     // in milliseconds before the second attempt, doubled on every attempt (default 1000)
+	MaxRetryBackoff int `mapstructure:"max_retry_backoff"` // in milliseconds (default 60000)
+	// RetrySuffix and DeadLetterSuffix name the retry and dead letter topics
+	// of a topic (default ".retry" and ".dlq", e.g. "payments.retry").
+	RetrySuffix      string `mapstructure:"retry_suffix"`
+	DeadLetterSuffix string `mapstructure:"dead_letter_suffix"`
+}
@@ -3,8 +3,10 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/viper"
@@ -14,71 +16,265 @@ import (
 // for all domain-specific configurations.
 var globalViper *viper.Viper
 
+// globalFile is the file globalViper was read from.
+var globalFile string
+
 // InitGlobalConfig initializes the base configuration from the provided globalPath.
 // It parses the YAML file, expands environment variables, and stores the state internally.
 // Use the returned *Config for global infrastructure setup like Telemetry or Global App settings.
+// It panics when the configuration cannot be loaded or is invalid (see
+// Config.Validate), see LoadGlobalConfig.
 //
 // Example:
 //
 //	globalCfg := config.InitGlobalConfig("config/config.yaml")
 func InitGlobalConfig(globalPath string) *Config {
-	v := viper.New()
-	v.AutomaticEnv()
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	cfg, err := LoadGlobalConfig(globalPath)
+	if err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+// LoadGlobalConfig is InitGlobalConfig returning the loading failure instead
+// of panicking, for commands reporting startup failures (see package startup).
+//
+// The settings are read by increasing precedence from the environment
+// variables, through the ${VAR:default} placeholders of the files only, the
+// file, its profile for app.env (see ProfilePath), then the overrides of the
+// command line (see SetOverrides).
+//
+// With CONFIG_SOURCE=env, globalPath is ignored: the configuration is read
+// from the environment variables only (see EnvOnly).
+func LoadGlobalConfig(globalPath string) (*Config, error) {
+	if EnvOnly() {
+		v, cfg, err := loadGlobalEnv()
+		if err != nil {
+			return nil, err
+		}
+		globalViper = v
+		globalFile = ""
+		return cfg, nil
+	}
 
+	v := viper.New()
 	content, err := processingFile(globalPath)
 	if err != nil {
-		panic(fmt.Errorf("error reading global config: %w", err))
+		return nil, fmt.Errorf("error reading global config: %w", err)
 	}
 
 	v.SetConfigType("yaml")
-	v.ReadConfig(strings.NewReader(content))
-
-	globalViper = v
+	if err := v.ReadConfig(strings.NewReader(content)); err != nil {
+		return nil, fmt.Errorf("error parsing global config: %w", err)
+	}
+	profile, err := mergeProfile(v, globalPath)
+	if err != nil {
+		return nil, err
+	}
+	remote, err := mergeRemoteWithOverrides(v)
+	if err != nil {
+		return nil, err
+	}
 
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
-		panic(fmt.Errorf("unable to decode global config into struct: %v", err))
+		return nil, fmt.Errorf("unable to decode global config into struct: %w", err)
+	}
+	source := sourceOf(globalPath, profile, remote)
+	if err := withSource(cfg.Validate(), source); err != nil {
+		return nil, err
 	}
+	cfg.keepSections(v, source)
 
-	return &cfg
+	globalViper = v
+	globalFile = globalPath
+	return &cfg, nil
+}
+
+// GlobalPath returns the file of the global configuration, as given to
+// LoadGlobalConfig; empty until it is loaded, and in env mode.
+func GlobalPath() string {
+	return globalFile
 }
 
 // LoadDomainConfig creates a domain-specific configuration by merging the global settings
-// with the specific settings found in the domainPath.
+// with the specific settings found in the domainPath, then in its profile for
+// app.env (config/booking/config.production.yaml, see ProfilePath).
 // It performs a deep copy of the global configuration, ensuring that domain-specific
 // overrides do not pollute the global state or other domains.
+// It panics when the configuration cannot be loaded, with a *ValidationError
+// listing the invalid settings (see Config.ValidateDomain), see
+// ReadDomainConfig.
 //
 // Example:
 //
 //	bookingCfg := config.LoadDomainConfig("config/booking/config.yaml")
 func LoadDomainConfig(domainPath string) *Config {
+	cfg, err := ReadDomainConfig(domainPath)
+	if err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+// ReadDomainConfig is LoadDomainConfig returning the loading failure instead
+// of panicking, for the commands reporting every invalid file (see package
+// doctor). In env mode, the file is not read: the domain settings are read
+// from the environment variables prefixed with the domain (see EnvOnly).
+func ReadDomainConfig(domainPath string) (*Config, error) {
 	if globalViper == nil {
-		panic(fmt.Errorf("ERROR: Global Config is nil! InitGlobalConfig must be called first from the same package."))
+		return nil, fmt.Errorf("ERROR: Global Config is nil! InitGlobalConfig must be called first from the same package.")
+	}
+	if EnvOnly() {
+		return readDomainEnv(domainPath)
 	}
 
 	domainViper := viper.New()
-	domainViper.AutomaticEnv()
-	domainViper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-
 	if err := domainViper.MergeConfigMap(globalViper.AllSettings()); err != nil {
-		panic(fmt.Errorf("Error merging global settings: %v", err))
+		return nil, fmt.Errorf("Error merging global settings: %v", err)
 	}
 
+	var profile string
 	if domainPath != "" {
 		content, err := processingFile(domainPath)
 		if err != nil {
-			panic(fmt.Errorf("failed to load domain config %s: %w", domainPath, err))
+			return nil, fmt.Errorf("failed to load domain config %s: %w", domainPath, err)
 		}
 		domainViper.SetConfigType("yaml")
-		domainViper.MergeConfig(strings.NewReader(content))
+		if err := domainViper.MergeConfig(strings.NewReader(content)); err != nil {
+			return nil, fmt.Errorf("error parsing domain config %s: %w", domainPath, err)
+		}
+		if profile, err = mergeProfile(domainViper, domainPath); err != nil {
+			return nil, err
+		}
+	}
+	remote, err := mergeRemoteDomain(domainViper, domainOf(domainPath))
+	if err != nil {
+		return nil, err
+	}
+	if err := applyOverrides(domainViper); err != nil {
+		return nil, err
 	}
 
 	var cfg Config
 	if err := domainViper.Unmarshal(&cfg); err != nil {
-		panic(fmt.Errorf("unable to decode domain config into struct: %v", err))
+		return nil, fmt.Errorf("unable to decode domain config into struct: %v", err)
+	}
+	source := sourceOf(domainPath, profile, remote)
+	if err := withSource(cfg.ValidateDomain(), source); err != nil {
+		return nil, err
 	}
-	return &cfg
+	cfg.keepSections(domainViper, source)
+	return &cfg, nil
+}
+
+// WithOverrides returns a copy of c where the settings of overrides, nested
+// sections keyed like the YAML file (e.g. {"webhook": {"timeout": 5}}),
+// replace the current ones. c is left unchanged.
+func (c *Config) WithOverrides(overrides map[string]any) (*Config, error) {
+	// Deep copy: decoding into a shallow copy would write to the slices and
+	// maps shared with c.
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("copying config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("copying config: %w", err)
+	}
+
+	v := viper.New()
+	if err := v.MergeConfigMap(overrides); err != nil {
+		return nil, fmt.Errorf("reading overrides: %w", err)
+	}
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode overrides: %w", err)
+	}
+	cfg.sections = c.sections
+	return &cfg, nil
+}
+
+// ProfilePath returns the profile of the configuration file path for the
+// environment env, the overlay merged over it by the loaders when it exists:
+// config/config.production.yaml for config/config.yaml in production. It is
+// empty without environment.
+func ProfilePath(path, env string) string {
+	if env == "" {
+		return ""
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + env + ext
+}
+
+// mergeProfile merges the profile of path for the environment of v (app.env,
+// or its override) over the settings of v, and returns the merged file,
+// empty when it does not exist.
+func mergeProfile(v *viper.Viper, path string) (string, error) {
+	env := v.GetString("app.env")
+	if app, ok := overrides["app"].(map[string]any); ok {
+		if overridden, ok := app["env"].(string); ok {
+			env = overridden
+		}
+	}
+	profile := ProfilePath(FilePath(path), env)
+	if profile == "" {
+		return "", nil
+	}
+	if _, err := os.Stat(profile); os.IsNotExist(err) {
+		return "", nil
+	}
+
+	content, err := processingFile(profile)
+	if err != nil {
+		return "", fmt.Errorf("failed to load config profile %s: %w", profile, err)
+	}
+	v.SetConfigType("yaml")
+	if err := v.MergeConfig(strings.NewReader(content)); err != nil {
+		return "", fmt.Errorf("error parsing config profile %s: %w", profile, err)
+	}
+	return profile, nil
+}
+
+// sourceOf returns the source of the validation errors of the file path
+// merged with its profile and its remote document, when they exist.
+func sourceOf(path string, merged ...string) string {
+	parts := []string{path}
+	for _, m := range merged {
+		if m != "" {
+			parts = append(parts, m)
+		}
+	}
+	return strings.Join(parts, " + ")
+}
+
+// mergeRemoteWithOverrides merges the global document of the remote store
+// over the settings of v, the overrides applied both before, since they may
+// configure the store (--set remote.provider=consul), and after, to win over
+// the store.
+func mergeRemoteWithOverrides(v *viper.Viper) (string, error) {
+	if err := applyOverrides(v); err != nil {
+		return "", err
+	}
+	remote, err := mergeRemote(v)
+	if err != nil {
+		return "", err
+	}
+	return remote, applyOverrides(v)
+}
+
+// withSource sets the file of the validation error err.
+func withSource(err error, path string) error {
+	if verr, ok := err.(*ValidationError); ok {
+		verr.Source = path
+	}
+	return err
+}
+
+// FilePath returns the file the loaders read for path: path itself, or else
+// the same file two directories up or in the working directory (tests and
+// binaries run from their directory).
+func FilePath(path string) string {
+	return findActualPath(path)
 }
 
 func processingFile(path string) (string, error) {
@@ -88,15 +284,19 @@ func processingFile(path string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	return expand(string(content)), nil
+}
 
-	return os.Expand(string(content), func(s string) string {
+// expand replaces the ${VAR:default} placeholders of content.
+func expand(content string) string {
+	return os.Expand(content, func(s string) string {
 		parts := strings.SplitN(s, ":", 2)
 		val := os.Getenv(parts[0])
 		if val == "" && len(parts) > 1 {
 			return parts[1]
 		}
 		return val
-	}), nil
+	})
 }
 
 func findActualPath(configPath string) string {
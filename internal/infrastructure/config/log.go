@@ -1,12 +1,66 @@
 package config
 
 type LogConfig struct {
-	Path     string `mapstructure:"path"`
-	Level    int    `mapstructure:"level"`
+	// Driver selects the logger: "stdout", "logrus", "zap", "zerolog", "otel"
+	// or "tee" (every logger of Sinks); empty selects it by the application
+	// environment.
+	Driver string `mapstructure:"driver"`
+	Path   string `mapstructure:"path"`
+	Level  int    `mapstructure:"level"`
+	// Levels overrides Level by logger: a domain, or "main" for the global
+	// logger, to a level name (trace, debug, info, warn, error).
+	Levels   map[string]string `mapstructure:"levels"`
 	Rotation struct {
 		MaxSize   int  `mapstructure:"max_size"`
 		MaxBackup int  `mapstructure:"max_backup"`
 		MaxAge    int  `mapstructure:"max_age"`
 		Compress  bool `mapstructure:"compress"`
 	} `mapstructure:"rotation"`
+	OTLP     LogOTLPConfig     `mapstructure:"otlp"`
+	Sampling LogSamplingConfig `mapstructure:"sampling"`
+	Sinks    []LogSinkConfig   `mapstructure:"sinks"`
+	Masking  MaskingConfig     `mapstructure:"masking"`
+	// ErrorStacks captures the stack where the application errors are
+	// created, logged and traced with them (see apperror.CaptureStacks).
+	// Global configuration only.
+	ErrorStacks bool `mapstructure:"error_stacks"`
+}
+
+// LogSinkConfig is a logger of the "tee" driver.
+type LogSinkConfig struct {
+	Driver string `mapstructure:"driver"`
+	Level  string `mapstructure:"level"` // level name, log.level when empty
+}
+
+// LogOTLPConfig configures the "otel" driver.
+type LogOTLPConfig struct {
+	Address string `mapstructure:"address"` // OTel Collector gRPC
+}
+
+// LogSamplingConfig limits the identical warn and error messages logged per
+// interval: the First ones are logged, then one in Thereafter.
+type LogSamplingConfig struct {
+	Enabled    bool `mapstructure:"enabled"`
+	Interval   int  `mapstructure:"interval"` // in seconds
+	First      int  `mapstructure:"first"`
+	Thereafter int  `mapstructure:"thereafter"` // 0 drops every message after the First ones
+}
+
+// MaskingConfig configures the masking of the logged and traced values (see
+// utils.ConfigureMasking); empty settings keep the defaults.
+type MaskingConfig struct {
+	// SensitiveKeys are keywords: the value of a key containing one is
+	// redacted, as is a string containing one.
+	SensitiveKeys []string `mapstructure:"sensitive_keys"`
+	// Strategies mask the value of the keys containing a keyword with a
+	// strategy instead of redacting it: redact, last4, sha256 or truncate.
+	Strategies map[string]string `mapstructure:"strategies"`
+	// HashSalt is prepended to the values hashed by the sha256 strategy.
+	HashSalt       string `mapstructure:"hash_salt"`
+	TruncateLength int    `mapstructure:"truncate_length"` // characters kept by the truncate strategy
+	// Patterns are regular expressions whose matches are redacted from the
+	// strings and messages.
+	Patterns     []string `mapstructure:"patterns"`
+	MaxFieldSize int      `mapstructure:"max_field_size"` // in bytes
+	MaxDepth     int      `mapstructure:"max_depth"`      // of the nested maps, slices and JSON strings
 }
@@ -0,0 +1,28 @@
+package config
+
+type MaintenanceConfig struct {
+	// Enabled forces the maintenance mode from the configuration (e.g., during
+	// a deployment); the admin routes cannot switch it off, reloading the
+	// configuration can (see Reload).
+	Enabled    bool   `mapstructure:"enabled"`
+	Message    string `mapstructure:"message"`     // answered while in maintenance
+	RetryAfter int    `mapstructure:"retry_after"` // in seconds, hint sent to rejected clients (default 60)
+
+	// Store keeps the mode toggled at runtime: "memory" (default, per
+	// instance) or "redis" (shared by every instance, uses the redis section).
+	Store           string `mapstructure:"store"`
+	Key             string `mapstructure:"key"`              // Redis key of the mode
+	RefreshInterval int    `mapstructure:"refresh_interval"` // in seconds, how long an instance caches the mode (default 5)
+
+	// AllowedPaths are path prefixes served during maintenance; the health
+	// routes and the admin routes always are.
+	AllowedPaths []string `mapstructure:"allowed_paths"`
+	// AllowedIPs are the clients (IPs or CIDRs) served during maintenance,
+	// e.g. the office network checking a deployment.
+	AllowedIPs []string `mapstructure:"allowed_ips"`
+}
+
+const (
+	MaintenanceStoreMemory = "memory"
+	MaintenanceStoreRedis  = "redis"
+)
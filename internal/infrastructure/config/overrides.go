@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// overrides are the settings replacing the ones of the files and of the
+// environment, as nested sections (see SetOverrides).
+var overrides map[string]any
+
+// overridden are the paths of the overrides, as given to SetOverrides.
+var overridden []string
+
+// SetOverrides sets the settings replacing the ones of the configuration
+// files and of the environment in the configurations loaded next, e.g. the
+// flags of the command line. settings are keyed by YAML path ("http.port",
+// "log.levels.booking"), their values written like the variables of the env
+// mode (see EnvOnly): numbers, booleans, comma-separated lists or JSON
+// documents. It fails on a path that is not a setting; empty settings clear
+// the overrides.
+func SetOverrides(settings map[string]string) error {
+	env := make(map[string]string, len(settings))
+	for path, value := range settings {
+		name := strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+		if envSettings(map[string]string{name: value}, "") == nil {
+			return fmt.Errorf("unknown setting %q", path)
+		}
+		env[name] = value
+	}
+	overrides = envSettings(env, "")
+	overridden = slices.Sorted(maps.Keys(settings))
+	return nil
+}
+
+// Overridden returns the paths of the settings set by SetOverrides, sorted,
+// e.g. to report the flags replacing the configuration files.
+func Overridden() []string {
+	return slices.Clone(overridden)
+}
+
+// applyOverrides merges the overrides over the settings of v.
+func applyOverrides(v *viper.Viper) error {
+	if overrides == nil {
+		return nil
+	}
+	if err := v.MergeConfigMap(overrides); err != nil {
+		return fmt.Errorf("error applying the overridden settings: %w", err)
+	}
+	return nil
+}
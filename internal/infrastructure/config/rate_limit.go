@@ -0,0 +1,38 @@
+package config
+
+type RateLimitConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Store keeps the counters: "redis" (default, shared by every instance,
+	// uses the redis section) or "memory" (single instance, local development).
+	Store     string `mapstructure:"store"`
+	KeyPrefix string `mapstructure:"key_prefix"` // prefix of the Redis keys
+
+	// Default applies to every request not matched by a route rule.
+	Default RateLimitRuleConfig `mapstructure:"default"`
+	// Routes override the default for a path prefix; the longest match wins.
+	Routes []RateLimitRouteConfig `mapstructure:"routes"`
+}
+
+type RateLimitRuleConfig struct {
+	Limit  int `mapstructure:"limit"`  // requests allowed per window, 0 disables the rule
+	Window int `mapstructure:"window"` // in seconds, sliding
+	// KeyBy selects who is counted: "ip" (default) or "api_key" (the client
+	// app authenticated by the gateway, see security.gateway; falls back to the
+	// IP for the other requests).
+	KeyBy string `mapstructure:"key_by"`
+}
+
+type RateLimitRouteConfig struct {
+	RateLimitRuleConfig `mapstructure:",squash"`
+
+	Method string `mapstructure:"method"` // empty matches every method
+	Path   string `mapstructure:"path"`   // path prefix (e.g., "/api/v1/bookings")
+}
+
+const (
+	RateLimitStoreRedis  = "redis"
+	RateLimitStoreMemory = "memory"
+
+	RateLimitKeyByIP     = "ip"
+	RateLimitKeyByAPIKey = "api_key"
+)
@@ -1,8 +1,40 @@
 package config
 
+// Redis modes (RedisConfig.Mode).
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+)
+
 type RedisConfig struct {
-	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
-	Password string `mapstructure:"password"`
-	DB       int    `mapstructure:"db"`
+	// Mode is standalone (default), sentinel or cluster.
+	Mode string `mapstructure:"mode"`
+	// Host and Port address the standalone server.
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+	// Addrs are the "host:port" of the sentinels (sentinel mode) or of the
+	// cluster nodes to discover the cluster from (cluster mode).
+	Addrs []string `mapstructure:"addrs"`
+	// MasterName is the name of the master monitored by the sentinels.
+	MasterName       string `mapstructure:"master_name"`
+	SentinelPassword string `mapstructure:"sentinel_password"`
+	Username         string `mapstructure:"username"`
+	Password         string `mapstructure:"password"`
+	// DB is the database number, unused in cluster mode.
+	DB       int                 `mapstructure:"db"`
+	Pool     RedisPoolConfig     `mapstructure:"pool"`
+	Timeouts RedisTimeoutsConfig `mapstructure:"timeouts"`
+}
+
+type RedisPoolConfig struct {
+	Size    int `mapstructure:"size"`     // connections per node (default 10 per CPU)
+	MinIdle int `mapstructure:"min_idle"` // idle connections kept open per node
+	Timeout int `mapstructure:"timeout"`  // in milliseconds, waiting for a free connection (default read timeout + 1s)
+}
+
+type RedisTimeoutsConfig struct {
+	Dial  int `mapstructure:"dial"`  // in milliseconds (default 5000)
+	Read  int `mapstructure:"read"`  // in milliseconds (default 3000)
+	Write int `mapstructure:"write"` // in milliseconds (default the read timeout)
 }
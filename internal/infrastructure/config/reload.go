@@ -0,0 +1,12 @@
+package config
+
+// ReloadConfig watches the configuration files of the running process: the
+// changes of the reloadable settings (log levels, trace sampling, rate
+// limits, maintenance mode) apply without a restart, see package reload.
+type ReloadConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Debounce is how long the files must stay unchanged before they are
+	// reloaded, in milliseconds (default 500): editors write a file in
+	// several steps.
+	Debounce int `mapstructure:"debounce"`
+}
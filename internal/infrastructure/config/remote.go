@@ -0,0 +1,33 @@
+package config
+
+// RemoteConfig reads settings from a key/value store shared by the fleet,
+// Consul or etcd, merged over the configuration files (see RemoteProvider).
+// The store holds YAML documents laid out like the files:
+// <path>/config.yaml for the global settings and <path>/<domain>/config.yaml
+// for the settings of a domain; a missing document is skipped.
+type RemoteConfig struct {
+	// Provider is "consul" or "etcd"; no store is read when empty.
+	Provider string `mapstructure:"provider"`
+	// Endpoint is the HTTP address of the store: the Consul agent
+	// (http://localhost:8500) or the gRPC gateway of etcd
+	// (http://localhost:2379).
+	Endpoint string `mapstructure:"endpoint"`
+	// Path is the prefix of the documents (default "voyago").
+	Path string `mapstructure:"path"`
+	// Token authenticates the requests: the ACL token of Consul, the auth
+	// token of etcd.
+	Token string `mapstructure:"token"`
+	// Timeout bounds each read, in seconds (default 5).
+	Timeout int `mapstructure:"timeout"`
+	// Optional starts with the files alone while the store is unreachable,
+	// instead of failing the startup.
+	Optional bool `mapstructure:"optional"`
+	// Watch reloads the configuration on every change of the documents, like
+	// the edits of the files (needs reload.enabled, see package reload).
+	Watch bool `mapstructure:"watch"`
+}
+
+const (
+	RemoteProviderConsul = "consul"
+	RemoteProviderEtcd   = "etcd"
+)
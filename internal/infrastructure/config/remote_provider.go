@@ -0,0 +1,377 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// RemoteProvider reads the configuration documents of a key/value store (see
+// RemoteConfig).
+type RemoteProvider interface {
+	// Get returns the document stored at key, nil when the key does not
+	// exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Watch calls changed after every change of the keys under prefix. It
+	// blocks until ctx is done, returning its error, or until the store
+	// fails.
+	Watch(ctx context.Context, prefix string, changed func()) error
+}
+
+const (
+	defaultRemotePath    = "voyago"
+	defaultRemoteTimeout = 5 * time.Second
+	// consulWait is how long a Consul blocking query waits for a change.
+	consulWait = "5m"
+)
+
+// NewRemoteProvider returns the client of the store of cfg, nil when
+// cfg.Provider is empty. The provider talks to the HTTP APIs of the stores:
+// the KV API of Consul, the gRPC gateway (JSON) of etcd v3.
+func NewRemoteProvider(cfg RemoteConfig) (RemoteProvider, error) {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultRemoteTimeout
+	}
+	endpoint := strings.TrimSuffix(cfg.Endpoint, "/")
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case RemoteProviderConsul:
+		return &consulProvider{endpoint: endpoint, token: cfg.Token, timeout: timeout, client: &http.Client{}}, nil
+	case RemoteProviderEtcd:
+		return &etcdProvider{endpoint: endpoint, token: cfg.Token, timeout: timeout, client: &http.Client{}}, nil
+	}
+	return nil, fmt.Errorf("unknown remote provider %q: must be consul or etcd", cfg.Provider)
+}
+
+// RemoteKey returns the key of the document of domain under path, the global
+// document when domain is empty: voyago/config.yaml,
+// voyago/booking/config.yaml.
+func RemoteKey(path, domain string) string {
+	if path = strings.Trim(path, "/"); path == "" {
+		path = defaultRemotePath
+	}
+	if domain == "" {
+		return path + "/config.yaml"
+	}
+	return path + "/" + domain + "/config.yaml"
+}
+
+// consulProvider reads the Consul KV store.
+type consulProvider struct {
+	endpoint string
+	token    string
+	timeout  time.Duration
+	client   *http.Client
+}
+
+func (p *consulProvider) Get(ctx context.Context, key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	resp, err := p.do(ctx, "/v1/kv/"+key+"?raw")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("consul: reading %s: %s", key, resp.Status)
+}
+
+// Watch runs blocking queries on prefix: each returns once the index of the
+// keys moved past the previous one, or after consulWait.
+func (p *consulProvider) Watch(ctx context.Context, prefix string, changed func()) error {
+	var index uint64
+	for {
+		resp, err := p.do(ctx, fmt.Sprintf("/v1/kv/%s?recurse&index=%d&wait=%s", prefix, index, consulWait))
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("consul: watching %s: %s", prefix, resp.Status)
+		}
+
+		next, err := strconv.ParseUint(resp.Header.Get("X-Consul-Index"), 10, 64)
+		if err != nil {
+			return fmt.Errorf("consul: watching %s: invalid X-Consul-Index: %w", prefix, err)
+		}
+		if index != 0 && next != index {
+			changed()
+		}
+		// The index moves backwards when the store is restored: start over.
+		if next < index {
+			next = 0
+		}
+		index = next
+	}
+}
+
+func (p *consulProvider) do(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.token != "" {
+		req.Header.Set("X-Consul-Token", p.token)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("consul: %w", err)
+	}
+	return resp, nil
+}
+
+// etcdProvider reads etcd v3 through its gRPC gateway, the keys and values
+// being base64 encoded.
+type etcdProvider struct {
+	endpoint string
+	token    string
+	timeout  time.Duration
+	client   *http.Client
+}
+
+func (p *etcdProvider) Get(ctx context.Context, key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	resp, err := p.post(ctx, "/v3/kv/range", map[string]any{"key": encodeKey([]byte(key))})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("etcd: reading %s: %s", key, resp.Status)
+	}
+
+	var out struct {
+		Kvs []struct {
+			Value string `json:"value"`
+		} `json:"kvs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("etcd: reading %s: %w", key, err)
+	}
+	if len(out.Kvs) == 0 {
+		return nil, nil
+	}
+	value, err := base64.StdEncoding.DecodeString(out.Kvs[0].Value)
+	if err != nil {
+		return nil, fmt.Errorf("etcd: reading %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// Watch streams the events of the keys under prefix.
+func (p *etcdProvider) Watch(ctx context.Context, prefix string, changed func()) error {
+	resp, err := p.post(ctx, "/v3/watch", map[string]any{
+		"create_request": map[string]any{
+			"key":       encodeKey([]byte(prefix)),
+			"range_end": encodeKey(prefixEnd([]byte(prefix))),
+		},
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("etcd: watching %s: %s", prefix, resp.Status)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var msg struct {
+			Result struct {
+				Canceled     bool              `json:"canceled"`
+				CancelReason string            `json:"cancel_reason"`
+				Events       []json.RawMessage `json:"events"`
+			} `json:"result"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := dec.Decode(&msg); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("etcd: watching %s: stream closed", prefix)
+			}
+			return fmt.Errorf("etcd: watching %s: %w", prefix, err)
+		}
+		switch {
+		case msg.Error != nil:
+			return fmt.Errorf("etcd: watching %s: %s", prefix, msg.Error.Message)
+		case msg.Result.Canceled:
+			return fmt.Errorf("etcd: watching %s: canceled: %s", prefix, msg.Result.CancelReason)
+		case len(msg.Result.Events) > 0:
+			changed()
+		}
+	}
+}
+
+func (p *etcdProvider) post(ctx context.Context, path string, body any) (*http.Response, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+path, bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", p.token)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("etcd: %w", err)
+	}
+	return resp, nil
+}
+
+func encodeKey(key []byte) string {
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// prefixEnd returns the end of the range of the keys starting with prefix,
+// like clientv3.GetPrefixRangeEnd.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return []byte{0}
+}
+
+// remoteLabel names the document key of the store of cfg in the sources of
+// the configurations: "consul:voyago/config.yaml".
+func remoteLabel(cfg RemoteConfig, key string) string {
+	return cfg.Provider + ":" + key
+}
+
+// remoteStore is the store of remote.provider, set by the loading of the
+// global configuration.
+var remoteStore struct {
+	provider RemoteProvider
+	cfg      RemoteConfig
+	// global are the settings of the global document, merged again over the
+	// domain files.
+	global map[string]any
+}
+
+// Remote returns the store the configurations are read from and the prefix of
+// its documents, to watch them (see package reload); nil without
+// remote.provider.
+func Remote() (RemoteProvider, string) {
+	if remoteStore.provider == nil {
+		return nil, ""
+	}
+	return remoteStore.provider, strings.TrimSuffix(RemoteKey(remoteStore.cfg.Path, ""), "config.yaml")
+}
+
+// mergeRemote merges the global document of the store configured by the
+// remote section of v over its settings, and returns the label of the
+// document, empty without store or document.
+func mergeRemote(v *viper.Viper) (string, error) {
+	var cfg RemoteConfig
+	if err := v.UnmarshalKey("remote", &cfg); err != nil {
+		return "", fmt.Errorf("unable to decode remote config: %w", err)
+	}
+	provider, err := NewRemoteProvider(cfg)
+	if err != nil {
+		return "", fmt.Errorf("invalid remote configuration: %w", err)
+	}
+	remoteStore.provider, remoteStore.cfg, remoteStore.global = provider, cfg, nil
+	if provider == nil {
+		return "", nil
+	}
+
+	settings, label, err := readRemote(RemoteKey(cfg.Path, ""))
+	if err != nil {
+		return "", err
+	}
+	remoteStore.global = settings
+	return label, mergeSettings(v, settings)
+}
+
+// mergeRemoteDomain merges the global document of the store, then the one of
+// domain, over the settings of v, and returns the label of the domain
+// document.
+func mergeRemoteDomain(v *viper.Viper, domain string) (string, error) {
+	if remoteStore.provider == nil {
+		return "", nil
+	}
+	if err := mergeSettings(v, remoteStore.global); err != nil {
+		return "", err
+	}
+	if domain == "" {
+		return "", nil
+	}
+	settings, label, err := readRemote(RemoteKey(remoteStore.cfg.Path, domain))
+	if err != nil {
+		return "", err
+	}
+	return label, mergeSettings(v, settings)
+}
+
+// readRemote reads and parses the document key of the store, nil when
+// missing. With remote.optional, an unreachable store reads as a missing
+// document, labelled unreachable.
+func readRemote(key string) (map[string]any, string, error) {
+	cfg := remoteStore.cfg
+	label := remoteLabel(cfg, key)
+	raw, err := remoteStore.provider.Get(context.Background(), key)
+	if err != nil {
+		if cfg.Optional {
+			return nil, label + " (unreachable)", nil
+		}
+		return nil, "", fmt.Errorf("error reading remote config %s: %w", label, err)
+	}
+	if raw == nil {
+		return nil, "", nil
+	}
+
+	r := viper.New()
+	r.SetConfigType("yaml")
+	if err := r.ReadConfig(strings.NewReader(expand(string(raw)))); err != nil {
+		return nil, "", fmt.Errorf("error parsing remote config %s: %w", label, err)
+	}
+	return r.AllSettings(), label, nil
+}
+
+func mergeSettings(v *viper.Viper, settings map[string]any) error {
+	if settings == nil {
+		return nil
+	}
+	if err := v.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("error merging remote config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package config
+
+type SchedulerConfig struct {
+	// LeaderElection runs the scheduled jobs on a single replica: the one
+	// holding the leadership lock. Disabled, every replica runs them.
+	LeaderElection struct {
+		Enabled bool `mapstructure:"enabled"`
+		// Driver is "redis" (default, uses the redis section) or "postgres"
+		// (an advisory lock on the database of Domain).
+		Driver string `mapstructure:"driver"`
+		// Domain is the module whose database holds the lock of the postgres
+		// driver (default "booking").
+		Domain string `mapstructure:"domain"`
+		// Key is the lock of the leadership (default "scheduler:leader").
+		Key string `mapstructure:"key"`
+		// TTL is how long the leadership outlives a crashed leader, in
+		// seconds (default 15).
+		TTL int `mapstructure:"ttl"`
+	} `mapstructure:"leader_election"`
+}
+
+const (
+	LeaderElectionDriverRedis    = "redis"
+	LeaderElectionDriverPostgres = "postgres"
+)
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// SectionValidator is implemented by the settings decoded by Section checking
+// their values. The paths of the problems are relative to the section
+// ("checkout.timeout" for booking.checkout.timeout).
+type SectionValidator interface {
+	Validate() []Problem
+}
+
+// sections are the settings of a configuration outside Config, kept by the
+// loaders for Section: the settings of the modules.
+type sections struct {
+	values map[string]any
+	// source is the file they were read from, for the validation errors.
+	source string
+	// envPrefixes are the prefixes of the variables of the sections, by
+	// increasing precedence, in env mode (see EnvOnly).
+	envPrefixes []string
+}
+
+// configKeys are the sections decoded into Config.
+var configKeys = func() []string {
+	t := reflect.TypeFor[Config]()
+	keys := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		if key, _, _ := strings.Cut(t.Field(i).Tag.Get("mapstructure"), ","); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}()
+
+// keepSections keeps the settings of v outside Config, read from source.
+func (c *Config) keepSections(v *viper.Viper, source string, envPrefixes ...string) {
+	values := map[string]any{}
+	for key, value := range v.AllSettings() {
+		if !slices.Contains(configKeys, key) {
+			values[key] = value
+		}
+	}
+	c.sections = sections{values: values, source: source, envPrefixes: envPrefixes}
+}
+
+// Source returns where the configuration was read from: its file merged with
+// its profile ("config/config.yaml + config/config.production.yaml"), or the
+// environment in env mode. It is empty for a configuration built in memory.
+func (c *Config) Source() string {
+	return c.sections.source
+}
+
+// Sections returns the settings outside Config by section, e.g. the settings
+// of the modules (see Section), as read from the files. The result is a copy.
+func (c *Config) Sections() map[string]any {
+	return maps.Clone(c.sections.values)
+}
+
+// Section decodes the section key of the configuration, a section outside
+// Config such as the settings of a module (booking: ...), into target, a
+// pointer to a struct with mapstructure tags. The settings absent from the
+// file keep the values of target. In env mode, the section is read from the
+// variables of its key, like the settings of Config: BOOKING_CHECKOUT_TIMEOUT,
+// or BOOKING__BOOKING_CHECKOUT_TIMEOUT for the booking domain only.
+//
+// When target is a SectionValidator, the decoded settings are validated; the
+// problems are returned as a *ValidationError.
+//
+// Example:
+//
+//	var settings booking.Settings
+//	if err := cfg.Section("booking", &settings); err != nil { ... }
+func (c *Config) Section(key string, target any) error {
+	if slices.Contains(configKeys, key) {
+		return fmt.Errorf("section %q is decoded into Config", key)
+	}
+	t := reflect.TypeOf(target)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("section %q: target must be a pointer to a struct, got %T", key, target)
+	}
+
+	v := viper.New()
+	if value, ok := c.sections.values[key]; ok {
+		if err := v.MergeConfigMap(map[string]any{key: value}); err != nil {
+			return fmt.Errorf("error reading section %s: %w", key, err)
+		}
+	}
+	if len(c.sections.envPrefixes) > 0 {
+		env := environ()
+		for _, prefix := range c.sections.envPrefixes {
+			value := envValue(env, prefix+strings.ToUpper(key), t.Elem())
+			if value == nil {
+				continue
+			}
+			if err := v.MergeConfigMap(map[string]any{key: value}); err != nil {
+				return fmt.Errorf("error reading section %s from the environment: %w", key, err)
+			}
+		}
+	}
+	if err := v.UnmarshalKey(key, target); err != nil {
+		return fmt.Errorf("unable to decode section %s: %w", key, err)
+	}
+
+	validator, ok := target.(SectionValidator)
+	if !ok {
+		return nil
+	}
+	problems := validator.Validate()
+	if len(problems) == 0 {
+		return nil
+	}
+	for i := range problems {
+		problems[i].Path = key + "." + problems[i].Path
+	}
+	return &ValidationError{Source: c.sections.source, Problems: problems}
+}
@@ -0,0 +1,59 @@
+package config
+
+type SecurityConfig struct {
+	Headers SecurityHeadersConfig `mapstructure:"headers"`
+	CORS    CORSConfig            `mapstructure:"cors"`
+	CSRF    CSRFConfig            `mapstructure:"csrf"`
+	Gateway GatewayConfig         `mapstructure:"gateway"`
+}
+
+// GatewayConfig identifies the API gateway authenticating the callers, the
+// only one trusted with the identity headers (see gateway.Trust). Without
+// secret nor trusted IPs, the identity headers are ignored.
+type GatewayConfig struct {
+	// Secret is shared with the gateway, which sends it in X-Gateway-Secret.
+	Secret string `mapstructure:"secret"`
+	// TrustedIPs are the IPs or CIDRs the gateway connects from.
+	TrustedIPs []string `mapstructure:"trusted_ips"`
+}
+
+type SecurityHeadersConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// ContentSecurityPolicy is sent as is, the header is omitted when empty.
+	ContentSecurityPolicy string `mapstructure:"content_security_policy"`
+	FrameOptions          string `mapstructure:"frame_options"`   // defaults to "DENY"
+	ReferrerPolicy        string `mapstructure:"referrer_policy"` // defaults to "no-referrer"
+	// HSTSMaxAge is the max-age of Strict-Transport-Security, in seconds
+	// (0 omits the header). Only enable it where the API is served over HTTPS.
+	HSTSMaxAge            int  `mapstructure:"hsts_max_age"`
+	HSTSIncludeSubdomains bool `mapstructure:"hsts_include_subdomains"`
+	HSTSPreload           bool `mapstructure:"hsts_preload"`
+}
+
+type CORSConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// AllowedOrigins lists the origins allowed to call the API (a comma
+	// separated string is accepted); "*" allows every origin.
+	AllowedOrigins   []string `mapstructure:"allowed_origins"`
+	AllowedMethods   []string `mapstructure:"allowed_methods"`
+	AllowedHeaders   []string `mapstructure:"allowed_headers"`
+	ExposedHeaders   []string `mapstructure:"exposed_headers"`
+	AllowCredentials bool     `mapstructure:"allow_credentials"` // cookies, not allowed with the "*" origin
+	MaxAge           int      `mapstructure:"max_age"`           // in seconds, caching of preflight responses
+}
+
+// CSRFConfig protects cookie-authenticated requests with a double submit
+// cookie: unsafe methods must echo the cookie in a header.
+type CSRFConfig struct {
+	Enabled        bool   `mapstructure:"enabled"`
+	CookieName     string `mapstructure:"cookie_name"`      // defaults to "csrf_token"
+	HeaderName     string `mapstructure:"header_name"`      // defaults to "X-CSRF-Token"
+	CookieDomain   string `mapstructure:"cookie_domain"`    // empty scopes the cookie to the API host
+	CookieSecure   bool   `mapstructure:"cookie_secure"`    // only sent over HTTPS
+	CookieSameSite string `mapstructure:"cookie_same_site"` // "Lax" (default), "Strict" or "None"
+	Expiration     int    `mapstructure:"expiration"`       // in seconds, lifetime of the cookie (default 12 hours)
+	// ExemptPaths are path prefixes never checked (e.g., callbacks of third
+	// parties). Requests carrying an Authorization header are never checked
+	// either: they do not rely on cookies.
+	ExemptPaths []string `mapstructure:"exempt_paths"`
+}
@@ -0,0 +1,11 @@
+package config
+
+type ShutdownConfig struct {
+	// GracePeriod bounds the draining of the application: in-flight requests,
+	// event handlers and background workers (in seconds, default 30). Keep it
+	// below the termination grace period of the orchestrator.
+	GracePeriod int `mapstructure:"grace_period"`
+	// CloseTimeout bounds the closing of connections (databases, caches) and
+	// the flush of telemetry once drained (in seconds, default 5).
+	CloseTimeout int `mapstructure:"close_timeout"`
+}
@@ -0,0 +1,7 @@
+package config
+
+type SSEConfig struct {
+	HeartbeatInterval int `mapstructure:"heartbeat_interval"` // in seconds, keeps idle streams alive through proxies
+	WriteTimeout      int `mapstructure:"write_timeout"`      // in seconds, per event; slow clients are disconnected
+	BufferSize        int `mapstructure:"buffer_size"`        // events queued per connection before it is dropped
+}
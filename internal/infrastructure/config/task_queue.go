@@ -0,0 +1,35 @@
+package config
+
+type TaskQueueConfig struct {
+	// Enabled processes the tasks enqueued by the use cases (see package
+	// taskqueue). Disabled, the tasks are not enqueued.
+	Enabled bool `mapstructure:"enabled"`
+	// Driver is "memory" (default, the tasks are lost on restart and only
+	// processed by the process enqueuing them) or "redis" (shared by every
+	// instance, uses the redis section).
+	Driver string `mapstructure:"driver"`
+	// Prefix is prepended to the keys stored in Redis (default "voyago:tasks:").
+	Prefix string `mapstructure:"prefix"`
+	// Queues are processed in this order: the tasks of a queue run before
+	// the due tasks of the next ones (default ["default"]).
+	Queues []string `mapstructure:"queues"`
+	// Concurrency is the number of tasks run at once (default 10).
+	Concurrency  int `mapstructure:"concurrency"`
+	PollInterval int `mapstructure:"poll_interval"` // in milliseconds between two polls of empty queues (default 1000)
+	// Lease is how long a task may run, in seconds, before it is cancelled
+	// and handed to another processor (default 60).
+	Lease int `mapstructure:"lease"`
+	Retry struct {
+		MaxRetry    int `mapstructure:"max_retry"`    // retries of a failed task before it is dead, unless set by the task (default 5)
+		BaseBackoff int `mapstructure:"base_backoff"` // in seconds before the first retry, doubled on every retry (default 10)
+		MaxBackoff  int `mapstructure:"max_backoff"`  // in seconds (default 3600)
+	} `mapstructure:"retry"`
+	// DeadRetention is how long the dead tasks are kept, in hours, to be
+	// inspected (default 168).
+	DeadRetention int `mapstructure:"dead_retention"`
+}
+
+const (
+	TaskQueueDriverMemory = "memory"
+	TaskQueueDriverRedis  = "redis"
+)
@@ -7,4 +7,76 @@ type TelemetryConfig struct {
 	TracerAddress  string  `mapstructure:"tracer_address"`
 	Namespace      string  `mapstructure:"namespace"`
 	SampleRate     float64 `mapstructure:"sample_rate"`
+	// Sampling refines SampleRate per span name and keeps the failed and
+	// slow spans.
+	Sampling TraceSamplingConfig `mapstructure:"sampling"`
+	// DegradedMode keeps the application starting with no-op metrics or
+	// traces when their exporter fails to start, instead of exiting.
+	DegradedMode bool `mapstructure:"degraded_mode"`
+	// StatsDFallback is a StatsD/DogStatsD address (e.g., "127.0.0.1:8125")
+	// the metrics are sent to over UDP when the exporter fails to start with
+	// DegradedMode, instead of being discarded.
+	StatsDFallback string `mapstructure:"statsd_fallback"`
+	// Prometheus configures the scrape endpoint of the "prometheus" type.
+	Prometheus PrometheusConfig `mapstructure:"prometheus"`
+	// Buffer records the metrics in the background (ignored by the
+	// "prometheus" type, which records in memory).
+	Buffer MetricsBufferConfig `mapstructure:"buffer"`
+	// DBStatsInterval is how often the connection pool statistics of the
+	// domain databases and of Redis are recorded, in seconds (default 15).
+	DBStatsInterval int `mapstructure:"db_stats_interval"`
+	// Cardinality bounds the tags of the metrics.
+	Cardinality MetricsCardinalityConfig `mapstructure:"cardinality"`
+}
+
+type TraceSamplingConfig struct {
+	// Rules override SampleRate for the root spans whose name matches; the
+	// first matching rule applies.
+	Rules []TraceSamplingRule `mapstructure:"rules"`
+	// KeepErrors keeps the failed spans whatever the sampling rate.
+	KeepErrors bool `mapstructure:"keep_errors"`
+	// SlowThreshold keeps the spans lasting at least this long whatever the
+	// sampling rate, in milliseconds (0 disables).
+	SlowThreshold int `mapstructure:"slow_threshold"`
+}
+
+type TraceSamplingRule struct {
+	// Name is a span name pattern, where "*" matches any characters and "?"
+	// a single one (e.g., "HTTP GET /health", "gRPC /booking.v1.*").
+	Name string `mapstructure:"name"`
+	// Rate is the sampling rate of the matching spans, from 0 to 1.
+	Rate float64 `mapstructure:"rate"`
+}
+
+type MetricsBufferConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Size is the number of recordings queued before new ones are dropped
+	// (default 10000).
+	Size int `mapstructure:"size"`
+}
+
+type PrometheusConfig struct {
+	// Path of the scrape endpoint (default "/metrics").
+	Path string `mapstructure:"path"`
+	// Address (e.g., ":9090") serves the endpoint on its own listener, kept
+	// off the public port. Empty serves it on the HTTP port; the gRPC server
+	// needs an address.
+	Address string `mapstructure:"address"`
+}
+
+type MetricsCardinalityConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MaxSeries caps the unique tag combinations of a metric (default 1000);
+	// the values of the combinations beyond it are replaced by "other".
+	MaxSeries int `mapstructure:"max_series"`
+	// Allowlist restricts the tag keys of metrics; a metric without entry
+	// keeps all its tags.
+	Allowlist []MetricTagAllowlist `mapstructure:"allowlist"`
+}
+
+type MetricTagAllowlist struct {
+	// Metric is the name as recorded (e.g., "http_client_request_duration").
+	Metric string `mapstructure:"metric"`
+	// Tags are the allowed tag keys (e.g., "host,method,status").
+	Tags []string `mapstructure:"tags"`
 }
@@ -0,0 +1,27 @@
+package config
+
+// TenancyConfig resolves the tenant of every request (see package tenancy).
+// The tenant scopes the rows of the tenant-scoped tables and selects the
+// configuration overrides of the tenant.
+type TenancyConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Resolvers are tried in order until one finds the tenant: "header",
+	// "subdomain" or "claim" (defaults to "header").
+	Resolvers  []string `mapstructure:"resolvers"`
+	Header     string   `mapstructure:"header"`      // read by the header resolver (default "X-Tenant-ID")
+	BaseDomain string   `mapstructure:"base_domain"` // subdomain resolver, e.g. "voyago.com" resolves acme.voyago.com to acme
+	Claim      string   `mapstructure:"claim"`       // claim of the bearer JWT read by the claim resolver (default "tenant_id")
+	// Required rejects the requests whose tenant is not resolved, except on
+	// the health routes, the admin routes and the ExemptPaths prefixes.
+	Required    bool     `mapstructure:"required"`
+	ExemptPaths []string `mapstructure:"exempt_paths"`
+	// Overrides are configuration sections replaced for a tenant, keyed by
+	// tenant ID (lowercased), e.g. {acme: {webhook: {retry: {max_attempts: 10}}}}.
+	Overrides map[string]map[string]any `mapstructure:"overrides"`
+}
+
+const (
+	TenantResolverHeader    = "header"
+	TenantResolverSubdomain = "subdomain"
+	TenantResolverClaim     = "claim"
+)
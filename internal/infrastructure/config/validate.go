@@ -0,0 +1,300 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ValidationError reports every invalid setting of a configuration file at
+// once, by YAML path, instead of the first one failing at runtime.
+type ValidationError struct {
+	// Source is the configuration file, the domain one for a domain
+	// configuration (merged with the global file).
+	Source   string
+	Problems []Problem
+}
+
+// Problem is an invalid setting.
+type Problem struct {
+	// Path is the YAML path of the setting (e.g., "log.sinks[1].driver").
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration %s:", e.Source)
+	for _, p := range e.Problems {
+		fmt.Fprintf(&b, "\n  - %s: %s", p.Path, p.Message)
+	}
+	return b.String()
+}
+
+// Validate checks the settings shared by every process: the required ones,
+// the ports and ranges, and the values of the enumerations (log driver,
+// telemetry type, ...). The empty settings documented with a default are
+// valid. It returns a *ValidationError listing every problem.
+func (c *Config) Validate() error {
+	var v validation
+	c.validateGlobal(&v)
+	return v.err()
+}
+
+// ValidateDomain is Validate checking the settings of the domains too:
+// database, cache and IDs.
+func (c *Config) ValidateDomain() error {
+	var v validation
+	c.validateGlobal(&v)
+	c.validateDomain(&v)
+	return v.err()
+}
+
+func (c *Config) validateGlobal(v *validation) {
+	v.required("app.name", c.App.Name)
+
+	v.port("http.port", c.Http.Port)
+	v.nonNegative("http.body_limit", c.Http.BodyLimit)
+	v.oneOf("http.error_format", c.Http.ErrorFormat, "", ErrorFormatEnvelope, ErrorFormatProblem)
+	v.nonNegative("http.retry_after", c.Http.RetryAfter)
+	v.oneOf("http.compression.level", c.Http.Compression.Level, "",
+		CompressionLevelDefault, CompressionLevelBestSpeed, CompressionLevelBestCompression)
+	if c.Grpc.Port != 0 {
+		v.port("grpc.port", c.Grpc.Port)
+	}
+	v.address("worker.health_address", c.Worker.HealthAddress)
+
+	v.nonNegative("reload.debounce", c.Reload.Debounce)
+	v.oneOf("remote.provider", c.Remote.Provider, "", RemoteProviderConsul, RemoteProviderEtcd)
+	if c.Remote.Provider != "" {
+		v.required("remote.endpoint", c.Remote.Endpoint)
+	}
+	v.nonNegative("remote.timeout", c.Remote.Timeout)
+	if c.Kafka.Enabled && len(c.Kafka.Brokers) == 0 {
+		v.add("kafka.brokers", "is required when kafka is enabled")
+	}
+	for i, addr := range c.Kafka.Brokers {
+		v.address(fmt.Sprintf("kafka.brokers[%d]", i), addr)
+	}
+	v.nonNegative("shutdown.grace_period", c.Shutdown.GracePeriod)
+	v.nonNegative("shutdown.close_timeout", c.Shutdown.CloseTimeout)
+
+	v.oneOf("task_queue.driver", c.TaskQueue.Driver, "", TaskQueueDriverMemory, TaskQueueDriverRedis)
+	v.nonNegative("task_queue.concurrency", c.TaskQueue.Concurrency)
+	v.oneOf("scheduler.leader_election.driver", c.Scheduler.LeaderElection.Driver, "",
+		LeaderElectionDriverRedis, LeaderElectionDriverPostgres)
+	c.FeatureFlags.validate(v)
+	c.ErrorReporting.validate(v)
+
+	v.oneOf("redis.mode", c.Redis.Mode, "", RedisModeStandalone, RedisModeSentinel, RedisModeCluster)
+	if c.Redis.Port != 0 {
+		v.port("redis.port", c.Redis.Port)
+	}
+	switch c.Redis.Mode {
+	case RedisModeSentinel:
+		v.required("redis.master_name", c.Redis.MasterName)
+		fallthrough
+	case RedisModeCluster:
+		if len(c.Redis.Addrs) == 0 {
+			v.add("redis.addrs", "is required in %s mode", c.Redis.Mode)
+		}
+	}
+	for i, addr := range c.Redis.Addrs {
+		v.address(fmt.Sprintf("redis.addrs[%d]", i), addr)
+	}
+
+	if c.Telemetry.Enabled {
+		v.oneOf("telemetry.type", c.Telemetry.Type, "", "datadog", "otel", "prometheus", "statsd")
+	}
+	v.rate("telemetry.sample_rate", c.Telemetry.SampleRate)
+	for i, rule := range c.Telemetry.Sampling.Rules {
+		v.required(fmt.Sprintf("telemetry.sampling.rules[%d].name", i), rule.Name)
+		v.rate(fmt.Sprintf("telemetry.sampling.rules[%d].rate", i), rule.Rate)
+	}
+	v.address("telemetry.statsd_fallback", c.Telemetry.StatsDFallback)
+	v.nonNegative("telemetry.db_stats_interval", c.Telemetry.DBStatsInterval)
+
+	c.Log.validate(v)
+}
+
+func (c *FeatureFlagsConfig) validate(v *validation) {
+	v.oneOf("feature_flags.driver", c.Driver, "",
+		FeatureFlagDriverConfig, FeatureFlagDriverUnleash, FeatureFlagDriverLaunchDarkly)
+	v.nonNegative("feature_flags.refresh_interval", c.RefreshInterval)
+	switch c.Driver {
+	case FeatureFlagDriverUnleash:
+		v.required("feature_flags.unleash.url", c.Unleash.URL)
+		v.required("feature_flags.unleash.api_token", c.Unleash.APIToken)
+	case FeatureFlagDriverLaunchDarkly:
+		v.required("feature_flags.launchdarkly.sdk_key", c.LaunchDarkly.SDKKey)
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Flags)) {
+		if p := c.Flags[name].Percentage; p < 0 || p > 100 {
+			v.add("feature_flags.flags."+name+".percentage", "must be between 0 and 100, got %d", p)
+		}
+	}
+}
+
+func (c *ErrorReportingConfig) validate(v *validation) {
+	if !c.Enabled {
+		return
+	}
+	v.oneOf("error_reporting.driver", c.Driver, "", ErrorReportingDriverSentry)
+	v.nonNegative("error_reporting.queue_size", c.QueueSize)
+	v.nonNegative("error_reporting.timeout", c.Timeout)
+	if c.Driver == ErrorReportingDriverSentry || c.Driver == "" {
+		v.required("error_reporting.sentry.dsn", c.Sentry.DSN)
+	}
+}
+
+// logLevels are the level names of log.levels and log.sinks.
+var logLevels = []string{"trace", "debug", "info", "warn", "error"}
+
+func (c *LogConfig) validate(v *validation) {
+	v.oneOf("log.driver", c.Driver, "", "stdout", "logrus", "zap", "zerolog", "otel", "tee")
+	if c.Level < 0 || c.Level > 6 {
+		v.add("log.level", "must be between 0 (panic) and 6 (trace), got %d", c.Level)
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Levels)) {
+		v.oneOf("log.levels."+name, c.Levels[name], logLevels...)
+	}
+	for i, s := range c.Sinks {
+		v.oneOf(fmt.Sprintf("log.sinks[%d].driver", i), s.Driver, "stdout", "logrus", "zap", "zerolog", "otel")
+		v.oneOf(fmt.Sprintf("log.sinks[%d].level", i), s.Level, append([]string{""}, logLevels...)...)
+	}
+	for _, key := range slices.Sorted(maps.Keys(c.Masking.Strategies)) {
+		v.oneOf("log.masking.strategies."+key, strings.ToLower(c.Masking.Strategies[key]), "redact", "last4", "sha256", "truncate")
+	}
+	for i, p := range c.Masking.Patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			v.add(fmt.Sprintf("log.masking.patterns[%d]", i), "is not a regular expression: %v", err)
+		}
+	}
+}
+
+func (c *Config) validateDomain(v *validation) {
+	db := c.Database
+	v.oneOf("database.driver", db.Driver, "", DatabaseDriverPostgres, DatabaseDriverMySQL, DatabaseDriverSQLite)
+	switch {
+	case db.Connection != "":
+		if _, err := db.ForConnection(); err != nil {
+			v.add("database.connection", "%v", err)
+		}
+	case db.Driver == DatabaseDriverSQLite:
+		v.required("database.name", db.Name)
+	default:
+		v.required("database.host", db.Host)
+		v.port("database.port", db.Port)
+		v.required("database.name", db.Name)
+	}
+	db.Pool.validate(v, "database.pool")
+	for _, name := range slices.Sorted(maps.Keys(db.Connections)) {
+		db.Connections[name].validate(v, "database.connections."+name)
+	}
+	for i, r := range db.Replicas {
+		r.validate(v, fmt.Sprintf("database.replicas[%d]", i))
+	}
+	for _, tenant := range slices.Sorted(maps.Keys(db.Tenants)) {
+		db.Tenants[tenant].validate(v, "database.tenants."+tenant)
+	}
+	v.nonNegative("database.retry.max_attempts", db.Retry.MaxAttempts)
+	v.nonNegative("database.circuit_breaker.failure_threshold", db.CircuitBreaker.FailureThreshold)
+	v.nonNegative("database.timeouts.statement", db.Timeouts.Statement)
+	v.nonNegative("database.timeouts.lock", db.Timeouts.Lock)
+
+	v.oneOf("cache.driver", c.Cache.Driver, "", CacheDriverMemory, CacheDriverRedis)
+	v.nonNegative("cache.max_entries", c.Cache.MaxEntries)
+	v.oneOf("ids.generator", c.IDs.Generator, "", "uuidv7", "ulid")
+}
+
+func (e DatabaseEndpointConfig) validate(v *validation, path string) {
+	if e.Port != 0 {
+		v.port(path+".port", e.Port)
+	}
+	e.Pool.validate(v, path+".pool")
+}
+
+func (p DatabasePoolConfig) validate(v *validation, path string) {
+	v.nonNegative(path+".idle", p.Idle)
+	v.nonNegative(path+".max", p.Max)
+	v.nonNegative(path+".lifetime", p.Lifetime)
+	if p.Max > 0 && p.Idle > p.Max {
+		v.add(path+".idle", "must not exceed %s.max (%d), got %d", path, p.Max, p.Idle)
+	}
+}
+
+// validation collects the problems of a configuration.
+type validation struct {
+	problems []Problem
+}
+
+func (v *validation) add(path, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns the problems as a *ValidationError, nil when there is none.
+// The loaders set its source.
+func (v *validation) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+func (v *validation) required(path, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(path, "is required")
+	}
+}
+
+func (v *validation) port(path string, port int) {
+	if port < 1 || port > 65535 {
+		v.add(path, "must be a port between 1 and 65535, got %d", port)
+	}
+}
+
+func (v *validation) nonNegative(path string, n int) {
+	if n < 0 {
+		v.add(path, "must not be negative, got %d", n)
+	}
+}
+
+func (v *validation) rate(path string, r float64) {
+	if r < 0 || r > 1 {
+		v.add(path, "must be between 0 and 1, got %s", strconv.FormatFloat(r, 'f', -1, 64))
+	}
+}
+
+// oneOf checks that value is one of allowed; "" in allowed makes the setting
+// optional.
+func (v *validation) oneOf(path, value string, allowed ...string) {
+	if slices.Contains(allowed, value) {
+		return
+	}
+	quoted := make([]string, 0, len(allowed))
+	for _, a := range allowed {
+		if a != "" {
+			quoted = append(quoted, strconv.Quote(a))
+		}
+	}
+	v.add(path, "must be one of %s, got %q", strings.Join(quoted, ", "), value)
+}
+
+// address checks an optional "host:port" address.
+func (v *validation) address(path, addr string) {
+	if addr == "" {
+		return
+	}
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		v.add(path, "must be a host:port address, got %q", addr)
+		return
+	}
+	if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+		v.add(path, "must be a host:port address, got %q", addr)
+	}
+}
@@ -0,0 +1,19 @@
+package config
+
+type WebhookConfig struct {
+	// Timeout is the per-attempt HTTP timeout in seconds.
+	Timeout int `mapstructure:"timeout"`
+	// AllowInternalTargets lets the endpoints use http and target localhost
+	// and the private addresses, for the local development and the tests
+	// only: the receivers are otherwise https URLs of public hosts.
+	AllowInternalTargets bool `mapstructure:"allow_internal_targets"`
+	Worker               struct {
+		Interval  int `mapstructure:"interval"`   // polling interval in seconds
+		BatchSize int `mapstructure:"batch_size"` // deliveries claimed per tick
+	} `mapstructure:"worker"`
+	Retry struct {
+		MaxAttempts int `mapstructure:"max_attempts"`
+		BaseBackoff int `mapstructure:"base_backoff"` // in seconds, doubled on every attempt
+		MaxBackoff  int `mapstructure:"max_backoff"`  // in seconds
+	} `mapstructure:"retry"`
+}
@@ -0,0 +1,13 @@
+package config
+
+type WebsocketConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Path    string `mapstructure:"path"`   // defaults to /ws
+	Secret  string `mapstructure:"secret"` // signs the connection tokens
+
+	AllowedOrigins []string `mapstructure:"allowed_origins"` // empty allows every origin
+	PingInterval   int      `mapstructure:"ping_interval"`   // in seconds, a missing pong closes the socket
+	WriteTimeout   int      `mapstructure:"write_timeout"`   // in seconds, per message
+	BufferSize     int      `mapstructure:"buffer_size"`     // messages queued per socket before it is dropped
+	DrainTimeout   int      `mapstructure:"drain_timeout"`   // in seconds, time given to sockets to close on shutdown
+}
@@ -0,0 +1,10 @@
+package config
+
+type WorkerConfig struct {
+	// Standalone moves the background work (broker consumers, webhook
+	// deliveries, scheduled jobs) to cmd/worker: the HTTP and gRPC servers
+	// no longer run it. The in-process event handlers stay in every process.
+	Standalone bool `mapstructure:"standalone"`
+	// HealthAddress is where cmd/worker serves /health (default ":8081").
+	HealthAddress string `mapstructure:"health_address"`
+}
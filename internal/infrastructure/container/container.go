@@ -0,0 +1,57 @@
+// Package container wires the dependencies of the modules with fx. A module
+// declares its constructors (repositories, services, use cases) and the
+// container calls them in dependency order; a missing or ambiguous
+// dependency fails the startup with the path of the constructor needing it,
+// instead of the nil dereference of a forgotten argument.
+//
+// The graph runs no application: it is built once per transport, when the
+// module is registered. The constructors starting background work register
+// their OnStart and OnStop hooks on the fx.Lifecycle they receive; the
+// OnStop hooks run with the shutdown hooks of the application (see package
+// lifecycle).
+//
+// Example:
+//
+//	var uc usecase.CreateBookingUseCase
+//	container.Build(env.Lifecycle, "booking",
+//		container.Supply(env.DB),
+//		fx.Provide(command.NewBookingRepository, usecase.NewCreateBookingUseCase),
+//		fx.Populate(&uc),
+//	)
+package container
+
+import (
+	"context"
+	"fmt"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+
+	"go.uber.org/fx"
+)
+
+// Supply provides v as T. Unlike fx.Supply, which provides the dynamic type
+// of its values, it provides interfaces, nil ones included (e.g. the task
+// enqueuer of a disabled task queue).
+func Supply[T any](v T) fx.Option {
+	return fx.Provide(func() T { return v })
+}
+
+// Build calls the constructors of opts needed by their invocations
+// (fx.Invoke, fx.Populate), then the OnStart hooks. The OnStop hooks run in
+// the workers phase of lc, before the databases are closed; lc may be nil
+// when the graph registers no hook. name identifies the graph in the errors
+// and the shutdown logs.
+//
+// Build panics when a dependency is missing, a constructor or an OnStart hook
+// fails: the graph is only built at startup.
+func Build(lc *lifecycle.Manager, name string, opts ...fx.Option) {
+	app := fx.New(append([]fx.Option{fx.NopLogger}, opts...)...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), app.StartTimeout())
+	defer cancel()
+	if err := app.Start(ctx); err != nil {
+		panic(fmt.Errorf("invalid %s wiring: %w", name, err))
+	}
+	if lc != nil {
+		lc.Register(lifecycle.PhaseWorkers, name, app.Stop)
+	}
+}
@@ -1,22 +1,28 @@
 package ctxkey
 
-import "context"
+import (
+	"context"
+	"strings"
+)
 
-type key struct{}
+// key values must differ: equal keys would shadow each other in a context.
+type key int
 
-var (
-	kTx        = key{}
-	kRequestID = key{}
+const (
+	kTx key = iota
+	kRequestID
+	kUserID
+	kRoles
+	kTenantID
+	kClientApp
+	kReadPrimary
+	kLocales
 )
 
+const maxIdentifierLength = 128
+
 func GetRequestID(ctx context.Context) string {
-	if ctx == nil {
-		return ""
-	}
-	if id, ok := ctx.Value(kRequestID).(string); ok {
-		return id
-	}
-	return ""
+	return getString(ctx, kRequestID)
 }
 
 func GetTransaction(ctx context.Context) any {
@@ -33,3 +39,110 @@ func SetTransaction(ctx context.Context, tx any) context.Context {
 func SetRequestID(ctx context.Context, id string) context.Context {
 	return context.WithValue(ctx, kRequestID, id)
 }
+
+// GetUserID returns the authenticated user of the request, empty when
+// unknown.
+func GetUserID(ctx context.Context) string {
+	return getString(ctx, kUserID)
+}
+
+// SetUserID stores the authenticated user of the request.
+func SetUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, kUserID, id)
+}
+
+// GetRoles returns the roles of the authenticated user, nil when unknown.
+func GetRoles(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+	roles, _ := ctx.Value(kRoles).([]string)
+	return roles
+}
+
+// SetRoles stores the roles of the authenticated user.
+func SetRoles(ctx context.Context, roles []string) context.Context {
+	return context.WithValue(ctx, kRoles, roles)
+}
+
+// GetTenantID returns the tenant the request acts on, empty when unknown.
+func GetTenantID(ctx context.Context) string {
+	return getString(ctx, kTenantID)
+}
+
+// SetTenantID stores the tenant the request acts on.
+func SetTenantID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, kTenantID, id)
+}
+
+// GetClientApp returns the application that sent the request (e.g., the
+// mobile app, a partner integration), empty when unknown.
+func GetClientApp(ctx context.Context) string {
+	return getString(ctx, kClientApp)
+}
+
+// SetClientApp stores the application that sent the request.
+func SetClientApp(ctx context.Context, app string) context.Context {
+	return context.WithValue(ctx, kClientApp, app)
+}
+
+// GetReadPrimary reports whether the reads of ctx must be served by the
+// primary database rather than a replica.
+func GetReadPrimary(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	primary, _ := ctx.Value(kReadPrimary).(bool)
+	return primary
+}
+
+// SetReadPrimary makes the reads of ctx go to the primary database, so that
+// they see the writes just committed (read-your-writes) despite the
+// replication lag.
+func SetReadPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, kReadPrimary, true)
+}
+
+// GetLocales returns the locales the caller accepts, by preference (e.g.,
+// from Accept-Language), nil when unknown.
+func GetLocales(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+	locales, _ := ctx.Value(kLocales).([]string)
+	return locales
+}
+
+// SetLocales stores the locales the caller accepts, by preference.
+func SetLocales(ctx context.Context, locales []string) context.Context {
+	return context.WithValue(ctx, kLocales, locales)
+}
+
+func getString(ctx context.Context, k key) string {
+	if ctx == nil {
+		return ""
+	}
+	if v, ok := ctx.Value(k).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// IsValidIdentifier reports whether a caller-provided user ID, role, tenant
+// ID or client app may be stored as is: 1 to 128 characters among letters,
+// digits and "-_.:+/=@" (UUIDs, emails, service names). Anything else, e.g.
+// spaces or control characters that could forge log lines, is rejected.
+func IsValidIdentifier(id string) bool {
+	if id == "" || len(id) > maxIdentifierLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		switch c := id[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.IndexByte("-_.:+/=@", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
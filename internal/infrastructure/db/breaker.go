@@ -0,0 +1,149 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/breaker"
+	baserepo "voyago/core-api/internal/pkg/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultBreakerOpenTimeout = 30 * time.Second
+
+	metricCircuitState = "db_circuit_state"
+
+	breakerAllowedKey = "breaker:allowed"
+)
+
+// ErrDatabaseUnavailable fails the statements and transactions without
+// reaching the database while its circuit breaker is open.
+var ErrDatabaseUnavailable = apperror.NewTransient(apperror.CodeServiceUnavailable, "database unavailable, retry later")
+
+// breakerDatabase fails fast while its database keeps failing to connect.
+type breakerDatabase struct {
+	Database
+	breaker *breaker.Breaker
+}
+
+var _ readSplitter = (*breakerDatabase)(nil)
+
+// WithCircuitBreaker returns db behind a circuit breaker configured by cfg:
+// after cfg.FailureThreshold consecutive connection failures (see
+// IsConnectionError), the statements of WithContext and the transactions of
+// Atomic fail with ErrDatabaseUnavailable without reaching the database, for
+// cfg.OpenTimeout seconds; a single trial statement then decides whether the
+// circuit closes. The state of the circuit is recorded in the
+// db_circuit_state gauge (0 closed, 1 half-open, 2 open), tagged with the
+// domain. db itself is returned when cfg disables the breaker.
+//
+// The breaker guards the statements of db.GetDB(): the default database, not
+// the replicas nor the tenant databases.
+func WithCircuitBreaker(db Database, cfg config.DatabaseCircuitBreakerConfig, domain string, m metrics.Metrics) Database {
+	if cfg.FailureThreshold <= 0 {
+		return db
+	}
+
+	openTimeout := defaultBreakerOpenTimeout
+	if cfg.OpenTimeout > 0 {
+		openTimeout = time.Duration(cfg.OpenTimeout) * time.Second
+	}
+	var onChange func(breaker.State)
+	if m != nil {
+		tags := []string{"domain:" + domain}
+		onChange = func(s breaker.State) { m.Gauge(metricCircuitState, float64(s), tags) }
+		m.Gauge(metricCircuitState, float64(breaker.Closed), tags)
+	}
+
+	b := &breakerDatabase{
+		Database: db,
+		breaker:  breaker.New(cfg.FailureThreshold, openTimeout, onChange),
+	}
+	b.useCallbacks(db.GetDB())
+	return b
+}
+
+// useCallbacks registers GORM callbacks letting every statement through the
+// breaker and recording its outcome.
+func (b *breakerDatabase) useCallbacks(db *gorm.DB) {
+	before := func(tx *gorm.DB) {
+		if tx.Error != nil {
+			return
+		}
+		if !b.breaker.Allow() {
+			_ = tx.AddError(ErrDatabaseUnavailable)
+			return
+		}
+		tx.InstanceSet(breakerAllowedKey, true)
+	}
+	after := func(tx *gorm.DB) {
+		if _, ok := tx.InstanceGet(breakerAllowedKey); !ok {
+			return
+		}
+		b.record(tx.Statement.Context, tx.Error)
+	}
+
+	cb := db.Callback()
+	_ = cb.Create().Before("gorm:create").Register("breaker:before_create", before)
+	_ = cb.Query().Before("gorm:query").Register("breaker:before_query", before)
+	_ = cb.Update().Before("gorm:update").Register("breaker:before_update", before)
+	_ = cb.Delete().Before("gorm:delete").Register("breaker:before_delete", before)
+	_ = cb.Row().Before("gorm:row").Register("breaker:before_row", before)
+	_ = cb.Raw().Before("gorm:raw").Register("breaker:before_raw", before)
+
+	_ = cb.Create().After("gorm:create").Register("breaker:after_create", after)
+	_ = cb.Query().After("gorm:query").Register("breaker:after_query", after)
+	_ = cb.Update().After("gorm:update").Register("breaker:after_update", after)
+	_ = cb.Delete().After("gorm:delete").Register("breaker:after_delete", after)
+	_ = cb.Row().After("gorm:row").Register("breaker:after_row", after)
+	_ = cb.Raw().After("gorm:raw").Register("breaker:after_raw", after)
+}
+
+// Atomic fails fast while the circuit is open. The statements of the
+// transaction go through the breaker; so does the transaction itself when it
+// cannot begin.
+func (b *breakerDatabase) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	return b.AtomicWithOptions(ctx, baserepo.TxOptions{}, fn)
+}
+
+func (b *breakerDatabase) AtomicWithOptions(ctx context.Context, opts baserepo.TxOptions, fn func(ctx context.Context) error) error {
+	if b.breaker.State() == breaker.Open {
+		return ErrDatabaseUnavailable
+	}
+
+	begun := false
+	err := b.Database.AtomicWithOptions(ctx, opts, func(txCtx context.Context) error {
+		begun = true
+		return fn(txCtx)
+	})
+	if !begun && IsConnectionError(err) && ctx.Err() == nil {
+		b.breaker.Record(true)
+	}
+	return err
+}
+
+func (b *breakerDatabase) Reader() Database {
+	return Reader(b.Database)
+}
+
+// record counts the outcome of a statement allowed by the breaker.
+func (b *breakerDatabase) record(ctx context.Context, err error) {
+	if ctx != nil && ctx.Err() != nil {
+		// Canceled by the caller: says nothing about the database.
+		b.breaker.Release()
+		return
+	}
+	b.breaker.Record(IsConnectionError(err))
+}
+
+// IsConnectionError reports whether err, mapped by MapDBError, is a failure
+// to reach the database.
+func IsConnectionError(err error) bool {
+	var appErr *apperror.AppError
+	return errors.As(MapDBError(err), &appErr) && appErr.Code == apperror.CodeDbConnectionFailed
+}
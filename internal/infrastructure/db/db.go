@@ -6,6 +6,7 @@ package database
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"voyago/core-api/internal/infrastructure/config"
 	"voyago/core-api/internal/infrastructure/logger"
@@ -51,8 +52,37 @@ type Database interface {
 //   - cfg: Database connection and pooling settings.
 //   - log: Application logger to be used as a GORM log sink.
 //   - trc: Tracer for injecting OpenTelemetry hooks into database queries.
+//
+// When cfg.Replicas is set, the reads of the query repositories go to the
+// replicas (see Reader). When cfg.Tenants is set, the statements of these
+// tenants are routed to their own database (see NewRoutingDatabase).
+//
+// When cfg.Connection is set, the database gets a pool of its own on the
+// connection; a Pools shares it with the other domains naming it instead.
 func NewDatabase(cfg *config.DatabaseConfig, log logger.Logger, trc tracer.Tracer) Database {
-	return NewGormDatabase(cfg, log, trc)
+	if cfg.Connection != "" {
+		resolved, err := cfg.ForConnection()
+		if err != nil {
+			log.Error(fmt.Sprintf("failed to connect database: %v", err))
+			panic(err)
+		}
+		cfg = &resolved
+	}
+	return withReplicasAndTenants(NewGormDatabase(cfg, log, trc), cfg, log, trc)
+}
+
+// withReplicasAndTenants returns the database of cfg on primary, routing the
+// reads to the replicas and the statements of the tenants to their databases.
+func withReplicasAndTenants(db Database, cfg *config.DatabaseConfig, log logger.Logger, trc tracer.Tracer) Database {
+	var replicas []Database
+	for _, replica := range cfg.ForReplicas() {
+		replicas = append(replicas, NewGormDatabase(&replica, log, trc))
+	}
+	primary := NewReplicatedDatabase(db, replicas...)
+
+	return NewRoutingDatabase(primary, cfg, func(cfg *config.DatabaseConfig) (Database, error) {
+		return OpenGormDatabase(cfg, log, trc)
+	})
 }
 
 // --------- Error Mapping ---------
@@ -71,16 +101,35 @@ func MapDBError(err error) error {
 		return err
 	}
 
+	// 1b. Errors already mapped (schema guard, tenant routing, ...)
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return err
+	}
+
 	// 2. Handle System/Context errors
 	if errors.Is(err, context.DeadlineExceeded) {
 		return apperror.NewTransient(apperror.CodeDbTimeout, "database operation timed out", err)
 	}
+	if errors.Is(err, context.Canceled) {
+		return apperror.NewTransient(apperror.CodeDbTimeout, "database operation canceled", err)
+	}
 
 	// 3. Driver specific mappers (Postgres)
 	if pgErr := mapPgError(err); pgErr != nil {
 		return pgErr
 	}
 
+	// 3b. Driver specific mappers (MySQL)
+	if myErr := mapMySQLError(err); myErr != nil {
+		return myErr
+	}
+
+	// 3c. Driver specific mappers (SQLite, in-memory test mode and sqlite driver)
+	if sqliteErr := mapSQLiteError(err); sqliteErr != nil {
+		return sqliteErr
+	}
+
 	// 4. Default fallback for connection issues (string matching for dial errors)
 	msg := err.Error()
 	if strings.Contains(msg, "connection refused") ||
@@ -112,10 +161,18 @@ func mapPgError(err error) error {
 	case "40P01":
 		return apperror.NewTransient(apperror.CodeDbDeadlock, "database deadlock detected, please retry", pgErr)
 
+	// Serialization failures of REPEATABLE READ and SERIALIZABLE transactions
+	case "40001":
+		return apperror.NewTransient(apperror.CodeDbDeadlock, "database serialization failure, please retry", pgErr)
+
 	// Lock Timeouts
 	case "55P03":
 		return apperror.NewTransient(apperror.CodeDbTimeout, "database lock timeout", pgErr)
 
+	// Statement Timeouts (and statements canceled by the server)
+	case "57014":
+		return apperror.NewTransient(apperror.CodeDbTimeout, "database statement timeout", pgErr)
+
 	// --- Permanent Errors (Client Side / Data Issue) ---
 
 	// Unique Violation (e.g., duplicate email/code)
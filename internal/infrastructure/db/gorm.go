@@ -1,15 +1,22 @@
 package database
 
 import (
+	"cmp"
 	"context"
+	"database/sql"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 	"voyago/core-api/internal/infrastructure/config"
 	"voyago/core-api/internal/infrastructure/ctxkey"
 	"voyago/core-api/internal/infrastructure/logger"
 	"voyago/core-api/internal/infrastructure/telemetry/tracer"
-	"voyago/core-api/internal/pkg/utils"
+	baserepo "voyago/core-api/internal/pkg/repository"
+	"voyago/core-api/internal/pkg/sqlmask"
 
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	gormlog "gorm.io/gorm/logger"
@@ -17,44 +24,134 @@ import (
 
 type gormDatabase struct {
 	db *gorm.DB
+
+	// Default timeouts of the transactions, see setLocalTimeouts.
+	statementTimeout time.Duration
+	lockTimeout      time.Duration
+
+	// release replaces the closing of the pool for the databases on a
+	// shared pool, closed with the last of them (see Pools).
+	release func() error
 }
 
 var _ Database = (*gormDatabase)(nil)
 
 func NewGormDatabase(cfg *config.DatabaseConfig, log logger.Logger, trc tracer.Tracer) Database {
-	dsn := fmt.Sprintf(
-		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
-		cfg.Host,
-		cfg.Port,
-		cfg.User,
-		cfg.Password,
-		cfg.Name,
-	)
+	db, err := OpenGormDatabase(cfg, log, trc)
+	if err != nil {
+		log.Error(fmt.Sprintf("failed to connect database: %v", err))
+		panic(err)
+	}
+	return db
+}
+
+// OpenGormDatabase is NewGormDatabase returning the connection failure
+// instead of panicking, for the databases opened while serving (see
+// NewRoutingDatabase).
+func OpenGormDatabase(cfg *config.DatabaseConfig, log logger.Logger, trc tracer.Tracer) (Database, error) {
+	// Outside of a Pools, the database of a shared connection gets a pool of
+	// its own, its search_path pinned to the schema of the domain.
+	resolved, err := cfg.ForConnection()
+	if err != nil {
+		return nil, err
+	}
+	cfg = &resolved
 
+	dialector, err := newDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	db, err := openGorm(dialector, cfg, log, trc)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, _ := db.DB()
+	configurePool(sqlDB, cfg)
+
+	return newGormDatabase(db, cfg), nil
+}
+
+// openGorm opens the GORM database of dialector with the callbacks of cfg.
+func openGorm(dialector gorm.Dialector, cfg *config.DatabaseConfig, log logger.Logger, trc tracer.Tracer) (*gorm.DB, error) {
 	db, err := gorm.Open(
-		postgres.Open(dsn),
+		dialector,
 		&gorm.Config{
-			Logger:                 NewGormLoggerBridge(log),
+			Logger:                 NewGormLoggerBridge(log, dialector.Name()),
 			PrepareStmt:            true,
 			SkipDefaultTransaction: true,
 		},
 	)
 
 	if err != nil {
-		log.Error(fmt.Sprintf("failed to connect database: %v", err))
-		panic(err)
+		return nil, err
 	}
 
+	UseSchemaGuard(db, cfg.Schema)
+	UseTenantScope(db)
+
 	if trc != nil {
 		trc.UseGorm(db)
 	}
+	return db, nil
+}
 
-	sqlDB, _ := db.DB()
-	sqlDB.SetMaxIdleConns(cfg.Pool.Idle)
-	sqlDB.SetMaxOpenConns(cfg.Pool.Max)
+// configurePool applies the pool settings of cfg to sqlDB.
+func configurePool(sqlDB *sql.DB, cfg *config.DatabaseConfig) {
+	if cfg.Driver == config.DatabaseDriverSQLite {
+		// SQLite does not support concurrent writers, see NewSQLiteDatabase.
+		sqlDB.SetMaxOpenConns(1)
+	} else {
+		sqlDB.SetMaxIdleConns(cfg.Pool.Idle)
+		sqlDB.SetMaxOpenConns(cfg.Pool.Max)
+	}
 	sqlDB.SetConnMaxLifetime(time.Second * time.Duration(cfg.Pool.Lifetime))
+}
+
+func newGormDatabase(db *gorm.DB, cfg *config.DatabaseConfig) *gormDatabase {
+	return &gormDatabase{
+		db:               db,
+		statementTimeout: time.Duration(cfg.Timeouts.Statement) * time.Millisecond,
+		lockTimeout:      time.Duration(cfg.Timeouts.Lock) * time.Millisecond,
+	}
+}
+
+// postgresDSN returns the Postgres connection string of cfg.
+func postgresDSN(cfg *config.DatabaseConfig) string {
+	dsn := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		cfg.Host,
+		cfg.Port,
+		cfg.User,
+		cfg.Password,
+		cfg.Name,
+	)
+
+	// Pin the search_path so unqualified tables resolve to the domain-owned schema.
+	if cfg.Schema != "" {
+		dsn += fmt.Sprintf(" search_path=%s", cfg.Schema)
+	}
+	return dsn
+}
+
+// newDialector returns the GORM dialector of cfg.Driver.
+func newDialector(cfg *config.DatabaseConfig) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case "", config.DatabaseDriverPostgres:
+		return postgres.Open(postgresDSN(cfg)), nil
+
+	case config.DatabaseDriverMySQL:
+		return mysql.Open(mysqlDSN(cfg)), nil
+
+	case config.DatabaseDriverSQLite:
+		sep := "?"
+		if strings.Contains(cfg.Name, "?") {
+			sep = "&"
+		}
+		return sqlite.Open(cfg.Name + sep + "_pragma=foreign_keys(1)"), nil
+	}
 
-	return &gormDatabase{db: db}
+	return nil, fmt.Errorf("unsupported database driver %q", cfg.Driver)
 }
 
 func (g *gormDatabase) GetDB() *gorm.DB {
@@ -71,6 +168,9 @@ func (g *gormDatabase) WithContext(ctx context.Context) *gorm.DB {
 }
 
 func (g *gormDatabase) Close() error {
+	if g.release != nil {
+		return g.release()
+	}
 	sqlDB, err := g.db.DB()
 	if err != nil {
 		return err
@@ -79,10 +179,69 @@ func (g *gormDatabase) Close() error {
 }
 
 func (g *gormDatabase) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
-	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txCtx := ctxkey.SetTransaction(ctx, tx)
+	return g.AtomicWithOptions(ctx, baserepo.TxOptions{}, fn)
+}
+
+// AtomicWithOptions begins the transaction from the session of ctx: within
+// the transaction of ctx, GORM runs fn in a savepoint instead. The hooks
+// registered by fn with baserepo.RegisterAfterCommit run once the
+// transaction commits.
+func (g *gormDatabase) AtomicWithOptions(ctx context.Context, opts baserepo.TxOptions, fn func(ctx context.Context) error) error {
+	var txOpts []*sql.TxOptions
+	if opts.Isolation != sql.LevelDefault || opts.ReadOnly {
+		txOpts = append(txOpts, &sql.TxOptions{Isolation: opts.Isolation, ReadOnly: opts.ReadOnly})
+	}
+	outermost := ctxkey.GetTransaction(ctx) == nil
+	// The SQLite driver ignores the read-only option: the connection
+	// rejects the writes for the time of the transaction instead.
+	queryOnly := opts.ReadOnly && outermost && g.db.Dialector.Name() == config.DatabaseDriverSQLite
+	var afterCommit *baserepo.AfterCommit
+	err := g.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if outermost {
+			if err := g.setLocalTimeouts(tx, opts); err != nil {
+				return err
+			}
+		}
+		if queryOnly {
+			if err := tx.Exec("PRAGMA query_only = 1").Error; err != nil {
+				return err
+			}
+			defer tx.Exec("PRAGMA query_only = 0")
+		}
+		var txCtx context.Context
+		txCtx, afterCommit = baserepo.WithAfterCommit(ctxkey.SetTransaction(ctx, tx))
 		return fn(txCtx)
-	})
+	}, txOpts...)
+	if err != nil {
+		return err
+	}
+	afterCommit.Committed(ctx)
+	return nil
+}
+
+// setLocalTimeouts sets the statement and lock timeouts of the transaction
+// tx, from opts or else the defaults of the database. SET LOCAL only lasts
+// until the transaction ends, so the pooled connection keeps its settings.
+// Postgres only: the other drivers have no transaction-scoped timeouts.
+func (g *gormDatabase) setLocalTimeouts(tx *gorm.DB, opts baserepo.TxOptions) error {
+	if g.db.Dialector.Name() != config.DatabaseDriverPostgres {
+		return nil
+	}
+	for _, t := range []struct {
+		name    string
+		timeout time.Duration
+	}{
+		{"statement_timeout", cmp.Or(opts.StatementTimeout, g.statementTimeout)},
+		{"lock_timeout", cmp.Or(opts.LockTimeout, g.lockTimeout)},
+	} {
+		if t.timeout <= 0 {
+			continue
+		}
+		if err := tx.Exec(fmt.Sprintf("SET LOCAL %s = %d", t.name, t.timeout.Milliseconds())).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ----- GORM Logger Bridge -----
@@ -90,15 +249,32 @@ func (g *gormDatabase) Atomic(ctx context.Context, fn func(ctx context.Context)
 type gormLoggerBridge struct {
 	Log           logger.Logger
 	SlowThreshold time.Duration
+	// Dialect is the name of the GORM dialector, it selects how the
+	// statements are obfuscated (see sqlmask.Obfuscate).
+	Dialect string
 }
 
-func NewGormLoggerBridge(l logger.Logger) gormlog.Interface {
+// NewGormLoggerBridge logs the statements of the GORM database of dialect
+// (e.g., "postgres", see gorm.Dialector.Name) with l.
+func NewGormLoggerBridge(l logger.Logger, dialect string) gormlog.Interface {
 	return &gormLoggerBridge{
 		Log:           l.WithField("component", "database").WithField("source", "gorm"),
 		SlowThreshold: 200 * time.Millisecond,
+		Dialect:       dialect,
 	}
 }
 
+// ParamsFilter drops the bound values of the traced statements: GORM then
+// logs them with their placeholders instead of the values its Explain would
+// inline (see gorm.ParamsFilter).
+func (l *gormLoggerBridge) ParamsFilter(_ context.Context, sql string, _ ...any) (string, []any) {
+	return sql, nil
+}
+
+// unboundPlaceholder is a numeric placeholder ($1) as the Explain of
+// PostgreSQL leaves it without a bound value ($1$).
+var unboundPlaceholder = regexp.MustCompile(`\$(\d+)\$`)
+
 func (l *gormLoggerBridge) LogMode(level gormlog.LogLevel) gormlog.Interface {
 	return l
 }
@@ -118,11 +294,12 @@ func (l *gormLoggerBridge) Error(ctx context.Context, msg string, data ...any) {
 func (l *gormLoggerBridge) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
 	elapsed := time.Since(begin)
 	sql, rows := fc()
+	sql = unboundPlaceholder.ReplaceAllString(sql, "$$$1")
 	isSlow := elapsed > l.SlowThreshold
 
 	log := l.Log.WithContext(ctx).
 		WithFields(map[string]any{
-			"db_sql":        utils.MaskSensitive(sql),
+			"db_sql":        sqlmask.Obfuscate(sql, l.Dialect), // the literals of raw queries
 			"db_rows":       rows,
 			"db_elapsed":    elapsed.String(),
 			"db_latency_ms": float64(elapsed.Nanoseconds()) / 1e6,
@@ -2,8 +2,15 @@ package database
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/apperror"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 // ErrorMapper is a function type for mapping database errors to application errors.
@@ -59,13 +66,124 @@ func (r *GormBaseRepository[T]) Create(ctx context.Context, entity *T) error {
 	return r.mapErr(r.getDB(ctx).Create(entity).Error)
 }
 
-// Update performs a full update of the entity using GORM's Save method.
-// WARNING: Save updates all columns. If you pass a partial struct, fields not set
+// Update performs a full update of the stored entity, identified by its
+// primary key.
+// WARNING: every column is updated. If you pass a partial struct, fields not set
 // will be overwritten with zero values in the database.
 //
-// For partial updates, implement a custom method using .Updates() in the domain repository.
+// Unlike GORM's Save, Update never inserts: when no row matches (the entity
+// was deleted, or belongs to another tenant than the one of ctx), it returns
+// gorm.ErrRecordNotFound. Save would fall back to an INSERT ... ON CONFLICT
+// DO UPDATE, escaping the tenant scope.
+//
+// For partial updates, use UpdateFields or Patch.
 func (r *GormBaseRepository[T]) Update(ctx context.Context, entity *T) error {
-	return r.mapErr(r.getDB(ctx).Save(entity).Error)
+	res := r.getDB(ctx).Model(entity).Select("*").Updates(entity)
+	if res.Error != nil {
+		return r.mapErr(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return r.mapErr(gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// UpdateFields updates the given fields of the stored entity, and only them,
+// with the values of entity, zero values included. The fields are columns
+// (e.g., "is_active") or struct fields (e.g., "IsActive"); the associations
+// are not saved.
+//
+// Whitelisting: a field that is unknown, the primary key (it identifies the
+// row) or the tenant fails with INTERNAL_ERROR, without any update.
+func (r *GormBaseRepository[T]) UpdateFields(ctx context.Context, entity *T, fields ...string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	db := r.getDB(ctx)
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(entity); err != nil {
+		return r.mapErr(err)
+	}
+
+	columns := make([]string, 0, len(fields))
+	for _, name := range fields {
+		f, err := updatableField(stmt.Schema, name)
+		if err != nil {
+			return err
+		}
+		columns = append(columns, f.DBName)
+	}
+	return r.mapErr(db.Model(entity).Select(columns).Updates(entity).Error)
+}
+
+// updatableField returns the field name of s, an error when it is unknown,
+// the primary key or the tenant.
+func updatableField(s *schema.Schema, name string) (*schema.Field, error) {
+	f := s.LookUpField(name)
+	if f == nil || f.DBName == "" || f.PrimaryKey || f.DBName == TenantColumn {
+		return nil, apperror.NewInternal(apperror.CodeInternalError, fmt.Sprintf("field %q of %s cannot be updated", name, s.Name))
+	}
+	return f, nil
+}
+
+// PatchTag is the struct tag of the fields of a patch, naming the column
+// they update (see Patch).
+const PatchTag = "patch"
+
+// Patch applies patch to entity, and updates the patched fields of the
+// stored entity with UpdateFields. patch is a struct (or a pointer to one)
+// whose pointer fields tagged `patch:"<column>"` set their column when not
+// nil, e.g. the fields of a PATCH request:
+//
+//	type EndpointPatch struct {
+//		URL      *string `patch:"url"`
+//		IsActive *bool   `patch:"is_active"`
+//	}
+//
+// A nil pointer leaves its column unchanged; a patch without any field set
+// updates nothing.
+func (r *GormBaseRepository[T]) Patch(ctx context.Context, entity *T, patch any) error {
+	stmt := &gorm.Statement{DB: r.getDB(ctx)}
+	if err := stmt.Parse(entity); err != nil {
+		return r.mapErr(err)
+	}
+
+	pv := reflect.Indirect(reflect.ValueOf(patch))
+	if pv.Kind() != reflect.Struct {
+		return apperror.NewInternal(apperror.CodeInternalError, fmt.Sprintf("patch of %s is a %T, not a struct", stmt.Schema.Name, patch))
+	}
+
+	// The whole patch is checked before entity is changed.
+	type patched struct {
+		field *schema.Field
+		value any
+	}
+	var set []patched
+	for i := 0; i < pv.NumField(); i++ {
+		column := pv.Type().Field(i).Tag.Get(PatchTag)
+		value := pv.Field(i)
+		if column == "" || column == "-" || value.Kind() != reflect.Pointer || value.IsNil() {
+			continue
+		}
+		f, err := updatableField(stmt.Schema, column)
+		if err != nil {
+			return err
+		}
+		if vt := value.Type().Elem(); !vt.AssignableTo(f.FieldType) && !(f.FieldType.Kind() == reflect.Pointer && vt.AssignableTo(f.FieldType.Elem())) {
+			return apperror.NewInternal(apperror.CodeInternalError, fmt.Sprintf("patch field %q of %s is a %s, not a %s", column, stmt.Schema.Name, vt, f.FieldType))
+		}
+		set = append(set, patched{field: f, value: value.Elem().Interface()})
+	}
+
+	columns := make([]string, 0, len(set))
+	ev := reflect.ValueOf(entity).Elem()
+	for _, p := range set {
+		if err := p.field.Set(ctx, ev, p.value); err != nil {
+			return apperror.NewInternal(apperror.CodeInternalError, fmt.Sprintf("cannot patch field %q of %s", p.field.DBName, stmt.Schema.Name), err)
+		}
+		columns = append(columns, p.field.DBName)
+	}
+	return r.UpdateFields(ctx, entity, columns...)
 }
 
 // Delete removes the record of type T from the database.
@@ -73,3 +191,98 @@ func (r *GormBaseRepository[T]) Update(ctx context.Context, entity *T) error {
 func (r *GormBaseRepository[T]) Delete(ctx context.Context, entity *T) error {
 	return r.mapErr(r.getDB(ctx).Delete(entity).Error)
 }
+
+// CreateInBatches inserts entities with one INSERT per batch of batchSize
+// rows (every row in a single INSERT when batchSize is 0 or less), for
+// high-volume writes such as imports.
+//
+// The batches are inserted in a single transaction (a savepoint within the
+// transaction of ctx): a failing batch, mapped by the ErrorMapper, leaves
+// none of the entities stored.
+func (r *GormBaseRepository[T]) CreateInBatches(ctx context.Context, entities []*T, batchSize int) error {
+	return r.inBatches(ctx, entities, batchSize, nil)
+}
+
+// OnConflict tells Upsert what to do with the rows already stored.
+type OnConflict struct {
+	// Columns are the columns of the unique constraint identifying a stored
+	// row: the primary key when empty.
+	Columns []string
+	// Update lists the columns overwritten with the inserted values: every
+	// column but the primary key, the tenant and created_at when empty.
+	Update []string
+}
+
+// Upsert inserts entities as CreateInBatches does, updating the rows already
+// stored (INSERT ... ON CONFLICT DO UPDATE) instead of failing with
+// DB_CONFLICT.
+//
+// With a tenant in ctx, a conflicting row owned by another tenant is left
+// untouched (ON CONFLICT DO UPDATE ... WHERE tenant_id = excluded.tenant_id):
+// the inserted row is dropped. MySQL cannot guard its ON DUPLICATE KEY UPDATE
+// this way, so the upserts of tenant-scoped rows with a tenant in ctx fail
+// with INTERNAL_ERROR there.
+func (r *GormBaseRepository[T]) Upsert(ctx context.Context, entities []*T, batchSize int, c OnConflict) error {
+	db := r.getDB(ctx)
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return r.mapErr(err)
+	}
+
+	columns := c.Columns
+	if len(columns) == 0 {
+		columns = stmt.Schema.PrimaryFieldDBNames
+	}
+	update := c.Update
+	if len(update) == 0 {
+		for _, f := range stmt.Schema.Fields {
+			if f.DBName == "" || f.PrimaryKey || f.DBName == TenantColumn || f.DBName == "created_at" {
+				continue
+			}
+			update = append(update, f.DBName)
+		}
+	}
+
+	onConflict := clause.OnConflict{DoUpdates: clause.AssignmentColumns(update)}
+	for _, col := range columns {
+		onConflict.Columns = append(onConflict.Columns, clause.Column{Name: col})
+	}
+	if ctxkey.GetTenantID(ctx) != "" && stmt.Schema.LookUpField(TenantColumn) != nil {
+		if name := db.Dialector.Name(); name != "postgres" && name != "sqlite" {
+			return apperror.NewInternal(apperror.CodeInternalError, fmt.Sprintf("upsert of the tenant-scoped %s is not supported on %s", stmt.Schema.Name, name))
+		}
+		onConflict.Where = clause.Where{Exprs: []clause.Expression{clause.Expr{
+			SQL:  "? = excluded.?",
+			Vars: []any{clause.Column{Table: clause.CurrentTable, Name: TenantColumn}, clause.Column{Name: TenantColumn}},
+		}}}
+	}
+	return r.inBatches(ctx, entities, batchSize, &onConflict)
+}
+
+// inBatches inserts entities in batches within a transaction, with the
+// onConflict clause when set.
+func (r *GormBaseRepository[T]) inBatches(ctx context.Context, entities []*T, batchSize int, onConflict *clause.OnConflict) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	create := func(ctx context.Context, batch []*T) error {
+		db := r.getDB(ctx)
+		if onConflict != nil {
+			db = db.Clauses(*onConflict)
+		}
+		return r.mapErr(db.Create(batch).Error)
+	}
+	if batchSize <= 0 || batchSize >= len(entities) {
+		// A single statement is atomic on its own.
+		return create(ctx, entities)
+	}
+
+	return r.DB.Atomic(ctx, func(txCtx context.Context) error {
+		for batch := range slices.Chunk(entities, batchSize) {
+			if err := create(txCtx, batch); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultStatsInterval = 15 * time.Second
+
+	metricQueryDuration = "db_query_duration"
+
+	metricPoolMaxOpen      = "db_pool_max_open_connections"
+	metricPoolOpen         = "db_pool_open_connections"
+	metricPoolInUse        = "db_pool_in_use_connections"
+	metricPoolIdle         = "db_pool_idle_connections"
+	metricPoolWaitCount    = "db_pool_wait_count"
+	metricPoolWaitDuration = "db_pool_wait_duration_seconds"
+	metricPoolIdleClosed   = "db_pool_max_idle_closed"
+	metricPoolLifeClosed   = "db_pool_max_lifetime_closed"
+
+	queryStartKey = "metrics:start"
+)
+
+// UseMetrics registers GORM callbacks timing every statement of db in the
+// db_query_duration metric, tagged with the domain, the table, the operation
+// (create, query, update, delete, row, raw) and the status (ok, error). Raw
+// statements without a table are tagged "unknown".
+func UseMetrics(db *gorm.DB, domain string, m metrics.Metrics) {
+	before := func(tx *gorm.DB) {
+		tx.InstanceSet(queryStartKey, time.Now())
+	}
+	after := func(operation string) func(tx *gorm.DB) {
+		return func(tx *gorm.DB) {
+			v, ok := tx.InstanceGet(queryStartKey)
+			if !ok {
+				return
+			}
+			start, ok := v.(time.Time)
+			if !ok {
+				return
+			}
+
+			table := tx.Statement.Table
+			if table == "" {
+				table = "unknown"
+			}
+			status := "ok"
+			if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+				status = "error"
+			}
+			m.Timing(metricQueryDuration, time.Since(start), []string{
+				"domain:" + domain,
+				"table:" + table,
+				"operation:" + operation,
+				"status:" + status,
+			})
+		}
+	}
+
+	cb := db.Callback()
+	_ = cb.Create().Before("gorm:create").Register("metrics:before_create", before)
+	_ = cb.Query().Before("gorm:query").Register("metrics:before_query", before)
+	_ = cb.Update().Before("gorm:update").Register("metrics:before_update", before)
+	_ = cb.Delete().Before("gorm:delete").Register("metrics:before_delete", before)
+	_ = cb.Row().Before("gorm:row").Register("metrics:before_row", before)
+	_ = cb.Raw().Before("gorm:raw").Register("metrics:before_raw", before)
+
+	_ = cb.Create().After("gorm:create").Register("metrics:after_create", after("create"))
+	_ = cb.Query().After("gorm:query").Register("metrics:after_query", after("query"))
+	_ = cb.Update().After("gorm:update").Register("metrics:after_update", after("update"))
+	_ = cb.Delete().After("gorm:delete").Register("metrics:after_delete", after("delete"))
+	_ = cb.Row().After("gorm:row").Register("metrics:after_row", after("row"))
+	_ = cb.Raw().After("gorm:raw").Register("metrics:after_raw", after("raw"))
+}
+
+// PoolReporter records the connection pool statistics of a database as
+// gauges tagged with its domain, so that a pool running out of connections
+// (in use reaching the maximum, wait count growing) shows before requests
+// time out.
+type PoolReporter struct {
+	db       *sql.DB
+	tags     []string
+	metrics  metrics.Metrics
+	interval time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// NewPoolReporter creates a PoolReporter recording every interval (default
+// 15s).
+func NewPoolReporter(db *sql.DB, domain string, m metrics.Metrics, interval time.Duration) *PoolReporter {
+	if interval <= 0 {
+		interval = defaultStatsInterval
+	}
+	return &PoolReporter{
+		db:       db,
+		tags:     []string{"domain:" + domain},
+		metrics:  m,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start records the statistics until Stop is called.
+func (r *PoolReporter) Start() {
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		r.Report()
+		for {
+			select {
+			case <-ticker.C:
+				r.Report()
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the recording started by Start.
+func (r *PoolReporter) Stop() {
+	r.once.Do(func() {
+		close(r.stop)
+		<-r.done
+	})
+}
+
+// Report records the current statistics. The wait and closed connection
+// counts are cumulative since the pool was opened.
+func (r *PoolReporter) Report() {
+	s := r.db.Stats()
+	r.metrics.Gauge(metricPoolMaxOpen, float64(s.MaxOpenConnections), r.tags)
+	r.metrics.Gauge(metricPoolOpen, float64(s.OpenConnections), r.tags)
+	r.metrics.Gauge(metricPoolInUse, float64(s.InUse), r.tags)
+	r.metrics.Gauge(metricPoolIdle, float64(s.Idle), r.tags)
+	r.metrics.Gauge(metricPoolWaitCount, float64(s.WaitCount), r.tags)
+	r.metrics.Gauge(metricPoolWaitDuration, s.WaitDuration.Seconds(), r.tags)
+	r.metrics.Gauge(metricPoolIdleClosed, float64(s.MaxIdleClosed), r.tags)
+	r.metrics.Gauge(metricPoolLifeClosed, float64(s.MaxLifetimeClosed), r.tags)
+}
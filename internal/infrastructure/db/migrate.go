@@ -0,0 +1,172 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratedb "github.com/golang-migrate/migrate/v4/database"
+	migratepgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the "pgx" database/sql driver
+)
+
+// MigrationStatus is the migration version of a database.
+type MigrationStatus struct {
+	Version uint // last applied migration, 0 when none
+	Dirty   bool // migration Version failed halfway: the schema needs a manual repair
+	Latest  uint // latest migration available, 0 when none
+}
+
+// Err returns an error when the database is dirty or behind the latest
+// migration. A database ahead of it, migrated by a newer release, is
+// accepted.
+func (s MigrationStatus) Err() error {
+	switch {
+	case s.Dirty:
+		return fmt.Errorf("migration %d is dirty (it failed halfway): repair the schema, then force the version", s.Version)
+	case s.Version < s.Latest:
+		return fmt.Errorf("database at migration %d, latest is %d: run the migrations", s.Version, s.Latest)
+	}
+	return nil
+}
+
+// Migrator applies the golang-migrate SQL migrations of a domain (see package
+// migrations) to its database, recording the version in the
+// schema_migrations table of the domain schema. Concurrent migrators of the
+// same database wait for each other (advisory lock).
+type Migrator struct {
+	m      *migrate.Migrate
+	latest uint
+}
+
+// NewMigrator connects a migrator of files to the database of cfg, creating
+// the domain schema when missing. The migrations are Postgres SQL: other
+// drivers are rejected. The migrator must be closed.
+func NewMigrator(cfg *config.DatabaseConfig, files fs.FS) (*Migrator, error) {
+	resolved, err := cfg.ForConnection()
+	if err != nil {
+		return nil, err
+	}
+	cfg = &resolved
+	if cfg.Driver != "" && cfg.Driver != config.DatabaseDriverPostgres {
+		return nil, fmt.Errorf("the migrations are Postgres SQL, %s databases are migrated by their own tooling", cfg.Driver)
+	}
+
+	src, err := iofs.New(files, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	latest, err := latestMigration(src)
+	if err != nil {
+		_ = src.Close()
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	db, err := sql.Open("pgx", postgresDSN(cfg))
+	if err != nil {
+		_ = src.Close()
+		return nil, err
+	}
+	driver, err := newMigrationDriver(db, cfg.Schema)
+	if err != nil {
+		_ = src.Close()
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect database: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "pgx5", driver)
+	if err != nil {
+		_ = src.Close()
+		_ = driver.Close()
+		return nil, err
+	}
+	return &Migrator{m: m, latest: latest}, nil
+}
+
+// newMigrationDriver returns the golang-migrate driver of db, which owns it.
+// The schema_migrations table is created in schema, created itself first:
+// the first migration of a domain may not create its schema.
+func newMigrationDriver(db *sql.DB, schema string) (migratedb.Driver, error) {
+	if schema != "" {
+		if _, err := db.Exec("CREATE SCHEMA IF NOT EXISTS " + pgx.Identifier{schema}.Sanitize()); err != nil {
+			return nil, err
+		}
+	}
+	return migratepgx.WithInstance(db, &migratepgx.Config{SchemaName: schema})
+}
+
+// latestMigration returns the highest version of src, 0 when it is empty.
+func latestMigration(src source.Driver) (uint, error) {
+	v, err := src.First()
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	for err == nil {
+		var next uint
+		next, err = src.Next(v)
+		if errors.Is(err, fs.ErrNotExist) {
+			return v, nil
+		}
+		v = next
+	}
+	return 0, err
+}
+
+// Up applies the pending migrations.
+func (m *Migrator) Up() error {
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down rolls back the last steps migrations.
+func (m *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("invalid number of steps %d", steps)
+	}
+	return m.m.Steps(-steps)
+}
+
+// Force records version as applied and clean, without running any migration,
+// once the schema of a dirty migration has been repaired by hand.
+func (m *Migrator) Force(version int) error {
+	return m.m.Force(version)
+}
+
+// Status returns the migration version of the database.
+func (m *Migrator) Status() (MigrationStatus, error) {
+	version, dirty, err := m.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return MigrationStatus{}, err
+	}
+	return MigrationStatus{Version: version, Dirty: dirty, Latest: m.latest}, nil
+}
+
+// Close closes the migrations and the database connection.
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+// CheckMigrations returns the migration status of the database of cfg against
+// files, with its error (see MigrationStatus.Err).
+func CheckMigrations(cfg *config.DatabaseConfig, files fs.FS) (MigrationStatus, error) {
+	m, err := NewMigrator(cfg, files)
+	if err != nil {
+		return MigrationStatus{}, err
+	}
+	defer m.Close()
+
+	status, err := m.Status()
+	if err != nil {
+		return status, err
+	}
+	return status, status.Err()
+}
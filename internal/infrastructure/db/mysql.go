@@ -0,0 +1,78 @@
+package database
+
+import (
+	"cmp"
+	"errors"
+	"net"
+	"strconv"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/pkg/apperror"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+)
+
+// mysqlDSN returns the DSN of a MySQL database. A MySQL schema is a database:
+// the domain-owned schema, when set, is the database connected to.
+//
+// The affected rows of an UPDATE are the rows found, like on Postgres and
+// SQLite, rather than the rows changed: an update leaving a row unchanged is
+// not mistaken by the repositories for a missing row.
+func mysqlDSN(cfg *config.DatabaseConfig) string {
+	c := mysqldriver.NewConfig()
+	c.User = cfg.User
+	c.Passwd = cfg.Password
+	c.Net = "tcp"
+	c.Addr = net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	c.DBName = cmp.Or(cfg.Schema, cfg.Name)
+	c.ParseTime = true
+	c.ClientFoundRows = true
+	c.Params = map[string]string{"charset": "utf8mb4"}
+	return c.FormatDSN()
+}
+
+// mapMySQLError handles the MySQL errors that have a Postgres counterpart in
+// mapPgError, using the MySQL server error numbers.
+func mapMySQLError(err error) error {
+	if errors.Is(err, mysqldriver.ErrInvalidConn) {
+		return apperror.NewTransient(apperror.CodeDbConnectionFailed, "database connection failed", err)
+	}
+
+	var myErr *mysqldriver.MySQLError
+	if !errors.As(err, &myErr) {
+		return nil
+	}
+
+	switch myErr.Number {
+	// --- Transient Errors (Retryable) ---
+
+	// Too many connections, server shutdown
+	case 1040, 1053:
+		return apperror.NewTransient(apperror.CodeDbConnectionFailed, "database connection failed", myErr)
+
+	// Deadlocks
+	case 1213:
+		return apperror.NewTransient(apperror.CodeDbDeadlock, "database deadlock detected, please retry", myErr)
+
+	// Lock wait timeout
+	case 1205:
+		return apperror.NewTransient(apperror.CodeDbTimeout, "database lock timeout", myErr)
+
+	// --- Permanent Errors (Client Side / Data Issue) ---
+
+	// Duplicate entry
+	case 1062:
+		return apperror.NewPersistance(apperror.CodeDbConflict, "duplicate data", myErr)
+
+	// Foreign key, not null and check constraints
+	case 1451, 1452, 1048, 3819:
+		return apperror.NewPersistance(apperror.CodeDbConstraint, "database constraint violation: "+myErr.Message, myErr)
+
+	// --- Internal Errors (Developer / Config Issue) ---
+
+	// Unknown column, syntax error, unknown table
+	case 1054, 1064, 1146:
+		return apperror.NewInternal(apperror.CodeInternalError, "database schema or syntax error", myErr)
+	}
+
+	return nil
+}
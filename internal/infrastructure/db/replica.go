@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	baserepo "voyago/core-api/internal/pkg/repository"
+
+	"gorm.io/gorm"
+)
+
+// readSplitter is implemented by the databases having read replicas.
+type readSplitter interface {
+	// Reader returns the view of the database serving the reads.
+	Reader() Database
+}
+
+// Reader returns the view of db serving the reads of the query repositories:
+// its statements go to a replica, unless ctx holds a transaction (see
+// Database.Atomic) or requires the primary (ctxkey.SetReadPrimary). db itself
+// is returned when it has no replica.
+//
+// Closing the view does nothing: close db.
+func Reader(db Database) Database {
+	if s, ok := db.(readSplitter); ok {
+		return s.Reader()
+	}
+	return db
+}
+
+// replicatedDatabase is a primary database with read replicas. Its own
+// statements go to the primary; the ones of its Reader to the replicas, in
+// turn.
+type replicatedDatabase struct {
+	Database // primary
+	replicas []Database
+	next     atomic.Uint64
+}
+
+var _ readSplitter = (*replicatedDatabase)(nil)
+
+// NewReplicatedDatabase returns primary with the read replicas replicas (see
+// Reader), primary itself when there is none.
+func NewReplicatedDatabase(primary Database, replicas ...Database) Database {
+	if len(replicas) == 0 {
+		return primary
+	}
+	return &replicatedDatabase{Database: primary, replicas: replicas}
+}
+
+func (r *replicatedDatabase) Reader() Database {
+	return replicaReader{r}
+}
+
+// Close closes the primary and the replicas.
+func (r *replicatedDatabase) Close() error {
+	errs := []error{r.Database.Close()}
+	for _, replica := range r.replicas {
+		errs = append(errs, replica.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func (r *replicatedDatabase) replica() Database {
+	return r.replicas[(r.next.Add(1)-1)%uint64(len(r.replicas))]
+}
+
+// replicaReader is the Reader of a replicatedDatabase.
+type replicaReader struct {
+	r *replicatedDatabase
+}
+
+var _ Database = replicaReader{}
+
+func (v replicaReader) WithContext(ctx context.Context) *gorm.DB {
+	if ctxkey.GetTransaction(ctx) != nil || ctxkey.GetReadPrimary(ctx) {
+		return v.r.Database.WithContext(ctx)
+	}
+	return v.r.replica().WithContext(ctx)
+}
+
+// Atomic runs fn in a transaction of the primary: a transaction reads its own
+// writes.
+func (v replicaReader) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	return v.r.Database.Atomic(ctx, fn)
+}
+
+func (v replicaReader) AtomicWithOptions(ctx context.Context, opts baserepo.TxOptions, fn func(ctx context.Context) error) error {
+	return v.r.Database.AtomicWithOptions(ctx, opts, fn)
+}
+
+func (v replicaReader) GetDB() *gorm.DB {
+	return v.r.replica().GetDB()
+}
+
+func (replicaReader) Close() error {
+	return nil
+}
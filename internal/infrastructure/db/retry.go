@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/pkg/apperror"
+	baserepo "voyago/core-api/internal/pkg/repository"
+	"voyago/core-api/internal/pkg/retry"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const metricTxRetry = "db_tx_retry"
+
+// retryingDatabase retries the transactions of a database failing with a
+// transient database error.
+type retryingDatabase struct {
+	Database
+	exec retry.Executor
+}
+
+var _ readSplitter = (*retryingDatabase)(nil)
+
+// WithRetry returns db whose Atomic runs the transaction again, from the
+// start, when it fails with a deadlock, a lock timeout or a lost connection
+// (see IsTransientError), as configured by cfg. Every retry increments the
+// db_tx_retry metric, tagged with the domain and the error code. db itself is
+// returned when cfg disables retries.
+//
+// A transaction is retried as a whole: fn must not have effects outside of
+// the database (publish its events after Atomic returns). The transactions
+// nested in another one are not retried, the outer one is.
+func WithRetry(db Database, cfg config.DatabaseRetryConfig, domain string, m metrics.Metrics) Database {
+	if cfg.MaxAttempts <= 1 {
+		return db
+	}
+
+	return &retryingDatabase{
+		Database: db,
+		exec: retry.Executor{
+			MaxAttempts: cfg.MaxAttempts,
+			BaseBackoff: time.Duration(cfg.BaseBackoff) * time.Millisecond,
+			MaxBackoff:  time.Duration(cfg.MaxBackoff) * time.Millisecond,
+			Retryable:   IsTransientError,
+			OnRetry: func(_ context.Context, _ int, err error, _ time.Duration) {
+				if m == nil {
+					return
+				}
+				var appErr *apperror.AppError
+				errors.As(MapDBError(err), &appErr)
+				m.Incr(metricTxRetry, []string{"domain:" + domain, "code:" + appErr.Code})
+			},
+		},
+	}
+}
+
+func (r *retryingDatabase) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.AtomicWithOptions(ctx, baserepo.TxOptions{}, fn)
+}
+
+func (r *retryingDatabase) AtomicWithOptions(ctx context.Context, opts baserepo.TxOptions, fn func(ctx context.Context) error) error {
+	if ctxkey.GetTransaction(ctx) != nil {
+		return r.Database.AtomicWithOptions(ctx, opts, fn)
+	}
+	return r.exec.Do(ctx, func(ctx context.Context) error {
+		return r.Database.AtomicWithOptions(ctx, opts, fn)
+	})
+}
+
+func (r *retryingDatabase) Reader() Database {
+	return Reader(r.Database)
+}
+
+// IsTransientError reports whether err, mapped by MapDBError, is a deadlock,
+// a lock timeout or a lost connection: an error that running the same
+// statements again may not hit. A statement timeout is not: the same
+// statement would be as slow again.
+func IsTransientError(err error) bool {
+	var appErr *apperror.AppError
+	if !errors.As(MapDBError(err), &appErr) || !appErr.IsRetryable() {
+		return false
+	}
+	switch appErr.Code {
+	case apperror.CodeDbDeadlock, apperror.CodeDbConnectionFailed:
+		return true
+	case apperror.CodeDbTimeout:
+		var pgErr *pgconn.PgError
+		return !errors.As(err, &pgErr) || pgErr.Code != "57014"
+	}
+	return false
+}
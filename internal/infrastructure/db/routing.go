@@ -0,0 +1,232 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/apperror"
+	baserepo "voyago/core-api/internal/pkg/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultTenantPoolsMaxOpen     = 10
+	defaultTenantPoolsIdleTimeout = 600 // in seconds
+)
+
+// ErrTooManyTenantPools fails the statements of a tenant whose database
+// cannot be opened without exceeding DatabaseTenantPoolsConfig.MaxOpen. It
+// is transient: the idle pools are closed after their idle timeout.
+var ErrTooManyTenantPools = apperror.NewTransient(apperror.CodeDbConnectionFailed, "too many tenant databases open", nil)
+
+// routingDatabase routes the statements of the tenants having a database of
+// their own (DatabaseConfig.Tenants) to it, the others to the default one.
+type routingDatabase struct {
+	fallback Database
+	cfg      config.DatabaseConfig
+	open     func(cfg *config.DatabaseConfig) (Database, error)
+
+	maxOpen     int
+	idleTimeout time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	pools     map[string]*tenantPool
+	lastSweep time.Time
+}
+
+// tenantPool is the database of a tenant, opened once by its first user.
+type tenantPool struct {
+	once     sync.Once
+	opened   atomic.Bool
+	db       Database
+	err      error
+	lastUsed atomic.Int64 // Unix nanoseconds
+}
+
+var (
+	_ Database     = (*routingDatabase)(nil)
+	_ readSplitter = (*routingDatabase)(nil)
+)
+
+// NewRoutingDatabase returns a Database routing the statements of a tenant
+// (ctxkey.GetTenantID) listed in cfg.Tenants to its own database, fallback
+// otherwise. fallback itself is returned when cfg lists no tenant.
+//
+// The tenant databases are opened with open on the first statement of the
+// tenant, at most cfg.TenantPools.MaxOpen at once, and closed once unused
+// for cfg.TenantPools.IdleTimeout. They are not migrated here: run the
+// migrations of the domain against every tenant database.
+func NewRoutingDatabase(fallback Database, cfg *config.DatabaseConfig, open func(cfg *config.DatabaseConfig) (Database, error)) Database {
+	if cfg == nil || len(cfg.Tenants) == 0 {
+		return fallback
+	}
+
+	maxOpen := cfg.TenantPools.MaxOpen
+	if maxOpen <= 0 {
+		maxOpen = defaultTenantPoolsMaxOpen
+	}
+	idleTimeout := cfg.TenantPools.IdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = defaultTenantPoolsIdleTimeout
+	}
+
+	return &routingDatabase{
+		fallback:    fallback,
+		cfg:         *cfg,
+		open:        open,
+		maxOpen:     maxOpen,
+		idleTimeout: time.Duration(idleTimeout) * time.Second,
+		now:         time.Now,
+		pools:       make(map[string]*tenantPool),
+	}
+}
+
+// WithContext returns the session of the database of the tenant of ctx. When
+// that database cannot be opened, the returned session fails every statement
+// with a transient error.
+func (r *routingDatabase) WithContext(ctx context.Context) *gorm.DB {
+	return r.withContext(ctx, false)
+}
+
+func (r *routingDatabase) withContext(ctx context.Context, read bool) *gorm.DB {
+	if ctxkey.GetTransaction(ctx) != nil {
+		// The transaction was begun by Atomic on the database of the tenant:
+		// any database returns it.
+		return r.fallback.WithContext(ctx)
+	}
+
+	db, err := r.resolve(ctx)
+	if err != nil {
+		tx := r.fallback.GetDB().WithContext(ctx)
+		_ = tx.AddError(err)
+		return tx
+	}
+	if read {
+		db = Reader(db)
+	}
+	return db.WithContext(ctx)
+}
+
+// Reader returns the view of the databases serving the reads: the replicas
+// of the default database for the tenants without a database of their own.
+func (r *routingDatabase) Reader() Database {
+	return routingReader{r}
+}
+
+func (r *routingDatabase) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.AtomicWithOptions(ctx, baserepo.TxOptions{}, fn)
+}
+
+func (r *routingDatabase) AtomicWithOptions(ctx context.Context, opts baserepo.TxOptions, fn func(ctx context.Context) error) error {
+	db, err := r.resolve(ctx)
+	if err != nil {
+		return err
+	}
+	return db.AtomicWithOptions(ctx, opts, fn)
+}
+
+// GetDB returns the default database.
+func (r *routingDatabase) GetDB() *gorm.DB {
+	return r.fallback.GetDB()
+}
+
+// Close closes the default database and the tenant databases.
+func (r *routingDatabase) Close() error {
+	r.mu.Lock()
+	pools := r.pools
+	r.pools = make(map[string]*tenantPool)
+	r.mu.Unlock()
+
+	errs := []error{r.fallback.Close()}
+	for _, p := range pools {
+		if p.opened.Load() && p.err == nil {
+			errs = append(errs, p.db.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// routingReader is the Reader of a routingDatabase.
+type routingReader struct {
+	*routingDatabase
+}
+
+func (v routingReader) WithContext(ctx context.Context) *gorm.DB {
+	return v.withContext(ctx, true)
+}
+
+func (v routingReader) GetDB() *gorm.DB {
+	return Reader(v.fallback).GetDB()
+}
+
+func (routingReader) Close() error {
+	return nil
+}
+
+// resolve returns the database of the tenant of ctx, opening it when needed.
+func (r *routingDatabase) resolve(ctx context.Context) (Database, error) {
+	tenant := strings.ToLower(ctxkey.GetTenantID(ctx))
+	cfg, ok := r.cfg.ForTenant(tenant)
+	if !ok {
+		return r.fallback, nil
+	}
+
+	now := r.now()
+	r.mu.Lock()
+	r.sweep(now)
+	p, ok := r.pools[tenant]
+	if !ok {
+		if len(r.pools) >= r.maxOpen {
+			r.mu.Unlock()
+			return nil, ErrTooManyTenantPools
+		}
+		p = &tenantPool{}
+		r.pools[tenant] = p
+	}
+	p.lastUsed.Store(now.UnixNano())
+	r.mu.Unlock()
+
+	p.once.Do(func() {
+		p.db, p.err = r.open(&cfg)
+		p.opened.Store(true)
+	})
+	if p.err != nil {
+		// Forget the failed pool: the next statement retries.
+		r.mu.Lock()
+		if r.pools[tenant] == p {
+			delete(r.pools, tenant)
+		}
+		r.mu.Unlock()
+		return nil, apperror.NewTransient(apperror.CodeDbConnectionFailed, "tenant database connection failed", p.err).
+			WithDetail("tenant_id", tenant)
+	}
+	return p.db, nil
+}
+
+// sweep closes the pools unused for the idle timeout, at most once per half
+// of it. The caller holds r.mu.
+func (r *routingDatabase) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < r.idleTimeout/2 {
+		return
+	}
+	r.lastSweep = now
+
+	for tenant, p := range r.pools {
+		idle := now.Sub(time.Unix(0, p.lastUsed.Load())) >= r.idleTimeout
+		if !idle || !p.opened.Load() {
+			continue
+		}
+		delete(r.pools, tenant)
+		if p.err == nil {
+			// Close waits for the running statements to finish.
+			go func() { _ = p.db.Close() }()
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+)
+
+// UseSchemaGuard registers GORM callbacks that enforce schema ownership for a domain.
+// Every statement referencing a table qualified with a schema other than the
+// owned one (e.g., "merchant.merchants" from the booking connection) is aborted
+// before reaching the database, preventing accidental cross-domain joins or writes.
+//
+// The queries are checked once their SQL is built, so that the tables of
+// their joins and subqueries are seen along with the target table; so is the
+// SQL of db.Raw and db.Exec. The writes built by GORM are checked on their
+// target table.
+//
+// Unqualified tables are always allowed since they resolve through the
+// connection's search_path, which is pinned to the owned schema (on a shared
+// pool, UseSchemaQualifier qualifies them with it instead).
+func UseSchemaGuard(db *gorm.DB, schema string) {
+	if schema == "" {
+		return
+	}
+
+	guard := func(tx *gorm.DB) {
+		target := foreignSchema(tx.Statement, schema)
+		if target == "" {
+			return
+		}
+		_ = tx.AddError(apperror.NewInternal(
+			apperror.CodeDbSchemaViolation,
+			fmt.Sprintf("schema %q is not owned by this domain", target),
+		).WithDetail("owned_schema", schema).WithDetail("target_schema", target))
+	}
+	// guardBuilt builds the SQL of the query, which run leaves as is, and
+	// checks it before running it.
+	guardBuilt := func(run func(*gorm.DB)) func(*gorm.DB) {
+		return func(tx *gorm.DB) {
+			if tx.Error == nil {
+				callbacks.BuildQuerySQL(tx)
+				guard(tx)
+			}
+			run(tx)
+		}
+	}
+
+	_ = db.Callback().Create().Before("gorm:create").Register("schema:guard_create", guard)
+	_ = db.Callback().Update().Before("gorm:update").Register("schema:guard_update", guard)
+	_ = db.Callback().Delete().Before("gorm:delete").Register("schema:guard_delete", guard)
+	_ = db.Callback().Raw().Before("gorm:raw").Register("schema:guard_raw", guard)
+	_ = db.Callback().Query().Replace("gorm:query", guardBuilt(db.Callback().Query().Get("gorm:query")))
+	_ = db.Callback().Row().Replace("gorm:row", guardBuilt(db.Callback().Row().Get("gorm:row")))
+}
+
+// foreignSchema returns the first schema other than owned referenced by the
+// statement: by its target table, or by the tables of its SQL when built.
+// Returns "" when there is none.
+func foreignSchema(stmt *gorm.Statement, owned string) string {
+	if stmt == nil {
+		return ""
+	}
+	schemas := referencedSchemas(stmt.SQL.String())
+	if s := statementSchema(stmt); s != "" {
+		schemas = append(schemas, s)
+	}
+	for _, s := range schemas {
+		if !strings.EqualFold(s, owned) {
+			return s
+		}
+	}
+	return ""
+}
+
+// statementSchema resolves the schema qualifier of the statement's target table.
+// It inspects both the parsed table name (from TableName()) and the raw table
+// expression (from db.Table("schema.table")). Returns "" when unqualified.
+func statementSchema(stmt *gorm.Statement) string {
+	if stmt == nil {
+		return ""
+	}
+
+	if s := schemaOf(stmt.Table); s != "" {
+		return s
+	}
+
+	if stmt.TableExpr != nil {
+		// The target is the first token; the tables joined in the expression
+		// are found in the SQL of the query.
+		expr := strings.Fields(stmt.TableExpr.SQL)
+		if len(expr) > 0 {
+			return schemaOf(expr[0])
+		}
+	}
+
+	return ""
+}
+
+func schemaOf(table string) string {
+	table = strings.NewReplacer(`"`, "", "`", "").Replace(table)
+	parts := strings.Split(table, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[len(parts)-2]
+}
+
+// UseSchemaQualifier registers GORM callbacks qualifying the unqualified
+// target table of the statements with schema. The databases sharing a pool
+// (see Pools) cannot pin the search_path of its connections to the schema of
+// each domain: their statements name the schema instead.
+//
+// Only the target table is qualified: the tables of raw SQL (db.Exec,
+// db.Raw) and joins resolve through the search_path of the connection, so
+// qualify them explicitly.
+func UseSchemaQualifier(db *gorm.DB, schema string) {
+	if schema == "" {
+		return
+	}
+
+	qualify := func(tx *gorm.DB) {
+		stmt := tx.Statement
+		if stmt.Table == "" || strings.Contains(stmt.Table, ".") {
+			return
+		}
+		// A table expression other than the bare table (alias, subquery) is
+		// left as written.
+		if stmt.TableExpr != nil && stmt.TableExpr.SQL != stmt.Quote(stmt.Table) {
+			return
+		}
+		stmt.Table = schema + "." + stmt.Table
+		if stmt.TableExpr != nil {
+			stmt.TableExpr = &clause.Expr{SQL: stmt.Quote(stmt.Table)}
+		}
+	}
+
+	_ = db.Callback().Create().Before("gorm:create").Register("schema:qualify_create", qualify)
+	_ = db.Callback().Query().Before("gorm:query").Register("schema:qualify_query", qualify)
+	_ = db.Callback().Update().Before("gorm:update").Register("schema:qualify_update", qualify)
+	_ = db.Callback().Delete().Before("gorm:delete").Register("schema:qualify_delete", qualify)
+	_ = db.Callback().Row().Before("gorm:row").Register("schema:qualify_row", qualify)
+}
+
+// referencedSchemas returns the schema qualifiers of the tables referenced by
+// query: the names following FROM (and the comma-separated names of its
+// list), JOIN, INTO and UPDATE, at any depth of subquery. The FROM of a
+// function call (EXTRACT(YEAR FROM ...), IS DISTINCT FROM) names a column, not
+// a table. Literals and comments are skipped.
+func referencedSchemas(query string) []string {
+	type scope struct {
+		subquery bool // the parentheses hold a subquery, not a call or a list
+		fromList bool // inside the table list of a FROM
+	}
+	var (
+		schemas     []string
+		scopes      = []scope{{subquery: true}}
+		expectTable bool
+		prevWord    string
+	)
+	tokens := sqlTokens(query)
+	for i, tok := range tokens {
+		top := &scopes[len(scopes)-1]
+		switch {
+		case tok == "(":
+			if expectTable {
+				top.fromList = true
+				expectTable = false
+			}
+			next := ""
+			if i+1 < len(tokens) {
+				next = strings.ToUpper(tokens[i+1])
+			}
+			scopes = append(scopes, scope{subquery: next == "SELECT" || next == "WITH" || next == "VALUES"})
+
+		case tok == ")":
+			if len(scopes) > 1 {
+				scopes = scopes[:len(scopes)-1]
+			}
+			expectTable = false
+
+		case tok == ",":
+			expectTable = top.fromList
+
+		case expectTable:
+			if s := schemaOf(tok); s != "" {
+				schemas = append(schemas, s)
+			}
+			expectTable = false
+
+		default:
+			word := strings.ToUpper(tok)
+			switch word {
+			case "FROM":
+				if top.subquery && prevWord != "DISTINCT" {
+					expectTable, top.fromList = true, true
+				}
+			case "JOIN", "INTO", "UPDATE":
+				expectTable, top.fromList = true, false
+			case "WHERE", "ON", "USING", "SET", "VALUES", "GROUP", "ORDER", "HAVING",
+				"LIMIT", "UNION", "INTERSECT", "EXCEPT", "RETURNING", "WINDOW", "FOR":
+				top.fromList = false
+			}
+			prevWord = word
+		}
+	}
+	return schemas
+}
+
+// sqlTokens splits query into words, dotted names (merchant.merchants, with
+// their quotes) and single-character symbols, without the literals and the
+// comments.
+func sqlTokens(query string) []string {
+	var tokens []string
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+		case c == '\'':
+			i = skipSQLQuoted(query, i)
+
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return tokens
+			}
+			i += end
+
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return tokens
+			}
+			i += end + 4
+
+		case c == '"' || c == '`' || isSQLIdentChar(c):
+			// A name, and the names it is qualified with.
+			j := i
+			for j < len(query) {
+				if q := query[j]; q == '"' || q == '`' {
+					end := strings.IndexByte(query[j+1:], q)
+					if end < 0 {
+						j = len(query)
+						break
+					}
+					j += end + 2
+				} else if isSQLIdentChar(q) {
+					for j < len(query) && isSQLIdentChar(query[j]) {
+						j++
+					}
+				} else {
+					break
+				}
+				if j+1 < len(query) && query[j] == '.' && (query[j+1] == '"' || query[j+1] == '`' || isSQLIdentChar(query[j+1])) {
+					j++
+					continue
+				}
+				break
+			}
+			tokens = append(tokens, query[i:j])
+			i = j
+
+		default:
+			tokens = append(tokens, query[i:i+1])
+			i++
+		}
+	}
+	return tokens
+}
+
+// skipSQLQuoted returns the index following the string literal quoted at i,
+// where a doubled quote and a backslash escape a quote.
+func skipSQLQuoted(query string, i int) int {
+	for j := i + 1; j < len(query); j++ {
+		switch query[j] {
+		case '\\':
+			j++
+		case '\'':
+			if j+1 < len(query) && query[j+1] == '\'' {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return len(query)
+}
+
+func isSQLIdentChar(c byte) bool {
+	return c == '_' || c == '$' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
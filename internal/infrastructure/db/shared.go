@@ -0,0 +1,139 @@
+package database
+
+import (
+	"cmp"
+	"database/sql"
+	"fmt"
+	"sync"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlog "gorm.io/gorm/logger"
+)
+
+// Pools are the connection pools of the named connections (see
+// config.DatabaseConfig.Connection), shared by the databases of the domains
+// naming them instead of one pool per domain. A pool is opened with the first
+// database on it, sized by the pool settings of the connection, and closed
+// with the last one.
+//
+// Each database keeps its own logger, callbacks and timeouts on the shared
+// pool; its statements are qualified with the schema of its domain (see
+// UseSchemaQualifier).
+type Pools struct {
+	mu    sync.Mutex
+	pools map[string]*sharedPool
+}
+
+type sharedPool struct {
+	driver string
+	db     *sql.DB
+	refs   int
+}
+
+func NewPools() *Pools {
+	return &Pools{pools: make(map[string]*sharedPool)}
+}
+
+// NewDatabase is NewDatabase opening the primary database of cfg on the pool
+// of its connection, when it names one. Its replicas and tenants get pools of
+// their own.
+func (p *Pools) NewDatabase(cfg *config.DatabaseConfig, log logger.Logger, trc tracer.Tracer) Database {
+	if cfg.Connection == "" {
+		return NewDatabase(cfg, log, trc)
+	}
+	resolved, err := cfg.ForConnection()
+	var db Database
+	if err == nil {
+		db, err = p.open(cfg.Connection, &resolved, log, trc)
+	}
+	if err != nil {
+		log.Error(fmt.Sprintf("failed to connect database: %v", err))
+		panic(err)
+	}
+	return withReplicasAndTenants(db, &resolved, log, trc)
+}
+
+// open returns a database of cfg on the pool of the connection name, opening
+// the pool when it is the first one.
+func (p *Pools) open(name string, cfg *config.DatabaseConfig, log logger.Logger, trc tracer.Tracer) (Database, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pool, ok := p.pools[name]
+	if !ok {
+		sqlDB, err := openPool(cfg)
+		if err != nil {
+			return nil, err
+		}
+		pool = &sharedPool{driver: cmp.Or(cfg.Driver, config.DatabaseDriverPostgres), db: sqlDB}
+		p.pools[name] = pool
+	}
+	if driver := cmp.Or(cfg.Driver, config.DatabaseDriverPostgres); driver != pool.driver {
+		return nil, fmt.Errorf("database connection %q is opened with the %s driver, not %s", name, pool.driver, driver)
+	}
+
+	var dialector gorm.Dialector
+	if pool.driver == config.DatabaseDriverMySQL {
+		dialector = mysql.New(mysql.Config{Conn: pool.db})
+	} else {
+		dialector = postgres.New(postgres.Config{Conn: pool.db})
+	}
+	db, err := openGorm(dialector, cfg, log, trc)
+	if err != nil {
+		if pool.refs == 0 {
+			delete(p.pools, name)
+			_ = pool.db.Close()
+		}
+		return nil, err
+	}
+	UseSchemaQualifier(db, cfg.Schema)
+
+	pool.refs++
+	shared := newGormDatabase(db, cfg)
+	var once sync.Once
+	shared.release = func() (err error) {
+		once.Do(func() { err = p.release(name, pool) })
+		return err
+	}
+	return shared, nil
+}
+
+// release closes the pool of the connection name once its last database is
+// closed.
+func (p *Pools) release(name string, pool *sharedPool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pool.refs--
+	if pool.refs > 0 {
+		return nil
+	}
+	delete(p.pools, name)
+	return pool.db.Close()
+}
+
+// openPool opens the connection pool of cfg. Its search_path is left to the
+// default of the server: the schema differs between the databases on it.
+func openPool(cfg *config.DatabaseConfig) (*sql.DB, error) {
+	shared := *cfg
+	shared.Schema = ""
+	dialector, err := newDialector(&shared)
+	if err != nil {
+		return nil, err
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: gormlog.Discard})
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	configurePool(sqlDB, &shared)
+	return sqlDB, nil
+}
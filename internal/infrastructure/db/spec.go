@@ -0,0 +1,87 @@
+package database
+
+import (
+	"fmt"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/spec"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SpecFields maps the fields of a spec.Spec to the columns of a query
+// repository: the fields its callers may filter and order by.
+type SpecFields map[string]string
+
+// ApplySpec adds the filters, orders and page of s to db. The fields are
+// translated with fields and quoted as identifiers, the values bound as
+// parameters: s cannot inject SQL. A field missing from fields, or a filter
+// with an unknown operator or the wrong number of values, fails the query
+// with INVALID_REQUEST.
+func ApplySpec(db *gorm.DB, s spec.Spec, fields SpecFields) *gorm.DB {
+	for _, f := range s.Filters {
+		col, ok := fields[f.Field]
+		if !ok {
+			return invalidSpec(db, fmt.Sprintf("unknown filter field %q", f.Field))
+		}
+		expr, err := filterExpr(clause.Column{Name: col}, f)
+		if err != "" {
+			return invalidSpec(db, err)
+		}
+		db = db.Where(expr)
+	}
+
+	for _, o := range s.Orders {
+		col, ok := fields[o.Field]
+		if !ok {
+			return invalidSpec(db, fmt.Sprintf("unknown order field %q", o.Field))
+		}
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: col}, Desc: bool(o.Direction)})
+	}
+
+	if s.Limit > 0 {
+		db = db.Limit(s.Limit)
+	}
+	if s.Offset > 0 {
+		db = db.Offset(s.Offset)
+	}
+	return db
+}
+
+// filterExpr returns the condition of f on col, or why f is invalid.
+func filterExpr(col clause.Column, f spec.Filter) (clause.Expression, string) {
+	switch f.Op {
+	case spec.OpIn:
+		return clause.IN{Column: col, Values: f.Values}, ""
+	case spec.OpBetween:
+		if len(f.Values) != 2 {
+			return nil, fmt.Sprintf("filter %q needs 2 bounds", f.Field)
+		}
+		return clause.Expr{SQL: "? BETWEEN ? AND ?", Vars: []any{col, f.Values[0], f.Values[1]}}, ""
+	}
+
+	if len(f.Values) != 1 {
+		return nil, fmt.Sprintf("filter %q needs a single value", f.Field)
+	}
+	v := f.Values[0]
+	switch f.Op {
+	case spec.Eq:
+		return clause.Eq{Column: col, Value: v}, ""
+	case spec.Ne:
+		return clause.Neq{Column: col, Value: v}, ""
+	case spec.Lt:
+		return clause.Lt{Column: col, Value: v}, ""
+	case spec.Lte:
+		return clause.Lte{Column: col, Value: v}, ""
+	case spec.Gt:
+		return clause.Gt{Column: col, Value: v}, ""
+	case spec.Gte:
+		return clause.Gte{Column: col, Value: v}, ""
+	}
+	return nil, fmt.Sprintf("unknown operator %q of filter %q", f.Op, f.Field)
+}
+
+func invalidSpec(db *gorm.DB, msg string) *gorm.DB {
+	_ = db.AddError(apperror.NewPersistance(apperror.CodeInvalidRequest, msg))
+	return db
+}
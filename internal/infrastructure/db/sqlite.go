@@ -0,0 +1,72 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/sqlmask"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// NewSQLiteDatabase opens a private in-memory SQLite database identified by name.
+//
+// It exists for the in-memory test mode (see test/helper) so that the full
+// application can run without external services. The database lives as long
+// as the returned connection is open; each name yields an isolated database.
+//
+// The pool is limited to a single connection because in-memory SQLite does not
+// support concurrent writers; callers are serialized by database/sql.
+func NewSQLiteDatabase(name string, log logger.Logger, trc tracer.Tracer) Database {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_pragma=foreign_keys(1)", name)
+
+	db, err := gorm.Open(
+		sqlite.Open(dsn),
+		&gorm.Config{
+			Logger:                 NewGormLoggerBridge(log, sqlmask.DialectSQLite),
+			SkipDefaultTransaction: true,
+		},
+	)
+	if err != nil {
+		log.Error(fmt.Sprintf("failed to open sqlite database: %v", err))
+		panic(err)
+	}
+
+	UseTenantScope(db)
+
+	if trc != nil {
+		trc.UseGorm(db)
+	}
+
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	return &gormDatabase{db: db}
+}
+
+// mapSQLiteError handles the SQLite errors that have a Postgres counterpart in
+// mapPgError, so that repositories behave identically in the in-memory test mode.
+func mapSQLiteError(err error) error {
+	msg := err.Error()
+
+	switch {
+	case strings.Contains(msg, "UNIQUE constraint failed"):
+		return apperror.NewPersistance(apperror.CodeDbConflict, "duplicate data", err)
+
+	case strings.Contains(msg, "FOREIGN KEY constraint failed"),
+		strings.Contains(msg, "NOT NULL constraint failed"),
+		strings.Contains(msg, "CHECK constraint failed"):
+		return apperror.NewPersistance(apperror.CodeDbConstraint, "database constraint violation: "+msg, err)
+
+	case strings.Contains(msg, "database is locked"):
+		return apperror.NewTransient(apperror.CodeDbTimeout, "database lock timeout", err)
+
+	case strings.Contains(msg, "no such table"), strings.Contains(msg, "no such column"):
+		return apperror.NewInternal(apperror.CodeInternalError, "database schema or syntax error", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"reflect"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// TenantColumn is the column of the tenant owning a row. A model with this
+// column (a nullable varchar(128), indexed) is tenant-scoped, see
+// UseTenantScope.
+const TenantColumn = "tenant_id"
+
+// ErrTenantMismatch aborts the creation of a row owned by another tenant than
+// the one of the context.
+var ErrTenantMismatch = apperror.NewInternal(apperror.CodeDbConstraint, "row owned by another tenant")
+
+// UseTenantScope registers GORM callbacks that scope the statements on the
+// tenant-scoped models to the tenant of the context (ctxkey.GetTenantID):
+//   - queries, updates and deletes only match the rows of the tenant;
+//   - created and saved rows are stamped with the tenant.
+//
+// Every repository is scoped this way, the GormBaseRepository ones included.
+// A context without tenant (event handlers, workers, single-tenant
+// deployments) is not scoped. Raw SQL and the statements on a bare table
+// (db.Table) are never scoped: filter them on TenantColumn explicitly.
+func UseTenantScope(db *gorm.DB) {
+	_ = db.Callback().Create().Before("gorm:create").Register("tenant:stamp_create", stampTenant)
+	_ = db.Callback().Query().Before("gorm:query").Register("tenant:scope_query", scopeTenant)
+	_ = db.Callback().Update().Before("gorm:update").Register("tenant:stamp_update", stampTenant)
+	_ = db.Callback().Update().Before("gorm:update").Register("tenant:scope_update", scopeTenant)
+	_ = db.Callback().Delete().Before("gorm:delete").Register("tenant:scope_delete", scopeTenant)
+}
+
+// tenantField returns the tenant of the statement context and the tenant
+// field of its model, nil when either is missing.
+func tenantField(tx *gorm.DB) (string, *schema.Field) {
+	stmt := tx.Statement
+	if tx.Error != nil || stmt.Schema == nil {
+		return "", nil
+	}
+	tenant := ctxkey.GetTenantID(stmt.Context)
+	if tenant == "" {
+		return "", nil
+	}
+	return tenant, stmt.Schema.LookUpField(TenantColumn)
+}
+
+// scopeTenant adds "tenant_id = <tenant>" to the conditions of the statement.
+// The existing conditions are grouped first, so that an OR cannot escape the
+// tenant.
+func scopeTenant(tx *gorm.DB) {
+	tenant, field := tenantField(tx)
+	if field == nil {
+		return
+	}
+
+	eq := clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: tenant}
+	exprs := []clause.Expression{eq}
+	if c, ok := tx.Statement.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			exprs = []clause.Expression{clause.And(where.Exprs...), eq}
+		}
+	}
+	tx.Statement.Clauses["WHERE"] = clause.Clause{Name: "WHERE", Expression: clause.Where{Exprs: exprs}}
+}
+
+// stampTenant sets the tenant of the created or saved rows that have none;
+// a row of another tenant aborts the statement with ErrTenantMismatch.
+func stampTenant(tx *gorm.DB) {
+	tenant, field := tenantField(tx)
+	if field == nil {
+		return
+	}
+
+	stamp := func(row reflect.Value) {
+		row = reflect.Indirect(row)
+		if row.Kind() != reflect.Struct {
+			return
+		}
+		switch v, zero := field.ValueOf(tx.Statement.Context, row); {
+		case zero:
+			var value any = tenant
+			if field.FieldType.Kind() == reflect.Pointer {
+				value = &tenant
+			}
+			_ = tx.AddError(field.Set(tx.Statement.Context, row, value))
+		case reflect.Indirect(reflect.ValueOf(v)).String() != tenant:
+			_ = tx.AddError(ErrTenantMismatch)
+		}
+	}
+
+	switch rv := tx.Statement.ReflectValue; rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			stamp(rv.Index(i))
+		}
+	default:
+		stamp(rv)
+	}
+}
@@ -0,0 +1,67 @@
+package dedupe
+
+import (
+	"context"
+	"time"
+	database "voyago/core-api/internal/infrastructure/db"
+
+	"gorm.io/gorm/clause"
+)
+
+// Record is an event processed by a consumer, in the processed_events table
+// of the module database.
+type Record struct {
+	Consumer    string `gorm:"column:consumer;type:varchar(128);primaryKey"`
+	EventID     string `gorm:"column:event_id;type:varchar(128);primaryKey"`
+	ProcessedAt int64  `gorm:"column:processed_at;type:bigint;not null"`
+}
+
+func (Record) TableName() string {
+	return "processed_events"
+}
+
+// DatabaseStore records the processed events in the transaction of their
+// handler.
+type DatabaseStore struct {
+	db database.Database
+}
+
+var _ Store = (*DatabaseStore)(nil)
+
+// NewDatabaseStore returns the store of the processed_events table of db.
+func NewDatabaseStore(db database.Database) *DatabaseStore {
+	return &DatabaseStore{db: db}
+}
+
+// Once records id and runs fn in one transaction (a savepoint within the
+// transaction of ctx). A concurrent delivery of id waits for the record to
+// commit, then is skipped; it runs fn when the first one rolled back.
+func (s *DatabaseStore) Once(ctx context.Context, consumer, id string, fn func(ctx context.Context) error) (bool, error) {
+	var ran bool
+	err := s.db.Atomic(ctx, func(txCtx context.Context) error {
+		ran = false // the transaction may be retried
+		res := s.db.WithContext(txCtx).Clauses(clause.OnConflict{DoNothing: true}).Create(&Record{
+			Consumer:    consumer,
+			EventID:     id,
+			ProcessedAt: time.Now().UnixMilli(),
+		})
+		if res.Error != nil {
+			return database.MapDBError(res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		ran = true
+		return fn(txCtx)
+	})
+	return ran, err
+}
+
+// Purge deletes the records older than retention, once their events can no
+// longer be delivered again (e.g. past the retention of the broker), and
+// returns how many were deleted.
+func (s *DatabaseStore) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	before := time.Now().Add(-retention).UnixMilli()
+	res := s.db.WithContext(ctx).Where("processed_at < ?", before).Delete(&Record{})
+	return res.RowsAffected, database.MapDBError(res.Error)
+}
@@ -0,0 +1,50 @@
+// Package dedupe makes the consumers of at-least-once deliveries (broker
+// redeliveries and retries, a relay publishing an event twice) process each
+// event once: the ID of a processed event is recorded per consumer, and the
+// deliveries of a recorded ID are skipped.
+//
+// The Database store records the ID in the transaction of the handler: the
+// record and the changes of the handler commit, or roll back, together. It
+// suits handlers writing to the database of their module, joined with
+// db.WithContext(ctx) or db.Atomic(ctx, ...).
+//
+// The Redis store records the ID around the handler, for side effects
+// outside the database (e-mails, HTTP calls): a process crashing while
+// handling an event has it handled again once the lease expires.
+//
+//	router.Handle(topic, messaging.EventHandler(
+//		dedupe.Handler(dedupe.NewDatabaseStore(db), "booking.apply_payment_status", sub.HandlePaymentStatusChanged),
+//	))
+package dedupe
+
+import (
+	"context"
+	"errors"
+	"voyago/core-api/internal/infrastructure/eventbus"
+)
+
+// ErrInProgress is returned by the Redis store when another consumer is
+// handling the event: the delivery should be retried later.
+var ErrInProgress = errors.New("dedupe: event being processed")
+
+// Store records the events processed by the consumers.
+type Store interface {
+	// Once runs fn unless consumer processed id before, reporting whether fn
+	// ran. When fn fails, its error is returned and id is not recorded.
+	Once(ctx context.Context, consumer, id string, fn func(ctx context.Context) error) (bool, error)
+}
+
+// Handler wraps h so that consumer handles each event ID once. The consumer
+// names the handler, not the module: two handlers of the same events need
+// their own names. Events without ID are always handled.
+func Handler(store Store, consumer string, h eventbus.Handler) eventbus.Handler {
+	return func(ctx context.Context, evt eventbus.Event) error {
+		if evt.ID == "" {
+			return h(ctx, evt)
+		}
+		_, err := store.Once(ctx, consumer, evt.ID, func(ctx context.Context) error {
+			return h(ctx, evt)
+		})
+		return err
+	}
+}
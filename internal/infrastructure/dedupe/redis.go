@@ -0,0 +1,79 @@
+package dedupe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultRedisTTL   = 7 * 24 * time.Hour
+	defaultRedisLease = time.Minute
+
+	stateProcessing = "processing"
+	stateDone       = "done"
+)
+
+// RedisStore records the processed events in Redis, as the key
+// "<prefix><consumer>:<id>": "processing" for the lease while the handler
+// runs, then "done" for the TTL.
+type RedisStore struct {
+	client redis.Cmdable
+	prefix string
+	ttl    time.Duration
+	lease  time.Duration
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// NewRedisStore returns a store on client. ttl is how long a processed ID
+// is remembered (default 7 days), past the time its event may be delivered
+// again; lease bounds the duration of a handler (default 1 minute).
+func NewRedisStore(client redis.Cmdable, prefix string, ttl, lease time.Duration) *RedisStore {
+	if ttl <= 0 {
+		ttl = defaultRedisTTL
+	}
+	if lease <= 0 {
+		lease = defaultRedisLease
+	}
+	return &RedisStore{client: client, prefix: prefix, ttl: ttl, lease: lease}
+}
+
+// Once claims id for the lease and runs fn. It fails with ErrInProgress when
+// id is claimed by another delivery still running. A failing fn releases id.
+func (s *RedisStore) Once(ctx context.Context, consumer, id string, fn func(ctx context.Context) error) (bool, error) {
+	key := s.prefix + consumer + ":" + id
+	claimed, err := s.client.SetNX(ctx, key, stateProcessing, s.lease).Result()
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		state, err := s.client.Get(ctx, key).Result()
+		switch {
+		case errors.Is(err, redis.Nil):
+			return false, ErrInProgress // released meanwhile: retried later
+		case err != nil:
+			return false, err
+		case state == stateDone:
+			return false, nil
+		}
+		return false, ErrInProgress
+	}
+
+	// The claim is settled even when ctx is canceled by then.
+	settleCtx := context.WithoutCancel(ctx)
+	if err := fn(ctx); err != nil {
+		if derr := s.client.Del(settleCtx, key).Err(); derr != nil {
+			return true, errors.Join(err, fmt.Errorf("release %s: %w", key, derr))
+		}
+		return true, err
+	}
+	if err := s.client.Set(settleCtx, key, stateDone, s.ttl).Err(); err != nil {
+		// The claim expires with the lease: a delivery after it runs fn again.
+		return true, fmt.Errorf("record %s: %w", key, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,120 @@
+// Package errorreport reports the unexpected failures of the requests (the
+// internal errors and the panics) to an error tracker, where they are grouped,
+// assigned and alerted on with the context of the request: its trace ID, the
+// masked request, the caller (user, tenant) and the release.
+//
+// The transports report through a Reporter (see middleware.Telemetrist and
+// interceptor.Telemetrist HandleErrorReport); the expected failures
+// (validation, not found, conflicts) and the transient ones, measured by the
+// error metrics, are not reported (see Reportable).
+package errorreport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/apperror"
+)
+
+// Event is a failure to report.
+type Event struct {
+	Err error
+	// Panic is set for a recovered panic, Err being the error answered.
+	Panic bool
+	// Stack is where the failure happened, in the layout of
+	// runtime/debug.Stack: the stack of the panic, or the one captured by the
+	// AppError (see apperror.StackTrace).
+	Stack string
+	// TraceID ties the report to the trace and the logs of the request.
+	TraceID string
+	// Transport is "http" or "grpc".
+	Transport string
+	Request   Request
+
+	// UserID, TenantID and ClientApp are the caller of the request.
+	UserID    string
+	TenantID  string
+	ClientApp string
+}
+
+// Request is the request failing, masked like its logs.
+type Request struct {
+	// Method is the HTTP method, empty for a gRPC call.
+	Method string
+	// URL is the path of an HTTP request (without its query), the full
+	// method of a gRPC call; Route is its pattern.
+	URL   string
+	Route string
+	// Headers, Query and Body are masked (see utils.MaskHttpHeaders and
+	// utils.MaskSensitive).
+	Headers map[string]string
+	Query   map[string]any
+	Body    any
+}
+
+// NewEvent returns the event of err failing the request of ctx, with its
+// caller and the stack captured by the AppError.
+func NewEvent(ctx context.Context, err error) Event {
+	return Event{
+		Err:       err,
+		Stack:     apperror.StackTrace(err),
+		UserID:    ctxkey.GetUserID(ctx),
+		TenantID:  ctxkey.GetTenantID(ctx),
+		ClientApp: ctxkey.GetClientApp(ctx),
+	}
+}
+
+// Reportable reports whether err is an unexpected failure: an AppError of
+// KindInternal, or an error which is no AppError. The requests canceled by
+// their client are not.
+func Reportable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Kind == apperror.KindInternal
+	}
+	return true
+}
+
+// Reporter sends the failures to an error tracker.
+type Reporter interface {
+	// Report sends e in the background, never blocking the request. e is not
+	// kept: the request may reuse its memory once Report returned.
+	Report(ctx context.Context, e Event)
+	// Close sends the pending reports.
+	Close() error
+}
+
+// NewReporter returns the reporter of the driver of error_reporting, a no-op
+// one when disabled. The release and environment default to those of the
+// application.
+func NewReporter(cfg *config.Config, log logger.Logger) (Reporter, error) {
+	rc := cfg.ErrorReporting
+	if !rc.Enabled {
+		return NewNoOpReporter(), nil
+	}
+	if rc.Release == "" {
+		rc.Release = cfg.App.Version
+	}
+	if rc.Environment == "" {
+		rc.Environment = cfg.App.Env
+	}
+
+	switch rc.Driver {
+	case config.ErrorReportingDriverSentry, "":
+		return NewSentry(rc, log)
+	default:
+		return nil, fmt.Errorf("unknown driver %q", rc.Driver)
+	}
+}
+
+type noOpReporter struct{}
+
+func NewNoOpReporter() Reporter                    { return noOpReporter{} }
+func (noOpReporter) Report(context.Context, Event) {}
+func (noOpReporter) Close() error                  { return nil }
@@ -0,0 +1,384 @@
+package errorreport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+const (
+	defaultQueueSize   = 100
+	defaultSendTimeout = 5 * time.Second
+
+	sentryClient = "voyago-core-api/1.0"
+	// appModule prefixes the functions of the application, the in-app frames
+	// of the stack traces.
+	appModule = "voyago/core-api/"
+)
+
+// Sentry sends the failures to a Sentry project, as events of its envelope
+// endpoint, from a single goroutine: the requests never wait for Sentry. The
+// failures arriving while the queue is full are counted and logged on Close.
+type Sentry struct {
+	endpoint    string
+	auth        string
+	dsn         string
+	release     string
+	environment string
+	serverName  string
+	client      *http.Client
+	log         logger.Logger
+
+	queue   chan []byte
+	dropped atomic.Uint64
+	closed  atomic.Bool
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+var _ Reporter = (*Sentry)(nil)
+
+// NewSentry starts sending to the project of cfg.Sentry.DSN. It fails on an
+// invalid DSN.
+func NewSentry(cfg config.ErrorReportingConfig, log logger.Logger) (*Sentry, error) {
+	endpoint, key, err := parseDSN(cfg.Sentry.DSN)
+	if err != nil {
+		return nil, err
+	}
+	size := cfg.QueueSize
+	if size <= 0 {
+		size = defaultQueueSize
+	}
+	timeout := defaultSendTimeout
+	if cfg.Timeout > 0 {
+		timeout = time.Duration(cfg.Timeout) * time.Second
+	}
+	hostname, _ := os.Hostname()
+
+	s := &Sentry{
+		endpoint:    endpoint,
+		auth:        fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", sentryClient, key),
+		dsn:         cfg.Sentry.DSN,
+		release:     cfg.Release,
+		environment: cfg.Environment,
+		serverName:  hostname,
+		client:      &http.Client{Timeout: timeout},
+		log:         log.WithField("component", "errorreport"),
+		queue:       make(chan []byte, size),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+// parseDSN returns the envelope endpoint and the public key of dsn
+// (https://<public key>@<host>[/<path>]/<project ID>).
+func parseDSN(dsn string) (string, string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", fmt.Errorf("sentry: invalid DSN: %w", err)
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	i := strings.LastIndexByte(path, '/')
+	if u.Scheme == "" || u.Host == "" || u.User.Username() == "" || i < 0 || i == len(path)-1 {
+		return "", "", errors.New("sentry: invalid DSN, expected https://<public key>@<host>/<project ID>")
+	}
+	return fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, path[:i], path[i+1:]), u.User.Username(), nil
+}
+
+func (s *Sentry) Report(_ context.Context, e Event) {
+	if s.closed.Load() {
+		s.dropped.Add(1)
+		return
+	}
+	envelope, err := s.envelope(e)
+	if err != nil {
+		s.log.WithField("error", err.Error()).Warn("error report could not be encoded")
+		return
+	}
+	select {
+	case s.queue <- envelope:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// Close sends the queued reports. The reports made after Close are dropped.
+func (s *Sentry) Close() error {
+	s.once.Do(func() {
+		s.closed.Store(true)
+		close(s.stop)
+		<-s.done
+		if n := s.dropped.Load(); n > 0 {
+			s.log.WithField("dropped", n).Warn("error reports dropped, the queue was full")
+		}
+	})
+	return nil
+}
+
+func (s *Sentry) run() {
+	defer close(s.done)
+	for {
+		select {
+		case envelope := <-s.queue:
+			s.send(envelope)
+		case <-s.stop:
+			for {
+				select {
+				case envelope := <-s.queue:
+					s.send(envelope)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *Sentry) send(envelope []byte) {
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(envelope))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", s.auth)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.log.WithField("error", err.Error()).Warn("error report failed")
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		s.log.WithField("status", resp.StatusCode).Warn("error report rejected by sentry")
+	}
+}
+
+// sentryEvent is the subset of the Sentry event payload reported.
+type sentryEvent struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	Release     string            `json:"release,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Transaction string            `json:"transaction,omitempty"`
+	Tags        map[string]string `json:"tags"`
+	User        *sentryUser       `json:"user,omitempty"`
+	Request     sentryRequest     `json:"request"`
+	Exception   struct {
+		Values []sentryException `json:"values"`
+	} `json:"exception"`
+	Extra map[string]any `json:"extra,omitempty"`
+}
+
+type sentryUser struct {
+	ID string `json:"id"`
+}
+
+type sentryRequest struct {
+	Method      string            `json:"method,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	QueryString map[string]any    `json:"query_string,omitempty"`
+	Data        any               `json:"data,omitempty"`
+}
+
+type sentryException struct {
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+	Mechanism struct {
+		Type    string `json:"type"`
+		Handled bool   `json:"handled"`
+	} `json:"mechanism"`
+	Stacktrace *sentryStacktrace `json:"stacktrace,omitempty"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	AbsPath  string `json:"abs_path"`
+	Filename string `json:"filename"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// envelope encodes e as a Sentry envelope of a single event.
+func (s *Sentry) envelope(e Event) ([]byte, error) {
+	id, err := eventID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+
+	event := sentryEvent{
+		EventID:     id,
+		Timestamp:   now,
+		Platform:    "go",
+		Level:       "error",
+		Release:     s.release,
+		Environment: s.environment,
+		ServerName:  s.serverName,
+		Transaction: strings.TrimSpace(e.Request.Method + " " + e.Request.Route),
+		Tags: map[string]string{
+			"transport": e.Transport,
+			"trace_id":  e.TraceID,
+		},
+		Request: sentryRequest{
+			Method:      e.Request.Method,
+			URL:         e.Request.URL,
+			Headers:     e.Request.Headers,
+			QueryString: e.Request.Query,
+			Data:        e.Request.Body,
+		},
+		Extra: map[string]any{"error.chain": apperror.Chain(e.Err)},
+	}
+	if e.UserID != "" {
+		event.User = &sentryUser{ID: e.UserID}
+	}
+	if e.TenantID != "" {
+		event.Tags["tenant_id"] = e.TenantID
+	}
+	if e.ClientApp != "" {
+		event.Tags["client_app"] = e.ClientApp
+	}
+
+	exception := sentryException{Type: fmt.Sprintf("%T", apperror.RootCause(e.Err)), Value: e.Err.Error()}
+	var appErr *apperror.AppError
+	if errors.As(e.Err, &appErr) {
+		exception.Type = appErr.Code
+		exception.Value = strings.Join(apperror.Chain(e.Err), ": ")
+		event.Tags["error.code"] = appErr.Code
+	}
+	exception.Value = utils.MaskPatterns(exception.Value)
+	exception.Mechanism.Type, exception.Mechanism.Handled = "generic", true
+	if e.Panic {
+		exception.Mechanism.Type, exception.Mechanism.Handled = "panic", false
+	}
+	if frames := parseStack(e.Stack); len(frames) > 0 {
+		exception.Stacktrace = &sentryStacktrace{Frames: frames}
+	}
+	event.Exception.Values = []sentryException{exception}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	header, err := json.Marshal(map[string]string{"event_id": id, "sent_at": now, "dsn": s.dsn})
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.Write(header)
+	fmt.Fprintf(&b, "\n{\"type\":\"event\",\"length\":%d}\n", len(payload))
+	b.Write(payload)
+	b.WriteByte('\n')
+	return b.Bytes(), nil
+}
+
+// eventID returns a random Sentry event ID: a UUID v4 without dashes.
+func eventID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return hex.EncodeToString(b[:]), nil
+}
+
+// parseStack turns a stack in the layout of runtime/debug.Stack into Sentry
+// frames, the outermost call first as Sentry expects.
+//
+//	voyago/core-api/internal/modules/booking/usecase.(*createBookingUseCase).Execute(0xc000a1e000, ...)
+//		/app/internal/modules/booking/usecase/create_booking.go:120 +0x1d
+func parseStack(stack string) []sentryFrame {
+	var frames []sentryFrame
+	var function string
+	for line := range strings.SplitSeq(stack, "\n") {
+		if !strings.HasPrefix(line, "\t") {
+			function = parseFunction(line)
+			continue
+		}
+		if function == "" {
+			continue
+		}
+		location, _, _ := strings.Cut(strings.TrimSpace(line), " +0x")
+		i := strings.LastIndexByte(location, ':')
+		if i < 0 {
+			continue
+		}
+		lineno, err := strconv.Atoi(location[i+1:])
+		if err != nil {
+			continue
+		}
+		module, name := splitFunction(function)
+		frames = append(frames, sentryFrame{
+			Function: name,
+			Module:   module,
+			AbsPath:  location[:i],
+			Filename: location[:i],
+			Lineno:   lineno,
+			InApp:    strings.HasPrefix(function, appModule),
+		})
+		function = ""
+	}
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return frames
+}
+
+// parseFunction returns the function of a line of a stack, without its
+// arguments; empty for the goroutine headers.
+func parseFunction(line string) string {
+	if strings.HasPrefix(line, "goroutine ") {
+		return ""
+	}
+	if rest, ok := strings.CutPrefix(line, "created by "); ok {
+		line, _, _ = strings.Cut(rest, " in goroutine ")
+	}
+	if strings.HasSuffix(line, ")") {
+		if i := strings.LastIndexByte(line, '('); i > 0 {
+			line = line[:i]
+		}
+	}
+	return line
+}
+
+// splitFunction splits a function into its package and its name:
+// "voyago/core-api/internal/app.(*Server).Run" into
+// "voyago/core-api/internal/app" and "(*Server).Run".
+func splitFunction(function string) (string, string) {
+	slash := strings.LastIndexByte(function, '/')
+	dot := strings.IndexByte(function[slash+1:], '.')
+	if dot < 0 {
+		return "", function
+	}
+	dot += slash + 1
+	return function[:dot], function[dot+1:]
+}
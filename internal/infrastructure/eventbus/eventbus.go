@@ -0,0 +1,69 @@
+// Package eventbus provides an abstraction for publishing and subscribing to
+// domain events across modules without introducing compile-time coupling.
+package eventbus
+
+import (
+	"context"
+	"time"
+	"voyago/core-api/internal/pkg/uid"
+)
+
+// Wildcard subscribes a handler to every event type published on the bus.
+const Wildcard = "*"
+
+// Event is the envelope exchanged between modules.
+// Payload must be JSON-serializable so that it can cross process boundaries
+// (e.g., webhooks or message brokers) without transformation.
+type Event struct {
+	// ID uniquely identifies the event occurrence (used for de-duplication).
+	ID string `json:"id"`
+	// Type follows the "domain.action" pattern (e.g., "booking.created").
+	Type string `json:"type"`
+	// Source is the module that emitted the event (e.g., "booking").
+	Source string `json:"source"`
+	// OccurredAt is the time the domain change was committed.
+	OccurredAt time.Time `json:"occurred_at"`
+	// SchemaVersion is the version of the Payload schema of Type, raised
+	// when the payload changes incompatibly (0 is version 1).
+	SchemaVersion int `json:"schema_version,omitempty"`
+	// Payload holds the event data.
+	Payload any `json:"data"`
+}
+
+// NewEvent builds an Event with a generated ID and the current timestamp.
+//
+// Example:
+//
+//	evt := eventbus.NewEvent("booking.created", "booking", payload)
+func NewEvent(eventType, source string, payload any) Event {
+	return Event{
+		ID:         uid.NewEventID(),
+		Type:       eventType,
+		Source:     source,
+		OccurredAt: time.Now().UTC(),
+		Payload:    payload,
+	}
+}
+
+// Handler processes a single event. Returning an error only affects logging;
+// handlers are responsible for their own retry semantics.
+type Handler func(ctx context.Context, evt Event) error
+
+// Publisher is the narrow contract used by use cases to emit events.
+type Publisher interface {
+	// Publish emits the event to every subscriber of its type.
+	// It MUST only be called after the related transaction has been committed.
+	Publish(ctx context.Context, evt Event) error
+}
+
+// Bus defines the full event bus contract used by the bootstrap and subscribers.
+type Bus interface {
+	Publisher
+
+	// Subscribe registers a handler for the given event type.
+	// Use Wildcard to receive every event.
+	Subscribe(eventType string, handler Handler)
+
+	// Close stops accepting new events and waits for in-flight handlers to finish.
+	Close() error
+}
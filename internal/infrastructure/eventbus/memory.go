@@ -0,0 +1,87 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/apperror"
+)
+
+type inMemoryBus struct {
+	log logger.Logger
+
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	closed   bool
+	inFlight sync.WaitGroup
+}
+
+var _ Bus = (*inMemoryBus)(nil)
+
+// NewInMemoryBus creates a process-local Bus.
+// Handlers are executed asynchronously so publishers never block on subscribers.
+// The handler context is detached from the publisher's cancellation but keeps its
+// values (request ID, trace), preserving log and trace correlation.
+func NewInMemoryBus(log logger.Logger) Bus {
+	return &inMemoryBus{
+		log:      log.WithField("component", "eventbus"),
+		handlers: make(map[string][]Handler),
+	}
+}
+
+func (b *inMemoryBus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+func (b *inMemoryBus) Publish(ctx context.Context, evt Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return apperror.NewTransient(apperror.CodeInternalError, "event bus is closed")
+	}
+
+	handlers := make([]Handler, 0, len(b.handlers[evt.Type])+len(b.handlers[Wildcard]))
+	handlers = append(handlers, b.handlers[evt.Type]...)
+	handlers = append(handlers, b.handlers[Wildcard]...)
+
+	detached := context.WithoutCancel(ctx)
+	for _, h := range handlers {
+		b.inFlight.Add(1)
+		go b.dispatch(detached, h, evt)
+	}
+	return nil
+}
+
+func (b *inMemoryBus) dispatch(ctx context.Context, h Handler, evt Event) {
+	defer b.inFlight.Done()
+	defer func() {
+		if r := recover(); r != nil {
+			b.log.WithContext(ctx).WithFields(map[string]any{
+				"event_id":   evt.ID,
+				"event_type": evt.Type,
+				"panic":      fmt.Sprintf("%v", r),
+			}).Error("event handler panicked")
+		}
+	}()
+
+	if err := h(ctx, evt); err != nil {
+		b.log.WithContext(ctx).WithFields(map[string]any{
+			"event_id":     evt.ID,
+			"event_type":   evt.Type,
+			"error_detail": err.Error(),
+		}).Error("event handler failed")
+	}
+}
+
+func (b *inMemoryBus) Close() error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	b.inFlight.Wait()
+	return nil
+}
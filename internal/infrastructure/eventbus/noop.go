@@ -0,0 +1,13 @@
+package eventbus
+
+import "context"
+
+type noOpBus struct{}
+
+var _ Bus = (*noOpBus)(nil)
+
+func NewNoOpBus() Bus { return &noOpBus{} }
+
+func (b *noOpBus) Publish(ctx context.Context, evt Event) error { return nil }
+func (b *noOpBus) Subscribe(eventType string, handler Handler)  {}
+func (b *noOpBus) Close() error                                 { return nil }
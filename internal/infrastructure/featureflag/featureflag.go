@@ -0,0 +1,170 @@
+// Package featureflag gates the new behaviors of the use cases behind flags
+// turned on without a release, for some users or tenants first (e.g. a new
+// pricing engine rolled out to 10% of the users).
+//
+// The flags are evaluated for the caller of the request, read from the
+// context (see package ctxkey), by a Provider: the flags of the configuration
+// file (config driver), Unleash or LaunchDarkly. The use cases ask Flags,
+// which turns every failure into a disabled flag: the current behavior.
+//
+//	price := featureflag.Choose(ctx, uc.flags, "new_pricing_engine", uc.pricing.Quote, uc.legacyQuote)
+//	if uc.flags.Enabled(ctx, "instant_confirmation") { ... }
+package featureflag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/logger"
+)
+
+// ErrUnknownFlag is returned by the providers for the flags they do not
+// define.
+var ErrUnknownFlag = errors.New("featureflag: unknown flag")
+
+// Target is who a flag is evaluated for.
+type Target struct {
+	UserID    string
+	TenantID  string
+	ClientApp string
+	Roles     []string
+}
+
+// TargetOf returns the caller of the request of ctx.
+func TargetOf(ctx context.Context) Target {
+	return Target{
+		UserID:    ctxkey.GetUserID(ctx),
+		TenantID:  ctxkey.GetTenantID(ctx),
+		ClientApp: ctxkey.GetClientApp(ctx),
+		Roles:     ctxkey.GetRoles(ctx),
+	}
+}
+
+// key returns the identity a percentage rollout buckets t by: the user, the
+// tenant for the requests without user, empty for anonymous ones.
+func (t Target) key() string {
+	if t.UserID != "" {
+		return t.UserID
+	}
+	return t.TenantID
+}
+
+// Provider evaluates the flags.
+type Provider interface {
+	// Enabled reports whether flag is on for t, ErrUnknownFlag when the
+	// provider does not define it.
+	Enabled(ctx context.Context, flag string, t Target) (bool, error)
+}
+
+// NewProvider returns the provider of the driver of cfg. The unleash and
+// launchdarkly providers fetch the flags in the background until closed
+// (they implement io.Closer); their flags are disabled until the first fetch
+// succeeded.
+func NewProvider(cfg *config.FeatureFlagsConfig, log logger.Logger) (Provider, error) {
+	switch cfg.Driver {
+	case config.FeatureFlagDriverConfig, "":
+		return NewStatic(cfg.Flags), nil
+	case config.FeatureFlagDriverUnleash:
+		return NewUnleash(cfg, log), nil
+	case config.FeatureFlagDriverLaunchDarkly:
+		return NewLaunchDarkly(cfg, log), nil
+	default:
+		return nil, fmt.Errorf("unknown driver %q", cfg.Driver)
+	}
+}
+
+// Flags is what the use cases gate their behaviors with. A nil *Flags has
+// every flag disabled, for the use cases built without flags (e.g. in tests).
+type Flags struct {
+	provider Provider
+	log      logger.Logger
+}
+
+func New(p Provider, log logger.Logger) *Flags {
+	if log == nil {
+		log = logger.NewNoOpLogger()
+	}
+	return &Flags{provider: p, log: log.WithField("component", "featureflag")}
+}
+
+// Enabled reports whether flag is on for the caller of the request of ctx.
+// A flag evaluated with a pinned context (see Pin) keeps its first value for
+// the rest of the request. An unknown flag or a failing provider is logged
+// and disables the flag.
+func (f *Flags) Enabled(ctx context.Context, flag string) bool {
+	if f == nil {
+		return false
+	}
+	p, _ := ctx.Value(pinKey{}).(*pinned)
+	if p == nil {
+		return f.EnabledFor(ctx, flag, TargetOf(ctx))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if on, ok := p.flags[flag]; ok {
+		return on
+	}
+	on := f.EnabledFor(ctx, flag, TargetOf(ctx))
+	p.flags[flag] = on
+	return on
+}
+
+// EnabledFor reports whether flag is on for t, e.g. for the owner of a
+// booking processed by a job.
+func (f *Flags) EnabledFor(ctx context.Context, flag string, t Target) bool {
+	if f == nil {
+		return false
+	}
+	on, err := f.provider.Enabled(ctx, flag, t)
+	if err != nil {
+		f.log.WithContext(ctx).WithFields(map[string]any{
+			"flag":  flag,
+			"error": err.Error(),
+		}).Warn("feature flag evaluation failed, disabled")
+		return false
+	}
+	return on
+}
+
+// Choose returns on when flag is enabled for the caller of the request of
+// ctx, off otherwise.
+func Choose[T any](ctx context.Context, f *Flags, flag string, on, off T) T {
+	if f.Enabled(ctx, flag) {
+		return on
+	}
+	return off
+}
+
+type pinKey struct{}
+
+// pinned are the flags evaluated during a request.
+type pinned struct {
+	mu    sync.Mutex
+	flags map[string]bool
+}
+
+// Pin returns a context whose flags keep their first value: a request sees
+// the same behavior from start to end, even when the flags change meanwhile.
+// The HTTP and gRPC servers pin the context of every request (see
+// middleware.FeatureFlags).
+func Pin(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(pinKey{}).(*pinned); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, pinKey{}, &pinned{flags: map[string]bool{}})
+}
+
+// matches reports whether t is one of the users or tenants; a target list
+// without entries matches every target.
+func (t Target) matches(users, tenants []string) bool {
+	if len(users) == 0 && len(tenants) == 0 {
+		return true
+	}
+	return (t.UserID != "" && slices.Contains(users, t.UserID)) ||
+		(t.TenantID != "" && slices.Contains(tenants, t.TenantID))
+}
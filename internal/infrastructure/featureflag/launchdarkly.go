@@ -0,0 +1,297 @@
+package featureflag
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+)
+
+const defaultLaunchDarklyURL = "https://sdk.launchdarkly.com"
+
+// LaunchDarkly evaluates the boolean flags of a LaunchDarkly environment,
+// polled from its server-side SDK endpoint (or a Relay Proxy) and evaluated
+// locally.
+//
+// The target is a user context keyed by the user ID, with the clientApp and
+// roles attributes, and a tenant context keyed by the tenant ID. The
+// evaluation follows the flag: off variation, prerequisites, individual
+// targets, rules (the in, startsWith, endsWith and contains operators) and
+// fallthrough, with percentage rollouts. A flag is on when it serves the
+// variation true; the segments are not supported.
+type LaunchDarkly struct {
+	*poller
+	url    string
+	sdkKey string
+	client *http.Client
+
+	mu    sync.RWMutex
+	flags map[string]ldFlag
+}
+
+type ldFlag struct {
+	Key            string          `json:"key"`
+	On             bool            `json:"on"`
+	Salt           string          `json:"salt"`
+	Deleted        bool            `json:"deleted"`
+	Variations     []any           `json:"variations"`
+	OffVariation   *int            `json:"offVariation"`
+	Prerequisites  []ldPrereq      `json:"prerequisites"`
+	Targets        []ldTarget      `json:"targets"`
+	ContextTargets []ldTarget      `json:"contextTargets"`
+	Rules          []ldRule        `json:"rules"`
+	Fallthrough    ldVariationSpec `json:"fallthrough"`
+}
+
+type ldPrereq struct {
+	Key       string `json:"key"`
+	Variation int    `json:"variation"`
+}
+
+type ldTarget struct {
+	ContextKind string   `json:"contextKind"`
+	Values      []string `json:"values"`
+	Variation   int      `json:"variation"`
+}
+
+type ldRule struct {
+	ldVariationSpec
+	Clauses []ldClause `json:"clauses"`
+}
+
+type ldClause struct {
+	ContextKind string `json:"contextKind"`
+	Attribute   string `json:"attribute"`
+	Op          string `json:"op"`
+	Values      []any  `json:"values"`
+	Negate      bool   `json:"negate"`
+}
+
+// ldVariationSpec serves a variation, or a variation picked by rollout.
+type ldVariationSpec struct {
+	Variation *int `json:"variation"`
+	Rollout   *struct {
+		ContextKind string `json:"contextKind"`
+		BucketBy    string `json:"bucketBy"`
+		Seed        *int   `json:"seed"`
+		Variations  []struct {
+			Variation int `json:"variation"`
+			Weight    int `json:"weight"`
+		} `json:"variations"`
+	} `json:"rollout"`
+}
+
+// NewLaunchDarkly fetches the flags of feature_flags.launchdarkly, then
+// every refresh_interval until closed.
+func NewLaunchDarkly(cfg *config.FeatureFlagsConfig, log logger.Logger) *LaunchDarkly {
+	url := strings.TrimSuffix(cfg.LaunchDarkly.BaseURL, "/")
+	if url == "" {
+		url = defaultLaunchDarklyURL
+	}
+	ld := &LaunchDarkly{url: url, sdkKey: cfg.LaunchDarkly.SDKKey, client: &http.Client{Timeout: fetchTimeout}}
+	ld.poller = startPoller(config.FeatureFlagDriverLaunchDarkly, ld.fetch, cfg.RefreshInterval, log)
+	return ld
+}
+
+func (ld *LaunchDarkly) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ld.url+"/sdk/latest-flags", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", ld.sdkKey)
+	resp, err := ld.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("launchdarkly: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("launchdarkly: fetching the flags: %s", resp.Status)
+	}
+
+	var flags map[string]ldFlag
+	if err := json.NewDecoder(resp.Body).Decode(&flags); err != nil {
+		return fmt.Errorf("launchdarkly: decoding the flags: %w", err)
+	}
+	ld.mu.Lock()
+	ld.flags = flags
+	ld.mu.Unlock()
+	return nil
+}
+
+func (ld *LaunchDarkly) Enabled(_ context.Context, flag string, t Target) (bool, error) {
+	ld.mu.RLock()
+	defer ld.mu.RUnlock()
+	f, ok := ld.flags[flag]
+	if !ok || f.Deleted {
+		return false, ErrUnknownFlag
+	}
+	v, ok := ld.evaluate(f, t, 0)
+	if !ok || v < 0 || v >= len(f.Variations) {
+		return false, nil
+	}
+	on, _ := f.Variations[v].(bool)
+	return on, nil
+}
+
+// maxPrerequisiteDepth stops the evaluation of prerequisite cycles.
+const maxPrerequisiteDepth = 10
+
+// evaluate returns the index of the variation f serves to t, false when it
+// serves none (off without off variation).
+func (ld *LaunchDarkly) evaluate(f ldFlag, t Target, depth int) (int, bool) {
+	off := func() (int, bool) {
+		if f.OffVariation == nil {
+			return 0, false
+		}
+		return *f.OffVariation, true
+	}
+	if !f.On || depth > maxPrerequisiteDepth {
+		return off()
+	}
+	for _, p := range f.Prerequisites {
+		pf, ok := ld.flags[p.Key]
+		if !ok || pf.Deleted || !pf.On {
+			return off()
+		}
+		if v, ok := ld.evaluate(pf, t, depth+1); !ok || v != p.Variation {
+			return off()
+		}
+	}
+
+	for _, target := range f.Targets {
+		if t.UserID != "" && slices.Contains(target.Values, t.UserID) {
+			return target.Variation, true
+		}
+	}
+	for _, target := range f.ContextTargets {
+		if key := ldContextKey(t, target.ContextKind); key != "" && slices.Contains(target.Values, key) {
+			return target.Variation, true
+		}
+	}
+	for _, rule := range f.Rules {
+		if ldRuleMatches(rule, t) {
+			return ldServe(f, rule.ldVariationSpec, t)
+		}
+	}
+	return ldServe(f, f.Fallthrough, t)
+}
+
+func ldRuleMatches(rule ldRule, t Target) bool {
+	for _, c := range rule.Clauses {
+		if ldClauseMatches(c, t) == c.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+// ldClauseMatches reports whether an attribute of t matches a value of c.
+func ldClauseMatches(c ldClause, t Target) bool {
+	var attrs []string
+	switch kind := c.ContextKind; {
+	case c.Attribute == "kind":
+		if t.UserID != "" {
+			attrs = append(attrs, "user")
+		}
+		if t.TenantID != "" {
+			attrs = append(attrs, "tenant")
+		}
+	case c.Attribute == "key":
+		if key := ldContextKey(t, kind); key != "" {
+			attrs = []string{key}
+		}
+	case kind == "" || kind == "user":
+		switch c.Attribute {
+		case "clientApp":
+			attrs = []string{t.ClientApp}
+		case "roles":
+			attrs = t.Roles
+		case "tenantId":
+			attrs = []string{t.TenantID}
+		}
+	}
+
+	for _, attr := range attrs {
+		for _, raw := range c.Values {
+			value, ok := raw.(string)
+			if !ok {
+				continue
+			}
+			var match bool
+			switch c.Op {
+			case "in":
+				match = attr == value
+			case "startsWith":
+				match = strings.HasPrefix(attr, value)
+			case "endsWith":
+				match = strings.HasSuffix(attr, value)
+			case "contains":
+				match = strings.Contains(attr, value)
+			}
+			if match {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ldContextKey returns the key of the context of kind of t, the user when
+// kind is empty.
+func ldContextKey(t Target, kind string) string {
+	switch kind {
+	case "", "user":
+		return t.UserID
+	case "tenant":
+		return t.TenantID
+	}
+	return ""
+}
+
+// ldServe returns the variation of spec, picking it by the bucket of t for a
+// rollout.
+func ldServe(f ldFlag, spec ldVariationSpec, t Target) (int, bool) {
+	if spec.Variation != nil {
+		return *spec.Variation, true
+	}
+	r := spec.Rollout
+	if r == nil || len(r.Variations) == 0 {
+		return 0, false
+	}
+
+	bucket := 0.0
+	if r.BucketBy == "" || r.BucketBy == "key" {
+		if key := ldContextKey(t, r.ContextKind); key != "" {
+			prefix := f.Key + "." + f.Salt
+			if r.Seed != nil {
+				prefix = strconv.Itoa(*r.Seed)
+			}
+			bucket = ldBucket(prefix + "." + key)
+		}
+	}
+	sum := 0.0
+	for _, v := range r.Variations {
+		sum += float64(v.Weight) / 100000
+		if bucket < sum {
+			return v.Variation, true
+		}
+	}
+	// The weights may not add up to 100%: the last variation takes the rest.
+	return r.Variations[len(r.Variations)-1].Variation, true
+}
+
+// ldBucket hashes s between 0 and 1 like the LaunchDarkly SDKs: the first 15
+// hexadecimal digits of its SHA-1.
+func ldBucket(s string) float64 {
+	sum := sha1.Sum([]byte(s))
+	n, _ := strconv.ParseInt(hex.EncodeToString(sum[:])[:15], 16, 64)
+	return float64(n) / float64(0xFFFFFFFFFFFFFFF)
+}
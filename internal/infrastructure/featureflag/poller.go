@@ -0,0 +1,77 @@
+package featureflag
+
+import (
+	"context"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/logger"
+)
+
+const (
+	defaultRefreshInterval = 30 * time.Second
+	fetchTimeout           = 10 * time.Second
+)
+
+// poller fetches the flags of a remote provider at startup, then every
+// interval until closed. A failed fetch keeps the flags of the previous one.
+type poller struct {
+	fetch    func(ctx context.Context) error
+	interval time.Duration
+	log      logger.Logger
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// startPoller fetches the flags once, then starts polling. The first fetch
+// failing is logged, not fatal: the flags are disabled until the provider is
+// reachable.
+func startPoller(name string, fetch func(ctx context.Context) error, intervalSeconds int, log logger.Logger) *poller {
+	interval := time.Duration(intervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultRefreshInterval
+	}
+	if log == nil {
+		log = logger.NewNoOpLogger()
+	}
+	p := &poller{
+		fetch:    fetch,
+		interval: interval,
+		log:      log.WithFields(map[string]any{"component": "featureflag", "driver": name}),
+		stop:     make(chan struct{}),
+	}
+	p.poll()
+	p.wg.Add(1)
+	go p.run()
+	return p
+}
+
+func (p *poller) run() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.poll()
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+func (p *poller) poll() {
+	ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+	defer cancel()
+	if err := p.fetch(ctx); err != nil {
+		p.log.WithField("error", err.Error()).Warn("fetching the feature flags failed, the previous ones are kept")
+	}
+}
+
+// Close stops polling.
+func (p *poller) Close() error {
+	p.once.Do(func() { close(p.stop) })
+	p.wg.Wait()
+	return nil
+}
@@ -0,0 +1,98 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/binary"
+	"math/bits"
+	"sync/atomic"
+	"voyago/core-api/internal/infrastructure/config"
+)
+
+// Static evaluates the flags of the configuration file (config driver).
+type Static struct {
+	flags atomic.Pointer[map[string]config.FeatureFlagConfig]
+}
+
+func NewStatic(flags map[string]config.FeatureFlagConfig) *Static {
+	s := &Static{}
+	s.Reload(flags)
+	return s
+}
+
+// Reload replaces the flags, e.g. after an edit of feature_flags.flags (see
+// package reload). The evaluations running keep the previous ones.
+func (s *Static) Reload(flags map[string]config.FeatureFlagConfig) {
+	if flags == nil {
+		flags = map[string]config.FeatureFlagConfig{}
+	}
+	s.flags.Store(&flags)
+}
+
+func (s *Static) Enabled(_ context.Context, flag string, t Target) (bool, error) {
+	f, ok := (*s.flags.Load())[flag]
+	if !ok {
+		return false, ErrUnknownFlag
+	}
+	if !f.Enabled || !t.matches(f.Users, f.Tenants) {
+		return false, nil
+	}
+	return rolledOut(flag, t.key(), f.Percentage), nil
+}
+
+// rolledOut reports whether the target key is in the first percentage of the
+// targets of flag, 100 when 0. The anonymous targets are only in a full
+// rollout.
+func rolledOut(flag, key string, percentage int) bool {
+	if percentage == 0 || percentage >= 100 {
+		return true
+	}
+	if key == "" {
+		return false
+	}
+	return bucket(flag, key) <= percentage
+}
+
+// bucket spreads the keys of group between 1 and 100, stably: the hash of
+// the Unleash SDKs, so that a user lands in the same bucket whatever the
+// driver.
+func bucket(group, key string) int {
+	return int(murmur3([]byte(group+":"+key))%100) + 1
+}
+
+// murmur3 is the 32-bit MurmurHash3 of data, with seed 0.
+func murmur3(data []byte) uint32 {
+	const c1, c2 = 0xcc9e2d51, 0x1b873593
+	var h uint32
+	n := len(data)
+	for ; len(data) >= 4; data = data[4:] {
+		k := binary.LittleEndian.Uint32(data)
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+	var k uint32
+	switch len(data) {
+	case 3:
+		k ^= uint32(data[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(data[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(data[0])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+	}
+	h ^= uint32(n)
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
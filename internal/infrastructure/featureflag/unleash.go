@@ -0,0 +1,210 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+)
+
+// Unleash evaluates the flags of an Unleash server, fetched from its client
+// API and evaluated locally, like the Unleash server-side SDKs.
+//
+// The standard strategies are supported (default, userWithId,
+// flexibleRollout, gradualRolloutUserId), with the IN and NOT_IN constraints
+// on userId, tenantId, clientApp and appName. A strategy of another kind is
+// off. A flag is on when it is enabled and one of its strategies is on, or
+// when it has none.
+type Unleash struct {
+	*poller
+	url     string
+	token   string
+	appName string
+	client  *http.Client
+
+	mu       sync.RWMutex
+	features map[string]unleashFeature
+	etag     string
+}
+
+type unleashFeature struct {
+	Name       string            `json:"name"`
+	Enabled    bool              `json:"enabled"`
+	Strategies []unleashStrategy `json:"strategies"`
+}
+
+type unleashStrategy struct {
+	Name        string              `json:"name"`
+	Parameters  map[string]string   `json:"parameters"`
+	Constraints []unleashConstraint `json:"constraints"`
+}
+
+type unleashConstraint struct {
+	ContextName string   `json:"contextName"`
+	Operator    string   `json:"operator"`
+	Values      []string `json:"values"`
+	Inverted    bool     `json:"inverted"`
+}
+
+// NewUnleash fetches the flags of feature_flags.unleash, then every
+// refresh_interval until closed.
+func NewUnleash(cfg *config.FeatureFlagsConfig, log logger.Logger) *Unleash {
+	u := &Unleash{
+		url:     strings.TrimSuffix(cfg.Unleash.URL, "/"),
+		token:   cfg.Unleash.APIToken,
+		appName: cfg.Unleash.AppName,
+		client:  &http.Client{Timeout: fetchTimeout},
+	}
+	u.poller = startPoller(config.FeatureFlagDriverUnleash, u.fetch, cfg.RefreshInterval, log)
+	return u
+}
+
+func (u *Unleash) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.url+"/client/features", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", u.token)
+	req.Header.Set("UNLEASH-APPNAME", u.appName)
+	u.mu.RLock()
+	if u.etag != "" {
+		req.Header.Set("If-None-Match", u.etag)
+	}
+	u.mu.RUnlock()
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unleash: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil
+	case http.StatusOK:
+	default:
+		return fmt.Errorf("unleash: fetching the features: %s", resp.Status)
+	}
+
+	var out struct {
+		Features []unleashFeature `json:"features"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return fmt.Errorf("unleash: decoding the features: %w", err)
+	}
+	features := make(map[string]unleashFeature, len(out.Features))
+	for _, f := range out.Features {
+		features[f.Name] = f
+	}
+	u.mu.Lock()
+	u.features, u.etag = features, resp.Header.Get("ETag")
+	u.mu.Unlock()
+	return nil
+}
+
+func (u *Unleash) Enabled(_ context.Context, flag string, t Target) (bool, error) {
+	u.mu.RLock()
+	f, ok := u.features[flag]
+	u.mu.RUnlock()
+	if !ok {
+		return false, ErrUnknownFlag
+	}
+	if !f.Enabled {
+		return false, nil
+	}
+	if len(f.Strategies) == 0 {
+		return true, nil
+	}
+	for _, s := range f.Strategies {
+		if u.strategyOn(flag, s, t) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (u *Unleash) strategyOn(flag string, s unleashStrategy, t Target) bool {
+	for _, c := range s.Constraints {
+		if !u.constraintMet(c, t) {
+			return false
+		}
+	}
+	switch s.Name {
+	case "default":
+		return true
+	case "userWithId":
+		return t.UserID != "" && slices.Contains(splitList(s.Parameters["userIds"]), t.UserID)
+	case "flexibleRollout":
+		key := t.key()
+		switch s.Parameters["stickiness"] {
+		case "userId":
+			key = t.UserID
+		case "tenantId":
+			key = t.TenantID
+		}
+		return unleashRollout(s.Parameters["groupId"], flag, key, s.Parameters["rollout"])
+	case "gradualRolloutUserId":
+		return unleashRollout(s.Parameters["groupId"], flag, t.UserID, s.Parameters["percentage"])
+	}
+	return false
+}
+
+// unleashRollout reports whether key is in the first percentage of group,
+// the flag when empty. Without key, only a full rollout is on.
+func unleashRollout(group, flag, key, percentage string) bool {
+	p, err := strconv.Atoi(percentage)
+	if err != nil || p <= 0 {
+		return false
+	}
+	if p >= 100 {
+		return true
+	}
+	if key == "" {
+		return false
+	}
+	if group == "" {
+		group = flag
+	}
+	return bucket(group, key) <= p
+}
+
+func (u *Unleash) constraintMet(c unleashConstraint, t Target) bool {
+	var value string
+	switch c.ContextName {
+	case "userId":
+		value = t.UserID
+	case "tenantId":
+		value = t.TenantID
+	case "clientApp":
+		value = t.ClientApp
+	case "appName":
+		value = u.appName
+	}
+
+	var met bool
+	switch c.Operator {
+	case "IN":
+		met = slices.Contains(c.Values, value)
+	case "NOT_IN":
+		met = !slices.Contains(c.Values, value)
+	default:
+		return false
+	}
+	return met != c.Inverted
+}
+
+// splitList splits the comma-separated list of a strategy parameter.
+func splitList(s string) []string {
+	var out []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
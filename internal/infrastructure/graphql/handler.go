@@ -0,0 +1,109 @@
+package gqlserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+)
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type response struct {
+	Data   json.RawMessage         `json:"data,omitempty"`
+	Errors []*gqlerrors.QueryError `json:"errors,omitempty"`
+}
+
+// Handler serves the schema over HTTP (POST with a JSON body, or GET with
+// query parameters), following the GraphQL over HTTP conventions.
+//
+// Malformed requests bubble an AppError to the global error handler.
+// Execution errors are returned in the "errors" array, enriched by FormatErrors.
+func (s *Schema) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := new(request)
+		if c.Method() == fiber.MethodGet {
+			req.Query = c.Query("query")
+			req.OperationName = c.Query("operationName")
+			if v := c.Query("variables"); v != "" {
+				if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+					return apperror.ErrCodeMalformedRequest.WithError(err)
+				}
+			}
+		} else if err := c.BodyParser(req); err != nil {
+			return apperror.ErrCodeMalformedRequest.WithError(err)
+		}
+
+		if strings.TrimSpace(req.Query) == "" {
+			return apperror.NewPersistance(apperror.CodeInvalidRequest, "Invalid request").
+				AddValidationError("query", "query is required")
+		}
+
+		ctx := c.UserContext()
+		for _, fn := range s.contexts {
+			ctx = fn(ctx)
+		}
+
+		res := s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+		traceID, _ := c.Locals("trace_id").(string)
+		FormatErrors(c.UserContext(), res.Errors, traceID)
+
+		// A response without data means the request itself was rejected
+		// (syntax or validation error); execution errors keep partial data.
+		status := fiber.StatusOK
+		if len(res.Data) == 0 && len(res.Errors) > 0 {
+			status = fiber.StatusBadRequest
+		}
+
+		return c.Status(status).JSON(response{
+			Data:   res.Data,
+			Errors: res.Errors,
+		})
+	}
+}
+
+// FormatErrors is the GraphQL counterpart of the global error handler.
+// It fills the "extensions" of each error with the same contract as the REST
+// error envelope: code, is_retryable, details and trace_id.
+//   - Resolver errors wrapping an *apperror.AppError expose its code and message,
+//     in the locale of the request of ctx (see apperror.AppError.Localized).
+//   - Other resolver errors are reported as INTERNAL_ERROR.
+//   - Errors without a resolver error (syntax, validation) are INVALID_REQUEST.
+func FormatErrors(ctx context.Context, errs []*gqlerrors.QueryError, traceID string) {
+	for _, qe := range errs {
+		ext := qe.Extensions
+		if ext == nil {
+			ext = make(map[string]any)
+		}
+
+		var appErr *apperror.AppError
+		switch {
+		case errors.As(qe.ResolverError, &appErr):
+			appErr = appErr.Localized(ctx)
+			qe.Message = appErr.Message
+			ext["code"] = appErr.Code
+			ext["is_retryable"] = appErr.IsRetryable()
+			if appErr.Details != nil {
+				ext["details"] = appErr.Details
+			}
+		case qe.ResolverError != nil:
+			ext["code"] = apperror.CodeInternalError
+		default:
+			ext["code"] = apperror.CodeInvalidRequest
+		}
+
+		if traceID != "" {
+			ext["trace_id"] = traceID
+		}
+		qe.Extensions = ext
+	}
+}
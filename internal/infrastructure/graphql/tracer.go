@@ -0,0 +1,81 @@
+package gqlserver
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/pkg/utils"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/introspection"
+	gqltracer "github.com/graph-gophers/graphql-go/trace/tracer"
+)
+
+// graphqlTracer bridges graphql-go tracing hooks to the application tracer.
+// Trivial fields (plain struct fields and methods without context) are not traced.
+type graphqlTracer struct {
+	trc tracer.Tracer
+}
+
+var _ gqltracer.Tracer = (*graphqlTracer)(nil)
+
+func newTracer(trc tracer.Tracer) *graphqlTracer {
+	return &graphqlTracer{trc: trc}
+}
+
+func (t *graphqlTracer) TraceQuery(ctx context.Context, queryString string, operationName string, variables map[string]any, varTypes map[string]*introspection.Type) (context.Context, gqltracer.QueryFinishFunc) {
+	name := "GraphQL"
+	if operationName != "" {
+		name = fmt.Sprintf("GraphQL %s", operationName)
+	}
+
+	span, ctx := t.trc.StartSpan(ctx, name)
+	span.SetTag("graphql.operation_name", operationName)
+
+	return ctx, func(errs []*errors.QueryError) {
+		if len(errs) > 0 {
+			utils.RecordSpanError(span, errs[0])
+			span.SetTag("graphql.error_count", len(errs))
+		}
+		span.Finish()
+	}
+}
+
+func (t *graphqlTracer) TraceField(ctx context.Context, label, typeName, fieldName string, trivial bool, args map[string]any) (context.Context, gqltracer.FieldFinishFunc) {
+	if trivial {
+		return ctx, func(*errors.QueryError) {}
+	}
+
+	span, ctx := t.trc.StartSpan(ctx, fmt.Sprintf("GraphQL %s.%s", typeName, fieldName))
+	span.SetTag("graphql.type", typeName)
+	span.SetTag("graphql.field", fieldName)
+
+	return ctx, func(err *errors.QueryError) {
+		if err != nil {
+			utils.RecordSpanError(span, err)
+		}
+		span.Finish()
+	}
+}
+
+// panicLogger reports resolver panics through the application logger.
+type panicLogger struct {
+	log logger.Logger
+}
+
+func newPanicLogger(log logger.Logger) *panicLogger {
+	return &panicLogger{log: log.WithField("component", "graphql")}
+}
+
+func (l *panicLogger) LogPanic(ctx context.Context, value any) {
+	const size = 64 << 10
+	buf := make([]byte, size)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	l.log.WithContext(ctx).WithFields(map[string]any{
+		"panic": fmt.Sprint(value),
+		"stack": string(buf),
+	}).Error("graphql resolver panicked")
+}
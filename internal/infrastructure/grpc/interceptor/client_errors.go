@@ -0,0 +1,25 @@
+package interceptor
+
+import (
+	"context"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// ClientErrors is the client interceptor of the outbound gRPC connections
+// translating the errors of the calls into *apperror.AppError (see
+// apperror.FromGRPCStatus): an error of another service is handled, logged
+// and returned to the caller like a local one, with the same HTTP status.
+//
+//	conn, err := grpc.NewClient(addr, grpc.WithChainUnaryInterceptor(interceptor.ClientErrors()))
+func ClientErrors() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			return nil
+		}
+		return apperror.FromGRPCStatus(status.Convert(err))
+	}
+}
@@ -0,0 +1,37 @@
+package interceptor
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/errorreport"
+	"voyago/core-api/internal/pkg/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// HandleErrorReport is the gRPC counterpart of
+// middleware.Telemetrist.HandleErrorReport: it reports the unexpected
+// failures of the handler to r, with the masked request message and
+// metadata. It must run after HandleTrace, for the trace ID.
+func (m *Telemetrist) HandleErrorReport(r errorreport.Reporter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if !errorreport.Reportable(err) {
+			return resp, err
+		}
+
+		e := errorreport.NewEvent(ctx, err)
+		e.TraceID, _, _ = m.TracerProvider.ExtractTraceInfo(ctx)
+		e.Transport = "grpc"
+		md, _ := metadata.FromIncomingContext(ctx)
+		e.Request = errorreport.Request{
+			URL:     info.FullMethod,
+			Route:   info.FullMethod,
+			Headers: utils.MaskHttpHeaders(md),
+			Body:    m.parseMessage(req),
+		}
+
+		r.Report(ctx, e)
+		return resp, err
+	}
+}
@@ -0,0 +1,16 @@
+package interceptor
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/featureflag"
+
+	"google.golang.org/grpc"
+)
+
+// FeatureFlags interceptor pins the feature flags of the call, like
+// middleware.FeatureFlags.
+func FeatureFlags() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(featureflag.Pin(ctx), req)
+	}
+}
@@ -0,0 +1,90 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/gateway"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Identity metadata keys, set by the API gateway once the caller is
+// authenticated.
+const (
+	HeaderUserID    = "x-user-id"
+	HeaderUserRoles = "x-user-roles" // comma separated
+	HeaderTenantID  = "x-tenant-id"
+	HeaderClientApp = "x-client-app"
+)
+
+// Identity interceptor is the gRPC counterpart of middleware.Identity: it
+// stores the user, roles, tenant and client app of the identity metadata in
+// the context, ignoring the values that are not valid identifiers (see
+// ctxkey.IsValidIdentifier). The metadata is only read from the calls of the
+// gateway, the tenant only with tenancy enabled.
+//
+// It panics on a trusted IP of the gateway that is neither an IP nor a CIDR.
+func Identity(gw config.GatewayConfig, tenancy config.TenancyConfig) grpc.UnaryServerInterceptor {
+	trust, err := gateway.New(gw)
+	if err != nil {
+		panic(fmt.Errorf("invalid gateway trusted ip: %w", err))
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		get := func(key string) string {
+			if v := md.Get(key); len(v) > 0 {
+				return v[0]
+			}
+			return ""
+		}
+		if !trust.Trusted(get(strings.ToLower(gateway.HeaderSecret)), peerAddr(ctx)) {
+			return handler(ctx, req)
+		}
+
+		if id := get(HeaderUserID); ctxkey.IsValidIdentifier(id) {
+			ctx = ctxkey.SetUserID(ctx, id)
+		}
+		if roles := parseRoles(get(HeaderUserRoles)); len(roles) > 0 {
+			ctx = ctxkey.SetRoles(ctx, roles)
+		}
+		if id := get(HeaderTenantID); tenancy.Enabled && ctxkey.IsValidIdentifier(id) {
+			ctx = ctxkey.SetTenantID(ctx, id)
+		}
+		if app := get(HeaderClientApp); ctxkey.IsValidIdentifier(app) {
+			ctx = ctxkey.SetClientApp(ctx, app)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// peerAddr returns the address the call comes from, invalid when unknown.
+func peerAddr(ctx context.Context) netip.Addr {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return netip.Addr{}
+	}
+	addrPort, err := netip.ParseAddrPort(p.Addr.String())
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addrPort.Addr()
+}
+
+// parseRoles returns the valid roles of a comma separated list.
+func parseRoles(value string) []string {
+	var roles []string
+	for role := range strings.SplitSeq(value, ",") {
+		if role = strings.TrimSpace(role); ctxkey.IsValidIdentifier(role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
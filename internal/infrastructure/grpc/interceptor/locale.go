@@ -0,0 +1,28 @@
+package interceptor
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/i18n"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// HeaderAcceptLanguage is the metadata key carrying the locales of the
+// caller, as the HTTP header.
+const HeaderAcceptLanguage = "accept-language"
+
+// Locale interceptor stores the locales of the accept-language metadata in
+// the context, like middleware.Locale.
+func Locale() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if v := md.Get(HeaderAcceptLanguage); len(v) > 0 {
+			if locales := i18n.ParseAcceptLanguage(v[0]); len(locales) > 0 {
+				ctx = ctxkey.SetLocales(ctx, locales)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
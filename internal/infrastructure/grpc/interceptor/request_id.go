@@ -0,0 +1,38 @@
+package interceptor
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/uid"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// HeaderRequestID is the metadata key carrying the correlation identifier.
+// gRPC metadata keys are lower-case by specification.
+const HeaderRequestID = "x-request-id"
+
+// RequestID interceptor manages the correlation identifier for each incoming call.
+// It is the gRPC counterpart of middleware.RequestID and follows the same rules:
+// the ID is read from the incoming metadata, replaced by a new one when absent or
+// invalid, echoed back in the response header and stored in the context for
+// downstream loggers and outbound calls.
+func RequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		reqId := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if v := md.Get(HeaderRequestID); len(v) > 0 {
+				reqId = v[0]
+			}
+		}
+
+		if !uid.IsValidRequestID(reqId) {
+			reqId = uid.NewRequestID()
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(HeaderRequestID, reqId))
+
+		return handler(ctxkey.SetRequestID(ctx, reqId), req)
+	}
+}
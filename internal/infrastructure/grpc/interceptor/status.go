@@ -0,0 +1,46 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ToStatus converts any error returned by a handler into a gRPC status.
+// It is the gRPC counterpart of the Fiber global error handler:
+//   - *apperror.AppError: see apperror.AppError.ToGRPCStatus.
+//   - gRPC status errors are returned unchanged.
+//   - Context cancellation and deadline errors map to Canceled and DeadlineExceeded.
+//   - Anything else is reported as Internal.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.ToGRPCStatus()
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, err.Error())
+	}
+
+	return status.New(codes.Internal, err.Error())
+}
+
+// CodeFromHttpStatus maps the HTTP status resolved by apperror.AppError.GetHttpStatus
+// to the closest gRPC code (see apperror.GRPCCodeFromHttpStatus).
+func CodeFromHttpStatus(httpStatus int) codes.Code {
+	return apperror.GRPCCodeFromHttpStatus(httpStatus)
+}
@@ -0,0 +1,231 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// HeaderTraceID is the response header carrying the trace identifier,
+// mirroring the X-Trace-Id header of the HTTP transport.
+const HeaderTraceID = "x-trace-id"
+
+// Telemetrist is the gRPC counterpart of middleware.Telemetrist.
+// Register the interceptors in this order so that tracing and metrics observe
+// the final gRPC status produced by HandleLog:
+//
+//	RequestID(), HandleMetrics(), HandleTrace(), HandleLog()
+type Telemetrist struct {
+	LogProvider     logger.Logger
+	TracerProvider  tracer.Tracer
+	MetricsProvider metrics.Metrics
+}
+
+func NewTelemetrist(
+	log logger.Logger,
+	trc tracer.Tracer,
+	metrics metrics.Metrics,
+) *Telemetrist {
+	return &Telemetrist{
+		LogProvider:     log,
+		TracerProvider:  trc,
+		MetricsProvider: metrics,
+	}
+}
+
+// HandleTrace initiates the call span.
+// It must run before HandleLog so the logger can attach the trace identifiers.
+// Like its HTTP counterpart, the span joins the trace propagated in the
+// incoming metadata.
+func (m *Telemetrist) HandleTrace() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			ctx = m.TracerProvider.Extract(ctx, metadataCarrier(md))
+		}
+		span, ctx := m.TracerProvider.StartSpan(ctx, fmt.Sprintf("gRPC %s", info.FullMethod))
+		defer span.Finish()
+		tracer.TagCaller(span, ctx)
+
+		tID, _, _ := m.TracerProvider.ExtractTraceInfo(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(HeaderTraceID, tID))
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		span.SetTag("rpc.system", "grpc")
+		span.SetTag("rpc.method", info.FullMethod)
+		span.SetTag("rpc.grpc.status_code", code.String())
+
+		if err != nil {
+			span.SetTag("error", true)
+			span.SetTag("error.message", err.Error())
+		}
+		return resp, err
+	}
+}
+
+// metadataCarrier reads the trace context from the incoming metadata.
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	if values := metadata.MD(m).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// HandleMetrics records latency and throughput.
+func (m *Telemetrist) HandleMetrics() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		duration := time.Since(start).Seconds()
+		m.MetricsProvider.RecordGRPC(info.FullMethod, status.Code(err).String(), duration)
+
+		return resp, err
+	}
+}
+
+// HandleLog provides the final audit trail of the call.
+// Like its HTTP counterpart, which invokes the global error handler, it is the
+// place where application errors are converted into a gRPC status (see ToStatus),
+// counted by code, with the latency of the server errors (see metrics.Errors).
+func (m *Telemetrist) HandleLog() grpc.UnaryServerInterceptor {
+	em := metrics.NewErrors(m.MetricsProvider)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		elapsed := time.Since(start)
+		latency := float64(elapsed.Nanoseconds()) / 1e6
+
+		var st *status.Status
+		if err != nil {
+			st = ToStatus(apperror.Localize(ctx, err))
+			recordError(em, st, info.FullMethod, elapsed)
+		}
+		code := st.Code() // a nil *status.Status reports codes.OK
+
+		var ip string
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			ip = p.Addr.String()
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		tID, _, _ := m.TracerProvider.ExtractTraceInfo(ctx)
+
+		logEntry := m.LogProvider.WithContext(ctx).WithFields(map[string]any{
+			"component": "telemetry.interceptor",
+
+			"transport":  "grpc",
+			"method":     info.FullMethod,
+			"status":     code.String(),
+			"latency_ms": latency,
+			"ip":         ip,
+			"trace_id":   tID,
+
+			"request": map[string]any{
+				"headers": utils.MaskHttpHeaders(md),
+				"body":    m.parseMessage(req),
+			},
+
+			"response": map[string]any{
+				"body": m.parseMessage(resp),
+			},
+		})
+
+		switch {
+		case isServerError(code):
+			logEntry.WithFields(apperror.LogFields(err)).Error("grpc request completed with error")
+		case code != codes.OK:
+			logEntry.Warn("grpc request completed with client error")
+		default:
+			logEntry.Info("grpc request completed")
+		}
+
+		if st != nil {
+			return resp, st.Err()
+		}
+		return resp, nil
+	}
+}
+
+// recordError counts the error of st by code, as the client reads it back
+// (see apperror.FromGRPCStatus), and records the latency of a server error.
+func recordError(em *metrics.Errors, st *status.Status, method string, elapsed time.Duration) {
+	appErr := apperror.FromGRPCStatus(st)
+	if appErr == nil {
+		return
+	}
+	entry, _ := apperror.Lookup(appErr.Code)
+	em.AppError(appErr.Code, string(appErr.Kind), entry.Module, appErr.IsRetryable())
+	if isServerError(st.Code()) {
+		em.GRPCServerError(method, st.Code().String(), elapsed)
+	}
+}
+
+// parseMessage renders a protobuf message as JSON, enforces size limits
+// and applies sensitivity masking.
+func (m *Telemetrist) parseMessage(msg any) any {
+	pm, ok := msg.(proto.Message)
+	if !ok || pm == nil {
+		return nil
+	}
+
+	body, err := protojson.Marshal(pm)
+	if err != nil {
+		return "[parse error: invalid message]"
+	}
+
+	if len(body) == 0 || string(body) == "{}" {
+		return nil
+	}
+
+	// Enforce limit to prevent log bloat and high memory usage during unmarshaling
+	const limit = 2 * 1024 // 2KB
+	if len(body) > limit {
+		return fmt.Sprintf("[body too large: %d bytes]", len(body))
+	}
+
+	var obj any
+	if err := json.Unmarshal(body, &obj); err != nil {
+		return "[parse error: invalid json]"
+	}
+
+	return utils.MaskSensitive(obj)
+}
+
+// isServerError reports whether a code is the equivalent of an HTTP 5xx.
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss,
+		codes.Unimplemented, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
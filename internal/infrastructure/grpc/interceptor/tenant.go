@@ -0,0 +1,78 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/gateway"
+	"voyago/core-api/internal/pkg/tenancy"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// tenantExemptServices never require a tenant: the health checks and the
+// reflection service.
+var tenantExemptServices = []string{"/grpc.health.v1.Health/", "/grpc.reflection."}
+
+// Tenant interceptor is the gRPC counterpart of middleware.Tenant: it stores
+// the tenant resolved by the cfg.Resolvers from the metadata (the subdomain
+// resolver reads the :authority) in the context, keeping the tenant of the
+// identity metadata when none resolves it. The header and claim resolvers
+// only read the calls of the gateway of gw (see gateway.Trust). With
+// cfg.Required, the calls without tenant are rejected with the status of
+// tenancy.ErrTenantRequired, except the health checks and reflection.
+//
+// It panics on an invalid resolver configuration (see tenancy.NewResolver)
+// and on a trusted IP of the gateway that is neither an IP nor a CIDR.
+func Tenant(cfg config.TenancyConfig, gw config.GatewayConfig) grpc.UnaryServerInterceptor {
+	resolve, err := tenancy.NewResolver(cfg)
+	if err != nil {
+		panic(err)
+	}
+	trust, err := gateway.New(gw)
+	if err != nil {
+		panic(fmt.Errorf("invalid gateway trusted ip: %w", err))
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		r := metadataRequest{md: md}
+		r.trusted = trust.Trusted(r.Header(strings.ToLower(gateway.HeaderSecret)), peerAddr(ctx))
+		if tenant := resolve(r); tenant != "" {
+			ctx = ctxkey.SetTenantID(ctx, tenant)
+		}
+		if ctxkey.GetTenantID(ctx) == "" && cfg.Required && !tenantExempt(info.FullMethod) {
+			return nil, ToStatus(apperror.Localize(ctx, tenancy.ErrTenantRequired)).Err()
+		}
+		return handler(ctx, req)
+	}
+}
+
+func tenantExempt(method string) bool {
+	for _, prefix := range tenantExemptServices {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// metadataRequest adapts the incoming metadata to tenancy.Request.
+type metadataRequest struct {
+	md      metadata.MD
+	trusted bool
+}
+
+func (r metadataRequest) Header(name string) string {
+	if v := r.md.Get(name); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (r metadataRequest) Host() string  { return r.Header(":authority") }
+func (r metadataRequest) Trusted() bool { return r.trusted }
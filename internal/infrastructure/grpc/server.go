@@ -0,0 +1,111 @@
+// Package grpcserver provides the gRPC server infrastructure.
+// It is the gRPC counterpart of the Fiber based server package and shares
+// the same configuration, logger and lifecycle conventions.
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server represents the gRPC server wrapper.
+// It encapsulates the grpc-go engine and provides lifecycle management.
+type Server struct {
+	// App is the underlying grpc-go instance.
+	// Use this to register services.
+	App *grpc.Server
+
+	// Health is the standard gRPC health service (grpc.health.v1.Health).
+	// The overall status ("") is set to SERVING on Start and NOT_SERVING on Stop.
+	Health *health.Server
+
+	cfg *config.Config
+	log logger.Logger
+}
+
+// NewServer initializes a new gRPC server with settings from the config.
+// Interceptors are chained in the given order; the first one is the outermost.
+//
+// Parameters:
+//   - cfg: Application configuration (port, message size, timeouts).
+//   - log: Logger instance for infrastructure-level logging.
+//   - interceptors: Unary server interceptors (see the interceptor package).
+func NewServer(
+	cfg *config.Config,
+	log logger.Logger,
+	interceptors ...grpc.UnaryServerInterceptor,
+) *Server {
+	maxRecvMsgSize := 4 * 1024 * 1024 // 4MB, grpc-go default
+	if cfg.Grpc.MaxRecvMsgSize != 0 {
+		maxRecvMsgSize = cfg.Grpc.MaxRecvMsgSize
+	}
+
+	connectionTimeout := 10 * time.Second
+	if cfg.Grpc.ConnectionTimeout != 0 {
+		connectionTimeout = time.Duration(cfg.Grpc.ConnectionTimeout) * time.Second
+	}
+
+	app := grpc.NewServer(
+		grpc.MaxRecvMsgSize(maxRecvMsgSize),
+		grpc.ConnectionTimeout(connectionTimeout),
+		grpc.ChainUnaryInterceptor(interceptors...),
+	)
+
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(app, hs)
+
+	if cfg.Grpc.Reflection {
+		reflection.Register(app)
+	}
+
+	return &Server{
+		App:    app,
+		Health: hs,
+		cfg:    cfg,
+		log:    log.WithField("component", "app"),
+	}
+}
+
+// Start launches the gRPC server on the port defined in the configuration.
+// It blocks until the server stops and returns an error if it fails to bind.
+func (s *Server) Start() error {
+	addr := fmt.Sprintf(":%d", s.cfg.Grpc.Port)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	s.Health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	s.log.Info(fmt.Sprintf("gRPC server [%s] started and listening on %s", s.cfg.App.Name, addr))
+	return s.App.Serve(lis)
+}
+
+// Stop gracefully shuts down the server, waiting for in-flight RPCs to finish.
+// If the context expires first, remaining RPCs are cancelled forcefully.
+func (s *Server) Stop(ctx context.Context) error {
+	s.log.Warn(fmt.Sprintf("Shutting down gRPC server [%s] gracefully...", s.cfg.App.Name))
+	s.Health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.App.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.App.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit rejects the requests whose body exceeds limit bytes with
+// PAYLOAD_TOO_LARGE (413), before any handler parses it. A limit of 0 applies
+// fiber's default (4MB).
+//
+// Compressed bodies (Content-Encoding gzip, deflate or br) are decompressed
+// here, up to the limit, and passed on decompressed: c.Body() would otherwise
+// inflate them without bound, in the handlers as in the telemetry logger. Other
+// encodings are answered with UNSUPPORTED_MEDIA_TYPE (415). A rejected body is
+// dropped so that the logger never reads it.
+//
+// Routes may set a lower limit:
+//
+//	bookings.Post("/import", middleware.BodyLimit(512*1024), r.Handler.ImportBookings)
+func BodyLimit(limit int) fiber.Handler {
+	if limit <= 0 {
+		limit = fiber.DefaultBodyLimit
+	}
+
+	return func(c *fiber.Ctx) error {
+		req := c.Request()
+
+		var err error
+		if req.Header.ContentLength() > limit || len(req.Body()) > limit {
+			err = errPayloadTooLarge(limit)
+		} else if encoding := string(req.Header.ContentEncoding()); encoding != "" && len(req.Body()) > 0 {
+			var body []byte
+			if body, err = decodeBody(req.Body(), encoding, limit); err == nil {
+				req.SetBodyRaw(body)
+				req.Header.SetContentLength(len(body))
+			}
+		}
+
+		// The body is passed on decoded, or dropped when rejected.
+		req.Header.Del(fiber.HeaderContentEncoding)
+		if err != nil {
+			req.ResetBody()
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func errPayloadTooLarge(limit int) error {
+	return apperror.NewPersistance(apperror.CodePayloadTooLarge, fmt.Sprintf("request body exceeds %d bytes", limit))
+}
+
+// decodeBody undoes the encodings of body, in the reverse order of their
+// application, reading at most limit bytes of decoded content.
+func decodeBody(body []byte, encoding string, limit int) ([]byte, error) {
+	codings := strings.Split(encoding, ",")
+	for i := len(codings) - 1; i >= 0; i-- {
+		var (
+			r   io.Reader
+			err error
+		)
+		switch coding := strings.ToLower(strings.TrimSpace(codings[i])); coding {
+		case "identity", "":
+			continue
+		case "gzip", "x-gzip":
+			r, err = gzip.NewReader(bytes.NewReader(body))
+		case "deflate":
+			r, err = zlib.NewReader(bytes.NewReader(body))
+		case "br":
+			r = brotli.NewReader(bytes.NewReader(body))
+		default:
+			return nil, apperror.NewPersistance(apperror.CodeUnsupportedMediaType, fmt.Sprintf("unsupported content encoding %q", coding))
+		}
+		if err == nil {
+			body, err = io.ReadAll(io.LimitReader(r, int64(limit)+1))
+		}
+		if err != nil {
+			return nil, apperror.NewPersistance(apperror.CodeMalformedRequest, "invalid compressed request body", err)
+		}
+		if len(body) > limit {
+			return nil, errPayloadTooLarge(limit)
+		}
+	}
+	return body, nil
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"strconv"
+	"sync/atomic"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	metricBulkheadInFlight = "http.bulkhead.in_flight"
+	metricBulkheadRejected = "http.bulkhead.rejected"
+
+	defaultRetryAfter = 1
+)
+
+// ErrOverloaded is returned when a route group has no capacity left.
+var ErrOverloaded = apperror.NewTransient(apperror.CodeServiceUnavailable, "too many requests in flight, retry later")
+
+// Bulkhead caps the number of requests a route group handles at once, so that
+// a spike on one group cannot exhaust the database pool shared with the
+// others. Excess requests are shed immediately with SERVICE_UNAVAILABLE (503,
+// retryable) and a Retry-After header rather than queued.
+//
+//	b := middleware.NewBulkhead("/api/v1/bookings", 50, 1, metrics)
+//	app.Use("/api/v1/bookings", b.Handler())
+//
+// Only the handler time is counted: a stream (SSE) is released as soon as its
+// handler returns.
+type Bulkhead struct {
+	name       string
+	limit      int64
+	retryAfter string
+	metrics    metrics.Metrics
+	tags       []string
+
+	inFlight atomic.Int64
+}
+
+// NewBulkhead returns a bulkhead named after its route group, letting limit
+// requests in at once. retryAfter (seconds) defaults to 1.
+func NewBulkhead(name string, limit, retryAfter int, m metrics.Metrics) *Bulkhead {
+	if retryAfter <= 0 {
+		retryAfter = defaultRetryAfter
+	}
+	return &Bulkhead{
+		name:       name,
+		limit:      int64(limit),
+		retryAfter: strconv.Itoa(retryAfter),
+		metrics:    m,
+		tags:       []string{"group:" + name},
+	}
+}
+
+// InFlight returns the number of requests being handled.
+func (b *Bulkhead) InFlight() int {
+	return int(b.inFlight.Load())
+}
+
+// Handler admits the request if the group has capacity and sheds it otherwise.
+func (b *Bulkhead) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		n := b.inFlight.Add(1)
+		if n > b.limit {
+			b.inFlight.Add(-1)
+			b.metrics.Incr(metricBulkheadRejected, b.tags)
+			c.Set(fiber.HeaderRetryAfter, b.retryAfter)
+			return ErrOverloaded
+		}
+		b.metrics.Gauge(metricBulkheadInFlight, float64(n), b.tags)
+
+		defer func() {
+			b.metrics.Gauge(metricBulkheadInFlight, float64(b.inFlight.Add(-1)), b.tags)
+		}()
+		return c.Next()
+	}
+}
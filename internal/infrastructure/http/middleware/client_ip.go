@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/netip"
+	"strings"
+	"voyago/core-api/internal/pkg/ipnet"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ClientIP returns the IP of the client of a request. The requests of the
+// trusted proxies (the load balancers) come from the rightmost address of
+// X-Forwarded-For that is not a trusted proxy: each proxy appends the
+// address it received the request from, the addresses on its left are set
+// by the client and may be forged. The other requests come from their peer,
+// their X-Forwarded-For is ignored.
+func ClientIP(c *fiber.Ctx, trustedProxies ipnet.List) string {
+	peer, _ := netip.AddrFromSlice(c.Context().RemoteIP())
+	peer = peer.Unmap()
+	if !trustedProxies.Contains(peer) {
+		return peer.String()
+	}
+
+	client := peer
+	hops := strings.Split(c.Get(fiber.HeaderXForwardedFor), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		client = addr.Unmap()
+		if !trustedProxies.Contains(client) {
+			break
+		}
+	}
+	return client.String()
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+const defaultCompressMinSize = 1024
+
+var defaultCompressTypes = []string{
+	fiber.MIMEApplicationJSON,
+	"application/problem+json",
+	fiber.MIMEApplicationXML,
+	fiber.MIMEApplicationJavaScript,
+	"text/",
+}
+
+// Compress compresses the responses with brotli, gzip or deflate, following
+// the Accept-Encoding of the client. Only the responses of an allowed content
+// type (prefix match) and of at least cfg.MinSize bytes are compressed;
+// streamed responses (Server-Sent Events) are always sent as is. Strong ETags
+// of compressed responses are made weak.
+//
+// It must be the first middleware so that error responses, rendered by the
+// log middleware, are compressed too and logged uncompressed. It panics on an
+// unknown level.
+func Compress(cfg config.CompressionConfig) fiber.Handler {
+	var brotliLevel, gzipLevel int
+	switch cfg.Level {
+	case config.CompressionLevelDefault, "":
+		brotliLevel, gzipLevel = fasthttp.CompressBrotliDefaultCompression, fasthttp.CompressDefaultCompression
+	case config.CompressionLevelBestSpeed:
+		brotliLevel, gzipLevel = fasthttp.CompressBrotliBestSpeed, fasthttp.CompressBestSpeed
+	case config.CompressionLevelBestCompression:
+		brotliLevel, gzipLevel = fasthttp.CompressBrotliBestCompression, fasthttp.CompressBestCompression
+	default:
+		panic(fmt.Errorf("invalid compression level %q", cfg.Level))
+	}
+	compress := fasthttp.CompressHandlerBrotliLevel(func(*fasthttp.RequestCtx) {}, brotliLevel, gzipLevel)
+
+	minSize := cfg.MinSize
+	if minSize <= 0 {
+		minSize = defaultCompressMinSize
+	}
+	types := cfg.ContentTypes
+	if len(types) == 0 {
+		types = defaultCompressTypes
+	}
+
+	compressible := func(contentType []byte) bool {
+		for _, t := range types {
+			if bytes.HasPrefix(contentType, []byte(t)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		res := c.Response()
+		if res.IsBodyStream() || len(res.Body()) < minSize || !compressible(res.Header.ContentType()) {
+			return nil
+		}
+		compress(c.Context())
+
+		// A strong ETag validates the uncompressed bytes only.
+		if etag := res.Header.Peek(fiber.HeaderETag); len(res.Header.ContentEncoding()) > 0 && len(etag) > 0 && !bytes.HasPrefix(etag, []byte("W/")) {
+			res.Header.Set(fiber.HeaderETag, "W/"+string(etag))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"strings"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+)
+
+// CORS answers preflight requests and sets the Access-Control-* headers for
+// the configured origins. Origins may use a subdomain wildcard (e.g.,
+// "https://*.voyago.com"); requests from other origins get no CORS header and
+// are blocked by the browser.
+//
+// It panics on an invalid origin and when credentials are allowed for every
+// origin ("*").
+func CORS(cfg config.CORSConfig) fiber.Handler {
+	return cors.New(cors.Config{
+		AllowOrigins:     join(cfg.AllowedOrigins),
+		AllowMethods:     join(cfg.AllowedMethods),
+		AllowHeaders:     join(cfg.AllowedHeaders),
+		ExposeHeaders:    join(cfg.ExposedHeaders),
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           cfg.MaxAge,
+	})
+}
+
+// join returns the comma separated list of the non-empty values.
+func join(values []string) string {
+	kept := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			kept = append(kept, v)
+		}
+	}
+	return strings.Join(kept, ",")
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"strings"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultCSRFCookie     = "csrf_token"
+	defaultCSRFHeader     = "X-CSRF-Token"
+	defaultCSRFExpiration = 12 * 60 * 60
+)
+
+// ErrInvalidCSRFToken is returned when an unsafe request does not echo the
+// CSRF cookie in the CSRF header.
+var ErrInvalidCSRFToken = apperror.NewPersistance(apperror.CodeForbidden, "missing or invalid CSRF token")
+
+// CSRF protects cookie-authenticated requests with a double submit cookie.
+// Safe requests (GET, HEAD, OPTIONS, TRACE) receive a random token in a cookie
+// readable by the front-end; unsafe requests must send it back in the CSRF
+// header, which a cross-site form or script cannot do. Mismatches are answered
+// with FORBIDDEN (403).
+//
+// The token is stateless, so every instance validates the tokens of the
+// others. Requests carrying an Authorization header and the exempt path
+// prefixes are not checked.
+func CSRF(cfg config.CSRFConfig) fiber.Handler {
+	cookieName := cfg.CookieName
+	if cookieName == "" {
+		cookieName = defaultCSRFCookie
+	}
+	headerName := cfg.HeaderName
+	if headerName == "" {
+		headerName = defaultCSRFHeader
+	}
+	expiration := cfg.Expiration
+	if expiration <= 0 {
+		expiration = defaultCSRFExpiration
+	}
+	sameSite := cfg.CookieSameSite
+	if sameSite == "" {
+		sameSite = fiber.CookieSameSiteLaxMode
+	}
+
+	exempt := func(c *fiber.Ctx) bool {
+		if c.Get(fiber.HeaderAuthorization) != "" {
+			return true
+		}
+		for _, prefix := range cfg.ExemptPaths {
+			if strings.HasPrefix(c.Path(), prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(c *fiber.Ctx) error {
+		if exempt(c) {
+			return c.Next()
+		}
+
+		token := c.Cookies(cookieName)
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions, fiber.MethodTrace:
+			if token == "" {
+				token = rand.Text()
+				c.Cookie(&fiber.Cookie{
+					Name:     cookieName,
+					Value:    token,
+					Path:     "/",
+					Domain:   cfg.CookieDomain,
+					Expires:  time.Now().Add(time.Duration(expiration) * time.Second),
+					Secure:   cfg.CookieSecure,
+					HTTPOnly: false, // read by the front-end to fill the header
+					SameSite: sameSite,
+				})
+			}
+			return c.Next()
+		}
+
+		sent := c.Get(headerName)
+		if token == "" || sent == "" || subtle.ConstantTimeCompare([]byte(token), []byte(sent)) != 1 {
+			return ErrInvalidCSRFToken
+		}
+		return c.Next()
+	}
+}
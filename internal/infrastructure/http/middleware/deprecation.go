@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Deprecation advertises the retirement of the routes it is mounted on:
+//
+//   - Deprecation (RFC 9745): "@<unix time>" of deprecatedAt, when set.
+//   - Sunset (RFC 8594): HTTP date of sunsetAt, when set.
+//   - Link: link with rel="deprecation", pointing clients to the migration guide.
+//
+// Zero times and an empty link are omitted, so the middleware can be mounted
+// on every route group and only speaks up for retired ones.
+func Deprecation(deprecatedAt, sunsetAt time.Time, link string) fiber.Handler {
+	headers := make(map[string]string, 3)
+	if !deprecatedAt.IsZero() {
+		headers["Deprecation"] = "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+	}
+	if !sunsetAt.IsZero() {
+		headers["Sunset"] = sunsetAt.UTC().Format(http.TimeFormat)
+	}
+	if link != "" {
+		headers[fiber.HeaderLink] = "<" + link + `>; rel="deprecation"; type="text/html"`
+	}
+
+	return func(c *fiber.Ctx) error {
+		for k, v := range headers {
+			c.Set(k, v)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"voyago/core-api/internal/infrastructure/errorreport"
+	"voyago/core-api/internal/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HandleErrorReport reports the unexpected failures of the downstream
+// handlers to r (see errorreport.Reportable), with the masked request, its
+// caller and its trace ID. The panics are reported with their stack: it must
+// run after HandleLog and before HandleRecover.
+func (m *Telemetrist) HandleErrorReport(r errorreport.Reporter) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+		if !errorreport.Reportable(err) {
+			return err
+		}
+
+		ctx := c.UserContext()
+		e := errorreport.NewEvent(ctx, err)
+		if stack, ok := c.Locals(localPanicStack).(string); ok {
+			e.Panic, e.Stack = true, stack
+		}
+		e.TraceID, _ = c.Locals("trace_id").(string)
+		e.Transport = "http"
+
+		route := c.Path()
+		if rt := c.Route(); rt != nil && rt.Path != "" {
+			route = rt.Path
+		}
+		e.Request = errorreport.Request{
+			Method:  c.Method(),
+			URL:     c.Path(),
+			Route:   route,
+			Headers: utils.MaskHttpHeaders(c.GetReqHeaders()),
+			Body:    m.parseBody(c.Body(), string(c.Request().Header.ContentType())),
+		}
+		if query, ok := utils.MaskSensitive(c.Queries()).(map[string]any); ok {
+			e.Request.Query = query
+		}
+
+		r.Report(ctx, e)
+		return err
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"voyago/core-api/internal/infrastructure/featureflag"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FeatureFlags middleware pins the feature flags of the request (see
+// featureflag.Pin): a flag evaluated twice while serving it, e.g. by the
+// handler then by a use case, keeps its first value even when the flags are
+// reloaded meanwhile.
+func FeatureFlags() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.SetUserContext(featureflag.Pin(c.UserContext()))
+		return c.Next()
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/gateway"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Identity headers, set by the API gateway once the caller is authenticated.
+const (
+	HeaderUserID    = "X-User-ID"
+	HeaderUserRoles = "X-User-Roles" // comma separated
+	HeaderTenantID  = "X-Tenant-ID"
+	HeaderClientApp = "X-Client-App"
+)
+
+// Identity middleware stores the caller of the request in the context: the
+// user, roles, tenant and client app of the identity headers. Values that are
+// not valid identifiers (see ctxkey.IsValidIdentifier) are ignored. The
+// loggers, the root span and the audit log read them from the context.
+//
+// The headers are only read from the requests of the gateway (see
+// gateway.Trust); the other requests have no identity. The tenant is only
+// read with tenancy enabled: it scopes the repositories.
+//
+// It panics on a trusted IP of the gateway that is neither an IP nor a CIDR.
+func Identity(gw config.GatewayConfig, tenancy config.TenancyConfig) fiber.Handler {
+	trust, err := gateway.New(gw)
+	if err != nil {
+		panic(fmt.Errorf("invalid gateway trusted ip: %w", err))
+	}
+
+	return func(c *fiber.Ctx) error {
+		peer, _ := netip.AddrFromSlice(c.Context().RemoteIP())
+		if !trust.Trusted(c.Get(gateway.HeaderSecret), peer) {
+			return c.Next()
+		}
+
+		ctx := c.UserContext()
+		if id := c.Get(HeaderUserID); ctxkey.IsValidIdentifier(id) {
+			ctx = ctxkey.SetUserID(ctx, id)
+		}
+		if roles := parseRoles(c.Get(HeaderUserRoles)); len(roles) > 0 {
+			ctx = ctxkey.SetRoles(ctx, roles)
+		}
+		if id := c.Get(HeaderTenantID); tenancy.Enabled && ctxkey.IsValidIdentifier(id) {
+			ctx = ctxkey.SetTenantID(ctx, id)
+		}
+		if app := c.Get(HeaderClientApp); ctxkey.IsValidIdentifier(app) {
+			ctx = ctxkey.SetClientApp(ctx, app)
+		}
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
+
+// parseRoles returns the valid roles of a comma separated list.
+func parseRoles(header string) []string {
+	var roles []string
+	for role := range strings.SplitSeq(header, ",") {
+		if role = strings.TrimSpace(role); ctxkey.IsValidIdentifier(role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Locale middleware stores the locales of the Accept-Language header in the
+// context, by preference, so that the error and validation messages are
+// translated into the language of the caller (see package i18n).
+func Locale() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if locales := i18n.ParseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage)); len(locales) > 0 {
+			c.SetUserContext(ctxkey.SetLocales(c.UserContext(), locales))
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/maintenance"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const defaultMaintenanceRetryAfter = 60
+
+// maintenanceExemptPaths are served whatever the mode: load balancers keep
+// probing the instances.
+var maintenanceExemptPaths = []string{"/", "/health"}
+
+// Maintenance answers every request with SERVICE_UNAVAILABLE (503, retryable)
+// and a Retry-After header while mode is enabled. The health routes, the
+// exemptPaths prefixes (e.g., the admin routes), the cfg.AllowedPaths prefixes
+// and the cfg.AllowedIPs clients are still served.
+//
+// It panics on an allowed IP that is neither an IP nor a CIDR.
+func Maintenance(mode *maintenance.Mode, cfg config.MaintenanceConfig, exemptPaths ...string) fiber.Handler {
+	var prefixes []string
+	for _, p := range slices.Concat(exemptPaths, cfg.AllowedPaths) {
+		if p == "" {
+			continue
+		}
+		prefixes = append(prefixes, strings.TrimSuffix(p, "/"))
+	}
+
+	var networks []netip.Prefix
+	for _, ip := range cfg.AllowedIPs {
+		network, err := netip.ParsePrefix(ip)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(ip)
+			if addrErr != nil {
+				panic(fmt.Errorf("invalid maintenance allowed ip %q", ip))
+			}
+			network = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		networks = append(networks, network.Masked())
+	}
+
+	retryAfter := cfg.RetryAfter
+	if retryAfter <= 0 {
+		retryAfter = defaultMaintenanceRetryAfter
+	}
+
+	allowed := func(c *fiber.Ctx) bool {
+		path := c.Path()
+		for _, p := range maintenanceExemptPaths {
+			if path == p {
+				return true
+			}
+		}
+		for _, p := range prefixes {
+			if path == p || strings.HasPrefix(path, p+"/") {
+				return true
+			}
+		}
+		if addr, err := netip.ParseAddr(c.IP()); err == nil {
+			for _, network := range networks {
+				if network.Contains(addr.Unmap()) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	return func(c *fiber.Ctx) error {
+		state := mode.State(c.UserContext())
+		if !state.Enabled || allowed(c) {
+			return c.Next()
+		}
+
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+		return apperror.NewTransient(apperror.CodeServiceUnavailable, state.Message)
+	}
+}
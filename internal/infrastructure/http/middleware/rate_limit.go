@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/ratelimit"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/ipnet"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+
+	metricThrottled      = "ratelimit.throttled"
+	metricRateLimitError = "ratelimit.errors"
+)
+
+// RateLimit throttles requests with the rule the limiter matches for them
+// (see ratelimit.Limiter). Counted responses carry the X-RateLimit-Limit,
+// X-RateLimit-Remaining and X-RateLimit-Reset (seconds) headers; a throttled
+// request is answered with TOO_MANY_REQUESTS (429) and a Retry-After header.
+//
+// Clients are identified by IP (see ClientIP for the requests of the trusted
+// proxies), or by the client app the gateway authenticated for rules keyed by
+// "api_key" (see Identity). When the store is unavailable, requests are let
+// through: an outage of Redis must not take the API down with it.
+func RateLimit(limiter *ratelimit.Limiter, trustedProxies ipnet.List, log logger.Logger, m metrics.Metrics) fiber.Handler {
+	log = log.WithField("component", "ratelimit")
+
+	return func(c *fiber.Ctx) error {
+		rule := limiter.Match(c.Method(), c.Path())
+		if !rule.Enabled() {
+			return c.Next()
+		}
+
+		res, err := limiter.Allow(c.UserContext(), rule, identity(c, rule, trustedProxies))
+		if err != nil {
+			log.WithContext(c.UserContext()).WithFields(map[string]any{
+				"rule":         rule.Name,
+				"error_detail": err.Error(),
+			}).Warn("rate limit store unavailable, request let through")
+			m.Incr(metricRateLimitError, []string{"rule:" + rule.Name})
+			return c.Next()
+		}
+
+		c.Set(HeaderRateLimitLimit, strconv.Itoa(res.Limit))
+		c.Set(HeaderRateLimitRemaining, strconv.Itoa(max(res.Remaining, 0)))
+		c.Set(HeaderRateLimitReset, strconv.Itoa(seconds(res.Reset)))
+
+		if !res.Allowed {
+			m.Incr(metricThrottled, []string{"rule:" + rule.Name, "key_by:" + rule.KeyBy})
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds(res.Reset)))
+			return apperror.ErrCodeTooManyRequests
+		}
+		return c.Next()
+	}
+}
+
+// identity returns the counted client of a request. Only the credentials
+// validated upstream count a client apart: a key sent by the client itself
+// would let it draw a new bucket per request.
+func identity(c *fiber.Ctx, rule ratelimit.Rule, trustedProxies ipnet.List) string {
+	if rule.KeyBy == config.RateLimitKeyByAPIKey {
+		if app := ctxkey.GetClientApp(c.UserContext()); app != "" {
+			return "app:" + app
+		}
+	}
+	return "ip:" + ClientIP(c, trustedProxies)
+}
+
+// seconds rounds d up to whole seconds, at least 1.
+func seconds(d time.Duration) int {
+	return max(int(math.Ceil(d.Seconds())), 1)
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"fmt"
+	"runtime/debug"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// localSpan holds the request span started by HandleTrace.
+	localSpan = "span"
+	// localPanicStack holds the stack of the panic recovered by HandleRecover,
+	// reported by HandleErrorReport.
+	localPanicStack = "panic_stack"
+
+	metricPanic = "panic_total"
+)
+
+// HandleRecover turns a panic of the downstream handlers into an
+// INTERNAL_ERROR, so that the request is answered, logged and measured like
+// any other failure instead of crashing the server.
+//
+// The panic value and stack trace are attached to the request span and to a
+// dedicated error log carrying the trace ID; the client only receives the
+// generic internal error. It must run after HandleTrace and HandleLog.
+func (m *Telemetrist) HandleRecover() fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			stack := string(debug.Stack())
+			c.Locals(localPanicStack, stack)
+
+			var routePath string
+			if rt := c.Route(); rt != nil {
+				routePath = rt.Path
+			}
+			if routePath == "" {
+				routePath = c.Path()
+			}
+
+			if span, ok := c.Locals(localSpan).(tracer.Span); ok {
+				span.SetTag("error", true)
+				span.SetTag("error.type", "panic")
+				span.SetTag("error.stack", stack)
+				span.SetTag("panic.value", fmt.Sprintf("%v", r))
+			}
+
+			m.LogProvider.WithContext(c.UserContext()).WithFields(map[string]any{
+				"component": "telemetry.middleware",
+				"transport": "http",
+				"method":    c.Method(),
+				"path":      c.Path(),
+				"route":     routePath,
+				"trace_id":  c.Locals("trace_id"),
+				"panic":     fmt.Sprintf("%v", r),
+				"stack":     stack,
+			}).Error("http handler panicked")
+
+			m.MetricsProvider.Incr(metricPanic, []string{"transport:http", "route:" + routePath})
+
+			cause, ok := r.(error)
+			if !ok {
+				cause = fmt.Errorf("%v", r)
+			}
+			err = apperror.NewInternal(apperror.CodeInternalError, apperror.ErrCodeInternalError.Message,
+				fmt.Errorf("panic: %w", cause))
+		}()
+
+		return c.Next()
+	}
+}
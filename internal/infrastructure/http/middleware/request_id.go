@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/uid"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -13,11 +14,11 @@ func RequestID() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Attempt to extract the Request ID from incoming headers.
 		reqId := c.Get(fiber.HeaderXRequestID)
-		// In scenarios where the header is absent, we default to "unknown".
-		// This explicitly indicates in the logs that the request did not originate
-		// with a predefined correlation ID from the upstream gateway or client.
-		if reqId == "" {
-			reqId = "unknown"
+		// When the header is absent or invalid (see uid.IsValidRequestID), a new ID
+		// is generated: every request can be correlated, and a caller cannot forge
+		// log lines through the header.
+		if !uid.IsValidRequestID(reqId) {
+			reqId = uid.NewRequestID()
 		}
 
 		// Reflect the Request ID back to the client in the response headers.
@@ -26,8 +27,8 @@ func RequestID() fiber.Handler {
 
 		// Context Propagation:
 		// We store the Request ID into the Fiber UserContext using a dedicated context key.
-		// This allows the logger and other downstream components to extract the ID
-		// and maintain a unified audit trail for the entire execution lifecycle.
+		// This allows the logger, the outbound HTTP client and other downstream components
+		// to extract the ID and maintain a unified audit trail for the entire execution lifecycle.
 		ctx := ctxkey.SetRequestID(c.UserContext(), reqId)
 		c.SetUserContext(ctx)
 
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"strconv"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultFrameOptions   = "DENY"
+	defaultReferrerPolicy = "no-referrer"
+)
+
+// SecurityHeaders sets the standard security headers on every response:
+// X-Content-Type-Options, X-Frame-Options, Referrer-Policy and, when
+// configured, Content-Security-Policy and Strict-Transport-Security.
+//
+// HSTS is meant for the environments served over HTTPS (TLS usually ends at
+// the load balancer, so the protocol of the request is not checked).
+func SecurityHeaders(cfg config.SecurityHeadersConfig) fiber.Handler {
+	frameOptions := cfg.FrameOptions
+	if frameOptions == "" {
+		frameOptions = defaultFrameOptions
+	}
+	referrerPolicy := cfg.ReferrerPolicy
+	if referrerPolicy == "" {
+		referrerPolicy = defaultReferrerPolicy
+	}
+
+	var hsts string
+	if cfg.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(cfg.HSTSMaxAge)
+		if cfg.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		if cfg.HSTSPreload {
+			hsts += "; preload"
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+		c.Set(fiber.HeaderXFrameOptions, frameOptions)
+		c.Set(fiber.HeaderReferrerPolicy, referrerPolicy)
+		if cfg.ContentSecurityPolicy != "" {
+			c.Set(fiber.HeaderContentSecurityPolicy, cfg.ContentSecurityPolicy)
+		}
+		if hsts != "" {
+			c.Set(fiber.HeaderStrictTransportSecurity, hsts)
+		}
+		return c.Next()
+	}
+}
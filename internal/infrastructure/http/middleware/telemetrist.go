@@ -34,13 +34,19 @@ func NewTelemetrist(
 
 // TraceMiddleware initiates the request span.
 // It must run first so other middlewares can attach data to this span.
+// The span joins the distributed trace of the caller when the request carries
+// a trace context (e.g., W3C traceparent), and propagated baggage is kept in
+// the request context.
 func (m *Telemetrist) HandleTrace() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		span, ctx := m.TracerProvider.StartSpan(c.UserContext(), fmt.Sprintf("HTTP %s %s", c.Method(), c.Path()))
+		ctx := m.TracerProvider.Extract(c.UserContext(), headerCarrier{c})
+		span, ctx := m.TracerProvider.StartSpan(ctx, fmt.Sprintf("HTTP %s %s", c.Method(), c.Path()))
 		defer span.Finish()
+		tracer.TagCaller(span, ctx)
 
 		tID, _, _ := m.TracerProvider.ExtractTraceInfo(ctx)
 		c.Locals("trace_id", tID)
+		c.Locals(localSpan, span)
 		c.Set("X-Trace-Id", tID)
 
 		c.SetUserContext(ctx)
@@ -77,6 +83,23 @@ func (m *Telemetrist) HandleTrace() fiber.Handler {
 	}
 }
 
+// headerCarrier reads the trace context from the request headers.
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, h.c.Request().Header.Len())
+	h.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
+
 // MetricsMiddleware records latency and throughput.
 func (m *Telemetrist) HandleMetrics() fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -141,6 +164,13 @@ func (m *Telemetrist) HandleLog() fiber.Handler {
 		reqContentType := string(c.Request().Header.ContentType())
 		resContentType := string(c.Response().Header.ContentType())
 
+		// Reading a streamed body (e.g., Server-Sent Events) would block until
+		// the stream ends and buffer it entirely.
+		var resBody any = "[streamed content]"
+		if !c.Response().IsBodyStream() {
+			resBody = m.parseBody(c.Response().Body(), resContentType)
+		}
+
 		logEntry := m.LogProvider.WithContext(ctx).WithFields(map[string]any{
 			"component": "telemetry.middleware",
 
@@ -161,12 +191,14 @@ func (m *Telemetrist) HandleLog() fiber.Handler {
 			},
 
 			"response": map[string]any{
-				"body": m.parseBody(c.Response().Body(), resContentType),
+				"body": resBody,
 			},
 		})
 
-		if err != nil || statusCode >= 500 {
-			logEntry.WithField("error", err.Error()).Error("http request completed with error")
+		if err != nil {
+			logEntry.WithFields(apperror.LogFields(err)).Error("http request completed with error")
+		} else if statusCode >= 500 {
+			logEntry.Error("http request completed with error")
 		} else if statusCode >= 400 {
 			logEntry.Warn("http request completed with client error")
 		} else {
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/gateway"
+	"voyago/core-api/internal/pkg/tenancy"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// tenantExemptPaths never require a tenant: load balancers probe them.
+var tenantExemptPaths = []string{"/", "/health"}
+
+// Tenant middleware stores the tenant resolved by the cfg.Resolvers in the
+// context, replacing the one of the identity headers; when none resolves it,
+// the tenant of the identity headers is kept. The header and claim resolvers
+// only read the requests of the gateway of gw (see gateway.Trust). With
+// cfg.Required, the requests without tenant are rejected with
+// ErrTenantRequired, except on the health routes and the exemptPaths and
+// cfg.ExemptPaths prefixes.
+//
+// It panics on an invalid resolver configuration (see tenancy.NewResolver)
+// and on a trusted IP of the gateway that is neither an IP nor a CIDR.
+func Tenant(cfg config.TenancyConfig, gw config.GatewayConfig, exemptPaths ...string) fiber.Handler {
+	resolve, err := tenancy.NewResolver(cfg)
+	if err != nil {
+		panic(err)
+	}
+	trust, err := gateway.New(gw)
+	if err != nil {
+		panic(fmt.Errorf("invalid gateway trusted ip: %w", err))
+	}
+
+	var prefixes []string
+	for _, p := range slices.Concat(exemptPaths, cfg.ExemptPaths) {
+		if p != "" {
+			prefixes = append(prefixes, strings.TrimSuffix(p, "/"))
+		}
+	}
+	exempt := func(path string) bool {
+		if slices.Contains(tenantExemptPaths, path) {
+			return true
+		}
+		for _, p := range prefixes {
+			if path == p || strings.HasPrefix(path, p+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	return func(c *fiber.Ctx) error {
+		peer, _ := netip.AddrFromSlice(c.Context().RemoteIP())
+		trusted := trust.Trusted(c.Get(gateway.HeaderSecret), peer)
+		if tenant := resolve(fiberRequest{c: c, trusted: trusted}); tenant != "" {
+			c.SetUserContext(ctxkey.SetTenantID(c.UserContext(), tenant))
+		}
+		if ctxkey.GetTenantID(c.UserContext()) == "" && cfg.Required && !exempt(c.Path()) {
+			return tenancy.ErrTenantRequired
+		}
+		return c.Next()
+	}
+}
+
+// fiberRequest adapts a fiber.Ctx to tenancy.Request.
+type fiberRequest struct {
+	c       *fiber.Ctx
+	trusted bool
+}
+
+func (r fiberRequest) Header(name string) string { return r.c.Get(name) }
+func (r fiberRequest) Host() string              { return r.c.Hostname() }
+func (r fiberRequest) Trusted() bool             { return r.trusted }
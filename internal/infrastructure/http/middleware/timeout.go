@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// localTimeout marks a request whose context already carries a deadline set
+// by Timeout.
+const localTimeout = "request_timeout"
+
+// Timeout bounds the request with a deadline on c.UserContext(). Every layer
+// using that context (use cases, GORM queries through DB.WithContext, outgoing
+// calls) is canceled once the deadline passes, and an error caused by it is
+// returned as a REQUEST_TIMEOUT (408, retryable) error.
+//
+// Mounted globally, it sets the default deadline; mounted again on a route,
+// it overrides it, longer or shorter:
+//
+//	app.Use(middleware.Timeout(8 * time.Second))
+//	reports.Get("/export", middleware.Timeout(60*time.Second), h.Export)
+//
+// A zero timeout removes the deadline. Handlers are not interrupted: they
+// stop when the work they wait on honors the context.
+func Timeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		if _, overridden := c.Locals(localTimeout).(time.Duration); overridden {
+			// Drop the outer deadline so that the override can extend it.
+			ctx = context.WithoutCancel(ctx)
+		}
+		c.Locals(localTimeout, timeout)
+
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		if err == nil || isRequestTimeout(err) {
+			return err
+		}
+		// The context of the innermost Timeout decides: an outer deadline
+		// replaced by a route override is not the cause of the error.
+		if errors.Is(c.UserContext().Err(), context.DeadlineExceeded) {
+			return apperror.NewTransient(apperror.CodeRequestTimeout, apperror.ErrCodeRequestTimeout.Message, err)
+		}
+		return err
+	}
+}
+
+func isRequestTimeout(err error) bool {
+	var appErr *apperror.AppError
+	return errors.As(err, &appErr) && appErr.Code == apperror.CodeRequestTimeout
+}
@@ -3,14 +3,21 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"sync"
 	"time"
 	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
 	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
 	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/i18n"
 	"voyago/core-api/internal/pkg/response"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 )
 
 // Server represents the HTTP server wrapper for the Fiber application.
@@ -29,10 +36,17 @@ type Server struct {
 // Parameters:
 //   - cfg: Application configuration (ports, timeouts, prefork settings).
 //   - log: Logger instance for infrastructure-level logging.
+//   - m: Metrics recording the errors answered by the error handler, none
+//     when nil.
 func NewServer(
 	cfg *config.Config,
 	log logger.Logger,
+	m metrics.Metrics,
 ) *Server {
+	if m == nil {
+		m = metrics.NewNoOpMetrics()
+	}
+
 	readTimeout := 10 * time.Second
 	if cfg.Http.ReadTimeout != 0 {
 		readTimeout = time.Duration(cfg.Http.ReadTimeout) * time.Second
@@ -54,7 +68,10 @@ func NewServer(
 		ReadTimeout:  readTimeout,
 		WriteTimeout: writeTimeout,
 		IdleTimeout:  idleTimeout,
-		ErrorHandler: errorHdlr,
+		// Bodies over the limit are rejected while being read, see also
+		// middleware.BodyLimit for compressed bodies.
+		BodyLimit:    cfg.Http.BodyLimit,
+		ErrorHandler: newErrorHandler(cfg.Http, log.WithField("component", "error_handler"), metrics.NewErrors(m)),
 	})
 
 	return &Server{
@@ -79,35 +96,120 @@ func (s *Server) Stop(ctx context.Context) error {
 	return s.App.ShutdownWithContext(ctx)
 }
 
-func errorHdlr(c *fiber.Ctx, err error) error {
-	// Default response
-	code := fiber.ErrInternalServerError.Code
-	message := err.Error()
-	errCode := fmt.Sprintf("ERR_%d", fiber.ErrInternalServerError.Code)
-	var details any
-	var isRetryable bool
-
-	// check if it appError
-	if e, ok := err.(*apperror.AppError); ok {
-		code = e.GetHttpStatus()
-		message = e.Message
-		errCode = e.Code
-		details = e.Details
-		isRetryable = e.IsRetryable()
-	} else if e, ok := err.(*fiber.Error); ok {
-		// Error from Fiber itself (e.g. 404 route not found)
-		code = e.Code
-		message = e.Message
-		errCode = fmt.Sprintf("ERR_%d", e.Code)
+// requestContext returns the context of the request of c with the locales
+// of the caller, also for the errors returned before middleware.Locale ran.
+func requestContext(c *fiber.Ctx) context.Context {
+	ctx := c.UserContext()
+	if ctxkey.GetLocales(ctx) == nil {
+		if locales := i18n.ParseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage)); len(locales) > 0 {
+			ctx = ctxkey.SetLocales(ctx, locales)
+		}
 	}
+	return ctx
+}
 
-	traceID, _ := c.Locals("trace_id").(string)
-	return c.Status(code).JSON(response.Http{
-		Success:     false,
-		Message:     message,
-		ErrorCode:   errCode,
-		Errors:      details,
-		TraceID:     traceID,
-		IsRetryable: isRetryable,
-	})
+// defaultRetryAfter is the Retry-After hint of the retryable errors, in
+// seconds, when http.retry_after is not set.
+const defaultRetryAfter = 1
+
+// newErrorHandler returns the global error handler. Errors are rendered as
+// the standard response envelope, or as RFC 7807 problems when the
+// "problem" error format is configured. The AppError messages are in the
+// locale of the caller (see apperror.AppError.Localized). The codes missing from the error
+// catalog (see apperror.Register) are logged, once per code: the client
+// teams do not know them. The AppErrors and the unexpected errors are
+// counted by code, and the latency of the 5xx responses is recorded apart
+// (see metrics.Errors). The retryable errors and the 429 and 503 responses
+// carry a Retry-After header. The AppErrors and fiber errors are matched
+// through the wrapped and joined errors; the message of the unexpected
+// errors is never sent to the client.
+func newErrorHandler(cfg config.HttpConfig, log logger.Logger, em *metrics.Errors) fiber.ErrorHandler {
+	problem := cfg.ErrorFormat == config.ErrorFormatProblem
+	retryAfter := strconv.Itoa(defaultRetryAfter)
+	if cfg.RetryAfter > 0 {
+		retryAfter = strconv.Itoa(cfg.RetryAfter)
+	}
+	var unregistered sync.Map
+
+	return func(c *fiber.Ctx, err error) error {
+		// Default response
+		code := fiber.ErrInternalServerError.Code
+		message := utils.StatusMessage(code)
+		errCode := fmt.Sprintf("ERR_%d", fiber.ErrInternalServerError.Code)
+		var details any
+		var isRetryable bool
+
+		var appErr *apperror.AppError
+		var fiberErr *fiber.Error
+		if errors.As(err, &appErr) {
+			e := appErr.Localized(requestContext(c))
+			code = e.GetHttpStatus()
+			message = e.Message
+			errCode = e.Code
+			details = e.Details
+			isRetryable = e.IsRetryable()
+			entry, registered := apperror.Lookup(e.Code)
+			if !registered {
+				if _, logged := unregistered.LoadOrStore(e.Code, struct{}{}); !logged {
+					log.WithField("error_code", e.Code).Warn("error code missing from the error catalog")
+				}
+			}
+			em.AppError(e.Code, string(e.Kind), entry.Module, isRetryable)
+		} else if errors.As(err, &fiberErr) {
+			// Error from Fiber itself (e.g. 404 route not found)
+			e := fiberErr
+			code = e.Code
+			message = e.Message
+			errCode = fmt.Sprintf("ERR_%d", e.Code)
+			if e.Code == fiber.StatusRequestEntityTooLarge {
+				errCode = apperror.CodePayloadTooLarge
+			}
+		} else {
+			// An unexpected error, answered as an internal error.
+			em.AppError(errCode, string(apperror.KindInternal), "", false)
+		}
+
+		if code >= fiber.StatusInternalServerError {
+			em.HTTPServerError(c.Method(), c.Route().Path, code, time.Since(c.Context().Time()))
+		}
+
+		// The clients wait for Retry-After before retrying: the delay computed
+		// by the middleware rejecting the request when it set one (e.g., the
+		// reset of the rate limit window), the default hint otherwise.
+		if isRetryable || code == fiber.StatusTooManyRequests || code == fiber.StatusServiceUnavailable {
+			if c.GetRespHeader(fiber.HeaderRetryAfter) == "" {
+				c.Set(fiber.HeaderRetryAfter, retryAfter)
+			}
+		}
+
+		traceID, _ := c.Locals("trace_id").(string)
+
+		if problem {
+			problemType := "about:blank"
+			if entry, ok := apperror.Lookup(errCode); ok && entry.DocURL != "" {
+				problemType = entry.DocURL
+			} else if cfg.ProblemTypeBase != "" {
+				problemType = cfg.ProblemTypeBase + errCode
+			}
+			return c.Status(code).JSON(response.Problem{
+				Type:        problemType,
+				Title:       utils.StatusMessage(code),
+				Status:      code,
+				Detail:      message,
+				Instance:    traceID,
+				ErrorCode:   errCode,
+				IsRetryable: isRetryable,
+				Errors:      details,
+			}, response.ContentTypeProblem)
+		}
+
+		return response.Send(c, code, response.Http{
+			Success:     false,
+			Message:     message,
+			ErrorCode:   errCode,
+			Errors:      details,
+			TraceID:     traceID,
+			IsRetryable: isRetryable,
+		})
+	}
 }
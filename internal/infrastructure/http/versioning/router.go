@@ -0,0 +1,173 @@
+// Package versioning mounts the REST API under versioned route groups
+// (e.g., /api/v1/bookings) and lets modules register their routes per version.
+//
+// Modules register a route set for the versions it belongs to:
+//
+//	routes.Register(func(v *versioning.Group) {
+//		bookings := v.Group("/bookings")
+//		bookings.Post("/", h.CreateBooking)
+//		v.Document(openapi.Operation{Method: fiber.MethodPost, Path: "/bookings/", ...})
+//	}, versioning.V1, versioning.V2)
+//
+// Only the versions listed in the api configuration are served: a route set
+// registered for an unlisted version is skipped. Deprecated versions answer
+// with Deprecation/Sunset headers until they are removed from the configuration.
+package versioning
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/infrastructure/openapi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	V1 = "v1"
+	V2 = "v2"
+
+	defaultPrefix = "/api"
+	dateLayout    = "2006-01-02"
+)
+
+// Version is a served API version.
+type Version struct {
+	// Name is the path segment of the version (e.g., "v1").
+	Name string
+	// Deprecated is the date the version was deprecated, zero while it is supported.
+	Deprecated time.Time
+	// Sunset is the date the version stops being served, zero when not planned.
+	Sunset time.Time
+	// Link points clients to the migration guide.
+	Link string
+}
+
+// IsDeprecated reports whether clients should migrate away from the version.
+func (v Version) IsDeprecated() bool {
+	return !v.Deprecated.IsZero() || !v.Sunset.IsZero()
+}
+
+// Router holds the route group of every served version.
+type Router struct {
+	groups map[string]*Group
+	order  []string
+}
+
+// Group is the route group of one version. Routes are registered on the
+// embedded fiber.Router with paths relative to the version prefix.
+type Group struct {
+	fiber.Router
+	Version Version
+
+	prefix string
+	docs   *openapi.Spec
+}
+
+// NewRouter mounts a route group per configured version on app. Operations
+// documented through the groups are added to docs, which may be nil.
+func NewRouter(app *fiber.App, cfg config.ApiConfig, docs *openapi.Spec) (*Router, error) {
+	prefix := strings.TrimSuffix(cfg.Prefix, "/")
+	if prefix == "" {
+		prefix = defaultPrefix
+	}
+
+	versions := cfg.Versions
+	if len(versions) == 0 {
+		versions = []config.ApiVersionConfig{{Name: V1}}
+	}
+
+	r := &Router{groups: make(map[string]*Group, len(versions))}
+	for _, vc := range versions {
+		v, err := parseVersion(vc)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := r.groups[v.Name]; ok {
+			return nil, fmt.Errorf("api version %q is configured twice", v.Name)
+		}
+
+		g := &Group{
+			Version: v,
+			prefix:  prefix + "/" + v.Name,
+			docs:    docs,
+		}
+		if v.IsDeprecated() {
+			g.Router = app.Group(g.prefix, middleware.Deprecation(v.Deprecated, v.Sunset, v.Link))
+		} else {
+			g.Router = app.Group(g.prefix)
+		}
+
+		r.groups[v.Name] = g
+		r.order = append(r.order, v.Name)
+	}
+	return r, nil
+}
+
+// Register calls fn with the group of every listed version that is served.
+func (r *Router) Register(fn func(v *Group), versions ...string) {
+	for _, name := range versions {
+		if g, ok := r.groups[name]; ok {
+			fn(g)
+		}
+	}
+}
+
+// Versions returns the served versions, in configuration order.
+func (r *Router) Versions() []Version {
+	out := make([]Version, 0, len(r.order))
+	for _, name := range r.order {
+		out = append(out, r.groups[name].Version)
+	}
+	return out
+}
+
+// Path returns the full path of a route of the group (e.g., "/bookings/"
+// becomes "/api/v1/bookings/").
+func (g *Group) Path(path string) string {
+	return g.prefix + path
+}
+
+// Document adds operations to the OpenAPI document. Paths are relative to the
+// version prefix; tags default to the first path segment (e.g., "bookings")
+// and operations of deprecated versions are flagged as such.
+func (g *Group) Document(ops ...openapi.Operation) {
+	for i := range ops {
+		if len(ops[i].Tags) == 0 {
+			if tag, _, _ := strings.Cut(strings.TrimPrefix(ops[i].Path, "/"), "/"); tag != "" {
+				ops[i].Tags = []string{tag}
+			}
+		}
+		ops[i].Path = g.Path(ops[i].Path)
+		ops[i].Deprecated = g.Version.IsDeprecated()
+	}
+	g.docs.Add(ops...)
+}
+
+func parseVersion(cfg config.ApiVersionConfig) (Version, error) {
+	v := Version{Name: strings.Trim(cfg.Name, "/"), Link: cfg.Link}
+	if v.Name == "" {
+		return Version{}, fmt.Errorf("api version without a name")
+	}
+
+	var err error
+	if v.Deprecated, err = parseDate(cfg.Deprecated); err != nil {
+		return Version{}, fmt.Errorf("api version %q: invalid deprecated date: %w", v.Name, err)
+	}
+	if v.Sunset, err = parseDate(cfg.Sunset); err != nil {
+		return Version{}, fmt.Errorf("api version %q: invalid sunset date: %w", v.Name, err)
+	}
+	if !v.Deprecated.IsZero() && !v.Sunset.IsZero() && v.Sunset.Before(v.Deprecated) {
+		return Version{}, fmt.Errorf("api version %q: sunset is before deprecation", v.Name)
+	}
+	return v, nil
+}
+
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(dateLayout, s)
+}
@@ -0,0 +1,250 @@
+// Package httpclient makes outgoing HTTP calls to third parties (payment
+// providers, partner APIs). Every call joins the trace of its context and
+// forwards its request ID, is retried with backoff when the upstream is
+// temporarily unavailable, and is short-circuited while the upstream host
+// keeps failing:
+//
+//	client := httpclient.New(cfg.HttpClient, trc, m)
+//	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+//	resp, err := client.Do(req)
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/breaker"
+)
+
+const (
+	defaultTimeout     = 10 * time.Second
+	defaultBaseBackoff = 100 * time.Millisecond
+	defaultMaxBackoff  = 2 * time.Second
+	defaultOpenTimeout = 30 * time.Second
+
+	// HeaderIdempotencyKey marks a request as safe to retry whatever its method.
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderRequestID carries the request ID of the context to the upstream.
+	HeaderRequestID = "X-Request-Id"
+
+	metricRequestDuration = "http_client_request_duration"
+	metricCircuitRejected = "http_client_circuit_rejected"
+	metricCircuitState    = "http_client_circuit_state"
+
+	// maxDrain caps how much of a discarded response is read to reuse its connection.
+	maxDrain = 4096
+)
+
+// ErrCircuitOpen is returned without calling the upstream while the circuit
+// of its host is open.
+var ErrCircuitOpen = apperror.NewTransient(apperror.CodeServiceUnavailable, "upstream service unavailable, retry later")
+
+// Client sends requests with tracing, retries and per-host circuit breaking.
+// It is safe for concurrent use and meant to be shared.
+type Client struct {
+	client      *http.Client
+	tracer      tracer.Tracer
+	metrics     metrics.Metrics
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	breakers    *breaker.Group
+}
+
+// New creates a Client from cfg; zero values take the defaults.
+func New(cfg config.HttpClientConfig, trc tracer.Tracer, m metrics.Metrics) *Client {
+	return &Client{
+		client:      &http.Client{Timeout: seconds(cfg.Timeout, defaultTimeout)},
+		tracer:      trc,
+		metrics:     m,
+		maxAttempts: max(cfg.Retry.MaxAttempts, 1),
+		baseBackoff: milliseconds(cfg.Retry.BaseBackoff, defaultBaseBackoff),
+		maxBackoff:  milliseconds(cfg.Retry.MaxBackoff, defaultMaxBackoff),
+		breakers: breaker.NewGroup(
+			cfg.CircuitBreaker.FailureThreshold,
+			seconds(cfg.CircuitBreaker.OpenTimeout, defaultOpenTimeout),
+			func(host string, s breaker.State) {
+				m.Gauge(metricCircuitState, float64(s), []string{"host:" + host})
+			},
+		),
+	}
+}
+
+// Do sends req and returns the response of the last attempt; as with
+// http.Client, a response is not an error whatever its status code.
+//
+// Responses 429, 502, 503 and 504 and transport errors are retried up to the
+// configured attempts, waiting an exponential backoff with jitter (or the
+// Retry-After of the upstream when longer, unless it exceeds the maximum
+// backoff). Only requests that can be replayed safely are retried: idempotent
+// methods, or any method carrying an Idempotency-Key header, and a body
+// created with GetBody (as http.NewRequest does for in-memory bodies).
+//
+// ErrCircuitOpen is returned while the host keeps failing, and the context
+// error once the context of req is done.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	span, ctx := c.tracer.StartSpan(req.Context(), "http.client "+req.Method)
+	defer span.Finish()
+	span.SetTag("http.method", req.Method)
+	span.SetTag("http.url", req.URL.Scheme+"://"+req.URL.Host+req.URL.Path)
+	span.SetTag("peer.hostname", req.URL.Hostname())
+
+	attempts := 1
+	if replayable(req) {
+		attempts = c.maxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, req, attempt)
+
+		var wait time.Duration
+		retry := attempt < attempts && ctx.Err() == nil && retryable(resp, err)
+		if retry {
+			wait, retry = c.backoff(attempt, resp)
+		}
+		if !retry {
+			span.SetTag("http.attempts", attempt)
+			if err != nil {
+				span.SetTag("error", true)
+				span.SetTag("error.message", err.Error())
+				return nil, err
+			}
+			span.SetTag("http.status_code", resp.StatusCode)
+			return resp, nil
+		}
+
+		discard(resp)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			span.SetTag("error", true)
+			span.SetTag("error.message", ctx.Err().Error())
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// send makes one attempt through the circuit breaker of the host. Retries
+// send a fresh copy of the body.
+func (c *Client) send(ctx context.Context, req *http.Request, attempt int) (*http.Response, error) {
+	out := req.Clone(ctx)
+	if attempt > 1 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		out.Body = body
+	}
+	c.tracer.Inject(ctx, out.Header)
+	if id := ctxkey.GetRequestID(ctx); id != "" && out.Header.Get(HeaderRequestID) == "" {
+		out.Header.Set(HeaderRequestID, id)
+	}
+
+	host := req.URL.Host
+	b := c.breakers.Get(host)
+	if !b.Allow() {
+		c.metrics.Incr(metricCircuitRejected, []string{"host:" + host})
+		return nil, ErrCircuitOpen
+	}
+
+	start := time.Now()
+	resp, err := c.client.Do(out)
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	c.metrics.Timing(metricRequestDuration, time.Since(start), []string{
+		"host:" + host,
+		"method:" + req.Method,
+		"status:" + status,
+	})
+
+	if ctx.Err() != nil {
+		// Canceled by the caller: says nothing about the upstream.
+		b.Release()
+	} else {
+		b.Record(err != nil || resp.StatusCode >= http.StatusInternalServerError)
+	}
+	return resp, err
+}
+
+// backoff returns the wait before the attempt following attempt, and false
+// when the upstream asks to wait longer than the maximum backoff.
+func (c *Client) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	wait := c.baseBackoff << (attempt - 1)
+	if wait <= 0 || wait > c.maxBackoff {
+		wait = c.maxBackoff
+	}
+	// Equal jitter: spread the retries of concurrent callers.
+	wait = wait/2 + rand.N(wait/2+1)
+
+	if resp != nil {
+		if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s > 0 {
+			retryAfter := time.Duration(s) * time.Second
+			if retryAfter > c.maxBackoff {
+				return 0, false
+			}
+			wait = max(wait, retryAfter)
+		}
+	}
+	return wait, true
+}
+
+// replayable reports whether req may be sent again.
+func replayable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(HeaderIdempotencyKey) != ""
+}
+
+// retryable reports whether the outcome of an attempt is worth retrying.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, ErrCircuitOpen)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// discard closes a response that is not returned, draining it so that the
+// connection can be reused.
+func discard(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	_, _ = io.CopyN(io.Discard, resp.Body, maxDrain)
+	resp.Body.Close()
+}
+
+func seconds(v int, fallback time.Duration) time.Duration {
+	if v <= 0 {
+		return fallback
+	}
+	return time.Duration(v) * time.Second
+}
+
+func milliseconds(v int, fallback time.Duration) time.Duration {
+	if v <= 0 {
+		return fallback
+	}
+	return time.Duration(v) * time.Millisecond
+}
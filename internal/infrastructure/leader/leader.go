@@ -0,0 +1,151 @@
+// Package leader elects a single replica of the application among those
+// campaigning for the same key, e.g. to run the scheduled jobs once across
+// the deployment.
+//
+// The leadership is a lock (see the lock package) taken for a TTL and
+// extended while the leader is alive. A leader that crashes, or fails to
+// extend its lock, hands the leadership to the next replica campaigning once
+// the TTL expired.
+//
+//	e := leader.New(locker, "scheduler:leader", 15*time.Second, log, m)
+//	e.Start()
+//	defer e.Stop()
+//	if e.IsLeader() {
+//		// only one replica at a time
+//	}
+package leader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+	"voyago/core-api/internal/infrastructure/lock"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+)
+
+// metricLeader is 1 on the leader, 0 on the other replicas.
+const metricLeader = "leader_elected"
+
+// Elector campaigns for the leadership of a key from Start to Stop.
+type Elector struct {
+	locker lock.Locker
+	key    string
+	ttl    time.Duration
+
+	log     logger.Logger
+	metrics metrics.Metrics
+
+	// held is the lock of the leadership, nil on the other replicas. It is
+	// only used by the campaign loop.
+	held    lock.Lock
+	leading atomic.Bool
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// New creates the elector of key. ttl bounds how long the leadership stays
+// with a crashed leader; the lock is extended every third of it. m may be
+// nil.
+func New(locker lock.Locker, key string, ttl time.Duration, log logger.Logger, m metrics.Metrics) *Elector {
+	return &Elector{
+		locker:  locker,
+		key:     key,
+		ttl:     ttl,
+		log:     log.WithFields(map[string]any{"component": "leader", "key": key}),
+		metrics: m,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// IsLeader reports whether this replica holds the leadership. It may lag
+// behind a lost lock by up to a third of the TTL.
+func (e *Elector) IsLeader() bool {
+	return e.leading.Load()
+}
+
+// Start campaigns at once, then every third of the TTL.
+func (e *Elector) Start() {
+	go e.campaign()
+}
+
+// Stop stops campaigning and resigns the leadership, handing it to the next
+// replica without waiting for the TTL.
+func (e *Elector) Stop() {
+	e.stopOnce.Do(func() {
+		close(e.stop)
+		<-e.done
+	})
+}
+
+func (e *Elector) campaign() {
+	defer close(e.done)
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		e.renew()
+		select {
+		case <-e.stop:
+			e.resign()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// renew extends the leadership, or tries to take it.
+func (e *Elector) renew() {
+	ctx, cancel := context.WithTimeout(context.Background(), e.ttl/3)
+	defer cancel()
+
+	if e.held != nil {
+		if err := e.held.Extend(ctx, e.ttl); err != nil {
+			e.log.WithField("error", err.Error()).Warn("leadership lost")
+			e.held = nil
+			e.set(false)
+		}
+		return
+	}
+
+	lk, err := e.locker.TryLock(ctx, e.key, e.ttl)
+	if err != nil {
+		if !errors.Is(err, lock.ErrNotAcquired) {
+			e.log.WithField("error", err.Error()).Warn("failed to campaign for leadership")
+		}
+		e.set(false)
+		return
+	}
+	e.held = lk
+	e.log.Info("elected leader")
+	e.set(true)
+}
+
+func (e *Elector) resign() {
+	e.set(false)
+	if e.held == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.ttl/3)
+	defer cancel()
+	if err := e.held.Unlock(ctx); err != nil && !errors.Is(err, lock.ErrLockLost) {
+		e.log.WithField("error", err.Error()).Warn("failed to resign leadership")
+	}
+	e.held = nil
+}
+
+func (e *Elector) set(leading bool) {
+	e.leading.Store(leading)
+	if e.metrics != nil {
+		value := 0.0
+		if leading {
+			value = 1
+		}
+		e.metrics.Gauge(metricLeader, value, []string{"key:" + e.key})
+	}
+}
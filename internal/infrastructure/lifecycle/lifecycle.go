@@ -0,0 +1,267 @@
+// Package lifecycle runs the graceful shutdown of the application. Components
+// register a shutdown hook in the phase matching their dependencies; on
+// SIGINT or SIGTERM the phases run in order:
+//
+//  1. PhaseServers: stop accepting requests and wait for the in-flight ones.
+//  2. PhaseConsumers: stop consuming events and drain the running handlers.
+//  3. PhaseWorkers: stop the background workers.
+//  4. PhaseResources: close databases and caches.
+//  5. PhaseTelemetry: flush and close metrics and traces.
+//
+// The first three phases share the grace period; the last two get the close
+// timeout once drained, so that connections are released even when draining
+// overran. The hooks of a phase run concurrently:
+//
+//	lc := lifecycle.New(cfg.Shutdown, log)
+//	lc.Register(lifecycle.PhaseTelemetry, "tracer", lifecycle.Closer(trc.Close))
+//	lc.Register(lifecycle.PhaseServers, "http server", srv.Stop)
+//	err := lc.Run(srv.Start)
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+)
+
+const (
+	defaultGracePeriod  = 30 * time.Second
+	defaultCloseTimeout = 5 * time.Second
+)
+
+// Phase orders the shutdown hooks: a phase starts once the previous one is
+// over.
+type Phase int
+
+const (
+	PhaseServers Phase = iota
+	PhaseConsumers
+	PhaseWorkers
+	PhaseResources
+	PhaseTelemetry
+)
+
+var phaseNames = [...]string{"servers", "consumers", "workers", "resources", "telemetry"}
+
+func (p Phase) String() string {
+	if p < 0 || int(p) >= len(phaseNames) {
+		return fmt.Sprintf("phase(%d)", int(p))
+	}
+	return phaseNames[p]
+}
+
+// Hook releases a component. It should return once ctx is done; the
+// shutdown moves on without waiting for hooks that do not.
+type Hook func(ctx context.Context) error
+
+// Closer adapts a Close method to a Hook.
+func Closer(close func() error) Hook {
+	return func(context.Context) error {
+		return close()
+	}
+}
+
+// Func adapts a stop function to a Hook.
+func Func(stop func()) Hook {
+	return func(context.Context) error {
+		stop()
+		return nil
+	}
+}
+
+type hook struct {
+	name string
+	run  Hook
+}
+
+// Manager holds the shutdown hooks. It is safe for concurrent use.
+type Manager struct {
+	grace        time.Duration
+	closeTimeout time.Duration
+	log          logger.Logger
+
+	mu     sync.Mutex
+	phases [len(phaseNames)][]hook
+	once   sync.Once
+	err    error
+}
+
+// New creates a Manager from cfg; zero values take the defaults.
+func New(cfg config.ShutdownConfig, log logger.Logger) *Manager {
+	m := &Manager{
+		grace:        defaultGracePeriod,
+		closeTimeout: defaultCloseTimeout,
+		log:          log.WithField("component", "lifecycle"),
+	}
+	if cfg.GracePeriod > 0 {
+		m.grace = time.Duration(cfg.GracePeriod) * time.Second
+	}
+	if cfg.CloseTimeout > 0 {
+		m.closeTimeout = time.Duration(cfg.CloseTimeout) * time.Second
+	}
+	return m
+}
+
+// Register adds a shutdown hook to phase. It panics on an unknown phase.
+func (m *Manager) Register(phase Phase, name string, run Hook) {
+	if phase < 0 || int(phase) >= len(phaseNames) {
+		panic(fmt.Errorf("lifecycle: unknown %s", phase))
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.phases[phase] = append(m.phases[phase], hook{name: name, run: run})
+}
+
+// Run calls start (e.g., srv.Start) and blocks until SIGINT or SIGTERM is
+// received or start fails, then shuts down. A second signal cuts the grace
+// period short. It returns the errors of start and of the hooks.
+func (m *Manager) Run(start func() error) error {
+	quit := make(chan os.Signal, 2)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	failed := make(chan error, 1)
+	go func() {
+		failed <- start()
+	}()
+
+	var startErr error
+	select {
+	case sig := <-quit:
+		m.log.Warn(fmt.Sprintf("Received %s, shutting down", sig))
+	case startErr = <-failed:
+		m.log.Warn("Server stopped, shutting down")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-quit:
+			m.log.Warn("Received a second signal, skipping the grace period")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return errors.Join(startErr, m.Shutdown(ctx))
+}
+
+// Shutdown runs the hooks phase by phase; only the first call runs them,
+// later calls return its result. ctx cuts the grace period short when done
+// earlier.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.once.Do(func() {
+		m.err = m.shutdown(ctx)
+	})
+	return m.err
+}
+
+func (m *Manager) shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	phases := m.phases
+	m.mu.Unlock()
+
+	start := time.Now()
+	var errs []error
+
+	drainCtx, cancel := context.WithTimeout(ctx, m.grace)
+	for _, phase := range []Phase{PhaseServers, PhaseConsumers, PhaseWorkers} {
+		errs = append(errs, m.runPhase(drainCtx, phase, phases[phase]))
+	}
+	cancel()
+
+	// Connections are closed even when draining overran or was cut short.
+	closeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), m.closeTimeout)
+	defer cancel()
+	for _, phase := range []Phase{PhaseResources, PhaseTelemetry} {
+		errs = append(errs, m.runPhase(closeCtx, phase, phases[phase]))
+	}
+
+	err := errors.Join(errs...)
+	log := m.log.WithField("duration_ms", time.Since(start).Milliseconds())
+	if err != nil {
+		log.WithField("error_detail", err.Error()).Error("Shutdown completed with errors")
+	} else {
+		log.Info("Shutdown completed")
+	}
+	return err
+}
+
+// runPhase runs hooks concurrently and waits for them until ctx is done.
+func (m *Manager) runPhase(ctx context.Context, phase Phase, hooks []hook) error {
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(hooks))
+	for _, h := range hooks {
+		go func() {
+			start := time.Now()
+			err := h.run(ctx)
+			m.log.WithFields(map[string]any{
+				"phase":       phase.String(),
+				"hook":        h.name,
+				"duration_ms": time.Since(start).Milliseconds(),
+			}).Debug("Shutdown hook completed")
+			results <- result{name: h.name, err: err}
+		}()
+	}
+
+	pending := make(map[string]int, len(hooks))
+	for _, h := range hooks {
+		pending[h.name]++
+	}
+
+	var errs []error
+	for range hooks {
+		var r result
+		select {
+		case r = <-results:
+		case <-ctx.Done():
+			select {
+			case r = <-results:
+			default:
+				return errors.Join(append(errs, m.timedOut(ctx, phase, pending))...)
+			}
+		}
+
+		pending[r.name]--
+		if r.err != nil {
+			m.log.WithFields(map[string]any{
+				"phase":        phase.String(),
+				"hook":         r.name,
+				"error_detail": r.err.Error(),
+			}).Error("Shutdown hook failed")
+			errs = append(errs, fmt.Errorf("%s: %s: %w", phase, r.name, r.err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// timedOut reports the hooks of phase still running once ctx is done.
+func (m *Manager) timedOut(ctx context.Context, phase Phase, pending map[string]int) error {
+	var errs []error
+	for name, n := range pending {
+		if n == 0 {
+			continue
+		}
+		m.log.WithFields(map[string]any{
+			"phase": phase.String(),
+			"hook":  name,
+		}).Warn("Shutdown hook did not finish in time")
+		errs = append(errs, fmt.Errorf("%s: %s: %w", phase, name, ctx.Err()))
+	}
+	return errors.Join(errs...)
+}
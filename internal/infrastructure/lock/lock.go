@@ -0,0 +1,102 @@
+// Package lock serializes critical sections across the replicas of the
+// application: a lock is held by a single process at a time, until it is
+// unlocked or its TTL expires.
+//
+// The TTL bounds how long a crashed or stuck holder blocks the others: pick
+// it above the expected duration of the critical section, and Extend long
+// running ones (e.g. a leader renewing its leadership). A lock outliving its
+// TTL may be taken by another process: Extend and Unlock then fail with
+// ErrLockLost, and the holder must stop writing.
+//
+//	err := lock.WithLock(ctx, locker, "booking-code:"+code, 5*time.Second, func(ctx context.Context) error {
+//		// exclusive across replicas
+//	})
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+var (
+	// ErrNotAcquired is returned by TryLock when the lock is held, and by
+	// Lock when its context is done before the lock is released.
+	ErrNotAcquired = errors.New("lock: not acquired")
+	// ErrLockLost is returned by Extend and Unlock when the TTL of the lock
+	// expired (the lock may have been taken by another process since).
+	ErrLockLost = errors.New("lock: lost")
+)
+
+// retryInterval is the base interval at which Lock tries again, jittered to
+// avoid waiters retrying in lockstep.
+const retryInterval = 50 * time.Millisecond
+
+// Locker acquires locks by key. The keys are shared by every process using
+// the same backend: prefix them with their use (e.g. "booking-code:").
+type Locker interface {
+	// Lock acquires key for ttl, waiting until it is released or ctx is
+	// done (ErrNotAcquired, wrapping the context error).
+	Lock(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+	// TryLock acquires key for ttl, failing with ErrNotAcquired when it is
+	// held.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (Lock, error)
+}
+
+// Lock is an acquired lock.
+type Lock interface {
+	Key() string
+	// Extend resets the TTL of the lock to ttl from now.
+	Extend(ctx context.Context, ttl time.Duration) error
+	// Unlock releases the lock. Once unlocked, a lock cannot be used again.
+	Unlock(ctx context.Context) error
+}
+
+// WithLock runs fn while holding key, then unlocks it. The unlock error is
+// returned when fn succeeded: ErrLockLost means fn outlived the TTL.
+func WithLock(ctx context.Context, l Locker, key string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	lk, err := l.Lock(ctx, key, ttl)
+	if err != nil {
+		return err
+	}
+
+	fnErr := fn(ctx)
+	// Released even when ctx was cancelled by fn's caller.
+	unlockErr := lk.Unlock(context.WithoutCancel(ctx))
+	if fnErr != nil {
+		return fnErr
+	}
+	return unlockErr
+}
+
+// waitFor calls try until it acquires the lock, fails or ctx is done.
+func waitFor(ctx context.Context, try func() (Lock, error)) (Lock, error) {
+	for {
+		lk, err := try()
+		if !errors.Is(err, ErrNotAcquired) {
+			return lk, err
+		}
+
+		wait := retryInterval/2 + rand.N(retryInterval)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w: %w", ErrNotAcquired, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// validate rejects the empty keys and the TTLs under a millisecond.
+func validate(key string, ttl time.Duration) error {
+	if key == "" {
+		return errors.New("lock: empty key")
+	}
+	if ttl < time.Millisecond {
+		return fmt.Errorf("lock: ttl %s under a millisecond", ttl)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+type postgresLocker struct {
+	db *sql.DB
+}
+
+// NewPostgresLocker returns a Locker taking Postgres session advisory locks
+// on db, for deployments without Redis. A lock holds a connection of the
+// pool until it is released: size the pool for the locks held concurrently.
+//
+// Postgres has no TTL for advisory locks: the lock is released by the
+// process when the TTL expires, and by Postgres when the process dies (its
+// connections close).
+func NewPostgresLocker(db *sql.DB) Locker {
+	return &postgresLocker{db: db}
+}
+
+func (l *postgresLocker) Lock(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	return waitFor(ctx, func() (Lock, error) { return l.TryLock(ctx, key, ttl) })
+}
+
+func (l *postgresLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	if err := validate(key, ttl); err != nil {
+		return nil, err
+	}
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id := advisoryKey(key)
+	var ok bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", id).Scan(&ok); err != nil {
+		discard(conn)
+		return nil, err
+	}
+	if !ok {
+		_ = conn.Close()
+		return nil, ErrNotAcquired
+	}
+
+	lk := &postgresLock{key: key, id: id, conn: conn}
+	lk.timer = time.AfterFunc(ttl, func() { _ = lk.Unlock(context.Background()) })
+	return lk, nil
+}
+
+// advisoryKey maps key to the 64-bit key of the advisory lock (FNV-1a).
+func advisoryKey(key string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return int64(h.Sum64())
+}
+
+// discard closes conn instead of returning it to the pool: a connection in
+// an unknown state may still hold an advisory lock, which the next user of
+// the session would acquire again (advisory locks are reentrant).
+func discard(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = conn.Close()
+}
+
+type postgresLock struct {
+	key string
+	id  int64
+
+	mu    sync.Mutex
+	conn  *sql.Conn // nil once released
+	timer *time.Timer
+}
+
+func (l *postgresLock) Key() string {
+	return l.key
+}
+
+func (l *postgresLock) Extend(_ context.Context, ttl time.Duration) error {
+	if err := validate(l.key, ttl); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn == nil || !l.timer.Stop() {
+		return ErrLockLost
+	}
+	l.timer.Reset(ttl)
+	return nil
+}
+
+func (l *postgresLock) Unlock(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn == nil {
+		return ErrLockLost
+	}
+	l.timer.Stop()
+	conn := l.conn
+	l.conn = nil
+
+	var released bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", l.id).Scan(&released); err != nil || !released {
+		// Closing the session releases the lock.
+		discard(conn)
+		if err == nil {
+			err = ErrLockLost
+		}
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,110 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// The lock value is a random token of its holder: only the holder extends or
+// deletes it, even after its TTL expired and another process took it.
+var (
+	extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+	unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// redisClient is the part of the Redis client the locks use.
+type redisClient interface {
+	redis.Cmdable
+	redis.Scripter
+}
+
+type redisLocker struct {
+	client redisClient
+	prefix string
+}
+
+// NewRedisLocker returns a Locker keeping the locks in Redis under prefix
+// (e.g. "voyago:lock:"). The locks are held by a single Redis (or sentinel
+// master, or cluster shard): a failover losing the writes of the master
+// before they were replicated may let two holders in.
+func NewRedisLocker(client redisClient, prefix string) Locker {
+	return &redisLocker{client: client, prefix: prefix}
+}
+
+func (l *redisLocker) Lock(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	return waitFor(ctx, func() (Lock, error) { return l.TryLock(ctx, key, ttl) })
+}
+
+func (l *redisLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (Lock, error) {
+	if err := validate(key, ttl); err != nil {
+		return nil, err
+	}
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := l.client.SetNX(ctx, l.prefix+key, token, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotAcquired
+	}
+	return &redisLock{client: l.client, key: key, redisKey: l.prefix + key, token: token}, nil
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+type redisLock struct {
+	client   redisClient
+	key      string
+	redisKey string
+	token    string
+}
+
+func (l *redisLock) Key() string {
+	return l.key
+}
+
+func (l *redisLock) Extend(ctx context.Context, ttl time.Duration) error {
+	if err := validate(l.key, ttl); err != nil {
+		return err
+	}
+	return l.run(ctx, extendScript, ttl.Milliseconds())
+}
+
+func (l *redisLock) Unlock(ctx context.Context) error {
+	return l.run(ctx, unlockScript)
+}
+
+// run runs script on the lock, failing with ErrLockLost when the lock is no
+// longer held with the token.
+func (l *redisLock) run(ctx context.Context, script *redis.Script, args ...any) error {
+	n, err := script.Run(ctx, l.client, []string{l.redisKey}, append([]any{l.token}, args...)...).Int()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Log levels accepted by Leveler.SetLevel, from the most to the least verbose.
+const (
+	LevelTrace = "trace"
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// Leveler is implemented by the loggers whose level can be changed at runtime.
+// Loggers derived with WithContext, WithField and WithFields share the level
+// of the logger they derive from.
+type Leveler interface {
+	// Level returns the current level name.
+	Level() string
+	// SetLevel changes the level; it fails on an unknown name.
+	SetLevel(level string) error
+}
+
+// level maps a level name to its logrus and slog counterparts. The log.level
+// configuration uses the logrus numbering (2: error ... 6: trace).
+type level struct {
+	name   string
+	logrus logrus.Level
+	slog   slog.Level
+}
+
+var levels = []level{
+	{LevelTrace, logrus.TraceLevel, slog.LevelDebug - 4},
+	{LevelDebug, logrus.DebugLevel, slog.LevelDebug},
+	{LevelInfo, logrus.InfoLevel, slog.LevelInfo},
+	{LevelWarn, logrus.WarnLevel, slog.LevelWarn},
+	{LevelError, logrus.ErrorLevel, slog.LevelError},
+}
+
+func levelByName(name string) (level, error) {
+	for _, l := range levels {
+		if l.name == name {
+			return l, nil
+		}
+	}
+	return level{}, fmt.Errorf("unknown log level %q", name)
+}
+
+func levelByLogrus(lvl logrus.Level) level {
+	for _, l := range levels {
+		if l.logrus == lvl {
+			return l
+		}
+	}
+	return levels[len(levels)-1]
+}
+
+func levelBySlog(lvl slog.Level) level {
+	for _, l := range levels {
+		if l.slog == lvl {
+			return l
+		}
+	}
+	return levels[2]
+}
+
+// ConfiguredLevel returns the level name cfg configures for the logger name
+// (a domain, or "main"): log.levels[name], or else log.level.
+func ConfiguredLevel(cfg config.LogConfig, name string) string {
+	if lvl, ok := cfg.Levels[name]; ok {
+		return lvl
+	}
+	return levelByLogrus(logrus.Level(cfg.Level)).name
+}
+
+// slogLevel maps the log.level configuration (logrus numbering) to a slog
+// level, Info by default.
+func slogLevel(configured int) slog.Level {
+	for _, l := range levels {
+		if int(l.logrus) == configured {
+			return l.slog
+		}
+	}
+	return slog.LevelInfo
+}
+
+// DebugToggle switches a set of loggers to the debug level and back to the
+// levels they had, e.g. on SIGHUP. Loggers that do not implement Leveler are
+// left out.
+type DebugToggle struct {
+	mu       sync.Mutex
+	levelers map[string]Leveler
+	// restore holds the levels to restore while debug is on.
+	restore map[string]string
+}
+
+// NewDebugToggle creates a DebugToggle of loggers, by name.
+func NewDebugToggle(loggers map[string]Logger) *DebugToggle {
+	levelers := make(map[string]Leveler, len(loggers))
+	for name, l := range loggers {
+		if leveler, ok := l.(Leveler); ok {
+			levelers[name] = leveler
+		}
+	}
+	return &DebugToggle{levelers: levelers}
+}
+
+// Toggle sets every logger to debug, or restores their previous levels when
+// the previous call did. It reports whether debug is now on. A level changed
+// in between (e.g. by the admin routes) is overwritten.
+func (t *DebugToggle) Toggle() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.restore != nil {
+		for name, lvl := range t.restore {
+			_ = t.levelers[name].SetLevel(lvl)
+		}
+		t.restore = nil
+		return false
+	}
+
+	t.restore = make(map[string]string, len(t.levelers))
+	for name, l := range t.levelers {
+		t.restore[name] = l.Level()
+		_ = l.SetLevel(LevelDebug)
+	}
+	return true
+}
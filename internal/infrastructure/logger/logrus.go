@@ -2,10 +2,9 @@ package logger
 
 import (
 	"context"
+	"io"
 	"voyago/core-api/internal/infrastructure/config"
-	"voyago/core-api/internal/infrastructure/ctxkey"
 	"voyago/core-api/internal/infrastructure/telemetry/tracer"
-	"voyago/core-api/internal/pkg/utils"
 
 	"github.com/sirupsen/logrus"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -16,20 +15,17 @@ type logrusLogger struct {
 	tracer tracer.Tracer
 }
 
-var _ Logger = (*logrusLogger)(nil)
+var (
+	_ Logger  = (*logrusLogger)(nil)
+	_ Leveler = (*logrusLogger)(nil)
+)
 
 func NewLogrus(cfg *config.Config, trc tracer.Tracer) Logger {
 	baseLogger := logrus.New()
 	baseLogger.SetFormatter(&logrus.JSONFormatter{})
 	baseLogger.SetLevel(logrus.Level(cfg.Log.Level))
 
-	baseLogger.SetOutput(&lumberjack.Logger{
-		Filename:   cfg.Log.Path,
-		MaxSize:    cfg.Log.Rotation.MaxSize,
-		MaxBackups: cfg.Log.Rotation.MaxBackup,
-		MaxAge:     cfg.Log.Rotation.MaxAge,
-		Compress:   cfg.Log.Rotation.Compress,
-	})
+	baseLogger.SetOutput(rotatingFile(cfg))
 
 	baseLogger.AddHook(NewMaskingHook())
 
@@ -39,16 +35,26 @@ func NewLogrus(cfg *config.Config, trc tracer.Tracer) Logger {
 	}
 }
 
+// rotatingFile returns the log.path file, rotated by log.rotation, written by
+// the JSON drivers.
+func rotatingFile(cfg *config.Config) io.Writer {
+	return &lumberjack.Logger{
+		Filename:   cfg.Log.Path,
+		MaxSize:    cfg.Log.Rotation.MaxSize,
+		MaxBackups: cfg.Log.Rotation.MaxBackup,
+		MaxAge:     cfg.Log.Rotation.MaxAge,
+		Compress:   cfg.Log.Rotation.Compress,
+	}
+}
+
 func (l *logrusLogger) WithContext(ctx context.Context) Logger {
 	if ctx == nil {
 		return l
 	}
 
-	requestID := ctxkey.GetRequestID(ctx)
 	fields := logrus.Fields{}
-
-	if requestID != "" && requestID != "unknown" {
-		fields["request_id"] = requestID
+	for _, f := range contextFields(ctx) {
+		fields[f.key] = f.value
 	}
 
 	// Extract Trace & Span IDs for log correlation
@@ -84,6 +90,19 @@ func (l *logrusLogger) WithFields(fields map[string]any) Logger {
 	}
 }
 
+func (l *logrusLogger) Level() string {
+	return levelByLogrus(l.log.Logger.GetLevel()).name
+}
+
+func (l *logrusLogger) SetLevel(name string) error {
+	lvl, err := levelByName(name)
+	if err != nil {
+		return err
+	}
+	l.log.Logger.SetLevel(lvl.logrus)
+	return nil
+}
+
 func (l *logrusLogger) Debug(message string) { l.log.Debug(message) }
 func (l *logrusLogger) Info(message string)  { l.log.Info(message) }
 func (l *logrusLogger) Warn(message string)  { l.log.Warn(message) }
@@ -103,20 +122,9 @@ func (h *MaskingHook) Levels() []logrus.Level {
 
 func (h *MaskingHook) Fire(entry *logrus.Entry) error {
 	for k, v := range entry.Data {
-		if utils.IsSensitiveKey(k) {
-			entry.Data[k] = "******** [REDACTED]"
-			continue
-		}
-		entry.Data[k] = utils.MaskSensitive(v)
-	}
-
-	if len(entry.Message) > utils.MaxFieldSize {
-		entry.Message = "[message too large to log]"
-	} else {
-		if utils.ContainsSensitiveToken(entry.Message) {
-			entry.Message = "******** [REDACTED]"
-		}
+		entry.Data[k] = maskField(k, v)
 	}
+	entry.Message = maskMessage(entry.Message)
 
 	return nil
 }
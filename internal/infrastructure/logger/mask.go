@@ -0,0 +1,25 @@
+package logger
+
+import "voyago/core-api/internal/pkg/utils"
+
+// redacted replaces the sensitive values and messages.
+const redacted = utils.Redacted
+
+// maskMessage applies the masking rules shared by the drivers to a message:
+// messages over utils.MaxFieldSize are dropped, those containing a sensitive
+// token redacted, and the matches of the sensitive patterns removed.
+func maskMessage(msg string) string {
+	if len(msg) > utils.MaxFieldSize() {
+		return "[message too large to log]"
+	}
+	if utils.ContainsSensitiveToken(msg) {
+		return redacted
+	}
+	return utils.MaskPatterns(msg)
+}
+
+// maskField applies the masking rules shared by the drivers to a field (see
+// utils.MaskField).
+func maskField(key string, value any) any {
+	return utils.MaskField(key, value)
+}
@@ -0,0 +1,269 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// otelLogger ships the logs through the OTLP logs signal. The context given
+// to WithContext is passed with every record, so that the records of a
+// request carry the trace and span IDs of its OTel span; the trace_id and
+// span_id attributes are also set, whatever the tracer.
+type otelLogger struct {
+	logger *slog.Logger
+	tracer tracer.Tracer
+	ctx    context.Context
+	// level and provider are shared by the derived loggers.
+	level    *slog.LevelVar
+	provider *sdklog.LoggerProvider
+}
+
+var (
+	_ Logger  = (*otelLogger)(nil)
+	_ Leveler = (*otelLogger)(nil)
+)
+
+// NewOTelLogger creates a logger exporting to the OTLP gRPC endpoint of
+// cfg.Log.OTLP, with the service name and environment of cfg as resource.
+// The exporter connects lazily: records are batched, and dropped while the
+// collector is unreachable. Close flushes the pending records.
+func NewOTelLogger(cfg *config.Config, trc tracer.Tracer) (Logger, error) {
+	ctx := context.Background()
+
+	exporter, err := otlploggrpc.New(ctx,
+		otlploggrpc.WithEndpoint(cfg.Log.OTLP.Address),
+		otlploggrpc.WithInsecure(), // Use TLS in production!
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP log exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(cfg.Telemetry.Namespace),
+			semconv.DeploymentEnvironment(cfg.App.Env),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)),
+		sdklog.WithResource(res),
+	)
+
+	level := new(slog.LevelVar)
+	level.Set(slogLevel(cfg.Log.Level))
+	handler := NewMaskingHandler(NewOTelHandler(provider.Logger(cfg.Telemetry.Namespace), level))
+
+	return &otelLogger{
+		logger:   slog.New(handler),
+		tracer:   trc,
+		ctx:      context.Background(),
+		level:    level,
+		provider: provider,
+	}, nil
+}
+
+func (l *otelLogger) derive(logger *slog.Logger, ctx context.Context) *otelLogger {
+	return &otelLogger{
+		logger:   logger,
+		tracer:   l.tracer,
+		ctx:      ctx,
+		level:    l.level,
+		provider: l.provider,
+	}
+}
+
+func (l *otelLogger) WithContext(ctx context.Context) Logger {
+	if ctx == nil {
+		return l
+	}
+
+	var args []any
+	for _, f := range contextFields(ctx) {
+		args = append(args, slog.String(f.key, f.value))
+	}
+
+	if l.tracer != nil {
+		if traceID, spanID, ok := l.tracer.ExtractTraceInfo(ctx); ok {
+			args = append(args,
+				slog.String("trace_id", traceID),
+				slog.String("span_id", spanID),
+			)
+		}
+	}
+
+	return l.derive(l.logger.With(args...), ctx)
+}
+
+func (l *otelLogger) WithField(key string, value any) Logger {
+	return l.derive(l.logger.With(slog.Any(key, value)), l.ctx)
+}
+
+func (l *otelLogger) WithFields(fields map[string]any) Logger {
+	args := make([]any, 0, len(fields)*2)
+	for k, v := range fields {
+		args = append(args, k, v)
+	}
+	return l.derive(l.logger.With(args...), l.ctx)
+}
+
+func (l *otelLogger) Level() string {
+	return levelBySlog(l.level.Level()).name
+}
+
+func (l *otelLogger) SetLevel(name string) error {
+	lvl, err := levelByName(name)
+	if err != nil {
+		return err
+	}
+	l.level.Set(lvl.slog)
+	return nil
+}
+
+func (l *otelLogger) Debug(msg string) { l.logger.Log(l.ctx, slog.LevelDebug, msg) }
+func (l *otelLogger) Info(msg string)  { l.logger.Log(l.ctx, slog.LevelInfo, msg) }
+func (l *otelLogger) Warn(msg string)  { l.logger.Log(l.ctx, slog.LevelWarn, msg) }
+func (l *otelLogger) Error(msg string) { l.logger.Log(l.ctx, slog.LevelError, msg) }
+
+// Close flushes the pending records and stops the exporter.
+func (l *otelLogger) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return l.provider.Shutdown(ctx)
+}
+
+// ------- OTEL HANDLER -------
+
+// OTelHandler is a slog.Handler emitting the records to an OTel logger: the
+// message is the body, the attributes (prefixed by their groups) are the
+// record attributes, and the context passed to the slog.Logger methods gives
+// the trace correlation.
+type OTelHandler struct {
+	logger otellog.Logger
+	level  slog.Leveler
+	attrs  []otellog.KeyValue
+	group  string
+}
+
+// NewOTelHandler creates an OTelHandler emitting the records of level and
+// above to logger.
+func NewOTelHandler(logger otellog.Logger, level slog.Leveler) *OTelHandler {
+	return &OTelHandler{logger: logger, level: level}
+}
+
+func (h *OTelHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *OTelHandler) Handle(ctx context.Context, r slog.Record) error {
+	var rec otellog.Record
+	rec.SetTimestamp(r.Time)
+	rec.SetObservedTimestamp(time.Now())
+	rec.SetBody(otellog.StringValue(r.Message))
+	rec.SetSeverity(severity(r.Level))
+	rec.SetSeverityText(levelBySlog(r.Level).name)
+
+	rec.AddAttributes(h.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		rec.AddAttributes(otelKeyValue(h.group, a))
+		return true
+	})
+
+	h.logger.Emit(ctx, rec)
+	return nil
+}
+
+func (h *OTelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *h
+	next.attrs = make([]otellog.KeyValue, len(h.attrs), len(h.attrs)+len(attrs))
+	copy(next.attrs, h.attrs)
+	for _, a := range attrs {
+		next.attrs = append(next.attrs, otelKeyValue(h.group, a))
+	}
+	return &next
+}
+
+func (h *OTelHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	next := *h
+	next.group = h.group + name + "."
+	return &next
+}
+
+// severity maps a slog level to the OTel severity.
+func severity(level slog.Level) otellog.Severity {
+	switch {
+	case level < slog.LevelDebug:
+		return otellog.SeverityTrace
+	case level < slog.LevelInfo:
+		return otellog.SeverityDebug
+	case level < slog.LevelWarn:
+		return otellog.SeverityInfo
+	case level < slog.LevelError:
+		return otellog.SeverityWarn
+	default:
+		return otellog.SeverityError
+	}
+}
+
+func otelKeyValue(group string, a slog.Attr) otellog.KeyValue {
+	return otellog.KeyValue{Key: group + a.Key, Value: otelValue(a.Value)}
+}
+
+// otelValue converts a slog value; the values of unsupported types (structs,
+// slices, errors) are formatted.
+func otelValue(v slog.Value) otellog.Value {
+	switch v.Kind() {
+	case slog.KindString:
+		return otellog.StringValue(v.String())
+	case slog.KindInt64:
+		return otellog.Int64Value(v.Int64())
+	case slog.KindUint64:
+		return otellog.Int64Value(int64(v.Uint64()))
+	case slog.KindFloat64:
+		return otellog.Float64Value(v.Float64())
+	case slog.KindBool:
+		return otellog.BoolValue(v.Bool())
+	case slog.KindDuration:
+		return otellog.StringValue(v.Duration().String())
+	case slog.KindTime:
+		return otellog.StringValue(v.Time().Format(time.RFC3339Nano))
+	case slog.KindGroup:
+		attrs := v.Group()
+		kvs := make([]otellog.KeyValue, len(attrs))
+		for i, a := range attrs {
+			kvs[i] = otelKeyValue("", a)
+		}
+		return otellog.MapValue(kvs...)
+	case slog.KindLogValuer:
+		return otelValue(v.Resolve())
+	default:
+		switch a := v.Any().(type) {
+		case map[string]any:
+			kvs := make([]otellog.KeyValue, 0, len(a))
+			for k, val := range a {
+				kvs = append(kvs, otellog.KeyValue{Key: k, Value: otelValue(slog.AnyValue(val))})
+			}
+			return otellog.MapValue(kvs...)
+		case error:
+			return otellog.StringValue(a.Error())
+		default:
+			return otellog.StringValue(fmt.Sprintf("%+v", a))
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+)
+
+// maxSampledMessages bounds the distinct messages counted per interval; the
+// messages beyond it are logged without sampling.
+const maxSampledMessages = 1000
+
+// samplingLogger limits the identical warn and error messages (same level and
+// message, whatever the fields) logged per interval, to protect the log
+// aggregator during error storms. Debug and info messages are not sampled.
+//
+// When an interval ends with suppressed messages, the next sampled call logs
+// a "Suppressed N similar log messages" summary per message through the
+// sampled logger, without the fields of the calls.
+type samplingLogger struct {
+	next Logger
+	// sampler is shared by the derived loggers.
+	sampler *sampler
+}
+
+var (
+	_ Logger    = (*samplingLogger)(nil)
+	_ Leveler   = (*samplingLogger)(nil)
+	_ io.Closer = (*samplingLogger)(nil)
+)
+
+// NewSampler wraps next with the sampling of cfg; it returns next when the
+// sampling is disabled.
+func NewSampler(next Logger, cfg config.LogSamplingConfig) Logger {
+	if !cfg.Enabled || cfg.Interval <= 0 {
+		return next
+	}
+	return &samplingLogger{
+		next: next,
+		sampler: &sampler{
+			base:       next,
+			interval:   time.Duration(cfg.Interval) * time.Second,
+			first:      cfg.First,
+			thereafter: cfg.Thereafter,
+			counts:     make(map[sampleKey]*sampleCount),
+		},
+	}
+}
+
+func (l *samplingLogger) WithContext(ctx context.Context) Logger {
+	return &samplingLogger{next: l.next.WithContext(ctx), sampler: l.sampler}
+}
+
+func (l *samplingLogger) WithField(key string, value any) Logger {
+	return &samplingLogger{next: l.next.WithField(key, value), sampler: l.sampler}
+}
+
+func (l *samplingLogger) WithFields(fields map[string]any) Logger {
+	return &samplingLogger{next: l.next.WithFields(fields), sampler: l.sampler}
+}
+
+func (l *samplingLogger) Level() string {
+	if leveler, ok := l.next.(Leveler); ok {
+		return leveler.Level()
+	}
+	return ""
+}
+
+func (l *samplingLogger) SetLevel(name string) error {
+	if leveler, ok := l.next.(Leveler); ok {
+		return leveler.SetLevel(name)
+	}
+	return fmt.Errorf("logger has no level")
+}
+
+// Close closes the sampled logger when it is an io.Closer.
+func (l *samplingLogger) Close() error {
+	if closer, ok := l.next.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (l *samplingLogger) Debug(msg string) { l.next.Debug(msg) }
+func (l *samplingLogger) Info(msg string)  { l.next.Info(msg) }
+
+func (l *samplingLogger) Warn(msg string) {
+	if l.sampler.allow(LevelWarn, msg) {
+		l.next.Warn(msg)
+	}
+}
+
+func (l *samplingLogger) Error(msg string) {
+	if l.sampler.allow(LevelError, msg) {
+		l.next.Error(msg)
+	}
+}
+
+type sampleKey struct {
+	level   string
+	message string
+}
+
+type sampleCount struct {
+	seen       int
+	suppressed int
+}
+
+// sampler counts the messages of the current interval.
+type sampler struct {
+	// base logs the summaries.
+	base       Logger
+	interval   time.Duration
+	first      int
+	thereafter int
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[sampleKey]*sampleCount
+}
+
+// allow reports whether the message is logged, and logs the summaries of the
+// previous interval when it just ended.
+func (s *sampler) allow(level, msg string) bool {
+	s.mu.Lock()
+	now := time.Now()
+	var ended map[sampleKey]*sampleCount
+	if now.Sub(s.start) >= s.interval {
+		ended = s.counts
+		s.counts = make(map[sampleKey]*sampleCount, len(ended))
+		s.start = now
+	}
+	allowed := s.count(sampleKey{level: level, message: msg})
+	s.mu.Unlock()
+
+	for key, c := range ended {
+		if c.suppressed > 0 {
+			s.base.WithFields(map[string]any{
+				"sampled_level":   key.level,
+				"sampled_message": key.message,
+				"suppressed":      c.suppressed,
+			}).Warn(fmt.Sprintf("Suppressed %d similar log messages", c.suppressed))
+		}
+	}
+	return allowed
+}
+
+func (s *sampler) count(key sampleKey) bool {
+	c, ok := s.counts[key]
+	if !ok {
+		if len(s.counts) >= maxSampledMessages {
+			return true
+		}
+		c = &sampleCount{}
+		s.counts[key] = c
+	}
+
+	c.seen++
+	if c.seen <= s.first || (s.thereafter > 0 && (c.seen-s.first)%s.thereafter == 0) {
+		return true
+	}
+	c.suppressed++
+	return false
+}
@@ -6,7 +6,6 @@ import (
 	"os"
 	"time"
 	"voyago/core-api/internal/infrastructure/config"
-	"voyago/core-api/internal/infrastructure/ctxkey"
 	"voyago/core-api/internal/infrastructure/telemetry/tracer"
 	"voyago/core-api/internal/pkg/utils"
 
@@ -17,9 +16,14 @@ type stdoutLogger struct {
 	handler slog.Handler
 	logger  *slog.Logger
 	tracer  tracer.Tracer
+	// level is shared by the derived loggers.
+	level *slog.LevelVar
 }
 
-var _ Logger = (*stdoutLogger)(nil)
+var (
+	_ Logger  = (*stdoutLogger)(nil)
+	_ Leveler = (*stdoutLogger)(nil)
+)
 
 func NewStdoutLogger(config *config.Config, trc tracer.Tracer) Logger {
 	var slogLevel slog.Level
@@ -38,8 +42,11 @@ func NewStdoutLogger(config *config.Config, trc tracer.Tracer) Logger {
 		slogLevel = slog.LevelInfo
 	}
 
+	level := new(slog.LevelVar)
+	level.Set(slogLevel)
+
 	baseHandler := tint.NewHandler(os.Stdout, &tint.Options{
-		Level:      slogLevel,
+		Level:      level,
 		TimeFormat: time.RFC1123,
 	})
 	maskingHandler := NewMaskingHandler(baseHandler)
@@ -48,6 +55,7 @@ func NewStdoutLogger(config *config.Config, trc tracer.Tracer) Logger {
 		handler: maskingHandler,
 		logger:  slog.New(maskingHandler),
 		tracer:  trc,
+		level:   level,
 	}
 }
 
@@ -57,8 +65,8 @@ func (l *stdoutLogger) WithContext(ctx context.Context) Logger {
 	}
 
 	var args []any
-	if requestID := ctxkey.GetRequestID(ctx); requestID != "" {
-		args = append(args, slog.String("request_id", requestID))
+	for _, f := range contextFields(ctx) {
+		args = append(args, slog.String(f.key, f.value))
 	}
 
 	if l.tracer != nil {
@@ -75,6 +83,7 @@ func (l *stdoutLogger) WithContext(ctx context.Context) Logger {
 			handler: l.handler,
 			logger:  l.logger.With(args...),
 			tracer:  l.tracer,
+			level:   l.level,
 		}
 	}
 
@@ -83,7 +92,7 @@ func (l *stdoutLogger) WithContext(ctx context.Context) Logger {
 
 func (l *stdoutLogger) WithField(key string, value any) Logger {
 	newLogger := l.logger.With(slog.Any(key, value))
-	return &stdoutLogger{handler: l.handler, logger: newLogger, tracer: l.tracer}
+	return &stdoutLogger{handler: l.handler, logger: newLogger, tracer: l.tracer, level: l.level}
 }
 
 func (l *stdoutLogger) WithFields(fields map[string]any) Logger {
@@ -92,7 +101,20 @@ func (l *stdoutLogger) WithFields(fields map[string]any) Logger {
 		args = append(args, k, v)
 	}
 	newLogger := l.logger.With(args...)
-	return &stdoutLogger{handler: l.handler, logger: newLogger, tracer: l.tracer}
+	return &stdoutLogger{handler: l.handler, logger: newLogger, tracer: l.tracer, level: l.level}
+}
+
+func (l *stdoutLogger) Level() string {
+	return levelBySlog(l.level.Level()).name
+}
+
+func (l *stdoutLogger) SetLevel(name string) error {
+	lvl, err := levelByName(name)
+	if err != nil {
+		return err
+	}
+	l.level.Set(lvl.slog)
+	return nil
 }
 
 func (l *stdoutLogger) Debug(msg string) { l.logger.Debug(msg) }
@@ -116,11 +138,7 @@ func (h *MaskingHandler) Enabled(ctx context.Context, level slog.Level) bool {
 
 func (h *MaskingHandler) Handle(ctx context.Context, r slog.Record) error {
 	// 1. Mask the Message
-	if len(r.Message) > utils.MaxFieldSize {
-		r.Message = "[message too large to log]"
-	} else if utils.ContainsSensitiveToken(r.Message) {
-		r.Message = "******** [REDACTED]"
-	}
+	r.Message = maskMessage(r.Message)
 
 	// 2. Create a new record to hold masked attributes
 	newRecord := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
@@ -135,9 +153,9 @@ func (h *MaskingHandler) Handle(ctx context.Context, r slog.Record) error {
 }
 
 func (h *MaskingHandler) maskAttr(a slog.Attr) slog.Attr {
-	// If key is sensitive, redact immediately
-	if utils.IsSensitiveKey(a.Key) {
-		return slog.String(a.Key, "******** [REDACTED]")
+	// If the key of a group is sensitive, redact the whole group
+	if utils.IsSensitiveKey(a.Key) && a.Value.Kind() == slog.KindGroup {
+		return slog.String(a.Key, redacted)
 	}
 
 	// For nested groups, mask recursively
@@ -150,9 +168,8 @@ func (h *MaskingHandler) maskAttr(a slog.Attr) slog.Attr {
 		return slog.Group(a.Key, anyToAnySlice(maskedGroup)...)
 	}
 
-	// Use your utils.MaskSensitive for everything else
-	maskedValue := utils.MaskSensitive(a.Value.Any())
-	return slog.Any(a.Key, maskedValue)
+	// Apply the strategy or redaction of the key, utils.MaskSensitive otherwise
+	return slog.Any(a.Key, maskField(a.Key, a.Value.Any()))
 }
 
 // Helper to convert Attrs for slog.Group
@@ -0,0 +1,168 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+)
+
+// defaultSinks are the sinks of the "tee" driver when log.sinks is empty.
+var defaultSinks = []config.LogSinkConfig{{Driver: "stdout"}, {Driver: "logrus"}}
+
+// teeLogger writes every entry to several loggers (sinks), each filtering it
+// with its own level.
+type teeLogger struct {
+	sinks []Logger
+}
+
+var (
+	_ Logger    = (*teeLogger)(nil)
+	_ Leveler   = (*teeLogger)(nil)
+	_ io.Closer = (*teeLogger)(nil)
+)
+
+// NewTee creates a logger writing to the sinks of log.sinks (stdout and the
+// log.path file when empty). Each sink is created like the log.driver of the
+// same name, at its level, or at log.level when it has none. NewForDomain
+// rejects the unknown levels; a "tee" sink is ignored.
+//
+// Example:
+//
+//	log:
+//	  driver: tee
+//	  sinks:
+//	    - { driver: stdout, level: debug }
+//	    - { driver: zerolog, level: warn }
+func NewTee(cfg *config.Config, trc tracer.Tracer) Logger {
+	sinkConfigs := cfg.Log.Sinks
+	if len(sinkConfigs) == 0 {
+		sinkConfigs = defaultSinks
+	}
+
+	sinks := make([]Logger, 0, len(sinkConfigs))
+	for _, s := range sinkConfigs {
+		if s.Driver == "tee" {
+			continue
+		}
+		sinkCfg := *cfg
+		sinkCfg.Log.Driver = s.Driver
+		sink := newDriver(&sinkCfg, trc)
+		if leveler, ok := sink.(Leveler); ok && s.Level != "" {
+			_ = leveler.SetLevel(s.Level)
+		}
+		sinks = append(sinks, sink)
+	}
+	return &teeLogger{sinks: sinks}
+}
+
+func (l *teeLogger) derive(derive func(Logger) Logger) Logger {
+	sinks := make([]Logger, len(l.sinks))
+	for i, s := range l.sinks {
+		sinks[i] = derive(s)
+	}
+	return &teeLogger{sinks: sinks}
+}
+
+func (l *teeLogger) WithContext(ctx context.Context) Logger {
+	return l.derive(func(s Logger) Logger { return s.WithContext(ctx) })
+}
+
+func (l *teeLogger) WithField(key string, value any) Logger {
+	return l.derive(func(s Logger) Logger { return s.WithField(key, value) })
+}
+
+func (l *teeLogger) WithFields(fields map[string]any) Logger {
+	return l.derive(func(s Logger) Logger { return s.WithFields(fields) })
+}
+
+// Level returns the level of the most verbose sink.
+func (l *teeLogger) Level() string {
+	current := len(levels)
+	for _, s := range l.sinks {
+		if leveler, ok := s.(Leveler); ok {
+			for i, lvl := range levels {
+				if lvl.name == leveler.Level() && i < current {
+					current = i
+				}
+			}
+		}
+	}
+	if current == len(levels) {
+		return ""
+	}
+	return levels[current].name
+}
+
+// SetLevel sets the level of every sink.
+func (l *teeLogger) SetLevel(name string) error {
+	if _, err := levelByName(name); err != nil {
+		return err
+	}
+	for _, s := range l.sinks {
+		if leveler, ok := s.(Leveler); ok {
+			_ = leveler.SetLevel(name)
+		}
+	}
+	return nil
+}
+
+// Close closes the sinks implementing io.Closer.
+func (l *teeLogger) Close() error {
+	var errs []error
+	for _, s := range l.sinks {
+		if closer, ok := s.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (l *teeLogger) Debug(msg string) {
+	for _, s := range l.sinks {
+		s.Debug(msg)
+	}
+}
+
+func (l *teeLogger) Info(msg string) {
+	for _, s := range l.sinks {
+		s.Info(msg)
+	}
+}
+
+func (l *teeLogger) Warn(msg string) {
+	for _, s := range l.sinks {
+		s.Warn(msg)
+	}
+}
+
+func (l *teeLogger) Error(msg string) {
+	for _, s := range l.sinks {
+		s.Error(msg)
+	}
+}
+
+// validateSinks checks the levels of log.sinks, that no sink is a tee, and
+// that a single sink writes to log.path.
+func validateSinks(sinks []config.LogSinkConfig) error {
+	files := 0
+	for i, s := range sinks {
+		switch s.Driver {
+		case "tee":
+			return fmt.Errorf("invalid log.sinks[%d].driver: a sink cannot be a tee", i)
+		case "logrus", "zap", "zerolog":
+			if files++; files > 1 {
+				return fmt.Errorf("invalid log.sinks[%d].driver: a single sink can write to log.path", i)
+			}
+		}
+		if s.Level == "" {
+			continue
+		}
+		if _, err := levelByName(s.Level); err != nil {
+			return fmt.Errorf("invalid log.sinks[%d].level: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/rs/zerolog"
+)
+
+// zerologLogger writes JSON lines like the Logrus logger, without allocating
+// for a message: the fields are masked and encoded once, when they are added.
+// Its entries name the message "message" (zerolog's default) instead of "msg".
+type zerologLogger struct {
+	log    zerolog.Logger
+	tracer tracer.Tracer
+	// level is shared by the derived loggers; zerolog copies the level of a
+	// logger into the loggers derived from it.
+	level *slog.LevelVar
+}
+
+var (
+	_ Logger  = (*zerologLogger)(nil)
+	_ Leveler = (*zerologLogger)(nil)
+)
+
+func NewZerolog(cfg *config.Config, trc tracer.Tracer) Logger {
+	level := new(slog.LevelVar)
+	level.Set(slogLevel(cfg.Log.Level))
+
+	return &zerologLogger{
+		log:    zerolog.New(rotatingFile(cfg)).With().Timestamp().Logger(),
+		tracer: trc,
+		level:  level,
+	}
+}
+
+func (l *zerologLogger) WithContext(ctx context.Context) Logger {
+	if ctx == nil {
+		return l
+	}
+
+	fields := l.log.With()
+	added := false
+	for _, f := range contextFields(ctx) {
+		fields = fields.Str(f.key, f.value)
+		added = true
+	}
+
+	if l.tracer != nil {
+		if traceID, spanID, ok := l.tracer.ExtractTraceInfo(ctx); ok {
+			fields = fields.Str("trace_id", traceID).Str("span_id", spanID)
+			added = true
+		}
+	}
+
+	if added {
+		return &zerologLogger{log: fields.Logger(), tracer: l.tracer, level: l.level}
+	}
+
+	return l
+}
+
+func (l *zerologLogger) WithField(key string, value any) Logger {
+	return &zerologLogger{
+		log:    l.log.With().Interface(key, maskField(key, value)).Logger(),
+		tracer: l.tracer,
+		level:  l.level,
+	}
+}
+
+func (l *zerologLogger) WithFields(fields map[string]any) Logger {
+	masked := make(map[string]any, len(fields))
+	for k, v := range fields {
+		masked[k] = maskField(k, v)
+	}
+	return &zerologLogger{log: l.log.With().Fields(masked).Logger(), tracer: l.tracer, level: l.level}
+}
+
+func (l *zerologLogger) Level() string {
+	return levelBySlog(l.level.Level()).name
+}
+
+func (l *zerologLogger) SetLevel(name string) error {
+	lvl, err := levelByName(name)
+	if err != nil {
+		return err
+	}
+	l.level.Set(lvl.slog)
+	return nil
+}
+
+func (l *zerologLogger) Debug(msg string) { l.write(slog.LevelDebug, zerolog.DebugLevel, msg) }
+func (l *zerologLogger) Info(msg string)  { l.write(slog.LevelInfo, zerolog.InfoLevel, msg) }
+func (l *zerologLogger) Warn(msg string)  { l.write(slog.LevelWarn, zerolog.WarnLevel, msg) }
+func (l *zerologLogger) Error(msg string) { l.write(slog.LevelError, zerolog.ErrorLevel, msg) }
+
+// write checks the level before masking the message.
+func (l *zerologLogger) write(lvl slog.Level, zlvl zerolog.Level, msg string) {
+	if lvl < l.level.Level() {
+		return
+	}
+	l.log.WithLevel(zlvl).Msg(maskMessage(msg))
+}
@@ -0,0 +1,134 @@
+// Package maintenance switches the API into maintenance mode: every request
+// is answered with 503 Service Unavailable (retryable), except the health
+// routes, the admin routes and the configured allowlist.
+//
+// The mode is forced by the configuration (maintenance.enabled) or toggled at
+// runtime through the admin routes (see package admin), without a restart. The runtime state is
+// kept in a Store; with the Redis store every instance follows the same state
+// within refresh_interval:
+//
+//	mode := maintenance.NewMode(cfg.Maintenance, maintenance.NewRedisStore(client, cfg.Maintenance.Key), log)
+//	app.Use(middleware.Maintenance(mode, cfg.Maintenance, admin.Path(cfg.Admin)))
+package maintenance
+
+import (
+	"cmp"
+	"context"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+)
+
+const (
+	defaultMessage         = "The service is under maintenance, retry later"
+	defaultRefreshInterval = 5 * time.Second
+)
+
+// State is the maintenance mode of the API.
+type State struct {
+	Enabled bool `json:"enabled"`
+	// Message is answered to rejected requests, the configured one when empty.
+	Message string `json:"message,omitempty"`
+	// Forced is set when the configuration enables the mode, whatever the
+	// runtime state.
+	Forced    bool      `json:"forced"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+}
+
+// Store keeps the state toggled at runtime.
+type Store interface {
+	// Get returns the stored state, the zero State when none was stored.
+	Get(ctx context.Context) (State, error)
+	Set(ctx context.Context, state State) error
+}
+
+// Mode reads and toggles the maintenance mode. The stored state is cached for
+// the refresh interval, so that requests do not query the store.
+type Mode struct {
+	forced  bool
+	message string
+	refresh time.Duration
+	store   Store
+	log     logger.Logger
+	now     func() time.Time
+
+	mu        sync.Mutex
+	state     State
+	fetchedAt time.Time
+}
+
+func NewMode(cfg config.MaintenanceConfig, store Store, log logger.Logger) *Mode {
+	m := &Mode{
+		forced:  cfg.Enabled,
+		message: cfg.Message,
+		refresh: time.Duration(cfg.RefreshInterval) * time.Second,
+		store:   store,
+		log:     log,
+		now:     time.Now,
+	}
+	if m.message == "" {
+		m.message = defaultMessage
+	}
+	if m.refresh <= 0 {
+		m.refresh = defaultRefreshInterval
+	}
+	return m
+}
+
+// State returns the current mode. When the store is unavailable, the last
+// known state is kept.
+func (m *Mode) State(ctx context.Context) State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if now := m.now(); m.fetchedAt.IsZero() || now.Sub(m.fetchedAt) >= m.refresh {
+		state, err := m.store.Get(ctx)
+		if err != nil {
+			m.log.WithContext(ctx).WithFields(map[string]any{
+				"component":    "maintenance",
+				"error_detail": err.Error(),
+			}).Warn("maintenance state unavailable, keeping the last known state")
+		} else {
+			m.state = state
+		}
+		m.fetchedAt = now
+	}
+	return m.effective(m.state)
+}
+
+// Set toggles the mode at runtime and returns the resulting state. A forced
+// mode stays enabled.
+func (m *Mode) Set(ctx context.Context, enabled bool, message string) (State, error) {
+	state := State{Enabled: enabled, Message: message, UpdatedAt: m.now().UTC()}
+	if err := m.store.Set(ctx, state); err != nil {
+		return State{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = state
+	m.fetchedAt = m.now()
+	return m.effective(state), nil
+}
+
+// Reload applies the forced mode and the message of cfg to the next
+// requests; the store and the refresh interval are not reloaded.
+func (m *Mode) Reload(cfg config.MaintenanceConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.forced = cfg.Enabled
+	m.message = cmp.Or(cfg.Message, defaultMessage)
+}
+
+// effective applies the configuration to a stored state.
+func (m *Mode) effective(state State) State {
+	if m.forced {
+		state.Enabled = true
+		state.Forced = true
+	}
+	if state.Message == "" {
+		state.Message = m.message
+	}
+	return state
+}
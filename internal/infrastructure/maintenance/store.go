@@ -0,0 +1,78 @@
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultKey = "maintenance"
+
+type memoryStore struct {
+	mu    sync.Mutex
+	state State
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a Store keeping the state in process memory: a
+// toggle only applies to the instance receiving it.
+func NewMemoryStore() Store {
+	return &memoryStore{}
+}
+
+func (s *memoryStore) Get(context.Context) (State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state, nil
+}
+
+func (s *memoryStore) Set(_ context.Context, state State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state
+	return nil
+}
+
+type redisStore struct {
+	client redis.Cmdable
+	key    string
+}
+
+var _ Store = (*redisStore)(nil)
+
+// NewRedisStore returns a Store keeping the state as JSON under key, shared
+// by every instance.
+func NewRedisStore(client redis.Cmdable, key string) Store {
+	if key == "" {
+		key = defaultKey
+	}
+	return &redisStore{client: client, key: key}
+}
+
+func (s *redisStore) Get(ctx context.Context) (State, error) {
+	raw, err := s.client.Get(ctx, s.key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, err
+	}
+
+	var state State
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return State{}, err
+	}
+	return state, nil
+}
+
+func (s *redisStore) Set(ctx context.Context, state State) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.key, raw, 0).Err()
+}
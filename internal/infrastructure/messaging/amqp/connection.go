@@ -0,0 +1,153 @@
+// Package amqp is the RabbitMQ driver of package messaging: a Publisher with
+// publisher confirms, and a Consumer of the topics registered on a
+// messaging.Router, on a Connection that reconnects on its own.
+//
+// A topic is a routing key of the amqp.exchange topic exchange. The consumer
+// of a topic declares its queues, "<group>.<topic>" being the queue bound to
+// the topic:
+//
+//	<group>.<topic>        bound to the topic, dead-letters to the .dlx exchange
+//	<group>.<topic>.retry  failed messages, back to the queue after retry_delay
+//	<group>.<topic>.dlq    messages failing max_attempts times (or Permanent),
+//	                       bound to the <exchange>.dlx exchange
+package amqp
+
+import (
+	"errors"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	defaultReconnectDelay = time.Second
+	maxReconnectDelay     = 30 * time.Second
+)
+
+// Connection is a connection to RabbitMQ, opened again when it is lost. The
+// publishers and consumers using it open their channels in OnConnect, so
+// that they are opened again on the new connection.
+type Connection struct {
+	url            string
+	reconnectDelay time.Duration
+	log            logger.Logger
+
+	mu        sync.Mutex
+	conn      *amqp.Connection
+	listeners []func(*amqp.Connection) error
+	closed    bool
+	done      chan struct{}
+}
+
+// Dial connects to the broker of cfg. It fails when the broker is
+// unreachable; later disconnections are retried until Close.
+func Dial(cfg *config.AMQPConfig, log logger.Logger) (*Connection, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("amqp url is required")
+	}
+	conn, err := amqp.Dial(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Connection{
+		url:            cfg.URL,
+		reconnectDelay: time.Duration(cfg.ReconnectDelay) * time.Millisecond,
+		log:            log.WithField("component", "amqp"),
+		conn:           conn,
+		done:           make(chan struct{}),
+	}
+	if c.reconnectDelay <= 0 {
+		c.reconnectDelay = defaultReconnectDelay
+	}
+	go c.watch(conn)
+	return c, nil
+}
+
+// OnConnect calls fn with the current connection, then with every new one.
+// An error of fn on a new connection closes it, to be opened again.
+func (c *Connection) OnConnect(fn func(*amqp.Connection) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return amqp.ErrClosed
+	}
+	if err := fn(c.conn); err != nil {
+		return err
+	}
+	c.listeners = append(c.listeners, fn)
+	return nil
+}
+
+// watch opens the connection again whenever it is lost.
+func (c *Connection) watch(conn *amqp.Connection) {
+	for {
+		reason, ok := <-conn.NotifyClose(make(chan *amqp.Error, 1))
+		if !ok || reason == nil {
+			return // closed by Close
+		}
+		c.log.WithFields(map[string]any{"error": reason.Error()}).Warn("amqp connection lost, reconnecting")
+
+		if conn = c.reconnect(); conn == nil {
+			return
+		}
+		c.log.Info("amqp connection restored")
+	}
+}
+
+// reconnect dials until it succeeds and the listeners accept the connection,
+// or Close is called (nil).
+func (c *Connection) reconnect() *amqp.Connection {
+	delay := c.reconnectDelay
+	for {
+		select {
+		case <-c.done:
+			return nil
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+
+		conn, err := amqp.Dial(c.url)
+		if err != nil {
+			c.log.WithFields(map[string]any{"error": err.Error()}).Warn("amqp reconnection failed")
+			continue
+		}
+
+		c.mu.Lock()
+		if c.closed {
+			c.mu.Unlock()
+			_ = conn.Close()
+			return nil
+		}
+		c.conn = conn
+		err = nil
+		for _, fn := range c.listeners {
+			if err = fn(conn); err != nil {
+				break
+			}
+		}
+		c.mu.Unlock()
+		if err != nil {
+			c.log.WithFields(map[string]any{"error": err.Error()}).Warn("amqp reconnection failed")
+			_ = conn.Close()
+			continue
+		}
+		return conn
+	}
+}
+
+// Close closes the connection and stops reconnecting. The channels of the
+// publishers and consumers close with it: stop the consumers first.
+func (c *Connection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	close(c.done)
+	return c.conn.Close()
+}
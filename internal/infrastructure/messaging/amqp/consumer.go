@@ -0,0 +1,307 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/messaging"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	defaultPrefetch    = 10
+	defaultConcurrency = 1
+	defaultMaxAttempts = 3
+	defaultRetryDelay  = 5 * time.Second
+
+	metricConsumeDuration = "amqp_consume_duration"
+)
+
+// Outcomes of a message (status tag of amqp_consume_duration).
+const (
+	statusOK         = "ok"
+	statusRetry      = "retry"
+	statusDeadLetter = "dead_letter"
+)
+
+// Consumer consumes the topics of a Router from the queues of its group.
+type Consumer struct {
+	conn      *Connection
+	router    *messaging.Router
+	publisher *Publisher
+	log       logger.Logger
+	tracer    tracer.Tracer
+	metrics   metrics.Metrics
+
+	exchange    string
+	group       string
+	prefetch    int
+	concurrency int
+	maxAttempts int
+	retryDelay  time.Duration
+
+	mu       sync.Mutex
+	ch       *amqp.Channel
+	stopping bool
+	wg       sync.WaitGroup
+}
+
+// NewConsumer creates a Consumer of router on conn. The failed messages are
+// sent to the retry queues with publisher. m may be nil.
+func NewConsumer(conn *Connection, cfg *config.AMQPConfig, router *messaging.Router, publisher *Publisher, log logger.Logger, trc tracer.Tracer, m metrics.Metrics) (*Consumer, error) {
+	if cfg.Consumer.Group == "" {
+		return nil, errors.New("a consumer group is required")
+	}
+	c := &Consumer{
+		conn:        conn,
+		router:      router,
+		publisher:   publisher,
+		log:         log.WithField("component", "amqp"),
+		tracer:      trc,
+		metrics:     m,
+		exchange:    exchangeOf(cfg),
+		group:       cfg.Consumer.Group,
+		prefetch:    cfg.Consumer.Prefetch,
+		concurrency: cfg.Consumer.Concurrency,
+		maxAttempts: cfg.Consumer.MaxAttempts,
+		retryDelay:  time.Duration(cfg.Consumer.RetryDelay) * time.Millisecond,
+	}
+	if c.prefetch <= 0 {
+		c.prefetch = defaultPrefetch
+	}
+	if c.concurrency <= 0 {
+		c.concurrency = defaultConcurrency
+	}
+	if c.maxAttempts <= 0 {
+		c.maxAttempts = defaultMaxAttempts
+	}
+	if c.retryDelay <= 0 {
+		c.retryDelay = defaultRetryDelay
+	}
+	return c, nil
+}
+
+// Queue returns the queue of topic in the group.
+func (c *Consumer) Queue(topic string) string {
+	return c.group + "." + topic
+}
+
+// Start declares the queues of the topics of the router and consumes them,
+// again after every reconnection, until Stop is called.
+func (c *Consumer) Start() error {
+	return c.conn.OnConnect(c.subscribe)
+}
+
+// subscribe consumes the queues on a new channel of conn.
+func (c *Consumer) subscribe(conn *amqp.Connection) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopping {
+		return nil
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	// The prefetch applies per consumer, i.e. per queue.
+	if err := ch.Qos(c.prefetch, 0, false); err != nil {
+		_ = ch.Close()
+		return err
+	}
+	for _, topic := range c.router.Topics() {
+		if err := c.declare(ch, topic); err != nil {
+			_ = ch.Close()
+			return fmt.Errorf("failed to declare the queues of %s: %w", topic, err)
+		}
+		deliveries, err := ch.Consume(c.Queue(topic), c.Queue(topic), false, false, false, false, nil)
+		if err != nil {
+			_ = ch.Close()
+			return fmt.Errorf("failed to consume %s: %w", c.Queue(topic), err)
+		}
+		h := c.router.Handler(topic)
+		for range c.concurrency {
+			c.wg.Add(1)
+			go c.work(topic, h, deliveries)
+		}
+	}
+	c.ch = ch
+	return nil
+}
+
+// declare declares the topology of topic: its queue bound to the exchange,
+// dead-lettering to its dead letter queue, and its retry queue, whose
+// messages expire back to the queue.
+func (c *Consumer) declare(ch *amqp.Channel, topic string) error {
+	queue := c.Queue(topic)
+	dlx := c.exchange + ".dlx"
+
+	if err := ch.ExchangeDeclare(c.exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		return err
+	}
+	if err := ch.ExchangeDeclare(dlx, amqp.ExchangeDirect, true, false, false, false, nil); err != nil {
+		return err
+	}
+
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, amqp.Table{
+		"x-dead-letter-exchange":    dlx,
+		"x-dead-letter-routing-key": queue,
+	}); err != nil {
+		return err
+	}
+	if err := ch.QueueBind(queue, topic, c.exchange, false, nil); err != nil {
+		return err
+	}
+
+	if _, err := ch.QueueDeclare(queue+".dlq", true, false, false, false, nil); err != nil {
+		return err
+	}
+	if err := ch.QueueBind(queue+".dlq", queue, dlx, false, nil); err != nil {
+		return err
+	}
+
+	_, err := ch.QueueDeclare(queue+".retry", true, false, false, false, amqp.Table{
+		"x-message-ttl":             c.retryDelay.Milliseconds(),
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": queue,
+	})
+	return err
+}
+
+// work handles the deliveries of topic until the channel closes, on Stop or
+// when the connection is lost (the unacknowledged messages are delivered
+// again then).
+func (c *Consumer) work(topic string, h messaging.Handler, deliveries <-chan amqp.Delivery) {
+	defer c.wg.Done()
+	for d := range deliveries {
+		c.mu.Lock()
+		stopping := c.stopping
+		c.mu.Unlock()
+		if stopping {
+			// Prefetched before the consumer was cancelled.
+			_ = d.Nack(false, true)
+			continue
+		}
+		c.process(topic, h, d)
+	}
+}
+
+// process handles d and settles it: acknowledged once handled or sent to the
+// retry queue, rejected to the dead letter exchange when its attempts are
+// exhausted or its error is permanent.
+func (c *Consumer) process(topic string, h messaging.Handler, d amqp.Delivery) {
+	start := time.Now()
+	msg := toMessage(d, topic)
+	attempt := attemptOf(msg)
+
+	// The handler runs to completion during a graceful stop.
+	ctx := c.tracer.Extract(context.Background(), msg.Headers)
+	span, ctx := c.tracer.StartSpan(ctx, "amqp.consume "+topic)
+	span.SetTag("messaging.system", "rabbitmq")
+	span.SetTag("messaging.destination", topic)
+	span.SetTag("messaging.rabbitmq.queue", c.Queue(topic))
+	span.SetTag("messaging.rabbitmq.attempt", attempt)
+	err := messaging.Handle(ctx, h, msg)
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.Finish()
+
+	status := statusOK
+	switch {
+	case err == nil:
+		c.settle(d.Ack(false), topic)
+	case attempt < c.maxAttempts && !messaging.IsPermanent(err):
+		status = statusRetry
+		if perr := c.retry(ctx, topic, msg, attempt, err); perr != nil {
+			c.log.WithFields(map[string]any{"topic": topic, "error": perr.Error()}).Error("failed to publish failed amqp message")
+			// Delivered again right away, without counting an attempt.
+			c.settle(d.Nack(false, true), topic)
+			break
+		}
+		c.settle(d.Ack(false), topic)
+	default:
+		status = statusDeadLetter
+		c.settle(d.Nack(false, false), topic)
+	}
+
+	if err != nil {
+		c.log.WithContext(ctx).WithFields(map[string]any{
+			"topic":        topic,
+			"queue":        c.Queue(topic),
+			"attempt":      attempt,
+			"status":       status,
+			"error_detail": err.Error(),
+		}).Error("amqp message handling failed")
+	}
+	if c.metrics != nil {
+		c.metrics.Timing(metricConsumeDuration, time.Since(start), []string{"topic:" + topic, "status:" + status})
+	}
+}
+
+// retry publishes msg to the retry queue of topic, with its next attempt
+// number and the error of this one.
+func (c *Consumer) retry(ctx context.Context, topic string, msg messaging.Message, attempt int, err error) error {
+	headers := make(messaging.Headers, len(msg.Headers)+3)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[messaging.HeaderAttempt] = strconv.Itoa(attempt + 1)
+	headers[messaging.HeaderOriginalTopic] = topic
+	headers[messaging.HeaderError] = err.Error()
+
+	// The default exchange routes to the queue named by the routing key.
+	retried := messaging.Message{Topic: c.Queue(topic) + ".retry", Key: msg.Key, Value: msg.Value, Headers: headers}
+	return c.publisher.publish(context.WithoutCancel(ctx), "", retried)
+}
+
+// settle logs the error of an acknowledgement: the message is delivered
+// again once the channel is closed.
+func (c *Consumer) settle(err error, topic string) {
+	if err != nil {
+		c.log.WithFields(map[string]any{"topic": topic, "error": err.Error()}).Warn("failed to settle amqp message")
+	}
+}
+
+// Stop cancels the consumers, waits for the messages being handled and
+// closes the channel. The messages prefetched but not handled yet are
+// requeued.
+func (c *Consumer) Stop() {
+	c.mu.Lock()
+	if c.stopping {
+		c.mu.Unlock()
+		return
+	}
+	c.stopping = true
+	ch := c.ch
+	if ch != nil {
+		for _, topic := range c.router.Topics() {
+			if err := ch.Cancel(c.Queue(topic), false); err != nil {
+				c.log.WithFields(map[string]any{"queue": c.Queue(topic), "error": err.Error()}).Warn("failed to cancel amqp consumer")
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	c.wg.Wait()
+	if ch != nil && !ch.IsClosed() {
+		_ = ch.Close()
+	}
+}
+
+// attemptOf returns the attempt number of msg, 1 for a first delivery.
+func attemptOf(msg messaging.Message) int {
+	n, err := strconv.Atoi(msg.Headers.Get(messaging.HeaderAttempt))
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
@@ -0,0 +1,190 @@
+package amqp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/messaging"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	defaultExchange       = "voyago"
+	defaultConfirmTimeout = 5 * time.Second
+)
+
+// ErrNotConnected is returned by Publish while the connection is being
+// opened again.
+var ErrNotConnected = errors.New("amqp: not connected")
+
+// Publisher is the messaging.Publisher of RabbitMQ. Its channel is in confirm
+// mode: Publish returns once the broker confirmed (persisted, for the durable
+// queues) every message. It propagates the trace context of the caller in
+// the headers of the messages.
+type Publisher struct {
+	exchange string
+	timeout  time.Duration
+	tracer   tracer.Tracer
+
+	mu sync.RWMutex
+	ch *amqp.Channel
+}
+
+var _ messaging.Publisher = (*Publisher)(nil)
+
+// NewPublisher opens a Publisher on conn, declaring the exchange of cfg.
+func NewPublisher(conn *Connection, cfg *config.AMQPConfig, trc tracer.Tracer) (*Publisher, error) {
+	p := &Publisher{
+		exchange: exchangeOf(cfg),
+		timeout:  time.Duration(cfg.ConfirmTimeout) * time.Millisecond,
+		tracer:   trc,
+	}
+	if p.timeout <= 0 {
+		p.timeout = defaultConfirmTimeout
+	}
+	if err := conn.OnConnect(p.open); err != nil {
+		return nil, fmt.Errorf("failed to open amqp publisher: %w", err)
+	}
+	return p, nil
+}
+
+// open opens the confirm channel of the publisher on conn.
+func (p *Publisher) open(conn *amqp.Connection) error {
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	if err := ch.Confirm(false); err != nil {
+		_ = ch.Close()
+		return err
+	}
+	if err := ch.ExchangeDeclare(p.exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		_ = ch.Close()
+		return err
+	}
+
+	p.mu.Lock()
+	p.ch = ch
+	p.mu.Unlock()
+	return nil
+}
+
+// Publish publishes msgs to the exchange, routed by their topic, and waits
+// for the broker to confirm them, up to amqp.confirm_timeout. A message
+// rejected (nack) by the broker fails the call: publishing it again may
+// duplicate the messages confirmed before it.
+func (p *Publisher) Publish(ctx context.Context, msgs ...messaging.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	span, ctx := p.tracer.StartSpan(ctx, "amqp.publish "+msgs[0].Topic)
+	defer span.Finish()
+	span.SetTag("messaging.system", "rabbitmq")
+	span.SetTag("messaging.destination", msgs[0].Topic)
+	span.SetTag("messaging.batch.message_count", len(msgs))
+
+	for i := range msgs {
+		if msgs[i].Headers == nil {
+			msgs[i].Headers = messaging.Headers{}
+		}
+		p.tracer.Inject(ctx, msgs[i].Headers)
+	}
+	if err := p.publish(ctx, p.exchange, msgs...); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// publish publishes msgs to exchange, routed by their topic, and waits for
+// their confirmations.
+func (p *Publisher) publish(ctx context.Context, exchange string, msgs ...messaging.Message) error {
+	p.mu.RLock()
+	ch := p.ch
+	p.mu.RUnlock()
+	if ch == nil || ch.IsClosed() {
+		return ErrNotConnected
+	}
+
+	confirms := make([]*amqp.DeferredConfirmation, 0, len(msgs))
+	for _, msg := range msgs {
+		dc, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, msg.Topic, false, false, toPublishing(msg))
+		if err != nil {
+			return fmt.Errorf("failed to publish to %s: %w", msg.Topic, err)
+		}
+		confirms = append(confirms, dc)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	for i, dc := range confirms {
+		acked, err := dc.WaitContext(ctx)
+		if err != nil {
+			return fmt.Errorf("no confirmation of the message to %s: %w", msgs[i].Topic, err)
+		}
+		if !acked {
+			return fmt.Errorf("message to %s rejected by the broker", msgs[i].Topic)
+		}
+	}
+	return nil
+}
+
+// Close closes the channel of the publisher.
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ch == nil || p.ch.IsClosed() {
+		return nil
+	}
+	return p.ch.Close()
+}
+
+// toPublishing returns the persistent AMQP message of msg. Its key, which
+// routes nothing in AMQP, is kept as the message ID.
+func toPublishing(msg messaging.Message) amqp.Publishing {
+	headers := make(amqp.Table, len(msg.Headers))
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	ts := msg.Time
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	return amqp.Publishing{
+		Headers:      headers,
+		ContentType:  msg.Headers.Get(messaging.HeaderContentType),
+		DeliveryMode: amqp.Persistent,
+		MessageId:    string(msg.Key),
+		Timestamp:    ts,
+		Body:         msg.Value,
+	}
+}
+
+// toMessage returns the message of a delivery of topic.
+func toMessage(d amqp.Delivery, topic string) messaging.Message {
+	headers := make(messaging.Headers, len(d.Headers))
+	for k, v := range d.Headers {
+		if s, ok := v.(string); ok {
+			headers[k] = s
+		}
+	}
+	return messaging.Message{
+		Topic:   topic,
+		Key:     []byte(d.MessageId),
+		Value:   d.Body,
+		Headers: headers,
+		Time:    d.Timestamp,
+	}
+}
+
+func exchangeOf(cfg *config.AMQPConfig) string {
+	if cfg.Exchange == "" {
+		return defaultExchange
+	}
+	return cfg.Exchange
+}
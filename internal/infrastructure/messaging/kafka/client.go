@@ -0,0 +1,124 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/messaging"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+const (
+	dialTimeout = 10 * time.Second
+	// batchTimeout bounds the wait of a Publish for more messages to batch
+	// with its own: Publish returns once its messages are acknowledged.
+	batchTimeout = 10 * time.Millisecond
+)
+
+// Client is the Transport of the brokers of the kafka section, on
+// segmentio/kafka-go. The readers commit the offsets synchronously, and the
+// writer waits for every in-sync replica and partitions by key.
+type Client struct {
+	brokers   []string
+	dialer    *kafkago.Dialer
+	transport *kafkago.Transport
+}
+
+var _ Transport = (*Client)(nil)
+
+// NewClient creates the Client of cfg. The brokers are dialed by the readers
+// and the writers, on their first message.
+func NewClient(cfg *config.KafkaConfig) (*Client, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafka brokers are required")
+	}
+	return &Client{
+		brokers: cfg.Brokers,
+		dialer:  &kafkago.Dialer{ClientID: cfg.ClientID, Timeout: dialTimeout, DualStack: true},
+		transport: &kafkago.Transport{
+			ClientID:    cfg.ClientID,
+			DialTimeout: dialTimeout,
+		},
+	}, nil
+}
+
+// NewReader reads topic in the consumer group groupID, from the first offset
+// of the partitions the group never committed.
+func (c *Client) NewReader(topic, groupID string) Reader {
+	return &reader{r: kafkago.NewReader(kafkago.ReaderConfig{
+		Brokers:     c.brokers,
+		GroupID:     groupID,
+		Topic:       topic,
+		Dialer:      c.dialer,
+		StartOffset: kafkago.FirstOffset,
+		// Commit synchronously: a committed message is never handled again.
+		CommitInterval: 0,
+	})}
+}
+
+// NewWriter writes the messages to the topic they name. The topics missing
+// are created when the brokers allow it (auto.create.topics.enable).
+func (c *Client) NewWriter() Writer {
+	return &writer{w: &kafkago.Writer{
+		Addr:                   kafkago.TCP(c.brokers...),
+		Balancer:               &kafkago.Hash{},
+		RequiredAcks:           kafkago.RequireAll,
+		BatchTimeout:           batchTimeout,
+		AllowAutoTopicCreation: true,
+		Transport:              c.transport,
+	}}
+}
+
+type reader struct {
+	r *kafkago.Reader
+}
+
+func (r *reader) Fetch(ctx context.Context) (messaging.Message, error) {
+	m, err := r.r.FetchMessage(ctx)
+	if err != nil {
+		return messaging.Message{}, err
+	}
+	headers := make(messaging.Headers, len(m.Headers))
+	for _, h := range m.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	return messaging.Message{
+		Topic:     m.Topic,
+		Key:       m.Key,
+		Value:     m.Value,
+		Headers:   headers,
+		Time:      m.Time,
+		Partition: m.Partition,
+		Offset:    m.Offset,
+	}, nil
+}
+
+func (r *reader) Commit(ctx context.Context, msg messaging.Message) error {
+	return r.r.CommitMessages(ctx, kafkago.Message{Topic: msg.Topic, Partition: msg.Partition, Offset: msg.Offset})
+}
+
+func (r *reader) Close() error {
+	return r.r.Close()
+}
+
+type writer struct {
+	w *kafkago.Writer
+}
+
+func (w *writer) Write(ctx context.Context, msgs ...messaging.Message) error {
+	out := make([]kafkago.Message, len(msgs))
+	for i, msg := range msgs {
+		headers := make([]kafkago.Header, 0, len(msg.Headers))
+		for k, v := range msg.Headers {
+			headers = append(headers, kafkago.Header{Key: k, Value: []byte(v)})
+		}
+		out[i] = kafkago.Message{Topic: msg.Topic, Key: msg.Key, Value: msg.Value, Headers: headers, Time: msg.Time}
+	}
+	return w.w.WriteMessages(ctx, out...)
+}
+
+func (w *writer) Close() error {
+	return w.w.Close()
+}
@@ -0,0 +1,48 @@
+// Package kafka is the Kafka driver of package messaging: a Producer, and a
+// Runner consuming the topics registered on a messaging.Router by the
+// modules, with a span per message, retry and dead letter topics, and a
+// graceful stop.
+//
+// The broker client is a Transport: a Client on segmentio/kafka-go, created
+// by the binary from the kafka section (see app.BootstrapHttpConfig.KafkaTransport).
+// The runner only relies on fetching and committing the messages of a
+// consumer group.
+//
+// A failed message is sent to the retry topic of its topic (e.g.,
+// "payments.retry") with its attempt number and the time it is due, and
+// handled again by the same handler; after kafka.consumer.max_attempts it is
+// sent to the dead letter topic ("payments.dlq") with the error. Either way
+// the offset is committed: a failing message never blocks its partition.
+package kafka
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/messaging"
+)
+
+// HeaderRetryAt is the RFC 3339 time before which a retried message is not
+// handled.
+const HeaderRetryAt = "x-retry-at"
+
+// Reader fetches the messages of a topic for a consumer group.
+type Reader interface {
+	// Fetch returns the next message, blocking until one is available or ctx
+	// is done.
+	Fetch(ctx context.Context) (messaging.Message, error)
+	// Commit marks msg, and the messages before it in its partition, as
+	// consumed by the group.
+	Commit(ctx context.Context, msg messaging.Message) error
+	Close() error
+}
+
+// Writer publishes messages.
+type Writer interface {
+	Write(ctx context.Context, msgs ...messaging.Message) error
+	Close() error
+}
+
+// Transport is the Kafka client the producer and the runner use.
+type Transport interface {
+	NewReader(topic, groupID string) Reader
+	NewWriter() Writer
+}
@@ -0,0 +1,50 @@
+package kafka
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/messaging"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+)
+
+// Producer is the messaging.Publisher of Kafka. It propagates the trace
+// context of the caller in the headers of the messages.
+type Producer struct {
+	writer Writer
+	tracer tracer.Tracer
+}
+
+var _ messaging.Publisher = (*Producer)(nil)
+
+// NewProducer creates a Producer on transport. It must be closed.
+func NewProducer(transport Transport, trc tracer.Tracer) *Producer {
+	return &Producer{writer: transport.NewWriter(), tracer: trc}
+}
+
+// Publish publishes msgs, returning once the brokers acknowledged them.
+func (p *Producer) Publish(ctx context.Context, msgs ...messaging.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	span, ctx := p.tracer.StartSpan(ctx, "kafka.produce "+msgs[0].Topic)
+	defer span.Finish()
+	span.SetTag("messaging.system", "kafka")
+	span.SetTag("messaging.destination", msgs[0].Topic)
+	span.SetTag("messaging.batch.message_count", len(msgs))
+
+	for i := range msgs {
+		if msgs[i].Headers == nil {
+			msgs[i].Headers = messaging.Headers{}
+		}
+		p.tracer.Inject(ctx, msgs[i].Headers)
+	}
+	if err := p.writer.Write(ctx, msgs...); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// Close flushes the pending messages and closes the writer.
+func (p *Producer) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,276 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/messaging"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+)
+
+const (
+	defaultMaxAttempts      = 3
+	defaultRetryBackoff     = time.Second
+	defaultMaxRetryBackoff  = time.Minute
+	defaultRetrySuffix      = ".retry"
+	defaultDeadLetterSuffix = ".dlq"
+
+	// fetchBackoff is the pause after a failed fetch or publish, before
+	// trying again.
+	fetchBackoff = time.Second
+
+	metricConsumeDuration = "kafka_consume_duration"
+)
+
+// Outcomes of a message (status tag of kafka_consume_duration).
+const (
+	statusOK         = "ok"
+	statusRetry      = "retry"
+	statusDeadLetter = "dead_letter"
+)
+
+// Runner consumes the topics of a Router in the consumer group of the
+// configuration. The messages of a topic are handled one at a time, in
+// order within a partition.
+type Runner struct {
+	router    *messaging.Router
+	transport Transport
+	producer  *Producer
+	log       logger.Logger
+	tracer    tracer.Tracer
+	metrics   metrics.Metrics
+
+	groupID          string
+	maxAttempts      int
+	retryBackoff     time.Duration
+	maxRetryBackoff  time.Duration
+	retrySuffix      string
+	deadLetterSuffix string
+
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewRunner creates a Runner of router. The failed messages are published
+// with producer. m may be nil.
+func NewRunner(cfg config.KafkaConsumerConfig, router *messaging.Router, transport Transport, producer *Producer, log logger.Logger, trc tracer.Tracer, m metrics.Metrics) (*Runner, error) {
+	if cfg.GroupID == "" {
+		return nil, errors.New("a consumer group_id is required")
+	}
+	r := &Runner{
+		router:           router,
+		transport:        transport,
+		producer:         producer,
+		log:              log.WithField("component", "kafka"),
+		tracer:           trc,
+		metrics:          m,
+		groupID:          cfg.GroupID,
+		maxAttempts:      cfg.MaxAttempts,
+		retryBackoff:     time.Duration(cfg.RetryBackoff) * time.Millisecond,
+		maxRetryBackoff:  time.Duration(cfg.MaxRetryBackoff) * time.Millisecond,
+		retrySuffix:      cfg.RetrySuffix,
+		deadLetterSuffix: cfg.DeadLetterSuffix,
+		stop:             make(chan struct{}),
+	}
+	if r.maxAttempts <= 0 {
+		r.maxAttempts = defaultMaxAttempts
+	}
+	if r.retryBackoff <= 0 {
+		r.retryBackoff = defaultRetryBackoff
+	}
+	if r.maxRetryBackoff <= 0 {
+		r.maxRetryBackoff = defaultMaxRetryBackoff
+	}
+	if r.retrySuffix == "" {
+		r.retrySuffix = defaultRetrySuffix
+	}
+	if r.deadLetterSuffix == "" {
+		r.deadLetterSuffix = defaultDeadLetterSuffix
+	}
+	return r, nil
+}
+
+// Start consumes every topic of the router, and its retry topic, until Stop
+// is called.
+func (r *Runner) Start() {
+	for _, topic := range r.router.Topics() {
+		r.consume(topic, topic)
+		if r.maxAttempts > 1 {
+			r.consume(topic+r.retrySuffix, topic)
+		}
+	}
+}
+
+// Stop stops fetching, waits for the messages being handled and closes the
+// readers. A message whose retry is not due yet is left uncommitted: it is
+// fetched again by the next consumer of the partition.
+func (r *Runner) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		r.wg.Wait()
+	})
+}
+
+// consume handles the messages of topic with the handler of origin (topic
+// itself, or the topic of the retry topic).
+func (r *Runner) consume(topic, origin string) {
+	reader := r.transport.NewReader(topic, r.groupID)
+	h := r.router.Handler(origin)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go func() {
+		<-r.stop
+		cancel()
+	}()
+	go func() {
+		defer r.wg.Done()
+		defer func() {
+			if err := reader.Close(); err != nil {
+				r.log.WithFields(map[string]any{"topic": topic, "error": err.Error()}).Warn("failed to close kafka reader")
+			}
+		}()
+
+		for {
+			msg, err := reader.Fetch(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				r.log.WithFields(map[string]any{"topic": topic, "error": err.Error()}).Error("failed to fetch kafka message")
+				r.sleep(ctx, fetchBackoff)
+				continue
+			}
+			if !r.waitUntilDue(ctx, msg) {
+				return
+			}
+			if !r.process(ctx, origin, msg, h) {
+				return
+			}
+			// The message is settled: it is committed even when stopping.
+			if err := reader.Commit(context.WithoutCancel(ctx), msg); err != nil {
+				r.log.WithFields(map[string]any{"topic": topic, "offset": msg.Offset, "error": err.Error()}).Error("failed to commit kafka message")
+			}
+		}
+	}()
+}
+
+// process handles msg and, when it fails, publishes it to the retry or the
+// dead letter topic. It returns false when the runner stopped before the
+// failed message could be published: it is not committed then.
+func (r *Runner) process(ctx context.Context, origin string, msg messaging.Message, h messaging.Handler) bool {
+	start := time.Now()
+	attempt := attemptOf(msg)
+
+	// The handler runs to completion during a graceful stop.
+	hctx := r.tracer.Extract(context.Background(), msg.Headers)
+	span, hctx := r.tracer.StartSpan(hctx, "kafka.consume "+origin)
+	span.SetTag("messaging.system", "kafka")
+	span.SetTag("messaging.destination", msg.Topic)
+	span.SetTag("messaging.kafka.partition", msg.Partition)
+	span.SetTag("messaging.kafka.offset", msg.Offset)
+	span.SetTag("messaging.kafka.attempt", attempt)
+	err := messaging.Handle(hctx, h, msg)
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.Finish()
+
+	status := statusOK
+	if err != nil {
+		var failed messaging.Message
+		failed, status = r.failed(origin, msg, attempt, err)
+		r.log.WithContext(hctx).WithFields(map[string]any{
+			"topic":        msg.Topic,
+			"partition":    msg.Partition,
+			"offset":       msg.Offset,
+			"attempt":      attempt,
+			"forwarded_to": failed.Topic,
+			"error_detail": err.Error(),
+		}).Error("kafka message handling failed")
+
+		for {
+			perr := r.producer.Publish(hctx, failed)
+			if perr == nil {
+				break
+			}
+			r.log.WithFields(map[string]any{"topic": failed.Topic, "error": perr.Error()}).Error("failed to publish failed kafka message")
+			if !r.sleep(ctx, fetchBackoff) {
+				return false
+			}
+		}
+	}
+
+	if r.metrics != nil {
+		r.metrics.Timing(metricConsumeDuration, time.Since(start), []string{"topic:" + origin, "status:" + status})
+	}
+	return true
+}
+
+// failed returns the message to publish for a failed attempt of msg, with its
+// status: to the retry topic while attempts remain, to the dead letter topic
+// otherwise.
+func (r *Runner) failed(origin string, msg messaging.Message, attempt int, err error) (messaging.Message, string) {
+	headers := make(messaging.Headers, len(msg.Headers)+4)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[messaging.HeaderOriginalTopic] = origin
+	headers[messaging.HeaderError] = err.Error()
+	delete(headers, HeaderRetryAt)
+
+	out := messaging.Message{Key: msg.Key, Value: msg.Value, Headers: headers}
+	if attempt >= r.maxAttempts || messaging.IsPermanent(err) {
+		out.Topic = origin + r.deadLetterSuffix
+		return out, statusDeadLetter
+	}
+
+	backoff := r.retryBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > r.maxRetryBackoff {
+		backoff = r.maxRetryBackoff
+	}
+	out.Topic = origin + r.retrySuffix
+	headers[messaging.HeaderAttempt] = strconv.Itoa(attempt + 1)
+	headers[HeaderRetryAt] = time.Now().Add(backoff).UTC().Format(time.RFC3339Nano)
+	return out, statusRetry
+}
+
+// waitUntilDue waits until the retry of msg is due. It returns false when the
+// runner stopped first.
+func (r *Runner) waitUntilDue(ctx context.Context, msg messaging.Message) bool {
+	at, err := time.Parse(time.RFC3339Nano, msg.Headers.Get(HeaderRetryAt))
+	if err != nil {
+		return true
+	}
+	return r.sleep(ctx, time.Until(at))
+}
+
+// sleep waits for d, returning false when ctx is done first.
+func (r *Runner) sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// attemptOf returns the attempt number of msg, 1 for a first delivery.
+func attemptOf(msg messaging.Message) int {
+	n, err := strconv.Atoi(msg.Headers.Get(messaging.HeaderAttempt))
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
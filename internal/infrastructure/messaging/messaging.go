@@ -0,0 +1,178 @@
+// Package messaging is the port of the message brokers: the modules publish
+// with a Publisher and register their handlers on a Router, whatever the
+// broker (see the kafka and amqp drivers).
+//
+// A failing handler has its message retried, then dead-lettered, by the
+// driver: handlers must be idempotent.
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/pkg/events"
+)
+
+// Headers set by the drivers on the retried and dead-lettered messages.
+const (
+	HeaderAttempt       = "x-attempt"        // attempt number of the retried message, from 2
+	HeaderOriginalTopic = "x-original-topic" // topic the message was first published to
+	HeaderError         = "x-error"          // error of the last attempt
+
+	HeaderContentType = "content-type"
+)
+
+// Message is a message of a topic: a Kafka topic, or an AMQP routing key.
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers Headers
+	Time    time.Time
+
+	// Partition and Offset locate a Kafka record.
+	Partition int
+	Offset    int64
+}
+
+// Headers are the headers of a message. They carry the trace context: they
+// are a tracer.Carrier and a tracer.Setter.
+type Headers map[string]string
+
+func (h Headers) Get(key string) string {
+	if v, ok := h[key]; ok {
+		return v
+	}
+	for k, v := range h {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+func (h Headers) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (h Headers) Set(key, value string) {
+	h[key] = value
+}
+
+// Publisher publishes messages, returning once the broker acknowledged them.
+type Publisher interface {
+	Publish(ctx context.Context, msgs ...Message) error
+}
+
+// PublishEvent publishes evt to topic as a CloudEvent encoded by enc, keyed
+// by its ID. EventHandler decodes it on the consumer side.
+func PublishEvent(ctx context.Context, p Publisher, enc *events.Encoder, topic string, evt eventbus.Event) error {
+	value, err := enc.Encode(ctx, evt)
+	if err != nil {
+		return err
+	}
+	return p.Publish(ctx, Message{
+		Topic:   topic,
+		Key:     []byte(evt.ID),
+		Value:   value,
+		Headers: Headers{HeaderContentType: events.ContentType},
+	})
+}
+
+// Handler processes a message. An error (or a panic) has the message retried,
+// then dead-lettered.
+type Handler func(ctx context.Context, msg Message) error
+
+// Router holds the handler of each consumed topic. The modules register their
+// handlers at bootstrap, like their HTTP routes.
+type Router struct {
+	handlers map[string]Handler
+}
+
+func NewRouter() *Router {
+	return &Router{handlers: map[string]Handler{}}
+}
+
+// Handle registers h for topic. It panics when the topic already has a
+// handler: a message is handled once per consumer group.
+func (r *Router) Handle(topic string, h Handler) {
+	if topic == "" || h == nil {
+		panic("messaging: Handle needs a topic and a handler")
+	}
+	if _, ok := r.handlers[topic]; ok {
+		panic(fmt.Errorf("messaging: topic %s handled twice", topic))
+	}
+	r.handlers[topic] = h
+}
+
+// Topics returns the topics with a handler, sorted.
+func (r *Router) Topics() []string {
+	topics := make([]string, 0, len(r.handlers))
+	for topic := range r.handlers {
+		topics = append(topics, topic)
+	}
+	slices.Sort(topics)
+	return topics
+}
+
+// Handler returns the handler of topic, nil when it has none.
+func (r *Router) Handler(topic string) Handler {
+	return r.handlers[topic]
+}
+
+// EventHandler adapts an event bus handler to the CloudEvents published by
+// PublishEvent: the same subscriber serves the bus and the brokers. The
+// payload is decoded JSON (a map), not the original struct; its schema
+// version is the one of the envelope.
+func EventHandler(h eventbus.Handler) Handler {
+	return func(ctx context.Context, msg Message) error {
+		env, err := events.Decode(msg.Value)
+		if err != nil {
+			return Permanent(err)
+		}
+		evt, err := env.Event()
+		if err != nil {
+			return Permanent(err)
+		}
+		return h(ctx, evt)
+	}
+}
+
+// permanentError fails a message without retrying it.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying (e.g., a message that does not
+// decode): the message is dead-lettered at once.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var permanent permanentError
+	return errors.As(err, &permanent)
+}
+
+// Handle calls h, turning a panic into an error.
+func Handle(ctx context.Context, h Handler, msg Message) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("handler panicked: %v", p)
+		}
+	}()
+	return h(ctx, msg)
+}
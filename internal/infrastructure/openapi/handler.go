@@ -0,0 +1,80 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/filesystem"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+const (
+	defaultSpecPath = "/openapi.json"
+	defaultUIPath   = "/docs"
+)
+
+// Mount serves the document at cfg.SpecPath and Swagger UI under cfg.UIPath.
+// Nothing is mounted when the docs are disabled.
+//
+// Mount must be called after the modules registered their operations: the
+// document is rendered on the first request.
+func Mount(app *fiber.App, cfg config.DocsConfig, spec *Spec) {
+	if !cfg.Enabled || spec == nil {
+		return
+	}
+
+	specPath := cfg.SpecPath
+	if specPath == "" {
+		specPath = defaultSpecPath
+	}
+	uiPath := strings.TrimSuffix(cfg.UIPath, "/")
+	if uiPath == "" {
+		uiPath = defaultUIPath
+	}
+
+	app.Get(specPath, func(c *fiber.Ctx) error {
+		doc, err := spec.JSON()
+		if err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.Send(doc)
+	})
+
+	// The bundled initializer points at the petstore example: replace it.
+	// Registered before the static files so that it takes precedence.
+	initializer := []byte(swaggerInitializer(specPath))
+	app.Get(uiPath+"/swagger-initializer.js", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "text/javascript; charset=utf-8")
+		return c.Send(initializer)
+	})
+
+	app.Get(uiPath, func(c *fiber.Ctx) error {
+		return c.Redirect(uiPath+"/index.html", fiber.StatusMovedPermanently)
+	})
+	app.Use(uiPath, filesystem.New(filesystem.Config{
+		Root: http.FS(swaggerFiles.FS),
+	}))
+}
+
+func swaggerInitializer(specPath string) string {
+	return `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: ` + strconv.Quote(specPath) + `,
+    dom_id: '#swagger-ui',
+    deepLinking: true,
+    presets: [
+      SwaggerUIBundle.presets.apis,
+      SwaggerUIStandalonePreset
+    ],
+    plugins: [
+      SwaggerUIBundle.plugins.DownloadUrl
+    ],
+    layout: "StandaloneLayout"
+  });
+};
+`
+}
@@ -0,0 +1,299 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Schema is the subset of the OpenAPI 3.0 Schema Object produced from DTOs.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaRegistry converts Go types into schemas. Named structs are emitted
+// once under components/schemas and referenced with $ref.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	types   map[reflect.Type]string
+	names   map[string]reflect.Type
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		types:   make(map[reflect.Type]string),
+		names:   make(map[string]reflect.Type),
+	}
+}
+
+// schemaOf returns the schema of t. Pointers are nullable.
+func (r *schemaRegistry) schemaOf(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		s := r.schemaOf(t.Elem())
+		if s.Ref != "" {
+			// Siblings of $ref are ignored in OpenAPI 3.0: wrap it.
+			return &Schema{AllOf: []*Schema{s}, Nullable: true}
+		}
+		s.Nullable = true
+		return s
+	}
+
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + r.register(t)}
+	}
+
+	// interface{} and other dynamic values accept anything.
+	return &Schema{}
+}
+
+// register emits the component schema of a named struct and returns its name.
+func (r *schemaRegistry) register(t reflect.Type) string {
+	if name, ok := r.types[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if other, taken := r.names[name]; taken && other != t {
+		name = qualifiedName(t)
+	}
+	r.types[t] = name
+	r.names[name] = t
+
+	// Registered before being built so that recursive types terminate.
+	r.schemas[name] = &Schema{}
+	*r.schemas[name] = *r.structSchema(t)
+	return name
+}
+
+// qualifiedName prefixes the type name with its module, e.g. the "usecase"
+// package of internal/modules/webhook gives "WebhookCreateRequest".
+func qualifiedName(t reflect.Type) string {
+	parts := strings.Split(t.PkgPath(), "/")
+	prefix := parts[len(parts)-1]
+	for i, p := range parts {
+		if p == "modules" && i+1 < len(parts) {
+			prefix = parts[i+1]
+			break
+		}
+	}
+	runes := []rune(prefix)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes) + t.Name()
+}
+
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, skip := jsonName(f)
+		if skip {
+			continue
+		}
+
+		// Embedded structs without a json name are flattened, like encoding/json does.
+		if f.Anonymous && f.Tag.Get("json") == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded := r.structSchema(ft)
+				for k, v := range embedded.Properties {
+					s.Properties[k] = v
+				}
+				s.Required = append(s.Required, embedded.Required...)
+				continue
+			}
+		}
+
+		prop, required := r.fieldSchema(f)
+		s.Properties[name] = prop
+		if required {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// fieldSchema builds the schema of a struct field, enriched with its
+// `validate` and `label` tags. It reports whether the field is required.
+func (r *schemaRegistry) fieldSchema(f reflect.StructField) (*Schema, bool) {
+	s := r.schemaOf(f.Type)
+	if s.Ref != "" || len(s.AllOf) > 0 {
+		// Constraints cannot be attached to a reference in OpenAPI 3.0.
+		return s, hasRule(f.Tag.Get("validate"), "required")
+	}
+
+	if label := f.Tag.Get("label"); label != "" {
+		s.Title = label
+	}
+
+	required := false
+	target := s
+	for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		if key == "dive" {
+			if target.Items == nil {
+				break
+			}
+			target = target.Items
+			continue
+		}
+		if key == "required" && target == s {
+			required = true
+		}
+		applyRule(target, key, value)
+	}
+	return s, required
+}
+
+// applyRule maps a go-playground/validator rule to schema constraints.
+// Unknown rules are ignored: the schema documents, it does not validate.
+func applyRule(s *Schema, key, value string) {
+	switch key {
+	case "uuid", "uuid4", "uuid_rfc4122", "uuid4_rfc4122":
+		s.Format = "uuid"
+	case "url", "http_url":
+		s.Format = "uri"
+	case "email":
+		s.Format = "email"
+	case "oneof":
+		for _, v := range strings.Fields(value) {
+			s.Enum = append(s.Enum, enumValue(s, v))
+		}
+	case "min", "max", "len":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return
+		}
+		switch s.Type {
+		case "string":
+			if key != "max" {
+				s.MinLength = &n
+			}
+			if key != "min" {
+				s.MaxLength = &n
+			}
+		case "array":
+			if key != "max" {
+				s.MinItems = &n
+			}
+			if key != "min" {
+				s.MaxItems = &n
+			}
+		case "integer", "number":
+			v := float64(n)
+			if key != "max" {
+				s.Minimum = &v
+			}
+			if key != "min" {
+				s.Maximum = &v
+			}
+		}
+	case "gt", "gte":
+		if v, err := strconv.ParseFloat(value, 64); err == nil && (s.Type == "integer" || s.Type == "number") {
+			s.Minimum = &v
+			s.ExclusiveMinimum = key == "gt"
+		}
+	case "lt", "lte":
+		if v, err := strconv.ParseFloat(value, 64); err == nil && (s.Type == "integer" || s.Type == "number") {
+			s.Maximum = &v
+			s.ExclusiveMaximum = key == "lt"
+		}
+	}
+}
+
+func enumValue(s *Schema, v string) any {
+	switch s.Type {
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	}
+	return v
+}
+
+func hasRule(validate, rule string) bool {
+	for _, r := range strings.Split(validate, ",") {
+		if r == rule {
+			return true
+		}
+		if r == "dive" {
+			return false
+		}
+	}
+	return false
+}
+
+// jsonName resolves the JSON property name of a field like encoding/json.
+func jsonName(f reflect.StructField) (name string, skip bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ = strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, false
+}
@@ -0,0 +1,369 @@
+// Package openapi builds an OpenAPI 3 document from the routes registered by
+// the modules and their DTO structs, and serves it with Swagger UI.
+//
+// Modules describe each route next to its Fiber registration, through their
+// API version group (see versioning.Group.Document):
+//
+//	bookings.Post("/", r.Handler.CreateBooking)
+//	v.Document(openapi.Operation{
+//		Method:   fiber.MethodPost,
+//		Path:     routeGroup + "/",
+//		Summary:  "Create a booking",
+//		Request:  usecase.CreateBookingRequest{},
+//		Response: usecase.CreateBookingResponse{},
+//		Status:   fiber.StatusCreated,
+//	})
+//
+// Request and response schemas are derived from the `json`, `validate` and
+// `label` struct tags, so the document stays in sync with the validation rules.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"voyago/core-api/internal/pkg/response"
+)
+
+const openAPIVersion = "3.0.3"
+
+// Info is the metadata of the API.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Operation describes a single route.
+type Operation struct {
+	// Method is the HTTP method (e.g., fiber.MethodPost).
+	Method string
+	// Path uses the Fiber syntax: "/bookings/:id" is documented as "/bookings/{id}".
+	Path        string
+	Summary     string
+	Description string
+	// Tags group operations in Swagger UI. Defaults to the first path segment.
+	Tags []string
+
+	// Request is a value of the JSON body DTO, nil when the route has no body.
+	Request any
+	// Query is a value of a DTO whose `query` tagged fields are query parameters.
+	Query any
+	// Response is a value of the type carried in response.Http.Data, nil when
+	// the response has no data.
+	Response any
+	// Status is the success status code. Defaults to 200.
+	Status int
+	// ContentType is the media type of a success response that is not the
+	// JSON envelope (e.g., "text/event-stream"). Response is ignored when set.
+	ContentType string
+	// Errors lists the documented error status codes (e.g., 400, 404, 409).
+	Errors []int
+	// Deprecated flags operations clients should migrate away from.
+	Deprecated bool
+}
+
+// Spec collects operations and renders them as an OpenAPI document.
+// A nil *Spec is valid and ignores every operation, so documentation stays
+// optional for callers (e.g., tests) that do not need it.
+type Spec struct {
+	info Info
+	// problemErrors documents error responses as RFC 7807 problems.
+	problemErrors bool
+
+	mu  sync.Mutex
+	ops []Operation
+	doc []byte
+}
+
+func New(info Info) *Spec {
+	return &Spec{info: info}
+}
+
+// UseProblemErrors documents error responses as application/problem+json
+// (response.Problem) instead of the standard envelope, matching a server
+// running in the "problem" error format.
+func (s *Spec) UseProblemErrors() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.problemErrors = true
+	s.doc = nil
+}
+
+// Add registers operations. It must be called before the document is first served.
+func (s *Spec) Add(ops ...Operation) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ops = append(s.ops, ops...)
+	s.doc = nil
+}
+
+// JSON returns the rendered document. It is built once and cached until the
+// next call to Add.
+func (s *Spec) JSON() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.doc != nil {
+		return s.doc, nil
+	}
+	doc, err := json.Marshal(s.build())
+	if err != nil {
+		return nil, err
+	}
+	s.doc = doc
+	return doc, nil
+}
+
+// -------- Document model --------
+
+type document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]operation `json:"paths"`
+	Components components                      `json:"components"`
+}
+
+type components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type operation struct {
+	Tags        []string             `json:"tags,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	OperationID string               `json:"operationId"`
+	Parameters  []parameter          `json:"parameters,omitempty"`
+	RequestBody *requestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]apiResult `json:"responses"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type apiResult struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// envelopeSchema is the component name of response.Http.
+const envelopeSchema = "Response"
+
+// problemSchema is the component name of response.Problem.
+const problemSchema = "Problem"
+
+func (s *Spec) build() document {
+	reg := newSchemaRegistry()
+
+	// The standard envelope is always documented, under a stable name.
+	envelope := reflect.TypeOf(response.Http{})
+	reg.types[envelope] = envelopeSchema
+	reg.names[envelopeSchema] = envelope
+	reg.schemas[envelopeSchema] = reg.structSchema(envelope)
+
+	errorContent := jsonContent(&Schema{Ref: "#/components/schemas/" + envelopeSchema})
+	if s.problemErrors {
+		problem := reflect.TypeOf(response.Problem{})
+		reg.types[problem] = problemSchema
+		reg.names[problemSchema] = problem
+		reg.schemas[problemSchema] = reg.structSchema(problem)
+		errorContent = map[string]mediaType{
+			response.ContentTypeProblem: {Schema: &Schema{Ref: "#/components/schemas/" + problemSchema}},
+		}
+	}
+
+	doc := document{
+		OpenAPI: openAPIVersion,
+		Info:    s.info,
+		Paths:   make(map[string]map[string]operation),
+	}
+
+	for _, op := range s.ops {
+		path, pathParams := convertPath(op.Path)
+		method := strings.ToLower(op.Method)
+
+		o := operation{
+			Tags:        op.Tags,
+			Summary:     op.Summary,
+			Description: op.Description,
+			OperationID: operationID(op.Method, path),
+			Responses:   make(map[string]apiResult),
+			Deprecated:  op.Deprecated,
+		}
+		if len(o.Tags) == 0 {
+			if tag := firstSegment(path); tag != "" {
+				o.Tags = []string{tag}
+			}
+		}
+
+		for _, name := range pathParams {
+			o.Parameters = append(o.Parameters, parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+		if op.Query != nil {
+			o.Parameters = append(o.Parameters, queryParameters(reg, indirect(reflect.TypeOf(op.Query)))...)
+		}
+
+		if op.Request != nil {
+			o.RequestBody = &requestBody{
+				Required: true,
+				Content:  map[string]mediaType{"application/json": {Schema: reg.schemaOf(indirect(reflect.TypeOf(op.Request)))}},
+			}
+		}
+
+		status := op.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if op.ContentType != "" {
+			o.Responses[strconv.Itoa(status)] = apiResult{
+				Description: http.StatusText(status),
+				Content:     map[string]mediaType{op.ContentType: {Schema: &Schema{Type: "string"}}},
+			}
+		} else {
+			o.Responses[strconv.Itoa(status)] = successResult(reg, status, op.Response)
+		}
+		for _, code := range op.Errors {
+			o.Responses[strconv.Itoa(code)] = apiResult{
+				Description: http.StatusText(code),
+				Content:     errorContent,
+			}
+		}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]operation)
+		}
+		doc.Paths[path][method] = o
+	}
+
+	doc.Components.Schemas = reg.schemas
+	return doc
+}
+
+func successResult(reg *schemaRegistry, status int, data any) apiResult {
+	if status == http.StatusNoContent || status == http.StatusSwitchingProtocols {
+		return apiResult{Description: http.StatusText(status)}
+	}
+
+	envelope := &Schema{Ref: "#/components/schemas/" + envelopeSchema}
+	if data == nil {
+		return apiResult{Description: http.StatusText(status), Content: jsonContent(envelope)}
+	}
+	return apiResult{
+		Description: http.StatusText(status),
+		Content: jsonContent(&Schema{AllOf: []*Schema{
+			envelope,
+			{Type: "object", Properties: map[string]*Schema{"data": reg.schemaOf(indirect(reflect.TypeOf(data)))}},
+		}}),
+	}
+}
+
+// indirect lets operations be described with values or pointers alike.
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func jsonContent(s *Schema) map[string]mediaType {
+	return map[string]mediaType{"application/json": {Schema: s}}
+}
+
+// queryParameters documents the `query` tagged fields of a DTO.
+func queryParameters(reg *schemaRegistry, t reflect.Type) []parameter {
+	var params []parameter
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("query"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		schema, required := reg.fieldSchema(f)
+		params = append(params, parameter{Name: name, In: "query", Required: required, Schema: schema})
+	}
+	return params
+}
+
+// convertPath turns a Fiber path into an OpenAPI path and lists its parameters.
+// A trailing slash is dropped (Fiber is not strict about it by default).
+func convertPath(path string) (string, []string) {
+	var params []string
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") {
+			name := strings.TrimSuffix(strings.TrimPrefix(seg, ":"), "?")
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+
+	out := strings.Join(segments, "/")
+	if len(out) > 1 {
+		out = strings.TrimSuffix(out, "/")
+	}
+	return out, params
+}
+
+// operationID derives a stable identifier, e.g. "post_bookings_id_payment-status".
+func operationID(method, path string) string {
+	parts := []string{strings.ToLower(method)}
+	for _, seg := range strings.Split(path, "/") {
+		seg = strings.Trim(seg, "{}")
+		if seg != "" {
+			parts = append(parts, seg)
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+func firstSegment(path string) string {
+	seg, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if strings.HasPrefix(seg, "{") {
+		return ""
+	}
+	return seg
+}
+
+// Paths returns the documented paths, sorted. It is mainly useful in tests.
+func (s *Spec) Paths() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool)
+	var paths []string
+	for _, op := range s.ops {
+		p, _ := convertPath(op.Path)
+		if !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
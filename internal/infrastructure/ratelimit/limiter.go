@@ -0,0 +1,160 @@
+// Package ratelimit throttles clients with a sliding-window counter kept in
+// Redis, so that every instance of the service shares the same budget.
+//
+// Rules come from the rate_limit configuration: a default rule and route
+// rules matched on the request method and path prefix. The HTTP side is the
+// middleware.RateLimit middleware:
+//
+//	store := ratelimit.NewRedisStore(redisClient, cfg.RateLimit.KeyPrefix)
+//	limiter, err := ratelimit.NewLimiter(cfg.RateLimit, store)
+//	app.Use(middleware.RateLimit(limiter, trustedProxies, log, metrics))
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+)
+
+const defaultRuleName = "default"
+
+// Result is the outcome of counting one request.
+type Result struct {
+	Allowed bool
+	// Limit is the number of requests allowed per window.
+	Limit int
+	// Remaining is the number of requests still allowed in the current window.
+	Remaining int
+	// Reset is the time until the oldest counted request leaves the window,
+	// i.e. until one more request is allowed when Allowed is false.
+	Reset time.Duration
+}
+
+// Store counts requests per key over a sliding window.
+type Store interface {
+	// Allow counts a request for key and reports whether it fits in limit
+	// requests per window. Rejected requests are not counted.
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (Result, error)
+}
+
+// Rule is the budget applied to a request.
+type Rule struct {
+	// Name identifies the rule in keys and metrics (e.g., "default", "POST /api/v1/bookings").
+	Name   string
+	Limit  int
+	Window time.Duration
+	KeyBy  string
+}
+
+// Enabled reports whether requests matched by the rule are limited.
+func (r Rule) Enabled() bool {
+	return r.Limit > 0 && r.Window > 0
+}
+
+type route struct {
+	Rule
+	method string
+	path   string
+}
+
+// Limiter applies the configured rules on a Store. The rules may be
+// replaced while requests are counted (see Reload).
+type Limiter struct {
+	store Store
+	rules atomic.Pointer[rules]
+}
+
+// rules are the rules of a configuration.
+type rules struct {
+	fallback Rule
+	// routes are sorted by decreasing path length: the first match is the longest.
+	routes []route
+}
+
+// NewLimiter validates cfg and returns the limiter applying it on store.
+func NewLimiter(cfg config.RateLimitConfig, store Store) (*Limiter, error) {
+	l := &Limiter{store: store}
+	if err := l.Reload(cfg); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Reload validates the rules of cfg (default and routes) and applies them to
+// the next requests. The counters of the rules are kept; the store is not
+// reloaded.
+func (l *Limiter) Reload(cfg config.RateLimitConfig) error {
+	fallback, err := newRule(defaultRuleName, cfg.Default)
+	if err != nil {
+		return err
+	}
+	r := &rules{fallback: fallback}
+
+	for i, rc := range cfg.Routes {
+		if !strings.HasPrefix(rc.Path, "/") {
+			return fmt.Errorf("rate limit route %d: path %q must start with /", i, rc.Path)
+		}
+		method := strings.ToUpper(rc.Method)
+		name := strings.TrimSpace(method + " " + rc.Path)
+		rule, err := newRule(name, rc.RateLimitRuleConfig)
+		if err != nil {
+			return err
+		}
+		r.routes = append(r.routes, route{Rule: rule, method: method, path: strings.TrimSuffix(rc.Path, "/")})
+	}
+	sort.SliceStable(r.routes, func(i, j int) bool {
+		return len(r.routes[i].path) > len(r.routes[j].path)
+	})
+
+	l.rules.Store(r)
+	return nil
+}
+
+func newRule(name string, rc config.RateLimitRuleConfig) (Rule, error) {
+	if rc.Limit < 0 || rc.Window < 0 {
+		return Rule{}, fmt.Errorf("rate limit rule %q: limit and window must not be negative", name)
+	}
+	if rc.Limit > 0 && rc.Window == 0 {
+		return Rule{}, fmt.Errorf("rate limit rule %q: window is required", name)
+	}
+
+	keyBy := rc.KeyBy
+	switch keyBy {
+	case "":
+		keyBy = config.RateLimitKeyByIP
+	case config.RateLimitKeyByIP, config.RateLimitKeyByAPIKey:
+	default:
+		return Rule{}, fmt.Errorf("rate limit rule %q: unknown key_by %q", name, rc.KeyBy)
+	}
+
+	return Rule{
+		Name:   name,
+		Limit:  rc.Limit,
+		Window: time.Duration(rc.Window) * time.Second,
+		KeyBy:  keyBy,
+	}, nil
+}
+
+// Match returns the rule of a request: the route rule with the longest path
+// prefix matching method and path, or the default rule.
+func (l *Limiter) Match(method, path string) Rule {
+	rules := l.rules.Load()
+	for _, r := range rules.routes {
+		if r.method != "" && r.method != method {
+			continue
+		}
+		if path == r.path || strings.HasPrefix(path, r.path+"/") {
+			return r.Rule
+		}
+	}
+	return rules.fallback
+}
+
+// Allow counts a request of the client identified by identity under rule.
+func (l *Limiter) Allow(ctx context.Context, rule Rule, identity string) (Result, error) {
+	return l.store.Allow(ctx, rule.Name+":"+identity, rule.Limit, rule.Window)
+}
@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepEvery is the number of requests between two removals of idle keys.
+const sweepEvery = 1024
+
+type memoryStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	calls   int
+}
+
+// bucket holds the counted requests of a key, oldest first.
+type bucket struct {
+	hits   []time.Time
+	window time.Duration
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a Store keeping its counters in process memory. The
+// budget is per instance: use it for local development and tests only.
+func NewMemoryStore() Store {
+	return &memoryStore{now: time.Now, buckets: make(map[string]*bucket)}
+}
+
+func (s *memoryStore) Allow(_ context.Context, key string, limit int, window time.Duration) (Result, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+	if s.calls%sweepEvery == 0 {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{}
+		s.buckets[key] = b
+	}
+	b.window = window
+	b.hits = prune(b.hits, now.Add(-window))
+
+	allowed := len(b.hits) < limit
+	if allowed {
+		b.hits = append(b.hits, now)
+	}
+
+	reset := window
+	if len(b.hits) > 0 {
+		reset = b.hits[0].Add(window).Sub(now)
+	} else {
+		delete(s.buckets, key)
+	}
+	return Result{
+		Allowed:   allowed,
+		Limit:     limit,
+		Remaining: limit - len(b.hits),
+		Reset:     reset,
+	}, nil
+}
+
+// sweep drops the keys without a request in their last window.
+func (s *memoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if len(b.hits) == 0 || !b.hits[len(b.hits)-1].After(now.Add(-b.window)) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// prune drops the hits at or before since; hits are sorted.
+func prune(hits []time.Time, since time.Time) []time.Time {
+	i := 0
+	for i < len(hits) && !hits[i].After(since) {
+		i++
+	}
+	return hits[i:]
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// slidingWindow keeps the timestamps (ms) of the counted requests of a key in
+// a sorted set. It atomically drops the ones older than the window, counts a
+// new request when the limit allows it, and returns
+// {allowed, remaining, reset_ms}.
+var slidingWindow = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', key, window)
+
+local reset = window
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+return {allowed, limit - count, reset}
+`)
+
+type redisStore struct {
+	client redis.Scripter
+	prefix string
+	now    func() time.Time
+}
+
+var _ Store = (*redisStore)(nil)
+
+// NewRedisStore returns a Store keeping its counters in Redis under prefix.
+// Timestamps come from the local clock: instances must be NTP-synchronized.
+func NewRedisStore(client redis.Scripter, prefix string) Store {
+	return &redisStore{client: client, prefix: prefix, now: time.Now}
+}
+
+func (s *redisStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (Result, error) {
+	now := s.now()
+	// Requests of the same millisecond need distinct members.
+	member := strconv.FormatInt(now.UnixNano(), 10) + "-" + strconv.FormatUint(rand.Uint64(), 36)
+
+	res, err := slidingWindow.Run(ctx, s.client, []string{s.prefix + key},
+		now.UnixMilli(), window.Milliseconds(), limit, member).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("rate limit %q: %w", key, err)
+	}
+	if len(res) != 3 {
+		return Result{}, fmt.Errorf("rate limit %q: unexpected script result %v", key, res)
+	}
+
+	return Result{
+		Allowed:   res[0] == 1,
+		Limit:     limit,
+		Remaining: int(res[1]),
+		Reset:     time.Duration(res[2]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,299 @@
+// Package reload applies the edits of the configuration files to the running
+// process, without a restart. Only the settings safe to change while serving
+// are reloaded: the log levels, the trace sampling, the rate limits, the
+// maintenance mode and the feature flags of the configuration file. The other
+// changes are logged and wait for the next restart.
+//
+// The components subscribe to the settings they apply with OnChange; every
+// applied change is logged and recorded in the audit log of the domains (see
+// package audit), by the "system" actor.
+//
+// Example:
+//
+//	r := reload.New(reload.Config{GlobalPath: "config/config.yaml", Current: cfg, Log: log})
+//	r.OnChange("rate_limit.routes", func(_ context.Context, _ reload.Change, cfg *config.Config) error {
+//		return limiter.Reload(cfg.RateLimit)
+//	})
+//	if err := r.Start(); err != nil { ... }
+//	defer r.Stop()
+package reload
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/audit"
+)
+
+// Main is the domain of the changes of the global configuration file.
+const Main = "main"
+
+const (
+	// AuditAction is the action of the audit entries of the applied changes.
+	AuditAction = "config.reload"
+	// AuditEntityType is their entity type, the entity ID being the path of
+	// the setting.
+	AuditEntityType = "config"
+
+	defaultDebounce = 500 * time.Millisecond
+)
+
+// Change is a reloaded setting.
+type Change struct {
+	// Domain is the domain whose configuration changed, Main for the global
+	// file. The domain configurations merge the global file: a global change
+	// is reported for every domain not overriding the setting.
+	Domain string
+	// Path is the YAML path of the setting, e.g. "log.level".
+	Path string
+	From any
+	To   any
+}
+
+// Hook applies a change; cfg is the new configuration of c.Domain. A failing
+// hook leaves the change unapplied: it is logged and not audited.
+type Hook func(ctx context.Context, c Change, cfg *config.Config) error
+
+// setting is a reloadable setting.
+type setting struct {
+	path string
+	// perDomain settings are compared in the domain configurations too; the
+	// others are applied once, from the global file.
+	perDomain bool
+	get       func(*config.Config) any
+	clear     func(*config.Config)
+}
+
+// settings are the reloadable settings, in the order their hooks run.
+var settings = []setting{
+	{"log.level", true,
+		func(c *config.Config) any { return c.Log.Level },
+		func(c *config.Config) { c.Log.Level = 0 }},
+	{"log.levels", true,
+		func(c *config.Config) any { return c.Log.Levels },
+		func(c *config.Config) { c.Log.Levels = nil }},
+	{"telemetry.sample_rate", false,
+		func(c *config.Config) any { return c.Telemetry.SampleRate },
+		func(c *config.Config) { c.Telemetry.SampleRate = 0 }},
+	{"telemetry.sampling.rules", false,
+		func(c *config.Config) any { return c.Telemetry.Sampling.Rules },
+		func(c *config.Config) { c.Telemetry.Sampling.Rules = nil }},
+	{"rate_limit.default", false,
+		func(c *config.Config) any { return c.RateLimit.Default },
+		func(c *config.Config) { c.RateLimit.Default = config.RateLimitRuleConfig{} }},
+	{"rate_limit.routes", false,
+		func(c *config.Config) any { return c.RateLimit.Routes },
+		func(c *config.Config) { c.RateLimit.Routes = nil }},
+	{"maintenance.enabled", false,
+		func(c *config.Config) any { return c.Maintenance.Enabled },
+		func(c *config.Config) { c.Maintenance.Enabled = false }},
+	{"maintenance.message", false,
+		func(c *config.Config) any { return c.Maintenance.Message },
+		func(c *config.Config) { c.Maintenance.Message = "" }},
+	{"feature_flags.flags", false,
+		func(c *config.Config) any { return c.FeatureFlags.Flags },
+		func(c *config.Config) { c.FeatureFlags.Flags = nil }},
+}
+
+// Paths returns the paths of the reloadable settings.
+func Paths() []string {
+	paths := make([]string, len(settings))
+	for i, s := range settings {
+		paths[i] = s.path
+	}
+	return paths
+}
+
+// Config configures a Reloader.
+type Config struct {
+	// GlobalPath is the global configuration file (see config.LoadGlobalConfig).
+	GlobalPath string
+	// Domains are the configuration files of the domains, by domain (see
+	// config.ReadDomainConfig).
+	Domains map[string]string
+	// Current are the configurations in use, by domain, Main included; the
+	// changes are computed against them.
+	Current map[string]*config.Config
+	Log     logger.Logger
+	// Audit records the applied changes, by domain. The changes of the
+	// global file are recorded by every domain.
+	Audit map[string]audit.Recorder
+	// Debounce is how long the files must stay unchanged before they are
+	// reloaded, 500ms when not positive.
+	Debounce time.Duration
+}
+
+// Reloader reloads the configuration files and runs the hooks of the changed
+// settings.
+type Reloader struct {
+	cfg Config
+	log logger.Logger
+
+	mu      sync.Mutex
+	current map[string]*config.Config
+	hooks   map[string][]Hook
+
+	watcher *watcher
+}
+
+// New creates a Reloader of cfg. The files are reloaded by Reload, or on
+// every write once Start is called.
+func New(cfg Config) *Reloader {
+	if cfg.Debounce <= 0 {
+		cfg.Debounce = defaultDebounce
+	}
+	log := cfg.Log
+	if log == nil {
+		log = logger.NewNoOpLogger()
+	}
+	current := make(map[string]*config.Config, len(cfg.Current))
+	for domain, c := range cfg.Current {
+		current[domain] = c
+	}
+	return &Reloader{
+		cfg:     cfg,
+		log:     log.WithField("component", "config-reload"),
+		current: current,
+		hooks:   map[string][]Hook{},
+	}
+}
+
+// OnChange registers hook for the changes of the setting path, one of Paths.
+// It panics on another path: the hooks are registered at startup.
+func (r *Reloader) OnChange(path string, hook Hook) {
+	if !slices.Contains(Paths(), path) {
+		panic(fmt.Errorf("reload: %q is not a reloadable setting", path))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks[path] = append(r.hooks[path], hook)
+}
+
+// Reload reads the configuration files and applies the changes of the
+// reloadable settings. An invalid file leaves every setting unchanged, until
+// it is fixed. It returns the applied changes.
+func (r *Reloader) Reload(ctx context.Context) ([]Change, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, err := r.read()
+	if err != nil {
+		r.log.WithField("error", err.Error()).Error("configuration not reloaded, the current settings are kept")
+		return nil, err
+	}
+
+	var applied []Change
+	for _, domain := range r.domains() {
+		prev, cfg := r.current[domain], next[domain]
+		if prev == nil || cfg == nil {
+			continue
+		}
+		for _, s := range settings {
+			if !s.perDomain && domain != Main {
+				continue
+			}
+			from, to := s.get(prev), s.get(cfg)
+			if reflect.DeepEqual(from, to) {
+				continue
+			}
+			c := Change{Domain: domain, Path: s.path, From: from, To: to}
+			if r.apply(ctx, c, cfg) {
+				applied = append(applied, c)
+			}
+		}
+		if restartNeeded(prev, cfg) {
+			r.log.WithField("domain", domain).
+				Warn("configuration changed outside the reloadable settings, restart to apply it")
+		}
+	}
+	r.current = next
+	return applied, nil
+}
+
+// read loads every configuration file.
+func (r *Reloader) read() (map[string]*config.Config, error) {
+	next := make(map[string]*config.Config, len(r.cfg.Domains)+1)
+	global, err := config.LoadGlobalConfig(r.cfg.GlobalPath)
+	if err != nil {
+		return nil, err
+	}
+	next[Main] = global
+	for domain, path := range r.cfg.Domains {
+		cfg, err := config.ReadDomainConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		next[domain] = cfg
+	}
+	return next, nil
+}
+
+// domains returns the domains of the current configurations, Main first.
+func (r *Reloader) domains() []string {
+	domains := make([]string, 0, len(r.current))
+	for domain := range r.current {
+		if domain != Main {
+			domains = append(domains, domain)
+		}
+	}
+	slices.Sort(domains)
+	return append([]string{Main}, domains...)
+}
+
+// apply runs the hooks of c, then logs and audits it. It reports whether
+// every hook succeeded.
+func (r *Reloader) apply(ctx context.Context, c Change, cfg *config.Config) bool {
+	log := r.log.WithFields(map[string]any{
+		"domain":  c.Domain,
+		"setting": c.Path,
+		"from":    c.From,
+		"to":      c.To,
+	})
+	for _, hook := range r.hooks[c.Path] {
+		if err := hook(ctx, c, cfg); err != nil {
+			log.WithField("error", err.Error()).Error("configuration change not applied")
+			return false
+		}
+	}
+	log.Warn("configuration change applied")
+
+	entry := audit.Change{
+		Action:     AuditAction,
+		EntityType: AuditEntityType,
+		EntityID:   c.Path,
+		Before:     snapshot{File: c.Domain, Value: c.From},
+		After:      snapshot{File: c.Domain, Value: c.To},
+	}
+	for domain, recorder := range r.cfg.Audit {
+		if c.Domain != Main && c.Domain != domain {
+			continue
+		}
+		if err := recorder.Record(ctx, entry); err != nil {
+			log.WithFields(map[string]any{"error": err.Error(), "audit": domain}).Error("configuration change not audited")
+		}
+	}
+	return true
+}
+
+// snapshot is the audited state of a setting.
+type snapshot struct {
+	// File is the domain of the changed file, Main for the global one.
+	File  string `json:"file"`
+	Value any    `json:"value"`
+}
+
+// restartNeeded reports whether prev and next differ outside the reloadable
+// settings.
+func restartNeeded(prev, next *config.Config) bool {
+	a, b := *prev, *next
+	for _, s := range settings {
+		s.clear(&a)
+		s.clear(&b)
+	}
+	return !reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,169 @@
+package reload
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watcher reloads the configuration once its files stay unchanged for the
+// debounce delay.
+type watcher struct {
+	fs    *fsnotify.Watcher
+	files map[string]bool
+	done  chan struct{}
+	wg    sync.WaitGroup
+	// stopRemote stops watching the remote store, nil when not watched.
+	stopRemote context.CancelFunc
+
+	mu    sync.Mutex
+	timer *time.Timer
+}
+
+// Start watches the configuration files, with their profile, and reloads them
+// after every write.
+// The directories of the files are watched rather than the files, which
+// editors and Kubernetes ConfigMaps replace instead of writing them.
+// With remote.watch, the documents of the remote store are watched too (see
+// config.Remote); no file is watched without GlobalPath (env mode).
+func (r *Reloader) Start() error {
+	fs, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watching the configuration: %w", err)
+	}
+	w := &watcher{fs: fs, files: map[string]bool{}, done: make(chan struct{})}
+
+	var paths []string
+	if r.cfg.GlobalPath != "" {
+		paths = append(paths, r.cfg.GlobalPath)
+		for _, path := range r.cfg.Domains {
+			paths = append(paths, path)
+		}
+	}
+	var env string
+	var remote config.RemoteConfig
+	r.mu.Lock()
+	if global := r.current[Main]; global != nil {
+		env, remote = global.App.Env, global.Remote
+	}
+	r.mu.Unlock()
+	dirs := map[string]bool{}
+	for _, path := range paths {
+		file, err := filepath.Abs(config.FilePath(path))
+		if err != nil {
+			_ = fs.Close()
+			return fmt.Errorf("watching %s: %w", path, err)
+		}
+		w.files[file] = true
+		// The profile is watched even while missing: creating it applies it.
+		if profile := config.ProfilePath(file, env); profile != "" {
+			w.files[profile] = true
+		}
+		dirs[filepath.Dir(file)] = true
+	}
+	for dir := range dirs {
+		if err := fs.Add(dir); err != nil {
+			_ = fs.Close()
+			return fmt.Errorf("watching %s: %w", dir, err)
+		}
+	}
+
+	r.watcher = w
+	w.wg.Add(1)
+	go r.watch(w)
+	if provider, prefix := config.Remote(); provider != nil && remote.Watch {
+		ctx, cancel := context.WithCancel(context.Background())
+		w.stopRemote = cancel
+		w.wg.Add(1)
+		go r.watchRemote(ctx, w, provider, prefix)
+	}
+	return nil
+}
+
+// Stop stops watching the files; a pending reload is dropped.
+func (r *Reloader) Stop() {
+	w := r.watcher
+	if w == nil {
+		return
+	}
+	close(w.done)
+	_ = w.fs.Close()
+	if w.stopRemote != nil {
+		w.stopRemote()
+	}
+	w.wg.Wait()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+}
+
+func (r *Reloader) watch(w *watcher) {
+	defer w.wg.Done()
+	for {
+		select {
+		case event, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if file, err := filepath.Abs(event.Name); err == nil && w.files[file] {
+				w.schedule(r.cfg.Debounce, func() { _, _ = r.Reload(context.Background()) })
+			}
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			r.log.WithField("error", err.Error()).Error("watching the configuration failed")
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// remoteRetryDelay is how long the watch of a failing remote store waits
+// before watching it again.
+const remoteRetryDelay = 5 * time.Second
+
+// watchRemote reloads the configuration after every change of the documents
+// of the remote store under prefix. A failing watch is retried, then reloads
+// the configuration to catch up with the changes missed meanwhile.
+func (r *Reloader) watchRemote(ctx context.Context, w *watcher, provider config.RemoteProvider, prefix string) {
+	defer w.wg.Done()
+	reload := func() { _, _ = r.Reload(context.Background()) }
+	for {
+		err := provider.Watch(ctx, prefix, func() { w.schedule(r.cfg.Debounce, reload) })
+		if ctx.Err() != nil {
+			return
+		}
+		r.log.WithFields(map[string]any{"error": err.Error(), "prefix": prefix}).Error("watching the remote configuration failed")
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(remoteRetryDelay):
+			w.schedule(r.cfg.Debounce, reload)
+		}
+	}
+}
+
+// schedule runs reload after delay, postponed by the next calls.
+func (w *watcher) schedule(delay time.Duration, reload func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(delay, func() {
+		select {
+		case <-w.done:
+		default:
+			reload()
+		}
+	})
+}
@@ -0,0 +1,414 @@
+// Package saga runs a business process spanning several services as a
+// sequence of steps, each with the compensation undoing it: when a step
+// fails, or the saga times out, the steps are compensated in reverse order.
+// The progress of a saga is persisted after every step, in the sagas table
+// of the module database, so that a saga interrupted by a crash is resumed
+// (or compensated) by Recover.
+//
+//	def := saga.Definition[Checkout]{
+//		Name:    "booking.checkout",
+//		Timeout: 15 * time.Minute,
+//		Steps: []saga.Step[Checkout]{
+//			{Name: "reserve_inventory", Action: reserve, Compensate: release},
+//			{Name: "capture_payment", Action: capture, Compensate: refund},
+//			{Name: "confirm_booking", Action: confirm},
+//		},
+//	}
+//	c := saga.NewCoordinator(def, saga.NewDatabaseStore(db), log, trc, m)
+//	status, err := c.Run(ctx, bookingID, &Checkout{...})
+//
+// A step runs at least once: an interrupted step is run again on recovery.
+// The compensation of a step also runs when its action failed or was
+// interrupted, since its outcome is unknown (e.g. a payment capture timing
+// out). Actions and compensations must therefore be idempotent, and
+// compensations must tolerate an action that never happened: key them on
+// the saga ID rather than on the result of the action.
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+)
+
+const (
+	metricStepDuration = "saga_step_duration"
+	metricEnded        = "saga_ended"
+)
+
+// Outcomes of a step (status tag of saga_step_duration).
+const (
+	statusOK     = "ok"
+	statusFailed = "failed"
+)
+
+// defaultStepTimeout bounds the steps without Timeout.
+const defaultStepTimeout = 30 * time.Second
+
+// Status is the state of a saga.
+type Status string
+
+const (
+	// StatusRunning: the steps are running.
+	StatusRunning Status = "RUNNING"
+	// StatusCompleted: every step succeeded.
+	StatusCompleted Status = "COMPLETED"
+	// StatusCompensating: a step failed or the saga timed out; the steps are
+	// being compensated.
+	StatusCompensating Status = "COMPENSATING"
+	// StatusCompensated: every step was compensated.
+	StatusCompensated Status = "COMPENSATED"
+)
+
+var (
+	// ErrDuplicate is returned by Run when a saga with the same name and ID
+	// was already started.
+	ErrDuplicate = errors.New("saga: already started")
+	// ErrConflict is returned when a saga was changed concurrently, e.g.
+	// recovered while it was still running: the other run carries on.
+	ErrConflict = errors.New("saga: changed concurrently")
+	// ErrTimeout is the cause of the compensation of a saga past its
+	// Timeout.
+	ErrTimeout = errors.New("saga: timed out")
+)
+
+// StepError is returned by Run when a saga was compensated, with the step
+// that failed and its error.
+type StepError struct {
+	Step string
+	Err  error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("saga step %s failed: %v", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error { return e.Err }
+
+// Step is a step of a saga over its data D. Action may update data: the
+// changes are persisted with the progress of the saga.
+type Step[D any] struct {
+	Name   string
+	Action func(ctx context.Context, data *D) error
+	// Compensate, optional, undoes Action.
+	Compensate func(ctx context.Context, data *D) error
+	// Timeout bounds Action and Compensate (default 30s).
+	Timeout time.Duration
+}
+
+// Definition is a saga: its steps, run in order.
+type Definition[D any] struct {
+	// Name identifies the saga in the sagas table, the logs, spans and
+	// metrics, e.g. "booking.checkout".
+	Name  string
+	Steps []Step[D]
+	// Timeout, optional, compensates the saga still running past it.
+	Timeout time.Duration
+}
+
+func (d Definition[D]) validate() error {
+	if d.Name == "" || len(d.Steps) == 0 {
+		return errors.New("saga: a definition needs a name and steps")
+	}
+	for i, s := range d.Steps {
+		if s.Name == "" || s.Action == nil {
+			return fmt.Errorf("saga: %s: step %d needs a name and an action", d.Name, i)
+		}
+		if slices.ContainsFunc(d.Steps[:i], func(o Step[D]) bool { return o.Name == s.Name }) {
+			return fmt.Errorf("saga: %s: step %s defined twice", d.Name, s.Name)
+		}
+	}
+	return nil
+}
+
+// Coordinator runs the sagas of a definition.
+type Coordinator[D any] struct {
+	def   Definition[D]
+	store Store
+
+	log     logger.Logger
+	tracer  tracer.Tracer
+	metrics metrics.Metrics
+}
+
+// NewCoordinator returns the coordinator of def, keeping the sagas in store.
+// It panics on an invalid definition. m may be nil.
+func NewCoordinator[D any](def Definition[D], store Store, log logger.Logger, trc tracer.Tracer, m metrics.Metrics) *Coordinator[D] {
+	if err := def.validate(); err != nil {
+		panic(err)
+	}
+	return &Coordinator[D]{
+		def:     def,
+		store:   store,
+		log:     log.WithFields(map[string]any{"component": "saga", "saga": def.Name}),
+		tracer:  trc,
+		metrics: m,
+	}
+}
+
+// Run starts the saga id with data and runs it to its end. It returns:
+//   - StatusCompleted and nil when every step succeeded;
+//   - StatusCompensated and a *StepError when a step failed (or ErrTimeout);
+//   - StatusCompensating and the error of the compensation that failed: the
+//     compensation is carried on by Recover.
+//
+// A saga already started with id fails with ErrDuplicate.
+func (c *Coordinator[D]) Run(ctx context.Context, id string, data *D) (Status, error) {
+	span, ctx := c.tracer.StartSpan(ctx, "saga "+c.def.Name)
+	defer span.Finish()
+	span.SetTag("saga.id", id)
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("saga: failed to encode %s data: %w", c.def.Name, err)
+	}
+	now := time.Now()
+	inst := &Instance{
+		Name:      c.def.Name,
+		ID:        id,
+		Status:    StatusRunning,
+		Data:      string(raw),
+		CreatedAt: now.UnixMilli(),
+		UpdatedAt: now.UnixMilli(),
+	}
+	if c.def.Timeout > 0 {
+		inst.Deadline = now.Add(c.def.Timeout).UnixMilli()
+	}
+	if err := c.store.Create(ctx, inst); err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+
+	status, err := c.drive(ctx, inst, data)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return status, err
+}
+
+// Recover carries on up to limit sagas left unfinished for stale (their
+// process crashed): a running saga resumes at its interrupted step, or is
+// compensated once past its timeout; a compensating one carries on its
+// compensation. It returns how many sagas were recovered. Pick stale above
+// the timeout of the steps, not to recover a saga still running.
+func (c *Coordinator[D]) Recover(ctx context.Context, stale time.Duration, limit int) (int, error) {
+	instances, err := c.store.Unfinished(ctx, c.def.Name, time.Now().Add(-stale).UnixMilli(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	recovered := 0
+	for i := range instances {
+		inst := &instances[i]
+		if err := c.recover(ctx, inst); err != nil {
+			if !errors.Is(err, ErrConflict) {
+				c.log.WithFields(map[string]any{"saga_id": inst.ID, "error": err.Error()}).Error("failed to recover saga")
+			}
+			continue
+		}
+		recovered++
+	}
+	return recovered, nil
+}
+
+func (c *Coordinator[D]) recover(ctx context.Context, inst *Instance) error {
+	span, ctx := c.tracer.StartSpan(ctx, "saga "+c.def.Name)
+	defer span.Finish()
+	span.SetTag("saga.id", inst.ID)
+	span.SetTag("saga.recovered", true)
+
+	data := new(D)
+	if err := json.Unmarshal([]byte(inst.Data), data); err != nil {
+		return fmt.Errorf("saga: failed to decode %s data: %w", c.def.Name, err)
+	}
+	c.log.WithContext(ctx).WithFields(map[string]any{
+		"saga_id": inst.ID,
+		"status":  inst.Status,
+		"step":    c.stepName(inst.Step),
+	}).Warn("recovering saga")
+
+	// Taken over first: a concurrent recovery fails with ErrConflict.
+	if err := c.save(ctx, inst, data); err != nil {
+		return err
+	}
+	_, err := c.drive(ctx, inst, data)
+	var stepErr *StepError
+	if errors.As(err, &stepErr) {
+		// Compensated: recovered.
+		return nil
+	}
+	return err
+}
+
+// drive runs the steps of inst from inst.Step, then compensates them when
+// one failed.
+func (c *Coordinator[D]) drive(ctx context.Context, inst *Instance, data *D) (Status, error) {
+	var cause error
+	for inst.Status == StatusRunning && inst.Step < len(c.def.Steps) {
+		if inst.Deadline > 0 && time.Now().UnixMilli() > inst.Deadline {
+			cause = ErrTimeout
+		} else {
+			cause = c.runStep(ctx, inst, c.def.Steps[inst.Step].Action, data)
+		}
+		if cause != nil {
+			// The step is compensated too: its outcome is unknown.
+			step, msg := c.stepName(inst.Step), cause.Error()
+			inst.Status = StatusCompensating
+			inst.FailedStep = &step
+			inst.Error = &msg
+			if err := c.save(ctx, inst, data); err != nil {
+				return inst.Status, err
+			}
+			break
+		}
+
+		inst.Step++
+		if inst.Step == len(c.def.Steps) {
+			inst.Status = StatusCompleted
+		}
+		if err := c.save(ctx, inst, data); err != nil {
+			return inst.Status, err
+		}
+	}
+
+	if inst.Status == StatusCompensating {
+		if err := c.compensate(ctx, inst, data); err != nil {
+			c.log.WithContext(ctx).WithFields(map[string]any{
+				"saga_id":      inst.ID,
+				"step":         c.stepName(inst.Step),
+				"error_detail": err.Error(),
+			}).Error("saga compensation failed")
+			return inst.Status, err
+		}
+	}
+	c.end(ctx, inst)
+
+	if inst.Status == StatusCompensated {
+		return inst.Status, c.failure(inst, cause)
+	}
+	return inst.Status, nil
+}
+
+// compensate compensates the steps from inst.Step down to the first one.
+func (c *Coordinator[D]) compensate(ctx context.Context, inst *Instance, data *D) error {
+	inst.Step = min(inst.Step, len(c.def.Steps)-1)
+	for inst.Step >= 0 {
+		if fn := c.def.Steps[inst.Step].Compensate; fn != nil {
+			if err := c.runStep(ctx, inst, fn, data); err != nil {
+				if saveErr := c.save(ctx, inst, data); saveErr != nil {
+					return saveErr
+				}
+				return err
+			}
+		}
+		inst.Step--
+		if inst.Step < 0 {
+			break
+		}
+		if err := c.save(ctx, inst, data); err != nil {
+			return err
+		}
+	}
+	inst.Step = 0
+	inst.Status = StatusCompensated
+	return c.save(ctx, inst, data)
+}
+
+// runStep runs fn, the action or the compensation of the current step,
+// turning a panic into an error.
+func (c *Coordinator[D]) runStep(ctx context.Context, inst *Instance, fn func(ctx context.Context, data *D) error, data *D) (err error) {
+	step := c.def.Steps[inst.Step]
+	phase := "action"
+	if inst.Status == StatusCompensating {
+		phase = "compensation"
+	}
+	timeout := step.Timeout
+	if timeout <= 0 {
+		timeout = defaultStepTimeout
+	}
+
+	start := time.Now()
+	span, ctx := c.tracer.StartSpan(ctx, "saga "+c.def.Name+"."+step.Name)
+	span.SetTag("saga.phase", phase)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("saga step panicked: %v", p)
+		}
+		cancel()
+		status := statusOK
+		if err != nil {
+			status = statusFailed
+			span.RecordError(err)
+			c.log.WithContext(ctx).WithFields(map[string]any{
+				"saga_id":      inst.ID,
+				"step":         step.Name,
+				"phase":        phase,
+				"error_detail": err.Error(),
+			}).Warn("saga step failed")
+		}
+		span.Finish()
+		if c.metrics != nil {
+			c.metrics.Timing(metricStepDuration, time.Since(start), []string{
+				"saga:" + c.def.Name, "step:" + step.Name, "phase:" + phase, "status:" + status,
+			})
+		}
+	}()
+	return fn(ctx, data)
+}
+
+// save persists the progress of inst with data.
+func (c *Coordinator[D]) save(ctx context.Context, inst *Instance, data *D) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("saga: failed to encode %s data: %w", c.def.Name, err)
+	}
+	inst.Data = string(raw)
+	inst.UpdatedAt = time.Now().UnixMilli()
+	// The progress is saved even when the caller gave up on the saga.
+	return c.store.Update(context.WithoutCancel(ctx), inst)
+}
+
+func (c *Coordinator[D]) end(ctx context.Context, inst *Instance) {
+	log := c.log.WithContext(ctx).WithFields(map[string]any{"saga_id": inst.ID, "status": inst.Status})
+	switch inst.Status {
+	case StatusCompleted:
+		log.Info("saga completed")
+	case StatusCompensated:
+		log.WithField("error_detail", ptrValue(inst.Error)).Warn("saga compensated")
+	default:
+		return
+	}
+	if c.metrics != nil {
+		c.metrics.Incr(metricEnded, []string{"saga:" + c.def.Name, "status:" + string(inst.Status)})
+	}
+}
+
+// failure returns the error of the compensated inst: cause when its step
+// failed in this run, the recorded one otherwise.
+func (c *Coordinator[D]) failure(inst *Instance, cause error) error {
+	if cause == nil {
+		cause = errors.New(ptrValue(inst.Error))
+	}
+	return &StepError{Step: ptrValue(inst.FailedStep), Err: cause}
+}
+
+func (c *Coordinator[D]) stepName(i int) string {
+	if i < 0 || i >= len(c.def.Steps) {
+		return ""
+	}
+	return c.def.Steps[i].Name
+}
+
+func ptrValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,123 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	database "voyago/core-api/internal/infrastructure/db"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Instance is the persisted state of a saga, in the sagas table of the
+// module database.
+type Instance struct {
+	Name   string `gorm:"column:name;type:varchar(100);primaryKey"`
+	ID     string `gorm:"column:id;type:varchar(128);primaryKey"`
+	Status Status `gorm:"column:status;type:varchar(20);not null"`
+	// Step is the step to run while running, the step to compensate while
+	// compensating.
+	Step int `gorm:"column:step;not null;default:0"`
+	// Data is the JSON data of the steps.
+	Data string `gorm:"column:data;type:text;not null"`
+	// FailedStep and Error are the step that failed and its error, once
+	// compensating.
+	FailedStep *string `gorm:"column:failed_step;type:varchar(100)"`
+	Error      *string `gorm:"column:error;type:text"`
+	// Deadline is when the running saga times out, in Unix milliseconds; 0
+	// without timeout.
+	Deadline int64 `gorm:"column:deadline;type:bigint;not null;default:0"`
+	// Version is incremented on every update (optimistic concurrency).
+	Version   int   `gorm:"column:version;not null;default:0"`
+	CreatedAt int64 `gorm:"column:created_at;type:bigint;not null"`
+	UpdatedAt int64 `gorm:"column:updated_at;type:bigint;not null"`
+}
+
+func (Instance) TableName() string {
+	return "sagas"
+}
+
+// Store keeps the state of the sagas.
+type Store interface {
+	// Create stores a new saga, failing with ErrDuplicate when it exists.
+	Create(ctx context.Context, inst *Instance) error
+	// Update stores inst if it was not changed since it was read, and
+	// increments its version. It fails with ErrConflict otherwise.
+	Update(ctx context.Context, inst *Instance) error
+	// Find returns the saga name/id, nil when it does not exist.
+	Find(ctx context.Context, name, id string) (*Instance, error)
+	// Unfinished returns up to limit sagas of name, running or compensating,
+	// not updated since updatedBefore (Unix milliseconds), the oldest first.
+	Unfinished(ctx context.Context, name string, updatedBefore int64, limit int) ([]Instance, error)
+}
+
+// DatabaseStore keeps the sagas in the sagas table of a module database.
+type DatabaseStore struct {
+	db database.Database
+}
+
+var _ Store = (*DatabaseStore)(nil)
+
+// NewDatabaseStore returns the store of the sagas table of db.
+func NewDatabaseStore(db database.Database) *DatabaseStore {
+	return &DatabaseStore{db: db}
+}
+
+func (s *DatabaseStore) Create(ctx context.Context, inst *Instance) error {
+	res := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(inst)
+	if res.Error != nil {
+		return database.MapDBError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrDuplicate
+	}
+	return nil
+}
+
+func (s *DatabaseStore) Update(ctx context.Context, inst *Instance) error {
+	res := s.db.WithContext(ctx).
+		Model(&Instance{}).
+		Where("name = ? AND id = ? AND version = ?", inst.Name, inst.ID, inst.Version).
+		Updates(map[string]any{
+			"status":      inst.Status,
+			"step":        inst.Step,
+			"data":        inst.Data,
+			"failed_step": inst.FailedStep,
+			"error":       inst.Error,
+			"version":     inst.Version + 1,
+			"updated_at":  inst.UpdatedAt,
+		})
+	if res.Error != nil {
+		return database.MapDBError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrConflict
+	}
+	inst.Version++
+	return nil
+}
+
+func (s *DatabaseStore) Find(ctx context.Context, name, id string) (*Instance, error) {
+	var inst Instance
+	err := s.db.WithContext(ctx).Where("name = ? AND id = ?", name, id).Take(&inst).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, database.MapDBError(err)
+	}
+	return &inst, nil
+}
+
+func (s *DatabaseStore) Unfinished(ctx context.Context, name string, updatedBefore int64, limit int) ([]Instance, error) {
+	var instances []Instance
+	err := s.db.WithContext(ctx).
+		Where("name = ? AND status IN ? AND updated_at < ?", name, []Status{StatusRunning, StatusCompensating}, updatedBefore).
+		Order("updated_at").
+		Limit(limit).
+		Find(&instances).Error
+	if err != nil {
+		return nil, database.MapDBError(err)
+	}
+	return instances, nil
+}
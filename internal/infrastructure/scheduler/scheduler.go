@@ -0,0 +1,235 @@
+// Package scheduler runs the periodic jobs of the modules (purges,
+// reconciliations) in the background runtime, each in its own span, with a
+// graceful stop.
+//
+// A job runs every interval, or on a cron schedule (Spec), on every replica,
+// unless the scheduler has a Leader (see RunOnLeader): the jobs then run on
+// the elected replica only. A job with a Locker runs on the replica taking
+// the lock of its turn, the others skipping it.
+//
+//	s.Add(scheduler.Job{
+//		Name: "booking.purge_processed_events",
+//		Spec: "@hourly",
+//		Run: func(ctx context.Context) error {
+//			_, err := store.Purge(ctx, 7*24*time.Hour)
+//			return err
+//		},
+//	})
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/lock"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	metricJobDuration = "scheduler_job_duration"
+	metricJobSkipped  = "scheduler_job_skipped"
+)
+
+// Outcomes of a run (status tag of scheduler_job_duration).
+const (
+	statusOK     = "ok"
+	statusFailed = "failed"
+)
+
+// Job is a function run periodically.
+type Job struct {
+	// Name identifies the job in the logs, spans, metrics and lock keys.
+	Name string
+	// Interval runs the job every interval from Start. Either Interval or
+	// Spec is set.
+	Interval time.Duration
+	// Spec runs the job on a cron schedule: 5 fields (minute hour
+	// day-of-month month day-of-week), or a descriptor such as "@hourly" or
+	// "@every 90s". The time zone is the local one unless the spec starts
+	// with "CRON_TZ=<zone> ".
+	Spec string
+	Run  func(ctx context.Context) error
+	// Locker, optional, runs the job on a single replica per interval.
+	Locker lock.Locker
+}
+
+// Leader tells whether this replica is the elected one (see leader.Elector).
+type Leader interface {
+	IsLeader() bool
+}
+
+// entry is a registered job with its schedule.
+type entry struct {
+	Job
+	schedule cron.Schedule
+}
+
+// Scheduler runs jobs from Start to Stop.
+type Scheduler struct {
+	log     logger.Logger
+	tracer  tracer.Tracer
+	metrics metrics.Metrics
+	leader  Leader
+
+	jobs     []entry
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// New creates a Scheduler. m may be nil.
+func New(log logger.Logger, trc tracer.Tracer, m metrics.Metrics) *Scheduler {
+	return &Scheduler{
+		log:     log.WithField("component", "scheduler"),
+		tracer:  trc,
+		metrics: m,
+		stop:    make(chan struct{}),
+	}
+}
+
+// RunOnLeader runs the jobs on the replica elected by l only, before Start.
+// The other replicas skip their turns, counted by scheduler_job_skipped.
+func (s *Scheduler) RunOnLeader(l Leader) {
+	s.leader = l
+}
+
+// Add registers job, before Start. It panics on a job without name or run,
+// without a positive interval or a valid spec (or with both), or whose name
+// is already registered.
+func (s *Scheduler) Add(job Job) {
+	if job.Name == "" || job.Run == nil {
+		panic("scheduler: a job needs a name and a Run")
+	}
+	if (job.Interval > 0) == (job.Spec != "") || job.Interval < 0 {
+		panic(fmt.Errorf("scheduler: job %s needs either a positive interval or a spec", job.Name))
+	}
+	if slices.ContainsFunc(s.jobs, func(e entry) bool { return e.Name == job.Name }) {
+		panic(fmt.Errorf("scheduler: job %s registered twice", job.Name))
+	}
+
+	e := entry{Job: job}
+	if job.Spec != "" {
+		schedule, err := cron.ParseStandard(job.Spec)
+		if err != nil {
+			panic(fmt.Errorf("scheduler: job %s: invalid spec %q: %w", job.Name, job.Spec, err))
+		}
+		e.schedule = schedule
+	}
+	s.jobs = append(s.jobs, e)
+}
+
+// Jobs returns the names of the registered jobs.
+func (s *Scheduler) Jobs() []string {
+	names := make([]string, len(s.jobs))
+	for i, j := range s.jobs {
+		names[i] = j.Name
+	}
+	return names
+}
+
+// Start runs every job each interval, the first time one interval after
+// Start, or at the times of its spec.
+func (s *Scheduler) Start() {
+	for _, e := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(e)
+	}
+}
+
+// Stop stops scheduling and waits for the running jobs to complete.
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		s.wg.Wait()
+	})
+}
+
+func (s *Scheduler) loop(e entry) {
+	defer s.wg.Done()
+	if e.schedule == nil {
+		ticker := time.NewTicker(e.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.run(e.Job, e.Interval)
+			}
+		}
+	}
+
+	for {
+		now := time.Now()
+		next := e.schedule.Next(now)
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			// The lock of a turn lasts until the next one.
+			s.run(e.Job, e.schedule.Next(next).Sub(next))
+		}
+	}
+}
+
+// run runs job once, the turn lasting period. A running job completes during
+// a graceful stop.
+func (s *Scheduler) run(job Job, period time.Duration) {
+	ctx := context.Background()
+	if s.leader != nil && !s.leader.IsLeader() {
+		if s.metrics != nil {
+			s.metrics.Incr(metricJobSkipped, []string{"job:" + job.Name, "reason:not_leader"})
+		}
+		return
+	}
+	if job.Locker != nil {
+		// The lock is left to expire with the turn: the other replicas skip
+		// this turn even when the job ended early.
+		if _, err := job.Locker.TryLock(ctx, "job:"+job.Name, period); err != nil {
+			if !errors.Is(err, lock.ErrNotAcquired) {
+				s.log.WithFields(map[string]any{"job": job.Name, "error": err.Error()}).Warn("failed to lock scheduled job")
+			}
+			return
+		}
+	}
+
+	start := time.Now()
+	span, ctx := s.tracer.StartSpan(ctx, "job "+job.Name)
+	err := runJob(ctx, job)
+	status := statusOK
+	log := s.log.WithContext(ctx).WithFields(map[string]any{
+		"job":         job.Name,
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+	if err != nil {
+		status = statusFailed
+		span.RecordError(err)
+		log.WithField("error_detail", err.Error()).Error("scheduled job failed")
+	} else {
+		log.Info("scheduled job completed")
+	}
+	span.Finish()
+
+	if s.metrics != nil {
+		s.metrics.Timing(metricJobDuration, time.Since(start), []string{"job:" + job.Name, "status:" + status})
+	}
+}
+
+// runJob runs job, turning a panic into an error.
+func runJob(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job panicked: %v", p)
+		}
+	}()
+	return job.Run(ctx)
+}
@@ -0,0 +1,150 @@
+// Package seed fills the databases of the domains with data: reference data
+// the application needs in every environment, and sample data for local
+// development and demos, never seeded in production.
+//
+// The modules register their seeders per domain in a Registry (see
+// app.Seeders); `voyago seed` runs the pending ones. Each seeder runs once per
+// database: it is recorded in the schema_seeds table of the domain schema in
+// the transaction of its data. Seeders should still be safe to run again
+// (fixed IDs, ON CONFLICT DO NOTHING), e.g. once the table was dropped.
+//
+// Example:
+//
+//	r.Register("booking", seed.Seeder{
+//		Name: "sample_bookings",
+//		Kind: seed.Sample,
+//		Run: func(ctx context.Context, db database.Database) error {
+//			return db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&bookings).Error
+//		},
+//	})
+package seed
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+	database "voyago/core-api/internal/infrastructure/db"
+)
+
+// Kind tells in which environments a seeder runs.
+type Kind int
+
+const (
+	// Reference data is needed by the application in every environment
+	// (e.g. currencies, default settings).
+	Reference Kind = iota
+	// Sample data demonstrates the application, it is never seeded in
+	// production (see IsProduction).
+	Sample
+)
+
+// Seeder inserts a set of data in the database of its domain.
+type Seeder struct {
+	// Name identifies the seeder within its domain, it is recorded once the
+	// seeder ran: renaming a seeder runs it again.
+	Name string
+	Kind Kind
+	// Run inserts the data, with db.WithContext(ctx): ctx is the context of
+	// the transaction recording the seeder.
+	Run func(ctx context.Context, db database.Database) error
+}
+
+// Registry holds the seeders of every domain, in registration order.
+type Registry struct {
+	byDomain map[string][]Seeder
+}
+
+func NewRegistry() *Registry {
+	return &Registry{byDomain: map[string][]Seeder{}}
+}
+
+// Register adds seeders to domain, run in this order. It panics on a seeder
+// without name or Run, or whose name is already registered in the domain.
+func (r *Registry) Register(domain string, seeders ...Seeder) {
+	for _, s := range seeders {
+		if s.Name == "" || s.Run == nil {
+			panic(fmt.Errorf("seed: a seeder of %s has no name or Run", domain))
+		}
+		if slices.ContainsFunc(r.byDomain[domain], func(o Seeder) bool { return o.Name == s.Name }) {
+			panic(fmt.Errorf("seed: seeder %s of %s registered twice", s.Name, domain))
+		}
+		r.byDomain[domain] = append(r.byDomain[domain], s)
+	}
+}
+
+// Seeders returns the seeders of domain.
+func (r *Registry) Seeders(domain string) []Seeder {
+	return slices.Clone(r.byDomain[domain])
+}
+
+// IsProduction reports whether env (app.env) is a production environment,
+// where the Sample seeders are refused.
+func IsProduction(env string) bool {
+	return env == "production" || env == "prod"
+}
+
+// Outcome is what Run did with a seeder.
+type Outcome string
+
+const (
+	Applied Outcome = "applied"
+	// AlreadyApplied seeders ran before on the database.
+	AlreadyApplied Outcome = "already applied"
+	// Refused seeders are Sample ones in production.
+	Refused Outcome = "refused in production"
+)
+
+// Result is the outcome of a seeder.
+type Result struct {
+	Name    string
+	Outcome Outcome
+}
+
+// record is a seeder that ran on the database.
+type record struct {
+	Name      string `gorm:"column:name;type:varchar(255);primaryKey"`
+	AppliedAt int64  `gorm:"column:applied_at;type:bigint;not null"`
+}
+
+func (record) TableName() string {
+	return "schema_seeds"
+}
+
+// Run runs the seeders not applied yet on db, in order, each in its own
+// transaction, for the environment env. It stops at the first failing
+// seeder, rolled back, and returns the results of the seeders before it.
+func Run(ctx context.Context, db database.Database, env string, seeders []Seeder) ([]Result, error) {
+	if err := db.WithContext(ctx).AutoMigrate(&record{}); err != nil {
+		return nil, fmt.Errorf("failed to create the seeds table: %w", err)
+	}
+
+	results := make([]Result, 0, len(seeders))
+	for _, s := range seeders {
+		if s.Kind == Sample && IsProduction(env) {
+			results = append(results, Result{Name: s.Name, Outcome: Refused})
+			continue
+		}
+
+		var applied int64
+		if err := db.WithContext(ctx).Model(&record{}).Where("name = ?", s.Name).Count(&applied).Error; err != nil {
+			return results, fmt.Errorf("seeder %s: %w", s.Name, err)
+		}
+		if applied > 0 {
+			results = append(results, Result{Name: s.Name, Outcome: AlreadyApplied})
+			continue
+		}
+
+		err := db.Atomic(ctx, func(txCtx context.Context) error {
+			if err := s.Run(txCtx, db); err != nil {
+				return err
+			}
+			return db.WithContext(txCtx).Create(&record{Name: s.Name, AppliedAt: time.Now().UnixMilli()}).Error
+		})
+		if err != nil {
+			return results, fmt.Errorf("seeder %s: %w", s.Name, err)
+		}
+		results = append(results, Result{Name: s.Name, Outcome: Applied})
+	}
+	return results, nil
+}
@@ -0,0 +1,161 @@
+// Package sse streams events to HTTP clients with Server-Sent Events.
+//
+// A Broker fans messages out to the open connections of a stream. Modules
+// feed it from the event bus and serve subscriptions from their handlers:
+//
+//	// event bus subscriber
+//	broker.Publish("booking", bookingID, sse.Message{ID: evt.ID, Event: evt.Type, Data: evt.Payload})
+//
+//	// handler
+//	sub, err := broker.Subscribe(c.UserContext(), "booking", bookingID)
+//	if err != nil {
+//		return err
+//	}
+//	return sub.Serve(c)
+package sse
+
+import (
+	"context"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/pkg/apperror"
+)
+
+const (
+	defaultHeartbeatInterval = 15 * time.Second
+	defaultWriteTimeout      = 10 * time.Second
+	defaultBufferSize        = 16
+
+	metricConnections = "sse.connections"
+	metricDropped     = "sse.connections.dropped"
+)
+
+// Broker routes messages to the subscriptions of a stream key
+// (e.g., stream "booking", key "<booking id>").
+type Broker struct {
+	log     logger.Logger
+	metrics metrics.Metrics
+
+	heartbeat    time.Duration
+	writeTimeout time.Duration
+	bufferSize   int
+
+	mu   sync.Mutex
+	subs map[topic]map[*Subscription]struct{}
+	open map[string]int // open connections per stream
+}
+
+type topic struct {
+	stream string
+	key    string
+}
+
+func NewBroker(cfg config.SSEConfig, log logger.Logger, m metrics.Metrics) *Broker {
+	b := &Broker{
+		log:          log.WithField("component", "sse"),
+		metrics:      m,
+		heartbeat:    defaultHeartbeatInterval,
+		writeTimeout: defaultWriteTimeout,
+		bufferSize:   defaultBufferSize,
+		subs:         make(map[topic]map[*Subscription]struct{}),
+		open:         make(map[string]int),
+	}
+	if cfg.HeartbeatInterval > 0 {
+		b.heartbeat = time.Duration(cfg.HeartbeatInterval) * time.Second
+	}
+	if cfg.WriteTimeout > 0 {
+		b.writeTimeout = time.Duration(cfg.WriteTimeout) * time.Second
+	}
+	if cfg.BufferSize > 0 {
+		b.bufferSize = cfg.BufferSize
+	}
+	return b
+}
+
+// Subscribe opens a subscription to a stream key. The subscription context
+// keeps the values of ctx (request ID, trace) and is canceled when the
+// subscription is closed.
+//
+// The subscription must be served (see Subscription.Serve) or closed.
+// Subscribing before loading an initial snapshot guarantees that no message
+// published in between is lost.
+func (b *Broker) Subscribe(ctx context.Context, stream, key string) (*Subscription, error) {
+	if b == nil {
+		return nil, apperror.NewTransient(apperror.CodeInternalError, "event streaming is not configured")
+	}
+
+	subCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	s := &Subscription{
+		broker:   b,
+		topic:    topic{stream: stream, key: key},
+		ctx:      subCtx,
+		cancel:   cancel,
+		messages: make(chan Message, b.bufferSize),
+	}
+
+	b.mu.Lock()
+	if b.subs[s.topic] == nil {
+		b.subs[s.topic] = make(map[*Subscription]struct{})
+	}
+	b.subs[s.topic][s] = struct{}{}
+	b.open[stream]++
+	open := b.open[stream]
+	b.mu.Unlock()
+
+	b.metrics.Gauge(metricConnections, float64(open), []string{"stream:" + stream})
+	return s, nil
+}
+
+// Publish delivers msg to every subscription of the stream key without
+// blocking. A subscription whose buffer is full is disconnected: the client
+// reconnects and reloads its snapshot instead of silently missing messages.
+func (b *Broker) Publish(stream, key string, msg Message) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	var slow []*Subscription
+	for s := range b.subs[topic{stream: stream, key: key}] {
+		select {
+		case s.messages <- msg:
+		default:
+			slow = append(slow, s)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, s := range slow {
+		b.log.WithContext(s.ctx).WithFields(map[string]any{
+			"stream": stream,
+			"key":    key,
+		}).Warn("sse client too slow, disconnecting")
+		b.metrics.Incr(metricDropped, []string{"stream:" + stream})
+		s.Close()
+	}
+}
+
+// Connections returns the number of open connections of a stream.
+func (b *Broker) Connections(stream string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.open[stream]
+}
+
+func (b *Broker) remove(s *Subscription) {
+	b.mu.Lock()
+	delete(b.subs[s.topic], s)
+	if len(b.subs[s.topic]) == 0 {
+		delete(b.subs, s.topic)
+	}
+	b.open[s.topic.stream]--
+	open := b.open[s.topic.stream]
+	// Closed under the lock so that Publish never sends on a closed channel.
+	close(s.messages)
+	b.mu.Unlock()
+
+	b.metrics.Gauge(metricConnections, float64(open), []string{"stream:" + s.topic.stream})
+}
@@ -0,0 +1,139 @@
+package sse
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Message is a single Server-Sent Event.
+type Message struct {
+	// ID is sent as the event id (e.g., the event bus event ID).
+	ID string
+	// Event is the event name the client listens to (e.g., "booking.status_changed").
+	Event string
+	// Data is encoded as JSON.
+	Data any
+}
+
+// Subscription is an open connection to a stream key.
+type Subscription struct {
+	broker *Broker
+	topic  topic
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	once   sync.Once
+
+	messages chan Message
+}
+
+// Context is canceled when the subscription is closed (client gone, server
+// shutting down or client too slow).
+func (s *Subscription) Context() context.Context {
+	return s.ctx
+}
+
+// Close releases the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.cancel()
+		s.broker.remove(s)
+	})
+}
+
+// Serve streams the initial messages, then every published message, to the
+// client until it disconnects, the subscription is closed or the server shuts
+// down. A heartbeat comment is sent when the stream is idle, which also
+// detects clients that went away. Serve takes ownership of the subscription.
+func (s *Subscription) Serve(c *fiber.Ctx, initial ...Message) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	// Disables response buffering in nginx.
+	c.Set("X-Accel-Buffering", "no")
+
+	// The Fiber context is released when the handler returns: capture what
+	// the stream writer needs beforehand.
+	conn := c.Context().Conn()
+	shutdown := c.Context().Done()
+	b := s.broker
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer s.Close()
+
+		write := func(fn func() error) bool {
+			// The server write timeout applies to the whole response: extend
+			// it per event instead.
+			_ = conn.SetWriteDeadline(time.Now().Add(b.writeTimeout))
+			if err := fn(); err != nil {
+				return false
+			}
+			return w.Flush() == nil
+		}
+
+		ok := write(func() error {
+			for _, msg := range initial {
+				if err := encode(w, msg); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if !ok {
+			return
+		}
+
+		ticker := time.NewTicker(b.heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case msg, open := <-s.messages:
+				if !open {
+					return
+				}
+				ok = write(func() error { return encode(w, msg) })
+			case <-ticker.C:
+				ok = write(func() error {
+					_, err := w.WriteString(": heartbeat\n\n")
+					return err
+				})
+			case <-s.ctx.Done():
+				return
+			case <-shutdown:
+				return
+			}
+			if !ok {
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// encode writes msg in the text/event-stream format.
+func encode(w *bufio.Writer, msg Message) error {
+	data, err := json.Marshal(msg.Data)
+	if err != nil {
+		return fmt.Errorf("encode sse message: %w", err)
+	}
+
+	var sb strings.Builder
+	if msg.ID != "" {
+		fmt.Fprintf(&sb, "id: %s\n", msg.ID)
+	}
+	if msg.Event != "" {
+		fmt.Fprintf(&sb, "event: %s\n", msg.Event)
+	}
+	fmt.Fprintf(&sb, "data: %s\n\n", data)
+
+	_, err = w.WriteString(sb.String())
+	return err
+}
@@ -0,0 +1,172 @@
+// Package startup reports the failures of the application start. Commands
+// turn every failure into an *Error naming the failing component; the
+// Pipeline logs it as a structured fatal error and returns the process exit
+// code, instead of panicking:
+//
+//	func main() { os.Exit(run()) }
+//
+//	func run() (code int) {
+//		s := startup.New(fallbackLog)
+//		defer s.Recover(&code)
+//
+//		cfg, err := config.LoadGlobalConfig(path)
+//		if err != nil {
+//			return s.Fail(startup.Config("config", err))
+//		}
+//		...
+//	}
+//
+// Optional components (e.g., telemetry exporters) may instead be degraded:
+// the failure is logged and the application keeps running without them.
+package startup
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"voyago/core-api/internal/infrastructure/logger"
+)
+
+// Exit codes of the commands, following sysexits.h so that orchestrators can
+// tell a bad deployment from a transient failure.
+const (
+	ExitOK = 0
+	// ExitFailure reports an application stopped with errors.
+	ExitFailure = 1
+	// ExitUnavailable reports a required dependency that cannot be reached
+	// (EX_UNAVAILABLE): restarting may help.
+	ExitUnavailable = 69
+	// ExitSoftware reports an internal error such as a panic (EX_SOFTWARE).
+	ExitSoftware = 70
+	// ExitConfig reports an invalid configuration (EX_CONFIG): restarting
+	// does not help.
+	ExitConfig = 78
+)
+
+// Error is a startup failure of a component.
+type Error struct {
+	// Component is what failed, e.g. "config", "metrics" or "http server".
+	Component string
+	// Code is the exit code of the process.
+	Code int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %v", e.Component, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Config reports an invalid configuration of component.
+func Config(component string, err error) *Error {
+	return &Error{Component: component, Code: ExitConfig, Err: err}
+}
+
+// Unavailable reports a dependency of component that cannot be reached.
+func Unavailable(component string, err error) *Error {
+	return &Error{Component: component, Code: ExitUnavailable, Err: err}
+}
+
+// Guard calls fn and returns its panic, if any, as an Error of component
+// with code. Setup code panicking on invalid configuration is guarded with
+// ExitConfig.
+func Guard(component string, code int, fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &Error{Component: component, Code: code, Err: panicError(r)}
+		}
+	}()
+	fn()
+	return nil
+}
+
+func panicError(r any) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", r)
+}
+
+// Pipeline logs the startup failures and keeps track of the degraded
+// components. It is safe for concurrent use.
+type Pipeline struct {
+	mu       sync.Mutex
+	log      logger.Logger
+	degraded []string
+}
+
+// New creates a Pipeline logging with log until SetLogger is called.
+func New(log logger.Logger) *Pipeline {
+	return &Pipeline{log: log}
+}
+
+// SetLogger replaces the logger, once the configured one is created.
+func (p *Pipeline) SetLogger(log logger.Logger) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.log = log
+}
+
+// Fail logs err as a fatal error and returns the exit code: the Code of an
+// *Error, ExitFailure otherwise.
+func (p *Pipeline) Fail(err error) int {
+	code, component := ExitFailure, "app"
+	var startupErr *Error
+	if errors.As(err, &startupErr) {
+		code, component = startupErr.Code, startupErr.Component
+	}
+
+	p.logger().WithFields(map[string]any{
+		"component":    component,
+		"error_detail": err.Error(),
+		"exit_code":    code,
+	}).Error("Application failed")
+	return code
+}
+
+// Degrade logs that component failed but the application carries on
+// without it.
+func (p *Pipeline) Degrade(component string, err error) {
+	p.mu.Lock()
+	p.degraded = append(p.degraded, component)
+	log := p.log
+	p.mu.Unlock()
+
+	log.WithFields(map[string]any{
+		"component":    component,
+		"error_detail": err.Error(),
+	}).Warn("Running in degraded mode")
+}
+
+// Degraded returns the degraded components.
+func (p *Pipeline) Degraded() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.degraded...)
+}
+
+// Recover turns a panic of the calling function into a logged fatal error
+// and sets code to ExitSoftware. It must be deferred directly.
+func (p *Pipeline) Recover(code *int) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	p.logger().WithFields(map[string]any{
+		"component":    "app",
+		"error_detail": panicError(r).Error(),
+		"stack":        string(debug.Stack()),
+		"exit_code":    ExitSoftware,
+	}).Error("Application panicked")
+	*code = ExitSoftware
+}
+
+func (p *Pipeline) logger() logger.Logger {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.log
+}
@@ -0,0 +1,119 @@
+package taskqueue
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// memoryBroker keeps the tasks in the memory of the process.
+type memoryBroker struct {
+	mu sync.Mutex
+	// tasks are the enqueued tasks by ID.
+	tasks map[string]*memoryTask
+	dead  map[string]*memoryTask
+}
+
+type memoryTask struct {
+	task Task
+	// leasedUntil is zero while the task waits.
+	leasedUntil time.Time
+	diedAt      time.Time
+}
+
+// NewMemoryBroker returns a Broker keeping the tasks in memory, for a single
+// process: they are lost on restart, and only processed by the processors of
+// this process. It suits the tests and the local development.
+func NewMemoryBroker() Broker {
+	return &memoryBroker{
+		tasks: map[string]*memoryTask{},
+		dead:  map[string]*memoryTask{},
+	}
+}
+
+func (b *memoryBroker) Enqueue(_ context.Context, task *Task) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.tasks[task.ID]; ok {
+		return ErrDuplicateTask
+	}
+	b.tasks[task.ID] = &memoryTask{task: *task}
+	return nil
+}
+
+// Dequeue returns the due task of queue due first.
+func (b *memoryBroker) Dequeue(_ context.Context, queue string, lease time.Duration) (*Task, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	var next *memoryTask
+	for _, t := range b.tasks {
+		if t.task.Queue != queue || t.task.ProcessAt > now.UnixMilli() {
+			continue
+		}
+		if !t.leasedUntil.IsZero() && t.leasedUntil.After(now) {
+			continue
+		}
+		if next == nil || t.task.ProcessAt < next.task.ProcessAt {
+			next = t
+		}
+	}
+	if next == nil {
+		return nil, nil
+	}
+	next.leasedUntil = now.Add(lease)
+	task := next.task
+	return &task, nil
+}
+
+func (b *memoryBroker) Complete(_ context.Context, task *Task) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.tasks, task.ID)
+	return nil
+}
+
+func (b *memoryBroker) Retry(_ context.Context, task *Task) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tasks[task.ID] = &memoryTask{task: *task}
+	return nil
+}
+
+func (b *memoryBroker) Kill(_ context.Context, task *Task, retention time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	delete(b.tasks, task.ID)
+	b.dead[task.ID] = &memoryTask{task: *task, diedAt: now}
+	for id, t := range b.dead {
+		if now.Sub(t.diedAt) > retention {
+			delete(b.dead, id)
+		}
+	}
+	return nil
+}
+
+func (b *memoryBroker) Dead(_ context.Context, queue string, limit int) ([]*Task, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var dead []*memoryTask
+	for _, t := range b.dead {
+		if t.task.Queue == queue {
+			dead = append(dead, t)
+		}
+	}
+	slices.SortFunc(dead, func(a, b *memoryTask) int { return b.diedAt.Compare(a.diedAt) })
+
+	tasks := make([]*Task, 0, min(limit, len(dead)))
+	for _, t := range dead[:min(limit, len(dead))] {
+		task := t.task
+		tasks = append(tasks, &task)
+	}
+	return tasks, nil
+}
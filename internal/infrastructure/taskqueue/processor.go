@@ -0,0 +1,263 @@
+package taskqueue
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+)
+
+const metricProcessDuration = "taskqueue_process_duration"
+
+// Outcomes of a run (status tag of taskqueue_process_duration).
+const (
+	statusOK    = "ok"
+	statusRetry = "retry"
+	statusDead  = "dead"
+)
+
+// Handler runs a task. An error (or a panic) has the task retried, then dead.
+type Handler func(ctx context.Context, task *Task) error
+
+// Mux holds the handler of each task type. The modules register their
+// handlers at bootstrap, like their HTTP routes.
+type Mux struct {
+	handlers map[string]Handler
+}
+
+func NewMux() *Mux {
+	return &Mux{handlers: map[string]Handler{}}
+}
+
+// Handle registers h for taskType. It panics when the type already has a
+// handler.
+func (m *Mux) Handle(taskType string, h Handler) {
+	if taskType == "" || h == nil {
+		panic("taskqueue: Handle needs a task type and a handler")
+	}
+	if _, ok := m.handlers[taskType]; ok {
+		panic(fmt.Errorf("taskqueue: task type %s handled twice", taskType))
+	}
+	m.handlers[taskType] = h
+}
+
+// Types returns the task types with a handler, sorted.
+func (m *Mux) Types() []string {
+	types := make([]string, 0, len(m.handlers))
+	for t := range m.handlers {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// Processor runs the due tasks of its queues with the handlers of a Mux,
+// from Start to Stop.
+type Processor struct {
+	broker Broker
+	mux    *Mux
+
+	queues        []string
+	concurrency   int
+	pollInterval  time.Duration
+	lease         time.Duration
+	maxRetry      int
+	baseBackoff   time.Duration
+	maxBackoff    time.Duration
+	deadRetention time.Duration
+
+	log     logger.Logger
+	tracer  tracer.Tracer
+	metrics metrics.Metrics
+
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewProcessor creates the processor of the queues of cfg. m may be nil.
+func NewProcessor(broker Broker, mux *Mux, cfg config.TaskQueueConfig, log logger.Logger, trc tracer.Tracer, m metrics.Metrics) *Processor {
+	p := &Processor{
+		broker:        broker,
+		mux:           mux,
+		queues:        cfg.Queues,
+		concurrency:   cfg.Concurrency,
+		pollInterval:  time.Duration(cfg.PollInterval) * time.Millisecond,
+		lease:         time.Duration(cfg.Lease) * time.Second,
+		maxRetry:      cfg.Retry.MaxRetry,
+		baseBackoff:   time.Duration(cfg.Retry.BaseBackoff) * time.Second,
+		maxBackoff:    time.Duration(cfg.Retry.MaxBackoff) * time.Second,
+		deadRetention: time.Duration(cfg.DeadRetention) * time.Hour,
+		log:           log.WithField("component", "taskqueue"),
+		tracer:        trc,
+		metrics:       m,
+		stop:          make(chan struct{}),
+	}
+	if len(p.queues) == 0 {
+		p.queues = []string{DefaultQueue}
+	}
+	if p.concurrency <= 0 {
+		p.concurrency = 10
+	}
+	if p.pollInterval <= 0 {
+		p.pollInterval = time.Second
+	}
+	if p.lease <= 0 {
+		p.lease = time.Minute
+	}
+	if p.maxRetry <= 0 {
+		p.maxRetry = 5
+	}
+	if p.baseBackoff <= 0 {
+		p.baseBackoff = 10 * time.Second
+	}
+	if p.maxBackoff <= 0 {
+		p.maxBackoff = time.Hour
+	}
+	if p.deadRetention <= 0 {
+		p.deadRetention = 7 * 24 * time.Hour
+	}
+	return p
+}
+
+// Start starts the workers of the processor.
+func (p *Processor) Start() {
+	for range p.concurrency {
+		p.wg.Add(1)
+		go p.work()
+	}
+}
+
+// Stop stops claiming tasks and waits for the running ones to complete.
+func (p *Processor) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+		p.wg.Wait()
+	})
+}
+
+// work runs the due tasks, the queues in order, until Stop.
+func (p *Processor) work() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+		if p.next() {
+			continue
+		}
+		select {
+		case <-p.stop:
+			return
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// next runs the first due task of the queues. It returns false when none is
+// due (or the broker failed).
+func (p *Processor) next() bool {
+	for _, queue := range p.queues {
+		task, err := p.broker.Dequeue(context.Background(), queue, p.lease)
+		if err != nil {
+			p.log.WithFields(map[string]any{"queue": queue, "error": err.Error()}).Error("failed to dequeue task")
+			return false
+		}
+		if task != nil {
+			p.process(task)
+			return true
+		}
+	}
+	return false
+}
+
+// process runs task and settles it: completed, retried or dead. A running
+// task completes during a graceful stop, within its lease.
+func (p *Processor) process(task *Task) {
+	start := time.Now()
+
+	ctx := p.tracer.Extract(context.Background(), task.Trace)
+	span, ctx := p.tracer.StartSpan(ctx, "task "+task.Type)
+	span.SetTag("task.id", task.ID)
+	span.SetTag("task.queue", task.Queue)
+	span.SetTag("task.retried", task.Retried)
+	runCtx, cancel := context.WithTimeout(ctx, p.lease)
+	err := p.run(runCtx, task)
+	cancel()
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.Finish()
+
+	status := statusOK
+	var settleErr error
+	if err == nil {
+		settleErr = p.broker.Complete(ctx, task)
+	} else {
+		status = p.fail(task, err)
+		if status == statusRetry {
+			settleErr = p.broker.Retry(ctx, task)
+		} else {
+			settleErr = p.broker.Kill(ctx, task, p.deadRetention)
+		}
+		p.log.WithContext(ctx).WithFields(map[string]any{
+			"task_type":    task.Type,
+			"task_id":      task.ID,
+			"retried":      task.Retried,
+			"status":       status,
+			"error_detail": err.Error(),
+		}).Error("task failed")
+	}
+	if settleErr != nil {
+		// The task is run again once its lease expires.
+		p.log.WithFields(map[string]any{"task_id": task.ID, "error": settleErr.Error()}).Error("failed to settle task")
+	}
+
+	if p.metrics != nil {
+		p.metrics.Timing(metricProcessDuration, time.Since(start), []string{"type:" + task.Type, "status:" + status})
+	}
+}
+
+// run calls the handler of task, turning a panic into an error.
+func (p *Processor) run(ctx context.Context, task *Task) (err error) {
+	h, ok := p.mux.handlers[task.Type]
+	if !ok {
+		// Retried: a newer release may enqueue it before the processors
+		// handling it are deployed.
+		return fmt.Errorf("no handler for task type %s", task.Type)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panicked: %v", r)
+		}
+	}()
+	return h(ctx, task)
+}
+
+// fail records the failed run of task and returns its status: retried while
+// retries remain, dead otherwise.
+func (p *Processor) fail(task *Task, err error) string {
+	maxRetry := p.maxRetry
+	if task.MaxRetry != nil {
+		maxRetry = *task.MaxRetry
+	}
+	task.LastError = err.Error()
+	if task.Retried >= maxRetry || IsPermanent(err) {
+		return statusDead
+	}
+
+	backoff := p.baseBackoff << task.Retried
+	if backoff <= 0 || backoff > p.maxBackoff {
+		backoff = p.maxBackoff
+	}
+	task.Retried++
+	task.ProcessAt = time.Now().Add(backoff).UnixMilli()
+	return statusRetry
+}
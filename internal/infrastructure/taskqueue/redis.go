@@ -0,0 +1,186 @@
+package taskqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// The keys of a queue share the hash tag {queue}, so that the scripts run on
+// a single node of a cluster:
+//
+//	<prefix>{<queue>}:task:<id>  the task, JSON
+//	<prefix>{<queue>}:scheduled  the waiting tasks, scored by due time
+//	<prefix>{<queue>}:active     the claimed tasks, scored by lease deadline
+//	<prefix>{<queue>}:dead       the dead tasks, scored by time of death
+var (
+	enqueueScript = redis.NewScript(`
+if not redis.call("SET", KEYS[1], ARGV[1], "NX") then
+	return 0
+end
+redis.call("ZADD", KEYS[2], ARGV[2], ARGV[3])
+return 1`)
+	// The tasks whose lease expired are claimed again first.
+	dequeueScript = redis.NewScript(`
+local expired = redis.call("ZRANGEBYSCORE", KEYS[2], "-inf", ARGV[1])
+for _, id in ipairs(expired) do
+	redis.call("ZREM", KEYS[2], id)
+	redis.call("ZADD", KEYS[1], ARGV[1], id)
+end
+while true do
+	local ids = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, 1)
+	if #ids == 0 then
+		return false
+	end
+	redis.call("ZREM", KEYS[1], ids[1])
+	local task = redis.call("GET", ARGV[3] .. ids[1])
+	if task then
+		redis.call("ZADD", KEYS[2], ARGV[2], ids[1])
+		return task
+	end
+end`)
+)
+
+// redisClient is the part of the Redis client the broker uses.
+type redisClient interface {
+	redis.Cmdable
+	redis.Scripter
+}
+
+type redisBroker struct {
+	client redisClient
+	prefix string
+}
+
+// NewRedisBroker returns a Broker keeping the tasks in Redis under prefix
+// (default "voyago:tasks:"), shared by the processes using the same Redis.
+func NewRedisBroker(client redisClient, prefix string) Broker {
+	if prefix == "" {
+		prefix = "voyago:tasks:"
+	}
+	return &redisBroker{client: client, prefix: prefix}
+}
+
+func (b *redisBroker) key(queue, name string) string {
+	return b.prefix + "{" + queue + "}:" + name
+}
+
+func (b *redisBroker) taskKey(queue, id string) string {
+	return b.key(queue, "task:"+id)
+}
+
+func (b *redisBroker) Enqueue(ctx context.Context, task *Task) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	added, err := enqueueScript.Run(ctx, b.client,
+		[]string{b.taskKey(task.Queue, task.ID), b.key(task.Queue, "scheduled")},
+		data, task.ProcessAt, task.ID,
+	).Int()
+	if err != nil {
+		return err
+	}
+	if added == 0 {
+		return ErrDuplicateTask
+	}
+	return nil
+}
+
+func (b *redisBroker) Dequeue(ctx context.Context, queue string, lease time.Duration) (*Task, error) {
+	now := time.Now()
+	data, err := dequeueScript.Run(ctx, b.client,
+		[]string{b.key(queue, "scheduled"), b.key(queue, "active")},
+		now.UnixMilli(), now.Add(lease).UnixMilli(), b.key(queue, "task:"),
+	).Text()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var task Task
+	if err := json.Unmarshal([]byte(data), &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (b *redisBroker) Complete(ctx context.Context, task *Task) error {
+	_, err := b.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.ZRem(ctx, b.key(task.Queue, "active"), task.ID)
+		p.Del(ctx, b.taskKey(task.Queue, task.ID))
+		return nil
+	})
+	return err
+}
+
+func (b *redisBroker) Retry(ctx context.Context, task *Task) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	_, err = b.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, b.taskKey(task.Queue, task.ID), data, 0)
+		p.ZRem(ctx, b.key(task.Queue, "active"), task.ID)
+		p.ZAdd(ctx, b.key(task.Queue, "scheduled"), redis.Z{Score: float64(task.ProcessAt), Member: task.ID})
+		return nil
+	})
+	return err
+}
+
+// Kill expires the dead task after retention; the dead set is trimmed of the
+// expired ones.
+func (b *redisBroker) Kill(ctx context.Context, task *Task, retention time.Duration) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	dead := b.key(task.Queue, "dead")
+	_, err = b.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, b.taskKey(task.Queue, task.ID), data, retention)
+		p.ZRem(ctx, b.key(task.Queue, "active"), task.ID)
+		p.ZAdd(ctx, dead, redis.Z{Score: float64(now.UnixMilli()), Member: task.ID})
+		p.ZRemRangeByScore(ctx, dead, "-inf", strconv.FormatInt(now.Add(-retention).UnixMilli(), 10))
+		return nil
+	})
+	return err
+}
+
+func (b *redisBroker) Dead(ctx context.Context, queue string, limit int) ([]*Task, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	ids, err := b.client.ZRevRange(ctx, b.key(queue, "dead"), 0, int64(limit-1)).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = b.taskKey(queue, id)
+	}
+	values, err := b.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*Task, 0, len(values))
+	for _, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			continue // expired
+		}
+		var task Task
+		if err := json.Unmarshal([]byte(data), &task); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, &task)
+	}
+	return tasks, nil
+}
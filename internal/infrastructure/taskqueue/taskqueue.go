@@ -0,0 +1,224 @@
+// Package taskqueue runs deferred work out of the request that asked for it:
+// a use case enqueues a typed task, run later by a Processor (booking
+// reminders, e-mails, calls to flaky partners), retried with an exponential
+// backoff until it succeeds or dies.
+//
+// The tasks are kept by a Broker: in Redis, shared by every process (see
+// NewRedisBroker), or in memory for a single process (see NewMemoryBroker).
+// A task runs at least once: a processor crashing or overrunning the lease of
+// a task hands it to another one, so handlers must be idempotent.
+//
+//	var ReminderTask = taskqueue.NewType[ReminderPayload]("booking.payment_reminder")
+//
+//	// in the use case, once the booking is committed
+//	task, _ := ReminderTask.New(payload, taskqueue.ProcessIn(24*time.Hour), taskqueue.ID("reminder:"+id))
+//	taskqueue.EnqueueAfterCommit(txCtx, log, tasks, task)
+//
+//	// in the module
+//	mux.Handle(ReminderTask.Name, ReminderTask.Handler(uc.Execute))
+package taskqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	baserepo "voyago/core-api/internal/pkg/repository"
+	"voyago/core-api/internal/pkg/uid"
+)
+
+// DefaultQueue receives the tasks enqueued without Queue.
+const DefaultQueue = "default"
+
+// ErrDuplicateTask is returned by Enqueue when a task with the same ID is
+// already enqueued (see ID).
+var ErrDuplicateTask = errors.New("taskqueue: task already enqueued")
+
+// Task is a unit of work of a given type, with its JSON payload.
+type Task struct {
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+	Queue   string          `json:"queue"`
+	// ProcessAt is when the task is due, in Unix milliseconds.
+	ProcessAt int64 `json:"process_at"`
+	// MaxRetry overrides task_queue.retry.max_retry when set.
+	MaxRetry *int `json:"max_retry,omitempty"`
+	// Retried counts the failed runs of the task.
+	Retried   int    `json:"retried"`
+	LastError string `json:"last_error,omitempty"`
+	// Trace is the trace context of the enqueuer: the run of the task joins
+	// its trace.
+	Trace tracer.TraceContext `json:"trace,omitempty"`
+}
+
+// Option sets an option of a task.
+type Option func(*Task)
+
+// ID sets the ID of the task, making it unique: enqueuing it again while it
+// is enqueued fails with ErrDuplicateTask. Without it, a random ID is used.
+func ID(id string) Option {
+	return func(t *Task) { t.ID = id }
+}
+
+// Queue enqueues the task in queue instead of DefaultQueue.
+func Queue(queue string) Option {
+	return func(t *Task) { t.Queue = queue }
+}
+
+// ProcessAt runs the task at at instead of now.
+func ProcessAt(at time.Time) Option {
+	return func(t *Task) { t.ProcessAt = at.UnixMilli() }
+}
+
+// ProcessIn runs the task after d.
+func ProcessIn(d time.Duration) Option {
+	return func(t *Task) { t.ProcessAt = time.Now().Add(d).UnixMilli() }
+}
+
+// MaxRetry sets the number of retries of the failing task before it dies,
+// 0 running it once.
+func MaxRetry(n int) Option {
+	return func(t *Task) { t.MaxRetry = &n }
+}
+
+// NewTask returns a task of type taskType with the JSON payload, due now in
+// DefaultQueue unless changed by opts.
+func NewTask(taskType string, payload json.RawMessage, opts ...Option) *Task {
+	t := &Task{
+		ID:        uid.UUIDv7.NewID(),
+		Type:      taskType,
+		Payload:   payload,
+		Queue:     DefaultQueue,
+		ProcessAt: time.Now().UnixMilli(),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Enqueuer enqueues tasks.
+type Enqueuer interface {
+	// Enqueue stores task until it is due and processed.
+	Enqueue(ctx context.Context, task *Task) error
+}
+
+// Broker keeps the tasks from their enqueuing to their completion.
+type Broker interface {
+	Enqueuer
+	// Dequeue claims the next due task of queue for lease: the task is
+	// handed to another processor once the lease expired. It returns nil
+	// when no task is due.
+	Dequeue(ctx context.Context, queue string, lease time.Duration) (*Task, error)
+	// Complete deletes a processed task.
+	Complete(ctx context.Context, task *Task) error
+	// Retry stores the failed task again, due at task.ProcessAt.
+	Retry(ctx context.Context, task *Task) error
+	// Kill keeps the task that failed for good with the dead tasks of its
+	// queue, for retention.
+	Kill(ctx context.Context, task *Task, retention time.Duration) error
+	// Dead returns up to limit dead tasks of queue, the last dead first.
+	Dead(ctx context.Context, queue string, limit int) ([]*Task, error)
+}
+
+// Client enqueues the tasks of the use cases in its broker, within their
+// trace.
+type Client struct {
+	broker Broker
+	tracer tracer.Tracer
+}
+
+var _ Enqueuer = (*Client)(nil)
+
+func NewClient(broker Broker, trc tracer.Tracer) *Client {
+	return &Client{broker: broker, tracer: trc}
+}
+
+func (c *Client) Enqueue(ctx context.Context, task *Task) error {
+	span, ctx := c.tracer.StartSpan(ctx, "taskqueue.enqueue "+task.Type)
+	defer span.Finish()
+	span.SetTag("task.id", task.ID)
+	span.SetTag("task.queue", task.Queue)
+
+	task.Trace = tracer.TraceContext{}
+	c.tracer.Inject(ctx, task.Trace)
+	if err := c.broker.Enqueue(ctx, task); err != nil {
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// EnqueueAfterCommit enqueues task once the transaction of ctx is committed,
+// never when it is rolled back. A failure is logged: the transaction is
+// already committed, the task is lost. A duplicate task is ignored. q may be
+// nil, when the task queue is disabled.
+func EnqueueAfterCommit(ctx context.Context, log logger.Logger, q Enqueuer, task *Task) {
+	if q == nil {
+		return
+	}
+	baserepo.RegisterAfterCommit(ctx, func(ctx context.Context) {
+		if err := q.Enqueue(ctx, task); err != nil && !errors.Is(err, ErrDuplicateTask) {
+			log.WithContext(ctx).WithFields(map[string]any{
+				"task_type":    task.Type,
+				"task_id":      task.ID,
+				"error_detail": err.Error(),
+			}).Error("failed to enqueue task")
+		}
+	})
+}
+
+// Type is a type of task with its payload P.
+type Type[P any] struct {
+	Name string
+}
+
+func NewType[P any](name string) Type[P] {
+	return Type[P]{Name: name}
+}
+
+// New returns a task of this type with payload.
+func (t Type[P]) New(payload P, opts ...Option) (*Task, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s payload: %w", t.Name, err)
+	}
+	return NewTask(t.Name, data, opts...), nil
+}
+
+// Handler returns the handler of the tasks of this type, decoding their
+// payload for fn. A payload that does not decode fails the task for good.
+func (t Type[P]) Handler(fn func(ctx context.Context, payload P) error) Handler {
+	return func(ctx context.Context, task *Task) error {
+		var payload P
+		if err := json.Unmarshal(task.Payload, &payload); err != nil {
+			return Permanent(fmt.Errorf("failed to decode %s payload: %w", t.Name, err))
+		}
+		return fn(ctx, payload)
+	}
+}
+
+// permanentError fails a task without retrying it.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as not worth retrying (e.g., the booking of a reminder
+// was deleted): the task dies at once.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var permanent permanentError
+	return errors.As(err, &permanent)
+}
@@ -0,0 +1,150 @@
+package metrics
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultBufferSize = 10000
+	// bufferReportInterval is how often the drops are reported.
+	bufferReportInterval = 10 * time.Second
+
+	metricDropped = "metrics.dropped"
+)
+
+// Dropper is implemented by the metrics discarding recordings rather than
+// blocking their callers.
+type Dropper interface {
+	// Dropped returns the number of recordings discarded so far.
+	Dropped() uint64
+}
+
+// bufferedMetrics queues the recordings and applies them to next from a
+// single goroutine, so that a slow or unreachable exporter never blocks the
+// callers. Recordings arriving while the queue is full are dropped and
+// counted; the total is reported as the metricDropped gauge.
+type bufferedMetrics struct {
+	next    Metrics
+	queue   chan func(Metrics)
+	dropped atomic.Uint64
+	closed  atomic.Bool
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+var (
+	_ Metrics = (*bufferedMetrics)(nil)
+	_ Dropper = (*bufferedMetrics)(nil)
+)
+
+// NewBufferedMetrics wraps next with a queue of size recordings (default
+// 10000).
+func NewBufferedMetrics(next Metrics, size int) Metrics {
+	if size <= 0 {
+		size = defaultBufferSize
+	}
+	m := &bufferedMetrics{
+		next:  next,
+		queue: make(chan func(Metrics), size),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go m.run()
+	return m
+}
+
+func (m *bufferedMetrics) Incr(name string, tags []string) {
+	m.enqueue(func(next Metrics) { next.Incr(name, tags) })
+}
+
+func (m *bufferedMetrics) Distribution(name string, value float64, tags []string) {
+	m.enqueue(func(next Metrics) { next.Distribution(name, value, tags) })
+}
+
+func (m *bufferedMetrics) Timing(name string, value time.Duration, tags []string) {
+	m.enqueue(func(next Metrics) { next.Timing(name, value, tags) })
+}
+
+func (m *bufferedMetrics) Gauge(name string, value float64, tags []string) {
+	m.enqueue(func(next Metrics) { next.Gauge(name, value, tags) })
+}
+
+func (m *bufferedMetrics) RecordHTTP(method string, path string, routePath string, statusCode int, duration float64) {
+	m.enqueue(func(next Metrics) { next.RecordHTTP(method, path, routePath, statusCode, duration) })
+}
+
+func (m *bufferedMetrics) RecordGRPC(method string, code string, duration float64) {
+	m.enqueue(func(next Metrics) { next.RecordGRPC(method, code, duration) })
+}
+
+// Dropped returns the recordings dropped by the queue, and by next when it
+// drops too.
+func (m *bufferedMetrics) Dropped() uint64 {
+	n := m.dropped.Load()
+	if d, ok := m.next.(Dropper); ok {
+		n += d.Dropped()
+	}
+	return n
+}
+
+// Close applies the queued recordings, then closes next. Recordings made
+// after Close are dropped.
+func (m *bufferedMetrics) Close() error {
+	var err error
+	m.once.Do(func() {
+		m.closed.Store(true)
+		close(m.stop)
+		<-m.done
+		err = m.next.Close()
+	})
+	return err
+}
+
+func (m *bufferedMetrics) enqueue(record func(Metrics)) {
+	if m.closed.Load() {
+		m.dropped.Add(1)
+		return
+	}
+	select {
+	case m.queue <- record:
+	default:
+		m.dropped.Add(1)
+	}
+}
+
+func (m *bufferedMetrics) run() {
+	defer close(m.done)
+	ticker := time.NewTicker(bufferReportInterval)
+	defer ticker.Stop()
+
+	var reported uint64
+	report := func() {
+		if n := m.dropped.Load(); n != reported {
+			reported = n
+			m.next.Gauge(metricDropped, float64(n), nil)
+		}
+	}
+
+	for {
+		select {
+		case record := <-m.queue:
+			record(m.next)
+		case <-ticker.C:
+			report()
+		case <-m.stop:
+			for {
+				select {
+				case record := <-m.queue:
+					record(m.next)
+				default:
+					report()
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	// unknownTagValue replaces the tag values outside of their schema, so that
+	// a malformed value cannot create a series of its own.
+	unknownTagValue = "OTHER"
+	maxTagValueLen  = 64
+)
+
+// tagValuePattern is the schema of the enumerated tag values (error codes,
+// statuses): upper snake case identifiers.
+var tagValuePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// instrument is a metric with a fixed name and tag keys.
+type instrument struct {
+	name string
+	keys []string
+}
+
+// tags pairs the keys of i with values, replacing the values outside of the
+// schema with unknownTagValue. It panics when the counts differ: the
+// instruments are only recorded by Business.
+func (i instrument) tags(values ...string) []string {
+	if len(values) != len(i.keys) {
+		panic(fmt.Sprintf("metrics: %s takes %d tags, got %d", i.name, len(i.keys), len(values)))
+	}
+	enums := make([]string, len(values))
+	for n, v := range values {
+		enums[n] = enumValue(v)
+	}
+	return i.pair(enums...)
+}
+
+// pair pairs the keys of i with values as they are, for the values bounded
+// by their caller.
+func (i instrument) pair(values ...string) []string {
+	tags := make([]string, len(values))
+	for n, v := range values {
+		tags[n] = i.keys[n] + ":" + v
+	}
+	return tags
+}
+
+// enumValue returns v, unknownTagValue when it is outside of the schema of
+// the enumerated tag values.
+func enumValue(v string) string {
+	if len(v) > maxTagValueLen || !tagValuePattern.MatchString(v) {
+		return unknownTagValue
+	}
+	return v
+}
+
+// The business instruments: their names and tag keys are the schema shared by
+// the dashboards and alerts.
+var (
+	bookingCreated       = instrument{name: "booking.created"}
+	bookingAmount        = instrument{name: "booking.amount"}
+	bookingFailed        = instrument{name: "booking.failed", keys: []string{"code"}}
+	paymentStatusChanged = instrument{name: "booking.payment_status.changed", keys: []string{"from", "to"}}
+)
+
+// Business records the business events of the use cases through
+// pre-defined instruments, so that the use cases never build tags: every
+// method records the same names with the same tag keys, and tag values are
+// restricted to upper snake case identifiers (error codes, statuses), which
+// keeps the cardinality bounded.
+//
+//	bm := metrics.NewBusiness(m)
+//	bm.BookingCreated(e.TotalAmount)
+//
+// A nil *Business records nothing.
+type Business struct {
+	metrics Metrics
+}
+
+// NewBusiness creates a Business recording to m.
+func NewBusiness(m Metrics) *Business {
+	return &Business{metrics: m}
+}
+
+// BookingCreated counts a created booking and records its total amount.
+func (b *Business) BookingCreated(amount float64) {
+	if b == nil {
+		return
+	}
+	b.metrics.Incr(bookingCreated.name, bookingCreated.tags())
+	b.metrics.Distribution(bookingAmount.name, amount, bookingAmount.tags())
+}
+
+// BookingFailed counts a booking rejected with the error code (e.g.,
+// "BOOKING_CODE_ALREADY_EXISTS").
+func (b *Business) BookingFailed(code string) {
+	if b == nil {
+		return
+	}
+	b.metrics.Incr(bookingFailed.name, bookingFailed.tags(code))
+}
+
+// PaymentStatusChanged counts a payment status transition (e.g., "UNPAID" to
+// "PAID").
+func (b *Business) PaymentStatusChanged(from, to string) {
+	if b == nil {
+		return
+	}
+	b.metrics.Incr(paymentStatusChanged.name, paymentStatusChanged.tags(from, to))
+}
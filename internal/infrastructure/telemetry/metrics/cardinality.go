@@ -0,0 +1,176 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+)
+
+const (
+	defaultMaxSeries = 1000
+	// overflowTagValue replaces the tag values of the combinations beyond the
+	// cap: they are all recorded as a single series.
+	overflowTagValue = "other"
+
+	metricCardinalityOverflow = "metrics.cardinality.overflow"
+
+	// httpRouteSeries and grpcMethodSeries key the routes and methods
+	// recorded by RecordHTTP and RecordGRPC.
+	httpRouteSeries  = "http.request"
+	grpcMethodSeries = "grpc.request"
+)
+
+// cardinalityGuard keeps the tags of the metrics within an allowlist of keys
+// and caps the unique tag combinations of each metric, protecting the backend
+// from series explosions (e.g., raw paths or IDs used as tag values). The
+// routes of RecordHTTP and the methods of RecordGRPC are capped the same way.
+type cardinalityGuard struct {
+	next      Metrics
+	maxSeries int
+	allowlist map[string]map[string]bool
+
+	mu     sync.Mutex
+	series map[string]map[string]struct{}
+}
+
+var (
+	_ Metrics = (*cardinalityGuard)(nil)
+	_ Dropper = (*cardinalityGuard)(nil)
+)
+
+// NewCardinalityGuard wraps next with the allowlist and cap of cfg.
+func NewCardinalityGuard(next Metrics, cfg config.MetricsCardinalityConfig) Metrics {
+	g := &cardinalityGuard{
+		next:      next,
+		maxSeries: cfg.MaxSeries,
+		allowlist: make(map[string]map[string]bool, len(cfg.Allowlist)),
+		series:    map[string]map[string]struct{}{},
+	}
+	if g.maxSeries <= 0 {
+		g.maxSeries = defaultMaxSeries
+	}
+	for _, entry := range cfg.Allowlist {
+		keys := make(map[string]bool, len(entry.Tags))
+		for _, k := range entry.Tags {
+			keys[strings.TrimSpace(k)] = true
+		}
+		g.allowlist[entry.Metric] = keys
+	}
+	return g
+}
+
+func (g *cardinalityGuard) Incr(name string, tags []string) {
+	g.next.Incr(name, g.guard(name, tags))
+}
+
+func (g *cardinalityGuard) Distribution(name string, value float64, tags []string) {
+	g.next.Distribution(name, value, g.guard(name, tags))
+}
+
+func (g *cardinalityGuard) Timing(name string, value time.Duration, tags []string) {
+	g.next.Timing(name, value, g.guard(name, tags))
+}
+
+func (g *cardinalityGuard) Gauge(name string, value float64, tags []string) {
+	g.next.Gauge(name, value, g.guard(name, tags))
+}
+
+func (g *cardinalityGuard) RecordHTTP(method string, path string, routePath string, statusCode int, duration float64) {
+	if !g.admit(httpRouteSeries, routePath) {
+		routePath = overflowTagValue
+	}
+	g.next.RecordHTTP(method, path, routePath, statusCode, duration)
+}
+
+func (g *cardinalityGuard) RecordGRPC(method string, code string, duration float64) {
+	if !g.admit(grpcMethodSeries, method) {
+		method = overflowTagValue
+	}
+	g.next.RecordGRPC(method, code, duration)
+}
+
+// Dropped returns the drops of the wrapped metrics: the guard only rewrites
+// tags.
+func (g *cardinalityGuard) Dropped() uint64 {
+	if d, ok := g.next.(Dropper); ok {
+		return d.Dropped()
+	}
+	return 0
+}
+
+func (g *cardinalityGuard) Close() error {
+	return g.next.Close()
+}
+
+// guard drops the tags outside of the allowlist of name, then replaces the
+// values of a new combination beyond the cap with overflowTagValue.
+func (g *cardinalityGuard) guard(name string, tags []string) []string {
+	if keys, ok := g.allowlist[name]; ok {
+		allowed := make([]string, 0, len(tags))
+		for _, t := range tags {
+			if keys[tagKey(t)] {
+				allowed = append(allowed, t)
+			}
+		}
+		tags = allowed
+	}
+	if len(tags) == 0 || g.admit(name, strings.Join(tags, ",")) {
+		return tags
+	}
+
+	overflow := make([]string, len(tags))
+	for i, t := range tags {
+		overflow[i] = tagKey(t) + ":" + overflowTagValue
+	}
+	return overflow
+}
+
+// admit reports whether the series of name can be recorded: it was already
+// seen, or the cap of name is not reached yet. A refused series is counted
+// in metricCardinalityOverflow.
+func (g *cardinalityGuard) admit(name, series string) bool {
+	g.mu.Lock()
+	seen, ok := g.series[name]
+	if !ok {
+		seen = map[string]struct{}{}
+		g.series[name] = seen
+	}
+	_, known := seen[series]
+	admitted := known || len(seen) < g.maxSeries
+	if admitted && !known {
+		seen[series] = struct{}{}
+	}
+	g.mu.Unlock()
+
+	if !admitted {
+		g.next.Incr(metricCardinalityOverflow, []string{"metric:" + name})
+	}
+	return admitted
+}
+
+// tagKey returns the key of a "key:value" tag, the tag itself without colon.
+func tagKey(tag string) string {
+	key, _, _ := strings.Cut(tag, ":")
+	return key
+}
+
+// handlerMetrics exposes the Handler of wrapped metrics.
+type handlerMetrics struct {
+	Metrics
+	handler http.Handler
+}
+
+func (m handlerMetrics) Handler() http.Handler {
+	return m.handler
+}
+
+// withHandler keeps the Handler of next reachable through its wrapper m.
+func withHandler(m, next Metrics) Metrics {
+	h, ok := next.(Handler)
+	if !ok || m == next {
+		return m
+	}
+	return handlerMetrics{Metrics: m, handler: h.Handler()}
+}
@@ -37,6 +37,10 @@ func (m *datadogMetrics) Timing(name string, value time.Duration, tags []string)
 	_ = m.client.Timing(name, value, tags, 1.0)
 }
 
+func (m *datadogMetrics) Gauge(name string, value float64, tags []string) {
+	_ = m.client.Gauge(name, value, tags, 1.0)
+}
+
 func (m *datadogMetrics) RecordHTTP(method string, path string, routePath string, statusCode int, duration float64) {
 	tags := []string{
 		fmt.Sprintf("method:%s", method),
@@ -49,6 +53,15 @@ func (m *datadogMetrics) RecordHTTP(method string, path string, routePath string
 	_ = m.client.Distribution("http.request.duration", duration, tags, 1.0)
 }
 
+func (m *datadogMetrics) RecordGRPC(method string, code string, duration float64) {
+	tags := []string{
+		fmt.Sprintf("resource:%s", method),
+		fmt.Sprintf("grpc_code:%s", code),
+	}
+	_ = m.client.Incr("grpc.request.total", tags, 1.0)
+	_ = m.client.Distribution("grpc.request.duration", duration, tags, 1.0)
+}
+
 func (m *datadogMetrics) Close() error {
 	return m.client.Close()
 }
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// moduleTagPattern is the schema of the module names (see apperror.Register):
+// lower snake case identifiers.
+var moduleTagPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// unknownModule is the module of the error codes registered by no module.
+const unknownModule = "unknown"
+
+// The error instruments, the signals of the error alerts: the errors by code
+// and the latency of the failing requests.
+var (
+	appError        = instrument{name: "app_error.total", keys: []string{"code", "kind", "module", "retryable"}}
+	httpServerError = instrument{name: "http.server_error.duration", keys: []string{"method", "route", "status_code"}}
+	grpcServerError = instrument{name: "grpc.server_error.duration", keys: []string{"method", "code"}}
+)
+
+// Errors records the errors answered by the transports: every error counted
+// by code, and the latency of the server errors (5xx, or the gRPC codes
+// logged as errors) apart from the latency of the other requests, so that
+// slow failures (e.g., timeouts) are not averaged out by the successes.
+//
+//	em := metrics.NewErrors(m)
+//	em.AppError("BOOKING_NOT_FOUND", "PERSISTANCE", "booking", false)
+//
+// A nil *Errors records nothing.
+type Errors struct {
+	metrics Metrics
+}
+
+// NewErrors creates an Errors recording to m.
+func NewErrors(m Metrics) *Errors {
+	return &Errors{metrics: m}
+}
+
+// AppError counts an error answered with code and kind, registered by module
+// (see apperror.Register), empty for the codes of no module (recorded as
+// "unknown"). The codes and kinds outside of the upper snake case schema, and
+// the modules outside of the lower snake case one, are recorded as OTHER.
+func (e *Errors) AppError(code, kind, module string, retryable bool) {
+	if e == nil {
+		return
+	}
+	switch {
+	case module == "":
+		module = unknownModule
+	case len(module) > maxTagValueLen || !moduleTagPattern.MatchString(module):
+		module = unknownTagValue
+	}
+	e.metrics.Incr(appError.name, appError.pair(enumValue(code), enumValue(kind), module, strconv.FormatBool(retryable)))
+}
+
+// HTTPServerError records the duration of a request answered with a 5xx
+// status, by route (the pattern, not the raw path).
+func (e *Errors) HTTPServerError(method, route string, statusCode int, d time.Duration) {
+	if e == nil {
+		return
+	}
+	e.metrics.Timing(httpServerError.name, d, httpServerError.pair(method, route, strconv.Itoa(statusCode)))
+}
+
+// GRPCServerError records the duration of a call answered with a server
+// error code (e.g., "Internal", "Unavailable").
+func (e *Errors) GRPCServerError(method, code string, d time.Duration) {
+	if e == nil {
+		return
+	}
+	e.metrics.Timing(grpcServerError.name, d, grpcServerError.pair(method, code))
+}
@@ -18,6 +18,10 @@ type Metrics interface {
 	// Timing records the duration of an operation.
 	Timing(name string, value time.Duration, tags []string)
 
+	// Gauge records the current value of a quantity that goes up and down
+	// (e.g., open connections). The last recorded value wins.
+	Gauge(name string, value float64, tags []string)
+
 	// RecordHTTP captures performance data for an incoming HTTP request.
 	//
 	// Parameters:
@@ -30,13 +34,24 @@ type Metrics interface {
 	// and a Histogram/Summary for latency distribution (P99, P95).
 	RecordHTTP(method string, path string, routePath string, statusCode int, duration float64)
 
+	// RecordGRPC captures performance data for an incoming gRPC call.
+	//
+	// Parameters:
+	//   - method: The full gRPC method name (e.g., "/booking.v1.BookingService/CreateBooking").
+	//   - code: The gRPC status code name (e.g., "OK", "InvalidArgument").
+	//   - duration: Total execution time in seconds (float64).
+	RecordGRPC(method string, code string, duration float64)
+
 	// Close flushes any buffered metrics and closes the connection to the provider.
 	Close() error
 }
 
 // New creates a new Metrics instance based on the provided TelemetryConfig.
 // It returns a NoOp (No-Operation) implementation if telemetry is disabled.
-// Supported types: "datadog", "otel".
+// Supported types: "datadog", "otel", "prometheus" (see Handler), "statsd".
+// With cfg.Cardinality enabled, the tags are bounded (see
+// NewCardinalityGuard); with cfg.Buffer enabled, the metrics are recorded in
+// the background (see NewBufferedMetrics).
 //
 // Parameters:
 //   - cfg: The telemetry settings.
@@ -50,15 +65,33 @@ func New(cfg *config.TelemetryConfig, env string) (Metrics, error) {
 		return NewNoOpMetrics(), nil
 	}
 
+	var m Metrics
+	var err error
 	switch cfg.Type {
 	case "datadog":
-		return NewDatadogMetrics(
+		m, err = NewDatadogMetrics(
 			cfg.MetricsAddress,
 			cfg.Namespace,
 			[]string{"env:" + env},
 		)
 	case "otel":
-		return NewOTelMetrics(
+		m, err = NewOTelMetrics(
+			cfg.MetricsAddress,
+			cfg.Namespace,
+			[]string{"env:" + env},
+		)
+	case "prometheus":
+		// Recorded in memory: nothing to buffer.
+		m, err = NewPrometheusMetrics(
+			cfg.Namespace,
+			[]string{"env:" + env},
+		)
+		if err != nil {
+			return nil, err
+		}
+		return withHandler(guard(cfg, m), m), nil
+	case "statsd":
+		m, err = NewStatsDMetrics(
 			cfg.MetricsAddress,
 			cfg.Namespace,
 			[]string{"env:" + env},
@@ -66,4 +99,38 @@ func New(cfg *config.TelemetryConfig, env string) (Metrics, error) {
 	default:
 		return NewNoOpMetrics(), nil
 	}
+	if err != nil {
+		return nil, err
+	}
+	return buffer(cfg, guard(cfg, m)), nil
+}
+
+// NewFallback creates the metrics replacing those of cfg when their exporter
+// failed to start: StatsD metrics sent to cfg.StatsDFallback, or NoOp without
+// a fallback address.
+func NewFallback(cfg *config.TelemetryConfig, env string) (Metrics, error) {
+	if cfg.StatsDFallback == "" {
+		return NewNoOpMetrics(), nil
+	}
+	m, err := NewStatsDMetrics(cfg.StatsDFallback, cfg.Namespace, []string{"env:" + env})
+	if err != nil {
+		return nil, err
+	}
+	return buffer(cfg, guard(cfg, m)), nil
+}
+
+// guard is applied under the buffer: the tags are checked off the request
+// path.
+func guard(cfg *config.TelemetryConfig, m Metrics) Metrics {
+	if !cfg.Cardinality.Enabled {
+		return m
+	}
+	return NewCardinalityGuard(m, cfg.Cardinality)
+}
+
+func buffer(cfg *config.TelemetryConfig, m Metrics) Metrics {
+	if !cfg.Buffer.Enabled {
+		return m
+	}
+	return NewBufferedMetrics(m, cfg.Buffer.Size)
 }
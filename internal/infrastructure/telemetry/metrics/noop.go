@@ -10,6 +10,8 @@ func NewNoOpMetrics() Metrics                                                 {
 func (m *noOpMetrics) Incr(name string, tags []string)                        {}
 func (m *noOpMetrics) Distribution(name string, value float64, tags []string) {}
 func (m *noOpMetrics) Timing(name string, value time.Duration, tags []string) {}
+func (m *noOpMetrics) Gauge(name string, value float64, tags []string)        {}
 func (m *noOpMetrics) RecordHTTP(method string, path string, routePath string, status int, duration float64) {
 }
-func (m *noOpMetrics) Close() error { return nil }
+func (m *noOpMetrics) RecordGRPC(method string, code string, duration float64) {}
+func (m *noOpMetrics) Close() error                                            { return nil }
@@ -22,6 +22,7 @@ type otelMetrics struct {
 	meter    metric.Meter
 	counters sync.Map
 	histos   sync.Map
+	gauges   sync.Map
 }
 
 var _ Metrics = (*otelMetrics)(nil)
@@ -161,6 +162,24 @@ func (m *otelMetrics) Distribution(name string, value float64, tags []string) {
 	histogram.Record(context.Background(), value, metric.WithAttributes(m.parseAttributes(tags)...))
 }
 
+func (m *otelMetrics) Gauge(name string, value float64, tags []string) {
+	cleanName := m.sanitizeName(name)
+
+	var gauge metric.Float64Gauge
+	if val, ok := m.gauges.Load(cleanName); ok {
+		gauge = val.(metric.Float64Gauge)
+	} else {
+		var err error
+		gauge, err = m.meter.Float64Gauge(cleanName, metric.WithDescription("Current value of "+name))
+		if err != nil {
+			return
+		}
+		m.gauges.Store(cleanName, gauge)
+	}
+
+	gauge.Record(context.Background(), value, metric.WithAttributes(m.parseAttributes(tags)...))
+}
+
 func (m *otelMetrics) RecordHTTP(method string, path string, routePath string, statusCode int, duration float64) {
 	// Standard attributes based on OTel semantic conventions
 	tags := []attribute.KeyValue{
@@ -175,6 +194,17 @@ func (m *otelMetrics) RecordHTTP(method string, path string, routePath string, s
 	m.recordDistributionWithAttributes("http.request.duration", duration, tags)
 }
 
+func (m *otelMetrics) RecordGRPC(method string, code string, duration float64) {
+	tags := []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.method", method),
+		attribute.String("rpc.grpc.status_code", code),
+	}
+
+	m.recordWithAttributes("grpc.request.total", 1, tags)
+	m.recordDistributionWithAttributes("grpc.request.duration", duration, tags)
+}
+
 func (m *otelMetrics) Close() error {
 	if m.provider != nil {
 		return m.provider.Shutdown(context.Background())
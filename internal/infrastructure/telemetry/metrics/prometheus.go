@@ -0,0 +1,283 @@
+package metrics
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler is implemented by the drivers scraped over HTTP rather than
+// pushing to a collector.
+type Handler interface {
+	// Handler serves the metrics in the Prometheus text format.
+	Handler() http.Handler
+}
+
+// prometheusMetrics keeps the metrics in its own registry, scraped through
+// Handler. The label names of a metric are those of the tags of its first
+// recording: later recordings leave the missing labels empty and drop the
+// extra ones.
+type prometheusMetrics struct {
+	registry    *prometheus.Registry
+	handler     http.Handler
+	namespace   string
+	constLabels prometheus.Labels
+
+	mu         sync.Mutex
+	counters   map[string]*vec[*prometheus.CounterVec]
+	histograms map[string]*vec[*prometheus.HistogramVec]
+	gauges     map[string]*vec[*prometheus.GaugeVec]
+}
+
+// vec is a registered metric with its label names, or the registration error
+// of a name already taken by another kind of metric.
+type vec[T any] struct {
+	metric T
+	labels []string
+	err    error
+}
+
+var (
+	_ Metrics = (*prometheusMetrics)(nil)
+	_ Handler = (*prometheusMetrics)(nil)
+)
+
+func NewPrometheusMetrics(namespace string, tags []string) (Metrics, error) {
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collectors.NewGoCollector()); err != nil {
+		return nil, err
+	}
+	if err := registry.Register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{})); err != nil {
+		return nil, err
+	}
+
+	constLabels := prometheus.Labels{}
+	for _, l := range parseLabels(tags) {
+		constLabels[l.name] = l.value
+	}
+
+	return &prometheusMetrics{
+		registry:    registry,
+		handler:     promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}),
+		namespace:   sanitizePrometheusName(namespace),
+		constLabels: constLabels,
+		counters:    map[string]*vec[*prometheus.CounterVec]{},
+		histograms:  map[string]*vec[*prometheus.HistogramVec]{},
+		gauges:      map[string]*vec[*prometheus.GaugeVec]{},
+	}, nil
+}
+
+func (m *prometheusMetrics) Handler() http.Handler {
+	return m.handler
+}
+
+func (m *prometheusMetrics) Incr(name string, tags []string) {
+	name = sanitizePrometheusName(name)
+	if !strings.HasSuffix(name, "_total") {
+		name += "_total"
+	}
+	labels := m.parseLabels(tags)
+
+	c := m.counter(name, "Total count of "+name, labels)
+	if c.err == nil {
+		c.metric.WithLabelValues(labelValues(c.labels, labels)...).Inc()
+	}
+}
+
+// Timing records the duration in seconds, under the name suffixed with
+// "_duration_seconds".
+func (m *prometheusMetrics) Timing(name string, value time.Duration, tags []string) {
+	name = strings.TrimSuffix(sanitizePrometheusName(name), "_duration")
+	m.Distribution(name+"_duration_seconds", value.Seconds(), tags)
+}
+
+func (m *prometheusMetrics) Distribution(name string, value float64, tags []string) {
+	name = sanitizePrometheusName(name)
+	labels := m.parseLabels(tags)
+
+	h := m.histogram(name, "Distribution of "+name, labels)
+	if h.err == nil {
+		h.metric.WithLabelValues(labelValues(h.labels, labels)...).Observe(value)
+	}
+}
+
+func (m *prometheusMetrics) Gauge(name string, value float64, tags []string) {
+	name = sanitizePrometheusName(name)
+	labels := m.parseLabels(tags)
+
+	g := m.gauge(name, "Current value of "+name, labels)
+	if g.err == nil {
+		g.metric.WithLabelValues(labelValues(g.labels, labels)...).Set(value)
+	}
+}
+
+func (m *prometheusMetrics) RecordHTTP(method string, path string, routePath string, statusCode int, duration float64) {
+	labels := []label{
+		{"method", method},
+		{"route", routePath},
+		{"status_code", strconv.Itoa(statusCode)},
+	}
+
+	total := m.counter("http_requests_total", "Total count of HTTP requests", labels)
+	if total.err == nil {
+		total.metric.WithLabelValues(labelValues(total.labels, labels)...).Inc()
+	}
+	latency := m.histogram("http_request_duration_seconds", "Duration of HTTP requests", labels)
+	if latency.err == nil {
+		latency.metric.WithLabelValues(labelValues(latency.labels, labels)...).Observe(duration)
+	}
+}
+
+func (m *prometheusMetrics) RecordGRPC(method string, code string, duration float64) {
+	labels := []label{
+		{"method", method},
+		{"code", code},
+	}
+
+	total := m.counter("grpc_requests_total", "Total count of gRPC calls", labels)
+	if total.err == nil {
+		total.metric.WithLabelValues(labelValues(total.labels, labels)...).Inc()
+	}
+	latency := m.histogram("grpc_request_duration_seconds", "Duration of gRPC calls", labels)
+	if latency.err == nil {
+		latency.metric.WithLabelValues(labelValues(latency.labels, labels)...).Observe(duration)
+	}
+}
+
+// Close does nothing: the metrics are pulled by the scraper.
+func (m *prometheusMetrics) Close() error {
+	return nil
+}
+
+// parseLabels parses tags, dropping the labels set on every metric (e.g.,
+// "env").
+func (m *prometheusMetrics) parseLabels(tags []string) []label {
+	return slices.DeleteFunc(parseLabels(tags), func(l label) bool {
+		_, ok := m.constLabels[l.name]
+		return ok
+	})
+}
+
+func (m *prometheusMetrics) counter(name, help string, labels []label) *vec[*prometheus.CounterVec] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.counters[name]; ok {
+		return c
+	}
+	c := &vec[*prometheus.CounterVec]{labels: labelNames(labels)}
+	c.metric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   m.namespace,
+		Name:        name,
+		Help:        help,
+		ConstLabels: m.constLabels,
+	}, c.labels)
+	c.err = m.registry.Register(c.metric)
+	m.counters[name] = c
+	return c
+}
+
+func (m *prometheusMetrics) histogram(name, help string, labels []label) *vec[*prometheus.HistogramVec] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if h, ok := m.histograms[name]; ok {
+		return h
+	}
+	h := &vec[*prometheus.HistogramVec]{labels: labelNames(labels)}
+	h.metric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace:   m.namespace,
+		Name:        name,
+		Help:        help,
+		ConstLabels: m.constLabels,
+		// 5ms to 10s, as the OTel HTTP view.
+		Buckets: prometheus.DefBuckets,
+	}, h.labels)
+	h.err = m.registry.Register(h.metric)
+	m.histograms[name] = h
+	return h
+}
+
+func (m *prometheusMetrics) gauge(name, help string, labels []label) *vec[*prometheus.GaugeVec] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if g, ok := m.gauges[name]; ok {
+		return g
+	}
+	g := &vec[*prometheus.GaugeVec]{labels: labelNames(labels)}
+	g.metric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace:   m.namespace,
+		Name:        name,
+		Help:        help,
+		ConstLabels: m.constLabels,
+	}, g.labels)
+	g.err = m.registry.Register(g.metric)
+	m.gauges[name] = g
+	return g
+}
+
+type label struct {
+	name  string
+	value string
+}
+
+// parseLabels turns "key:value" tags into labels; a tag without a colon is
+// the value of the "tag" label. A repeated name keeps its last value.
+func parseLabels(tags []string) []label {
+	labels := make([]label, 0, len(tags))
+	for _, t := range tags {
+		l := label{name: "tag", value: t}
+		if name, value, ok := strings.Cut(t, ":"); ok {
+			l = label{name: sanitizePrometheusName(name), value: value}
+		}
+		if i := slices.IndexFunc(labels, func(o label) bool { return o.name == l.name }); i >= 0 {
+			labels[i] = l
+			continue
+		}
+		labels = append(labels, l)
+	}
+	return labels
+}
+
+func labelNames(labels []label) []string {
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.name
+	}
+	return names
+}
+
+// labelValues orders the values of labels as names; a missing label is empty.
+func labelValues(names []string, labels []label) []string {
+	values := make([]string, len(names))
+	for i, name := range names {
+		for _, l := range labels {
+			if l.name == name {
+				values[i] = l.value
+				break
+			}
+		}
+	}
+	return values
+}
+
+// sanitizePrometheusName replaces the characters invalid in metric and label
+// names (e.g., "." and "-") with "_".
+func sanitizePrometheusName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		valid := c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9'
+		if !valid {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,200 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// statsdMaxPacket keeps the packets under the usual network MTU.
+	statsdMaxPacket = 1432
+	// statsdFlushInterval bounds how long a line waits for its packet to fill.
+	statsdFlushInterval = 100 * time.Millisecond
+)
+
+var (
+	// statsdNameReplacer and statsdTagReplacer replace the separators of the
+	// line format.
+	statsdNameReplacer = strings.NewReplacer(":", "_", "|", "_", "@", "_", "\n", "_")
+	statsdTagReplacer  = strings.NewReplacer("|", "_", ",", "_", "\n", "_")
+)
+
+// statsdMetrics sends the metrics over UDP in the StatsD line format, with
+// the DogStatsD tags extension, needing neither an agent library nor a
+// connection: any StatsD server (Datadog agent, statsd_exporter, Telegraf)
+// receives them. Lines are packed into packets flushed when full or every
+// statsdFlushInterval; a packet that cannot be sent is dropped and counted.
+type statsdMetrics struct {
+	conn      net.Conn
+	namespace string
+	tags      string
+
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	dropped atomic.Uint64
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+var (
+	_ Metrics = (*statsdMetrics)(nil)
+	_ Dropper = (*statsdMetrics)(nil)
+)
+
+func NewStatsDMetrics(addr string, namespace string, tags []string) (Metrics, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize statsd: %w", err)
+	}
+	if namespace != "" && !strings.HasSuffix(namespace, ".") {
+		namespace += "."
+	}
+
+	m := &statsdMetrics{
+		conn:      conn,
+		namespace: namespace,
+		tags:      joinStatsDTags(tags),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go m.flushLoop()
+	return m, nil
+}
+
+func (m *statsdMetrics) Incr(name string, tags []string) {
+	m.send(name, "1", "c", tags)
+}
+
+func (m *statsdMetrics) Distribution(name string, value float64, tags []string) {
+	m.send(name, formatStatsDValue(value), "h", tags)
+}
+
+func (m *statsdMetrics) Timing(name string, value time.Duration, tags []string) {
+	m.send(name, formatStatsDValue(float64(value)/float64(time.Millisecond)), "ms", tags)
+}
+
+func (m *statsdMetrics) Gauge(name string, value float64, tags []string) {
+	m.send(name, formatStatsDValue(value), "g", tags)
+}
+
+func (m *statsdMetrics) RecordHTTP(method string, path string, routePath string, statusCode int, duration float64) {
+	tags := []string{
+		"method:" + method,
+		"resource:" + routePath,
+		"status:" + strconv.Itoa(statusCode),
+		fmt.Sprintf("status_group:%dxx", statusCode/100),
+	}
+	m.Incr("http.request.total", tags)
+	m.Distribution("http.request.duration", duration, tags)
+}
+
+func (m *statsdMetrics) RecordGRPC(method string, code string, duration float64) {
+	tags := []string{
+		"resource:" + method,
+		"grpc_code:" + code,
+	}
+	m.Incr("grpc.request.total", tags)
+	m.Distribution("grpc.request.duration", duration, tags)
+}
+
+// Dropped returns the number of lines that could not be sent.
+func (m *statsdMetrics) Dropped() uint64 {
+	return m.dropped.Load()
+}
+
+// Close sends the pending lines and closes the socket.
+func (m *statsdMetrics) Close() error {
+	var err error
+	m.once.Do(func() {
+		close(m.stop)
+		<-m.done
+		err = m.conn.Close()
+	})
+	return err
+}
+
+// send appends a line to the pending packet, flushing the packet first when
+// the line does not fit.
+func (m *statsdMetrics) send(name, value, kind string, tags []string) {
+	var line strings.Builder
+	line.WriteString(m.namespace)
+	line.WriteString(statsdNameReplacer.Replace(name))
+	line.WriteByte(':')
+	line.WriteString(value)
+	line.WriteByte('|')
+	line.WriteString(kind)
+	if t := joinStatsDTags(tags); t != "" || m.tags != "" {
+		line.WriteString("|#")
+		line.WriteString(m.tags)
+		if t != "" && m.tags != "" {
+			line.WriteByte(',')
+		}
+		line.WriteString(t)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.buf.Len() > 0 && m.buf.Len()+1+line.Len() > statsdMaxPacket {
+		m.flushLocked()
+	}
+	if m.buf.Len() > 0 {
+		m.buf.WriteByte('\n')
+	}
+	m.buf.WriteString(line.String())
+}
+
+func (m *statsdMetrics) flushLoop() {
+	defer close(m.done)
+	ticker := time.NewTicker(statsdFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.flush()
+		case <-m.stop:
+			m.flush()
+			return
+		}
+	}
+}
+
+func (m *statsdMetrics) flush() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.flushLocked()
+}
+
+// flushLocked sends the pending packet. UDP never blocks on the receiver:
+// an unreachable server only shows as write errors, counted as drops.
+func (m *statsdMetrics) flushLocked() {
+	if m.buf.Len() == 0 {
+		return
+	}
+	if _, err := m.conn.Write(m.buf.Bytes()); err != nil {
+		m.dropped.Add(uint64(bytes.Count(m.buf.Bytes(), []byte{'\n'}) + 1))
+	}
+	m.buf.Reset()
+}
+
+func joinStatsDTags(tags []string) string {
+	clean := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t != "" {
+			clean = append(clean, statsdTagReplacer.Replace(t))
+		}
+	}
+	return strings.Join(clean, ",")
+}
+
+func formatStatsDValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
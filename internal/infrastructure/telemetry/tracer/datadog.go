@@ -2,36 +2,126 @@ package tracer
 
 import (
 	"context"
+	"encoding/binary"
+	"fmt"
 	"strconv"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
 
 	gormtrace "gopkg.in/DataDog/dd-trace-go.v1/contrib/gorm.io/gorm.v1"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 	"gorm.io/gorm"
 )
 
 type datadogTracer struct {
 	serviceName string
+	keepErrors  bool
+	slow        time.Duration
 }
 
 type datadogSpan struct {
 	span tracer.Span
+
+	// start, failed, keepErrors and slow decide whether Finish keeps the
+	// trace regardless of its sampling priority.
+	start      time.Time
+	failed     bool
+	keepErrors bool
+	slow       time.Duration
 }
 
 var _ Tracer = (*datadogTracer)(nil)
 
-func NewDatadogTracer(serviceName, env, addr string, sampleRate float64) Tracer {
+// NewDatadogTracer starts the Datadog tracer. The traces are sampled by the
+// sampling rules of the tracer: the rules of sampling, then sampleRate. The
+// failed and slow spans of sampling are kept by setting the manual keep
+// priority of their trace when they finish.
+func NewDatadogTracer(serviceName, env, addr string, sampleRate float64, sampling config.TraceSamplingConfig) Tracer {
+	rules := make([]tracer.SamplingRule, 0, len(sampling.Rules)+1)
+	for _, r := range sampling.Rules {
+		rules = append(rules, tracer.NameRule(r.Name, r.Rate))
+	}
+	rules = append(rules, tracer.RateRule(sampleRate))
+
 	tracer.Start(
 		tracer.WithService(serviceName),
 		tracer.WithEnv(env),
 		tracer.WithAgentAddr(addr),
-		tracer.WithSampler(tracer.NewRateSampler(sampleRate)),
+		tracer.WithSamplingRules(rules),
 	)
-	return &datadogTracer{serviceName: serviceName}
+	return &datadogTracer{
+		serviceName: serviceName,
+		keepErrors:  sampling.KeepErrors,
+		slow:        time.Duration(sampling.SlowThreshold) * time.Millisecond,
+	}
 }
 
+// remoteParentKey holds the span context extracted from a caller.
+type remoteParentKey struct{}
+
 func (t *datadogTracer) StartSpan(ctx context.Context, name string) (Span, context.Context) {
-	span, ctx := tracer.StartSpanFromContext(ctx, name)
-	return &datadogSpan{span: span}, ctx
+	return t.startSpan(ctx, name)
+}
+
+// startSpan starts a span with opts, child of the remote parent extracted in
+// ctx when ctx holds no span.
+func (t *datadogTracer) startSpan(ctx context.Context, name string, opts ...tracer.StartSpanOption) (Span, context.Context) {
+	if _, ok := tracer.SpanFromContext(ctx); !ok {
+		if parent, ok := ctx.Value(remoteParentKey{}).(ddtrace.SpanContext); ok {
+			opts = append(opts, tracer.ChildOf(parent))
+		}
+	}
+	span, ctx := tracer.StartSpanFromContext(ctx, name, opts...)
+	return &datadogSpan{
+		span:       span,
+		start:      time.Now(),
+		keepErrors: t.keepErrors,
+		slow:       t.slow,
+	}, ctx
+}
+
+func (t *datadogTracer) StartSpanWithLinks(ctx context.Context, name string, links ...Link) (Span, context.Context) {
+	ddLinks := make([]ddtrace.SpanLink, 0, len(links))
+	for _, l := range links {
+		sc, err := tracer.Extract(datadogCarrier{l.Context})
+		if err != nil {
+			continue
+		}
+		link := ddtrace.SpanLink{TraceID: sc.TraceID(), SpanID: sc.SpanID()}
+		if w3c, ok := sc.(ddtrace.SpanContextW3C); ok {
+			id := w3c.TraceID128Bytes()
+			link.TraceIDHigh = binary.BigEndian.Uint64(id[:8])
+		}
+		if len(l.Attributes) > 0 {
+			link.Attributes = make(map[string]string, len(l.Attributes))
+			for k, v := range l.Attributes {
+				link.Attributes[k] = fmt.Sprintf("%v", v)
+			}
+		}
+		ddLinks = append(ddLinks, link)
+	}
+
+	return t.startSpan(ctx, name, tracer.WithSpanLinks(ddLinks))
+}
+
+// Extract reads the Datadog (x-datadog-*) and W3C headers, following the
+// DD_TRACE_PROPAGATION_STYLE_EXTRACT setting. Baggage items travel with the
+// extracted span context and are inherited by its children.
+func (t *datadogTracer) Extract(ctx context.Context, carrier Carrier) context.Context {
+	parent, err := tracer.Extract(datadogCarrier{carrier})
+	if err != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteParentKey{}, parent)
+}
+
+// Inject writes the headers selected by DD_TRACE_PROPAGATION_STYLE_INJECT.
+func (t *datadogTracer) Inject(ctx context.Context, carrier Setter) {
+	if span, ok := tracer.SpanFromContext(ctx); ok {
+		_ = tracer.Inject(span.Context(), carrier)
+	}
 }
 
 func (t *datadogTracer) ExtractTraceInfo(ctx context.Context) (traceID, spanID string, ok bool) {
@@ -51,14 +141,54 @@ func (t *datadogTracer) Close() error {
 	return nil
 }
 
+// datadogCarrier adapts a Carrier to tracer.TextMapReader.
+type datadogCarrier struct {
+	Carrier
+}
+
+func (c datadogCarrier) ForeachKey(handler func(key, val string) error) error {
+	for _, key := range c.Keys() {
+		if err := handler(key, c.Get(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *datadogSpan) SetOperationName(name string) {
 	s.span.SetOperationName(name)
 }
 
+// Finish keeps the trace when the span failed or was slow (see
+// NewDatadogTracer).
 func (s *datadogSpan) Finish() {
+	if (s.keepErrors && s.failed) || (s.slow > 0 && time.Since(s.start) >= s.slow) {
+		s.span.SetTag(ext.ManualKeep, true)
+	}
 	s.span.Finish()
 }
 
 func (s *datadogSpan) SetTag(key string, value any) {
+	if key == ext.Error && value == true {
+		s.failed = true
+	}
 	s.span.SetTag(key, value)
 }
+
+func (s *datadogSpan) AddEvent(name string, attrs map[string]any) {
+	ddtrace.AddSpanEvent(s.span, name, ddtrace.WithSpanEventAttributes(attrs))
+}
+
+// RecordError sets the error tags of the span (error.message, error.type,
+// error.stack) and records the exception event.
+func (s *datadogSpan) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.failed = true
+	s.span.SetTag(ext.Error, err)
+	s.AddEvent("exception", map[string]any{
+		"exception.message": err.Error(),
+		"exception.type":    fmt.Sprintf("%T", err),
+	})
+}
@@ -20,6 +20,16 @@ func (t *noOpTracer) StartSpan(ctx context.Context, name string) (Span, context.
 	return &noOpSpan{}, ctx
 }
 
+func (t *noOpTracer) StartSpanWithLinks(ctx context.Context, name string, links ...Link) (Span, context.Context) {
+	return &noOpSpan{}, ctx
+}
+
+func (t *noOpTracer) Extract(ctx context.Context, carrier Carrier) context.Context {
+	return ctx
+}
+
+func (t *noOpTracer) Inject(ctx context.Context, carrier Setter) {}
+
 func (t *noOpTracer) UseGorm(db *gorm.DB) {}
 
 func (t *noOpTracer) ExtractTraceInfo(ctx context.Context) (traceID, spanID string, ok bool) {
@@ -35,3 +45,7 @@ func (s *noOpSpan) SetOperationName(name string) {}
 func (s *noOpSpan) Finish() {}
 
 func (s *noOpSpan) SetTag(key string, value any) {}
+
+func (s *noOpSpan) AddEvent(name string, attrs map[string]any) {}
+
+func (s *noOpSpan) RecordError(err error) {}
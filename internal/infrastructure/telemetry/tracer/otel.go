@@ -3,6 +3,8 @@ package tracer
 import (
 	"context"
 	"fmt"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/pkg/sqlmask"
 
 	"gorm.io/gorm"
 
@@ -20,16 +22,21 @@ import (
 type otelTracer struct {
 	provider    *sdktrace.TracerProvider
 	tracer      trace.Tracer
+	propagator  propagation.TextMapPropagator
 	serviceName string
+	sampler     *ruleSampler
 }
 
 type otelSpan struct {
 	span trace.Span
 }
 
-var _ Tracer = (*otelTracer)(nil)
+var (
+	_ Tracer    = (*otelTracer)(nil)
+	_ Resampler = (*otelTracer)(nil)
+)
 
-func NewOTelTracer(serviceName, env, addr string, sampleRate float64) (Tracer, error) {
+func NewOTelTracer(serviceName, env, addr string, sampleRate float64, sampling config.TraceSamplingConfig) (Tracer, error) {
 	ctx := context.Background()
 
 	// Create OTLP exporter
@@ -53,31 +60,69 @@ func NewOTelTracer(serviceName, env, addr string, sampleRate float64) (Tracer, e
 	}
 
 	// Create tracer provider
+	sampler, root := newSampler(sampleRate, sampling)
 	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSpanProcessor(NewKeepProcessor(sdktrace.NewBatchSpanProcessor(exporter), sampling)),
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(sampleRate)),
+		sdktrace.WithSampler(sampler),
 	)
 
-	// Set global tracer provider
-	otel.SetTracerProvider(tp)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+	// W3C trace context and baggage
+	propagator := propagation.NewCompositeTextMapPropagator(
 		propagation.TraceContext{},
 		propagation.Baggage{},
-	))
+	)
+
+	// Set global tracer provider
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagator)
 
 	return &otelTracer{
 		provider:    tp,
 		tracer:      tp.Tracer(serviceName),
+		propagator:  propagator,
 		serviceName: serviceName,
+		sampler:     root,
 	}, nil
 }
 
+// SetSampling replaces the default rate and the rules of the root spans.
+// The failed and slow spans are kept as configured at startup.
+func (t *otelTracer) SetSampling(sampleRate float64, cfg config.TraceSamplingConfig) {
+	t.sampler.set(sampleRate, cfg.Rules)
+}
+
 func (t *otelTracer) StartSpan(ctx context.Context, name string) (Span, context.Context) {
 	ctx, span := t.tracer.Start(ctx, name)
 	return &otelSpan{span: span}, ctx
 }
 
+func (t *otelTracer) StartSpanWithLinks(ctx context.Context, name string, links ...Link) (Span, context.Context) {
+	otelLinks := make([]trace.Link, 0, len(links))
+	for _, l := range links {
+		sc := trace.SpanContextFromContext(t.propagator.Extract(context.Background(), otelCarrier{l.Context}))
+		if !sc.IsValid() {
+			continue
+		}
+		attrs := make([]attribute.KeyValue, 0, len(l.Attributes))
+		for k, v := range l.Attributes {
+			attrs = append(attrs, attributeOf(k, v))
+		}
+		otelLinks = append(otelLinks, trace.Link{SpanContext: sc, Attributes: attrs})
+	}
+
+	ctx, span := t.tracer.Start(ctx, name, trace.WithLinks(otelLinks...))
+	return &otelSpan{span: span}, ctx
+}
+
+func (t *otelTracer) Extract(ctx context.Context, carrier Carrier) context.Context {
+	return t.propagator.Extract(ctx, otelCarrier{carrier})
+}
+
+func (t *otelTracer) Inject(ctx context.Context, carrier Setter) {
+	t.propagator.Inject(ctx, otelSetter{carrier})
+}
+
 func (t *otelTracer) ExtractTraceInfo(ctx context.Context) (traceID, spanID string, ok bool) {
 	span := trace.SpanFromContext(ctx)
 	if !span.IsRecording() {
@@ -109,7 +154,7 @@ func (t *otelTracer) beforeCallback(db *gorm.DB) {
 	ctx, span := t.tracer.Start(db.Statement.Context, "gorm:"+db.Statement.Table,
 		trace.WithSpanKind(trace.SpanKindClient),
 		trace.WithAttributes(
-			attribute.String("db.system", "postgresql"),
+			attribute.String("db.system", dbSystem(db.Dialector)),
 			attribute.String("db.table", db.Statement.Table),
 		),
 	)
@@ -117,6 +162,20 @@ func (t *otelTracer) beforeCallback(db *gorm.DB) {
 	db.InstanceSet("otel:span", span)
 }
 
+// dbSystem returns the OpenTelemetry db.system of a GORM dialector.
+func dbSystem(d gorm.Dialector) string {
+	if d == nil {
+		return "other_sql"
+	}
+	switch name := d.Name(); name {
+	case "postgres":
+		return "postgresql"
+	case "mysql", "sqlite":
+		return name
+	}
+	return "other_sql"
+}
+
 func (t *otelTracer) afterCallback(db *gorm.DB) {
 	if val, ok := db.InstanceGet("otel:span"); ok {
 		if span, ok := val.(trace.Span); ok {
@@ -126,11 +185,10 @@ func (t *otelTracer) afterCallback(db *gorm.DB) {
 			} else {
 				span.SetStatus(codes.Ok, "")
 			}
-			sqlStatement := db.Statement.SQL.String()
-			// sqlStatementMask := utils.MaskSensitive(sqlStatement)
+			// The literals of raw queries are obfuscated; the bound values
+			// (db.Statement.Vars) are never attached.
 			span.SetAttributes(
-				// attribute.String("db.statement", db.Explain(db.Statement.SQL.String(), db.Statement.Vars...)),
-				attribute.String("db.statement", sqlStatement),
+				attribute.String("db.statement", sqlmask.Obfuscate(db.Statement.SQL.String(), db.Dialector.Name())),
 				attribute.Int64("db.rows_affected", db.RowsAffected),
 			)
 			span.End()
@@ -145,6 +203,21 @@ func (t *otelTracer) Close() error {
 	return nil
 }
 
+// otelCarrier adapts a Carrier to the read-only use of propagation.TextMapCarrier.
+type otelCarrier struct {
+	Carrier
+}
+
+func (c otelCarrier) Set(key, value string) {}
+
+// otelSetter adapts a Setter to the write-only use of propagation.TextMapCarrier.
+type otelSetter struct {
+	Setter
+}
+
+func (c otelSetter) Get(key string) string { return "" }
+func (c otelSetter) Keys() []string        { return nil }
+
 func (s *otelSpan) SetOperationName(name string) {
 	s.span.SetName(name)
 }
@@ -154,18 +227,42 @@ func (s *otelSpan) Finish() {
 }
 
 func (s *otelSpan) SetTag(key string, value any) {
+	s.span.SetAttributes(attributeOf(key, value))
+}
+
+func (s *otelSpan) AddEvent(name string, attrs map[string]any) {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		kvs = append(kvs, attributeOf(k, v))
+	}
+	s.span.AddEvent(name, trace.WithAttributes(kvs...))
+}
+
+// RecordError records the exception event with the stack trace and sets the
+// status of the span to Error.
+func (s *otelSpan) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.span.RecordError(err, trace.WithStackTrace(true))
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// attributeOf converts a tag value to an attribute, formatting the
+// unsupported types.
+func attributeOf(key string, value any) attribute.KeyValue {
 	switch v := value.(type) {
 	case string:
-		s.span.SetAttributes(attribute.String(key, v))
+		return attribute.String(key, v)
 	case int:
-		s.span.SetAttributes(attribute.Int(key, v))
+		return attribute.Int(key, v)
 	case int64:
-		s.span.SetAttributes(attribute.Int64(key, v))
+		return attribute.Int64(key, v)
 	case float64:
-		s.span.SetAttributes(attribute.Float64(key, v))
+		return attribute.Float64(key, v)
 	case bool:
-		s.span.SetAttributes(attribute.Bool(key, v))
+		return attribute.Bool(key, v)
 	default:
-		s.span.SetAttributes(attribute.String(key, fmt.Sprintf("%v", v)))
+		return attribute.String(key, fmt.Sprintf("%v", v))
 	}
 }
@@ -0,0 +1,219 @@
+package tracer
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// samplingRule is a compiled config.TraceSamplingRule.
+type samplingRule struct {
+	name    *regexp.Regexp
+	sampler sdktrace.Sampler
+}
+
+// compileRules compiles the glob patterns of rules.
+func compileRules(rules []config.TraceSamplingRule) []samplingRule {
+	compiled := make([]samplingRule, 0, len(rules))
+	for _, r := range rules {
+		compiled = append(compiled, samplingRule{
+			name:    globPattern(r.Name),
+			sampler: sdktrace.TraceIDRatioBased(r.Rate),
+		})
+	}
+	return compiled
+}
+
+// globPattern converts a pattern where "*" matches any characters and "?" a
+// single one (the syntax of the Datadog sampling rules) to an anchored
+// regular expression.
+func globPattern(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// keeps reports whether cfg keeps the failed or slow spans regardless of
+// their sampling rate.
+func keeps(cfg config.TraceSamplingConfig) bool {
+	return cfg.KeepErrors || cfg.SlowThreshold > 0
+}
+
+// ruleSampler samples the root spans at the rate of the first rule matching
+// their name, or at the default rate. When the failed or slow spans are kept,
+// the spans it does not sample are still recorded (RecordOnly), so that the
+// keepProcessor can export them once they end. The rates may be changed
+// while spans are sampled (see set).
+type ruleSampler struct {
+	rates  atomic.Pointer[samplingRates]
+	record bool
+}
+
+// samplingRates are the rules and the default rate of a ruleSampler.
+type samplingRates struct {
+	rules    []samplingRule
+	fallback sdktrace.Sampler
+}
+
+// NewSampler creates the OTel sampler of sampleRate and cfg: root spans are
+// sampled by the rules, child spans follow their parent. A child of an
+// unsampled parent is recorded with it when cfg keeps the failed or slow
+// spans.
+func NewSampler(sampleRate float64, cfg config.TraceSamplingConfig) sdktrace.Sampler {
+	sampler, _ := newSampler(sampleRate, cfg)
+	return sampler
+}
+
+// newSampler is NewSampler also returning the sampler of the root spans.
+func newSampler(sampleRate float64, cfg config.TraceSamplingConfig) (sdktrace.Sampler, *ruleSampler) {
+	root := &ruleSampler{record: keeps(cfg)}
+	root.set(sampleRate, cfg.Rules)
+	notSampled := unsampledParentSampler{record: root.record}
+	return sdktrace.ParentBased(root,
+		sdktrace.WithLocalParentNotSampled(notSampled),
+		sdktrace.WithRemoteParentNotSampled(notSampled),
+	), root
+}
+
+// set replaces the default rate and the rules of the sampler.
+func (s *ruleSampler) set(sampleRate float64, rules []config.TraceSamplingRule) {
+	s.rates.Store(&samplingRates{
+		rules:    compileRules(rules),
+		fallback: sdktrace.TraceIDRatioBased(sampleRate),
+	})
+}
+
+func (s *ruleSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	rates := s.rates.Load()
+	sampler := rates.fallback
+	for _, r := range rates.rules {
+		if r.name.MatchString(p.Name) {
+			sampler = r.sampler
+			break
+		}
+	}
+
+	res := sampler.ShouldSample(p)
+	if res.Decision == sdktrace.Drop && s.record {
+		res.Decision = sdktrace.RecordOnly
+	}
+	return res
+}
+
+func (s *ruleSampler) Description() string {
+	return "RuleSampler"
+}
+
+// unsampledParentSampler records the children of an unsampled parent when the
+// failed or slow spans are kept. The children of a local parent that is not
+// recorded either are dropped.
+type unsampledParentSampler struct {
+	record bool
+}
+
+func (s unsampledParentSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	parent := trace.SpanFromContext(p.ParentContext)
+	res := sdktrace.SamplingResult{
+		Decision:   sdktrace.Drop,
+		Tracestate: parent.SpanContext().TraceState(),
+	}
+	if s.record && (parent.SpanContext().IsRemote() || parent.IsRecording()) {
+		res.Decision = sdktrace.RecordOnly
+	}
+	return res
+}
+
+func (s unsampledParentSampler) Description() string {
+	return "UnsampledParentSampler"
+}
+
+// keepProcessor exports the spans sampled by the sampler through next, and the
+// recorded but unsampled ones that failed (Error status or "error" tag) or
+// lasted at least the slow threshold.
+type keepProcessor struct {
+	next       sdktrace.SpanProcessor
+	keepErrors bool
+	slow       time.Duration
+}
+
+// NewKeepProcessor wraps next with the kept spans of cfg.
+func NewKeepProcessor(next sdktrace.SpanProcessor, cfg config.TraceSamplingConfig) sdktrace.SpanProcessor {
+	if !keeps(cfg) {
+		return next
+	}
+	return &keepProcessor{
+		next:       next,
+		keepErrors: cfg.KeepErrors,
+		slow:       time.Duration(cfg.SlowThreshold) * time.Millisecond,
+	}
+}
+
+func (p *keepProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+func (p *keepProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		p.next.OnEnd(s)
+		return
+	}
+	if p.keep(s) {
+		p.next.OnEnd(keptSpan{s})
+	}
+}
+
+func (p *keepProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *keepProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+func (p *keepProcessor) keep(s sdktrace.ReadOnlySpan) bool {
+	if p.slow > 0 && s.EndTime().Sub(s.StartTime()) >= p.slow {
+		return true
+	}
+	if !p.keepErrors {
+		return false
+	}
+	if s.Status().Code == codes.Error {
+		return true
+	}
+	for _, kv := range s.Attributes() {
+		if kv.Key == "error" && kv.Value.Type() == attribute.BOOL && kv.Value.AsBool() {
+			return true
+		}
+	}
+	return false
+}
+
+// keptSpan flags an unsampled span as sampled, so that the batch processor
+// exports it.
+type keptSpan struct {
+	sdktrace.ReadOnlySpan
+}
+
+func (s keptSpan) SpanContext() trace.SpanContext {
+	sc := s.ReadOnlySpan.SpanContext()
+	return sc.WithTraceFlags(sc.TraceFlags().WithSampled(true))
+}
@@ -4,7 +4,9 @@ package tracer
 
 import (
 	"context"
+	"strings"
 	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
 
 	"gorm.io/gorm"
 )
@@ -16,6 +18,25 @@ type Tracer interface {
 	// Always call Finish() on the returned Span to avoid memory leaks.
 	StartSpan(ctx context.Context, name string) (Span, context.Context)
 
+	// StartSpanWithLinks starts a span like StartSpan, linked to the spans of
+	// links. A batch worker uses it to relate the span of each item to the
+	// trace that produced the item (e.g., the request that enqueued it), which
+	// is not its parent: the item span stays a child of the batch span.
+	// Links without a valid trace context are ignored.
+	StartSpanWithLinks(ctx context.Context, name string, links ...Link) (Span, context.Context)
+
+	// Extract joins ctx to the distributed trace propagated by a caller (e.g.,
+	// the W3C traceparent/tracestate headers of an incoming request): the next
+	// span started from the returned context is a child of the caller's span.
+	// Propagated baggage is carried by the returned context as well. ctx is
+	// returned as is when the carrier holds no valid trace context.
+	Extract(ctx context.Context, carrier Carrier) context.Context
+
+	// Inject writes the trace context of ctx and its baggage to carrier (e.g.,
+	// the headers of an outgoing request), so that the callee joins the trace.
+	// Nothing is written when ctx holds no span.
+	Inject(ctx context.Context, carrier Setter)
+
 	// UseGorm injects tracing instrumentation into a GORM database instance.
 	UseGorm(db *gorm.DB)
 
@@ -27,6 +48,63 @@ type Tracer interface {
 	Close() error
 }
 
+// Resampler is implemented by the tracers whose sampling can be changed at
+// runtime (see package reload): the OTel tracer. The Datadog tracer applies
+// its sampling rules from its start.
+type Resampler interface {
+	// SetSampling replaces the default rate and the rules of sampling.
+	SetSampling(sampleRate float64, sampling config.TraceSamplingConfig)
+}
+
+// Carrier reads the trace context propagated by a caller, such as the headers
+// of an incoming request or the metadata of a gRPC call.
+type Carrier interface {
+	// Get returns the value of key (case-insensitive), empty when absent.
+	Get(key string) string
+	// Keys lists the keys held by the carrier.
+	Keys() []string
+}
+
+// Setter receives the trace context propagated to a callee. http.Header
+// implements it.
+type Setter interface {
+	// Set sets key to value, replacing any existing value.
+	Set(key, value string)
+}
+
+// TraceContext is a trace context serialized as key/value pairs by Inject
+// (e.g., "traceparent"), stored with the work processed asynchronously so
+// that the worker can link back to the producer. It is a Carrier and a
+// Setter.
+//
+//	tc := tracer.TraceContext{}
+//	trc.Inject(ctx, tc)
+type TraceContext map[string]string
+
+func (c TraceContext) Get(key string) string {
+	return c[strings.ToLower(key)]
+}
+
+func (c TraceContext) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (c TraceContext) Set(key, value string) {
+	c[strings.ToLower(key)] = value
+}
+
+// Link relates a span to the span of another trace.
+type Link struct {
+	// Context is the trace context of the linked span, as written by Inject.
+	Context TraceContext
+	// Attributes describe the relation (e.g., "webhook.delivery_id").
+	Attributes map[string]any
+}
+
 // Span represents a single unit of work within a trace.
 type Span interface {
 	// SetOperationName changes the name of the span after it has been started.
@@ -37,6 +115,33 @@ type Span interface {
 
 	// SetTag attaches metadata to the span for better filtering in dashboards.
 	SetTag(key string, value any)
+
+	// AddEvent records a timestamped event with attributes on the span (an
+	// OTel span event, a Datadog span event), e.g., a retry or a cache miss
+	// happening within the unit of work.
+	AddEvent(name string, attrs map[string]any)
+
+	// RecordError marks the span as failed and records err as an "exception"
+	// event carrying its message and type.
+	RecordError(err error)
+}
+
+// TagCaller attaches the caller of ctx (see ctxkey) to span, under the
+// OTel attribute names: enduser.id, enduser.role (comma separated),
+// tenant.id and client.app. The root spans of the requests are tagged.
+func TagCaller(span Span, ctx context.Context) {
+	if id := ctxkey.GetUserID(ctx); id != "" {
+		span.SetTag("enduser.id", id)
+	}
+	if roles := ctxkey.GetRoles(ctx); len(roles) > 0 {
+		span.SetTag("enduser.role", strings.Join(roles, ","))
+	}
+	if id := ctxkey.GetTenantID(ctx); id != "" {
+		span.SetTag("tenant.id", id)
+	}
+	if app := ctxkey.GetClientApp(ctx); app != "" {
+		span.SetTag("client.app", app)
+	}
 }
 
 // New initializes a new Tracer based on the TelemetryConfig provided.
@@ -62,6 +167,7 @@ func New(cfg *config.TelemetryConfig, env string) (Tracer, error) {
 			env,
 			cfg.TracerAddress,
 			cfg.SampleRate,
+			cfg.Sampling,
 		), nil
 	case "otel":
 		return NewOTelTracer(
@@ -69,6 +175,7 @@ func New(cfg *config.TelemetryConfig, env string) (Tracer, error) {
 			env,
 			cfg.TracerAddress,
 			cfg.SampleRate,
+			cfg.Sampling,
 		)
 	default:
 		return NewNoOpTracer(), nil
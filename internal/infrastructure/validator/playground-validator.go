@@ -1,9 +1,11 @@
 package validator
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
+	"voyago/core-api/internal/pkg/i18n"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -50,12 +52,12 @@ func (v *playgroundValidator) Validate(i any) error {
 	return v.driver.Struct(i)
 }
 
-func (v *playgroundValidator) ToCustomError(err error) []ValidationError {
+func (v *playgroundValidator) ToCustomError(ctx context.Context, err error) []ValidationError {
 	var result []ValidationError
 
 	if ve, ok := err.(validator.ValidationErrors); ok {
 		for _, fe := range ve {
-			message := v.translateTag(fe)
+			message := v.translateTag(ctx, fe)
 			code := fe.Tag()
 			if code == "uuid_rfc4122" {
 				code = "uuid"
@@ -70,11 +72,11 @@ func (v *playgroundValidator) ToCustomError(err error) []ValidationError {
 	return result
 }
 
-func (v *playgroundValidator) ToMap(err error) map[string]any {
+func (v *playgroundValidator) ToMap(ctx context.Context, err error) map[string]any {
 	res := make(map[string]any)
 	if ve, ok := err.(validator.ValidationErrors); ok {
 		for _, fe := range ve {
-			message := v.translateTag(fe)
+			message := v.translateTag(ctx, fe)
 			code := fe.Tag()
 			if code == "uuid_rfc4122" {
 				code = "uuid"
@@ -89,7 +91,7 @@ func (v *playgroundValidator) ToMap(err error) map[string]any {
 	return res
 }
 
-func (v *playgroundValidator) ToDetails(err error) []map[string]any {
+func (v *playgroundValidator) ToDetails(ctx context.Context, err error) []map[string]any {
 	var res []map[string]any
 
 	ve, ok := err.(validator.ValidationErrors)
@@ -98,7 +100,7 @@ func (v *playgroundValidator) ToDetails(err error) []map[string]any {
 	}
 
 	for _, fe := range ve {
-		message := v.translateTag(fe)
+		message := v.translateTag(ctx, fe)
 		code := fe.Tag()
 		if code == "uuid_rfc4122" {
 			code = "uuid"
@@ -115,52 +117,67 @@ func (v *playgroundValidator) ToDetails(err error) []map[string]any {
 	return res
 }
 
-func (v *playgroundValidator) translateTag(fe validator.FieldError) string {
+// translateTag returns the message of the rule fe failed, in the locale of
+// the request of ctx: the message of key validation.<tag> (validation.<tag>_string
+// for the length of a string), the label of the field being translated by
+// labels.<label>.
+func (v *playgroundValidator) translateTag(ctx context.Context, fe validator.FieldError) string {
+	key, message := v.defaultMessage(fe)
+	label := v.getLabel(fe)
+	return i18n.Translate(ctx, "validation."+key, message, map[string]string{
+		"field": i18n.Translate(ctx, "labels."+label, label, nil),
+		"param": fe.Param(),
+	})
+}
+
+// defaultMessage returns the key and the English message of the rule fe
+// failed.
+func (v *playgroundValidator) defaultMessage(fe validator.FieldError) (string, string) {
 	displayLabel := v.getLabel(fe)
 	param := fe.Param()
 
 	switch fe.Tag() {
 	case "required":
-		return fmt.Sprintf("%s is required", displayLabel)
+		return "required", fmt.Sprintf("%s is required", displayLabel)
 
 	case "min":
 		if fe.Type().Kind() == reflect.String {
-			return fmt.Sprintf("%s must be at least %s characters", displayLabel, param)
+			return "min_string", fmt.Sprintf("%s must be at least %s characters", displayLabel, param)
 		}
-		return fmt.Sprintf("%s must be at least %s", displayLabel, param)
+		return "min", fmt.Sprintf("%s must be at least %s", displayLabel, param)
 
 	case "max":
 		if fe.Type().Kind() == reflect.String {
-			return fmt.Sprintf("%s must not be greater than %s characters", displayLabel, param)
+			return "max_string", fmt.Sprintf("%s must not be greater than %s characters", displayLabel, param)
 		}
-		return fmt.Sprintf("%s must not be greater than %s", displayLabel, param)
+		return "max", fmt.Sprintf("%s must not be greater than %s", displayLabel, param)
 
 	case "email":
-		return fmt.Sprintf("%s is an invalid email address", displayLabel)
+		return "email", fmt.Sprintf("%s is an invalid email address", displayLabel)
 
 	case "uuid", "uuid_rfc4122":
-		return fmt.Sprintf("%s must be a valid UUID", displayLabel)
+		return "uuid", fmt.Sprintf("%s must be a valid UUID", displayLabel)
 
 	case "gt":
-		return fmt.Sprintf("%s must be greater than %s", displayLabel, param)
+		return "gt", fmt.Sprintf("%s must be greater than %s", displayLabel, param)
 
 	case "gte":
-		return fmt.Sprintf("%s must be greater than or equal to %s", displayLabel, param)
+		return "gte", fmt.Sprintf("%s must be greater than or equal to %s", displayLabel, param)
 
 	case "lt":
-		return fmt.Sprintf("%s must be less than %s", displayLabel, param)
+		return "lt", fmt.Sprintf("%s must be less than %s", displayLabel, param)
 
 	case "lte":
-		return fmt.Sprintf("%s must be less than or equal to %s", displayLabel, param)
+		return "lte", fmt.Sprintf("%s must be less than or equal to %s", displayLabel, param)
 
 	case "eq":
-		return fmt.Sprintf("%s must be equal to %s", displayLabel, param)
+		return "eq", fmt.Sprintf("%s must be equal to %s", displayLabel, param)
 
 	case "ne":
-		return fmt.Sprintf("%s must not be equal to %s", displayLabel, param)
+		return "ne", fmt.Sprintf("%s must not be equal to %s", displayLabel, param)
 
 	default:
-		return fmt.Sprintf("%s is invalid", displayLabel)
+		return "invalid", fmt.Sprintf("%s is invalid", displayLabel)
 	}
 }
 
@@ -1,5 +1,7 @@
 package validator
 
+import "context"
+
 // ValidationError represents a single field validation failure.
 type ValidationError struct {
 	Field   string `json:"field"`
@@ -15,13 +17,14 @@ type Validator interface {
 
 	// ToCustomError converts a standard validator error into a slice of ValidationError structs.
 	// Useful for internal logic where you need to process errors as objects.
-	ToCustomError(err error) []ValidationError
+	// The messages are in the locale of the request of ctx (see package i18n).
+	ToCustomError(ctx context.Context, err error) []ValidationError
 
 	// ToMap converts validation errors into a map where keys are field names.
 	// Primarily used for simpler, legacy-style error responses.
-	ToMap(err error) map[string]any
+	ToMap(ctx context.Context, err error) map[string]any
 
 	// ToDetails converts validation errors into a slice of key-value maps.
 	// Designed for API responses to provide "field" and "message" keys for Front-End consumption.
-	ToDetails(err error) []map[string]any
+	ToDetails(ctx context.Context, err error) []map[string]any
 }
@@ -0,0 +1,116 @@
+package wsserver
+
+import (
+	"sync"
+	"time"
+
+	fiberws "github.com/gofiber/contrib/websocket"
+)
+
+// client is one open socket. Writes happen on a single goroutine (the
+// socket does not support concurrent writers); reads only process control
+// frames since the socket is push-only.
+type client struct {
+	userID string
+	conn   *fiberws.Conn
+	send   chan []byte
+
+	closeOnce sync.Once
+	closing   chan closeFrame
+	done      chan struct{}
+}
+
+type closeFrame struct {
+	code   int
+	reason string
+}
+
+// close asks the writer to send a close frame and end the connection.
+// It is safe to call more than once and from any goroutine.
+func (cl *client) close(code int, reason string) {
+	cl.closeOnce.Do(func() {
+		cl.closing <- closeFrame{code: code, reason: reason}
+	})
+}
+
+// serve runs for the lifetime of a socket; the connection is closed by the
+// Fiber websocket middleware when it returns.
+func (h *Hub) serve(conn *fiberws.Conn) {
+	userID, _ := conn.Locals(localUserID).(string)
+	cl := &client{
+		userID:  userID,
+		conn:    conn,
+		send:    make(chan []byte, h.bufferSize),
+		closing: make(chan closeFrame, 1),
+		done:    make(chan struct{}),
+	}
+	if !h.register(cl) {
+		_ = conn.WriteControl(fiberws.CloseMessage,
+			fiberws.FormatCloseMessage(fiberws.CloseTryAgainLater, "server shutting down"),
+			time.Now().Add(h.writeTimeout))
+		return
+	}
+	defer h.unregister(cl)
+
+	log := h.log.WithField("user_id", userID)
+	log.Info("websocket connected")
+	defer log.Info("websocket disconnected")
+
+	go h.read(cl)
+	h.write(cl)
+}
+
+// read consumes incoming frames so that pongs and close frames are processed,
+// and closes done when the peer goes away.
+func (h *Hub) read(cl *client) {
+	defer close(cl.done)
+
+	cl.conn.SetReadLimit(maxMessageSize)
+	_ = cl.conn.SetReadDeadline(time.Now().Add(h.pongWait()))
+	cl.conn.SetPongHandler(func(string) error {
+		return cl.conn.SetReadDeadline(time.Now().Add(h.pongWait()))
+	})
+
+	for {
+		if _, _, err := cl.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (h *Hub) write(cl *client) {
+	ticker := time.NewTicker(h.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case frame := <-cl.send:
+			_ = cl.conn.SetWriteDeadline(time.Now().Add(h.writeTimeout))
+			err = cl.conn.WriteMessage(fiberws.TextMessage, frame)
+		case <-ticker.C:
+			err = cl.conn.WriteControl(fiberws.PingMessage, nil, time.Now().Add(h.writeTimeout))
+		case f := <-cl.closing:
+			_ = cl.conn.WriteControl(fiberws.CloseMessage,
+				fiberws.FormatCloseMessage(f.code, f.reason),
+				time.Now().Add(h.writeTimeout))
+			// Give the peer a chance to acknowledge the close handshake.
+			select {
+			case <-cl.done:
+			case <-time.After(h.writeTimeout):
+			}
+			return
+		case <-cl.done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// pongWait is how long a socket may stay silent: a pong is expected for
+// every ping.
+func (h *Hub) pongWait() time.Duration {
+	return h.pingInterval + h.writeTimeout
+}
@@ -0,0 +1,44 @@
+package wsserver
+
+import (
+	"context"
+	"encoding/json"
+	"voyago/core-api/internal/infrastructure/eventbus"
+)
+
+// ForwardEvents pushes every domain event carrying a "user_id" to the
+// sockets of that user. Events without a user are not broadcast.
+func (h *Hub) ForwardEvents(bus eventbus.Bus) {
+	bus.Subscribe(eventbus.Wildcard, h.forward)
+}
+
+func (h *Hub) forward(_ context.Context, evt eventbus.Event) error {
+	userID := recipient(evt.Payload)
+	if userID == "" {
+		return nil
+	}
+
+	h.SendToUser(userID, Message{
+		Type: evt.Type,
+		ID:   evt.ID,
+		Data: evt.Payload,
+	})
+	return nil
+}
+
+// recipient extracts the "user_id" of an event payload. Payloads are the
+// domain structs of each module, so they are inspected through their JSON
+// representation rather than their Go types.
+func recipient(payload any) string {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+	var p struct {
+		UserID string `json:"user_id"`
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return ""
+	}
+	return p.UserID
+}
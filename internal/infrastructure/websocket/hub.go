@@ -0,0 +1,256 @@
+// Package wsserver provides the WebSocket subsystem: a hub of authenticated
+// sockets keyed by user ID, used to push real-time notifications.
+//
+// The hub is mounted on the Fiber app and fed from the event bus:
+//
+//	hub := wsserver.NewHub(cfg.Websocket, log, metrics)
+//	hub.Mount(app, wsserver.NewTokenAuthenticator(cfg.Websocket.Secret))
+//	hub.ForwardEvents(bus)
+//	...
+//	hub.Drain(ctx) // on shutdown
+package wsserver
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/pkg/apperror"
+
+	fiberws "github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	defaultPath         = "/ws"
+	defaultPingInterval = 30 * time.Second
+	defaultWriteTimeout = 10 * time.Second
+	defaultBufferSize   = 32
+	defaultDrainTimeout = 5 * time.Second
+
+	// maxMessageSize bounds client messages: the socket is push-only.
+	maxMessageSize = 512
+
+	localUserID = "ws_user_id"
+
+	metricConnections = "ws.connections"
+	metricDropped     = "ws.connections.dropped"
+
+	CodeWebsocketDraining = "WEBSOCKET_DRAINING"
+)
+
+var ErrDraining = apperror.NewTransient(CodeWebsocketDraining, "server is shutting down, reconnect later")
+
+func init() {
+	apperror.Register(apperror.CoreModule, apperror.Def{Err: ErrDraining})
+}
+
+// Message is the JSON frame pushed to clients.
+type Message struct {
+	// Type is the notification type (e.g., the domain event type "booking.status_changed").
+	Type string `json:"type"`
+	// ID identifies the notification (e.g., the event ID), for client-side de-duplication.
+	ID   string `json:"id,omitempty"`
+	Data any    `json:"data,omitempty"`
+}
+
+// Hub tracks the open sockets of every user.
+type Hub struct {
+	log     logger.Logger
+	metrics metrics.Metrics
+
+	path         string
+	origins      []string
+	pingInterval time.Duration
+	writeTimeout time.Duration
+	bufferSize   int
+	drainTimeout time.Duration
+
+	mu       sync.Mutex
+	clients  map[string]map[*client]struct{}
+	open     int
+	draining bool
+	sockets  sync.WaitGroup
+}
+
+func NewHub(cfg config.WebsocketConfig, log logger.Logger, m metrics.Metrics) *Hub {
+	h := &Hub{
+		log:          log.WithField("component", "websocket"),
+		metrics:      m,
+		path:         defaultPath,
+		origins:      cfg.AllowedOrigins,
+		pingInterval: defaultPingInterval,
+		writeTimeout: defaultWriteTimeout,
+		bufferSize:   defaultBufferSize,
+		drainTimeout: defaultDrainTimeout,
+		clients:      make(map[string]map[*client]struct{}),
+	}
+	if cfg.Path != "" {
+		h.path = cfg.Path
+	}
+	if cfg.PingInterval > 0 {
+		h.pingInterval = time.Duration(cfg.PingInterval) * time.Second
+	}
+	if cfg.WriteTimeout > 0 {
+		h.writeTimeout = time.Duration(cfg.WriteTimeout) * time.Second
+	}
+	if cfg.BufferSize > 0 {
+		h.bufferSize = cfg.BufferSize
+	}
+	if cfg.DrainTimeout > 0 {
+		h.drainTimeout = time.Duration(cfg.DrainTimeout) * time.Second
+	}
+	return h
+}
+
+// Mount registers the WebSocket endpoint. Clients are authenticated on the
+// upgrade request; failures are answered with the standard error response.
+func (h *Hub) Mount(app *fiber.App, auth Authenticator) {
+	app.Use(h.path, func(c *fiber.Ctx) error {
+		if !fiberws.IsWebSocketUpgrade(c) {
+			return apperror.ErrCodeUpgradeRequired
+		}
+		if h.isDraining() {
+			return ErrDraining
+		}
+
+		userID, err := auth.Authenticate(c)
+		if err != nil {
+			return err
+		}
+		c.Locals(localUserID, userID)
+		c.SetUserContext(ctxkey.SetUserID(c.UserContext(), userID))
+		return c.Next()
+	})
+
+	app.Get(h.path, fiberws.New(h.serve, fiberws.Config{
+		Origins: h.origins,
+	}))
+}
+
+// SendToUser queues msg on every socket of userID and returns how many
+// sockets it was queued on. It never blocks: a socket whose buffer is full is
+// disconnected so that the client reconnects and resynchronizes.
+func (h *Hub) SendToUser(userID string, msg Message) int {
+	frame, err := json.Marshal(msg)
+	if err != nil {
+		h.log.WithFields(map[string]any{
+			"type":  msg.Type,
+			"error": err.Error(),
+		}).Error("failed to encode websocket message")
+		return 0
+	}
+
+	h.mu.Lock()
+	sent := 0
+	var slow []*client
+	for cl := range h.clients[userID] {
+		select {
+		case cl.send <- frame:
+			sent++
+		default:
+			slow = append(slow, cl)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, cl := range slow {
+		h.log.WithField("user_id", userID).Warn("websocket client too slow, disconnecting")
+		h.metrics.Incr(metricDropped, nil)
+		cl.close(fiberws.CloseTryAgainLater, "too slow")
+	}
+	return sent
+}
+
+// Path is the route the hub is mounted on.
+func (h *Hub) Path() string {
+	return h.path
+}
+
+// Connections returns the number of open sockets.
+func (h *Hub) Connections() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.open
+}
+
+// Drain refuses new sockets, asks every open socket to close ("going away")
+// and waits for them until ctx is done or the configured drain timeout
+// elapses. Sockets still open afterwards are closed abruptly.
+func (h *Hub) Drain(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, h.drainTimeout)
+	defer cancel()
+
+	h.mu.Lock()
+	h.draining = true
+	var all []*client
+	for _, set := range h.clients {
+		for cl := range set {
+			all = append(all, cl)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, cl := range all {
+		cl.close(fiberws.CloseGoingAway, "server shutting down")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		h.sockets.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		for _, cl := range all {
+			_ = cl.conn.Close()
+		}
+		return ctx.Err()
+	}
+}
+
+func (h *Hub) isDraining() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.draining
+}
+
+func (h *Hub) register(cl *client) bool {
+	h.mu.Lock()
+	if h.draining {
+		h.mu.Unlock()
+		return false
+	}
+	if h.clients[cl.userID] == nil {
+		h.clients[cl.userID] = make(map[*client]struct{})
+	}
+	h.clients[cl.userID][cl] = struct{}{}
+	h.open++
+	open := h.open
+	h.sockets.Add(1)
+	h.mu.Unlock()
+
+	h.metrics.Gauge(metricConnections, float64(open), nil)
+	return true
+}
+
+func (h *Hub) unregister(cl *client) {
+	h.mu.Lock()
+	delete(h.clients[cl.userID], cl)
+	if len(h.clients[cl.userID]) == 0 {
+		delete(h.clients, cl.userID)
+	}
+	h.open--
+	open := h.open
+	h.mu.Unlock()
+
+	h.metrics.Gauge(metricConnections, float64(open), nil)
+	h.sockets.Done()
+}
@@ -0,0 +1,57 @@
+// Package event connects the booking module to the internal event bus.
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/modules/booking/usecase"
+)
+
+type Subscriber struct {
+	ApplyPaymentStatus usecase.ApplyBookingPaymentStatusUseCase
+}
+
+func NewSubscriber(applyPaymentStatus usecase.ApplyBookingPaymentStatusUseCase) *Subscriber {
+	return &Subscriber{ApplyPaymentStatus: applyPaymentStatus}
+}
+
+// Register subscribes to the payment status changes emitted by this module,
+// closing the payment -> booking feedback loop.
+func (s *Subscriber) Register(bus eventbus.Bus) {
+	bus.Subscribe(entity.EventBookingPaymentStatusChanged, s.HandlePaymentStatusChanged)
+}
+
+func (s *Subscriber) HandlePaymentStatusChanged(ctx context.Context, evt eventbus.Event) error {
+	payload, err := decodePayload[entity.BookingPaymentStatusChangedPayload](evt)
+	if err != nil {
+		return err
+	}
+	return s.ApplyPaymentStatus.Execute(ctx, payload)
+}
+
+// decodePayload returns the typed payload of evt. In-process buses deliver the
+// original struct; transports that cross process boundaries deliver decoded
+// JSON, which is converted through a JSON round trip.
+func decodePayload[T any](evt eventbus.Event) (T, error) {
+	var payload T
+	switch p := evt.Payload.(type) {
+	case T:
+		return p, nil
+	case *T:
+		if p != nil {
+			return *p, nil
+		}
+	}
+
+	raw, err := json.Marshal(evt.Payload)
+	if err != nil {
+		return payload, fmt.Errorf("encode %s payload: %w", evt.Type, err)
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return payload, fmt.Errorf("decode %s payload: %w", evt.Type, err)
+	}
+	return payload, nil
+}
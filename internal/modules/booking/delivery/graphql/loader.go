@@ -0,0 +1,39 @@
+package graphql
+
+import (
+	"context"
+	"time"
+	"voyago/core-api/internal/modules/booking/usecase"
+	"voyago/core-api/internal/pkg/dataloader"
+)
+
+type loadersKey struct{}
+
+// Loaders holds the request-scoped dataloaders of the booking module.
+// They batch the lookups of sibling resolvers into a single use case call.
+type Loaders struct {
+	Booking *dataloader.Loader[string, usecase.BookingResponse]
+	Details *dataloader.Loader[string, []usecase.BookingDetailResponse]
+}
+
+func newLoaders(uc HandlerUseCases, wait time.Duration, maxBatch int) *Loaders {
+	return &Loaders{
+		Booking: dataloader.New(uc.GetBookingsByIDsUseCase.Execute, wait, maxBatch),
+		Details: dataloader.New(uc.GetBookingDetailsUseCase.Execute, wait, maxBatch),
+	}
+}
+
+// WithLoaders attaches a fresh set of loaders to ctx.
+// It is registered as the module gqlserver.ContextFunc and runs once per request.
+func (r *Resolver) WithLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(r.Uc, r.batchWait, r.maxBatch))
+}
+
+// loadersFrom returns the loaders of the request. A private set is created
+// when none is attached (e.g., the schema is executed without the gateway handler).
+func (r *Resolver) loadersFrom(ctx context.Context) *Loaders {
+	if l, ok := ctx.Value(loadersKey{}).(*Loaders); ok {
+		return l
+	}
+	return newLoaders(r.Uc, r.batchWait, r.maxBatch)
+}
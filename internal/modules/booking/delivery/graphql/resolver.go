@@ -0,0 +1,188 @@
+/*
+|------------------------------------------------------------------------------------
+| GRAPHQL RESOLVER
+|------------------------------------------------------------------------------------
+|
+| Root query resolvers follow the HTTP handler standards (see delivery/http/handler.go):
+| DTO validation at the entry point, a single Anchor Log and error bubbling.
+| Errors are converted to GraphQL errors by gqlserver.FormatErrors.
+|
+| Nested fields (Booking.details) must go through the request-scoped dataloaders
+| so that a list of N bookings costs one query instead of N.
+|
+|------------------------------------------------------------------------------------
+*/
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/validator"
+	"voyago/core-api/internal/modules/booking/usecase"
+	"voyago/core-api/internal/pkg/apperror"
+
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+// Schema is the SDL fragment of the booking module.
+//
+//go:embed schema.graphql
+var Schema string
+
+type HandlerUseCases struct {
+	ListBookingsUseCase      usecase.ListBookingsUseCase
+	GetBookingsByIDsUseCase  usecase.GetBookingsByIDsUseCase
+	GetBookingDetailsUseCase usecase.GetBookingDetailsUseCase
+}
+
+// Resolver resolves the booking fields of the root Query type.
+// Embed it in the gateway root resolver.
+type Resolver struct {
+	Cfg *config.Config
+	Log logger.Logger
+	Val validator.Validator
+	Uc  HandlerUseCases
+
+	batchWait time.Duration
+	maxBatch  int
+}
+
+func NewResolver(cfg *config.Config, log logger.Logger, validator validator.Validator, useCases HandlerUseCases) *Resolver {
+	batchWait := 2 * time.Millisecond
+	if cfg.Graphql.BatchWait != 0 {
+		batchWait = time.Duration(cfg.Graphql.BatchWait) * time.Millisecond
+	}
+
+	return &Resolver{
+		Cfg:       cfg,
+		Log:       log,
+		Val:       validator,
+		Uc:        useCases,
+		batchWait: batchWait,
+		maxBatch:  cfg.Graphql.MaxBatch,
+	}
+}
+
+type getBookingRequest struct {
+	ID string `json:"id" validate:"required,uuid" label:"ID"`
+}
+
+func (r *Resolver) Booking(ctx context.Context, args struct{ ID gql.ID }) (*BookingResolver, error) {
+	log := r.Log.WithContext(ctx).WithField("method", "Booking")
+
+	request := &getBookingRequest{ID: string(args.ID)}
+	if err := r.Val.Validate(request); err != nil {
+		return nil, apperror.NewPersistance(apperror.CodeInvalidRequest, "Invalid request", err).
+			AddValidationErrors(r.Val.ToDetails(ctx, err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"booking_id": request.ID,
+		},
+	}).Info("request received")
+
+	// Going through the loader lets several booking(id) selections of the
+	// same document share a single query.
+	booking, found, err := r.loadersFrom(ctx).Booking.Load(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+
+	return &BookingResolver{r: r, b: booking}, nil
+}
+
+type bookingsArgs struct {
+	UserID gql.ID
+	Limit  int32
+	Offset int32
+}
+
+func (r *Resolver) Bookings(ctx context.Context, args bookingsArgs) ([]*BookingResolver, error) {
+	log := r.Log.WithContext(ctx).WithField("method", "Bookings")
+
+	request := &usecase.ListBookingsRequest{
+		UserID: string(args.UserID),
+		Limit:  int(args.Limit),
+		Offset: int(args.Offset),
+	}
+
+	if err := r.Val.Validate(request); err != nil {
+		return nil, apperror.NewPersistance(apperror.CodeInvalidRequest, "Invalid request", err).
+			AddValidationErrors(r.Val.ToDetails(ctx, err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"user_id": request.UserID,
+		},
+	}).Info("request received")
+
+	bookings, err := r.Uc.ListBookingsUseCase.Execute(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*BookingResolver, 0, len(bookings))
+	for _, b := range bookings {
+		res = append(res, &BookingResolver{r: r, b: b})
+	}
+	return res, nil
+}
+
+// BookingResolver resolves the Booking type.
+type BookingResolver struct {
+	r *Resolver
+	b usecase.BookingResponse
+}
+
+func (b *BookingResolver) ID() gql.ID            { return gql.ID(b.b.BookingID) }
+func (b *BookingResolver) Code() string          { return b.b.BookingCode }
+func (b *BookingResolver) UserID() gql.ID        { return gql.ID(b.b.UserID) }
+func (b *BookingResolver) TotalAmount() float64  { return b.b.TotalAmount }
+func (b *BookingResolver) Status() string        { return b.b.Status }
+func (b *BookingResolver) PaymentStatus() string { return b.b.PaymentStatus }
+func (b *BookingResolver) CreatedAt() string     { return formatMillis(b.b.CreatedAt) }
+
+func (b *BookingResolver) UpdatedAt() *string {
+	if b.b.UpdatedAt == nil {
+		return nil
+	}
+	s := formatMillis(*b.b.UpdatedAt)
+	return &s
+}
+
+func (b *BookingResolver) Details(ctx context.Context) ([]*BookingDetailResolver, error) {
+	details, _, err := b.r.loadersFrom(ctx).Details.Load(ctx, b.b.BookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*BookingDetailResolver, 0, len(details))
+	for _, d := range details {
+		res = append(res, &BookingDetailResolver{d: d})
+	}
+	return res, nil
+}
+
+// BookingDetailResolver resolves the BookingDetail type.
+type BookingDetailResolver struct {
+	d usecase.BookingDetailResponse
+}
+
+func (d *BookingDetailResolver) ID() gql.ID            { return gql.ID(d.d.ID) }
+func (d *BookingDetailResolver) ProductID() gql.ID     { return gql.ID(d.d.ProductID) }
+func (d *BookingDetailResolver) ProductName() *string  { return d.d.ProductName }
+func (d *BookingDetailResolver) Qty() int32            { return d.d.Qty }
+func (d *BookingDetailResolver) PricePerUnit() float64 { return d.d.PricePerUnit }
+func (d *BookingDetailResolver) SubTotal() float64     { return d.d.SubTotal }
+
+func formatMillis(ms int64) string {
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
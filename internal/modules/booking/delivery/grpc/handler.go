@@ -0,0 +1,167 @@
+/*
+|------------------------------------------------------------------------------------
+| GRPC HANDLER
+|------------------------------------------------------------------------------------
+|
+| The gRPC handler follows the exact same standards as the HTTP handler
+| (see delivery/http/handler.go): a single Anchor Log, DTO validation at the
+| entry point, zero post-entry logging and error bubbling.
+|
+| Errors are returned as *apperror.AppError; the telemetry interceptor converts
+| them into a gRPC status, the same way the Fiber global error handler renders
+| them as JSON.
+|
+|------------------------------------------------------------------------------------
+*/
+package grpc
+
+import (
+	"context"
+	bookingv1 "voyago/core-api/api/proto/booking/v1"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/validator"
+	"voyago/core-api/internal/modules/booking/usecase"
+	"voyago/core-api/internal/pkg/apperror"
+)
+
+type HandlerUseCases struct {
+	CreateBookingUseCase usecase.CreateBookingUseCase
+	ListBookingsUseCase  usecase.ListBookingsUseCase
+}
+
+type Handler struct {
+	bookingv1.UnimplementedBookingServiceServer
+
+	Cfg *config.Config
+	Log logger.Logger
+	Val validator.Validator
+	Uc  HandlerUseCases
+}
+
+// Compile-time check to ensure Handler implements the generated service contract.
+var _ bookingv1.BookingServiceServer = (*Handler)(nil)
+
+func NewHandler(cfg *config.Config, log logger.Logger, validator validator.Validator, useCases HandlerUseCases) *Handler {
+	return &Handler{
+		Cfg: cfg,
+		Log: log,
+		Val: validator,
+		Uc:  useCases,
+	}
+}
+
+func (h *Handler) CreateBooking(ctx context.Context, in *bookingv1.CreateBookingRequest) (*bookingv1.CreateBookingResponse, error) {
+	// 1. INITIALIZE CONTEXTUAL LOGGER
+	// ctx has been enriched by the Telemetrist interceptors (span, request ID).
+	log := h.Log.WithContext(ctx).WithField("method", "CreateBooking")
+
+	// 2. MAP REQUEST MESSAGE TO THE USE CASE DTO
+	request := toCreateBookingRequest(in)
+
+	// 3. VALIDATE REQUEST DTO
+	if err := h.Val.Validate(request); err != nil {
+		return nil, apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(ctx, err))
+	}
+
+	// 4. THE ANCHOR LOG & BUSINESS CORRELATION
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"booking_code": request.BookingCode,
+		},
+	}).Info("request received")
+
+	// --- HANDOVER TO DOMAIN LAYER (THE ZERO-LOG HANDOVER) ---
+	createBooking, err := h.Uc.CreateBookingUseCase.Execute(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCreateBookingResponse(createBooking), nil
+}
+
+func (h *Handler) ListBookings(ctx context.Context, in *bookingv1.ListBookingsRequest) (*bookingv1.ListBookingsResponse, error) {
+	log := h.Log.WithContext(ctx).WithField("method", "ListBookings")
+
+	request := &usecase.ListBookingsRequest{
+		UserID: in.GetUserId(),
+		Limit:  int(in.GetLimit()),
+		Offset: int(in.GetOffset()),
+	}
+
+	if err := h.Val.Validate(request); err != nil {
+		return nil, apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(ctx, err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"user_id": request.UserID,
+		},
+	}).Info("request received")
+
+	bookings, err := h.Uc.ListBookingsUseCase.Execute(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &bookingv1.ListBookingsResponse{Bookings: make([]*bookingv1.Booking, 0, len(bookings))}
+	for _, b := range bookings {
+		res.Bookings = append(res.Bookings, toBooking(b))
+	}
+	return res, nil
+}
+
+func toCreateBookingRequest(in *bookingv1.CreateBookingRequest) *usecase.CreateBookingRequest {
+	details := make([]usecase.CreateBookingDetailRequest, 0, len(in.GetDetails()))
+	for _, d := range in.GetDetails() {
+		details = append(details, usecase.CreateBookingDetailRequest{
+			ProductID:    d.GetProductId(),
+			ProductName:  d.ProductName,
+			Qty:          d.GetQty(),
+			PricePerUnit: d.GetPricePerUnit(),
+			SubTotal:     d.GetSubTotal(),
+		})
+	}
+
+	return &usecase.CreateBookingRequest{
+		BookingCode: in.GetCode(),
+		UserID:      in.GetUserId(),
+		TotalAmount: in.GetTotalAmount(),
+		Details:     details,
+	}
+}
+
+func toCreateBookingResponse(out *usecase.CreateBookingResponse) *bookingv1.CreateBookingResponse {
+	details := make([]*bookingv1.CreateBookingDetail, 0, len(out.Details))
+	for _, d := range out.Details {
+		details = append(details, &bookingv1.CreateBookingDetail{
+			ProductId:    d.ProductID,
+			ProductName:  d.ProductName,
+			Qty:          d.Qty,
+			PricePerUnit: d.PricePerUnit,
+			SubTotal:     d.SubTotal,
+		})
+	}
+
+	return &bookingv1.CreateBookingResponse{
+		Id:          out.BookingID,
+		Code:        out.BookingCode,
+		UserId:      out.UserID,
+		TotalAmount: out.TotalAmount,
+		Details:     details,
+	}
+}
+
+func toBooking(b usecase.BookingResponse) *bookingv1.Booking {
+	return &bookingv1.Booking{
+		Id:               b.BookingID,
+		Code:             b.BookingCode,
+		UserId:           b.UserID,
+		TotalAmount:      b.TotalAmount,
+		Status:           b.Status,
+		PaymentStatus:    b.PaymentStatus,
+		PaymentReference: b.PaymentReference,
+		CreatedAt:        b.CreatedAt,
+		UpdatedAt:        b.UpdatedAt,
+	}
+}
@@ -0,0 +1,18 @@
+package grpc
+
+import (
+	bookingv1 "voyago/core-api/api/proto/booking/v1"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"google.golang.org/grpc"
+)
+
+type ServiceConfig struct {
+	Config  *config.Config
+	Server  *grpc.Server
+	Handler *Handler
+}
+
+func (s *ServiceConfig) Setup() {
+	bookingv1.RegisterBookingServiceServer(s.Server, s.Handler)
+}
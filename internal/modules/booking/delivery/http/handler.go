@@ -34,9 +34,12 @@ package http
 import (
 	"voyago/core-api/internal/infrastructure/config"
 	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/sse"
 	"voyago/core-api/internal/infrastructure/validator"
+	"voyago/core-api/internal/modules/booking/entity"
 	"voyago/core-api/internal/modules/booking/usecase"
 	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/bind"
 	"voyago/core-api/internal/pkg/response"
 
 	"github.com/gofiber/fiber/v2"
@@ -50,25 +53,35 @@ const (
 )
 
 type HandlerUseCases struct {
-	CreateBookingUseCase usecase.CreateBookingUseCase
+	CreateBookingUseCase              usecase.CreateBookingUseCase
+	UpdateBookingPaymentStatusUseCase usecase.UpdateBookingPaymentStatusUseCase
+	GetBookingsByIDsUseCase           usecase.GetBookingsByIDsUseCase
+	CheckoutBookingUseCase            usecase.CheckoutBookingUseCase
 }
 
 type Handler struct {
-	Cfg *config.Config
-	Log logger.Logger
-	Val validator.Validator
-	Uc  HandlerUseCases
+	Cfg     *config.Config
+	Log     logger.Logger
+	Val     validator.Validator
+	Uc      HandlerUseCases
+	Streams *sse.Broker
 }
 
-func NewHandler(cfg *config.Config, log logger.Logger, validator validator.Validator, useCases HandlerUseCases) *Handler {
+func NewHandler(cfg *config.Config, log logger.Logger, validator validator.Validator, useCases HandlerUseCases, streams *sse.Broker) *Handler {
 	return &Handler{
-		Cfg: cfg,
-		Log: log,
-		Val: validator,
-		Uc:  useCases,
+		Cfg:     cfg,
+		Log:     log,
+		Val:     validator,
+		Uc:      useCases,
+		Streams: streams,
 	}
 }
 
+// bookingIDParam validates the ":id" route parameter.
+type bookingIDParam struct {
+	ID string `params:"id" validate:"required,uuid" label:"Booking ID"`
+}
+
 func (h *Handler) CreateBooking(c *fiber.Ctx) error {
 	// We use c.UserContext() which has been enriched by the Telemetrist middlewares.
 	// There's no need to start a new span here unless we have complex logic
@@ -81,12 +94,12 @@ func (h *Handler) CreateBooking(c *fiber.Ctx) error {
 	// by the Telemetrist middleware, linking this log to the entire trace.
 	log := h.Log.WithContext(ctx).WithField("method", "CreateBooking")
 
-	// 2. PARSE REQUEST BODY
+	// 2. BIND REQUEST (body, query, path and headers, sanitized)
 	request := new(usecase.CreateBookingRequest)
-	if err := c.BodyParser(request); err != nil {
+	if err := bind.Request(c, request); err != nil {
 		// [LOG HYGIENE]: We don't log here. The error is bubbled to the Global Error Handler,
 		// which will emit a single error log with full context and TraceID.
-		return apperror.ErrCodeMalformedRequest.WithError(err)
+		return err
 	}
 
 	// 3. VALIDATE REQUEST DTO
@@ -94,7 +107,7 @@ func (h *Handler) CreateBooking(c *fiber.Ctx) error {
 	if err := h.Val.Validate(request); err != nil {
 		// [LOG HYGIENE]: Bubble up directly. Redundant logging at this stage would
 		// only clutter the aggregator since the failure is already captured in the response.
-		return apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(err))
+		return apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(c.UserContext(), err))
 	}
 
 	// 4. THE ANCHOR LOG & BUSINESS CORRELATION
@@ -149,3 +162,105 @@ func (h *Handler) CreateBooking(c *fiber.Ctx) error {
 		Data:    createBooking, // Use the processed entity from UseCase
 	})
 }
+
+// UpdatePaymentStatus is the entry point used by the payment module to report
+// the outcome of a payment attached to a booking.
+func (h *Handler) UpdatePaymentStatus(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := h.Log.WithContext(ctx).WithField("method", "UpdatePaymentStatus")
+
+	request := new(usecase.UpdateBookingPaymentStatusRequest)
+	if err := bind.Request(c, request); err != nil {
+		return err
+	}
+	if err := h.Val.Validate(request); err != nil {
+		return apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(c.UserContext(), err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"booking_id":        request.BookingID,
+			"payment_reference": request.PaymentReference,
+		},
+	}).Info("request received")
+
+	booking, err := h.Uc.UpdateBookingPaymentStatusUseCase.Execute(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Booking payment status updated successfully",
+		Data:    booking,
+	})
+}
+
+// Checkout pays a pending booking and confirms it.
+func (h *Handler) Checkout(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := h.Log.WithContext(ctx).WithField("method", "Checkout")
+
+	request := new(usecase.CheckoutBookingRequest)
+	if err := bind.Request(c, request); err != nil {
+		return err
+	}
+	if err := h.Val.Validate(request); err != nil {
+		return apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(c.UserContext(), err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"booking_id":     request.BookingID,
+			"payment_method": request.PaymentMethod,
+		},
+	}).Info("request received")
+
+	booking, err := h.Uc.CheckoutBookingUseCase.Execute(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Booking checked out successfully",
+		Data:    booking,
+	})
+}
+
+// StreamEvents streams the status changes of a booking with Server-Sent Events.
+// The current booking is sent first as a "booking.snapshot" event, then every
+// "booking.payment_status_changed" and "booking.status_changed" event.
+func (h *Handler) StreamEvents(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := h.Log.WithContext(ctx).WithField("method", "StreamEvents")
+
+	param := bookingIDParam{}
+	if err := bind.Request(c, &param); err != nil {
+		return err
+	}
+	if err := h.Val.Validate(&param); err != nil {
+		return apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(c.UserContext(), err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{"booking_id": param.ID},
+	}).Info("request received")
+
+	// Subscribe before loading the snapshot so that no change is missed in between.
+	sub, err := h.Streams.Subscribe(ctx, streamName, param.ID)
+	if err != nil {
+		return err
+	}
+
+	bookings, err := h.Uc.GetBookingsByIDsUseCase.Execute(ctx, []string{param.ID})
+	if err != nil {
+		sub.Close()
+		return err
+	}
+	booking, ok := bookings[param.ID]
+	if !ok {
+		sub.Close()
+		return entity.ErrBookingNotFound
+	}
+
+	return sub.Serve(c, sse.Message{Event: snapshotEvent, Data: booking})
+}
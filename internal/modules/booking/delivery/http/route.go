@@ -2,13 +2,16 @@ package http
 
 import (
 	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/http/versioning"
+	"voyago/core-api/internal/infrastructure/openapi"
+	"voyago/core-api/internal/modules/booking/usecase"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type RouteConfig struct {
 	Config  *config.Config
-	Server  *fiber.App
+	Routes  *versioning.Router
 	Handler *Handler
 }
 
@@ -17,6 +20,55 @@ const (
 )
 
 func (r *RouteConfig) Setup() {
-	bookings := r.Server.Group(routeGroup)
+	r.Routes.Register(r.v1, versioning.V1)
+}
+
+// v1 registers the routes of API version 1.
+func (r *RouteConfig) v1(v *versioning.Group) {
+	bookings := v.Group(routeGroup)
 	bookings.Post("/", r.Handler.CreateBooking)
+	bookings.Patch("/:id/payment-status", r.Handler.UpdatePaymentStatus)
+	bookings.Post("/:id/checkout", r.Handler.Checkout)
+	bookings.Get("/:id/events", r.Handler.StreamEvents)
+
+	v.Document(
+		openapi.Operation{
+			Method:   fiber.MethodPost,
+			Path:     routeGroup + "/",
+			Summary:  "Create a booking",
+			Request:  usecase.CreateBookingRequest{},
+			Response: usecase.CreateBookingResponse{},
+			Status:   fiber.StatusCreated,
+			Errors:   []int{fiber.StatusBadRequest, fiber.StatusConflict},
+		},
+		openapi.Operation{
+			Method:      fiber.MethodPatch,
+			Path:        routeGroup + "/:id/payment-status",
+			Summary:     "Update the payment status of a booking",
+			Description: "Entry point of the payment module. Replaying the same status and reference is idempotent.",
+			Request:     usecase.UpdateBookingPaymentStatusRequest{},
+			Response:    usecase.BookingResponse{},
+			Errors:      []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusConflict},
+		},
+		openapi.Operation{
+			Method:  fiber.MethodPost,
+			Path:    routeGroup + "/:id/checkout",
+			Summary: "Pay a pending booking and confirm it",
+			Description: "Reserves the inventory, captures the payment, then confirms the booking. " +
+				"When a step fails, the steps done are compensated and the booking is cancelled.",
+			Request:  usecase.CheckoutBookingRequest{},
+			Response: usecase.BookingResponse{},
+			Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusConflict, fiber.StatusUnprocessableEntity},
+		},
+		openapi.Operation{
+			Method:  fiber.MethodGet,
+			Path:    routeGroup + "/:id/events",
+			Summary: "Stream the status changes of a booking (Server-Sent Events)",
+			Description: "Sends a `booking.snapshot` event with the current booking, then every " +
+				"`booking.payment_status_changed` and `booking.status_changed` event. " +
+				"Idle streams receive a heartbeat comment.",
+			ContentType: "text/event-stream",
+			Errors:      []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+		},
+	)
 }
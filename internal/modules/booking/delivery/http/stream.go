@@ -0,0 +1,52 @@
+package http
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/sse"
+	"voyago/core-api/internal/modules/booking/entity"
+)
+
+const (
+	// streamName is the SSE stream of the booking module, keyed by booking ID.
+	streamName = "booking"
+	// snapshotEvent is the first event of a stream, carrying the current booking.
+	snapshotEvent = "booking.snapshot"
+)
+
+// StreamForwarder feeds the booking SSE streams from the event bus.
+type StreamForwarder struct {
+	Streams *sse.Broker
+}
+
+func NewStreamForwarder(streams *sse.Broker) *StreamForwarder {
+	return &StreamForwarder{Streams: streams}
+}
+
+// Register subscribes to the booking events streamed to clients.
+func (f *StreamForwarder) Register(bus eventbus.Bus) {
+	bus.Subscribe(entity.EventBookingPaymentStatusChanged, f.Forward)
+	bus.Subscribe(entity.EventBookingStatusChanged, f.Forward)
+}
+
+// Forward publishes evt to the stream of the booking it belongs to.
+// Events without a booking ID are ignored.
+func (f *StreamForwarder) Forward(_ context.Context, evt eventbus.Event) error {
+	var bookingID string
+	switch p := evt.Payload.(type) {
+	case entity.BookingPaymentStatusChangedPayload:
+		bookingID = p.BookingID
+	case entity.BookingStatusChangedPayload:
+		bookingID = p.BookingID
+	}
+	if bookingID == "" {
+		return nil
+	}
+
+	f.Streams.Publish(streamName, bookingID, sse.Message{
+		ID:    evt.ID,
+		Event: evt.Type,
+		Data:  evt.Payload,
+	})
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package messaging connects the booking module to the broker topics it
+// consumes.
+package messaging
+
+import (
+	"voyago/core-api/internal/infrastructure/dedupe"
+	"voyago/core-api/internal/infrastructure/messaging"
+	"voyago/core-api/internal/modules/booking/delivery/event"
+	"voyago/core-api/internal/modules/booking/entity"
+)
+
+// consumerApplyPaymentStatus names the handler of the payment status changes
+// in the processed events.
+const consumerApplyPaymentStatus = "booking.apply_payment_status"
+
+type Consumer struct {
+	Subscriber *event.Subscriber
+	// Processed records the handled events: the brokers deliver them at
+	// least once.
+	Processed dedupe.Store
+}
+
+func NewConsumer(subscriber *event.Subscriber, processed dedupe.Store) *Consumer {
+	return &Consumer{Subscriber: subscriber, Processed: processed}
+}
+
+// Register consumes the payment status changes published to the broker
+// (e.g., by a payment service), handled like the ones of the event bus, once
+// per event.
+func (c *Consumer) Register(router *messaging.Router) {
+	router.Handle(entity.EventBookingPaymentStatusChanged, messaging.EventHandler(
+		dedupe.Handler(c.Processed, consumerApplyPaymentStatus, c.Subscriber.HandlePaymentStatusChanged),
+	))
+}
@@ -0,0 +1,21 @@
+// Package task runs the deferred tasks of the booking module enqueued in the
+// task queue.
+package task
+
+import (
+	"voyago/core-api/internal/infrastructure/taskqueue"
+	"voyago/core-api/internal/modules/booking/usecase"
+)
+
+type Handler struct {
+	SendPaymentReminderUseCase usecase.SendPaymentReminderUseCase
+}
+
+func NewHandler(sendPaymentReminder usecase.SendPaymentReminderUseCase) *Handler {
+	return &Handler{SendPaymentReminderUseCase: sendPaymentReminder}
+}
+
+// Register handles the task types of the module.
+func (h *Handler) Register(mux *taskqueue.Mux) {
+	mux.Handle(usecase.PaymentReminderTask.Name, usecase.PaymentReminderTask.Handler(h.SendPaymentReminderUseCase.Execute))
+}
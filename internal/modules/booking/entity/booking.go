@@ -1,8 +1,10 @@
 package entity
 
 import (
+	"errors"
 	"math"
 	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/fsm"
 )
 
 // [ENTITY STANDARD: DOMAIN SPECIFIC ERROR]
@@ -12,6 +14,12 @@ const (
 	CodeBookingAmountInconsistent         = "BOOKING_AMOUNT_INCONSISTENT"
 	CodeBookingDetailSubtotalInconsistent = "BOOKING_DETAIL_SUBTOTAL_INCONSISTENT"
 	CodeBookingDetailsRequired            = "BOOKING_DETAILS_REQUIRED"
+	CodeBookingPaymentTransitionInvalid   = "BOOKING_PAYMENT_TRANSITION_INVALID"
+	CodeBookingStatusTransitionInvalid    = "BOOKING_STATUS_TRANSITION_INVALID"
+	CodeBookingPaymentStatusConflict      = "BOOKING_PAYMENT_STATUS_CONFLICT"
+	CodeBookingNotAwaitingPayment         = "BOOKING_NOT_AWAITING_PAYMENT"
+	CodeBookingCheckoutAlreadyStarted     = "BOOKING_CHECKOUT_ALREADY_STARTED"
+	CodeBookingCheckoutFailed             = "BOOKING_CHECKOUT_FAILED"
 )
 
 var (
@@ -39,14 +47,62 @@ var (
 		CodeBookingDetailsRequired,
 		"booking must have at least one detail",
 	)
+
+	ErrBookingPaymentTransitionInvalid = apperror.NewPersistance(
+		CodeBookingPaymentTransitionInvalid,
+		"payment status transition is not allowed",
+	)
+
+	ErrBookingStatusTransitionInvalid = apperror.NewPersistance(
+		CodeBookingStatusTransitionInvalid,
+		"booking status transition is not allowed",
+	)
+
+	// ErrBookingPaymentStatusConflict is returned when the payment status was
+	// changed concurrently between read and write.
+	ErrBookingPaymentStatusConflict = apperror.NewPersistance(
+		CodeBookingPaymentStatusConflict,
+		"payment status was modified concurrently",
+	)
+
+	ErrBookingNotAwaitingPayment = apperror.NewPersistance(
+		CodeBookingNotAwaitingPayment,
+		"booking is not awaiting payment",
+	)
+
+	// ErrBookingCheckoutAlreadyStarted is returned when the checkout of a
+	// booking is run twice: a booking is checked out once.
+	ErrBookingCheckoutAlreadyStarted = apperror.NewPersistance(
+		CodeBookingCheckoutAlreadyStarted,
+		"booking checkout already started",
+	)
+
+	// ErrBookingCheckoutFailed is returned when a step of the checkout failed
+	// and the booking was cancelled.
+	ErrBookingCheckoutFailed = apperror.NewPersistance(
+		CodeBookingCheckoutFailed,
+		"booking checkout failed",
+	)
 )
 
 func init() {
-	// [OPTIONAL] Register custom HTTP status codes for specific domain errors.
-	// This is NOT mandatory. If an error code is not registered here,
-	// it will automatically fallback to the default status based on its apperror.Kind
-	// (e.g., KindPersistance -> 400, KindInternal -> 500).
-	apperror.RegisterStatus(CodeBookingCodeAlreadyExists, 409)
+	// [ENTITY STANDARD: ERROR CATALOG] Every error of the module is registered
+	// in the error catalog, exported to the client teams (voyago gen errors).
+	// The status is optional: without it, the error falls back to the default
+	// status of its apperror.Kind (e.g., KindPersistance -> 400, KindInternal -> 500).
+	apperror.Register("booking",
+		apperror.Def{Err: ErrBookingNotFound, Status: 404},
+		apperror.Def{Err: ErrBookingCodeAlreadyExists, Status: 409},
+		apperror.Def{Err: ErrBookingAmountInconsistent},
+		apperror.Def{Err: ErrBookingDetailSubtotalInconsistent},
+		apperror.Def{Err: ErrBookingDetailsRequired},
+		apperror.Def{Err: ErrBookingPaymentTransitionInvalid, Status: 409},
+		apperror.Def{Err: ErrBookingStatusTransitionInvalid, Status: 409},
+		apperror.Def{Err: ErrBookingPaymentStatusConflict, Status: 409},
+		apperror.Def{Err: ErrBookingNotAwaitingPayment, Status: 409},
+		apperror.Def{Err: ErrBookingCheckoutAlreadyStarted, Status: 409},
+		apperror.Def{Err: ErrBookingCheckoutFailed, Status: 422},
+	)
 }
 
 type BookingStatus string
@@ -58,16 +114,61 @@ const (
 	BookingStatusCompleted BookingStatus = "COMPLETED"
 )
 
+type PaymentStatus string
+
+const (
+	PaymentStatusUnpaid   PaymentStatus = "UNPAID"
+	PaymentStatusPaid     PaymentStatus = "PAID"
+	PaymentStatusFailed   PaymentStatus = "FAILED"
+	PaymentStatusRefunded PaymentStatus = "REFUNDED"
+)
+
+// paymentStatusMachine moves the payment status. A failed payment can be
+// retried; a refund is terminal.
+var paymentStatusMachine = fsm.New("booking.payment_status",
+	func(b *Booking) *PaymentStatus { return &b.PaymentStatus },
+	fsm.Table[PaymentStatus]{
+		PaymentStatusUnpaid:   {PaymentStatusPaid, PaymentStatusFailed},
+		PaymentStatusFailed:   {PaymentStatusPaid, PaymentStatusFailed},
+		PaymentStatusPaid:     {PaymentStatusRefunded},
+		PaymentStatusRefunded: {},
+	},
+)
+
+// errBookingNotPaid refuses to confirm a booking before its payment.
+var errBookingNotPaid = errors.New("booking is not paid")
+
+// statusMachine moves the booking status. Only a paid booking is confirmed.
+var statusMachine = fsm.New("booking.status",
+	func(b *Booking) *BookingStatus { return &b.Status },
+	fsm.Table[BookingStatus]{
+		BookingStatusPending:   {BookingStatusConfirmed, BookingStatusCancelled},
+		BookingStatusConfirmed: {BookingStatusCancelled, BookingStatusCompleted},
+		BookingStatusCancelled: {},
+		BookingStatusCompleted: {},
+	},
+).Guard(BookingStatusConfirmed, func(b *Booking, _ fsm.Transition[BookingStatus]) error {
+	if b.PaymentStatus != PaymentStatusPaid {
+		return errBookingNotPaid
+	}
+	return nil
+})
+
 type Booking struct {
 	ID            string        `gorm:"column:id;type:uuid;primaryKey"`
 	BookingCode   string        `gorm:"column:booking_code;type:varchar(50);not null;unique"`
 	UserID        string        `gorm:"column:user_id;type:uuid;not null"`
 	TotalAmount   float64       `gorm:"column:total_amount;type:decimal(15,2);not null;default:0"`
 	Status        BookingStatus `gorm:"column:status;type:varchar(20);not null;default:'PENDING'"`
-	PaymentStatus string        `gorm:"column:payment_status;type:varchar(20);not null;default:'UNPAID'"`
-	CreatedAt     int64         `gorm:"column:created_at;type:bigint;not null;autoCreateTime:milli"`
-	UpdatedAt     *int64        `gorm:"column:updated_at;type:bigint;autoUpdateTime:false"`
-	DeletedAt     *int64        `gorm:"column:deleted_at;autoUpdateTime:false"`
+	PaymentStatus PaymentStatus `gorm:"column:payment_status;type:varchar(20);not null;default:'UNPAID'"`
+	// PaymentReference identifies the transaction in the payment module
+	// that produced the current PaymentStatus.
+	PaymentReference *string `gorm:"column:payment_reference;type:varchar(100)"`
+	// TenantID scopes the booking to a tenant, see database.TenantColumn.
+	TenantID  *string `gorm:"column:tenant_id;type:varchar(128);index"`
+	CreatedAt int64   `gorm:"column:created_at;type:bigint;not null;autoCreateTime:milli"`
+	UpdatedAt *int64  `gorm:"column:updated_at;type:bigint;autoUpdateTime:false"`
+	DeletedAt *int64  `gorm:"column:deleted_at;autoUpdateTime:false"`
 
 	Details []BookingDetail `gorm:"foreignKey:BookingID;references:ID"`
 }
@@ -127,3 +228,57 @@ func (e *Booking) Validate() error {
 
 	return nil
 }
+
+// ChangePaymentStatus moves the booking to the given payment status.
+// It returns ErrBookingPaymentTransitionInvalid when the transition is not
+// allowed from the current status.
+func (e *Booking) ChangePaymentStatus(status PaymentStatus, reference string) error {
+	from := e.PaymentStatus
+	if err := paymentStatusMachine.Fire(e, status); err != nil {
+		return ErrBookingPaymentTransitionInvalid.
+			WithDetail("from", from).
+			WithDetail("to", status)
+	}
+	e.PaymentReference = &reference
+	return nil
+}
+
+// ChangeStatus moves the booking to the given status. It returns
+// ErrBookingStatusTransitionInvalid when the transition is not allowed from
+// the current status, or the booking is confirmed before it is paid.
+func (e *Booking) ChangeStatus(status BookingStatus) error {
+	from := e.Status
+	if err := statusMachine.Fire(e, status); err != nil {
+		return ErrBookingStatusTransitionInvalid.
+			WithDetail("from", from).
+			WithDetail("to", status)
+	}
+	return nil
+}
+
+// AwaitsPayment reports whether the booking is pending until it is paid: not
+// paid yet, or its payment failed.
+func (e *Booking) AwaitsPayment() bool {
+	return e.Status == BookingStatusPending && paymentStatusMachine.Allowed(e.PaymentStatus, PaymentStatusPaid)
+}
+
+// StatusAfterPayment returns the booking status implied by the current payment
+// status, and false when the payment does not affect the booking lifecycle.
+//
+//   - PAID confirms a PENDING booking.
+//   - REFUNDED cancels a PENDING or CONFIRMED booking.
+func (e *Booking) StatusAfterPayment() (BookingStatus, bool) {
+	var status BookingStatus
+	switch e.PaymentStatus {
+	case PaymentStatusPaid:
+		status = BookingStatusConfirmed
+	case PaymentStatusRefunded:
+		status = BookingStatusCancelled
+	default:
+		return e.Status, false
+	}
+	if statusMachine.Can(e, status) != nil {
+		return e.Status, false
+	}
+	return status, true
+}
@@ -0,0 +1,48 @@
+package entity
+
+// [ENTITY STANDARD: DOMAIN EVENTS]
+// Event types emitted by the booking module. They follow the "domain.action"
+// pattern and are part of the module's public contract for subscribers
+// (other modules, webhooks, message brokers).
+const (
+	EventSource         = "booking"
+	EventBookingCreated = "booking.created"
+
+	EventBookingPaymentStatusChanged = "booking.payment_status_changed"
+	EventBookingStatusChanged        = "booking.status_changed"
+)
+
+// BookingCreatedPayload is the data carried by EventBookingCreated.
+type BookingCreatedPayload struct {
+	BookingID     string        `json:"booking_id"`
+	BookingCode   string        `json:"booking_code"`
+	UserID        string        `json:"user_id"`
+	TotalAmount   float64       `json:"total_amount"`
+	Status        BookingStatus `json:"status"`
+	PaymentStatus PaymentStatus `json:"payment_status"`
+}
+
+// BookingPaymentStatusChangedPayload is the data carried by
+// EventBookingPaymentStatusChanged. It is emitted every time the payment
+// module moves a booking to a new payment status.
+type BookingPaymentStatusChangedPayload struct {
+	BookingID        string        `json:"booking_id"`
+	BookingCode      string        `json:"booking_code"`
+	UserID           string        `json:"user_id"`
+	OldStatus        PaymentStatus `json:"old_status"`
+	NewStatus        PaymentStatus `json:"new_status"`
+	Amount           float64       `json:"amount"`
+	PaymentReference string        `json:"payment_reference"`
+}
+
+// BookingStatusChangedPayload is the data carried by EventBookingStatusChanged.
+// It is emitted when the booking lifecycle moves (e.g., PENDING -> CONFIRMED
+// once the booking is paid).
+type BookingStatusChangedPayload struct {
+	BookingID     string        `json:"booking_id"`
+	BookingCode   string        `json:"booking_code"`
+	UserID        string        `json:"user_id"`
+	OldStatus     BookingStatus `json:"old_status"`
+	NewStatus     BookingStatus `json:"new_status"`
+	PaymentStatus PaymentStatus `json:"payment_status"`
+}
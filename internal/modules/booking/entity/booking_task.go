@@ -0,0 +1,14 @@
+package entity
+
+// [ENTITY STANDARD: DEFERRED TASKS]
+// Task types run by the task queue for the booking module, after the request
+// that enqueued them. They follow the "domain.action" pattern of the events.
+const (
+	TaskPaymentReminder = "booking.payment_reminder"
+)
+
+// PaymentReminderPayload is the payload of TaskPaymentReminder: the booking
+// is read again when the reminder is due, it may be paid by then.
+type PaymentReminderPayload struct {
+	BookingID string `json:"booking_id"`
+}
@@ -0,0 +1,64 @@
+// Package gateway holds the implementations of the services called by the
+// booking use cases: usecase.InventoryService and usecase.PaymentGateway.
+package gateway
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/modules/booking/usecase"
+)
+
+// logInventory writes the reservations to the application log. It is the
+// default until the inventory service is integrated.
+type logInventory struct {
+	log logger.Logger
+}
+
+var _ usecase.InventoryService = (*logInventory)(nil)
+
+func NewLogInventory(log logger.Logger) usecase.InventoryService {
+	return &logInventory{log: log.WithField("component", "inventory")}
+}
+
+func (g *logInventory) Reserve(ctx context.Context, r usecase.InventoryReservation) error {
+	g.log.WithContext(ctx).WithFields(map[string]any{
+		"booking_id": r.BookingID,
+		"items":      len(r.Items),
+	}).Info("inventory reserved")
+	return nil
+}
+
+func (g *logInventory) Release(ctx context.Context, bookingID string) error {
+	g.log.WithContext(ctx).WithField("booking_id", bookingID).Info("inventory released")
+	return nil
+}
+
+// logPayments writes the payments to the application log, approving every
+// capture. It is the default until a payment provider is integrated.
+type logPayments struct {
+	log logger.Logger
+}
+
+var _ usecase.PaymentGateway = (*logPayments)(nil)
+
+func NewLogPayments(log logger.Logger) usecase.PaymentGateway {
+	return &logPayments{log: log.WithField("component", "payments")}
+}
+
+func (g *logPayments) Capture(ctx context.Context, p usecase.PaymentCapture) (string, error) {
+	// Derived from the booking: capturing it again returns the same payment.
+	reference := "LOG-" + p.BookingID
+	g.log.WithContext(ctx).WithFields(map[string]any{
+		"booking_id":        p.BookingID,
+		"user_id":           p.UserID,
+		"method":            p.Method,
+		"amount":            p.Amount,
+		"payment_reference": reference,
+	}).Info("payment captured")
+	return reference, nil
+}
+
+func (g *logPayments) Refund(ctx context.Context, bookingID string) error {
+	g.log.WithContext(ctx).WithField("booking_id", bookingID).Info("payment refunded")
+	return nil
+}
@@ -1,46 +1,263 @@
 package booking
 
 import (
+	"context"
+	"fmt"
+	"time"
 	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/container"
 	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/dedupe"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	gqlserver "voyago/core-api/internal/infrastructure/graphql"
+	"voyago/core-api/internal/infrastructure/http/versioning"
 	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/messaging"
+	"voyago/core-api/internal/infrastructure/saga"
+	"voyago/core-api/internal/infrastructure/scheduler"
+	"voyago/core-api/internal/infrastructure/sse"
+	"voyago/core-api/internal/infrastructure/taskqueue"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
 	"voyago/core-api/internal/infrastructure/telemetry/tracer"
 	"voyago/core-api/internal/infrastructure/validator"
+	"voyago/core-api/internal/modules"
+	"voyago/core-api/internal/modules/booking/delivery/event"
+	graphqldelivery "voyago/core-api/internal/modules/booking/delivery/graphql"
+	grpcdelivery "voyago/core-api/internal/modules/booking/delivery/grpc"
 	"voyago/core-api/internal/modules/booking/delivery/http"
+	messagingdelivery "voyago/core-api/internal/modules/booking/delivery/messaging"
+	taskdelivery "voyago/core-api/internal/modules/booking/delivery/task"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/modules/booking/gateway"
+	"voyago/core-api/internal/modules/booking/notifier"
+	"voyago/core-api/internal/modules/booking/repository"
 	"voyago/core-api/internal/modules/booking/repository/command"
 	"voyago/core-api/internal/modules/booking/repository/query"
 	"voyago/core-api/internal/modules/booking/usecase"
+	"voyago/core-api/internal/pkg/audit"
+	"voyago/core-api/internal/pkg/uid"
+	"voyago/core-api/internal/pkg/utils"
 
-	"github.com/gofiber/fiber/v2"
+	"go.uber.org/fx"
+	"google.golang.org/grpc"
 )
 
+func init() {
+	modules.Register(modules.Module{
+		Name:     "booking",
+		Settings: modules.SettingsOf[Settings](),
+		Models: []any{
+			&entity.Booking{},
+			&entity.BookingDetail{},
+			&audit.Entry{},
+			&dedupe.Record{},
+			&saga.Instance{},
+		},
+		HTTP: func(env modules.HTTP) {
+			RegisterHttpModule(HttpModuleConfig{
+				Config:   env.Config,
+				Settings: env.Settings.(*Settings),
+				Routes:   env.Routes,
+				DB:       env.DB,
+				Log:      env.Log,
+				Val:      env.Val,
+				Tracer:   env.Tracer,
+				Metrics:  env.Metrics,
+				Bus:      env.Bus,
+				Tasks:    env.Tasks,
+				Streams:  sse.NewBroker(env.SSE, env.Log, env.Metrics),
+			})
+		},
+		GRPC: func(env modules.GRPC) {
+			RegisterGrpcModule(GrpcModuleConfig{
+				Config:   env.Config,
+				Settings: env.Settings.(*Settings),
+				Server:   env.Server,
+				DB:       env.DB,
+				Log:      env.Log,
+				Val:      env.Val,
+				Tracer:   env.Tracer,
+				Metrics:  env.Metrics,
+				Bus:      env.Bus,
+				Tasks:    env.Tasks,
+			})
+		},
+		Worker: func(env modules.Worker) {
+			RegisterWorkerModule(WorkerModuleConfig{
+				Config:   env.Config,
+				Settings: env.Settings.(*Settings),
+				DB:       env.DB,
+				Log:      env.Log,
+				Tracer:   env.Tracer,
+				Metrics:  env.Metrics,
+				Bus:      env.Bus,
+			})
+		},
+		Messaging: func(env modules.Messaging) {
+			RegisterMessagingModule(MessagingModuleConfig{
+				Config:   env.Config,
+				Settings: env.Settings.(*Settings),
+				Router:   env.Router,
+				DB:       env.DB,
+				Log:      env.Log,
+				Tracer:   env.Tracer,
+				Metrics:  env.Metrics,
+				Bus:      env.Bus,
+			})
+		},
+		Jobs: func(env modules.Jobs) {
+			RegisterJobModule(JobModuleConfig{
+				Config:    env.Config,
+				Settings:  env.Settings.(*Settings),
+				Scheduler: env.Scheduler,
+				DB:        env.DB,
+				Log:       env.Log,
+				Tracer:    env.Tracer,
+				Metrics:   env.Metrics,
+				Bus:       env.Bus,
+			})
+		},
+		Tasks: func(env modules.Tasks) {
+			RegisterTaskModule(TaskModuleConfig{
+				Config:   env.Config,
+				Settings: env.Settings.(*Settings),
+				Mux:      env.Mux,
+				DB:       env.DB,
+				Log:      env.Log,
+				Tracer:   env.Tracer,
+				Metrics:  env.Metrics,
+				Bus:      env.Bus,
+			})
+		},
+		Seeders: RegisterSeeders,
+	})
+}
+
 type HttpModuleConfig struct {
 	Config *config.Config
-	Server *fiber.App
+	// Settings are the booking section of Config, decoded from it when nil.
+	Settings *Settings
+	// Routes mounts the module routes under the versioned API prefix (e.g., /api/v1).
+	Routes *versioning.Router
 	DB     database.Database
 	Log    logger.Logger
 	Val    validator.Validator
 	Tracer tracer.Tracer
+	// Metrics records the business metrics of the use cases.
+	Metrics metrics.Metrics
+	Bus     eventbus.Bus
+	// Tasks enqueues the deferred tasks of the use cases, nil when the task
+	// queue is disabled.
+	Tasks taskqueue.Enqueuer
+	// Streams serves the booking Server-Sent Events streams.
+	Streams *sse.Broker
+}
+
+type GrpcModuleConfig struct {
+	Config *config.Config
+	// Settings are the booking section of Config (see HttpModuleConfig).
+	Settings *Settings
+	Server   *grpc.Server
+	DB       database.Database
+	Log      logger.Logger
+	Val      validator.Validator
+	Tracer   tracer.Tracer
+	// Metrics records the business metrics of the use cases.
+	Metrics metrics.Metrics
+	Bus     eventbus.Bus
+	// Tasks enqueues the deferred tasks of the use cases (see HttpModuleConfig).
+	Tasks taskqueue.Enqueuer
+}
+
+type MessagingModuleConfig struct {
+	Config   *config.Config
+	Settings *Settings
+	// Router receives the handlers of the consumed topics.
+	Router *messaging.Router
+	DB     database.Database
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	// Metrics records the business metrics of the use cases.
+	Metrics metrics.Metrics
+	Bus     eventbus.Bus
+}
+
+// WorkerModuleConfig configures the module in the background runtime
+// (cmd/worker), which serves no API.
+type WorkerModuleConfig struct {
+	Config   *config.Config
+	Settings *Settings
+	DB       database.Database
+	Log      logger.Logger
+	Tracer   tracer.Tracer
+	// Metrics records the business metrics of the use cases.
+	Metrics metrics.Metrics
+	Bus     eventbus.Bus
+}
+
+type TaskModuleConfig struct {
+	Config   *config.Config
+	Settings *Settings
+	// Mux receives the handlers of the task types of the module.
+	Mux    *taskqueue.Mux
+	DB     database.Database
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	// Metrics records the business metrics of the use cases.
+	Metrics metrics.Metrics
+	Bus     eventbus.Bus
+}
+
+type JobModuleConfig struct {
+	Config   *config.Config
+	Settings *Settings
+	// Scheduler receives the scheduled jobs of the module.
+	Scheduler *scheduler.Scheduler
+	DB        database.Database
+	Log       logger.Logger
+	Tracer    tracer.Tracer
+	// Metrics records the business metrics of the use cases.
+	Metrics metrics.Metrics
+	Bus     eventbus.Bus
+}
+
+type GraphqlModuleConfig struct {
+	Config   *config.Config
+	Settings *Settings
+	DB       database.Database
+	Log      logger.Logger
+	Val      validator.Validator
+	Tracer   tracer.Tracer
+	// Metrics records the business metrics of the use cases.
+	Metrics metrics.Metrics
+	Bus     eventbus.Bus
+}
+
+// registerMasking masks the confidential fields of the module in the logs and
+// traces. Support teams match payments by the end of their reference.
+func registerMasking() {
+	utils.RegisterMaskingStrategy("payment_reference", utils.MaskLast4)
+}
+
+// useCases groups the use cases shared by every delivery transport.
+type useCases struct {
+	createBooking     usecase.CreateBookingUseCase
+	listBookings      usecase.ListBookingsUseCase
+	getBookingsByIDs  usecase.GetBookingsByIDsUseCase
+	getBookingDetails usecase.GetBookingDetailsUseCase
+
+	updatePaymentStatus usecase.UpdateBookingPaymentStatusUseCase
+	applyPaymentStatus  usecase.ApplyBookingPaymentStatusUseCase
+	sendPaymentReminder usecase.SendPaymentReminderUseCase
+	checkoutBooking     usecase.CheckoutBookingUseCase
 }
 
 func RegisterHttpModule(cfg HttpModuleConfig) {
-	ucLogger := cfg.Log.WithField("component", "usecase")
-	hdlrLogger := cfg.Log.WithField("component", "handler")
+	registerMasking()
 
-	// setup repositories
-	bookingCmdRepository := command.NewBookingRepository(cfg.DB)
-	bookingQryRepository := query.NewBookingRepository(cfg.DB)
+	hdlrLogger := cfg.Log.WithField("component", "handler")
 
-	// setup use cases
-	createBookingUseCase := usecase.NewCreateBookingUseCase(
-		ucLogger,
-		cfg.Tracer,
-		cfg.DB,
-		usecase.CreateBookingRepositories{
-			BookingCmd: bookingCmdRepository,
-			BookingQry: bookingQryRepository,
-		},
-	)
+	uc := setupUseCases(cfg.Config, settingsOf(cfg.Settings, cfg.Config), cfg.DB, cfg.Log, cfg.Tracer, cfg.Metrics, cfg.Bus, cfg.Tasks)
 
 	// setup handler
 	h := http.NewHandler(
@@ -48,14 +265,233 @@ func RegisterHttpModule(cfg HttpModuleConfig) {
 		hdlrLogger,
 		cfg.Val,
 		http.HandlerUseCases{
-			CreateBookingUseCase: createBookingUseCase,
+			CreateBookingUseCase:              uc.createBooking,
+			UpdateBookingPaymentStatusUseCase: uc.updatePaymentStatus,
+			GetBookingsByIDsUseCase:           uc.getBookingsByIDs,
+			CheckoutBookingUseCase:            uc.checkoutBooking,
 		},
+		cfg.Streams,
 	)
 
 	routeConfig := http.RouteConfig{
-		Server:  cfg.Server,
+		Routes:  cfg.Routes,
 		Config:  cfg.Config,
 		Handler: h,
 	}
 	routeConfig.Setup()
+
+	// setup event subscribers
+	event.NewSubscriber(uc.applyPaymentStatus).Register(cfg.Bus)
+	http.NewStreamForwarder(cfg.Streams).Register(cfg.Bus)
+}
+
+func RegisterGrpcModule(cfg GrpcModuleConfig) {
+	registerMasking()
+
+	hdlrLogger := cfg.Log.WithField("component", "handler")
+
+	uc := setupUseCases(cfg.Config, settingsOf(cfg.Settings, cfg.Config), cfg.DB, cfg.Log, cfg.Tracer, cfg.Metrics, cfg.Bus, cfg.Tasks)
+
+	// setup handler
+	h := grpcdelivery.NewHandler(
+		cfg.Config,
+		hdlrLogger,
+		cfg.Val,
+		grpcdelivery.HandlerUseCases{
+			CreateBookingUseCase: uc.createBooking,
+			ListBookingsUseCase:  uc.listBookings,
+		},
+	)
+
+	serviceConfig := grpcdelivery.ServiceConfig{
+		Server:  cfg.Server,
+		Config:  cfg.Config,
+		Handler: h,
+	}
+	serviceConfig.Setup()
+
+	// setup event subscriber
+	event.NewSubscriber(uc.applyPaymentStatus).Register(cfg.Bus)
+}
+
+// RegisterMessagingModule registers the handlers of the broker topics
+// consumed by the module, on Kafka or RabbitMQ.
+func RegisterMessagingModule(cfg MessagingModuleConfig) {
+	registerMasking()
+
+	uc := setupUseCases(cfg.Config, settingsOf(cfg.Settings, cfg.Config), cfg.DB, cfg.Log, cfg.Tracer, cfg.Metrics, cfg.Bus, nil)
+
+	messagingdelivery.NewConsumer(event.NewSubscriber(uc.applyPaymentStatus), dedupe.NewDatabaseStore(cfg.DB)).Register(cfg.Router)
+}
+
+// RegisterWorkerModule subscribes the module to the events of the
+// background runtime, published by its consumers and jobs.
+func RegisterWorkerModule(cfg WorkerModuleConfig) {
+	registerMasking()
+
+	uc := setupUseCases(cfg.Config, settingsOf(cfg.Settings, cfg.Config), cfg.DB, cfg.Log, cfg.Tracer, cfg.Metrics, cfg.Bus, nil)
+
+	event.NewSubscriber(uc.applyPaymentStatus).Register(cfg.Bus)
+}
+
+// RegisterTaskModule registers the handlers of the deferred tasks of the
+// module, enqueued by its use cases.
+func RegisterTaskModule(cfg TaskModuleConfig) {
+	registerMasking()
+
+	uc := setupUseCases(cfg.Config, settingsOf(cfg.Settings, cfg.Config), cfg.DB, cfg.Log, cfg.Tracer, cfg.Metrics, cfg.Bus, nil)
+
+	taskdelivery.NewHandler(uc.sendPaymentReminder).Register(cfg.Mux)
+}
+
+// processedEventsRetention is how long the events consumed from the brokers
+// are remembered, past their redelivery window.
+const processedEventsRetention = 7 * 24 * time.Hour
+
+// RegisterJobModule registers the scheduled jobs of the module.
+func RegisterJobModule(cfg JobModuleConfig) {
+	registerMasking()
+
+	uc := setupUseCases(cfg.Config, settingsOf(cfg.Settings, cfg.Config), cfg.DB, cfg.Log, cfg.Tracer, cfg.Metrics, cfg.Bus, nil)
+
+	processed := dedupe.NewDatabaseStore(cfg.DB)
+	cfg.Scheduler.Add(scheduler.Job{
+		Name: "booking.purge_processed_events",
+		Spec: "@hourly",
+		Run: func(ctx context.Context) error {
+			_, err := processed.Purge(ctx, processedEventsRetention)
+			return err
+		},
+	})
+	cfg.Scheduler.Add(scheduler.Job{
+		Name:     "booking.recover_checkouts",
+		Interval: time.Minute,
+		Run: func(ctx context.Context) error {
+			_, err := uc.checkoutBooking.Recover(ctx)
+			return err
+		},
+	})
+}
+
+// RegisterGraphqlModule builds the booking resolver of the GraphQL gateway.
+// The resolver must be embedded in the gateway root resolver and the returned
+// module passed to gqlserver.NewSchema.
+func RegisterGraphqlModule(cfg GraphqlModuleConfig) (*graphqldelivery.Resolver, gqlserver.Module) {
+	registerMasking()
+
+	hdlrLogger := cfg.Log.WithField("component", "handler")
+
+	uc := setupUseCases(cfg.Config, settingsOf(cfg.Settings, cfg.Config), cfg.DB, cfg.Log, cfg.Tracer, cfg.Metrics, cfg.Bus, nil)
+
+	// setup resolver
+	r := graphqldelivery.NewResolver(
+		cfg.Config,
+		hdlrLogger,
+		cfg.Val,
+		graphqldelivery.HandlerUseCases{
+			ListBookingsUseCase:      uc.listBookings,
+			GetBookingsByIDsUseCase:  uc.getBookingsByIDs,
+			GetBookingDetailsUseCase: uc.getBookingDetails,
+		},
+	)
+
+	return r, gqlserver.Module{
+		Schema:  graphqldelivery.Schema,
+		Context: r.WithLoaders,
+	}
+}
+
+// setupUseCases builds the use cases of the module. tasks is nil for the
+// transports creating no booking.
+func setupUseCases(cfg *config.Config, settings *Settings, db database.Database, log logger.Logger, trc tracer.Tracer, m metrics.Metrics, bus eventbus.Bus, tasks taskqueue.Enqueuer) useCases {
+	var uc useCases
+	container.Build(nil, "booking",
+		modules.Env{Config: cfg, Settings: settings, DB: db, Log: log, Tracer: trc, Metrics: m, Bus: bus, Tasks: tasks}.Options(),
+		fx.Supply(fx.Annotated{Name: "checkout_timeout", Target: time.Duration(settings.Checkout.Timeout) * time.Second}),
+		fx.Provide(
+			metrics.NewBusiness,
+			fx.Annotate(audit.NewService, fx.As(new(audit.Recorder))),
+			newIDGenerator,
+			command.NewBookingRepository,
+			query.NewBookingRepository,
+			fx.Annotate(saga.NewDatabaseStore, fx.As(new(saga.Store))),
+			newRepositories,
+			newPaymentReminderPolicy,
+			notifier.NewLogNotifier,
+			gateway.NewLogInventory,
+			gateway.NewLogPayments,
+			func(inv usecase.InventoryService, pay usecase.PaymentGateway) usecase.CheckoutServices {
+				return usecase.CheckoutServices{Inventory: inv, Payments: pay}
+			},
+		),
+		// The use cases log as the usecase component, their dependencies
+		// as the module.
+		fx.Module("usecase",
+			fx.Decorate(func(log logger.Logger) logger.Logger {
+				return log.WithField("component", "usecase")
+			}),
+			fx.Provide(
+				usecase.NewCreateBookingUseCase,
+				usecase.NewListBookingsUseCase,
+				usecase.NewGetBookingsByIDsUseCase,
+				usecase.NewGetBookingDetailsUseCase,
+				usecase.NewUpdateBookingPaymentStatusUseCase,
+				usecase.NewApplyBookingPaymentStatusUseCase,
+				usecase.NewSendPaymentReminderUseCase,
+				fx.Annotate(usecase.NewCheckoutBookingUseCase, fx.ParamTags("", "", "", "", "", "", "", "", `name:"checkout_timeout"`)),
+			),
+		),
+		fx.Populate(
+			&uc.createBooking,
+			&uc.listBookings,
+			&uc.getBookingsByIDs,
+			&uc.getBookingDetails,
+			&uc.updatePaymentStatus,
+			&uc.applyPaymentStatus,
+			&uc.sendPaymentReminder,
+			&uc.checkoutBooking,
+		),
+	)
+	return uc
+}
+
+// newIDGenerator returns the generator of the booking primary keys configured
+// by ids.generator.
+func newIDGenerator(cfg *config.Config) (uid.Generator, error) {
+	ids, err := uid.NewGenerator(cfg.IDs.Generator)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ids configuration: %w", err)
+	}
+	return ids, nil
+}
+
+// newPaymentReminderPolicy returns the reminders of the unpaid bookings
+// configured by booking.payment_reminder.
+func newPaymentReminderPolicy(settings *Settings, tasks taskqueue.Enqueuer) usecase.PaymentReminderPolicy {
+	return usecase.PaymentReminderPolicy{
+		Tasks: tasks,
+		Delay: time.Duration(settings.PaymentReminder.Delay) * time.Second,
+	}
+}
+
+// newRepositories returns the repositories of each use case, sharing the
+// command and query repositories of the bookings.
+func newRepositories(cmd repository.BookingCommandRepository, qry repository.BookingQueryRepository, sagas saga.Store) (
+	usecase.CreateBookingRepositories,
+	usecase.ListBookingsRepositories,
+	usecase.GetBookingsByIDsRepositories,
+	usecase.GetBookingDetailsRepositories,
+	usecase.UpdateBookingPaymentStatusRepositories,
+	usecase.ApplyBookingPaymentStatusRepositories,
+	usecase.SendPaymentReminderRepositories,
+	usecase.CheckoutBookingRepositories,
+) {
+	return usecase.CreateBookingRepositories{BookingCmd: cmd, BookingQry: qry},
+		usecase.ListBookingsRepositories{BookingQry: qry},
+		usecase.GetBookingsByIDsRepositories{BookingQry: qry},
+		usecase.GetBookingDetailsRepositories{BookingQry: qry},
+		usecase.UpdateBookingPaymentStatusRepositories{BookingCmd: cmd, BookingQry: qry},
+		usecase.ApplyBookingPaymentStatusRepositories{BookingCmd: cmd, BookingQry: qry},
+		usecase.SendPaymentReminderRepositories{BookingQry: qry},
+		usecase.CheckoutBookingRepositories{BookingCmd: cmd, BookingQry: qry, Sagas: sagas}
 }
@@ -0,0 +1,41 @@
+// Package notifier holds the implementations of usecase.BookingNotifier.
+package notifier
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/modules/booking/usecase"
+)
+
+// logNotifier writes notifications to the application log. It is the default
+// until a user-facing channel (e-mail, push) is integrated.
+type logNotifier struct {
+	log logger.Logger
+}
+
+var _ usecase.BookingNotifier = (*logNotifier)(nil)
+
+func NewLogNotifier(log logger.Logger) usecase.BookingNotifier {
+	return &logNotifier{log: log.WithField("component", "notifier")}
+}
+
+func (n *logNotifier) NotifyPaymentStatusChanged(ctx context.Context, msg usecase.BookingPaymentNotification) error {
+	n.log.WithContext(ctx).WithFields(map[string]any{
+		"user_id":           msg.UserID,
+		"booking_code":      msg.BookingCode,
+		"payment_status":    msg.PaymentStatus,
+		"booking_status":    msg.BookingStatus,
+		"amount":            msg.Amount,
+		"payment_reference": msg.PaymentReference,
+	}).Info("booking payment status notification")
+	return nil
+}
+
+func (n *logNotifier) NotifyPaymentReminder(ctx context.Context, msg usecase.BookingPaymentReminder) error {
+	n.log.WithContext(ctx).WithFields(map[string]any{
+		"user_id":      msg.UserID,
+		"booking_code": msg.BookingCode,
+		"amount":       msg.Amount,
+	}).Info("booking payment reminder notification")
+	return nil
+}
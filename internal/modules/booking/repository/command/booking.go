@@ -29,6 +29,7 @@
 package command
 
 import (
+	"context"
 	database "voyago/core-api/internal/infrastructure/db"
 	"voyago/core-api/internal/modules/booking/entity"
 	"voyago/core-api/internal/modules/booking/repository"
@@ -61,3 +62,37 @@ func NewBookingRepository(db database.Database) repository.BookingCommandReposit
 		},
 	}
 }
+
+func (r *bookingRepository) UpdatePaymentStatus(ctx context.Context, booking *entity.Booking, from entity.PaymentStatus) error {
+	// Only the payment columns are written so that the booking details loaded
+	// with the entity are not re-saved as associations.
+	res := r.DB.WithContext(ctx).
+		Model(&entity.Booking{}).
+		Where("id = ? AND payment_status = ?", booking.ID, from).
+		Updates(map[string]any{
+			"payment_status":    booking.PaymentStatus,
+			"payment_reference": booking.PaymentReference,
+			"updated_at":        booking.UpdatedAt,
+		})
+	if res.Error != nil {
+		return database.MapDBError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return entity.ErrBookingPaymentStatusConflict
+	}
+	return nil
+}
+
+func (r *bookingRepository) UpdateStatus(ctx context.Context, booking *entity.Booking, from entity.BookingStatus) (bool, error) {
+	res := r.DB.WithContext(ctx).
+		Model(&entity.Booking{}).
+		Where("id = ? AND status = ?", booking.ID, from).
+		Updates(map[string]any{
+			"status":     booking.Status,
+			"updated_at": booking.UpdatedAt,
+		})
+	if res.Error != nil {
+		return false, database.MapDBError(res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/pkg/spec"
 )
 
 // -------- Repository Command --------
@@ -11,6 +12,15 @@ type BookingCommandRepository interface {
 	Create(ctx context.Context, booking *entity.Booking) error
 	Update(ctx context.Context, booking *entity.Booking) error
 	Delete(ctx context.Context, booking *entity.Booking) error
+
+	// UpdatePaymentStatus persists the booking payment status and reference only
+	// if the stored payment status still equals from (optimistic concurrency).
+	// It returns entity.ErrBookingPaymentStatusConflict otherwise.
+	UpdatePaymentStatus(ctx context.Context, booking *entity.Booking, from entity.PaymentStatus) error
+
+	// UpdateStatus persists the booking status only if the stored status still
+	// equals from. It reports whether a row was changed.
+	UpdateStatus(ctx context.Context, booking *entity.Booking, from entity.BookingStatus) (bool, error)
 }
 
 // -------- Repository Query --------
@@ -19,4 +29,16 @@ type BookingQueryRepository interface {
 	ExistsByBookingCode(ctx context.Context, code string) (bool, error)
 	FindByID(ctx context.Context, id string) (*entity.Booking, error)
 	FindByCode(ctx context.Context, code string) (*entity.Booking, error)
+
+	// FindByIDs returns the bookings matching ids, without details, in no particular order.
+	// Missing ids are simply absent from the result.
+	FindByIDs(ctx context.Context, ids []string) ([]entity.Booking, error)
+
+	// List returns the bookings matching s, without details. s may filter and
+	// order by id, booking_code, user_id, status, payment_status,
+	// total_amount, created_at and updated_at.
+	List(ctx context.Context, s spec.Spec) ([]entity.Booking, error)
+
+	// FindDetailsByBookingIDs returns the details of every given booking in a single query.
+	FindDetailsByBookingIDs(ctx context.Context, bookingIDs []string) ([]entity.BookingDetail, error)
 }
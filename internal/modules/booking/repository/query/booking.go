@@ -18,6 +18,8 @@
 | [3. READ-ONLY CONTEXT]
 | - Ensure .WithContext(ctx) is called to respect timeouts, cancellations,
 |   and tracing propagation.
+| - Reads are served by the read replicas (database.Reader): a command use
+|   case reading what it is about to change sets ctxkey.SetReadPrimary.
 |
 | [4. PRELOAD DISCIPLINE]
 | - Only Preload relationships that are strictly necessary for the requested
@@ -33,10 +35,23 @@ import (
 	database "voyago/core-api/internal/infrastructure/db"
 	"voyago/core-api/internal/modules/booking/entity"
 	"voyago/core-api/internal/modules/booking/repository"
+	"voyago/core-api/internal/pkg/spec"
 
 	"gorm.io/gorm"
 )
 
+// bookingFields are the fields of the booking lists (see List).
+var bookingFields = database.SpecFields{
+	"id":             "id",
+	"booking_code":   "booking_code",
+	"user_id":        "user_id",
+	"status":         "status",
+	"payment_status": "payment_status",
+	"total_amount":   "total_amount",
+	"created_at":     "created_at",
+	"updated_at":     "updated_at",
+}
+
 // bookingRepository implements the repository.BookingQueryRepository interface.
 // It focuses on efficient data fetching and complex filtering logic.
 type bookingRepository struct {
@@ -47,9 +62,10 @@ type bookingRepository struct {
 var _ repository.BookingQueryRepository = (*bookingRepository)(nil)
 
 // NewBookingRepository creates a new instance for reading Booking data.
+// The reads go to the replicas of db, if any (see database.Reader).
 func NewBookingRepository(db database.Database) repository.BookingQueryRepository {
 	return &bookingRepository{
-		DB: db,
+		DB: database.Reader(db),
 	}
 }
 
@@ -83,6 +99,8 @@ func (r *bookingRepository) FindByCode(ctx context.Context, code string) (*entit
 			"total_amount",
 			"status",
 			"payment_status",
+			"payment_reference",
+			"tenant_id",
 			"created_at",
 			"updated_at",
 		).
@@ -113,6 +131,8 @@ func (r *bookingRepository) FindByID(ctx context.Context, id string) (*entity.Bo
 			"total_amount",
 			"status",
 			"payment_status",
+			"payment_reference",
+			"tenant_id",
 			"created_at",
 			"updated_at",
 		).
@@ -132,3 +152,80 @@ func (r *bookingRepository) FindByID(ctx context.Context, id string) (*entity.Bo
 
 	return &booking, nil
 }
+
+func (r *bookingRepository) FindByIDs(ctx context.Context, ids []string) ([]entity.Booking, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var bookings []entity.Booking
+	err := r.DB.WithContext(ctx).
+		Model(&entity.Booking{}).
+		Select(
+			"id",
+			"booking_code",
+			"user_id",
+			"total_amount",
+			"status",
+			"payment_status",
+			"payment_reference",
+			"tenant_id",
+			"created_at",
+			"updated_at",
+		).
+		Where("id IN ?", ids).
+		Find(&bookings).
+		Error
+
+	if err != nil {
+		return nil, database.MapDBError(err)
+	}
+
+	return bookings, nil
+}
+
+func (r *bookingRepository) List(ctx context.Context, s spec.Spec) ([]entity.Booking, error) {
+	var bookings []entity.Booking
+	err := database.ApplySpec(r.DB.WithContext(ctx).
+		Model(&entity.Booking{}).
+		Select(
+			"id",
+			"booking_code",
+			"user_id",
+			"total_amount",
+			"status",
+			"payment_status",
+			"payment_reference",
+			"tenant_id",
+			"created_at",
+			"updated_at",
+		), s, bookingFields).
+		Find(&bookings).
+		Error
+
+	if err != nil {
+		return nil, database.MapDBError(err)
+	}
+
+	return bookings, nil
+}
+
+func (r *bookingRepository) FindDetailsByBookingIDs(ctx context.Context, bookingIDs []string) ([]entity.BookingDetail, error) {
+	if len(bookingIDs) == 0 {
+		return nil, nil
+	}
+	var details []entity.BookingDetail
+	err := r.DB.WithContext(ctx).
+		Model(&entity.BookingDetail{}).
+		Select("id", "booking_id", "product_id", "product_name", "qty", "price_per_unit", "sub_total").
+		Where("booking_id IN ?", bookingIDs).
+		Order("created_at").
+		Order("id").
+		Find(&details).
+		Error
+
+	if err != nil {
+		return nil, database.MapDBError(err)
+	}
+
+	return details, nil
+}
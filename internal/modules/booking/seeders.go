@@ -0,0 +1,47 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/seed"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/modules/booking/fixture"
+
+	"gorm.io/gorm/clause"
+)
+
+// RegisterSeeders registers the seeders of the module.
+func RegisterSeeders(r *seed.Registry) {
+	r.Register("booking", seed.Seeder{
+		Name: "sample_bookings",
+		Kind: seed.Sample,
+		Run:  seedSampleBookings,
+	})
+}
+
+// seedSampleBookings inserts a booking per status for the default fixture
+// user, with fixed IDs so that local clients and docs can reference them.
+func seedSampleBookings(ctx context.Context, db database.Database) error {
+	sample := func(n int, code string, status entity.BookingStatus) *entity.Booking {
+		detail := fixture.NewBookingDetail("44444444-4444-4444-4444-444444444444", 2, 50)
+		detail.ID = fmt.Sprintf("0195a5c0-0000-7000-8000-0000000001%02d", n)
+		return fixture.NewBooking().
+			WithID(fmt.Sprintf("0195a5c0-0000-7000-8000-0000000000%02d", n)).
+			WithBookingCode(code).
+			WithStatus(status).
+			WithDetails([]fixture.BookingDetail{detail}).
+			ToEntity()
+	}
+
+	confirmed := sample(2, "SAMPLE-CONFIRMED", entity.BookingStatusConfirmed)
+	confirmed.PaymentStatus = entity.PaymentStatusPaid
+	bookings := []*entity.Booking{
+		sample(1, "SAMPLE-PENDING", entity.BookingStatusPending),
+		confirmed,
+		sample(3, "SAMPLE-CANCELLED", entity.BookingStatusCancelled),
+	}
+
+	// Running it again keeps the existing rows, changed or not.
+	return db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&bookings).Error
+}
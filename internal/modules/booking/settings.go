@@ -0,0 +1,53 @@
+package booking
+
+import (
+	"fmt"
+	"voyago/core-api/internal/infrastructure/config"
+)
+
+// Settings are the booking section of the domain configuration
+// (config/booking/config.yaml), decoded and validated at startup.
+type Settings struct {
+	PaymentReminder struct {
+		// Delay is how long after its creation the owner of a booking still
+		// unpaid is reminded, in seconds; 0 disables the reminders. It needs
+		// task_queue.enabled.
+		Delay int `mapstructure:"delay"`
+	} `mapstructure:"payment_reminder"`
+	Checkout struct {
+		// Timeout is how long a checkout may run before it is compensated, in
+		// seconds (default 900).
+		Timeout int `mapstructure:"timeout"`
+	} `mapstructure:"checkout"`
+}
+
+// Validate checks the delays are not negative.
+func (s *Settings) Validate() []config.Problem {
+	var problems []config.Problem
+	if s.PaymentReminder.Delay < 0 {
+		problems = append(problems, config.Problem{
+			Path:    "payment_reminder.delay",
+			Message: fmt.Sprintf("must not be negative, got %d", s.PaymentReminder.Delay),
+		})
+	}
+	if s.Checkout.Timeout < 0 {
+		problems = append(problems, config.Problem{
+			Path:    "checkout.timeout",
+			Message: fmt.Sprintf("must not be negative, got %d", s.Checkout.Timeout),
+		})
+	}
+	return problems
+}
+
+// settingsOf returns settings, or else the ones decoded from cfg, for the
+// callers of the Register functions leaving them unset.
+func settingsOf(settings *Settings, cfg *config.Config) *Settings {
+	if settings != nil {
+		return settings
+	}
+	settings = &Settings{}
+	if err := cfg.Section("booking", settings); err != nil {
+		panic(fmt.Errorf("invalid booking configuration: %w", err))
+	}
+	return settings
+}
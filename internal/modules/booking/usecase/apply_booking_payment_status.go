@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/modules/booking/repository"
+	"voyago/core-api/internal/pkg/audit"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+type ApplyBookingPaymentStatusRepositories struct {
+	BookingCmd repository.BookingCommandRepository
+	BookingQry repository.BookingQueryRepository
+}
+
+// applyBookingPaymentStatusUseCase is the private implementation of ApplyBookingPaymentStatusUseCase.
+type applyBookingPaymentStatusUseCase struct {
+	Log      logger.Logger
+	Tracer   tracer.Tracer
+	Events   eventbus.Publisher
+	Notifier BookingNotifier
+	Audit    audit.Recorder
+	Repo     ApplyBookingPaymentStatusRepositories
+}
+
+const applyPaymentStatusUseCaseName = "usecase:booking.payment_status.apply"
+
+var _ ApplyBookingPaymentStatusUseCase = (*applyBookingPaymentStatusUseCase)(nil)
+
+func NewApplyBookingPaymentStatusUseCase(log logger.Logger, trc tracer.Tracer, events eventbus.Publisher, notifier BookingNotifier, aud audit.Recorder, repo ApplyBookingPaymentStatusRepositories) ApplyBookingPaymentStatusUseCase {
+	return &applyBookingPaymentStatusUseCase{
+		Log:      log.WithField("action", applyPaymentStatusUseCaseName),
+		Tracer:   trc,
+		Events:   events,
+		Notifier: notifier,
+		Audit:    aud,
+		Repo:     repo,
+	}
+}
+
+func (uc *applyBookingPaymentStatusUseCase) Execute(ctx context.Context, payload entity.BookingPaymentStatusChangedPayload) error {
+	span, ctx := uc.Tracer.StartSpan(ctx, applyPaymentStatusUseCaseName)
+	defer span.Finish()
+	ctx = ctxkey.SetReadPrimary(ctx) // the booking is read to be changed: not from a lagging replica
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"booking_id":        payload.BookingID,
+			"payment_status":    payload.NewStatus,
+			"payment_reference": payload.PaymentReference,
+		},
+	}).Info("usecase started")
+
+	e, err := uc.Repo.BookingQry.FindByID(ctx, payload.BookingID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	if e == nil {
+		logAndTraceError(span, log, entity.ErrBookingNotFound, "booking not found", false)
+		return entity.ErrBookingNotFound
+	}
+
+	before := toBookingResponse(e)
+
+	// A newer payment change may already be stored when this event is
+	// handled; only the event payment status drives the booking lifecycle.
+	e.PaymentStatus = payload.NewStatus
+	if status, ok := e.StatusAfterPayment(); ok {
+		from := e.Status
+		if err := e.ChangeStatus(status); err != nil {
+			logAndTraceError(span, log, err, "domain logic validation failed", false)
+			return err
+		}
+		updatedAt := time.Now().UnixMilli()
+		e.UpdatedAt = &updatedAt
+
+		// A single conditional update does not need an explicit transaction.
+		// Losing the race against another status change is not an error: the
+		// booking has moved on and must not be overwritten.
+		changed, err := uc.Repo.BookingCmd.UpdateStatus(ctx, e, from)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return err
+		}
+		if !changed {
+			e.Status = from
+		} else {
+			// The update is already committed: a recording failure is logged
+			// but does not fail the handler, the event bus does not retry.
+			if err := uc.Audit.Record(ctx, audit.Change{
+				Action:     auditAction(applyPaymentStatusUseCaseName),
+				EntityType: auditEntityBooking,
+				EntityID:   e.ID,
+				Before:     before,
+				After:      toBookingResponse(e),
+			}); err != nil {
+				log.WithField("error", err.Error()).Warn("failed to record audit entry")
+			}
+
+			// PUBLISH DOMAIN EVENT (after the update, never inside it)
+			publishEvent(ctx, log, uc.Events, eventbus.NewEvent(entity.EventBookingStatusChanged, entity.EventSource, entity.BookingStatusChangedPayload{
+				BookingID:     e.ID,
+				BookingCode:   e.BookingCode,
+				UserID:        e.UserID,
+				OldStatus:     from,
+				NewStatus:     e.Status,
+				PaymentStatus: payload.NewStatus,
+			}))
+		}
+	}
+
+	// Notification failures are logged but do not fail the handler: the booking
+	// state is already consistent and the event bus does not retry.
+	if err := uc.Notifier.NotifyPaymentStatusChanged(ctx, BookingPaymentNotification{
+		UserID:           e.UserID,
+		BookingID:        e.ID,
+		BookingCode:      e.BookingCode,
+		PaymentStatus:    string(payload.NewStatus),
+		BookingStatus:    string(e.Status),
+		Amount:           payload.Amount,
+		PaymentReference: payload.PaymentReference,
+	}); err != nil {
+		log.WithFields(map[string]any{
+			"error": err.Error(),
+		}).Warn("failed to notify user")
+	}
+
+	log.Info("usecase completed")
+	return nil
+}
@@ -0,0 +1,360 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/saga"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/modules/booking/repository"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/audit"
+	baserepo "voyago/core-api/internal/pkg/repository"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+// CheckoutSagaName is the name of the checkout saga in the sagas table.
+const CheckoutSagaName = "booking.checkout"
+
+const (
+	// defaultCheckoutTimeout compensates a checkout still running past it,
+	// when booking.checkout.timeout is not set.
+	defaultCheckoutTimeout = 15 * time.Minute
+	// checkoutRecoveryStale is how long a checkout is left alone before it is
+	// recovered: above the timeout of its steps.
+	checkoutRecoveryStale = 2 * time.Minute
+	checkoutRecoveryBatch = 100
+)
+
+// CheckoutSagaData is the state of a checkout, persisted with its progress.
+type CheckoutSagaData struct {
+	BookingID     string  `json:"booking_id"`
+	UserID        string  `json:"user_id"`
+	Amount        float64 `json:"amount"`
+	PaymentMethod string  `json:"payment_method"`
+	// PaymentReference is set once the payment is captured.
+	PaymentReference string `json:"payment_reference,omitempty"`
+}
+
+type CheckoutBookingRepositories struct {
+	BookingCmd repository.BookingCommandRepository
+	BookingQry repository.BookingQueryRepository
+	Sagas      saga.Store
+}
+
+// CheckoutServices are the services called by the checkout.
+type CheckoutServices struct {
+	Inventory InventoryService
+	Payments  PaymentGateway
+}
+
+// checkoutBookingUseCase is the private implementation of CheckoutBookingUseCase.
+type checkoutBookingUseCase struct {
+	Log      logger.Logger
+	Tracer   tracer.Tracer
+	Metrics  *metrics.Business
+	Runner   baserepo.TransactionManager
+	Events   eventbus.Publisher
+	Audit    audit.Recorder
+	Services CheckoutServices
+	Repo     CheckoutBookingRepositories
+
+	saga *saga.Coordinator[CheckoutSagaData]
+}
+
+const (
+	checkoutBookingUseCaseName = "usecase:booking.checkout"
+	// cancelCheckoutName is the audit action of the cancellation of a booking
+	// by a failed checkout.
+	cancelCheckoutName = "usecase:booking.checkout.cancel"
+)
+
+var _ CheckoutBookingUseCase = (*checkoutBookingUseCase)(nil)
+
+// NewCheckoutBookingUseCase returns the checkout use case. m records the
+// metrics of the saga; timeout 0 uses the default of 15 minutes.
+func NewCheckoutBookingUseCase(log logger.Logger, trc tracer.Tracer, bm *metrics.Business, m metrics.Metrics, runner baserepo.TransactionManager, events eventbus.Publisher, aud audit.Recorder, services CheckoutServices, timeout time.Duration, repo CheckoutBookingRepositories) CheckoutBookingUseCase {
+	if timeout <= 0 {
+		timeout = defaultCheckoutTimeout
+	}
+	uc := &checkoutBookingUseCase{
+		Log:      log.WithField("action", checkoutBookingUseCaseName),
+		Tracer:   trc,
+		Metrics:  bm,
+		Runner:   runner,
+		Events:   events,
+		Audit:    aud,
+		Services: services,
+		Repo:     repo,
+	}
+	uc.saga = saga.NewCoordinator(saga.Definition[CheckoutSagaData]{
+		Name:    CheckoutSagaName,
+		Timeout: timeout,
+		Steps: []saga.Step[CheckoutSagaData]{
+			// Cancelling the booking is the compensation of the whole checkout.
+			{Name: "hold_booking", Action: uc.holdBooking, Compensate: uc.cancelBooking},
+			{Name: "reserve_inventory", Action: uc.reserveInventory, Compensate: uc.releaseInventory},
+			{Name: "capture_payment", Action: uc.capturePayment, Compensate: uc.refundPayment},
+			{Name: "confirm_booking", Action: uc.confirmBooking},
+		},
+	}, repo.Sagas, log, trc, m)
+	return uc
+}
+
+func (uc *checkoutBookingUseCase) Execute(ctx context.Context, req *CheckoutBookingRequest) (*BookingResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, checkoutBookingUseCaseName)
+	defer span.Finish()
+	ctx = ctxkey.SetReadPrimary(ctx) // the booking is read to be changed: not from a lagging replica
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"booking_id":     req.BookingID,
+			"payment_method": req.PaymentMethod,
+		},
+	}).Info("usecase started")
+
+	e, err := uc.Repo.BookingQry.FindByID(ctx, req.BookingID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	if e == nil {
+		logAndTraceError(span, log, entity.ErrBookingNotFound, "booking not found", false)
+		return nil, entity.ErrBookingNotFound
+	}
+	if !e.AwaitsPayment() {
+		logAndTraceError(span, log, entity.ErrBookingNotAwaitingPayment, "booking not awaiting payment", false)
+		return nil, entity.ErrBookingNotAwaitingPayment
+	}
+
+	_, err = uc.saga.Run(ctx, e.ID, &CheckoutSagaData{
+		BookingID:     e.ID,
+		UserID:        e.UserID,
+		Amount:        e.TotalAmount,
+		PaymentMethod: req.PaymentMethod,
+	})
+	var stepErr *saga.StepError
+	switch {
+	case errors.Is(err, saga.ErrDuplicate):
+		logAndTraceError(span, log, entity.ErrBookingCheckoutAlreadyStarted, "checkout already started", false)
+		return nil, entity.ErrBookingCheckoutAlreadyStarted
+	case errors.As(err, &stepErr):
+		appErr := apperror.NewPersistance(entity.CodeBookingCheckoutFailed, entity.ErrBookingCheckoutFailed.Message, stepErr).
+			WithDetail("step", stepErr.Step)
+		logAndTraceError(span, log, appErr, "checkout compensated", false)
+		return nil, appErr
+	case err != nil:
+		// The compensation is carried on by Recover.
+		logAndTraceError(span, log, err, "checkout interrupted", true)
+		return nil, err
+	}
+
+	e, err = uc.Repo.BookingQry.FindByID(ctx, req.BookingID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	if e == nil {
+		return nil, entity.ErrBookingNotFound
+	}
+
+	log.Info("usecase completed")
+	res := toBookingResponse(e)
+	return &res, nil
+}
+
+func (uc *checkoutBookingUseCase) Recover(ctx context.Context) (int, error) {
+	return uc.saga.Recover(ctx, checkoutRecoveryStale, checkoutRecoveryBatch)
+}
+
+// findBooking returns the booking of the checkout, read from the primary.
+func (uc *checkoutBookingUseCase) findBooking(ctx context.Context, id string) (*entity.Booking, error) {
+	e, err := uc.Repo.BookingQry.FindByID(ctxkey.SetReadPrimary(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		return nil, entity.ErrBookingNotFound
+	}
+	return e, nil
+}
+
+// holdBooking checks that the booking still awaits its payment: it may have
+// been paid or cancelled since the checkout was requested.
+func (uc *checkoutBookingUseCase) holdBooking(ctx context.Context, data *CheckoutSagaData) error {
+	e, err := uc.findBooking(ctx, data.BookingID)
+	if err != nil {
+		return err
+	}
+	if !e.AwaitsPayment() {
+		return entity.ErrBookingNotAwaitingPayment
+	}
+	return nil
+}
+
+// cancelBooking cancels the booking of a failed checkout, unless it is no
+// longer awaiting its payment (e.g., paid by another channel).
+func (uc *checkoutBookingUseCase) cancelBooking(ctx context.Context, data *CheckoutSagaData) error {
+	e, err := uc.findBooking(ctx, data.BookingID)
+	if errors.Is(err, entity.ErrBookingNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !e.AwaitsPayment() {
+		return nil
+	}
+
+	before := toBookingResponse(e)
+	from := e.Status
+	if err := e.ChangeStatus(entity.BookingStatusCancelled); err != nil {
+		return err
+	}
+	updatedAt := time.Now().UnixMilli()
+	e.UpdatedAt = &updatedAt
+
+	return uc.Runner.Atomic(ctx, func(txCtx context.Context) error {
+		changed, err := uc.Repo.BookingCmd.UpdateStatus(txCtx, e, from)
+		if err != nil || !changed {
+			return err
+		}
+		if err := uc.Audit.Record(txCtx, audit.Change{
+			Action:     auditAction(cancelCheckoutName),
+			EntityType: auditEntityBooking,
+			EntityID:   e.ID,
+			Before:     before,
+			After:      toBookingResponse(e),
+		}); err != nil {
+			return err
+		}
+
+		baserepo.RegisterAfterCommit(txCtx, func(ctx context.Context) {
+			publishEvent(ctx, uc.Log.WithContext(ctx), uc.Events, eventbus.NewEvent(entity.EventBookingStatusChanged, entity.EventSource, entity.BookingStatusChangedPayload{
+				BookingID:     e.ID,
+				BookingCode:   e.BookingCode,
+				UserID:        e.UserID,
+				OldStatus:     from,
+				NewStatus:     e.Status,
+				PaymentStatus: e.PaymentStatus,
+			}))
+		})
+		return nil
+	})
+}
+
+func (uc *checkoutBookingUseCase) reserveInventory(ctx context.Context, data *CheckoutSagaData) error {
+	details, err := uc.Repo.BookingQry.FindDetailsByBookingIDs(ctxkey.SetReadPrimary(ctx), []string{data.BookingID})
+	if err != nil {
+		return err
+	}
+	items := make([]InventoryItem, 0, len(details))
+	for _, d := range details {
+		items = append(items, InventoryItem{ProductID: d.ProductID, Qty: d.Qty})
+	}
+	return uc.Services.Inventory.Reserve(ctx, InventoryReservation{BookingID: data.BookingID, Items: items})
+}
+
+func (uc *checkoutBookingUseCase) releaseInventory(ctx context.Context, data *CheckoutSagaData) error {
+	return uc.Services.Inventory.Release(ctx, data.BookingID)
+}
+
+func (uc *checkoutBookingUseCase) capturePayment(ctx context.Context, data *CheckoutSagaData) error {
+	reference, err := uc.Services.Payments.Capture(ctx, PaymentCapture{
+		BookingID: data.BookingID,
+		UserID:    data.UserID,
+		Method:    data.PaymentMethod,
+		Amount:    data.Amount,
+	})
+	if err != nil {
+		return err
+	}
+	data.PaymentReference = reference
+	return nil
+}
+
+func (uc *checkoutBookingUseCase) refundPayment(ctx context.Context, data *CheckoutSagaData) error {
+	return uc.Services.Payments.Refund(ctx, data.BookingID)
+}
+
+// confirmBooking records the captured payment on the booking and confirms
+// it, in one transaction. Running it again once recorded is a no-op.
+func (uc *checkoutBookingUseCase) confirmBooking(ctx context.Context, data *CheckoutSagaData) error {
+	e, err := uc.findBooking(ctx, data.BookingID)
+	if err != nil {
+		return err
+	}
+	if e.PaymentStatus == entity.PaymentStatusPaid && e.PaymentReference != nil && *e.PaymentReference == data.PaymentReference {
+		return nil
+	}
+	if !e.AwaitsPayment() {
+		return entity.ErrBookingNotAwaitingPayment
+	}
+
+	before := toBookingResponse(e)
+	oldPaymentStatus, oldStatus := e.PaymentStatus, e.Status
+	if err := e.ChangePaymentStatus(entity.PaymentStatusPaid, data.PaymentReference); err != nil {
+		return err
+	}
+	if err := e.ChangeStatus(entity.BookingStatusConfirmed); err != nil {
+		return err
+	}
+	updatedAt := time.Now().UnixMilli()
+	e.UpdatedAt = &updatedAt
+
+	err = uc.Runner.Atomic(ctx, func(txCtx context.Context) error {
+		if err := uc.Repo.BookingCmd.UpdatePaymentStatus(txCtx, e, oldPaymentStatus); err != nil {
+			return err
+		}
+		changed, err := uc.Repo.BookingCmd.UpdateStatus(txCtx, e, oldStatus)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			// Cancelled concurrently: the payment is refunded.
+			return entity.ErrBookingNotAwaitingPayment
+		}
+		if err := uc.Audit.Record(txCtx, audit.Change{
+			Action:     auditAction(checkoutBookingUseCaseName),
+			EntityType: auditEntityBooking,
+			EntityID:   e.ID,
+			Before:     before,
+			After:      toBookingResponse(e),
+		}); err != nil {
+			return err
+		}
+
+		baserepo.RegisterAfterCommit(txCtx, func(ctx context.Context) {
+			log := uc.Log.WithContext(ctx)
+			publishEvent(ctx, log, uc.Events, eventbus.NewEvent(entity.EventBookingPaymentStatusChanged, entity.EventSource, entity.BookingPaymentStatusChangedPayload{
+				BookingID:        e.ID,
+				BookingCode:      e.BookingCode,
+				UserID:           e.UserID,
+				OldStatus:        oldPaymentStatus,
+				NewStatus:        e.PaymentStatus,
+				Amount:           e.TotalAmount,
+				PaymentReference: data.PaymentReference,
+			}))
+			publishEvent(ctx, log, uc.Events, eventbus.NewEvent(entity.EventBookingStatusChanged, entity.EventSource, entity.BookingStatusChangedPayload{
+				BookingID:     e.ID,
+				BookingCode:   e.BookingCode,
+				UserID:        e.UserID,
+				OldStatus:     oldStatus,
+				NewStatus:     e.Status,
+				PaymentStatus: e.PaymentStatus,
+			}))
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	uc.Metrics.PaymentStatusChanged(string(oldPaymentStatus), string(e.PaymentStatus))
+	return nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"voyago/core-api/internal/modules/booking/entity"
 )
 
 // -------- DTOs --------
@@ -37,6 +38,113 @@ type CreateBookingDetailResponse struct {
 	SubTotal     float64 `json:"sub_total"`
 }
 
+type ListBookingsRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid" label:"User ID"`
+	Limit  int    `json:"limit" validate:"omitempty,gte=1,lte=100" label:"Limit"`
+	Offset int    `json:"offset" validate:"omitempty,gte=0" label:"Offset"`
+}
+
+// BookingResponse is the read model of a booking header.
+// Details are resolved separately (see GetBookingDetailsUseCase) so that
+// callers only pay for them when requested.
+type BookingResponse struct {
+	BookingID     string  `json:"id"`
+	BookingCode   string  `json:"code"`
+	UserID        string  `json:"user_id"`
+	TotalAmount   float64 `json:"total_amount"`
+	Status        string  `json:"status"`
+	PaymentStatus string  `json:"payment_status"`
+	// PaymentReference is the payment module transaction behind PaymentStatus.
+	PaymentReference *string `json:"payment_reference"`
+	CreatedAt        int64   `json:"created_at"`
+	UpdatedAt        *int64  `json:"updated_at"`
+}
+
+// UpdateBookingPaymentStatusRequest is sent by the payment module whenever a
+// payment attached to a booking changes state.
+type UpdateBookingPaymentStatusRequest struct {
+	BookingID        string `json:"-" params:"id" validate:"required,uuid" label:"Booking ID"`
+	PaymentStatus    string `json:"payment_status" validate:"required,oneof=PAID FAILED REFUNDED" label:"Payment status"`
+	PaymentReference string `json:"payment_reference" validate:"required,max=100" label:"Payment reference"`
+}
+
+// BookingPaymentNotification is what the user is told about a payment change.
+type BookingPaymentNotification struct {
+	UserID           string
+	BookingID        string
+	BookingCode      string
+	PaymentStatus    string
+	BookingStatus    string
+	Amount           float64
+	PaymentReference string
+}
+
+// BookingPaymentReminder reminds the user to pay a pending booking.
+type BookingPaymentReminder struct {
+	UserID      string
+	BookingID   string
+	BookingCode string
+	Amount      float64
+}
+
+// BookingNotifier delivers notifications to the booking owner. It is a port so
+// that the channel (e-mail, push, ...) can be swapped without touching the use case.
+type BookingNotifier interface {
+	NotifyPaymentStatusChanged(ctx context.Context, n BookingPaymentNotification) error
+	NotifyPaymentReminder(ctx context.Context, r BookingPaymentReminder) error
+}
+
+// CheckoutBookingRequest pays a pending booking and confirms it.
+type CheckoutBookingRequest struct {
+	BookingID     string `json:"-" params:"id" validate:"required,uuid" label:"Booking ID"`
+	PaymentMethod string `json:"payment_method" validate:"required,max=50" label:"Payment method"`
+}
+
+// InventoryItem is a product held for a booking.
+type InventoryItem struct {
+	ProductID string
+	Qty       int32
+}
+
+// InventoryReservation holds the products of a booking until it is confirmed.
+type InventoryReservation struct {
+	BookingID string
+	Items     []InventoryItem
+}
+
+// InventoryService holds the stock of the products. Both calls are keyed on
+// the booking and idempotent: releasing a booking never reserved succeeds.
+type InventoryService interface {
+	Reserve(ctx context.Context, r InventoryReservation) error
+	Release(ctx context.Context, bookingID string) error
+}
+
+// PaymentCapture charges the owner of a booking.
+type PaymentCapture struct {
+	BookingID string
+	UserID    string
+	Method    string
+	Amount    float64
+}
+
+// PaymentGateway charges the payments. Both calls are keyed on the booking
+// and idempotent: capturing a booking twice charges it once, refunding a
+// booking never captured succeeds.
+type PaymentGateway interface {
+	// Capture returns the reference of the payment transaction.
+	Capture(ctx context.Context, p PaymentCapture) (string, error)
+	Refund(ctx context.Context, bookingID string) error
+}
+
+type BookingDetailResponse struct {
+	ID           string  `json:"id"`
+	ProductID    string  `json:"product_id"`
+	ProductName  *string `json:"product_name"`
+	Qty          int32   `json:"qty"`
+	PricePerUnit float64 `json:"price_per_unit"`
+	SubTotal     float64 `json:"sub_total"`
+}
+
 // -------- Usecase Interfaces --------
 // [CONTRACT DEFINITION]
 // CreateBookingUseCase defines the business contract for booking creation.
@@ -46,3 +154,54 @@ type CreateBookingUseCase interface {
 	// It returns a CreateBookingResponse on success or an apperror.AppError on failure.
 	Execute(ctx context.Context, req *CreateBookingRequest) (*CreateBookingResponse, error)
 }
+
+// ListBookingsUseCase returns a page of a user's bookings, newest first.
+type ListBookingsUseCase interface {
+	Execute(ctx context.Context, req *ListBookingsRequest) ([]BookingResponse, error)
+}
+
+// GetBookingsByIDsUseCase resolves many bookings at once.
+// It is designed as a dataloader batch function: the result is keyed by ID
+// and unknown IDs are absent from the map.
+type GetBookingsByIDsUseCase interface {
+	Execute(ctx context.Context, ids []string) (map[string]BookingResponse, error)
+}
+
+// GetBookingDetailsUseCase resolves the details of many bookings in a single query.
+// It is designed as a dataloader batch function: the result is keyed by booking ID.
+type GetBookingDetailsUseCase interface {
+	Execute(ctx context.Context, bookingIDs []string) (map[string][]BookingDetailResponse, error)
+}
+
+// UpdateBookingPaymentStatusUseCase records a payment status change on a booking
+// and publishes entity.EventBookingPaymentStatusChanged after commit.
+// Repeating the current status with the same reference is a no-op, so payment
+// callbacks can be retried safely.
+type UpdateBookingPaymentStatusUseCase interface {
+	Execute(ctx context.Context, req *UpdateBookingPaymentStatusRequest) (*BookingResponse, error)
+}
+
+// ApplyBookingPaymentStatusUseCase consumes entity.EventBookingPaymentStatusChanged:
+// it moves the booking lifecycle accordingly (e.g., PAID confirms a pending
+// booking) and notifies the user.
+type ApplyBookingPaymentStatusUseCase interface {
+	Execute(ctx context.Context, payload entity.BookingPaymentStatusChangedPayload) error
+}
+
+// CheckoutBookingUseCase pays a pending booking and confirms it, as the
+// booking.checkout saga: the inventory is reserved, the payment captured,
+// then recorded on the booking. When a step fails, the steps done are
+// compensated and the booking is cancelled.
+type CheckoutBookingUseCase interface {
+	Execute(ctx context.Context, req *CheckoutBookingRequest) (*BookingResponse, error)
+	// Recover carries on the checkouts interrupted by a crash, returning how
+	// many were recovered.
+	Recover(ctx context.Context) (int, error)
+}
+
+// SendPaymentReminderUseCase runs entity.TaskPaymentReminder: it reminds the
+// user to pay the booking when it is still pending and unpaid. A booking
+// paid, cancelled or deleted in the meantime is skipped.
+type SendPaymentReminderUseCase interface {
+	Execute(ctx context.Context, payload entity.PaymentReminderPayload) error
+}
@@ -13,7 +13,8 @@
 | - Observability: Ensure actions are searchable via business keys.
 | - Validation: Enforce strict DTO validation before domain processing.
 | - Atomicity: Guarantee data consistency via TransactionManager.
-| - Side Effects: Trigger external events ONLY after a successful commit.
+| - Side Effects: Trigger external events ONLY after a successful commit:
+|   register them inside Atomic with baserepo.RegisterAfterCommit.
 |
 | [2. LOGGING OPERATIONAL SCOPE]
 | - MINIMAL LOGS: Each execution logs "started" and either "completed"
@@ -41,11 +42,16 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/eventbus"
 	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
 	"voyago/core-api/internal/infrastructure/telemetry/tracer"
 	"voyago/core-api/internal/modules/booking/entity"
 	"voyago/core-api/internal/modules/booking/repository"
 	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/audit"
 	baserepo "voyago/core-api/internal/pkg/repository"
 	"voyago/core-api/internal/pkg/uid"
 	"voyago/core-api/internal/pkg/utils"
@@ -59,10 +65,16 @@ type CreateBookingRepositories struct {
 // createBookingUseCase is the private implementation of CreateBookingUseCase.
 // Use NewCreateBookingUseCase constructor to instantiate.
 type createBookingUseCase struct {
-	Log    logger.Logger
-	Tracer tracer.Tracer
-	Runner baserepo.TransactionManager
-	Repo   CreateBookingRepositories
+	Log     logger.Logger
+	Tracer  tracer.Tracer
+	Metrics *metrics.Business
+	Runner  baserepo.TransactionManager
+	Events  eventbus.Publisher
+	Audit   audit.Recorder
+	IDs     uid.Generator
+	// Reminders schedules the payment reminder of the booking.
+	Reminders PaymentReminderPolicy
+	Repo      CreateBookingRepositories
 }
 
 const (
@@ -76,13 +88,18 @@ const (
 // This prevents runtime panics or dependency injection failures if the interface changes.
 var _ CreateBookingUseCase = (*createBookingUseCase)(nil)
 
-func NewCreateBookingUseCase(log logger.Logger, trc tracer.Tracer, runner baserepo.TransactionManager, repo CreateBookingRepositories) CreateBookingUseCase {
+func NewCreateBookingUseCase(log logger.Logger, trc tracer.Tracer, bm *metrics.Business, runner baserepo.TransactionManager, events eventbus.Publisher, aud audit.Recorder, ids uid.Generator, reminders PaymentReminderPolicy, repo CreateBookingRepositories) CreateBookingUseCase {
 	return &createBookingUseCase{
 		// WithField creates a sub-logger that automatically attaches the "action" context.
-		Log:    log.WithField("action", useCaseName),
-		Tracer: trc,
-		Runner: runner,
-		Repo:   repo,
+		Log:       log.WithField("action", useCaseName),
+		Tracer:    trc,
+		Metrics:   bm,
+		Runner:    runner,
+		Events:    events,
+		Audit:     aud,
+		IDs:       ids,
+		Reminders: reminders,
+		Repo:      repo,
 	}
 }
 
@@ -135,11 +152,11 @@ func (uc *createBookingUseCase) Execute(ctx context.Context, req *CreateBookingR
 	//    utils.RecordSpanError(span, err)
 	//    return nil, err // BUBBLE UP: Let Repo handle the logging
 	// }
-	bookingID := uid.NewUUID()
+	bookingID := uc.IDs.NewID()
 	totalAmount := 0.0
 	var details []entity.BookingDetail
 	for _, d := range req.Details {
-		detailID := uid.NewUUID()
+		detailID := uc.IDs.NewID()
 		totalAmount += d.PricePerUnit * float64(d.Qty)
 		details = append(details, entity.BookingDetail{
 			ID:           detailID,
@@ -157,7 +174,7 @@ func (uc *createBookingUseCase) Execute(ctx context.Context, req *CreateBookingR
 		UserID:        req.UserID,
 		TotalAmount:   req.TotalAmount,
 		Status:        entity.BookingStatusPending,
-		PaymentStatus: "UNPAID",
+		PaymentStatus: entity.PaymentStatusUnpaid,
 		Details:       details,
 	}
 
@@ -188,6 +205,7 @@ func (uc *createBookingUseCase) Execute(ctx context.Context, req *CreateBookingR
 			logFields["retryable"] = appErr.IsRetryable()
 		}
 		log.WithFields(logFields).Warn("domain logic validation failed")
+		uc.Metrics.BookingFailed(errorCode(err))
 
 		// 3. HALT: Return the error immediately.
 		// Since e.Validate() returns an AppError, the transport layer
@@ -197,12 +215,14 @@ func (uc *createBookingUseCase) Execute(ctx context.Context, req *CreateBookingR
 
 	// --- PILLAR: BUSINESS RULE VALIDATION ---
 	// Checking for uniqueness is a business rule that requires external context (DB).
-	exists, err := uc.Repo.BookingQry.ExistsByBookingCode(ctx, e.BookingCode)
+	// It is read from the primary: a replica may lag behind a booking just created.
+	exists, err := uc.Repo.BookingQry.ExistsByBookingCode(ctxkey.SetReadPrimary(ctx), e.BookingCode)
 	if err != nil {
 		// [STANDARD ERROR HANDLING]: BUBBLE UP
 		// We only record the span error to ensure the trace reflects the failure.
 		// Logging is already handled by the Repository/DB bridge.
 		utils.RecordSpanError(span, err)
+		uc.Metrics.BookingFailed(errorCode(err))
 		return nil, err
 	}
 
@@ -210,6 +230,7 @@ func (uc *createBookingUseCase) Execute(ctx context.Context, req *CreateBookingR
 		// [STANDARD ERROR HANDLING]: Logged because it's a UseCase-level business violation.
 		// We add an attribute to the span to mark this specific business failure.
 		logAndTraceError(span, log, entity.ErrBookingCodeAlreadyExists, "domain logic validation failed", false)
+		uc.Metrics.BookingFailed(entity.CodeBookingCodeAlreadyExists)
 		return nil, entity.ErrBookingCodeAlreadyExists
 	}
 
@@ -218,19 +239,45 @@ func (uc *createBookingUseCase) Execute(ctx context.Context, req *CreateBookingR
 	// This guarantees ACID compliance—ensuring that the Booking header,
 	// associated line items, and any state changes are committed as a single unit.
 	// If any repository call fails, the entire transaction will roll back to prevent data corruption.
+	// The audit entry is part of the transaction: no change is committed unrecorded.
 	errRunner := uc.Runner.Atomic(ctx, func(txCtx context.Context) error {
 		if err := uc.Repo.BookingCmd.Create(txCtx, &e); err != nil {
 			return err
 		}
-		return nil
+		if err := uc.Audit.Record(txCtx, audit.Change{
+			Action:     auditAction(useCaseName),
+			EntityType: auditEntityBooking,
+			EntityID:   e.ID,
+			After:      toBookingResponse(&e),
+		}); err != nil {
+			return err
+		}
+
+		// --- PILLAR: SIDE EFFECTS (AFTER COMMIT) ---
+		// Events are published only once the transaction has been committed, so
+		// subscribers never observe a booking that could still be rolled back.
+		// A publish failure must not fail the request: the booking already exists.
+		baserepo.RegisterAfterCommit(txCtx, func(ctx context.Context) {
+			publishEvent(ctx, log, uc.Events, eventbus.NewEvent(entity.EventBookingCreated, entity.EventSource, entity.BookingCreatedPayload{
+				BookingID:     e.ID,
+				BookingCode:   e.BookingCode,
+				UserID:        e.UserID,
+				TotalAmount:   e.TotalAmount,
+				Status:        e.Status,
+				PaymentStatus: e.PaymentStatus,
+			}))
+		})
+		return uc.Reminders.schedule(txCtx, log, e.ID)
 	})
 	if errRunner != nil {
 		// [STANDARD ERROR HANDLING]: BUBBLE UP
 		// We only record the span error to ensure the trace reflects the failure.
 		// Logging is already handled by the Repository/DB bridge.
 		utils.RecordSpanError(span, errRunner)
+		uc.Metrics.BookingFailed(errorCode(errRunner))
 		return nil, errRunner
 	}
+	uc.Metrics.BookingCreated(e.TotalAmount)
 
 	// [LOGGING OPERATIONAL SCOPE: COMPLETED]
 	// Clean exit log: relying on TraceID for correlation with the "started" log.
@@ -283,3 +330,33 @@ func logAndTraceError(span tracer.Span, log logger.Logger, err error, msg string
 		l.Warn(msg)
 	}
 }
+
+// publishEvent publishes evt, logging a failure: the change is already
+// committed, so a publish failure must not fail the use case.
+func publishEvent(ctx context.Context, log logger.Logger, events eventbus.Publisher, evt eventbus.Event) {
+	if err := events.Publish(ctx, evt); err != nil {
+		log.WithFields(map[string]any{
+			"error":      err.Error(),
+			"event_type": evt.Type,
+		}).Warn("failed to publish domain event")
+	}
+}
+
+// auditEntityBooking is the entity type of the booking audit entries.
+const auditEntityBooking = "booking"
+
+// auditAction returns the audit action of a use case: its name without the
+// layer (e.g., "booking.create").
+func auditAction(useCaseName string) string {
+	return strings.TrimPrefix(useCaseName, "usecase:")
+}
+
+// errorCode returns the code of err for the business metrics, INTERNAL_ERROR
+// for an error that is not an AppError.
+func errorCode(err error) string {
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return apperror.CodeInternalError
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/booking/repository"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+type GetBookingDetailsRepositories struct {
+	BookingQry repository.BookingQueryRepository
+}
+
+// getBookingDetailsUseCase is the private implementation of GetBookingDetailsUseCase.
+type getBookingDetailsUseCase struct {
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	Repo   GetBookingDetailsRepositories
+}
+
+const getDetailsUseCaseName = "usecase:booking.detail.get_by_booking_ids"
+
+var _ GetBookingDetailsUseCase = (*getBookingDetailsUseCase)(nil)
+
+func NewGetBookingDetailsUseCase(log logger.Logger, trc tracer.Tracer, repo GetBookingDetailsRepositories) GetBookingDetailsUseCase {
+	return &getBookingDetailsUseCase{
+		Log:    log.WithField("action", getDetailsUseCaseName),
+		Tracer: trc,
+		Repo:   repo,
+	}
+}
+
+func (uc *getBookingDetailsUseCase) Execute(ctx context.Context, bookingIDs []string) (map[string][]BookingDetailResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, getDetailsUseCaseName)
+	defer span.Finish()
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"count_booking_ids": len(bookingIDs),
+		},
+	}).Info("usecase started")
+
+	details, err := uc.Repo.BookingQry.FindDetailsByBookingIDs(ctx, bookingIDs)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	res := make(map[string][]BookingDetailResponse, len(bookingIDs))
+	for _, d := range details {
+		res[d.BookingID] = append(res[d.BookingID], BookingDetailResponse{
+			ID:           d.ID,
+			ProductID:    d.ProductID,
+			ProductName:  d.ProductName,
+			Qty:          d.Qty,
+			PricePerUnit: d.PricePerUnit,
+			SubTotal:     d.SubTotal,
+		})
+	}
+
+	log.Info("usecase completed")
+	return res, nil
+}
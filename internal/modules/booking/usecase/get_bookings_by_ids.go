@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/booking/repository"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+type GetBookingsByIDsRepositories struct {
+	BookingQry repository.BookingQueryRepository
+}
+
+// getBookingsByIDsUseCase is the private implementation of GetBookingsByIDsUseCase.
+type getBookingsByIDsUseCase struct {
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	Repo   GetBookingsByIDsRepositories
+}
+
+const getByIDsUseCaseName = "usecase:booking.get_by_ids"
+
+var _ GetBookingsByIDsUseCase = (*getBookingsByIDsUseCase)(nil)
+
+func NewGetBookingsByIDsUseCase(log logger.Logger, trc tracer.Tracer, repo GetBookingsByIDsRepositories) GetBookingsByIDsUseCase {
+	return &getBookingsByIDsUseCase{
+		Log:    log.WithField("action", getByIDsUseCaseName),
+		Tracer: trc,
+		Repo:   repo,
+	}
+}
+
+func (uc *getBookingsByIDsUseCase) Execute(ctx context.Context, ids []string) (map[string]BookingResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, getByIDsUseCaseName)
+	defer span.Finish()
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"count_ids": len(ids),
+		},
+	}).Info("usecase started")
+
+	bookings, err := uc.Repo.BookingQry.FindByIDs(ctx, ids)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	res := make(map[string]BookingResponse, len(bookings))
+	for i := range bookings {
+		res[bookings[i].ID] = toBookingResponse(&bookings[i])
+	}
+
+	log.Info("usecase completed")
+	return res, nil
+}
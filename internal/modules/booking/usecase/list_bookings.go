@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/modules/booking/repository"
+	"voyago/core-api/internal/pkg/spec"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+type ListBookingsRepositories struct {
+	BookingQry repository.BookingQueryRepository
+}
+
+// listBookingsUseCase is the private implementation of ListBookingsUseCase.
+type listBookingsUseCase struct {
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	Repo   ListBookingsRepositories
+}
+
+const (
+	listUseCaseName     = "usecase:booking.list"
+	defaultBookingLimit = 20
+)
+
+var _ ListBookingsUseCase = (*listBookingsUseCase)(nil)
+
+func NewListBookingsUseCase(log logger.Logger, trc tracer.Tracer, repo ListBookingsRepositories) ListBookingsUseCase {
+	return &listBookingsUseCase{
+		Log:    log.WithField("action", listUseCaseName),
+		Tracer: trc,
+		Repo:   repo,
+	}
+}
+
+func (uc *listBookingsUseCase) Execute(ctx context.Context, req *ListBookingsRequest) ([]BookingResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, listUseCaseName)
+	defer span.Finish()
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"user_id": req.UserID,
+		},
+	}).Info("usecase started")
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultBookingLimit
+	}
+
+	// A page of the user's bookings, newest first.
+	bookings, err := uc.Repo.BookingQry.List(ctx, spec.New(
+		spec.Where("user_id", spec.Eq, req.UserID),
+		spec.OrderBy("created_at", spec.Desc),
+		spec.OrderBy("id", spec.Asc),
+		spec.Limit(limit),
+		spec.Offset(req.Offset),
+	))
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	res := make([]BookingResponse, 0, len(bookings))
+	for i := range bookings {
+		res = append(res, toBookingResponse(&bookings[i]))
+	}
+
+	log.Info("usecase completed")
+	return res, nil
+}
+
+func toBookingResponse(e *entity.Booking) BookingResponse {
+	return BookingResponse{
+		BookingID:        e.ID,
+		BookingCode:      e.BookingCode,
+		UserID:           e.UserID,
+		TotalAmount:      e.TotalAmount,
+		Status:           string(e.Status),
+		PaymentStatus:    string(e.PaymentStatus),
+		PaymentReference: e.PaymentReference,
+		CreatedAt:        e.CreatedAt,
+		UpdatedAt:        e.UpdatedAt,
+	}
+}
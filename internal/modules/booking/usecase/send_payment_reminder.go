@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/taskqueue"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/modules/booking/repository"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+// PaymentReminderTask is entity.TaskPaymentReminder with its payload.
+var PaymentReminderTask = taskqueue.NewType[entity.PaymentReminderPayload](entity.TaskPaymentReminder)
+
+// PaymentReminderPolicy schedules the payment reminder of the new bookings.
+// The zero value schedules none.
+type PaymentReminderPolicy struct {
+	// Tasks enqueues the reminders, nil when the task queue is disabled.
+	Tasks taskqueue.Enqueuer
+	// Delay is how long after its creation a booking is reminded, 0
+	// disables the reminders.
+	Delay time.Duration
+}
+
+// schedule enqueues the reminder of the booking once the transaction of
+// txCtx is committed. The booking ID makes the task unique.
+func (p PaymentReminderPolicy) schedule(txCtx context.Context, log logger.Logger, bookingID string) error {
+	if p.Tasks == nil || p.Delay <= 0 {
+		return nil
+	}
+	task, err := PaymentReminderTask.New(
+		entity.PaymentReminderPayload{BookingID: bookingID},
+		taskqueue.ProcessIn(p.Delay),
+		taskqueue.ID(entity.TaskPaymentReminder+":"+bookingID),
+	)
+	if err != nil {
+		return err
+	}
+	taskqueue.EnqueueAfterCommit(txCtx, log, p.Tasks, task)
+	return nil
+}
+
+type SendPaymentReminderRepositories struct {
+	BookingQry repository.BookingQueryRepository
+}
+
+// sendPaymentReminderUseCase is the private implementation of SendPaymentReminderUseCase.
+type sendPaymentReminderUseCase struct {
+	Log      logger.Logger
+	Tracer   tracer.Tracer
+	Notifier BookingNotifier
+	Repo     SendPaymentReminderRepositories
+}
+
+const sendPaymentReminderUseCaseName = "usecase:booking.payment_reminder.send"
+
+var _ SendPaymentReminderUseCase = (*sendPaymentReminderUseCase)(nil)
+
+func NewSendPaymentReminderUseCase(log logger.Logger, trc tracer.Tracer, notifier BookingNotifier, repo SendPaymentReminderRepositories) SendPaymentReminderUseCase {
+	return &sendPaymentReminderUseCase{
+		Log:      log.WithField("action", sendPaymentReminderUseCaseName),
+		Tracer:   trc,
+		Notifier: notifier,
+		Repo:     repo,
+	}
+}
+
+func (uc *sendPaymentReminderUseCase) Execute(ctx context.Context, payload entity.PaymentReminderPayload) error {
+	span, ctx := uc.Tracer.StartSpan(ctx, sendPaymentReminderUseCaseName)
+	defer span.Finish()
+	ctx = ctxkey.SetReadPrimary(ctx) // a payment just recorded must not be missed on a lagging replica
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"booking_id": payload.BookingID,
+		},
+	}).Info("usecase started")
+
+	e, err := uc.Repo.BookingQry.FindByID(ctx, payload.BookingID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	if e == nil || !e.AwaitsPayment() {
+		// Deleted, paid or cancelled since: there is nothing to remind.
+		log.Info("usecase completed, no reminder needed")
+		return nil
+	}
+
+	// A notifier failure has the task retried.
+	if err := uc.Notifier.NotifyPaymentReminder(ctx, BookingPaymentReminder{
+		UserID:      e.UserID,
+		BookingID:   e.ID,
+		BookingCode: e.BookingCode,
+		Amount:      e.TotalAmount,
+	}); err != nil {
+		logAndTraceError(span, log, err, "failed to send payment reminder", false)
+		return err
+	}
+
+	log.Info("usecase completed")
+	return nil
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/modules/booking/repository"
+	"voyago/core-api/internal/pkg/audit"
+	baserepo "voyago/core-api/internal/pkg/repository"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+type UpdateBookingPaymentStatusRepositories struct {
+	BookingCmd repository.BookingCommandRepository
+	BookingQry repository.BookingQueryRepository
+}
+
+// updateBookingPaymentStatusUseCase is the private implementation of UpdateBookingPaymentStatusUseCase.
+type updateBookingPaymentStatusUseCase struct {
+	Log     logger.Logger
+	Tracer  tracer.Tracer
+	Metrics *metrics.Business
+	Runner  baserepo.TransactionManager
+	Events  eventbus.Publisher
+	Audit   audit.Recorder
+	Repo    UpdateBookingPaymentStatusRepositories
+}
+
+const updatePaymentStatusUseCaseName = "usecase:booking.payment_status.update"
+
+var _ UpdateBookingPaymentStatusUseCase = (*updateBookingPaymentStatusUseCase)(nil)
+
+func NewUpdateBookingPaymentStatusUseCase(log logger.Logger, trc tracer.Tracer, bm *metrics.Business, runner baserepo.TransactionManager, events eventbus.Publisher, aud audit.Recorder, repo UpdateBookingPaymentStatusRepositories) UpdateBookingPaymentStatusUseCase {
+	return &updateBookingPaymentStatusUseCase{
+		Log:     log.WithField("action", updatePaymentStatusUseCaseName),
+		Tracer:  trc,
+		Metrics: bm,
+		Runner:  runner,
+		Events:  events,
+		Audit:   aud,
+		Repo:    repo,
+	}
+}
+
+func (uc *updateBookingPaymentStatusUseCase) Execute(ctx context.Context, req *UpdateBookingPaymentStatusRequest) (*BookingResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, updatePaymentStatusUseCaseName)
+	defer span.Finish()
+	ctx = ctxkey.SetReadPrimary(ctx) // the booking is read to be changed: not from a lagging replica
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"booking_id":        req.BookingID,
+			"payment_status":    req.PaymentStatus,
+			"payment_reference": req.PaymentReference,
+		},
+	}).Info("usecase started")
+
+	e, err := uc.Repo.BookingQry.FindByID(ctx, req.BookingID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	if e == nil {
+		logAndTraceError(span, log, entity.ErrBookingNotFound, "booking not found", false)
+		return nil, entity.ErrBookingNotFound
+	}
+
+	status := entity.PaymentStatus(req.PaymentStatus)
+
+	// Payment callbacks are delivered at least once: replaying the change that
+	// is already recorded must succeed without emitting a second event.
+	if e.PaymentStatus == status && e.PaymentReference != nil && *e.PaymentReference == req.PaymentReference {
+		log.Info("usecase completed")
+		res := toBookingResponse(e)
+		return &res, nil
+	}
+
+	oldStatus := e.PaymentStatus
+	before := toBookingResponse(e)
+	if err := e.ChangePaymentStatus(status, req.PaymentReference); err != nil {
+		logAndTraceError(span, log, err, "domain logic validation failed", false)
+		return nil, err
+	}
+	updatedAt := time.Now().UnixMilli()
+	e.UpdatedAt = &updatedAt
+
+	errRunner := uc.Runner.Atomic(ctx, func(txCtx context.Context) error {
+		if err := uc.Repo.BookingCmd.UpdatePaymentStatus(txCtx, e, oldStatus); err != nil {
+			return err
+		}
+		if err := uc.Audit.Record(txCtx, audit.Change{
+			Action:     auditAction(updatePaymentStatusUseCaseName),
+			EntityType: auditEntityBooking,
+			EntityID:   e.ID,
+			Before:     before,
+			After:      toBookingResponse(e),
+		}); err != nil {
+			return err
+		}
+
+		// --- PILLAR: SIDE EFFECTS (AFTER COMMIT) ---
+		baserepo.RegisterAfterCommit(txCtx, func(ctx context.Context) {
+			publishEvent(ctx, log, uc.Events, eventbus.NewEvent(entity.EventBookingPaymentStatusChanged, entity.EventSource, entity.BookingPaymentStatusChangedPayload{
+				BookingID:        e.ID,
+				BookingCode:      e.BookingCode,
+				UserID:           e.UserID,
+				OldStatus:        oldStatus,
+				NewStatus:        e.PaymentStatus,
+				Amount:           e.TotalAmount,
+				PaymentReference: req.PaymentReference,
+			}))
+		})
+		return nil
+	})
+	if errRunner != nil {
+		utils.RecordSpanError(span, errRunner)
+		return nil, errRunner
+	}
+	uc.Metrics.PaymentStatusChanged(string(oldStatus), string(e.PaymentStatus))
+
+	log.Info("usecase completed")
+	res := toBookingResponse(e)
+	return &res, nil
+}
@@ -0,0 +1,176 @@
+/*
+|------------------------------------------------------------------------------------
+| GRAPHQL RESOLVER
+|------------------------------------------------------------------------------------
+|
+| Root query resolvers follow the HTTP handler standards of the booking module:
+| DTO validation at the entry point, a single Anchor Log and error bubbling.
+| Errors are converted to GraphQL errors by gqlserver.FormatErrors.
+|
+| Nested fields (Category.parent, Category.children) must go through the
+| request-scoped dataloaders so that a tree of N categories costs one query
+| per level instead of N.
+|
+|------------------------------------------------------------------------------------
+*/
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/validator"
+	"voyago/core-api/internal/modules/category/usecase"
+	"voyago/core-api/internal/pkg/apperror"
+
+	gql "github.com/graph-gophers/graphql-go"
+)
+
+// Schema is the SDL fragment of the category module.
+//
+//go:embed schema.graphql
+var Schema string
+
+type HandlerUseCases struct {
+	ListCategoriesUseCase      usecase.ListCategoriesUseCase
+	GetCategoriesByIDsUseCase  usecase.GetCategoriesByIDsUseCase
+	GetCategoryChildrenUseCase usecase.GetCategoryChildrenUseCase
+}
+
+// Resolver resolves the category fields of the root Query type.
+// Embed it in the gateway root resolver.
+type Resolver struct {
+	Cfg *config.Config
+	Log logger.Logger
+	Val validator.Validator
+	Uc  HandlerUseCases
+
+	batchWait time.Duration
+	maxBatch  int
+}
+
+func NewResolver(cfg *config.Config, log logger.Logger, validator validator.Validator, useCases HandlerUseCases) *Resolver {
+	batchWait := 2 * time.Millisecond
+	if cfg.Graphql.BatchWait != 0 {
+		batchWait = time.Duration(cfg.Graphql.BatchWait) * time.Millisecond
+	}
+
+	return &Resolver{
+		Cfg:       cfg,
+		Log:       log,
+		Val:       validator,
+		Uc:        useCases,
+		batchWait: batchWait,
+		maxBatch:  cfg.Graphql.MaxBatch,
+	}
+}
+
+type getCategoryRequest struct {
+	ID string `json:"id" validate:"required,uuid" label:"ID"`
+}
+
+func (r *Resolver) Category(ctx context.Context, args struct{ ID gql.ID }) (*CategoryResolver, error) {
+	log := r.Log.WithContext(ctx).WithField("method", "Category")
+
+	request := &getCategoryRequest{ID: string(args.ID)}
+	if err := r.Val.Validate(request); err != nil {
+		return nil, apperror.NewPersistance(apperror.CodeInvalidRequest, "Invalid request", err).
+			AddValidationErrors(r.Val.ToDetails(ctx, err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"category_id": request.ID,
+		},
+	}).Info("request received")
+
+	return r.load(ctx, request.ID)
+}
+
+type categoriesArgs struct {
+	Limit  int32
+	Offset int32
+}
+
+func (r *Resolver) Categories(ctx context.Context, args categoriesArgs) ([]*CategoryResolver, error) {
+	log := r.Log.WithContext(ctx).WithField("method", "Categories")
+
+	request := &usecase.ListCategoriesRequest{
+		Limit:  int(args.Limit),
+		Offset: int(args.Offset),
+	}
+
+	if err := r.Val.Validate(request); err != nil {
+		return nil, apperror.NewPersistance(apperror.CodeInvalidRequest, "Invalid request", err).
+			AddValidationErrors(r.Val.ToDetails(ctx, err))
+	}
+
+	log.Info("request received")
+
+	categories, err := r.Uc.ListCategoriesUseCase.Execute(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return r.resolvers(categories), nil
+}
+
+// load resolves the category id through the loader, nil when it does not exist.
+func (r *Resolver) load(ctx context.Context, id string) (*CategoryResolver, error) {
+	category, found, err := r.loadersFrom(ctx).Category.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return &CategoryResolver{r: r, c: category}, nil
+}
+
+func (r *Resolver) resolvers(categories []usecase.CategoryResponse) []*CategoryResolver {
+	res := make([]*CategoryResolver, 0, len(categories))
+	for _, c := range categories {
+		res = append(res, &CategoryResolver{r: r, c: c})
+	}
+	return res
+}
+
+// CategoryResolver resolves the Category type.
+type CategoryResolver struct {
+	r *Resolver
+	c usecase.CategoryResponse
+}
+
+func (c *CategoryResolver) ID() gql.ID        { return gql.ID(c.c.CategoryID) }
+func (c *CategoryResolver) Name() string      { return c.c.Name }
+func (c *CategoryResolver) Slug() string      { return c.c.Slug }
+func (c *CategoryResolver) Position() int32   { return c.c.Position }
+func (c *CategoryResolver) CreatedAt() string { return formatMillis(c.c.CreatedAt) }
+
+func (c *CategoryResolver) UpdatedAt() *string {
+	if c.c.UpdatedAt == nil {
+		return nil
+	}
+	s := formatMillis(*c.c.UpdatedAt)
+	return &s
+}
+
+func (c *CategoryResolver) Parent(ctx context.Context) (*CategoryResolver, error) {
+	if c.c.ParentID == nil {
+		return nil, nil
+	}
+	return c.r.load(ctx, *c.c.ParentID)
+}
+
+func (c *CategoryResolver) Children(ctx context.Context) ([]*CategoryResolver, error) {
+	children, _, err := c.r.loadersFrom(ctx).Children.Load(ctx, c.c.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	return c.r.resolvers(children), nil
+}
+
+func formatMillis(ms int64) string {
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,147 @@
+/*
+|------------------------------------------------------------------------------------
+| GRPC HANDLER
+|------------------------------------------------------------------------------------
+|
+| The gRPC handler follows the standards of the booking module
+| (see booking/delivery/grpc/handler.go): DTO validation at the entry point,
+| a single Anchor Log and error bubbling.
+|
+| The calls are answered from the batch use cases of the GraphQL dataloaders,
+| with a single key: a gRPC response carries no nested field to batch.
+|
+|------------------------------------------------------------------------------------
+*/
+package grpc
+
+import (
+	"context"
+	categoryv1 "voyago/core-api/api/proto/category/v1"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/validator"
+	"voyago/core-api/internal/modules/category/usecase"
+	"voyago/core-api/internal/pkg/apperror"
+)
+
+type HandlerUseCases struct {
+	ListCategoriesUseCase      usecase.ListCategoriesUseCase
+	GetCategoriesByIDsUseCase  usecase.GetCategoriesByIDsUseCase
+	GetCategoryChildrenUseCase usecase.GetCategoryChildrenUseCase
+}
+
+type Handler struct {
+	categoryv1.UnimplementedCategoryServiceServer
+
+	Cfg *config.Config
+	Log logger.Logger
+	Val validator.Validator
+	Uc  HandlerUseCases
+}
+
+// Compile-time check to ensure Handler implements the generated service contract.
+var _ categoryv1.CategoryServiceServer = (*Handler)(nil)
+
+func NewHandler(cfg *config.Config, log logger.Logger, validator validator.Validator, useCases HandlerUseCases) *Handler {
+	return &Handler{
+		Cfg: cfg,
+		Log: log,
+		Val: validator,
+		Uc:  useCases,
+	}
+}
+
+type getCategoryRequest struct {
+	ID string `json:"id" validate:"required,uuid" label:"ID"`
+}
+
+type listCategoryChildrenRequest struct {
+	ParentID string `json:"parent_id" validate:"required,uuid" label:"Parent ID"`
+}
+
+func (h *Handler) ListCategories(ctx context.Context, in *categoryv1.ListCategoriesRequest) (*categoryv1.ListCategoriesResponse, error) {
+	log := h.Log.WithContext(ctx).WithField("method", "ListCategories")
+
+	request := &usecase.ListCategoriesRequest{
+		Limit:  int(in.GetLimit()),
+		Offset: int(in.GetOffset()),
+	}
+
+	if err := h.Val.Validate(request); err != nil {
+		return nil, apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(ctx, err))
+	}
+
+	log.Info("request received")
+
+	categories, err := h.Uc.ListCategoriesUseCase.Execute(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return &categoryv1.ListCategoriesResponse{Categories: toCategories(categories)}, nil
+}
+
+func (h *Handler) GetCategory(ctx context.Context, in *categoryv1.GetCategoryRequest) (*categoryv1.GetCategoryResponse, error) {
+	log := h.Log.WithContext(ctx).WithField("method", "GetCategory")
+
+	request := &getCategoryRequest{ID: in.GetId()}
+	if err := h.Val.Validate(request); err != nil {
+		return nil, apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(ctx, err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"category_id": request.ID,
+		},
+	}).Info("request received")
+
+	categories, err := h.Uc.GetCategoriesByIDsUseCase.Execute(ctx, []string{request.ID})
+	if err != nil {
+		return nil, err
+	}
+	category, found := categories[request.ID]
+	if !found {
+		return nil, apperror.ErrCodeNotFound.WithDetail("id", request.ID)
+	}
+	return &categoryv1.GetCategoryResponse{Category: toCategory(category)}, nil
+}
+
+func (h *Handler) ListCategoryChildren(ctx context.Context, in *categoryv1.ListCategoryChildrenRequest) (*categoryv1.ListCategoryChildrenResponse, error) {
+	log := h.Log.WithContext(ctx).WithField("method", "ListCategoryChildren")
+
+	request := &listCategoryChildrenRequest{ParentID: in.GetParentId()}
+	if err := h.Val.Validate(request); err != nil {
+		return nil, apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(ctx, err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"category_id": request.ParentID,
+		},
+	}).Info("request received")
+
+	children, err := h.Uc.GetCategoryChildrenUseCase.Execute(ctx, []string{request.ParentID})
+	if err != nil {
+		return nil, err
+	}
+	return &categoryv1.ListCategoryChildrenResponse{Categories: toCategories(children[request.ParentID])}, nil
+}
+
+func toCategories(categories []usecase.CategoryResponse) []*categoryv1.Category {
+	res := make([]*categoryv1.Category, 0, len(categories))
+	for _, c := range categories {
+		res = append(res, toCategory(c))
+	}
+	return res
+}
+
+func toCategory(c usecase.CategoryResponse) *categoryv1.Category {
+	return &categoryv1.Category{
+		Id:        c.CategoryID,
+		ParentId:  c.ParentID,
+		Name:      c.Name,
+		Slug:      c.Slug,
+		Position:  c.Position,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
@@ -0,0 +1,18 @@
+package grpc
+
+import (
+	categoryv1 "voyago/core-api/api/proto/category/v1"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"google.golang.org/grpc"
+)
+
+type ServiceConfig struct {
+	Config  *config.Config
+	Server  *grpc.Server
+	Handler *Handler
+}
+
+func (s *ServiceConfig) Setup() {
+	categoryv1.RegisterCategoryServiceServer(s.Server, s.Handler)
+}
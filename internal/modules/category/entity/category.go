@@ -0,0 +1,18 @@
+package entity
+
+// Category is a node of the catalog tree: the root categories have no
+// parent, the children of a category are ordered by Position.
+type Category struct {
+	ID       string  `gorm:"column:id;type:uuid;primaryKey"`
+	ParentID *string `gorm:"column:parent_id;type:uuid;index"`
+	Name     string  `gorm:"column:name;type:varchar(100);not null"`
+	Slug     string  `gorm:"column:slug;type:varchar(100);not null;unique"`
+	Position int32   `gorm:"column:position;type:integer;not null;default:0"`
+
+	CreatedAt int64  `gorm:"column:created_at;type:bigint;not null;autoCreateTime:milli"`
+	UpdatedAt *int64 `gorm:"column:updated_at;type:bigint;autoUpdateTime:false"`
+}
+
+func (Category) TableName() string {
+	return "categories"
+}
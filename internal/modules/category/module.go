@@ -0,0 +1,165 @@
+package category
+
+import (
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/container"
+	database "voyago/core-api/internal/infrastructure/db"
+	gqlserver "voyago/core-api/internal/infrastructure/graphql"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/infrastructure/validator"
+	"voyago/core-api/internal/modules"
+	graphqldelivery "voyago/core-api/internal/modules/category/delivery/graphql"
+	grpcdelivery "voyago/core-api/internal/modules/category/delivery/grpc"
+	"voyago/core-api/internal/modules/category/entity"
+	"voyago/core-api/internal/modules/category/repository"
+	"voyago/core-api/internal/modules/category/repository/query"
+	"voyago/core-api/internal/modules/category/usecase"
+
+	"go.uber.org/fx"
+	"google.golang.org/grpc"
+)
+
+// The category module is read only: the catalog tree is served by the
+// GraphQL gateway (see RegisterGraphqlModule) and over gRPC.
+func init() {
+	modules.Register(modules.Module{
+		Name:   "category",
+		Models: []any{&entity.Category{}},
+		GRPC: func(env modules.GRPC) {
+			RegisterGrpcModule(GrpcModuleConfig{
+				Config:  env.Config,
+				Server:  env.Server,
+				DB:      env.DB,
+				Log:     env.Log,
+				Val:     env.Val,
+				Tracer:  env.Tracer,
+				Metrics: env.Metrics,
+			})
+		},
+		Seeders: RegisterSeeders,
+	})
+}
+
+type GraphqlModuleConfig struct {
+	Config *config.Config
+	DB     database.Database
+	Log    logger.Logger
+	Val    validator.Validator
+	Tracer tracer.Tracer
+	// Metrics records the business metrics of the use cases.
+	Metrics metrics.Metrics
+}
+
+type GrpcModuleConfig struct {
+	Config *config.Config
+	Server *grpc.Server
+	DB     database.Database
+	Log    logger.Logger
+	Val    validator.Validator
+	Tracer tracer.Tracer
+	// Metrics records the business metrics of the use cases.
+	Metrics metrics.Metrics
+}
+
+// useCases groups the use cases of the module.
+type useCases struct {
+	listCategories      usecase.ListCategoriesUseCase
+	getCategoriesByIDs  usecase.GetCategoriesByIDsUseCase
+	getCategoryChildren usecase.GetCategoryChildrenUseCase
+}
+
+// RegisterGraphqlModule builds the category resolver of the GraphQL gateway.
+// The resolver must be embedded in the gateway root resolver and the returned
+// module passed to gqlserver.NewSchema.
+func RegisterGraphqlModule(cfg GraphqlModuleConfig) (*graphqldelivery.Resolver, gqlserver.Module) {
+	hdlrLogger := cfg.Log.WithField("component", "handler")
+
+	uc := setupUseCases(cfg.Config, cfg.DB, cfg.Log, cfg.Tracer, cfg.Metrics)
+
+	// setup resolver
+	r := graphqldelivery.NewResolver(
+		cfg.Config,
+		hdlrLogger,
+		cfg.Val,
+		graphqldelivery.HandlerUseCases{
+			ListCategoriesUseCase:      uc.listCategories,
+			GetCategoriesByIDsUseCase:  uc.getCategoriesByIDs,
+			GetCategoryChildrenUseCase: uc.getCategoryChildren,
+		},
+	)
+
+	return r, gqlserver.Module{
+		Schema:  graphqldelivery.Schema,
+		Context: r.WithLoaders,
+	}
+}
+
+// RegisterGrpcModule registers the CategoryService on the gRPC server.
+func RegisterGrpcModule(cfg GrpcModuleConfig) {
+	hdlrLogger := cfg.Log.WithField("component", "handler")
+
+	uc := setupUseCases(cfg.Config, cfg.DB, cfg.Log, cfg.Tracer, cfg.Metrics)
+
+	// setup handler
+	h := grpcdelivery.NewHandler(
+		cfg.Config,
+		hdlrLogger,
+		cfg.Val,
+		grpcdelivery.HandlerUseCases{
+			ListCategoriesUseCase:      uc.listCategories,
+			GetCategoriesByIDsUseCase:  uc.getCategoriesByIDs,
+			GetCategoryChildrenUseCase: uc.getCategoryChildren,
+		},
+	)
+
+	serviceConfig := grpcdelivery.ServiceConfig{
+		Server:  cfg.Server,
+		Config:  cfg.Config,
+		Handler: h,
+	}
+	serviceConfig.Setup()
+}
+
+// setupUseCases builds the use cases of the module.
+func setupUseCases(cfg *config.Config, db database.Database, log logger.Logger, trc tracer.Tracer, m metrics.Metrics) useCases {
+	var uc useCases
+	container.Build(nil, "category",
+		modules.Env{Config: cfg, DB: db, Log: log, Tracer: trc, Metrics: m}.Options(),
+		fx.Provide(
+			query.NewCategoryRepository,
+			newRepositories,
+		),
+		// The use cases log as the usecase component, their dependencies
+		// as the module.
+		fx.Module("usecase",
+			fx.Decorate(func(log logger.Logger) logger.Logger {
+				return log.WithField("component", "usecase")
+			}),
+			fx.Provide(
+				usecase.NewListCategoriesUseCase,
+				usecase.NewGetCategoriesByIDsUseCase,
+				usecase.NewGetCategoryChildrenUseCase,
+			),
+		),
+		fx.Populate(
+			&uc.listCategories,
+			&uc.getCategoriesByIDs,
+			&uc.getCategoryChildren,
+		),
+	)
+	return uc
+}
+
+// newRepositories returns the repositories of each use case, sharing the
+// query repository of the categories.
+func newRepositories(qry repository.CategoryQueryRepository) (
+	usecase.ListCategoriesRepositories,
+	usecase.GetCategoriesByIDsRepositories,
+	usecase.GetCategoryChildrenRepositories,
+) {
+	return usecase.ListCategoriesRepositories{CategoryQry: qry},
+		usecase.GetCategoriesByIDsRepositories{CategoryQry: qry},
+		usecase.GetCategoryChildrenRepositories{CategoryQry: qry}
+}
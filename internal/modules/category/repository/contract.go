@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"voyago/core-api/internal/modules/category/entity"
+	"voyago/core-api/internal/pkg/spec"
+)
+
+// -------- Repository Query --------
+
+type CategoryQueryRepository interface {
+	// FindByIDs returns the categories matching ids, in no particular order.
+	// Missing ids are simply absent from the result.
+	FindByIDs(ctx context.Context, ids []string) ([]entity.Category, error)
+
+	// FindByParentIDs returns the children of every given category in a
+	// single query, ordered by position.
+	FindByParentIDs(ctx context.Context, parentIDs []string) ([]entity.Category, error)
+
+	// ListRoots returns the root categories matching s. s may filter and
+	// order by id, name, slug, position, created_at and updated_at.
+	ListRoots(ctx context.Context, s spec.Spec) ([]entity.Category, error)
+}
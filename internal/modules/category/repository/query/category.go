@@ -0,0 +1,123 @@
+/*
+|------------------------------------------------------------------------------------
+| REPOSITORY ARCHITECTURAL STANDARDS & QUERY OPTIMIZATION MANIFESTO
+|------------------------------------------------------------------------------------
+|
+| The Query Repository is dedicated to data retrieval. It follows the R-side of
+| CQRS, focusing on performance, filtering, and non-mutating operations.
+|
+| [1. SELECTIVE RETRIEVAL (NO SELECT *)]
+| - Always specify required fields in .Select(). Avoid 'SELECT *' to minimize
+|   database I/O and prevent sensitive data leakage.
+|
+| [2. NULLABLE VS ERROR]
+| - If a record is NOT FOUND, return (nil, nil) instead of an error for Query
+|   methods (unless the business logic dictates that the absence is an anomaly).
+| - Database connection issues or syntax errors MUST still be mapped and returned.
+|
+| [3. READ-ONLY CONTEXT]
+| - Ensure .WithContext(ctx) is called to respect timeouts, cancellations,
+|   and tracing propagation.
+| - Reads are served by the read replicas (database.Reader): a command use
+|   case reading what it is about to change sets ctxkey.SetReadPrimary.
+|
+| [4. PRELOAD DISCIPLINE]
+| - Only Preload relationships that are strictly necessary for the requested
+|   operation to avoid N+1 query problems or heavy payload bloat.
+|
+|------------------------------------------------------------------------------------
+*/
+package query
+
+import (
+	"context"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/modules/category/entity"
+	"voyago/core-api/internal/modules/category/repository"
+	"voyago/core-api/internal/pkg/spec"
+)
+
+// categoryFields are the fields of the category lists (see ListRoots).
+var categoryFields = database.SpecFields{
+	"id":         "id",
+	"name":       "name",
+	"slug":       "slug",
+	"position":   "position",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// categoryColumns are the columns read for every category.
+var categoryColumns = []string{"id", "parent_id", "name", "slug", "position", "created_at", "updated_at"}
+
+// categoryRepository implements the repository.CategoryQueryRepository interface.
+type categoryRepository struct {
+	DB database.Database
+}
+
+// [INTERFACE COMPLIANCE CHECK]
+var _ repository.CategoryQueryRepository = (*categoryRepository)(nil)
+
+// NewCategoryRepository creates a new instance for reading Category data.
+// The reads go to the replicas of db, if any (see database.Reader).
+func NewCategoryRepository(db database.Database) repository.CategoryQueryRepository {
+	return &categoryRepository{
+		DB: database.Reader(db),
+	}
+}
+
+func (r *categoryRepository) FindByIDs(ctx context.Context, ids []string) ([]entity.Category, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var categories []entity.Category
+	err := r.DB.WithContext(ctx).
+		Model(&entity.Category{}).
+		Select(categoryColumns).
+		Where("id IN ?", ids).
+		Find(&categories).
+		Error
+
+	if err != nil {
+		return nil, database.MapDBError(err)
+	}
+
+	return categories, nil
+}
+
+func (r *categoryRepository) FindByParentIDs(ctx context.Context, parentIDs []string) ([]entity.Category, error) {
+	if len(parentIDs) == 0 {
+		return nil, nil
+	}
+	var categories []entity.Category
+	err := r.DB.WithContext(ctx).
+		Model(&entity.Category{}).
+		Select(categoryColumns).
+		Where("parent_id IN ?", parentIDs).
+		Order("position").
+		Order("id").
+		Find(&categories).
+		Error
+
+	if err != nil {
+		return nil, database.MapDBError(err)
+	}
+
+	return categories, nil
+}
+
+func (r *categoryRepository) ListRoots(ctx context.Context, s spec.Spec) ([]entity.Category, error) {
+	var categories []entity.Category
+	err := database.ApplySpec(r.DB.WithContext(ctx).
+		Model(&entity.Category{}).
+		Select(categoryColumns).
+		Where("parent_id IS NULL"), s, categoryFields).
+		Find(&categories).
+		Error
+
+	if err != nil {
+		return nil, database.MapDBError(err)
+	}
+
+	return categories, nil
+}
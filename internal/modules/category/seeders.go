@@ -0,0 +1,40 @@
+package category
+
+import (
+	"context"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/seed"
+	"voyago/core-api/internal/modules/category/entity"
+
+	"gorm.io/gorm/clause"
+)
+
+// RegisterSeeders registers the seeders of the module.
+func RegisterSeeders(r *seed.Registry) {
+	r.Register("category", seed.Seeder{
+		Name: "sample_categories",
+		Kind: seed.Sample,
+		Run:  seedSampleCategories,
+	})
+}
+
+// seedSampleCategories inserts a two-level catalog tree, with fixed IDs so
+// that local clients and docs can reference them.
+func seedSampleCategories(ctx context.Context, db database.Database) error {
+	const (
+		stays      = "0195a5c0-0000-7000-8000-000000000201"
+		activities = "0195a5c0-0000-7000-8000-000000000202"
+	)
+	parent := func(id string) *string { return &id }
+
+	categories := []*entity.Category{
+		{ID: stays, Name: "Stays", Slug: "stays", Position: 1},
+		{ID: activities, Name: "Activities", Slug: "activities", Position: 2},
+		{ID: "0195a5c0-0000-7000-8000-000000000211", ParentID: parent(stays), Name: "Hotels", Slug: "hotels", Position: 1},
+		{ID: "0195a5c0-0000-7000-8000-000000000212", ParentID: parent(stays), Name: "Villas", Slug: "villas", Position: 2},
+		{ID: "0195a5c0-0000-7000-8000-000000000221", ParentID: parent(activities), Name: "Tours", Slug: "tours", Position: 1},
+	}
+
+	// Running it again keeps the existing rows, changed or not.
+	return db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&categories).Error
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import "context"
+
+// -------- DTOs --------
+
+type ListCategoriesRequest struct {
+	Limit  int `json:"limit" validate:"omitempty,gte=1,lte=100" label:"Limit"`
+	Offset int `json:"offset" validate:"omitempty,gte=0" label:"Offset"`
+}
+
+// CategoryResponse is the read model of a category. Its parent and children
+// are resolved separately (see GetCategoriesByIDsUseCase and
+// GetCategoryChildrenUseCase).
+type CategoryResponse struct {
+	CategoryID string  `json:"id"`
+	ParentID   *string `json:"parent_id"`
+	Name       string  `json:"name"`
+	Slug       string  `json:"slug"`
+	Position   int32   `json:"position"`
+	CreatedAt  int64   `json:"created_at"`
+	UpdatedAt  *int64  `json:"updated_at"`
+}
+
+// -------- Usecase Interfaces --------
+
+// ListCategoriesUseCase returns a page of the root categories, by position.
+type ListCategoriesUseCase interface {
+	Execute(ctx context.Context, req *ListCategoriesRequest) ([]CategoryResponse, error)
+}
+
+// GetCategoriesByIDsUseCase resolves many categories at once.
+// It is designed as a dataloader batch function: the result is keyed by ID
+// and unknown IDs are absent from the map.
+type GetCategoriesByIDsUseCase interface {
+	Execute(ctx context.Context, ids []string) (map[string]CategoryResponse, error)
+}
+
+// GetCategoryChildrenUseCase resolves the children of many categories in a
+// single query. It is designed as a dataloader batch function: the result is
+// keyed by parent ID.
+type GetCategoryChildrenUseCase interface {
+	Execute(ctx context.Context, parentIDs []string) (map[string][]CategoryResponse, error)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/category/repository"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+type GetCategoriesByIDsRepositories struct {
+	CategoryQry repository.CategoryQueryRepository
+}
+
+// getCategoriesByIDsUseCase is the private implementation of GetCategoriesByIDsUseCase.
+type getCategoriesByIDsUseCase struct {
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	Repo   GetCategoriesByIDsRepositories
+}
+
+const getByIDsUseCaseName = "usecase:category.get_by_ids"
+
+var _ GetCategoriesByIDsUseCase = (*getCategoriesByIDsUseCase)(nil)
+
+func NewGetCategoriesByIDsUseCase(log logger.Logger, trc tracer.Tracer, repo GetCategoriesByIDsRepositories) GetCategoriesByIDsUseCase {
+	return &getCategoriesByIDsUseCase{
+		Log:    log.WithField("action", getByIDsUseCaseName),
+		Tracer: trc,
+		Repo:   repo,
+	}
+}
+
+func (uc *getCategoriesByIDsUseCase) Execute(ctx context.Context, ids []string) (map[string]CategoryResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, getByIDsUseCaseName)
+	defer span.Finish()
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"count_ids": len(ids),
+		},
+	}).Info("usecase started")
+
+	categories, err := uc.Repo.CategoryQry.FindByIDs(ctx, ids)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	res := make(map[string]CategoryResponse, len(categories))
+	for i := range categories {
+		res[categories[i].ID] = toCategoryResponse(&categories[i])
+	}
+
+	log.Info("usecase completed")
+	return res, nil
+}
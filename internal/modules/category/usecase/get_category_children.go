@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/category/repository"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+type GetCategoryChildrenRepositories struct {
+	CategoryQry repository.CategoryQueryRepository
+}
+
+// getCategoryChildrenUseCase is the private implementation of GetCategoryChildrenUseCase.
+type getCategoryChildrenUseCase struct {
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	Repo   GetCategoryChildrenRepositories
+}
+
+const getChildrenUseCaseName = "usecase:category.get_children_by_parent_ids"
+
+var _ GetCategoryChildrenUseCase = (*getCategoryChildrenUseCase)(nil)
+
+func NewGetCategoryChildrenUseCase(log logger.Logger, trc tracer.Tracer, repo GetCategoryChildrenRepositories) GetCategoryChildrenUseCase {
+	return &getCategoryChildrenUseCase{
+		Log:    log.WithField("action", getChildrenUseCaseName),
+		Tracer: trc,
+		Repo:   repo,
+	}
+}
+
+func (uc *getCategoryChildrenUseCase) Execute(ctx context.Context, parentIDs []string) (map[string][]CategoryResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, getChildrenUseCaseName)
+	defer span.Finish()
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"count_parent_ids": len(parentIDs),
+		},
+	}).Info("usecase started")
+
+	children, err := uc.Repo.CategoryQry.FindByParentIDs(ctx, parentIDs)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	res := make(map[string][]CategoryResponse, len(parentIDs))
+	for i := range children {
+		parentID := *children[i].ParentID
+		res[parentID] = append(res[parentID], toCategoryResponse(&children[i]))
+	}
+
+	log.Info("usecase completed")
+	return res, nil
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/category/entity"
+	"voyago/core-api/internal/modules/category/repository"
+	"voyago/core-api/internal/pkg/spec"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+type ListCategoriesRepositories struct {
+	CategoryQry repository.CategoryQueryRepository
+}
+
+// listCategoriesUseCase is the private implementation of ListCategoriesUseCase.
+type listCategoriesUseCase struct {
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	Repo   ListCategoriesRepositories
+}
+
+const (
+	listUseCaseName      = "usecase:category.list"
+	defaultCategoryLimit = 50
+)
+
+var _ ListCategoriesUseCase = (*listCategoriesUseCase)(nil)
+
+func NewListCategoriesUseCase(log logger.Logger, trc tracer.Tracer, repo ListCategoriesRepositories) ListCategoriesUseCase {
+	return &listCategoriesUseCase{
+		Log:    log.WithField("action", listUseCaseName),
+		Tracer: trc,
+		Repo:   repo,
+	}
+}
+
+func (uc *listCategoriesUseCase) Execute(ctx context.Context, req *ListCategoriesRequest) ([]CategoryResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, listUseCaseName)
+	defer span.Finish()
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.Info("usecase started")
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultCategoryLimit
+	}
+
+	categories, err := uc.Repo.CategoryQry.ListRoots(ctx, spec.New(
+		spec.OrderBy("position", spec.Asc),
+		spec.OrderBy("id", spec.Asc),
+		spec.Limit(limit),
+		spec.Offset(req.Offset),
+	))
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	res := make([]CategoryResponse, 0, len(categories))
+	for i := range categories {
+		res = append(res, toCategoryResponse(&categories[i]))
+	}
+
+	log.Info("usecase completed")
+	return res, nil
+}
+
+func toCategoryResponse(e *entity.Category) CategoryResponse {
+	return CategoryResponse{
+		CategoryID: e.ID,
+		ParentID:   e.ParentID,
+		Name:       e.Name,
+		Slug:       e.Slug,
+		Position:   e.Position,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.UpdatedAt,
+	}
+}
@@ -0,0 +1,254 @@
+// Package modules is the registry of the domain modules. Each module
+// registers itself from an init function of its package, imported by
+// internal/app (see internal/app/modules.go); the bootstraps create the
+// infrastructure of every registered module, then call the hooks of the
+// transports they serve.
+//
+// Example:
+//
+//	func init() {
+//		modules.Register(modules.Module{
+//			Name:   "loyalty",
+//			Models: []any{&entity.Reward{}},
+//			HTTP: func(env modules.HTTP) {
+//				RegisterHttpModule(HttpModuleConfig{Config: env.Config, Routes: env.Routes, ...})
+//			},
+//		})
+//	}
+package modules
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/container"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/featureflag"
+	"voyago/core-api/internal/infrastructure/http/versioning"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/messaging"
+	"voyago/core-api/internal/infrastructure/scheduler"
+	"voyago/core-api/internal/infrastructure/seed"
+	"voyago/core-api/internal/infrastructure/taskqueue"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/infrastructure/validator"
+	baserepo "voyago/core-api/internal/pkg/repository"
+
+	"go.uber.org/fx"
+	"google.golang.org/grpc"
+)
+
+// Module describes a domain module: its infrastructure and the hooks
+// registering it on each transport. A nil hook leaves the module out of the
+// transport.
+type Module struct {
+	// Name is the domain of the module, naming its configuration, database,
+	// migrations and logs.
+	Name string
+	// Config is the configuration file of the domain, config/<name>/config.yaml
+	// when empty (see ConfigPath).
+	Config string
+	// Models are the tables of the module, created with AutoMigrate by the
+	// in-memory test mode; the SQL migrations create them otherwise.
+	Models []any
+	// Settings decodes the typed settings of the module from the section of
+	// its domain configuration named after it, validated at startup (see
+	// SettingsOf and DecodeSettings). They are then in Env.Settings.
+	Settings func(cfg *config.Config, section string) (any, error)
+
+	// HTTP mounts the module on the HTTP server.
+	HTTP func(HTTP)
+	// GRPC registers the services of the module on the gRPC server. The
+	// gRPC server falls back to Worker for the modules without gRPC API, so
+	// that the events published by its calls still reach them.
+	GRPC func(GRPC)
+	// Worker subscribes the module to the events of a runtime serving no API
+	// of the module.
+	Worker func(Worker)
+	// Messaging registers the handlers of the broker topics consumed by the
+	// module.
+	Messaging func(Messaging)
+	// Jobs registers the scheduled jobs of the module.
+	Jobs func(Jobs)
+	// Tasks registers the handlers of the deferred tasks of the module.
+	Tasks func(Tasks)
+	// Seeders registers the seeders of the module, run by `voyago seed`.
+	Seeders func(*seed.Registry)
+}
+
+// ConfigPath returns the configuration file of the domain.
+func (m Module) ConfigPath() string {
+	if m.Config != "" {
+		return m.Config
+	}
+	return fmt.Sprintf("config/%s/config.yaml", m.Name)
+}
+
+// DecodeSettings returns the settings of the module read from cfg, its domain
+// configuration, nil when the module has none.
+func (m Module) DecodeSettings(cfg *config.Config) (any, error) {
+	if m.Settings == nil {
+		return nil, nil
+	}
+	return m.Settings(cfg, m.Name)
+}
+
+// SettingsOf returns the Module.Settings decoding the section of the module
+// into a *T, a struct with mapstructure tags, validated when it implements
+// config.SectionValidator (see config.Config.Section).
+//
+// Example:
+//
+//	modules.Register(modules.Module{Name: "loyalty", Settings: modules.SettingsOf[loyalty.Settings]()})
+func SettingsOf[T any]() func(*config.Config, string) (any, error) {
+	return func(cfg *config.Config, section string) (any, error) {
+		settings := new(T)
+		if err := cfg.Section(section, settings); err != nil {
+			return nil, err
+		}
+		return settings, nil
+	}
+}
+
+// Env is the infrastructure a module is registered with, whatever the
+// transport.
+type Env struct {
+	// Config is the configuration of the domain.
+	Config *config.Config
+	// Settings are the typed settings of the module (see Module.Settings),
+	// nil when it has none.
+	Settings any
+	DB       database.Database
+	Log      logger.Logger
+	Tracer   tracer.Tracer
+	Metrics  metrics.Metrics
+	Bus      eventbus.Bus
+	// Tasks enqueues the deferred tasks of the use cases, nil when the task
+	// queue is disabled.
+	Tasks taskqueue.Enqueuer
+	// Flags gates the new behaviors of the use cases (see package
+	// featureflag); nil disables every flag.
+	Flags *featureflag.Flags
+	// Lifecycle receives the shutdown hooks of the module.
+	Lifecycle *lifecycle.Manager
+}
+
+// Options provides the infrastructure of env to the dependency graph of the
+// module (see package container), with the database as the transaction
+// manager of the use cases and the bus as their event publisher.
+func (e Env) Options() fx.Option {
+	settings := fx.Options()
+	if e.Settings != nil {
+		// Supplied as their concrete type, e.g. *booking.Settings.
+		settings = fx.Supply(e.Settings)
+	}
+	return fx.Options(
+		container.Supply(e.Config),
+		settings,
+		container.Supply(e.DB),
+		container.Supply(e.Log),
+		container.Supply(e.Tracer),
+		container.Supply(e.Metrics),
+		container.Supply(e.Bus),
+		container.Supply(e.Tasks),
+		container.Supply(e.Flags),
+		fx.Provide(func(db database.Database) baserepo.TransactionManager { return db }),
+		fx.Provide(func(bus eventbus.Bus) eventbus.Publisher { return bus }),
+	)
+}
+
+type HTTP struct {
+	Env
+	// Routes mounts the module routes under the versioned API prefix.
+	Routes *versioning.Router
+	Val    validator.Validator
+	// SSE is the global configuration of the Server-Sent Events streams.
+	SSE config.SSEConfig
+}
+
+type GRPC struct {
+	Env
+	Server *grpc.Server
+	Val    validator.Validator
+}
+
+type Worker struct {
+	Env
+	// Dedicated is set in the worker runtime (cmd/worker), which runs the
+	// background work left by the API processes started with
+	// worker.standalone.
+	Dedicated bool
+}
+
+type Messaging struct {
+	Env
+	// Router receives the handlers of the consumed topics.
+	Router *messaging.Router
+}
+
+type Jobs struct {
+	Env
+	// Scheduler receives the scheduled jobs.
+	Scheduler *scheduler.Scheduler
+}
+
+type Tasks struct {
+	Env
+	// Mux receives the handlers of the task types.
+	Mux *taskqueue.Mux
+}
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Module{}
+)
+
+// Register adds m to the registry. It panics when the name is empty, not a
+// lowercase identifier or already registered: registering happens at init.
+func Register(m Module) {
+	if m.Name == "" || strings.ToLower(m.Name) != m.Name || strings.ContainsAny(m.Name, "/. ") {
+		panic(fmt.Errorf("modules: invalid module name %q", m.Name))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := registry[m.Name]; ok {
+		panic(fmt.Errorf("modules: module %q registered twice", m.Name))
+	}
+	registry[m.Name] = m
+}
+
+// All returns the registered modules, sorted by name: the start order of the
+// bootstraps.
+func All() []Module {
+	mu.RLock()
+	defer mu.RUnlock()
+	out := make([]Module, 0, len(registry))
+	for _, m := range registry {
+		out = append(out, m)
+	}
+	slices.SortFunc(out, func(a, b Module) int { return strings.Compare(a.Name, b.Name) })
+	return out
+}
+
+// Get returns the registered module name.
+func Get(name string) (Module, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	m, ok := registry[name]
+	return m, ok
+}
+
+// Names returns the names of the registered modules, sorted.
+func Names() []string {
+	all := All()
+	names := make([]string, len(all))
+	for i, m := range all {
+		names[i] = m.Name
+	}
+	return names
+}
@@ -0,0 +1,27 @@
+// Package event connects the webhook module to the internal event bus.
+package event
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/modules/webhook/usecase"
+)
+
+type Subscriber struct {
+	Enqueue usecase.EnqueueWebhookDeliveriesUseCase
+}
+
+func NewSubscriber(enqueue usecase.EnqueueWebhookDeliveriesUseCase) *Subscriber {
+	return &Subscriber{Enqueue: enqueue}
+}
+
+// Register subscribes to every event on the bus. Filtering by event type is
+// done per endpoint inside the use case.
+func (s *Subscriber) Register(bus eventbus.Bus) {
+	bus.Subscribe(eventbus.Wildcard, s.Handle)
+}
+
+func (s *Subscriber) Handle(ctx context.Context, evt eventbus.Event) error {
+	_, err := s.Enqueue.Execute(ctx, evt)
+	return err
+}
@@ -0,0 +1,192 @@
+// Package http exposes the webhook subscription management API.
+// Handlers follow the architectural standards documented in the booking
+// module handler (single anchor log, zero post-entry logging, error bubbling).
+package http
+
+import (
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/validator"
+	"voyago/core-api/internal/modules/webhook/usecase"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/bind"
+	"voyago/core-api/internal/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type HandlerUseCases struct {
+	CreateEndpointUseCase usecase.CreateWebhookEndpointUseCase
+	UpdateEndpointUseCase usecase.UpdateWebhookEndpointUseCase
+	DeleteEndpointUseCase usecase.DeleteWebhookEndpointUseCase
+	GetEndpointUseCase    usecase.GetWebhookEndpointUseCase
+	ListEndpointsUseCase  usecase.ListWebhookEndpointsUseCase
+	ListDeliveriesUseCase usecase.ListWebhookDeliveriesUseCase
+}
+
+type Handler struct {
+	Cfg *config.Config
+	Log logger.Logger
+	Val validator.Validator
+	Uc  HandlerUseCases
+}
+
+// endpointIDParam validates the ":id" route parameter.
+type endpointIDParam struct {
+	ID string `params:"id" validate:"required,uuid" label:"Webhook endpoint ID"`
+}
+
+func NewHandler(cfg *config.Config, log logger.Logger, validator validator.Validator, useCases HandlerUseCases) *Handler {
+	return &Handler{
+		Cfg: cfg,
+		Log: log,
+		Val: validator,
+		Uc:  useCases,
+	}
+}
+
+func (h *Handler) CreateEndpoint(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := h.Log.WithContext(ctx).WithField("method", "CreateEndpoint")
+
+	request := new(usecase.CreateWebhookEndpointRequest)
+	if err := bind.Request(c, request); err != nil {
+		return err
+	}
+	if err := h.Val.Validate(request); err != nil {
+		return apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(c.UserContext(), err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{"url": request.URL},
+	}).Info("request received")
+
+	endpoint, err := h.Uc.CreateEndpointUseCase.Execute(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return response.NewHttp(c).Created(response.Http{
+		Message: "Webhook endpoint created successfully",
+		Data:    endpoint,
+	})
+}
+
+func (h *Handler) UpdateEndpoint(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := h.Log.WithContext(ctx).WithField("method", "UpdateEndpoint")
+
+	request := new(usecase.UpdateWebhookEndpointRequest)
+	if err := bind.Request(c, request); err != nil {
+		return err
+	}
+	if err := h.Val.Validate(request); err != nil {
+		return apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(c.UserContext(), err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{"webhook_endpoint_id": request.ID},
+	}).Info("request received")
+
+	endpoint, err := h.Uc.UpdateEndpointUseCase.Execute(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Webhook endpoint updated successfully",
+		Data:    endpoint,
+	})
+}
+
+func (h *Handler) DeleteEndpoint(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := h.Log.WithContext(ctx).WithField("method", "DeleteEndpoint")
+
+	param := endpointIDParam{}
+	if err := bind.Request(c, &param); err != nil {
+		return err
+	}
+	if err := h.Val.Validate(&param); err != nil {
+		return apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(c.UserContext(), err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{"webhook_endpoint_id": param.ID},
+	}).Info("request received")
+
+	if err := h.Uc.DeleteEndpointUseCase.Execute(ctx, param.ID); err != nil {
+		return err
+	}
+
+	return response.NewHttp(c).NoContent()
+}
+
+func (h *Handler) GetEndpoint(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := h.Log.WithContext(ctx).WithField("method", "GetEndpoint")
+
+	param := endpointIDParam{}
+	if err := bind.Request(c, &param); err != nil {
+		return err
+	}
+	if err := h.Val.Validate(&param); err != nil {
+		return apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(c.UserContext(), err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{"webhook_endpoint_id": param.ID},
+	}).Info("request received")
+
+	endpoint, err := h.Uc.GetEndpointUseCase.Execute(ctx, param.ID)
+	if err != nil {
+		return err
+	}
+
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Webhook endpoint retrieved successfully",
+		Data:    endpoint,
+	})
+}
+
+func (h *Handler) ListEndpoints(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	h.Log.WithContext(ctx).WithField("method", "ListEndpoints").Info("request received")
+
+	endpoints, err := h.Uc.ListEndpointsUseCase.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Webhook endpoints retrieved successfully",
+		Data:    endpoints,
+	})
+}
+
+func (h *Handler) ListDeliveries(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	log := h.Log.WithContext(ctx).WithField("method", "ListDeliveries")
+
+	request := new(usecase.ListWebhookDeliveriesRequest)
+	if err := bind.Request(c, request); err != nil {
+		return err
+	}
+	if err := h.Val.Validate(request); err != nil {
+		return apperror.ErrCodeInvalidRequest.WithError(err).AddValidationErrors(h.Val.ToDetails(c.UserContext(), err))
+	}
+
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{"webhook_endpoint_id": request.EndpointID},
+	}).Info("request received")
+
+	deliveries, err := h.Uc.ListDeliveriesUseCase.Execute(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	return response.NewHttp(c).OK(response.Http{
+		Message: "Webhook deliveries retrieved successfully",
+		Data:    deliveries,
+	})
+}
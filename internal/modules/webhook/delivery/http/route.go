@@ -0,0 +1,83 @@
+package http
+
+import (
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/http/versioning"
+	"voyago/core-api/internal/infrastructure/openapi"
+	"voyago/core-api/internal/modules/webhook/usecase"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RouteConfig struct {
+	Config  *config.Config
+	Routes  *versioning.Router
+	Handler *Handler
+}
+
+const (
+	routeGroup = "/webhooks"
+)
+
+func (r *RouteConfig) Setup() {
+	r.Routes.Register(r.v1, versioning.V1)
+}
+
+// v1 registers the routes of API version 1.
+func (r *RouteConfig) v1(v *versioning.Group) {
+	webhooks := v.Group(routeGroup)
+	webhooks.Post("/", r.Handler.CreateEndpoint)
+	webhooks.Get("/", r.Handler.ListEndpoints)
+	webhooks.Get("/:id", r.Handler.GetEndpoint)
+	webhooks.Put("/:id", r.Handler.UpdateEndpoint)
+	webhooks.Delete("/:id", r.Handler.DeleteEndpoint)
+	webhooks.Get("/:id/deliveries", r.Handler.ListDeliveries)
+
+	v.Document(
+		openapi.Operation{
+			Method:   fiber.MethodPost,
+			Path:     routeGroup + "/",
+			Summary:  "Register a webhook endpoint",
+			Request:  usecase.CreateWebhookEndpointRequest{},
+			Response: usecase.WebhookEndpointResponse{},
+			Status:   fiber.StatusCreated,
+			Errors:   []int{fiber.StatusBadRequest, fiber.StatusUnprocessableEntity},
+		},
+		openapi.Operation{
+			Method:   fiber.MethodGet,
+			Path:     routeGroup + "/",
+			Summary:  "List webhook endpoints",
+			Response: []usecase.WebhookEndpointResponse{},
+		},
+		openapi.Operation{
+			Method:   fiber.MethodGet,
+			Path:     routeGroup + "/:id",
+			Summary:  "Get a webhook endpoint",
+			Response: usecase.WebhookEndpointResponse{},
+			Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:   fiber.MethodPut,
+			Path:     routeGroup + "/:id",
+			Summary:  "Update a webhook endpoint",
+			Request:  usecase.UpdateWebhookEndpointRequest{},
+			Response: usecase.WebhookEndpointResponse{},
+			Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusUnprocessableEntity},
+		},
+		openapi.Operation{
+			Method:  fiber.MethodDelete,
+			Path:    routeGroup + "/:id",
+			Summary: "Delete a webhook endpoint",
+			Status:  fiber.StatusNoContent,
+			Errors:  []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+		},
+		openapi.Operation{
+			Method:   fiber.MethodGet,
+			Path:     routeGroup + "/:id/deliveries",
+			Summary:  "List the recent deliveries of a webhook endpoint",
+			Query:    usecase.ListWebhookDeliveriesRequest{},
+			Response: []usecase.WebhookDeliveryResponse{},
+			Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound},
+		},
+	)
+}
@@ -0,0 +1,64 @@
+// Package worker drives the webhook delivery loop in the background.
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/modules/webhook/usecase"
+)
+
+// Worker periodically delivers pending webhooks until it is stopped.
+type Worker struct {
+	Log      logger.Logger
+	Interval time.Duration
+	Deliver  usecase.DeliverPendingWebhooksUseCase
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewWorker(log logger.Logger, interval time.Duration, deliver usecase.DeliverPendingWebhooksUseCase) *Worker {
+	return &Worker{
+		Log:      log.WithField("component", "worker"),
+		Interval: interval,
+		Deliver:  deliver,
+	}
+}
+
+// Start launches the polling loop in a background goroutine.
+func (w *Worker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.Interval)
+		defer ticker.Stop()
+
+		w.Log.WithField("interval", w.Interval.String()).Info("webhook delivery worker started")
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Errors are already traced and logged by the use case;
+				// the next tick simply retries.
+				_, _ = w.Deliver.Execute(ctx)
+			}
+		}
+	}()
+}
+
+// Stop signals the loop to exit and waits for the current batch to finish.
+func (w *Worker) Stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	w.wg.Wait()
+	w.Log.Info("webhook delivery worker stopped")
+}
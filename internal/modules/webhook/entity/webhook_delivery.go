@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "PENDING"
+	DeliveryStatusSucceeded DeliveryStatus = "SUCCEEDED"
+	DeliveryStatusFailed    DeliveryStatus = "FAILED"
+)
+
+// Headers sent with every webhook delivery.
+const (
+	HeaderSignature = "X-Voyago-Signature"
+	HeaderEvent     = "X-Voyago-Event"
+	HeaderDelivery  = "X-Voyago-Delivery"
+)
+
+// WebhookDelivery tracks a single event that must be delivered to a single endpoint.
+// It is the unit of retry: every attempt is recorded as a WebhookDeliveryAttempt.
+// TraceContext holds the JSON trace context of the request that enqueued it, so
+// that the span of each attempt links back to that request.
+type WebhookDelivery struct {
+	ID            string         `gorm:"column:id;type:uuid;primaryKey"`
+	EndpointID    string         `gorm:"column:endpoint_id;type:uuid;not null;uniqueIndex:unq_webhook_deliveries_endpoint_event"`
+	EventID       string         `gorm:"column:event_id;type:uuid;not null;uniqueIndex:unq_webhook_deliveries_endpoint_event"`
+	EventType     string         `gorm:"column:event_type;type:varchar(100);not null"`
+	Payload       string         `gorm:"column:payload;type:text;not null"`
+	Status        DeliveryStatus `gorm:"column:status;type:varchar(20);not null;default:'PENDING'"`
+	AttemptCount  int            `gorm:"column:attempt_count;type:int;not null;default:0"`
+	NextAttemptAt int64          `gorm:"column:next_attempt_at;type:bigint;not null;default:0"`
+	LastError     *string        `gorm:"column:last_error;type:text"`
+	TraceContext  *string        `gorm:"column:trace_context;type:text"`
+	CreatedAt     int64          `gorm:"column:created_at;type:bigint;not null;autoCreateTime:milli"`
+	UpdatedAt     *int64         `gorm:"column:updated_at;type:bigint;autoUpdateTime:false"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// [ENTITY STANDARD: DOMAIN VALIDATION]
+func (e *WebhookDelivery) Validate() error {
+	return nil
+}
+
+// MarkSucceeded records a successful attempt.
+func (e *WebhookDelivery) MarkSucceeded(now time.Time) {
+	updatedAt := now.UnixMilli()
+	e.AttemptCount++
+	e.Status = DeliveryStatusSucceeded
+	e.LastError = nil
+	e.UpdatedAt = &updatedAt
+}
+
+// MarkAttemptFailed records a failed attempt and schedules the next one using
+// exponential backoff. Once maxAttempts is reached the delivery is marked FAILED
+// and will no longer be picked up by the worker.
+func (e *WebhookDelivery) MarkAttemptFailed(now time.Time, reason string, maxAttempts int, base, max time.Duration) {
+	updatedAt := now.UnixMilli()
+	e.AttemptCount++
+	e.LastError = &reason
+	e.UpdatedAt = &updatedAt
+
+	if e.AttemptCount >= maxAttempts {
+		e.Status = DeliveryStatusFailed
+		return
+	}
+
+	e.Status = DeliveryStatusPending
+	e.NextAttemptAt = now.Add(Backoff(e.AttemptCount, base, max)).UnixMilli()
+}
+
+// Backoff returns the delay before the next attempt: base * 2^(attempt-1), capped at max.
+//
+// Example (base=30s, max=1h): 30s, 1m, 2m, 4m, 8m, 16m, 32m, 1h, 1h...
+func Backoff(attempt int, base, max time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return min(delay, max)
+}
+
+// Sign computes the value of the HeaderSignature header.
+//
+// Format: "t=<unix seconds>,v1=<hex(HMAC-SHA256(secret, "<t>.<payload>"))>"
+//
+// Receivers should recompute the HMAC using the shared secret and reject
+// requests whose timestamp is too old to protect against replay attacks.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(payload)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
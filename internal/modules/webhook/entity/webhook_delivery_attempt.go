@@ -0,0 +1,23 @@
+package entity
+
+// WebhookDeliveryAttempt is the audit record of a single HTTP call made for a delivery.
+// Attempts are append-only and never updated.
+type WebhookDeliveryAttempt struct {
+	ID             string  `gorm:"column:id;type:uuid;primaryKey"`
+	DeliveryID     string  `gorm:"column:delivery_id;type:uuid;not null"`
+	AttemptNo      int     `gorm:"column:attempt_no;type:int;not null"`
+	ResponseStatus *int    `gorm:"column:response_status;type:int"`
+	ResponseBody   *string `gorm:"column:response_body;type:text"`
+	Error          *string `gorm:"column:error;type:text"`
+	DurationMs     int64   `gorm:"column:duration_ms;type:bigint;not null;default:0"`
+	CreatedAt      int64   `gorm:"column:created_at;type:bigint;not null;autoCreateTime:milli"`
+}
+
+func (WebhookDeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
+
+// [ENTITY STANDARD: DOMAIN VALIDATION]
+func (e *WebhookDeliveryAttempt) Validate() error {
+	return nil
+}
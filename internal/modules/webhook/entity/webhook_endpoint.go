@@ -0,0 +1,171 @@
+package entity
+
+import (
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"voyago/core-api/internal/pkg/apperror"
+)
+
+// [ENTITY STANDARD: DOMAIN SPECIFIC ERROR]
+const (
+	CodeWebhookEndpointNotFound   = "WEBHOOK_ENDPOINT_NOT_FOUND"
+	CodeWebhookEndpointInvalidURL = "WEBHOOK_ENDPOINT_INVALID_URL"
+	CodeWebhookEventTypesRequired = "WEBHOOK_EVENT_TYPES_REQUIRED"
+	CodeWebhookSecretTooShort     = "WEBHOOK_SECRET_TOO_SHORT"
+	CodeWebhookDeliveryNotFound   = "WEBHOOK_DELIVERY_NOT_FOUND"
+)
+
+const (
+	// MinSecretLength is the minimum length of the HMAC signing secret.
+	MinSecretLength = 16
+	// EventTypeWildcard subscribes an endpoint to every published event.
+	EventTypeWildcard = "*"
+
+	eventTypeSeparator = ","
+)
+
+var (
+	ErrWebhookEndpointNotFound = apperror.NewPersistance(
+		CodeWebhookEndpointNotFound,
+		"webhook endpoint not found",
+	)
+
+	ErrWebhookEndpointInvalidURL = apperror.NewPersistance(
+		CodeWebhookEndpointInvalidURL,
+		"webhook url must be an absolute https url of a public host",
+	)
+
+	ErrWebhookEventTypesRequired = apperror.NewPersistance(
+		CodeWebhookEventTypesRequired,
+		"webhook endpoint must subscribe to at least one event type",
+	)
+
+	ErrWebhookSecretTooShort = apperror.NewPersistance(
+		CodeWebhookSecretTooShort,
+		"webhook secret is too short",
+	)
+
+	ErrWebhookDeliveryNotFound = apperror.NewPersistance(
+		CodeWebhookDeliveryNotFound,
+		"webhook delivery not found",
+	)
+)
+
+func init() {
+	apperror.Register("webhook",
+		apperror.Def{Err: ErrWebhookEndpointNotFound, Status: 404},
+		apperror.Def{Err: ErrWebhookEndpointInvalidURL, Status: 422},
+		apperror.Def{Err: ErrWebhookEventTypesRequired},
+		apperror.Def{Err: ErrWebhookSecretTooShort},
+		apperror.Def{Err: ErrWebhookDeliveryNotFound, Status: 404},
+	)
+}
+
+type WebhookEndpoint struct {
+	ID          string  `gorm:"column:id;type:uuid;primaryKey"`
+	URL         string  `gorm:"column:url;type:varchar(2048);not null"`
+	Secret      string  `gorm:"column:secret;type:varchar(255);not null"`
+	EventTypes  string  `gorm:"column:event_types;type:text;not null"`
+	Description *string `gorm:"column:description;type:varchar(255)"`
+	IsActive    bool    `gorm:"column:is_active;not null;default:true"`
+	CreatedAt   int64   `gorm:"column:created_at;type:bigint;not null;autoCreateTime:milli"`
+	UpdatedAt   *int64  `gorm:"column:updated_at;type:bigint;autoUpdateTime:false"`
+	DeletedAt   *int64  `gorm:"column:deleted_at;autoUpdateTime:false"`
+}
+
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// SetEventTypes normalizes (trim, de-duplicate) and stores the subscribed event types.
+func (e *WebhookEndpoint) SetEventTypes(types []string) {
+	normalized := make([]string, 0, len(types))
+	for _, t := range types {
+		t = strings.TrimSpace(t)
+		if t == "" || slices.Contains(normalized, t) {
+			continue
+		}
+		normalized = append(normalized, t)
+	}
+	e.EventTypes = strings.Join(normalized, eventTypeSeparator)
+}
+
+// GetEventTypes returns the subscribed event types as a slice.
+func (e *WebhookEndpoint) GetEventTypes() []string {
+	if e.EventTypes == "" {
+		return []string{}
+	}
+	return strings.Split(e.EventTypes, eventTypeSeparator)
+}
+
+// Subscribes reports whether the endpoint should receive the given event type.
+func (e *WebhookEndpoint) Subscribes(eventType string) bool {
+	if !e.IsActive {
+		return false
+	}
+	types := e.GetEventTypes()
+	return slices.Contains(types, EventTypeWildcard) || slices.Contains(types, eventType)
+}
+
+// nonPublicPrefixes are the ranges not caught by the netip predicates that no
+// receiver is reachable on: "this network" and the carrier-grade NAT.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// IsPublicAddr reports whether addr may receive webhooks: a global unicast
+// address, neither loopback, link-local (e.g., the cloud metadata service on
+// 169.254.169.254) nor private (RFC 1918, IPv6 unique local). The webhooks
+// must not reach the internal network of the service.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// [ENTITY STANDARD: DOMAIN VALIDATION]
+func (e *WebhookEndpoint) Validate() error {
+	u, err := url.Parse(e.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrWebhookEndpointInvalidURL
+	}
+
+	if len(e.GetEventTypes()) == 0 {
+		return ErrWebhookEventTypesRequired
+	}
+
+	if len(e.Secret) < MinSecretLength {
+		return ErrWebhookSecretTooShort.WithDetail("min_length", MinSecretLength)
+	}
+
+	return nil
+}
+
+// ValidateTarget checks that the URL does not target the internal network:
+// it is https, and its host is neither localhost nor a non-public IP
+// literal. The host names are resolved when delivering, where the sender
+// refuses the non-public addresses they resolve to.
+func (e *WebhookEndpoint) ValidateTarget() error {
+	u, err := url.Parse(e.URL)
+	if err != nil || u.Scheme != "https" {
+		return ErrWebhookEndpointInvalidURL
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrWebhookEndpointInvalidURL
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddr(addr) {
+		return ErrWebhookEndpointInvalidURL
+	}
+	return nil
+}
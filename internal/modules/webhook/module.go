@@ -0,0 +1,258 @@
+package webhook
+
+import (
+	"fmt"
+	"time"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/container"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/http/versioning"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/infrastructure/validator"
+	"voyago/core-api/internal/modules"
+	"voyago/core-api/internal/modules/webhook/delivery/event"
+	"voyago/core-api/internal/modules/webhook/delivery/http"
+	"voyago/core-api/internal/modules/webhook/delivery/worker"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/modules/webhook/repository"
+	"voyago/core-api/internal/modules/webhook/repository/command"
+	"voyago/core-api/internal/modules/webhook/repository/query"
+	"voyago/core-api/internal/modules/webhook/sender"
+	"voyago/core-api/internal/modules/webhook/usecase"
+	"voyago/core-api/internal/pkg/audit"
+	"voyago/core-api/internal/pkg/events"
+	"voyago/core-api/internal/pkg/uid"
+	"voyago/core-api/internal/pkg/utils"
+
+	"go.uber.org/fx"
+)
+
+func init() {
+	modules.Register(modules.Module{
+		Name: "webhook",
+		Models: []any{
+			&entity.WebhookEndpoint{},
+			&entity.WebhookDelivery{},
+			&entity.WebhookDeliveryAttempt{},
+			&audit.Entry{},
+		},
+		HTTP: func(env modules.HTTP) {
+			RegisterHttpModule(HttpModuleConfig{
+				Config:    env.Config,
+				Routes:    env.Routes,
+				DB:        env.DB,
+				Log:       env.Log,
+				Val:       env.Val,
+				Tracer:    env.Tracer,
+				Bus:       env.Bus,
+				Lifecycle: env.Lifecycle,
+				// The worker process sends the deliveries (worker.standalone).
+				NoDispatcher: env.Config.Worker.Standalone,
+			})
+		},
+		// The webhook API is HTTP only: the gRPC server runs the worker.
+		Worker: func(env modules.Worker) {
+			RegisterWorkerModule(WorkerModuleConfig{
+				Config:       env.Config,
+				DB:           env.DB,
+				Log:          env.Log,
+				Tracer:       env.Tracer,
+				Bus:          env.Bus,
+				Lifecycle:    env.Lifecycle,
+				NoDispatcher: !env.Dedicated && env.Config.Worker.Standalone,
+			})
+		},
+	})
+}
+
+type HttpModuleConfig struct {
+	Config *config.Config
+	// Routes mounts the module routes under the versioned API prefix (e.g., /api/v1).
+	Routes *versioning.Router
+	DB     database.Database
+	Log    logger.Logger
+	Val    validator.Validator
+	Tracer tracer.Tracer
+	Bus    eventbus.Bus
+	// Lifecycle stops the delivery worker on shutdown.
+	Lifecycle *lifecycle.Manager
+	// NoDispatcher leaves the deliveries to the dispatcher of the worker
+	// process (worker.standalone).
+	NoDispatcher bool
+}
+
+// sensitiveKeys are the confidential fields of the module, masked in the logs
+// and traces (the signing secrets already are).
+var sensitiveKeys = []string{"signature"}
+
+// RegisterHttpModule wires the webhook API and starts the module worker
+// (see RegisterWorkerModule).
+func RegisterHttpModule(cfg HttpModuleConfig) {
+	hdlrLogger := cfg.Log.WithField("component", "handler")
+
+	var uc http.HandlerUseCases
+	container.Build(nil, "webhook",
+		providers(modules.Env{Config: cfg.Config, DB: cfg.DB, Log: cfg.Log, Tracer: cfg.Tracer, Bus: cfg.Bus}),
+		fx.Populate(
+			&uc.CreateEndpointUseCase,
+			&uc.UpdateEndpointUseCase,
+			&uc.DeleteEndpointUseCase,
+			&uc.GetEndpointUseCase,
+			&uc.ListEndpointsUseCase,
+			&uc.ListDeliveriesUseCase,
+		),
+	)
+
+	// setup handler
+	h := http.NewHandler(cfg.Config, hdlrLogger, cfg.Val, uc)
+
+	routeConfig := http.RouteConfig{
+		Routes:  cfg.Routes,
+		Config:  cfg.Config,
+		Handler: h,
+	}
+	routeConfig.Setup()
+
+	// setup event subscriber and delivery worker
+	RegisterWorkerModule(WorkerModuleConfig{
+		Config:       cfg.Config,
+		DB:           cfg.DB,
+		Log:          cfg.Log,
+		Tracer:       cfg.Tracer,
+		Bus:          cfg.Bus,
+		Lifecycle:    cfg.Lifecycle,
+		NoDispatcher: cfg.NoDispatcher,
+	})
+}
+
+// WorkerModuleConfig configures the background part of the module only.
+type WorkerModuleConfig struct {
+	Config *config.Config
+	DB     database.Database
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	Bus    eventbus.Bus
+	// Lifecycle stops the delivery worker on shutdown.
+	Lifecycle *lifecycle.Manager
+	// NoDispatcher only enqueues the deliveries of the published events,
+	// another process (cmd/worker) sends them.
+	NoDispatcher bool
+}
+
+// RegisterWorkerModule subscribes the module to domain events and starts its
+// delivery worker, without exposing any endpoint. It is used by transports
+// that do not serve the webhook API (e.g., the gRPC server) so that events
+// published in that process still reach subscribers.
+func RegisterWorkerModule(cfg WorkerModuleConfig) {
+	utils.RegisterSensitiveKeys(sensitiveKeys...)
+
+	opts := []fx.Option{
+		providers(modules.Env{Config: cfg.Config, DB: cfg.DB, Log: cfg.Log, Tracer: cfg.Tracer, Bus: cfg.Bus}),
+		// setup event subscriber
+		fx.Invoke(func(enqueue usecase.EnqueueWebhookDeliveriesUseCase) {
+			event.NewSubscriber(enqueue).Register(cfg.Bus)
+		}),
+	}
+	if !cfg.NoDispatcher {
+		opts = append(opts, fx.Invoke(startDispatcher))
+	}
+	container.Build(cfg.Lifecycle, "webhook dispatcher", opts...)
+}
+
+// startDispatcher runs the delivery worker from the start of the graph until
+// its stop, with the workers on shutdown.
+func startDispatcher(lc fx.Lifecycle, cfg *config.Config, log logger.Logger, deliver usecase.DeliverPendingWebhooksUseCase) {
+	w := worker.NewWorker(log, time.Duration(cfg.Webhook.Worker.Interval)*time.Second, deliver)
+	lc.Append(fx.StartStopHook(w.Start, w.Stop))
+}
+
+// providers returns the constructors of the module, shared by its
+// transports. The graph only calls those its transport needs.
+func providers(env modules.Env) fx.Option {
+	return fx.Options(
+		env.Options(),
+		fx.Provide(
+			fx.Annotate(audit.NewService, fx.As(new(audit.Recorder))),
+			newIDGenerator,
+			newDeliveryPolicy,
+			newTargetPolicy,
+			command.NewWebhookEndpointRepository,
+			query.NewWebhookEndpointRepository,
+			command.NewWebhookDeliveryRepository,
+			query.NewWebhookDeliveryRepository,
+			newRepositories,
+			func(cfg *config.Config) usecase.WebhookSender {
+				return sender.NewHttpSender(time.Duration(cfg.Webhook.Timeout)*time.Second, cfg.Webhook.AllowInternalTargets)
+			},
+			func(cfg *config.Config, trc tracer.Tracer) *events.Encoder {
+				return events.NewEncoder(cfg.App.Name, trc)
+			},
+		),
+		// The use cases log as the usecase component, the worker as the
+		// module.
+		fx.Module("usecase",
+			fx.Decorate(func(log logger.Logger) logger.Logger {
+				return log.WithField("component", "usecase")
+			}),
+			fx.Provide(
+				usecase.NewCreateWebhookEndpointUseCase,
+				usecase.NewUpdateWebhookEndpointUseCase,
+				usecase.NewDeleteWebhookEndpointUseCase,
+				usecase.NewGetWebhookEndpointUseCase,
+				usecase.NewListWebhookEndpointsUseCase,
+				usecase.NewListWebhookDeliveriesUseCase,
+				usecase.NewEnqueueWebhookDeliveriesUseCase,
+				usecase.NewDeliverPendingWebhooksUseCase,
+			),
+		),
+	)
+}
+
+// newRepositories returns the repositories of each use case.
+func newRepositories(
+	endpointCmd repository.WebhookEndpointCommandRepository,
+	endpointQry repository.WebhookEndpointQueryRepository,
+	deliveryCmd repository.WebhookDeliveryCommandRepository,
+	deliveryQry repository.WebhookDeliveryQueryRepository,
+) (
+	usecase.WebhookEndpointRepositories,
+	usecase.ListWebhookDeliveriesRepositories,
+	usecase.EnqueueWebhookDeliveriesRepositories,
+	usecase.DeliverPendingWebhooksRepositories,
+) {
+	return usecase.WebhookEndpointRepositories{EndpointCmd: endpointCmd, EndpointQry: endpointQry},
+		usecase.ListWebhookDeliveriesRepositories{EndpointQry: endpointQry, DeliveryQry: deliveryQry},
+		usecase.EnqueueWebhookDeliveriesRepositories{EndpointQry: endpointQry, DeliveryCmd: deliveryCmd},
+		usecase.DeliverPendingWebhooksRepositories{EndpointQry: endpointQry, DeliveryCmd: deliveryCmd}
+}
+
+// newDeliveryPolicy returns the batching and retries of the deliveries
+// configured by webhook.
+func newDeliveryPolicy(cfg *config.Config) usecase.DeliveryPolicy {
+	whCfg := cfg.Webhook
+	return usecase.DeliveryPolicy{
+		BatchSize:   whCfg.Worker.BatchSize,
+		Timeout:     time.Duration(whCfg.Timeout) * time.Second,
+		MaxAttempts: whCfg.Retry.MaxAttempts,
+		BaseBackoff: time.Duration(whCfg.Retry.BaseBackoff) * time.Second,
+		MaxBackoff:  time.Duration(whCfg.Retry.MaxBackoff) * time.Second,
+	}
+}
+
+// newTargetPolicy returns the endpoints accepted by webhook.allow_internal_targets.
+func newTargetPolicy(cfg *config.Config) usecase.TargetPolicy {
+	return usecase.TargetPolicy{AllowInternal: cfg.Webhook.AllowInternalTargets}
+}
+
+// newIDGenerator returns the generator of the module primary keys configured
+// by ids.generator.
+func newIDGenerator(cfg *config.Config) (uid.Generator, error) {
+	ids, err := uid.NewGenerator(cfg.IDs.Generator)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ids configuration: %w", err)
+	}
+	return ids, nil
+}
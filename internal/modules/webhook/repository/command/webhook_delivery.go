@@ -0,0 +1,82 @@
+package command
+
+import (
+	"context"
+	"time"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/modules/webhook/repository"
+
+	"gorm.io/gorm/clause"
+)
+
+// webhookDeliveryRepository provides the concrete implementation of WebhookDeliveryCommandRepository.
+type webhookDeliveryRepository struct {
+	*database.GormBaseRepository[entity.WebhookDelivery]
+}
+
+// [INTERFACE COMPLIANCE CHECK]
+var _ repository.WebhookDeliveryCommandRepository = (*webhookDeliveryRepository)(nil)
+
+// NewWebhookDeliveryRepository initializes the repository with a Database connection
+// and a centralized ErrorMapper.
+func NewWebhookDeliveryRepository(db database.Database) repository.WebhookDeliveryCommandRepository {
+	return &webhookDeliveryRepository{
+		GormBaseRepository: &database.GormBaseRepository[entity.WebhookDelivery]{
+			DB:          db,
+			ErrorMapper: database.MapDBError,
+		},
+	}
+}
+
+func (r *webhookDeliveryRepository) CreateMany(ctx context.Context, deliveries []entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	err := r.DB.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&deliveries).
+		Error
+	return database.MapDBError(err)
+}
+
+func (r *webhookDeliveryRepository) CreateAttempt(ctx context.Context, attempt *entity.WebhookDeliveryAttempt) error {
+	return database.MapDBError(r.DB.WithContext(ctx).Create(attempt).Error)
+}
+
+func (r *webhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.WebhookDelivery, error) {
+	var deliveries []entity.WebhookDelivery
+
+	err := r.DB.Atomic(ctx, func(txCtx context.Context) error {
+		db := r.DB.WithContext(txCtx)
+
+		if err := db.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entity.DeliveryStatusPending, now.UnixMilli()).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).
+			Error; err != nil {
+			return err
+		}
+
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(deliveries))
+		for i, d := range deliveries {
+			ids[i] = d.ID
+		}
+
+		return db.Model(&entity.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			UpdateColumn("next_attempt_at", now.Add(lease).UnixMilli()).
+			Error
+	})
+	if err != nil {
+		return nil, database.MapDBError(err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,26 @@
+package command
+
+import (
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/modules/webhook/repository"
+)
+
+// webhookEndpointRepository provides the concrete implementation of WebhookEndpointCommandRepository.
+type webhookEndpointRepository struct {
+	*database.GormBaseRepository[entity.WebhookEndpoint]
+}
+
+// [INTERFACE COMPLIANCE CHECK]
+var _ repository.WebhookEndpointCommandRepository = (*webhookEndpointRepository)(nil)
+
+// NewWebhookEndpointRepository initializes the repository with a Database connection
+// and a centralized ErrorMapper.
+func NewWebhookEndpointRepository(db database.Database) repository.WebhookEndpointCommandRepository {
+	return &webhookEndpointRepository{
+		GormBaseRepository: &database.GormBaseRepository[entity.WebhookEndpoint]{
+			DB:          db,
+			ErrorMapper: database.MapDBError,
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/pkg/spec"
+)
+
+// -------- Repository Command --------
+
+type WebhookEndpointCommandRepository interface {
+	Create(ctx context.Context, endpoint *entity.WebhookEndpoint) error
+	Update(ctx context.Context, endpoint *entity.WebhookEndpoint) error
+	// UpdateFields updates the given columns of the stored endpoint only.
+	UpdateFields(ctx context.Context, endpoint *entity.WebhookEndpoint, fields ...string) error
+	Delete(ctx context.Context, endpoint *entity.WebhookEndpoint) error
+}
+
+type WebhookDeliveryCommandRepository interface {
+	// CreateMany inserts deliveries, silently skipping (endpoint_id, event_id)
+	// pairs that already exist so that re-published events are not delivered twice.
+	CreateMany(ctx context.Context, deliveries []entity.WebhookDelivery) error
+	Update(ctx context.Context, delivery *entity.WebhookDelivery) error
+	CreateAttempt(ctx context.Context, attempt *entity.WebhookDeliveryAttempt) error
+
+	// ClaimDue locks up to 'limit' pending deliveries whose next attempt is due,
+	// and pushes their next_attempt_at forward by 'lease' so concurrent workers
+	// (other replicas) skip them while they are being processed.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.WebhookDelivery, error)
+}
+
+// -------- Repository Query --------
+
+type WebhookEndpointQueryRepository interface {
+	FindByID(ctx context.Context, id string) (*entity.WebhookEndpoint, error)
+	FindAll(ctx context.Context) ([]entity.WebhookEndpoint, error)
+	FindActive(ctx context.Context) ([]entity.WebhookEndpoint, error)
+}
+
+type WebhookDeliveryQueryRepository interface {
+	// List returns the deliveries matching s. s may filter and order by id,
+	// endpoint_id, event_id, event_type, status, next_attempt_at and
+	// created_at.
+	List(ctx context.Context, s spec.Spec) ([]entity.WebhookDelivery, error)
+	FindAttemptsByDeliveryID(ctx context.Context, deliveryID string) ([]entity.WebhookDeliveryAttempt, error)
+}
@@ -0,0 +1,85 @@
+package query
+
+import (
+	"context"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/modules/webhook/repository"
+	"voyago/core-api/internal/pkg/spec"
+)
+
+// deliveryFields are the fields of the delivery lists (see List).
+var deliveryFields = database.SpecFields{
+	"id":              "id",
+	"endpoint_id":     "endpoint_id",
+	"event_id":        "event_id",
+	"event_type":      "event_type",
+	"status":          "status",
+	"next_attempt_at": "next_attempt_at",
+	"created_at":      "created_at",
+}
+
+// webhookDeliveryRepository implements the repository.WebhookDeliveryQueryRepository interface.
+type webhookDeliveryRepository struct {
+	DB database.Database
+}
+
+// [INTERFACE COMPLIANCE CHECK]
+var _ repository.WebhookDeliveryQueryRepository = (*webhookDeliveryRepository)(nil)
+
+// NewWebhookDeliveryRepository creates a new instance for reading WebhookDelivery data.
+// The reads go to the replicas of db, if any (see database.Reader).
+func NewWebhookDeliveryRepository(db database.Database) repository.WebhookDeliveryQueryRepository {
+	return &webhookDeliveryRepository{
+		DB: database.Reader(db),
+	}
+}
+
+func (r *webhookDeliveryRepository) List(ctx context.Context, s spec.Spec) ([]entity.WebhookDelivery, error) {
+	var deliveries []entity.WebhookDelivery
+	if err := database.ApplySpec(r.DB.WithContext(ctx).
+		Model(&entity.WebhookDelivery{}).
+		Select(
+			"id",
+			"endpoint_id",
+			"event_id",
+			"event_type",
+			"status",
+			"attempt_count",
+			"next_attempt_at",
+			"last_error",
+			"created_at",
+			"updated_at",
+		), s, deliveryFields).
+		Find(&deliveries).
+		Error; err != nil {
+		return nil, database.MapDBError(err)
+	}
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryRepository) FindAttemptsByDeliveryID(ctx context.Context, deliveryID string) ([]entity.WebhookDeliveryAttempt, error) {
+	if deliveryID == "" {
+		return nil, nil
+	}
+	var attempts []entity.WebhookDeliveryAttempt
+	if err := r.DB.WithContext(ctx).
+		Model(&entity.WebhookDeliveryAttempt{}).
+		Select(
+			"id",
+			"delivery_id",
+			"attempt_no",
+			"response_status",
+			"response_body",
+			"error",
+			"duration_ms",
+			"created_at",
+		).
+		Where("delivery_id = ?", deliveryID).
+		Order("attempt_no").
+		Find(&attempts).
+		Error; err != nil {
+		return nil, database.MapDBError(err)
+	}
+	return attempts, nil
+}
@@ -0,0 +1,88 @@
+package query
+
+import (
+	"context"
+	"errors"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/modules/webhook/repository"
+
+	"gorm.io/gorm"
+)
+
+// endpointColumns lists the columns returned by endpoint queries (no SELECT *).
+var endpointColumns = []string{
+	"id",
+	"url",
+	"secret",
+	"event_types",
+	"description",
+	"is_active",
+	"created_at",
+	"updated_at",
+}
+
+// webhookEndpointRepository implements the repository.WebhookEndpointQueryRepository interface.
+type webhookEndpointRepository struct {
+	DB database.Database
+}
+
+// [INTERFACE COMPLIANCE CHECK]
+var _ repository.WebhookEndpointQueryRepository = (*webhookEndpointRepository)(nil)
+
+// NewWebhookEndpointRepository creates a new instance for reading WebhookEndpoint data.
+// The reads go to the replicas of db, if any (see database.Reader).
+func NewWebhookEndpointRepository(db database.Database) repository.WebhookEndpointQueryRepository {
+	return &webhookEndpointRepository{
+		DB: database.Reader(db),
+	}
+}
+
+func (r *webhookEndpointRepository) FindByID(ctx context.Context, id string) (*entity.WebhookEndpoint, error) {
+	if id == "" {
+		return nil, nil
+	}
+	var endpoint entity.WebhookEndpoint
+	err := r.DB.WithContext(ctx).
+		Model(&entity.WebhookEndpoint{}).
+		Select(endpointColumns).
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&endpoint).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, database.MapDBError(err)
+	}
+
+	return &endpoint, nil
+}
+
+func (r *webhookEndpointRepository) FindAll(ctx context.Context) ([]entity.WebhookEndpoint, error) {
+	var endpoints []entity.WebhookEndpoint
+	if err := r.DB.WithContext(ctx).
+		Model(&entity.WebhookEndpoint{}).
+		Select(endpointColumns).
+		Where("deleted_at IS NULL").
+		Order("created_at DESC").
+		Find(&endpoints).
+		Error; err != nil {
+		return nil, database.MapDBError(err)
+	}
+	return endpoints, nil
+}
+
+func (r *webhookEndpointRepository) FindActive(ctx context.Context) ([]entity.WebhookEndpoint, error) {
+	var endpoints []entity.WebhookEndpoint
+	if err := r.DB.WithContext(ctx).
+		Model(&entity.WebhookEndpoint{}).
+		Select(endpointColumns).
+		Where("is_active = ? AND deleted_at IS NULL", true).
+		Find(&endpoints).
+		Error; err != nil {
+		return nil, database.MapDBError(err)
+	}
+	return endpoints, nil
+}
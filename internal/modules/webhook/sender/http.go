@@ -0,0 +1,99 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/modules/webhook/usecase"
+)
+
+// maxResponseBody caps how much of the receiver's response body is read.
+const maxResponseBody = 4096
+
+// httpSender is the net/http implementation of usecase.WebhookSender.
+type httpSender struct {
+	client    *http.Client
+	httpsOnly bool
+}
+
+var _ usecase.WebhookSender = (*httpSender)(nil)
+
+// NewHttpSender creates a WebhookSender with a per-request timeout.
+// Redirects are not followed: receivers must answer on the registered URL.
+//
+// Unless allowInternal (local development and tests), only https URLs are
+// sent to, and only to public addresses (see entity.IsPublicAddr): the
+// address is checked when dialing, once the host is resolved, so that a host
+// name resolving to an internal address (DNS rebinding included) cannot make
+// the worker reach the internal network. The environment proxy is not used,
+// since it would be the address dialed.
+func NewHttpSender(timeout time.Duration, allowInternal bool) usecase.WebhookSender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowInternal {
+		dialer.Control = publicOnly
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &httpSender{
+		httpsOnly: !allowInternal,
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// publicOnly refuses the connections to the non-public addresses.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !entity.IsPublicAddr(addr) {
+		return fmt.Errorf("webhook target %s is not a public address", host)
+	}
+	return nil
+}
+
+func (s *httpSender) Send(ctx context.Context, req usecase.SendWebhookRequest) usecase.SendWebhookResult {
+	start := time.Now()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return usecase.SendWebhookResult{Duration: time.Since(start), Err: err}
+	}
+	if s.httpsOnly && httpReq.URL.Scheme != "https" {
+		return usecase.SendWebhookResult{Duration: time.Since(start), Err: fmt.Errorf("webhook url scheme %q is not https", httpReq.URL.Scheme)}
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return usecase.SendWebhookResult{Duration: time.Since(start), Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+
+	return usecase.SendWebhookResult{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		Duration:   time.Since(start),
+	}
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/modules/webhook/entity"
+)
+
+// -------- DTOs --------
+type CreateWebhookEndpointRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=2048" label:"URL"`
+	Secret      string   `json:"secret" sanitize:"-" validate:"required,min=16,max=255" label:"Secret"`
+	EventTypes  []string `json:"event_types" validate:"required,min=1,dive,required,max=100" label:"Event types"`
+	Description *string  `json:"description" validate:"omitempty,max=255" label:"Description"`
+}
+
+type UpdateWebhookEndpointRequest struct {
+	ID          string   `json:"-" params:"id" validate:"required,uuid" label:"Webhook endpoint ID"`
+	URL         *string  `json:"url" validate:"omitempty,url,max=2048" label:"URL"`
+	Secret      *string  `json:"secret" sanitize:"-" validate:"omitempty,min=16,max=255" label:"Secret"`
+	EventTypes  []string `json:"event_types" validate:"omitempty,min=1,dive,required,max=100" label:"Event types"`
+	Description *string  `json:"description" validate:"omitempty,max=255" label:"Description"`
+	IsActive    *bool    `json:"is_active" label:"Is active"`
+}
+
+type ListWebhookDeliveriesRequest struct {
+	EndpointID string `json:"-" params:"id" validate:"required,uuid" label:"Webhook endpoint ID"`
+	Limit      int    `json:"limit" query:"limit" validate:"omitempty,gte=1,lte=100" label:"Limit"`
+}
+
+// WebhookEndpointResponse never exposes the signing secret.
+type WebhookEndpointResponse struct {
+	ID          string   `json:"id"`
+	URL         string   `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	Description *string  `json:"description"`
+	IsActive    bool     `json:"is_active"`
+	CreatedAt   int64    `json:"created_at"`
+	UpdatedAt   *int64   `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID            string                           `json:"id"`
+	EventID       string                           `json:"event_id"`
+	EventType     string                           `json:"event_type"`
+	Status        string                           `json:"status"`
+	AttemptCount  int                              `json:"attempt_count"`
+	NextAttemptAt int64                            `json:"next_attempt_at"`
+	LastError     *string                          `json:"last_error"`
+	CreatedAt     int64                            `json:"created_at"`
+	Attempts      []WebhookDeliveryAttemptResponse `json:"attempts"`
+}
+
+type WebhookDeliveryAttemptResponse struct {
+	AttemptNo      int     `json:"attempt_no"`
+	ResponseStatus *int    `json:"response_status"`
+	Error          *string `json:"error"`
+	DurationMs     int64   `json:"duration_ms"`
+	CreatedAt      int64   `json:"created_at"`
+}
+
+type DeliverPendingWebhooksResponse struct {
+	Claimed   int
+	Succeeded int
+	Failed    int
+}
+
+// -------- Ports --------
+
+// SendWebhookRequest is a fully prepared (signed) HTTP request for an endpoint.
+type SendWebhookRequest struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// SendWebhookResult captures the outcome of a single HTTP call.
+// Err is set for transport failures (DNS, timeout, connection refused).
+type SendWebhookResult struct {
+	StatusCode int
+	Body       string
+	Duration   time.Duration
+	Err        error
+}
+
+// Succeeded reports whether the receiver acknowledged the delivery (2xx).
+func (r SendWebhookResult) Succeeded() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// TargetPolicy tells which URLs the endpoints may target: https URLs of
+// public hosts only (see entity.WebhookEndpoint.ValidateTarget), unless
+// AllowInternal (local development and tests).
+type TargetPolicy struct {
+	AllowInternal bool
+}
+
+// validate validates e, and its target unless the internal ones are allowed.
+func (p TargetPolicy) validate(e *entity.WebhookEndpoint) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	if p.AllowInternal {
+		return nil
+	}
+	return e.ValidateTarget()
+}
+
+// WebhookSender performs the outbound HTTP call. It is a port so that the
+// delivery use case can be tested without network access.
+type WebhookSender interface {
+	Send(ctx context.Context, req SendWebhookRequest) SendWebhookResult
+}
+
+// -------- Usecase Interfaces --------
+
+type CreateWebhookEndpointUseCase interface {
+	Execute(ctx context.Context, req *CreateWebhookEndpointRequest) (*WebhookEndpointResponse, error)
+}
+
+type UpdateWebhookEndpointUseCase interface {
+	Execute(ctx context.Context, req *UpdateWebhookEndpointRequest) (*WebhookEndpointResponse, error)
+}
+
+type DeleteWebhookEndpointUseCase interface {
+	Execute(ctx context.Context, id string) error
+}
+
+type GetWebhookEndpointUseCase interface {
+	Execute(ctx context.Context, id string) (*WebhookEndpointResponse, error)
+}
+
+type ListWebhookEndpointsUseCase interface {
+	Execute(ctx context.Context) ([]WebhookEndpointResponse, error)
+}
+
+type ListWebhookDeliveriesUseCase interface {
+	// Execute returns the most recent deliveries of an endpoint together with
+	// their attempt audit trail.
+	Execute(ctx context.Context, req *ListWebhookDeliveriesRequest) ([]WebhookDeliveryResponse, error)
+}
+
+type EnqueueWebhookDeliveriesUseCase interface {
+	// Execute fans a domain event out into one delivery per subscribed endpoint.
+	// It returns the number of deliveries scheduled.
+	Execute(ctx context.Context, evt eventbus.Event) (int, error)
+}
+
+type DeliverPendingWebhooksUseCase interface {
+	// Execute claims due deliveries, sends them and records every attempt.
+	Execute(ctx context.Context) (*DeliverPendingWebhooksResponse, error)
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/pkg/audit"
+	baserepo "voyago/core-api/internal/pkg/repository"
+	"voyago/core-api/internal/pkg/uid"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+// createWebhookEndpointUseCase is the private implementation of CreateWebhookEndpointUseCase.
+type createWebhookEndpointUseCase struct {
+	Log     logger.Logger
+	Tracer  tracer.Tracer
+	Runner  baserepo.TransactionManager
+	Audit   audit.Recorder
+	IDs     uid.Generator
+	Repo    WebhookEndpointRepositories
+	Targets TargetPolicy
+}
+
+const createEndpointUseCaseName = "usecase:webhook.endpoint.create"
+
+var _ CreateWebhookEndpointUseCase = (*createWebhookEndpointUseCase)(nil)
+
+func NewCreateWebhookEndpointUseCase(log logger.Logger, trc tracer.Tracer, runner baserepo.TransactionManager, aud audit.Recorder, ids uid.Generator, repo WebhookEndpointRepositories, targets TargetPolicy) CreateWebhookEndpointUseCase {
+	return &createWebhookEndpointUseCase{
+		Log:     log.WithField("action", createEndpointUseCaseName),
+		Tracer:  trc,
+		Runner:  runner,
+		Audit:   aud,
+		IDs:     ids,
+		Repo:    repo,
+		Targets: targets,
+	}
+}
+
+func (uc *createWebhookEndpointUseCase) Execute(ctx context.Context, req *CreateWebhookEndpointRequest) (*WebhookEndpointResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, createEndpointUseCaseName)
+	defer span.Finish()
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"url":         req.URL,
+			"event_types": req.EventTypes,
+		},
+	}).Info("usecase started")
+
+	e := entity.WebhookEndpoint{
+		ID:          uc.IDs.NewID(),
+		URL:         req.URL,
+		Secret:      req.Secret,
+		Description: req.Description,
+		IsActive:    true,
+	}
+	e.SetEventTypes(req.EventTypes)
+
+	// --- PILLAR: DOMAIN VALIDATION ---
+	if err := uc.Targets.validate(&e); err != nil {
+		logAndTraceError(span, log, err, "domain logic validation failed", false)
+		return nil, err
+	}
+
+	// --- PILLAR: PERSISTENCE ---
+	err := uc.Runner.Atomic(ctx, func(txCtx context.Context) error {
+		if err := uc.Repo.EndpointCmd.Create(txCtx, &e); err != nil {
+			return err
+		}
+		return uc.Audit.Record(txCtx, audit.Change{
+			Action:     auditAction(createEndpointUseCaseName),
+			EntityType: auditEntityEndpoint,
+			EntityID:   e.ID,
+			After:      toEndpointResponse(&e),
+		})
+	})
+	if err != nil {
+		// [STANDARD ERROR HANDLING]: BUBBLE UP
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	log.Info("usecase completed")
+	return toEndpointResponse(&e), nil
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/pkg/audit"
+	baserepo "voyago/core-api/internal/pkg/repository"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+// deleteWebhookEndpointUseCase is the private implementation of DeleteWebhookEndpointUseCase.
+type deleteWebhookEndpointUseCase struct {
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	Runner baserepo.TransactionManager
+	Audit  audit.Recorder
+	Repo   WebhookEndpointRepositories
+}
+
+const deleteEndpointUseCaseName = "usecase:webhook.endpoint.delete"
+
+var _ DeleteWebhookEndpointUseCase = (*deleteWebhookEndpointUseCase)(nil)
+
+func NewDeleteWebhookEndpointUseCase(log logger.Logger, trc tracer.Tracer, runner baserepo.TransactionManager, aud audit.Recorder, repo WebhookEndpointRepositories) DeleteWebhookEndpointUseCase {
+	return &deleteWebhookEndpointUseCase{
+		Log:    log.WithField("action", deleteEndpointUseCaseName),
+		Tracer: trc,
+		Runner: runner,
+		Audit:  aud,
+		Repo:   repo,
+	}
+}
+
+// Execute soft-deletes the endpoint. Deliveries and their attempt records are
+// retained for audit purposes; the endpoint simply stops receiving new events.
+func (uc *deleteWebhookEndpointUseCase) Execute(ctx context.Context, id string) error {
+	span, ctx := uc.Tracer.StartSpan(ctx, deleteEndpointUseCaseName)
+	defer span.Finish()
+	ctx = ctxkey.SetReadPrimary(ctx) // the endpoint is read to be deleted: not from a lagging replica
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{"webhook_endpoint_id": id},
+	}).Info("usecase started")
+
+	e, err := uc.Repo.EndpointQry.FindByID(ctx, id)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+	if e == nil {
+		logAndTraceError(span, log, entity.ErrWebhookEndpointNotFound, "webhook endpoint not found", false)
+		return entity.ErrWebhookEndpointNotFound
+	}
+
+	before := toEndpointResponse(e)
+	now := time.Now().UnixMilli()
+	e.IsActive = false
+	e.UpdatedAt = &now
+	e.DeletedAt = &now
+
+	err = uc.Runner.Atomic(ctx, func(txCtx context.Context) error {
+		if err := uc.Repo.EndpointCmd.UpdateFields(txCtx, e, "is_active", "updated_at", "deleted_at"); err != nil {
+			return err
+		}
+		return uc.Audit.Record(txCtx, audit.Change{
+			Action:     auditAction(deleteEndpointUseCaseName),
+			EntityType: auditEntityEndpoint,
+			EntityID:   e.ID,
+			Before:     before,
+		})
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return err
+	}
+
+	log.Info("usecase completed")
+	return nil
+}
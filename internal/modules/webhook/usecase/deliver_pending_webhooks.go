@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/modules/webhook/repository"
+	"voyago/core-api/internal/pkg/events"
+	"voyago/core-api/internal/pkg/uid"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+// maxStoredResponseBody bounds how much of the receiver's response is kept in the audit trail.
+const maxStoredResponseBody = 1024
+
+type DeliverPendingWebhooksRepositories struct {
+	EndpointQry repository.WebhookEndpointQueryRepository
+	DeliveryCmd repository.WebhookDeliveryCommandRepository
+}
+
+// DeliveryPolicy controls batching and retry behavior of the delivery worker.
+type DeliveryPolicy struct {
+	BatchSize   int
+	Timeout     time.Duration
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// deliverPendingWebhooksUseCase is the private implementation of DeliverPendingWebhooksUseCase.
+type deliverPendingWebhooksUseCase struct {
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	Sender WebhookSender
+	Policy DeliveryPolicy
+	IDs    uid.Generator
+	Repo   DeliverPendingWebhooksRepositories
+}
+
+const (
+	deliverPendingUseCaseName = "usecase:webhook.delivery.deliver_pending"
+	deliverSpanName           = "usecase:webhook.delivery.deliver"
+)
+
+var _ DeliverPendingWebhooksUseCase = (*deliverPendingWebhooksUseCase)(nil)
+
+func NewDeliverPendingWebhooksUseCase(log logger.Logger, trc tracer.Tracer, sender WebhookSender, policy DeliveryPolicy, ids uid.Generator, repo DeliverPendingWebhooksRepositories) DeliverPendingWebhooksUseCase {
+	return &deliverPendingWebhooksUseCase{
+		Log:    log.WithField("action", deliverPendingUseCaseName),
+		Tracer: trc,
+		Sender: sender,
+		Policy: policy,
+		IDs:    ids,
+		Repo:   repo,
+	}
+}
+
+func (uc *deliverPendingWebhooksUseCase) Execute(ctx context.Context) (*DeliverPendingWebhooksResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, deliverPendingUseCaseName)
+	defer span.Finish()
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+
+	// The lease must outlive a full HTTP attempt, otherwise another replica
+	// could claim the same delivery while it is still in flight.
+	lease := uc.Policy.Timeout * 2
+	deliveries, err := uc.Repo.DeliveryCmd.ClaimDue(ctx, time.Now(), lease, uc.Policy.BatchSize)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	res := &DeliverPendingWebhooksResponse{Claimed: len(deliveries)}
+	for i := range deliveries {
+		ok, err := uc.deliver(ctx, log, &deliveries[i])
+		if err != nil {
+			// Persistence failures are not fatal for the batch: the lease expires
+			// and the delivery is picked up again on a later tick.
+			logAndTraceError(span, log, err, "failed to record delivery outcome", true)
+			continue
+		}
+		if ok {
+			res.Succeeded++
+		} else {
+			res.Failed++
+		}
+	}
+
+	if res.Claimed > 0 {
+		log.WithFields(map[string]any{
+			"claimed":   res.Claimed,
+			"succeeded": res.Succeeded,
+			"failed":    res.Failed,
+		}).Info("usecase completed")
+	}
+	return res, nil
+}
+
+// deliver sends a single delivery and records its outcome.
+// It reports whether the receiver acknowledged the delivery.
+//
+// Its span is a child of the batch span, linked to the trace of the request
+// that enqueued the delivery.
+func (uc *deliverPendingWebhooksUseCase) deliver(ctx context.Context, log logger.Logger, d *entity.WebhookDelivery) (delivered bool, err error) {
+	span, ctx := uc.Tracer.StartSpanWithLinks(ctx, deliverSpanName, traceLinks(d.TraceContext, map[string]any{
+		"webhook.event_id": d.EventID,
+	})...)
+	defer span.Finish()
+	span.SetTag("webhook.delivery_id", d.ID)
+	span.SetTag("webhook.event_type", d.EventType)
+	span.SetTag("webhook.attempt", d.AttemptCount+1)
+	defer func() {
+		if err != nil {
+			utils.RecordSpanError(span, err)
+		} else if !delivered {
+			span.SetTag("error", true)
+			if d.LastError != nil {
+				span.SetTag("error.message", *d.LastError)
+			}
+		}
+	}()
+
+	log = log.WithContext(ctx).WithFields(map[string]any{
+		"delivery_id": d.ID,
+		"endpoint_id": d.EndpointID,
+		"event_type":  d.EventType,
+	})
+
+	endpoint, err := uc.Repo.EndpointQry.FindByID(ctx, d.EndpointID)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	if endpoint == nil || !endpoint.IsActive {
+		// The endpoint was removed or disabled after the event was enqueued:
+		// give up immediately instead of retrying into the void.
+		reason := "webhook endpoint is inactive or deleted"
+		d.MarkAttemptFailed(now, reason, d.AttemptCount+1, uc.Policy.BaseBackoff, uc.Policy.MaxBackoff)
+		log.Warn(reason)
+		return false, uc.Repo.DeliveryCmd.Update(ctx, d)
+	}
+
+	body := []byte(d.Payload)
+	result := uc.Sender.Send(ctx, SendWebhookRequest{
+		URL: endpoint.URL,
+		Headers: map[string]string{
+			"Content-Type":         events.ContentType,
+			entity.HeaderSignature: entity.Sign(endpoint.Secret, now.Unix(), body),
+			entity.HeaderEvent:     d.EventType,
+			entity.HeaderDelivery:  d.ID,
+		},
+		Body: body,
+	})
+
+	attempt := entity.WebhookDeliveryAttempt{
+		ID:         uc.IDs.NewID(),
+		DeliveryID: d.ID,
+		AttemptNo:  d.AttemptCount + 1,
+		DurationMs: result.Duration.Milliseconds(),
+	}
+	if result.StatusCode != 0 {
+		status := result.StatusCode
+		attempt.ResponseStatus = &status
+		respBody := truncate(result.Body, maxStoredResponseBody)
+		attempt.ResponseBody = &respBody
+	}
+
+	finishedAt := time.Now()
+	if result.Succeeded() {
+		d.MarkSucceeded(finishedAt)
+	} else {
+		reason := failureReason(result)
+		attempt.Error = &reason
+		d.MarkAttemptFailed(finishedAt, reason, uc.Policy.MaxAttempts, uc.Policy.BaseBackoff, uc.Policy.MaxBackoff)
+		log.WithFields(map[string]any{
+			"attempt": d.AttemptCount,
+			"status":  d.Status,
+			"error":   reason,
+		}).Warn("webhook delivery attempt failed")
+	}
+
+	if err := uc.Repo.DeliveryCmd.CreateAttempt(ctx, &attempt); err != nil {
+		return false, err
+	}
+	if err := uc.Repo.DeliveryCmd.Update(ctx, d); err != nil {
+		return false, err
+	}
+
+	return result.Succeeded(), nil
+}
+
+func failureReason(r SendWebhookResult) string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	return fmt.Sprintf("unexpected response status %d", r.StatusCode)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
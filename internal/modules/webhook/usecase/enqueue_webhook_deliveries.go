@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/modules/webhook/repository"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/events"
+	"voyago/core-api/internal/pkg/uid"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+type EnqueueWebhookDeliveriesRepositories struct {
+	EndpointQry repository.WebhookEndpointQueryRepository
+	DeliveryCmd repository.WebhookDeliveryCommandRepository
+}
+
+// enqueueWebhookDeliveriesUseCase is the private implementation of EnqueueWebhookDeliveriesUseCase.
+type enqueueWebhookDeliveriesUseCase struct {
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	IDs    uid.Generator
+	Events *events.Encoder
+	Repo   EnqueueWebhookDeliveriesRepositories
+}
+
+const enqueueDeliveriesUseCaseName = "usecase:webhook.delivery.enqueue"
+
+var _ EnqueueWebhookDeliveriesUseCase = (*enqueueWebhookDeliveriesUseCase)(nil)
+
+func NewEnqueueWebhookDeliveriesUseCase(log logger.Logger, trc tracer.Tracer, ids uid.Generator, enc *events.Encoder, repo EnqueueWebhookDeliveriesRepositories) EnqueueWebhookDeliveriesUseCase {
+	return &enqueueWebhookDeliveriesUseCase{
+		Log:    log.WithField("action", enqueueDeliveriesUseCaseName),
+		Tracer: trc,
+		IDs:    ids,
+		Events: enc,
+		Repo:   repo,
+	}
+}
+
+func (uc *enqueueWebhookDeliveriesUseCase) Execute(ctx context.Context, evt eventbus.Event) (int, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, enqueueDeliveriesUseCaseName)
+	defer span.Finish()
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{
+			"event_id":   evt.ID,
+			"event_type": evt.Type,
+		},
+	}).Info("usecase started")
+
+	endpoints, err := uc.Repo.EndpointQry.FindActive(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return 0, err
+	}
+
+	// The payload (a CloudEvent) is serialized once and shared by every
+	// endpoint so that all subscribers receive (and sign) exactly the same
+	// bytes.
+	payload, err := uc.Events.Encode(ctx, evt)
+	if err != nil {
+		appErr := apperror.NewInternal(apperror.CodeInternalError, "failed to serialize event payload", err)
+		logAndTraceError(span, log, appErr, "failed to serialize event payload", true)
+		return 0, appErr
+	}
+
+	// The worker links the span of each attempt to this trace.
+	traceContext := encodeTraceContext(ctx, uc.Tracer)
+
+	now := time.Now().UnixMilli()
+	deliveries := make([]entity.WebhookDelivery, 0, len(endpoints))
+	for _, e := range endpoints {
+		if !e.Subscribes(evt.Type) {
+			continue
+		}
+		deliveries = append(deliveries, entity.WebhookDelivery{
+			ID:            uc.IDs.NewID(),
+			EndpointID:    e.ID,
+			EventID:       evt.ID,
+			EventType:     evt.Type,
+			Payload:       string(payload),
+			Status:        entity.DeliveryStatusPending,
+			NextAttemptAt: now,
+			TraceContext:  traceContext,
+		})
+	}
+
+	if len(deliveries) == 0 {
+		log.Debug("no endpoint subscribed to event")
+		return 0, nil
+	}
+
+	if err := uc.Repo.DeliveryCmd.CreateMany(ctx, deliveries); err != nil {
+		utils.RecordSpanError(span, err)
+		return 0, err
+	}
+
+	log.WithField("deliveries", len(deliveries)).Info("usecase completed")
+	return len(deliveries), nil
+}
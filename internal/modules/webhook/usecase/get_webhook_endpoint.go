@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+// getWebhookEndpointUseCase is the private implementation of GetWebhookEndpointUseCase.
+type getWebhookEndpointUseCase struct {
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	Repo   WebhookEndpointRepositories
+}
+
+const getEndpointUseCaseName = "usecase:webhook.endpoint.get"
+
+var _ GetWebhookEndpointUseCase = (*getWebhookEndpointUseCase)(nil)
+
+func NewGetWebhookEndpointUseCase(log logger.Logger, trc tracer.Tracer, repo WebhookEndpointRepositories) GetWebhookEndpointUseCase {
+	return &getWebhookEndpointUseCase{
+		Log:    log.WithField("action", getEndpointUseCaseName),
+		Tracer: trc,
+		Repo:   repo,
+	}
+}
+
+func (uc *getWebhookEndpointUseCase) Execute(ctx context.Context, id string) (*WebhookEndpointResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, getEndpointUseCaseName)
+	defer span.Finish()
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+
+	e, err := uc.Repo.EndpointQry.FindByID(ctx, id)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	if e == nil {
+		logAndTraceError(span, log, entity.ErrWebhookEndpointNotFound, "webhook endpoint not found", false)
+		return nil, entity.ErrWebhookEndpointNotFound
+	}
+
+	return toEndpointResponse(e), nil
+}
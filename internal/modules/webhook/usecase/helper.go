@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/modules/webhook/repository"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+// WebhookEndpointRepositories groups the repositories used by endpoint use cases.
+type WebhookEndpointRepositories struct {
+	EndpointCmd repository.WebhookEndpointCommandRepository
+	EndpointQry repository.WebhookEndpointQueryRepository
+}
+
+func logAndTraceError(span tracer.Span, log logger.Logger, err error, msg string, isCritical bool) {
+	if err == nil {
+		return
+	}
+
+	utils.RecordSpanError(span, err)
+
+	var appErr *apperror.AppError
+	logFields := map[string]any{"error": err.Error()}
+	if errors.As(err, &appErr) {
+		if appErr.Err != nil {
+			logFields["internal_detail"] = appErr.Err.Error()
+		}
+		if appErr.Details != nil {
+			logFields["details"] = appErr.Details
+		}
+		logFields["retryable"] = appErr.IsRetryable()
+	}
+	l := log.WithFields(logFields)
+	if isCritical {
+		l.Error(msg)
+	} else {
+		l.Warn(msg)
+	}
+}
+
+// encodeTraceContext serializes the trace context of ctx, nil when ctx holds
+// no trace.
+func encodeTraceContext(ctx context.Context, trc tracer.Tracer) *string {
+	tc := tracer.TraceContext{}
+	trc.Inject(ctx, tc)
+	if len(tc) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(tc)
+	if err != nil {
+		return nil
+	}
+	s := string(b)
+	return &s
+}
+
+// traceLinks returns the link to the trace context encoded by
+// encodeTraceContext, none when it is missing or malformed.
+func traceLinks(encoded *string, attrs map[string]any) []tracer.Link {
+	if encoded == nil {
+		return nil
+	}
+	var tc tracer.TraceContext
+	if err := json.Unmarshal([]byte(*encoded), &tc); err != nil || len(tc) == 0 {
+		return nil
+	}
+	return []tracer.Link{{Context: tc, Attributes: attrs}}
+}
+
+// auditEntityEndpoint is the entity type of the webhook endpoint audit entries.
+const auditEntityEndpoint = "webhook_endpoint"
+
+// auditAction returns the audit action of a use case: its name without the
+// layer (e.g., "webhook.endpoint.create").
+func auditAction(useCaseName string) string {
+	return strings.TrimPrefix(useCaseName, "usecase:")
+}
+
+func toEndpointResponse(e *entity.WebhookEndpoint) *WebhookEndpointResponse {
+	return &WebhookEndpointResponse{
+		ID:          e.ID,
+		URL:         e.URL,
+		EventTypes:  e.GetEventTypes(),
+		Description: e.Description,
+		IsActive:    e.IsActive,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/modules/webhook/repository"
+	"voyago/core-api/internal/pkg/spec"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+type ListWebhookDeliveriesRepositories struct {
+	EndpointQry repository.WebhookEndpointQueryRepository
+	DeliveryQry repository.WebhookDeliveryQueryRepository
+}
+
+// listWebhookDeliveriesUseCase is the private implementation of ListWebhookDeliveriesUseCase.
+type listWebhookDeliveriesUseCase struct {
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	Repo   ListWebhookDeliveriesRepositories
+}
+
+const (
+	listDeliveriesUseCaseName = "usecase:webhook.delivery.list"
+	defaultDeliveriesLimit    = 20
+)
+
+var _ ListWebhookDeliveriesUseCase = (*listWebhookDeliveriesUseCase)(nil)
+
+func NewListWebhookDeliveriesUseCase(log logger.Logger, trc tracer.Tracer, repo ListWebhookDeliveriesRepositories) ListWebhookDeliveriesUseCase {
+	return &listWebhookDeliveriesUseCase{
+		Log:    log.WithField("action", listDeliveriesUseCaseName),
+		Tracer: trc,
+		Repo:   repo,
+	}
+}
+
+func (uc *listWebhookDeliveriesUseCase) Execute(ctx context.Context, req *ListWebhookDeliveriesRequest) ([]WebhookDeliveryResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, listDeliveriesUseCaseName)
+	defer span.Finish()
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+
+	endpoint, err := uc.Repo.EndpointQry.FindByID(ctx, req.EndpointID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	if endpoint == nil {
+		logAndTraceError(span, log, entity.ErrWebhookEndpointNotFound, "webhook endpoint not found", false)
+		return nil, entity.ErrWebhookEndpointNotFound
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultDeliveriesLimit
+	}
+
+	// The latest deliveries of the endpoint.
+	deliveries, err := uc.Repo.DeliveryQry.List(ctx, spec.New(
+		spec.Where("endpoint_id", spec.Eq, req.EndpointID),
+		spec.OrderBy("created_at", spec.Desc),
+		spec.Limit(limit),
+	))
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	res := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		attempts, err := uc.Repo.DeliveryQry.FindAttemptsByDeliveryID(ctx, d.ID)
+		if err != nil {
+			utils.RecordSpanError(span, err)
+			return nil, err
+		}
+
+		attemptsRes := make([]WebhookDeliveryAttemptResponse, 0, len(attempts))
+		for _, a := range attempts {
+			attemptsRes = append(attemptsRes, WebhookDeliveryAttemptResponse{
+				AttemptNo:      a.AttemptNo,
+				ResponseStatus: a.ResponseStatus,
+				Error:          a.Error,
+				DurationMs:     a.DurationMs,
+				CreatedAt:      a.CreatedAt,
+			})
+		}
+
+		res = append(res, WebhookDeliveryResponse{
+			ID:            d.ID,
+			EventID:       d.EventID,
+			EventType:     d.EventType,
+			Status:        string(d.Status),
+			AttemptCount:  d.AttemptCount,
+			NextAttemptAt: d.NextAttemptAt,
+			LastError:     d.LastError,
+			CreatedAt:     d.CreatedAt,
+			Attempts:      attemptsRes,
+		})
+	}
+
+	return res, nil
+}
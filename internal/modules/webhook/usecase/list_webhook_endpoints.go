@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+// listWebhookEndpointsUseCase is the private implementation of ListWebhookEndpointsUseCase.
+type listWebhookEndpointsUseCase struct {
+	Log    logger.Logger
+	Tracer tracer.Tracer
+	Repo   WebhookEndpointRepositories
+}
+
+const listEndpointsUseCaseName = "usecase:webhook.endpoint.list"
+
+var _ ListWebhookEndpointsUseCase = (*listWebhookEndpointsUseCase)(nil)
+
+func NewListWebhookEndpointsUseCase(log logger.Logger, trc tracer.Tracer, repo WebhookEndpointRepositories) ListWebhookEndpointsUseCase {
+	return &listWebhookEndpointsUseCase{
+		Log:    log.WithField("action", listEndpointsUseCaseName),
+		Tracer: trc,
+		Repo:   repo,
+	}
+}
+
+func (uc *listWebhookEndpointsUseCase) Execute(ctx context.Context) ([]WebhookEndpointResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, listEndpointsUseCaseName)
+	defer span.Finish()
+
+	endpoints, err := uc.Repo.EndpointQry.FindAll(ctx)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	res := make([]WebhookEndpointResponse, 0, len(endpoints))
+	for i := range endpoints {
+		res = append(res, *toEndpointResponse(&endpoints[i]))
+	}
+	return res, nil
+}
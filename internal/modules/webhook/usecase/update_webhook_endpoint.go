@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/pkg/audit"
+	baserepo "voyago/core-api/internal/pkg/repository"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+// updateWebhookEndpointUseCase is the private implementation of UpdateWebhookEndpointUseCase.
+type updateWebhookEndpointUseCase struct {
+	Log     logger.Logger
+	Tracer  tracer.Tracer
+	Runner  baserepo.TransactionManager
+	Audit   audit.Recorder
+	Repo    WebhookEndpointRepositories
+	Targets TargetPolicy
+}
+
+const updateEndpointUseCaseName = "usecase:webhook.endpoint.update"
+
+var _ UpdateWebhookEndpointUseCase = (*updateWebhookEndpointUseCase)(nil)
+
+func NewUpdateWebhookEndpointUseCase(log logger.Logger, trc tracer.Tracer, runner baserepo.TransactionManager, aud audit.Recorder, repo WebhookEndpointRepositories, targets TargetPolicy) UpdateWebhookEndpointUseCase {
+	return &updateWebhookEndpointUseCase{
+		Log:     log.WithField("action", updateEndpointUseCaseName),
+		Tracer:  trc,
+		Runner:  runner,
+		Audit:   aud,
+		Repo:    repo,
+		Targets: targets,
+	}
+}
+
+func (uc *updateWebhookEndpointUseCase) Execute(ctx context.Context, req *UpdateWebhookEndpointRequest) (*WebhookEndpointResponse, error) {
+	span, ctx := uc.Tracer.StartSpan(ctx, updateEndpointUseCaseName)
+	defer span.Finish()
+	ctx = ctxkey.SetReadPrimary(ctx) // the endpoint is read to be changed: not from a lagging replica
+
+	log := uc.Log.WithContext(ctx).WithField("method", "Exec")
+	log.WithFields(map[string]any{
+		"business_key": map[string]any{"webhook_endpoint_id": req.ID},
+	}).Info("usecase started")
+
+	e, err := uc.Repo.EndpointQry.FindByID(ctx, req.ID)
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+	if e == nil {
+		logAndTraceError(span, log, entity.ErrWebhookEndpointNotFound, "webhook endpoint not found", false)
+		return nil, entity.ErrWebhookEndpointNotFound
+	}
+
+	before := toEndpointResponse(e)
+
+	// Apply only the fields provided by the client (PATCH semantics).
+	if req.URL != nil {
+		e.URL = *req.URL
+	}
+	if req.Secret != nil {
+		e.Secret = *req.Secret
+	}
+	if req.EventTypes != nil {
+		e.SetEventTypes(req.EventTypes)
+	}
+	if req.Description != nil {
+		e.Description = req.Description
+	}
+	if req.IsActive != nil {
+		e.IsActive = *req.IsActive
+	}
+	updatedAt := time.Now().UnixMilli()
+	e.UpdatedAt = &updatedAt
+
+	if err := uc.Targets.validate(e); err != nil {
+		logAndTraceError(span, log, err, "domain logic validation failed", false)
+		return nil, err
+	}
+
+	err = uc.Runner.Atomic(ctx, func(txCtx context.Context) error {
+		if err := uc.Repo.EndpointCmd.Update(txCtx, e); err != nil {
+			return err
+		}
+		return uc.Audit.Record(txCtx, audit.Change{
+			Action:     auditAction(updateEndpointUseCaseName),
+			EntityType: auditEntityEndpoint,
+			EntityID:   e.ID,
+			Before:     before,
+			After:      toEndpointResponse(e),
+		})
+	})
+	if err != nil {
+		utils.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	log.Info("usecase completed")
+	return toEndpointResponse(e), nil
+}
@@ -0,0 +1,140 @@
+package apperror
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// CoreModule is the module of the errors of the infrastructure (codes.go) in
+// the catalog.
+const CoreModule = "core"
+
+// Def describes an error of a module for the catalog.
+type Def struct {
+	// Err is the package-level error: its code, default message and kind.
+	Err *AppError
+	// Status is its HTTP status, the status of its kind when zero (see
+	// GetHttpStatus).
+	Status int
+	// DocURL documents the error for the clients, e.g. the page of the
+	// problem type. The problem responses use it as their type.
+	DocURL string
+}
+
+// CatalogEntry is an error of the catalog, as exported to the client teams.
+type CatalogEntry struct {
+	Code       string `json:"code"`
+	Module     string `json:"module"`
+	HttpStatus int    `json:"http_status"`
+	GRPCCode   string `json:"grpc_code"`
+	Kind       Kind   `json:"kind"`
+	Retryable  bool   `json:"retryable"`
+	Message    string `json:"message"`
+	DocURL     string `json:"doc_url,omitempty"`
+}
+
+type catalogDef struct {
+	module string
+	Def
+}
+
+var (
+	catalogMu sync.RWMutex
+	catalog   = make(map[string]catalogDef)
+)
+
+// Register adds the errors of module to the catalog, typically from the
+// init() of its entity package, and registers their HTTP status (see
+// RegisterStatus):
+//
+//	apperror.Register("booking",
+//		apperror.Def{Err: ErrBookingNotFound, Status: 404},
+//		apperror.Def{Err: ErrBookingDetailsRequired},
+//	)
+//
+// A code belongs to a single module: registering it from another one panics.
+func Register(module string, defs ...Def) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+
+	for _, d := range defs {
+		if d.Err == nil || d.Err.Code == "" {
+			panic(fmt.Sprintf("apperror: registering an error without code in module %q", module))
+		}
+		if prev, ok := catalog[d.Err.Code]; ok && prev.module != module {
+			panic(fmt.Sprintf("apperror: error code %s of module %q already registered by module %q", d.Err.Code, module, prev.module))
+		}
+		catalog[d.Err.Code] = catalogDef{module: module, Def: d}
+		if d.Status != 0 {
+			RegisterStatus(d.Err.Code, d.Status)
+		}
+	}
+}
+
+// Lookup returns the catalog entry of code, false when no module registered
+// it.
+func Lookup(code string) (CatalogEntry, bool) {
+	catalogMu.RLock()
+	d, ok := catalog[code]
+	catalogMu.RUnlock()
+	if !ok {
+		return CatalogEntry{}, false
+	}
+	return d.entry(), true
+}
+
+// Catalog returns the registered errors, by module then code.
+func Catalog() []CatalogEntry {
+	catalogMu.RLock()
+	entries := make([]CatalogEntry, 0, len(catalog))
+	for _, d := range catalog {
+		entries = append(entries, d.entry())
+	}
+	catalogMu.RUnlock()
+
+	slices.SortFunc(entries, func(a, b CatalogEntry) int {
+		return cmp.Or(cmp.Compare(a.Module, b.Module), cmp.Compare(a.Code, b.Code))
+	})
+	return entries
+}
+
+// entry resolves the statuses of d as its errors get them.
+func (d catalogDef) entry() CatalogEntry {
+	status := d.Err.GetHttpStatus()
+	return CatalogEntry{
+		Code:       d.Err.Code,
+		Module:     d.module,
+		HttpStatus: status,
+		GRPCCode:   d.Err.ToGRPCStatus().Code().String(),
+		Kind:       d.Err.Kind,
+		Retryable:  d.Err.IsRetryable(),
+		Message:    d.Err.Message,
+		DocURL:     d.DocURL,
+	}
+}
+
+func init() {
+	core := []*AppError{
+		ErrCodeDbConnectionFailed, ErrCodeDbTimeout, ErrCodeDbDeadlock, ErrCodeDbConstraint,
+		ErrCodeDbConflict, ErrCodeDbSchemaViolation, ErrCodeInternalError,
+
+		ErrCodeMalformedRequest, ErrCodeInvalidRequest, ErrCodeValidation, ErrCodeUnauthorized,
+		ErrCodeForbidden, ErrCodeNotFound, ErrCodeMethodNotAllowed, ErrCodeNotAcceptable,
+		ErrCodeRequestTimeout, ErrCodeConflict, ErrCodeGone, ErrCodeLengthRequired,
+		ErrCodePreconditionFailed, ErrCodePayloadTooLarge, ErrCodeURITooLong,
+		ErrCodeUnsupportedMediaType, ErrCodeRangeNotSatisfiable, ErrCodeExpectationFailed,
+		ErrCodeTeapot, ErrCodeMisdirectedRequest, ErrCodeUnprocessableEntity, ErrCodeLocked,
+		ErrCodeFailedDependency, ErrCodeTooEarly, ErrCodeUpgradeRequired,
+		ErrCodePreconditionRequired, ErrCodeTooManyRequests, ErrCodeRequestHeaderFieldsTooLarge,
+		ErrCodeUnavailableForLegalReasons, ErrCodeServiceUnavailable,
+		ErrCodeNetworkAuthenticationRequired,
+	}
+	defs := make([]Def, len(core))
+	for i, err := range core {
+		// Their statuses are those of the registry (types.go).
+		defs[i] = Def{Err: err}
+	}
+	Register(CoreModule, defs...)
+}
@@ -0,0 +1,85 @@
+package apperror
+
+import "errors"
+
+// Wrap returns an AppError of code and message wrapping err, nil when err is
+// nil, so that a failure keeps its cause while it crosses the layers:
+//
+//	if err := repo.Save(ctx, booking); err != nil {
+//		return apperror.Wrap(err, CodeBookingNotSaved, "Booking could not be saved")
+//	}
+//
+// The error has the kind of the AppError err wraps (e.g. KindTransient for a
+// database timeout), KindInternal when err wraps none. It captures the stack
+// when enabled (see CaptureStacks) and err carries none yet. err may join
+// several errors (errors.Join): errors.Is and errors.As search all of them.
+//
+// The result is an error rather than an *AppError, so that a nil err is a nil
+// error; use errors.As to add details.
+func Wrap(err error, code, message string) error {
+	if err == nil {
+		return nil
+	}
+	kind := KindInternal
+	var inner *AppError
+	if errors.As(err, &inner) {
+		kind = inner.Kind
+	}
+	appErr := &AppError{Code: code, Message: message, Kind: kind, Err: err}
+	if StackTrace(err) == "" {
+		appErr.stack = captureStack()
+	}
+	return appErr
+}
+
+// RootCause returns the innermost error of the chain of err: the error the
+// others wrap, err itself when it wraps none. For errors joined with
+// errors.Join, the chain follows the first one. It returns nil for a nil err.
+func RootCause(err error) error {
+	root := err
+	walk(err, func(err error) { root = err })
+	return root
+}
+
+// Chain returns the links of the chain of err worth reporting, outermost
+// first: the AppErrors as "CODE: message", then the root cause.
+//
+//	["BOOKING_NOT_SAVED: Booking could not be saved", "DB_TIMEOUT: Database timeout", "context deadline exceeded"]
+func Chain(err error) []string {
+	var links []string
+	walk(err, func(err error) {
+		if appErr, ok := err.(*AppError); ok {
+			links = append(links, appErr.Code+": "+appErr.Message)
+		}
+	})
+	if root := RootCause(err); root != nil {
+		if _, ok := root.(*AppError); !ok {
+			links = append(links, root.Error())
+		}
+	}
+	return links
+}
+
+// LogFields returns the fields logging err: its message ("error"), with the
+// code and kind of its outermost AppError, its chain when it wraps other
+// errors and its stack when captured. It returns nil for a nil err.
+//
+//	log.WithFields(apperror.LogFields(err)).Error("booking not created")
+func LogFields(err error) map[string]any {
+	if err == nil {
+		return nil
+	}
+	fields := map[string]any{"error": err.Error()}
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		fields["error_code"] = appErr.Code
+		fields["error_kind"] = string(appErr.Kind)
+	}
+	if chain := Chain(err); len(chain) > 1 {
+		fields["error_chain"] = chain
+	}
+	if stack := StackTrace(err); stack != "" {
+		fields["error_stack"] = stack
+	}
+	return fields
+}
@@ -7,6 +7,7 @@ const (
 	CodeDbDeadlock         = "DB_DEADLOCK"          // HTTP Status 500
 	CodeDbConstraint       = "DB_CONSTRAINT"        // HTTP Status 500
 	CodeDbConflict         = "DB_CONFLICT"          // HTTP Status 500
+	CodeDbSchemaViolation  = "DB_SCHEMA_VIOLATION"  // HTTP Status 500
 	CodeInternalError      = "INTERNAL_ERROR"       // HTTP Status 500
 )
 
@@ -40,6 +41,7 @@ const (
 	CodeTooManyRequests               = "TOO_MANY_REQUESTS"               // HTTP Status 429
 	CodeRequestHeaderFieldsTooLarge   = "REQUEST_HEADER_FIELDS_TOO_LARGE" // HTTP Status 431
 	CodeUnavailableForLegalReasons    = "UNAVAILABLE_FOR_LEGAL_REASONS"   // HTTP Status 451
+	CodeServiceUnavailable            = "SERVICE_UNAVAILABLE"             // HTTP Status 503
 	CodeNetworkAuthenticationRequired = "NETWORK_AUTHENTICATION_REQUIRED" // HTTP Status 511
 )
 
@@ -49,6 +51,7 @@ var (
 	ErrCodeDbDeadlock         = NewTransient(CodeDbDeadlock, "Database deadlock", nil)
 	ErrCodeDbConstraint       = NewPersistance(CodeDbConstraint, "Database constraint violation", nil)
 	ErrCodeDbConflict         = NewPersistance(CodeDbConflict, "Database conflict", nil)
+	ErrCodeDbSchemaViolation  = NewInternal(CodeDbSchemaViolation, "Database schema ownership violation", nil)
 	ErrCodeInternalError      = NewInternal(CodeInternalError, "Internal error", nil)
 )
 
@@ -82,5 +85,6 @@ var (
 	ErrCodeTooManyRequests               = NewPersistance(CodeTooManyRequests, "Too many requests", nil)
 	ErrCodeRequestHeaderFieldsTooLarge   = NewPersistance(CodeRequestHeaderFieldsTooLarge, "Request header fields too large", nil)
 	ErrCodeUnavailableForLegalReasons    = NewPersistance(CodeUnavailableForLegalReasons, "Unavailable for legal reasons", nil)
+	ErrCodeServiceUnavailable            = NewTransient(CodeServiceUnavailable, "Service unavailable", nil)
 	ErrCodeNetworkAuthenticationRequired = NewPersistance(CodeNetworkAuthenticationRequired, "Network authentication required", nil)
 )
@@ -1,11 +1,13 @@
 package apperror
 
-// New is the generic constructor for AppError.
+// New is the generic constructor for AppError. The stack of the caller is
+// captured when enabled (see CaptureStacks).
 func New(code, message string, kind Kind, err ...error) *AppError {
 	appErr := &AppError{
 		Code:    code,
 		Message: message,
 		Kind:    kind,
+		stack:   captureStack(),
 	}
 	if len(err) > 0 && err[0] != nil {
 		appErr.Err = err[0]
@@ -0,0 +1,236 @@
+package apperror
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the errdetails.ErrorInfo domain of the statuses built from
+// an AppError.
+const ErrorDomain = "voyago"
+
+// statusClientClosedRequest is the de facto HTTP status of a request
+// canceled by its client (nginx, google.rpc.Code).
+const statusClientClosedRequest = 499
+
+// ToGRPCStatus converts the error into a gRPC status, consistently with its
+// HTTP status: the code is derived from GetHttpStatus (see
+// GRPCCodeFromHttpStatus), a retryable internal failure being Unavailable so
+// that the gRPC retry policies act on it. The AppError code is attached as
+// errdetails.ErrorInfo.Reason and the validation details as
+// errdetails.BadRequest field violations.
+func (e *AppError) ToGRPCStatus() *status.Status {
+	code := GRPCCodeFromHttpStatus(e.GetHttpStatus())
+	if e.IsRetryable() && code == codes.Internal {
+		code = codes.Unavailable
+	}
+
+	st := status.New(code, e.Message)
+	withInfo, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: e.Code,
+		Domain: ErrorDomain,
+		Metadata: map[string]string{
+			"is_retryable": strconv.FormatBool(e.IsRetryable()),
+		},
+	})
+	if err != nil {
+		return st
+	}
+
+	if violations := fieldViolations(e.Details); len(violations) > 0 {
+		if withViolations, err := withInfo.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+			return withViolations
+		}
+	}
+	return withInfo
+}
+
+// FromGRPCStatus converts the status returned by a gRPC server into an
+// AppError, nil for an OK status. A status built by ToGRPCStatus, here or by
+// another service, gives back its code, retryability and validation details;
+// another one gets the code and kind of its gRPC code. The HTTP status of the
+// error is the one of the gRPC code, whatever the local registry maps its code
+// to. The status error stays wrapped for status.FromError.
+//
+//	if err := client.Reserve(ctx, req); err != nil {
+//		return apperror.FromGRPCStatus(status.Convert(err))
+//	}
+func FromGRPCStatus(st *status.Status) *AppError {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	e := &AppError{
+		Code:       codeOfGRPCCode(st.Code()),
+		Message:    st.Message(),
+		Kind:       kindOfGRPCCode(st.Code()),
+		Err:        st.Err(),
+		httpStatus: HttpStatusFromGRPCCode(st.Code()),
+		stack:      captureStack(),
+	}
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetReason() != "" {
+				e.Code = d.GetReason()
+			}
+			if retryable, err := strconv.ParseBool(d.GetMetadata()["is_retryable"]); err == nil {
+				e.Kind = retryableKind(retryable, e.Kind)
+			}
+		case *errdetails.BadRequest:
+			details := make([]map[string]any, 0, len(d.GetFieldViolations()))
+			for _, v := range d.GetFieldViolations() {
+				details = append(details, map[string]any{"field": v.GetField(), "message": v.GetDescription()})
+			}
+			e.Details = details
+		}
+	}
+	return e
+}
+
+// GRPCCodeFromHttpStatus maps an HTTP status, e.g. resolved by
+// AppError.GetHttpStatus, to the closest gRPC code, so that both transports
+// share a single registry.
+func GRPCCodeFromHttpStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound, http.StatusGone:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusPreconditionFailed, http.StatusUnprocessableEntity,
+		http.StatusLocked, http.StatusFailedDependency, http.StatusPreconditionRequired:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case statusClientClosedRequest:
+		return codes.Canceled
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	}
+
+	switch {
+	case httpStatus >= 400 && httpStatus < 500:
+		return codes.InvalidArgument
+	case httpStatus >= 500:
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// HttpStatusFromGRPCCode maps a gRPC code to its HTTP status, the reverse of
+// GRPCCodeFromHttpStatus.
+func HttpStatusFromGRPCCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return statusClientClosedRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// codeOfGRPCCode returns the AppError code of a status without ErrorInfo.
+func codeOfGRPCCode(code codes.Code) string {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return CodeInvalidRequest
+	case codes.Unauthenticated:
+		return CodeUnauthorized
+	case codes.PermissionDenied:
+		return CodeForbidden
+	case codes.NotFound:
+		return CodeNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return CodeConflict
+	case codes.FailedPrecondition:
+		return CodePreconditionFailed
+	case codes.ResourceExhausted:
+		return CodeTooManyRequests
+	case codes.Unavailable:
+		return CodeServiceUnavailable
+	}
+	return CodeInternalError
+}
+
+// kindOfGRPCCode returns the kind of a status without ErrorInfo: the codes
+// the gRPC retry guidelines retry are transient.
+func kindOfGRPCCode(code codes.Code) Kind {
+	switch code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted:
+		return KindTransient
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unimplemented:
+		return KindInternal
+	}
+	return KindPersistance
+}
+
+// retryableKind returns the kind of an error whose retryability is known.
+func retryableKind(retryable bool, kind Kind) Kind {
+	switch {
+	case retryable:
+		return KindTransient
+	case kind == KindTransient:
+		return KindInternal
+	}
+	return kind
+}
+
+// fieldViolations converts validator.ToDetails output (a slice of maps with
+// "field" and "message" keys) into BadRequest field violations.
+func fieldViolations(details any) []*errdetails.BadRequest_FieldViolation {
+	var out []*errdetails.BadRequest_FieldViolation
+
+	switch list := details.(type) {
+	case []map[string]any:
+		for _, d := range list {
+			out = append(out, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprint(d["field"]),
+				Description: fmt.Sprint(d["message"]),
+			})
+		}
+	case []map[string]string:
+		for _, d := range list {
+			out = append(out, &errdetails.BadRequest_FieldViolation{
+				Field:       d["field"],
+				Description: d["message"],
+			})
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,40 @@
+package apperror
+
+import (
+	"context"
+	"errors"
+	"voyago/core-api/internal/pkg/i18n"
+)
+
+// Localized returns e with its message in the locale of the request of ctx:
+// the message of key errors.<code> (see package i18n). Only the default
+// message of the error in the catalog (see Register) is translated; a
+// message written for a single failure, e.g. with the limit it exceeded,
+// is kept.
+func (e *AppError) Localized(ctx context.Context) *AppError {
+	entry, ok := Lookup(e.Code)
+	if !ok || entry.Message != e.Message {
+		return e
+	}
+	message := i18n.Translate(ctx, "errors."+e.Code, e.Message, nil)
+	if message == e.Message {
+		return e
+	}
+	c := *e
+	c.Message = message
+	return &c
+}
+
+// Localize returns the AppError of err localized (see Localized), err when
+// it has none or its message is not translated. The transports call it
+// before rendering an error.
+func Localize(ctx context.Context, err error) error {
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		return err
+	}
+	if localized := appErr.Localized(ctx); localized != appErr {
+		return localized
+	}
+	return err
+}
@@ -0,0 +1,117 @@
+package apperror
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// maxStackFrames bounds the stack captured by an AppError.
+const maxStackFrames = 32
+
+// captureStacks is set by CaptureStacks.
+var captureStacks atomic.Bool
+
+// CaptureStacks makes the AppErrors created from now on capture the stack
+// where they are created (log.error_stacks), logged and traced with them (see
+// StackTrace). It costs a runtime.Callers per error: keep it for the
+// environments debugging their failures. The package-level errors (e.g.
+// ErrCodeNotFound), created at init, carry no stack.
+func CaptureStacks(enabled bool) {
+	captureStacks.Store(enabled)
+}
+
+// stack is the program counters of a captured stack.
+type stack []uintptr
+
+// captureStack returns the stack of the caller of the apperror function
+// creating the error, nil when the capture is disabled.
+func captureStack() stack {
+	if !captureStacks.Load() {
+		return nil
+	}
+	pcs := make([]uintptr, maxStackFrames)
+	// Skips runtime.Callers and captureStack; the other frames of the
+	// package are skipped when formatting.
+	n := runtime.Callers(2, pcs)
+	return pcs[:n]
+}
+
+// String formats s in the layout of runtime/debug.Stack, without the frames
+// of the package:
+//
+//	voyago/core-api/internal/modules/booking/usecase.(*createBookingUseCase).Execute
+//		/app/internal/modules/booking/usecase/create_booking.go:120
+func (s stack) String() string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(s)
+	for {
+		f, more := frames.Next()
+		if !inPackage(f.Function) {
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// inPackage reports whether the function belongs to package apperror (not to
+// its tests).
+func inPackage(function string) bool {
+	const pkg = "voyago/core-api/internal/pkg/apperror."
+	rest, ok := strings.CutPrefix(function, pkg)
+	return ok && !strings.HasPrefix(rest, "_test")
+}
+
+// StackTrace returns the stack captured where e was created, empty when none
+// was (see CaptureStacks).
+func (e *AppError) StackTrace() string {
+	if len(e.stack) == 0 {
+		return ""
+	}
+	return e.stack.String()
+}
+
+// StackTrace returns the stack of the innermost AppError of the chain of err
+// (see RootCause) capturing one: the closest to where the failure happened.
+// It is empty when no stack was captured.
+func StackTrace(err error) string {
+	var found stack
+	walk(err, func(err error) {
+		if appErr, ok := err.(*AppError); ok && len(appErr.stack) > 0 {
+			found = appErr.stack
+		}
+	})
+	if found == nil {
+		return ""
+	}
+	return found.String()
+}
+
+// walk calls fn with err, then with the errors it wraps, down to its root
+// cause (see RootCause).
+func walk(err error, fn func(error)) {
+	for err != nil {
+		fn(err)
+		err = unwrapOne(err)
+	}
+}
+
+// unwrapOne returns the error err wraps: the first non-nil one of the errors
+// joined by errors.Join.
+func unwrapOne(err error) error {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return e.Unwrap()
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			if inner != nil {
+				return inner
+			}
+		}
+	}
+	return nil
+}
@@ -36,6 +36,12 @@ type AppError struct {
 	Details any
 	// Err is the original underlying error (useful for stack traces).
 	Err error
+
+	// stack is where the error was created, captured with CaptureStacks.
+	stack stack
+	// httpStatus is the status of an error received from another service
+	// (see FromGRPCStatus).
+	httpStatus int
 }
 
 // Error implements the standard error interface.
@@ -49,52 +55,73 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
-// WithDetail adds a key-value pair to the error's details map.
-// If the current Details is not a map[string]any, it will be initialized as one.
+// Is reports whether target is an AppError of the same code, so that
+// errors.Is matches a package-level error (e.g. ErrCodeNotFound) against the
+// copies its builder methods return.
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code != "" && t.Code == e.Code
+}
+
+// clone returns a copy of e for the builder methods, which never modify e:
+// the package-level errors are shared by every request. The copy of an error
+// without stack captures the stack of the caller (see CaptureStacks).
+func (e *AppError) clone() *AppError {
+	c := *e
+	if c.stack == nil {
+		c.stack = captureStack()
+	}
+	return &c
+}
+
+// WithDetail returns a copy of the error with a key-value pair added to its
+// details map. If the current Details is not a map[string]any, the copy starts
+// a new one.
 func (e *AppError) WithDetail(key string, value any) *AppError {
-	currentDetails, ok := e.Details.(map[string]any)
-	if !ok || currentDetails == nil {
-		currentDetails = make(map[string]any)
+	current, _ := e.Details.(map[string]any)
+	details := make(map[string]any, len(current)+1)
+	for k, v := range current {
+		details[k] = v
 	}
+	details[key] = value
 
-	currentDetails[key] = value
-	e.Details = currentDetails
-	return e
+	c := e.clone()
+	c.Details = details
+	return c
 }
 
-// WithError wraps an existing error into the AppError context.
-// It allows for chaining and provides a way to retain the original
+// WithError returns a copy of the error wrapping err, retaining the original
 // underlying error for logging or debugging purposes.
 func (e *AppError) WithError(err error) *AppError {
-	e.Err = err
-	return e
+	c := e.clone()
+	c.Err = err
+	return c
 }
 
-// AddValidationError appends a structured validation error to the Details field.
-// It treats Details as a slice of field-message pairs. If Details is not already
-// a slice of maps, it initializes a new one.
+// AddValidationError returns a copy of the error with a structured validation
+// error appended to its details. It treats Details as a slice of field-message
+// pairs. If Details is not already a slice of maps, the copy starts a new one.
 func (e *AppError) AddValidationError(field, message string) *AppError {
-	list, ok := e.Details.([]map[string]string)
-	if !ok {
-		list = []map[string]string{}
-	}
-
+	current, _ := e.Details.([]map[string]string)
+	list := make([]map[string]string, len(current), len(current)+1)
+	copy(list, current)
 	list = append(list, map[string]string{
 		"field":   field,
 		"message": message,
 	})
 
-	e.Details = list
-	return e
+	c := e.clone()
+	c.Details = list
+	return c
 }
 
-// AddValidationErrors sets the validation details.
+// AddValidationErrors returns a copy of the error with the validation details.
 // It overwrites existing details to prevent duplicate error entries
 // if validation is triggered multiple times in the same execution flow.
 func (e *AppError) AddValidationErrors(errors []map[string]any) *AppError {
-	// Directly assign instead of appending to avoid duplication
-	e.Details = errors
-	return e
+	c := e.clone()
+	c.Details = errors
+	return c
 }
 
 // IsRetryable is a helper method to check if the error is a Transient failure.
@@ -102,15 +129,23 @@ func (e *AppError) IsRetryable() bool {
 	return e.Kind == KindTransient
 }
 
-// ToMap converts the AppError to a map for logging purposes.
+// ToMap converts the AppError to a map for logging purposes, with the root
+// cause of the error and its stack when captured (see CaptureStacks).
 func (e *AppError) ToMap() map[string]any {
-	return map[string]any{
+	m := map[string]any{
 		"code":         e.Code,
 		"kind":         string(e.Kind),
 		"is_retryable": e.IsRetryable(),
 		"details":      e.Details,
 		"raw_error":    e.Err,
 	}
+	if e.Err != nil {
+		m["root_cause"] = RootCause(e).Error()
+	}
+	if stack := StackTrace(e); stack != "" {
+		m["stack"] = stack
+	}
+	return m
 }
 
 var (
@@ -132,6 +167,7 @@ func init() {
 	statusRegistry[CodeDbDeadlock] = 500
 	statusRegistry[CodeDbConstraint] = 500
 	statusRegistry[CodeDbConflict] = 409
+	statusRegistry[CodeDbSchemaViolation] = 500
 	statusRegistry[CodeInternalError] = 500
 
 	statusRegistry[CodeMalformedRequest] = 400
@@ -163,16 +199,24 @@ func init() {
 	statusRegistry[CodeTooManyRequests] = 429
 	statusRegistry[CodeRequestHeaderFieldsTooLarge] = 431
 	statusRegistry[CodeUnavailableForLegalReasons] = 451
+	statusRegistry[CodeServiceUnavailable] = 503
 	statusRegistry[CodeNetworkAuthenticationRequired] = 511
 }
 
 // GetHttpStatus resolves the appropriate HTTP status code for the error.
-// It first attempts to match the 'Code' against the statusRegistry.
-// If no match is found, it falls back to a status based on the 'Kind':
+// An error received from another service (see FromGRPCStatus) keeps the
+// status it was sent with. Otherwise, it first attempts to match the 'Code'
+// against the statusRegistry. If no match is found, it falls back to a status
+// based on the 'Kind':
 // - KindPersistance -> 400 (Bad Request)
 // - KindTransient -> 503 (Service Unavailable)
 // - KindInternal  -> 500 (Internal Server Error)
 func (e *AppError) GetHttpStatus() int {
+	// 0. Status of the service the error comes from
+	if e.httpStatus != 0 {
+		return e.httpStatus
+	}
+
 	// 1. Check direct code mapping in registry
 	if status, exists := statusRegistry[e.Code]; exists {
 		return status
@@ -0,0 +1,105 @@
+// Package audit records who changed what, and when: every command use case
+// records an Entry with the caller of the request (user, roles, tenant and
+// client app, see ctxkey), the changed entity, its state before and after the
+// change and the changed fields. The entries are stored in the audit_logs
+// table of the module schema and are queried by the admin routes
+// (GET /admin/audit).
+//
+// Example:
+//
+//	err := uc.Audit.Record(txCtx, audit.Change{
+//		Action:     "booking.create",
+//		EntityType: "booking",
+//		EntityID:   e.ID,
+//		After:      toBookingResponse(&e),
+//	})
+package audit
+
+import "context"
+
+// SystemActor is recorded when the context holds neither user nor client
+// app, e.g. for the event handlers, the workers and the unauthenticated
+// requests.
+const SystemActor = "system"
+
+// Change is a state change of an entity. Before is nil for a creation and
+// After for a deletion; both are snapshotted as JSON objects, so pass the
+// response DTOs (or any value with JSON tags) rather than the entities.
+type Change struct {
+	Action     string
+	EntityType string
+	EntityID   string
+	Before     any
+	After      any
+}
+
+// FieldChange is the value of a field before and after a change.
+type FieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// Entry is a recorded change. The sensitive values of the snapshots are
+// masked (see utils.MaskSensitive).
+type Entry struct {
+	ID         string                 `gorm:"column:id;type:uuid;primaryKey" json:"id"`
+	Actor      string                 `gorm:"column:actor;type:varchar(128);not null" json:"actor"`
+	Action     string                 `gorm:"column:action;type:varchar(100);not null" json:"action"`
+	EntityType string                 `gorm:"column:entity_type;type:varchar(100);not null" json:"entity_type"`
+	EntityID   string                 `gorm:"column:entity_id;type:varchar(128);not null" json:"entity_id"`
+	Before     map[string]any         `gorm:"column:before;type:text;serializer:json" json:"before,omitempty"`
+	After      map[string]any         `gorm:"column:after;type:text;serializer:json" json:"after,omitempty"`
+	Diff       map[string]FieldChange `gorm:"column:diff;type:text;serializer:json" json:"diff,omitempty"`
+	RequestID  *string                `gorm:"column:request_id;type:varchar(128)" json:"request_id,omitempty"`
+	TenantID   *string                `gorm:"column:tenant_id;type:varchar(128)" json:"tenant_id,omitempty"`
+	ClientApp  *string                `gorm:"column:client_app;type:varchar(128)" json:"client_app,omitempty"`
+	Roles      []string               `gorm:"column:roles;type:text;serializer:json" json:"roles,omitempty"`
+	CreatedAt  int64                  `gorm:"column:created_at;type:bigint;not null;autoCreateTime:milli" json:"created_at"`
+}
+
+func (Entry) TableName() string {
+	return "audit_logs"
+}
+
+// Filter selects the entries returned by Find; empty fields match every
+// entry.
+type Filter struct {
+	Actor      string
+	TenantID   string
+	Action     string
+	EntityType string
+	EntityID   string
+	// Since and Until bound the creation time, in Unix milliseconds (Until
+	// excluded).
+	Since int64
+	Until int64
+	// Limit is DefaultLimit when not positive, MaxLimit at most.
+	Limit int
+}
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+// Recorder records the changes of the command use cases.
+type Recorder interface {
+	// Record stores the change with the caller and request ID of ctx. Call it
+	// with the transaction context of the change, so that both are committed
+	// together.
+	Record(ctx context.Context, c Change) error
+}
+
+// Reader returns the recorded changes, newest first.
+type Reader interface {
+	Find(ctx context.Context, f Filter) ([]Entry, error)
+}
+
+type noOpRecorder struct{}
+
+// NewNoOpRecorder returns a Recorder discarding the changes.
+func NewNoOpRecorder() Recorder {
+	return noOpRecorder{}
+}
+
+func (noOpRecorder) Record(context.Context, Change) error { return nil }
@@ -0,0 +1,170 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"time"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/pkg/uid"
+	"voyago/core-api/internal/pkg/utils"
+)
+
+// Service records the changes to, and reads them from, the audit_logs table
+// of a module database.
+type Service struct {
+	db database.Database
+}
+
+var (
+	_ Recorder = (*Service)(nil)
+	_ Reader   = (*Service)(nil)
+)
+
+// NewService returns the audit log stored in db.
+func NewService(db database.Database) *Service {
+	return &Service{db: db}
+}
+
+func (s *Service) Record(ctx context.Context, c Change) error {
+	entry, err := NewEntry(ctx, c)
+	if err != nil {
+		return err
+	}
+	return database.MapDBError(s.db.WithContext(ctx).Create(entry).Error)
+}
+
+func (s *Service) Find(ctx context.Context, f Filter) ([]Entry, error) {
+	q := s.db.WithContext(ctx).Model(&Entry{})
+	if f.Actor != "" {
+		q = q.Where("actor = ?", f.Actor)
+	}
+	if f.TenantID != "" {
+		q = q.Where("tenant_id = ?", f.TenantID)
+	}
+	if f.Action != "" {
+		q = q.Where("action = ?", f.Action)
+	}
+	if f.EntityType != "" {
+		q = q.Where("entity_type = ?", f.EntityType)
+	}
+	if f.EntityID != "" {
+		q = q.Where("entity_id = ?", f.EntityID)
+	}
+	if f.Since > 0 {
+		q = q.Where("created_at >= ?", f.Since)
+	}
+	if f.Until > 0 {
+		q = q.Where("created_at < ?", f.Until)
+	}
+
+	var entries []Entry
+	err := q.Order("created_at DESC").Order("id").Limit(f.limit()).Find(&entries).Error
+	if err != nil {
+		return nil, database.MapDBError(err)
+	}
+	return entries, nil
+}
+
+func (f Filter) limit() int {
+	switch {
+	case f.Limit <= 0:
+		return DefaultLimit
+	case f.Limit > MaxLimit:
+		return MaxLimit
+	default:
+		return f.Limit
+	}
+}
+
+// NewEntry builds the entry of c: the caller of ctx, the masked snapshots and
+// the changed fields. The actor is the user of ctx, else its client app, else
+// SystemActor.
+func NewEntry(ctx context.Context, c Change) (*Entry, error) {
+	before, err := snapshot(c.Before)
+	if err != nil {
+		return nil, fmt.Errorf("audit %s: before: %w", c.Action, err)
+	}
+	after, err := snapshot(c.After)
+	if err != nil {
+		return nil, fmt.Errorf("audit %s: after: %w", c.Action, err)
+	}
+
+	actor := ctxkey.GetUserID(ctx)
+	if actor == "" {
+		actor = ctxkey.GetClientApp(ctx)
+	}
+	if actor == "" {
+		actor = SystemActor
+	}
+	entry := &Entry{
+		ID:         uid.NewUUID(),
+		Actor:      actor,
+		Action:     c.Action,
+		EntityType: c.EntityType,
+		EntityID:   c.EntityID,
+		Before:     mask(before),
+		After:      mask(after),
+		Roles:      ctxkey.GetRoles(ctx),
+		CreatedAt:  time.Now().UnixMilli(),
+	}
+	entry.Diff = diff(before, after, entry.Before, entry.After)
+	if id := ctxkey.GetRequestID(ctx); id != "" {
+		entry.RequestID = &id
+	}
+	if id := ctxkey.GetTenantID(ctx); id != "" {
+		entry.TenantID = &id
+	}
+	if app := ctxkey.GetClientApp(ctx); app != "" {
+		entry.ClientApp = &app
+	}
+	return entry, nil
+}
+
+// snapshot returns v as a JSON object, nil when v is nil.
+func snapshot(v any) (map[string]any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("snapshot is not a JSON object: %w", err)
+	}
+	return m, nil
+}
+
+func mask(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	masked, _ := utils.MaskSensitive(m).(map[string]any)
+	return masked
+}
+
+// diff returns the fields whose value differs between before and after,
+// with their masked values: a changed secret is listed without being
+// disclosed.
+func diff(before, after, maskedBefore, maskedAfter map[string]any) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	keys := maps.Clone(before)
+	if keys == nil {
+		keys = make(map[string]any, len(after))
+	}
+	maps.Copy(keys, after)
+	for k := range keys {
+		if !reflect.DeepEqual(before[k], after[k]) {
+			changes[k] = FieldChange{From: maskedBefore[k], To: maskedAfter[k]}
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
@@ -0,0 +1,58 @@
+// Package bind parses request bodies in the formats served by the response
+// package: JSON, XML and MessagePack. Handlers use it instead of
+// c.BodyParser so that every DTO accepts the same formats it can be answered in.
+package bind
+
+import (
+	"encoding/json"
+	"strings"
+	"voyago/core-api/internal/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Body decodes the request body into out, a pointer to a DTO, according to
+// the Content-Type header:
+//
+//   - application/xml, text/xml or "+xml": the XML mirror of the JSON body
+//     (see response.Send), mapped on the `json` tags of out.
+//   - application/msgpack or application/x-msgpack: a MessagePack map with
+//     the JSON field names.
+//   - anything else is handled by c.BodyParser (JSON, forms).
+//
+// The returned error is the raw parsing error: handlers wrap it in
+// apperror.ErrCodeMalformedRequest.
+func Body(c *fiber.Ctx, out any) error {
+	switch mediaType(c) {
+	case fiber.MIMEApplicationXML, fiber.MIMETextXML:
+		return decodeXML(c.Body(), out)
+	case response.MIMEApplicationMsgpack, response.MIMEApplicationXMsgpack:
+		return decodeMsgpack(c.Body(), out)
+	default:
+		if strings.HasSuffix(mediaType(c), "+xml") {
+			return decodeXML(c.Body(), out)
+		}
+		return c.BodyParser(out)
+	}
+}
+
+// mediaType returns the lower-cased Content-Type without its parameters.
+func mediaType(c *fiber.Ctx) string {
+	ctype, _, _ := strings.Cut(string(c.Request().Header.ContentType()), ";")
+	return strings.ToLower(strings.TrimSpace(ctype))
+}
+
+// decodeMsgpack decodes body through its JSON equivalent, so that out is
+// filled by its `json` tags exactly as for a JSON body.
+func decodeMsgpack(body []byte, out any) error {
+	var generic any
+	if err := msgpack.Unmarshal(body, &generic); err != nil {
+		return err
+	}
+	raw, err := json.Marshal(generic)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
@@ -0,0 +1,180 @@
+package bind
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// source is a part of the request bound through a struct tag.
+type source struct {
+	tag    string
+	lookup func(c *fiber.Ctx, name string) []string
+}
+
+// sources are applied in order: route parameters come last so that they
+// always win (e.g., the ":id" of the URL over any other input).
+var sources = []source{
+	{tag: "query", lookup: func(c *fiber.Ctx, name string) []string {
+		var values []string
+		for _, v := range c.Context().QueryArgs().PeekMulti(name) {
+			values = append(values, string(v))
+		}
+		return values
+	}},
+	{tag: "header", lookup: func(c *fiber.Ctx, name string) []string {
+		if v := c.Get(name); v != "" {
+			return []string{v}
+		}
+		return nil
+	}},
+	{tag: "params", lookup: func(c *fiber.Ctx, name string) []string {
+		if v := c.Params(name); v != "" {
+			return []string{v}
+		}
+		return nil
+	}},
+}
+
+// Request binds the whole request into out, a pointer to a DTO:
+//
+//  1. the body, when there is one (see Body);
+//  2. the query string into fields tagged `query:"name"`;
+//  3. headers into fields tagged `header:"X-Name"`;
+//  4. route parameters into fields tagged `params:"name"`;
+//  5. string fields are sanitized (see Sanitize).
+//
+// Only tagged fields are read from the query string, headers and route
+// parameters; a field keeps its body value when its source is absent.
+// Every failure is returned as a MALFORMED_REQUEST error, ready to be
+// returned by the handler.
+func Request(c *fiber.Ctx, out any) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind: %T is not a pointer to a struct", out)
+	}
+
+	if len(c.Body()) > 0 {
+		if err := Body(c, out); err != nil {
+			return malformed(err)
+		}
+	}
+
+	if err := bindFields(c, v.Elem()); err != nil {
+		return err
+	}
+
+	Sanitize(out)
+	return nil
+}
+
+// bindFields fills the tagged fields of v, including the promoted fields of
+// embedded structs.
+func bindFields(c *fiber.Ctx, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if err := bindFields(c, v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		for _, src := range sources {
+			name := f.Tag.Get(src.tag)
+			if name == "" || name == "-" {
+				continue
+			}
+			values := src.lookup(c, name)
+			if len(values) == 0 {
+				continue
+			}
+			if err := setValue(v.Field(i), values); err != nil {
+				return malformed(fmt.Errorf("%s %q: %w", src.tag, name, err)).
+					AddValidationError(name, fmt.Sprintf("invalid %s value", src.tag))
+			}
+		}
+	}
+	return nil
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// setValue converts values to the type of field. Slices take every value,
+// comma-separated values included; other types take the first one.
+func setValue(field reflect.Value, values []string) error {
+	if field.CanAddr() && field.Addr().Type().Implements(textUnmarshalerType) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(values[0]))
+	}
+
+	switch field.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(field.Type().Elem())
+		if err := setValue(elem.Elem(), values); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	case reflect.Slice:
+		var items []string
+		for _, v := range values {
+			items = append(items, strings.Split(v, ",")...)
+		}
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setValue(slice.Index(i), []string{item}); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	raw := strings.TrimSpace(values[0])
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(values[0])
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// malformed wraps err in a new MALFORMED_REQUEST error. The shared
+// apperror.ErrCodeMalformedRequest is not used since WithError mutates it.
+func malformed(err error) *apperror.AppError {
+	return apperror.NewPersistance(apperror.CodeMalformedRequest, apperror.ErrCodeMalformedRequest.Message, err)
+}
@@ -0,0 +1,68 @@
+package bind
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Sanitize normalizes the string fields of out, a pointer to a DTO, in
+// place, including nested structs, slices and pointers:
+//
+//   - every string is trimmed of leading and trailing white space;
+//   - `sanitize:"lower"` / `sanitize:"upper"` also change its case;
+//   - `sanitize:"squash"` also collapses inner white space runs to one space;
+//   - `sanitize:"-"` leaves the field untouched (e.g., secrets, passwords).
+//
+// Rules can be combined: `sanitize:"squash,lower"`.
+func Sanitize(out any) {
+	sanitize(reflect.ValueOf(out), nil)
+}
+
+func sanitize(v reflect.Value, rules []string) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			sanitize(v.Elem(), rules)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			tag := f.Tag.Get("sanitize")
+			if tag == "-" {
+				continue
+			}
+			var fieldRules []string
+			if tag != "" {
+				fieldRules = strings.Split(tag, ",")
+			}
+			sanitize(v.Field(i), fieldRules)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			sanitize(v.Index(i), rules)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(normalize(v.String(), rules))
+		}
+	}
+}
+
+func normalize(s string, rules []string) string {
+	s = strings.TrimSpace(s)
+	for _, rule := range rules {
+		switch strings.TrimSpace(rule) {
+		case "lower":
+			s = strings.ToLower(s)
+		case "upper":
+			s = strings.ToUpper(s)
+		case "squash":
+			s = strings.Join(strings.Fields(s), " ")
+		}
+	}
+	return s
+}
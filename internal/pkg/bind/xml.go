@@ -0,0 +1,218 @@
+package bind
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// xmlNode is a parsed XML element.
+type xmlNode struct {
+	name     string
+	key      string // "key" attribute of <entry> elements
+	text     strings.Builder
+	children []*xmlNode
+}
+
+// decodeXML converts the document to the JSON value expected by out, guided
+// by the type of out (XML does not tell objects, arrays and numbers apart),
+// then decodes it with encoding/json.
+func decodeXML(body []byte, out any) error {
+	root, err := parseXML(body)
+	if err != nil {
+		return err
+	}
+
+	t := reflect.TypeOf(out)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return errors.New("bind: out must be a pointer")
+	}
+
+	raw, err := json.Marshal(root.value(t.Elem()))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, out)
+}
+
+func parseXML(body []byte) (*xmlNode, error) {
+	dec := xml.NewDecoder(bytes.NewReader(body))
+
+	var root *xmlNode
+	var stack []*xmlNode
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name.Local}
+			for _, a := range t.Attr {
+				if a.Name.Local == "key" {
+					n.key = a.Value
+				}
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("bind: empty xml document")
+	}
+	return root, nil
+}
+
+var textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// value returns the JSON value of n for a Go value of type t.
+func (n *xmlNode) value(t reflect.Type) any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshaler) {
+		// e.g., time.Time: decoded from its JSON string.
+		return strings.TrimSpace(n.text.String())
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := jsonFields(t)
+		obj := make(map[string]any, len(n.children))
+		for _, child := range n.children {
+			if ft, ok := fields[child.fieldName()]; ok {
+				obj[child.fieldName()] = child.value(ft)
+			}
+		}
+		return obj
+	case reflect.Map:
+		obj := make(map[string]any, len(n.children))
+		for _, child := range n.children {
+			obj[child.fieldName()] = child.value(t.Elem())
+		}
+		return obj
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is base64 text, as in JSON.
+			return strings.TrimSpace(n.text.String())
+		}
+		list := make([]any, 0, len(n.children))
+		for _, child := range n.children {
+			list = append(list, child.value(t.Elem()))
+		}
+		return list
+	case reflect.Interface:
+		return n.untyped()
+	case reflect.Bool:
+		// Invalid literals are reported by encoding/json.
+		return n.literal(func(s string) any { return json.RawMessage(s) })
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return n.literal(func(s string) any { return json.Number(s) })
+	default:
+		return n.text.String()
+	}
+}
+
+// literal returns the JSON literal of a scalar element, null when empty.
+func (n *xmlNode) literal(fn func(string) any) any {
+	s := strings.TrimSpace(n.text.String())
+	if s == "" {
+		return nil
+	}
+	return fn(s)
+}
+
+// untyped returns the JSON value of n for an interface{} destination: text
+// for leaves, arrays for lists of <item>, objects otherwise.
+func (n *xmlNode) untyped() any {
+	if len(n.children) == 0 {
+		return n.text.String()
+	}
+
+	items := true
+	for _, child := range n.children {
+		items = items && child.name == "item"
+	}
+	if items {
+		list := make([]any, 0, len(n.children))
+		for _, child := range n.children {
+			list = append(list, child.untyped())
+		}
+		return list
+	}
+
+	obj := make(map[string]any, len(n.children))
+	for _, child := range n.children {
+		obj[child.fieldName()] = child.untyped()
+	}
+	return obj
+}
+
+// fieldName is the JSON key of n: the "key" attribute of <entry> elements,
+// the element name otherwise.
+func (n *xmlNode) fieldName() string {
+	if n.name == "entry" && n.key != "" {
+		return n.key
+	}
+	return n.name
+}
+
+// jsonFields maps the JSON names of the fields of t (including promoted
+// fields of embedded structs) to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
@@ -0,0 +1,184 @@
+// Package breaker implements circuit breakers, which stop calling a
+// dependency (database, upstream host) that keeps failing so that the callers
+// fail fast instead of piling up on it.
+//
+// Closed, a breaker lets every call through and counts the consecutive
+// failures; once the threshold is reached it opens and rejects the calls for
+// the open timeout. A single trial call is then let through (half-open): its
+// success closes the circuit, its failure opens it again.
+//
+// Example:
+//
+//	b := breaker.New(5, 30*time.Second, nil)
+//	if !b.Allow() {
+//		return ErrUnavailable
+//	}
+//	err := call()
+//	b.Record(err != nil)
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// State is the state of a circuit, its value is the one recorded in the
+// state gauges.
+type State int
+
+const (
+	Closed   State = 0
+	HalfOpen State = 1
+	Open     State = 2
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case HalfOpen:
+		return "half_open"
+	case Open:
+		return "open"
+	}
+	return "unknown"
+}
+
+// Breaker is a circuit breaker, safe for concurrent use.
+type Breaker struct {
+	threshold   int
+	openTimeout time.Duration
+	onChange    func(State)
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time // zero while closed
+	trial    bool      // a trial call is in flight
+	state    State     // last notified state
+}
+
+// New returns a breaker opening after threshold consecutive failures, for
+// openTimeout. A threshold of 0 or less disables it: every call is allowed.
+// onChange, when set, is called with the new state of the circuit on every
+// change, while the breaker is locked: keep it fast (e.g. record a gauge).
+func New(threshold int, openTimeout time.Duration, onChange func(State)) *Breaker {
+	return &Breaker{threshold: threshold, openTimeout: openTimeout, onChange: onChange}
+}
+
+// Allow reports whether a call may be made. Every allowed call must be
+// followed by Record or Release.
+func (b *Breaker) Allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openedAt.IsZero() {
+		return true
+	}
+	if b.trial || time.Since(b.openedAt) < b.openTimeout {
+		return false
+	}
+	b.trial = true
+	b.notify(HalfOpen)
+	return true
+}
+
+// Record counts the outcome of a call allowed by Allow.
+func (b *Breaker) Record(failed bool) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		b.notify(Closed)
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = time.Now()
+		b.notify(Open)
+	}
+}
+
+// Release gives up a call allowed by Allow without an outcome, e.g. when it
+// was canceled by its caller: it says nothing about the dependency.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+// State returns the state of the circuit. An open circuit whose timeout has
+// passed is half-open: the next call is a trial.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case b.openedAt.IsZero():
+		return Closed
+	case b.trial || time.Since(b.openedAt) >= b.openTimeout:
+		return HalfOpen
+	}
+	return Open
+}
+
+// notify calls onChange when the state changed. The caller holds b.mu.
+func (b *Breaker) notify(s State) {
+	if s == b.state {
+		return
+	}
+	b.state = s
+	if b.onChange != nil {
+		b.onChange(s)
+	}
+}
+
+// Group holds a breaker per key, e.g. per upstream host, created on first
+// use.
+type Group struct {
+	threshold   int
+	openTimeout time.Duration
+	onChange    func(key string, s State)
+
+	mu    sync.Mutex
+	byKey map[string]*Breaker
+}
+
+// NewGroup returns a group of breakers configured as New. onChange, when set,
+// is called with the key of the breaker.
+func NewGroup(threshold int, openTimeout time.Duration, onChange func(key string, s State)) *Group {
+	return &Group{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		onChange:    onChange,
+		byKey:       map[string]*Breaker{},
+	}
+}
+
+// Get returns the breaker of key.
+func (g *Group) Get(key string) *Breaker {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b, ok := g.byKey[key]
+	if !ok {
+		var onChange func(State)
+		if g.onChange != nil {
+			onChange = func(s State) { g.onChange(key, s) }
+		}
+		b = New(g.threshold, g.openTimeout, onChange)
+		g.byKey[key] = b
+	}
+	return b
+}
@@ -0,0 +1,110 @@
+// Package dataloader provides a small, generic request-scoped batching loader.
+// It coalesces the individual Load calls issued during a short window into a
+// single batch call, which removes the N+1 query pattern of nested resolvers
+// (e.g., loading the details of every booking returned by a GraphQL list).
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc fetches every key of a batch at once.
+// Keys without a value must simply be absent from the returned map.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader batches and caches lookups by key.
+// A Loader is meant to live for a single request: results (including errors)
+// are cached for its whole lifetime and are never invalidated.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	cache   map[K]*batch[K, V]
+	current *batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	full    chan struct{}
+	done    chan struct{}
+	results map[K]V
+	err     error
+}
+
+// New creates a Loader.
+//
+// Parameters:
+//   - fetch: The batch function, usually a use case designed for batching.
+//   - wait: How long to collect keys before dispatching a batch (e.g., 2ms).
+//   - maxBatch: Dispatch early once a batch holds this many keys (0 = unlimited).
+func New[K comparable, V any](fetch BatchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		cache:    make(map[K]*batch[K, V]),
+	}
+}
+
+// Load returns the value of key, waiting for the batch it belongs to.
+// found is false when the batch function returned no value for the key.
+//
+// The context of the first caller of a batch is the one passed to the batch function.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (value V, found bool, err error) {
+	l.mu.Lock()
+	b, cached := l.cache[key]
+	if !cached {
+		b = l.current
+		if b == nil {
+			b = &batch[K, V]{
+				full: make(chan struct{}),
+				done: make(chan struct{}),
+			}
+			l.current = b
+			go l.dispatch(ctx, b)
+		}
+
+		b.keys = append(b.keys, key)
+		l.cache[key] = b
+
+		if l.maxBatch > 0 && len(b.keys) >= l.maxBatch {
+			l.current = nil
+			close(b.full)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return value, false, ctx.Err()
+	}
+
+	if b.err != nil {
+		return value, false, b.err
+	}
+	value, found = b.results[key]
+	return value, found, nil
+}
+
+func (l *Loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	timer := time.NewTimer(l.wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		if l.current == b {
+			l.current = nil
+		}
+		l.mu.Unlock()
+	case <-b.full:
+	}
+
+	b.results, b.err = l.fetch(ctx, b.keys)
+	close(b.done)
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+)
+
+// Encoder encodes the events of an application as CloudEvents.
+type Encoder struct {
+	source string
+	tracer tracer.Tracer
+}
+
+// NewEncoder creates the Encoder of the application app (app.name): the
+// source of its events is "/<app>/<module>". The trace context of the
+// caller, injected by trc, is carried in the traceparent extension.
+func NewEncoder(app string, trc tracer.Tracer) *Encoder {
+	return &Encoder{source: "/" + strings.Trim(app, "/"), tracer: trc}
+}
+
+// Envelope returns the envelope of evt.
+func (e *Encoder) Envelope(ctx context.Context, evt eventbus.Event) (Envelope, error) {
+	data, err := json.Marshal(evt.Payload)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("encode %s event: %w", evt.Type, err)
+	}
+	version := max(evt.SchemaVersion, 1)
+
+	env := Envelope{
+		SpecVersion:     SpecVersion,
+		ID:              evt.ID,
+		Source:          e.source + "/" + evt.Source,
+		Type:            evt.Type,
+		Time:            evt.OccurredAt.UTC(),
+		DataContentType: dataContentType,
+		DataSchema:      DataSchema(evt.Type, version),
+		SchemaVersion:   version,
+		Data:            data,
+	}
+	trace := traceContext{}
+	e.tracer.Inject(ctx, trace)
+	env.TraceParent = trace[attrTraceParent]
+	env.TraceState = trace[attrTraceState]
+	return env, nil
+}
+
+// Encode returns the JSON envelope of evt.
+func (e *Encoder) Encode(ctx context.Context, evt eventbus.Event) ([]byte, error) {
+	env, err := e.Envelope(ctx, evt)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(env)
+}
+
+// traceContext collects the W3C headers injected by the tracer; the others
+// (e.g. x-datadog-*) have no CloudEvents attribute.
+type traceContext map[string]string
+
+func (c traceContext) Set(key, value string) {
+	c[strings.ToLower(key)] = value
+}
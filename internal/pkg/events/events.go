@@ -0,0 +1,160 @@
+// Package events encodes the domain events leaving the process (message
+// brokers, webhooks) as CloudEvents 1.0 in the structured JSON mode: the
+// attributes and the data in one JSON document.
+//
+//	{
+//	  "specversion": "1.0",
+//	  "id": "019c3163-0a1b-7c2d-9e3f-4a5b6c7d8e9f",
+//	  "source": "/voyago-core-api/booking",
+//	  "type": "booking.created",
+//	  "time": "2026-02-12T10:00:00Z",
+//	  "datacontenttype": "application/json",
+//	  "dataschema": "urn:voyago:events:booking.created:v1",
+//	  "schemaversion": 1,
+//	  "traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+//	  "data": {"booking_id": "..."}
+//	}
+//
+// The schema version of a type is raised when its data changes in a way its
+// consumers cannot ignore (a field removed, renamed or retyped); adding a
+// field keeps it. Consumers read it from the envelope to handle the versions
+// still in flight.
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+	"voyago/core-api/internal/infrastructure/eventbus"
+)
+
+const (
+	SpecVersion = "1.0"
+	// ContentType is the media type of a structured-mode event, e.g. the
+	// Content-Type of a webhook.
+	ContentType = "application/cloudevents+json"
+
+	dataContentType = "application/json"
+)
+
+// Extension attributes carrying the trace context (distributed tracing
+// extension).
+const (
+	attrTraceParent = "traceparent"
+	attrTraceState  = "tracestate"
+)
+
+// Envelope is a CloudEvents 1.0 event.
+type Envelope struct {
+	SpecVersion string `json:"specversion"`
+	ID          string `json:"id"`
+	// Source is the application and the module that emitted the event,
+	// e.g. "/voyago-core-api/booking".
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	// DataSchema identifies the schema of Data, version included.
+	DataSchema string `json:"dataschema,omitempty"`
+
+	// SchemaVersion (extension) is the version of the schema of Data.
+	SchemaVersion int `json:"schemaversion"`
+	// TraceParent and TraceState (extensions) are the W3C trace context of
+	// the emitter.
+	TraceParent string `json:"traceparent,omitempty"`
+	TraceState  string `json:"tracestate,omitempty"`
+
+	Data json.RawMessage `json:"data"`
+}
+
+// Get returns the trace context attribute key: the envelope is a
+// tracer.Carrier.
+func (e Envelope) Get(key string) string {
+	switch strings.ToLower(key) {
+	case attrTraceParent:
+		return e.TraceParent
+	case attrTraceState:
+		return e.TraceState
+	}
+	return ""
+}
+
+func (e Envelope) Keys() []string {
+	return []string{attrTraceParent, attrTraceState}
+}
+
+// Event returns the event bus event of the envelope, its payload decoded
+// JSON (a map for an object) and its source the module.
+func (e Envelope) Event() (eventbus.Event, error) {
+	var payload any
+	if len(e.Data) > 0 {
+		if err := json.Unmarshal(e.Data, &payload); err != nil {
+			return eventbus.Event{}, fmt.Errorf("decode %s data: %w", e.Type, err)
+		}
+	}
+	return eventbus.Event{
+		ID:            e.ID,
+		Type:          e.Type,
+		Source:        path.Base(e.Source),
+		OccurredAt:    e.Time,
+		SchemaVersion: e.SchemaVersion,
+		Payload:       payload,
+	}, nil
+}
+
+// DataSchema returns the dataschema of version of eventType.
+func DataSchema(eventType string, version int) string {
+	return fmt.Sprintf("urn:voyago:events:%s:v%d", eventType, version)
+}
+
+// Decode decodes a structured-mode event. The bus envelope published before
+// CloudEvents (no specversion) is still accepted, as schema version 1.
+func Decode(data []byte) (Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Envelope{}, fmt.Errorf("decode event: %w", err)
+	}
+	if env.SpecVersion == "" {
+		return decodeLegacy(data)
+	}
+	if major, _, _ := strings.Cut(env.SpecVersion, "."); major != "1" {
+		return Envelope{}, fmt.Errorf("unsupported cloudevents specversion %q", env.SpecVersion)
+	}
+	if env.ID == "" || env.Source == "" || env.Type == "" {
+		return Envelope{}, errors.New("decode event: id, source and type are required")
+	}
+	if env.SchemaVersion == 0 {
+		env.SchemaVersion = 1
+	}
+	return env, nil
+}
+
+// decodeLegacy decodes a JSON eventbus.Event.
+func decodeLegacy(data []byte) (Envelope, error) {
+	var legacy struct {
+		ID         string          `json:"id"`
+		Type       string          `json:"type"`
+		Source     string          `json:"source"`
+		OccurredAt time.Time       `json:"occurred_at"`
+		Data       json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return Envelope{}, fmt.Errorf("decode event: %w", err)
+	}
+	if legacy.ID == "" || legacy.Type == "" {
+		return Envelope{}, errors.New("decode event: not a cloudevent")
+	}
+	return Envelope{
+		SpecVersion:     SpecVersion,
+		ID:              legacy.ID,
+		Source:          legacy.Source,
+		Type:            legacy.Type,
+		Time:            legacy.OccurredAt,
+		DataContentType: dataContentType,
+		SchemaVersion:   1,
+		Data:            legacy.Data,
+	}, nil
+}
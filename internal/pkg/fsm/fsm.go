@@ -0,0 +1,145 @@
+// Package fsm guards the status fields of the entities with finite state
+// machines: a declarative table of the allowed transitions, guards checking
+// the entity before a transition, and listeners told of every transition.
+//
+// A machine is declared once per status field, usually as a package variable
+// of the entity, and configured before it is used; it is then safe for
+// concurrent use.
+//
+//	var statusMachine = fsm.New("booking.status",
+//		func(b *Booking) *BookingStatus { return &b.Status },
+//		fsm.Table[BookingStatus]{
+//			BookingStatusPending:   {BookingStatusConfirmed, BookingStatusCancelled},
+//			BookingStatusConfirmed: {BookingStatusCancelled, BookingStatusCompleted},
+//			BookingStatusCancelled: {},
+//			BookingStatusCompleted: {},
+//		},
+//	).Guard(BookingStatusConfirmed, requirePaid)
+//
+//	err := statusMachine.Fire(booking, BookingStatusConfirmed)
+package fsm
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrTransitionNotAllowed is the cause of a TransitionError for a transition
+// absent from the table.
+var ErrTransitionNotAllowed = errors.New("fsm: transition not allowed")
+
+// Table lists, for each state, the states it may move to. Every state is a
+// key: the final states have no transitions.
+type Table[S comparable] map[S][]S
+
+// Transition is a move from a state to another.
+type Transition[S comparable] struct {
+	From S
+	To   S
+}
+
+// TransitionError is returned when a transition is refused, either absent
+// from the table (ErrTransitionNotAllowed) or by a guard (its error).
+type TransitionError[S comparable] struct {
+	Machine string
+	Transition[S]
+	Err error
+}
+
+func (e *TransitionError[S]) Error() string {
+	return fmt.Sprintf("fsm: %s: %v -> %v: %v", e.Machine, e.From, e.To, e.Err)
+}
+
+func (e *TransitionError[S]) Unwrap() error { return e.Err }
+
+// Guard checks that subject may make transition t, which is in the table.
+type Guard[T any, S comparable] func(subject *T, t Transition[S]) error
+
+// Listener is told of a transition once subject is in its new state.
+type Listener[T any, S comparable] func(subject *T, t Transition[S])
+
+// Machine is the state machine of the status field S of the entities T.
+type Machine[T any, S comparable] struct {
+	name      string
+	state     func(*T) *S
+	table     Table[S]
+	guards    map[S][]Guard[T, S]
+	listeners []Listener[T, S]
+}
+
+// New returns the machine name of the field returned by state, moving along
+// table. It panics when a state of table is not a key of it.
+func New[T any, S comparable](name string, state func(*T) *S, table Table[S]) *Machine[T, S] {
+	for from, tos := range table {
+		for _, to := range tos {
+			if _, ok := table[to]; !ok {
+				panic(fmt.Sprintf("fsm: %s: %v -> %v: %v is not a state of the table", name, from, to, to))
+			}
+		}
+	}
+	return &Machine[T, S]{
+		name:   name,
+		state:  state,
+		table:  table,
+		guards: make(map[S][]Guard[T, S]),
+	}
+}
+
+// Guard adds g to the guards of the transitions to to.
+func (m *Machine[T, S]) Guard(to S, g Guard[T, S]) *Machine[T, S] {
+	m.guards[to] = append(m.guards[to], g)
+	return m
+}
+
+// OnTransition adds l to the listeners of the transitions.
+func (m *Machine[T, S]) OnTransition(l Listener[T, S]) *Machine[T, S] {
+	m.listeners = append(m.listeners, l)
+	return m
+}
+
+// Name returns the name of the machine.
+func (m *Machine[T, S]) Name() string {
+	return m.name
+}
+
+// Allowed reports whether the table allows moving from from to to, ignoring
+// the guards.
+func (m *Machine[T, S]) Allowed(from, to S) bool {
+	return slices.Contains(m.table[from], to)
+}
+
+// Next returns the states from may move to, ignoring the guards.
+func (m *Machine[T, S]) Next(from S) []S {
+	return slices.Clone(m.table[from])
+}
+
+// Can returns nil when subject may move to to, the *TransitionError of Fire
+// otherwise.
+func (m *Machine[T, S]) Can(subject *T, to S) error {
+	t := Transition[S]{From: *m.state(subject), To: to}
+	if !m.Allowed(t.From, t.To) {
+		return &TransitionError[S]{Machine: m.name, Transition: t, Err: ErrTransitionNotAllowed}
+	}
+	for _, g := range m.guards[to] {
+		if err := g(subject, t); err != nil {
+			return &TransitionError[S]{Machine: m.name, Transition: t, Err: err}
+		}
+	}
+	return nil
+}
+
+// Fire moves subject to to, then tells the listeners. A refused transition
+// leaves subject unchanged and returns a *TransitionError.
+func (m *Machine[T, S]) Fire(subject *T, to S) error {
+	if err := m.Can(subject, to); err != nil {
+		return err
+	}
+	state := m.state(subject)
+	t := Transition[S]{From: *state, To: to}
+	*state = to
+	for _, l := range m.listeners {
+		l(subject, t)
+	}
+	return nil
+}
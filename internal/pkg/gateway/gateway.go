@@ -0,0 +1,54 @@
+// Package gateway tells the requests relayed by the API gateway apart from
+// the others. The gateway authenticates the callers and forwards their
+// identity in headers (see middleware.Identity): a client reaching the
+// service without it could claim any user, role or tenant, so the identity
+// headers are only read from the requests of the gateway.
+package gateway
+
+import (
+	"crypto/subtle"
+	"net/netip"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/pkg/ipnet"
+)
+
+// HeaderSecret carries the secret shared with the gateway (gRPC: the same
+// lowercase metadata key).
+const HeaderSecret = "X-Gateway-Secret"
+
+// Trust recognizes the requests of the gateway of security.gateway.
+type Trust struct {
+	secret []byte
+	ips    ipnet.List
+}
+
+// New returns the trust of cfg. It fails on a trusted IP that is neither an
+// IP nor a CIDR.
+func New(cfg config.GatewayConfig) (*Trust, error) {
+	ips, err := ipnet.Parse(cfg.TrustedIPs)
+	if err != nil {
+		return nil, err
+	}
+	t := &Trust{ips: ips}
+	if cfg.Secret != "" {
+		t.secret = []byte(cfg.Secret)
+	}
+	return t, nil
+}
+
+// Trusted reports whether a request comes from the gateway: it carries the
+// shared secret when one is configured, and its peer (the address of the
+// connection, not of a forwarding header) is a trusted IP when some are.
+// Without secret nor trusted IPs, no request is trusted.
+func (t *Trust) Trusted(secret string, peer netip.Addr) bool {
+	if t == nil || (t.secret == nil && len(t.ips) == 0) {
+		return false
+	}
+	if t.secret != nil && subtle.ConstantTimeCompare([]byte(secret), t.secret) != 1 {
+		return false
+	}
+	if len(t.ips) > 0 && !t.ips.Contains(peer) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,224 @@
+// Package i18n translates the messages returned to the clients, the error and
+// validation messages, into the locale of the request.
+//
+// The messages are looked up by key (e.g. "errors.BOOKING_NOT_FOUND",
+// "validation.required") in the catalogs of the locales, loaded from a
+// directory per locale of YAML files (see package locales). The locales the
+// caller accepts (ctxkey.GetLocales, from Accept-Language) are tried by
+// preference, each followed by its language (id-ID, then id), then the
+// default locale. The messages of the code are the English ones
+// (SourceLocale): the message of a key missing from every catalog of the
+// chain.
+//
+//	msg := i18n.Translate(ctx, "errors."+code, e.Message, nil)
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SourceLocale is the locale of the messages of the code.
+const SourceLocale = "en"
+
+// Bundle holds the catalogs of the locales.
+type Bundle struct {
+	// messages are by locale, then key.
+	messages map[string]map[string]string
+}
+
+// Load reads the catalogs of fsys: the YAML files of a directory per locale
+// (e.g. id/booking.yaml), whose nested keys are joined by dots:
+//
+//	errors:
+//	  BOOKING_NOT_FOUND: data pemesanan tidak ditemukan
+func Load(fsys fs.FS) (*Bundle, error) {
+	b := &Bundle{messages: map[string]map[string]string{}}
+	files, err := fs.Glob(fsys, "*/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		raw, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		var tree map[string]any
+		if err := yaml.Unmarshal(raw, &tree); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		locale := normalize(path.Dir(file))
+		if b.messages[locale] == nil {
+			b.messages[locale] = map[string]string{}
+		}
+		if err := flatten(b.messages[locale], "", tree); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return b, nil
+}
+
+func flatten(out map[string]string, prefix string, tree map[string]any) error {
+	for k, v := range tree {
+		key := prefix + k
+		switch v := v.(type) {
+		case string:
+			out[key] = v
+		case map[string]any:
+			if err := flatten(out, key+".", v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("message %q is not a string", key)
+		}
+	}
+	return nil
+}
+
+// Locales returns the locales with a catalog, and SourceLocale.
+func (b *Bundle) Locales() []string {
+	locales := []string{SourceLocale}
+	for l := range b.messages {
+		if l != SourceLocale {
+			locales = append(locales, l)
+		}
+	}
+	slices.Sort(locales[1:])
+	return locales
+}
+
+// Message returns the message of key in the first locale of the chain of
+// locales (see package doc) defining it, with the fallback of the messages
+// of the code when the chain reaches SourceLocale first.
+func (b *Bundle) Message(locales []string, key, fallback string) (string, bool) {
+	for _, l := range chain(locales) {
+		if msg, ok := b.messages[l][key]; ok {
+			return msg, true
+		}
+		if l == SourceLocale {
+			return fallback, false
+		}
+	}
+	return fallback, false
+}
+
+// chain returns the locales followed by their languages, without
+// duplicates.
+func chain(locales []string) []string {
+	out := make([]string, 0, 2*len(locales))
+	for _, l := range locales {
+		l = normalize(l)
+		lang, _, _ := strings.Cut(l, "-")
+		for _, c := range []string{l, lang} {
+			if c != "" && !slices.Contains(out, c) {
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// normalize returns locale in lower case with "-" separators (pt_BR:
+// pt-br), to match the tags of Accept-Language case-insensitively.
+func normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+type translator struct {
+	bundle        *Bundle
+	defaultLocale string
+}
+
+// current is set by Configure; without it, every message is the one of the
+// code.
+var current atomic.Pointer[translator]
+
+// Configure translates the messages with the catalogs of b, defaulting to
+// cfg.DefaultLocale, which must be SourceLocale or have a catalog.
+func Configure(b *Bundle, cfg config.I18nConfig) error {
+	def := normalize(cfg.DefaultLocale)
+	if def == "" {
+		def = SourceLocale
+	}
+	if def != SourceLocale && b.messages[def] == nil {
+		return fmt.Errorf("no messages for the default locale %q, expected one of %s", cfg.DefaultLocale, strings.Join(b.Locales(), ", "))
+	}
+	current.Store(&translator{bundle: b, defaultLocale: def})
+	return nil
+}
+
+// Translate returns the message of key in the locale of the request of ctx,
+// its {name} placeholders replaced by args, or fallback, the message of the
+// code, when no locale of the chain defines it.
+func Translate(ctx context.Context, key, fallback string, args map[string]string) string {
+	t := current.Load()
+	if t == nil {
+		return fallback
+	}
+	locales := append(slices.Clip(ctxkey.GetLocales(ctx)), t.defaultLocale)
+	msg, ok := t.bundle.Message(locales, key, fallback)
+	if !ok {
+		return fallback
+	}
+	return Format(msg, args)
+}
+
+// Format replaces the {name} placeholders of message by args.
+func Format(message string, args map[string]string) string {
+	if len(args) == 0 || !strings.Contains(message, "{") {
+		return message
+	}
+	pairs := make([]string, 0, 2*len(args))
+	for k, v := range args {
+		pairs = append(pairs, "{"+k+"}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+// ParseAcceptLanguage returns the locales of an Accept-Language header by
+// preference (quality), without the wildcard and the refused ones (q=0):
+//
+//	ParseAcceptLanguage("en;q=0.8, id-ID, id;q=0.9") // [id-ID id en]
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+	var ranges []weighted
+	for part := range strings.SplitSeq(header, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.TrimSpace(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			ranges = append(ranges, weighted{locale, q})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	locales := make([]string, len(ranges))
+	for i, r := range ranges {
+		locales[i] = r.locale
+	}
+	return locales
+}
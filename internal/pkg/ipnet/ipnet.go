@@ -0,0 +1,48 @@
+// Package ipnet matches the addresses of the peers against the lists of IPs
+// and CIDRs of the configuration (e.g., the API gateway, the load balancers).
+package ipnet
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// List is a list of networks, a single IP being a network of one address.
+type List []netip.Prefix
+
+// Parse returns the list of values, IPs or CIDRs (e.g., "10.0.0.1",
+// "10.0.0.0/8"). It fails on a value that is neither.
+func Parse(values []string) (List, error) {
+	var list List
+	for _, v := range values {
+		network, err := netip.ParsePrefix(v)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(v)
+			if addrErr != nil {
+				return nil, fmt.Errorf("%q is neither an IP nor a CIDR", v)
+			}
+			network = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		list = append(list, network.Masked())
+	}
+	return list, nil
+}
+
+// Contains reports whether addr belongs to one of the networks. An IPv4
+// address mapped in IPv6 (::ffff:10.0.0.1) is matched as the IPv4 one.
+func (l List) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, network := range l {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsIP is Contains for the textual form of an address, false when ip
+// is not one.
+func (l List) ContainsIP(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && l.Contains(addr)
+}
@@ -0,0 +1,56 @@
+package baserepo
+
+import (
+	"context"
+	"sync"
+)
+
+type afterCommitKey struct{}
+
+// AfterCommit holds the hooks registered with RegisterAfterCommit during a
+// transaction. TransactionManager implementations create one per Atomic
+// call (see WithAfterCommit) and hand it its outcome.
+type AfterCommit struct {
+	mu    sync.Mutex
+	hooks []func(ctx context.Context)
+}
+
+// RegisterAfterCommit schedules fn, a side effect of the transaction of ctx
+// (event publish, cache invalidation, notification), to run once that
+// transaction has been committed. fn never runs when the transaction is
+// rolled back, nor when it is retried: only the hooks of the committed
+// attempt run. The hooks of a nested Atomic call wait for the outer
+// transaction.
+//
+// Outside a transaction there is nothing to wait for: fn runs immediately.
+func RegisterAfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	a, _ := ctx.Value(afterCommitKey{}).(*AfterCommit)
+	if a == nil {
+		fn(ctx)
+		return
+	}
+	a.mu.Lock()
+	a.hooks = append(a.hooks, fn)
+	a.mu.Unlock()
+}
+
+// WithAfterCommit returns the context of a new transaction collecting the
+// hooks registered with RegisterAfterCommit.
+func WithAfterCommit(ctx context.Context) (context.Context, *AfterCommit) {
+	a := &AfterCommit{}
+	return context.WithValue(ctx, afterCommitKey{}, a), a
+}
+
+// Committed runs the hooks, in their registration order, with ctx: the
+// context of the Atomic call. When ctx is itself the context of a
+// transaction, the hooks are handed to it instead.
+func (a *AfterCommit) Committed(ctx context.Context) {
+	a.mu.Lock()
+	hooks := a.hooks
+	a.hooks = nil
+	a.mu.Unlock()
+
+	for _, fn := range hooks {
+		RegisterAfterCommit(ctx, fn)
+	}
+}
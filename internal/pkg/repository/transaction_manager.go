@@ -1,6 +1,33 @@
+// Package baserepo holds the persistence contracts shared by the modules:
+// the transactions of the use cases (TransactionManager) and their
+// after-commit hooks. It has no implementation of its own: the generic
+// persistence helper of the command repositories is
+// database.GormBaseRepository, in the infrastructure layer.
 package baserepo
 
-import "context"
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// TxOptions tunes the transaction begun by AtomicWithOptions. The zero value
+// begins a read-write transaction at the default isolation level of the
+// database.
+type TxOptions struct {
+	// Isolation is the isolation level of the transaction, e.g.
+	// sql.LevelSerializable for a read-modify-write that must not interleave
+	// with a concurrent one.
+	Isolation sql.IsolationLevel
+	// ReadOnly rejects the writes of the transaction.
+	ReadOnly bool
+	// StatementTimeout and LockTimeout bound every statement of the
+	// transaction and every wait for a lock, overriding the timeouts of the
+	// database configuration; zero keeps them. A statement exceeding them
+	// fails with DB_TIMEOUT.
+	StatementTimeout time.Duration
+	LockTimeout      time.Duration
+}
 
 type TransactionManager interface {
 	// Atomic executes the provided function within a database transaction.
@@ -8,5 +35,14 @@ type TransactionManager interface {
 	// multiple tables or requires data consistency MUST use this method
 	// If the function returns an error, the transaction is automatically rolled back.
 	// Otherwise, it is committed.
+	//
+	// Called with the context of a transaction, the function runs in a
+	// savepoint of that transaction: its error only rolls back its own
+	// operations, and the outer function decides whether to give up.
 	Atomic(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// AtomicWithOptions is Atomic with the isolation level and access mode of
+	// the transaction. The options are ignored by a nested call: they are set
+	// when the outer transaction begins.
+	AtomicWithOptions(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error
 }
@@ -0,0 +1,77 @@
+package response
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// localCachePolicy holds the Cache-Control value declared by Cache.
+const localCachePolicy = "cache_policy"
+
+// CachePolicy declares how browsers and shared caches (CDNs) may cache the
+// successful GET responses of a route. The zero value allows public caching
+// but requires revalidation (max-age=0), which pairs with the ETag of OK.
+type CachePolicy struct {
+	// MaxAge is how long a response stays fresh.
+	MaxAge time.Duration
+	// SharedMaxAge overrides MaxAge for shared caches (s-maxage), zero when
+	// they follow MaxAge.
+	SharedMaxAge time.Duration
+	// StaleWhileRevalidate is how long a stale response may still be served
+	// while the cache revalidates it in the background.
+	StaleWhileRevalidate time.Duration
+	// Private restricts caching to the client, for responses depending on the
+	// caller; shared caches never store them.
+	Private bool
+	// NoStore forbids caching at all, the other fields are ignored.
+	NoStore bool
+}
+
+// NoStore is the policy of responses that must never be cached.
+var NoStore = CachePolicy{NoStore: true}
+
+// String returns the Cache-Control value of the policy.
+func (p CachePolicy) String() string {
+	if p.NoStore {
+		return "no-store"
+	}
+
+	directives := []string{"public"}
+	if p.Private {
+		directives[0] = "private"
+	}
+	directives = append(directives, "max-age="+strconv.Itoa(int(p.MaxAge.Seconds())))
+	if p.SharedMaxAge > 0 && !p.Private {
+		directives = append(directives, "s-maxage="+strconv.Itoa(int(p.SharedMaxAge.Seconds())))
+	}
+	if p.StaleWhileRevalidate > 0 {
+		directives = append(directives, "stale-while-revalidate="+strconv.Itoa(int(p.StaleWhileRevalidate.Seconds())))
+	}
+	return strings.Join(directives, ", ")
+}
+
+// Cache declares the cache policy of a route. The builder applies it to the
+// OK responses of GET and HEAD requests (and their 304 Not Modified); errors
+// and other methods are never marked cacheable.
+//
+//	categories.Get("/", response.Cache(response.CachePolicy{
+//		MaxAge:               5 * time.Minute,
+//		StaleWhileRevalidate: time.Minute,
+//	}), r.Handler.ListCategories)
+func Cache(policy CachePolicy) fiber.Handler {
+	value := policy.String()
+	return func(c *fiber.Ctx) error {
+		c.Locals(localCachePolicy, value)
+		return c.Next()
+	}
+}
+
+// applyCachePolicy sets the Cache-Control header declared for the route.
+func applyCachePolicy(c *fiber.Ctx) {
+	if value, ok := c.Locals(localCachePolicy).(string); ok {
+		c.Set(fiber.HeaderCacheControl, value)
+	}
+}
@@ -0,0 +1,66 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// sendValidated sends response like Send, with an ETag validating it. When the
+// If-None-Match header of the request matches the ETag, the body is dropped
+// and 304 Not Modified is answered instead.
+//
+// The ETag is strong when the response is sent byte for byte again for the
+// same data, i.e., without a trace ID. Otherwise only the trace ID differs
+// between two responses carrying the same data: the ETag is weak and computed
+// without it.
+func sendValidated(c *fiber.Ctx, status int, response Http) error {
+	c.Vary(fiber.HeaderAccept)
+
+	raw, contentType, err := encode(c, response)
+	if err != nil {
+		return err
+	}
+
+	var etag string
+	if response.TraceID == "" {
+		etag = `"` + digest(raw) + `"`
+	} else {
+		response.TraceID = ""
+		stable, _, err := encode(c, response)
+		if err != nil {
+			return err
+		}
+		etag = `W/"` + digest(stable) + `"`
+	}
+
+	c.Set(fiber.HeaderETag, etag)
+	if noneMatch := c.Get(fiber.HeaderIfNoneMatch); noneMatch != "" && matchesETag(noneMatch, etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	return c.Status(status).Send(raw)
+}
+
+// digest returns the opaque tag of an encoded body. Formats encode the same
+// data differently, so each format gets its own tag.
+func digest(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+// matchesETag reports whether the If-None-Match header lists etag, using the
+// weak comparison of RFC 9110 (the W/ prefixes are ignored).
+func matchesETag(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -21,7 +21,13 @@ type Http struct {
 	// ErrorCode is a unique application-specific string used for programmatic error handling.
 	ErrorCode string `json:"error_code,omitempty"`
 
-	// IsRetryable hints to the client whether repeating the same request might eventually succeed.
+	// IsRetryable tells the client whether the same request, unchanged, may
+	// succeed later: true for the transient failures (apperror.KindTransient,
+	// e.g., a dependency unavailable or timing out), retried after the delay
+	// of the Retry-After header, with a backoff. It is false for the errors
+	// the request itself causes (validation, not found, conflict) and for the
+	// internal errors. A 429 is not retryable either, the client exceeding its
+	// rate must slow down: its Retry-After is when its quota is restored.
 	IsRetryable bool `json:"is_retryable,omitempty"`
 
 	// Errors contains granular validation details or field-specific error messages.
@@ -39,15 +45,23 @@ type builder struct {
 
 // NewHttp initializes a new HTTP response builder.
 // It captures the context once to avoid redundant passing in subsequent method calls.
+// Responses are written in the format negotiated from the Accept header (see Send).
 func NewHttp(c *fiber.Ctx) *builder {
 	return &builder{ctx: c}
 }
 
 // OK sends a standardized successful response (HTTP 200).
+// GET and HEAD responses carry an ETag computed from their content; a request
+// whose If-None-Match matches it is answered with 304 Not Modified, without
+// body. They also carry the cache policy declared for the route (see Cache).
 func (b *builder) OK(response Http) error {
 	response.Success = true
 	response.TraceID, _ = b.ctx.Locals("trace_id").(string)
-	return b.ctx.Status(fiber.StatusOK).JSON(response)
+	if b.ctx.Method() == fiber.MethodGet || b.ctx.Method() == fiber.MethodHead {
+		applyCachePolicy(b.ctx)
+		return sendValidated(b.ctx, fiber.StatusOK, response)
+	}
+	return Send(b.ctx, fiber.StatusOK, response)
 }
 
 // Created sends a standardized resource creation response (HTTP 201).
@@ -55,7 +69,7 @@ func (b *builder) OK(response Http) error {
 func (b *builder) Created(response Http) error {
 	response.Success = true
 	response.TraceID, _ = b.ctx.Locals("trace_id").(string)
-	return b.ctx.Status(fiber.StatusCreated).JSON(response)
+	return Send(b.ctx, fiber.StatusCreated, response)
 }
 
 // Accepted sends a standardized response for asynchronous processing (HTTP 202).
@@ -63,7 +77,7 @@ func (b *builder) Created(response Http) error {
 func (b *builder) Accepted(response Http) error {
 	response.Success = true
 	response.TraceID, _ = b.ctx.Locals("trace_id").(string)
-	return b.ctx.Status(fiber.StatusAccepted).JSON(response)
+	return Send(b.ctx, fiber.StatusAccepted, response)
 }
 
 // NoContent sends a successful response with no body (HTTP 204).
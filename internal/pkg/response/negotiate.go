@@ -0,0 +1,212 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	// MIMEApplicationMsgpack is the media type of MessagePack bodies.
+	MIMEApplicationMsgpack = "application/msgpack"
+	// MIMEApplicationXMsgpack is the legacy media type of MessagePack bodies.
+	MIMEApplicationXMsgpack = "application/x-msgpack"
+
+	// xmlRoot is the root element of XML bodies.
+	xmlRoot = "response"
+	// xmlItem is the element of each array entry in XML bodies.
+	xmlItem = "item"
+	// xmlEntry replaces object keys that are not valid XML names; the key is
+	// kept in its "key" attribute.
+	xmlEntry = "entry"
+)
+
+// offers are the media types Send can produce, JSON first: it is served when
+// the client sends no Accept header or accepts none of them.
+var offers = []string{
+	fiber.MIMEApplicationJSON,
+	fiber.MIMEApplicationXML,
+	fiber.MIMETextXML,
+	MIMEApplicationMsgpack,
+	MIMEApplicationXMsgpack,
+}
+
+// Send writes body with status in the format negotiated from the Accept
+// header: JSON, XML or MessagePack.
+//
+// XML and MessagePack are transcoded from the JSON encoding of body, so every
+// format carries the same field names (`json` tags) and omits the same empty
+// fields. In XML, the document element is <response>, array entries are <item>
+// elements and keys that are not valid XML names become <entry key="...">.
+func Send(c *fiber.Ctx, status int, body any) error {
+	c.Vary(fiber.HeaderAccept)
+
+	raw, contentType, err := encode(c, body)
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	return c.Status(status).Send(raw)
+}
+
+// encode encodes body in the format negotiated from the Accept header and
+// returns it with its content type.
+func encode(c *fiber.Ctx, body any) ([]byte, string, error) {
+	switch c.Accepts(offers...) {
+	case fiber.MIMEApplicationXML, fiber.MIMETextXML:
+		raw, err := MarshalXML(body)
+		return raw, fiber.MIMEApplicationXMLCharsetUTF8, err
+	case MIMEApplicationMsgpack, MIMEApplicationXMsgpack:
+		raw, err := MarshalMsgpack(body)
+		return raw, MIMEApplicationMsgpack, err
+	default:
+		raw, err := c.App().Config().JSONEncoder(body)
+		return raw, fiber.MIMEApplicationJSON, err
+	}
+}
+
+// MarshalXML encodes v as an XML document mirroring its JSON encoding.
+func MarshalXML(v any) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	enc := xml.NewEncoder(&buf)
+	if err := writeXML(dec, enc, xmlRoot); err != nil {
+		return nil, fmt.Errorf("encode xml: %w", err)
+	}
+	if err := enc.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeXML transcodes the next JSON value of dec as the element name.
+func writeXML(dec *json.Decoder, enc *xml.Encoder, name string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	start := xmlElement(name)
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		for dec.More() {
+			child := xmlItem
+			if t == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				child = key.(string)
+			}
+			if err := writeXML(dec, enc, child); err != nil {
+				return err
+			}
+		}
+		// Closing delimiter.
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	case string:
+		err = enc.EncodeToken(xml.CharData(t))
+	case json.Number:
+		err = enc.EncodeToken(xml.CharData(t.String()))
+	case bool:
+		err = enc.EncodeToken(xml.CharData(strconv.FormatBool(t)))
+	case nil:
+		// null is an empty element.
+	}
+	if err != nil {
+		return err
+	}
+	return enc.EncodeToken(start.End())
+}
+
+func xmlElement(name string) xml.StartElement {
+	if isXMLName(name) {
+		return xml.StartElement{Name: xml.Name{Local: name}}
+	}
+	return xml.StartElement{
+		Name: xml.Name{Local: xmlEntry},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: name}},
+	}
+}
+
+// isXMLName reports whether name can be used as an element name as is.
+func isXMLName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+		case i > 0 && (r == '-' || r == '.' || (r >= '0' && r <= '9')):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalMsgpack encodes v as MessagePack mirroring its JSON encoding.
+func MarshalMsgpack(v any) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	// Deterministic output: JSON objects have no key order once decoded.
+	enc.SetSortMapKeys(true)
+	if err := enc.Encode(numbers(generic)); err != nil {
+		return nil, fmt.Errorf("encode msgpack: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// numbers replaces the json.Number values of a decoded JSON value with
+// integers when they are whole, floats otherwise.
+func numbers(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			t[k] = numbers(e)
+		}
+	case []any:
+		for i, e := range t {
+			t[i] = numbers(e)
+		}
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	}
+	return v
+}
@@ -0,0 +1,41 @@
+package response
+
+// ContentTypeProblem is the media type of Problem responses.
+const ContentTypeProblem = "application/problem+json"
+
+// Problem is the RFC 7807 representation of an error, emitted instead of the
+// Http envelope when the server runs in the "problem" error format.
+// ErrorCode, Errors and IsRetryable are extension members carrying the same
+// information as their envelope counterparts.
+type Problem struct {
+	// Type is a URI identifying the problem type ("about:blank" when the
+	// problem has no documentation beyond its HTTP status).
+	Type string `json:"type"`
+
+	// Title is the short summary of the problem type (the HTTP status text).
+	Title string `json:"title"`
+
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+
+	// Detail is the human-readable explanation of this occurrence.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance identifies this occurrence: the trace ID of the request.
+	Instance string `json:"instance,omitempty"`
+
+	// ErrorCode is the application-specific error code (e.g., "BOOKING_NOT_FOUND").
+	ErrorCode string `json:"error_code,omitempty"`
+
+	// IsRetryable tells the client whether the same request, unchanged, may
+	// succeed later: true for the transient failures (apperror.KindTransient,
+	// e.g., a dependency unavailable or timing out), retried after the delay
+	// of the Retry-After header, with a backoff. It is false for the errors
+	// the request itself causes (validation, not found, conflict) and for the
+	// internal errors. A 429 is not retryable either, the client exceeding its
+	// rate must slow down: its Retry-After is when its quota is restored.
+	IsRetryable bool `json:"is_retryable,omitempty"`
+
+	// Errors contains granular validation details or field-specific error messages.
+	Errors any `json:"errors,omitempty"`
+}
@@ -0,0 +1,96 @@
+// Package retry runs an operation again when it fails with a transient error
+// (see apperror.AppError.IsRetryable), waiting an exponential backoff with
+// jitter between the attempts.
+//
+// Example:
+//
+//	exec := retry.Executor{MaxAttempts: 3, BaseBackoff: 50 * time.Millisecond}
+//	err := exec.Do(ctx, func(ctx context.Context) error {
+//		return client.Publish(ctx, msg)
+//	})
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+	"voyago/core-api/internal/pkg/apperror"
+)
+
+const (
+	DefaultBaseBackoff = 100 * time.Millisecond
+	DefaultMaxBackoff  = 2 * time.Second
+)
+
+// Executor runs operations with retries. The zero value runs them once.
+type Executor struct {
+	// MaxAttempts is the number of attempts, including the first; 0 or 1
+	// disables retries.
+	MaxAttempts int
+	// BaseBackoff is the wait before the second attempt (default
+	// DefaultBaseBackoff), doubled on every attempt up to MaxBackoff (default
+	// DefaultMaxBackoff).
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Retryable reports whether a failed attempt may be retried, IsRetryable
+	// when nil.
+	Retryable func(err error) bool
+	// OnRetry, when set, is called with the failed attempt (starting at 1)
+	// before waiting for the next one, e.g. to record a metric.
+	OnRetry func(ctx context.Context, attempt int, err error, wait time.Duration)
+}
+
+// Do calls fn until it succeeds, fails with an error that is not retryable,
+// or MaxAttempts is reached, and returns its last error. It stops waiting as
+// soon as ctx is done, returning the error of the last attempt.
+func (e Executor) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	retryable := e.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= e.MaxAttempts || ctx.Err() != nil || !retryable(err) {
+			return err
+		}
+
+		wait := e.backoff(attempt)
+		if e.OnRetry != nil {
+			e.OnRetry(ctx, attempt, err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the wait after the failed attempt.
+func (e Executor) backoff(attempt int) time.Duration {
+	base, maxWait := e.BaseBackoff, e.MaxBackoff
+	if base <= 0 {
+		base = DefaultBaseBackoff
+	}
+	if maxWait <= 0 {
+		maxWait = DefaultMaxBackoff
+	}
+
+	wait := base << (attempt - 1)
+	if wait <= 0 || wait > maxWait {
+		wait = maxWait
+	}
+	// Equal jitter: spread the retries of concurrent callers.
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// IsRetryable reports whether err is a transient AppError.
+func IsRetryable(err error) bool {
+	var appErr *apperror.AppError
+	return errors.As(err, &appErr) && appErr.IsRetryable()
+}
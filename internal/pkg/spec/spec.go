@@ -0,0 +1,135 @@
+// Package spec describes the filters, order and page of a list query
+// independently of the database, so that use cases build them and query
+// repositories translate them (see database.ApplySpec) instead of
+// hand-rolling filter code.
+//
+// A specification names fields, never columns: the repository maps the
+// fields it supports to its columns, and the values are always bound as
+// query parameters.
+//
+// Example:
+//
+//	s := spec.New(
+//		spec.Where("user_id", spec.Eq, userID),
+//		spec.In("status", "PENDING", "CONFIRMED"),
+//		spec.Between("created_at", from, to),
+//		spec.OrderBy("created_at", spec.Desc),
+//		spec.Limit(20),
+//	)
+package spec
+
+import "slices"
+
+// Operator compares a field to a value.
+type Operator string
+
+const (
+	Eq        Operator = "="
+	Ne        Operator = "<>"
+	Lt        Operator = "<"
+	Lte       Operator = "<="
+	Gt        Operator = ">"
+	Gte       Operator = ">="
+	OpIn      Operator = "IN"      // see In
+	OpBetween Operator = "BETWEEN" // see Between
+)
+
+// Direction is the direction of an order.
+type Direction bool
+
+const (
+	Asc  Direction = false
+	Desc Direction = true
+)
+
+// Filter restricts the results to the rows whose Field compares to Values
+// with Op: a single value for a comparison, the candidates of OpIn, the
+// bounds of Between.
+type Filter struct {
+	Field  string
+	Op     Operator
+	Values []any
+}
+
+// Order sorts the results by Field.
+type Order struct {
+	Field     string
+	Direction Direction
+}
+
+// Spec is a list query: its filters are combined with AND. The zero value
+// matches every row, in the order of the database.
+type Spec struct {
+	Filters []Filter
+	Orders  []Order
+	// Limit is the maximum number of results, 0 for no limit.
+	Limit int
+	// Offset is the number of results skipped.
+	Offset int
+}
+
+// Option adds a filter, an order or a page to a Spec.
+type Option func(*Spec)
+
+// New returns the Spec of opts.
+func New(opts ...Option) Spec {
+	return Spec{}.With(opts...)
+}
+
+// With returns a copy of s extended with opts, s is left unchanged: a base
+// Spec can be shared and extended per request.
+func (s Spec) With(opts ...Option) Spec {
+	s.Filters = slices.Clip(s.Filters)
+	s.Orders = slices.Clip(s.Orders)
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// Where keeps the rows whose field compares to value with op. A nil value
+// compared with Eq (Ne) keeps the rows whose field is (is not) NULL.
+func Where(field string, op Operator, value any) Option {
+	return func(s *Spec) {
+		s.Filters = append(s.Filters, Filter{Field: field, Op: op, Values: []any{value}})
+	}
+}
+
+// In keeps the rows whose field is one of values; none when values is empty.
+func In[T any](field string, values ...T) Option {
+	vals := make([]any, len(values))
+	for i, v := range values {
+		vals[i] = v
+	}
+	return func(s *Spec) {
+		s.Filters = append(s.Filters, Filter{Field: field, Op: OpIn, Values: vals})
+	}
+}
+
+// Between keeps the rows whose field is between from and to, both included.
+func Between(field string, from, to any) Option {
+	return func(s *Spec) {
+		s.Filters = append(s.Filters, Filter{Field: field, Op: OpBetween, Values: []any{from, to}})
+	}
+}
+
+// OrderBy sorts the results by field, after the orders already added.
+func OrderBy(field string, dir Direction) Option {
+	return func(s *Spec) {
+		s.Orders = append(s.Orders, Order{Field: field, Direction: dir})
+	}
+}
+
+// Limit returns at most n results.
+func Limit(n int) Option {
+	return func(s *Spec) {
+		s.Limit = n
+	}
+}
+
+// Offset skips the first n results.
+func Offset(n int) Option {
+	return func(s *Spec) {
+		s.Offset = n
+	}
+}
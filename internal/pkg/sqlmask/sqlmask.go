@@ -0,0 +1,199 @@
+// Package sqlmask obfuscates the literals of SQL statements before they are
+// attached to spans or logged, so that the values bound or inlined in a query
+// (emails, payment references, amounts) never leave the process:
+//
+//	sqlmask.Obfuscate("SELECT * FROM bookings WHERE user_id = 'u-42' LIMIT 10", sqlmask.DialectPostgres)
+//	// SELECT * FROM bookings WHERE user_id = ? LIMIT ?
+//
+// The structure of the statement is kept: keywords, identifiers (quoted or
+// not), operators and the $1 / ? placeholders are left as is.
+package sqlmask
+
+import "strings"
+
+// Placeholder replaces every literal.
+const Placeholder = "?"
+
+// Dialects of Obfuscate, named after the GORM dialectors (gorm.Dialector.Name).
+const (
+	DialectPostgres = "postgres"
+	DialectMySQL    = "mysql"
+	DialectSQLite   = "sqlite"
+)
+
+// Obfuscate replaces the string literals ('...', E'...', X'...', B'...',
+// N'...', $$...$$ and $tag$...$tag$) and the numeric literals of query by
+// Placeholder, and removes the comments. An unterminated literal or comment
+// is replaced up to the end of the query.
+//
+// "..." quotes an identifier in PostgreSQL only: MySQL reads it as a string
+// and SQLite's Explain inlines the strings with it, so it is a literal in
+// every other dialect, unknown ones included. Backquoted identifiers (MySQL,
+// SQLite) are kept.
+func Obfuscate(query, dialect string) string {
+	quotedIdents := dialect == DialectPostgres
+	var b strings.Builder
+	b.Grow(len(query))
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'':
+			i = skipQuoted(query, i)
+			b.WriteString(Placeholder)
+
+		case isStringPrefix(c) && i+1 < len(query) && query[i+1] == '\'' && !isIdentChar(prev(query, i)):
+			i = skipQuoted(query, i+1)
+			b.WriteString(Placeholder)
+
+		case c == '"' && !quotedIdents:
+			i = skipQuoted(query, i)
+			b.WriteString(Placeholder)
+
+		case c == '"' || c == '`':
+			// Quoted identifier, kept.
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end+2])
+			i += end + 2
+
+		case c == '$':
+			if tag, ok := dollarTag(query, i); ok {
+				end := strings.Index(query[i+len(tag):], tag)
+				if end < 0 {
+					i = len(query)
+				} else {
+					i += len(tag) + end + len(tag)
+				}
+				b.WriteString(Placeholder)
+				continue
+			}
+			// Placeholder ($1), kept.
+			j := i + 1
+			for j < len(query) && isDigit(query[j]) {
+				j++
+			}
+			b.WriteString(query[i:j])
+			i = j
+
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return strings.TrimRight(b.String(), " ")
+			}
+			i += end
+
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return strings.TrimRight(b.String(), " ")
+			}
+			b.WriteByte(' ')
+			i += end + 4
+
+		case isDigit(c) && !isIdentChar(prev(query, i)):
+			i = skipNumber(query, i)
+			b.WriteString(Placeholder)
+
+		case c == '.' && i+1 < len(query) && isDigit(query[i+1]) && !isIdentChar(prev(query, i)):
+			i = skipNumber(query, i)
+			b.WriteString(Placeholder)
+
+		default:
+			// Identifiers are copied whole, so that their digits are kept.
+			j := i + 1
+			if isIdentChar(c) {
+				for j < len(query) && isIdentChar(query[j]) {
+					j++
+				}
+			}
+			b.WriteString(query[i:j])
+			i = j
+		}
+	}
+	return b.String()
+}
+
+// skipQuoted returns the index following the literal quoted at i (by ' or
+// "), where a doubled quote and a backslash (in E'...' and MySQL strings)
+// escape a quote.
+func skipQuoted(query string, i int) int {
+	quote := query[i]
+	for j := i + 1; j < len(query); j++ {
+		switch query[j] {
+		case '\\':
+			j++
+		case quote:
+			if j+1 < len(query) && query[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return len(query)
+}
+
+// dollarTag returns the $tag$ opening a dollar-quoted string at i.
+func dollarTag(query string, i int) (string, bool) {
+	for j := i + 1; j < len(query); j++ {
+		c := query[j]
+		if c == '$' {
+			return query[i : j+1], true
+		}
+		if !isIdentChar(c) || (j == i+1 && isDigit(c)) {
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// skipNumber returns the index following the number at i: digits, decimal
+// point, exponent and hexadecimal digits (0x...).
+func skipNumber(query string, i int) int {
+	j := i
+	for j < len(query) {
+		c := query[j]
+		switch {
+		case isDigit(c) || c == '.' || c == '_':
+		case (c == 'e' || c == 'E') && j+1 < len(query) && (isDigit(query[j+1]) || query[j+1] == '-' || query[j+1] == '+'):
+			j++
+		case (c == 'x' || c == 'X') && j == i+1 && query[i] == '0':
+		case isHexLetter(c) && j > i+1 && (query[i+1] == 'x' || query[i+1] == 'X'):
+		default:
+			return j
+		}
+		j++
+	}
+	return j
+}
+
+func prev(query string, i int) byte {
+	if i == 0 {
+		return ' '
+	}
+	return query[i-1]
+}
+
+func isStringPrefix(c byte) bool {
+	switch c {
+	case 'e', 'E', 'x', 'X', 'b', 'B', 'n', 'N':
+		return true
+	}
+	return false
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexLetter(c byte) bool {
+	return (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
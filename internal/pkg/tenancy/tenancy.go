@@ -0,0 +1,215 @@
+// Package tenancy resolves the tenant a request acts on and the configuration
+// of the tenants.
+//
+// The HTTP middleware (middleware.Tenant) and the gRPC interceptor
+// (interceptor.Tenant) run the configured resolvers and store the tenant in
+// the context (ctxkey.SetTenantID), from where:
+//   - the databases scope the rows of the tenant-scoped tables to it (see
+//     database.UseTenantScope);
+//   - Config returns the configuration with the overrides of the tenant.
+//
+// Example:
+//
+//	cfg := tenancy.Config(ctx, h.Config)
+//	maxAttempts := cfg.Webhook.Retry.MaxAttempts
+package tenancy
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"runtime"
+	"strings"
+	"sync"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/apperror"
+	"weak"
+)
+
+const (
+	DefaultHeader = "X-Tenant-ID"
+	DefaultClaim  = "tenant_id"
+)
+
+// ErrTenantRequired is returned for the requests whose tenant is not
+// resolved while TenancyConfig.Required is set.
+var ErrTenantRequired = apperror.NewPersistance(apperror.CodeInvalidRequest, "missing or invalid tenant")
+
+// Request is the part of an incoming request read by the resolvers.
+type Request interface {
+	// Header returns the value of a header (gRPC: metadata key), empty when
+	// absent.
+	Header(name string) string
+	// Host returns the host the request was sent to, possibly with a port.
+	Host() string
+	// Trusted reports whether the request comes from the API gateway (see
+	// gateway.Trust): the headers of the other requests are set by the client.
+	Trusted() bool
+}
+
+// Resolver returns the tenant of a request, empty when it cannot tell. The
+// returned tenant is a valid identifier (see ctxkey.IsValidIdentifier).
+type Resolver func(r Request) string
+
+// NewResolver returns the chain of the resolvers of cfg: the tenant is the
+// first one found.
+func NewResolver(cfg config.TenancyConfig) (Resolver, error) {
+	names := cfg.Resolvers
+	if len(names) == 0 {
+		names = []string{config.TenantResolverHeader}
+	}
+
+	var chain []Resolver
+	for _, name := range names {
+		switch name {
+		case config.TenantResolverHeader:
+			chain = append(chain, HeaderResolver(cmp.Or(cfg.Header, DefaultHeader)))
+		case config.TenantResolverSubdomain:
+			if cfg.BaseDomain == "" {
+				return nil, fmt.Errorf("tenancy: the subdomain resolver requires base_domain")
+			}
+			chain = append(chain, SubdomainResolver(cfg.BaseDomain))
+		case config.TenantResolverClaim:
+			chain = append(chain, ClaimResolver(cmp.Or(cfg.Claim, DefaultClaim)))
+		default:
+			return nil, fmt.Errorf("tenancy: unknown resolver %q", name)
+		}
+	}
+
+	return func(r Request) string {
+		for _, resolve := range chain {
+			if tenant := resolve(r); tenant != "" {
+				return tenant
+			}
+		}
+		return ""
+	}, nil
+}
+
+// HeaderResolver reads the tenant from a header, set by the API gateway: the
+// requests not trusted to come from it have no tenant.
+func HeaderResolver(name string) Resolver {
+	return func(r Request) string {
+		if !r.Trusted() {
+			return ""
+		}
+		return valid(r.Header(name))
+	}
+}
+
+// SubdomainResolver reads the tenant from the subdomain of baseDomain the
+// request was sent to: acme.voyago.com is acme for "voyago.com". baseDomain
+// itself and the nested subdomains (a.b.voyago.com) have no tenant.
+func SubdomainResolver(baseDomain string) Resolver {
+	suffix := "." + strings.ToLower(strings.Trim(baseDomain, "."))
+	return func(r Request) string {
+		host := r.Host()
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		sub, ok := strings.CutSuffix(strings.ToLower(host), suffix)
+		if !ok || strings.Contains(sub, ".") {
+			return ""
+		}
+		return valid(sub)
+	}
+}
+
+// ClaimResolver reads the tenant from a string claim of the bearer JWT of
+// the Authorization header. The token is not verified here: like the
+// identity headers, it is only read from the requests of the API gateway,
+// which verified it.
+func ClaimResolver(claim string) Resolver {
+	return func(r Request) string {
+		if !r.Trusted() {
+			return ""
+		}
+		token, ok := strings.CutPrefix(r.Header("Authorization"), "Bearer ")
+		if !ok {
+			return ""
+		}
+		parts := strings.Split(token, ".")
+		if len(parts) != 3 {
+			return ""
+		}
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil {
+			return ""
+		}
+		var claims map[string]any
+		if err := json.Unmarshal(payload, &claims); err != nil {
+			return ""
+		}
+		tenant, _ := claims[claim].(string)
+		return valid(tenant)
+	}
+}
+
+func valid(tenant string) string {
+	tenant = strings.TrimSpace(tenant)
+	if !ctxkey.IsValidIdentifier(tenant) {
+		return ""
+	}
+	return tenant
+}
+
+// configs caches the configurations with overrides of a base configuration,
+// by tenant (a *sync.Map), keyed by a weak pointer to the base configuration:
+// the cache does not keep alive the configurations replaced by a reload or a
+// remote refresh, and their entry is deleted once they are collected.
+var configs sync.Map
+
+// tenantConfigs returns the cache of the configurations with overrides of
+// base, by tenant.
+func tenantConfigs(base *config.Config) *sync.Map {
+	key := weak.Make(base)
+	if c, ok := configs.Load(key); ok {
+		return c.(*sync.Map)
+	}
+	c, loaded := configs.LoadOrStore(key, &sync.Map{})
+	if !loaded {
+		runtime.AddCleanup(base, func(key weak.Pointer[config.Config]) { configs.Delete(key) }, key)
+	}
+	return c.(*sync.Map)
+}
+
+// Config returns cfg with the overrides of the tenant of ctx applied (see
+// TenancyConfig.Overrides), cfg itself when the tenant has none. The
+// overrides are checked at startup by ValidateOverrides; an override that
+// cannot be applied is ignored.
+func Config(ctx context.Context, cfg *config.Config) *config.Config {
+	tenant := strings.ToLower(ctxkey.GetTenantID(ctx))
+	if cfg == nil || tenant == "" {
+		return cfg
+	}
+	overrides, ok := cfg.Tenancy.Overrides[tenant]
+	if !ok {
+		return cfg
+	}
+
+	cache := tenantConfigs(cfg)
+	if c, ok := cache.Load(tenant); ok {
+		return c.(*config.Config)
+	}
+	c, err := cfg.WithOverrides(overrides)
+	if err != nil {
+		return cfg
+	}
+	actual, _ := cache.LoadOrStore(tenant, c)
+	return actual.(*config.Config)
+}
+
+// ValidateOverrides returns an error when the overrides of a tenant cannot be
+// applied to cfg, e.g. a value of the wrong type.
+func ValidateOverrides(cfg *config.Config) error {
+	for tenant, overrides := range cfg.Tenancy.Overrides {
+		if _, err := cfg.WithOverrides(overrides); err != nil {
+			return fmt.Errorf("tenancy: overrides of %q: %w", tenant, err)
+		}
+	}
+	return nil
+}
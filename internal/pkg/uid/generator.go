@@ -0,0 +1,54 @@
+package uid
+
+import (
+	"fmt"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// Names of the generators, as configured by ids.generator.
+const (
+	GeneratorUUIDv7 = "uuidv7"
+	GeneratorULID   = "ulid"
+)
+
+// Generator generates the primary keys of the entities. It is injected into
+// the use cases creating entities, so that each module picks the format of
+// its keys (see NewGenerator) and the tests pick predictable ones.
+type Generator interface {
+	NewID() string
+}
+
+// GeneratorFunc adapts a function to Generator.
+type GeneratorFunc func() string
+
+func (f GeneratorFunc) NewID() string { return f() }
+
+var (
+	// UUIDv7 generates time-ordered UUIDs, see NewUUID. They fit the uuid
+	// columns.
+	UUIDv7 Generator = GeneratorFunc(NewUUID)
+	// ULID generates ULIDs, see NewULID. They need text columns (26
+	// characters) and IDs validated as such rather than as UUIDs.
+	ULID Generator = GeneratorFunc(NewULID)
+)
+
+// NewULID generates a ULID: 26 Crockford base32 characters, a millisecond
+// timestamp followed by random bits. The ULIDs generated by the process
+// within the same millisecond are monotonic, so they sort in generation order
+// as strings, like their bytes.
+func NewULID() string {
+	return ulid.Make().String()
+}
+
+// NewGenerator returns the generator named name: GeneratorUUIDv7 (the
+// default when name is empty) or GeneratorULID.
+func NewGenerator(name string) (Generator, error) {
+	switch name {
+	case GeneratorUUIDv7, "":
+		return UUIDv7, nil
+	case GeneratorULID:
+		return ULID, nil
+	}
+	return nil, fmt.Errorf("unknown id generator %q", name)
+}
@@ -2,7 +2,11 @@
 // It leverages UUID v7 for time-ordered sorting and falls back to v4 if necessary.
 package uid
 
-import "github.com/google/uuid"
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
 
 // NewUUID generates a unique identifier using the UUID v7 standard.
 //
@@ -27,3 +31,31 @@ func NewUUID() string {
 func NewEventID() string {
 	return NewUUID()
 }
+
+// maxRequestIDLength caps the size of caller-provided request IDs.
+const maxRequestIDLength = 128
+
+// NewRequestID generates the correlation identifier of a request that came
+// without a valid one.
+func NewRequestID() string {
+	return NewUUID()
+}
+
+// IsValidRequestID reports whether a caller-provided request ID may be used as
+// is: 1 to 128 characters among letters, digits and "-_.:+/=" (UUIDs, hex and
+// base64 IDs of gateways). Anything else, e.g. spaces or control characters
+// that could forge log lines, is rejected.
+func IsValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		switch c := id[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.IndexByte("-_.:+/=", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -6,21 +6,167 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"regexp"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"voyago/core-api/internal/infrastructure/config"
 )
 
 const (
-	// MaxFieldSize defines the maximum allowed size (in bytes) for a string field in logs.
-	// If exceeded, the value is replaced with a warning message to prevent log bloat.
-	MaxFieldSize = 2048
-	// maxDepth limits recursion to prevent stack overflow on deeply nested or circular objects.
-	maxDepth = 3
+	// DefaultMaxFieldSize defines the maximum allowed size (in bytes) for a string field in logs
+	// when none is configured. If exceeded, the value is replaced with a warning message to
+	// prevent log bloat.
+	DefaultMaxFieldSize = 2048
+	// DefaultMaxDepth limits recursion to prevent stack overflow on deeply nested or circular
+	// objects when no depth is configured.
+	DefaultMaxDepth = 3
+	// Redacted replaces the sensitive values.
+	Redacted = "******** [REDACTED]"
+	// redactedMatch replaces the matches of the sensitive patterns.
+	redactedMatch = "[REDACTED]"
 )
 
-// sensitiveKeys defines a list of keywords identified as confidential.
-// Any field containing these keywords will have its value redacted.
-var sensitiveKeys = []string{"password", "token", "secret", "otp", "credential", "authorization"}
+// defaultSensitiveKeys defines the keywords identified as confidential when none are
+// configured. Any field containing these keywords will have its value redacted.
+var defaultSensitiveKeys = []string{"password", "token", "secret", "otp", "credential", "authorization", "sdk_key", "dsn"}
+
+// maskingRules are the rules in use, see ConfigureMasking.
+type maskingRules struct {
+	sensitiveKeys []string
+	// strategies are sorted by sortStrategies.
+	strategies     []keyStrategy
+	patterns       []*regexp.Regexp
+	maxFieldSize   int
+	maxDepth       int
+	hashSalt       string
+	truncateLength int
+}
+
+var (
+	// rulesMu serializes the changes of rules; the readers load it lock-free.
+	rulesMu sync.Mutex
+	rules   atomic.Pointer[maskingRules]
+	// configured are the rules of ConfigureMasking, registered the keys of
+	// RegisterSensitiveKeys and registeredStrategies those of
+	// RegisterMaskingStrategy; rules combines them.
+	configured           = defaultRules()
+	registered           []string
+	registeredStrategies []keyStrategy
+)
+
+func init() {
+	rules.Store(&configured)
+}
+
+func defaultRules() maskingRules {
+	return maskingRules{
+		sensitiveKeys:  defaultSensitiveKeys,
+		maxFieldSize:   DefaultMaxFieldSize,
+		maxDepth:       DefaultMaxDepth,
+		truncateLength: DefaultTruncateLength,
+	}
+}
+
+// ConfigureMasking replaces the masking rules by those of cfg (log.masking):
+// the sensitive keywords, the per key strategies (see MaskStrategy), the
+// regular expressions whose matches are redacted from the strings (e.g.,
+// card numbers, emails), the maximum field size and the recursion depth.
+// Empty settings keep the defaults. The keys and strategies registered by
+// the modules are kept. It fails on an invalid pattern or strategy.
+//
+// The rules are process-wide: call it once at startup, before the loggers
+// are used.
+func ConfigureMasking(cfg config.MaskingConfig) error {
+	next := defaultRules()
+	if len(cfg.SensitiveKeys) > 0 {
+		next.sensitiveKeys = lowerAll(cfg.SensitiveKeys)
+	}
+	for keyword, name := range cfg.Strategies {
+		strategy, err := parseStrategy(name)
+		if err != nil {
+			return fmt.Errorf("invalid masking strategy of %q: %w", keyword, err)
+		}
+		next.strategies = append(next.strategies, keyStrategy{keyword: strings.ToLower(keyword), strategy: strategy})
+	}
+	next.hashSalt = cfg.HashSalt
+	if cfg.TruncateLength > 0 {
+		next.truncateLength = cfg.TruncateLength
+	}
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return fmt.Errorf("invalid masking pattern %q: %w", p, err)
+		}
+		next.patterns = append(next.patterns, re)
+	}
+	if cfg.MaxFieldSize > 0 {
+		next.maxFieldSize = cfg.MaxFieldSize
+	}
+	if cfg.MaxDepth > 0 {
+		next.maxDepth = cfg.MaxDepth
+	}
+
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	configured = next
+	storeRules()
+	return nil
+}
+
+// RegisterSensitiveKeys adds keywords to the sensitive ones, e.g. the
+// confidential fields of a module, registered at bootstrap:
+//
+//	utils.RegisterSensitiveKeys("payment_reference")
+func RegisterSensitiveKeys(keys ...string) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	for _, k := range lowerAll(keys) {
+		if !slices.Contains(registered, k) {
+			registered = append(registered, k)
+		}
+	}
+	storeRules()
+}
+
+// storeRules publishes the configured rules with the registered keys and
+// strategies; a configured strategy wins over a registered one.
+func storeRules() {
+	next := configured
+	next.sensitiveKeys = slices.Concat(configured.sensitiveKeys, registered)
+	next.strategies = slices.Clone(configured.strategies)
+	for _, s := range registeredStrategies {
+		if !slices.ContainsFunc(next.strategies, func(c keyStrategy) bool { return c.keyword == s.keyword }) {
+			next.strategies = append(next.strategies, s)
+		}
+	}
+	sortStrategies(next.strategies)
+	rules.Store(&next)
+}
+
+func lowerAll(keys []string) []string {
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// MaxFieldSize returns the maximum size (in bytes) of a string field in logs.
+func MaxFieldSize() int {
+	return rules.Load().maxFieldSize
+}
+
+// MaskPatterns redacts the matches of the sensitive patterns from s.
+func MaskPatterns(s string) string {
+	for _, re := range rules.Load().patterns {
+		s = re.ReplaceAllString(s, redactedMatch)
+	}
+	return s
+}
 
 // MaskSensitive processes any data type (struct, map, slice, string) to:
 // 1. Redact sensitive values based on predefined keys.
@@ -31,7 +177,7 @@ var sensitiveKeys = []string{"password", "token", "secret", "otp", "credential",
 //
 //	maskedBody := utils.MaskSensitive(req.Body)
 func MaskSensitive(data any) any {
-	return maskRecursive(data, 0)
+	return maskRecursive(rules.Load(), data, 0)
 }
 
 // MaskHttpHeaders filters HTTP headers based on a whitelist of allowed keys
@@ -57,7 +203,7 @@ func MaskHttpHeaders(headers map[string][]string) map[string]string {
 
 		val := strings.Join(v, ", ")
 		if IsSensitiveKey(key) {
-			out[k] = "******** [REDACTED]"
+			out[k] = Redacted
 		} else {
 			out[k] = val
 		}
@@ -68,8 +214,12 @@ func MaskHttpHeaders(headers map[string][]string) map[string]string {
 // IsSensitiveKey checks if a given key name contains any sensitive keywords.
 // It is case-insensitive and matches substrings (e.g., "access_token" matches "token").
 func IsSensitiveKey(key string) bool {
+	return rules.Load().isSensitiveKey(key)
+}
+
+func (r *maskingRules) isSensitiveKey(key string) bool {
 	lowerKey := strings.ToLower(key)
-	return slices.ContainsFunc(sensitiveKeys, func(s string) bool {
+	return slices.ContainsFunc(r.sensitiveKeys, func(s string) bool {
 		return strings.Contains(lowerKey, s)
 	})
 }
@@ -77,7 +227,7 @@ func IsSensitiveKey(key string) bool {
 // ContainsSensitiveToken provides a quick check for sensitive tokens within a raw string.
 func ContainsSensitiveToken(msg string) bool {
 	lower := strings.ToLower(msg)
-	for _, word := range sensitiveKeys {
+	for _, word := range rules.Load().sensitiveKeys {
 		if strings.Contains(lower, word) {
 			return true
 		}
@@ -85,8 +235,8 @@ func ContainsSensitiveToken(msg string) bool {
 	return false
 }
 
-func maskRecursive(data any, depth int) any {
-	if data == nil || depth > maxDepth {
+func maskRecursive(r *maskingRules, data any, depth int) any {
+	if data == nil || depth > r.maxDepth {
 		return data
 	}
 
@@ -101,19 +251,19 @@ func maskRecursive(data any, depth int) any {
 
 	switch val.Kind() {
 	case reflect.String:
-		return maskString(val.String(), depth)
+		return maskString(r, val.String(), depth)
 
 	case reflect.Slice, reflect.Array:
-		return maskSlice(val, depth)
+		return maskSlice(r, val, depth)
 
 	case reflect.Map:
-		return maskMap(val, depth)
+		return maskMap(r, val, depth)
 
 	case reflect.Struct:
 		b, _ := json.Marshal(data)
 		var m any
 		if err := json.Unmarshal(b, &m); err == nil {
-			return maskRecursive(m, depth)
+			return maskRecursive(r, m, depth)
 		}
 		return data
 
@@ -122,12 +272,12 @@ func maskRecursive(data any, depth int) any {
 	}
 }
 
-func maskSlice(val reflect.Value, depth int) []any {
+func maskSlice(r *maskingRules, val reflect.Value, depth int) []any {
 	limit := min(val.Len(), 10)
 	newSlice := make([]any, val.Len())
 	for i := 0; i < val.Len(); i++ {
 		if i < limit {
-			newSlice[i] = maskRecursive(val.Index(i).Interface(), depth+1)
+			newSlice[i] = maskRecursive(r, val.Index(i).Interface(), depth+1)
 		} else {
 			newSlice[i] = val.Index(i).Interface()
 		}
@@ -135,19 +285,19 @@ func maskSlice(val reflect.Value, depth int) []any {
 	return newSlice
 }
 
-func maskString(v string, depth int) any {
+func maskString(r *maskingRules, v string, depth int) any {
 	trimmed := strings.TrimSpace(v)
 	if len(trimmed) == 0 {
 		return v
 	}
 
-	if len(trimmed) > MaxFieldSize {
+	if len(trimmed) > r.maxFieldSize {
 		return fmt.Sprintf("[field size %d bytes, too large to log]", len(trimmed))
 	}
-	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && depth < maxDepth {
+	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && depth < r.maxDepth {
 		var nested any
 		if err := json.Unmarshal([]byte(trimmed), &nested); err == nil {
-			masked := maskRecursive(nested, depth+1)
+			masked := maskRecursive(r, nested, depth+1)
 			if b, err := json.Marshal(masked); err == nil {
 				return string(b)
 			}
@@ -155,27 +305,34 @@ func maskString(v string, depth int) any {
 	}
 
 	lower := strings.ToLower(trimmed)
-	for _, word := range sensitiveKeys {
+	for _, word := range r.sensitiveKeys {
 		if strings.Contains(lower, word) {
-			return "******** [REDACTED]"
+			return Redacted
 		}
 	}
 
+	for _, re := range r.patterns {
+		v = re.ReplaceAllString(v, redactedMatch)
+	}
 	return v
 }
 
-func maskMap(val reflect.Value, depth int) map[string]any {
+func maskMap(r *maskingRules, val reflect.Value, depth int) map[string]any {
 	newMap := make(map[string]any, val.Len())
 	iter := val.MapRange()
 	for iter.Next() {
 		k := iter.Key().String()
 		v := iter.Value().Interface()
 
-		if IsSensitiveKey(k) {
-			newMap[k] = "******** [REDACTED]"
+		if strategy, ok := r.strategyFor(k); ok {
+			newMap[k] = r.apply(strategy, v)
+			continue
+		}
+		if r.isSensitiveKey(k) {
+			newMap[k] = Redacted
 			continue
 		}
-		newMap[k] = maskRecursive(v, depth+1)
+		newMap[k] = maskRecursive(r, v, depth+1)
 	}
 	return newMap
 }
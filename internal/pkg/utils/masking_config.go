@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"voyago/core-api/internal/infrastructure/config"
+)
+
+// MaskConfig returns the settings of cfg as nested maps keyed like the
+// configuration files (the mapstructure names), the sections of the modules
+// included (see config.Config.Sections), for the configuration dumps. The
+// non-empty values of the sensitive keys (see IsSensitiveKey) are redacted,
+// whatever their depth; the other strings have the sensitive patterns
+// redacted (see MaskPatterns).
+//
+// Example:
+//
+//	log.WithField("config", utils.MaskConfig(cfg)).Debug("effective configuration")
+func MaskConfig(cfg *config.Config) map[string]any {
+	if cfg == nil {
+		return nil
+	}
+	out := map[string]any{}
+	for key, section := range cfg.Sections() {
+		out[key] = maskConfigField(key, reflect.ValueOf(section))
+	}
+	maskConfigStruct(reflect.ValueOf(cfg).Elem(), out)
+	return out
+}
+
+func maskConfigValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return maskConfigValue(v.Elem())
+	case reflect.Struct:
+		out := map[string]any{}
+		maskConfigStruct(v, out)
+		return out
+	case reflect.Map:
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, ok := iter.Key().Interface().(string)
+			if !ok {
+				continue
+			}
+			out[key] = maskConfigField(key, iter.Value())
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = maskConfigValue(v.Index(i))
+		}
+		return out
+	case reflect.String:
+		return MaskPatterns(v.String())
+	default:
+		return v.Interface()
+	}
+}
+
+// maskConfigStruct adds the exported fields of v to out; squashed fields are
+// merged into out.
+func maskConfigStruct(v reflect.Value, out map[string]any) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("mapstructure"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "squash") && f.Type.Kind() == reflect.Struct {
+			maskConfigStruct(v.Field(i), out)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		out[name] = maskConfigField(name, v.Field(i))
+	}
+}
+
+func maskConfigField(key string, v reflect.Value) any {
+	if v.IsValid() && !v.IsZero() && IsSensitiveKey(key) {
+		return Redacted
+	}
+	return maskConfigValue(v)
+}
@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaskStrategy is how the value of a key is masked.
+type MaskStrategy string
+
+const (
+	// MaskRedact replaces the value by Redacted, as for the sensitive keys.
+	MaskRedact MaskStrategy = "redact"
+	// MaskLast4 keeps the last 4 characters: "****1111".
+	MaskLast4 MaskStrategy = "last4"
+	// MaskSHA256 replaces the value by the start of its (salted) SHA-256:
+	// "sha256:5e884898da280471". Equal values give equal hashes, so that they
+	// can be correlated across entries.
+	MaskSHA256 MaskStrategy = "sha256"
+	// MaskTruncate keeps the first characters (8 by default): "Jl. Sudi...".
+	MaskTruncate MaskStrategy = "truncate"
+)
+
+// DefaultTruncateLength is the number of characters kept by MaskTruncate
+// when none is configured.
+const DefaultTruncateLength = 8
+
+// keyStrategy applies strategy to the keys containing keyword.
+type keyStrategy struct {
+	keyword  string
+	strategy MaskStrategy
+}
+
+// parseStrategy returns the strategy named name, case-insensitively.
+func parseStrategy(name string) (MaskStrategy, error) {
+	s := MaskStrategy(strings.ToLower(strings.TrimSpace(name)))
+	switch s {
+	case MaskRedact, MaskLast4, MaskSHA256, MaskTruncate:
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown masking strategy %q: must be one of redact, last4, sha256, truncate", name)
+}
+
+// sortStrategies orders the strategies by keyword, the longest (most
+// specific) first, so that "card_number" wins over "card".
+func sortStrategies(strategies []keyStrategy) {
+	slices.SortFunc(strategies, func(a, b keyStrategy) int {
+		if c := cmp.Compare(len(b.keyword), len(a.keyword)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.keyword, b.keyword)
+	})
+}
+
+// RegisterMaskingStrategy masks the values of the keys containing keyword
+// with strategy, e.g. the fields of a module that support teams correlate,
+// registered at bootstrap:
+//
+//	utils.RegisterMaskingStrategy("phone", utils.MaskLast4)
+//
+// A strategy configured for the same keyword (log.masking.strategies) wins.
+func RegisterMaskingStrategy(keyword string, strategy MaskStrategy) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+	registeredStrategies = slices.DeleteFunc(registeredStrategies, func(s keyStrategy) bool {
+		return s.keyword == keyword
+	})
+	registeredStrategies = append(registeredStrategies, keyStrategy{keyword: keyword, strategy: strategy})
+	storeRules()
+}
+
+// strategyFor returns the strategy of key, when one applies.
+func (r *maskingRules) strategyFor(key string) (MaskStrategy, bool) {
+	if len(r.strategies) == 0 {
+		return "", false
+	}
+	lowerKey := strings.ToLower(key)
+	for _, s := range r.strategies {
+		if strings.Contains(lowerKey, s.keyword) {
+			return s.strategy, true
+		}
+	}
+	return "", false
+}
+
+// apply masks value with strategy; nil stays nil, other values are masked
+// in their string form.
+func (r *maskingRules) apply(strategy MaskStrategy, value any) any {
+	if value == nil {
+		return nil
+	}
+	v := fmt.Sprint(value)
+
+	switch strategy {
+	case MaskLast4:
+		runes := []rune(v)
+		if len(runes) <= 4 {
+			return "****"
+		}
+		return "****" + string(runes[len(runes)-4:])
+	case MaskSHA256:
+		sum := sha256.Sum256([]byte(r.hashSalt + v))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	case MaskTruncate:
+		runes := []rune(v)
+		if len(runes) <= r.truncateLength {
+			return v
+		}
+		return string(runes[:r.truncateLength]) + "..."
+	default:
+		return Redacted
+	}
+}
+
+// MaskField masks the value of a field: with the strategy of its key when one
+// applies, redacted when the key is sensitive, by MaskSensitive otherwise.
+func MaskField(key string, value any) any {
+	r := rules.Load()
+	if s, ok := r.strategyFor(key); ok {
+		return r.apply(s, value)
+	}
+	if r.isSensitiveKey(key) {
+		return Redacted
+	}
+	return maskRecursive(r, value, 0)
+}
@@ -1,13 +1,32 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
 	"voyago/core-api/internal/infrastructure/telemetry/tracer"
 	"voyago/core-api/internal/pkg/apperror"
 )
 
+// maxStackFrames bounds the stack trace attached to a span.
+const maxStackFrames = 32
+
 // RecordSpanError is a global helper to enrich a trace span with error metadata.
-// It automatically detects if the error is an apperror.AppError and extracts
-// machine-readable tags (Code, Kind) and debugging details.
+// It records err on the span (see tracer.Span.RecordError), and attaches the
+// stack trace of the caller and, when err wraps an apperror.AppError, its
+// machine-readable Code and Kind as tags:
+//
+//	error          true
+//	error.message  err.Error()
+//	error.type     the Go type of err (e.g., "*apperror.AppError")
+//	error.stack    the stack captured where err was created (see
+//	               apperror.CaptureStacks), the stack trace of the caller of
+//	               RecordSpanError otherwise
+//	error.cause    the message of the root cause of err (see
+//	               apperror.RootCause), when it differs
+//	error.code     AppError.Code (e.g., "BOOKING_NOT_FOUND")
+//	error.kind     AppError.Kind (e.g., "PERSISTANCE")
 //
 // Parameters:
 //   - span: The active tracer span. If nil, this function does nothing.
@@ -17,13 +36,47 @@ func RecordSpanError(span tracer.Span, err error) {
 		return
 	}
 
+	span.RecordError(err)
+
 	// Standard error tags
 	span.SetTag("error", true)
 	span.SetTag("error.message", err.Error())
+	span.SetTag("error.type", fmt.Sprintf("%T", err))
+	stack := apperror.StackTrace(err)
+	if stack == "" {
+		stack = callerStack(2)
+	}
+	span.SetTag("error.stack", stack)
+	if cause := apperror.RootCause(err).Error(); cause != err.Error() {
+		span.SetTag("error.cause", cause)
+	}
 
-	// Enhanced metadata for AppError
-	if appErr, ok := err.(*apperror.AppError); ok {
+	// Enhanced metadata for AppError, also when wrapped
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
 		span.SetTag("error.code", appErr.Code)
 		span.SetTag("error.kind", string(appErr.Kind))
 	}
 }
+
+// callerStack formats the stack trace of the goroutine, skipping the skip
+// innermost frames (1 is the caller of callerStack), in the layout of
+// runtime/debug.Stack:
+//
+//	voyago/core-api/internal/modules/booking/usecase.(*createBookingUseCase).Execute
+//		/app/internal/modules/booking/usecase/create_booking.go:120
+func callerStack(skip int) string {
+	pcs := make([]uintptr, maxStackFrames)
+	n := runtime.Callers(skip+1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var b strings.Builder
+	for {
+		f, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,263 @@
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// UseCase describes a use case added to an existing module. The fields are
+// read by the templates.
+type UseCase struct {
+	Module Module
+	// Type is the use case in PascalCase, without the UseCase suffix (e.g.
+	// "CancelBooking").
+	Type string
+	// Var is Type in camelCase (e.g. "cancelBooking").
+	Var string
+	// Snake is Type in snake_case (e.g. "cancel_booking"), the name of its
+	// files.
+	Snake string
+	// Action names the use case in its traces, logs and audit entries, after
+	// "usecase:" (e.g. "booking.cancel").
+	Action string
+}
+
+// UseCase returns the files adding the use case name to the existing module
+// m under root: its contract, appended to usecase/contract.go, its
+// implementation and a unit test stub.
+func (m Module) UseCase(root, name string) ([]File, error) {
+	name = strings.TrimSuffix(name, "UseCase")
+	if !entityName.MatchString(name) {
+		return nil, fmt.Errorf("invalid use case name %q: PascalCase letters and digits", name)
+	}
+	words := splitWords(name)
+	uc := UseCase{
+		Module: m,
+		Type:   name,
+		Var:    words[0] + name[len(words[0]):],
+		Snake:  strings.Join(words, "_"),
+		Action: m.action(words),
+	}
+
+	contract := path.Join("internal/modules", m.Name, "usecase/contract.go")
+	extended, err := extendContract(root, contract, []string{name + "UseCase", name + "Request", name + "Response"},
+		"usecase", uc, "context")
+	if err != nil {
+		return nil, err
+	}
+	files, err := render(useCaseTemplates, uc, strings.NewReplacer(
+		"__module__", m.Name,
+		"__usecase__", uc.Snake,
+		".tmpl", "",
+	))
+	return append(files, extended), err
+}
+
+// action returns the action of the use case whose name is words: the module,
+// the entity unless it is the module, then the verb (e.g. "booking.cancel",
+// "booking.payment_reminder.send").
+func (m Module) action(words []string) string {
+	verb, object := words[0], words[1:]
+	if len(object) > 0 && (object[0] == m.Name || object[0] == pluralize(m.Name)) {
+		object = object[1:]
+	}
+	if len(object) == 0 {
+		return m.Name + "." + verb
+	}
+	return m.Name + "." + strings.Join(object, "_") + "." + verb
+}
+
+// Repository returns the files adding the command and query repositories of
+// the existing entity m.Entity to the existing module m under root: their
+// contracts, appended to repository/contract.go, and their implementations.
+// The query repository reads the columns of the entity.
+func (m Module) Repository(root string) ([]File, error) {
+	columns, softDelete, err := entityColumns(root, m)
+	if err != nil {
+		return nil, err
+	}
+	m.Columns, m.SoftDelete = columns, softDelete
+
+	contract := path.Join("internal/modules", m.Name, "repository/contract.go")
+	extended, err := extendContract(root, contract, []string{m.Entity + "CommandRepository", m.Entity + "QueryRepository"},
+		"repository", m, "context", m.ModulePath+"/internal/modules/"+m.Name+"/entity")
+	if err != nil {
+		return nil, err
+	}
+	files, err := render(repoTemplates, m, m.replacer())
+	return append(files, extended), err
+}
+
+// extendContract returns the contract file p of root with the snippet
+// appended, importing the packages imports. The snippet declares names,
+// which the contract must not declare yet.
+func extendContract(root, p string, names []string, snippet string, data any, imports ...string) (File, error) {
+	src, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(p)))
+	if errors.Is(err, os.ErrNotExist) {
+		return File{}, fmt.Errorf("module not found: no %s", p)
+	}
+	if err != nil {
+		return File{}, err
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), p, src, parser.SkipObjectResolution)
+	if err != nil {
+		return File{}, fmt.Errorf("scaffold: %w", err)
+	}
+	for _, name := range names {
+		if declares(f, name) {
+			return File{}, fmt.Errorf("%s already declares %s", p, name)
+		}
+	}
+
+	added, err := execute(snippet, `{{template "`+snippet+`" .}}`, data)
+	if err != nil {
+		return File{}, err
+	}
+	content := append(bytes.TrimRight(src, "\n"), '\n')
+	content = append(content, added...)
+	if content, err = addImports(content, imports...); err != nil {
+		return File{}, err
+	}
+	if content, err = format.Source(content); err != nil {
+		return File{}, fmt.Errorf("scaffold: format %s: %w", p, err)
+	}
+	return File{Path: p, Content: content, Replace: true}, nil
+}
+
+// declares reports whether f declares the type name.
+func declares(f *ast.File, name string) bool {
+	for _, decl := range f.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+			for _, spec := range gen.Specs {
+				if spec.(*ast.TypeSpec).Name.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// addImports adds the missing imports among paths to the Go source src, in
+// its parenthesized import declaration when there is one.
+func addImports(src []byte, paths ...string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ImportsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("scaffold: %w", err)
+	}
+	var missing []string
+	for _, p := range paths {
+		if !slices.ContainsFunc(f.Imports, func(imp *ast.ImportSpec) bool { return imp.Path.Value == strconv.Quote(p) }) {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) == 0 {
+		return src, nil
+	}
+
+	var lines bytes.Buffer
+	offset := fset.Position(f.Name.End()).Offset
+	for _, decl := range f.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT && gen.Rparen.IsValid() {
+			offset = fset.Position(gen.Rparen).Offset
+			for _, p := range missing {
+				fmt.Fprintf(&lines, "\t%q\n", p)
+			}
+			break
+		}
+	}
+	if lines.Len() == 0 {
+		lines.WriteString("\n\nimport (\n")
+		for _, p := range missing {
+			fmt.Fprintf(&lines, "\t%q\n", p)
+		}
+		lines.WriteString(")")
+	}
+	return slices.Concat(src[:offset], lines.Bytes(), src[offset:]), nil
+}
+
+// gormColumn matches the column of a gorm struct tag.
+var gormColumn = regexp.MustCompile(`(?:^|;)column:([^;]+)`)
+
+// entityColumns returns the columns of the entity m.Entity of the module m
+// under root, and whether it has a deleted_at column. The relations and the
+// fields ignored by gorm are skipped.
+func entityColumns(root string, m Module) ([]string, bool, error) {
+	dir := path.Join("internal/modules", m.Name, "entity")
+	src := sourceReader{root: root}
+	files, err := src.goFiles(dir)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, p := range files {
+		content, err := src.read(p)
+		if err != nil {
+			return nil, false, err
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), p, content, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, false, fmt.Errorf("scaffold: %w", err)
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				st, ok := ts.Type.(*ast.StructType)
+				if ok && ts.Name.Name == m.Entity {
+					columns := structColumns(st)
+					return columns, slices.Contains(columns, "deleted_at"), nil
+				}
+			}
+		}
+	}
+	return nil, false, fmt.Errorf("entity %s not found in %s: add it first", m.Entity, dir)
+}
+
+// structColumns returns the columns of the fields of st.
+func structColumns(st *ast.StructType) []string {
+	var columns []string
+	for _, field := range st.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			raw, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(raw)
+		}
+		gorm := tag.Get("gorm")
+		if gorm == "-" || strings.Contains(gorm, "foreignKey") || strings.Contains(gorm, "many2many") {
+			continue
+		}
+		if arr, ok := field.Type.(*ast.ArrayType); ok {
+			if elt, ok := arr.Elt.(*ast.Ident); !ok || elt.Name != "byte" {
+				continue
+			}
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			if m := gormColumn.FindStringSubmatch(gorm); m != nil {
+				columns = append(columns, m[1])
+			} else {
+				columns = append(columns, strings.Join(splitWords(name.Name), "_"))
+			}
+		}
+	}
+	return columns
+}
@@ -0,0 +1,514 @@
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MocksDir is the package of the generated mocks, relative to the repository
+// root.
+const MocksDir = "test/mocks"
+
+// mockHeader marks the generated mock files.
+const mockHeader = "// Code generated by voyago gen mocks. DO NOT EDIT."
+
+// MockSource is a package whose interfaces are mocked in MocksDir.
+type MockSource struct {
+	// Dir is the directory of the package, relative to the repository root.
+	Dir string
+	// Interfaces are the mocked interfaces. When empty, every exported
+	// interface of the contract.go file of Dir is.
+	Interfaces []string
+	// File is the name of the mock file in MocksDir.
+	File string
+}
+
+// sharedMocks are the infrastructure ports the use cases depend on.
+var sharedMocks = []MockSource{
+	{Dir: "internal/infrastructure/logger", Interfaces: []string{"Logger"}, File: "logger.go"},
+	{Dir: "internal/infrastructure/telemetry/tracer", Interfaces: []string{"Tracer", "Span"}, File: "tracer.go"},
+	{Dir: "internal/pkg/repository", Interfaces: []string{"TransactionManager"}, File: "transaction_manager.go"},
+	{Dir: "internal/infrastructure/eventbus", Interfaces: []string{"Publisher"}, File: "eventbus.go"},
+	{Dir: "internal/pkg/audit", Interfaces: []string{"Recorder"}, File: "audit.go"},
+	{Dir: "internal/infrastructure/taskqueue", Interfaces: []string{"Enqueuer"}, File: "taskqueue.go"},
+}
+
+// contractPath matches the contracts of the modules, whose interfaces are
+// all mocked.
+var contractPath = regexp.MustCompile(`^internal/modules/([a-z][a-z0-9]*)/(repository|usecase)/contract\.go$`)
+
+// Mocks renders the mocks of the shared ports and of the repository and use
+// case contracts of every module under root. The files of pending, not
+// written yet, are read instead of their version on disk.
+func Mocks(root, modulePath string, pending []File) ([]File, error) {
+	src := sourceReader{root: root, pending: make(map[string][]byte, len(pending))}
+	for _, f := range pending {
+		src.pending[f.Path] = f.Content
+	}
+	sources, err := src.mockSources()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []File
+	declared := map[string]string{}
+	for _, s := range sources {
+		content, names, err := src.renderMocks(s, modulePath)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if other, ok := declared[name]; ok {
+				return nil, fmt.Errorf("scaffold: Mock%s is declared by both %s and %s", name, other, s.Dir)
+			}
+			declared[name] = s.Dir
+		}
+		files = append(files, File{Path: path.Join(MocksDir, s.File), Content: content, Replace: true})
+	}
+	return files, nil
+}
+
+// sourceReader reads the Go files of the repository, the pending files first.
+type sourceReader struct {
+	root    string
+	pending map[string][]byte
+}
+
+func (r sourceReader) read(p string) ([]byte, error) {
+	if content, ok := r.pending[p]; ok {
+		return content, nil
+	}
+	return os.ReadFile(filepath.Join(r.root, filepath.FromSlash(p)))
+}
+
+// goFiles returns the non-test Go files of the package dir.
+func (r sourceReader) goFiles(dir string) ([]string, error) {
+	var files []string
+	entries, err := os.ReadDir(filepath.Join(r.root, filepath.FromSlash(dir)))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, e := range entries {
+		files = append(files, path.Join(dir, e.Name()))
+	}
+	for p := range r.pending {
+		if path.Dir(p) == dir && !slices.Contains(files, p) {
+			files = append(files, p)
+		}
+	}
+	files = slices.DeleteFunc(files, func(p string) bool {
+		return !strings.HasSuffix(p, ".go") || strings.HasSuffix(p, "_test.go")
+	})
+	sort.Strings(files)
+	return files, nil
+}
+
+// mockSources returns sharedMocks, then the contracts of the modules.
+func (r sourceReader) mockSources() ([]MockSource, error) {
+	contracts, err := filepath.Glob(filepath.Join(r.root, "internal", "modules", "*", "*", "contract.go"))
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, c := range contracts {
+		rel, err := filepath.Rel(r.root, c)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, filepath.ToSlash(rel))
+	}
+	for p := range r.pending {
+		if !slices.Contains(paths, p) {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	sources := slices.Clone(sharedMocks)
+	for _, p := range paths {
+		if m := contractPath.FindStringSubmatch(p); m != nil {
+			sources = append(sources, MockSource{Dir: path.Dir(p), File: m[1] + "_" + m[2] + ".go"})
+		}
+	}
+	return sources, nil
+}
+
+// mockedInterface is an interface of a source file.
+type mockedInterface struct {
+	name  string
+	iface *ast.InterfaceType
+	file  *ast.File
+}
+
+// interfaces returns the mocked interfaces of s, in the order of s.Interfaces
+// or of their declaration.
+func (r sourceReader) interfaces(s MockSource) ([]mockedInterface, error) {
+	files := []string{path.Join(s.Dir, "contract.go")}
+	if len(s.Interfaces) > 0 {
+		var err error
+		if files, err = r.goFiles(s.Dir); err != nil {
+			return nil, err
+		}
+	}
+
+	var found []mockedInterface
+	fset := token.NewFileSet()
+	for _, p := range files {
+		content, err := r.read(p)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, p, content, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("scaffold: %w", err)
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				it, ok := ts.Type.(*ast.InterfaceType)
+				if !ok || !ts.Name.IsExported() || ts.TypeParams != nil {
+					continue
+				}
+				if len(s.Interfaces) == 0 || slices.Contains(s.Interfaces, ts.Name.Name) {
+					found = append(found, mockedInterface{name: ts.Name.Name, iface: it, file: f})
+				}
+			}
+		}
+	}
+
+	if len(s.Interfaces) > 0 {
+		var ordered []mockedInterface
+		for _, name := range s.Interfaces {
+			i := slices.IndexFunc(found, func(m mockedInterface) bool { return m.name == name })
+			if i < 0 {
+				return nil, fmt.Errorf("scaffold: interface %s not found in %s", name, s.Dir)
+			}
+			ordered = append(ordered, found[i])
+		}
+		found = ordered
+	}
+	return found, nil
+}
+
+// renderMocks renders the mock file of s and returns the mocked interfaces.
+func (r sourceReader) renderMocks(s MockSource, modulePath string) ([]byte, []string, error) {
+	ifaces, err := r.interfaces(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ifaces) == 0 {
+		return nil, nil, fmt.Errorf("scaffold: no interface to mock in %s", s.Dir)
+	}
+
+	g := &mockWriter{imports: map[string]string{}}
+	pkg := ifaces[0].file.Name.Name
+	g.imports[pkg] = modulePath + "/" + s.Dir
+	g.imports["mock"] = "github.com/stretchr/testify/mock"
+
+	var body bytes.Buffer
+	var names []string
+	for _, it := range ifaces {
+		q := qualifier{pkg: pkg, file: it.file, used: g.imports}
+		if err := g.writeMock(&body, it, q); err != nil {
+			return nil, nil, fmt.Errorf("scaffold: mock %s.%s: %w", pkg, it.name, err)
+		}
+		names = append(names, it.name)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%s\n\npackage mocks\n\nimport (\n", mockHeader)
+	// The standard library, the repository, then the other modules.
+	groups := make([][]string, 3)
+	for alias, p := range g.imports {
+		spec := strconv.Quote(p)
+		if importName(p) != alias {
+			spec = alias + " " + spec
+		}
+		group := 2
+		if p == modulePath || strings.HasPrefix(p, modulePath+"/") {
+			group = 1
+		} else if !strings.Contains(strings.Split(p, "/")[0], ".") {
+			group = 0
+		}
+		groups[group] = append(groups[group], spec)
+	}
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+		sort.Strings(group)
+		fmt.Fprintf(&out, "\t%s\n\n", strings.Join(group, "\n\t"))
+	}
+	out.WriteString(")\n")
+	out.Write(body.Bytes())
+
+	content, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, nil, fmt.Errorf("scaffold: format the mocks of %s: %w", s.Dir, err)
+	}
+	return content, names, nil
+}
+
+// mockWriter writes the mocks of a file.
+type mockWriter struct {
+	// imports are the packages used by the mocks, by name.
+	imports map[string]string
+}
+
+// reservedNames are the parameter names renamed in the mocks: blank, or used
+// by the generated methods.
+var reservedNames = []string{"", "_", "m", "args", "f", "ok", "v"}
+
+// mockParam is a parameter or a result of a mocked method.
+type mockParam struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+func (g *mockWriter) writeMock(w *bytes.Buffer, it mockedInterface, q qualifier) error {
+	mockName := "Mock" + it.name
+	fmt.Fprintf(w, "\n// %s is a mock implementation of %s.%s.\ntype %s struct {\n\tmock.Mock\n}\n", mockName, q.pkg, it.name, mockName)
+	fmt.Fprintf(w, "\nvar _ %s.%s = (*%s)(nil)\n", q.pkg, it.name, mockName)
+
+	for _, field := range it.iface.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			return fmt.Errorf("embedded interface %s is not supported", q.print(field.Type))
+		}
+		params, err := q.fields(ft.Params)
+		if err != nil {
+			return err
+		}
+		results, err := q.fields(ft.Results)
+		if err != nil {
+			return err
+		}
+		for _, name := range field.Names {
+			g.writeMethod(w, mockName, name.Name, params, results)
+		}
+	}
+	return nil
+}
+
+// writeMethod writes a method of the mock recording its call. The results are
+// those given to Return; a function of the signature of the method given to
+// Return is called instead, as with mockery.
+func (g *mockWriter) writeMethod(w *bytes.Buffer, mockName, method string, params, results []mockParam) {
+	var names, decls, forwards, types []string
+	for i, p := range params {
+		name := p.name
+		if slices.Contains(reservedNames, name) || g.imports[name] != "" {
+			name = "p" + strconv.Itoa(i)
+		}
+		typ := p.typ
+		forward := name
+		if p.variadic {
+			typ = "..." + typ
+			forward += "..."
+		}
+		names = append(names, name)
+		decls = append(decls, name+" "+typ)
+		forwards = append(forwards, forward)
+		types = append(types, typ)
+	}
+	var resultTypes []string
+	for _, r := range results {
+		resultTypes = append(resultTypes, r.typ)
+	}
+	resultList := strings.Join(resultTypes, ", ")
+	if len(resultTypes) > 1 {
+		resultList = "(" + resultList + ")"
+	}
+
+	fmt.Fprintf(w, "\nfunc (m *%s) %s(%s) %s {\n", mockName, method, strings.Join(decls, ", "), resultList)
+	if len(results) == 0 {
+		fmt.Fprintf(w, "\tm.Called(%s)\n}\n", strings.Join(names, ", "))
+		return
+	}
+	fmt.Fprintf(w, "\targs := m.Called(%s)\n", strings.Join(names, ", "))
+	fmt.Fprintf(w, "\tif f, ok := args.Get(0).(func(%s) %s); ok {\n\t\treturn f(%s)\n\t}\n",
+		strings.Join(types, ", "), resultList, strings.Join(forwards, ", "))
+
+	var returns []string
+	for i, r := range results {
+		switch r.typ {
+		case "error":
+			returns = append(returns, fmt.Sprintf("args.Error(%d)", i))
+		case "bool":
+			returns = append(returns, fmt.Sprintf("args.Bool(%d)", i))
+		case "string":
+			returns = append(returns, fmt.Sprintf("args.String(%d)", i))
+		case "int":
+			returns = append(returns, fmt.Sprintf("args.Int(%d)", i))
+		default:
+			fmt.Fprintf(w, "\tvar r%[1]d %[2]s\n\tif v := args.Get(%[1]d); v != nil {\n\t\tr%[1]d = v.(%[2]s)\n\t}\n", i, r.typ)
+			returns = append(returns, fmt.Sprintf("r%d", i))
+		}
+	}
+	fmt.Fprintf(w, "\treturn %s\n}\n", strings.Join(returns, ", "))
+}
+
+// qualifier prints the types of a source file as seen from the mocks package.
+type qualifier struct {
+	// pkg is the name of the source package.
+	pkg  string
+	file *ast.File
+	// used collects the packages of the printed types, by name.
+	used map[string]string
+}
+
+// fields expands a parameter or result list, one entry per name.
+func (q qualifier) fields(list *ast.FieldList) ([]mockParam, error) {
+	if list == nil {
+		return nil, nil
+	}
+	var params []mockParam
+	for _, f := range list.List {
+		typ, variadic := f.Type, false
+		if e, ok := typ.(*ast.Ellipsis); ok {
+			typ, variadic = e.Elt, true
+		}
+		qualified, err := q.qualify(typ)
+		if err != nil {
+			return nil, err
+		}
+		p := mockParam{typ: q.print(qualified), variadic: variadic}
+		if len(f.Names) == 0 {
+			params = append(params, p)
+		}
+		for _, name := range f.Names {
+			p.name = name.Name
+			params = append(params, p)
+		}
+	}
+	return params, nil
+}
+
+// qualify returns e with the types of the source package prefixed by its
+// name, recording the packages used.
+func (q qualifier) qualify(e ast.Expr) (ast.Expr, error) {
+	var err error
+	switch t := e.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(t.Name) != nil {
+			return t, nil
+		}
+		return &ast.SelectorExpr{X: ast.NewIdent(q.pkg), Sel: t}, nil
+	case *ast.SelectorExpr:
+		x, ok := t.X.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %s", q.print(t))
+		}
+		p, err := q.importPath(x.Name)
+		if err != nil {
+			return nil, err
+		}
+		q.used[x.Name] = p
+		return t, nil
+	case *ast.StarExpr:
+		c := *t
+		c.X, err = q.qualify(t.X)
+		return &c, err
+	case *ast.ArrayType:
+		c := *t
+		c.Elt, err = q.qualify(t.Elt)
+		return &c, err
+	case *ast.MapType:
+		c := *t
+		if c.Key, err = q.qualify(t.Key); err != nil {
+			return nil, err
+		}
+		c.Value, err = q.qualify(t.Value)
+		return &c, err
+	case *ast.ChanType:
+		c := *t
+		c.Value, err = q.qualify(t.Value)
+		return &c, err
+	case *ast.Ellipsis:
+		c := *t
+		c.Elt, err = q.qualify(t.Elt)
+		return &c, err
+	case *ast.FuncType:
+		c := *t
+		if c.Params, err = q.qualifyFields(t.Params); err != nil {
+			return nil, err
+		}
+		c.Results, err = q.qualifyFields(t.Results)
+		return &c, err
+	case *ast.InterfaceType:
+		if len(t.Methods.List) > 0 {
+			return nil, errors.New("unsupported inline interface")
+		}
+		return t, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", q.print(e))
+}
+
+func (q qualifier) qualifyFields(list *ast.FieldList) (*ast.FieldList, error) {
+	if list == nil {
+		return nil, nil
+	}
+	c := &ast.FieldList{}
+	for _, f := range list.List {
+		typ, err := q.qualify(f.Type)
+		if err != nil {
+			return nil, err
+		}
+		c.List = append(c.List, &ast.Field{Names: f.Names, Type: typ})
+	}
+	return c, nil
+}
+
+// importPath returns the package imported by the source file as name.
+func (q qualifier) importPath(name string) (string, error) {
+	for _, imp := range q.file.Imports {
+		p, _ := strconv.Unquote(imp.Path.Value)
+		if imp.Name != nil && imp.Name.Name == name {
+			return p, nil
+		}
+		if imp.Name == nil && importName(p) == name {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("no import for package %s", name)
+}
+
+func (q qualifier) print(e ast.Expr) string {
+	var b bytes.Buffer
+	_ = printer.Fprint(&b, token.NewFileSet(), e)
+	return b.String()
+}
+
+// versionSuffix matches the major version element of an import path.
+var versionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// importName returns the conventional name of the package at p: its last
+// element, before a major version, without a "go-" prefix.
+func importName(p string) string {
+	elems := strings.Split(p, "/")
+	name := elems[len(elems)-1]
+	if versionSuffix.MatchString(name) && len(elems) > 1 {
+		name = elems[len(elems)-2]
+	}
+	return strings.TrimPrefix(name, "go-")
+}
@@ -0,0 +1,270 @@
+// Package scaffold generates code following the conventions of the booking
+// module: the skeleton of a new domain module (entity, repository contracts
+// with their command and query implementations, use cases, HTTP handler and
+// routes, configuration, migrations and test stubs), the repositories of a new
+// entity or a new use case of an existing module, and the testify mocks of
+// the contracts.
+//
+// The templates live under templates/, one directory per generator mirroring
+// the repository tree; the __module__, __entity__ and __usecase__ segments of
+// their paths are replaced by the module name and the snake_case names of the
+// entity and of the use case.
+//
+//	m, err := scaffold.New("loyalty", "Reward", "voyago/core-api", time.Now())
+//	files, err := m.Files()
+//	err = scaffold.Write(".", files)
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+)
+
+//go:embed all:templates
+var templates embed.FS
+
+const templateRoot = "templates"
+
+// Template sets, the directories of templateRoot.
+const (
+	moduleTemplates  = "module"
+	repoTemplates    = "repo"
+	useCaseTemplates = "usecase"
+)
+
+// ErrExists is returned by Write when a generated file already exists.
+var ErrExists = errors.New("scaffold: file already exists")
+
+var (
+	moduleName = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+	entityName = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+// Module describes the module to generate. The fields are read by the
+// templates.
+type Module struct {
+	// Name is the domain: package, configuration directory, schema and
+	// migrations directory (e.g. "loyalty").
+	Name string
+	// Entity is the aggregate of the module in PascalCase (e.g.
+	// "LoyaltyReward").
+	Entity string
+	// Var is Entity in camelCase (e.g. "loyaltyReward").
+	Var string
+	// Snake is Entity in snake_case (e.g. "loyalty_reward"), used in the
+	// file names, columns and use case names.
+	Snake string
+	// Table is the table of the entity (e.g. "loyalty_rewards").
+	Table string
+	// Route is the route group of the entity (e.g. "/loyalty-rewards").
+	Route string
+	// Code prefixes the error codes of the entity (e.g. "LOYALTY_REWARD").
+	Code string
+	// Label is Entity in words, for messages (e.g. "loyalty reward").
+	Label string
+	// Title is Label starting with a capital (e.g. "Loyalty reward").
+	Title string
+	// ModulePath is the Go module of the repository (e.g. "voyago/core-api").
+	ModulePath string
+	// Version is the version of the initial migration, a timestamp.
+	Version string
+	// Columns are the columns read by the query repository.
+	Columns []string
+	// SoftDelete reports whether the entity is soft deleted (deleted_at).
+	SoftDelete bool
+}
+
+// File is a generated file.
+type File struct {
+	// Path is relative to the repository root, slash-separated.
+	Path    string
+	Content []byte
+	// Replace allows Write to overwrite the file: a contract extended by the
+	// generator or a generated mock.
+	Replace bool
+}
+
+// New returns the module name whose aggregate is entity, the PascalCase name
+// of name when empty. modulePath is the Go module of the repository and now
+// dates the initial migration.
+func New(name, entity, modulePath string, now time.Time) (Module, error) {
+	if !moduleName.MatchString(name) {
+		return Module{}, fmt.Errorf("invalid module name %q: lowercase letters and digits, starting with a letter", name)
+	}
+	if entity == "" {
+		entity = string(unicode.ToUpper(rune(name[0]))) + name[1:]
+	}
+	if !entityName.MatchString(entity) {
+		return Module{}, fmt.Errorf("invalid entity name %q: PascalCase letters and digits", entity)
+	}
+	if modulePath == "" {
+		return Module{}, errors.New("missing Go module path")
+	}
+
+	words := splitWords(entity)
+	snake := strings.Join(words, "_")
+	plural := append(words[:len(words)-1:len(words)-1], pluralize(words[len(words)-1]))
+	return Module{
+		Name:       name,
+		Entity:     entity,
+		Var:        words[0] + entity[len(words[0]):],
+		Snake:      snake,
+		Table:      strings.Join(plural, "_"),
+		Route:      "/" + strings.Join(plural, "-"),
+		Code:       strings.ToUpper(snake),
+		Label:      strings.Join(words, " "),
+		Title:      strings.ToUpper(words[0][:1]) + strings.Join(words, " ")[1:],
+		ModulePath: modulePath,
+		Version:    now.UTC().Format("20060102150405"),
+		Columns:    []string{"id", "name", "created_at", "updated_at"},
+		SoftDelete: true,
+	}, nil
+}
+
+// Files renders the templates of the module m and of its repositories. The
+// Go files are gofmt-ed.
+func (m Module) Files() ([]File, error) {
+	files, err := render(moduleTemplates, m, m.replacer())
+	if err != nil {
+		return nil, err
+	}
+	repos, err := render(repoTemplates, m, m.replacer())
+	return append(files, repos...), err
+}
+
+// replacer replaces the segments of the template paths of m.
+func (m Module) replacer() *strings.Replacer {
+	return strings.NewReplacer(
+		"__module__", m.Name,
+		"__entity__", m.Snake,
+		"__version__", m.Version,
+		".tmpl", "",
+	)
+}
+
+// render renders the templates of set with data, their paths rewritten by r.
+func render(set string, data any, r *strings.Replacer) ([]File, error) {
+	var files []File
+	dir := path.Join(templateRoot, set)
+	err := fs.WalkDir(templates, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		raw, err := templates.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		content, err := execute(p, string(raw), data)
+		if err != nil {
+			return err
+		}
+		out := r.Replace(strings.TrimPrefix(p, dir+"/"))
+		if strings.HasSuffix(out, ".go") {
+			if content, err = format.Source(content); err != nil {
+				return fmt.Errorf("scaffold: format %s: %w", out, err)
+			}
+		}
+		files = append(files, File{Path: out, Content: content})
+		return nil
+	})
+	return files, err
+}
+
+// execute runs the template text, named name, with the shared snippets.
+func execute(name, text string, data any) ([]byte, error) {
+	tmpl, err := template.New(path.Base(name)).Parse(text)
+	if err == nil {
+		_, err = tmpl.ParseFS(templates, path.Join(templateRoot, "snippets.tmpl"))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("scaffold: parse %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("scaffold: render %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Write writes files under root. It writes nothing and returns ErrExists when
+// one of them, not to be replaced, already exists.
+func Write(root string, files []File) error {
+	for _, f := range files {
+		if f.Replace {
+			continue
+		}
+		dst := filepath.Join(root, filepath.FromSlash(f.Path))
+		if _, err := os.Stat(dst); err == nil {
+			return fmt.Errorf("%w: %s", ErrExists, f.Path)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	for _, f := range files {
+		dst := filepath.Join(root, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, f.Content, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ModulePath returns the Go module declared by the go.mod of root.
+func ModulePath(root string) (string, error) {
+	raw, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(raw), "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`), nil
+		}
+	}
+	return "", errors.New("no module directive in go.mod")
+}
+
+// splitWords splits a PascalCase name into lowercase words, keeping the
+// acronyms together (e.g. "APIKey" -> ["api", "key"]).
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		upper := unicode.IsUpper(runes[i])
+		boundary := upper && (!unicode.IsUpper(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1])))
+		if boundary {
+			words = append(words, strings.ToLower(string(runes[start:i])))
+			start = i
+		}
+	}
+	return append(words, strings.ToLower(string(runes[start:])))
+}
+
+// pluralize returns the English plural of a lowercase noun, for the regular
+// nouns.
+func pluralize(w string) string {
+	switch {
+	case strings.HasSuffix(w, "y") && len(w) > 1 && !strings.ContainsRune("aeiou", rune(w[len(w)-2])):
+		return w[:len(w)-1] + "ies"
+	case strings.HasSuffix(w, "s"), strings.HasSuffix(w, "x"), strings.HasSuffix(w, "z"),
+		strings.HasSuffix(w, "ch"), strings.HasSuffix(w, "sh"):
+		return w + "es"
+	}
+	return w + "s"
+}
@@ -0,0 +1,18 @@
+// Package locales embeds the message catalogs of the locales other than the
+// one of the code (English), one directory per locale of YAML files, one per
+// module (e.g. id/booking.yaml), so that the binaries translate the messages
+// without the repository files (see package i18n).
+package locales
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed */*.yaml
+var files embed.FS
+
+// FS returns the catalogs.
+func FS() fs.FS {
+	return files
+}
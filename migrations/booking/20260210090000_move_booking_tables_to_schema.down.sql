@@ -0,0 +1,4 @@
+Alter Table If Exists "booking"."booking_details" Set Schema "public";
+Alter Table If Exists "booking"."bookings" Set Schema "public";
+
+Drop Schema If Exists "booking";
@@ -0,0 +1,4 @@
+Create Schema If Not Exists "booking";
+
+Alter Table If Exists "public"."bookings" Set Schema "booking";
+Alter Table If Exists "public"."booking_details" Set Schema "booking";
@@ -0,0 +1,3 @@
+Comment On Column "booking"."bookings"."payment_status" Is NULL;
+
+Alter Table "booking"."bookings" Drop Column If Exists "payment_reference";
@@ -0,0 +1,6 @@
+Alter Table "booking"."bookings" Add Column If Not Exists "payment_reference" Character Varying (100) Null;
+
+Comment On Column "booking"."bookings"."payment_status" Is '- UNPAID
+- PAID
+- FAILED
+- REFUNDED';
@@ -0,0 +1,5 @@
+Drop Index If Exists "booking"."idx_audit_logs_tenant";
+
+Alter Table "booking"."audit_logs" Drop Column If Exists "roles";
+Alter Table "booking"."audit_logs" Drop Column If Exists "client_app";
+Alter Table "booking"."audit_logs" Drop Column If Exists "tenant_id";
@@ -0,0 +1,5 @@
+Alter Table "booking"."audit_logs" Add Column If Not Exists "tenant_id" Character Varying (128) Null;
+Alter Table "booking"."audit_logs" Add Column If Not Exists "client_app" Character Varying (128) Null;
+Alter Table "booking"."audit_logs" Add Column If Not Exists "roles" Text Null; -- JSON array of the user roles
+
+Create Index If Not Exists "idx_audit_logs_tenant" On "booking"."audit_logs" ("tenant_id", "created_at");
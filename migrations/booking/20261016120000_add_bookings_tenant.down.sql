@@ -0,0 +1,3 @@
+Drop Index If Exists "booking"."idx_bookings_tenant";
+
+Alter Table "booking"."bookings" Drop Column If Exists "tenant_id";
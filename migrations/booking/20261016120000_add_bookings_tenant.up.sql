@@ -0,0 +1,5 @@
+-- Tenant-scoped table (see database.UseTenantScope): NULL for the bookings
+-- created without tenant.
+Alter Table "booking"."bookings" Add Column If Not Exists "tenant_id" Character Varying (128) Null;
+
+Create Index If Not Exists "idx_bookings_tenant" On "booking"."bookings" ("tenant_id", "created_at");
@@ -0,0 +1 @@
+Drop Table If Exists "booking"."processed_events";
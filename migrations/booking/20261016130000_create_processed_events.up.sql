@@ -0,0 +1,10 @@
+-- Events processed by the consumers of the module (see dedupe.DatabaseStore).
+Create Table If Not Exists "booking"."processed_events" (
+  "consumer" Character Varying (128) Not Null, -- e.g. "booking.apply_payment_status"
+  "event_id" Character Varying (128) Not Null,
+  "processed_at" BigInt Not Null,
+
+  Constraint "pk_processed_events" Primary Key ("consumer", "event_id")
+);
+
+Create Index If Not Exists "idx_processed_events_processed_at" On "booking"."processed_events" ("processed_at");
@@ -0,0 +1,19 @@
+-- Progress of the sagas of the module (see saga.DatabaseStore).
+Create Table If Not Exists "booking"."sagas" (
+  "name" Character Varying (100) Not Null, -- e.g. "booking.checkout"
+  "id" Character Varying (128) Not Null,
+  "status" Character Varying (20) Not Null, -- RUNNING, COMPLETED, COMPENSATING, COMPENSATED
+  "step" Integer Not Null Default 0,
+  "data" Text Not Null,
+  "failed_step" Character Varying (100),
+  "error" Text,
+  "deadline" BigInt Not Null Default 0,
+  "version" Integer Not Null Default 0,
+  "created_at" BigInt Not Null,
+  "updated_at" BigInt Not Null,
+
+  Constraint "pk_sagas" Primary Key ("name", "id")
+);
+
+-- Recovery of the unfinished sagas.
+Create Index If Not Exists "idx_sagas_unfinished" On "booking"."sagas" ("name", "updated_at") Where "status" In ('RUNNING', 'COMPENSATING');
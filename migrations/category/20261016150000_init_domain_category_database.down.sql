@@ -0,0 +1,2 @@
+Drop Table If Exists "category"."categories";
+Drop Schema If Exists "category";
@@ -0,0 +1,17 @@
+Create Schema If Not Exists "category";
+
+Create Table If Not Exists "category"."categories" (
+  "id" UUID Not Null,
+  "parent_id" UUID Null, -- Null for the root categories
+  "name" Character Varying (100) Not Null,
+  "slug" Character Varying (100) Not Null,
+  "position" Integer Not Null Default 0, -- order among the children of the parent
+  "created_at" BigInt Not Null Default 0,
+  "updated_at" BigInt Null,
+
+  Constraint "pk_categories" Primary Key ("id"),
+  Constraint "uq_categories_slug" Unique ("slug"),
+  Constraint "fk_categories_parent" Foreign Key ("parent_id") References "category"."categories" ("id")
+);
+
+Create Index If Not Exists "idx_categories_parent" On "category"."categories" ("parent_id", "position");
@@ -0,0 +1,23 @@
+// Package migrations embeds the golang-migrate SQL migrations of the domains,
+// one directory per domain ("<version>_<name>.up.sql" and ".down.sql"), so
+// that the binaries apply and check them without the repository files (see
+// database.NewMigrator).
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed */*.sql
+var files embed.FS
+
+// FS returns the migrations of domain, empty when it has none.
+func FS(domain string) fs.FS {
+	sub, err := fs.Sub(files, domain)
+	if err != nil {
+		// Only an invalid path fails, e.g. "../booking".
+		return embed.FS{}
+	}
+	return sub
+}
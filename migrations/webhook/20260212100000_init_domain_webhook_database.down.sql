@@ -0,0 +1,4 @@
+Drop Table If Exists "webhook"."webhook_delivery_attempts";
+Drop Table If Exists "webhook"."webhook_deliveries";
+Drop Table If Exists "webhook"."webhook_endpoints";
+Drop Schema If Exists "webhook";
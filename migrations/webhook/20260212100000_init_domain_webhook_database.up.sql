@@ -0,0 +1,49 @@
+Create Schema If Not Exists "webhook";
+
+Create Table If Not Exists "webhook"."webhook_endpoints" (
+  "id" UUID Not Null,
+  "url" Character Varying (2048) Not Null,
+  "secret" Character Varying (255) Not Null,
+  "event_types" Text Not Null, -- comma separated, "*" subscribes to every event
+  "description" Character Varying (255) Null,
+  "is_active" Boolean Not Null Default True,
+  "created_at" BigInt Not Null Default 0,
+  "updated_at" BigInt Null,
+  "deleted_at" BigInt Null,
+
+  Constraint "pk_webhook_endpoints" Primary Key ("id")
+);
+
+Create Table If Not Exists "webhook"."webhook_deliveries" (
+  "id" UUID Not Null,
+  "endpoint_id" UUID Not Null,
+  "event_id" UUID Not Null,
+  "event_type" Character Varying (100) Not Null,
+  "payload" Text Not Null,
+  "status" Character Varying (20) Not Null Default 'PENDING', -- PENDING, SUCCEEDED, FAILED
+  "attempt_count" Int Not Null Default 0,
+  "next_attempt_at" BigInt Not Null Default 0,
+  "last_error" Text Null,
+  "created_at" BigInt Not Null Default 0,
+  "updated_at" BigInt Null,
+
+  Constraint "pk_webhook_deliveries" Primary Key ("id"),
+  Constraint "unq_webhook_deliveries_endpoint_event" Unique ("endpoint_id", "event_id"),
+  Constraint "fk_webhook_deliveries_endpoints" Foreign Key ("endpoint_id") References "webhook"."webhook_endpoints" ("id") On Delete Cascade
+);
+
+Create Index If Not Exists "idx_webhook_deliveries_due" On "webhook"."webhook_deliveries" ("status", "next_attempt_at");
+
+Create Table If Not Exists "webhook"."webhook_delivery_attempts" (
+  "id" UUID Not Null,
+  "delivery_id" UUID Not Null,
+  "attempt_no" Int Not Null,
+  "response_status" Int Null,
+  "response_body" Text Null,
+  "error" Text Null,
+  "duration_ms" BigInt Not Null Default 0,
+  "created_at" BigInt Not Null Default 0,
+
+  Constraint "pk_webhook_delivery_attempts" Primary Key ("id"),
+  Constraint "fk_webhook_delivery_attempts_deliveries" Foreign Key ("delivery_id") References "webhook"."webhook_deliveries" ("id") On Delete Cascade
+);
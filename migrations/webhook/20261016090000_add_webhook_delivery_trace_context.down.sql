@@ -0,0 +1 @@
+Alter Table "webhook"."webhook_deliveries" Drop Column If Exists "trace_context";
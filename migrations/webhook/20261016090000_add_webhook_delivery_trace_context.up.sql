@@ -0,0 +1 @@
+Alter Table "webhook"."webhook_deliveries" Add Column If Not Exists "trace_context" Text Null; -- JSON trace context of the request that enqueued the delivery
@@ -0,0 +1 @@
+Drop Table If Exists "webhook"."audit_logs";
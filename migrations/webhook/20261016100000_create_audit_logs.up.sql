@@ -0,0 +1,18 @@
+Create Table If Not Exists "webhook"."audit_logs" (
+  "id" UUID Not Null,
+  "actor" Character Varying (128) Not Null, -- user ID, client app or "system"
+  "action" Character Varying (100) Not Null, -- e.g. "webhook.endpoint.create"
+  "entity_type" Character Varying (100) Not Null,
+  "entity_id" Character Varying (128) Not Null,
+  "before" Text Null, -- JSON snapshot, masked; null on creation
+  "after" Text Null, -- JSON snapshot, masked; null on deletion
+  "diff" Text Null, -- JSON { field: { from, to } }
+  "request_id" Character Varying (128) Null,
+  "created_at" BigInt Not Null Default 0,
+
+  Constraint "pk_audit_logs" Primary Key ("id")
+);
+
+Create Index If Not Exists "idx_audit_logs_entity" On "webhook"."audit_logs" ("entity_type", "entity_id", "created_at");
+Create Index If Not Exists "idx_audit_logs_actor" On "webhook"."audit_logs" ("actor", "created_at");
+Create Index If Not Exists "idx_audit_logs_created_at" On "webhook"."audit_logs" ("created_at");
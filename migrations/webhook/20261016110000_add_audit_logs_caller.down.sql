@@ -0,0 +1,5 @@
+Drop Index If Exists "webhook"."idx_audit_logs_tenant";
+
+Alter Table "webhook"."audit_logs" Drop Column If Exists "roles";
+Alter Table "webhook"."audit_logs" Drop Column If Exists "client_app";
+Alter Table "webhook"."audit_logs" Drop Column If Exists "tenant_id";
@@ -0,0 +1,48 @@
+//go:build e2e
+// +build e2e
+
+package booking_test
+
+import (
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/saga"
+	"voyago/core-api/internal/modules/booking/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingCheckout_E2E_ConfirmsTheBooking(t *testing.T) {
+	a, db := setupTestServer(t)
+	bookingID := createBookingForGraphql(t, a, "CHECKOUT-E2E-001")
+
+	resp := a.POST("/api/v1/bookings/"+bookingID+"/checkout", map[string]interface{}{
+		"payment_method": "card",
+	})
+
+	var body map[string]interface{}
+	a.AssertJSONResponse(resp, 200, &body)
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, "CONFIRMED", data["status"])
+	assert.Equal(t, "PAID", data["payment_status"])
+	assert.NotEmpty(t, data["payment_reference"])
+
+	var inst saga.Instance
+	require.NoError(t, db.GetDB().First(&inst, "name = ? AND id = ?", usecase.CheckoutSagaName, bookingID).Error)
+	assert.Equal(t, saga.StatusCompleted, inst.Status)
+}
+
+func TestBookingCheckout_E2E_Twice(t *testing.T) {
+	a, _ := setupTestServer(t)
+	bookingID := createBookingForGraphql(t, a, "CHECKOUT-E2E-002")
+	a.AssertJSONResponse(a.POST("/api/v1/bookings/"+bookingID+"/checkout", map[string]interface{}{
+		"payment_method": "card",
+	}), 200, nil)
+
+	resp := a.POST("/api/v1/bookings/"+bookingID+"/checkout", map[string]interface{}{
+		"payment_method": "card",
+	})
+
+	a.AssertErrorResponse(resp, 409)
+}
@@ -0,0 +1,162 @@
+//go:build e2e
+// +build e2e
+
+package booking_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"voyago/core-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const graphqlUserID = "550e8400-e29b-41d4-a716-446655440000"
+
+func createBookingForGraphql(t *testing.T, a *helper.TestApp, code string) string {
+	t.Helper()
+
+	resp := a.POST("/api/v1/bookings/", map[string]interface{}{
+		"code":         code,
+		"user_id":      graphqlUserID,
+		"total_amount": 150.0,
+		"details": []map[string]interface{}{
+			{"product_id": "650e8400-e29b-41d4-a716-446655440000", "qty": 2, "price_per_unit": 50.0, "sub_total": 100.0},
+			{"product_id": "650e8400-e29b-41d4-a716-446655440001", "qty": 1, "price_per_unit": 50.0, "sub_total": 50.0},
+		},
+	})
+
+	var body map[string]interface{}
+	a.AssertJSONResponse(resp, 201, &body)
+	return body["data"].(map[string]interface{})["id"].(string)
+}
+
+// countDetailQueries counts the SELECTs issued against booking_details.
+func countDetailQueries(t *testing.T, db *gorm.DB) *int32 {
+	t.Helper()
+
+	var count int32
+	err := db.Callback().Query().After("gorm:query").Register("test:count_detail_queries", func(tx *gorm.DB) {
+		if tx.Statement.Table == "booking_details" {
+			atomic.AddInt32(&count, 1)
+		}
+	})
+	require.NoError(t, err)
+	return &count
+}
+
+func TestBookingGraphql_E2E_ListWithDetailsIsBatched(t *testing.T) {
+	a, db := setupTestServer(t)
+
+	for i := 0; i < 3; i++ {
+		createBookingForGraphql(t, a, fmt.Sprintf("GQL-%03d", i))
+	}
+	detailQueries := countDetailQueries(t, db.GetDB())
+
+	resp := a.POST("/graphql", map[string]interface{}{
+		"query": `query($userId: ID!) {
+			bookings(userId: $userId) { id code status details { productId qty subTotal } }
+		}`,
+		"variables": map[string]interface{}{"userId": graphqlUserID},
+	})
+
+	var body struct {
+		Data struct {
+			Bookings []struct {
+				ID      string
+				Code    string
+				Status  string
+				Details []struct {
+					ProductID string
+					Qty       int
+					SubTotal  float64
+				}
+			}
+		}
+		Errors []map[string]interface{}
+	}
+	a.AssertJSONResponse(resp, 200, &body)
+
+	require.Empty(t, body.Errors)
+	require.Len(t, body.Data.Bookings, 3)
+	for _, b := range body.Data.Bookings {
+		assert.Equal(t, "PENDING", b.Status)
+		assert.Len(t, b.Details, 2)
+	}
+
+	// One query for every booking of the page, not one per booking.
+	assert.Equal(t, int32(1), atomic.LoadInt32(detailQueries))
+}
+
+func TestBookingGraphql_E2E_BookingByID(t *testing.T) {
+	a, _ := setupTestServer(t)
+	id := createBookingForGraphql(t, a, "GQL-BY-ID")
+
+	resp := a.POST("/graphql", map[string]interface{}{
+		"query": fmt.Sprintf(`{
+			found: booking(id: %q) { code details { qty } }
+			missing: booking(id: "00000000-0000-4000-8000-000000000000") { code }
+		}`, id),
+	})
+
+	var body struct {
+		Data struct {
+			Found *struct {
+				Code    string
+				Details []struct{ Qty int }
+			}
+			Missing *struct{ Code string }
+		}
+		Errors []map[string]interface{}
+	}
+	a.AssertJSONResponse(resp, 200, &body)
+
+	require.Empty(t, body.Errors)
+	require.NotNil(t, body.Data.Found)
+	assert.Equal(t, "GQL-BY-ID", body.Data.Found.Code)
+	assert.Len(t, body.Data.Found.Details, 2)
+	assert.Nil(t, body.Data.Missing)
+}
+
+func TestBookingGraphql_E2E_ValidationError(t *testing.T) {
+	a, _ := setupTestServer(t)
+
+	resp := a.POST("/graphql", map[string]interface{}{
+		"query": `{ bookings(userId: "not-a-uuid") { id } }`,
+	})
+
+	var body struct {
+		Data   map[string]interface{}
+		Errors []struct {
+			Message    string
+			Extensions map[string]interface{}
+		}
+	}
+	a.AssertJSONResponse(resp, 200, &body)
+
+	require.Len(t, body.Errors, 1)
+	assert.Equal(t, "INVALID_REQUEST", body.Errors[0].Extensions["code"])
+	assert.NotEmpty(t, body.Errors[0].Extensions["details"])
+}
+
+func TestBookingGraphql_E2E_SyntaxError(t *testing.T) {
+	a, _ := setupTestServer(t)
+
+	resp := a.POST("/graphql", map[string]interface{}{
+		"query": `{ bookings( }`,
+	})
+
+	var body struct {
+		Errors []struct {
+			Extensions map[string]interface{}
+		}
+	}
+	a.AssertJSONResponse(resp, 400, &body)
+
+	require.NotEmpty(t, body.Errors)
+	assert.Equal(t, "INVALID_REQUEST", body.Errors[0].Extensions["code"])
+}
@@ -0,0 +1,51 @@
+//go:build e2e
+// +build e2e
+
+package booking_test
+
+import (
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/modules/booking/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingPaymentStatus_E2E_PaidConfirmsBooking(t *testing.T) {
+	a, db := setupTestServer(t)
+	bookingID := createBookingForGraphql(t, a, "PAY-E2E-001")
+
+	resp := a.PATCH("/api/v1/bookings/"+bookingID+"/payment-status", map[string]interface{}{
+		"payment_status":    "PAID",
+		"payment_reference": "PAY-REF-001",
+	})
+
+	var body map[string]interface{}
+	a.AssertJSONResponse(resp, 200, &body)
+	data := body["data"].(map[string]interface{})
+	assert.Equal(t, "PAID", data["payment_status"])
+	assert.Equal(t, "PAY-REF-001", data["payment_reference"])
+
+	// The booking is confirmed asynchronously by the event consumer.
+	require.Eventually(t, func() bool {
+		var booking entity.Booking
+		if err := db.GetDB().First(&booking, "id = ?", bookingID).Error; err != nil {
+			return false
+		}
+		return booking.Status == entity.BookingStatusConfirmed
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestBookingPaymentStatus_E2E_InvalidTransition(t *testing.T) {
+	a, _ := setupTestServer(t)
+	bookingID := createBookingForGraphql(t, a, "PAY-E2E-002")
+
+	resp := a.PATCH("/api/v1/bookings/"+bookingID+"/payment-status", map[string]interface{}{
+		"payment_status":    "REFUNDED",
+		"payment_reference": "PAY-REF-002",
+	})
+
+	a.AssertErrorResponse(resp, 409)
+}
@@ -0,0 +1,136 @@
+//go:build e2e
+// +build e2e
+
+package booking_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"voyago/core-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sseEvent struct {
+	ID    string
+	Event string
+	Data  map[string]interface{}
+}
+
+// listen serves the app on a real socket: streamed responses cannot be read
+// through fiber's App.Test.
+func listen(t *testing.T, a *helper.TestApp) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = a.App.Listener(ln) }()
+	t.Cleanup(func() { _ = a.App.ShutdownWithTimeout(time.Second) })
+
+	return "http://" + ln.Addr().String()
+}
+
+// readEvents decodes the events of a stream, skipping heartbeat comments.
+func readEvents(t *testing.T, body *bufio.Reader, events chan<- sseEvent) {
+	var evt sseEvent
+	for {
+		line, err := body.ReadString('\n')
+		if err != nil {
+			close(events)
+			return
+		}
+		line = strings.TrimRight(line, "\n")
+
+		switch {
+		case line == "":
+			if evt.Event != "" {
+				events <- evt
+			}
+			evt = sseEvent{}
+		case strings.HasPrefix(line, "id: "):
+			evt.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			evt.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			assert.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &evt.Data))
+		}
+	}
+}
+
+func nextEvent(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+
+	select {
+	case evt, ok := <-events:
+		require.True(t, ok, "stream closed")
+		return evt
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+		return sseEvent{}
+	}
+}
+
+func TestBookingStream_E2E_StreamsStatusChanges(t *testing.T) {
+	a, _ := setupTestServer(t)
+	bookingID := createBookingForGraphql(t, a, "SSE-E2E-001")
+	baseURL := listen(t, a)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/api/v1/bookings/"+bookingID+"/events", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan sseEvent, 8)
+	go readEvents(t, bufio.NewReader(resp.Body), events)
+
+	snapshot := nextEvent(t, events)
+	assert.Equal(t, "booking.snapshot", snapshot.Event)
+	assert.Equal(t, "PENDING", snapshot.Data["status"])
+
+	patch := a.PATCH("/api/v1/bookings/"+bookingID+"/payment-status", map[string]interface{}{
+		"payment_status":    "PAID",
+		"payment_reference": "SSE-REF-001",
+	})
+	a.AssertJSONResponse(patch, 200, nil)
+
+	paid := nextEvent(t, events)
+	assert.Equal(t, "booking.payment_status_changed", paid.Event)
+	assert.NotEmpty(t, paid.ID)
+	assert.Equal(t, "PAID", paid.Data["new_status"])
+
+	confirmed := nextEvent(t, events)
+	assert.Equal(t, "booking.status_changed", confirmed.Event)
+	assert.Equal(t, "PENDING", confirmed.Data["old_status"])
+	assert.Equal(t, "CONFIRMED", confirmed.Data["new_status"])
+}
+
+func TestBookingStream_E2E_UnknownBooking(t *testing.T) {
+	a, _ := setupTestServer(t)
+
+	resp := a.GET("/api/v1/bookings/750e8400-e29b-41d4-a716-446655440099/events")
+
+	a.AssertErrorResponse(resp, 404)
+}
+
+func TestBookingStream_E2E_InvalidID(t *testing.T) {
+	a, _ := setupTestServer(t)
+
+	resp := a.GET("/api/v1/bookings/not-a-uuid/events")
+
+	a.AssertErrorResponse(resp, 400)
+}
@@ -0,0 +1,76 @@
+//go:build e2e
+// +build e2e
+
+package booking_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/pkg/gateway"
+	"voyago/core-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tenancyGatewaySecret is the secret of the gateway relaying the requests of
+// postAsTenant: the header resolver only reads the requests of the gateway.
+const tenancyGatewaySecret = "e2e-gateway-secret"
+
+// postAsTenant sends a JSON request of the gateway on behalf of tenant
+// (X-Tenant-ID, read by the header resolver).
+func postAsTenant(t *testing.T, a *helper.TestApp, tenant, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	raw, err := json.Marshal(body)
+	require.NoError(t, err)
+	req := httptest.NewRequest("POST", path, bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant-ID", tenant)
+	req.Header.Set(gateway.HeaderSecret, tenancyGatewaySecret)
+	return a.Do(req)
+}
+
+func TestBookingTenancy_E2E_RowsAreScopedToTheTenant(t *testing.T) {
+	a := helper.NewInMemoryApp(t, func(cfg *config.Config) {
+		cfg.Tenancy.Enabled = true
+		cfg.Tenancy.Resolvers = []string{config.TenantResolverHeader}
+		cfg.Tenancy.Header = "X-Tenant-ID"
+		cfg.Security.Gateway.Secret = tenancyGatewaySecret
+	})
+	db := a.DB("booking")
+
+	resp := postAsTenant(t, a, "acme", "/api/v1/bookings/", map[string]any{
+		"code":         "TENANT-001",
+		"user_id":      graphqlUserID,
+		"total_amount": 50.0,
+		"details": []map[string]any{
+			{"product_id": "650e8400-e29b-41d4-a716-446655440000", "qty": 1, "price_per_unit": 50.0, "sub_total": 50.0},
+		},
+	})
+	require.Equal(t, 201, resp.Code, resp.Body.String())
+
+	var stored entity.Booking
+	require.NoError(t, db.GetDB().Where("booking_code = ?", "TENANT-001").First(&stored).Error)
+	require.NotNil(t, stored.TenantID)
+	assert.Equal(t, "acme", *stored.TenantID)
+
+	countFor := func(tenant string) int {
+		resp := postAsTenant(t, a, tenant, "/graphql", map[string]any{
+			"query":     `query($userId: ID!) { bookings(userId: $userId) { code } }`,
+			"variables": map[string]any{"userId": graphqlUserID},
+		})
+		var body struct {
+			Data struct{ Bookings []struct{ Code string } }
+		}
+		a.AssertJSONResponse(resp, 200, &body)
+		return len(body.Data.Bookings)
+	}
+	assert.Equal(t, 1, countFor("acme"))
+	assert.Equal(t, 0, countFor("globex"), "the bookings of another tenant are not visible")
+}
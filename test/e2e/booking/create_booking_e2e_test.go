@@ -5,66 +5,33 @@ package booking_test
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
-	"voyago/core-api/internal/infrastructure/config"
 	database "voyago/core-api/internal/infrastructure/db"
-	"voyago/core-api/internal/infrastructure/logger"
-	"voyago/core-api/internal/infrastructure/telemetry/tracer"
-	"voyago/core-api/internal/infrastructure/validator"
-	"voyago/core-api/internal/modules/booking"
 	"voyago/core-api/internal/modules/booking/usecase"
 	"voyago/core-api/test/helper"
 
-	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
-// setupTestServer initializes a test Fiber app with all dependencies
-func setupTestServer(t *testing.T) (*helper.HTTPTestHelper, database.Database) {
+// setupTestServer boots the full application in in-memory mode
+// (SQLite per domain, NoOp telemetry, in-process event bus).
+func setupTestServer(t *testing.T) (*helper.TestApp, database.Database) {
 	t.Helper()
 
-	// Setup test database
-	db := helper.SetupTestDB(t)
-
-	// Initialize infrastructure
-	cfg := &config.Config{
-		App: config.AppConfig{
-			Name: "voyago-test",
-			Env:  "test",
-		},
-	}
-	log := logger.NewNoOpLogger()
-	trc := tracer.NewNoOpTracer()
-	val := validator.NewPlaygroundValidator()
-
-	// Create Fiber app directly
-	app := fiber.New(fiber.Config{
-		AppName: cfg.App.Name,
-	})
-
-	// Register booking module
-	booking.RegisterModule(booking.ModuleConfig{
-		Config: cfg,
-		Server: app,
-		DB:     db,
-		Log:    log,
-		Val:    val,
-		Tracer: trc,
-	})
-
-	return helper.NewHTTPTestHelper(app, t), db
+	a := helper.NewInMemoryApp(t)
+	return a, a.DB("booking")
 }
 
 // TestCreateBooking_E2E_Success tests successful booking creation via HTTP
 func TestCreateBooking_E2E_Success(t *testing.T) {
 	// Setup
-	httpHelper, db := setupTestServer(t)
-	defer helper.CleanupTestDB(t, db)
-
-	// Clean tables
-	helper.TruncateTables(t, db.GetDB(), "booking_details", "bookings")
+	httpHelper, _ := setupTestServer(t)
 
 	// Prepare request
 	productName := "E2E Test Product"
@@ -84,13 +51,13 @@ func TestCreateBooking_E2E_Success(t *testing.T) {
 	}
 
 	// Execute
-	resp := httpHelper.POST("/bookings/", requestBody)
+	resp := httpHelper.POST("/api/v1/bookings/", requestBody)
 
 	// Assert response
 	var response map[string]interface{}
 	httpHelper.AssertJSONResponse(resp, 201, &response)
 
-	assert.Equal(t, "success", response["status"])
+	assert.Equal(t, true, response["success"])
 	assert.Equal(t, "Booking created successfully", response["message"])
 
 	// Verify response data
@@ -106,8 +73,7 @@ func TestCreateBooking_E2E_Success(t *testing.T) {
 // TestCreateBooking_E2E_ValidationError tests validation error responses
 func TestCreateBooking_E2E_ValidationError(t *testing.T) {
 	// Setup
-	httpHelper, db := setupTestServer(t)
-	defer helper.CleanupTestDB(t, db)
+	httpHelper, _ := setupTestServer(t)
 
 	testCases := []struct {
 		name               string
@@ -167,15 +133,15 @@ func TestCreateBooking_E2E_ValidationError(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Execute
-			resp := httpHelper.POST("/bookings/", tc.requestBody)
+			resp := httpHelper.POST("/api/v1/bookings/", tc.requestBody)
 
 			// Assert
 			errResp := httpHelper.AssertErrorResponse(resp, tc.expectedStatus)
 
-			assert.Equal(t, "error", errResp["status"])
+			assert.Equal(t, false, errResp["success"])
 
-			// Check if details field exists for validation errors
-			if details, ok := errResp["details"]; ok {
+			// Check if errors field exists for validation errors
+			if details, ok := errResp["errors"]; ok {
 				detailsArray, ok := details.([]interface{})
 				require.True(t, ok, "Details should be an array")
 				require.NotEmpty(t, detailsArray, "Details array should not be empty")
@@ -187,11 +153,7 @@ func TestCreateBooking_E2E_ValidationError(t *testing.T) {
 // TestCreateBooking_E2E_DuplicateCode tests duplicate booking code handling
 func TestCreateBooking_E2E_DuplicateCode(t *testing.T) {
 	// Setup
-	httpHelper, db := setupTestServer(t)
-	defer helper.CleanupTestDB(t, db)
-
-	// Clean tables
-	helper.TruncateTables(t, db.GetDB(), "booking_details", "bookings")
+	httpHelper, _ := setupTestServer(t)
 
 	// Create first booking
 	productName := "Product 1"
@@ -211,42 +173,37 @@ func TestCreateBooking_E2E_DuplicateCode(t *testing.T) {
 	}
 
 	// First request should succeed
-	resp1 := httpHelper.POST("/bookings/", requestBody)
+	resp1 := httpHelper.POST("/api/v1/bookings/", requestBody)
 	var successResp map[string]interface{}
 	httpHelper.AssertJSONResponse(resp1, 201, &successResp)
-	assert.Equal(t, "success", successResp["status"])
+	assert.Equal(t, true, successResp["success"])
 
 	// Second request with same code should fail
-	resp2 := httpHelper.POST("/bookings/", requestBody)
-	errResp := httpHelper.AssertErrorResponse(resp2, 400)
+	resp2 := httpHelper.POST("/api/v1/bookings/", requestBody)
+	errResp := httpHelper.AssertErrorResponse(resp2, 409)
 
-	assert.Equal(t, "error", errResp["status"])
+	assert.Equal(t, false, errResp["success"])
 	assert.Contains(t, errResp["message"], "already exists")
 }
 
 // TestCreateBooking_E2E_MalformedJSON tests malformed JSON request handling
 func TestCreateBooking_E2E_MalformedJSON(t *testing.T) {
 	// Setup
-	httpHelper, db := setupTestServer(t)
-	defer helper.CleanupTestDB(t, db)
+	httpHelper, _ := setupTestServer(t)
 
 	// Create a request with invalid JSON (using string instead of struct)
 	// This tests the BodyParser error handling
-	resp := httpHelper.POST("/bookings/", "invalid json")
+	resp := httpHelper.POST("/api/v1/bookings/", "invalid json")
 
 	// Assert
 	errResp := httpHelper.AssertErrorResponse(resp, 400)
-	assert.Equal(t, "error", errResp["status"])
+	assert.Equal(t, false, errResp["success"])
 }
 
 // TestCreateBooking_E2E_AmountMismatch tests amount validation
 func TestCreateBooking_E2E_AmountMismatch(t *testing.T) {
 	// Setup
-	httpHelper, db := setupTestServer(t)
-	defer helper.CleanupTestDB(t, db)
-
-	// Clean tables
-	helper.TruncateTables(t, db.GetDB(), "booking_details", "bookings")
+	httpHelper, _ := setupTestServer(t)
 
 	// Request with mismatched total amount
 	productName := "Product"
@@ -266,11 +223,11 @@ func TestCreateBooking_E2E_AmountMismatch(t *testing.T) {
 	}
 
 	// Execute
-	resp := httpHelper.POST("/bookings/", requestBody)
+	resp := httpHelper.POST("/api/v1/bookings/", requestBody)
 
 	// Assert
 	errResp := httpHelper.AssertErrorResponse(resp, 400)
-	assert.Equal(t, "error", errResp["status"])
+	assert.Equal(t, false, errResp["success"])
 	assert.Contains(t, errResp["message"], "amount")
 }
 
@@ -278,10 +235,6 @@ func TestCreateBooking_E2E_AmountMismatch(t *testing.T) {
 func TestCreateBooking_E2E_CompleteFlow(t *testing.T) {
 	// Setup
 	httpHelper, db := setupTestServer(t)
-	defer helper.CleanupTestDB(t, db)
-
-	// Clean tables
-	helper.TruncateTables(t, db.GetDB(), "booking_details", "bookings")
 
 	// Step 1: Create a booking with multiple details
 	product1 := "Product A"
@@ -292,14 +245,14 @@ func TestCreateBooking_E2E_CompleteFlow(t *testing.T) {
 		"total_amount": 300.0,
 		"details": []map[string]interface{}{
 			{
-				"product_id":     "prod-001",
+				"product_id":     "650e8400-e29b-41d4-a716-446655440001",
 				"product_name":   product1,
 				"qty":            2,
 				"price_per_unit": 50.0,
 				"sub_total":      100.0,
 			},
 			{
-				"product_id":     "prod-002",
+				"product_id":     "650e8400-e29b-41d4-a716-446655440002",
 				"product_name":   product2,
 				"qty":            4,
 				"price_per_unit": 50.0,
@@ -309,13 +262,13 @@ func TestCreateBooking_E2E_CompleteFlow(t *testing.T) {
 	}
 
 	// Execute
-	resp := httpHelper.POST("/bookings/", requestBody)
+	resp := httpHelper.POST("/api/v1/bookings/", requestBody)
 
 	// Assert
 	var response map[string]interface{}
 	httpHelper.AssertJSONResponse(resp, 201, &response)
 
-	assert.Equal(t, "success", response["status"])
+	assert.Equal(t, true, response["success"])
 
 	data, ok := response["data"].(map[string]interface{})
 	require.True(t, ok)
@@ -338,4 +291,58 @@ func TestCreateBooking_E2E_CompleteFlow(t *testing.T) {
 	assert.Equal(t, "FLOW001", found.BookingCode)
 	assert.Equal(t, 300.0, found.TotalAmount)
 	assert.Len(t, found.Details, 2)
+
+	var detailCount int64
+	require.NoError(t, db.GetDB().Table("booking_details").Where("booking_id = ?", bookingID).Count(&detailCount).Error)
+	assert.Equal(t, int64(2), detailCount)
+}
+
+// TestCreateBooking_E2E_XMLRequestMsgpackResponse tests content negotiation:
+// the body is parsed from XML and the response encoded as MessagePack.
+func TestCreateBooking_E2E_XMLRequestMsgpackResponse(t *testing.T) {
+	a, _ := setupTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/bookings/", strings.NewReader(`<?xml version="1.0"?>
+		<request>
+			<code>BKG-XML-001</code>
+			<user_id>550e8400-e29b-41d4-a716-446655440000</user_id>
+			<total_amount>100</total_amount>
+			<details>
+				<item>
+					<product_id>650e8400-e29b-41d4-a716-446655440000</product_id>
+					<qty>2</qty>
+					<price_per_unit>50</price_per_unit>
+					<sub_total>100</sub_total>
+				</item>
+			</details>
+		</request>`))
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Accept", "application/msgpack")
+
+	resp := a.Do(req)
+
+	require.Equal(t, 201, resp.Code, resp.Body.String())
+	assert.Equal(t, "application/msgpack", resp.Header().Get("Content-Type"))
+
+	var body map[string]interface{}
+	require.NoError(t, msgpack.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Equal(t, true, body["success"])
+	assert.Equal(t, "BKG-XML-001", body["data"].(map[string]interface{})["code"])
+}
+
+// TestCreateBooking_E2E_XMLErrorResponse tests that errors follow the
+// negotiated format.
+func TestCreateBooking_E2E_XMLErrorResponse(t *testing.T) {
+	a, _ := setupTestServer(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/bookings/", strings.NewReader(`<request><code>BKG</request>`))
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Accept", "application/xml")
+
+	resp := a.Do(req)
+
+	assert.Equal(t, 400, resp.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Contains(t, resp.Body.String(), "<success>false</success>")
+	assert.Contains(t, resp.Body.String(), "<error_code>MALFORMED_REQUEST</error_code>")
 }
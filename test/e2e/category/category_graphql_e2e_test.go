@@ -0,0 +1,134 @@
+//go:build e2e
+// +build e2e
+
+package category_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/seed"
+	"voyago/core-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// hotelID is the sample category Hotels, a child of Stays.
+const hotelID = "0195a5c0-0000-7000-8000-000000000211"
+
+// setupTestServer returns the app with the sample categories: the roots
+// Stays (Hotels, Villas) and Activities (Tours).
+func setupTestServer(t *testing.T) (*helper.TestApp, *gorm.DB) {
+	t.Helper()
+
+	a := helper.NewInMemoryApp(t)
+	db := a.DB("category")
+	_, err := seed.Run(context.Background(), db, "development", app.Seeders().Seeders("category"))
+	require.NoError(t, err)
+	return a, db.GetDB()
+}
+
+// countCategoryQueries counts the SELECTs issued against categories.
+func countCategoryQueries(t *testing.T, db *gorm.DB) *int32 {
+	t.Helper()
+
+	var count int32
+	err := db.Callback().Query().After("gorm:query").Register("test:count_category_queries", func(tx *gorm.DB) {
+		if tx.Statement.Table == "categories" {
+			atomic.AddInt32(&count, 1)
+		}
+	})
+	require.NoError(t, err)
+	return &count
+}
+
+type category struct {
+	Name     string
+	Slug     string
+	Parent   *struct{ Name string }
+	Children []category
+}
+
+func TestCategoryGraphql_E2E_TreeIsBatched(t *testing.T) {
+	a, db := setupTestServer(t)
+	queries := countCategoryQueries(t, db)
+
+	resp := a.POST("/graphql", map[string]interface{}{
+		"query": `{
+			categories { name slug parent { name } children { name parent { name } children { name } } }
+		}`,
+	})
+
+	var body struct {
+		Data struct {
+			Categories []category
+		}
+		Errors []map[string]interface{}
+	}
+	a.AssertJSONResponse(resp, 200, &body)
+
+	require.Empty(t, body.Errors)
+	require.Len(t, body.Data.Categories, 2)
+	stays, activities := body.Data.Categories[0], body.Data.Categories[1]
+	assert.Equal(t, "stays", stays.Slug)
+	assert.Nil(t, stays.Parent)
+	require.Len(t, stays.Children, 2)
+	assert.Equal(t, "Hotels", stays.Children[0].Name)
+	assert.Equal(t, "Villas", stays.Children[1].Name)
+	assert.Equal(t, "Stays", stays.Children[0].Parent.Name)
+	assert.Empty(t, stays.Children[0].Children)
+	require.Len(t, activities.Children, 1)
+	assert.Equal(t, "Tours", activities.Children[0].Name)
+
+	// The roots, then one query per level of children and one for their
+	// parents: not one per category.
+	assert.Equal(t, int32(4), atomic.LoadInt32(queries))
+}
+
+func TestCategoryGraphql_E2E_CategoryByID(t *testing.T) {
+	a, _ := setupTestServer(t)
+
+	resp := a.POST("/graphql", map[string]interface{}{
+		"query": `{
+			found: category(id: "` + hotelID + `") { name parent { name } }
+			missing: category(id: "00000000-0000-4000-8000-000000000000") { name }
+		}`,
+	})
+
+	var body struct {
+		Data struct {
+			Found   *category
+			Missing *category
+		}
+		Errors []map[string]interface{}
+	}
+	a.AssertJSONResponse(resp, 200, &body)
+
+	require.Empty(t, body.Errors)
+	require.NotNil(t, body.Data.Found)
+	assert.Equal(t, "Hotels", body.Data.Found.Name)
+	assert.Equal(t, "Stays", body.Data.Found.Parent.Name)
+	assert.Nil(t, body.Data.Missing)
+}
+
+func TestCategoryGraphql_E2E_InvalidID(t *testing.T) {
+	a, _ := setupTestServer(t)
+
+	resp := a.POST("/graphql", map[string]interface{}{
+		"query": `{ category(id: "not-a-uuid") { name } }`,
+	})
+
+	var body struct {
+		Errors []struct {
+			Extensions map[string]interface{}
+		}
+	}
+	a.AssertJSONResponse(resp, 200, &body)
+
+	require.Len(t, body.Errors, 1)
+	assert.Equal(t, "INVALID_REQUEST", body.Errors[0].Extensions["code"])
+}
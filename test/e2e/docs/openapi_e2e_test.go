@@ -0,0 +1,49 @@
+//go:build e2e
+// +build e2e
+
+package docs_test
+
+import (
+	"testing"
+
+	"voyago/core-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI_E2E_DocumentListsModuleRoutes(t *testing.T) {
+	a := helper.NewInMemoryApp(t)
+
+	resp := a.GET("/openapi.json")
+
+	var doc map[string]interface{}
+	a.AssertJSONResponse(resp, 200, &doc)
+	assert.Equal(t, "3.0.3", doc["openapi"])
+
+	paths := doc["paths"].(map[string]interface{})
+	require.Contains(t, paths, "/api/v1/bookings")
+	assert.Contains(t, paths["/api/v1/bookings"], "post")
+	create := paths["/api/v1/bookings"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"bookings"}, create["tags"], "tags ignore the version prefix")
+	assert.NotContains(t, create, "deprecated")
+	assert.Contains(t, paths, "/api/v1/bookings/{id}/payment-status")
+	assert.Contains(t, paths, "/api/v1/webhooks/{id}/deliveries")
+	assert.Contains(t, paths, "/health")
+
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	assert.Contains(t, schemas, "CreateBookingRequest")
+	assert.Contains(t, schemas, "Response")
+}
+
+func TestOpenAPI_E2E_SwaggerUIPointsAtDocument(t *testing.T) {
+	a := helper.NewInMemoryApp(t)
+
+	resp := a.GET("/docs/index.html")
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), "swagger-ui")
+
+	resp = a.GET("/docs/swagger-initializer.js")
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `url: "/openapi.json"`)
+}
@@ -0,0 +1,135 @@
+//go:build e2e
+// +build e2e
+
+package webhook_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "e2e-shared-secret-0123"
+
+// receivedWebhook is a request captured by the fake receiver.
+type receivedWebhook struct {
+	Headers http.Header
+	Body    []byte
+}
+
+// TestWebhook_E2E_BookingCreatedIsDelivered covers the full asynchronous flow:
+// HTTP request -> booking use case -> event bus -> webhook enqueue -> worker -> receiver.
+func TestWebhook_E2E_BookingCreatedIsDelivered(t *testing.T) {
+	// Setup: a receiver standing in for the subscriber's system
+	var (
+		mu       sync.Mutex
+		received []receivedWebhook
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, receivedWebhook{Headers: r.Header.Clone(), Body: body})
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	app := helper.NewInMemoryApp(t)
+
+	// Step 1: Register an endpoint
+	resp := app.POST("/api/v1/webhooks/", map[string]any{
+		"url":         receiver.URL,
+		"secret":      testSecret,
+		"event_types": []string{"booking.created"},
+	})
+	assert.NotContains(t, resp.Body.String(), testSecret, "secret must never be returned")
+	var created map[string]any
+	app.AssertJSONResponse(resp, 201, &created)
+	endpointID := created["data"].(map[string]any)["id"].(string)
+
+	// Step 2: Create a booking, which publishes booking.created
+	resp = app.POST("/api/v1/bookings/", map[string]any{
+		"code":         "WH_E2E001",
+		"user_id":      "550e8400-e29b-41d4-a716-446655440000",
+		"total_amount": 100.0,
+		"details": []map[string]any{
+			{
+				"product_id":     "650e8400-e29b-41d4-a716-446655440000",
+				"qty":            2,
+				"price_per_unit": 50.0,
+				"sub_total":      100.0,
+			},
+		},
+	})
+	require.Equal(t, 201, resp.Code)
+
+	// Step 3: The worker delivers it asynchronously
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	mu.Lock()
+	got := received[0]
+	mu.Unlock()
+
+	assert.Equal(t, "booking.created", got.Headers.Get(entity.HeaderEvent))
+	assert.NotEmpty(t, got.Headers.Get(entity.HeaderDelivery))
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(got.Body, &payload))
+	assert.Equal(t, "application/cloudevents+json", got.Headers.Get("Content-Type"))
+	assert.Equal(t, "1.0", payload["specversion"])
+	assert.Equal(t, "booking.created", payload["type"])
+	assert.Equal(t, "WH_E2E001", payload["data"].(map[string]any)["booking_code"])
+
+	// The signature must verify with the shared secret
+	signature := got.Headers.Get(entity.HeaderSignature)
+	ts, err := strconv.ParseInt(strings.TrimPrefix(strings.Split(signature, ",")[0], "t="), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, entity.Sign(testSecret, ts, got.Body), signature)
+
+	// Step 4: The delivery and its attempt are visible through the API
+	require.Eventually(t, func() bool {
+		resp := app.GET("/api/v1/webhooks/" + endpointID + "/deliveries")
+		var list map[string]any
+		if json.Unmarshal(resp.Body.Bytes(), &list) != nil {
+			return false
+		}
+		deliveries, _ := list["data"].([]any)
+		if len(deliveries) != 1 {
+			return false
+		}
+		d := deliveries[0].(map[string]any)
+		return d["status"] == string(entity.DeliveryStatusSucceeded) && len(d["attempts"].([]any)) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestWebhook_E2E_InternalTargetIsRejected(t *testing.T) {
+	app := helper.NewInMemoryApp(t, func(cfg *config.Config) { cfg.Webhook.AllowInternalTargets = false })
+
+	for _, url := range []string{"https://169.254.169.254/latest/meta-data", "https://localhost:8080/hooks", "http://partner.example.com/hooks"} {
+		resp := app.POST("/api/v1/webhooks/", map[string]any{
+			"url":         url,
+			"secret":      testSecret,
+			"event_types": []string{"booking.created"},
+		})
+
+		body := app.AssertErrorResponse(resp, 422)
+		assert.Equal(t, entity.CodeWebhookEndpointInvalidURL, body["error_code"], url)
+	}
+}
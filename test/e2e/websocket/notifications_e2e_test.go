@@ -0,0 +1,122 @@
+//go:build e2e
+// +build e2e
+
+package websocket_test
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	wsserver "voyago/core-api/internal/infrastructure/websocket"
+	"voyago/core-api/test/helper"
+
+	fasthttpws "github.com/fasthttp/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const userID = "550e8400-e29b-41d4-a716-446655440000"
+
+// listen serves the app on a real socket: upgrades cannot go through
+// fiber's App.Test.
+func listen(t *testing.T, a *helper.TestApp) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = a.App.Listener(ln) }()
+	t.Cleanup(func() { _ = a.App.ShutdownWithTimeout(time.Second) })
+
+	return "ws://" + ln.Addr().String() + "/ws"
+}
+
+func dial(t *testing.T, url, user string) *fasthttpws.Conn {
+	t.Helper()
+
+	token := wsserver.NewTokenAuthenticator(helper.WebsocketSecret).Issue(user, time.Minute)
+	conn, resp, err := fasthttpws.DefaultDialer.Dial(url+"?token="+token, nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// nextMessage reads the next notification, or fails after 2 seconds.
+func nextMessage(t *testing.T, conn *fasthttpws.Conn) map[string]interface{} {
+	t.Helper()
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg map[string]interface{}
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func createBooking(t *testing.T, a *helper.TestApp, code string) string {
+	t.Helper()
+
+	resp := a.POST("/api/v1/bookings/", map[string]interface{}{
+		"code":         code,
+		"user_id":      userID,
+		"total_amount": 100.0,
+		"details": []map[string]interface{}{
+			{"product_id": "650e8400-e29b-41d4-a716-446655440000", "qty": 2, "price_per_unit": 50.0, "sub_total": 100.0},
+		},
+	})
+
+	var body map[string]interface{}
+	a.AssertJSONResponse(resp, 201, &body)
+	return body["data"].(map[string]interface{})["id"].(string)
+}
+
+func TestWebsocket_E2E_PushesBookingEventsToTheirUser(t *testing.T) {
+	a := helper.NewInMemoryApp(t)
+	url := listen(t, a)
+	conn := dial(t, url, userID)
+	// Give the hub time to register the socket before events are published.
+	time.Sleep(50 * time.Millisecond)
+
+	bookingID := createBooking(t, a, "WS-E2E-001")
+
+	created := nextMessage(t, conn)
+	assert.Equal(t, "booking.created", created["type"])
+	assert.NotEmpty(t, created["id"])
+	assert.Equal(t, bookingID, created["data"].(map[string]interface{})["booking_id"])
+
+	patch := a.PATCH("/api/v1/bookings/"+bookingID+"/payment-status", map[string]interface{}{
+		"payment_status":    "PAID",
+		"payment_reference": "WS-REF-001",
+	})
+	a.AssertJSONResponse(patch, 200, nil)
+
+	// Events are dispatched concurrently: their order is not guaranteed.
+	newStatus := map[string]interface{}{}
+	for i := 0; i < 2; i++ {
+		msg := nextMessage(t, conn)
+		newStatus[msg["type"].(string)] = msg["data"].(map[string]interface{})["new_status"]
+	}
+	assert.Equal(t, map[string]interface{}{
+		"booking.payment_status_changed": "PAID",
+		"booking.status_changed":         "CONFIRMED",
+	}, newStatus)
+}
+
+func TestWebsocket_E2E_RejectsMissingToken(t *testing.T) {
+	a := helper.NewInMemoryApp(t)
+	url := listen(t, a)
+
+	_, resp, err := fasthttpws.DefaultDialer.Dial(url, nil)
+
+	require.ErrorIs(t, err, fasthttpws.ErrBadHandshake)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestWebsocket_E2E_RequiresUpgrade(t *testing.T) {
+	a := helper.NewInMemoryApp(t)
+
+	resp := a.GET("/ws")
+
+	a.AssertErrorResponse(resp, 426)
+}
@@ -0,0 +1,85 @@
+//go:build e2e
+// +build e2e
+
+package worker_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWorker_E2E_DeliversTheWebhooksOfAStandaloneApp covers the split
+// runtime: the app enqueues the deliveries of its events, the worker sends
+// them.
+func TestWorker_E2E_DeliversTheWebhooksOfAStandaloneApp(t *testing.T) {
+	var received atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	app := helper.NewInMemoryApp(t, helper.Standalone)
+
+	resp := app.POST("/api/v1/webhooks/", map[string]any{
+		"url":         receiver.URL,
+		"secret":      "worker-shared-secret-0123",
+		"event_types": []string{"booking.created"},
+	})
+	require.Equal(t, 201, resp.Code)
+
+	resp = app.POST("/api/v1/bookings/", map[string]any{
+		"code":         "WK_E2E001",
+		"user_id":      "550e8400-e29b-41d4-a716-446655440000",
+		"total_amount": 100.0,
+		"details": []map[string]any{
+			{
+				"product_id":     "650e8400-e29b-41d4-a716-446655440000",
+				"qty":            2,
+				"price_per_unit": 50.0,
+				"sub_total":      100.0,
+			},
+		},
+	})
+	require.Equal(t, 201, resp.Code)
+
+	// The app has no dispatcher: the delivery stays pending.
+	require.Eventually(t, func() bool {
+		var pending int64
+		app.DB("webhook").GetDB().Model(&entity.WebhookDelivery{}).
+			Where("status = ?", entity.DeliveryStatusPending).Count(&pending)
+		return pending == 1
+	}, 5*time.Second, 50*time.Millisecond)
+	time.Sleep(1500 * time.Millisecond) // a dispatcher interval
+	assert.Zero(t, received.Load())
+
+	helper.NewInMemoryWorker(t)
+	require.Eventually(t, func() bool { return received.Load() == 1 }, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestWorker_E2E_HealthReportsTheDrain(t *testing.T) {
+	w := helper.NewInMemoryWorker(t)
+
+	resp := w.Health()
+	require.Equal(t, http.StatusOK, resp.Code)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Equal(t, "UP", body["status"])
+
+	w.Stop()
+
+	resp = w.Health()
+	require.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Equal(t, "DRAINING", body["status"])
+}
@@ -0,0 +1,150 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	server "voyago/core-api/internal/infrastructure/http"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/infrastructure/validator"
+	"voyago/core-api/internal/modules"
+)
+
+// TestApp is the complete application running in in-memory mode.
+type TestApp struct {
+	*HTTPTestHelper
+
+	// Bus is the in-process event bus shared by every module.
+	Bus eventbus.Bus
+
+	mu  sync.Mutex
+	dbs map[string]database.Database
+}
+
+// NewInMemoryApp boots the entire Fiber application (middlewares, every domain
+// module and background workers) on top of:
+//   - one in-memory SQLite database per domain,
+//   - NoOp logger, tracer and metrics,
+//   - the in-process event bus.
+//
+// No external service is required, which makes it suitable for CI.
+// Everything is shut down automatically when the test ends. opts change the
+// configuration of the app and of every domain.
+//
+// Example:
+//
+//	a := helper.NewInMemoryApp(t)
+//	resp := a.POST("/api/v1/bookings/", body)
+func NewInMemoryApp(t *testing.T, opts ...func(*config.Config)) *TestApp {
+	t.Helper()
+
+	globalCfg := inMemoryConfig(opts...)
+	log := logger.NewNoOpLogger()
+	trc := tracer.NewNoOpTracer()
+	bus := eventbus.NewInMemoryBus(log)
+
+	srv := server.NewServer(globalCfg, log, nil)
+	a := &TestApp{
+		HTTPTestHelper: NewHTTPTestHelper(srv.App, t),
+		Bus:            bus,
+		dbs:            make(map[string]database.Database),
+	}
+
+	bootstrap := app.BootstrapHttpConfig{
+		Config:  globalCfg,
+		App:     srv.App,
+		Val:     validator.NewPlaygroundValidator(),
+		Log:     log,
+		Tracer:  trc,
+		Metrics: metrics.NewNoOpMetrics(),
+		Bus:     bus,
+		LoadDomainConfig: func(domain string) *config.Config {
+			return inMemoryConfig(opts...)
+		},
+		OpenDomainDB: func(domain string, cfg *config.Config, log logger.Logger) database.Database {
+			db := openInMemoryDB(t, domain, log, trc)
+
+			a.mu.Lock()
+			a.dbs[domain] = db
+			a.mu.Unlock()
+			return db
+		},
+	}
+	bootstrap.Run()
+
+	t.Cleanup(func() {
+		// Drain in-flight event handlers before their databases are closed.
+		_ = bus.Close()
+		bootstrap.Stop()
+	})
+
+	return a
+}
+
+// DB returns the in-memory database of a domain (e.g., "booking").
+func (a *TestApp) DB(domain string) database.Database {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	db, ok := a.dbs[domain]
+	if !ok {
+		a.T.Fatalf("No in-memory database for domain %q", domain)
+	}
+	return db
+}
+
+// openInMemoryDB opens the in-memory database of domain for the test t,
+// shared by the app and the worker of the test: each test gets its own set
+// of databases.
+func openInMemoryDB(t *testing.T, domain string, log logger.Logger, trc tracer.Tracer) database.Database {
+	prefix := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	db := database.NewSQLiteDatabase(fmt.Sprintf("%s_%s", prefix, domain), log, trc)
+	// The SQL migrations under ./migrations are Postgres specific, only applied
+	// for the Postgres-backed suites (see MigrateTestDB).
+	m, _ := modules.Get(domain)
+	if err := db.GetDB().AutoMigrate(m.Models...); err != nil {
+		t.Fatalf("Failed to migrate in-memory database for %s: %v", domain, err)
+	}
+	return db
+}
+
+// WebsocketSecret signs the WebSocket tokens of the in-memory app
+// (see wsserver.TokenAuthenticator).
+const WebsocketSecret = "test-websocket-secret"
+
+// inMemoryConfig returns the configuration shared by every domain in the
+// in-memory test mode. The "test" environment selects the NoOp logger.
+func inMemoryConfig(opts ...func(*config.Config)) *config.Config {
+	cfg := &config.Config{
+		App: config.AppConfig{
+			Name: "voyago-test",
+			Env:  "test",
+		},
+	}
+
+	cfg.Docs.Enabled = true
+
+	cfg.Websocket.Enabled = true
+	cfg.Websocket.Secret = WebsocketSecret
+
+	cfg.Webhook.Timeout = 2
+	cfg.Webhook.AllowInternalTargets = true // the receivers are httptest servers
+	cfg.Webhook.Worker.Interval = 1
+	cfg.Webhook.Worker.BatchSize = 10
+	cfg.Webhook.Retry.MaxAttempts = 3
+	cfg.Webhook.Retry.BaseBackoff = 1
+	cfg.Webhook.Retry.MaxBackoff = 5
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
@@ -0,0 +1,20 @@
+package helper
+
+import "voyago/core-api/internal/infrastructure/config"
+
+// TestAMQPConfig returns the configuration of the test RabbitMQ broker of
+// url (see StartRabbitMQ), its queues prefixed with group.
+func TestAMQPConfig(url, group string) *config.AMQPConfig {
+	return &config.AMQPConfig{
+		Enabled:  true,
+		URL:      url,
+		Exchange: "voyago.test",
+		Consumer: config.AMQPConsumerConfig{
+			Group:       group,
+			Prefetch:    10,
+			Concurrency: 1,
+			MaxAttempts: 2,
+			RetryDelay:  100,
+		},
+	}
+}
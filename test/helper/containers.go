@@ -0,0 +1,91 @@
+//go:build integration
+// +build integration
+
+package helper
+
+import (
+	"context"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/testcontainers/testcontainers-go"
+	tckafka "github.com/testcontainers/testcontainers-go/modules/kafka"
+	tcmysql "github.com/testcontainers/testcontainers-go/modules/mysql"
+	tcrabbitmq "github.com/testcontainers/testcontainers-go/modules/rabbitmq"
+)
+
+// StartMySQL starts a MySQL server in a container (Docker is required),
+// removed at the end of the test, and returns the configuration of its
+// database.
+func StartMySQL(t *testing.T) *config.DatabaseConfig {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := tcmysql.Run(ctx, "mysql:8.4",
+		tcmysql.WithDatabase("voyago_test"),
+		tcmysql.WithUsername("voyago"),
+		tcmysql.WithPassword("voyago"),
+	)
+	testcontainers.CleanupContainer(t, container)
+	if err != nil {
+		t.Fatalf("Failed to start the MySQL container: %v", err)
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get the MySQL container host: %v", err)
+	}
+	port, err := container.MappedPort(ctx, "3306/tcp")
+	if err != nil {
+		t.Fatalf("Failed to get the MySQL container port: %v", err)
+	}
+
+	return &config.DatabaseConfig{
+		Driver:   config.DatabaseDriverMySQL,
+		Host:     host,
+		Port:     port.Int(),
+		User:     "voyago",
+		Password: "voyago",
+		Name:     "voyago_test",
+	}
+}
+
+// StartRabbitMQ starts a RabbitMQ broker in a container (Docker is
+// required), removed at the end of the test, and returns its AMQP URL.
+func StartRabbitMQ(t *testing.T) string {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := tcrabbitmq.Run(ctx, "rabbitmq:3.13-alpine")
+	testcontainers.CleanupContainer(t, container)
+	if err != nil {
+		t.Fatalf("Failed to start the RabbitMQ container: %v", err)
+	}
+
+	url, err := container.AmqpURL(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get the RabbitMQ container URL: %v", err)
+	}
+	return url
+}
+
+// StartKafka starts a single-broker Kafka cluster in a container (Docker is
+// required), removed at the end of the test, and returns the configuration of
+// its brokers.
+func StartKafka(t *testing.T) *config.KafkaConfig {
+	t.Helper()
+	ctx := context.Background()
+
+	container, err := tckafka.Run(ctx, "confluentinc/confluent-local:7.5.0", tckafka.WithClusterID("voyago-test"))
+	testcontainers.CleanupContainer(t, container)
+	if err != nil {
+		t.Fatalf("Failed to start the Kafka container: %v", err)
+	}
+
+	brokers, err := container.Brokers(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get the Kafka container brokers: %v", err)
+	}
+	return &config.KafkaConfig{Enabled: true, Brokers: brokers, ClientID: "voyago-test"}
+}
@@ -12,6 +12,7 @@ import (
 	database "voyago/core-api/internal/infrastructure/db"
 	"voyago/core-api/internal/infrastructure/logger"
 	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/migrations"
 
 	"gorm.io/gorm"
 )
@@ -23,6 +24,7 @@ type TestDatabaseConfig struct {
 	User     string
 	Password string
 	DBName   string
+	Schema   string
 }
 
 // DefaultTestDBConfig returns test database configuration from environment variables
@@ -41,6 +43,25 @@ func DefaultTestDBConfig() *TestDatabaseConfig {
 		User:     getEnv("TEST_DB_USER", "booking_user"),
 		Password: getEnv("TEST_DB_PASSWORD", ""), // MUST be set via env var
 		DBName:   getEnv("TEST_DB_NAME", "voyago_test"),
+		Schema:   getEnv("TEST_DB_SCHEMA", "booking"),
+	}
+}
+
+// databaseConfig returns the configuration of the test database, its
+// search_path pinned to schema.
+func (c *TestDatabaseConfig) databaseConfig(schema string) *config.DatabaseConfig {
+	return &config.DatabaseConfig{
+		Host:     c.Host,
+		Port:     c.Port,
+		User:     c.User,
+		Password: c.Password,
+		Name:     c.DBName,
+		Schema:   schema,
+		Pool: config.DatabasePoolConfig{
+			Idle:     5,
+			Max:      20,
+			Lifetime: 300,
+		},
 	}
 }
 
@@ -56,25 +77,16 @@ func getEnv(key, fallback string) string {
 // It should be called at the beginning of each integration test
 func SetupTestDB(t *testing.T) database.Database {
 	t.Helper()
+	return SetupDomainTestDB(t, DefaultTestDBConfig().Schema)
+}
 
-	cfg := DefaultTestDBConfig()
+// SetupDomainTestDB is SetupTestDB with the search_path pinned to the schema
+// of domain, for the suites of a module other than TEST_DB_SCHEMA.
+func SetupDomainTestDB(t *testing.T, domain string) database.Database {
+	t.Helper()
 
-	dbCfg := &config.DatabaseConfig{
-		Host:     cfg.Host,
-		Port:     cfg.Port,
-		User:     cfg.User,
-		Password: cfg.Password,
-		Name:     cfg.DBName,
-		Pool: struct {
-			Idle     int `mapstructure:"idle"`
-			Max      int `mapstructure:"max"`
-			Lifetime int `mapstructure:"lifetime"`
-		}{
-			Idle:     5,
-			Max:      20,
-			Lifetime: 300,
-		},
-	}
+	cfg := DefaultTestDBConfig()
+	dbCfg := cfg.databaseConfig(domain)
 
 	// Use NoOp logger and tracer for tests
 	log := logger.NewNoOpLogger()
@@ -100,6 +112,24 @@ func SetupTestDB(t *testing.T) database.Database {
 	return db
 }
 
+// MigrateTestDB applies the embedded migrations of domain (see package
+// migrations) to the test database, in the schema of the domain, so that the
+// Postgres-backed suites run against the production schema. Call it before
+// SetupTestDB; it is a no-op once the schema is up to date.
+func MigrateTestDB(t testing.TB, domain string) {
+	t.Helper()
+
+	m, err := database.NewMigrator(DefaultTestDBConfig().databaseConfig(domain), migrations.FS(domain))
+	if err != nil {
+		t.Fatalf("Failed to open the %s migrations of the test database: %v", domain, err)
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil {
+		t.Fatalf("Failed to migrate the test database for %s: %v", domain, err)
+	}
+}
+
 // CleanupTestDB closes the database connection
 func CleanupTestDB(t *testing.T, db database.Database) {
 	t.Helper()
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
@@ -28,6 +29,18 @@ func NewHTTPTestHelper(app *fiber.App, t *testing.T) *HTTPTestHelper {
 // POST makes a POST request to the given path with JSON body
 func (h *HTTPTestHelper) POST(path string, body interface{}) *httptest.ResponseRecorder {
 	h.T.Helper()
+	return h.sendJSON("POST", path, body)
+}
+
+// PATCH makes a PATCH request to the given path with JSON body
+func (h *HTTPTestHelper) PATCH(path string, body interface{}) *httptest.ResponseRecorder {
+	h.T.Helper()
+	return h.sendJSON("PATCH", path, body)
+}
+
+// sendJSON makes a request with a JSON-encoded body
+func (h *HTTPTestHelper) sendJSON(method, path string, body interface{}) *httptest.ResponseRecorder {
+	h.T.Helper()
 
 	var bodyReader io.Reader
 	if body != nil {
@@ -38,26 +51,10 @@ func (h *HTTPTestHelper) POST(path string, body interface{}) *httptest.ResponseR
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
-	req := httptest.NewRequest("POST", path, bodyReader)
+	req := httptest.NewRequest(method, path, bodyReader)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := h.App.Test(req, -1)
-	if err != nil {
-		h.T.Fatalf("Failed to execute request: %v", err)
-	}
-
-	recorder := httptest.NewRecorder()
-	recorder.WriteHeader(resp.StatusCode)
-	for key, values := range resp.Header {
-		for _, value := range values {
-			recorder.Header().Add(key, value)
-		}
-	}
-
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	recorder.Body.Write(bodyBytes)
-
-	return recorder
+	return h.Do(req)
 }
 
 // GET makes a GET request to the given path
@@ -67,6 +64,14 @@ func (h *HTTPTestHelper) GET(path string) *httptest.ResponseRecorder {
 	req := httptest.NewRequest("GET", path, nil)
 	req.Header.Set("Content-Type", "application/json")
 
+	return h.Do(req)
+}
+
+// Do executes req against the app (e.g., a request with a custom body format
+// or Accept header).
+func (h *HTTPTestHelper) Do(req *http.Request) *httptest.ResponseRecorder {
+	h.T.Helper()
+
 	resp, err := h.App.Test(req, -1)
 	if err != nil {
 		h.T.Fatalf("Failed to execute request: %v", err)
@@ -109,8 +114,9 @@ func (h *HTTPTestHelper) AssertErrorResponse(resp *httptest.ResponseRecorder, ex
 	assert.NoError(h.T, err, "Failed to decode error response")
 
 	// Assert standard error response fields exist
-	assert.Contains(h.T, errResp, "status", "Error response should contain 'status'")
+	assert.Contains(h.T, errResp, "success", "Error response should contain 'success'")
 	assert.Contains(h.T, errResp, "message", "Error response should contain 'message'")
+	assert.Contains(h.T, errResp, "error_code", "Error response should contain 'error_code'")
 
 	return errResp
 }
@@ -0,0 +1,80 @@
+package helper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+)
+
+// TestWorker is the background runtime (cmd/worker) running in in-memory
+// mode.
+type TestWorker struct {
+	// Bus is the in-process event bus of the worker.
+	Bus eventbus.Bus
+
+	bootstrap *app.BootstrapWorkerConfig
+}
+
+// NewInMemoryWorker boots the background runtime on the in-memory databases
+// of the test, shared with the app of NewInMemoryApp: run the app with
+// Standalone to leave the background work to the worker. It is shut down
+// when the test ends, or by Stop.
+//
+// Example:
+//
+//	a := helper.NewInMemoryApp(t, helper.Standalone)
+//	w := helper.NewInMemoryWorker(t)
+func NewInMemoryWorker(t *testing.T, opts ...func(*config.Config)) *TestWorker {
+	t.Helper()
+
+	log := logger.NewNoOpLogger()
+	trc := tracer.NewNoOpTracer()
+	bus := eventbus.NewInMemoryBus(log)
+
+	w := &TestWorker{
+		Bus: bus,
+		bootstrap: &app.BootstrapWorkerConfig{
+			Config:  inMemoryConfig(opts...),
+			Log:     log,
+			Tracer:  trc,
+			Metrics: metrics.NewNoOpMetrics(),
+			Bus:     bus,
+			LoadDomainConfig: func(domain string) *config.Config {
+				return inMemoryConfig(opts...)
+			},
+			OpenDomainDB: func(domain string, cfg *config.Config, log logger.Logger) database.Database {
+				return openInMemoryDB(t, domain, log, trc)
+			},
+		},
+	}
+	w.bootstrap.Run()
+	t.Cleanup(w.Stop)
+
+	return w
+}
+
+// Standalone runs the app without its background work (worker.standalone).
+func Standalone(cfg *config.Config) {
+	cfg.Worker.Standalone = true
+}
+
+// Health requests the health endpoint of the worker.
+func (w *TestWorker) Health() *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	w.bootstrap.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	return rec
+}
+
+// Stop drains and shuts the worker down.
+func (w *TestWorker) Stop() {
+	_ = w.Bus.Close()
+	w.bootstrap.Stop()
+}
@@ -7,12 +7,16 @@ import (
 	"context"
 	"testing"
 
+	"voyago/core-api/internal/infrastructure/eventbus"
 	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
 	"voyago/core-api/internal/infrastructure/telemetry/tracer"
 	"voyago/core-api/internal/modules/booking/entity"
 	"voyago/core-api/internal/modules/booking/repository/command"
 	"voyago/core-api/internal/modules/booking/repository/query"
 	"voyago/core-api/internal/modules/booking/usecase"
+	"voyago/core-api/internal/pkg/audit"
+	"voyago/core-api/internal/pkg/uid"
 	"voyago/core-api/test/helper"
 
 	"github.com/stretchr/testify/assert"
@@ -22,11 +26,12 @@ import (
 // TestCreateBooking_Integration tests the full flow with real database
 func TestCreateBooking_Integration(t *testing.T) {
 	// Setup
+	helper.MigrateTestDB(t, "booking")
 	db := helper.SetupTestDB(t)
 	defer helper.CleanupTestDB(t, db)
 
 	// Clean tables before test
-	helper.TruncateTables(t, db.GetDB(), "booking_details", "bookings")
+	helper.TruncateTables(t, db.GetDB(), "booking_details", "bookings", "audit_logs")
 
 	// Initialize real repositories
 	bookingCmd := command.NewBookingRepository(db)
@@ -39,7 +44,12 @@ func TestCreateBooking_Integration(t *testing.T) {
 	uc := usecase.NewCreateBookingUseCase(
 		log,
 		trc,
+		metrics.NewBusiness(metrics.NewNoOpMetrics()),
 		db, // TransactionManager
+		eventbus.NewNoOpBus(),
+		audit.NewService(db),
+		uid.UUIDv7,
+		usecase.PaymentReminderPolicy{},
 		usecase.CreateBookingRepositories{
 			BookingCmd: bookingCmd,
 			BookingQry: bookingQry,
@@ -88,11 +98,12 @@ func TestCreateBooking_Integration(t *testing.T) {
 // TestCreateBooking_Integration_DuplicateCode tests duplicate code detection
 func TestCreateBooking_Integration_DuplicateCode(t *testing.T) {
 	// Setup
+	helper.MigrateTestDB(t, "booking")
 	db := helper.SetupTestDB(t)
 	defer helper.CleanupTestDB(t, db)
 
 	// Clean tables
-	helper.TruncateTables(t, db.GetDB(), "booking_details", "bookings")
+	helper.TruncateTables(t, db.GetDB(), "booking_details", "bookings", "audit_logs")
 
 	// Initialize repositories and usecase
 	bookingCmd := command.NewBookingRepository(db)
@@ -103,7 +114,12 @@ func TestCreateBooking_Integration_DuplicateCode(t *testing.T) {
 	uc := usecase.NewCreateBookingUseCase(
 		log,
 		trc,
+		metrics.NewBusiness(metrics.NewNoOpMetrics()),
 		db,
+		eventbus.NewNoOpBus(),
+		audit.NewService(db),
+		uid.UUIDv7,
+		usecase.PaymentReminderPolicy{},
 		usecase.CreateBookingRepositories{
 			BookingCmd: bookingCmd,
 			BookingQry: bookingQry,
@@ -157,11 +173,12 @@ func TestCreateBooking_Integration_DuplicateCode(t *testing.T) {
 // TestCreateBooking_Integration_TransactionRollback tests transaction rollback
 func TestCreateBooking_Integration_TransactionRollback(t *testing.T) {
 	// Setup
+	helper.MigrateTestDB(t, "booking")
 	db := helper.SetupTestDB(t)
 	defer helper.CleanupTestDB(t, db)
 
 	// Clean tables
-	helper.TruncateTables(t, db.GetDB(), "booking_details", "bookings")
+	helper.TruncateTables(t, db.GetDB(), "booking_details", "bookings", "audit_logs")
 
 	// Create a booking fixture with invalid data that will fail validation
 	fixture := helper.NewBookingFixture().
@@ -191,7 +208,12 @@ func TestCreateBooking_Integration_TransactionRollback(t *testing.T) {
 	uc := usecase.NewCreateBookingUseCase(
 		log,
 		trc,
+		metrics.NewBusiness(metrics.NewNoOpMetrics()),
 		db,
+		eventbus.NewNoOpBus(),
+		audit.NewService(db),
+		uid.UUIDv7,
+		usecase.PaymentReminderPolicy{},
 		usecase.CreateBookingRepositories{
 			BookingCmd: bookingCmd,
 			BookingQry: bookingQry,
@@ -209,7 +231,7 @@ func TestCreateBooking_Integration_TransactionRollback(t *testing.T) {
 
 	// Assert: validation should fail
 	require.Error(t, err)
-	assert.Equal(t, entity.ErrBookingDetailRequired, err)
+	assert.Equal(t, entity.ErrBookingDetailsRequired, err)
 
 	// Verify nothing was persisted
 	found, err := bookingQry.FindByCode(ctx, "ROLLBACK002")
@@ -220,11 +242,12 @@ func TestCreateBooking_Integration_TransactionRollback(t *testing.T) {
 // TestCreateBooking_Integration_MultipleDetails tests booking with multiple details
 func TestCreateBooking_Integration_MultipleDetails(t *testing.T) {
 	// Setup
+	helper.MigrateTestDB(t, "booking")
 	db := helper.SetupTestDB(t)
 	defer helper.CleanupTestDB(t, db)
 
 	// Clean tables
-	helper.TruncateTables(t, db.GetDB(), "booking_details", "bookings")
+	helper.TruncateTables(t, db.GetDB(), "booking_details", "bookings", "audit_logs")
 
 	// Initialize components
 	bookingCmd := command.NewBookingRepository(db)
@@ -235,7 +258,12 @@ func TestCreateBooking_Integration_MultipleDetails(t *testing.T) {
 	uc := usecase.NewCreateBookingUseCase(
 		log,
 		trc,
+		metrics.NewBusiness(metrics.NewNoOpMetrics()),
 		db,
+		eventbus.NewNoOpBus(),
+		audit.NewService(db),
+		uid.UUIDv7,
+		usecase.PaymentReminderPolicy{},
 		usecase.CreateBookingRepositories{
 			BookingCmd: bookingCmd,
 			BookingQry: bookingQry,
@@ -0,0 +1,37 @@
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type mysqlItem struct {
+	ID   string `gorm:"column:id;type:varchar(36);primaryKey"`
+	Code string `gorm:"column:code;not null"`
+}
+
+func TestMySQLUpdate_Unchanged_Integration(t *testing.T) {
+	db, err := database.OpenGormDatabase(helper.StartMySQL(t), logger.NewNoOpLogger(), nil)
+	require.NoError(t, err)
+	defer helper.CleanupTestDB(t, db)
+	require.NoError(t, db.GetDB().AutoMigrate(&mysqlItem{}))
+	repo := &database.GormBaseRepository[mysqlItem]{DB: db, ErrorMapper: database.MapDBError}
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, &mysqlItem{ID: "1", Code: "A"}))
+
+	require.NoError(t, repo.Update(ctx, &mysqlItem{ID: "1", Code: "A"}), "an unchanged row is found")
+
+	err = repo.Update(ctx, &mysqlItem{ID: "2", Code: "B"})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,41 @@
+//go:build integration
+// +build integration
+
+package lock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/lock"
+	"voyago/core-api/test/helper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresLocker_Integration(t *testing.T) {
+	db := helper.SetupTestDB(t)
+	defer helper.CleanupTestDB(t, db)
+	sqlDB, err := db.GetDB().DB()
+	require.NoError(t, err)
+	l := lock.NewPostgresLocker(sqlDB)
+	ctx := context.Background()
+
+	lk, err := l.TryLock(ctx, "booking-code:ABC", time.Minute)
+	require.NoError(t, err)
+	_, err = l.TryLock(ctx, "booking-code:ABC", time.Minute)
+	assert.ErrorIs(t, err, lock.ErrNotAcquired, "held by another session")
+
+	require.NoError(t, lk.Extend(ctx, time.Minute))
+	require.NoError(t, lk.Unlock(ctx))
+	assert.ErrorIs(t, lk.Unlock(ctx), lock.ErrLockLost)
+
+	expiring, err := l.TryLock(ctx, "relay", 100*time.Millisecond)
+	require.NoError(t, err)
+	next, err := l.Lock(ctx, "relay", time.Minute)
+	require.NoError(t, err, "released when the TTL expired")
+	assert.ErrorIs(t, expiring.Extend(ctx, time.Minute), lock.ErrLockLost)
+	require.NoError(t, next.Unlock(ctx))
+}
@@ -0,0 +1,120 @@
+//go:build integration
+// +build integration
+
+package messaging_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/messaging"
+	"voyago/core-api/internal/infrastructure/messaging/amqp"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/test/helper"
+
+	amqp091 "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startConsumer consumes router on a broker started for the test, and
+// returns the URL of the broker with the publisher and the consumer.
+func startConsumer(t *testing.T, router *messaging.Router) (string, *amqp.Publisher, *amqp.Consumer) {
+	t.Helper()
+	url := helper.StartRabbitMQ(t)
+	cfg := helper.TestAMQPConfig(url, "it")
+	conn, err := amqp.Dial(cfg, logger.NewNoOpLogger())
+	require.NoError(t, err)
+
+	publisher, err := amqp.NewPublisher(conn, cfg, tracer.NewNoOpTracer())
+	require.NoError(t, err)
+	consumer, err := amqp.NewConsumer(conn, cfg, router, publisher, logger.NewNoOpLogger(), tracer.NewNoOpTracer(), nil)
+	require.NoError(t, err)
+	require.NoError(t, consumer.Start())
+
+	t.Cleanup(func() {
+		consumer.Stop()
+		_ = conn.Close()
+	})
+	return url, publisher, consumer
+}
+
+// rawChannel opens a channel on the broker of url, to inspect the queues.
+func rawChannel(t *testing.T, url string) *amqp091.Channel {
+	t.Helper()
+	conn, err := amqp091.Dial(url)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	ch, err := conn.Channel()
+	require.NoError(t, err)
+	return ch
+}
+
+func TestAMQP_PublishesWithConfirmsAndConsumes_Integration(t *testing.T) {
+	received := make(chan messaging.Message, 1)
+	router := messaging.NewRouter()
+	router.Handle("payments", func(_ context.Context, msg messaging.Message) error {
+		received <- msg
+		return nil
+	})
+	_, publisher, _ := startConsumer(t, router)
+
+	err := publisher.Publish(context.Background(), messaging.Message{Topic: "payments", Key: []byte("b-1"), Value: []byte(`{"status":"paid"}`)})
+	require.NoError(t, err, "confirmed by the broker")
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, "payments", msg.Topic)
+		assert.Equal(t, "b-1", string(msg.Key))
+		assert.JSONEq(t, `{"status":"paid"}`, string(msg.Value))
+	case <-time.After(10 * time.Second):
+		t.Fatal("message not consumed")
+	}
+}
+
+func TestAMQP_RetriesThenDeadLetters_Integration(t *testing.T) {
+	var attempts atomic.Int32
+	router := messaging.NewRouter()
+	router.Handle("payments", func(context.Context, messaging.Message) error {
+		attempts.Add(1)
+		return errors.New("gateway down")
+	})
+	url, publisher, consumer := startConsumer(t, router)
+
+	require.NoError(t, publisher.Publish(context.Background(), messaging.Message{Topic: "payments", Value: []byte("{}")}))
+
+	ch := rawChannel(t, url)
+	var dead amqp091.Delivery
+	require.Eventually(t, func() bool {
+		d, ok, err := ch.Get(consumer.Queue("payments")+".dlq", true)
+		dead = d
+		return err == nil && ok
+	}, 10*time.Second, 50*time.Millisecond, "dead-lettered after max_attempts")
+
+	assert.EqualValues(t, 2, attempts.Load())
+	assert.Equal(t, "2", dead.Headers[messaging.HeaderAttempt])
+	assert.Equal(t, "gateway down", dead.Headers[messaging.HeaderError])
+}
+
+func TestAMQP_DeadLettersPermanentErrorsAtOnce_Integration(t *testing.T) {
+	var attempts atomic.Int32
+	router := messaging.NewRouter()
+	router.Handle("payments", func(context.Context, messaging.Message) error {
+		attempts.Add(1)
+		return messaging.Permanent(errors.New("invalid payload"))
+	})
+	url, publisher, consumer := startConsumer(t, router)
+
+	require.NoError(t, publisher.Publish(context.Background(), messaging.Message{Topic: "payments", Value: []byte("{")}))
+
+	ch := rawChannel(t, url)
+	require.Eventually(t, func() bool {
+		_, ok, err := ch.Get(consumer.Queue("payments")+".dlq", true)
+		return err == nil && ok
+	}, 10*time.Second, 50*time.Millisecond)
+	assert.EqualValues(t, 1, attempts.Load())
+}
@@ -0,0 +1,108 @@
+//go:build integration
+// +build integration
+
+package messaging_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/messaging"
+	"voyago/core-api/internal/infrastructure/messaging/kafka"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/test/helper"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startRunner consumes router on a Kafka broker started for the test, whose
+// topics (with their retry and dead letter topics) are created first.
+func startRunner(t *testing.T, router *messaging.Router) (*kafka.Client, *kafka.Producer) {
+	t.Helper()
+	cfg := helper.StartKafka(t)
+	createTopics(t, cfg.Brokers[0], router.Topics()...)
+
+	client, err := kafka.NewClient(cfg)
+	require.NoError(t, err)
+	producer := kafka.NewProducer(client, tracer.NewNoOpTracer())
+	consumerCfg := config.KafkaConsumerConfig{GroupID: "it", MaxAttempts: 2, RetryBackoff: 100}
+	runner, err := kafka.NewRunner(consumerCfg, router, client, producer, logger.NewNoOpLogger(), tracer.NewNoOpTracer(), nil)
+	require.NoError(t, err)
+	runner.Start()
+
+	t.Cleanup(func() {
+		runner.Stop()
+		_ = producer.Close()
+	})
+	return client, producer
+}
+
+// createTopics creates topics, their retry and dead letter topics, with a
+// single partition.
+func createTopics(t *testing.T, broker string, topics ...string) {
+	t.Helper()
+	conn, err := kafkago.Dial("tcp", broker)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var configs []kafkago.TopicConfig
+	for _, topic := range topics {
+		for _, name := range []string{topic, topic + ".retry", topic + ".dlq"} {
+			configs = append(configs, kafkago.TopicConfig{Topic: name, NumPartitions: 1, ReplicationFactor: 1})
+		}
+	}
+	require.NoError(t, conn.CreateTopics(configs...))
+}
+
+func TestKafka_ProducesAndConsumes_Integration(t *testing.T) {
+	received := make(chan messaging.Message, 1)
+	router := messaging.NewRouter()
+	router.Handle("payments", func(_ context.Context, msg messaging.Message) error {
+		received <- msg
+		return nil
+	})
+	_, producer := startRunner(t, router)
+
+	err := producer.Publish(context.Background(), messaging.Message{Topic: "payments", Key: []byte("b-1"), Value: []byte(`{"status":"paid"}`)})
+	require.NoError(t, err, "acknowledged by the broker")
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, "payments", msg.Topic)
+		assert.Equal(t, "b-1", string(msg.Key))
+		assert.JSONEq(t, `{"status":"paid"}`, string(msg.Value))
+	case <-time.After(30 * time.Second):
+		t.Fatal("message not consumed")
+	}
+}
+
+func TestKafka_RetriesThenDeadLetters_Integration(t *testing.T) {
+	var attempts atomic.Int32
+	router := messaging.NewRouter()
+	router.Handle("payments", func(context.Context, messaging.Message) error {
+		attempts.Add(1)
+		return errors.New("gateway down")
+	})
+	client, producer := startRunner(t, router)
+
+	require.NoError(t, producer.Publish(context.Background(), messaging.Message{Topic: "payments", Value: []byte("{}")}))
+
+	dlq := client.NewReader("payments.dlq", "it-dlq")
+	defer dlq.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	dead, err := dlq.Fetch(ctx)
+	require.NoError(t, err, "dead-lettered after max_attempts")
+
+	assert.EqualValues(t, 2, attempts.Load())
+	assert.Equal(t, "2", dead.Headers[messaging.HeaderAttempt])
+	assert.Equal(t, "payments", dead.Headers[messaging.HeaderOriginalTopic])
+	assert.Equal(t, "gateway down", dead.Headers[messaging.HeaderError])
+}
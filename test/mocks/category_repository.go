@@ -0,0 +1,56 @@
+// Code generated by voyago gen mocks. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"voyago/core-api/internal/modules/category/entity"
+	"voyago/core-api/internal/modules/category/repository"
+	"voyago/core-api/internal/pkg/spec"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCategoryQueryRepository is a mock implementation of repository.CategoryQueryRepository.
+type MockCategoryQueryRepository struct {
+	mock.Mock
+}
+
+var _ repository.CategoryQueryRepository = (*MockCategoryQueryRepository)(nil)
+
+func (m *MockCategoryQueryRepository) FindByIDs(ctx context.Context, ids []string) ([]entity.Category, error) {
+	args := m.Called(ctx, ids)
+	if f, ok := args.Get(0).(func(context.Context, []string) ([]entity.Category, error)); ok {
+		return f(ctx, ids)
+	}
+	var r0 []entity.Category
+	if v := args.Get(0); v != nil {
+		r0 = v.([]entity.Category)
+	}
+	return r0, args.Error(1)
+}
+
+func (m *MockCategoryQueryRepository) FindByParentIDs(ctx context.Context, parentIDs []string) ([]entity.Category, error) {
+	args := m.Called(ctx, parentIDs)
+	if f, ok := args.Get(0).(func(context.Context, []string) ([]entity.Category, error)); ok {
+		return f(ctx, parentIDs)
+	}
+	var r0 []entity.Category
+	if v := args.Get(0); v != nil {
+		r0 = v.([]entity.Category)
+	}
+	return r0, args.Error(1)
+}
+
+func (m *MockCategoryQueryRepository) ListRoots(ctx context.Context, s spec.Spec) ([]entity.Category, error) {
+	args := m.Called(ctx, s)
+	if f, ok := args.Get(0).(func(context.Context, spec.Spec) ([]entity.Category, error)); ok {
+		return f(ctx, s)
+	}
+	var r0 []entity.Category
+	if v := args.Get(0); v != nil {
+		r0 = v.([]entity.Category)
+	}
+	return r0, args.Error(1)
+}
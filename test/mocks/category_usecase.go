@@ -0,0 +1,68 @@
+// Code generated by voyago gen mocks. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"voyago/core-api/internal/modules/category/usecase"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockListCategoriesUseCase is a mock implementation of usecase.ListCategoriesUseCase.
+type MockListCategoriesUseCase struct {
+	mock.Mock
+}
+
+var _ usecase.ListCategoriesUseCase = (*MockListCategoriesUseCase)(nil)
+
+func (m *MockListCategoriesUseCase) Execute(ctx context.Context, req *usecase.ListCategoriesRequest) ([]usecase.CategoryResponse, error) {
+	args := m.Called(ctx, req)
+	if f, ok := args.Get(0).(func(context.Context, *usecase.ListCategoriesRequest) ([]usecase.CategoryResponse, error)); ok {
+		return f(ctx, req)
+	}
+	var r0 []usecase.CategoryResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.([]usecase.CategoryResponse)
+	}
+	return r0, args.Error(1)
+}
+
+// MockGetCategoriesByIDsUseCase is a mock implementation of usecase.GetCategoriesByIDsUseCase.
+type MockGetCategoriesByIDsUseCase struct {
+	mock.Mock
+}
+
+var _ usecase.GetCategoriesByIDsUseCase = (*MockGetCategoriesByIDsUseCase)(nil)
+
+func (m *MockGetCategoriesByIDsUseCase) Execute(ctx context.Context, ids []string) (map[string]usecase.CategoryResponse, error) {
+	args := m.Called(ctx, ids)
+	if f, ok := args.Get(0).(func(context.Context, []string) (map[string]usecase.CategoryResponse, error)); ok {
+		return f(ctx, ids)
+	}
+	var r0 map[string]usecase.CategoryResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(map[string]usecase.CategoryResponse)
+	}
+	return r0, args.Error(1)
+}
+
+// MockGetCategoryChildrenUseCase is a mock implementation of usecase.GetCategoryChildrenUseCase.
+type MockGetCategoryChildrenUseCase struct {
+	mock.Mock
+}
+
+var _ usecase.GetCategoryChildrenUseCase = (*MockGetCategoryChildrenUseCase)(nil)
+
+func (m *MockGetCategoryChildrenUseCase) Execute(ctx context.Context, parentIDs []string) (map[string][]usecase.CategoryResponse, error) {
+	args := m.Called(ctx, parentIDs)
+	if f, ok := args.Get(0).(func(context.Context, []string) (map[string][]usecase.CategoryResponse, error)); ok {
+		return f(ctx, parentIDs)
+	}
+	var r0 map[string][]usecase.CategoryResponse
+	if v := args.Get(0); v != nil {
+		r0 = v.(map[string][]usecase.CategoryResponse)
+	}
+	return r0, args.Error(1)
+}
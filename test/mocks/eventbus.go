@@ -0,0 +1,26 @@
+// Code generated by voyago gen mocks. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"voyago/core-api/internal/infrastructure/eventbus"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPublisher is a mock implementation of eventbus.Publisher.
+type MockPublisher struct {
+	mock.Mock
+}
+
+var _ eventbus.Publisher = (*MockPublisher)(nil)
+
+func (m *MockPublisher) Publish(ctx context.Context, evt eventbus.Event) error {
+	args := m.Called(ctx, evt)
+	if f, ok := args.Get(0).(func(context.Context, eventbus.Event) error); ok {
+		return f(ctx, evt)
+	}
+	return args.Error(0)
+}
@@ -0,0 +1,70 @@
+// Code generated by voyago gen mocks. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLogger is a mock implementation of logger.Logger.
+type MockLogger struct {
+	mock.Mock
+}
+
+var _ logger.Logger = (*MockLogger)(nil)
+
+func (m *MockLogger) WithContext(ctx context.Context) logger.Logger {
+	args := m.Called(ctx)
+	if f, ok := args.Get(0).(func(context.Context) logger.Logger); ok {
+		return f(ctx)
+	}
+	var r0 logger.Logger
+	if v := args.Get(0); v != nil {
+		r0 = v.(logger.Logger)
+	}
+	return r0
+}
+
+func (m *MockLogger) WithField(key string, value any) logger.Logger {
+	args := m.Called(key, value)
+	if f, ok := args.Get(0).(func(string, any) logger.Logger); ok {
+		return f(key, value)
+	}
+	var r0 logger.Logger
+	if v := args.Get(0); v != nil {
+		r0 = v.(logger.Logger)
+	}
+	return r0
+}
+
+func (m *MockLogger) WithFields(fields map[string]any) logger.Logger {
+	args := m.Called(fields)
+	if f, ok := args.Get(0).(func(map[string]any) logger.Logger); ok {
+		return f(fields)
+	}
+	var r0 logger.Logger
+	if v := args.Get(0); v != nil {
+		r0 = v.(logger.Logger)
+	}
+	return r0
+}
+
+func (m *MockLogger) Debug(message string) {
+	m.Called(message)
+}
+
+func (m *MockLogger) Info(message string) {
+	m.Called(message)
+}
+
+func (m *MockLogger) Warn(message string) {
+	m.Called(message)
+}
+
+func (m *MockLogger) Error(message string) {
+	m.Called(message)
+}
@@ -0,0 +1,22 @@
+// Package mocks holds the testify mocks of the ports of the application: the
+// shared infrastructure (logger, tracer, transactions, events, audit, task
+// queue) and the repository and use case contracts of every module.
+//
+// The other files are generated from the interfaces by voyago gen mocks,
+// which voyago gen module, gen repo and gen usecase run as well: change the
+// interface, then regenerate, instead of editing them.
+//
+// As with mockery, a mocked method given a function of its own signature by
+// Return calls it:
+//
+//	tx := new(mocks.MockTransactionManager)
+//	tx.On("Atomic", mock.Anything, mock.Anything).Return(mocks.RunAtomic)
+package mocks
+
+import "context"
+
+// RunAtomic runs the function given to TransactionManager.Atomic, as a
+// committed transaction does.
+func RunAtomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,26 @@
+// Code generated by voyago gen mocks. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"voyago/core-api/internal/infrastructure/taskqueue"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEnqueuer is a mock implementation of taskqueue.Enqueuer.
+type MockEnqueuer struct {
+	mock.Mock
+}
+
+var _ taskqueue.Enqueuer = (*MockEnqueuer)(nil)
+
+func (m *MockEnqueuer) Enqueue(ctx context.Context, task *taskqueue.Task) error {
+	args := m.Called(ctx, task)
+	if f, ok := args.Get(0).(func(context.Context, *taskqueue.Task) error); ok {
+		return f(ctx, task)
+	}
+	return args.Error(0)
+}
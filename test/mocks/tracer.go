@@ -0,0 +1,114 @@
+// Code generated by voyago gen mocks. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockTracer is a mock implementation of tracer.Tracer.
+type MockTracer struct {
+	mock.Mock
+}
+
+var _ tracer.Tracer = (*MockTracer)(nil)
+
+func (m *MockTracer) StartSpan(ctx context.Context, name string) (tracer.Span, context.Context) {
+	args := m.Called(ctx, name)
+	if f, ok := args.Get(0).(func(context.Context, string) (tracer.Span, context.Context)); ok {
+		return f(ctx, name)
+	}
+	var r0 tracer.Span
+	if v := args.Get(0); v != nil {
+		r0 = v.(tracer.Span)
+	}
+	var r1 context.Context
+	if v := args.Get(1); v != nil {
+		r1 = v.(context.Context)
+	}
+	return r0, r1
+}
+
+func (m *MockTracer) StartSpanWithLinks(ctx context.Context, name string, links ...tracer.Link) (tracer.Span, context.Context) {
+	args := m.Called(ctx, name, links)
+	if f, ok := args.Get(0).(func(context.Context, string, ...tracer.Link) (tracer.Span, context.Context)); ok {
+		return f(ctx, name, links...)
+	}
+	var r0 tracer.Span
+	if v := args.Get(0); v != nil {
+		r0 = v.(tracer.Span)
+	}
+	var r1 context.Context
+	if v := args.Get(1); v != nil {
+		r1 = v.(context.Context)
+	}
+	return r0, r1
+}
+
+func (m *MockTracer) Extract(ctx context.Context, carrier tracer.Carrier) context.Context {
+	args := m.Called(ctx, carrier)
+	if f, ok := args.Get(0).(func(context.Context, tracer.Carrier) context.Context); ok {
+		return f(ctx, carrier)
+	}
+	var r0 context.Context
+	if v := args.Get(0); v != nil {
+		r0 = v.(context.Context)
+	}
+	return r0
+}
+
+func (m *MockTracer) Inject(ctx context.Context, carrier tracer.Setter) {
+	m.Called(ctx, carrier)
+}
+
+func (m *MockTracer) UseGorm(db *gorm.DB) {
+	m.Called(db)
+}
+
+func (m *MockTracer) ExtractTraceInfo(ctx context.Context) (string, string, bool) {
+	args := m.Called(ctx)
+	if f, ok := args.Get(0).(func(context.Context) (string, string, bool)); ok {
+		return f(ctx)
+	}
+	return args.String(0), args.String(1), args.Bool(2)
+}
+
+func (m *MockTracer) Close() error {
+	args := m.Called()
+	if f, ok := args.Get(0).(func() error); ok {
+		return f()
+	}
+	return args.Error(0)
+}
+
+// MockSpan is a mock implementation of tracer.Span.
+type MockSpan struct {
+	mock.Mock
+}
+
+var _ tracer.Span = (*MockSpan)(nil)
+
+func (m *MockSpan) SetOperationName(name string) {
+	m.Called(name)
+}
+
+func (m *MockSpan) Finish() {
+	m.Called()
+}
+
+func (m *MockSpan) SetTag(key string, value any) {
+	m.Called(key, value)
+}
+
+func (m *MockSpan) AddEvent(name string, attrs map[string]any) {
+	m.Called(name, attrs)
+}
+
+func (m *MockSpan) RecordError(err error) {
+	m.Called(err)
+}
@@ -0,0 +1,34 @@
+// Code generated by voyago gen mocks. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	baserepo "voyago/core-api/internal/pkg/repository"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTransactionManager is a mock implementation of baserepo.TransactionManager.
+type MockTransactionManager struct {
+	mock.Mock
+}
+
+var _ baserepo.TransactionManager = (*MockTransactionManager)(nil)
+
+func (m *MockTransactionManager) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx, fn)
+	if f, ok := args.Get(0).(func(context.Context, func(ctx context.Context) error) error); ok {
+		return f(ctx, fn)
+	}
+	return args.Error(0)
+}
+
+func (m *MockTransactionManager) AtomicWithOptions(ctx context.Context, opts baserepo.TxOptions, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx, opts, fn)
+	if f, ok := args.Get(0).(func(context.Context, baserepo.TxOptions, func(ctx context.Context) error) error); ok {
+		return f(ctx, opts, fn)
+	}
+	return args.Error(0)
+}
@@ -265,3 +265,92 @@ func TestBookingDetail_Validate_Success(t *testing.T) {
 	// BookingDetail.Validate() returns nil (no validation rules)
 	assert.NoError(t, err)
 }
+
+func TestBooking_ChangePaymentStatus_Transitions(t *testing.T) {
+	tests := []struct {
+		from    entity.PaymentStatus
+		to      entity.PaymentStatus
+		allowed bool
+	}{
+		{entity.PaymentStatusUnpaid, entity.PaymentStatusPaid, true},
+		{entity.PaymentStatusUnpaid, entity.PaymentStatusFailed, true},
+		{entity.PaymentStatusUnpaid, entity.PaymentStatusRefunded, false},
+		{entity.PaymentStatusFailed, entity.PaymentStatusPaid, true},
+		{entity.PaymentStatusPaid, entity.PaymentStatusRefunded, true},
+		{entity.PaymentStatusPaid, entity.PaymentStatusFailed, false},
+		{entity.PaymentStatusRefunded, entity.PaymentStatusPaid, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			booking := createValidBooking()
+			booking.PaymentStatus = tt.from
+
+			err := booking.ChangePaymentStatus(tt.to, "PAY-001")
+
+			if tt.allowed {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, booking.PaymentStatus)
+				assert.Equal(t, "PAY-001", *booking.PaymentReference)
+			} else {
+				assert.ErrorIs(t, err, entity.ErrBookingPaymentTransitionInvalid)
+				assert.Equal(t, tt.from, booking.PaymentStatus)
+			}
+		})
+	}
+}
+
+func TestBooking_StatusAfterPayment(t *testing.T) {
+	booking := createValidBooking()
+
+	booking.PaymentStatus = entity.PaymentStatusPaid
+	status, ok := booking.StatusAfterPayment()
+	assert.True(t, ok)
+	assert.Equal(t, entity.BookingStatusConfirmed, status)
+
+	booking.Status = entity.BookingStatusConfirmed
+	booking.PaymentStatus = entity.PaymentStatusRefunded
+	status, ok = booking.StatusAfterPayment()
+	assert.True(t, ok)
+	assert.Equal(t, entity.BookingStatusCancelled, status)
+
+	booking.Status = entity.BookingStatusCompleted
+	_, ok = booking.StatusAfterPayment()
+	assert.False(t, ok)
+}
+
+func TestBooking_ChangeStatus_Transitions(t *testing.T) {
+	tests := []struct {
+		from    entity.BookingStatus
+		payment entity.PaymentStatus
+		to      entity.BookingStatus
+		allowed bool
+	}{
+		{entity.BookingStatusPending, entity.PaymentStatusPaid, entity.BookingStatusConfirmed, true},
+		{entity.BookingStatusPending, entity.PaymentStatusUnpaid, entity.BookingStatusConfirmed, false},
+		{entity.BookingStatusPending, entity.PaymentStatusUnpaid, entity.BookingStatusCancelled, true},
+		{entity.BookingStatusPending, entity.PaymentStatusPaid, entity.BookingStatusCompleted, false},
+		{entity.BookingStatusConfirmed, entity.PaymentStatusPaid, entity.BookingStatusCompleted, true},
+		{entity.BookingStatusConfirmed, entity.PaymentStatusRefunded, entity.BookingStatusCancelled, true},
+		{entity.BookingStatusCancelled, entity.PaymentStatusUnpaid, entity.BookingStatusPending, false},
+		{entity.BookingStatusCompleted, entity.PaymentStatusPaid, entity.BookingStatusCancelled, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to)+"/"+string(tt.payment), func(t *testing.T) {
+			booking := createValidBooking()
+			booking.Status = tt.from
+			booking.PaymentStatus = tt.payment
+
+			err := booking.ChangeStatus(tt.to)
+
+			if tt.allowed {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, booking.Status)
+			} else {
+				assert.ErrorIs(t, err, entity.ErrBookingStatusTransitionInvalid)
+				assert.Equal(t, tt.from, booking.Status)
+			}
+		})
+	}
+}
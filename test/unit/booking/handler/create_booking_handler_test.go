@@ -2,7 +2,6 @@ package http_test
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -15,6 +14,7 @@ import (
 	deliveryhttp "voyago/core-api/internal/modules/booking/delivery/http"
 	"voyago/core-api/internal/modules/booking/usecase"
 	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/test/mocks"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
@@ -22,25 +22,12 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// MockCreateBookingUseCase is a mock implementation of usecase.CreateBookingUseCase
-type MockCreateBookingUseCase struct {
-	mock.Mock
-}
-
-func (m *MockCreateBookingUseCase) Execute(ctx context.Context, req *usecase.CreateBookingRequest) (*usecase.CreateBookingResponse, error) {
-	args := m.Called(ctx, req)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*usecase.CreateBookingResponse), args.Error(1)
-}
-
 // setupTestHandler creates a test handler with mocked dependencies
-func setupTestHandler(t *testing.T) (*deliveryhttp.Handler, *MockCreateBookingUseCase, *fiber.App) {
+func setupTestHandler(t *testing.T) (*deliveryhttp.Handler, *mocks.MockCreateBookingUseCase, *fiber.App) {
 	t.Helper()
 
 	// Create mocks
-	mockUseCase := new(MockCreateBookingUseCase)
+	mockUseCase := new(mocks.MockCreateBookingUseCase)
 
 	// Create real dependencies
 	cfg := &config.Config{
@@ -60,6 +47,7 @@ func setupTestHandler(t *testing.T) (*deliveryhttp.Handler, *MockCreateBookingUs
 		deliveryhttp.HandlerUseCases{
 			CreateBookingUseCase: mockUseCase,
 		},
+		nil,
 	)
 
 	// Create Fiber app and register routes
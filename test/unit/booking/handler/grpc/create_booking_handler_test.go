@@ -0,0 +1,147 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	bookingv1 "voyago/core-api/api/proto/booking/v1"
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/infrastructure/validator"
+	deliverygrpc "voyago/core-api/internal/modules/booking/delivery/grpc"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/modules/booking/usecase"
+	"voyago/core-api/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// serve serves the booking gRPC handler of useCases, behind the production
+// interceptor chain, over an in-memory listener.
+func serve(t *testing.T, useCases deliverygrpc.HandlerUseCases) bookingv1.BookingServiceClient {
+	t.Helper()
+
+	log := logger.NewNoOpLogger()
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		app.GrpcInterceptors(nil, log, tracer.NewNoOpTracer(), metrics.NewNoOpMetrics(), nil)...,
+	))
+
+	service := deliverygrpc.ServiceConfig{
+		Config: &config.Config{},
+		Server: srv,
+		Handler: deliverygrpc.NewHandler(
+			&config.Config{},
+			log,
+			validator.NewPlaygroundValidator(),
+			useCases,
+		),
+	}
+	service.Setup()
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = srv.Serve(lis) }()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		srv.Stop()
+	})
+
+	return bookingv1.NewBookingServiceClient(conn)
+}
+
+// setupTestClient serves the CreateBooking use case mock.
+func setupTestClient(t *testing.T) (bookingv1.BookingServiceClient, *mocks.MockCreateBookingUseCase) {
+	t.Helper()
+
+	mockUseCase := new(mocks.MockCreateBookingUseCase)
+	return serve(t, deliverygrpc.HandlerUseCases{CreateBookingUseCase: mockUseCase}), mockUseCase
+}
+
+func validRequest() *bookingv1.CreateBookingRequest {
+	name := "Premium Package"
+	return &bookingv1.CreateBookingRequest{
+		Code:        "BKG-001",
+		UserId:      "550e8400-e29b-41d4-a716-446655440000",
+		TotalAmount: 100,
+		Details: []*bookingv1.CreateBookingDetail{
+			{
+				ProductId:    "660e8400-e29b-41d4-a716-446655440001",
+				ProductName:  &name,
+				Qty:          2,
+				PricePerUnit: 50,
+				SubTotal:     100,
+			},
+		},
+	}
+}
+
+func TestCreateBookingGrpc_Success(t *testing.T) {
+	client, mockUseCase := setupTestClient(t)
+
+	mockUseCase.On("Execute", mock.Anything, mock.MatchedBy(func(req *usecase.CreateBookingRequest) bool {
+		return req.BookingCode == "BKG-001" && len(req.Details) == 1 && *req.Details[0].ProductName == "Premium Package"
+	})).Return(&usecase.CreateBookingResponse{
+		BookingID:   "770e8400-e29b-41d4-a716-446655440003",
+		BookingCode: "BKG-001",
+		UserID:      "550e8400-e29b-41d4-a716-446655440000",
+		TotalAmount: 100,
+		Details: []usecase.CreateBookingDetailResponse{
+			{ProductID: "660e8400-e29b-41d4-a716-446655440001", Qty: 2, PricePerUnit: 50, SubTotal: 100},
+		},
+	}, nil)
+
+	resp, err := client.CreateBooking(context.Background(), validRequest())
+
+	require.NoError(t, err)
+	assert.Equal(t, "770e8400-e29b-41d4-a716-446655440003", resp.GetId())
+	assert.Equal(t, "BKG-001", resp.GetCode())
+	require.Len(t, resp.GetDetails(), 1)
+	assert.Nil(t, resp.GetDetails()[0].ProductName)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestCreateBookingGrpc_ValidationError(t *testing.T) {
+	client, mockUseCase := setupTestClient(t)
+
+	req := validRequest()
+	req.Code = ""
+
+	_, err := client.CreateBooking(context.Background(), req)
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockUseCase.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestCreateBookingGrpc_UseCaseErrorMapped(t *testing.T) {
+	client, mockUseCase := setupTestClient(t)
+
+	mockUseCase.On("Execute", mock.Anything, mock.Anything).Return(nil, entity.ErrBookingCodeAlreadyExists)
+
+	_, err := client.CreateBooking(context.Background(), validRequest())
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	assert.Equal(t, entity.ErrBookingCodeAlreadyExists.Message, st.Message())
+}
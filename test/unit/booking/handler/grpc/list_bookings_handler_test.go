@@ -0,0 +1,66 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	bookingv1 "voyago/core-api/api/proto/booking/v1"
+	deliverygrpc "voyago/core-api/internal/modules/booking/delivery/grpc"
+	"voyago/core-api/internal/modules/booking/usecase"
+	"voyago/core-api/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const listUserID = "550e8400-e29b-41d4-a716-446655440000"
+
+func TestListBookingsGrpc_Success(t *testing.T) {
+	mockUseCase := new(mocks.MockListBookingsUseCase)
+	client := serve(t, deliverygrpc.HandlerUseCases{ListBookingsUseCase: mockUseCase})
+
+	reference := "PAY-001"
+	updatedAt := int64(1700000001000)
+	mockUseCase.On("Execute", mock.Anything, &usecase.ListBookingsRequest{UserID: listUserID, Limit: 10, Offset: 5}).
+		Return([]usecase.BookingResponse{
+			{
+				BookingID:        "770e8400-e29b-41d4-a716-446655440003",
+				BookingCode:      "BKG-001",
+				UserID:           listUserID,
+				TotalAmount:      100,
+				Status:           "CONFIRMED",
+				PaymentStatus:    "PAID",
+				PaymentReference: &reference,
+				CreatedAt:        1700000000000,
+				UpdatedAt:        &updatedAt,
+			},
+			{BookingID: "770e8400-e29b-41d4-a716-446655440004", BookingCode: "BKG-002", UserID: listUserID, CreatedAt: 1690000000000},
+		}, nil)
+
+	resp, err := client.ListBookings(context.Background(), &bookingv1.ListBookingsRequest{UserId: listUserID, Limit: 10, Offset: 5})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetBookings(), 2)
+	first := resp.GetBookings()[0]
+	assert.Equal(t, "BKG-001", first.GetCode())
+	assert.Equal(t, "PAID", first.GetPaymentStatus())
+	assert.Equal(t, "PAY-001", first.GetPaymentReference())
+	assert.Equal(t, int64(1700000000000), first.GetCreatedAt())
+	assert.Equal(t, updatedAt, first.GetUpdatedAt())
+	assert.Nil(t, resp.GetBookings()[1].PaymentReference)
+	assert.Nil(t, resp.GetBookings()[1].UpdatedAt)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestListBookingsGrpc_ValidationError(t *testing.T) {
+	mockUseCase := new(mocks.MockListBookingsUseCase)
+	client := serve(t, deliverygrpc.HandlerUseCases{ListBookingsUseCase: mockUseCase})
+
+	_, err := client.ListBookings(context.Background(), &bookingv1.ListBookingsRequest{UserId: "not-a-uuid", Limit: 101})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	mockUseCase.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
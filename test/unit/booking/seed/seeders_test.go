@@ -0,0 +1,44 @@
+package seed_test
+
+import (
+	"context"
+	"testing"
+
+	"voyago/core-api/internal/app"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/seed"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/booking/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleBookings(t *testing.T) {
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), tracer.NewNoOpTracer())
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.GetDB().AutoMigrate(&entity.Booking{}, &entity.BookingDetail{}))
+	seeders := app.Seeders().Seeders("booking")
+
+	results, err := seed.Run(context.Background(), db, "development", seeders)
+	require.NoError(t, err)
+	assert.Equal(t, []seed.Result{{Name: "sample_bookings", Outcome: seed.Applied}}, results)
+
+	var bookings []entity.Booking
+	require.NoError(t, db.GetDB().Preload("Details").Order("booking_code").Find(&bookings).Error)
+	require.Len(t, bookings, 3)
+	for _, b := range bookings {
+		assert.NoError(t, b.Validate(), b.BookingCode)
+	}
+	assert.Equal(t, "SAMPLE-CANCELLED", bookings[0].BookingCode)
+	assert.Equal(t, entity.PaymentStatusPaid, bookings[1].PaymentStatus, "SAMPLE-CONFIRMED")
+
+	// Rerun once its record is lost: the existing rows are kept.
+	require.NoError(t, db.GetDB().Exec("DELETE FROM schema_seeds").Error)
+	_, err = seed.Run(context.Background(), db, "development", seeders)
+	require.NoError(t, err)
+	var count int64
+	require.NoError(t, db.GetDB().Model(&entity.Booking{}).Count(&count).Error)
+	assert.EqualValues(t, 3, count)
+}
@@ -0,0 +1,336 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/modules/booking/usecase"
+	"voyago/core-api/internal/pkg/audit"
+	"voyago/core-api/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+const paymentBookingID = "750e8400-e29b-41d4-a716-446655440000"
+
+func unpaidBooking() *entity.Booking {
+	return &entity.Booking{
+		ID:            paymentBookingID,
+		BookingCode:   "BOOK001",
+		UserID:        "550e8400-e29b-41d4-a716-446655440000",
+		TotalAmount:   100.0,
+		Status:        entity.BookingStatusPending,
+		PaymentStatus: entity.PaymentStatusUnpaid,
+	}
+}
+
+func setupUpdatePaymentStatus() (*mocks.MockBookingCommandRepository, *mocks.MockBookingQueryRepository, *mocks.MockPublisher, usecase.UpdateBookingPaymentStatusUseCase) {
+	cmd := new(mocks.MockBookingCommandRepository)
+	qry := new(mocks.MockBookingQueryRepository)
+	pub := new(mocks.MockPublisher)
+	txManager := new(mocks.MockTransactionManager)
+	txManager.On("Atomic", mock.Anything, mock.Anything).Return(mocks.RunAtomic)
+
+	uc := usecase.NewUpdateBookingPaymentStatusUseCase(
+		logger.NewNoOpLogger(),
+		tracer.NewNoOpTracer(),
+		metrics.NewBusiness(metrics.NewNoOpMetrics()),
+		txManager,
+		pub,
+		audit.NewNoOpRecorder(),
+		usecase.UpdateBookingPaymentStatusRepositories{BookingCmd: cmd, BookingQry: qry},
+	)
+	return cmd, qry, pub, uc
+}
+
+func setupApplyPaymentStatus() (*mocks.MockBookingCommandRepository, *mocks.MockBookingQueryRepository, *mocks.MockPublisher, *mocks.MockBookingNotifier, usecase.ApplyBookingPaymentStatusUseCase) {
+	cmd := new(mocks.MockBookingCommandRepository)
+	qry := new(mocks.MockBookingQueryRepository)
+	pub := new(mocks.MockPublisher)
+	notifier := new(mocks.MockBookingNotifier)
+
+	uc := usecase.NewApplyBookingPaymentStatusUseCase(
+		logger.NewNoOpLogger(),
+		tracer.NewNoOpTracer(),
+		pub,
+		notifier,
+		audit.NewNoOpRecorder(),
+		usecase.ApplyBookingPaymentStatusRepositories{BookingCmd: cmd, BookingQry: qry},
+	)
+	return cmd, qry, pub, notifier, uc
+}
+
+// ============================================================================
+// TEST CASES: UPDATE PAYMENT STATUS
+// ============================================================================
+
+func TestUpdateBookingPaymentStatus_Success_PublishesEvent(t *testing.T) {
+	cmd, qry, pub, uc := setupUpdatePaymentStatus()
+
+	qry.On("FindByID", mock.Anything, paymentBookingID).Return(unpaidBooking(), nil)
+	cmd.On("UpdatePaymentStatus", mock.Anything, mock.Anything, entity.PaymentStatusUnpaid).Return(nil)
+
+	var published eventbus.Event
+	pub.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		published = args.Get(1).(eventbus.Event)
+	}).Return(nil)
+
+	res, err := uc.Execute(context.Background(), &usecase.UpdateBookingPaymentStatusRequest{
+		BookingID:        paymentBookingID,
+		PaymentStatus:    "PAID",
+		PaymentReference: "PAY-001",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "PAID", res.PaymentStatus)
+	require.NotNil(t, res.PaymentReference)
+	assert.Equal(t, "PAY-001", *res.PaymentReference)
+
+	assert.Equal(t, entity.EventBookingPaymentStatusChanged, published.Type)
+	assert.Equal(t, entity.BookingPaymentStatusChangedPayload{
+		BookingID:        paymentBookingID,
+		BookingCode:      "BOOK001",
+		UserID:           "550e8400-e29b-41d4-a716-446655440000",
+		OldStatus:        entity.PaymentStatusUnpaid,
+		NewStatus:        entity.PaymentStatusPaid,
+		Amount:           100.0,
+		PaymentReference: "PAY-001",
+	}, published.Payload)
+}
+
+func TestUpdateBookingPaymentStatus_RecordsAuditEntry(t *testing.T) {
+	cmd := new(mocks.MockBookingCommandRepository)
+	qry := new(mocks.MockBookingQueryRepository)
+	recorder := new(mocks.MockRecorder)
+	txManager := new(mocks.MockTransactionManager)
+	txManager.On("Atomic", mock.Anything, mock.Anything).Return(mocks.RunAtomic)
+	uc := usecase.NewUpdateBookingPaymentStatusUseCase(
+		logger.NewNoOpLogger(),
+		tracer.NewNoOpTracer(),
+		metrics.NewBusiness(metrics.NewNoOpMetrics()),
+		txManager,
+		eventbus.NewNoOpBus(),
+		recorder,
+		usecase.UpdateBookingPaymentStatusRepositories{BookingCmd: cmd, BookingQry: qry},
+	)
+
+	qry.On("FindByID", mock.Anything, paymentBookingID).Return(unpaidBooking(), nil)
+	cmd.On("UpdatePaymentStatus", mock.Anything, mock.Anything, entity.PaymentStatusUnpaid).Return(nil)
+	var recorded audit.Change
+	recorder.On("Record", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = args.Get(1).(audit.Change)
+	}).Return(nil)
+
+	_, err := uc.Execute(context.Background(), &usecase.UpdateBookingPaymentStatusRequest{
+		BookingID:        paymentBookingID,
+		PaymentStatus:    "PAID",
+		PaymentReference: "PAY-001",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "booking.payment_status.update", recorded.Action)
+	assert.Equal(t, "booking", recorded.EntityType)
+	assert.Equal(t, paymentBookingID, recorded.EntityID)
+	assert.Equal(t, "UNPAID", recorded.Before.(usecase.BookingResponse).PaymentStatus)
+	assert.Equal(t, "PAID", recorded.After.(usecase.BookingResponse).PaymentStatus)
+}
+
+func TestUpdateBookingPaymentStatus_AuditFailure_FailsTheChange(t *testing.T) {
+	cmd := new(mocks.MockBookingCommandRepository)
+	qry := new(mocks.MockBookingQueryRepository)
+	pub := new(mocks.MockPublisher)
+	recorder := new(mocks.MockRecorder)
+	txManager := new(mocks.MockTransactionManager)
+	txManager.On("Atomic", mock.Anything, mock.Anything).Return(mocks.RunAtomic)
+	uc := usecase.NewUpdateBookingPaymentStatusUseCase(
+		logger.NewNoOpLogger(),
+		tracer.NewNoOpTracer(),
+		metrics.NewBusiness(metrics.NewNoOpMetrics()),
+		txManager,
+		pub,
+		recorder,
+		usecase.UpdateBookingPaymentStatusRepositories{BookingCmd: cmd, BookingQry: qry},
+	)
+
+	qry.On("FindByID", mock.Anything, paymentBookingID).Return(unpaidBooking(), nil)
+	cmd.On("UpdatePaymentStatus", mock.Anything, mock.Anything, entity.PaymentStatusUnpaid).Return(nil)
+	recorder.On("Record", mock.Anything, mock.Anything).Return(errors.New("audit table unavailable"))
+
+	_, err := uc.Execute(context.Background(), &usecase.UpdateBookingPaymentStatusRequest{
+		BookingID:        paymentBookingID,
+		PaymentStatus:    "PAID",
+		PaymentReference: "PAY-001",
+	})
+
+	assert.Error(t, err, "the change is rolled back with its audit entry")
+	pub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestUpdateBookingPaymentStatus_Replay_IsNoOp(t *testing.T) {
+	_, qry, pub, uc := setupUpdatePaymentStatus()
+
+	b := unpaidBooking()
+	ref := "PAY-001"
+	b.PaymentStatus = entity.PaymentStatusPaid
+	b.PaymentReference = &ref
+	qry.On("FindByID", mock.Anything, paymentBookingID).Return(b, nil)
+
+	res, err := uc.Execute(context.Background(), &usecase.UpdateBookingPaymentStatusRequest{
+		BookingID:        paymentBookingID,
+		PaymentStatus:    "PAID",
+		PaymentReference: ref,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "PAID", res.PaymentStatus)
+	pub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestUpdateBookingPaymentStatus_InvalidTransition(t *testing.T) {
+	cmd, qry, pub, uc := setupUpdatePaymentStatus()
+
+	qry.On("FindByID", mock.Anything, paymentBookingID).Return(unpaidBooking(), nil)
+
+	_, err := uc.Execute(context.Background(), &usecase.UpdateBookingPaymentStatusRequest{
+		BookingID:        paymentBookingID,
+		PaymentStatus:    "REFUNDED",
+		PaymentReference: "PAY-001",
+	})
+
+	assert.ErrorIs(t, err, entity.ErrBookingPaymentTransitionInvalid)
+	cmd.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything, mock.Anything)
+	pub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestUpdateBookingPaymentStatus_NotFound(t *testing.T) {
+	_, qry, _, uc := setupUpdatePaymentStatus()
+
+	qry.On("FindByID", mock.Anything, paymentBookingID).Return(nil, nil)
+
+	_, err := uc.Execute(context.Background(), &usecase.UpdateBookingPaymentStatusRequest{
+		BookingID:        paymentBookingID,
+		PaymentStatus:    "PAID",
+		PaymentReference: "PAY-001",
+	})
+
+	assert.ErrorIs(t, err, entity.ErrBookingNotFound)
+}
+
+func TestUpdateBookingPaymentStatus_ConcurrentChange_DoesNotPublish(t *testing.T) {
+	cmd, qry, pub, uc := setupUpdatePaymentStatus()
+
+	qry.On("FindByID", mock.Anything, paymentBookingID).Return(unpaidBooking(), nil)
+	cmd.On("UpdatePaymentStatus", mock.Anything, mock.Anything, entity.PaymentStatusUnpaid).
+		Return(entity.ErrBookingPaymentStatusConflict)
+
+	_, err := uc.Execute(context.Background(), &usecase.UpdateBookingPaymentStatusRequest{
+		BookingID:        paymentBookingID,
+		PaymentStatus:    "PAID",
+		PaymentReference: "PAY-001",
+	})
+
+	assert.ErrorIs(t, err, entity.ErrBookingPaymentStatusConflict)
+	pub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+// ============================================================================
+// TEST CASES: APPLY PAYMENT STATUS (EVENT CONSUMER)
+// ============================================================================
+
+func paidPayload() entity.BookingPaymentStatusChangedPayload {
+	return entity.BookingPaymentStatusChangedPayload{
+		BookingID:        paymentBookingID,
+		BookingCode:      "BOOK001",
+		UserID:           "550e8400-e29b-41d4-a716-446655440000",
+		OldStatus:        entity.PaymentStatusUnpaid,
+		NewStatus:        entity.PaymentStatusPaid,
+		Amount:           100.0,
+		PaymentReference: "PAY-001",
+	}
+}
+
+func TestApplyBookingPaymentStatus_Paid_ConfirmsAndNotifies(t *testing.T) {
+	cmd, qry, pub, notifier, uc := setupApplyPaymentStatus()
+
+	qry.On("FindByID", mock.Anything, paymentBookingID).Return(unpaidBooking(), nil)
+	cmd.On("UpdateStatus", mock.Anything, mock.MatchedBy(func(b *entity.Booking) bool {
+		return b.Status == entity.BookingStatusConfirmed
+	}), entity.BookingStatusPending).Return(true, nil)
+	notifier.On("NotifyPaymentStatusChanged", mock.Anything, mock.MatchedBy(func(n usecase.BookingPaymentNotification) bool {
+		return n.PaymentStatus == "PAID" && n.BookingStatus == "CONFIRMED" && n.PaymentReference == "PAY-001"
+	})).Return(nil)
+	pub.On("Publish", mock.Anything, mock.MatchedBy(func(evt eventbus.Event) bool {
+		p, ok := evt.Payload.(entity.BookingStatusChangedPayload)
+		return evt.Type == entity.EventBookingStatusChanged && ok &&
+			p.OldStatus == entity.BookingStatusPending &&
+			p.NewStatus == entity.BookingStatusConfirmed &&
+			p.PaymentStatus == entity.PaymentStatusPaid
+	})).Return(nil)
+
+	err := uc.Execute(context.Background(), paidPayload())
+
+	require.NoError(t, err)
+	cmd.AssertExpectations(t)
+	pub.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+}
+
+func TestApplyBookingPaymentStatus_Failed_OnlyNotifies(t *testing.T) {
+	cmd, qry, pub, notifier, uc := setupApplyPaymentStatus()
+
+	payload := paidPayload()
+	payload.NewStatus = entity.PaymentStatusFailed
+
+	qry.On("FindByID", mock.Anything, paymentBookingID).Return(unpaidBooking(), nil)
+	notifier.On("NotifyPaymentStatusChanged", mock.Anything, mock.MatchedBy(func(n usecase.BookingPaymentNotification) bool {
+		return n.PaymentStatus == "FAILED" && n.BookingStatus == "PENDING"
+	})).Return(nil)
+
+	err := uc.Execute(context.Background(), payload)
+
+	require.NoError(t, err)
+	cmd.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	pub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	notifier.AssertExpectations(t)
+}
+
+func TestApplyBookingPaymentStatus_LostRace_KeepsStoredStatus(t *testing.T) {
+	cmd, qry, pub, notifier, uc := setupApplyPaymentStatus()
+
+	qry.On("FindByID", mock.Anything, paymentBookingID).Return(unpaidBooking(), nil)
+	cmd.On("UpdateStatus", mock.Anything, mock.Anything, entity.BookingStatusPending).Return(false, nil)
+	notifier.On("NotifyPaymentStatusChanged", mock.Anything, mock.MatchedBy(func(n usecase.BookingPaymentNotification) bool {
+		return n.BookingStatus == "PENDING"
+	})).Return(nil)
+
+	err := uc.Execute(context.Background(), paidPayload())
+
+	require.NoError(t, err)
+	pub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	notifier.AssertExpectations(t)
+}
+
+func TestApplyBookingPaymentStatus_NotifierFailure_IsNotAnError(t *testing.T) {
+	cmd, qry, pub, notifier, uc := setupApplyPaymentStatus()
+
+	qry.On("FindByID", mock.Anything, paymentBookingID).Return(unpaidBooking(), nil)
+	cmd.On("UpdateStatus", mock.Anything, mock.Anything, entity.BookingStatusPending).Return(true, nil)
+	pub.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	notifier.On("NotifyPaymentStatusChanged", mock.Anything, mock.Anything).Return(errors.New("smtp down"))
+
+	err := uc.Execute(context.Background(), paidPayload())
+
+	assert.NoError(t, err)
+}
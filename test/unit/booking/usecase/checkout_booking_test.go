@@ -0,0 +1,177 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/saga"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/modules/booking/usecase"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/audit"
+	"voyago/core-api/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type checkoutMocks struct {
+	cmd       *mocks.MockBookingCommandRepository
+	qry       *mocks.MockBookingQueryRepository
+	pub       *mocks.MockPublisher
+	inventory *mocks.MockInventoryService
+	payments  *mocks.MockPaymentGateway
+	sagas     saga.Store
+}
+
+func setupCheckout(t *testing.T) (checkoutMocks, usecase.CheckoutBookingUseCase) {
+	t.Helper()
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), nil)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.GetDB().AutoMigrate(&saga.Instance{}))
+
+	m := checkoutMocks{
+		cmd:       new(mocks.MockBookingCommandRepository),
+		qry:       new(mocks.MockBookingQueryRepository),
+		pub:       new(mocks.MockPublisher),
+		inventory: new(mocks.MockInventoryService),
+		payments:  new(mocks.MockPaymentGateway),
+		sagas:     saga.NewDatabaseStore(db),
+	}
+	txManager := new(mocks.MockTransactionManager)
+	txManager.On("Atomic", mock.Anything, mock.Anything).Return(mocks.RunAtomic)
+
+	uc := usecase.NewCheckoutBookingUseCase(
+		logger.NewNoOpLogger(),
+		tracer.NewNoOpTracer(),
+		metrics.NewBusiness(metrics.NewNoOpMetrics()),
+		nil,
+		txManager,
+		m.pub,
+		audit.NewNoOpRecorder(),
+		usecase.CheckoutServices{Inventory: m.inventory, Payments: m.payments},
+		0,
+		usecase.CheckoutBookingRepositories{BookingCmd: m.cmd, BookingQry: m.qry, Sagas: m.sagas},
+	)
+	return m, uc
+}
+
+func checkoutRequest() *usecase.CheckoutBookingRequest {
+	return &usecase.CheckoutBookingRequest{BookingID: paymentBookingID, PaymentMethod: "card"}
+}
+
+func TestCheckoutBooking_Success_ConfirmsThePaidBooking(t *testing.T) {
+	m, uc := setupCheckout(t)
+	b := unpaidBooking()
+	m.qry.On("FindByID", mock.Anything, paymentBookingID).Return(b, nil)
+	m.qry.On("FindDetailsByBookingIDs", mock.Anything, []string{paymentBookingID}).Return([]entity.BookingDetail{
+		{ProductID: "p-1", Qty: 2},
+	}, nil)
+	m.inventory.On("Reserve", mock.Anything, usecase.InventoryReservation{
+		BookingID: paymentBookingID,
+		Items:     []usecase.InventoryItem{{ProductID: "p-1", Qty: 2}},
+	}).Return(nil)
+	m.payments.On("Capture", mock.Anything, usecase.PaymentCapture{
+		BookingID: paymentBookingID,
+		UserID:    b.UserID,
+		Method:    "card",
+		Amount:    100,
+	}).Return("PAY-1", nil)
+	m.cmd.On("UpdatePaymentStatus", mock.Anything, mock.Anything, entity.PaymentStatusUnpaid).Return(nil)
+	m.cmd.On("UpdateStatus", mock.Anything, mock.Anything, entity.BookingStatusPending).Return(true, nil)
+	var published []eventbus.Event
+	m.pub.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		published = append(published, args.Get(1).(eventbus.Event))
+	}).Return(nil)
+
+	res, err := uc.Execute(context.Background(), checkoutRequest())
+
+	require.NoError(t, err)
+	assert.Equal(t, string(entity.BookingStatusConfirmed), res.Status)
+	assert.Equal(t, string(entity.PaymentStatusPaid), res.PaymentStatus)
+	require.NotNil(t, res.PaymentReference)
+	assert.Equal(t, "PAY-1", *res.PaymentReference)
+	require.Len(t, published, 2)
+	assert.Equal(t, entity.EventBookingPaymentStatusChanged, published[0].Type)
+	assert.Equal(t, entity.EventBookingStatusChanged, published[1].Type)
+	m.inventory.AssertNotCalled(t, "Release", mock.Anything, mock.Anything)
+	m.payments.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything)
+
+	inst, err := m.sagas.Find(context.Background(), usecase.CheckoutSagaName, paymentBookingID)
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompleted, inst.Status)
+}
+
+func TestCheckoutBooking_PaymentDeclined_CompensatesAndCancels(t *testing.T) {
+	m, uc := setupCheckout(t)
+	b := unpaidBooking()
+	m.qry.On("FindByID", mock.Anything, paymentBookingID).Return(b, nil)
+	m.qry.On("FindDetailsByBookingIDs", mock.Anything, mock.Anything).Return([]entity.BookingDetail{}, nil)
+	m.inventory.On("Reserve", mock.Anything, mock.Anything).Return(nil)
+	m.inventory.On("Release", mock.Anything, paymentBookingID).Return(nil)
+	m.payments.On("Capture", mock.Anything, mock.Anything).Return("", errors.New("card declined"))
+	m.payments.On("Refund", mock.Anything, paymentBookingID).Return(nil)
+	var cancelled *entity.Booking
+	m.cmd.On("UpdateStatus", mock.Anything, mock.Anything, entity.BookingStatusPending).Run(func(args mock.Arguments) {
+		cancelled = args.Get(1).(*entity.Booking)
+	}).Return(true, nil)
+	m.pub.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+	res, err := uc.Execute(context.Background(), checkoutRequest())
+
+	assert.Nil(t, res)
+	var appErr *apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, entity.CodeBookingCheckoutFailed, appErr.Code)
+	assert.Equal(t, map[string]any{"step": "capture_payment"}, appErr.Details)
+	m.payments.AssertCalled(t, "Refund", mock.Anything, paymentBookingID)
+	m.inventory.AssertCalled(t, "Release", mock.Anything, paymentBookingID)
+	require.NotNil(t, cancelled)
+	assert.Equal(t, entity.BookingStatusCancelled, cancelled.Status)
+	m.cmd.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCheckoutBooking_NotAwaitingPayment(t *testing.T) {
+	m, uc := setupCheckout(t)
+	b := unpaidBooking()
+	b.Status = entity.BookingStatusConfirmed
+	b.PaymentStatus = entity.PaymentStatusPaid
+	m.qry.On("FindByID", mock.Anything, paymentBookingID).Return(b, nil)
+
+	_, err := uc.Execute(context.Background(), checkoutRequest())
+
+	assert.ErrorIs(t, err, entity.ErrBookingNotAwaitingPayment)
+	m.inventory.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything)
+}
+
+func TestCheckoutBooking_NotFound(t *testing.T) {
+	m, uc := setupCheckout(t)
+	m.qry.On("FindByID", mock.Anything, paymentBookingID).Return(nil, nil)
+
+	_, err := uc.Execute(context.Background(), checkoutRequest())
+
+	assert.ErrorIs(t, err, entity.ErrBookingNotFound)
+}
+
+func TestCheckoutBooking_AlreadyStarted(t *testing.T) {
+	m, uc := setupCheckout(t)
+	m.qry.On("FindByID", mock.Anything, paymentBookingID).Return(unpaidBooking(), nil)
+	require.NoError(t, m.sagas.Create(context.Background(), &saga.Instance{
+		Name:   usecase.CheckoutSagaName,
+		ID:     paymentBookingID,
+		Status: saga.StatusRunning,
+		Data:   "{}",
+	}))
+
+	_, err := uc.Execute(context.Background(), checkoutRequest())
+
+	assert.ErrorIs(t, err, entity.ErrBookingCheckoutAlreadyStarted)
+	m.inventory.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything)
+}
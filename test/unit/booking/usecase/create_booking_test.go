@@ -5,178 +5,37 @@ import (
 	"errors"
 	"testing"
 
-	"voyago/core-api/internal/infrastructure/logger"
-	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
 	"voyago/core-api/internal/modules/booking/entity"
 	"voyago/core-api/internal/modules/booking/usecase"
+	"voyago/core-api/internal/pkg/audit"
+	"voyago/core-api/internal/pkg/uid"
+	"voyago/core-api/test/mocks"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"gorm.io/gorm"
 )
 
-// ============================================================================
-// MOCKS
-// ============================================================================
-
-// MockLogger is a mock implementation of logger.Logger
-type MockLogger struct {
-	mock.Mock
-}
-
-func (m *MockLogger) WithContext(ctx context.Context) logger.Logger {
-	args := m.Called(ctx)
-	return args.Get(0).(logger.Logger)
-}
-
-func (m *MockLogger) WithField(key string, value any) logger.Logger {
-	args := m.Called(key, value)
-	return args.Get(0).(logger.Logger)
-}
-
-func (m *MockLogger) WithFields(fields map[string]any) logger.Logger {
-	args := m.Called(fields)
-	return args.Get(0).(logger.Logger)
-}
-
-func (m *MockLogger) Debug(message string) {
-	m.Called(message)
-}
-
-func (m *MockLogger) Info(message string) {
-	m.Called(message)
-}
-
-func (m *MockLogger) Warn(message string) {
-	m.Called(message)
-}
-
-func (m *MockLogger) Error(message string) {
-	m.Called(message)
-}
-
-// MockSpan is a mock implementation of tracer.Span
-type MockSpan struct {
-	mock.Mock
-}
-
-func (m *MockSpan) SetOperationName(name string) {
-	m.Called(name)
-}
-
-func (m *MockSpan) Finish() {
-	m.Called()
-}
-
-func (m *MockSpan) SetTag(key string, value any) {
-	m.Called(key, value)
-}
-
-// MockTracer is a mock implementation of tracer.Tracer
-type MockTracer struct {
-	mock.Mock
-}
-
-func (m *MockTracer) StartSpan(ctx context.Context, name string) (tracer.Span, context.Context) {
-	args := m.Called(ctx, name)
-	return args.Get(0).(tracer.Span), args.Get(1).(context.Context)
-}
-
-func (m *MockTracer) UseGorm(db *gorm.DB) {
-	m.Called(db)
-}
-
-func (m *MockTracer) ExtractTraceInfo(ctx context.Context) (traceID, spanID string, ok bool) {
-	args := m.Called(ctx)
-	return args.String(0), args.String(1), args.Bool(2)
-}
-
-func (m *MockTracer) Close() error {
-	args := m.Called()
-	return args.Error(0)
-}
-
-// MockTransactionManager is a mock implementation of baserepo.TransactionManager
-type MockTransactionManager struct {
-	mock.Mock
-}
-
-func (m *MockTransactionManager) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
-	args := m.Called(ctx, fn)
-
-	// Execute the function if we're testing success scenarios
-	if args.Error(0) == nil {
-		return fn(ctx)
-	}
-
-	return args.Error(0)
-}
-
-// MockBookingCommandRepository is a mock implementation of repository.BookingCommandRepository
-type MockBookingCommandRepository struct {
-	mock.Mock
-}
-
-func (m *MockBookingCommandRepository) Create(ctx context.Context, booking *entity.Booking) error {
-	args := m.Called(ctx, booking)
-	return args.Error(0)
-}
-
-func (m *MockBookingCommandRepository) Update(ctx context.Context, booking *entity.Booking) error {
-	args := m.Called(ctx, booking)
-	return args.Error(0)
-}
-
-func (m *MockBookingCommandRepository) Delete(ctx context.Context, booking *entity.Booking) error {
-	args := m.Called(ctx, booking)
-	return args.Error(0)
-}
-
-// MockBookingQueryRepository is a mock implementation of repository.BookingQueryRepository
-type MockBookingQueryRepository struct {
-	mock.Mock
-}
-
-func (m *MockBookingQueryRepository) ExistsByBookingCode(ctx context.Context, code string) (bool, error) {
-	args := m.Called(ctx, code)
-	return args.Bool(0), args.Error(1)
-}
-
-func (m *MockBookingQueryRepository) FindByID(ctx context.Context, id string) (*entity.Booking, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Booking), args.Error(1)
-}
-
-func (m *MockBookingQueryRepository) FindByCode(ctx context.Context, code string) (*entity.Booking, error) {
-	args := m.Called(ctx, code)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Booking), args.Error(1)
-}
-
 // ============================================================================
 // TEST HELPERS
 // ============================================================================
 
 func setupTest(t *testing.T) (
-	*MockLogger,
-	*MockTracer,
-	*MockSpan,
-	*MockTransactionManager,
-	*MockBookingCommandRepository,
-	*MockBookingQueryRepository,
+	*mocks.MockLogger,
+	*mocks.MockTracer,
+	*mocks.MockSpan,
+	*mocks.MockTransactionManager,
+	*mocks.MockBookingCommandRepository,
+	*mocks.MockBookingQueryRepository,
 	usecase.CreateBookingUseCase,
 ) {
-	mockLog := new(MockLogger)
-	mockTracer := new(MockTracer)
-	mockSpan := new(MockSpan)
-	mockTxManager := new(MockTransactionManager)
-	mockBookingCmd := new(MockBookingCommandRepository)
-	mockBookingQry := new(MockBookingQueryRepository)
+	mockLog := new(mocks.MockLogger)
+	mockTracer := new(mocks.MockTracer)
+	mockSpan := new(mocks.MockSpan)
+	mockTxManager := new(mocks.MockTransactionManager)
+	mockBookingCmd := new(mocks.MockBookingCommandRepository)
+	mockBookingQry := new(mocks.MockBookingQueryRepository)
 
 	// Setup common mock expectations for logger
 	mockLog.On("WithField", "action", "usecase:booking.create").Return(mockLog)
@@ -190,14 +49,21 @@ func setupTest(t *testing.T) (
 	// Setup common mock expectations for tracer
 	mockTracer.On("StartSpan", mock.Anything, "usecase:booking.create").Return(mockSpan, context.Background())
 	mockSpan.On("Finish").Return()
-	// RecordSpanError calls SetTag multiple times: error (bool), error.message, error.code, error.kind
+	// RecordSpanError calls RecordError, then SetTag multiple times: error (bool), error.message,
+	// error.type, error.stack, error.code, error.kind
 	// Use Maybe() to allow 0 or more calls
+	mockSpan.On("RecordError", mock.Anything).Return().Maybe()
 	mockSpan.On("SetTag", mock.Anything, mock.Anything).Return().Maybe()
 
 	uc := usecase.NewCreateBookingUseCase(
 		mockLog,
 		mockTracer,
+		metrics.NewBusiness(metrics.NewNoOpMetrics()),
 		mockTxManager,
+		eventbus.NewNoOpBus(),
+		audit.NewNoOpRecorder(),
+		uid.UUIDv7,
+		usecase.PaymentReminderPolicy{},
 		usecase.CreateBookingRepositories{
 			BookingCmd: mockBookingCmd,
 			BookingQry: mockBookingQry,
@@ -235,7 +101,7 @@ func TestCreateBookingUseCase_Execute_Success(t *testing.T) {
 	req := createValidRequest()
 
 	mockBookingQry.On("ExistsByBookingCode", mock.Anything, req.BookingCode).Return(false, nil)
-	mockTxManager.On("Atomic", mock.Anything, mock.Anything).Return(nil)
+	mockTxManager.On("Atomic", mock.Anything, mock.Anything).Return(mocks.RunAtomic)
 	mockBookingCmd.On("Create", mock.Anything, mock.Anything).Return(nil)
 
 	// Act
@@ -355,7 +221,7 @@ func TestCreateBookingUseCase_Execute_CreateError(t *testing.T) {
 	expectedErr := errors.New("database insert error")
 	mockBookingQry.On("ExistsByBookingCode", mock.Anything, req.BookingCode).Return(false, nil)
 	mockBookingCmd.On("Create", mock.Anything, mock.Anything).Return(expectedErr)
-	mockTxManager.On("Atomic", mock.Anything, mock.Anything).Return(nil)
+	mockTxManager.On("Atomic", mock.Anything, mock.Anything).Return(mocks.RunAtomic)
 
 	// Act
 	resp, err := uc.Execute(context.Background(), req)
@@ -423,7 +289,7 @@ func TestCreateBookingUseCase_Execute_MultipleDetails(t *testing.T) {
 	}
 
 	mockBookingQry.On("ExistsByBookingCode", mock.Anything, req.BookingCode).Return(false, nil)
-	mockTxManager.On("Atomic", mock.Anything, mock.Anything).Return(nil)
+	mockTxManager.On("Atomic", mock.Anything, mock.Anything).Return(mocks.RunAtomic)
 	mockBookingCmd.On("Create", mock.Anything, mock.Anything).Return(nil)
 
 	// Act
@@ -0,0 +1,137 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/taskqueue"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/booking/entity"
+	"voyago/core-api/internal/modules/booking/usecase"
+	"voyago/core-api/internal/pkg/audit"
+	"voyago/core-api/internal/pkg/uid"
+	"voyago/core-api/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSendPaymentReminder() (*mocks.MockBookingQueryRepository, *mocks.MockBookingNotifier, usecase.SendPaymentReminderUseCase) {
+	qry := new(mocks.MockBookingQueryRepository)
+	notifier := new(mocks.MockBookingNotifier)
+
+	uc := usecase.NewSendPaymentReminderUseCase(
+		logger.NewNoOpLogger(),
+		tracer.NewNoOpTracer(),
+		notifier,
+		usecase.SendPaymentReminderRepositories{BookingQry: qry},
+	)
+	return qry, notifier, uc
+}
+
+func TestSendPaymentReminder_RemindsAnUnpaidBooking(t *testing.T) {
+	qry, notifier, uc := setupSendPaymentReminder()
+	b := &entity.Booking{
+		ID:            "b-1",
+		UserID:        "u-1",
+		BookingCode:   "BOOK001",
+		TotalAmount:   100,
+		Status:        entity.BookingStatusPending,
+		PaymentStatus: entity.PaymentStatusUnpaid,
+	}
+	qry.On("FindByID", mock.Anything, "b-1").Return(b, nil)
+	notifier.On("NotifyPaymentReminder", mock.Anything, usecase.BookingPaymentReminder{
+		UserID:      "u-1",
+		BookingID:   "b-1",
+		BookingCode: "BOOK001",
+		Amount:      100,
+	}).Return(nil)
+
+	err := uc.Execute(context.Background(), entity.PaymentReminderPayload{BookingID: "b-1"})
+
+	assert.NoError(t, err)
+	notifier.AssertExpectations(t)
+}
+
+func TestSendPaymentReminder_SkipsTheBookingsNotAwaitingPayment(t *testing.T) {
+	cases := map[string]*entity.Booking{
+		"deleted":   nil,
+		"paid":      {ID: "b-1", Status: entity.BookingStatusConfirmed, PaymentStatus: entity.PaymentStatusPaid},
+		"cancelled": {ID: "b-1", Status: entity.BookingStatusCancelled, PaymentStatus: entity.PaymentStatusUnpaid},
+	}
+	for name, b := range cases {
+		t.Run(name, func(t *testing.T) {
+			qry, notifier, uc := setupSendPaymentReminder()
+			if b == nil {
+				qry.On("FindByID", mock.Anything, "b-1").Return(nil, nil)
+			} else {
+				qry.On("FindByID", mock.Anything, "b-1").Return(b, nil)
+			}
+
+			err := uc.Execute(context.Background(), entity.PaymentReminderPayload{BookingID: "b-1"})
+
+			assert.NoError(t, err)
+			notifier.AssertNotCalled(t, "NotifyPaymentReminder", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSendPaymentReminder_NotifierFailure_IsReturnedForARetry(t *testing.T) {
+	qry, notifier, uc := setupSendPaymentReminder()
+	qry.On("FindByID", mock.Anything, "b-1").Return(&entity.Booking{
+		ID:            "b-1",
+		Status:        entity.BookingStatusPending,
+		PaymentStatus: entity.PaymentStatusFailed,
+	}, nil)
+	notifier.On("NotifyPaymentReminder", mock.Anything, mock.Anything).Return(errors.New("smtp down"))
+
+	err := uc.Execute(context.Background(), entity.PaymentReminderPayload{BookingID: "b-1"})
+
+	assert.EqualError(t, err, "smtp down")
+}
+
+func TestCreateBookingUseCase_Execute_SchedulesThePaymentReminder(t *testing.T) {
+	mockTxManager := new(mocks.MockTransactionManager)
+	mockBookingCmd := new(mocks.MockBookingCommandRepository)
+	mockBookingQry := new(mocks.MockBookingQueryRepository)
+	tasks := new(mocks.MockEnqueuer)
+	req := createValidRequest()
+
+	mockBookingQry.On("ExistsByBookingCode", mock.Anything, req.BookingCode).Return(false, nil)
+	mockTxManager.On("Atomic", mock.Anything, mock.Anything).Return(mocks.RunAtomic)
+	mockBookingCmd.On("Create", mock.Anything, mock.Anything).Return(nil)
+	var task *taskqueue.Task
+	tasks.On("Enqueue", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		task = args.Get(1).(*taskqueue.Task)
+	}).Return(nil)
+
+	uc := usecase.NewCreateBookingUseCase(
+		logger.NewNoOpLogger(),
+		tracer.NewNoOpTracer(),
+		metrics.NewBusiness(metrics.NewNoOpMetrics()),
+		mockTxManager,
+		eventbus.NewNoOpBus(),
+		audit.NewNoOpRecorder(),
+		uid.UUIDv7,
+		usecase.PaymentReminderPolicy{Tasks: tasks, Delay: time.Hour},
+		usecase.CreateBookingRepositories{
+			BookingCmd: mockBookingCmd,
+			BookingQry: mockBookingQry,
+		},
+	)
+
+	resp, err := uc.Execute(context.Background(), req)
+
+	require.NoError(t, err)
+	require.NotNil(t, task)
+	assert.Equal(t, entity.TaskPaymentReminder, task.Type)
+	assert.Equal(t, entity.TaskPaymentReminder+":"+resp.BookingID, task.ID)
+	assert.JSONEq(t, `{"booking_id":"`+resp.BookingID+`"}`, string(task.Payload))
+	assert.InDelta(t, time.Now().Add(time.Hour).UnixMilli(), task.ProcessAt, float64(time.Minute.Milliseconds()))
+}
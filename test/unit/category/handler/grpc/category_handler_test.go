@@ -0,0 +1,209 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	categoryv1 "voyago/core-api/api/proto/category/v1"
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/infrastructure/validator"
+	deliverygrpc "voyago/core-api/internal/modules/category/delivery/grpc"
+	"voyago/core-api/internal/modules/category/usecase"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	rootID  = "550e8400-e29b-41d4-a716-446655440000"
+	childID = "660e8400-e29b-41d4-a716-446655440001"
+)
+
+type useCaseMocks struct {
+	list     *mocks.MockListCategoriesUseCase
+	byIDs    *mocks.MockGetCategoriesByIDsUseCase
+	children *mocks.MockGetCategoryChildrenUseCase
+}
+
+// setupTestClient serves the category gRPC handler, behind the production
+// interceptor chain, over an in-memory listener.
+func setupTestClient(t *testing.T) (categoryv1.CategoryServiceClient, useCaseMocks) {
+	t.Helper()
+
+	m := useCaseMocks{
+		list:     new(mocks.MockListCategoriesUseCase),
+		byIDs:    new(mocks.MockGetCategoriesByIDsUseCase),
+		children: new(mocks.MockGetCategoryChildrenUseCase),
+	}
+	log := logger.NewNoOpLogger()
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		app.GrpcInterceptors(nil, log, tracer.NewNoOpTracer(), metrics.NewNoOpMetrics(), nil)...,
+	))
+
+	service := deliverygrpc.ServiceConfig{
+		Config: &config.Config{},
+		Server: srv,
+		Handler: deliverygrpc.NewHandler(
+			&config.Config{},
+			log,
+			validator.NewPlaygroundValidator(),
+			deliverygrpc.HandlerUseCases{
+				ListCategoriesUseCase:      m.list,
+				GetCategoriesByIDsUseCase:  m.byIDs,
+				GetCategoryChildrenUseCase: m.children,
+			},
+		),
+	}
+	service.Setup()
+
+	lis := bufconn.Listen(1024 * 1024)
+	go func() { _ = srv.Serve(lis) }()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		srv.Stop()
+	})
+
+	return categoryv1.NewCategoryServiceClient(conn), m
+}
+
+func root() usecase.CategoryResponse {
+	return usecase.CategoryResponse{CategoryID: rootID, Name: "Tours", Slug: "tours", CreatedAt: 1700000000000}
+}
+
+func child() usecase.CategoryResponse {
+	parentID := rootID
+	updatedAt := int64(1700000001000)
+	return usecase.CategoryResponse{
+		CategoryID: childID,
+		ParentID:   &parentID,
+		Name:       "City Tours",
+		Slug:       "city-tours",
+		Position:   1,
+		CreatedAt:  1700000000000,
+		UpdatedAt:  &updatedAt,
+	}
+}
+
+func TestListCategoriesGrpc_Success(t *testing.T) {
+	client, m := setupTestClient(t)
+
+	m.list.On("Execute", mock.Anything, &usecase.ListCategoriesRequest{Limit: 10, Offset: 5}).
+		Return([]usecase.CategoryResponse{root()}, nil)
+
+	resp, err := client.ListCategories(context.Background(), &categoryv1.ListCategoriesRequest{Limit: 10, Offset: 5})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetCategories(), 1)
+	assert.Equal(t, "tours", resp.GetCategories()[0].GetSlug())
+	assert.Nil(t, resp.GetCategories()[0].ParentId)
+	assert.Nil(t, resp.GetCategories()[0].UpdatedAt)
+	m.list.AssertExpectations(t)
+}
+
+func TestListCategoriesGrpc_ValidationError(t *testing.T) {
+	client, m := setupTestClient(t)
+
+	_, err := client.ListCategories(context.Background(), &categoryv1.ListCategoriesRequest{Limit: 101})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	m.list.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestGetCategoryGrpc_Success(t *testing.T) {
+	client, m := setupTestClient(t)
+
+	m.byIDs.On("Execute", mock.Anything, []string{childID}).
+		Return(map[string]usecase.CategoryResponse{childID: child()}, nil)
+
+	resp, err := client.GetCategory(context.Background(), &categoryv1.GetCategoryRequest{Id: childID})
+
+	require.NoError(t, err)
+	category := resp.GetCategory()
+	assert.Equal(t, childID, category.GetId())
+	assert.Equal(t, rootID, category.GetParentId())
+	assert.Equal(t, "City Tours", category.GetName())
+	assert.Equal(t, int32(1), category.GetPosition())
+	assert.Equal(t, int64(1700000000000), category.GetCreatedAt())
+	assert.Equal(t, int64(1700000001000), category.GetUpdatedAt())
+}
+
+func TestGetCategoryGrpc_NotFound(t *testing.T) {
+	client, m := setupTestClient(t)
+
+	m.byIDs.On("Execute", mock.Anything, []string{childID}).
+		Return(map[string]usecase.CategoryResponse{}, nil)
+
+	_, err := client.GetCategory(context.Background(), &categoryv1.GetCategoryRequest{Id: childID})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, apperror.CodeNotFound, apperror.FromGRPCStatus(status.Convert(err)).Code)
+}
+
+func TestGetCategoryGrpc_ValidationError(t *testing.T) {
+	client, m := setupTestClient(t)
+
+	_, err := client.GetCategory(context.Background(), &categoryv1.GetCategoryRequest{Id: "not-a-uuid"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	m.byIDs.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestListCategoryChildrenGrpc_Success(t *testing.T) {
+	client, m := setupTestClient(t)
+
+	m.children.On("Execute", mock.Anything, []string{rootID}).
+		Return(map[string][]usecase.CategoryResponse{rootID: {child()}}, nil)
+
+	resp, err := client.ListCategoryChildren(context.Background(), &categoryv1.ListCategoryChildrenRequest{ParentId: rootID})
+
+	require.NoError(t, err)
+	require.Len(t, resp.GetCategories(), 1)
+	assert.Equal(t, childID, resp.GetCategories()[0].GetId())
+}
+
+func TestListCategoryChildrenGrpc_Leaf(t *testing.T) {
+	client, m := setupTestClient(t)
+
+	m.children.On("Execute", mock.Anything, []string{childID}).
+		Return(map[string][]usecase.CategoryResponse{}, nil)
+
+	resp, err := client.ListCategoryChildren(context.Background(), &categoryv1.ListCategoryChildrenRequest{ParentId: childID})
+
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetCategories())
+}
+
+func TestListCategoryChildrenGrpc_UseCaseErrorMapped(t *testing.T) {
+	client, m := setupTestClient(t)
+
+	m.children.On("Execute", mock.Anything, []string{rootID}).
+		Return(nil, apperror.NewTransient(apperror.CodeDbConnectionFailed, "Database connection failed"))
+
+	_, err := client.ListCategoryChildren(context.Background(), &categoryv1.ListCategoryChildrenRequest{ParentId: rootID})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,46 @@
+package seed_test
+
+import (
+	"context"
+	"testing"
+
+	"voyago/core-api/internal/app"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/seed"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/category/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSampleCategories(t *testing.T) {
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), tracer.NewNoOpTracer())
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.GetDB().AutoMigrate(&entity.Category{}))
+	seeders := app.Seeders().Seeders("category")
+
+	results, err := seed.Run(context.Background(), db, "development", seeders)
+	require.NoError(t, err)
+	assert.Equal(t, []seed.Result{{Name: "sample_categories", Outcome: seed.Applied}}, results)
+
+	var categories []entity.Category
+	require.NoError(t, db.GetDB().Order("slug").Find(&categories).Error)
+	require.Len(t, categories, 5)
+	bySlug := map[string]entity.Category{}
+	for _, c := range categories {
+		bySlug[c.Slug] = c
+	}
+	assert.Nil(t, bySlug["stays"].ParentID)
+	require.NotNil(t, bySlug["hotels"].ParentID)
+	assert.Equal(t, bySlug["stays"].ID, *bySlug["hotels"].ParentID)
+
+	// Rerun once its record is lost: the existing rows are kept.
+	require.NoError(t, db.GetDB().Exec("DELETE FROM schema_seeds").Error)
+	_, err = seed.Run(context.Background(), db, "development", seeders)
+	require.NoError(t, err)
+	var count int64
+	require.NoError(t, db.GetDB().Model(&entity.Category{}).Count(&count).Error)
+	assert.EqualValues(t, 5, count)
+}
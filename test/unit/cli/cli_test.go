@@ -0,0 +1,175 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"voyago/core-api/internal/cli"
+	"voyago/core-api/internal/infrastructure/startup"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func run(args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = cli.Run(args, &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRun_Help_ListsTheCommands(t *testing.T) {
+	code, stdout, _ := run("--help")
+
+	assert.Equal(t, startup.ExitOK, code)
+	for _, name := range []string{"serve", "worker", "migrate", "seed", "routes", "doctor"} {
+		assert.Contains(t, stdout, name)
+	}
+	assert.Contains(t, stdout, "--config")
+}
+
+func TestRun_UsageErrors(t *testing.T) {
+	cases := map[string]struct {
+		args []string
+		err  string
+	}{
+		"unknown command":        {[]string{"deploy"}, `unknown command "deploy"`},
+		"unknown flag":           {[]string{"serve", "--listen", ":80"}, "unknown flag: --listen"},
+		"setting without value":  {[]string{"routes", "--set", "log.level"}, `invalid --set "log.level", expected key=value`},
+		"unknown setting":        {[]string{"routes", "--set", "http.prot=80"}, `unknown setting "http.prot"`},
+		"unknown transport":      {[]string{"serve", "--transport", "soap"}, `unknown transport "soap"`},
+		"unknown domain":         {[]string{"migrate", "--domain", "billing", "up"}, `unknown domain "billing"`},
+		"down of every domain":   {[]string{"migrate", "down"}, "migrate down needs --domain"},
+		"force of every domain":  {[]string{"migrate", "force", "1"}, "migrate force needs --domain"},
+		"invalid rollback":       {[]string{"migrate", "--domain", "booking", "down", "0"}, `invalid number of migrations "0"`},
+		"invalid version":        {[]string{"migrate", "-d", "booking", "force", "latest"}, `invalid version "latest"`},
+		"missing version":        {[]string{"migrate", "-d", "booking", "force"}, "accepts 1 arg(s), received 0"},
+		"unknown domain to seed": {[]string{"seed", "--domain", "billing"}, `unknown domain "billing"`},
+		"use case without name":  {[]string{"gen", "usecase", "booking"}, "accepts 2 arg(s), received 1"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			code, _, stderr := run(tc.args...)
+
+			assert.Equal(t, cli.ExitUsage, code)
+			assert.Contains(t, stderr, tc.err)
+		})
+	}
+}
+
+// chdirToConfig runs the test in a directory holding the configuration of
+// the repository, the domains using their example configuration.
+func chdirToConfig(t *testing.T) {
+	t.Helper()
+	src := filepath.Join("..", "..", "..", "config")
+	dir := t.TempDir()
+	copyFile(t, filepath.Join(src, "config.yaml"), filepath.Join(dir, "config", "config.yaml"))
+	for _, domain := range []string{"booking", "category", "webhook"} {
+		copyFile(t,
+			filepath.Join(src, domain, "config.example.yaml"),
+			filepath.Join(dir, "config", domain, "config.yaml"))
+	}
+	t.Chdir(dir)
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+	content, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(dst), 0o755))
+	require.NoError(t, os.WriteFile(dst, content, 0o644))
+}
+
+func TestRun_Routes_PrintsTheRoutesOfTheModules(t *testing.T) {
+	chdirToConfig(t)
+
+	code, stdout, stderr := run("routes")
+
+	require.Equal(t, startup.ExitOK, code, stderr)
+	assert.Regexp(t, `(?m)^POST\s+/api/v1/bookings/:id/checkout$`, stdout)
+	assert.Regexp(t, `(?m)^GET\s+/api/v1/webhooks/:id/deliveries$`, stdout)
+	assert.Regexp(t, `(?m)^GET\s+/health$`, stdout)
+	assert.NotRegexp(t, `(?m)^HEAD\s`, stdout)
+}
+
+func TestRun_Routes_ReportsAMissingConfiguration(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	code, _, stderr := run("routes", "--config", "missing.yaml")
+
+	assert.Equal(t, startup.ExitConfig, code)
+	assert.Contains(t, stderr, "missing.yaml")
+}
+
+func TestRun_GenModule_DryRunWritesNothing(t *testing.T) {
+	t.Chdir("../../..")
+
+	code, stdout, _ := run("gen", "module", "loyalty", "--entity", "Reward", "--dry-run")
+
+	assert.Equal(t, startup.ExitOK, code)
+	assert.Contains(t, stdout, "would create internal/modules/loyalty/module.go")
+	assert.Contains(t, stdout, "would create test/mocks/loyalty_repository.go")
+	assert.Contains(t, stdout, "Next steps:")
+	assert.NoDirExists(t, "internal/modules/loyalty")
+}
+
+func TestRun_GenMocks_MocksAreUpToDate(t *testing.T) {
+	t.Chdir("../../..")
+
+	code, stdout, _ := run("gen", "mocks", "--dry-run")
+
+	assert.Equal(t, startup.ExitOK, code)
+	assert.Empty(t, stdout)
+}
+
+func TestRun_GenModule_NeedsTheRepositoryRoot(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	code, _, stderr := run("gen", "module", "loyalty")
+
+	assert.Equal(t, cli.ExitUsage, code)
+	assert.Contains(t, stderr, "run gen from the repository root")
+}
+
+func TestRun_Routes_FlagsReplaceTheConfiguration(t *testing.T) {
+	chdirToConfig(t)
+
+	code, _, stderr := run("routes", "--set", "http.port=0", "--set", "log.levels.booking=verbose")
+
+	assert.Equal(t, startup.ExitConfig, code)
+	assert.Contains(t, stderr, "http.port: must be a port between 1 and 65535, got 0")
+	assert.Contains(t, stderr, "log.levels.booking")
+}
+
+func TestRun_GenErrors_ExportsTheCatalog(t *testing.T) {
+	code, stdout, stderr := run("gen", "errors", "--doc-base", "https://docs.voyago.dev/errors/")
+	require.Equal(t, startup.ExitOK, code, stderr)
+
+	var catalog struct {
+		Domain string `json:"domain"`
+		Errors []struct {
+			Code       string `json:"code"`
+			Module     string `json:"module"`
+			HttpStatus int    `json:"http_status"`
+			DocURL     string `json:"doc_url"`
+		} `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &catalog))
+	assert.Equal(t, "voyago", catalog.Domain)
+
+	modules := map[string]bool{}
+	for _, e := range catalog.Errors {
+		modules[e.Module] = true
+		if e.Code == "BOOKING_NOT_FOUND" {
+			assert.Equal(t, 404, e.HttpStatus)
+			assert.Equal(t, "https://docs.voyago.dev/errors/BOOKING_NOT_FOUND", e.DocURL)
+		}
+	}
+	assert.Equal(t, map[string]bool{"core": true, "booking": true, "webhook": true}, modules)
+
+	code, stdout, _ = run("gen", "errors", "--format", "markdown")
+	require.Equal(t, startup.ExitOK, code)
+	assert.Contains(t, stdout, "| booking | `BOOKING_NOT_FOUND` | 404 | NotFound | false | booking record not found |")
+}
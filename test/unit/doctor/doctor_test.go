@@ -0,0 +1,193 @@
+package doctor_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/doctor"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+func envOf(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	}
+}
+
+func run(c doctor.Check) doctor.Result {
+	return c.Run(context.Background())
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// ============================================================================
+// TEST CASES
+// ============================================================================
+
+func TestConfigFile_MissingSuggestsExample(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "config/booking/config.example.yaml"), "log: {}")
+
+	res := run(doctor.ConfigFile(root, "config/booking/config.yaml", "config/booking/config.example.yaml"))
+
+	assert.Equal(t, doctor.StatusFail, res.Status)
+	assert.Equal(t, "cp config/booking/config.example.yaml config/booking/config.yaml", res.Fix)
+}
+
+func TestConfigFile_Found(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "config/config.yaml"), "app: {}")
+
+	res := run(doctor.ConfigFile(root, "config/config.yaml", ""))
+
+	assert.Equal(t, doctor.StatusOK, res.Status)
+}
+
+func TestConfigValid_ListsTheProblems(t *testing.T) {
+	err := &config.ValidationError{Source: "config/config.yaml", Problems: []config.Problem{
+		{Path: "http.port", Message: "is required"},
+		{Path: "log.driver", Message: `must be one of "stdout", got "file"`},
+	}}
+
+	res := run(doctor.ConfigValid("config/config.yaml", err))
+
+	assert.Equal(t, doctor.StatusFail, res.Status)
+	assert.Equal(t, `http.port is required; log.driver must be one of "stdout", got "file"`, res.Detail)
+	assert.Equal(t, "fix these settings in config/config.yaml", res.Fix)
+	assert.Equal(t, doctor.StatusOK, run(doctor.ConfigValid("config/config.yaml", nil)).Status)
+}
+
+func TestEnvPlaceholders(t *testing.T) {
+	vars := doctor.EnvPlaceholders(`
+host: ${DB_HOST:localhost}
+password: ${DB_PASSWORD}
+token: ${API_TOKEN:}
+again: ${DB_HOST}
+`)
+
+	assert.Equal(t, map[string]bool{
+		"DB_HOST":     false, // also referenced without default
+		"DB_PASSWORD": false,
+		"API_TOKEN":   true,
+	}, vars)
+}
+
+func TestConfigEnv_ReportsOnlyMissingRequiredVariables(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "config.yaml"), "a: ${SET_VAR}\nb: ${UNSET_VAR}\nc: ${DEFAULTED:x}")
+
+	res := run(doctor.ConfigEnv(root, []string{"config.yaml", "missing.yaml"}, envOf(map[string]string{"SET_VAR": "1"})))
+
+	assert.Equal(t, doctor.StatusFail, res.Status)
+	assert.Equal(t, "missing: UNSET_VAR", res.Detail)
+	assert.Contains(t, res.Fix, "export UNSET_VAR=")
+}
+
+func TestRequiredEnv_MissingIsWarning(t *testing.T) {
+	res := run(doctor.RequiredEnv("TEST_DB_PASSWORD", "tests cannot connect", envOf(nil)))
+	assert.Equal(t, doctor.StatusWarn, res.Status)
+
+	res = run(doctor.RequiredEnv("TEST_DB_PASSWORD", "tests cannot connect", envOf(map[string]string{"TEST_DB_PASSWORD": "secret"})))
+	assert.Equal(t, doctor.StatusOK, res.Status)
+}
+
+func TestTestDatabaseConfig_DefaultsAndOverrides(t *testing.T) {
+	cfg := doctor.TestDatabaseConfig(envOf(map[string]string{"TEST_DB_PORT": "6543", "TEST_DB_NAME": "other"}))
+
+	assert.Equal(t, "localhost", cfg.Host)
+	assert.Equal(t, 6543, cfg.Port)
+	assert.Equal(t, "other", cfg.Name)
+	assert.Equal(t, "booking", cfg.Schema)
+}
+
+func TestLatestMigration(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "20260101000000_init.up.sql"), "")
+	writeFile(t, filepath.Join(dir, "20260301000000_add.up.sql"), "")
+	writeFile(t, filepath.Join(dir, "20260401000000_add.down.sql"), "")
+	writeFile(t, filepath.Join(dir, "README.md"), "")
+
+	v, err := doctor.LatestMigration(dir)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20260301000000), v)
+}
+
+func TestMigrations_NoFilesIsSkipped(t *testing.T) {
+	res := run(doctor.Migrations("booking", config.DatabaseConfig{}, t.TempDir()))
+	assert.Equal(t, doctor.StatusSkip, res.Status)
+}
+
+func TestPortFree_PortInUse(t *testing.T) {
+	l, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer l.Close()
+	port := l.Addr().(*net.TCPAddr).Port
+
+	res := run(doctor.PortFree("http", port, "change http.port"))
+
+	assert.Equal(t, doctor.StatusFail, res.Status)
+	assert.Equal(t, "change http.port", res.Fix)
+}
+
+func TestTelemetry_DisabledIsSkipped(t *testing.T) {
+	checks := doctor.Telemetry(config.TelemetryConfig{Enabled: false})
+
+	require.Len(t, checks, 1)
+	assert.Equal(t, doctor.StatusSkip, run(checks[0]).Status)
+}
+
+func TestTelemetry_UnreachableIsWarning(t *testing.T) {
+	// Reserve then release a port so that nothing listens on it.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	checks := doctor.Telemetry(config.TelemetryConfig{Enabled: true, Type: "otel", TracerAddress: addr, MetricsAddress: addr})
+
+	require.Len(t, checks, 1, "a shared collector address is probed once")
+	assert.Equal(t, doctor.StatusWarn, run(checks[0]).Status)
+}
+
+func TestRunAndPrint(t *testing.T) {
+	checks := []doctor.Check{
+		{Name: "ok", Run: func(context.Context) doctor.Result { return doctor.Result{Status: doctor.StatusOK} }},
+		{Name: "warn", Run: func(context.Context) doctor.Result {
+			return doctor.Result{Status: doctor.StatusWarn, Detail: "optional", Fix: "do something"}
+		}},
+		{Name: "timeout", Run: func(ctx context.Context) doctor.Result {
+			<-ctx.Done()
+			return doctor.Result{Status: doctor.StatusFail, Detail: ctx.Err().Error()}
+		}},
+	}
+
+	report := doctor.Run(context.Background(), 10*time.Millisecond, checks)
+
+	assert.True(t, report.Failed())
+	require.Len(t, report.Results, 3)
+	assert.Equal(t, "context deadline exceeded", report.Results[2].Detail)
+
+	var out bytes.Buffer
+	doctor.Print(&out, report)
+	assert.Contains(t, out.String(), "[ OK ] ok")
+	assert.Contains(t, out.String(), "[WARN] warn - optional\n       fix: do something")
+	assert.Contains(t, out.String(), "1 ok, 1 warning(s), 1 failed, 0 skipped")
+}
@@ -0,0 +1,453 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/admin"
+	"voyago/core-api/internal/infrastructure/config"
+	server "voyago/core-api/internal/infrastructure/http"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/maintenance"
+	"voyago/core-api/internal/pkg/audit"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+const adminToken = "s3cret"
+
+func newConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.App = config.AppConfig{Name: "voyago", Version: "1.2.3", Env: "test"}
+	cfg.Admin.Token = adminToken
+	cfg.Redis = config.RedisConfig{Host: "redis.internal", Password: "redis-pass"}
+	cfg.Websocket.Secret = "ws-secret"
+	return cfg
+}
+
+func newAdminApp(cfg admin.HttpModuleConfig) *fiber.App {
+	srv := server.NewServer(&config.Config{}, logger.NewNoOpLogger(), nil)
+	cfg.App = srv.App
+	if cfg.Config == nil {
+		cfg.Config = newConfig()
+	}
+	if cfg.Log == nil {
+		cfg.Log = logger.NewNoOpLogger()
+	}
+	admin.RegisterHttpModule(cfg)
+	return srv.App
+}
+
+func call(t *testing.T, app *fiber.App, method, path, token, body string) (int, map[string]any) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var envelope map[string]any
+	require.NoError(t, json.Unmarshal(raw, &envelope), string(raw))
+	return resp.StatusCode, envelope
+}
+
+func newMode() *maintenance.Mode {
+	return maintenance.NewMode(config.MaintenanceConfig{}, maintenance.NewMemoryStore(), logger.NewNoOpLogger())
+}
+
+// ============================================================================
+// AUTHENTICATION
+// ============================================================================
+
+func TestAdmin_RequiresToken(t *testing.T) {
+	app := newAdminApp(admin.HttpModuleConfig{Maintenance: newMode()})
+
+	for name, token := range map[string]string{"missing": "", "wrong": "guess"} {
+		t.Run(name, func(t *testing.T) {
+			for _, path := range []string{"/admin/build", "/admin/config", "/admin/maintenance"} {
+				status, body := call(t, app, fiber.MethodGet, path, token, "")
+				assert.Equal(t, fiber.StatusUnauthorized, status, path)
+				assert.Equal(t, "UNAUTHORIZED", body["error_code"])
+			}
+		})
+	}
+}
+
+func TestAdmin_NotMountedWithoutToken(t *testing.T) {
+	cfg := newConfig()
+	cfg.Admin.Token = ""
+	app := newAdminApp(admin.HttpModuleConfig{Config: cfg})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/admin/build", nil), -1)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+// ============================================================================
+// LOG LEVEL
+// ============================================================================
+
+func TestAdmin_SetLogLevel(t *testing.T) {
+	main := logger.NewStdoutLogger(&config.Config{Log: config.LogConfig{Level: 4}}, nil)
+	booking := logger.NewStdoutLogger(&config.Config{Log: config.LogConfig{Level: 3}}, nil)
+	derived := booking.WithField("component", "handler")
+	app := newAdminApp(admin.HttpModuleConfig{Loggers: map[string]logger.Logger{
+		admin.MainLogger: main,
+		"booking":        booking,
+		"silent":         logger.NewNoOpLogger(),
+	}})
+
+	status, body := call(t, app, fiber.MethodGet, "/admin/log-level", adminToken, "")
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, []any{
+		map[string]any{"logger": "booking", "level": "warn"},
+		map[string]any{"logger": "main", "level": "info"},
+	}, body["data"], "loggers without a runtime level are left out")
+
+	status, _ = call(t, app, fiber.MethodPut, "/admin/log-level", adminToken, `{"level":"debug","logger":"booking"}`)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "debug", booking.(logger.Leveler).Level())
+	assert.Equal(t, "debug", derived.(logger.Leveler).Level(), "derived loggers follow")
+	assert.Equal(t, "info", main.(logger.Leveler).Level())
+
+	status, _ = call(t, app, fiber.MethodPut, "/admin/log-level", adminToken, `{"level":"error"}`)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "error", booking.(logger.Leveler).Level())
+	assert.Equal(t, "error", main.(logger.Leveler).Level())
+}
+
+func TestAdmin_SetLogLevel_Invalid(t *testing.T) {
+	main := logger.NewStdoutLogger(&config.Config{Log: config.LogConfig{Level: 4}}, nil)
+	app := newAdminApp(admin.HttpModuleConfig{Loggers: map[string]logger.Logger{admin.MainLogger: main}})
+
+	tests := map[string]string{
+		"unknown level":  `{"level":"verbose"}`,
+		"unknown logger": `{"level":"debug","logger":"merchant"}`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			status, resp := call(t, app, fiber.MethodPut, "/admin/log-level", adminToken, body)
+			assert.Equal(t, fiber.StatusBadRequest, status)
+			assert.Equal(t, "VALIDATION_ERROR", resp["error_code"])
+			assert.Equal(t, "info", main.(logger.Leveler).Level())
+		})
+	}
+}
+
+// ============================================================================
+// MAINTENANCE
+// ============================================================================
+
+func TestAdmin_TogglesMaintenance(t *testing.T) {
+	mode := newMode()
+	app := newAdminApp(admin.HttpModuleConfig{Maintenance: mode})
+
+	status, body := call(t, app, fiber.MethodPut, "/admin/maintenance", adminToken, `{"enabled":true,"message":"Back soon"}`)
+	require.Equal(t, fiber.StatusOK, status, body)
+	data := body["data"].(map[string]any)
+	assert.Equal(t, true, data["enabled"])
+	assert.Equal(t, "Back soon", data["message"])
+	assert.True(t, mode.State(context.Background()).Enabled)
+
+	status, body = call(t, app, fiber.MethodGet, "/admin/maintenance", adminToken, "")
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, true, body["data"].(map[string]any)["enabled"])
+
+	status, _ = call(t, app, fiber.MethodPut, "/admin/maintenance", adminToken, `{"enabled":false}`)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.False(t, mode.State(context.Background()).Enabled)
+}
+
+func TestAdmin_Maintenance_RequiresEnabled(t *testing.T) {
+	app := newAdminApp(admin.HttpModuleConfig{Maintenance: newMode()})
+
+	status, body := call(t, app, fiber.MethodPut, "/admin/maintenance", adminToken, `{"message":"Back soon"}`)
+
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Equal(t, "VALIDATION_ERROR", body["error_code"])
+}
+
+// ============================================================================
+// CACHE
+// ============================================================================
+
+func TestAdmin_FlushCache(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	for _, key := range []string{"voyago:cache:a", "voyago:cache:b", "voyago:cache*x", "voyago:maintenance", "voyago:ratelimit:ip"} {
+		require.NoError(t, mr.Set(key, "1"))
+	}
+	app := newAdminApp(admin.HttpModuleConfig{
+		Cache: admin.NewRedisCache(client, []string{"voyago:cache:", "", "voyago:cache*"}),
+	})
+
+	status, body := call(t, app, fiber.MethodPost, "/admin/cache/flush", adminToken, "")
+
+	require.Equal(t, fiber.StatusOK, status, body)
+	assert.Equal(t, float64(3), body["data"].(map[string]any)["deleted"])
+	assert.ElementsMatch(t, []string{"voyago:maintenance", "voyago:ratelimit:ip"}, mr.Keys(), "the prefix is matched literally")
+}
+
+func TestAdmin_FlushCache_NotMountedWithoutCache(t *testing.T) {
+	app := newAdminApp(admin.HttpModuleConfig{})
+
+	status, _ := call(t, app, fiber.MethodPost, "/admin/cache/flush", adminToken, "")
+
+	assert.Equal(t, fiber.StatusNotFound, status)
+}
+
+// ============================================================================
+// AUDIT LOG
+// ============================================================================
+
+// auditLog is an audit.Reader returning its entries matching the filter
+// action.
+type auditLog []audit.Entry
+
+func (l auditLog) Find(_ context.Context, f audit.Filter) ([]audit.Entry, error) {
+	var out []audit.Entry
+	for _, e := range l {
+		if f.Action == "" || e.Action == f.Action {
+			out = append(out, e)
+		}
+	}
+	return out[:min(len(out), f.Limit)], nil
+}
+
+func auditLogs() map[string]audit.Reader {
+	return map[string]audit.Reader{
+		"booking": auditLog{
+			{ID: "b2", Action: "booking.payment_status.update", CreatedAt: 300},
+			{ID: "b1", Action: "booking.create", CreatedAt: 100},
+		},
+		"webhook": auditLog{
+			{ID: "w1", Action: "webhook.endpoint.create", CreatedAt: 200},
+		},
+	}
+}
+
+func TestAdmin_GetAudit_MergesDomainsNewestFirst(t *testing.T) {
+	app := newAdminApp(admin.HttpModuleConfig{Audit: auditLogs()})
+
+	status, body := call(t, app, fiber.MethodGet, "/admin/audit?limit=2", adminToken, "")
+
+	require.Equal(t, fiber.StatusOK, status)
+	entries := body["data"].([]any)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "b2", entries[0].(map[string]any)["id"])
+	assert.Equal(t, "booking", entries[0].(map[string]any)["domain"])
+	assert.Equal(t, "w1", entries[1].(map[string]any)["id"])
+	assert.Equal(t, "webhook", entries[1].(map[string]any)["domain"])
+}
+
+func TestAdmin_GetAudit_FiltersByDomain(t *testing.T) {
+	app := newAdminApp(admin.HttpModuleConfig{Audit: auditLogs()})
+
+	status, body := call(t, app, fiber.MethodGet, "/admin/audit?domain=booking&action=booking.create", adminToken, "")
+
+	require.Equal(t, fiber.StatusOK, status)
+	entries := body["data"].([]any)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "b1", entries[0].(map[string]any)["id"])
+}
+
+func TestAdmin_GetAudit_InvalidQuery(t *testing.T) {
+	app := newAdminApp(admin.HttpModuleConfig{Audit: auditLogs()})
+
+	for _, query := range []string{"domain=merchant", "limit=501", "limit=-1"} {
+		status, body := call(t, app, fiber.MethodGet, "/admin/audit?"+query, adminToken, "")
+		assert.Equal(t, fiber.StatusBadRequest, status, query)
+		assert.Equal(t, "VALIDATION_ERROR", body["error_code"], query)
+	}
+}
+
+func TestAdmin_GetAudit_NotMountedWithoutAuditLogs(t *testing.T) {
+	app := newAdminApp(admin.HttpModuleConfig{})
+
+	status, _ := call(t, app, fiber.MethodGet, "/admin/audit", adminToken, "")
+
+	assert.Equal(t, fiber.StatusNotFound, status)
+}
+
+// ============================================================================
+// CONFIG & BUILD
+// ============================================================================
+
+func TestAdmin_GetConfig_Masked(t *testing.T) {
+	domain := &config.Config{}
+	domain.Database = config.DatabaseConfig{Host: "db.internal", User: "voyago", Password: "db-pass"}
+	app := newAdminApp(admin.HttpModuleConfig{Configs: map[string]*config.Config{"booking": domain}})
+
+	status, body := call(t, app, fiber.MethodGet, "/admin/config", adminToken, "")
+
+	require.Equal(t, fiber.StatusOK, status)
+	data := body["data"].(map[string]any)
+	global := data["global"].(map[string]any)
+	assert.Equal(t, "******** [REDACTED]", global["admin"].(map[string]any)["token"])
+	assert.Equal(t, "******** [REDACTED]", global["redis"].(map[string]any)["password"])
+	assert.Equal(t, "redis.internal", global["redis"].(map[string]any)["host"])
+	assert.Equal(t, "******** [REDACTED]", global["websocket"].(map[string]any)["secret"])
+	assert.Equal(t, "voyago", global["app"].(map[string]any)["name"])
+
+	database := data["domains"].(map[string]any)["booking"].(map[string]any)["database"].(map[string]any)
+	assert.Equal(t, "******** [REDACTED]", database["password"])
+	assert.Equal(t, "voyago", database["user"])
+	assert.Contains(t, database, "pool", "nested sections are dumped")
+
+	raw, err := json.Marshal(body)
+	require.NoError(t, err)
+	for _, secret := range []string{adminToken, "redis-pass", "ws-secret", "db-pass"} {
+		assert.NotContains(t, string(raw), secret)
+	}
+}
+
+func TestAdmin_GetConfig_ReportsWhatWasMerged(t *testing.T) {
+	dir := t.TempDir()
+	global := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(global, []byte(`app: { name: voyago }
+http: { port: 4000 }
+admin: { token: "`+adminToken+`" }
+`), 0o600))
+	domainPath := filepath.Join(dir, "booking.yaml")
+	require.NoError(t, os.WriteFile(domainPath, []byte(`database: { host: localhost, port: 5432, name: booking }
+booking: { checkout: { timeout: 900 }, gateway: { api_secret: "gw-secret" } }
+`), 0o600))
+	require.NoError(t, config.SetOverrides(map[string]string{"http.port": "5000"}))
+	t.Cleanup(func() { _ = config.SetOverrides(nil) })
+	cfg, err := config.LoadGlobalConfig(global)
+	require.NoError(t, err)
+	domain, err := config.ReadDomainConfig(domainPath)
+	require.NoError(t, err)
+	app := newAdminApp(admin.HttpModuleConfig{Config: cfg, Configs: map[string]*config.Config{"booking": domain}})
+
+	status, body := call(t, app, fiber.MethodGet, "/admin/config", adminToken, "")
+
+	require.Equal(t, fiber.StatusOK, status)
+	data := body["data"].(map[string]any)
+	assert.Equal(t, map[string]any{admin.MainLogger: global, "booking": domainPath}, data["sources"])
+	assert.Equal(t, []any{"http.port"}, data["overrides"])
+	assert.EqualValues(t, 5000, data["global"].(map[string]any)["http"].(map[string]any)["port"])
+
+	section := data["domains"].(map[string]any)["booking"].(map[string]any)["booking"].(map[string]any)
+	assert.EqualValues(t, 900, section["checkout"].(map[string]any)["timeout"], "the module sections are dumped")
+	assert.Equal(t, "******** [REDACTED]", section["gateway"].(map[string]any)["api_secret"])
+}
+
+func TestAdmin_GetBuild(t *testing.T) {
+	app := newAdminApp(admin.HttpModuleConfig{})
+
+	status, body := call(t, app, fiber.MethodGet, "/admin/build", adminToken, "")
+
+	require.Equal(t, fiber.StatusOK, status)
+	data := body["data"].(map[string]any)
+	assert.Equal(t, "voyago", data["name"])
+	assert.Equal(t, "1.2.3", data["version"])
+	assert.Equal(t, "test", data["env"])
+	assert.True(t, strings.HasPrefix(data["go_version"].(string), "go"))
+	assert.NotEmpty(t, data["started_at"])
+}
+
+func TestAdmin_GetRuntime(t *testing.T) {
+	app := newAdminApp(admin.HttpModuleConfig{})
+
+	status, body := call(t, app, fiber.MethodGet, "/admin/runtime", adminToken, "")
+
+	require.Equal(t, fiber.StatusOK, status)
+	data := body["data"].(map[string]any)
+	assert.Greater(t, data["goroutines"], float64(0))
+	assert.Greater(t, data["heap_alloc"], float64(0))
+}
+
+// ============================================================================
+// PPROF
+// ============================================================================
+
+func TestAdmin_Pprof(t *testing.T) {
+	cfg := newConfig()
+	cfg.Admin.Pprof.Enabled = true
+	app := newAdminApp(admin.HttpModuleConfig{Config: cfg})
+
+	profile := func(token string) *http.Response {
+		req := httptest.NewRequest(fiber.MethodGet, "/admin/debug/pprof/goroutine?debug=1", nil)
+		if token != "" {
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+		}
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := profile(adminToken)
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(raw), "goroutine profile")
+
+	resp = profile("")
+	resp.Body.Close()
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAdmin_Pprof_DisabledOrOnItsOwnListener(t *testing.T) {
+	for name, pprofCfg := range map[string]config.AdminPprofConfig{
+		"disabled":     {},
+		"own listener": {Enabled: true, Address: "127.0.0.1:6060"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := newConfig()
+			cfg.Admin.Pprof = pprofCfg
+			app := newAdminApp(admin.HttpModuleConfig{Config: cfg})
+
+			status, _ := call(t, app, fiber.MethodGet, "/admin/debug/pprof/heap", adminToken, "")
+
+			assert.Equal(t, fiber.StatusNotFound, status)
+		})
+	}
+}
+
+func TestPprofServer(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	srv := admin.NewPprofServer(address, logger.NewNoOpLogger())
+	done := make(chan error, 1)
+	go func() { done <- srv.Start() }()
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = http.Get("http://" + address + admin.PprofPath + "/heap?debug=1")
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.NoError(t, srv.Stop(context.Background()))
+	assert.NoError(t, <-done, "a stopped server is not a failure")
+}
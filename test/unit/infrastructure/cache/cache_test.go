@@ -0,0 +1,261 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/cache"
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingCache is a cache whose backend is down.
+type failingCache struct{}
+
+func (failingCache) Get(context.Context, string) ([]byte, error) { return nil, errors.New("down") }
+func (failingCache) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("down")
+}
+func (failingCache) Delete(context.Context, ...string) error { return errors.New("down") }
+
+func caches(t *testing.T) map[string]cache.Cache {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return map[string]cache.Cache{
+		"memory": cache.NewMemory(0),
+		"redis":  cache.NewRedisCache(client, "test:cache:"),
+	}
+}
+
+func TestCache_GetSetDelete(t *testing.T) {
+	ctx := context.Background()
+	for name, c := range caches(t) {
+		_, err := c.Get(ctx, "k")
+		assert.ErrorIs(t, err, cache.ErrMiss, name)
+
+		require.NoError(t, c.Set(ctx, "k", []byte("v1"), time.Minute), name)
+		require.NoError(t, c.Set(ctx, "other", []byte("v2"), 0), name)
+		v, err := c.Get(ctx, "k")
+		require.NoError(t, err, name)
+		assert.Equal(t, []byte("v1"), v, name)
+
+		require.NoError(t, c.Delete(ctx, "k", "missing"), name)
+		_, err = c.Get(ctx, "k")
+		assert.ErrorIs(t, err, cache.ErrMiss, name)
+		v, err = c.Get(ctx, "other")
+		require.NoError(t, err, name)
+		assert.Equal(t, []byte("v2"), v, name)
+	}
+}
+
+func TestRedisCache_PrefixesKeysAndSetsTTL(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	c := cache.NewRedisCache(client, "voyago:cache:")
+
+	require.NoError(t, c.Set(context.Background(), "tree", []byte("{}"), time.Minute))
+
+	mr.CheckGet(t, "voyago:cache:tree", "{}")
+	assert.Equal(t, time.Minute, mr.TTL("voyago:cache:tree"))
+}
+
+func TestMemory_ExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemory(10)
+
+	require.NoError(t, c.Set(ctx, "short", []byte("v"), 20*time.Millisecond))
+	require.NoError(t, c.Set(ctx, "forever", []byte("v"), 0))
+	time.Sleep(30 * time.Millisecond)
+
+	_, err := c.Get(ctx, "short")
+	assert.ErrorIs(t, err, cache.ErrMiss)
+	_, err = c.Get(ctx, "forever")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, c.Len(), "the expired entry was dropped when read")
+}
+
+func TestMemory_EvictsTheLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemory(2)
+
+	require.NoError(t, c.Set(ctx, "a", []byte("a"), 0))
+	require.NoError(t, c.Set(ctx, "b", []byte("b"), 0))
+	_, _ = c.Get(ctx, "a")
+	require.NoError(t, c.Set(ctx, "c", []byte("c"), 0))
+
+	_, err := c.Get(ctx, "b")
+	assert.ErrorIs(t, err, cache.ErrMiss, "least recently used")
+	_, err = c.Get(ctx, "a")
+	assert.NoError(t, err)
+	_, err = c.Get(ctx, "c")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestMemory_CopiesValues(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemory(0)
+	value := []byte("abc")
+
+	require.NoError(t, c.Set(ctx, "k", value, 0))
+	value[0] = 'x'
+	got, _ := c.Get(ctx, "k")
+	got[1] = 'x'
+
+	again, _ := c.Get(ctx, "k")
+	assert.Equal(t, []byte("abc"), again)
+}
+
+func TestMemory_Flush(t *testing.T) {
+	ctx := context.Background()
+	c := cache.NewMemory(0)
+	require.NoError(t, c.Set(ctx, "a", []byte("a"), 0))
+	require.NoError(t, c.Set(ctx, "b", []byte("b"), 0))
+
+	n, err := c.Flush(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestNew_SelectsTheDriver(t *testing.T) {
+	c, err := cache.New(&config.CacheConfig{}, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &cache.Memory{}, c)
+
+	_, err = cache.New(&config.CacheConfig{Driver: config.CacheDriverRedis}, nil)
+	assert.Error(t, err, "no Redis client")
+
+	r, err := cache.NewRedis(&config.RedisConfig{Host: "localhost", Port: 6379}, nil)
+	require.NoError(t, err)
+	defer r.Close()
+	c, err = cache.New(&config.CacheConfig{Driver: config.CacheDriverRedis, Prefix: "p:"}, r)
+	require.NoError(t, err)
+	assert.NotNil(t, c)
+
+	_, err = cache.New(&config.CacheConfig{Driver: "memcached"}, nil)
+	assert.ErrorContains(t, err, `unknown driver "memcached"`)
+}
+
+func TestLoader_DeduplicatesConcurrentLoads(t *testing.T) {
+	l := cache.NewLoader(cache.NewMemory(0))
+	release := make(chan struct{})
+	var loads int32
+
+	var wg sync.WaitGroup
+	results := make([][]byte, 10)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := l.Get(context.Background(), "tree", time.Minute, func(context.Context) ([]byte, error) {
+				atomic.AddInt32(&loads, 1)
+				<-release
+				return []byte("tree"), nil
+			})
+			assert.NoError(t, err)
+			results[i] = v
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads)
+	for _, v := range results {
+		assert.Equal(t, []byte("tree"), v)
+	}
+
+	_, err := l.Get(context.Background(), "tree", time.Minute, func(context.Context) ([]byte, error) {
+		t.Fatal("cached")
+		return nil, nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestLoader_DoesNotCacheErrors(t *testing.T) {
+	l := cache.NewLoader(cache.NewMemory(0))
+	boom := errors.New("boom")
+
+	_, err := l.Get(context.Background(), "k", time.Minute, func(context.Context) ([]byte, error) { return nil, boom })
+	assert.ErrorIs(t, err, boom)
+
+	v, err := l.Get(context.Background(), "k", time.Minute, func(context.Context) ([]byte, error) { return []byte("v"), nil })
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v"), v)
+}
+
+func TestLoader_CacheDownDegradesToLoading(t *testing.T) {
+	l := cache.NewLoader(failingCache{})
+
+	v, err := l.Get(context.Background(), "k", time.Minute, func(context.Context) ([]byte, error) { return []byte("v"), nil })
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("v"), v)
+}
+
+func TestLoader_CallerStopsWaitingWhenContextDone(t *testing.T) {
+	l := cache.NewLoader(cache.NewMemory(0))
+	release := make(chan struct{})
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := l.Get(ctx, "slow", time.Minute, func(ctx context.Context) ([]byte, error) {
+		<-release
+		return nil, ctx.Err()
+	})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+type categoryTree struct {
+	Name     string         `json:"name"`
+	Children []categoryTree `json:"children,omitempty"`
+}
+
+func TestFetch_CachesTypedValues(t *testing.T) {
+	c := cache.NewMemory(0)
+	l := cache.NewLoader(c)
+	loads := 0
+	load := func(context.Context) (categoryTree, error) {
+		loads++
+		return categoryTree{Name: "root", Children: []categoryTree{{Name: "tours"}}}, nil
+	}
+
+	for range 3 {
+		tree, err := cache.Fetch(context.Background(), l, "categories", time.Minute, load)
+		require.NoError(t, err)
+		assert.Equal(t, "tours", tree.Children[0].Name)
+	}
+	assert.Equal(t, 1, loads)
+
+	raw, err := c.Get(context.Background(), "categories")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"root","children":[{"name":"tours"}]}`, string(raw))
+}
+
+func TestFetch_ReloadsUndecodableEntries(t *testing.T) {
+	c := cache.NewMemory(0)
+	require.NoError(t, c.Set(context.Background(), "n", []byte(`"not a number"`), 0))
+	l := cache.NewLoader(c)
+
+	n, err := cache.Fetch(context.Background(), l, "n", time.Minute, func(context.Context) (int, error) { return 42, nil })
+
+	require.NoError(t, err)
+	assert.Equal(t, 42, n)
+	raw, _ := c.Get(context.Background(), "n")
+	assert.Equal(t, fmt.Sprint(42), string(raw))
+}
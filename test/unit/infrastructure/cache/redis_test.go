@@ -0,0 +1,192 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/cache"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+type recordingSpan struct {
+	name     string
+	tags     map[string]any
+	err      error
+	finished bool
+}
+
+func (s *recordingSpan) SetOperationName(name string)    { s.name = name }
+func (s *recordingSpan) Finish()                         { s.finished = true }
+func (s *recordingSpan) SetTag(key string, value any)    { s.tags[key] = value }
+func (s *recordingSpan) AddEvent(string, map[string]any) {}
+func (s *recordingSpan) RecordError(err error)           { s.err = err }
+
+type recordingTracer struct {
+	tracer.Tracer
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) StartSpan(ctx context.Context, name string) (tracer.Span, context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span := &recordingSpan{name: name, tags: map[string]any{}}
+	t.spans = append(t.spans, span)
+	return span, ctx
+}
+
+type recordingMetrics struct {
+	metrics.Metrics
+	mu     sync.Mutex
+	gauges map[string]float64
+	tags   []string
+}
+
+func (m *recordingMetrics) Gauge(name string, value float64, tags []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gauges[name] = value
+	m.tags = tags
+}
+
+func standaloneConfig(mr *miniredis.Miniredis) *config.RedisConfig {
+	port, _ := strconv.Atoi(mr.Port())
+	return &config.RedisConfig{Host: mr.Host(), Port: port}
+}
+
+// ============================================================================
+// TESTS
+// ============================================================================
+
+func TestNewRedis_Standalone(t *testing.T) {
+	mr := miniredis.RunT(t)
+	r, err := cache.NewRedis(standaloneConfig(mr), nil)
+	require.NoError(t, err)
+	defer r.Close()
+
+	assert.Equal(t, config.RedisModeStandalone, r.Mode())
+	require.NoError(t, r.Ping(context.Background()))
+	require.NoError(t, r.Client().Set(context.Background(), "k", "v", 0).Err())
+	mr.CheckGet(t, "k", "v")
+}
+
+func TestNewRedis_Modes(t *testing.T) {
+	r, err := cache.NewRedis(&config.RedisConfig{Mode: config.RedisModeCluster, Addrs: []string{"localhost:7000"}}, nil)
+	require.NoError(t, err)
+	_, ok := r.Client().(*redis.ClusterClient)
+	assert.True(t, ok)
+	assert.Equal(t, config.RedisModeCluster, r.Mode())
+	_ = r.Close()
+
+	r, err = cache.NewRedis(&config.RedisConfig{Mode: config.RedisModeSentinel, Addrs: []string{"localhost:26379"}, MasterName: "main"}, nil)
+	require.NoError(t, err)
+	_, ok = r.Client().(*redis.Client)
+	assert.True(t, ok)
+	_ = r.Close()
+}
+
+func TestNewRedis_InvalidConfiguration(t *testing.T) {
+	for name, cfg := range map[string]config.RedisConfig{
+		"unknown mode":            {Mode: "replicated"},
+		"sentinel without master": {Mode: config.RedisModeSentinel, Addrs: []string{"localhost:26379"}},
+		"sentinel without addrs":  {Mode: config.RedisModeSentinel, MasterName: "main"},
+		"cluster without addrs":   {Mode: config.RedisModeCluster},
+		"cluster with a db":       {Mode: config.RedisModeCluster, Addrs: []string{"localhost:7000"}, DB: 1},
+	} {
+		_, err := cache.NewRedis(&cfg, nil)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestPing_FailsWhenUnreachable(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cfg := standaloneConfig(mr)
+	mr.Close()
+
+	r, err := cache.NewRedis(cfg, nil)
+	require.NoError(t, err)
+	defer r.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	assert.Error(t, r.Ping(ctx))
+}
+
+func TestNewRedis_TracesCommands(t *testing.T) {
+	mr := miniredis.RunT(t)
+	trc := &recordingTracer{Tracer: tracer.NewNoOpTracer()}
+	r, err := cache.NewRedis(standaloneConfig(mr), trc)
+	require.NoError(t, err)
+	defer r.Close()
+	ctx := context.Background()
+
+	require.NoError(t, r.Client().Set(ctx, "secret-key", "secret-value", 0).Err())
+	assert.ErrorIs(t, r.Client().Get(ctx, "missing").Err(), redis.Nil)
+	_, err = r.Client().Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.Incr(ctx, "a")
+		p.Incr(ctx, "b")
+		return nil
+	})
+	require.NoError(t, err)
+
+	trc.mu.Lock()
+	defer trc.mu.Unlock()
+	var names []string
+	for _, s := range trc.spans {
+		names = append(names, s.name)
+		assert.True(t, s.finished, s.name)
+		assert.Equal(t, "redis", s.tags["db.system"], s.name)
+		for _, v := range s.tags {
+			assert.NotContains(t, fmt.Sprint(v), "secret", "arguments are not recorded")
+		}
+	}
+	assert.Contains(t, names, "redis set")
+	assert.Contains(t, names, "redis get")
+	assert.Contains(t, names, "redis pipeline")
+
+	for _, s := range trc.spans {
+		switch s.name {
+		case "redis get":
+			assert.NoError(t, s.err, "a missing key is not an error")
+		case "redis pipeline":
+			assert.Equal(t, 2, s.tags["db.redis.pipeline_length"])
+		}
+	}
+}
+
+func TestPoolReporter_RecordsPoolStats(t *testing.T) {
+	mr := miniredis.RunT(t)
+	r, err := cache.NewRedis(standaloneConfig(mr), nil)
+	require.NoError(t, err)
+	defer r.Close()
+	require.NoError(t, r.Ping(context.Background()))
+
+	m := &recordingMetrics{gauges: map[string]float64{}}
+	p := cache.NewPoolReporter(r, m, time.Hour)
+	p.Start()
+	p.Stop()
+	p.Stop()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	assert.Equal(t, float64(1), m.gauges["redis_pool_total_connections"])
+	assert.Equal(t, float64(1), m.gauges["redis_pool_idle_connections"])
+	assert.Contains(t, m.gauges, "redis_pool_timeouts")
+	assert.Contains(t, m.gauges, "redis_pool_wait_duration_seconds")
+	assert.Equal(t, []string{"mode:standalone"}, m.tags)
+}
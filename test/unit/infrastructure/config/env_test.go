@@ -0,0 +1,111 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setenv sets the variables of env for the test, in env mode.
+func setenv(t *testing.T, env map[string]string) {
+	t.Helper()
+	t.Setenv(config.SourceEnvVar, config.SourceEnv)
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+}
+
+func TestEnvOnly_LoadsTheWholeConfiguration(t *testing.T) {
+	setenv(t, map[string]string{
+		"APP_NAME":                              "core-api",
+		"HTTP_PORT":                             "4000",
+		"HTTP_REQUEST_TIMEOUT":                  "30",
+		"HTTP_READ_TIMEOUT":                     "5s",
+		"TELEMETRY_SAMPLE_RATE":                 "0.25",
+		"RATE_LIMIT_ENABLED":                    "true",
+		"RATE_LIMIT_DEFAULT_LIMIT":              "100",
+		"RATE_LIMIT_ROUTES":                     `[{"path": "/api/v1/bookings", "method": "POST", "limit": 10, "window": 60}]`,
+		"TENANCY_RESOLVERS":                     "header,claim",
+		"LOG_LEVEL":                             "5",
+		"LOG_LEVELS_BOOKING":                    "warn",
+		"LOG_SINKS_0_DRIVER":                    "stdout",
+		"LOG_SINKS_1_DRIVER":                    "zap",
+		"LOG_SINKS_1_LEVEL":                     "error",
+		"DATABASE_CONNECTIONS_MAIN_DB_HOST":     "db.internal",
+		"DATABASE_CONNECTIONS_MAIN_DB_POOL_MAX": "50",
+	})
+
+	cfg, err := config.LoadGlobalConfig("missing/config.yaml")
+
+	require.NoError(t, err)
+	assert.Empty(t, config.GlobalPath(), "no file is watched")
+	assert.Equal(t, "core-api", cfg.App.Name)
+	assert.Equal(t, 4000, cfg.Http.Port)
+	assert.Equal(t, time.Duration(30), cfg.Http.RequestTimeout, "numbers like in the YAML files")
+	assert.Equal(t, 5*time.Second, cfg.Http.ReadTimeout)
+	assert.Equal(t, 0.25, cfg.Telemetry.SampleRate)
+	assert.True(t, cfg.RateLimit.Enabled)
+	assert.Equal(t, 100, cfg.RateLimit.Default.Limit)
+	require.Len(t, cfg.RateLimit.Routes, 1)
+	assert.Equal(t, "/api/v1/bookings", cfg.RateLimit.Routes[0].Path)
+	assert.Equal(t, 10, cfg.RateLimit.Routes[0].Limit, "the squashed rule is decoded")
+	assert.Equal(t, []string{"header", "claim"}, cfg.Tenancy.Resolvers)
+	assert.Equal(t, 5, cfg.Log.Level)
+	assert.Equal(t, map[string]string{"booking": "warn"}, cfg.Log.Levels)
+	assert.Equal(t, []config.LogSinkConfig{{Driver: "stdout"}, {Driver: "zap", Level: "error"}}, cfg.Log.Sinks)
+	require.Contains(t, cfg.Database.Connections, "main_db")
+	assert.Equal(t, "db.internal", cfg.Database.Connections["main_db"].Host)
+	assert.Equal(t, 50, cfg.Database.Connections["main_db"].Pool.Max)
+}
+
+func TestEnvOnly_DomainSettingsOverrideTheGlobalOnes(t *testing.T) {
+	setenv(t, map[string]string{
+		"APP_NAME":               "core-api",
+		"HTTP_PORT":              "4000",
+		"DATABASE_HOST":          "db.internal",
+		"DATABASE_PORT":          "5432",
+		"DATABASE_NAME":          "voyago",
+		"BOOKING__DATABASE_NAME": "booking",
+		"BOOKING__BOOKING_PAYMENT_REMINDER_DELAY": "900",
+	})
+	_, err := config.LoadGlobalConfig("")
+	require.NoError(t, err)
+
+	booking, err := config.ReadDomainConfig("config/booking/config.yaml")
+	require.NoError(t, err)
+	webhook, err := config.ReadDomainConfig("config/webhook/config.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, "db.internal", booking.Database.Host)
+	assert.Equal(t, "booking", booking.Database.Name)
+	assert.Equal(t, "voyago", webhook.Database.Name, "the domain prefix applies to its domain only")
+
+	var bookingReminder, webhookReminder reminderSettings
+	require.NoError(t, booking.Section("booking", &bookingReminder))
+	require.NoError(t, webhook.Section("booking", &webhookReminder))
+	assert.Equal(t, 900, bookingReminder.PaymentReminder.Delay, "the booking section of the booking domain")
+	assert.Zero(t, webhookReminder.PaymentReminder.Delay)
+}
+
+func TestEnvOnly_ReportsTheInvalidSettings(t *testing.T) {
+	setenv(t, map[string]string{"HTTP_PORT": "4000", "LOG_LEVEL": "9"})
+
+	_, err := config.LoadGlobalConfig("config/config.yaml")
+
+	var verr *config.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "environment", verr.Source)
+	assert.Equal(t, []string{"app.name", "log.level"}, paths(verr.Problems))
+}
+
+func paths(problems []config.Problem) []string {
+	out := make([]string, len(problems))
+	for i, p := range problems {
+		out[i] = p.Path
+	}
+	return out
+}
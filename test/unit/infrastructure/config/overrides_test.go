@@ -0,0 +1,62 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfig writes content to name in dir and returns its path.
+func writeConfig(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func setOverrides(t *testing.T, settings map[string]string) {
+	t.Helper()
+	require.NoError(t, config.SetOverrides(settings))
+	t.Cleanup(func() { _ = config.SetOverrides(nil) })
+}
+
+func TestSetOverrides_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	global := writeConfig(t, dir, "config.yaml", `app: { name: "core-api", env: "${APP_ENV:development}" }
+http: { port: 4000 }
+log: { level: 4 }
+`)
+	booking := writeConfig(t, dir, "booking/config.yaml", `database: { host: "localhost", port: 5432, name: "booking" }
+log: { level: 2 }
+`)
+	t.Setenv("APP_ENV", "staging")
+	t.Setenv("HTTP_PORT", "5000")
+	setOverrides(t, map[string]string{"log.level": "5", "log.levels.booking": "debug"})
+
+	cfg, err := config.LoadGlobalConfig(global)
+	require.NoError(t, err)
+	domain, err := config.ReadDomainConfig(booking)
+	require.NoError(t, err)
+
+	assert.Equal(t, "staging", cfg.App.Env, "the variables of the placeholders apply")
+	assert.Equal(t, 4000, cfg.Http.Port, "the other variables do not replace the file")
+	assert.Equal(t, 5, cfg.Log.Level)
+	assert.Equal(t, 5, domain.Log.Level, "the overrides replace the domain files too")
+	assert.Equal(t, map[string]string{"booking": "debug"}, domain.Log.Levels)
+	assert.Equal(t, "booking", domain.Database.Name)
+	assert.Equal(t, []string{"log.level", "log.levels.booking"}, config.Overridden())
+	assert.Equal(t, global, cfg.Source())
+	assert.Equal(t, booking, domain.Source())
+}
+
+func TestSetOverrides_RejectsUnknownSettings(t *testing.T) {
+	err := config.SetOverrides(map[string]string{"http.prot": "80"})
+
+	assert.EqualError(t, err, `unknown setting "http.prot"`)
+}
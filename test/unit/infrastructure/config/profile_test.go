@@ -0,0 +1,88 @@
+package config_test
+
+import (
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// profiles writes a global and a booking configuration with their production
+// profiles, and returns the paths of the base files.
+func profiles(t *testing.T) (global, booking string) {
+	t.Helper()
+	dir := t.TempDir()
+	global = writeConfig(t, dir, "config.yaml", `app: { name: "core-api", env: "${APP_ENV:development}" }
+http: { port: 4000 }
+log: { level: 5 }
+telemetry: { sample_rate: 1 }
+`)
+	writeConfig(t, dir, "config.production.yaml", `log: { level: 4 }
+telemetry: { sample_rate: 0.1 }
+`)
+	booking = writeConfig(t, dir, "booking/config.yaml", `database: { host: "localhost", port: 5432, name: "booking" }
+`)
+	writeConfig(t, dir, "booking/config.production.yaml", `database: { host: "db.internal" }
+`)
+	return global, booking
+}
+
+func TestProfilePath(t *testing.T) {
+	assert.Equal(t, "config/config.production.yaml", config.ProfilePath("config/config.yaml", "production"))
+	assert.Empty(t, config.ProfilePath("config/config.yaml", ""))
+}
+
+func TestLoad_MergesTheProfileOfTheEnvironment(t *testing.T) {
+	global, booking := profiles(t)
+	t.Setenv("APP_ENV", "production")
+
+	cfg, err := config.LoadGlobalConfig(global)
+	require.NoError(t, err)
+	domain, err := config.ReadDomainConfig(booking)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, cfg.Log.Level)
+	assert.Equal(t, 0.1, cfg.Telemetry.SampleRate)
+	assert.Equal(t, 4000, cfg.Http.Port, "the settings missing from the profile are kept")
+	assert.Equal(t, "db.internal", domain.Database.Host)
+	assert.Equal(t, "booking", domain.Database.Name)
+	assert.Equal(t, 4, domain.Log.Level, "the global profile applies to the domains")
+}
+
+func TestLoad_WithoutProfile(t *testing.T) {
+	global, booking := profiles(t)
+
+	cfg, err := config.LoadGlobalConfig(global)
+	require.NoError(t, err)
+	domain, err := config.ReadDomainConfig(booking)
+	require.NoError(t, err)
+
+	assert.Equal(t, "development", cfg.App.Env)
+	assert.Equal(t, 5, cfg.Log.Level, "config.development.yaml does not exist")
+	assert.Equal(t, "localhost", domain.Database.Host)
+}
+
+func TestLoad_TheOverriddenEnvironmentSelectsTheProfile(t *testing.T) {
+	global, _ := profiles(t)
+	setOverrides(t, map[string]string{"app.env": "production", "telemetry.sample_rate": "0.5"})
+
+	cfg, err := config.LoadGlobalConfig(global)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, cfg.Log.Level)
+	assert.Equal(t, 0.5, cfg.Telemetry.SampleRate, "the overrides replace the profile")
+}
+
+func TestLoad_ReportsTheProfileOfTheInvalidSettings(t *testing.T) {
+	global, _ := profiles(t)
+	t.Setenv("APP_ENV", "production")
+	setOverrides(t, map[string]string{"http.port": "0"})
+
+	_, err := config.LoadGlobalConfig(global)
+
+	var verr *config.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, global+" + "+config.ProfilePath(global, "production"), verr.Source)
+}
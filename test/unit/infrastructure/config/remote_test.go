@@ -0,0 +1,286 @@
+package config_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConsul serves the KV API of Consul from memory, with blocking queries.
+type fakeConsul struct {
+	*httptest.Server
+	mu      sync.Mutex
+	kv      map[string]string
+	index   uint64
+	changed chan struct{}
+	tokens  []string
+}
+
+func newFakeConsul(t *testing.T, kv map[string]string) *fakeConsul {
+	t.Helper()
+	c := &fakeConsul{kv: kv, index: 1, changed: make(chan struct{})}
+	c.Server = httptest.NewServer(http.HandlerFunc(c.serve))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func (c *fakeConsul) put(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.kv[key] = value
+	c.index++
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+func (c *fakeConsul) serve(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+	c.mu.Lock()
+	c.tokens = append(c.tokens, r.Header.Get("X-Consul-Token"))
+	index, changed := c.index, c.changed
+	c.mu.Unlock()
+
+	if r.URL.Query().Has("recurse") {
+		if wait, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64); wait == index {
+			select {
+			case <-changed:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		w.Header().Set("X-Consul-Index", strconv.FormatUint(c.index, 10))
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	c.mu.Lock()
+	value, ok := c.kv[key]
+	c.mu.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	fmt.Fprint(w, value)
+}
+
+// fakeEtcd serves the range and watch calls of the etcd gateway from memory.
+type fakeEtcd struct {
+	*httptest.Server
+	kv     map[string]string
+	events chan string
+}
+
+func newFakeEtcd(t *testing.T, kv map[string]string) *fakeEtcd {
+	t.Helper()
+	e := &fakeEtcd{kv: kv, events: make(chan string)}
+	e.Server = httptest.NewServer(http.HandlerFunc(e.serve))
+	t.Cleanup(e.Close)
+	return e
+}
+
+func (e *fakeEtcd) serve(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v3/kv/range":
+		var req struct {
+			Key string `json:"key"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		key, _ := base64.StdEncoding.DecodeString(req.Key)
+		var kvs []map[string]string
+		if value, ok := e.kv[string(key)]; ok {
+			kvs = append(kvs, map[string]string{"key": req.Key, "value": base64.StdEncoding.EncodeToString([]byte(value))})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"kvs": kvs})
+	case "/v3/watch":
+		enc := json.NewEncoder(w)
+		_ = enc.Encode(map[string]any{"result": map[string]any{"created": true}})
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case key := <-e.events:
+				_ = enc.Encode(map[string]any{"result": map[string]any{"events": []any{map[string]any{"kv": map[string]string{"key": key}}}}})
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// remoteFiles writes a global file reading the store at endpoint and a
+// booking domain file, and returns their paths.
+func remoteFiles(t *testing.T, provider, endpoint, extra string) (global, booking string) {
+	t.Helper()
+	dir := t.TempDir()
+	global = writeConfig(t, dir, "config.yaml", fmt.Sprintf(`app: { name: "core-api" }
+http: { port: 4000 }
+log: { level: 4 }
+remote: { provider: %q, endpoint: %q, path: "fleet", token: "t0k3n"%s }
+`, provider, endpoint, extra))
+	booking = writeConfig(t, dir, "booking/config.yaml", `database: { host: "localhost", port: 5432, name: "booking" }
+log: { level: 3 }
+`)
+	return global, booking
+}
+
+func TestRemote_Consul_MergesTheDocumentsOverTheFiles(t *testing.T) {
+	consul := newFakeConsul(t, map[string]string{
+		"fleet/config.yaml":         "http: { port: 5000 }\nlog: { level: 5 }\n",
+		"fleet/booking/config.yaml": "database: { host: db.internal }\n",
+	})
+	global, booking := remoteFiles(t, "consul", consul.URL, "")
+	setOverrides(t, map[string]string{"http.port": "6000"})
+
+	cfg, err := config.LoadGlobalConfig(global)
+	require.NoError(t, err)
+	domain, err := config.ReadDomainConfig(booking)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, cfg.Log.Level)
+	assert.Equal(t, 6000, cfg.Http.Port, "the overrides win over the store")
+	assert.Equal(t, global+" + consul:fleet/config.yaml", cfg.Source())
+	assert.Equal(t, "db.internal", domain.Database.Host)
+	assert.Equal(t, 5, domain.Log.Level, "the global document wins over the domain file")
+	assert.Equal(t, booking+" + consul:fleet/booking/config.yaml", domain.Source())
+	assert.Contains(t, consul.tokens, "t0k3n")
+}
+
+func TestRemote_MissingDocumentsAreSkipped(t *testing.T) {
+	consul := newFakeConsul(t, map[string]string{})
+	global, booking := remoteFiles(t, "consul", consul.URL, "")
+
+	cfg, err := config.LoadGlobalConfig(global)
+	require.NoError(t, err)
+	domain, err := config.ReadDomainConfig(booking)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4000, cfg.Http.Port)
+	assert.Equal(t, global, cfg.Source())
+	assert.Equal(t, 3, domain.Log.Level)
+}
+
+func TestRemote_UnreachableStore(t *testing.T) {
+	consul := newFakeConsul(t, map[string]string{})
+	consul.Close()
+
+	global, _ := remoteFiles(t, "consul", consul.URL, "")
+	_, err := config.LoadGlobalConfig(global)
+	assert.ErrorContains(t, err, "error reading remote config consul:fleet/config.yaml")
+
+	global, _ = remoteFiles(t, "consul", consul.URL, ", optional: true")
+	cfg, err := config.LoadGlobalConfig(global)
+	require.NoError(t, err, "optional, the files are enough")
+	assert.Equal(t, 4000, cfg.Http.Port)
+	assert.Equal(t, global+" + consul:fleet/config.yaml (unreachable)", cfg.Source())
+}
+
+func TestRemote_InvalidDocument(t *testing.T) {
+	consul := newFakeConsul(t, map[string]string{"fleet/config.yaml": "log: { level: 9 }\n"})
+	global, _ := remoteFiles(t, "consul", consul.URL, "")
+
+	_, err := config.LoadGlobalConfig(global)
+
+	var verr *config.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, global+" + consul:fleet/config.yaml", verr.Source)
+	assert.Equal(t, []string{"log.level"}, paths(verr.Problems))
+}
+
+func TestRemote_Etcd(t *testing.T) {
+	etcd := newFakeEtcd(t, map[string]string{"fleet/booking/config.yaml": "database: { name: fleet }\n"})
+	global, booking := remoteFiles(t, "etcd", etcd.URL, "")
+
+	_, err := config.LoadGlobalConfig(global)
+	require.NoError(t, err)
+	domain, err := config.ReadDomainConfig(booking)
+	require.NoError(t, err)
+
+	assert.Equal(t, "fleet", domain.Database.Name)
+	assert.Equal(t, booking+" + etcd:fleet/booking/config.yaml", domain.Source())
+}
+
+func TestRemote_WithoutProvider(t *testing.T) {
+	global, _ := remoteFiles(t, "", "", "")
+
+	_, err := config.LoadGlobalConfig(global)
+	require.NoError(t, err)
+
+	provider, _ := config.Remote()
+	assert.Nil(t, provider)
+}
+
+func TestRemoteKey(t *testing.T) {
+	assert.Equal(t, "voyago/config.yaml", config.RemoteKey("", ""))
+	assert.Equal(t, "fleet/booking/config.yaml", config.RemoteKey("/fleet/", "booking"))
+}
+
+func TestRemoteProvider_Consul_Watch(t *testing.T) {
+	consul := newFakeConsul(t, map[string]string{})
+	provider, err := config.NewRemoteProvider(config.RemoteConfig{Provider: "consul", Endpoint: consul.URL})
+	require.NoError(t, err)
+
+	changes := watch(t, provider, "fleet/")
+	time.Sleep(50 * time.Millisecond)
+	consul.put("fleet/config.yaml", "log: { level: 5 }")
+
+	select {
+	case <-changes:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the change was not reported")
+	}
+}
+
+func TestRemoteProvider_Etcd_Watch(t *testing.T) {
+	etcd := newFakeEtcd(t, map[string]string{})
+	provider, err := config.NewRemoteProvider(config.RemoteConfig{Provider: "etcd", Endpoint: etcd.URL})
+	require.NoError(t, err)
+
+	changes := watch(t, provider, "fleet/")
+	etcd.events <- "fleet/config.yaml"
+
+	select {
+	case <-changes:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the change was not reported")
+	}
+}
+
+func TestNewRemoteProvider_UnknownProvider(t *testing.T) {
+	_, err := config.NewRemoteProvider(config.RemoteConfig{Provider: "zookeeper"})
+
+	assert.Error(t, err)
+}
+
+// watch watches prefix until the end of the test and returns the changes.
+func watch(t *testing.T, provider config.RemoteProvider, prefix string) <-chan struct{} {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := make(chan struct{}, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = provider.Watch(ctx, prefix, func() { changes <- struct{}{} })
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return changes
+}
@@ -0,0 +1,109 @@
+package config_test
+
+import (
+	"fmt"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reminderSettings are the settings of a module section.
+type reminderSettings struct {
+	PaymentReminder struct {
+		Delay int `mapstructure:"delay"`
+	} `mapstructure:"payment_reminder"`
+	Channels []string `mapstructure:"channels"`
+}
+
+func (s *reminderSettings) Validate() []config.Problem {
+	if s.PaymentReminder.Delay < 0 {
+		return []config.Problem{{
+			Path:    "payment_reminder.delay",
+			Message: fmt.Sprintf("must not be negative, got %d", s.PaymentReminder.Delay),
+		}}
+	}
+	return nil
+}
+
+// sectionConfig loads a global configuration and a booking domain one
+// declaring the booking section domainYAML.
+func sectionConfig(t *testing.T, domainYAML string) (*config.Config, string) {
+	t.Helper()
+	dir := t.TempDir()
+	global := writeConfig(t, dir, "config.yaml", `app: { name: "core-api" }
+http: { port: 4000 }
+booking: { channels: [email] }
+`)
+	_, err := config.LoadGlobalConfig(global)
+	require.NoError(t, err)
+
+	path := writeConfig(t, dir, "booking/config.yaml", `database: { host: "localhost", port: 5432, name: "booking" }
+`+domainYAML)
+	cfg, err := config.ReadDomainConfig(path)
+	require.NoError(t, err)
+	return cfg, path
+}
+
+func TestSection_DecodesTheModuleSettings(t *testing.T) {
+	cfg, _ := sectionConfig(t, `booking: { payment_reminder: { delay: 900 } }
+`)
+
+	var settings reminderSettings
+	require.NoError(t, cfg.Section("booking", &settings))
+
+	assert.Equal(t, 900, settings.PaymentReminder.Delay)
+	assert.Equal(t, []string{"email"}, settings.Channels, "the domain section merges the global one")
+}
+
+func TestSection_KeepsTheDefaultsOfTheMissingSettings(t *testing.T) {
+	cfg, _ := sectionConfig(t, "")
+
+	settings := reminderSettings{}
+	settings.PaymentReminder.Delay = 60
+	require.NoError(t, cfg.Section("loyalty", &settings))
+
+	assert.Equal(t, 60, settings.PaymentReminder.Delay)
+}
+
+func TestSection_ReportsTheInvalidSettings(t *testing.T) {
+	cfg, path := sectionConfig(t, `booking: { payment_reminder: { delay: -1 } }
+`)
+
+	err := cfg.Section("booking", &reminderSettings{})
+
+	var verr *config.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, path, verr.Source)
+	assert.Equal(t, []string{"booking.payment_reminder.delay"}, paths(verr.Problems))
+}
+
+func TestSection_RejectsUndecodableSettings(t *testing.T) {
+	cfg, _ := sectionConfig(t, `booking: { payment_reminder: { delay: "soon" } }
+`)
+
+	err := cfg.Section("booking", &reminderSettings{})
+
+	assert.ErrorContains(t, err, "unable to decode section booking")
+}
+
+func TestSection_RejectsTheSectionsOfConfig(t *testing.T) {
+	cfg, _ := sectionConfig(t, "")
+
+	assert.Error(t, cfg.Section("http", &reminderSettings{}))
+	assert.Error(t, cfg.Section("booking", reminderSettings{}), "the target must be a pointer")
+}
+
+func TestSection_IsKeptByWithOverrides(t *testing.T) {
+	cfg, _ := sectionConfig(t, `booking: { payment_reminder: { delay: 900 } }
+`)
+
+	overridden, err := cfg.WithOverrides(map[string]any{"http": map[string]any{"port": 5000}})
+	require.NoError(t, err)
+
+	var settings reminderSettings
+	require.NoError(t, overridden.Section("booking", &settings))
+	assert.Equal(t, 900, settings.PaymentReminder.Delay)
+}
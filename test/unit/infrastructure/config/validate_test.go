@@ -0,0 +1,152 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validConfig returns a domain configuration passing ValidateDomain.
+func validConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.App.Name = "core-api"
+	cfg.Http.Port = 4000
+	cfg.Database = config.DatabaseConfig{Host: "localhost", Port: 5432, Name: "voyago"}
+	return cfg
+}
+
+func problems(t *testing.T, err error) []config.Problem {
+	t.Helper()
+	var verr *config.ValidationError
+	require.ErrorAs(t, err, &verr)
+	return verr.Problems
+}
+
+func TestValidate_AcceptsTheDefaults(t *testing.T) {
+	assert.NoError(t, validConfig().ValidateDomain())
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	cfg := validConfig()
+	cfg.App.Name = ""
+	cfg.Http.Port = 70000
+	cfg.Log.Driver = "file"
+	cfg.Log.Sinks = []config.LogSinkConfig{{Driver: "tee"}}
+	cfg.Telemetry = config.TelemetryConfig{Enabled: true, Type: "jaeger", SampleRate: 1.5}
+
+	got := problems(t, cfg.Validate())
+
+	assert.Equal(t, []config.Problem{
+		{Path: "app.name", Message: "is required"},
+		{Path: "http.port", Message: "must be a port between 1 and 65535, got 70000"},
+		{Path: "telemetry.type", Message: `must be one of "datadog", "otel", "prometheus", "statsd", got "jaeger"`},
+		{Path: "telemetry.sample_rate", Message: "must be between 0 and 1, got 1.5"},
+		{Path: "log.driver", Message: `must be one of "stdout", "logrus", "zap", "zerolog", "otel", "tee", got "file"`},
+		{Path: "log.sinks[0].driver", Message: `must be one of "stdout", "logrus", "zap", "zerolog", "otel", got "tee"`},
+	}, got)
+}
+
+func TestValidateDomain_Database(t *testing.T) {
+	cases := map[string]struct {
+		db   config.DatabaseConfig
+		want []config.Problem
+	}{
+		"missing connection settings": {
+			config.DatabaseConfig{Driver: "postgres"},
+			[]config.Problem{
+				{Path: "database.host", Message: "is required"},
+				{Path: "database.port", Message: "must be a port between 1 and 65535, got 0"},
+				{Path: "database.name", Message: "is required"},
+			},
+		},
+		"sqlite needs the file only": {
+			config.DatabaseConfig{Driver: "sqlite", Name: "app.db"},
+			nil,
+		},
+		"unknown shared connection": {
+			config.DatabaseConfig{Schema: "booking", Connection: "main"},
+			[]config.Problem{{Path: "database.connection", Message: `unknown database connection "main"`}},
+		},
+		"pool": {
+			config.DatabaseConfig{Host: "db", Port: 5432, Name: "voyago", Pool: config.DatabasePoolConfig{Idle: 20, Max: 10},
+				Replicas: []config.DatabaseEndpointConfig{{Port: -1}}},
+			[]config.Problem{
+				{Path: "database.pool.idle", Message: "must not exceed database.pool.max (10), got 20"},
+				{Path: "database.replicas[0].port", Message: "must be a port between 1 and 65535, got -1"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.Database = tc.db
+
+			err := cfg.ValidateDomain()
+
+			if tc.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tc.want, problems(t, err))
+		})
+	}
+}
+
+func TestValidate_FeatureFlags(t *testing.T) {
+	cfg := validConfig()
+	cfg.FeatureFlags.Driver = config.FeatureFlagDriverUnleash
+	cfg.FeatureFlags.Flags = map[string]config.FeatureFlagConfig{
+		"new_pricing_engine": {Enabled: true, Percentage: 120},
+		"instant_checkout":   {Enabled: true, Percentage: 50},
+	}
+
+	got := problems(t, cfg.Validate())
+
+	assert.Equal(t, []config.Problem{
+		{Path: "feature_flags.unleash.url", Message: "is required"},
+		{Path: "feature_flags.unleash.api_token", Message: "is required"},
+		{Path: "feature_flags.flags.new_pricing_engine.percentage", Message: "must be between 0 and 100, got 120"},
+	}, got)
+}
+
+func TestValidate_KafkaBrokers(t *testing.T) {
+	cfg := validConfig()
+	cfg.Kafka.Enabled = true
+
+	assert.Equal(t, []config.Problem{
+		{Path: "kafka.brokers", Message: "is required when kafka is enabled"},
+	}, problems(t, cfg.Validate()))
+
+	cfg.Kafka.Brokers = []string{"kafka-1:9092", "kafka-2"}
+	assert.Equal(t, []config.Problem{
+		{Path: "kafka.brokers[1]", Message: `must be a host:port address, got "kafka-2"`},
+	}, problems(t, cfg.Validate()))
+}
+
+func TestValidationError_ListsThePaths(t *testing.T) {
+	err := &config.ValidationError{Source: "config/config.yaml", Problems: []config.Problem{
+		{Path: "http.port", Message: "is required"},
+		{Path: "redis.mode", Message: `must be one of "standalone", got "ring"`},
+	}}
+
+	assert.Equal(t, "invalid configuration config/config.yaml:\n"+
+		"  - http.port: is required\n"+
+		`  - redis.mode: must be one of "standalone", got "ring"`, err.Error())
+}
+
+func TestLoadGlobalConfig_ReportsTheFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("app:\n  name: core-api\nhttp:\n  port: 0\n"), 0o644))
+
+	_, err := config.LoadGlobalConfig(path)
+
+	var verr *config.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, path, verr.Source)
+	assert.Equal(t, []config.Problem{{Path: "http.port", Message: "must be a port between 1 and 65535, got 0"}}, verr.Problems)
+}
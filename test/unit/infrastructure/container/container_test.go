@@ -0,0 +1,104 @@
+package container_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/container"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+type greeter interface {
+	Greet() string
+}
+
+type english struct{ name string }
+
+func (e english) Greet() string { return "hello " + e.name }
+
+// buildErr returns the error Build panicked with.
+func buildErr(t *testing.T, opts ...fx.Option) (err error) {
+	t.Helper()
+	defer func() {
+		var ok bool
+		err, ok = recover().(error)
+		require.True(t, ok, "Build did not panic with an error")
+	}()
+	container.Build(nil, "demo", opts...)
+	return nil
+}
+
+func TestSupply_ProvidesTheInterface(t *testing.T) {
+	var g greeter
+	var w io.Writer
+
+	container.Build(nil, "demo",
+		container.Supply[greeter](english{name: "ana"}),
+		container.Supply[io.Writer](nil),
+		fx.Populate(&g, &w),
+	)
+
+	assert.Equal(t, "hello ana", g.Greet())
+	assert.Nil(t, w)
+}
+
+func TestBuild_FailsOnMissingDependency(t *testing.T) {
+	err := buildErr(t, fx.Invoke(func(greeter) {}))
+
+	assert.ErrorContains(t, err, "invalid demo wiring")
+	assert.ErrorContains(t, err, "missing type: container_test.greeter")
+}
+
+func TestBuild_FailsOnFailingConstructor(t *testing.T) {
+	boom := errors.New("boom")
+
+	err := buildErr(t,
+		fx.Provide(func() (greeter, error) { return nil, boom }),
+		fx.Invoke(func(greeter) {}),
+	)
+
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestBuild_DecoratesWithinTheModuleOnly(t *testing.T) {
+	var inner, outer string
+
+	container.Build(nil, "demo",
+		container.Supply[greeter](english{name: "ana"}),
+		fx.Provide(func(g greeter) string { return g.Greet() }),
+		fx.Module("inner",
+			fx.Decorate(func(greeter) greeter { return english{name: "bob"} }),
+			fx.Provide(fx.Annotate(func(g greeter) string { return g.Greet() }, fx.ResultTags(`name:"inner"`))),
+		),
+		fx.Invoke(fx.Annotate(func(i, o string) { inner, outer = i, o }, fx.ParamTags(`name:"inner"`, ``))),
+	)
+
+	assert.Equal(t, "hello bob", inner)
+	assert.Equal(t, "hello ana", outer)
+}
+
+func TestBuild_StopsWithTheLifecycle(t *testing.T) {
+	lc := lifecycle.New(config.ShutdownConfig{}, logger.NewNoOpLogger())
+	var events []string
+
+	container.Build(lc, "demo",
+		fx.Invoke(func(l fx.Lifecycle) {
+			l.Append(fx.Hook{
+				OnStart: func(context.Context) error { events = append(events, "start"); return nil },
+				OnStop:  func(context.Context) error { events = append(events, "stop"); return nil },
+			})
+		}),
+	)
+	require.Equal(t, []string{"start"}, events)
+
+	require.NoError(t, lc.Shutdown(context.Background()))
+	assert.Equal(t, []string{"start", "stop"}, events)
+}
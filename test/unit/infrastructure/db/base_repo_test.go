@@ -0,0 +1,183 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newItemRepo(t *testing.T, codes ...string) (*database.GormBaseRepository[tenantItem], database.Database) {
+	t.Helper()
+	db := newItemDB(t, "", codes...)
+	t.Cleanup(func() { _ = db.Close() })
+	return &database.GormBaseRepository[tenantItem]{DB: db, ErrorMapper: database.MapDBError}, db
+}
+
+func items(pairs ...string) []*tenantItem {
+	var items []*tenantItem
+	for i := 0; i < len(pairs); i += 2 {
+		items = append(items, &tenantItem{ID: pairs[i], Code: pairs[i+1]})
+	}
+	return items
+}
+
+func TestCreateInBatches(t *testing.T) {
+	repo, db := newItemRepo(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.CreateInBatches(ctx, items("1", "A", "2", "B", "3", "C", "4", "D", "5", "E"), 2))
+
+	assert.Equal(t, []string{"A", "B", "C", "D", "E"}, codes(t, db, ctx))
+	assert.NoError(t, repo.CreateInBatches(ctx, nil, 2))
+}
+
+func TestCreateInBatches_FailingBatchStoresNothing(t *testing.T) {
+	repo, db := newItemRepo(t, "A")
+	ctx := context.Background()
+
+	err := repo.CreateInBatches(ctx, items("1", "B", "2", "C", "0", "duplicate"), 2)
+
+	var appErr *apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperror.CodeDbConflict, appErr.Code)
+	assert.Equal(t, []string{"A"}, codes(t, db, ctx), "the first batch was rolled back")
+}
+
+func TestCreateInBatches_StampsTheTenant(t *testing.T) {
+	repo, db := newItemRepo(t)
+	ctx := tenantCtx("acme")
+
+	require.NoError(t, repo.CreateInBatches(ctx, items("1", "A", "2", "B", "3", "C"), 2))
+
+	assert.Equal(t, []string{"A", "B", "C"}, codes(t, db, ctx))
+	assert.Empty(t, codes(t, db, tenantCtx("globex")))
+}
+
+func TestUpsert(t *testing.T) {
+	repo, db := newItemRepo(t, "A", "B")
+	ctx := context.Background()
+
+	err := repo.Upsert(ctx, items("1", "B2", "2", "C"), 0, database.OnConflict{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B2", "C"}, codes(t, db, ctx))
+}
+
+func TestUpsert_OnlyTheGivenColumns(t *testing.T) {
+	repo, db := newItemRepo(t, "A")
+	ctx := context.Background()
+
+	err := repo.Upsert(ctx, items("0", "A2", "1", "B"), 1, database.OnConflict{Columns: []string{"id"}, Update: []string{"tenant_id"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B"}, codes(t, db, ctx), "the code is not updated")
+}
+
+func TestUpsert_AnotherTenantsRow(t *testing.T) {
+	repo, db := newItemRepo(t)
+	acme, globex := tenantCtx("acme"), tenantCtx("globex")
+	require.NoError(t, repo.Create(acme, &tenantItem{ID: "1", Code: "A"}))
+
+	err := repo.Upsert(globex, items("1", "HIJACK", "2", "B"), 0, database.OnConflict{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A"}, codes(t, db, acme), "the row of acme is left untouched")
+	assert.Equal(t, []string{"B"}, codes(t, db, globex))
+
+	require.NoError(t, repo.Upsert(acme, items("1", "A2"), 0, database.OnConflict{}))
+	assert.Equal(t, []string{"A2"}, codes(t, db, acme), "the rows of the tenant are updated")
+}
+
+// patchItem is a patch of tenantItem.
+type patchItem struct {
+	Code   *string `patch:"code"`
+	Tenant *string `patch:"tenant_id"`
+	Note   string
+}
+
+func TestUpdate(t *testing.T) {
+	repo, db := newItemRepo(t, "A")
+	ctx := context.Background()
+
+	require.NoError(t, repo.Update(ctx, &tenantItem{ID: "0", Code: "A2"}))
+	assert.Equal(t, []string{"A2"}, codes(t, db, ctx))
+
+	err := repo.Update(ctx, &tenantItem{ID: "1", Code: "B"})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Equal(t, []string{"A2"}, codes(t, db, ctx), "a missing row is not inserted")
+}
+
+func TestUpdate_Unchanged(t *testing.T) {
+	repo, db := newItemRepo(t, "A")
+	ctx := context.Background()
+
+	require.NoError(t, repo.Update(ctx, &tenantItem{ID: "0", Code: "A"}), "an unchanged row is found")
+	assert.Equal(t, []string{"A"}, codes(t, db, ctx))
+}
+
+func TestUpdate_AnotherTenantsRow(t *testing.T) {
+	repo, db := newItemRepo(t)
+	acme, globex := tenantCtx("acme"), tenantCtx("globex")
+	require.NoError(t, repo.Create(acme, &tenantItem{ID: "1", Code: "A"}))
+
+	err := repo.Update(globex, &tenantItem{ID: "1", Code: "HIJACK"})
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Equal(t, []string{"A"}, codes(t, db, acme), "the row keeps its code and its tenant")
+	assert.Empty(t, codes(t, db, globex))
+}
+
+func TestUpdateFields(t *testing.T) {
+	repo, db := newItemRepo(t, "A", "B")
+	ctx := context.Background()
+
+	item := &tenantItem{ID: "0", Code: "A2"}
+	require.NoError(t, repo.UpdateFields(ctx, item, "Code"))
+
+	assert.Equal(t, []string{"A2", "B"}, codes(t, db, ctx))
+}
+
+func TestUpdateFields_Whitelisting(t *testing.T) {
+	repo, db := newItemRepo(t, "A")
+	ctx := context.Background()
+
+	for _, field := range []string{"id", "tenant_id", "missing", "code = 'X', id"} {
+		err := repo.UpdateFields(ctx, &tenantItem{ID: "0", Code: "X"}, "code", field)
+
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr, field)
+		assert.Equal(t, apperror.CodeInternalError, appErr.Code)
+	}
+	assert.Equal(t, []string{"A"}, codes(t, db, ctx), "nothing was updated")
+}
+
+func TestPatch(t *testing.T) {
+	repo, db := newItemRepo(t, "A")
+	ctx := context.Background()
+	code := "A2"
+
+	item := &tenantItem{ID: "0", Code: "A"}
+	require.NoError(t, repo.Patch(ctx, item, patchItem{Code: &code, Note: "ignored"}))
+
+	assert.Equal(t, "A2", item.Code, "the entity is patched")
+	assert.Equal(t, []string{"A2"}, codes(t, db, ctx))
+	assert.NoError(t, repo.Patch(ctx, item, &patchItem{}), "an empty patch updates nothing")
+}
+
+func TestPatch_RejectedPatchLeavesTheEntity(t *testing.T) {
+	repo, _ := newItemRepo(t, "A")
+	code, tenant := "A2", "acme"
+
+	item := &tenantItem{ID: "0", Code: "A"}
+	err := repo.Patch(context.Background(), item, patchItem{Code: &code, Tenant: &tenant})
+
+	assert.Error(t, err)
+	assert.Equal(t, "A", item.Code)
+	assert.Nil(t, item.TenantID)
+}
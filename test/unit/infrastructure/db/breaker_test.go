@@ -0,0 +1,86 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newBreakerDB returns a database behind a circuit breaker opening after 2
+// connection failures, and a switch making its queries fail to connect.
+func newBreakerDB(t *testing.T) (database.Database, *atomic.Bool, *recordingMetrics) {
+	t.Helper()
+	db := newItemDB(t, "", "A")
+	t.Cleanup(func() { _ = db.Close() })
+	m := &recordingMetrics{Metrics: metrics.NewNoOpMetrics()}
+
+	guarded := database.WithCircuitBreaker(db, config.DatabaseCircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: 60}, "booking", m)
+
+	down := &atomic.Bool{}
+	require.NoError(t, db.GetDB().Callback().Query().Before("gorm:query").Register("test:down", func(tx *gorm.DB) {
+		if down.Load() {
+			_ = tx.AddError(errors.New("dial tcp 127.0.0.1:5432: connect: connection refused"))
+		}
+	}))
+	return guarded, down, m
+}
+
+func TestWithCircuitBreaker_OpensOnConnectionFailures(t *testing.T) {
+	db, down, m := newBreakerDB(t)
+	ctx := context.Background()
+
+	down.Store(true)
+	for range 2 {
+		err := db.WithContext(ctx).Find(&[]tenantItem{}).Error
+		assert.True(t, database.IsConnectionError(err))
+	}
+	down.Store(false)
+
+	err := db.WithContext(ctx).Find(&[]tenantItem{}).Error
+	assert.ErrorIs(t, err, database.ErrDatabaseUnavailable, "fails fast while open")
+
+	called := false
+	err = db.Atomic(ctx, func(context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, database.ErrDatabaseUnavailable)
+	assert.False(t, called)
+
+	var states []float64
+	for _, r := range m.named("db_circuit_state") {
+		assert.Equal(t, []string{"domain:booking"}, r.tags)
+		states = append(states, r.value)
+	}
+	assert.Equal(t, []float64{0, 2}, states, "closed, then open")
+}
+
+func TestWithCircuitBreaker_IgnoresOtherErrors(t *testing.T) {
+	db, _, _ := newBreakerDB(t)
+	ctx := context.Background()
+
+	for range 3 {
+		err := db.WithContext(ctx).Create(&tenantItem{ID: "0", Code: "B"}).Error
+		assert.Error(t, err, "a duplicate key reached the database")
+		err = db.WithContext(ctx).Table("missing").Find(&[]tenantItem{}).Error
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, []string{"A"}, codes(t, db, ctx), "the circuit is still closed")
+}
+
+func TestWithCircuitBreaker_Disabled(t *testing.T) {
+	db := newItemDB(t, "")
+	t.Cleanup(func() { _ = db.Close() })
+
+	assert.Same(t, db, database.WithCircuitBreaker(db, config.DatabaseCircuitBreakerConfig{}, "booking", nil))
+}
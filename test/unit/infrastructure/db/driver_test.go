@@ -0,0 +1,77 @@
+package database_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/apperror"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenGormDatabase_SQLiteDriver(t *testing.T) {
+	cfg := &config.DatabaseConfig{
+		Driver: config.DatabaseDriverSQLite,
+		Name:   filepath.Join(t.TempDir(), "app.db"),
+	}
+
+	db, err := database.OpenGormDatabase(cfg, logger.NewNoOpLogger(), nil)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.GetDB().AutoMigrate(&memItem{}))
+	require.NoError(t, db.GetDB().Create(&memItem{ID: "1", Code: "A"}).Error)
+	assert.Equal(t, "sqlite", db.GetDB().Dialector.Name())
+	assert.FileExists(t, cfg.Name)
+
+	err = database.MapDBError(db.GetDB().Create(&memItem{ID: "2", Code: "A"}).Error)
+	var appErr *apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperror.CodeDbConflict, appErr.Code)
+}
+
+func TestOpenGormDatabase_UnsupportedDriver(t *testing.T) {
+	_, err := database.OpenGormDatabase(&config.DatabaseConfig{Driver: "oracle"}, logger.NewNoOpLogger(), nil)
+
+	assert.ErrorContains(t, err, `unsupported database driver "oracle"`)
+}
+
+func TestMapDBError_MySQL(t *testing.T) {
+	tests := []struct {
+		number    uint16
+		code      string
+		retryable bool
+	}{
+		{1062, apperror.CodeDbConflict, false},
+		{1452, apperror.CodeDbConstraint, false},
+		{1048, apperror.CodeDbConstraint, false},
+		{1213, apperror.CodeDbDeadlock, true},
+		{1205, apperror.CodeDbTimeout, true},
+		{1040, apperror.CodeDbConnectionFailed, true},
+		{1146, apperror.CodeInternalError, false},
+		{9999, apperror.CodeInternalError, false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.number), func(t *testing.T) {
+			raw := &mysqldriver.MySQLError{Number: tt.number, Message: "boom"}
+
+			err := database.MapDBError(fmt.Errorf("query: %w", raw))
+
+			var appErr *apperror.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.code, appErr.Code)
+			assert.Equal(t, tt.retryable, appErr.IsRetryable())
+			assert.ErrorIs(t, err, raw)
+		})
+	}
+
+	var appErr *apperror.AppError
+	require.ErrorAs(t, database.MapDBError(mysqldriver.ErrInvalidConn), &appErr)
+	assert.Equal(t, apperror.CodeDbConnectionFailed, appErr.Code)
+}
@@ -0,0 +1,67 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/sqlmask"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// sqlLogger keeps the db_sql field of every entry.
+type sqlLogger struct {
+	logger.Logger
+	statements *[]string
+}
+
+func (l *sqlLogger) WithContext(context.Context) logger.Logger { return l }
+func (l *sqlLogger) WithField(string, any) logger.Logger       { return l }
+
+func (l *sqlLogger) WithFields(fields map[string]any) logger.Logger {
+	if sql, ok := fields["db_sql"].(string); ok {
+		*l.statements = append(*l.statements, sql)
+	}
+	return l
+}
+
+func newSQLLogger() *sqlLogger {
+	return &sqlLogger{Logger: logger.NewNoOpLogger(), statements: &[]string{}}
+}
+
+func TestGormLoggerBridge_LogsPlaceholders(t *testing.T) {
+	log := newSQLLogger()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               database.NewGormLoggerBridge(log, sqlmask.DialectPostgres),
+	})
+	require.NoError(t, err)
+
+	db.Where("code = ? AND id = ?", "jane@example.com", "42").Find(&[]memItem{})
+
+	assert.Equal(t, []string{`SELECT * FROM "mem_items" WHERE code = $1 AND id = $2`}, *log.statements)
+}
+
+func TestGormLoggerBridge_SQLiteLiteralsMasked(t *testing.T) {
+	log := newSQLLogger()
+	db := database.NewSQLiteDatabase(t.Name(), log, nil)
+	defer db.Close()
+	require.NoError(t, db.GetDB().AutoMigrate(&memItem{}))
+	*log.statements = nil
+
+	require.NoError(t, db.GetDB().Create(&memItem{ID: "1", Code: "jane@example.com"}).Error)
+	require.NoError(t, db.GetDB().Exec(`UPDATE mem_items SET code = "s3cret" WHERE id = ?`, "1").Error)
+
+	require.Len(t, *log.statements, 2)
+	for _, sql := range *log.statements {
+		assert.NotContains(t, sql, "jane@example.com")
+		assert.NotContains(t, sql, "s3cret")
+	}
+	assert.Equal(t, `UPDATE mem_items SET code = ? WHERE id = ?`, (*log.statements)[1])
+}
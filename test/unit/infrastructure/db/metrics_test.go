@@ -0,0 +1,114 @@
+package database_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedMetric struct {
+	name  string
+	value float64
+	tags  []string
+}
+
+type recordingMetrics struct {
+	metrics.Metrics
+	mu       sync.Mutex
+	recorded []recordedMetric
+}
+
+func (m *recordingMetrics) Timing(name string, value time.Duration, tags []string) {
+	m.record(name, value.Seconds(), tags)
+}
+
+func (m *recordingMetrics) Gauge(name string, value float64, tags []string) {
+	m.record(name, value, tags)
+}
+
+func (m *recordingMetrics) Incr(name string, tags []string) {
+	m.record(name, 1, tags)
+}
+
+func (m *recordingMetrics) record(name string, value float64, tags []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recorded = append(m.recorded, recordedMetric{name, value, tags})
+}
+
+func (m *recordingMetrics) named(name string) []recordedMetric {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []recordedMetric
+	for _, r := range m.recorded {
+		if r.name == name {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func TestUseMetrics_RecordsQueries(t *testing.T) {
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), nil)
+	defer db.Close()
+	require.NoError(t, db.GetDB().AutoMigrate(&memItem{}))
+
+	m := &recordingMetrics{Metrics: metrics.NewNoOpMetrics()}
+	database.UseMetrics(db.GetDB(), "booking", m)
+
+	require.NoError(t, db.GetDB().Create(&memItem{ID: "1", Code: "A"}).Error)
+	require.Error(t, db.GetDB().Create(&memItem{ID: "2", Code: "A"}).Error)
+	var item memItem
+	require.Error(t, db.GetDB().First(&item, "id = ?", "42").Error)
+
+	var tags [][]string
+	for _, r := range m.named("db_query_duration") {
+		tags = append(tags, r.tags)
+	}
+	assert.Equal(t, [][]string{
+		{"domain:booking", "table:mem_items", "operation:create", "status:ok"},
+		{"domain:booking", "table:mem_items", "operation:create", "status:error"},
+		{"domain:booking", "table:mem_items", "operation:query", "status:ok"},
+	}, tags, "a record not found is not an error")
+}
+
+func TestPoolReporter_Report(t *testing.T) {
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), nil)
+	defer db.Close()
+	sqlDB, err := db.GetDB().DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(4)
+
+	m := &recordingMetrics{Metrics: metrics.NewNoOpMetrics()}
+	database.NewPoolReporter(sqlDB, "booking", m, 0).Report()
+
+	maxOpen := m.named("db_pool_max_open_connections")
+	require.Len(t, maxOpen, 1)
+	assert.Equal(t, float64(4), maxOpen[0].value)
+	assert.Equal(t, []string{"domain:booking"}, maxOpen[0].tags)
+	assert.Len(t, m.recorded, 8)
+}
+
+func TestPoolReporter_StartStop(t *testing.T) {
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), nil)
+	defer db.Close()
+	sqlDB, err := db.GetDB().DB()
+	require.NoError(t, err)
+
+	m := &recordingMetrics{Metrics: metrics.NewNoOpMetrics()}
+	r := database.NewPoolReporter(sqlDB, "booking", m, 10*time.Millisecond)
+	r.Start()
+
+	assert.Eventually(t, func() bool {
+		return len(m.named("db_pool_open_connections")) >= 2
+	}, time.Second, 5*time.Millisecond)
+	r.Stop()
+	r.Stop()
+}
@@ -0,0 +1,29 @@
+package database_test
+
+import (
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/migrations"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationStatus_Err(t *testing.T) {
+	assert.NoError(t, database.MigrationStatus{Version: 3, Latest: 3}.Err())
+	assert.NoError(t, database.MigrationStatus{Version: 4, Latest: 3}.Err(), "migrated by a newer release")
+	assert.NoError(t, database.MigrationStatus{}.Err(), "no migrations")
+
+	assert.ErrorContains(t, database.MigrationStatus{Version: 2, Latest: 3}.Err(), "database at migration 2, latest is 3")
+	assert.ErrorContains(t, database.MigrationStatus{Latest: 3}.Err(), "database at migration 0")
+	assert.ErrorContains(t, database.MigrationStatus{Version: 3, Dirty: true, Latest: 3}.Err(), "migration 3 is dirty")
+}
+
+func TestNewMigrator_RejectsOtherDrivers(t *testing.T) {
+	cfg := &config.DatabaseConfig{Driver: config.DatabaseDriverSQLite, Name: ":memory:"}
+
+	_, err := database.NewMigrator(cfg, migrations.FS("booking"))
+
+	assert.ErrorContains(t, err, "the migrations are Postgres SQL")
+}
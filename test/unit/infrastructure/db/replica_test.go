@@ -0,0 +1,104 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newItemDB returns an in-memory database holding the tenantItem codes.
+func newItemDB(t *testing.T, name string, codes ...string) database.Database {
+	t.Helper()
+	db := database.NewSQLiteDatabase(t.Name()+name, logger.NewNoOpLogger(), nil)
+	require.NoError(t, db.GetDB().AutoMigrate(&tenantItem{}))
+	for i, code := range codes {
+		require.NoError(t, db.GetDB().Create(&tenantItem{ID: name + string(rune('0'+i)), Code: code}).Error)
+	}
+	return db
+}
+
+func TestReader_WithoutReplicasIsTheDatabase(t *testing.T) {
+	db := newItemDB(t, "primary")
+	t.Cleanup(func() { _ = db.Close() })
+
+	assert.Same(t, db, database.NewReplicatedDatabase(db))
+	assert.Same(t, db, database.Reader(db))
+}
+
+func TestReader_ReadsFromReplicas(t *testing.T) {
+	db := database.NewReplicatedDatabase(
+		newItemDB(t, "primary", "P"),
+		newItemDB(t, "replica1", "R1"),
+		newItemDB(t, "replica2", "R2"),
+	)
+	t.Cleanup(func() { _ = db.Close() })
+	reader := database.Reader(db)
+	ctx := context.Background()
+
+	assert.Equal(t, []string{"P"}, codes(t, db, ctx), "the database itself is the primary")
+	assert.Equal(t, []string{"R1"}, codes(t, reader, ctx))
+	assert.Equal(t, []string{"R2"}, codes(t, reader, ctx), "the replicas are used in turn")
+	assert.Equal(t, []string{"R1"}, codes(t, reader, ctx))
+	assert.Equal(t, []string{"P"}, codes(t, reader, ctxkey.SetReadPrimary(ctx)), "the primary is forced")
+
+	done := errors.New("done")
+	err := db.Atomic(ctx, func(txCtx context.Context) error {
+		require.NoError(t, db.WithContext(txCtx).Create(&tenantItem{ID: "new", Code: "N"}).Error)
+		assert.Equal(t, []string{"N", "P"}, codes(t, reader, txCtx), "a transaction reads its own writes")
+		return done
+	})
+	require.ErrorIs(t, err, done)
+
+	require.NoError(t, reader.Close(), "closing the reader leaves the database open")
+	assert.Equal(t, []string{"R2"}, codes(t, reader, ctx))
+}
+
+func TestReader_RoutingDatabase(t *testing.T) {
+	primary := database.NewReplicatedDatabase(newItemDB(t, "primary", "P"), newItemDB(t, "replica", "R"))
+	acme := newItemDB(t, "acme")
+	require.NoError(t, acme.WithContext(tenantCtx("acme")).Create(&tenantItem{ID: "a", Code: "A"}).Error)
+	db := database.NewRoutingDatabase(primary, &config.DatabaseConfig{
+		Tenants: map[string]config.DatabaseEndpointConfig{"acme": {Name: "acme"}},
+	}, func(*config.DatabaseConfig) (database.Database, error) {
+		return acme, nil
+	})
+	t.Cleanup(func() { _ = db.Close() })
+	reader := database.Reader(db)
+
+	assert.Equal(t, []string{"R"}, codes(t, reader, context.Background()))
+	assert.Equal(t, []string{"P"}, codes(t, db, context.Background()))
+	assert.Equal(t, []string{"A"}, codes(t, reader, tenantCtx("acme")), "a tenant database has no replica")
+}
+
+func TestDatabaseConfig_ForReplicas(t *testing.T) {
+	cfg := config.DatabaseConfig{
+		Host: "primary", Port: 5432, User: "app", Name: "voyago", Schema: "booking",
+		Pool:     config.DatabasePoolConfig{Idle: 10, Max: 100},
+		Replicas: []config.DatabaseEndpointConfig{{Host: "replica-1"}, {Host: "replica-2", Pool: config.DatabasePoolConfig{Max: 20}}},
+		Tenants:  map[string]config.DatabaseEndpointConfig{"acme": {Host: "acme"}},
+	}
+
+	replicas := cfg.ForReplicas()
+
+	require.Len(t, replicas, 2)
+	assert.Equal(t, "replica-1", replicas[0].Host)
+	assert.Equal(t, "voyago", replicas[0].Name)
+	assert.Equal(t, "booking", replicas[0].Schema)
+	assert.Equal(t, cfg.Pool, replicas[0].Pool)
+	assert.Equal(t, config.DatabasePoolConfig{Max: 20}, replicas[1].Pool)
+	assert.Nil(t, replicas[0].Replicas)
+	assert.Nil(t, replicas[0].Tenants)
+
+	tenant, ok := cfg.ForTenant("ACME")
+	require.True(t, ok)
+	assert.Equal(t, "acme", tenant.Host)
+	assert.Nil(t, tenant.Replicas, "the replicas of the default database are not the tenant's")
+}
@@ -0,0 +1,96 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRetryingDB(t *testing.T, maxAttempts int) (database.Database, *recordingMetrics) {
+	t.Helper()
+	db := newItemDB(t, "")
+	t.Cleanup(func() { _ = db.Close() })
+	m := &recordingMetrics{Metrics: metrics.NewNoOpMetrics()}
+	cfg := config.DatabaseRetryConfig{MaxAttempts: maxAttempts, BaseBackoff: 1, MaxBackoff: 2}
+	return database.WithRetry(db, cfg, "booking", m), m
+}
+
+func TestWithRetry_RetriesTransientTransactions(t *testing.T) {
+	db, m := newRetryingDB(t, 3)
+
+	attempts := 0
+	err := db.Atomic(context.Background(), func(txCtx context.Context) error {
+		attempts++
+		require.NoError(t, db.WithContext(txCtx).Create(&tenantItem{ID: "1", Code: "A"}).Error)
+		if attempts == 1 {
+			return apperror.NewTransient(apperror.CodeDbDeadlock, "deadlock")
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, []string{"A"}, codes(t, db, context.Background()), "the failed attempt was rolled back")
+	retries := m.named("db_tx_retry")
+	require.Len(t, retries, 1)
+	assert.Equal(t, []string{"domain:booking", "code:" + apperror.CodeDbDeadlock}, retries[0].tags)
+}
+
+func TestWithRetry_DoesNotRetryOtherErrors(t *testing.T) {
+	db, m := newRetryingDB(t, 3)
+
+	for _, want := range []error{
+		apperror.NewPersistance(apperror.CodeDbConflict, "duplicate data"),
+		apperror.NewTransient(apperror.CodeServiceUnavailable, "upstream service unavailable"),
+		errors.New("domain rule"),
+	} {
+		attempts := 0
+		err := db.Atomic(context.Background(), func(context.Context) error {
+			attempts++
+			return want
+		})
+
+		assert.ErrorIs(t, err, want)
+		assert.Equal(t, 1, attempts)
+	}
+	assert.Empty(t, m.named("db_tx_retry"))
+}
+
+func TestWithRetry_NestedTransactionIsNotRetried(t *testing.T) {
+	db, _ := newRetryingDB(t, 3)
+	deadlock := apperror.NewTransient(apperror.CodeDbDeadlock, "deadlock")
+	// The context of a transaction: the outer Atomic retries it.
+	txCtx := ctxkey.SetTransaction(context.Background(), db.GetDB())
+
+	attempts := 0
+	err := db.Atomic(txCtx, func(context.Context) error {
+		attempts++
+		return deadlock
+	})
+
+	assert.ErrorIs(t, err, deadlock)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWithRetry_Disabled(t *testing.T) {
+	db := newItemDB(t, "")
+	t.Cleanup(func() { _ = db.Close() })
+
+	assert.Same(t, db, database.WithRetry(db, config.DatabaseRetryConfig{MaxAttempts: 1}, "booking", nil))
+}
+
+func TestIsTransientError(t *testing.T) {
+	assert.True(t, database.IsTransientError(apperror.NewTransient(apperror.CodeDbTimeout, "database lock timeout")))
+	assert.True(t, database.IsTransientError(errors.New("dial tcp: connection refused")))
+	assert.False(t, database.IsTransientError(apperror.NewPersistance(apperror.CodeDbConstraint, "constraint")))
+	assert.False(t, database.IsTransientError(nil))
+}
@@ -0,0 +1,119 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRoutingDB returns a routing database over in-memory SQLite databases
+// named after cfg.Name, and the names of the databases opened so far.
+func newRoutingDB(t *testing.T, cfg *config.DatabaseConfig) (database.Database, *[]string) {
+	t.Helper()
+	var opened []string
+	open := func(cfg *config.DatabaseConfig) (database.Database, error) {
+		if cfg.Name == "down" {
+			return nil, errors.New("connection refused")
+		}
+		opened = append(opened, cfg.Name)
+		db := database.NewSQLiteDatabase(t.Name()+cfg.Name, logger.NewNoOpLogger(), nil)
+		if err := db.GetDB().AutoMigrate(&tenantItem{}); err != nil {
+			return nil, err
+		}
+		return db, nil
+	}
+	fallback, err := open(cfg)
+	require.NoError(t, err)
+	opened = nil
+
+	db := database.NewRoutingDatabase(fallback, cfg, open)
+	t.Cleanup(func() { _ = db.Close() })
+	return db, &opened
+}
+
+func routingConfig() *config.DatabaseConfig {
+	return &config.DatabaseConfig{
+		Name: "main",
+		Tenants: map[string]config.DatabaseEndpointConfig{
+			"acme":    {Name: "acme"},
+			"globex":  {Name: "globex"},
+			"initech": {Name: "down"},
+		},
+	}
+}
+
+func TestRoutingDatabase_NoTenantsReturnsFallback(t *testing.T) {
+	fallback := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), nil)
+	t.Cleanup(func() { _ = fallback.Close() })
+
+	db := database.NewRoutingDatabase(fallback, &config.DatabaseConfig{}, nil)
+
+	assert.Same(t, fallback, db)
+}
+
+func TestRoutingDatabase_RoutesPerTenant(t *testing.T) {
+	db, opened := newRoutingDB(t, routingConfig())
+
+	require.NoError(t, db.WithContext(tenantCtx("acme")).Create(&tenantItem{ID: "1", Code: "A"}).Error)
+	require.NoError(t, db.WithContext(tenantCtx("ACME")).Create(&tenantItem{ID: "2", Code: "B"}).Error)
+	require.NoError(t, db.WithContext(tenantCtx("umbrella")).Create(&tenantItem{ID: "3", Code: "C"}).Error)
+	require.NoError(t, db.WithContext(context.Background()).Create(&tenantItem{ID: "4", Code: "D"}).Error)
+
+	assert.Equal(t, []string{"A"}, codes(t, db, tenantCtx("acme")))
+	assert.Empty(t, codes(t, db, tenantCtx("globex")))
+	assert.Equal(t, []string{"C", "D"}, codes(t, db, context.Background()), "the unmapped tenants use the default database")
+	assert.Equal(t, []string{"acme", "globex"}, *opened, "the tenant databases are opened once, when first used")
+}
+
+func TestRoutingDatabase_AtomicStaysOnTenantDatabase(t *testing.T) {
+	db, _ := newRoutingDB(t, routingConfig())
+	acme := tenantCtx("acme")
+
+	rollback := errors.New("rollback")
+	err := db.Atomic(acme, func(txCtx context.Context) error {
+		require.NoError(t, db.WithContext(txCtx).Create(&tenantItem{ID: "1", Code: "A"}).Error)
+		assert.Equal(t, []string{"A"}, codes(t, db, txCtx))
+		return rollback
+	})
+	require.ErrorIs(t, err, rollback)
+	assert.Empty(t, codes(t, db, acme), "rolled back on the tenant database")
+
+	require.NoError(t, db.Atomic(acme, func(txCtx context.Context) error {
+		return db.WithContext(txCtx).Create(&tenantItem{ID: "2", Code: "B"}).Error
+	}))
+	assert.Equal(t, []string{"B"}, codes(t, db, acme))
+	assert.Empty(t, codes(t, db, context.Background()))
+}
+
+func TestRoutingDatabase_MaxOpen(t *testing.T) {
+	cfg := routingConfig()
+	cfg.TenantPools.MaxOpen = 1
+	db, _ := newRoutingDB(t, cfg)
+
+	require.NoError(t, db.WithContext(tenantCtx("acme")).Find(&[]tenantItem{}).Error)
+	err := db.WithContext(tenantCtx("globex")).Find(&[]tenantItem{}).Error
+
+	require.ErrorIs(t, err, database.ErrTooManyTenantPools)
+	assert.True(t, database.ErrTooManyTenantPools.IsRetryable())
+	assert.ErrorIs(t, db.Atomic(tenantCtx("globex"), func(context.Context) error { return nil }), database.ErrTooManyTenantPools)
+}
+
+func TestRoutingDatabase_OpenFailure(t *testing.T) {
+	db, _ := newRoutingDB(t, routingConfig())
+
+	err := db.WithContext(tenantCtx("initech")).Create(&tenantItem{ID: "1", Code: "A"}).Error
+
+	var appErr *apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperror.CodeDbConnectionFailed, appErr.Code)
+	assert.True(t, appErr.IsRetryable())
+	assert.Empty(t, codes(t, db, context.Background()), "nothing is written to the default database")
+}
@@ -0,0 +1,184 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type ownedRecord struct {
+	ID string
+}
+
+func (ownedRecord) TableName() string { return "records" }
+
+type foreignRecord struct {
+	ID string
+}
+
+func (foreignRecord) TableName() string { return "merchant.merchants" }
+
+// setupDryRunDB opens a GORM instance that never touches the network,
+// allowing the callback chain to be exercised in isolation.
+func setupDryRunDB(t *testing.T, schema string) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	database.UseSchemaGuard(db, schema)
+	return db
+}
+
+func TestSchemaGuard_AllowsUnqualifiedTable(t *testing.T) {
+	db := setupDryRunDB(t, "booking")
+
+	err := db.Find(&[]ownedRecord{}).Error
+
+	assert.NoError(t, err)
+}
+
+func TestSchemaGuard_AllowsOwnedSchema(t *testing.T) {
+	db := setupDryRunDB(t, "booking")
+
+	err := db.Table("booking.bookings").Find(&[]map[string]any{}).Error
+
+	assert.NoError(t, err)
+}
+
+func TestSchemaGuard_RejectsForeignSchemaFromTableName(t *testing.T) {
+	db := setupDryRunDB(t, "booking")
+
+	err := db.Find(&[]foreignRecord{}).Error
+
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeDbSchemaViolation, appErr.Code)
+}
+
+func TestSchemaGuard_RejectsForeignSchemaFromTableExpr(t *testing.T) {
+	db := setupDryRunDB(t, "booking")
+
+	err := db.Table(`"merchant"."merchants" m`).Find(&[]map[string]any{}).Error
+
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeDbSchemaViolation, appErr.Code)
+}
+
+func requireSchemaViolation(t *testing.T, err error) {
+	t.Helper()
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr), "got %v", err)
+	assert.Equal(t, apperror.CodeDbSchemaViolation, appErr.Code)
+}
+
+func TestSchemaGuard_RejectsForeignSchemaFromJoin(t *testing.T) {
+	db := setupDryRunDB(t, "booking")
+
+	err := db.Model(&ownedRecord{}).
+		Joins(`JOIN "merchant"."merchants" m ON m.id = records.merchant_id`).
+		Find(&[]map[string]any{}).Error
+
+	requireSchemaViolation(t, err)
+}
+
+func TestSchemaGuard_RejectsForeignSchemaFromSubqueryAndFromList(t *testing.T) {
+	db := setupDryRunDB(t, "booking")
+
+	subquery := db.Where("merchant_id IN (SELECT id FROM merchant.merchants WHERE active)").Find(&[]ownedRecord{}).Error
+	fromList := db.Table("booking.bookings b, merchant.merchants m").Find(&[]map[string]any{}).Error
+
+	requireSchemaViolation(t, subquery)
+	requireSchemaViolation(t, fromList)
+}
+
+func TestSchemaGuard_RejectsForeignSchemaFromRawSQL(t *testing.T) {
+	db := setupDryRunDB(t, "booking")
+
+	exec := db.Exec("UPDATE merchant.merchants SET name = ? WHERE id = ?", "x", "1").Error
+	raw := db.Raw("SELECT * FROM `merchant`.`merchants`").Scan(&[]map[string]any{}).Error
+
+	requireSchemaViolation(t, exec)
+	requireSchemaViolation(t, raw)
+}
+
+func TestSchemaGuard_AllowsOwnedJoinsAndColumns(t *testing.T) {
+	db := setupDryRunDB(t, "booking")
+
+	err := db.Model(&ownedRecord{}).
+		Select("records.id, EXTRACT(YEAR FROM b.created_at), 'FROM merchant.merchants'").
+		Joins("JOIN booking.bookings b ON b.record_id = records.id -- FROM merchant.merchants").
+		Where("b.code IS DISTINCT FROM b.previous_code").
+		Find(&[]map[string]any{}).Error
+	exec := db.Exec("DELETE FROM booking.bookings WHERE id = ?", "1").Error
+
+	assert.NoError(t, err)
+	assert.NoError(t, exec)
+}
+
+func TestSchemaGuard_DisabledWithoutSchema(t *testing.T) {
+	db := setupDryRunDB(t, "")
+
+	err := db.Find(&[]foreignRecord{}).Error
+
+	assert.NoError(t, err)
+}
+
+func qualifiedSQL(t *testing.T, fn func(db *gorm.DB) *gorm.DB) string {
+	t.Helper()
+	db := setupDryRunDB(t, "booking")
+	database.UseSchemaQualifier(db, "booking")
+
+	tx := fn(db)
+
+	require.NoError(t, tx.Error)
+	return tx.Statement.SQL.String()
+}
+
+func TestSchemaQualifier_QualifiesTheTable(t *testing.T) {
+	sql := qualifiedSQL(t, func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ?", "1").Find(&[]ownedRecord{})
+	})
+
+	assert.Contains(t, sql, `FROM "booking"."records" WHERE id = $1`)
+}
+
+func TestSchemaQualifier_QualifiesTheWrites(t *testing.T) {
+	noTx := &gorm.Session{SkipDefaultTransaction: true}
+	create := qualifiedSQL(t, func(db *gorm.DB) *gorm.DB { return db.Session(noTx).Create(&ownedRecord{ID: "1"}) })
+	del := qualifiedSQL(t, func(db *gorm.DB) *gorm.DB { return db.Session(noTx).Delete(&ownedRecord{ID: "1"}) })
+
+	assert.Contains(t, create, `INSERT INTO "booking"."records"`)
+	assert.Contains(t, del, `DELETE FROM "booking"."records"`)
+}
+
+func TestSchemaQualifier_QualifiesTheBareTableName(t *testing.T) {
+	sql := qualifiedSQL(t, func(db *gorm.DB) *gorm.DB {
+		return db.Table("records").Find(&[]map[string]any{})
+	})
+
+	assert.Contains(t, sql, `FROM "booking"."records"`)
+}
+
+func TestSchemaQualifier_KeepsQualifiedTablesAndExpressions(t *testing.T) {
+	qualified := qualifiedSQL(t, func(db *gorm.DB) *gorm.DB {
+		return db.Table("booking.bookings").Find(&[]map[string]any{})
+	})
+	aliased := qualifiedSQL(t, func(db *gorm.DB) *gorm.DB {
+		return db.Table("records r").Find(&[]map[string]any{})
+	})
+
+	assert.Contains(t, qualified, `FROM "booking"."bookings"`)
+	assert.Contains(t, aliased, `FROM records r`)
+}
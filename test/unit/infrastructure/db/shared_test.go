@@ -0,0 +1,90 @@
+package database_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sharedConfig() config.DatabaseConfig {
+	return config.DatabaseConfig{
+		Host: "booking-db", Port: 5432, User: "booking", Name: "booking", Schema: "booking",
+		Pool:       config.DatabasePoolConfig{Idle: 5, Max: 20},
+		Replicas:   []config.DatabaseEndpointConfig{{Host: "replica-1"}},
+		Connection: "main",
+		Connections: map[string]config.DatabaseEndpointConfig{
+			"main": {Host: "main-db", User: "app", Name: "voyago", Schema: "public", Pool: config.DatabasePoolConfig{Idle: 20, Max: 150}},
+		},
+	}
+}
+
+func TestDatabaseConfig_ForConnection(t *testing.T) {
+	cfg := sharedConfig()
+
+	resolved, err := cfg.ForConnection()
+
+	require.NoError(t, err)
+	assert.Equal(t, "main-db", resolved.Host)
+	assert.Equal(t, 5432, resolved.Port, "the empty settings default to the domain ones")
+	assert.Equal(t, "voyago", resolved.Name)
+	assert.Equal(t, config.DatabasePoolConfig{Idle: 20, Max: 150}, resolved.Pool)
+	assert.Equal(t, "booking", resolved.Schema, "the schema stays the domain one")
+	assert.Empty(t, resolved.Connection)
+	require.Len(t, resolved.ForReplicas(), 1)
+	assert.Equal(t, "voyago", resolved.ForReplicas()[0].Name)
+}
+
+func TestDatabaseConfig_ForConnection_WithoutConnection(t *testing.T) {
+	cfg := sharedConfig()
+	cfg.Connection = ""
+
+	resolved, err := cfg.ForConnection()
+
+	require.NoError(t, err)
+	assert.Equal(t, "booking-db", resolved.Host)
+}
+
+func TestDatabaseConfig_ForConnection_Errors(t *testing.T) {
+	cases := map[string]struct {
+		edit func(*config.DatabaseConfig)
+		err  string
+	}{
+		"unknown connection": {func(c *config.DatabaseConfig) { c.Connection = "other" }, `unknown database connection "other"`},
+		"missing schema":     {func(c *config.DatabaseConfig) { c.Schema = "" }, `database.schema is required on the shared connection "main"`},
+		"sqlite":             {func(c *config.DatabaseConfig) { c.Driver = config.DatabaseDriverSQLite }, `sqlite databases cannot share the connection "main"`},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := sharedConfig()
+			tc.edit(&cfg)
+
+			_, err := cfg.ForConnection()
+
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestPools_NewDatabase_WithoutConnection(t *testing.T) {
+	cfg := &config.DatabaseConfig{Driver: config.DatabaseDriverSQLite, Name: filepath.Join(t.TempDir(), "app.db")}
+
+	db := database.NewPools().NewDatabase(cfg, logger.NewNoOpLogger(), nil)
+	defer db.Close()
+
+	assert.Equal(t, "sqlite", db.GetDB().Dialector.Name())
+}
+
+func TestPools_NewDatabase_PanicsOnUnknownConnection(t *testing.T) {
+	cfg := sharedConfig()
+	cfg.Connection = "other"
+
+	assert.PanicsWithError(t, `unknown database connection "other"`, func() {
+		database.NewPools().NewDatabase(&cfg, logger.NewNoOpLogger(), nil)
+	})
+}
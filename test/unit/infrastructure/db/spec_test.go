@@ -0,0 +1,75 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/spec"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var itemFields = database.SpecFields{"id": "id", "code": "code", "tenant": "tenant_id"}
+
+// list returns the codes of the items matching s.
+func list(t *testing.T, db database.Database, s spec.Spec) ([]string, error) {
+	t.Helper()
+	var items []tenantItem
+	err := database.ApplySpec(db.WithContext(context.Background()).Model(&tenantItem{}), s, itemFields).Find(&items).Error
+	var codes []string
+	for _, it := range items {
+		codes = append(codes, it.Code)
+	}
+	return codes, err
+}
+
+func TestApplySpec(t *testing.T) {
+	db := newItemDB(t, "", "A", "B", "C", "D", "E")
+	t.Cleanup(func() { _ = db.Close() })
+
+	tests := []struct {
+		name string
+		spec spec.Spec
+		want []string
+	}{
+		{"where", spec.New(spec.Where("code", spec.Gte, "C"), spec.OrderBy("id", spec.Asc)), []string{"C", "D", "E"}},
+		{"where combined", spec.New(spec.Where("code", spec.Gt, "A"), spec.Where("code", spec.Ne, "C"), spec.OrderBy("id", spec.Asc)), []string{"B", "D", "E"}},
+		{"in", spec.New(spec.In("code", "B", "D", "Z"), spec.OrderBy("id", spec.Asc)), []string{"B", "D"}},
+		{"empty in", spec.New(spec.In[string]("code")), nil},
+		{"between", spec.New(spec.Between("code", "B", "D"), spec.OrderBy("id", spec.Asc)), []string{"B", "C", "D"}},
+		{"is null", spec.New(spec.Where("tenant", spec.Eq, nil), spec.Where("code", spec.Eq, "A")), []string{"A"}},
+		{"order and page", spec.New(spec.OrderBy("code", spec.Desc), spec.Limit(2), spec.Offset(1)), []string{"D", "C"}},
+		{"value bound as a parameter", spec.New(spec.Where("code", spec.Eq, "A' OR '1'='1")), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := list(t, db, tt.spec)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplySpec_InvalidSpec(t *testing.T) {
+	db := newItemDB(t, "", "A")
+	t.Cleanup(func() { _ = db.Close() })
+
+	for name, s := range map[string]spec.Spec{
+		"unknown filter field": spec.New(spec.Where("code = code OR 1=1 --", spec.Eq, "A")),
+		"unknown order field":  spec.New(spec.OrderBy("(SELECT 1)", spec.Asc)),
+		"unknown operator":     spec.New(spec.Where("code", spec.Operator("LIKE"), "A%")),
+		"missing bound":        {Filters: []spec.Filter{{Field: "code", Op: spec.OpBetween, Values: []any{"A"}}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := list(t, db, s)
+
+			var appErr *apperror.AppError
+			require.ErrorAs(t, database.MapDBError(err), &appErr)
+			assert.Equal(t, apperror.CodeInvalidRequest, appErr.Code)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memItem struct {
+	ID   string `gorm:"column:id;primaryKey"`
+	Code string `gorm:"column:code;not null;unique"`
+}
+
+func TestSQLiteDatabase_IsolatedPerName(t *testing.T) {
+	a := database.NewSQLiteDatabase(t.Name()+"_a", logger.NewNoOpLogger(), nil)
+	b := database.NewSQLiteDatabase(t.Name()+"_b", logger.NewNoOpLogger(), nil)
+	defer a.Close()
+	defer b.Close()
+
+	require.NoError(t, a.GetDB().AutoMigrate(&memItem{}))
+
+	assert.True(t, a.GetDB().Migrator().HasTable(&memItem{}))
+	assert.False(t, b.GetDB().Migrator().HasTable(&memItem{}))
+}
+
+func TestMapDBError_SQLiteUniqueViolation(t *testing.T) {
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), nil)
+	defer db.Close()
+	require.NoError(t, db.GetDB().AutoMigrate(&memItem{}))
+
+	require.NoError(t, db.GetDB().Create(&memItem{ID: "1", Code: "A"}).Error)
+	err := database.MapDBError(db.GetDB().Create(&memItem{ID: "2", Code: "A"}).Error)
+
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr))
+	assert.Equal(t, apperror.CodeDbConflict, appErr.Code)
+}
@@ -0,0 +1,106 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantItem struct {
+	ID       string  `gorm:"column:id;primaryKey"`
+	Code     string  `gorm:"column:code;not null"`
+	TenantID *string `gorm:"column:tenant_id;type:varchar(128);index"`
+}
+
+func newTenantDB(t *testing.T) database.Database {
+	t.Helper()
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), nil)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.GetDB().AutoMigrate(&tenantItem{}, &memItem{}))
+	return db
+}
+
+func tenantCtx(tenant string) context.Context {
+	return ctxkey.SetTenantID(context.Background(), tenant)
+}
+
+func codes(t *testing.T, db database.Database, ctx context.Context) []string {
+	t.Helper()
+	var items []tenantItem
+	require.NoError(t, db.WithContext(ctx).Order("id").Find(&items).Error)
+	var codes []string
+	for _, it := range items {
+		codes = append(codes, it.Code)
+	}
+	return codes
+}
+
+func TestTenantScope_CreateAndQuery(t *testing.T) {
+	db := newTenantDB(t)
+	acme, globex := tenantCtx("acme"), tenantCtx("globex")
+
+	a := tenantItem{ID: "1", Code: "A"}
+	require.NoError(t, db.WithContext(acme).Create(&a).Error)
+	require.NotNil(t, a.TenantID)
+	assert.Equal(t, "acme", *a.TenantID, "stamped with the tenant of the context")
+	require.NoError(t, db.WithContext(globex).Create([]*tenantItem{{ID: "2", Code: "B"}, {ID: "3", Code: "C"}}).Error)
+	require.NoError(t, db.WithContext(context.Background()).Create(&tenantItem{ID: "4", Code: "D"}).Error)
+
+	assert.Equal(t, []string{"A"}, codes(t, db, acme))
+	assert.Equal(t, []string{"B", "C"}, codes(t, db, globex))
+	assert.Equal(t, []string{"A", "B", "C", "D"}, codes(t, db, context.Background()), "a context without tenant is not scoped")
+
+	var count int64
+	require.NoError(t, db.WithContext(acme).Model(&tenantItem{}).Where("code = ?", "B").Or("code = ?", "C").Count(&count).Error)
+	assert.Zero(t, count, "an OR condition does not escape the tenant")
+
+	var item tenantItem
+	err := db.WithContext(acme).Where("id = ?", "2").First(&item).Error
+	assert.Error(t, err, "the row of another tenant is not found")
+}
+
+func TestTenantScope_UpdateAndDelete(t *testing.T) {
+	db := newTenantDB(t)
+	acme, globex := tenantCtx("acme"), tenantCtx("globex")
+	require.NoError(t, db.WithContext(acme).Create(&tenantItem{ID: "1", Code: "A"}).Error)
+
+	res := db.WithContext(globex).Model(&tenantItem{}).Where("id = ?", "1").Update("code", "X")
+	require.NoError(t, res.Error)
+	assert.Zero(t, res.RowsAffected)
+
+	res = db.WithContext(globex).Where("id = ?", "1").Delete(&tenantItem{})
+	require.NoError(t, res.Error)
+	assert.Zero(t, res.RowsAffected)
+
+	// Saved without its tenant (e.g. loaded without the column): the row keeps it.
+	require.NoError(t, db.WithContext(acme).Save(&tenantItem{ID: "1", Code: "B"}).Error)
+	assert.Equal(t, []string{"B"}, codes(t, db, acme))
+
+	res = db.WithContext(acme).Delete(&tenantItem{ID: "1"})
+	require.NoError(t, res.Error)
+	assert.EqualValues(t, 1, res.RowsAffected)
+}
+
+func TestTenantScope_RejectsAnotherTenant(t *testing.T) {
+	db := newTenantDB(t)
+	globex := "globex"
+
+	err := db.WithContext(tenantCtx("acme")).Create(&tenantItem{ID: "1", Code: "A", TenantID: &globex}).Error
+	assert.ErrorIs(t, err, database.ErrTenantMismatch)
+}
+
+func TestTenantScope_IgnoresModelsWithoutTenant(t *testing.T) {
+	db := newTenantDB(t)
+
+	require.NoError(t, db.WithContext(context.Background()).Create(&memItem{ID: "1", Code: "A"}).Error)
+
+	var items []memItem
+	require.NoError(t, db.WithContext(tenantCtx("acme")).Find(&items).Error)
+	assert.Len(t, items, 1)
+}
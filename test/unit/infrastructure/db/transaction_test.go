@@ -0,0 +1,212 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/pkg/apperror"
+	baserepo "voyago/core-api/internal/pkg/repository"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomic_NestedCallRollsBackItsSavepoint(t *testing.T) {
+	db := newItemDB(t, "", "A")
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.Background()
+	failed := errors.New("no seat left")
+
+	err := db.Atomic(ctx, func(txCtx context.Context) error {
+		require.NoError(t, db.WithContext(txCtx).Create(&tenantItem{ID: "1", Code: "B"}).Error)
+
+		err := db.Atomic(txCtx, func(spCtx context.Context) error {
+			require.NoError(t, db.WithContext(spCtx).Create(&tenantItem{ID: "2", Code: "C"}).Error)
+			return failed
+		})
+		assert.ErrorIs(t, err, failed)
+
+		return db.Atomic(txCtx, func(spCtx context.Context) error {
+			return db.WithContext(spCtx).Create(&tenantItem{ID: "3", Code: "D"}).Error
+		})
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B", "D"}, codes(t, db, ctx), "the outer transaction survived its failed savepoint")
+}
+
+func TestAtomic_OuterRollbackDiscardsSavepoints(t *testing.T) {
+	db := newItemDB(t, "", "A")
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.Background()
+	failed := errors.New("payment declined")
+
+	err := db.Atomic(ctx, func(txCtx context.Context) error {
+		require.NoError(t, db.Atomic(txCtx, func(spCtx context.Context) error {
+			return db.WithContext(spCtx).Create(&tenantItem{ID: "1", Code: "B"}).Error
+		}))
+		return failed
+	})
+
+	assert.ErrorIs(t, err, failed)
+	assert.Equal(t, []string{"A"}, codes(t, db, ctx))
+}
+
+func TestAtomicWithOptions_ReadOnly(t *testing.T) {
+	db := newItemDB(t, "", "A")
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.Background()
+
+	var read []string
+	err := db.AtomicWithOptions(ctx, baserepo.TxOptions{ReadOnly: true}, func(txCtx context.Context) error {
+		read = codes(t, db, txCtx)
+		return db.WithContext(txCtx).Create(&tenantItem{ID: "1", Code: "B"}).Error
+	})
+
+	assert.Error(t, err, "a read-only transaction rejects the writes")
+	assert.Equal(t, []string{"A"}, read)
+	assert.Equal(t, []string{"A"}, codes(t, db, ctx))
+	assert.NoError(t, db.WithContext(ctx).Create(&tenantItem{ID: "2", Code: "C"}).Error, "the connection accepts the writes again")
+}
+
+func TestAtomicWithOptions_Isolation(t *testing.T) {
+	db := newItemDB(t, "", "A")
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.Background()
+
+	err := db.AtomicWithOptions(ctx, baserepo.TxOptions{Isolation: sql.LevelSerializable}, func(txCtx context.Context) error {
+		return db.WithContext(txCtx).Create(&tenantItem{ID: "1", Code: "B"}).Error
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B"}, codes(t, db, ctx))
+}
+
+func TestAtomicWithOptions_ThroughTheWrappers(t *testing.T) {
+	db := database.NewReplicatedDatabase(newItemDB(t, "primary"), newItemDB(t, "replica"))
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.Background()
+
+	err := database.Reader(db).AtomicWithOptions(ctx, baserepo.TxOptions{Isolation: sql.LevelSerializable}, func(txCtx context.Context) error {
+		return db.WithContext(txCtx).Create(&tenantItem{ID: "1", Code: "B"}).Error
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"B"}, codes(t, db, ctx), "written to the primary")
+}
+
+func TestRegisterAfterCommit_RunsAfterCommit(t *testing.T) {
+	db := newItemDB(t, "")
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.Background()
+
+	var ran []string
+	err := db.Atomic(ctx, func(txCtx context.Context) error {
+		baserepo.RegisterAfterCommit(txCtx, func(hookCtx context.Context) {
+			assert.Nil(t, ctxkey.GetTransaction(hookCtx), "the hook runs outside the transaction")
+			ran = append(ran, "first")
+		})
+		baserepo.RegisterAfterCommit(txCtx, func(context.Context) { ran = append(ran, "second") })
+		assert.Empty(t, ran, "not before the commit")
+		return db.WithContext(txCtx).Create(&tenantItem{ID: "1", Code: "A"}).Error
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, ran)
+}
+
+func TestRegisterAfterCommit_DiscardedOnRollback(t *testing.T) {
+	db := newItemDB(t, "")
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.Background()
+
+	var ran []string
+	err := db.Atomic(ctx, func(txCtx context.Context) error {
+		baserepo.RegisterAfterCommit(txCtx, func(context.Context) { ran = append(ran, "outer") })
+		return errors.New("payment declined")
+	})
+	assert.Error(t, err)
+
+	err = db.Atomic(ctx, func(txCtx context.Context) error {
+		_ = db.Atomic(txCtx, func(spCtx context.Context) error {
+			baserepo.RegisterAfterCommit(spCtx, func(context.Context) { ran = append(ran, "rolled back savepoint") })
+			return errors.New("no seat left")
+		})
+		require.NoError(t, db.Atomic(txCtx, func(spCtx context.Context) error {
+			baserepo.RegisterAfterCommit(spCtx, func(context.Context) { ran = append(ran, "savepoint") })
+			return nil
+		}))
+		assert.Empty(t, ran, "a savepoint waits for the outer transaction")
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"savepoint"}, ran)
+}
+
+func TestRegisterAfterCommit_OnlyTheCommittedAttemptOfARetry(t *testing.T) {
+	db, _ := newRetryingDB(t, 3)
+
+	attempts, ran := 0, 0
+	err := db.Atomic(context.Background(), func(txCtx context.Context) error {
+		attempts++
+		baserepo.RegisterAfterCommit(txCtx, func(context.Context) { ran++ })
+		if attempts == 1 {
+			return apperror.NewTransient(apperror.CodeDbDeadlock, "deadlock")
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 1, ran)
+}
+
+func TestRegisterAfterCommit_OutsideATransactionRunsNow(t *testing.T) {
+	ran := false
+	baserepo.RegisterAfterCommit(context.Background(), func(context.Context) { ran = true })
+
+	assert.True(t, ran)
+}
+
+func TestAtomicWithOptions_TimeoutsIgnoredOutsidePostgres(t *testing.T) {
+	db := newItemDB(t, "")
+	t.Cleanup(func() { _ = db.Close() })
+	ctx := context.Background()
+
+	err := db.AtomicWithOptions(ctx, baserepo.TxOptions{StatementTimeout: time.Second, LockTimeout: time.Second}, func(txCtx context.Context) error {
+		return db.WithContext(txCtx).Create(&tenantItem{ID: "1", Code: "A"}).Error
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A"}, codes(t, db, ctx))
+}
+
+func TestMapDBError_PostgresTransactionErrors(t *testing.T) {
+	tests := []struct {
+		pgCode    string
+		code      string
+		transient bool
+	}{
+		{"57014", apperror.CodeDbTimeout, false}, // statement timeout: as slow again
+		{"55P03", apperror.CodeDbTimeout, true},  // lock timeout
+		{"40001", apperror.CodeDbDeadlock, true}, // serialization failure
+		{"40P01", apperror.CodeDbDeadlock, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pgCode, func(t *testing.T) {
+			raw := &pgconn.PgError{Code: tt.pgCode, Message: "boom"}
+
+			var appErr *apperror.AppError
+			require.ErrorAs(t, database.MapDBError(raw), &appErr)
+			assert.Equal(t, tt.code, appErr.Code)
+			assert.Equal(t, tt.transient, database.IsTransientError(raw))
+		})
+	}
+}
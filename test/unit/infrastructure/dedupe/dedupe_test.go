@@ -0,0 +1,180 @@
+package dedupe_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/dedupe"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// note is a side effect of a handler, written in its transaction.
+type note struct {
+	ID   uint `gorm:"primaryKey"`
+	Text string
+}
+
+func newDatabase(t *testing.T) database.Database {
+	t.Helper()
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), nil)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.GetDB().AutoMigrate(&dedupe.Record{}, &note{}))
+	return db
+}
+
+func newRedisStore(t *testing.T) (*dedupe.RedisStore, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return dedupe.NewRedisStore(client, "test:processed:", time.Hour, time.Minute), mr
+}
+
+func countNotes(t *testing.T, db database.Database) int64 {
+	t.Helper()
+	var n int64
+	require.NoError(t, db.GetDB().Model(&note{}).Count(&n).Error)
+	return n
+}
+
+func TestHandler_SkipsDuplicateEvents(t *testing.T) {
+	db := newDatabase(t)
+	calls := 0
+	h := dedupe.Handler(dedupe.NewDatabaseStore(db), "booking.apply_payment_status", func(ctx context.Context, evt eventbus.Event) error {
+		calls++
+		return db.WithContext(ctx).Create(&note{Text: evt.ID}).Error
+	})
+	evt := eventbus.NewEvent("booking.payment_status_changed", "payment", nil)
+	ctx := context.Background()
+
+	require.NoError(t, h(ctx, evt))
+	require.NoError(t, h(ctx, evt), "a redelivery is skipped")
+	require.NoError(t, h(ctx, eventbus.NewEvent("booking.payment_status_changed", "payment", nil)))
+
+	assert.Equal(t, 2, calls)
+	assert.EqualValues(t, 2, countNotes(t, db))
+}
+
+func TestHandler_ScopesEventsPerConsumer(t *testing.T) {
+	store := dedupe.NewDatabaseStore(newDatabase(t))
+	calls := 0
+	h := func(context.Context, eventbus.Event) error {
+		calls++
+		return nil
+	}
+	evt := eventbus.NewEvent("booking.created", "booking", nil)
+
+	require.NoError(t, dedupe.Handler(store, "notify", h)(context.Background(), evt))
+	require.NoError(t, dedupe.Handler(store, "index", h)(context.Background(), evt))
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestDatabaseStore_RollsBackTheRecordWithTheHandler(t *testing.T) {
+	db := newDatabase(t)
+	store := dedupe.NewDatabaseStore(db)
+	ctx := context.Background()
+	gatewayDown := errors.New("gateway down")
+
+	ran, err := store.Once(ctx, "consumer", "e-1", func(ctx context.Context) error {
+		require.NoError(t, db.WithContext(ctx).Create(&note{Text: "first"}).Error)
+		return gatewayDown
+	})
+	assert.True(t, ran)
+	assert.ErrorIs(t, err, gatewayDown)
+	assert.Zero(t, countNotes(t, db), "the side effects rolled back")
+
+	ran, err = store.Once(ctx, "consumer", "e-1", func(ctx context.Context) error {
+		return db.WithContext(ctx).Create(&note{Text: "retry"}).Error
+	})
+	require.NoError(t, err)
+	assert.True(t, ran, "the failed event is handled again")
+	assert.EqualValues(t, 1, countNotes(t, db))
+}
+
+func TestDatabaseStore_Purge(t *testing.T) {
+	db := newDatabase(t)
+	store := dedupe.NewDatabaseStore(db)
+	old := time.Now().Add(-48 * time.Hour).UnixMilli()
+	require.NoError(t, db.GetDB().Create(&dedupe.Record{Consumer: "consumer", EventID: "old", ProcessedAt: old}).Error)
+	_, err := store.Once(context.Background(), "consumer", "recent", func(context.Context) error { return nil })
+	require.NoError(t, err)
+
+	n, err := store.Purge(context.Background(), 24*time.Hour)
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+	ran, err := store.Once(context.Background(), "consumer", "recent", func(context.Context) error { return nil })
+	require.NoError(t, err)
+	assert.False(t, ran, "the recent record is kept")
+}
+
+func TestRedisStore_SkipsProcessedEvents(t *testing.T) {
+	store, mr := newRedisStore(t)
+	ctx := context.Background()
+	calls := 0
+	fn := func(context.Context) error {
+		calls++
+		return nil
+	}
+
+	ran, err := store.Once(ctx, "consumer", "e-1", fn)
+	require.NoError(t, err)
+	assert.True(t, ran)
+	ran, err = store.Once(ctx, "consumer", "e-1", fn)
+	require.NoError(t, err)
+	assert.False(t, ran)
+
+	assert.Equal(t, 1, calls)
+	v, _ := mr.Get("test:processed:consumer:e-1")
+	assert.Equal(t, "done", v)
+	assert.Equal(t, time.Hour, mr.TTL("test:processed:consumer:e-1"))
+}
+
+func TestRedisStore_ReleasesFailedEvents(t *testing.T) {
+	store, mr := newRedisStore(t)
+	ctx := context.Background()
+	gatewayDown := errors.New("gateway down")
+
+	_, err := store.Once(ctx, "consumer", "e-1", func(context.Context) error { return gatewayDown })
+	assert.ErrorIs(t, err, gatewayDown)
+	assert.False(t, mr.Exists("test:processed:consumer:e-1"))
+
+	ran, err := store.Once(ctx, "consumer", "e-1", func(context.Context) error { return nil })
+	require.NoError(t, err)
+	assert.True(t, ran)
+}
+
+func TestRedisStore_ConcurrentDeliveryIsInProgress(t *testing.T) {
+	store, mr := newRedisStore(t)
+	ctx := context.Background()
+
+	_, err := store.Once(ctx, "consumer", "e-1", func(ctx context.Context) error {
+		_, err := store.Once(ctx, "consumer", "e-1", func(context.Context) error {
+			t.Error("handled twice")
+			return nil
+		})
+		assert.ErrorIs(t, err, dedupe.ErrInProgress)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// A handler that crashed leaves its claim until the lease expires.
+	require.NoError(t, mr.Set("test:processed:consumer:e-2", "processing"))
+	mr.SetTTL("test:processed:consumer:e-2", time.Minute)
+	_, err = store.Once(ctx, "consumer", "e-2", func(context.Context) error { return nil })
+	assert.ErrorIs(t, err, dedupe.ErrInProgress)
+	mr.FastForward(time.Minute)
+	ran, err := store.Once(ctx, "consumer", "e-2", func(context.Context) error { return nil })
+	require.NoError(t, err)
+	assert.True(t, ran)
+}
@@ -0,0 +1,206 @@
+package errorreport_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/errorreport"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sentryServer records the envelopes posted to its envelope endpoint.
+type sentryServer struct {
+	*httptest.Server
+	mu        sync.Mutex
+	paths     []string
+	auth      []string
+	envelopes [][]byte
+}
+
+func newSentryServer(t *testing.T) *sentryServer {
+	t.Helper()
+	s := &sentryServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.paths = append(s.paths, r.URL.Path)
+		s.auth = append(s.auth, r.Header.Get("X-Sentry-Auth"))
+		s.envelopes = append(s.envelopes, body)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// dsn returns the DSN of project 42 on s.
+func (s *sentryServer) dsn() string {
+	return strings.Replace(s.URL, "http://", "http://public-key@", 1) + "/sentry/42"
+}
+
+// event decodes the event of the envelope n, checking the envelope layout.
+func (s *sentryServer) event(t *testing.T, n int) map[string]any {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	require.Greater(t, len(s.envelopes), n)
+
+	lines := bufio.NewScanner(bytes.NewReader(s.envelopes[n]))
+	var header, item, event map[string]any
+	for _, into := range []*map[string]any{&header, &item, &event} {
+		require.True(t, lines.Scan())
+		require.NoError(t, json.Unmarshal(lines.Bytes(), into))
+	}
+	assert.Equal(t, "event", item["type"])
+	assert.Equal(t, header["event_id"], event["event_id"])
+	return event
+}
+
+func newConfig(dsn string) *config.Config {
+	cfg := &config.Config{App: config.AppConfig{Version: "1.4.2", Env: "staging"}}
+	cfg.ErrorReporting.Enabled = true
+	cfg.ErrorReporting.Sentry.DSN = dsn
+	return cfg
+}
+
+func TestSentry_ReportsTheEventOfTheFailure(t *testing.T) {
+	srv := newSentryServer(t)
+	r, err := errorreport.NewReporter(newConfig(srv.dsn()), logger.NewNoOpLogger())
+	require.NoError(t, err)
+
+	cause := apperror.Wrap(errors.New("pq: relation \"bookings\" does not exist"), apperror.CodeInternalError, "Internal server error")
+	stack := "goroutine 7 [running]:\n" +
+		"runtime/debug.Stack()\n\t/usr/local/go/src/runtime/debug/stack.go:26 +0x5e\n" +
+		"voyago/core-api/internal/modules/booking/usecase.(*createBookingUseCase).Execute(0xc000a1e000, {0x1, 0x2})\n" +
+		"\t/app/internal/modules/booking/usecase/create_booking.go:120 +0x1d\n" +
+		"created by net/http.(*Server).Serve in goroutine 1\n\t/usr/local/go/src/net/http/server.go:3285 +0x4b4\n"
+	r.Report(context.Background(), errorreport.Event{
+		Err:       cause,
+		Panic:     true,
+		Stack:     stack,
+		TraceID:   "trace-123",
+		Transport: "http",
+		UserID:    "user-7",
+		TenantID:  "acme",
+		Request: errorreport.Request{
+			Method:  "POST",
+			URL:     "/api/v1/bookings",
+			Route:   "/api/v1/bookings",
+			Headers: map[string]string{"Authorization": "******** [REDACTED]"},
+			Body:    map[string]any{"code": "BK-1"},
+		},
+	})
+	require.NoError(t, r.Close())
+
+	assert.Equal(t, []string{"/sentry/api/42/envelope/"}, srv.paths)
+	assert.Contains(t, srv.auth[0], "sentry_key=public-key")
+
+	event := srv.event(t, 0)
+	assert.Equal(t, "1.4.2", event["release"], "app.version by default")
+	assert.Equal(t, "staging", event["environment"])
+	assert.Equal(t, "POST /api/v1/bookings", event["transaction"])
+	assert.Equal(t, map[string]any{"id": "user-7"}, event["user"])
+	assert.Equal(t, map[string]any{
+		"transport":  "http",
+		"trace_id":   "trace-123",
+		"tenant_id":  "acme",
+		"error.code": apperror.CodeInternalError,
+	}, event["tags"])
+	assert.Equal(t, map[string]any{
+		"method":  "POST",
+		"url":     "/api/v1/bookings",
+		"headers": map[string]any{"Authorization": "******** [REDACTED]"},
+		"data":    map[string]any{"code": "BK-1"},
+	}, event["request"])
+
+	exception := event["exception"].(map[string]any)["values"].([]any)[0].(map[string]any)
+	assert.Equal(t, apperror.CodeInternalError, exception["type"])
+	assert.Equal(t, "INTERNAL_ERROR: Internal server error: pq: relation \"bookings\" does not exist", exception["value"])
+	assert.Equal(t, map[string]any{"type": "panic", "handled": false}, exception["mechanism"])
+
+	frames := exception["stacktrace"].(map[string]any)["frames"].([]any)
+	require.Len(t, frames, 3)
+	assert.Equal(t, map[string]any{
+		"function": "(*Server).Serve",
+		"module":   "net/http",
+		"abs_path": "/usr/local/go/src/net/http/server.go",
+		"filename": "/usr/local/go/src/net/http/server.go",
+		"lineno":   float64(3285),
+		"in_app":   false,
+	}, frames[0], "the outermost call first")
+	assert.Equal(t, "(*createBookingUseCase).Execute", frames[1].(map[string]any)["function"])
+	assert.Equal(t, "voyago/core-api/internal/modules/booking/usecase", frames[1].(map[string]any)["module"])
+	assert.Equal(t, true, frames[1].(map[string]any)["in_app"])
+	assert.Equal(t, "Stack", frames[2].(map[string]any)["function"])
+}
+
+func TestSentry_PlainErrors(t *testing.T) {
+	srv := newSentryServer(t)
+	cfg := newConfig(srv.dsn())
+	cfg.ErrorReporting.Release = "2026.10.1"
+	r, err := errorreport.NewReporter(cfg, logger.NewNoOpLogger())
+	require.NoError(t, err)
+
+	r.Report(context.Background(), errorreport.Event{Err: fmt.Errorf("loading: %w", errors.New("boom")), Transport: "grpc"})
+	require.NoError(t, r.Close())
+
+	event := srv.event(t, 0)
+	assert.Equal(t, "2026.10.1", event["release"])
+	assert.NotContains(t, event, "user")
+	exception := event["exception"].(map[string]any)["values"].([]any)[0].(map[string]any)
+	assert.Equal(t, "*errors.errorString", exception["type"])
+	assert.Equal(t, "loading: boom", exception["value"])
+	assert.Equal(t, map[string]any{"type": "generic", "handled": true}, exception["mechanism"])
+	assert.NotContains(t, exception, "stacktrace")
+}
+
+func TestNewReporter(t *testing.T) {
+	r, err := errorreport.NewReporter(&config.Config{}, logger.NewNoOpLogger())
+	require.NoError(t, err)
+	assert.NoError(t, r.Close(), "disabled: a no-op reporter")
+
+	for _, dsn := range []string{"", "https://sentry.example.com/42", "https://key@sentry.example.com", "://key@host/1"} {
+		_, err := errorreport.NewReporter(newConfig(dsn), logger.NewNoOpLogger())
+		assert.Error(t, err, dsn)
+	}
+
+	cfg := newConfig("https://key@sentry.example.com/42")
+	cfg.ErrorReporting.Driver = "rollbar"
+	_, err = errorreport.NewReporter(cfg, logger.NewNoOpLogger())
+	assert.EqualError(t, err, `unknown driver "rollbar"`)
+}
+
+func TestReportable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"no error", nil, false},
+		{"internal", apperror.ErrCodeInternalError, true},
+		{"wrapped internal", fmt.Errorf("saving: %w", apperror.NewInternal("SEAT_MAP_CORRUPTED", "Seat map corrupted")), true},
+		{"unexpected", errors.New("nil map"), true},
+		{"expected", apperror.ErrCodeNotFound, false},
+		{"transient", apperror.NewTransient(apperror.CodeDbDeadlock, "deadlock"), false},
+		{"canceled by the client", fmt.Errorf("query: %w", context.Canceled), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, errorreport.Reportable(tt.err))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package featureflag_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/featureflag"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func userCtx(user, tenant string) context.Context {
+	ctx := ctxkey.SetUserID(context.Background(), user)
+	return ctxkey.SetTenantID(ctx, tenant)
+}
+
+func TestStatic_Targeting(t *testing.T) {
+	flags := featureflag.New(featureflag.NewStatic(map[string]config.FeatureFlagConfig{
+		"everyone":   {Enabled: true},
+		"off":        {Enabled: false},
+		"beta_users": {Enabled: true, Users: []string{"u-1"}},
+		"acme_only":  {Enabled: true, Tenants: []string{"acme"}},
+	}), nil)
+
+	assert.True(t, flags.Enabled(userCtx("u-2", "globex"), "everyone"))
+	assert.False(t, flags.Enabled(userCtx("u-1", "acme"), "off"))
+	assert.True(t, flags.Enabled(userCtx("u-1", "globex"), "beta_users"))
+	assert.False(t, flags.Enabled(userCtx("u-2", "globex"), "beta_users"))
+	assert.True(t, flags.Enabled(userCtx("u-2", "acme"), "acme_only"))
+	assert.False(t, flags.Enabled(context.Background(), "acme_only"))
+	assert.False(t, flags.Enabled(context.Background(), "unknown"), "an unknown flag is disabled")
+}
+
+func TestStatic_Percentage(t *testing.T) {
+	static := featureflag.NewStatic(map[string]config.FeatureFlagConfig{
+		"new_pricing_engine": {Enabled: true, Percentage: 20},
+	})
+
+	on := 0
+	for i := range 1000 {
+		enabled, err := static.Enabled(context.Background(), "new_pricing_engine", featureflag.Target{UserID: fmt.Sprintf("user-%d", i)})
+		require.NoError(t, err)
+		if enabled {
+			on++
+		}
+	}
+	assert.InDelta(t, 200, on, 50, "about 20%% of the users")
+
+	first, _ := static.Enabled(context.Background(), "new_pricing_engine", featureflag.Target{UserID: "user-7"})
+	again, _ := static.Enabled(context.Background(), "new_pricing_engine", featureflag.Target{UserID: "user-7"})
+	assert.Equal(t, first, again, "a user keeps its bucket")
+
+	anonymous, _ := static.Enabled(context.Background(), "new_pricing_engine", featureflag.Target{})
+	assert.False(t, anonymous, "the anonymous requests are out of a partial rollout")
+}
+
+func TestStatic_Reload(t *testing.T) {
+	static := featureflag.NewStatic(nil)
+	_, err := static.Enabled(context.Background(), "new_pricing_engine", featureflag.Target{})
+	assert.ErrorIs(t, err, featureflag.ErrUnknownFlag)
+
+	static.Reload(map[string]config.FeatureFlagConfig{"new_pricing_engine": {Enabled: true}})
+
+	on, err := static.Enabled(context.Background(), "new_pricing_engine", featureflag.Target{})
+	require.NoError(t, err)
+	assert.True(t, on)
+}
+
+type failingProvider struct{}
+
+func (failingProvider) Enabled(context.Context, string, featureflag.Target) (bool, error) {
+	return true, errors.New("unreachable")
+}
+
+func TestFlags_FailureDisablesTheFlag(t *testing.T) {
+	flags := featureflag.New(failingProvider{}, nil)
+
+	assert.False(t, flags.Enabled(context.Background(), "new_pricing_engine"))
+}
+
+func TestFlags_Nil(t *testing.T) {
+	var flags *featureflag.Flags
+
+	assert.False(t, flags.Enabled(context.Background(), "new_pricing_engine"))
+	assert.Equal(t, "legacy", featureflag.Choose(context.Background(), flags, "new_pricing_engine", "new", "legacy"))
+}
+
+func TestFlags_Choose(t *testing.T) {
+	flags := featureflag.New(featureflag.NewStatic(map[string]config.FeatureFlagConfig{
+		"new_pricing_engine": {Enabled: true, Tenants: []string{"acme"}},
+	}), nil)
+
+	assert.Equal(t, "new", featureflag.Choose(userCtx("u-1", "acme"), flags, "new_pricing_engine", "new", "legacy"))
+	assert.Equal(t, "legacy", featureflag.Choose(userCtx("u-1", "globex"), flags, "new_pricing_engine", "new", "legacy"))
+}
+
+func TestPin_KeepsTheFirstValue(t *testing.T) {
+	static := featureflag.NewStatic(map[string]config.FeatureFlagConfig{"new_pricing_engine": {Enabled: true}})
+	flags := featureflag.New(static, nil)
+	ctx := featureflag.Pin(context.Background())
+
+	assert.True(t, flags.Enabled(ctx, "new_pricing_engine"))
+	static.Reload(map[string]config.FeatureFlagConfig{"new_pricing_engine": {Enabled: false}})
+
+	assert.True(t, flags.Enabled(ctx, "new_pricing_engine"), "pinned for the request")
+	assert.True(t, flags.Enabled(featureflag.Pin(ctx), "new_pricing_engine"), "pinning again keeps the values")
+	assert.False(t, flags.Enabled(context.Background(), "new_pricing_engine"), "the next requests see the change")
+}
+
+func TestNewProvider_UnknownDriver(t *testing.T) {
+	_, err := featureflag.NewProvider(&config.FeatureFlagsConfig{Driver: "flagsmith"}, nil)
+
+	assert.Error(t, err)
+}
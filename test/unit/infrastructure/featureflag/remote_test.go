@@ -0,0 +1,154 @@
+package featureflag_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/featureflag"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unleashFeatures = `{"version": 2, "features": [
+	{"name": "everyone", "enabled": true, "strategies": []},
+	{"name": "disabled", "enabled": false, "strategies": [{"name": "default"}]},
+	{"name": "beta_users", "enabled": true, "strategies": [{"name": "userWithId", "parameters": {"userIds": "u-1, u-2"}}]},
+	{"name": "acme_only", "enabled": true, "strategies": [{"name": "default", "constraints": [
+		{"contextName": "tenantId", "operator": "IN", "values": ["acme"]}
+	]}]},
+	{"name": "half", "enabled": true, "strategies": [{"name": "flexibleRollout", "parameters": {"rollout": "50", "stickiness": "default", "groupId": "half"}}]},
+	{"name": "remote_address", "enabled": true, "strategies": [{"name": "remoteAddress", "parameters": {"IPs": "10.0.0.1"}}]}
+]}`
+
+func TestUnleash(t *testing.T) {
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if r.URL.Path != "/api/client/features" || r.Header.Get("Authorization") != "client-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "core-api", r.Header.Get("UNLEASH-APPNAME"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, unleashFeatures)
+	}))
+	defer server.Close()
+
+	cfg := &config.FeatureFlagsConfig{Driver: config.FeatureFlagDriverUnleash}
+	cfg.Unleash.URL, cfg.Unleash.APIToken, cfg.Unleash.AppName = server.URL+"/api/", "client-token", "core-api"
+	provider, err := featureflag.NewProvider(cfg, nil)
+	require.NoError(t, err)
+	unleash := provider.(*featureflag.Unleash)
+	defer unleash.Close()
+
+	enabled := func(flag string, target featureflag.Target) bool {
+		on, err := unleash.Enabled(context.Background(), flag, target)
+		require.NoError(t, err)
+		return on
+	}
+	assert.True(t, enabled("everyone", featureflag.Target{}))
+	assert.False(t, enabled("disabled", featureflag.Target{}))
+	assert.True(t, enabled("beta_users", featureflag.Target{UserID: "u-2"}))
+	assert.False(t, enabled("beta_users", featureflag.Target{UserID: "u-3"}))
+	assert.True(t, enabled("acme_only", featureflag.Target{TenantID: "acme"}))
+	assert.False(t, enabled("acme_only", featureflag.Target{TenantID: "globex"}))
+	assert.False(t, enabled("remote_address", featureflag.Target{}), "unsupported strategies are off")
+
+	on := 0
+	for i := range 1000 {
+		if enabled("half", featureflag.Target{UserID: fmt.Sprintf("user-%d", i)}) {
+			on++
+		}
+	}
+	assert.InDelta(t, 500, on, 60)
+
+	_, err = unleash.Enabled(context.Background(), "unknown", featureflag.Target{})
+	assert.ErrorIs(t, err, featureflag.ErrUnknownFlag)
+	assert.Equal(t, int32(1), fetches.Load(), "fetched at startup")
+}
+
+func TestUnleash_UnreachableAtStartup(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	cfg := &config.FeatureFlagsConfig{Driver: config.FeatureFlagDriverUnleash}
+	cfg.Unleash.URL = server.URL
+	unleash := featureflag.NewUnleash(cfg, nil)
+	defer unleash.Close()
+
+	_, err := unleash.Enabled(context.Background(), "everyone", featureflag.Target{})
+	assert.ErrorIs(t, err, featureflag.ErrUnknownFlag, "disabled until fetched")
+}
+
+const launchDarklyFlags = `{
+	"off": {"key": "off", "on": false, "offVariation": 1, "variations": [true, false]},
+	"targeted": {"key": "targeted", "on": true, "variations": [true, false], "salt": "s",
+		"targets": [{"values": ["u-1"], "variation": 0}],
+		"contextTargets": [{"contextKind": "tenant", "values": ["acme"], "variation": 0}],
+		"fallthrough": {"variation": 1}},
+	"rules": {"key": "rules", "on": true, "variations": [false, true], "salt": "s",
+		"rules": [
+			{"clauses": [{"attribute": "roles", "op": "in", "values": ["admin"]}], "variation": 1},
+			{"clauses": [{"contextKind": "tenant", "attribute": "key", "op": "startsWith", "values": ["beta-"]}], "variation": 1}
+		],
+		"fallthrough": {"variation": 0}},
+	"half": {"key": "half", "on": true, "variations": [true, false], "salt": "s",
+		"fallthrough": {"rollout": {"variations": [{"variation": 0, "weight": 50000}, {"variation": 1, "weight": 50000}]}}},
+	"needs_targeted": {"key": "needs_targeted", "on": true, "variations": [true, false], "offVariation": 1,
+		"prerequisites": [{"key": "targeted", "variation": 0}],
+		"fallthrough": {"variation": 0}},
+	"gone": {"key": "gone", "deleted": true}
+}`
+
+func TestLaunchDarkly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sdk/latest-flags" || r.Header.Get("Authorization") != "sdk-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, launchDarklyFlags)
+	}))
+	defer server.Close()
+
+	cfg := &config.FeatureFlagsConfig{Driver: config.FeatureFlagDriverLaunchDarkly}
+	cfg.LaunchDarkly.SDKKey, cfg.LaunchDarkly.BaseURL = "sdk-key", server.URL
+	provider, err := featureflag.NewProvider(cfg, nil)
+	require.NoError(t, err)
+	ld := provider.(*featureflag.LaunchDarkly)
+	defer ld.Close()
+
+	enabled := func(flag string, target featureflag.Target) bool {
+		on, err := ld.Enabled(context.Background(), flag, target)
+		require.NoError(t, err)
+		return on
+	}
+	assert.False(t, enabled("off", featureflag.Target{UserID: "u-1"}))
+	assert.True(t, enabled("targeted", featureflag.Target{UserID: "u-1"}))
+	assert.True(t, enabled("targeted", featureflag.Target{UserID: "u-2", TenantID: "acme"}))
+	assert.False(t, enabled("targeted", featureflag.Target{UserID: "u-2", TenantID: "globex"}))
+	assert.True(t, enabled("rules", featureflag.Target{UserID: "u-2", Roles: []string{"agent", "admin"}}))
+	assert.True(t, enabled("rules", featureflag.Target{UserID: "u-2", TenantID: "beta-acme"}))
+	assert.False(t, enabled("rules", featureflag.Target{UserID: "u-2", TenantID: "acme"}))
+	assert.True(t, enabled("needs_targeted", featureflag.Target{UserID: "u-1"}))
+	assert.False(t, enabled("needs_targeted", featureflag.Target{UserID: "u-2"}), "prerequisite not met")
+
+	on := 0
+	for i := range 1000 {
+		if enabled("half", featureflag.Target{UserID: fmt.Sprintf("user-%d", i)}) {
+			on++
+		}
+	}
+	assert.InDelta(t, 500, on, 60)
+
+	_, err = ld.Enabled(context.Background(), "gone", featureflag.Target{})
+	assert.ErrorIs(t, err, featureflag.ErrUnknownFlag)
+}
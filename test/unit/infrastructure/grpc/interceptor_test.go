@@ -0,0 +1,294 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/grpc/interceptor"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/uid"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus_AppErrorCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"invalid request", apperror.NewPersistance(apperror.CodeInvalidRequest, "invalid"), codes.InvalidArgument},
+		{"unauthorized", apperror.NewPersistance(apperror.CodeUnauthorized, "unauthorized"), codes.Unauthenticated},
+		{"forbidden", apperror.NewPersistance(apperror.CodeForbidden, "forbidden"), codes.PermissionDenied},
+		{"not found", apperror.NewPersistance(apperror.CodeNotFound, "not found"), codes.NotFound},
+		{"conflict", apperror.NewPersistance(apperror.CodeDbConflict, "duplicate data"), codes.AlreadyExists},
+		{"unprocessable", apperror.NewPersistance(apperror.CodeUnprocessableEntity, "unprocessable"), codes.FailedPrecondition},
+		{"too many requests", apperror.NewPersistance(apperror.CodeTooManyRequests, "slow down"), codes.ResourceExhausted},
+		{"transient", apperror.NewTransient(apperror.CodeDbDeadlock, "deadlock"), codes.Unavailable},
+		{"transient unregistered", apperror.NewTransient("SOME_UPSTREAM_DOWN", "down"), codes.Unavailable},
+		{"internal", apperror.NewInternal(apperror.CodeInternalError, "boom"), codes.Internal},
+		{"wrapped", errors.Join(errors.New("context"), apperror.NewPersistance(apperror.CodeNotFound, "nf")), codes.NotFound},
+		{"context canceled", context.Canceled, codes.Canceled},
+		{"deadline exceeded", context.DeadlineExceeded, codes.DeadlineExceeded},
+		{"plain error", errors.New("boom"), codes.Internal},
+		{"status error", status.Error(codes.Aborted, "aborted"), codes.Aborted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, interceptor.ToStatus(tt.err).Code())
+		})
+	}
+}
+
+func TestToStatus_AttachesErrorInfoAndViolations(t *testing.T) {
+	err := apperror.NewPersistance(apperror.CodeInvalidRequest, "Invalid request").
+		AddValidationErrors([]map[string]any{
+			{"field": "code", "message": "Booking code is required"},
+		})
+
+	st := interceptor.ToStatus(err)
+
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "Invalid request", st.Message())
+
+	var info *errdetails.ErrorInfo
+	var badRequest *errdetails.BadRequest
+	for _, d := range st.Details() {
+		switch v := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = v
+		case *errdetails.BadRequest:
+			badRequest = v
+		}
+	}
+
+	require.NotNil(t, info)
+	assert.Equal(t, apperror.CodeInvalidRequest, info.GetReason())
+	assert.Equal(t, "false", info.GetMetadata()["is_retryable"])
+
+	require.NotNil(t, badRequest)
+	require.Len(t, badRequest.GetFieldViolations(), 1)
+	assert.Equal(t, "code", badRequest.GetFieldViolations()[0].GetField())
+}
+
+func TestRequestID_PropagatesIncomingMetadata(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Service/Call"}
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want string // empty when a new ID is generated
+	}{
+		{"from metadata", metadata.Pairs(interceptor.HeaderRequestID, "req-123"), "req-123"},
+		{"missing", metadata.MD{}, ""},
+		{"invalid", metadata.Pairs(interceptor.HeaderRequestID, "req 123\nlevel=error"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			var got string
+			_, err := interceptor.RequestID()(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+				got = ctxkey.GetRequestID(ctx)
+				return nil, nil
+			})
+
+			require.NoError(t, err)
+			if tt.want == "" {
+				assert.True(t, uid.IsValidRequestID(got), "a new ID is generated, got %q", got)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIdentity(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Service/Call"}
+	identity := interceptor.Identity(config.GatewayConfig{Secret: "gateway-secret", TrustedIPs: []string{"10.0.0.0/8"}}, config.TenancyConfig{Enabled: true})
+	call := func(secret, peerIP string) context.Context {
+		md := metadata.Pairs(
+			"x-gateway-secret", secret,
+			interceptor.HeaderUserID, "user-1",
+			interceptor.HeaderUserRoles, "ops, admin",
+			interceptor.HeaderTenantID, "tenant 1",
+			interceptor.HeaderClientApp, "partner-api",
+		)
+		ctx := peer.NewContext(metadata.NewIncomingContext(context.Background(), md), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(peerIP), Port: 52000}})
+
+		var got context.Context
+		_, err := identity(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			got = ctx
+			return nil, nil
+		})
+		require.NoError(t, err)
+		return got
+	}
+
+	got := call("gateway-secret", "10.1.2.3")
+	assert.Equal(t, "user-1", ctxkey.GetUserID(got))
+	assert.Equal(t, []string{"ops", "admin"}, ctxkey.GetRoles(got))
+	assert.Empty(t, ctxkey.GetTenantID(got), "invalid values are ignored")
+	assert.Equal(t, "partner-api", ctxkey.GetClientApp(got))
+
+	for _, untrusted := range [][2]string{{"", "10.1.2.3"}, {"gateway-secret", "203.0.113.7"}} {
+		got := call(untrusted[0], untrusted[1])
+		assert.Empty(t, ctxkey.GetUserID(got), untrusted)
+		assert.Nil(t, ctxkey.GetRoles(got), untrusted)
+		assert.Empty(t, ctxkey.GetClientApp(got), untrusted)
+	}
+}
+
+func TestTenant(t *testing.T) {
+	tenant := interceptor.Tenant(config.TenancyConfig{
+		Resolvers:  []string{config.TenantResolverSubdomain, config.TenantResolverHeader},
+		BaseDomain: "voyago.com",
+		Required:   true,
+	}, config.GatewayConfig{Secret: "gateway-secret"})
+	call := func(method string, md metadata.MD) (string, error) {
+		var got string
+		_, err := tenant(metadata.NewIncomingContext(context.Background(), md), nil, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req any) (any, error) {
+				got = ctxkey.GetTenantID(ctx)
+				return nil, nil
+			})
+		return got, err
+	}
+
+	got, err := call("/test.v1.Service/Call", metadata.Pairs(":authority", "acme.voyago.com:4001"))
+	require.NoError(t, err)
+	assert.Equal(t, "acme", got)
+
+	got, err = call("/test.v1.Service/Call", metadata.Pairs("x-tenant-id", "globex", "x-gateway-secret", "gateway-secret"))
+	require.NoError(t, err)
+	assert.Equal(t, "globex", got)
+
+	_, err = call("/test.v1.Service/Call", metadata.Pairs("x-tenant-id", "globex"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "the metadata of an untrusted call is ignored")
+
+	var kept string
+	ctx := metadata.NewIncomingContext(ctxkey.SetTenantID(context.Background(), "initech"), metadata.MD{})
+	_, err = tenant(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test.v1.Service/Call"}, func(ctx context.Context, req any) (any, error) {
+		kept = ctxkey.GetTenantID(ctx)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "initech", kept, "the tenant of the identity metadata is kept")
+
+	_, err = call("/test.v1.Service/Call", metadata.MD{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = call("/grpc.health.v1.Health/Check", metadata.MD{})
+	assert.NoError(t, err, "the health checks need no tenant")
+}
+
+func TestTelemetrist_HandleTrace_JoinsIncomingTrace(t *testing.T) {
+	// Nothing listens on the collector address: spans are never exported.
+	trc, err := tracer.NewOTelTracer("voyago-test", "test", "127.0.0.1:1", 1, config.TraceSamplingConfig{})
+	require.NoError(t, err)
+
+	tel := interceptor.NewTelemetrist(logger.NewNoOpLogger(), trc, metrics.NewNoOpMetrics())
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Service/Call"}
+	md := metadata.Pairs("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	var got string
+	_, err = tel.HandleTrace()(metadata.NewIncomingContext(context.Background(), md), nil, info, func(ctx context.Context, req any) (any, error) {
+		got, _, _ = trc.ExtractTraceInfo(ctx)
+		return nil, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", got)
+}
+
+func TestTelemetrist_HandleLog_ConvertsAppError(t *testing.T) {
+	tm := interceptor.NewTelemetrist(logger.NewNoOpLogger(), tracer.NewNoOpTracer(), metrics.NewNoOpMetrics())
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Service/Call"}
+
+	_, err := tm.HandleLog()(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, apperror.NewPersistance(apperror.CodeNotFound, "record not found")
+	})
+
+	st, ok := status.FromError(err)
+	require.True(t, ok, "error must be a gRPC status")
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "record not found", st.Message())
+}
+
+// recordingMetrics keeps the tags of the counters and timings, by name.
+type recordingMetrics struct {
+	metrics.Metrics
+	recorded map[string][][]string
+}
+
+func (m *recordingMetrics) Incr(name string, tags []string) {
+	m.recorded[name] = append(m.recorded[name], tags)
+}
+
+func (m *recordingMetrics) Timing(name string, _ time.Duration, tags []string) {
+	m.recorded[name] = append(m.recorded[name], tags)
+}
+
+func TestTelemetrist_HandleLog_RecordsErrorMetrics(t *testing.T) {
+	m := &recordingMetrics{Metrics: metrics.NewNoOpMetrics(), recorded: map[string][][]string{}}
+	tm := interceptor.NewTelemetrist(logger.NewNoOpLogger(), tracer.NewNoOpTracer(), m)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Service/Call"}
+	handle := tm.HandleLog()
+
+	for _, err := range []error{
+		apperror.ErrCodeNotFound,
+		apperror.NewTransient(apperror.CodeDbDeadlock, "deadlock"),
+		errors.New("boom"),
+		nil,
+	} {
+		_, _ = handle(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return nil, err
+		})
+	}
+
+	assert.Equal(t, [][]string{
+		{"code:NOT_FOUND", "kind:PERSISTANCE", "module:core", "retryable:false"},
+		{"code:DB_DEADLOCK", "kind:TRANSIENT", "module:core", "retryable:true"},
+		{"code:INTERNAL_ERROR", "kind:INTERNAL", "module:core", "retryable:false"},
+	}, m.recorded["app_error.total"])
+	assert.Equal(t, [][]string{
+		{"method:/test.v1.Service/Call", "code:Unavailable"},
+		{"method:/test.v1.Service/Call", "code:Internal"},
+	}, m.recorded["grpc.server_error.duration"])
+}
+
+func TestClientErrors_TranslatesTheStatusErrors(t *testing.T) {
+	sent := apperror.NewPersistance(apperror.CodeNotFound, "Seat not found")
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return interceptor.ToStatus(sent).Err()
+	}
+
+	err := interceptor.ClientErrors()(context.Background(), "/inventory.v1.Seats/Reserve", nil, nil, nil, invoker)
+
+	var appErr *apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperror.CodeNotFound, appErr.Code)
+	assert.Equal(t, 404, appErr.GetHttpStatus())
+
+	ok := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+	assert.NoError(t, interceptor.ClientErrors()(context.Background(), "/inventory.v1.Seats/Reserve", nil, nil, nil, ok))
+}
@@ -0,0 +1,147 @@
+package middleware_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+// newBodyLimitApp echoes the request body. seen receives the body the
+// outermost middleware (the telemetry logger) reads once the request is done.
+func newBodyLimitApp(limit int, seen *[]byte) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			var appErr *apperror.AppError
+			if errors.As(err, &appErr) {
+				return c.Status(appErr.GetHttpStatus()).SendString(appErr.Code)
+			}
+			return c.SendStatus(fiber.StatusInternalServerError)
+		},
+	})
+	app.Use(func(c *fiber.Ctx) error {
+		err := c.Next()
+		*seen = append([]byte(nil), c.Body()...)
+		return err
+	})
+	app.Use(middleware.BodyLimit(limit))
+	app.Post("/echo", func(c *fiber.Ctx) error {
+		return c.Send(c.Body())
+	})
+	return app
+}
+
+func post(t *testing.T, app *fiber.App, body []byte, encoding string) (int, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPost, "/echo", bytes.NewReader(body))
+	if encoding != "" {
+		req.Header.Set(fiber.HeaderContentEncoding, encoding)
+	}
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(raw)
+}
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// ============================================================================
+// TESTS
+// ============================================================================
+
+func TestBodyLimit_RejectsLargeBodies(t *testing.T) {
+	var seen []byte
+	app := newBodyLimitApp(16, &seen)
+
+	status, body := post(t, app, []byte(`{"name":"voyago"}`), "")
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, status)
+	assert.Equal(t, apperror.CodePayloadTooLarge, body)
+	assert.Empty(t, seen, "the rejected body is dropped")
+
+	status, body = post(t, app, []byte(`{"a":1}`), "")
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, `{"a":1}`, body)
+}
+
+func TestBodyLimit_DecodesCompressedBodies(t *testing.T) {
+	var br bytes.Buffer
+	w := brotli.NewWriter(&br)
+	_, err := w.Write([]byte(`{"via":"br"}`))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	tests := map[string]struct {
+		body     []byte
+		encoding string
+		want     string
+	}{
+		"gzip":   {gzipped(t, `{"via":"gzip"}`), "gzip", `{"via":"gzip"}`},
+		"br":     {br.Bytes(), "br", `{"via":"br"}`},
+		"layers": {gzipped(t, string(gzipped(t, `{"via":"twice"}`))), "gzip, gzip", `{"via":"twice"}`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var seen []byte
+			app := newBodyLimitApp(1024, &seen)
+
+			status, body := post(t, app, tt.body, tt.encoding)
+			assert.Equal(t, fiber.StatusOK, status)
+			assert.Equal(t, tt.want, body)
+			assert.Equal(t, tt.want, string(seen))
+		})
+	}
+}
+
+func TestBodyLimit_RejectsCompressionBombs(t *testing.T) {
+	var seen []byte
+	app := newBodyLimitApp(4096, &seen)
+
+	bomb := gzipped(t, strings.Repeat("0", 1<<20))
+	require.Less(t, len(bomb), 4096)
+
+	status, body := post(t, app, bomb, "gzip")
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, status)
+	assert.Equal(t, apperror.CodePayloadTooLarge, body)
+	assert.Empty(t, seen, "the logger never inflates the bomb")
+}
+
+func TestBodyLimit_InvalidEncodings(t *testing.T) {
+	var seen []byte
+	app := newBodyLimitApp(1024, &seen)
+
+	status, body := post(t, app, []byte("not gzip"), "gzip")
+	assert.Equal(t, fiber.StatusBadRequest, status)
+	assert.Equal(t, apperror.CodeMalformedRequest, body)
+
+	status, body = post(t, app, []byte("data"), "zstd")
+	assert.Equal(t, fiber.StatusUnsupportedMediaType, status)
+	assert.Equal(t, apperror.CodeUnsupportedMediaType, body)
+}
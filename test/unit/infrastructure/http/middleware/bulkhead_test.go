@@ -0,0 +1,135 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBulkheadApp mounts b on /api/v1/bookings. Requests to /slow block
+// until release is closed; /fast answers right away.
+func newBulkheadApp(b *middleware.Bulkhead, entered chan<- struct{}, release <-chan struct{}) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			var appErr *apperror.AppError
+			if errors.As(err, &appErr) {
+				c.Set("X-Retryable", map[bool]string{true: "true", false: "false"}[appErr.IsRetryable()])
+				return c.Status(appErr.GetHttpStatus()).SendString(appErr.Code)
+			}
+			return c.SendStatus(fiber.StatusInternalServerError)
+		},
+	})
+	app.Use("/api/v1/bookings", b.Handler())
+	app.Get("/api/v1/bookings/slow", func(c *fiber.Ctx) error {
+		entered <- struct{}{}
+		<-release
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Get("/api/v1/bookings/fast", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Get("/api/v1/webhooks", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	return app
+}
+
+func get(t *testing.T, app *fiber.App, path string) *http.Response {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil), -1)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+func TestBulkhead_ShedsExcessLoad(t *testing.T) {
+	m := newRecordingMetrics()
+	b := middleware.NewBulkhead("/api/v1/bookings", 2, 3, m)
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	app := newBulkheadApp(b, entered, release)
+
+	// Fill the bulkhead with two blocked requests.
+	var wg sync.WaitGroup
+	statuses := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses <- get(t, app, "/api/v1/bookings/slow").StatusCode
+		}()
+		select {
+		case <-entered:
+		case <-time.After(2 * time.Second):
+			t.Fatal("request did not reach the handler")
+		}
+	}
+	assert.Equal(t, 2, b.InFlight())
+
+	// The third request is shed.
+	shed := get(t, app, "/api/v1/bookings/fast")
+	assert.Equal(t, fiber.StatusServiceUnavailable, shed.StatusCode)
+	assert.Equal(t, "3", shed.Header.Get(fiber.HeaderRetryAfter))
+	assert.Equal(t, "true", shed.Header.Get("X-Retryable"))
+	assert.Equal(t, []string{"group:/api/v1/bookings"}, m.counters["http.bulkhead.rejected"])
+
+	// Other groups are not affected.
+	assert.Equal(t, fiber.StatusNoContent, get(t, app, "/api/v1/webhooks").StatusCode)
+
+	close(release)
+	wg.Wait()
+	close(statuses)
+	for status := range statuses {
+		assert.Equal(t, fiber.StatusNoContent, status)
+	}
+
+	// Capacity is given back once the requests complete.
+	assert.Equal(t, 0, b.InFlight())
+	assert.Equal(t, fiber.StatusNoContent, get(t, app, "/api/v1/bookings/fast").StatusCode)
+
+	gauges := m.gauges["http.bulkhead.in_flight"]
+	require.NotEmpty(t, gauges)
+	assert.Contains(t, gauges, float64(2))
+	assert.Equal(t, float64(0), gauges[len(gauges)-1])
+}
+
+func TestBulkhead_ReleasesOnPanic(t *testing.T) {
+	b := middleware.NewBulkhead("/", 1, 0, newRecordingMetrics())
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if recover() != nil {
+				err = fiber.ErrInternalServerError
+			}
+		}()
+		return c.Next()
+	})
+	app.Use(b.Handler())
+	app.Get("/", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+
+	assert.Equal(t, fiber.StatusInternalServerError, get(t, app, "/").StatusCode)
+	assert.Equal(t, 0, b.InFlight())
+}
+
+func TestBulkhead_DefaultRetryAfter(t *testing.T) {
+	// A bulkhead without capacity sheds every request.
+	b := middleware.NewBulkhead("/api/v1/bookings", 0, 0, newRecordingMetrics())
+	app := newBulkheadApp(b, nil, nil)
+
+	resp := get(t, app, "/api/v1/bookings/fast")
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get(fiber.HeaderRetryAfter))
+}
@@ -0,0 +1,139 @@
+package middleware_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/http/middleware"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var largeJSON = `{"data":"` + strings.Repeat("voyago ", 500) + `"}`
+
+func newCompressApp(cfg config.CompressionConfig) *fiber.App {
+	app := fiber.New()
+	app.Use(middleware.Compress(cfg))
+	app.Get("/json", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.SendString(largeJSON)
+	})
+	app.Get("/etag", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderETag, `"v1"`)
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.SendString(largeJSON)
+	})
+	app.Get("/small", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"ok": true})
+	})
+	app.Get("/binary", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "application/msgpack")
+		return c.SendString(largeJSON)
+	})
+	app.Get("/stream", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			fmt.Fprintf(w, "data: %s\n\n", largeJSON)
+		})
+		return nil
+	})
+	return app
+}
+
+func fetch(t *testing.T, app *fiber.App, path, acceptEncoding string) (string, string) {
+	t.Helper()
+
+	resp, body := fetchResponse(t, app, path, acceptEncoding)
+	return resp.Header.Get(fiber.HeaderContentEncoding), body
+}
+
+func fetchResponse(t *testing.T, app *fiber.App, path, acceptEncoding string) (*http.Response, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodGet, path, nil)
+	req.Header.Set(fiber.HeaderAcceptEncoding, acceptEncoding)
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	encoding := resp.Header.Get(fiber.HeaderContentEncoding)
+	var r io.Reader = resp.Body
+	switch encoding {
+	case "gzip":
+		r, err = gzip.NewReader(resp.Body)
+		require.NoError(t, err)
+	case "br":
+		r = brotli.NewReader(resp.Body)
+	}
+	raw, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return resp, string(raw)
+}
+
+func TestCompress_NegotiatesEncoding(t *testing.T) {
+	app := newCompressApp(config.CompressionConfig{Enabled: true})
+
+	tests := map[string]string{
+		"gzip, deflate, br": "br",
+		"gzip":              "gzip",
+		"":                  "",
+	}
+	for accept, want := range tests {
+		t.Run(accept, func(t *testing.T) {
+			encoding, body := fetch(t, app, "/json", accept)
+			assert.Equal(t, want, encoding)
+			assert.Equal(t, largeJSON, body)
+		})
+	}
+}
+
+func TestCompress_SkipsIneligibleResponses(t *testing.T) {
+	app := newCompressApp(config.CompressionConfig{Enabled: true})
+
+	for _, path := range []string{"/small", "/binary", "/stream"} {
+		t.Run(path, func(t *testing.T) {
+			encoding, body := fetch(t, app, path, "gzip, br")
+			assert.Empty(t, encoding)
+			assert.NotEmpty(t, body)
+		})
+	}
+}
+
+func TestCompress_ConfiguredContentTypes(t *testing.T) {
+	app := newCompressApp(config.CompressionConfig{
+		Enabled:      true,
+		Level:        config.CompressionLevelBestSpeed,
+		ContentTypes: []string{"application/msgpack"},
+	})
+
+	encoding, _ := fetch(t, app, "/binary", "gzip")
+	assert.Equal(t, "gzip", encoding)
+	encoding, _ = fetch(t, app, "/json", "gzip")
+	assert.Empty(t, encoding)
+}
+
+func TestCompress_WeakensStrongETags(t *testing.T) {
+	app := newCompressApp(config.CompressionConfig{Enabled: true})
+
+	resp, _ := fetchResponse(t, app, "/etag", "gzip")
+	assert.Equal(t, `W/"v1"`, resp.Header.Get(fiber.HeaderETag))
+
+	resp, _ = fetchResponse(t, app, "/etag", "")
+	assert.Equal(t, `"v1"`, resp.Header.Get(fiber.HeaderETag), "uncompressed bytes keep their strong ETag")
+}
+
+func TestCompress_InvalidLevel(t *testing.T) {
+	assert.Panics(t, func() {
+		middleware.Compress(config.CompressionConfig{Level: "max"})
+	})
+}
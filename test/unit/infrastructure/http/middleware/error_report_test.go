@@ -0,0 +1,100 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/errorreport"
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingReporter struct {
+	mu     sync.Mutex
+	events []errorreport.Event
+}
+
+func (r *recordingReporter) Report(_ context.Context, e errorreport.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+func (r *recordingReporter) Close() error { return nil }
+
+// newErrorReportApp mounts the reporter between HandleLog and HandleRecover,
+// as the bootstrap does, for a user of the acme tenant.
+func newErrorReportApp(r errorreport.Reporter) *fiber.App {
+	app := fiber.New()
+	tm := middleware.NewTelemetrist(logger.NewNoOpLogger(), &fakeTracer{span: &recordingSpan{tags: map[string]any{}}}, newRecordingMetrics())
+	app.Use(func(c *fiber.Ctx) error {
+		ctx := ctxkey.SetUserID(c.UserContext(), "user-7")
+		c.SetUserContext(ctxkey.SetTenantID(ctx, "acme"))
+		return c.Next()
+	})
+	app.Use(tm.HandleTrace())
+	app.Use(tm.HandleLog())
+	app.Use(tm.HandleErrorReport(r))
+	app.Use(tm.HandleRecover())
+	return app
+}
+
+func TestHandleErrorReport_ReportsPanics(t *testing.T) {
+	r := &recordingReporter{}
+	app := newErrorReportApp(r)
+	app.Post("/bookings/:id", func(c *fiber.Ctx) error {
+		panic("nil booking repository")
+	})
+
+	req := httptest.NewRequest(fiber.MethodPost, "/bookings/42?password=hunter2&page=2", nil)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Len(t, r.events, 1)
+	e := r.events[0]
+	assert.True(t, e.Panic)
+	assert.Contains(t, e.Stack, "panic(")
+	assert.ErrorIs(t, e.Err, apperror.ErrCodeInternalError)
+	assert.Equal(t, "trace-123", e.TraceID)
+	assert.Equal(t, "http", e.Transport)
+	assert.Equal(t, "user-7", e.UserID)
+	assert.Equal(t, "acme", e.TenantID)
+	assert.Equal(t, fiber.MethodPost, e.Request.Method)
+	assert.Equal(t, "/bookings/42", e.Request.URL)
+	assert.Equal(t, "/bookings/:id", e.Request.Route)
+	assert.Equal(t, utils.Redacted, e.Request.Headers["Authorization"])
+	assert.Equal(t, utils.Redacted, e.Request.Query["password"])
+	assert.Equal(t, "2", e.Request.Query["page"])
+}
+
+func TestHandleErrorReport_SkipsExpectedFailures(t *testing.T) {
+	r := &recordingReporter{}
+	app := newErrorReportApp(r)
+	app.Get("/not-found", func(c *fiber.Ctx) error { return apperror.ErrCodeNotFound })
+	app.Get("/down", func(c *fiber.Ctx) error {
+		return apperror.NewTransient(apperror.CodeDbConnectionFailed, "Database connection failed")
+	})
+	app.Get("/internal", func(c *fiber.Ctx) error {
+		return apperror.NewInternal(apperror.CodeInternalError, "Internal error")
+	})
+
+	for _, path := range []string{"/not-found", "/down", "/internal"} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil), -1)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	require.Len(t, r.events, 1)
+	assert.False(t, r.events[0].Panic)
+	assert.Equal(t, "/internal", r.events[0].Request.URL)
+}
@@ -0,0 +1,107 @@
+package middleware_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/pkg/gateway"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const gatewaySecret = "gateway-shared-secret"
+
+func newIdentityApp(tenancy config.TenancyConfig, ctx *context.Context) *fiber.App {
+	app := fiber.New()
+	app.Use(middleware.RequestID())
+	app.Use(middleware.Identity(config.GatewayConfig{Secret: gatewaySecret}, tenancy))
+	app.Get("/", func(c *fiber.Ctx) error {
+		*ctx = c.UserContext()
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	return app
+}
+
+func TestIdentity(t *testing.T) {
+	var ctx context.Context
+	app := newIdentityApp(config.TenancyConfig{Enabled: true}, &ctx)
+
+	t.Run("gateway headers", func(t *testing.T) {
+		resp := do(t, app, fiber.MethodGet, "/", map[string]string{
+			gateway.HeaderSecret:       gatewaySecret,
+			middleware.HeaderUserID:    "550e8400-e29b-41d4-a716-446655440000",
+			middleware.HeaderUserRoles: "ops, admin,,bad role",
+			middleware.HeaderTenantID:  "tenant-1",
+			middleware.HeaderClientApp: "backoffice",
+		})
+
+		assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", ctxkey.GetUserID(ctx))
+		assert.Equal(t, []string{"ops", "admin"}, ctxkey.GetRoles(ctx))
+		assert.Equal(t, "tenant-1", ctxkey.GetTenantID(ctx))
+		assert.Equal(t, "backoffice", ctxkey.GetClientApp(ctx))
+		assert.Equal(t, resp.Header.Get(fiber.HeaderXRequestID), ctxkey.GetRequestID(ctx), "the request ID is kept next to the caller")
+	})
+
+	t.Run("missing or invalid", func(t *testing.T) {
+		do(t, app, fiber.MethodGet, "/", map[string]string{
+			gateway.HeaderSecret:       gatewaySecret,
+			middleware.HeaderUserID:    "abc\" level=error",
+			middleware.HeaderTenantID:  strings.Repeat("t", 129),
+			middleware.HeaderClientApp: "",
+		})
+
+		assert.Empty(t, ctxkey.GetUserID(ctx))
+		assert.Nil(t, ctxkey.GetRoles(ctx))
+		assert.Empty(t, ctxkey.GetTenantID(ctx))
+		assert.Empty(t, ctxkey.GetClientApp(ctx))
+	})
+}
+
+func TestIdentity_IgnoresTheRequestsWithoutTheGateway(t *testing.T) {
+	var ctx context.Context
+	app := newIdentityApp(config.TenancyConfig{Enabled: true}, &ctx)
+	spoofed := map[string]string{
+		middleware.HeaderUserID:    "admin-user",
+		middleware.HeaderUserRoles: "admin",
+		middleware.HeaderTenantID:  "acme",
+		middleware.HeaderClientApp: "backoffice",
+	}
+
+	for _, secret := range []string{"", "not-the-secret"} {
+		headers := map[string]string{gateway.HeaderSecret: secret}
+		for k, v := range spoofed {
+			headers[k] = v
+		}
+		do(t, app, fiber.MethodGet, "/", headers)
+
+		assert.Empty(t, ctxkey.GetUserID(ctx), secret)
+		assert.Nil(t, ctxkey.GetRoles(ctx), secret)
+		assert.Empty(t, ctxkey.GetTenantID(ctx), secret)
+		assert.Empty(t, ctxkey.GetClientApp(ctx), secret)
+	}
+}
+
+func TestIdentity_TenantOnlyWithTenancy(t *testing.T) {
+	var ctx context.Context
+	app := newIdentityApp(config.TenancyConfig{}, &ctx)
+
+	do(t, app, fiber.MethodGet, "/", map[string]string{
+		gateway.HeaderSecret:      gatewaySecret,
+		middleware.HeaderUserID:   "user-1",
+		middleware.HeaderTenantID: "acme",
+	})
+
+	assert.Equal(t, "user-1", ctxkey.GetUserID(ctx))
+	assert.Empty(t, ctxkey.GetTenantID(ctx), "the tenant does not scope the repositories of a single-tenant deployment")
+}
+
+func TestIdentity_PanicsOnAnInvalidTrustedIP(t *testing.T) {
+	assert.Panics(t, func() {
+		middleware.Identity(config.GatewayConfig{TrustedIPs: []string{"gateway.internal"}}, config.TenancyConfig{})
+	})
+}
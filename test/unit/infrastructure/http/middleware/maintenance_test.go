@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/maintenance"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMaintenanceApp(cfg config.MaintenanceConfig) (*fiber.App, *maintenance.Mode) {
+	mode := maintenance.NewMode(cfg, maintenance.NewMemoryStore(), logger.NewNoOpLogger())
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			var appErr *apperror.AppError
+			if errors.As(err, &appErr) {
+				c.Set("X-Retryable", map[bool]string{true: "true", false: "false"}[appErr.IsRetryable()])
+				return c.Status(appErr.GetHttpStatus()).SendString(appErr.Message)
+			}
+			return c.SendStatus(fiber.StatusInternalServerError)
+		},
+	})
+	app.Use(middleware.Maintenance(mode, cfg, "/admin"))
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+	for _, path := range []string{"/health", "/admin/maintenance", "/adminx", "/api/v1/bookings", "/api/v1/webhooks/42", "/api/v1/webhooksx"} {
+		app.Get(path, ok)
+	}
+	return app, mode
+}
+
+func TestMaintenance_RejectsRequests(t *testing.T) {
+	app, mode := newMaintenanceApp(config.MaintenanceConfig{RetryAfter: 120})
+
+	assert.Equal(t, fiber.StatusNoContent, get(t, app, "/api/v1/bookings").StatusCode, "disabled by default")
+
+	_, err := mode.Set(context.Background(), true, "Back at 10:00 UTC")
+	require.NoError(t, err)
+
+	resp := get(t, app, "/api/v1/bookings")
+	assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "true", resp.Header.Get("X-Retryable"))
+	assert.Equal(t, "120", resp.Header.Get(fiber.HeaderRetryAfter))
+
+	assert.Equal(t, fiber.StatusNoContent, get(t, app, "/health").StatusCode)
+	assert.Equal(t, fiber.StatusNoContent, get(t, app, "/admin/maintenance").StatusCode)
+	assert.Equal(t, fiber.StatusServiceUnavailable, get(t, app, "/adminx").StatusCode)
+}
+
+func TestMaintenance_Allowlist(t *testing.T) {
+	app, _ := newMaintenanceApp(config.MaintenanceConfig{
+		Enabled:      true,
+		AllowedPaths: []string{"/api/v1/webhooks/"},
+	})
+
+	assert.Equal(t, fiber.StatusNoContent, get(t, app, "/api/v1/webhooks/42").StatusCode)
+	assert.Equal(t, fiber.StatusServiceUnavailable, get(t, app, "/api/v1/webhooksx").StatusCode, "prefixes match whole segments")
+	assert.Equal(t, fiber.StatusServiceUnavailable, get(t, app, "/api/v1/bookings").StatusCode)
+
+	// app.Test requests come from 0.0.0.0.
+	admins, _ := newMaintenanceApp(config.MaintenanceConfig{Enabled: true, AllowedIPs: []string{"10.0.0.0/8", "0.0.0.0"}})
+	assert.Equal(t, fiber.StatusNoContent, get(t, admins, "/api/v1/bookings").StatusCode)
+
+	others, _ := newMaintenanceApp(config.MaintenanceConfig{Enabled: true, AllowedIPs: []string{"10.0.0.0/8"}})
+	assert.Equal(t, fiber.StatusServiceUnavailable, get(t, others, "/api/v1/bookings").StatusCode)
+}
+
+func TestMaintenance_InvalidAllowedIP(t *testing.T) {
+	assert.Panics(t, func() {
+		newMaintenanceApp(config.MaintenanceConfig{AllowedIPs: []string{"office"}})
+	})
+}
@@ -0,0 +1,195 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/ratelimit"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/gateway"
+	"voyago/core-api/internal/pkg/ipnet"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+type failingStore struct{}
+
+func (failingStore) Allow(context.Context, string, int, time.Duration) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func newRateLimitApp(t *testing.T, cfg config.RateLimitConfig, store ratelimit.Store, m metrics.Metrics) *fiber.App {
+	return newRateLimitAppBehind(t, nil, cfg, store, m)
+}
+
+// newRateLimitAppBehind trusts the gateway secret gatewaySecret and the
+// proxies trustedProxies.
+func newRateLimitAppBehind(t *testing.T, trustedProxies []string, cfg config.RateLimitConfig, store ratelimit.Store, m metrics.Metrics) *fiber.App {
+	t.Helper()
+
+	limiter, err := ratelimit.NewLimiter(cfg, store)
+	require.NoError(t, err)
+	proxies, err := ipnet.Parse(trustedProxies)
+	require.NoError(t, err)
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			var appErr *apperror.AppError
+			if errors.As(err, &appErr) {
+				return c.Status(appErr.GetHttpStatus()).SendString(appErr.Code)
+			}
+			return c.SendStatus(fiber.StatusInternalServerError)
+		},
+	})
+	app.Use(middleware.Identity(config.GatewayConfig{Secret: gatewaySecret}, config.TenancyConfig{}))
+	app.Use(middleware.RateLimit(limiter, proxies, logger.NewNoOpLogger(), m))
+
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+	app.Get("/api/v1/bookings", ok)
+	app.Post("/api/v1/bookings", ok)
+	app.Get("/health", ok)
+	return app
+}
+
+func do(t *testing.T, app *fiber.App, method, path string, headers map[string]string) *http.Response {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	resp.Body.Close()
+	return resp
+}
+
+var rateLimitCfg = config.RateLimitConfig{
+	Default: config.RateLimitRuleConfig{Limit: 2, Window: 60},
+	Routes: []config.RateLimitRouteConfig{
+		{Path: "/health"},
+		{
+			Method:              fiber.MethodPost,
+			Path:                "/api/v1/bookings",
+			RateLimitRuleConfig: config.RateLimitRuleConfig{Limit: 1, Window: 60, KeyBy: config.RateLimitKeyByAPIKey},
+		},
+	},
+}
+
+// ============================================================================
+// TESTS
+// ============================================================================
+
+func TestRateLimit_ThrottlesOverLimit(t *testing.T) {
+	m := newRecordingMetrics()
+	app := newRateLimitApp(t, rateLimitCfg, ratelimit.NewMemoryStore(), m)
+
+	first := do(t, app, fiber.MethodGet, "/api/v1/bookings", nil)
+	assert.Equal(t, fiber.StatusNoContent, first.StatusCode)
+	assert.Equal(t, "2", first.Header.Get(middleware.HeaderRateLimitLimit))
+	assert.Equal(t, "1", first.Header.Get(middleware.HeaderRateLimitRemaining))
+	assert.Equal(t, "60", first.Header.Get(middleware.HeaderRateLimitReset))
+
+	second := do(t, app, fiber.MethodGet, "/api/v1/bookings", nil)
+	assert.Equal(t, fiber.StatusNoContent, second.StatusCode)
+	assert.Equal(t, "0", second.Header.Get(middleware.HeaderRateLimitRemaining))
+
+	third := do(t, app, fiber.MethodGet, "/api/v1/bookings", nil)
+	assert.Equal(t, fiber.StatusTooManyRequests, third.StatusCode)
+	retryAfter, err := strconv.Atoi(third.Header.Get(fiber.HeaderRetryAfter))
+	require.NoError(t, err)
+	assert.InDelta(t, 60, retryAfter, 1)
+	assert.Equal(t, []string{"rule:default", "key_by:ip"}, m.counters["ratelimit.throttled"])
+}
+
+func TestRateLimit_ExemptRoutes(t *testing.T) {
+	app := newRateLimitApp(t, rateLimitCfg, ratelimit.NewMemoryStore(), metrics.NewNoOpMetrics())
+
+	for i := 0; i < 5; i++ {
+		resp := do(t, app, fiber.MethodGet, "/health", nil)
+		assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get(middleware.HeaderRateLimitLimit))
+	}
+}
+
+func TestRateLimit_PerClientAppRoute(t *testing.T) {
+	app := newRateLimitApp(t, rateLimitCfg, ratelimit.NewMemoryStore(), metrics.NewNoOpMetrics())
+	alice := map[string]string{gateway.HeaderSecret: gatewaySecret, middleware.HeaderClientApp: "alice-app"}
+	bob := map[string]string{gateway.HeaderSecret: gatewaySecret, middleware.HeaderClientApp: "bob-app"}
+
+	assert.Equal(t, fiber.StatusNoContent, do(t, app, fiber.MethodPost, "/api/v1/bookings", alice).StatusCode)
+	assert.Equal(t, fiber.StatusTooManyRequests, do(t, app, fiber.MethodPost, "/api/v1/bookings", alice).StatusCode)
+
+	// Same IP, another client app: counted separately.
+	assert.Equal(t, fiber.StatusNoContent, do(t, app, fiber.MethodPost, "/api/v1/bookings", bob).StatusCode)
+
+	// The route rule has its own budget: the default rule is untouched.
+	resp := do(t, app, fiber.MethodGet, "/api/v1/bookings", alice)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get(middleware.HeaderRateLimitRemaining))
+}
+
+func TestRateLimit_UnauthenticatedKeysShareTheIP(t *testing.T) {
+	app := newRateLimitApp(t, rateLimitCfg, ratelimit.NewMemoryStore(), metrics.NewNoOpMetrics())
+
+	first := map[string]string{"X-API-Key": "random-1", middleware.HeaderClientApp: "alice-app"}
+	assert.Equal(t, fiber.StatusNoContent, do(t, app, fiber.MethodPost, "/api/v1/bookings", first).StatusCode)
+
+	// Neither a new key nor a client app outside the gateway draws a new bucket.
+	second := map[string]string{"X-API-Key": "random-2", middleware.HeaderClientApp: "bob-app"}
+	assert.Equal(t, fiber.StatusTooManyRequests, do(t, app, fiber.MethodPost, "/api/v1/bookings", second).StatusCode)
+}
+
+func TestRateLimit_ClientIPBehindTrustedProxy(t *testing.T) {
+	// app.Test requests come from 0.0.0.0.
+	app := newRateLimitAppBehind(t, []string{"0.0.0.0", "10.0.0.0/8"}, rateLimitCfg, ratelimit.NewMemoryStore(), metrics.NewNoOpMetrics())
+	post := func(forwardedFor string) int {
+		return do(t, app, fiber.MethodPost, "/api/v1/bookings", map[string]string{fiber.HeaderXForwardedFor: forwardedFor}).StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusNoContent, post("203.0.113.7, 10.0.0.2"))
+	assert.Equal(t, fiber.StatusTooManyRequests, post("203.0.113.7"))
+
+	// Another client behind the load balancer: counted separately.
+	assert.Equal(t, fiber.StatusNoContent, post("198.51.100.9"))
+
+	// A forged leftmost address does not change the client.
+	assert.Equal(t, fiber.StatusTooManyRequests, post("192.0.2.1, 198.51.100.9, 10.0.0.2"))
+}
+
+func TestRateLimit_ForwardedForOfUntrustedPeersIsIgnored(t *testing.T) {
+	app := newRateLimitAppBehind(t, []string{"10.0.0.0/8"}, rateLimitCfg, ratelimit.NewMemoryStore(), metrics.NewNoOpMetrics())
+	post := func(forwardedFor string) int {
+		return do(t, app, fiber.MethodPost, "/api/v1/bookings", map[string]string{fiber.HeaderXForwardedFor: forwardedFor}).StatusCode
+	}
+
+	assert.Equal(t, fiber.StatusNoContent, post("203.0.113.7"))
+	assert.Equal(t, fiber.StatusTooManyRequests, post("198.51.100.9"))
+}
+
+func TestRateLimit_FailsOpen(t *testing.T) {
+	m := newRecordingMetrics()
+	app := newRateLimitApp(t, rateLimitCfg, failingStore{}, m)
+
+	for i := 0; i < 3; i++ {
+		resp := do(t, app, fiber.MethodGet, "/api/v1/bookings", nil)
+		assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	}
+	assert.Contains(t, m.counters, "ratelimit.errors")
+	assert.NotContains(t, m.counters, "ratelimit.throttled")
+}
@@ -0,0 +1,240 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// ============================================================================
+// FAKES
+// ============================================================================
+
+type recordingSpan struct {
+	mu   sync.Mutex
+	tags map[string]any
+}
+
+func (s *recordingSpan) SetOperationName(string)         {}
+func (s *recordingSpan) Finish()                         {}
+func (s *recordingSpan) AddEvent(string, map[string]any) {}
+func (s *recordingSpan) RecordError(error)               {}
+func (s *recordingSpan) SetTag(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tags[key] = value
+}
+
+type fakeTracer struct {
+	span *recordingSpan
+}
+
+func (t *fakeTracer) StartSpan(ctx context.Context, _ string) (tracer.Span, context.Context) {
+	return t.span, ctx
+}
+func (t *fakeTracer) StartSpanWithLinks(ctx context.Context, _ string, _ ...tracer.Link) (tracer.Span, context.Context) {
+	return t.span, ctx
+}
+func (t *fakeTracer) Extract(ctx context.Context, _ tracer.Carrier) context.Context {
+	return ctx
+}
+func (t *fakeTracer) Inject(context.Context, tracer.Setter) {}
+func (t *fakeTracer) UseGorm(*gorm.DB)                      {}
+func (t *fakeTracer) ExtractTraceInfo(context.Context) (string, string, bool) {
+	return "trace-123", "span-456", true
+}
+func (t *fakeTracer) Close() error { return nil }
+
+type recordingMetrics struct {
+	metrics.Metrics
+	mu       sync.Mutex
+	counters map[string][]string
+	gauges   map[string][]float64
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{
+		Metrics:  metrics.NewNoOpMetrics(),
+		counters: map[string][]string{},
+		gauges:   map[string][]float64{},
+	}
+}
+
+func (m *recordingMetrics) Incr(name string, tags []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters[name] = tags
+}
+
+func (m *recordingMetrics) Gauge(name string, value float64, _ []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gauges[name] = append(m.gauges[name], value)
+}
+
+// recordingLogger keeps the fields and message of every Error entry.
+type recordingLogger struct {
+	fields map[string]any
+	errors *[]map[string]any
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{fields: map[string]any{}, errors: &[]map[string]any{}}
+}
+
+func (l *recordingLogger) WithContext(context.Context) logger.Logger { return l }
+func (l *recordingLogger) WithField(key string, value any) logger.Logger {
+	return l.WithFields(map[string]any{key: value})
+}
+func (l *recordingLogger) WithFields(fields map[string]any) logger.Logger {
+	merged := make(map[string]any, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &recordingLogger{fields: merged, errors: l.errors}
+}
+func (l *recordingLogger) Debug(string) {}
+func (l *recordingLogger) Info(string)  {}
+func (l *recordingLogger) Warn(string)  {}
+func (l *recordingLogger) Error(message string) {
+	entry := map[string]any{"message": message}
+	for k, v := range l.fields {
+		entry[k] = v
+	}
+	*l.errors = append(*l.errors, entry)
+}
+
+// newRecoverApp mounts the telemetry chain the way the bootstrap does.
+func newRecoverApp(log logger.Logger, trc tracer.Tracer, m metrics.Metrics) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			var appErr *apperror.AppError
+			if errors.As(err, &appErr) {
+				return c.Status(appErr.GetHttpStatus()).JSON(response.Http{
+					Message:     appErr.Message,
+					ErrorCode:   appErr.Code,
+					IsRetryable: appErr.IsRetryable(),
+				})
+			}
+			return c.SendStatus(fiber.StatusInternalServerError)
+		},
+	})
+
+	t := middleware.NewTelemetrist(log, trc, m)
+	app.Use(t.HandleTrace())
+	app.Use(t.HandleLog())
+	app.Use(t.HandleRecover())
+	return app
+}
+
+// ============================================================================
+// TESTS
+// ============================================================================
+
+func TestHandleRecover_ConvertsPanic(t *testing.T) {
+	span := &recordingSpan{tags: map[string]any{}}
+	m := newRecordingMetrics()
+	log := newRecordingLogger()
+
+	app := newRecoverApp(log, &fakeTracer{span: span}, m)
+	app.Get("/bookings/:id", func(c *fiber.Ctx) error {
+		panic("nil booking repository")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/bookings/42", nil), -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// The client receives the generic internal error, not the panic value.
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var body response.Http
+	require.NoError(t, json.Unmarshal(raw, &body))
+	assert.Equal(t, apperror.CodeInternalError, body.ErrorCode)
+	assert.Equal(t, apperror.ErrCodeInternalError.Message, body.Message)
+	assert.False(t, body.IsRetryable)
+	assert.NotContains(t, string(raw), "nil booking repository")
+
+	// The span carries the stack trace.
+	assert.Equal(t, true, span.tags["error"])
+	assert.Equal(t, "panic", span.tags["error.type"])
+	assert.Equal(t, "nil booking repository", span.tags["panic.value"])
+	assert.Contains(t, span.tags["error.stack"], "recover_test.go")
+
+	// A dedicated error log carries the trace ID and the stack trace.
+	var panicLog map[string]any
+	for _, entry := range *log.errors {
+		if entry["message"] == "http handler panicked" {
+			panicLog = entry
+		}
+	}
+	require.NotNil(t, panicLog, "panic log not emitted")
+	assert.Equal(t, "trace-123", panicLog["trace_id"])
+	assert.Equal(t, "/bookings/:id", panicLog["route"])
+	assert.Equal(t, "nil booking repository", panicLog["panic"])
+	assert.Contains(t, panicLog["stack"], "recover_test.go")
+
+	// The panic is counted.
+	assert.Equal(t, []string{"transport:http", "route:/bookings/:id"}, m.counters["panic_total"])
+}
+
+func TestHandleRecover_KeepsErrorPanicsAsCause(t *testing.T) {
+	var got error
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			got = err
+			return c.SendStatus(fiber.StatusInternalServerError)
+		},
+	})
+	tm := middleware.NewTelemetrist(logger.NewNoOpLogger(), tracer.NewNoOpTracer(), metrics.NewNoOpMetrics())
+	app.Use(tm.HandleRecover())
+
+	cause := errors.New("boom")
+	app.Get("/", func(c *fiber.Ctx) error {
+		panic(cause)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), -1)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	var appErr *apperror.AppError
+	require.True(t, errors.As(got, &appErr))
+	assert.Equal(t, apperror.CodeInternalError, appErr.Code)
+	assert.ErrorIs(t, got, cause)
+}
+
+func TestHandleRecover_PassesThroughWithoutPanic(t *testing.T) {
+	m := newRecordingMetrics()
+	app := newRecoverApp(logger.NewNoOpLogger(), tracer.NewNoOpTracer(), m)
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), -1)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	assert.NotContains(t, m.counters, "panic_total")
+}
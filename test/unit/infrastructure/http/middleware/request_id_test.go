@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"strings"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/uid"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	var seen string
+	app := fiber.New()
+	app.Use(middleware.RequestID())
+	app.Get("/", func(c *fiber.Ctx) error {
+		seen = ctxkey.GetRequestID(c.UserContext())
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return apperror.NewPersistance(apperror.CodeNotFound, "not found")
+	})
+
+	tests := map[string]struct {
+		header string
+		want   string // empty when a new ID is generated
+	}{
+		"caller id":   {"0b5f8c1e-7c1d-4b7a-9d3e-2f6a1c9e4d21", "0b5f8c1e-7c1d-4b7a-9d3e-2f6a1c9e4d21"},
+		"gateway id":  {"Root=1-67891233-abcdef012345678912345678", "Root=1-67891233-abcdef012345678912345678"},
+		"missing":     {"", ""},
+		"log forging": {"abc\" level=error msg=\"forged", ""},
+		"too long":    {strings.Repeat("a", 129), ""},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := do(t, app, fiber.MethodGet, "/", map[string]string{fiber.HeaderXRequestID: tt.header})
+
+			got := resp.Header.Get(fiber.HeaderXRequestID)
+			assert.Equal(t, seen, got, "the context carries the echoed ID")
+			if tt.want == "" {
+				assert.True(t, uid.IsValidRequestID(got), "a new ID is generated, got %q", got)
+				assert.NotEqual(t, tt.header, got)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("error response", func(t *testing.T) {
+		resp := do(t, app, fiber.MethodGet, "/fail", map[string]string{fiber.HeaderXRequestID: "req-123"})
+		assert.Equal(t, "req-123", resp.Header.Get(fiber.HeaderXRequestID))
+	})
+}
@@ -0,0 +1,186 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+func newSecurityApp(handlers ...fiber.Handler) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			var appErr *apperror.AppError
+			if errors.As(err, &appErr) {
+				return c.Status(appErr.GetHttpStatus()).SendString(appErr.Code)
+			}
+			return c.SendStatus(fiber.StatusInternalServerError)
+		},
+	})
+	for _, h := range handlers {
+		app.Use(h)
+	}
+
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+	app.Get("/api/v1/bookings", ok)
+	app.Post("/api/v1/bookings", ok)
+	app.Post("/api/v1/callbacks", ok)
+	return app
+}
+
+func cookie(resp *http.Response, name string) *http.Cookie {
+	for _, c := range resp.Cookies() {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// SECURITY HEADERS
+// ============================================================================
+
+func TestSecurityHeaders_Defaults(t *testing.T) {
+	app := newSecurityApp(middleware.SecurityHeaders(config.SecurityHeadersConfig{Enabled: true}))
+
+	resp := do(t, app, fiber.MethodGet, "/api/v1/bookings", nil)
+	assert.Equal(t, "nosniff", resp.Header.Get(fiber.HeaderXContentTypeOptions))
+	assert.Equal(t, "DENY", resp.Header.Get(fiber.HeaderXFrameOptions))
+	assert.Equal(t, "no-referrer", resp.Header.Get(fiber.HeaderReferrerPolicy))
+	assert.Empty(t, resp.Header.Get(fiber.HeaderContentSecurityPolicy))
+	assert.Empty(t, resp.Header.Get(fiber.HeaderStrictTransportSecurity), "HSTS is opt-in")
+}
+
+func TestSecurityHeaders_Configured(t *testing.T) {
+	app := newSecurityApp(middleware.SecurityHeaders(config.SecurityHeadersConfig{
+		Enabled:               true,
+		ContentSecurityPolicy: "default-src 'none'",
+		FrameOptions:          "SAMEORIGIN",
+		HSTSMaxAge:            31536000,
+		HSTSIncludeSubdomains: true,
+	}))
+
+	// Headers are also set on error responses.
+	resp := do(t, app, fiber.MethodGet, "/missing", nil)
+	assert.Equal(t, "default-src 'none'", resp.Header.Get(fiber.HeaderContentSecurityPolicy))
+	assert.Equal(t, "SAMEORIGIN", resp.Header.Get(fiber.HeaderXFrameOptions))
+	assert.Equal(t, "max-age=31536000; includeSubDomains", resp.Header.Get(fiber.HeaderStrictTransportSecurity))
+}
+
+// ============================================================================
+// CORS
+// ============================================================================
+
+var corsCfg = config.CORSConfig{
+	Enabled:        true,
+	AllowedOrigins: []string{"https://app.voyago.com", "https://*.partners.voyago.com"},
+	AllowedMethods: []string{fiber.MethodGet, fiber.MethodPost},
+	AllowedHeaders: []string{"Content-Type", "X-CSRF-Token"},
+	ExposedHeaders: []string{"X-Request-ID"},
+	MaxAge:         600,
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	app := newSecurityApp(middleware.CORS(corsCfg))
+
+	resp := do(t, app, fiber.MethodOptions, "/api/v1/bookings", map[string]string{
+		fiber.HeaderOrigin:                     "https://app.voyago.com",
+		fiber.HeaderAccessControlRequestMethod: fiber.MethodPost,
+	})
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "https://app.voyago.com", resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "GET,POST", resp.Header.Get(fiber.HeaderAccessControlAllowMethods))
+	assert.Equal(t, "Content-Type,X-CSRF-Token", resp.Header.Get(fiber.HeaderAccessControlAllowHeaders))
+	assert.Equal(t, "600", resp.Header.Get(fiber.HeaderAccessControlMaxAge))
+}
+
+func TestCORS_Origins(t *testing.T) {
+	app := newSecurityApp(middleware.CORS(corsCfg))
+
+	tests := map[string]string{
+		"https://app.voyago.com":          "https://app.voyago.com",
+		"https://eu.partners.voyago.com":  "https://eu.partners.voyago.com",
+		"https://evil.example.com":        "",
+		"https://app.voyago.com.evil.com": "",
+	}
+	for origin, want := range tests {
+		t.Run(origin, func(t *testing.T) {
+			resp := do(t, app, fiber.MethodGet, "/api/v1/bookings", map[string]string{fiber.HeaderOrigin: origin})
+			assert.Equal(t, want, resp.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+		})
+	}
+}
+
+func TestCORS_RejectsCredentialsForEveryOrigin(t *testing.T) {
+	assert.Panics(t, func() {
+		middleware.CORS(config.CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true})
+	})
+}
+
+// ============================================================================
+// CSRF
+// ============================================================================
+
+func TestCSRF_DoubleSubmitCookie(t *testing.T) {
+	app := newSecurityApp(middleware.CSRF(config.CSRFConfig{Enabled: true, CookieSecure: true}))
+
+	// A safe request issues the token.
+	resp := do(t, app, fiber.MethodGet, "/api/v1/bookings", nil)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	issued := cookie(resp, "csrf_token")
+	require.NotNil(t, issued)
+	assert.NotEmpty(t, issued.Value)
+	assert.True(t, issued.Secure)
+	assert.False(t, issued.HttpOnly, "the front-end reads the token")
+	assert.Equal(t, http.SameSiteLaxMode, issued.SameSite)
+
+	// The token is kept while the cookie is sent back.
+	resp = do(t, app, fiber.MethodGet, "/api/v1/bookings", map[string]string{"Cookie": "csrf_token=" + issued.Value})
+	assert.Nil(t, cookie(resp, "csrf_token"))
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{
+		{"echoed token", map[string]string{"Cookie": "csrf_token=" + issued.Value, "X-CSRF-Token": issued.Value}, fiber.StatusNoContent},
+		{"missing header", map[string]string{"Cookie": "csrf_token=" + issued.Value}, fiber.StatusForbidden},
+		{"missing cookie", map[string]string{"X-CSRF-Token": issued.Value}, fiber.StatusForbidden},
+		{"mismatch", map[string]string{"Cookie": "csrf_token=" + issued.Value, "X-CSRF-Token": "forged"}, fiber.StatusForbidden},
+		{"bearer token", map[string]string{"Authorization": "Bearer abc"}, fiber.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := do(t, app, fiber.MethodPost, "/api/v1/bookings", tt.headers)
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+}
+
+func TestCSRF_ExemptPaths(t *testing.T) {
+	app := newSecurityApp(middleware.CSRF(config.CSRFConfig{
+		Enabled:     true,
+		CookieName:  "xsrf",
+		HeaderName:  "X-XSRF-Token",
+		ExemptPaths: []string{"/api/v1/callbacks"},
+	}))
+
+	assert.Equal(t, fiber.StatusNoContent, do(t, app, fiber.MethodPost, "/api/v1/callbacks", nil).StatusCode)
+	assert.Equal(t, fiber.StatusForbidden, do(t, app, fiber.MethodPost, "/api/v1/bookings", nil).StatusCode)
+	assert.Equal(t, fiber.StatusNoContent, do(t, app, fiber.MethodPost, "/api/v1/bookings", map[string]string{
+		"Cookie":       "xsrf=token",
+		"X-XSRF-Token": "token",
+	}).StatusCode)
+}
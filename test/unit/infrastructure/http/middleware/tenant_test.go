@@ -0,0 +1,108 @@
+package middleware_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/gateway"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTenantApp(cfg config.TenancyConfig) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			var appErr *apperror.AppError
+			if errors.As(err, &appErr) {
+				return c.Status(appErr.GetHttpStatus()).SendString(appErr.Message)
+			}
+			return c.SendStatus(fiber.StatusInternalServerError)
+		},
+	})
+	app.Use(middleware.Identity(config.GatewayConfig{Secret: gatewaySecret}, cfg))
+	app.Use(middleware.Tenant(cfg, config.GatewayConfig{Secret: gatewaySecret}, "/admin"))
+	echo := func(c *fiber.Ctx) error { return c.SendString(ctxkey.GetTenantID(c.UserContext())) }
+	for _, path := range []string{"/health", "/admin/audit", "/api/v1/bookings"} {
+		app.Get(path, echo)
+	}
+	return app
+}
+
+// tenantOf returns the tenant stored in the context of a request.
+func tenantOf(t *testing.T, app *fiber.App, headers map[string]string) string {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/bookings", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if host, ok := headers["Host"]; ok {
+		req.Host = host
+	}
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestTenant_Resolvers(t *testing.T) {
+	app := newTenantApp(config.TenancyConfig{
+		Resolvers:  []string{config.TenantResolverSubdomain, config.TenantResolverHeader},
+		BaseDomain: "voyago.com",
+	})
+
+	assert.Equal(t, "acme", tenantOf(t, app, map[string]string{"Host": "acme.voyago.com", "X-Tenant-ID": "globex", gateway.HeaderSecret: gatewaySecret}), "the first resolver wins")
+	assert.Equal(t, "globex", tenantOf(t, app, map[string]string{"Host": "voyago.com", "X-Tenant-ID": "globex", gateway.HeaderSecret: gatewaySecret}))
+	assert.Equal(t, "acme", tenantOf(t, app, map[string]string{"Host": "acme.voyago.com"}), "the subdomain needs no gateway")
+	assert.Empty(t, tenantOf(t, app, map[string]string{"Host": "voyago.com", "X-Tenant-ID": "globex"}), "the header of an untrusted request is ignored")
+}
+
+func TestTenant_ClaimNeedsTheGateway(t *testing.T) {
+	app := newTenantApp(config.TenancyConfig{Resolvers: []string{config.TenantResolverClaim}})
+	enc := base64.RawURLEncoding.EncodeToString
+	token := "Bearer " + enc([]byte(`{"alg":"none"}`)) + "." + enc([]byte(`{"tenant_id":"globex"}`)) + ".x"
+
+	assert.Equal(t, "globex", tenantOf(t, app, map[string]string{"Authorization": token, gateway.HeaderSecret: gatewaySecret}))
+	assert.Empty(t, tenantOf(t, app, map[string]string{"Authorization": token}), "an unverified token is ignored")
+}
+
+func TestTenant_KeepsIdentityTenant(t *testing.T) {
+	app := newTenantApp(config.TenancyConfig{Enabled: true, Resolvers: []string{config.TenantResolverSubdomain}, BaseDomain: "voyago.com"})
+
+	assert.Equal(t, "globex", tenantOf(t, app, map[string]string{"Host": "voyago.com", "X-Tenant-ID": "globex", gateway.HeaderSecret: gatewaySecret}),
+		"no resolver found a tenant: the one of the identity headers is kept")
+	assert.Equal(t, "acme", tenantOf(t, app, map[string]string{"Host": "acme.voyago.com", "X-Tenant-ID": "globex", gateway.HeaderSecret: gatewaySecret}),
+		"a resolved tenant replaces it")
+}
+
+func TestTenant_Required(t *testing.T) {
+	app := newTenantApp(config.TenancyConfig{Required: true})
+
+	assert.Equal(t, fiber.StatusBadRequest, do(t, app, fiber.MethodGet, "/api/v1/bookings", nil).StatusCode)
+	assert.Equal(t, fiber.StatusBadRequest, do(t, app, fiber.MethodGet, "/api/v1/bookings", map[string]string{"X-Tenant-ID": "bad tenant"}).StatusCode)
+	assert.Equal(t, fiber.StatusBadRequest, do(t, app, fiber.MethodGet, "/api/v1/bookings", map[string]string{"X-Tenant-ID": "acme"}).StatusCode)
+	assert.Equal(t, fiber.StatusOK, do(t, app, fiber.MethodGet, "/api/v1/bookings", map[string]string{"X-Tenant-ID": "acme", gateway.HeaderSecret: gatewaySecret}).StatusCode)
+	assert.Equal(t, fiber.StatusOK, do(t, app, fiber.MethodGet, "/health", nil).StatusCode)
+	assert.Equal(t, fiber.StatusOK, do(t, app, fiber.MethodGet, "/admin/audit", nil).StatusCode)
+}
+
+func TestTenant_PanicsOnInvalidConfig(t *testing.T) {
+	assert.Panics(t, func() { middleware.Tenant(config.TenancyConfig{Resolvers: []string{"cookie"}}, config.GatewayConfig{}) })
+	assert.Panics(t, func() {
+		middleware.Tenant(config.TenancyConfig{Resolvers: []string{config.TenantResolverSubdomain}}, config.GatewayConfig{})
+	})
+	assert.Panics(t, func() {
+		middleware.Tenant(config.TenancyConfig{}, config.GatewayConfig{TrustedIPs: []string{"gateway"}})
+	})
+}
@@ -0,0 +1,212 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+// serve runs a GET "/" through app.
+func serve(t *testing.T, app *fiber.App) {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), -1)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
+
+// newApp returns an app whose chain error is captured in *got.
+func newApp(got *error) *fiber.App {
+	return fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			*got = err
+			return c.SendStatus(fiber.StatusTeapot)
+		},
+	})
+}
+
+// waitDeadline blocks until the request context is done and returns its error.
+func waitDeadline(c *fiber.Ctx) error {
+	<-c.UserContext().Done()
+	return c.UserContext().Err()
+}
+
+func requireTimeout(t *testing.T, err error) {
+	t.Helper()
+
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr), "expected an AppError, got %v", err)
+	assert.Equal(t, apperror.CodeRequestTimeout, appErr.Code)
+	assert.Equal(t, fiber.StatusRequestTimeout, appErr.GetHttpStatus())
+	assert.True(t, appErr.IsRetryable())
+}
+
+// ============================================================================
+// DEADLINE
+// ============================================================================
+
+func TestTimeout_SetsDeadline(t *testing.T) {
+	var got error
+	var deadline time.Time
+	var hasDeadline bool
+	app := newApp(&got)
+	app.Use(middleware.Timeout(time.Minute))
+	app.Get("/", func(c *fiber.Ctx) error {
+		deadline, hasDeadline = c.UserContext().Deadline()
+		return nil
+	})
+
+	serve(t, app)
+
+	require.NoError(t, got)
+	require.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+}
+
+func TestTimeout_ZeroDisables(t *testing.T) {
+	var hasDeadline bool
+	var got error
+	app := newApp(&got)
+	app.Use(middleware.Timeout(0))
+	app.Get("/", func(c *fiber.Ctx) error {
+		_, hasDeadline = c.UserContext().Deadline()
+		return nil
+	})
+
+	serve(t, app)
+
+	require.NoError(t, got)
+	assert.False(t, hasDeadline)
+}
+
+func TestTimeout_ConvertsDeadlineExceeded(t *testing.T) {
+	var got error
+	app := newApp(&got)
+	app.Use(middleware.Timeout(10 * time.Millisecond))
+	app.Get("/", waitDeadline)
+
+	serve(t, app)
+
+	requireTimeout(t, got)
+	assert.ErrorIs(t, got, context.DeadlineExceeded, "the cause is kept")
+}
+
+func TestTimeout_KeepsErrorsBeforeDeadline(t *testing.T) {
+	var got error
+	app := newApp(&got)
+	app.Use(middleware.Timeout(time.Minute))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return apperror.ErrCodeNotFound
+	})
+
+	serve(t, app)
+
+	assert.Equal(t, apperror.ErrCodeNotFound, got)
+}
+
+func TestTimeout_KeepsSuccessAfterDeadline(t *testing.T) {
+	var got error
+	app := newApp(&got)
+	app.Use(middleware.Timeout(10 * time.Millisecond))
+	app.Get("/", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	serve(t, app)
+
+	assert.NoError(t, got)
+}
+
+// ============================================================================
+// PER-ROUTE OVERRIDE
+// ============================================================================
+
+func TestTimeout_RouteOverrideExtendsDeadline(t *testing.T) {
+	var got error
+	app := newApp(&got)
+	app.Use(middleware.Timeout(10 * time.Millisecond))
+	app.Get("/", middleware.Timeout(time.Minute), func(c *fiber.Ctx) error {
+		time.Sleep(30 * time.Millisecond)
+		if err := c.UserContext().Err(); err != nil {
+			return err
+		}
+		return apperror.ErrCodeConflict
+	})
+
+	serve(t, app)
+
+	assert.Equal(t, apperror.ErrCodeConflict, got, "the outer deadline does not apply")
+}
+
+func TestTimeout_RouteOverrideShortensDeadline(t *testing.T) {
+	var got error
+	app := newApp(&got)
+	app.Use(middleware.Timeout(time.Minute))
+	app.Get("/", middleware.Timeout(10*time.Millisecond), waitDeadline)
+
+	serve(t, app)
+
+	requireTimeout(t, got)
+}
+
+func TestTimeout_RouteOverrideDisablesDeadline(t *testing.T) {
+	var hasDeadline bool
+	var got error
+	app := newApp(&got)
+	app.Use(middleware.Timeout(time.Minute))
+	app.Get("/", middleware.Timeout(0), func(c *fiber.Ctx) error {
+		_, hasDeadline = c.UserContext().Deadline()
+		return nil
+	})
+
+	serve(t, app)
+
+	require.NoError(t, got)
+	assert.False(t, hasDeadline)
+}
+
+// ============================================================================
+// GORM PROPAGATION
+// ============================================================================
+
+type timeoutItem struct {
+	ID string `gorm:"column:id;primaryKey"`
+}
+
+func TestTimeout_CancelsDatabaseQueries(t *testing.T) {
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), nil)
+	defer db.Close()
+	require.NoError(t, db.GetDB().AutoMigrate(&timeoutItem{}))
+
+	var got error
+	app := newApp(&got)
+	app.Use(middleware.Timeout(10 * time.Millisecond))
+	app.Get("/", func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		<-ctx.Done()
+
+		var items []timeoutItem
+		return database.MapDBError(db.WithContext(ctx).Find(&items).Error)
+	})
+
+	serve(t, app)
+
+	requireTimeout(t, got)
+	assert.ErrorIs(t, got, context.DeadlineExceeded)
+}
@@ -0,0 +1,41 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/http/middleware"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+func TestHandleTrace_JoinsCallerTrace(t *testing.T) {
+	// Nothing listens on the collector address: spans are never exported.
+	trc, err := tracer.NewOTelTracer("voyago-test", "test", "127.0.0.1:1", 1, config.TraceSamplingConfig{})
+	require.NoError(t, err)
+
+	var tenant string
+	app := fiber.New()
+	app.Use(middleware.NewTelemetrist(logger.NewNoOpLogger(), trc, metrics.NewNoOpMetrics()).HandleTrace())
+	app.Get("/", func(c *fiber.Ctx) error {
+		tenant = baggage.FromContext(c.UserContext()).Member("tenant").Value()
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	joined := do(t, app, fiber.MethodGet, "/", map[string]string{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"baggage":     "tenant=acme",
+	})
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", joined.Header.Get("X-Trace-Id"))
+	assert.Equal(t, "acme", tenant)
+
+	root := do(t, app, fiber.MethodGet, "/", nil)
+	assert.NotEmpty(t, root.Header.Get("X-Trace-Id"))
+	assert.NotEqual(t, "4bf92f3577b34da6a3ce929d0e0e4736", root.Header.Get("X-Trace-Id"), "a new trace is started")
+}
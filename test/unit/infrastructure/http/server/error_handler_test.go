@@ -0,0 +1,354 @@
+package server_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	server "voyago/core-api/internal/infrastructure/http"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/i18n"
+	"voyago/core-api/locales"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+// newApp returns the server app with routes failing with an AppError and a
+// plain error. The trace ID is set as the telemetry middleware does.
+func newApp(httpCfg config.HttpConfig) *fiber.App {
+	srv := server.NewServer(&config.Config{Http: httpCfg}, logger.NewNoOpLogger(), nil)
+
+	srv.App.Use(func(c *fiber.Ctx) error {
+		c.Locals("trace_id", "trace-123")
+		return c.Next()
+	})
+	srv.App.Get("/conflict", func(c *fiber.Ctx) error {
+		return apperror.NewPersistance(apperror.CodeConflict, "Booking code already exists").
+			WithDetail("code", "taken")
+	})
+	srv.App.Get("/unavailable", func(c *fiber.Ctx) error {
+		return apperror.NewTransient(apperror.CodeDbConnectionFailed, "Database connection failed")
+	})
+	return srv.App
+}
+
+func get(t *testing.T, app *fiber.App, path string) (int, string, map[string]any) {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil), -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(raw, &body))
+	return resp.StatusCode, resp.Header.Get(fiber.HeaderContentType), body
+}
+
+// ============================================================================
+// ERROR FORMATS
+// ============================================================================
+
+func TestErrorHandler_EnvelopeByDefault(t *testing.T) {
+	app := newApp(config.HttpConfig{})
+
+	status, contentType, body := get(t, app, "/conflict")
+
+	assert.Equal(t, fiber.StatusConflict, status)
+	assert.Equal(t, fiber.MIMEApplicationJSON, contentType)
+	assert.Equal(t, false, body["success"])
+	assert.Equal(t, apperror.CodeConflict, body["error_code"])
+	assert.Equal(t, "Booking code already exists", body["message"])
+	assert.Equal(t, "trace-123", body["trace_id"])
+	assert.NotContains(t, body, "type")
+}
+
+func TestErrorHandler_Problem(t *testing.T) {
+	app := newApp(config.HttpConfig{ErrorFormat: config.ErrorFormatProblem})
+
+	status, contentType, body := get(t, app, "/conflict")
+
+	assert.Equal(t, fiber.StatusConflict, status)
+	assert.Equal(t, "application/problem+json", contentType)
+	assert.Equal(t, map[string]any{
+		"type":       "about:blank",
+		"title":      "Conflict",
+		"status":     float64(fiber.StatusConflict),
+		"detail":     "Booking code already exists",
+		"instance":   "trace-123",
+		"error_code": apperror.CodeConflict,
+		"errors":     map[string]any{"code": "taken"},
+	}, body)
+}
+
+func TestErrorHandler_ProblemTypeAndRetryable(t *testing.T) {
+	app := newApp(config.HttpConfig{
+		ErrorFormat:     config.ErrorFormatProblem,
+		ProblemTypeBase: "https://docs.voyago.com/errors/",
+	})
+
+	status, _, body := get(t, app, "/unavailable")
+
+	assert.Equal(t, fiber.StatusInternalServerError, status)
+	assert.Equal(t, "https://docs.voyago.com/errors/"+apperror.CodeDbConnectionFailed, body["type"])
+	assert.Equal(t, "Internal Server Error", body["title"])
+	assert.Equal(t, true, body["is_retryable"])
+}
+
+func TestErrorHandler_ProblemForFiberErrors(t *testing.T) {
+	app := newApp(config.HttpConfig{ErrorFormat: config.ErrorFormatProblem})
+
+	status, contentType, body := get(t, app, "/missing")
+
+	assert.Equal(t, fiber.StatusNotFound, status)
+	assert.Equal(t, "application/problem+json", contentType)
+	assert.Equal(t, "Not Found", body["title"])
+	assert.Equal(t, "ERR_404", body["error_code"])
+}
+
+func TestErrorHandler_PayloadTooLarge(t *testing.T) {
+	app := newApp(config.HttpConfig{BodyLimit: 1024})
+	// Returned by fiber for bodies over http.body_limit (the request is
+	// rejected while being read, which app.Test cannot simulate).
+	app.Get("/upload", func(c *fiber.Ctx) error {
+		return fiber.ErrRequestEntityTooLarge
+	})
+
+	status, _, body := get(t, app, "/upload")
+
+	assert.Equal(t, fiber.StatusRequestEntityTooLarge, status)
+	assert.Equal(t, apperror.CodePayloadTooLarge, body["error_code"])
+}
+
+func TestErrorHandler_WrappedAppError(t *testing.T) {
+	app := newApp(config.HttpConfig{})
+	conflict := apperror.NewPersistance(apperror.CodeConflict, "Booking code already exists")
+	app.Get("/wrapped", func(c *fiber.Ctx) error {
+		return fmt.Errorf("create booking: %w", conflict)
+	})
+	app.Get("/joined", func(c *fiber.Ctx) error {
+		return errors.Join(errors.New("release seats: connection reset"), conflict)
+	})
+
+	for _, path := range []string{"/wrapped", "/joined"} {
+		status, _, body := get(t, app, path)
+
+		assert.Equal(t, fiber.StatusConflict, status, path)
+		assert.Equal(t, apperror.CodeConflict, body["error_code"], path)
+		assert.Equal(t, "Booking code already exists", body["message"], path)
+	}
+}
+
+func TestErrorHandler_WrappedFiberError(t *testing.T) {
+	app := newApp(config.HttpConfig{})
+	app.Get("/forbidden", func(c *fiber.Ctx) error {
+		return fmt.Errorf("check access: %w", fiber.ErrForbidden)
+	})
+
+	status, _, body := get(t, app, "/forbidden")
+
+	assert.Equal(t, fiber.StatusForbidden, status)
+	assert.Equal(t, "ERR_403", body["error_code"])
+}
+
+func TestErrorHandler_HidesTheMessageOfUnexpectedErrors(t *testing.T) {
+	app := newApp(config.HttpConfig{})
+	app.Get("/unexpected", func(c *fiber.Ctx) error {
+		return errors.New("dial tcp 10.0.3.7:5432: connection refused")
+	})
+
+	status, _, body := get(t, app, "/unexpected")
+
+	assert.Equal(t, fiber.StatusInternalServerError, status)
+	assert.Equal(t, "ERR_500", body["error_code"])
+	assert.Equal(t, "Internal Server Error", body["message"])
+}
+
+// ============================================================================
+// ERROR CATALOG
+// ============================================================================
+
+// warnLogger keeps the error_code field of every Warn entry.
+type warnLogger struct {
+	logger.Logger
+	code  any
+	codes *[]any
+}
+
+func (l *warnLogger) WithField(key string, value any) logger.Logger {
+	if key == "error_code" {
+		return &warnLogger{Logger: l.Logger, code: value, codes: l.codes}
+	}
+	return l
+}
+func (l *warnLogger) Warn(string) { *l.codes = append(*l.codes, l.code) }
+
+func TestErrorHandler_LogsTheCodesMissingFromTheCatalog(t *testing.T) {
+	log := &warnLogger{Logger: logger.NewNoOpLogger(), codes: &[]any{}}
+	srv := server.NewServer(&config.Config{}, log, nil)
+	srv.App.Get("/unregistered", func(c *fiber.Ctx) error {
+		return apperror.NewPersistance("SEAT_UNKNOWN", "Seat unknown")
+	})
+	srv.App.Get("/registered", func(c *fiber.Ctx) error {
+		return apperror.ErrCodeConflict.WithDetail("code", "taken")
+	})
+
+	for range 2 {
+		status, _, _ := get(t, srv.App, "/unregistered")
+		assert.Equal(t, fiber.StatusBadRequest, status)
+		get(t, srv.App, "/registered")
+	}
+
+	assert.Equal(t, []any{"SEAT_UNKNOWN"}, *log.codes, "once per code")
+}
+
+func TestErrorHandler_ProblemTypeOfTheCatalog(t *testing.T) {
+	errDocumented := apperror.NewPersistance("SEAT_DOCUMENTED", "Seat documented")
+	apperror.Register("seat", apperror.Def{Err: errDocumented, Status: fiber.StatusGone, DocURL: "https://docs.voyago.com/seats#documented"})
+
+	app := newApp(config.HttpConfig{
+		ErrorFormat:     config.ErrorFormatProblem,
+		ProblemTypeBase: "https://docs.voyago.com/errors/",
+	})
+	app.Get("/documented", func(c *fiber.Ctx) error { return errDocumented })
+
+	status, _, body := get(t, app, "/documented")
+
+	assert.Equal(t, fiber.StatusGone, status)
+	assert.Equal(t, "https://docs.voyago.com/seats#documented", body["type"])
+}
+
+// ============================================================================
+// ERROR METRICS
+// ============================================================================
+
+// recordingMetrics keeps the names and tags of the counters and timings.
+type recordingMetrics struct {
+	metrics.Metrics
+	counters map[string][][]string
+	timings  map[string][][]string
+}
+
+func (m *recordingMetrics) Incr(name string, tags []string) {
+	m.counters[name] = append(m.counters[name], tags)
+}
+
+func (m *recordingMetrics) Timing(name string, _ time.Duration, tags []string) {
+	m.timings[name] = append(m.timings[name], tags)
+}
+
+func TestErrorHandler_RecordsErrorMetrics(t *testing.T) {
+	m := &recordingMetrics{Metrics: metrics.NewNoOpMetrics(), counters: map[string][][]string{}, timings: map[string][][]string{}}
+	srv := server.NewServer(&config.Config{}, logger.NewNoOpLogger(), m)
+	srv.App.Get("/conflict", func(c *fiber.Ctx) error { return apperror.ErrCodeConflict })
+	srv.App.Get("/bookings/:id", func(c *fiber.Ctx) error {
+		return apperror.NewTransient(apperror.CodeDbConnectionFailed, "Database connection failed")
+	})
+	srv.App.Get("/panic", func(c *fiber.Ctx) error { return errors.New("nil pointer dereference") })
+
+	get(t, srv.App, "/conflict")
+	get(t, srv.App, "/bookings/42")
+	get(t, srv.App, "/panic")
+	get(t, srv.App, "/missing")
+
+	assert.Equal(t, [][]string{
+		{"code:CONFLICT", "kind:PERSISTANCE", "module:core", "retryable:false"},
+		{"code:DB_CONNECTION_FAILED", "kind:TRANSIENT", "module:core", "retryable:true"},
+		{"code:ERR_500", "kind:INTERNAL", "module:unknown", "retryable:false"},
+	}, m.counters["app_error.total"], "the routing errors of Fiber are not counted")
+	assert.Equal(t, [][]string{
+		{"method:GET", "route:/bookings/:id", "status_code:500"},
+		{"method:GET", "route:/panic", "status_code:500"},
+	}, m.timings["http.server_error.duration"], "only the 5xx latency")
+}
+
+// ============================================================================
+// LOCALIZED MESSAGES
+// ============================================================================
+
+func TestErrorHandler_LocalizedMessage(t *testing.T) {
+	b, err := i18n.Load(locales.FS())
+	require.NoError(t, err)
+	require.NoError(t, i18n.Configure(b, config.I18nConfig{}))
+
+	srv := server.NewServer(&config.Config{}, logger.NewNoOpLogger(), nil)
+	srv.App.Get("/catalog", func(c *fiber.Ctx) error { return apperror.ErrCodeConflict })
+	srv.App.Get("/custom", func(c *fiber.Ctx) error {
+		return apperror.NewPersistance(apperror.CodeConflict, "Booking code already exists")
+	})
+
+	request := func(path, language string) map[string]any {
+		req := httptest.NewRequest(fiber.MethodGet, path, nil)
+		req.Header.Set(fiber.HeaderAcceptLanguage, language)
+		resp, err := srv.App.Test(req, -1)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return body
+	}
+
+	english := request("/catalog", "en-US")
+	indonesian := request("/catalog", "id-ID,id;q=0.9,en;q=0.8")
+	assert.Equal(t, apperror.ErrCodeConflict.Message, english["message"])
+	assert.Equal(t, "Konflik", indonesian["message"])
+	assert.Equal(t, apperror.CodeConflict, indonesian["error_code"])
+
+	custom := request("/custom", "id")
+	assert.Equal(t, "Booking code already exists", custom["message"], "the messages of a single failure are kept")
+}
+
+// ============================================================================
+// RETRY-AFTER
+// ============================================================================
+
+func TestErrorHandler_RetryAfter(t *testing.T) {
+	srv := server.NewServer(&config.Config{}, logger.NewNoOpLogger(), nil)
+	srv.App.Get("/transient", func(c *fiber.Ctx) error {
+		return apperror.NewTransient(apperror.CodeDbConnectionFailed, "Database connection failed")
+	})
+	srv.App.Get("/too-many", func(c *fiber.Ctx) error { return apperror.ErrCodeTooManyRequests })
+	srv.App.Get("/limited", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderRetryAfter, "42")
+		return apperror.ErrCodeTooManyRequests
+	})
+	srv.App.Get("/unavailable", func(c *fiber.Ctx) error { return fiber.ErrServiceUnavailable })
+	srv.App.Get("/conflict", func(c *fiber.Ctx) error { return apperror.ErrCodeConflict })
+
+	retryAfter := func(path string) string {
+		resp, err := srv.App.Test(httptest.NewRequest(fiber.MethodGet, path, nil), -1)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.Header.Get(fiber.HeaderRetryAfter)
+	}
+
+	assert.Equal(t, "1", retryAfter("/transient"), "the default hint")
+	assert.Equal(t, "1", retryAfter("/too-many"))
+	assert.Equal(t, "42", retryAfter("/limited"), "the delay of the middleware is kept")
+	assert.Equal(t, "1", retryAfter("/unavailable"))
+	assert.Empty(t, retryAfter("/conflict"))
+}
+
+func TestErrorHandler_RetryAfterOfTheConfig(t *testing.T) {
+	app := newApp(config.HttpConfig{RetryAfter: 5})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/unavailable", nil), -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "5", resp.Header.Get(fiber.HeaderRetryAfter))
+}
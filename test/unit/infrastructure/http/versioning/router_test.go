@@ -0,0 +1,156 @@
+package versioning_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/http/versioning"
+	"voyago/core-api/internal/infrastructure/openapi"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+// setup registers GET /ping on v1 (answering "v1") and v2 (answering "v2").
+func setup(t *testing.T, cfg config.ApiConfig, docs *openapi.Spec) (*fiber.App, *versioning.Router) {
+	t.Helper()
+
+	app := fiber.New()
+	routes, err := versioning.NewRouter(app, cfg, docs)
+	require.NoError(t, err)
+
+	routes.Register(func(v *versioning.Group) {
+		v.Get("/ping", func(c *fiber.Ctx) error {
+			return c.SendString(v.Version.Name)
+		})
+		v.Document(openapi.Operation{Method: fiber.MethodGet, Path: "/ping", Summary: "Ping"})
+	}, versioning.V1, versioning.V2)
+
+	return app, routes
+}
+
+func get(t *testing.T, app *fiber.App, path string) (int, string, map[string]string) {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil), -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body := make([]byte, 16)
+	n, _ := resp.Body.Read(body)
+	headers := map[string]string{
+		"Deprecation": resp.Header.Get("Deprecation"),
+		"Sunset":      resp.Header.Get("Sunset"),
+		"Link":        resp.Header.Get("Link"),
+	}
+	return resp.StatusCode, string(body[:n]), headers
+}
+
+// ============================================================================
+// ROUTING
+// ============================================================================
+
+func TestRouter_DefaultsToV1UnderApiPrefix(t *testing.T) {
+	app, routes := setup(t, config.ApiConfig{}, nil)
+
+	status, body, _ := get(t, app, "/api/v1/ping")
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, "v1", body)
+
+	status, _, _ = get(t, app, "/api/v2/ping")
+	assert.Equal(t, fiber.StatusNotFound, status, "unconfigured versions are not served")
+
+	require.Len(t, routes.Versions(), 1)
+	assert.Equal(t, "v1", routes.Versions()[0].Name)
+}
+
+func TestRouter_ServesEachConfiguredVersion(t *testing.T) {
+	app, _ := setup(t, config.ApiConfig{
+		Prefix:   "/public/",
+		Versions: []config.ApiVersionConfig{{Name: "v1"}, {Name: "v2"}},
+	}, nil)
+
+	for _, version := range []string{"v1", "v2"} {
+		status, body, headers := get(t, app, "/public/"+version+"/ping")
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, version, body)
+		assert.Empty(t, headers["Deprecation"], "supported versions are not deprecated")
+	}
+}
+
+func TestRouter_DeprecatedVersionAdvertisesRetirement(t *testing.T) {
+	app, routes := setup(t, config.ApiConfig{
+		Versions: []config.ApiVersionConfig{
+			{Name: "v1", Deprecated: "2026-01-01", Sunset: "2026-12-31", Link: "https://docs.voyago.com/api/v2-migration"},
+			{Name: "v2"},
+		},
+	}, nil)
+
+	status, body, headers := get(t, app, "/api/v1/ping")
+	assert.Equal(t, fiber.StatusOK, status, "deprecated versions keep being served")
+	assert.Equal(t, "v1", body)
+	assert.Equal(t, "@1767225600", headers["Deprecation"])
+	assert.Equal(t, "Thu, 31 Dec 2026 00:00:00 GMT", headers["Sunset"])
+	assert.Equal(t, `<https://docs.voyago.com/api/v2-migration>; rel="deprecation"; type="text/html"`, headers["Link"])
+
+	_, _, headers = get(t, app, "/api/v2/ping")
+	assert.Empty(t, headers["Deprecation"])
+	assert.Empty(t, headers["Sunset"])
+
+	assert.True(t, routes.Versions()[0].IsDeprecated())
+	assert.False(t, routes.Versions()[1].IsDeprecated())
+}
+
+func TestNewRouter_RejectsInvalidConfiguration(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []config.ApiVersionConfig
+	}{
+		{name: "missing name", versions: []config.ApiVersionConfig{{Name: ""}}},
+		{name: "duplicate version", versions: []config.ApiVersionConfig{{Name: "v1"}, {Name: "v1"}}},
+		{name: "invalid date", versions: []config.ApiVersionConfig{{Name: "v1", Sunset: "31/12/2026"}}},
+		{name: "sunset before deprecation", versions: []config.ApiVersionConfig{{Name: "v1", Deprecated: "2026-06-01", Sunset: "2026-01-01"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := versioning.NewRouter(fiber.New(), config.ApiConfig{Versions: tt.versions}, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// ============================================================================
+// DOCUMENTATION
+// ============================================================================
+
+func TestGroup_Document_PrefixesPathsAndFlagsDeprecation(t *testing.T) {
+	docs := openapi.New(openapi.Info{Title: "test", Version: "1.0.0"})
+	setup(t, config.ApiConfig{
+		Versions: []config.ApiVersionConfig{{Name: "v1", Deprecated: "2026-01-01"}, {Name: "v2"}},
+	}, docs)
+
+	raw, err := docs.JSON()
+	require.NoError(t, err)
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Tags       []string `json:"tags"`
+			Deprecated bool     `json:"deprecated"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &doc))
+
+	require.Contains(t, doc.Paths, "/api/v1/ping")
+	require.Contains(t, doc.Paths, "/api/v2/ping")
+	assert.True(t, doc.Paths["/api/v1/ping"]["get"].Deprecated)
+	assert.False(t, doc.Paths["/api/v2/ping"]["get"].Deprecated)
+	assert.Equal(t, []string{"ping"}, doc.Paths["/api/v2/ping"]["get"].Tags)
+}
@@ -0,0 +1,314 @@
+package httpclient_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/httpclient"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+type recordingMetrics struct {
+	metrics.Metrics
+	mu      sync.Mutex
+	timings map[string][][]string
+	counts  map[string]int
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{
+		Metrics: metrics.NewNoOpMetrics(),
+		timings: map[string][][]string{},
+		counts:  map[string]int{},
+	}
+}
+
+func (m *recordingMetrics) Timing(name string, _ time.Duration, tags []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.timings[name] = append(m.timings[name], tags)
+}
+
+func (m *recordingMetrics) Incr(name string, _ []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts[name]++
+}
+
+// upstream answers the statuses in turn, then 200, and records the bodies it
+// received.
+type upstream struct {
+	*httptest.Server
+	calls  atomic.Int32
+	mu     sync.Mutex
+	bodies []string
+}
+
+func newUpstream(t *testing.T, statuses ...int) *upstream {
+	u := &upstream{}
+	u.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(u.calls.Add(1))
+		body, _ := io.ReadAll(r.Body)
+		u.mu.Lock()
+		u.bodies = append(u.bodies, string(body))
+		u.mu.Unlock()
+
+		if n <= len(statuses) {
+			if statuses[n-1] == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "5")
+			}
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(u.Close)
+	return u
+}
+
+func newClient(m metrics.Metrics, threshold int) *httpclient.Client {
+	return httpclient.New(config.HttpClientConfig{
+		Timeout: 1,
+		Retry: config.HttpClientRetryConfig{
+			MaxAttempts: 3,
+			BaseBackoff: 1,
+			MaxBackoff:  10,
+		},
+		CircuitBreaker: config.HttpClientCircuitBreakerConfig{
+			FailureThreshold: threshold,
+			OpenTimeout:      1,
+		},
+	}, tracer.NewNoOpTracer(), m)
+}
+
+func send(t *testing.T, c *httpclient.Client, method, url, body string, headers map[string]string) (int, error) {
+	t.Helper()
+
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), method, url, r)
+	require.NoError(t, err)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// ============================================================================
+// RETRIES
+// ============================================================================
+
+func TestDo_RetriesTransientStatuses(t *testing.T) {
+	m := newRecordingMetrics()
+	u := newUpstream(t, http.StatusServiceUnavailable, http.StatusBadGateway)
+
+	status, err := send(t, newClient(m, 0), http.MethodGet, u.URL, "", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.EqualValues(t, 3, u.calls.Load())
+
+	durations := m.timings["http_client_request_duration"]
+	require.Len(t, durations, 3, "every attempt is measured")
+	assert.Contains(t, durations[0], "status:503")
+	assert.Contains(t, durations[2], "status:200")
+	assert.Contains(t, durations[2], "method:GET")
+}
+
+func TestDo_GivesUpAfterMaxAttempts(t *testing.T) {
+	u := newUpstream(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+	status, err := send(t, newClient(newRecordingMetrics(), 0), http.MethodGet, u.URL, "", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, status, "the last response is returned")
+	assert.EqualValues(t, 3, u.calls.Load())
+}
+
+func TestDo_RetriesOnlyReplayableRequests(t *testing.T) {
+	tests := map[string]struct {
+		method  string
+		headers map[string]string
+		calls   int32
+	}{
+		"post":                 {http.MethodPost, nil, 1},
+		"post idempotency key": {http.MethodPost, map[string]string{httpclient.HeaderIdempotencyKey: "pay-42"}, 2},
+		"put":                  {http.MethodPut, nil, 2},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			u := newUpstream(t, http.StatusServiceUnavailable)
+
+			_, err := send(t, newClient(newRecordingMetrics(), 0), tt.method, u.URL, `{"amount":100}`, tt.headers)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.calls, u.calls.Load())
+			for _, body := range u.bodies {
+				assert.Equal(t, `{"amount":100}`, body, "the body is replayed")
+			}
+		})
+	}
+}
+
+func TestDo_NoRetryBeyondMaxBackoff(t *testing.T) {
+	u := newUpstream(t, http.StatusTooManyRequests)
+
+	status, err := send(t, newClient(newRecordingMetrics(), 0), http.MethodGet, u.URL, "", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, status, "Retry-After exceeds the maximum backoff")
+	assert.EqualValues(t, 1, u.calls.Load())
+}
+
+func TestDo_NoRetryOfClientErrors(t *testing.T) {
+	u := newUpstream(t, http.StatusBadRequest)
+
+	status, err := send(t, newClient(newRecordingMetrics(), 0), http.MethodGet, u.URL, "", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.EqualValues(t, 1, u.calls.Load())
+}
+
+// ============================================================================
+// CIRCUIT BREAKER
+// ============================================================================
+
+func TestDo_CircuitBreaker(t *testing.T) {
+	m := newRecordingMetrics()
+	u := newUpstream(t, http.StatusInternalServerError, http.StatusInternalServerError)
+	client := newClient(m, 2)
+
+	for range 2 {
+		status, err := send(t, client, http.MethodGet, u.URL, "", nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, status)
+	}
+
+	_, err := send(t, client, http.MethodGet, u.URL, "", nil)
+	assert.ErrorIs(t, err, httpclient.ErrCircuitOpen)
+	assert.EqualValues(t, 2, u.calls.Load(), "the upstream is not called while the circuit is open")
+	assert.Equal(t, 1, m.counts["http_client_circuit_rejected"])
+
+	time.Sleep(time.Second)
+
+	status, err := send(t, client, http.MethodGet, u.URL, "", nil)
+	require.NoError(t, err, "a trial request is let through after the open timeout")
+	assert.Equal(t, http.StatusOK, status)
+
+	status, err = send(t, client, http.MethodGet, u.URL, "", nil)
+	require.NoError(t, err, "the successful trial closed the circuit")
+	assert.Equal(t, http.StatusOK, status)
+}
+
+func TestDo_CircuitPerHost(t *testing.T) {
+	failing := newUpstream(t, http.StatusInternalServerError)
+	healthy := newUpstream(t)
+	client := newClient(newRecordingMetrics(), 1)
+
+	_, err := send(t, client, http.MethodGet, failing.URL, "", nil)
+	require.NoError(t, err)
+	_, err = send(t, client, http.MethodGet, failing.URL, "", nil)
+	require.ErrorIs(t, err, httpclient.ErrCircuitOpen)
+
+	status, err := send(t, client, http.MethodGet, healthy.URL, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+}
+
+// ============================================================================
+// CONTEXT & TRACING
+// ============================================================================
+
+func TestDo_StopsWhenContextDone(t *testing.T) {
+	u := newUpstream(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	client := httpclient.New(config.HttpClientConfig{
+		Retry: config.HttpClientRetryConfig{MaxAttempts: 3, BaseBackoff: 5000, MaxBackoff: 5000},
+	}, tracer.NewNoOpTracer(), metrics.NewNoOpMetrics())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.URL, nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualValues(t, 1, u.calls.Load())
+}
+
+func TestDo_InjectsTraceContext(t *testing.T) {
+	// Nothing listens on the collector address: spans are never exported.
+	trc, err := tracer.NewOTelTracer("voyago-test", "test", "127.0.0.1:1", 1, config.TraceSamplingConfig{})
+	require.NoError(t, err)
+
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer srv.Close()
+
+	span, ctx := trc.StartSpan(context.Background(), "caller")
+	defer span.Finish()
+	traceID, _, _ := trc.ExtractTraceInfo(ctx)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := httpclient.New(config.HttpClientConfig{}, trc, metrics.NewNoOpMetrics()).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.NotEmpty(t, traceparent)
+	assert.Contains(t, traceparent, traceID, "the upstream joins the caller's trace")
+	assert.Empty(t, req.Header.Get("traceparent"), "the caller's request is left untouched")
+}
+
+func TestDo_ForwardsRequestID(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get(httpclient.HeaderRequestID))
+	}))
+	defer srv.Close()
+	client := newClient(newRecordingMetrics(), 0)
+	ctx := ctxkey.SetRequestID(context.Background(), "req-123")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set(httpclient.HeaderRequestID, "explicit")
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{"req-123", "explicit"}, got, "an explicit header is kept")
+}
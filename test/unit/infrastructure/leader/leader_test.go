@@ -0,0 +1,84 @@
+package leader_test
+
+import (
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/leader"
+	"voyago/core-api/internal/infrastructure/lock"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ttl = 60 * time.Millisecond
+
+func newLocker(t *testing.T) (*miniredis.Miniredis, lock.Locker) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return mr, lock.NewRedisLocker(client, "test:lock:")
+}
+
+func newElector(locker lock.Locker) *leader.Elector {
+	return leader.New(locker, "scheduler:leader", ttl, logger.NewNoOpLogger(), metrics.NewNoOpMetrics())
+}
+
+func leaders(electors ...*leader.Elector) int {
+	n := 0
+	for _, e := range electors {
+		if e.IsLeader() {
+			n++
+		}
+	}
+	return n
+}
+
+func TestElector_ElectsASingleReplica(t *testing.T) {
+	_, locker := newLocker(t)
+	a, b, c := newElector(locker), newElector(locker), newElector(locker)
+	for _, e := range []*leader.Elector{a, b, c} {
+		e.Start()
+		defer e.Stop()
+	}
+
+	assert.Eventually(t, func() bool { return leaders(a, b, c) == 1 }, time.Second, 5*time.Millisecond)
+	// The leader extends its lock: the leadership outlives the TTL.
+	time.Sleep(3 * ttl)
+	assert.Equal(t, 1, leaders(a, b, c))
+}
+
+func TestElector_Stop_HandsTheLeadershipOver(t *testing.T) {
+	_, locker := newLocker(t)
+	a := newElector(locker)
+	a.Start()
+	require.Eventually(t, a.IsLeader, time.Second, 5*time.Millisecond)
+
+	b := newElector(locker)
+	b.Start()
+	defer b.Stop()
+	time.Sleep(ttl / 2)
+	assert.False(t, b.IsLeader())
+
+	a.Stop()
+	assert.False(t, a.IsLeader())
+	assert.Eventually(t, b.IsLeader, time.Second, 5*time.Millisecond)
+}
+
+func TestElector_StepsDownWhenItsLockIsLost(t *testing.T) {
+	mr, locker := newLocker(t)
+	e := newElector(locker)
+	e.Start()
+	defer e.Stop()
+	require.Eventually(t, e.IsLeader, time.Second, 5*time.Millisecond)
+
+	// Another replica took the leadership meanwhile, e.g. after a pause of
+	// the leader longer than the TTL.
+	mr.Set("test:lock:scheduler:leader", "other")
+	assert.Eventually(t, func() bool { return !e.IsLeader() }, time.Second, 5*time.Millisecond)
+}
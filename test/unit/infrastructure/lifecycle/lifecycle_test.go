@@ -0,0 +1,144 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/lifecycle"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder records the hooks in the order they complete.
+type recorder struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (r *recorder) hook(name string) lifecycle.Hook {
+	return func(context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.names = append(r.names, name)
+		return nil
+	}
+}
+
+func (r *recorder) order() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.names...)
+}
+
+func TestShutdown_RunsPhasesInOrder(t *testing.T) {
+	rec := &recorder{}
+	lc := lifecycle.New(config.ShutdownConfig{}, logger.NewNoOpLogger())
+
+	// Registered out of order on purpose.
+	lc.Register(lifecycle.PhaseTelemetry, "tracer", rec.hook("tracer"))
+	lc.Register(lifecycle.PhaseResources, "database", rec.hook("database"))
+	lc.Register(lifecycle.PhaseWorkers, "dispatcher", rec.hook("dispatcher"))
+	lc.Register(lifecycle.PhaseConsumers, "event bus", rec.hook("event bus"))
+	lc.Register(lifecycle.PhaseServers, "http server", rec.hook("http server"))
+
+	require.NoError(t, lc.Shutdown(context.Background()))
+	assert.Equal(t, []string{"http server", "event bus", "dispatcher", "database", "tracer"}, rec.order())
+
+	require.NoError(t, lc.Shutdown(context.Background()))
+	assert.Len(t, rec.order(), 5, "hooks run once")
+}
+
+func TestShutdown_RunsPhaseHooksConcurrently(t *testing.T) {
+	lc := lifecycle.New(config.ShutdownConfig{GracePeriod: 1}, logger.NewNoOpLogger())
+
+	// Each server waits for the other: they only finish when run together.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	waitBoth := func(context.Context) error {
+		wg.Done()
+		wg.Wait()
+		return nil
+	}
+	lc.Register(lifecycle.PhaseServers, "http server", waitBoth)
+	lc.Register(lifecycle.PhaseServers, "websocket hub", waitBoth)
+
+	assert.NoError(t, lc.Shutdown(context.Background()))
+}
+
+func TestShutdown_GracePeriod(t *testing.T) {
+	rec := &recorder{}
+	lc := lifecycle.New(config.ShutdownConfig{GracePeriod: 1}, logger.NewNoOpLogger())
+
+	lc.Register(lifecycle.PhaseServers, "stuck server", func(ctx context.Context) error {
+		select {} // ignores ctx
+	})
+	lc.Register(lifecycle.PhaseWorkers, "worker", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	lc.Register(lifecycle.PhaseResources, "database", rec.hook("database"))
+
+	start := time.Now()
+	err := lc.Shutdown(context.Background())
+
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "servers: stuck server")
+	assert.ErrorContains(t, err, "workers: worker")
+	assert.Equal(t, []string{"database"}, rec.order(), "resources are closed once the grace period is over")
+}
+
+func TestShutdown_CutShort(t *testing.T) {
+	rec := &recorder{}
+	lc := lifecycle.New(config.ShutdownConfig{GracePeriod: 30}, logger.NewNoOpLogger())
+	lc.Register(lifecycle.PhaseServers, "http server", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	lc.Register(lifecycle.PhaseResources, "database", rec.hook("database"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	err := lc.Shutdown(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"database"}, rec.order())
+}
+
+func TestShutdown_JoinsHookErrors(t *testing.T) {
+	lc := lifecycle.New(config.ShutdownConfig{}, logger.NewNoOpLogger())
+	errDB := errors.New("connection reset")
+	lc.Register(lifecycle.PhaseResources, "booking database", lifecycle.Closer(func() error { return errDB }))
+	lc.Register(lifecycle.PhaseTelemetry, "metrics", lifecycle.Closer(func() error { return nil }))
+
+	err := lc.Shutdown(context.Background())
+
+	assert.ErrorIs(t, err, errDB)
+	assert.EqualError(t, err, "resources: booking database: connection reset")
+}
+
+func TestRun_ShutsDownWhenStartFails(t *testing.T) {
+	rec := &recorder{}
+	lc := lifecycle.New(config.ShutdownConfig{}, logger.NewNoOpLogger())
+	lc.Register(lifecycle.PhaseResources, "database", rec.hook("database"))
+	errBind := errors.New("address already in use")
+
+	err := lc.Run(func() error { return errBind })
+
+	assert.ErrorIs(t, err, errBind)
+	assert.Equal(t, []string{"database"}, rec.order())
+}
+
+func TestRegister_UnknownPhase(t *testing.T) {
+	lc := lifecycle.New(config.ShutdownConfig{}, logger.NewNoOpLogger())
+
+	assert.Panics(t, func() {
+		lc.Register(lifecycle.Phase(42), "hook", lifecycle.Func(func() {}))
+	})
+}
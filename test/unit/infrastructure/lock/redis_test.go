@@ -0,0 +1,149 @@
+package lock_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/lock"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRedisLocker(t *testing.T) (lock.Locker, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return lock.NewRedisLocker(client, "test:lock:"), mr
+}
+
+func TestRedisLocker_TryLock_IsExclusive(t *testing.T) {
+	l, mr := newRedisLocker(t)
+	ctx := context.Background()
+
+	lk, err := l.TryLock(ctx, "booking-code:ABC", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "booking-code:ABC", lk.Key())
+	assert.True(t, mr.Exists("test:lock:booking-code:ABC"))
+
+	_, err = l.TryLock(ctx, "booking-code:ABC", time.Minute)
+	assert.ErrorIs(t, err, lock.ErrNotAcquired)
+	other, err := l.TryLock(ctx, "booking-code:XYZ", time.Minute)
+	require.NoError(t, err, "other keys are free")
+	require.NoError(t, other.Unlock(ctx))
+
+	require.NoError(t, lk.Unlock(ctx))
+	assert.ErrorIs(t, lk.Unlock(ctx), lock.ErrLockLost)
+	again, err := l.TryLock(ctx, "booking-code:ABC", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, again.Unlock(ctx))
+}
+
+func TestRedisLocker_ExpiredLockIsLost(t *testing.T) {
+	l, mr := newRedisLocker(t)
+	ctx := context.Background()
+
+	lk, err := l.TryLock(ctx, "relay", time.Second)
+	require.NoError(t, err)
+	require.NoError(t, lk.Extend(ctx, 10*time.Second))
+	mr.FastForward(5 * time.Second)
+	_, err = l.TryLock(ctx, "relay", time.Second)
+	assert.ErrorIs(t, err, lock.ErrNotAcquired, "extended")
+
+	mr.FastForward(10 * time.Second)
+	next, err := l.TryLock(ctx, "relay", time.Minute)
+	require.NoError(t, err, "expired")
+
+	assert.ErrorIs(t, lk.Extend(ctx, time.Minute), lock.ErrLockLost)
+	assert.ErrorIs(t, lk.Unlock(ctx), lock.ErrLockLost)
+	assert.True(t, mr.Exists("test:lock:relay"), "the new holder keeps it")
+	require.NoError(t, next.Unlock(ctx))
+}
+
+func TestRedisLocker_Lock_WaitsForRelease(t *testing.T) {
+	l, _ := newRedisLocker(t)
+	ctx := context.Background()
+
+	held, err := l.TryLock(ctx, "k", time.Minute)
+	require.NoError(t, err)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = held.Unlock(ctx)
+	}()
+
+	lk, err := l.Lock(ctx, "k", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, lk.Unlock(ctx))
+}
+
+func TestRedisLocker_Lock_GivesUpWhenContextDone(t *testing.T) {
+	l, _ := newRedisLocker(t)
+
+	held, err := l.TryLock(context.Background(), "k", time.Minute)
+	require.NoError(t, err)
+	defer held.Unlock(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = l.Lock(ctx, "k", time.Minute)
+	assert.ErrorIs(t, err, lock.ErrNotAcquired)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRedisLocker_RejectsInvalidArguments(t *testing.T) {
+	l, _ := newRedisLocker(t)
+
+	_, err := l.TryLock(context.Background(), "", time.Minute)
+	assert.Error(t, err)
+	_, err = l.TryLock(context.Background(), "k", 0)
+	assert.Error(t, err)
+}
+
+func TestWithLock_SerializesCriticalSections(t *testing.T) {
+	l, mr := newRedisLocker(t)
+	ctx := context.Background()
+
+	var inside, maxInside, runs int32
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := lock.WithLock(ctx, l, "section", time.Minute, func(context.Context) error {
+				n := atomic.AddInt32(&inside, 1)
+				for {
+					m := atomic.LoadInt32(&maxInside)
+					if n <= m || atomic.CompareAndSwapInt32(&maxInside, m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&inside, -1)
+				atomic.AddInt32(&runs, 1)
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(5), runs)
+	assert.Equal(t, int32(1), maxInside)
+	assert.False(t, mr.Exists("test:lock:section"), "unlocked")
+}
+
+func TestWithLock_ReturnsTheErrorOfFn(t *testing.T) {
+	l, mr := newRedisLocker(t)
+	boom := errors.New("boom")
+
+	err := lock.WithLock(context.Background(), l, "k", time.Minute, func(context.Context) error { return boom })
+
+	assert.ErrorIs(t, err, boom)
+	assert.False(t, mr.Exists("test:lock:k"), "unlocked on failure")
+}
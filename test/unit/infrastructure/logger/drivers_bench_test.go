@@ -0,0 +1,70 @@
+package logger_test
+
+import (
+	"context"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// requestLogger returns the logger of a request, as the middlewares derive it.
+func requestLogger(log logger.Logger) logger.Logger {
+	ctx := ctxkey.SetRequestID(context.Background(), "2f1c7a52-9d3e-4c1b-8a57-3f0e2d9b6c41")
+	return log.
+		WithFields(map[string]any{"service": "core-api", "domain": "booking"}).
+		WithContext(ctx).
+		WithField("component", "usecase")
+}
+
+// BenchmarkJSONDrivers measures logging a message with a request logger (hot
+// path) and deriving the request logger then logging (a request logging once).
+//
+//	go test ./test/unit/infrastructure/logger -run '^$' -bench JSONDrivers -benchmem
+func BenchmarkJSONDrivers(b *testing.B) {
+	for _, name := range []string{"logrus", "zap", "zerolog"} {
+		newLogger := jsonDrivers[name]
+
+		b.Run(name+"/Info", func(b *testing.B) {
+			log := requestLogger(newLogger(fileConfig(b, 4)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				log.Info("booking created")
+			}
+		})
+
+		b.Run(name+"/Request", func(b *testing.B) {
+			log := newLogger(fileConfig(b, 4))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				requestLogger(log).Info("booking created")
+			}
+		})
+
+		b.Run(name+"/Disabled", func(b *testing.B) {
+			log := requestLogger(newLogger(fileConfig(b, 4)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				log.Debug("booking created")
+			}
+		})
+	}
+}
+
+// TestJSONDrivers_Allocations guards the point of the zap and zerolog drivers:
+// logging a message allocates less than with logrus.
+func TestJSONDrivers_Allocations(t *testing.T) {
+	allocs := map[string]float64{}
+	for name, newLogger := range jsonDrivers {
+		log := requestLogger(newLogger(fileConfig(t, 4)))
+		allocs[name] = testing.AllocsPerRun(100, func() { log.Info("booking created") })
+	}
+
+	assert.Less(t, allocs["zap"], allocs["logrus"]/2, "allocs per entry: %v", allocs)
+	assert.Less(t, allocs["zerolog"], allocs["logrus"]/2, "allocs per entry: %v", allocs)
+}
@@ -0,0 +1,122 @@
+package logger_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonDrivers are the drivers writing JSON lines to log.path.
+var jsonDrivers = map[string]func(*config.Config) logger.Logger{
+	"logrus":  func(cfg *config.Config) logger.Logger { return logger.NewLogrus(cfg, nil) },
+	"zap":     func(cfg *config.Config) logger.Logger { return logger.NewZap(cfg, nil) },
+	"zerolog": func(cfg *config.Config) logger.Logger { return logger.NewZerolog(cfg, nil) },
+}
+
+func fileConfig(t testing.TB, level int) *config.Config {
+	cfg := &config.Config{Log: config.LogConfig{Path: filepath.Join(t.TempDir(), "app.log"), Level: level}}
+	cfg.Log.Rotation.MaxSize = 100
+	return cfg
+}
+
+// readEntries decodes the JSON lines of path, with the message under "msg"
+// whatever the driver.
+func readEntries(t *testing.T, path string) []map[string]any {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var entries []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		if msg, ok := entry["message"]; ok {
+			entry["msg"] = msg
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestJSONDrivers_Entry(t *testing.T) {
+	for name, newLogger := range jsonDrivers {
+		t.Run(name, func(t *testing.T) {
+			cfg := fileConfig(t, 4)
+			ctx := ctxkey.SetRequestID(context.Background(), "req-1")
+			ctx = ctxkey.SetRoles(ctxkey.SetUserID(ctx, "user-1"), []string{"ops", "admin"})
+			ctx = ctxkey.SetTenantID(ctx, "tenant-1")
+
+			newLogger(cfg).
+				WithContext(ctx).
+				WithFields(map[string]any{"domain": "booking", "password": "s3cret"}).
+				WithField("attempt", 2).
+				Warn("payment retried")
+
+			entries := readEntries(t, cfg.Log.Path)
+			require.Len(t, entries, 1)
+			e := entries[0]
+			assert.Equal(t, "payment retried", e["msg"])
+			assert.Contains(t, []any{"warn", "warning"}, e["level"], "logrus names the level warning")
+			assert.Equal(t, "req-1", e["request_id"])
+			assert.Equal(t, "user-1", e["user_id"])
+			assert.Equal(t, "ops,admin", e["user_roles"])
+			assert.Equal(t, "tenant-1", e["tenant_id"])
+			assert.NotContains(t, e, "client_app", "the unknown caller fields are omitted")
+			assert.Equal(t, "booking", e["domain"])
+			assert.EqualValues(t, 2, e["attempt"])
+			assert.Equal(t, "******** [REDACTED]", e["password"])
+			assert.NotEmpty(t, e["time"])
+		})
+	}
+}
+
+func TestJSONDrivers_MasksMessage(t *testing.T) {
+	for name, newLogger := range jsonDrivers {
+		t.Run(name, func(t *testing.T) {
+			cfg := fileConfig(t, 4)
+			log := newLogger(cfg)
+
+			log.Info("token=abc123")
+			log.Info(strings.Repeat("x", 4096))
+
+			entries := readEntries(t, cfg.Log.Path)
+			require.Len(t, entries, 2)
+			assert.Equal(t, "******** [REDACTED]", entries[0]["msg"])
+			assert.Equal(t, "[message too large to log]", entries[1]["msg"])
+		})
+	}
+}
+
+func TestJSONDrivers_SharedLevel(t *testing.T) {
+	for name, newLogger := range jsonDrivers {
+		t.Run(name, func(t *testing.T) {
+			cfg := fileConfig(t, 4)
+			log := newLogger(cfg)
+			derived := log.WithField("component", "app")
+
+			derived.Debug("hidden")
+			require.NoError(t, log.(logger.Leveler).SetLevel(logger.LevelDebug))
+			assert.Equal(t, logger.LevelDebug, derived.(logger.Leveler).Level())
+			derived.Debug("shown")
+			require.NoError(t, log.(logger.Leveler).SetLevel(logger.LevelError))
+			derived.Warn("hidden")
+
+			entries := readEntries(t, cfg.Log.Path)
+			require.Len(t, entries, 1)
+			assert.Equal(t, "shown", entries[0]["msg"])
+			assert.Equal(t, "debug", entries[0]["level"])
+		})
+	}
+}
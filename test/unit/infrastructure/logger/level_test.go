@@ -0,0 +1,68 @@
+package logger_test
+
+import (
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugToggle(t *testing.T) {
+	main := logger.NewStdoutLogger(&config.Config{Log: config.LogConfig{Level: 4}}, nil)
+	booking := logger.NewZerolog(fileConfig(t, 2), nil)
+	derived := booking.WithField("component", "usecase")
+
+	toggle := logger.NewDebugToggle(map[string]logger.Logger{
+		"main":    main,
+		"booking": booking,
+		"noop":    logger.NewNoOpLogger(),
+	})
+
+	require.True(t, toggle.Toggle())
+	assert.Equal(t, logger.LevelDebug, main.(logger.Leveler).Level())
+	assert.Equal(t, logger.LevelDebug, derived.(logger.Leveler).Level(), "derived loggers follow")
+
+	require.False(t, toggle.Toggle())
+	assert.Equal(t, logger.LevelInfo, main.(logger.Leveler).Level())
+	assert.Equal(t, logger.LevelError, booking.(logger.Leveler).Level(), "each logger gets its own level back")
+
+	assert.True(t, toggle.Toggle(), "the toggle can be repeated")
+	assert.Equal(t, logger.LevelDebug, booking.(logger.Leveler).Level())
+}
+
+func TestNewForDomain(t *testing.T) {
+	cfg := &config.Config{
+		App: config.AppConfig{Env: "development"},
+		Log: config.LogConfig{Level: 4, Levels: map[string]string{"booking": "debug", "webhook": "warn"}},
+	}
+
+	for domain, want := range map[string]string{
+		"booking": logger.LevelDebug,
+		"webhook": logger.LevelWarn,
+		"main":    logger.LevelInfo,
+	} {
+		log, err := logger.NewForDomain(cfg, nil, domain)
+		require.NoError(t, err)
+		assert.Equal(t, want, log.(logger.Leveler).Level(), domain)
+	}
+}
+
+func TestNewForDomain_UnknownLevel(t *testing.T) {
+	cfg := &config.Config{Log: config.LogConfig{Levels: map[string]string{"booking": "verbose"}}}
+
+	_, err := logger.NewForDomain(cfg, nil, "booking")
+	assert.ErrorContains(t, err, "log.levels.booking")
+
+	_, err = logger.NewForDomain(cfg, nil, "webhook")
+	assert.NoError(t, err)
+}
+
+func TestConfiguredLevel(t *testing.T) {
+	cfg := config.LogConfig{Level: 5, Levels: map[string]string{"booking": "warn"}}
+
+	assert.Equal(t, logger.LevelWarn, logger.ConfiguredLevel(cfg, "booking"))
+	assert.Equal(t, logger.LevelDebug, logger.ConfiguredLevel(cfg, "main"))
+}
@@ -0,0 +1,103 @@
+package logger_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordingLogger is an OTel logger keeping the emitted records and their
+// context.
+type recordingLogger struct {
+	embedded.Logger
+	records  []otellog.Record
+	contexts []context.Context
+}
+
+func (l *recordingLogger) Emit(ctx context.Context, r otellog.Record) {
+	l.records = append(l.records, r.Clone())
+	l.contexts = append(l.contexts, ctx)
+}
+
+func (l *recordingLogger) Enabled(context.Context, otellog.EnabledParameters) bool {
+	return true
+}
+
+func attributes(r otellog.Record) map[string]otellog.Value {
+	attrs := map[string]otellog.Value{}
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func TestOTelHandler_Record(t *testing.T) {
+	rec := &recordingLogger{}
+	log := slog.New(logger.NewOTelHandler(rec, slog.LevelInfo)).
+		With(slog.String("domain", "booking")).
+		WithGroup("http")
+
+	log.Warn("slow request", slog.Int("status", 200), slog.Bool("cached", false))
+
+	require.Len(t, rec.records, 1)
+	r := rec.records[0]
+	assert.Equal(t, "slow request", r.Body().AsString())
+	assert.Equal(t, otellog.SeverityWarn, r.Severity())
+	assert.Equal(t, "warn", r.SeverityText())
+	assert.False(t, r.Timestamp().IsZero())
+
+	attrs := attributes(r)
+	assert.Equal(t, "booking", attrs["domain"].AsString())
+	assert.Equal(t, int64(200), attrs["http.status"].AsInt64())
+	assert.False(t, attrs["http.cached"].AsBool())
+}
+
+func TestOTelHandler_Level(t *testing.T) {
+	rec := &recordingLogger{}
+	level := new(slog.LevelVar)
+	log := slog.New(logger.NewOTelHandler(rec, level))
+
+	log.Debug("hidden")
+	level.Set(slog.LevelDebug)
+	log.Debug("shown")
+
+	require.Len(t, rec.records, 1)
+	assert.Equal(t, "shown", rec.records[0].Body().AsString())
+	assert.Equal(t, otellog.SeverityDebug, rec.records[0].Severity())
+}
+
+func TestOTelHandler_TraceContext(t *testing.T) {
+	rec := &recordingLogger{}
+	log := slog.New(logger.NewOTelHandler(rec, slog.LevelInfo))
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	log.InfoContext(ctx, "booking created")
+
+	require.Len(t, rec.contexts, 1)
+	assert.Equal(t, sc, trace.SpanContextFromContext(rec.contexts[0]),
+		"the context is passed to the OTel logger, which correlates the record with the span")
+}
+
+func TestOTelHandler_Masking(t *testing.T) {
+	rec := &recordingLogger{}
+	log := slog.New(logger.NewMaskingHandler(logger.NewOTelHandler(rec, slog.LevelInfo)))
+
+	log.Info("login", slog.String("password", "s3cret"))
+
+	require.Len(t, rec.records, 1)
+	assert.NotEqual(t, "s3cret", attributes(rec.records[0])["password"].AsString())
+}
@@ -0,0 +1,116 @@
+package logger_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type entry struct {
+	level   string
+	message string
+	fields  map[string]any
+}
+
+// recorder is a Logger keeping its entries, shared by the derived loggers.
+type recorder struct {
+	mu      *sync.Mutex
+	entries *[]entry
+	fields  map[string]any
+}
+
+func newRecorder() *recorder {
+	return &recorder{mu: &sync.Mutex{}, entries: &[]entry{}, fields: map[string]any{}}
+}
+
+func (r *recorder) with(fields map[string]any) *recorder {
+	merged := make(map[string]any, len(r.fields)+len(fields))
+	for k, v := range r.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &recorder{mu: r.mu, entries: r.entries, fields: merged}
+}
+
+func (r *recorder) WithContext(context.Context) logger.Logger { return r }
+func (r *recorder) WithField(key string, value any) logger.Logger {
+	return r.with(map[string]any{key: value})
+}
+func (r *recorder) WithFields(fields map[string]any) logger.Logger { return r.with(fields) }
+
+func (r *recorder) Debug(msg string) { r.log("debug", msg) }
+func (r *recorder) Info(msg string)  { r.log("info", msg) }
+func (r *recorder) Warn(msg string)  { r.log("warn", msg) }
+func (r *recorder) Error(msg string) { r.log("error", msg) }
+
+func (r *recorder) log(level, msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*r.entries = append(*r.entries, entry{level: level, message: msg, fields: r.fields})
+}
+
+func (r *recorder) count(level, msg string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, e := range *r.entries {
+		if e.level == level && e.message == msg {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSampler_LimitsIdenticalMessages(t *testing.T) {
+	rec := newRecorder()
+	log := logger.NewSampler(rec, config.LogSamplingConfig{Enabled: true, Interval: 60, First: 3, Thereafter: 10})
+
+	for i := 0; i < 25; i++ {
+		log.WithField("request_id", i).Error("database unavailable")
+		log.Warn("slow query")
+		log.Info("booking created")
+	}
+
+	assert.Equal(t, 5, rec.count("error", "database unavailable"), "the first 3, then the 13th and the 23rd")
+	assert.Equal(t, 5, rec.count("warn", "slow query"), "each message is counted apart")
+	assert.Equal(t, 25, rec.count("info", "booking created"), "info messages are not sampled")
+}
+
+func TestSampler_Summary(t *testing.T) {
+	rec := newRecorder()
+	log := logger.NewSampler(rec, config.LogSamplingConfig{Enabled: true, Interval: 1, First: 1})
+
+	for i := 0; i < 4; i++ {
+		log.WithField("request_id", i).Error("database unavailable")
+	}
+	require.Equal(t, 1, rec.count("error", "database unavailable"))
+
+	time.Sleep(time.Second)
+	log.Error("database unavailable")
+
+	assert.Equal(t, 2, rec.count("error", "database unavailable"), "a new interval logs the first messages again")
+	require.Equal(t, 1, rec.count("warn", "Suppressed 3 similar log messages"))
+	for _, e := range *rec.entries {
+		if e.level == "warn" {
+			assert.Equal(t, map[string]any{
+				"sampled_level":   "error",
+				"sampled_message": "database unavailable",
+				"suppressed":      3,
+			}, e.fields, "the summary is logged without the fields of the calls")
+		}
+	}
+}
+
+func TestSampler_Disabled(t *testing.T) {
+	rec := newRecorder()
+	assert.Same(t, logger.Logger(rec), logger.NewSampler(rec, config.LogSamplingConfig{}))
+}
@@ -0,0 +1,76 @@
+package logger_test
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureStdout returns what run writes to os.Stdout.
+func captureStdout(t *testing.T, run func()) string {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	run()
+	require.NoError(t, w.Close())
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestTee_SinkLevels(t *testing.T) {
+	cfg := fileConfig(t, 4)
+	cfg.Log.Driver = "tee"
+	cfg.Log.Sinks = []config.LogSinkConfig{
+		{Driver: "stdout", Level: "debug"},
+		{Driver: "zerolog", Level: "warn"},
+	}
+
+	var log logger.Logger
+	out := captureStdout(t, func() {
+		var err error
+		log, err = logger.NewForDomain(cfg, nil, "booking")
+		require.NoError(t, err)
+
+		derived := log.WithField("domain", "booking")
+		derived.Debug("cache miss")
+		derived.Warn("slow query")
+	})
+
+	assert.Contains(t, out, "cache miss")
+	assert.Contains(t, out, "slow query")
+	entries := readEntries(t, cfg.Log.Path)
+	require.Len(t, entries, 1, "the file sink only gets warn and above")
+	assert.Equal(t, "slow query", entries[0]["msg"])
+	assert.Equal(t, "booking", entries[0]["domain"])
+
+	assert.Equal(t, logger.LevelDebug, log.(logger.Leveler).Level(), "the level of the most verbose sink")
+	require.NoError(t, log.(logger.Leveler).SetLevel(logger.LevelError))
+	assert.Equal(t, logger.LevelError, log.(logger.Leveler).Level())
+}
+
+func TestTee_InvalidSinks(t *testing.T) {
+	for name, sinks := range map[string][]config.LogSinkConfig{
+		"unknown level": {{Driver: "stdout", Level: "verbose"}},
+		"nested tee":    {{Driver: "tee"}},
+		"two files":     {{Driver: "logrus"}, {Driver: "zap"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := fileConfig(t, 4)
+			cfg.Log.Driver = "tee"
+			cfg.Log.Sinks = sinks
+
+			_, err := logger.NewForDomain(cfg, nil, "main")
+			assert.ErrorContains(t, err, "log.sinks")
+		})
+	}
+}
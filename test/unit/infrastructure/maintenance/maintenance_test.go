@@ -0,0 +1,95 @@
+package maintenance_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/maintenance"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// MODE
+// ============================================================================
+
+func TestMode_Toggle(t *testing.T) {
+	ctx := context.Background()
+	mode := maintenance.NewMode(config.MaintenanceConfig{}, maintenance.NewMemoryStore(), logger.NewNoOpLogger())
+
+	assert.False(t, mode.State(ctx).Enabled)
+
+	state, err := mode.Set(ctx, true, "")
+	require.NoError(t, err)
+	assert.True(t, state.Enabled)
+	assert.Equal(t, "The service is under maintenance, retry later", state.Message, "the configured message applies")
+	assert.False(t, state.UpdatedAt.IsZero())
+
+	_, err = mode.Set(ctx, true, "Back at 10:00 UTC")
+	require.NoError(t, err)
+	assert.Equal(t, "Back at 10:00 UTC", mode.State(ctx).Message)
+
+	_, err = mode.Set(ctx, false, "")
+	require.NoError(t, err)
+	assert.False(t, mode.State(ctx).Enabled)
+}
+
+func TestMode_ForcedByConfig(t *testing.T) {
+	ctx := context.Background()
+	mode := maintenance.NewMode(config.MaintenanceConfig{Enabled: true, Message: "Deploying"}, maintenance.NewMemoryStore(), logger.NewNoOpLogger())
+
+	state, err := mode.Set(ctx, false, "")
+
+	require.NoError(t, err)
+	assert.True(t, state.Enabled, "a forced mode cannot be switched off at runtime")
+	assert.True(t, state.Forced)
+	assert.Equal(t, "Deploying", state.Message)
+}
+
+func TestMode_Reload(t *testing.T) {
+	ctx := context.Background()
+	mode := maintenance.NewMode(config.MaintenanceConfig{Enabled: true, Message: "Deploying"}, maintenance.NewMemoryStore(), logger.NewNoOpLogger())
+
+	mode.Reload(config.MaintenanceConfig{})
+
+	state := mode.State(ctx)
+	assert.False(t, state.Enabled, "reloading the configuration switches the forced mode off")
+	assert.False(t, state.Forced)
+
+	mode.Reload(config.MaintenanceConfig{Enabled: true})
+	state = mode.State(ctx)
+	assert.True(t, state.Forced)
+	assert.Equal(t, "The service is under maintenance, retry later", state.Message)
+}
+
+func TestMode_SharedThroughRedis(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	cfg := config.MaintenanceConfig{Key: "voyago:maintenance", RefreshInterval: 1}
+
+	first := maintenance.NewMode(cfg, maintenance.NewRedisStore(client, cfg.Key), logger.NewNoOpLogger())
+	second := maintenance.NewMode(cfg, maintenance.NewRedisStore(client, cfg.Key), logger.NewNoOpLogger())
+	require.False(t, second.State(ctx).Enabled)
+
+	_, err := first.Set(ctx, true, "Database migration")
+	require.NoError(t, err)
+	assert.True(t, mr.Exists("voyago:maintenance"))
+	assert.False(t, second.State(ctx).Enabled, "the state is cached for the refresh interval")
+
+	time.Sleep(time.Second)
+	state := second.State(ctx)
+	assert.True(t, state.Enabled)
+	assert.Equal(t, "Database migration", state.Message)
+
+	// Redis outage: the last known state is kept.
+	mr.Close()
+	time.Sleep(time.Second)
+	assert.True(t, second.State(ctx).Enabled)
+}
@@ -0,0 +1,339 @@
+package kafka_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/messaging"
+	"voyago/core-api/internal/infrastructure/messaging/kafka"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+// broker is an in-memory Transport: a topic is a single partition.
+type broker struct {
+	mu        sync.Mutex
+	topics    map[string][]messaging.Message
+	committed map[string]int64 // topic -> last committed offset
+	groups    map[string]bool
+}
+
+func newBroker() *broker {
+	return &broker{topics: map[string][]messaging.Message{}, committed: map[string]int64{}, groups: map[string]bool{}}
+}
+
+func (b *broker) NewReader(topic, groupID string) kafka.Reader {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.groups[groupID] = true
+	return &reader{broker: b, topic: topic}
+}
+
+func (b *broker) NewWriter() kafka.Writer {
+	return writer{broker: b}
+}
+
+func (b *broker) messages(topic string) []messaging.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]messaging.Message(nil), b.topics[topic]...)
+}
+
+func (b *broker) committedOffset(topic string) (int64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	o, ok := b.committed[topic]
+	return o, ok
+}
+
+type reader struct {
+	broker *broker
+	topic  string
+	next   int
+}
+
+func (r *reader) Fetch(ctx context.Context) (messaging.Message, error) {
+	for {
+		r.broker.mu.Lock()
+		msgs := r.broker.topics[r.topic]
+		if r.next < len(msgs) {
+			msg := msgs[r.next]
+			r.next++
+			r.broker.mu.Unlock()
+			return msg, nil
+		}
+		r.broker.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return messaging.Message{}, ctx.Err()
+		case <-time.After(2 * time.Millisecond):
+		}
+	}
+}
+
+func (r *reader) Commit(_ context.Context, msg messaging.Message) error {
+	r.broker.mu.Lock()
+	defer r.broker.mu.Unlock()
+	r.broker.committed[msg.Topic] = msg.Offset
+	return nil
+}
+
+func (r *reader) Close() error { return nil }
+
+type writer struct{ broker *broker }
+
+func (w writer) Write(_ context.Context, msgs ...messaging.Message) error {
+	w.broker.mu.Lock()
+	defer w.broker.mu.Unlock()
+	for _, msg := range msgs {
+		msg.Offset = int64(len(w.broker.topics[msg.Topic]))
+		headers := messaging.Headers{}
+		for k, v := range msg.Headers {
+			headers[k] = v
+		}
+		msg.Headers = headers
+		w.broker.topics[msg.Topic] = append(w.broker.topics[msg.Topic], msg)
+	}
+	return nil
+}
+
+func (w writer) Close() error { return nil }
+
+// propagatingTracer writes a fixed traceparent and records the ones it
+// extracts.
+type propagatingTracer struct {
+	tracer.Tracer
+	mu        sync.Mutex
+	extracted []string
+}
+
+func (t *propagatingTracer) Inject(_ context.Context, carrier tracer.Setter) {
+	carrier.Set("traceparent", "00-trace-span-01")
+}
+
+func (t *propagatingTracer) Extract(ctx context.Context, carrier tracer.Carrier) context.Context {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.extracted = append(t.extracted, carrier.Get("Traceparent"))
+	return ctx
+}
+
+func startRunner(t *testing.T, b *broker, cfg config.KafkaConsumerConfig, router *messaging.Router) *kafka.Runner {
+	return startRunnerWithTracer(t, b, cfg, router, tracer.NewNoOpTracer())
+}
+
+func startRunnerWithTracer(t *testing.T, b *broker, cfg config.KafkaConsumerConfig, router *messaging.Router, trc tracer.Tracer) *kafka.Runner {
+	if cfg.GroupID == "" {
+		cfg.GroupID = "voyago"
+	}
+	if cfg.RetryBackoff == 0 {
+		cfg.RetryBackoff = 1
+	}
+	runner, err := kafka.NewRunner(cfg, router, b, kafka.NewProducer(b, trc), logger.NewNoOpLogger(), trc, nil)
+	require.NoError(t, err)
+	runner.Start()
+	t.Cleanup(runner.Stop)
+	return runner
+}
+
+func publish(t *testing.T, b *broker, topic, value string) {
+	require.NoError(t, kafka.NewProducer(b, tracer.NewNoOpTracer()).Publish(context.Background(), messaging.Message{Topic: topic, Value: []byte(value)}))
+}
+
+func eventually(t *testing.T, cond func() bool, msg string) {
+	t.Helper()
+	require.Eventually(t, cond, 2*time.Second, 5*time.Millisecond, msg)
+}
+
+// ============================================================================
+// TESTS
+// ============================================================================
+
+func TestRunner_HandlesAndCommits(t *testing.T) {
+	b := newBroker()
+	var handled atomic.Value
+	router := messaging.NewRouter()
+	router.Handle("payments", func(_ context.Context, msg messaging.Message) error {
+		handled.Store(string(msg.Value))
+		return nil
+	})
+
+	startRunner(t, b, config.KafkaConsumerConfig{GroupID: "voyago-api"}, router)
+	publish(t, b, "payments", "paid")
+
+	eventually(t, func() bool { _, ok := b.committedOffset("payments"); return ok }, "committed")
+	assert.Equal(t, "paid", handled.Load())
+	assert.Empty(t, b.messages("payments.retry"))
+	assert.Empty(t, b.messages("payments.dlq"))
+	assert.True(t, b.groups["voyago-api"])
+}
+
+func TestRunner_RetriesThenDeadLetters(t *testing.T) {
+	b := newBroker()
+	var attempts atomic.Int32
+	router := messaging.NewRouter()
+	router.Handle("payments", func(_ context.Context, msg messaging.Message) error {
+		attempts.Add(1)
+		return errors.New("gateway down")
+	})
+
+	startRunner(t, b, config.KafkaConsumerConfig{MaxAttempts: 3}, router)
+	publish(t, b, "payments", "paid")
+
+	eventually(t, func() bool { return len(b.messages("payments.dlq")) == 1 }, "dead-lettered")
+	assert.Equal(t, int32(3), attempts.Load())
+
+	retries := b.messages("payments.retry")
+	require.Len(t, retries, 2)
+	assert.Equal(t, "2", retries[0].Headers[messaging.HeaderAttempt])
+	assert.Equal(t, "3", retries[1].Headers[messaging.HeaderAttempt])
+	assert.NotEmpty(t, retries[0].Headers[kafka.HeaderRetryAt])
+
+	dead := b.messages("payments.dlq")[0]
+	assert.Equal(t, "paid", string(dead.Value))
+	assert.Equal(t, "payments", dead.Headers[messaging.HeaderOriginalTopic])
+	assert.Equal(t, "gateway down", dead.Headers[messaging.HeaderError])
+	eventually(t, func() bool { o, ok := b.committedOffset("payments.retry"); return ok && o == 1 }, "retries committed")
+}
+
+func TestRunner_RetrySucceeds(t *testing.T) {
+	b := newBroker()
+	var attempts atomic.Int32
+	router := messaging.NewRouter()
+	router.Handle("payments", func(context.Context, messaging.Message) error {
+		if attempts.Add(1) == 1 {
+			return errors.New("timeout")
+		}
+		return nil
+	})
+
+	startRunner(t, b, config.KafkaConsumerConfig{}, router)
+	publish(t, b, "payments", "paid")
+
+	eventually(t, func() bool { _, ok := b.committedOffset("payments.retry"); return ok }, "retry handled")
+	assert.Equal(t, int32(2), attempts.Load())
+	assert.Empty(t, b.messages("payments.dlq"))
+}
+
+func TestRunner_RetryWaitsUntilDue(t *testing.T) {
+	b := newBroker()
+	var times []time.Time
+	var mu sync.Mutex
+	router := messaging.NewRouter()
+	router.Handle("payments", func(context.Context, messaging.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		times = append(times, time.Now())
+		return errors.New("timeout")
+	})
+
+	startRunner(t, b, config.KafkaConsumerConfig{MaxAttempts: 2, RetryBackoff: 100}, router)
+	publish(t, b, "payments", "paid")
+
+	eventually(t, func() bool { return len(b.messages("payments.dlq")) == 1 }, "dead-lettered")
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, times, 2)
+	assert.GreaterOrEqual(t, times[1].Sub(times[0]), 90*time.Millisecond)
+}
+
+func TestRunner_PermanentErrorsAndPanicsSkipOrUseRetries(t *testing.T) {
+	b := newBroker()
+	var permanentAttempts, panicAttempts atomic.Int32
+	router := messaging.NewRouter()
+	router.Handle("bad-json", func(context.Context, messaging.Message) error {
+		permanentAttempts.Add(1)
+		return messaging.Permanent(errors.New("invalid payload"))
+	})
+	router.Handle("panics", func(context.Context, messaging.Message) error {
+		panicAttempts.Add(1)
+		panic("nil map")
+	})
+
+	startRunner(t, b, config.KafkaConsumerConfig{MaxAttempts: 2}, router)
+	publish(t, b, "bad-json", "{")
+	publish(t, b, "panics", "x")
+
+	eventually(t, func() bool { return len(b.messages("bad-json.dlq")) == 1 }, "dead-lettered at once")
+	eventually(t, func() bool { return len(b.messages("panics.dlq")) == 1 }, "panic dead-lettered")
+	assert.Equal(t, int32(1), permanentAttempts.Load())
+	assert.Empty(t, b.messages("bad-json.retry"))
+	assert.Equal(t, int32(2), panicAttempts.Load())
+	assert.Contains(t, b.messages("panics.dlq")[0].Headers[messaging.HeaderError], "handler panicked: nil map")
+}
+
+func TestRunner_StopWaitsForTheMessageBeingHandled(t *testing.T) {
+	b := newBroker()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	router := messaging.NewRouter()
+	router.Handle("payments", func(ctx context.Context, _ messaging.Message) error {
+		close(started)
+		<-release
+		return ctx.Err()
+	})
+
+	runner := startRunner(t, b, config.KafkaConsumerConfig{}, router)
+	publish(t, b, "payments", "paid")
+	<-started
+
+	stopped := make(chan struct{})
+	go func() {
+		runner.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned while a message was handled")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-stopped
+	_, ok := b.committedOffset("payments")
+	assert.True(t, ok, "the handled message is committed")
+	assert.Empty(t, b.messages("payments.retry"), "the handler context is not cancelled")
+}
+
+func TestRunner_PropagatesTheTraceContext(t *testing.T) {
+	b := newBroker()
+	trc := &propagatingTracer{Tracer: tracer.NewNoOpTracer()}
+	router := messaging.NewRouter()
+	router.Handle("payments", func(context.Context, messaging.Message) error { return nil })
+
+	startRunnerWithTracer(t, b, config.KafkaConsumerConfig{}, router, trc)
+	require.NoError(t, kafka.NewProducer(b, trc).Publish(context.Background(), messaging.Message{Topic: "payments"}))
+
+	eventually(t, func() bool { _, ok := b.committedOffset("payments"); return ok }, "handled")
+	trc.mu.Lock()
+	defer trc.mu.Unlock()
+	assert.Equal(t, []string{"00-trace-span-01"}, trc.extracted)
+}
+
+func TestNewRunner_RequiresAGroup(t *testing.T) {
+	b := newBroker()
+	_, err := kafka.NewRunner(config.KafkaConsumerConfig{}, messaging.NewRouter(), b, kafka.NewProducer(b, tracer.NewNoOpTracer()), logger.NewNoOpLogger(), tracer.NewNoOpTracer(), nil)
+	assert.Error(t, err)
+}
+
+func TestNewClient_RequiresTheBrokers(t *testing.T) {
+	_, err := kafka.NewClient(&config.KafkaConfig{Enabled: true})
+	assert.EqualError(t, err, "kafka brokers are required")
+
+	client, err := kafka.NewClient(&config.KafkaConfig{Enabled: true, Brokers: []string{"localhost:9092"}, ClientID: "voyago"})
+	require.NoError(t, err)
+	assert.Implements(t, (*kafka.Transport)(nil), client)
+}
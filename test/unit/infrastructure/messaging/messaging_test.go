@@ -0,0 +1,94 @@
+package messaging_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/messaging"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// publisher records the published messages.
+type publisher struct {
+	msgs []messaging.Message
+}
+
+func (p *publisher) Publish(_ context.Context, msgs ...messaging.Message) error {
+	p.msgs = append(p.msgs, msgs...)
+	return nil
+}
+
+func TestRouter(t *testing.T) {
+	router := messaging.NewRouter()
+	h := func(context.Context, messaging.Message) error { return nil }
+	router.Handle("payments", h)
+	router.Handle("bookings", h)
+
+	assert.Equal(t, []string{"bookings", "payments"}, router.Topics())
+	assert.NotNil(t, router.Handler("payments"))
+	assert.Nil(t, router.Handler("refunds"))
+	assert.Panics(t, func() { router.Handle("payments", h) })
+	assert.Panics(t, func() { router.Handle("", h) })
+}
+
+func TestHeaders_GetIsCaseInsensitive(t *testing.T) {
+	h := messaging.Headers{"Traceparent": "00-abc"}
+
+	assert.Equal(t, "00-abc", h.Get("traceparent"))
+	assert.Empty(t, h.Get("x-attempt"))
+}
+
+func TestHandle_RecoversPanics(t *testing.T) {
+	err := messaging.Handle(context.Background(), func(context.Context, messaging.Message) error {
+		var m map[string]int
+		m["boom"]++
+		return nil
+	}, messaging.Message{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "handler panicked")
+}
+
+func TestPermanent(t *testing.T) {
+	err := messaging.Permanent(errors.New("invalid payload"))
+
+	assert.True(t, messaging.IsPermanent(err))
+	assert.True(t, messaging.IsPermanent(errors.Join(errors.New("context"), err)))
+	assert.False(t, messaging.IsPermanent(errors.New("gateway down")))
+	assert.EqualError(t, err, "invalid payload")
+}
+
+func TestEventHandler_DecodesPublishedEvents(t *testing.T) {
+	p := &publisher{}
+	evt := eventbus.NewEvent("booking.payment_status_changed", "payment", map[string]any{"booking_id": "b-1"})
+	enc := events.NewEncoder("voyago-test", tracer.NewNoOpTracer())
+	require.NoError(t, messaging.PublishEvent(context.Background(), p, enc, "payments", evt))
+
+	require.Len(t, p.msgs, 1)
+	msg := p.msgs[0]
+	assert.Equal(t, "payments", msg.Topic)
+	assert.Equal(t, evt.ID, string(msg.Key))
+	assert.Equal(t, events.ContentType, msg.Headers.Get(messaging.HeaderContentType))
+
+	var got eventbus.Event
+	err := messaging.EventHandler(func(_ context.Context, e eventbus.Event) error {
+		got = e
+		return nil
+	})(context.Background(), msg)
+	require.NoError(t, err)
+	assert.Equal(t, evt.ID, got.ID)
+	assert.Equal(t, evt.Type, got.Type)
+	assert.Equal(t, "payment", got.Source)
+	assert.Equal(t, 1, got.SchemaVersion)
+	assert.Equal(t, map[string]any{"booking_id": "b-1"}, got.Payload)
+
+	err = messaging.EventHandler(func(context.Context, eventbus.Event) error { return nil })(context.Background(), messaging.Message{Value: []byte("{")})
+	require.Error(t, err)
+	assert.True(t, messaging.IsPermanent(err))
+}
@@ -0,0 +1,181 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/openapi"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+type createItemRequest struct {
+	ID     string   `json:"-" validate:"required,uuid"`
+	Name   string   `json:"name" validate:"required,max=50" label:"Name"`
+	Kind   string   `json:"kind" validate:"required,oneof=A B"`
+	Qty    int      `json:"qty" validate:"gte=1,lte=10"`
+	Tags   []string `json:"tags" validate:"omitempty,min=1,dive,required,max=20"`
+	Note   *string  `json:"note" validate:"omitempty,max=255"`
+	Parent *itemRef `json:"parent"`
+}
+
+type itemRef struct {
+	ID string `json:"id" validate:"required,uuid"`
+}
+
+type listItemsRequest struct {
+	Limit int `query:"limit" validate:"omitempty,gte=1,lte=100"`
+}
+
+func render(t *testing.T, ops ...openapi.Operation) map[string]any {
+	t.Helper()
+
+	spec := openapi.New(openapi.Info{Title: "test", Version: "1.0.0"})
+	spec.Add(ops...)
+
+	raw, err := spec.JSON()
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	return doc
+}
+
+// lookup walks a decoded JSON document.
+func lookup(t *testing.T, v any, keys ...string) any {
+	t.Helper()
+	for _, k := range keys {
+		m, ok := v.(map[string]any)
+		require.True(t, ok, "%q is not an object", k)
+		v, ok = m[k]
+		require.True(t, ok, "missing %q", k)
+	}
+	return v
+}
+
+// ============================================================================
+// TEST CASES
+// ============================================================================
+
+func TestSpec_SchemaFromValidateTags(t *testing.T) {
+	doc := render(t, openapi.Operation{
+		Method:  fiber.MethodPost,
+		Path:    "/items/",
+		Request: createItemRequest{},
+		Status:  fiber.StatusCreated,
+	})
+
+	schema := lookup(t, doc, "components", "schemas", "createItemRequest").(map[string]any)
+	props := schema["properties"].(map[string]any)
+
+	assert.NotContains(t, props, "ID", "json:\"-\" fields are not documented")
+	assert.ElementsMatch(t, []any{"name", "kind"}, schema["required"])
+
+	assert.Equal(t, "Name", lookup(t, props, "name", "title"))
+	assert.Equal(t, 50.0, lookup(t, props, "name", "maxLength"))
+	assert.Equal(t, []any{"A", "B"}, lookup(t, props, "kind", "enum"))
+	assert.Equal(t, 1.0, lookup(t, props, "qty", "minimum"))
+	assert.Equal(t, 10.0, lookup(t, props, "qty", "maximum"))
+	assert.Equal(t, 1.0, lookup(t, props, "tags", "minItems"))
+	assert.Equal(t, 20.0, lookup(t, props, "tags", "items", "maxLength"))
+	assert.Equal(t, true, lookup(t, props, "note", "nullable"))
+	assert.Equal(t, "#/components/schemas/itemRef", lookup(t, props, "parent", "allOf").([]any)[0].(map[string]any)["$ref"])
+	assert.Equal(t, "uuid", lookup(t, doc, "components", "schemas", "itemRef", "properties", "id", "format"))
+}
+
+func TestSpec_PathsAndParameters(t *testing.T) {
+	doc := render(t, openapi.Operation{
+		Method:   fiber.MethodGet,
+		Path:     "/items/:id/children",
+		Query:    listItemsRequest{},
+		Response: []itemRef{},
+	})
+
+	op := lookup(t, doc, "paths", "/items/{id}/children", "get").(map[string]any)
+	assert.Equal(t, "get_items_id_children", op["operationId"])
+	assert.Equal(t, []any{"items"}, op["tags"])
+
+	params := op["parameters"].([]any)
+	require.Len(t, params, 2)
+	assert.Equal(t, map[string]any{"name": "id", "in": "path", "required": true, "schema": map[string]any{"type": "string"}}, params[0])
+	assert.Equal(t, "limit", params[1].(map[string]any)["name"])
+	assert.Equal(t, "query", params[1].(map[string]any)["in"])
+}
+
+func TestSpec_ResponsesUseEnvelope(t *testing.T) {
+	doc := render(t,
+		openapi.Operation{Method: fiber.MethodGet, Path: "/items/:id", Response: itemRef{}, Errors: []int{404}},
+		openapi.Operation{Method: fiber.MethodDelete, Path: "/items/:id", Status: fiber.StatusNoContent},
+	)
+
+	ok := lookup(t, doc, "paths", "/items/{id}", "get", "responses", "200", "content", "application/json", "schema", "allOf").([]any)
+	assert.Equal(t, "#/components/schemas/Response", ok[0].(map[string]any)["$ref"])
+	assert.Equal(t, "#/components/schemas/itemRef", lookup(t, ok[1], "properties", "data", "$ref"))
+
+	notFound := lookup(t, doc, "paths", "/items/{id}", "get", "responses", "404", "content", "application/json", "schema", "$ref")
+	assert.Equal(t, "#/components/schemas/Response", notFound)
+
+	noContent := lookup(t, doc, "paths", "/items/{id}", "delete", "responses", "204").(map[string]any)
+	assert.NotContains(t, noContent, "content")
+
+	assert.Contains(t, lookup(t, doc, "components", "schemas", "Response", "properties"), "error_code")
+}
+
+func TestSpec_ProblemErrors(t *testing.T) {
+	spec := openapi.New(openapi.Info{Title: "test", Version: "1.0.0"})
+	spec.UseProblemErrors()
+	spec.Add(openapi.Operation{Method: fiber.MethodGet, Path: "/items/:id", Response: itemRef{}, Errors: []int{404}})
+
+	raw, err := spec.JSON()
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(raw, &doc))
+
+	notFound := lookup(t, doc, "paths", "/items/{id}", "get", "responses", "404", "content", "application/problem+json", "schema", "$ref")
+	assert.Equal(t, "#/components/schemas/Problem", notFound)
+
+	// Successful responses keep the envelope.
+	ok := lookup(t, doc, "paths", "/items/{id}", "get", "responses", "200", "content", "application/json", "schema", "allOf").([]any)
+	assert.Equal(t, "#/components/schemas/Response", ok[0].(map[string]any)["$ref"])
+
+	problem := lookup(t, doc, "components", "schemas", "Problem", "properties")
+	for _, field := range []string{"type", "title", "status", "detail", "instance", "error_code"} {
+		assert.Contains(t, problem, field)
+	}
+}
+
+func TestSpec_NilIsIgnored(t *testing.T) {
+	var spec *openapi.Spec
+	assert.NotPanics(t, func() { spec.Add(openapi.Operation{Method: fiber.MethodGet, Path: "/"}) })
+}
+
+func TestMount_DisabledServesNothing(t *testing.T) {
+	app := fiber.New()
+	openapi.Mount(app, config.DocsConfig{Enabled: false}, openapi.New(openapi.Info{}))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/openapi.json", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestMount_CustomPaths(t *testing.T) {
+	app := fiber.New()
+	openapi.Mount(app, config.DocsConfig{Enabled: true, SpecPath: "/api.json", UIPath: "/swagger/"}, openapi.New(openapi.Info{}))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api.json", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/swagger", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusMovedPermanently, resp.StatusCode)
+	assert.Equal(t, "/swagger/index.html", resp.Header.Get(fiber.HeaderLocation))
+}
@@ -0,0 +1,157 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ratelimit"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// RULES
+// ============================================================================
+
+func rule(limit, window int, keyBy string) config.RateLimitRuleConfig {
+	return config.RateLimitRuleConfig{Limit: limit, Window: window, KeyBy: keyBy}
+}
+
+func TestNewLimiter_MatchesLongestRoutePrefix(t *testing.T) {
+	l, err := ratelimit.NewLimiter(config.RateLimitConfig{
+		Default: rule(100, 60, ""),
+		Routes: []config.RateLimitRouteConfig{
+			{Path: "/api/v1", RateLimitRuleConfig: rule(50, 60, "")},
+			{Method: "post", Path: "/api/v1/bookings/", RateLimitRuleConfig: rule(10, 30, config.RateLimitKeyByAPIKey)},
+			{Path: "/health", RateLimitRuleConfig: rule(0, 0, "")},
+		},
+	}, ratelimit.NewMemoryStore())
+	require.NoError(t, err)
+
+	tests := []struct {
+		method, path string
+		want         ratelimit.Rule
+	}{
+		{"POST", "/api/v1/bookings", ratelimit.Rule{Name: "POST /api/v1/bookings/", Limit: 10, Window: 30 * time.Second, KeyBy: "api_key"}},
+		{"POST", "/api/v1/bookings/42/payment-status", ratelimit.Rule{Name: "POST /api/v1/bookings/", Limit: 10, Window: 30 * time.Second, KeyBy: "api_key"}},
+		{"GET", "/api/v1/bookings", ratelimit.Rule{Name: "/api/v1", Limit: 50, Window: time.Minute, KeyBy: "ip"}},
+		{"GET", "/api/v10/bookings", ratelimit.Rule{Name: "default", Limit: 100, Window: time.Minute, KeyBy: "ip"}},
+		{"GET", "/graphql", ratelimit.Rule{Name: "default", Limit: 100, Window: time.Minute, KeyBy: "ip"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, l.Match(tt.method, tt.path))
+		})
+	}
+
+	health := l.Match("GET", "/health")
+	assert.False(t, health.Enabled(), "a zero limit exempts the routes")
+}
+
+func TestNewLimiter_Defaults(t *testing.T) {
+	l, err := ratelimit.NewLimiter(config.RateLimitConfig{}, ratelimit.NewMemoryStore())
+	require.NoError(t, err)
+
+	assert.False(t, l.Match("GET", "/").Enabled(), "nothing is limited without rules")
+}
+
+func TestNewLimiter_RejectsInvalidRules(t *testing.T) {
+	tests := map[string]config.RateLimitConfig{
+		"negative limit": {Default: rule(-1, 60, "")},
+		"missing window": {Default: rule(10, 0, "")},
+		"unknown key_by": {Default: rule(10, 60, "user")},
+		"relative path":  {Routes: []config.RateLimitRouteConfig{{Path: "api/v1", RateLimitRuleConfig: rule(10, 60, "")}}},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ratelimit.NewLimiter(cfg, ratelimit.NewMemoryStore())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLimiter_Reload(t *testing.T) {
+	l, err := ratelimit.NewLimiter(config.RateLimitConfig{Default: rule(100, 60, "")}, ratelimit.NewMemoryStore())
+	require.NoError(t, err)
+
+	err = l.Reload(config.RateLimitConfig{
+		Default: rule(20, 60, ""),
+		Routes:  []config.RateLimitRouteConfig{{Path: "/api/v1/bookings", RateLimitRuleConfig: rule(5, 10, "")}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 20, l.Match("GET", "/graphql").Limit)
+	assert.Equal(t, 5, l.Match("GET", "/api/v1/bookings").Limit)
+
+	err = l.Reload(config.RateLimitConfig{Routes: []config.RateLimitRouteConfig{{Path: "api"}}})
+	require.Error(t, err)
+	assert.Equal(t, 5, l.Match("GET", "/api/v1/bookings").Limit, "invalid rules leave the current ones")
+}
+
+// ============================================================================
+// STORES
+// ============================================================================
+
+func newRedisStore(t *testing.T) ratelimit.Store {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return ratelimit.NewRedisStore(client, "test:ratelimit:")
+}
+
+func stores(t *testing.T) map[string]ratelimit.Store {
+	return map[string]ratelimit.Store{
+		"redis":  newRedisStore(t),
+		"memory": ratelimit.NewMemoryStore(),
+	}
+}
+
+func TestStore_SlidingWindow(t *testing.T) {
+	const window = 300 * time.Millisecond
+
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			for i := 0; i < 3; i++ {
+				res, err := store.Allow(ctx, "client", 3, window)
+				require.NoError(t, err)
+				assert.True(t, res.Allowed, "request %d", i+1)
+				assert.Equal(t, 3, res.Limit)
+				assert.Equal(t, 2-i, res.Remaining)
+			}
+
+			res, err := store.Allow(ctx, "client", 3, window)
+			require.NoError(t, err)
+			assert.False(t, res.Allowed, "the 4th request exceeds the limit")
+			assert.Equal(t, 0, res.Remaining)
+			assert.Greater(t, res.Reset, time.Duration(0))
+			assert.LessOrEqual(t, res.Reset, window)
+
+			other, err := store.Allow(ctx, "other-client", 3, window)
+			require.NoError(t, err)
+			assert.True(t, other.Allowed, "keys are counted separately")
+
+			time.Sleep(res.Reset + 50*time.Millisecond)
+
+			res, err = store.Allow(ctx, "client", 3, window)
+			require.NoError(t, err)
+			assert.True(t, res.Allowed, "requests leave the window")
+		})
+	}
+}
+
+func TestRedisStore_Unavailable(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer client.Close()
+
+	store := ratelimit.NewRedisStore(client, "test:")
+	_, err := store.Allow(context.Background(), "client", 1, time.Second)
+	assert.Error(t, err)
+}
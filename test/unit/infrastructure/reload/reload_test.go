@@ -0,0 +1,266 @@
+package reload_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/reload"
+	"voyago/core-api/internal/pkg/audit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const globalFile = `app: { name: "voyago" }
+http: { port: 8080 }
+log: { level: 4 }
+rate_limit:
+  default: { limit: 100, window: 60 }
+`
+
+const bookingFile = `database: { driver: "sqlite", name: ":memory:" }
+`
+
+// recorder keeps the recorded changes.
+type recorder struct {
+	mu      sync.Mutex
+	changes []audit.Change
+}
+
+func (r *recorder) Record(_ context.Context, c audit.Change) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, c)
+	return nil
+}
+
+func (r *recorder) recorded() []audit.Change {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]audit.Change(nil), r.changes...)
+}
+
+// fixture writes the configuration files in a temporary directory and
+// returns a Reloader of them, with the audit recorder of the booking domain.
+type fixture struct {
+	dir      string
+	reloader *reload.Reloader
+	audit    *recorder
+}
+
+func newFixture(t *testing.T, debounce time.Duration) *fixture {
+	t.Helper()
+	f := &fixture{dir: t.TempDir(), audit: &recorder{}}
+	f.write(t, "config.yaml", globalFile)
+	f.write(t, "booking.yaml", bookingFile)
+
+	global, err := config.LoadGlobalConfig(f.path("config.yaml"))
+	require.NoError(t, err)
+	booking, err := config.ReadDomainConfig(f.path("booking.yaml"))
+	require.NoError(t, err)
+
+	f.reloader = reload.New(reload.Config{
+		GlobalPath: f.path("config.yaml"),
+		Domains:    map[string]string{"booking": f.path("booking.yaml")},
+		Current:    map[string]*config.Config{reload.Main: global, "booking": booking},
+		Audit:      map[string]audit.Recorder{"booking": f.audit},
+		Debounce:   debounce,
+	})
+	return f
+}
+
+func (f *fixture) path(name string) string {
+	return filepath.Join(f.dir, name)
+}
+
+func (f *fixture) write(t *testing.T, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(f.path(name), []byte(content), 0o600))
+}
+
+func TestReloader_AppliesChanges(t *testing.T) {
+	f := newFixture(t, 0)
+	levels := map[string]int{}
+	var limit int
+	f.reloader.OnChange("log.level", func(_ context.Context, c reload.Change, cfg *config.Config) error {
+		levels[c.Domain] = cfg.Log.Level
+		return nil
+	})
+	f.reloader.OnChange("rate_limit.default", func(_ context.Context, _ reload.Change, cfg *config.Config) error {
+		limit = cfg.RateLimit.Default.Limit
+		return nil
+	})
+
+	f.write(t, "config.yaml", `app: { name: "voyago" }
+http: { port: 8080 }
+log: { level: 5 }
+rate_limit:
+  default: { limit: 20, window: 60 }
+`)
+	f.write(t, "booking.yaml", bookingFile+"log: { level: 2 }\n")
+	changes, err := f.reloader.Reload(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []reload.Change{
+		{Domain: reload.Main, Path: "log.level", From: 4, To: 5},
+		{Domain: reload.Main, Path: "rate_limit.default",
+			From: config.RateLimitRuleConfig{Limit: 100, Window: 60},
+			To:   config.RateLimitRuleConfig{Limit: 20, Window: 60}},
+		{Domain: "booking", Path: "log.level", From: 4, To: 2},
+	}, changes, "the domain file overrides the global level")
+	assert.Equal(t, map[string]int{reload.Main: 5, "booking": 2}, levels)
+	assert.Equal(t, 20, limit)
+
+	recorded := f.audit.recorded()
+	require.Len(t, recorded, 3, "the global changes are audited by every domain")
+	assert.Equal(t, audit.Change{
+		Action:     reload.AuditAction,
+		EntityType: reload.AuditEntityType,
+		EntityID:   "log.level",
+		Before:     map[string]any{"file": "booking", "value": 4},
+		After:      map[string]any{"file": "booking", "value": 2},
+	}, normalized(t, recorded[2]))
+
+	changes, err = f.reloader.Reload(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, changes, "unchanged files apply nothing")
+}
+
+// normalized returns c with its snapshots as JSON objects.
+func normalized(t *testing.T, c audit.Change) audit.Change {
+	t.Helper()
+	entry, err := audit.NewEntry(context.Background(), c)
+	require.NoError(t, err)
+	c.Before, c.After = entry.Before, entry.After
+	for _, s := range []map[string]any{entry.Before, entry.After} {
+		if v, ok := s["value"].(float64); ok {
+			s["value"] = int(v)
+		}
+	}
+	return c
+}
+
+func TestReloader_KeepsTheSettingsOfAnInvalidFile(t *testing.T) {
+	f := newFixture(t, 0)
+	var calls int
+	f.reloader.OnChange("log.level", func(context.Context, reload.Change, *config.Config) error {
+		calls++
+		return nil
+	})
+
+	f.write(t, "config.yaml", `app: { name: "voyago" }
+http: { port: 8080 }
+log: { level: 9 }
+`)
+	_, err := f.reloader.Reload(context.Background())
+
+	var verr *config.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Zero(t, calls)
+
+	f.write(t, "config.yaml", globalFile)
+	changes, err := f.reloader.Reload(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, changes, "the fixed file is compared with the settings kept")
+}
+
+func TestReloader_FailingHookIsNotAudited(t *testing.T) {
+	f := newFixture(t, 0)
+	f.reloader.OnChange("maintenance.enabled", func(context.Context, reload.Change, *config.Config) error {
+		return errors.New("boom")
+	})
+
+	f.write(t, "config.yaml", globalFile+"maintenance: { enabled: true }\n")
+	changes, err := f.reloader.Reload(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Empty(t, f.audit.recorded())
+}
+
+func TestReloader_OnChangeRejectsOtherSettings(t *testing.T) {
+	f := newFixture(t, 0)
+
+	assert.PanicsWithError(t, `reload: "http.port" is not a reloadable setting`, func() {
+		f.reloader.OnChange("http.port", func(context.Context, reload.Change, *config.Config) error { return nil })
+	})
+}
+
+func TestReloader_WatchesTheFiles(t *testing.T) {
+	f := newFixture(t, 10*time.Millisecond)
+	messages := make(chan string, 1)
+	f.reloader.OnChange("maintenance.message", func(_ context.Context, _ reload.Change, cfg *config.Config) error {
+		messages <- cfg.Maintenance.Message
+		return nil
+	})
+	require.NoError(t, f.reloader.Start())
+	t.Cleanup(f.reloader.Stop)
+
+	f.write(t, "config.yaml", globalFile+`maintenance: { message: "Back at 10:00 UTC" }`+"\n")
+
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "Back at 10:00 UTC", msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the change was not reloaded")
+	}
+}
+
+func TestReloader_WatchesTheRemoteStore(t *testing.T) {
+	var mu sync.Mutex
+	document := ""
+	events := make(chan struct{})
+	// The range and watch calls of the etcd gateway.
+	etcd := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/kv/range" {
+			mu.Lock()
+			defer mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"kvs": []map[string]string{{"value": base64.StdEncoding.EncodeToString([]byte(document))}}})
+			return
+		}
+		w.(http.Flusher).Flush()
+		select {
+		case <-events:
+			_ = json.NewEncoder(w).Encode(map[string]any{"result": map[string]any{"events": []any{map[string]any{}}}})
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(etcd.Close)
+
+	f := newFixture(t, 10*time.Millisecond)
+	f.write(t, "config.yaml", globalFile+`remote: { provider: etcd, endpoint: "`+etcd.URL+`", watch: true }`+"\n")
+	global, err := config.LoadGlobalConfig(f.path("config.yaml"))
+	require.NoError(t, err)
+	f.reloader = reload.New(reload.Config{GlobalPath: f.path("config.yaml"), Current: map[string]*config.Config{reload.Main: global}, Debounce: 10 * time.Millisecond})
+	messages := make(chan string, 1)
+	f.reloader.OnChange("maintenance.message", func(_ context.Context, _ reload.Change, cfg *config.Config) error {
+		messages <- cfg.Maintenance.Message
+		return nil
+	})
+	require.NoError(t, f.reloader.Start())
+	t.Cleanup(f.reloader.Stop)
+
+	mu.Lock()
+	document = `maintenance: { message: "Back at 10:00 UTC" }`
+	mu.Unlock()
+	events <- struct{}{}
+
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "Back at 10:00 UTC", msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the change was not reloaded")
+	}
+}
@@ -0,0 +1,274 @@
+package saga_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/saga"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type order struct {
+	ID        string `json:"id"`
+	Reference string `json:"reference,omitempty"`
+}
+
+// journal records the calls of the steps, e.g. "reserve" or "undo:reserve".
+type journal struct {
+	calls []string
+	// fail makes the calls in it fail.
+	fail map[string]error
+}
+
+func (j *journal) step(name string) func(ctx context.Context, o *order) error {
+	return func(ctx context.Context, o *order) error {
+		j.calls = append(j.calls, name)
+		return j.fail[name]
+	}
+}
+
+func definition(j *journal) saga.Definition[order] {
+	return saga.Definition[order]{
+		Name: "test.order",
+		Steps: []saga.Step[order]{
+			{Name: "reserve", Action: j.step("reserve"), Compensate: j.step("undo:reserve")},
+			{Name: "pay", Action: func(ctx context.Context, o *order) error {
+				o.Reference = "PAY-" + o.ID
+				return j.step("pay")(ctx, o)
+			}, Compensate: j.step("undo:pay")},
+			{Name: "confirm", Action: j.step("confirm")},
+		},
+	}
+}
+
+func newStore(t *testing.T) (*saga.DatabaseStore, database.Database) {
+	t.Helper()
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), nil)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.GetDB().AutoMigrate(&saga.Instance{}))
+	return saga.NewDatabaseStore(db), db
+}
+
+func newCoordinator(def saga.Definition[order], store saga.Store) *saga.Coordinator[order] {
+	return saga.NewCoordinator(def, store, logger.NewNoOpLogger(), tracer.NewNoOpTracer(), nil)
+}
+
+func find(t *testing.T, store saga.Store, id string) *saga.Instance {
+	t.Helper()
+	inst, err := store.Find(context.Background(), "test.order", id)
+	require.NoError(t, err)
+	require.NotNil(t, inst)
+	return inst
+}
+
+// makeStale backdates the saga id, as if its process crashed a while ago.
+func makeStale(t *testing.T, db database.Database, id string) {
+	t.Helper()
+	require.NoError(t, db.GetDB().Model(&saga.Instance{}).
+		Where("id = ?", id).
+		Update("updated_at", time.Now().Add(-time.Hour).UnixMilli()).Error)
+}
+
+func TestCoordinator_Run_CompletesEveryStep(t *testing.T) {
+	store, _ := newStore(t)
+	j := &journal{}
+	c := newCoordinator(definition(j), store)
+
+	status, err := c.Run(context.Background(), "o-1", &order{ID: "o-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, saga.StatusCompleted, status)
+	assert.Equal(t, []string{"reserve", "pay", "confirm"}, j.calls)
+	inst := find(t, store, "o-1")
+	assert.Equal(t, saga.StatusCompleted, inst.Status)
+	assert.JSONEq(t, `{"id":"o-1","reference":"PAY-o-1"}`, inst.Data)
+}
+
+func TestCoordinator_Run_CompensatesInReverseOrder(t *testing.T) {
+	store, _ := newStore(t)
+	declined := errors.New("card declined")
+	j := &journal{fail: map[string]error{"pay": declined}}
+	c := newCoordinator(definition(j), store)
+
+	status, err := c.Run(context.Background(), "o-1", &order{ID: "o-1"})
+
+	assert.Equal(t, saga.StatusCompensated, status)
+	var stepErr *saga.StepError
+	require.ErrorAs(t, err, &stepErr)
+	assert.Equal(t, "pay", stepErr.Step)
+	assert.ErrorIs(t, err, declined)
+	// The failed step is compensated too: its outcome is unknown.
+	assert.Equal(t, []string{"reserve", "pay", "undo:pay", "undo:reserve"}, j.calls)
+	inst := find(t, store, "o-1")
+	assert.Equal(t, saga.StatusCompensated, inst.Status)
+	require.NotNil(t, inst.FailedStep)
+	assert.Equal(t, "pay", *inst.FailedStep)
+}
+
+func TestCoordinator_Run_RecoversAPanickingStep(t *testing.T) {
+	store, _ := newStore(t)
+	j := &journal{}
+	def := definition(j)
+	def.Steps[2].Action = func(ctx context.Context, o *order) error { panic("boom") }
+	c := newCoordinator(def, store)
+
+	status, err := c.Run(context.Background(), "o-1", &order{ID: "o-1"})
+
+	assert.Equal(t, saga.StatusCompensated, status)
+	assert.ErrorContains(t, err, "boom")
+	assert.Equal(t, []string{"reserve", "pay", "undo:pay", "undo:reserve"}, j.calls)
+}
+
+func TestCoordinator_Run_RejectsADuplicate(t *testing.T) {
+	store, _ := newStore(t)
+	j := &journal{}
+	c := newCoordinator(definition(j), store)
+	_, err := c.Run(context.Background(), "o-1", &order{ID: "o-1"})
+	require.NoError(t, err)
+
+	_, err = c.Run(context.Background(), "o-1", &order{ID: "o-1"})
+
+	assert.ErrorIs(t, err, saga.ErrDuplicate)
+	assert.Equal(t, []string{"reserve", "pay", "confirm"}, j.calls)
+}
+
+func TestCoordinator_Run_CompensatesPastTheTimeout(t *testing.T) {
+	store, _ := newStore(t)
+	j := &journal{}
+	def := definition(j)
+	def.Timeout = 20 * time.Millisecond
+	def.Steps[0].Action = func(ctx context.Context, o *order) error {
+		time.Sleep(40 * time.Millisecond)
+		return nil
+	}
+	c := newCoordinator(def, store)
+
+	status, err := c.Run(context.Background(), "o-1", &order{ID: "o-1"})
+
+	assert.Equal(t, saga.StatusCompensated, status)
+	assert.ErrorIs(t, err, saga.ErrTimeout)
+	assert.Equal(t, []string{"undo:pay", "undo:reserve"}, j.calls)
+}
+
+func TestCoordinator_Run_BoundsAStepWithItsTimeout(t *testing.T) {
+	store, _ := newStore(t)
+	j := &journal{}
+	def := definition(j)
+	def.Steps[1].Timeout = 10 * time.Millisecond
+	def.Steps[1].Action = func(ctx context.Context, o *order) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	c := newCoordinator(def, store)
+
+	status, err := c.Run(context.Background(), "o-1", &order{ID: "o-1"})
+
+	assert.Equal(t, saga.StatusCompensated, status)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{"reserve", "undo:pay", "undo:reserve"}, j.calls)
+}
+
+func TestCoordinator_FailedCompensation_IsCarriedOnByRecover(t *testing.T) {
+	store, db := newStore(t)
+	j := &journal{fail: map[string]error{"confirm": errors.New("conflict"), "undo:reserve": errors.New("inventory down")}}
+	c := newCoordinator(definition(j), store)
+
+	status, err := c.Run(context.Background(), "o-1", &order{ID: "o-1"})
+
+	assert.Equal(t, saga.StatusCompensating, status)
+	assert.EqualError(t, err, "inventory down")
+	inst := find(t, store, "o-1")
+	assert.Equal(t, saga.StatusCompensating, inst.Status)
+	assert.Equal(t, 0, inst.Step)
+
+	delete(j.fail, "undo:reserve")
+	j.calls = nil
+	makeStale(t, db, "o-1")
+	n, err := c.Recover(context.Background(), time.Minute, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"undo:reserve"}, j.calls)
+	assert.Equal(t, saga.StatusCompensated, find(t, store, "o-1").Status)
+}
+
+func TestCoordinator_Recover_ResumesAnInterruptedSaga(t *testing.T) {
+	store, db := newStore(t)
+	j := &journal{}
+	c := newCoordinator(definition(j), store)
+	// The process crashed while paying.
+	require.NoError(t, store.Create(context.Background(), &saga.Instance{
+		Name:   "test.order",
+		ID:     "o-1",
+		Status: saga.StatusRunning,
+		Step:   1,
+		Data:   `{"id":"o-1"}`,
+	}))
+	makeStale(t, db, "o-1")
+
+	n, err := c.Recover(context.Background(), time.Minute, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"pay", "confirm"}, j.calls)
+	inst := find(t, store, "o-1")
+	assert.Equal(t, saga.StatusCompleted, inst.Status)
+	assert.JSONEq(t, `{"id":"o-1","reference":"PAY-o-1"}`, inst.Data)
+}
+
+func TestCoordinator_Recover_LeavesTheSagasStillRunning(t *testing.T) {
+	store, _ := newStore(t)
+	j := &journal{}
+	c := newCoordinator(definition(j), store)
+	require.NoError(t, store.Create(context.Background(), &saga.Instance{
+		Name:      "test.order",
+		ID:        "o-1",
+		Status:    saga.StatusRunning,
+		Data:      `{"id":"o-1"}`,
+		UpdatedAt: time.Now().UnixMilli(),
+	}))
+
+	n, err := c.Recover(context.Background(), time.Minute, 10)
+
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Empty(t, j.calls)
+}
+
+func TestDatabaseStore_Update_DetectsAConcurrentChange(t *testing.T) {
+	store, _ := newStore(t)
+	ctx := context.Background()
+	require.NoError(t, store.Create(ctx, &saga.Instance{Name: "test.order", ID: "o-1", Status: saga.StatusRunning, Data: "{}"}))
+	first, second := find(t, store, "o-1"), find(t, store, "o-1")
+
+	first.Step = 1
+	require.NoError(t, store.Update(ctx, first))
+	second.Status = saga.StatusCompensating
+
+	assert.ErrorIs(t, store.Update(ctx, second), saga.ErrConflict)
+	assert.Equal(t, 1, find(t, store, "o-1").Version)
+}
+
+func TestNewCoordinator_PanicsOnAnInvalidDefinition(t *testing.T) {
+	store, _ := newStore(t)
+	noop := func(ctx context.Context, o *order) error { return nil }
+	cases := map[string]saga.Definition[order]{
+		"no name":     {Steps: []saga.Step[order]{{Name: "a", Action: noop}}},
+		"no steps":    {Name: "test.order"},
+		"no action":   {Name: "test.order", Steps: []saga.Step[order]{{Name: "a"}}},
+		"duplicate":   {Name: "test.order", Steps: []saga.Step[order]{{Name: "a", Action: noop}, {Name: "a", Action: noop}}},
+		"no stepname": {Name: "test.order", Steps: []saga.Step[order]{{Action: noop}}},
+	}
+	for name, def := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Panics(t, func() { newCoordinator(def, store) })
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/lock"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/scheduler"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newScheduler() *scheduler.Scheduler {
+	return scheduler.New(logger.NewNoOpLogger(), tracer.NewNoOpTracer(), metrics.NewNoOpMetrics())
+}
+
+func TestScheduler_RunsJobsEveryInterval(t *testing.T) {
+	s := newScheduler()
+	var runs, failing atomic.Int32
+	s.Add(scheduler.Job{Name: "count", Interval: 10 * time.Millisecond, Run: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+	s.Add(scheduler.Job{Name: "fail", Interval: 10 * time.Millisecond, Run: func(context.Context) error {
+		failing.Add(1)
+		return errors.New("boom")
+	}})
+	assert.Equal(t, []string{"count", "fail"}, s.Jobs())
+
+	s.Start()
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 && failing.Load() >= 3 }, time.Second, 5*time.Millisecond,
+		"a failing job runs again")
+	s.Stop()
+
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load(), "no run after Stop")
+}
+
+func TestScheduler_Add_RejectsInvalidJobs(t *testing.T) {
+	s := newScheduler()
+	run := func(context.Context) error { return nil }
+
+	assert.Panics(t, func() { s.Add(scheduler.Job{Interval: time.Second, Run: run}) }, "no name")
+	assert.Panics(t, func() { s.Add(scheduler.Job{Name: "job", Interval: time.Second}) }, "no run")
+	assert.Panics(t, func() { s.Add(scheduler.Job{Name: "job", Run: run}) }, "no interval nor spec")
+	assert.Panics(t, func() { s.Add(scheduler.Job{Name: "job", Interval: time.Second, Spec: "@hourly", Run: run}) }, "both")
+	assert.Panics(t, func() { s.Add(scheduler.Job{Name: "job", Spec: "61 * * * *", Run: run}) }, "invalid spec")
+
+	s.Add(scheduler.Job{Name: "job", Interval: time.Second, Run: run})
+	assert.Panics(t, func() { s.Add(scheduler.Job{Name: "job", Interval: time.Minute, Run: run}) }, "duplicate")
+}
+
+func TestScheduler_Stop_WaitsForTheRunningJobs(t *testing.T) {
+	s := newScheduler()
+	started := make(chan struct{})
+	var completed atomic.Bool
+	s.Add(scheduler.Job{Name: "slow", Interval: 5 * time.Millisecond, Run: func(context.Context) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		time.Sleep(50 * time.Millisecond)
+		completed.Store(true)
+		return nil
+	}})
+
+	s.Start()
+	<-started
+	s.Stop()
+	assert.True(t, completed.Load())
+	s.Stop() // idempotent
+}
+
+func TestScheduler_RecoversFromPanickingJobs(t *testing.T) {
+	s := newScheduler()
+	var runs atomic.Int32
+	s.Add(scheduler.Job{Name: "panic", Interval: 5 * time.Millisecond, Run: func(context.Context) error {
+		runs.Add(1)
+		panic("boom")
+	}})
+
+	s.Start()
+	defer s.Stop()
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, 5*time.Millisecond)
+}
+
+func TestScheduler_Spec_RunsTheJobOnItsSchedule(t *testing.T) {
+	s := newScheduler()
+	var runs atomic.Int32
+	s.Add(scheduler.Job{Name: "cron", Spec: "@every 1s", Run: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+	s.Add(scheduler.Job{Name: "nightly", Spec: "CRON_TZ=Asia/Jakarta 0 2 * * *", Run: func(context.Context) error { return nil }})
+
+	s.Start()
+	defer s.Stop()
+	assert.Eventually(t, func() bool { return runs.Load() == 1 }, 2*time.Second, 10*time.Millisecond)
+}
+
+type fakeLeader struct{ leading atomic.Bool }
+
+func (l *fakeLeader) IsLeader() bool { return l.leading.Load() }
+
+func TestScheduler_RunOnLeader_SkipsTheTurnsOfTheOtherReplicas(t *testing.T) {
+	s := newScheduler()
+	l := &fakeLeader{}
+	s.RunOnLeader(l)
+	var runs atomic.Int32
+	s.Add(scheduler.Job{Name: "count", Interval: 5 * time.Millisecond, Run: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+
+	s.Start()
+	defer s.Stop()
+	time.Sleep(30 * time.Millisecond)
+	assert.Zero(t, runs.Load(), "not elected")
+
+	l.leading.Store(true)
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, 5*time.Millisecond)
+}
+
+func TestScheduler_Locker_RunsAJobOnASingleReplica(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	locker := lock.NewRedisLocker(client, "test:lock:")
+
+	var runs atomic.Int32
+	job := scheduler.Job{Name: "purge", Interval: 20 * time.Millisecond, Locker: locker, Run: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}}
+
+	// miniredis expires the keys on FastForward only: the lock of the first
+	// turn is held for good.
+	replicas := []*scheduler.Scheduler{newScheduler(), newScheduler(), newScheduler()}
+	for _, s := range replicas {
+		s.Add(job)
+		s.Start()
+	}
+	time.Sleep(100 * time.Millisecond)
+	for _, s := range replicas {
+		s.Stop()
+	}
+
+	assert.Equal(t, int32(1), runs.Load())
+	require.True(t, mr.Exists("test:lock:job:purge"))
+}
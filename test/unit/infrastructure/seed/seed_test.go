@@ -0,0 +1,116 @@
+package seed_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/seed"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type setting struct {
+	Key string `gorm:"primaryKey"`
+}
+
+func newDB(t *testing.T) database.Database {
+	t.Helper()
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), tracer.NewNoOpTracer())
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.GetDB().AutoMigrate(&setting{}))
+	return db
+}
+
+// inserting returns a seeder inserting the setting key.
+func inserting(name string, kind seed.Kind, key string) seed.Seeder {
+	return seed.Seeder{
+		Name: name,
+		Kind: kind,
+		Run: func(ctx context.Context, db database.Database) error {
+			return db.WithContext(ctx).Create(&setting{Key: key}).Error
+		},
+	}
+}
+
+func keys(t *testing.T, db database.Database) []string {
+	t.Helper()
+	var out []string
+	require.NoError(t, db.GetDB().Model(&setting{}).Order("key").Pluck("key", &out).Error)
+	return out
+}
+
+func TestRun_AppliesEachSeederOnce(t *testing.T) {
+	db := newDB(t)
+	seeders := []seed.Seeder{inserting("currencies", seed.Reference, "currency"), inserting("demo", seed.Sample, "demo")}
+
+	results, err := seed.Run(context.Background(), db, "development", seeders)
+	require.NoError(t, err)
+	assert.Equal(t, []seed.Result{{Name: "currencies", Outcome: seed.Applied}, {Name: "demo", Outcome: seed.Applied}}, results)
+
+	results, err = seed.Run(context.Background(), db, "development", seeders)
+	require.NoError(t, err, "the seeders would fail on the duplicate keys")
+	assert.Equal(t, []seed.Result{{Name: "currencies", Outcome: seed.AlreadyApplied}, {Name: "demo", Outcome: seed.AlreadyApplied}}, results)
+	assert.Equal(t, []string{"currency", "demo"}, keys(t, db))
+}
+
+func TestRun_RefusesSampleDataInProduction(t *testing.T) {
+	for _, env := range []string{"production", "prod"} {
+		t.Run(env, func(t *testing.T) {
+			db := newDB(t)
+
+			results, err := seed.Run(context.Background(), db, env, []seed.Seeder{
+				inserting("currencies", seed.Reference, "currency"),
+				inserting("demo", seed.Sample, "demo"),
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, []seed.Result{{Name: "currencies", Outcome: seed.Applied}, {Name: "demo", Outcome: seed.Refused}}, results)
+			assert.Equal(t, []string{"currency"}, keys(t, db))
+		})
+	}
+}
+
+func TestRun_StopsAtAFailingSeeder(t *testing.T) {
+	db := newDB(t)
+	boom := errors.New("boom")
+	failing := seed.Seeder{Name: "broken", Run: func(ctx context.Context, db database.Database) error {
+		require.NoError(t, db.WithContext(ctx).Create(&setting{Key: "partial"}).Error)
+		return boom
+	}}
+
+	results, err := seed.Run(context.Background(), db, "development", []seed.Seeder{
+		inserting("first", seed.Reference, "first"),
+		failing,
+		inserting("last", seed.Reference, "last"),
+	})
+
+	assert.ErrorIs(t, err, boom)
+	assert.ErrorContains(t, err, "seeder broken")
+	assert.Equal(t, []seed.Result{{Name: "first", Outcome: seed.Applied}}, results)
+	assert.Equal(t, []string{"first"}, keys(t, db), "the failing seeder was rolled back")
+
+	results, err = seed.Run(context.Background(), db, "development", []seed.Seeder{inserting("broken", seed.Reference, "fixed")})
+	require.NoError(t, err)
+	assert.Equal(t, []seed.Result{{Name: "broken", Outcome: seed.Applied}}, results, "a failed seeder is not recorded")
+}
+
+func TestRegistry(t *testing.T) {
+	r := seed.NewRegistry()
+	r.Register("booking", inserting("a", seed.Sample, "a"), inserting("b", seed.Sample, "b"))
+
+	names := []string{}
+	for _, s := range r.Seeders("booking") {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"a", "b"}, names, "in registration order")
+	assert.Empty(t, r.Seeders("webhook"))
+
+	assert.Panics(t, func() { r.Register("booking", inserting("a", seed.Sample, "a")) }, "duplicate name")
+	assert.Panics(t, func() { r.Register("booking", seed.Seeder{Name: "c"}) }, "no Run")
+	assert.NotPanics(t, func() { r.Register("webhook", inserting("a", seed.Sample, "a")) }, "names are per domain")
+}
@@ -0,0 +1,148 @@
+package sse_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/sse"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+// serve exposes GET /streams/:key on a real socket and returns the base URL.
+func serve(t *testing.T, b *sse.Broker, initial ...sse.Message) string {
+	t.Helper()
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/streams/:key", func(c *fiber.Ctx) error {
+		sub, err := b.Subscribe(c.UserContext(), "test", c.Params("key"))
+		if err != nil {
+			return err
+		}
+		return sub.Serve(c, initial...)
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(ln) }()
+	t.Cleanup(func() { _ = app.ShutdownWithTimeout(time.Second) })
+
+	return "http://" + ln.Addr().String()
+}
+
+// open connects to a stream and returns a reader of its raw lines.
+func open(t *testing.T, url string) (*bufio.Reader, context.CancelFunc) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get(fiber.HeaderContentType))
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return bufio.NewReader(resp.Body), cancel
+}
+
+// readBlock reads lines up to the next blank line.
+func readBlock(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+
+	var sb strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		if line == "\n" {
+			return sb.String()
+		}
+		sb.WriteString(line)
+	}
+}
+
+func newBroker(cfg config.SSEConfig) *sse.Broker {
+	return sse.NewBroker(cfg, logger.NewNoOpLogger(), metrics.NewNoOpMetrics())
+}
+
+// ============================================================================
+// TEST CASES
+// ============================================================================
+
+func TestBroker_StreamsInitialAndPublishedMessages(t *testing.T) {
+	b := newBroker(config.SSEConfig{})
+	url := serve(t, b, sse.Message{Event: "snapshot", Data: map[string]string{"status": "PENDING"}})
+
+	r, cancel := open(t, url+"/streams/42")
+	defer cancel()
+
+	assert.Equal(t, "event: snapshot\ndata: {\"status\":\"PENDING\"}\n", readBlock(t, r))
+
+	// Another key is not delivered to this stream.
+	b.Publish("test", "other", sse.Message{Event: "ignored", Data: 1})
+	b.Publish("test", "42", sse.Message{ID: "evt-1", Event: "changed", Data: map[string]string{"status": "PAID"}})
+
+	assert.Equal(t, "id: evt-1\nevent: changed\ndata: {\"status\":\"PAID\"}\n", readBlock(t, r))
+}
+
+func TestBroker_SendsHeartbeat(t *testing.T) {
+	b := newBroker(config.SSEConfig{HeartbeatInterval: 1})
+	url := serve(t, b)
+
+	r, cancel := open(t, url+"/streams/42")
+	defer cancel()
+
+	assert.Equal(t, ": heartbeat\n", readBlock(t, r))
+}
+
+func TestBroker_ConnectionsReleasedOnDisconnect(t *testing.T) {
+	b := newBroker(config.SSEConfig{HeartbeatInterval: 1})
+	url := serve(t, b)
+
+	_, cancel := open(t, url+"/streams/42")
+	require.Eventually(t, func() bool { return b.Connections("test") == 1 }, time.Second, 10*time.Millisecond)
+
+	// The disconnect is detected by a failing heartbeat write.
+	cancel()
+	assert.Eventually(t, func() bool { return b.Connections("test") == 0 }, 10*time.Second, 20*time.Millisecond)
+}
+
+func TestBroker_SlowSubscriberIsDisconnected(t *testing.T) {
+	b := newBroker(config.SSEConfig{BufferSize: 1})
+
+	sub, err := b.Subscribe(context.Background(), "test", "42")
+	require.NoError(t, err)
+
+	b.Publish("test", "42", sse.Message{Data: 1})
+	assert.NoError(t, sub.Context().Err())
+
+	b.Publish("test", "42", sse.Message{Data: 2})
+	assert.ErrorIs(t, sub.Context().Err(), context.Canceled)
+	assert.Equal(t, 0, b.Connections("test"))
+
+	// Closing again is harmless.
+	sub.Close()
+}
+
+func TestBroker_NilBroker(t *testing.T) {
+	var b *sse.Broker
+
+	_, err := b.Subscribe(context.Background(), "test", "42")
+	assert.Error(t, err)
+	assert.NotPanics(t, func() { b.Publish("test", "42", sse.Message{}) })
+}
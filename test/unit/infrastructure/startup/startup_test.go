@@ -0,0 +1,119 @@
+package startup_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"voyago/core-api/internal/app"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/startup"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFail_ExitCodes(t *testing.T) {
+	s := startup.New(logger.NewNoOpLogger())
+	errDial := errors.New("connection refused")
+
+	tests := map[string]struct {
+		err  error
+		want int
+	}{
+		"config":      {startup.Config("config", errors.New("missing file")), startup.ExitConfig},
+		"unavailable": {startup.Unavailable("http server", errDial), startup.ExitUnavailable},
+		"wrapped":     {fmt.Errorf("shutdown: %w", startup.Unavailable("http server", errDial)), startup.ExitUnavailable},
+		"plain":       {errDial, startup.ExitFailure},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.Fail(tt.err))
+		})
+	}
+}
+
+func TestGuard_TurnsPanicsIntoErrors(t *testing.T) {
+	errStore := errors.New(`invalid maintenance configuration: unknown store "bogus"`)
+
+	err := startup.Guard("bootstrap", startup.ExitConfig, func() { panic(errStore) })
+
+	var startupErr *startup.Error
+	require.ErrorAs(t, err, &startupErr)
+	assert.Equal(t, "bootstrap", startupErr.Component)
+	assert.Equal(t, startup.ExitConfig, startupErr.Code)
+	assert.ErrorIs(t, err, errStore)
+
+	err = startup.Guard("bootstrap", startup.ExitConfig, func() { panic("schema is invalid") })
+	assert.EqualError(t, err, "bootstrap: schema is invalid")
+
+	assert.NoError(t, startup.Guard("bootstrap", startup.ExitConfig, func() {}))
+}
+
+func TestRecover_ExitSoftware(t *testing.T) {
+	run := func() (code int) {
+		s := startup.New(logger.NewNoOpLogger())
+		defer s.Recover(&code)
+		panic("boom")
+	}
+
+	assert.Equal(t, startup.ExitSoftware, run())
+}
+
+func TestNewTelemetry_DegradedMode(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Telemetry = config.TelemetryConfig{
+		Enabled:        true,
+		Type:           "datadog",
+		MetricsAddress: "not an address",
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		s := startup.New(logger.NewNoOpLogger())
+
+		_, _, err := app.NewTelemetry(cfg, s)
+
+		var startupErr *startup.Error
+		require.ErrorAs(t, err, &startupErr)
+		assert.Equal(t, "metrics", startupErr.Component)
+		assert.Equal(t, startup.ExitUnavailable, s.Fail(err))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		degraded := *cfg
+		degraded.Telemetry.DegradedMode = true
+		degraded.Telemetry.TracerAddress = "127.0.0.1:1"
+		s := startup.New(logger.NewNoOpLogger())
+
+		m, trc, err := app.NewTelemetry(&degraded, s)
+
+		require.NoError(t, err)
+		defer trc.Close()
+		assert.Equal(t, metrics.NewNoOpMetrics(), m)
+		assert.Equal(t, []string{"metrics"}, s.Degraded())
+	})
+
+	t.Run("statsd fallback", func(t *testing.T) {
+		degraded := *cfg
+		degraded.Telemetry.DegradedMode = true
+		degraded.Telemetry.TracerAddress = "127.0.0.1:1"
+		degraded.Telemetry.StatsDFallback = "127.0.0.1:8125"
+		s := startup.New(logger.NewNoOpLogger())
+
+		m, trc, err := app.NewTelemetry(&degraded, s)
+
+		require.NoError(t, err)
+		defer trc.Close()
+		defer m.Close()
+		assert.Implements(t, (*metrics.Dropper)(nil), m, "the metrics go to StatsD")
+		assert.Equal(t, []string{"metrics"}, s.Degraded())
+	})
+}
+
+func TestLoadGlobalConfig_ReturnsErrors(t *testing.T) {
+	_, err := config.LoadGlobalConfig("does/not/exist.yaml")
+
+	assert.ErrorContains(t, err, "error reading global config")
+}
@@ -0,0 +1,298 @@
+package taskqueue_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/taskqueue"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	baserepo "voyago/core-api/internal/pkg/repository"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type greeting struct {
+	Name string `json:"name"`
+}
+
+var greetTask = taskqueue.NewType[greeting]("test.greet")
+
+// brokers runs fn against every broker.
+func brokers(t *testing.T, fn func(t *testing.T, b taskqueue.Broker)) {
+	t.Run("memory", func(t *testing.T) {
+		fn(t, taskqueue.NewMemoryBroker())
+	})
+	t.Run("redis", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+		fn(t, taskqueue.NewRedisBroker(client, "test:tasks:"))
+	})
+}
+
+func newProcessor(b taskqueue.Broker, mux *taskqueue.Mux, cfg config.TaskQueueConfig) *taskqueue.Processor {
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = 5
+	}
+	return taskqueue.NewProcessor(b, mux, cfg, logger.NewNoOpLogger(), tracer.NewNoOpTracer(), metrics.NewNoOpMetrics())
+}
+
+func TestBroker_DequeuesTheDueTasksOnce(t *testing.T) {
+	brokers(t, func(t *testing.T, b taskqueue.Broker) {
+		ctx := context.Background()
+		later, err := greetTask.New(greeting{Name: "later"}, taskqueue.ProcessIn(time.Hour))
+		require.NoError(t, err)
+		now, err := greetTask.New(greeting{Name: "now"})
+		require.NoError(t, err)
+		require.NoError(t, b.Enqueue(ctx, later))
+		require.NoError(t, b.Enqueue(ctx, now))
+
+		task, err := b.Dequeue(ctx, taskqueue.DefaultQueue, time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, task)
+		assert.Equal(t, now.ID, task.ID)
+		assert.JSONEq(t, `{"name":"now"}`, string(task.Payload))
+
+		task, err = b.Dequeue(ctx, taskqueue.DefaultQueue, time.Minute)
+		require.NoError(t, err)
+		assert.Nil(t, task, "the leased task and the delayed one are not due")
+
+		other, err := b.Dequeue(ctx, "other", time.Minute)
+		require.NoError(t, err)
+		assert.Nil(t, other)
+	})
+}
+
+func TestBroker_Enqueue_RejectsADuplicateID(t *testing.T) {
+	brokers(t, func(t *testing.T, b taskqueue.Broker) {
+		ctx := context.Background()
+		first := taskqueue.NewTask("test.greet", []byte(`{}`), taskqueue.ID("greet:1"))
+		require.NoError(t, b.Enqueue(ctx, first))
+		assert.ErrorIs(t, b.Enqueue(ctx, taskqueue.NewTask("test.greet", []byte(`{}`), taskqueue.ID("greet:1"))), taskqueue.ErrDuplicateTask)
+
+		task, err := b.Dequeue(ctx, taskqueue.DefaultQueue, time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, b.Complete(ctx, task))
+		assert.NoError(t, b.Enqueue(ctx, taskqueue.NewTask("test.greet", []byte(`{}`), taskqueue.ID("greet:1"))),
+			"the ID is free once the task completed")
+	})
+}
+
+func TestBroker_HandsAnExpiredLeaseToAnotherProcessor(t *testing.T) {
+	brokers(t, func(t *testing.T, b taskqueue.Broker) {
+		ctx := context.Background()
+		require.NoError(t, b.Enqueue(ctx, taskqueue.NewTask("test.greet", []byte(`{}`))))
+
+		first, err := b.Dequeue(ctx, taskqueue.DefaultQueue, 10*time.Millisecond)
+		require.NoError(t, err)
+		require.NotNil(t, first)
+		time.Sleep(20 * time.Millisecond)
+
+		again, err := b.Dequeue(ctx, taskqueue.DefaultQueue, time.Minute)
+		require.NoError(t, err)
+		require.NotNil(t, again)
+		assert.Equal(t, first.ID, again.ID)
+	})
+}
+
+func TestBroker_Kill_KeepsTheDeadTasks(t *testing.T) {
+	brokers(t, func(t *testing.T, b taskqueue.Broker) {
+		ctx := context.Background()
+		require.NoError(t, b.Enqueue(ctx, taskqueue.NewTask("test.greet", []byte(`{}`), taskqueue.ID("a"))))
+		task, err := b.Dequeue(ctx, taskqueue.DefaultQueue, time.Minute)
+		require.NoError(t, err)
+		task.LastError = "boom"
+		require.NoError(t, b.Kill(ctx, task, time.Hour))
+
+		dead, err := b.Dead(ctx, taskqueue.DefaultQueue, 10)
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+		assert.Equal(t, "a", dead[0].ID)
+		assert.Equal(t, "boom", dead[0].LastError)
+
+		next, err := b.Dequeue(ctx, taskqueue.DefaultQueue, time.Minute)
+		require.NoError(t, err)
+		assert.Nil(t, next, "a dead task is not run again")
+	})
+}
+
+func TestProcessor_RunsTheTasksWithTheirPayload(t *testing.T) {
+	brokers(t, func(t *testing.T, b taskqueue.Broker) {
+		var mu sync.Mutex
+		var names []string
+		mux := taskqueue.NewMux()
+		mux.Handle(greetTask.Name, greetTask.Handler(func(_ context.Context, g greeting) error {
+			mu.Lock()
+			defer mu.Unlock()
+			names = append(names, g.Name)
+			return nil
+		}))
+
+		for _, name := range []string{"ann", "bob"} {
+			task, err := greetTask.New(greeting{Name: name})
+			require.NoError(t, err)
+			require.NoError(t, taskqueue.NewClient(b, tracer.NewNoOpTracer()).Enqueue(context.Background(), task))
+		}
+
+		p := newProcessor(b, mux, config.TaskQueueConfig{Concurrency: 2})
+		p.Start()
+		defer p.Stop()
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(names) == 2
+		}, time.Second, 5*time.Millisecond)
+		assert.ElementsMatch(t, []string{"ann", "bob"}, names)
+	})
+}
+
+func TestProcessor_RetriesThenKillsAFailingTask(t *testing.T) {
+	brokers(t, func(t *testing.T, b taskqueue.Broker) {
+		var runs atomic.Int32
+		mux := taskqueue.NewMux()
+		mux.Handle("test.fail", func(context.Context, *taskqueue.Task) error {
+			runs.Add(1)
+			return errors.New("partner down")
+		})
+		require.NoError(t, b.Enqueue(context.Background(), taskqueue.NewTask("test.fail", []byte(`{}`), taskqueue.MaxRetry(1))))
+
+		cfg := config.TaskQueueConfig{}
+		cfg.Retry.BaseBackoff = 1
+		p := newProcessor(b, mux, cfg)
+		p.Start()
+		defer p.Stop()
+
+		assert.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, 5*time.Millisecond)
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(t, int32(1), runs.Load(), "retried after the backoff")
+
+		var dead []*taskqueue.Task
+		assert.Eventually(t, func() bool {
+			dead, _ = b.Dead(context.Background(), taskqueue.DefaultQueue, 10)
+			return len(dead) == 1
+		}, 3*time.Second, 10*time.Millisecond)
+		assert.Equal(t, int32(2), runs.Load(), "run once, then retried once")
+		assert.Equal(t, 1, dead[0].Retried)
+		assert.Equal(t, "partner down", dead[0].LastError)
+	})
+}
+
+func TestProcessor_KillsAPermanentFailureAtOnce(t *testing.T) {
+	brokers(t, func(t *testing.T, b taskqueue.Broker) {
+		var runs atomic.Int32
+		mux := taskqueue.NewMux()
+		mux.Handle(greetTask.Name, greetTask.Handler(func(context.Context, greeting) error {
+			runs.Add(1)
+			return nil
+		}))
+		// The payload does not decode.
+		require.NoError(t, b.Enqueue(context.Background(), taskqueue.NewTask(greetTask.Name, []byte(`[1]`))))
+
+		p := newProcessor(b, mux, config.TaskQueueConfig{})
+		p.Start()
+		defer p.Stop()
+
+		var dead []*taskqueue.Task
+		assert.Eventually(t, func() bool {
+			dead, _ = b.Dead(context.Background(), taskqueue.DefaultQueue, 10)
+			return len(dead) == 1
+		}, time.Second, 5*time.Millisecond)
+		assert.Zero(t, runs.Load())
+		assert.Zero(t, dead[0].Retried)
+	})
+}
+
+func TestProcessor_RecoversFromAPanickingHandler(t *testing.T) {
+	b := taskqueue.NewMemoryBroker()
+	mux := taskqueue.NewMux()
+	mux.Handle("test.panic", func(context.Context, *taskqueue.Task) error { panic("boom") })
+	require.NoError(t, b.Enqueue(context.Background(), taskqueue.NewTask("test.panic", []byte(`{}`), taskqueue.MaxRetry(0))))
+
+	p := newProcessor(b, mux, config.TaskQueueConfig{})
+	p.Start()
+	defer p.Stop()
+
+	var dead []*taskqueue.Task
+	assert.Eventually(t, func() bool {
+		dead, _ = b.Dead(context.Background(), taskqueue.DefaultQueue, 10)
+		return len(dead) == 1
+	}, time.Second, 5*time.Millisecond)
+	assert.Contains(t, dead[0].LastError, "task panicked: boom")
+}
+
+func TestProcessor_Stop_WaitsForTheRunningTasks(t *testing.T) {
+	b := taskqueue.NewMemoryBroker()
+	started := make(chan struct{})
+	var done atomic.Bool
+	mux := taskqueue.NewMux()
+	mux.Handle("test.slow", func(context.Context, *taskqueue.Task) error {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		done.Store(true)
+		return nil
+	})
+	require.NoError(t, b.Enqueue(context.Background(), taskqueue.NewTask("test.slow", []byte(`{}`))))
+
+	p := newProcessor(b, mux, config.TaskQueueConfig{})
+	p.Start()
+	<-started
+	p.Stop()
+	assert.True(t, done.Load())
+}
+
+func TestMux_Handle_RejectsADuplicateType(t *testing.T) {
+	mux := taskqueue.NewMux()
+	h := func(context.Context, *taskqueue.Task) error { return nil }
+	mux.Handle("b", h)
+	mux.Handle("a", h)
+	assert.Equal(t, []string{"a", "b"}, mux.Types())
+	assert.Panics(t, func() { mux.Handle("a", h) })
+	assert.Panics(t, func() { mux.Handle("", h) })
+}
+
+type recordingEnqueuer struct {
+	mu    sync.Mutex
+	tasks []*taskqueue.Task
+	err   error
+}
+
+func (e *recordingEnqueuer) Enqueue(_ context.Context, task *taskqueue.Task) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.tasks = append(e.tasks, task)
+	return e.err
+}
+
+func TestEnqueueAfterCommit_WaitsForTheCommit(t *testing.T) {
+	q := &recordingEnqueuer{}
+	txCtx, tx := baserepo.WithAfterCommit(context.Background())
+	task := taskqueue.NewTask("test.greet", []byte(`{}`))
+
+	taskqueue.EnqueueAfterCommit(txCtx, logger.NewNoOpLogger(), q, task)
+	assert.Empty(t, q.tasks, "not before the commit")
+
+	tx.Committed(context.Background())
+	require.Len(t, q.tasks, 1)
+	assert.Same(t, task, q.tasks[0])
+}
+
+func TestEnqueueAfterCommit_ToleratesADisabledQueueAndDuplicates(t *testing.T) {
+	task := taskqueue.NewTask("test.greet", []byte(`{}`))
+	assert.NotPanics(t, func() {
+		taskqueue.EnqueueAfterCommit(context.Background(), logger.NewNoOpLogger(), nil, task)
+	})
+
+	q := &recordingEnqueuer{err: taskqueue.ErrDuplicateTask}
+	taskqueue.EnqueueAfterCommit(context.Background(), logger.NewNoOpLogger(), q, task)
+	assert.Len(t, q.tasks, 1)
+}
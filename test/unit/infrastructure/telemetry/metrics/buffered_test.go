@@ -0,0 +1,78 @@
+package metrics_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowMetrics blocks every recording until released, as an exporter stalled
+// by an unreachable collector.
+type slowMetrics struct {
+	metrics.Metrics
+	release chan struct{}
+
+	mu     sync.Mutex
+	counts map[string]int
+	gauges map[string]float64
+	closed bool
+}
+
+func newSlowMetrics() *slowMetrics {
+	return &slowMetrics{
+		Metrics: metrics.NewNoOpMetrics(),
+		release: make(chan struct{}),
+		counts:  map[string]int{},
+		gauges:  map[string]float64{},
+	}
+}
+
+func (m *slowMetrics) Incr(name string, _ []string) {
+	<-m.release
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts[name]++
+}
+
+func (m *slowMetrics) Gauge(name string, value float64, _ []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gauges[name] = value
+}
+
+func (m *slowMetrics) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	return nil
+}
+
+func TestBuffered_DropsWhenFull(t *testing.T) {
+	next := newSlowMetrics()
+	m := metrics.NewBufferedMetrics(next, 2)
+
+	start := time.Now()
+	for range 10 {
+		m.Incr("booking.created", nil)
+	}
+	assert.Less(t, time.Since(start), 100*time.Millisecond, "recording never waits for the exporter")
+
+	dropped := m.(metrics.Dropper).Dropped()
+	// One recording is being applied, two are queued.
+	assert.GreaterOrEqual(t, dropped, uint64(7))
+
+	close(next.release)
+	require.NoError(t, m.Close())
+
+	assert.Equal(t, 10-int(dropped), next.counts["booking.created"], "the queue is applied before closing")
+	assert.Equal(t, float64(dropped), next.gauges["metrics.dropped"], "the drops are reported")
+	assert.True(t, next.closed)
+
+	m.Incr("booking.created", nil)
+	assert.Equal(t, dropped+1, m.(metrics.Dropper).Dropped(), "recordings after Close are dropped")
+}
@@ -0,0 +1,72 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedMetric struct {
+	name  string
+	value float64
+	tags  []string
+}
+
+type recordingMetrics struct {
+	metrics.Metrics
+	recorded []recordedMetric
+}
+
+func (m *recordingMetrics) Incr(name string, tags []string) {
+	m.recorded = append(m.recorded, recordedMetric{name, 1, tags})
+}
+
+func (m *recordingMetrics) Distribution(name string, value float64, tags []string) {
+	m.recorded = append(m.recorded, recordedMetric{name, value, tags})
+}
+
+func (m *recordingMetrics) Timing(name string, value time.Duration, tags []string) {
+	m.recorded = append(m.recorded, recordedMetric{name, value.Seconds(), tags})
+}
+
+func TestBusiness_Instruments(t *testing.T) {
+	m := &recordingMetrics{Metrics: metrics.NewNoOpMetrics()}
+	bm := metrics.NewBusiness(m)
+
+	bm.BookingCreated(250000)
+	bm.BookingFailed("BOOKING_CODE_ALREADY_EXISTS")
+	bm.PaymentStatusChanged("UNPAID", "PAID")
+
+	assert.Equal(t, []recordedMetric{
+		{"booking.created", 1, []string{}},
+		{"booking.amount", 250000, []string{}},
+		{"booking.failed", 1, []string{"code:BOOKING_CODE_ALREADY_EXISTS"}},
+		{"booking.payment_status.changed", 1, []string{"from:UNPAID", "to:PAID"}},
+	}, m.recorded)
+}
+
+func TestBusiness_BoundsTagValues(t *testing.T) {
+	m := &recordingMetrics{Metrics: metrics.NewNoOpMetrics()}
+	bm := metrics.NewBusiness(m)
+
+	bm.BookingFailed("")
+	bm.BookingFailed("duplicate key value violates unique constraint \"bookings_pkey\"")
+	bm.PaymentStatusChanged("UNPAID", "BOOKING-42")
+
+	assert.Equal(t, []string{"code:OTHER"}, m.recorded[0].tags)
+	assert.Equal(t, []string{"code:OTHER"}, m.recorded[1].tags, "free text never becomes a series")
+	assert.Equal(t, []string{"from:UNPAID", "to:OTHER"}, m.recorded[2].tags)
+}
+
+func TestBusiness_Nil(t *testing.T) {
+	var bm *metrics.Business
+
+	assert.NotPanics(t, func() {
+		bm.BookingCreated(100)
+		bm.BookingFailed("INTERNAL_ERROR")
+		bm.PaymentStatusChanged("UNPAID", "PAID")
+	})
+}
@@ -0,0 +1,103 @@
+package metrics_test
+
+import (
+	"fmt"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type routeRecorder struct {
+	recordingMetrics
+	routes  []string
+	methods []string
+}
+
+func (m *routeRecorder) RecordHTTP(_ string, _ string, routePath string, _ int, _ float64) {
+	m.routes = append(m.routes, routePath)
+}
+
+func (m *routeRecorder) RecordGRPC(method string, _ string, _ float64) {
+	m.methods = append(m.methods, method)
+}
+
+func newGuarded(maxSeries int, allowlist ...config.MetricTagAllowlist) (*routeRecorder, metrics.Metrics) {
+	next := &routeRecorder{recordingMetrics: recordingMetrics{Metrics: metrics.NewNoOpMetrics()}}
+	return next, metrics.NewCardinalityGuard(next, config.MetricsCardinalityConfig{
+		Enabled:   true,
+		MaxSeries: maxSeries,
+		Allowlist: allowlist,
+	})
+}
+
+func TestCardinalityGuard_Allowlist(t *testing.T) {
+	next, m := newGuarded(10, config.MetricTagAllowlist{
+		Metric: "http_client_request_duration",
+		Tags:   []string{"host", "status"},
+	})
+
+	m.Incr("http_client_request_duration", []string{"host:api.partner.com", "url:/v1/payments/42", "status:200"})
+	m.Incr("cache.hit", []string{"cache:booking", "key:booking:42"})
+
+	assert.Equal(t, []string{"host:api.partner.com", "status:200"}, next.recorded[0].tags)
+	assert.Equal(t, []string{"cache:booking", "key:booking:42"}, next.recorded[1].tags, "a metric without entry keeps its tags")
+}
+
+func TestCardinalityGuard_CapsSeries(t *testing.T) {
+	next, m := newGuarded(2)
+
+	for i := range 4 {
+		m.Incr("booking.viewed", []string{fmt.Sprintf("booking_id:%d", i), "source:web"})
+	}
+	m.Incr("booking.viewed", []string{"booking_id:0", "source:web"})
+	m.Incr("cache.hit", []string{"cache:booking"})
+
+	var tags [][]string
+	var overflows int
+	for _, r := range next.recorded {
+		switch r.name {
+		case "booking.viewed":
+			tags = append(tags, r.tags)
+		case "metrics.cardinality.overflow":
+			overflows++
+			assert.Equal(t, []string{"metric:booking.viewed"}, r.tags)
+		}
+	}
+	assert.Equal(t, [][]string{
+		{"booking_id:0", "source:web"},
+		{"booking_id:1", "source:web"},
+		{"booking_id:other", "source:other"},
+		{"booking_id:other", "source:other"},
+		{"booking_id:0", "source:web"},
+	}, tags, "known series are still recorded once the cap is reached")
+	assert.Equal(t, 2, overflows)
+	assert.Equal(t, []string{"cache:booking"}, next.recorded[len(next.recorded)-1].tags, "the cap is per metric")
+}
+
+func TestCardinalityGuard_CapsRoutes(t *testing.T) {
+	next, m := newGuarded(2)
+
+	m.RecordHTTP("GET", "/api/v1/bookings/1", "/api/v1/bookings/:id", 200, 0.01)
+	m.RecordHTTP("GET", "/wp-login.php", "/wp-login.php", 404, 0.01)
+	m.RecordHTTP("GET", "/.env", "/.env", 404, 0.01)
+	m.RecordHTTP("GET", "/api/v1/bookings/2", "/api/v1/bookings/:id", 200, 0.01)
+	m.RecordGRPC("/booking.v1.BookingService/CreateBooking", "OK", 0.01)
+
+	assert.Equal(t, []string{"/api/v1/bookings/:id", "/wp-login.php", "other", "/api/v1/bookings/:id"}, next.routes)
+	assert.Equal(t, []string{"/booking.v1.BookingService/CreateBooking"}, next.methods)
+}
+
+func TestNew_GuardKeepsPrometheusHandler(t *testing.T) {
+	m, err := metrics.New(&config.TelemetryConfig{
+		Enabled:     true,
+		Type:        "prometheus",
+		Cardinality: config.MetricsCardinalityConfig{Enabled: true},
+	}, "test")
+	require.NoError(t, err)
+
+	assert.Implements(t, (*metrics.Handler)(nil), m)
+}
@@ -0,0 +1,58 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors_Instruments(t *testing.T) {
+	m := &recordingMetrics{Metrics: metrics.NewNoOpMetrics()}
+	em := metrics.NewErrors(m)
+
+	em.AppError("BOOKING_NOT_FOUND", "PERSISTANCE", "booking", false)
+	em.HTTPServerError("GET", "/api/v1/bookings/:id", 503, 1500*time.Millisecond)
+	em.GRPCServerError("/booking.v1.BookingService/GetBooking", "Unavailable", 2*time.Second)
+
+	assert.Equal(t, []recordedMetric{
+		{"app_error.total", 1, []string{"code:BOOKING_NOT_FOUND", "kind:PERSISTANCE", "module:booking", "retryable:false"}},
+		{"http.server_error.duration", 1.5, []string{"method:GET", "route:/api/v1/bookings/:id", "status_code:503"}},
+		{"grpc.server_error.duration", 2, []string{"method:/booking.v1.BookingService/GetBooking", "code:Unavailable"}},
+	}, m.recorded)
+}
+
+func TestErrors_BoundsTagValues(t *testing.T) {
+	m := &recordingMetrics{Metrics: metrics.NewNoOpMetrics()}
+	em := metrics.NewErrors(m)
+
+	em.AppError("record not found", "TRANSIENT", "", true)
+	em.AppError("DB_CONFLICT", "PERSISTANCE", "Booking Module", false)
+
+	assert.Equal(t, []string{"code:OTHER", "kind:TRANSIENT", "module:unknown", "retryable:true"}, m.recorded[0].tags)
+	assert.Equal(t, []string{"code:DB_CONFLICT", "kind:PERSISTANCE", "module:OTHER", "retryable:false"}, m.recorded[1].tags)
+}
+
+func TestErrors_Nil(t *testing.T) {
+	var em *metrics.Errors
+
+	assert.NotPanics(t, func() {
+		em.AppError("BOOKING_NOT_FOUND", "PERSISTANCE", "booking", false)
+		em.HTTPServerError("GET", "/", 500, time.Second)
+		em.GRPCServerError("/svc/Method", "Internal", time.Second)
+	})
+}
+
+func TestErrors_PrometheusNames(t *testing.T) {
+	m, scrape := newPrometheus(t)
+	em := metrics.NewErrors(m)
+
+	em.AppError("DB_CONNECTION_FAILED", "TRANSIENT", "core", true)
+	em.HTTPServerError("POST", "/api/v1/bookings", 503, 30*time.Millisecond)
+
+	body := scrape()
+	assert.Contains(t, body, `voyago_core_api_app_error_total{code="DB_CONNECTION_FAILED",env="test",kind="TRANSIENT",module="core",retryable="true"} 1`)
+	assert.Contains(t, body, `voyago_core_api_http_server_error_duration_seconds_count{env="test",method="POST",route="/api/v1/bookings",status_code="503"} 1`)
+}
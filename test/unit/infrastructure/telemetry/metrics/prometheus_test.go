@@ -0,0 +1,79 @@
+package metrics_test
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPrometheus(t *testing.T) (metrics.Metrics, func() string) {
+	t.Helper()
+
+	m, err := metrics.New(&config.TelemetryConfig{
+		Enabled:   true,
+		Type:      "prometheus",
+		Namespace: "voyago.core-api",
+	}, "test")
+	require.NoError(t, err)
+	h, ok := m.(metrics.Handler)
+	require.True(t, ok, "the prometheus driver is scraped over HTTP")
+
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		h.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		body, err := io.ReadAll(rec.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+	return m, scrape
+}
+
+func TestPrometheus_RecordHTTP(t *testing.T) {
+	m, scrape := newPrometheus(t)
+
+	m.RecordHTTP("GET", "/api/v1/bookings/42", "/api/v1/bookings/:id", 200, 0.02)
+	m.RecordHTTP("GET", "/api/v1/bookings/43", "/api/v1/bookings/:id", 200, 0.3)
+
+	body := scrape()
+	assert.Contains(t, body, `voyago_core_api_http_requests_total{env="test",method="GET",route="/api/v1/bookings/:id",status_code="200"} 2`)
+	assert.Contains(t, body, `voyago_core_api_http_request_duration_seconds_bucket{env="test",method="GET",route="/api/v1/bookings/:id",status_code="200",le="0.025"} 1`)
+	assert.Contains(t, body, "go_goroutines", "the runtime metrics are exposed")
+}
+
+func TestPrometheus_Tags(t *testing.T) {
+	m, scrape := newPrometheus(t)
+
+	m.Incr("cache.hit", []string{"cache:booking", "env:ignored"})
+	m.Incr("cache.hit", []string{"cache:booking", "extra:dropped"})
+	m.Incr("cache.hit", nil)
+	m.Timing("http_client_request_duration", 40*time.Millisecond, []string{"host:api.partner.com", "status:200"})
+	m.Gauge("websocket.connections", 3, []string{"unlabelled"})
+	m.Gauge("websocket.connections", 5, []string{"unlabelled"})
+
+	body := scrape()
+	assert.Contains(t, body, `voyago_core_api_cache_hit_total{cache="booking",env="test"} 2`, "the labels of a metric are those of its first recording")
+	assert.Contains(t, body, `voyago_core_api_cache_hit_total{cache="",env="test"} 1`)
+	assert.NotContains(t, body, "dropped")
+	assert.Contains(t, body, `voyago_core_api_http_client_request_duration_seconds_count{env="test",host="api.partner.com",status="200"} 1`)
+	assert.Contains(t, body, `voyago_core_api_websocket_connections{env="test",tag="unlabelled"} 5`)
+}
+
+func TestPrometheus_NameConflict(t *testing.T) {
+	m, scrape := newPrometheus(t)
+
+	m.Gauge("queue.depth", 7, nil)
+	assert.NotPanics(t, func() {
+		m.Distribution("queue.depth", 1, nil)
+	}, "a name taken by another kind of metric is ignored")
+
+	body := scrape()
+	assert.Contains(t, body, `voyago_core_api_queue_depth{env="test"} 7`)
+	require.NoError(t, m.Close())
+}
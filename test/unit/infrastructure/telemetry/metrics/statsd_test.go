@@ -0,0 +1,101 @@
+package metrics_test
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listenStatsD returns a UDP listener and a function reading the lines it
+// received within the deadline.
+func listenStatsD(t *testing.T) (string, func() []string) {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	read := func() []string {
+		var lines []string
+		buf := make([]byte, 65536)
+		for {
+			_ = conn.SetReadDeadline(time.Now().Add(300 * time.Millisecond))
+			n, _, err := conn.ReadFrom(buf)
+			if err != nil {
+				return lines
+			}
+			lines = append(lines, strings.Split(string(buf[:n]), "\n")...)
+		}
+	}
+	return conn.LocalAddr().String(), read
+}
+
+func TestStatsD_Lines(t *testing.T) {
+	addr, read := listenStatsD(t)
+	m, err := metrics.New(&config.TelemetryConfig{
+		Enabled:        true,
+		Type:           "statsd",
+		MetricsAddress: addr,
+		Namespace:      "voyago",
+	}, "test")
+	require.NoError(t, err)
+
+	m.Incr("cache.hit", []string{"cache:booking"})
+	m.Timing("http_client_request_duration", 40*time.Millisecond, nil)
+	m.Gauge("websocket.connections", 3, []string{"bad|tag"})
+	m.RecordHTTP("GET", "/api/v1/bookings/42", "/api/v1/bookings/:id", 404, 0.25)
+	require.NoError(t, m.Close(), "Close flushes the pending packet")
+
+	assert.Equal(t, []string{
+		"voyago.cache.hit:1|c|#env:test,cache:booking",
+		"voyago.http_client_request_duration:40|ms|#env:test",
+		"voyago.websocket.connections:3|g|#env:test,bad_tag",
+		"voyago.http.request.total:1|c|#env:test,method:GET,resource:/api/v1/bookings/:id,status:404,status_group:4xx",
+		"voyago.http.request.duration:0.25|h|#env:test,method:GET,resource:/api/v1/bookings/:id,status:404,status_group:4xx",
+	}, read())
+}
+
+func TestStatsD_PacketsStayUnderMTU(t *testing.T) {
+	addr, read := listenStatsD(t)
+	m, err := metrics.NewStatsDMetrics(addr, "", nil)
+	require.NoError(t, err)
+
+	for range 200 {
+		m.Incr("booking.created", []string{"source:web"})
+	}
+	require.NoError(t, m.Close())
+
+	lines := read()
+	assert.Len(t, lines, 200, "every line is sent, over several packets")
+	assert.Equal(t, uint64(0), m.(metrics.Dropper).Dropped())
+}
+
+func TestStatsD_UnreachableServerDrops(t *testing.T) {
+	// Nothing listens: the connected socket reports the ICMP refusals.
+	probe, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := probe.LocalAddr().String()
+	probe.Close()
+
+	m, err := metrics.NewStatsDMetrics(addr, "voyago", nil)
+	require.NoError(t, err)
+	defer m.Close()
+
+	start := time.Now()
+	for range 5 {
+		m.Incr("booking.created", nil)
+		time.Sleep(150 * time.Millisecond)
+	}
+
+	assert.Less(t, time.Since(start), 2*time.Second, "recording never blocks")
+	assert.Eventually(t, func() bool {
+		return m.(metrics.Dropper).Dropped() > 0
+	}, time.Second, 50*time.Millisecond)
+}
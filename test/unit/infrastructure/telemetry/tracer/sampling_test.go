@@ -0,0 +1,129 @@
+package tracer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newSampledProvider returns a provider sampling with cfg, and the recorder of
+// the spans it exports.
+func newSampledProvider(sampleRate float64, cfg config.TraceSamplingConfig) (trace.Tracer, *tracetest.SpanRecorder) {
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(tracer.NewSampler(sampleRate, cfg)),
+		sdktrace.WithSpanProcessor(tracer.NewKeepProcessor(exportSampled{rec}, cfg)),
+	)
+	return tp.Tracer("test"), rec
+}
+
+// exportSampled forwards the sampled spans only, like the batch processor.
+type exportSampled struct {
+	*tracetest.SpanRecorder
+}
+
+func (p exportSampled) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		p.SpanRecorder.OnEnd(s)
+	}
+}
+
+func endedNames(rec *tracetest.SpanRecorder) []string {
+	var names []string
+	for _, s := range rec.Ended() {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+func TestSampler_Rules(t *testing.T) {
+	trc, rec := newSampledProvider(1, config.TraceSamplingConfig{
+		Rules: []config.TraceSamplingRule{
+			{Name: "HTTP GET /health", Rate: 0},
+			{Name: "HTTP * /api/v1/bookings*", Rate: 1},
+			{Name: "HTTP *", Rate: 0},
+		},
+	})
+
+	for _, name := range []string{
+		"HTTP GET /health",
+		"HTTP POST /api/v1/bookings",
+		"HTTP GET /api/v1/merchants",
+		"gRPC /booking.v1.BookingService/CreateBooking",
+	} {
+		_, span := trc.Start(context.Background(), name)
+		span.End()
+	}
+
+	assert.Equal(t, []string{
+		"HTTP POST /api/v1/bookings",
+		"gRPC /booking.v1.BookingService/CreateBooking",
+	}, endedNames(rec), "the first matching rule applies, then the sample rate")
+}
+
+func TestSampler_ChildrenFollowRoot(t *testing.T) {
+	trc, rec := newSampledProvider(1, config.TraceSamplingConfig{
+		Rules: []config.TraceSamplingRule{{Name: "HTTP GET /health", Rate: 0}},
+	})
+
+	ctx, root := trc.Start(context.Background(), "HTTP GET /api/v1/bookings")
+	_, child := trc.Start(ctx, "HTTP GET /health")
+	child.End()
+	root.End()
+
+	assert.Equal(t, []string{"HTTP GET /health", "HTTP GET /api/v1/bookings"}, endedNames(rec),
+		"the rules only apply to root spans")
+}
+
+func TestKeepProcessor_KeepsErrors(t *testing.T) {
+	trc, rec := newSampledProvider(0, config.TraceSamplingConfig{KeepErrors: true})
+
+	ctx, root := trc.Start(context.Background(), "HTTP POST /api/v1/bookings")
+	_, query := trc.Start(ctx, "gorm:bookings")
+	query.End()
+	_, failed := trc.Start(ctx, "gorm:booking_details")
+	failed.RecordError(errors.New("deadlock detected"))
+	failed.SetStatus(codes.Error, "deadlock detected")
+	failed.End()
+	root.SetAttributes(attribute.Bool("error", true))
+	root.End()
+
+	assert.Equal(t, []string{"gorm:booking_details", "HTTP POST /api/v1/bookings"}, endedNames(rec))
+	for _, s := range rec.Ended() {
+		assert.True(t, s.SpanContext().IsSampled())
+	}
+}
+
+func TestKeepProcessor_KeepsSlowSpans(t *testing.T) {
+	trc, rec := newSampledProvider(0, config.TraceSamplingConfig{SlowThreshold: 50})
+
+	now := time.Now()
+	_, fast := trc.Start(context.Background(), "HTTP GET /fast", trace.WithTimestamp(now))
+	fast.End(trace.WithTimestamp(now.Add(10 * time.Millisecond)))
+	_, slow := trc.Start(context.Background(), "HTTP GET /slow", trace.WithTimestamp(now))
+	slow.End(trace.WithTimestamp(now.Add(80 * time.Millisecond)))
+
+	assert.Equal(t, []string{"HTTP GET /slow"}, endedNames(rec))
+}
+
+func TestSampler_DropsWithoutKeep(t *testing.T) {
+	trc, rec := newSampledProvider(0, config.TraceSamplingConfig{})
+
+	_, span := trc.Start(context.Background(), "HTTP GET /api/v1/bookings")
+	assert.False(t, span.IsRecording(), "unsampled spans are not recorded when nothing is kept")
+	span.SetAttributes(attribute.Bool("error", true))
+	span.End()
+
+	assert.Empty(t, rec.Ended())
+}
@@ -0,0 +1,118 @@
+package tracer_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// headers is a tracer.Carrier over HTTP headers.
+type headers http.Header
+
+func (h headers) Get(key string) string { return http.Header(h).Get(key) }
+func (h headers) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func newOTelTracer(t *testing.T) tracer.Tracer {
+	t.Helper()
+
+	// Nothing listens on the collector address: spans are never exported.
+	trc, err := tracer.NewOTelTracer("voyago-test", "test", "127.0.0.1:1", 1, config.TraceSamplingConfig{})
+	require.NoError(t, err)
+	return trc
+}
+
+func TestOTelTracer_JoinsPropagatedTrace(t *testing.T) {
+	trc := newOTelTracer(t)
+
+	h := http.Header{}
+	h.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	h.Set("tracestate", "vendor=value")
+	h.Set("baggage", "tenant=acme,plan=gold")
+
+	ctx := trc.Extract(context.Background(), headers(h))
+	span, ctx := trc.StartSpan(ctx, "HTTP GET /bookings")
+	defer span.Finish()
+
+	traceID, spanID, ok := trc.ExtractTraceInfo(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID, "the span joins the caller's trace")
+	assert.NotEqual(t, "00f067aa0ba902b7", spanID, "the span is a child of the caller's span")
+
+	bag := baggage.FromContext(ctx)
+	assert.Equal(t, "acme", bag.Member("tenant").Value())
+	assert.Equal(t, "gold", bag.Member("plan").Value())
+}
+
+func TestOTelTracer_StartsRootSpanWithoutTraceContext(t *testing.T) {
+	trc := newOTelTracer(t)
+
+	h := http.Header{}
+	h.Set("traceparent", "not-a-trace-context")
+
+	ctx := trc.Extract(context.Background(), headers(h))
+	span, ctx := trc.StartSpan(ctx, "HTTP GET /bookings")
+	defer span.Finish()
+
+	traceID, _, ok := trc.ExtractTraceInfo(ctx)
+	require.True(t, ok)
+	assert.Len(t, traceID, 32)
+	assert.NotEqual(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+}
+
+func TestNoOpTracer_Extract(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, tracer.NewNoOpTracer().Extract(ctx, headers(http.Header{})))
+}
+
+func TestTraceContext_RoundTrip(t *testing.T) {
+	trc := newOTelTracer(t)
+
+	span, ctx := trc.StartSpan(context.Background(), "HTTP POST /bookings")
+	defer span.Finish()
+	traceID, _, _ := trc.ExtractTraceInfo(ctx)
+
+	tc := tracer.TraceContext{}
+	trc.Inject(ctx, tc)
+	assert.Contains(t, tc.Get("Traceparent"), traceID, "keys are case-insensitive")
+
+	// A worker span linked to the request keeps its own trace.
+	item, itemCtx := trc.StartSpanWithLinks(context.Background(), "worker.item",
+		tracer.Link{Context: tc, Attributes: map[string]any{"item.id": "42"}},
+		tracer.Link{Context: tracer.TraceContext{"traceparent": "malformed"}},
+	)
+	defer item.Finish()
+	itemTraceID, _, ok := trc.ExtractTraceInfo(itemCtx)
+	require.True(t, ok)
+	assert.NotEqual(t, traceID, itemTraceID)
+}
+
+func TestOTelTracer_SetSampling(t *testing.T) {
+	trc := newOTelTracer(t)
+	sampled := func(name string) bool {
+		span, ctx := trc.StartSpan(context.Background(), name)
+		defer span.Finish()
+		return trace.SpanFromContext(ctx).SpanContext().IsSampled()
+	}
+	require.True(t, sampled("HTTP GET /api/v1/bookings"))
+
+	trc.(tracer.Resampler).SetSampling(0, config.TraceSamplingConfig{
+		Rules: []config.TraceSamplingRule{{Name: "HTTP GET /api/*", Rate: 1}},
+	})
+
+	assert.True(t, sampled("HTTP GET /api/v1/bookings"), "the reloaded rules apply")
+	assert.False(t, sampled("HTTP GET /health"), "the reloaded rate applies")
+}
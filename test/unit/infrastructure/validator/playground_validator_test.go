@@ -0,0 +1,42 @@
+package validator_test
+
+import (
+	"context"
+	"testing"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/infrastructure/validator"
+	"voyago/core-api/internal/pkg/i18n"
+	"voyago/core-api/locales"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type createEndpointRequest struct {
+	URL         string `json:"url" validate:"required" label:"URL"`
+	Description string `json:"description" validate:"min=3"`
+}
+
+func TestToDetails_Localized(t *testing.T) {
+	b, err := i18n.Load(locales.FS())
+	require.NoError(t, err)
+	require.NoError(t, i18n.Configure(b, config.I18nConfig{}))
+
+	v := validator.NewPlaygroundValidator()
+	err = v.Validate(createEndpointRequest{Description: "ab"})
+	require.Error(t, err)
+
+	english := v.ToDetails(context.Background(), err)
+	require.Len(t, english, 2)
+	assert.Equal(t, "URL is required", english[0]["message"])
+	assert.Equal(t, "description must be at least 3 characters", english[1]["message"])
+
+	ctx := ctxkey.SetLocales(context.Background(), []string{"id-ID"})
+	indonesian := v.ToDetails(ctx, err)
+	require.Len(t, indonesian, 2)
+	assert.Equal(t, "url", indonesian[0]["field"])
+	assert.Equal(t, "required", indonesian[0]["code"])
+	assert.Equal(t, "URL wajib diisi", indonesian[0]["message"])
+	assert.Equal(t, "description minimal 3 karakter", indonesian[1]["message"])
+}
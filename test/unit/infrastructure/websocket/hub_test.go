@@ -0,0 +1,211 @@
+package wsserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/metrics"
+	wsserver "voyago/core-api/internal/infrastructure/websocket"
+	"voyago/core-api/internal/pkg/apperror"
+
+	fasthttpws "github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "test-secret"
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+// serve mounts hub on a real socket and returns the ws:// URL of its endpoint.
+func serve(t *testing.T, hub *wsserver.Hub) string {
+	t.Helper()
+
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			if e, ok := err.(*apperror.AppError); ok {
+				return c.Status(e.GetHttpStatus()).SendString(e.Code)
+			}
+			return fiber.DefaultErrorHandler(c, err)
+		},
+	})
+	hub.Mount(app, wsserver.NewTokenAuthenticator(secret))
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(ln) }()
+	t.Cleanup(func() { _ = app.ShutdownWithTimeout(time.Second) })
+
+	return "ws://" + ln.Addr().String() + hub.Path()
+}
+
+func newHub(cfg config.WebsocketConfig) *wsserver.Hub {
+	return wsserver.NewHub(cfg, logger.NewNoOpLogger(), metrics.NewNoOpMetrics())
+}
+
+// dial opens a socket as userID and waits until the hub registered it.
+func dial(t *testing.T, hub *wsserver.Hub, url, userID string) *fasthttpws.Conn {
+	t.Helper()
+
+	before := hub.Connections()
+	token := wsserver.NewTokenAuthenticator(secret).Issue(userID, time.Minute)
+	conn, resp, err := fasthttpws.DefaultDialer.Dial(url+"?token="+token, nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	t.Cleanup(func() { _ = conn.Close() })
+
+	require.Eventually(t, func() bool { return hub.Connections() > before }, time.Second, 10*time.Millisecond)
+	return conn
+}
+
+func readMessage(t *testing.T, conn *fasthttpws.Conn) map[string]any {
+	t.Helper()
+
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, data, err := conn.ReadMessage()
+	require.NoError(t, err)
+
+	var msg map[string]any
+	require.NoError(t, json.Unmarshal(data, &msg))
+	return msg
+}
+
+// ============================================================================
+// TOKEN AUTHENTICATOR
+// ============================================================================
+
+func TestTokenAuthenticator_IssueAndVerify(t *testing.T) {
+	auth := wsserver.NewTokenAuthenticator(secret)
+
+	userID, err := auth.Verify(auth.Issue("user-1", time.Minute))
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+}
+
+func TestTokenAuthenticator_RejectsInvalidTokens(t *testing.T) {
+	auth := wsserver.NewTokenAuthenticator(secret)
+	valid := auth.Issue("user-1", time.Minute)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "expired", token: auth.Issue("user-1", -time.Second)},
+		{name: "tampered signature", token: valid[:len(valid)-1] + "0"},
+		{name: "other secret", token: wsserver.NewTokenAuthenticator("other").Issue("user-1", time.Minute)},
+		{name: "malformed", token: "not-a-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.Verify(tt.token)
+			assert.ErrorIs(t, err, wsserver.ErrInvalidToken)
+		})
+	}
+}
+
+// ============================================================================
+// HUB
+// ============================================================================
+
+func TestHub_RejectsUnauthenticatedUpgrade(t *testing.T) {
+	hub := newHub(config.WebsocketConfig{})
+	url := serve(t, hub)
+
+	_, resp, err := fasthttpws.DefaultDialer.Dial(url, nil)
+
+	require.ErrorIs(t, err, fasthttpws.ErrBadHandshake)
+	require.NotNil(t, resp)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Zero(t, hub.Connections())
+}
+
+func TestHub_SendToUser_DeliversOnlyToThatUser(t *testing.T) {
+	hub := newHub(config.WebsocketConfig{})
+	url := serve(t, hub)
+
+	first := dial(t, hub, url, "user-1")
+	second := dial(t, hub, url, "user-1")
+	other := dial(t, hub, url, "user-2")
+
+	sent := hub.SendToUser("user-1", wsserver.Message{Type: "test.ping", ID: "evt-1"})
+
+	assert.Equal(t, 2, sent)
+	for _, conn := range []*fasthttpws.Conn{first, second} {
+		msg := readMessage(t, conn)
+		assert.Equal(t, "test.ping", msg["type"])
+		assert.Equal(t, "evt-1", msg["id"])
+	}
+
+	_ = other.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	_, _, err := other.ReadMessage()
+	assert.Error(t, err, "user-2 must not receive user-1 messages")
+}
+
+func TestHub_ForwardEvents_RoutesByPayloadUserID(t *testing.T) {
+	hub := newHub(config.WebsocketConfig{})
+	url := serve(t, hub)
+	conn := dial(t, hub, url, "user-1")
+
+	bus := eventbus.NewInMemoryBus(logger.NewNoOpLogger())
+	t.Cleanup(func() { _ = bus.Close() })
+	hub.ForwardEvents(bus)
+
+	payload := struct {
+		UserID string `json:"user_id"`
+		Status string `json:"status"`
+	}{UserID: "user-1", Status: "CONFIRMED"}
+	require.NoError(t, bus.Publish(context.Background(), eventbus.NewEvent("booking.status_changed", "booking", payload)))
+
+	msg := readMessage(t, conn)
+	assert.Equal(t, "booking.status_changed", msg["type"])
+	assert.NotEmpty(t, msg["id"])
+	assert.Equal(t, map[string]any{"user_id": "user-1", "status": "CONFIRMED"}, msg["data"])
+}
+
+func TestHub_Drain_ClosesSocketsAndRefusesNewOnes(t *testing.T) {
+	hub := newHub(config.WebsocketConfig{DrainTimeout: 2})
+	url := serve(t, hub)
+	conn := dial(t, hub, url, "user-1")
+
+	// The client acknowledges the close frame, as browsers do.
+	closed := make(chan int, 1)
+	go func() {
+		_, _, err := conn.ReadMessage()
+		var ce *fasthttpws.CloseError
+		if assert.ErrorAs(t, err, &ce) {
+			closed <- ce.Code
+		}
+		_ = conn.Close()
+	}()
+
+	require.NoError(t, hub.Drain(context.Background()))
+
+	assert.Equal(t, fasthttpws.CloseGoingAway, <-closed)
+	assert.Zero(t, hub.Connections())
+
+	token := wsserver.NewTokenAuthenticator(secret).Issue("user-1", time.Minute)
+	_, resp, err := fasthttpws.DefaultDialer.Dial(url+"?token="+token, nil)
+	require.ErrorIs(t, err, fasthttpws.ErrBadHandshake)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestHub_Drain_WithoutSockets(t *testing.T) {
+	hub := newHub(config.WebsocketConfig{})
+
+	assert.NoError(t, hub.Drain(context.Background()))
+}
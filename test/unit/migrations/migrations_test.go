@@ -0,0 +1,44 @@
+package migrations_test
+
+import (
+	"io/fs"
+	"testing"
+
+	"voyago/core-api/internal/app"
+	"voyago/core-api/migrations"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFS_EmbedsAMigrationPairPerVersion(t *testing.T) {
+	for _, domain := range app.Domains() {
+		files, err := fs.Glob(migrations.FS(domain), "*.sql")
+		require.NoError(t, err)
+		require.NotEmpty(t, files, domain)
+
+		ups := map[uint]bool{}
+		downs := map[uint]bool{}
+		for _, f := range files {
+			m, err := source.DefaultParse(f)
+			require.NoError(t, err, "%s/%s is not named <version>_<name>.<up|down>.sql", domain, f)
+			if m.Direction == source.Up {
+				ups[m.Version] = true
+			} else {
+				downs[m.Version] = true
+			}
+		}
+		assert.Equal(t, ups, downs, "%s: every migration can be rolled back", domain)
+	}
+}
+
+func TestFS_UnknownDomainIsEmpty(t *testing.T) {
+	files, err := fs.Glob(migrations.FS("merchant"), "*.sql")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	files, err = fs.Glob(migrations.FS("../booking"), "*.sql")
+	require.NoError(t, err)
+	assert.Empty(t, files, "no path escapes the migrations")
+}
@@ -0,0 +1,119 @@
+package modules_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/modules"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+func TestRegister_ListsTheModulesByName(t *testing.T) {
+	modules.Register(modules.Module{Name: "registryzulu"})
+	modules.Register(modules.Module{Name: "registryalpha", Config: "config/alpha.yaml"})
+
+	names := modules.Names()
+
+	assert.Less(t, slices.Index(names, "registryalpha"), slices.Index(names, "registryzulu"))
+	m, ok := modules.Get("registryalpha")
+	require.True(t, ok)
+	assert.Equal(t, "config/alpha.yaml", m.ConfigPath())
+	m, ok = modules.Get("registryzulu")
+	require.True(t, ok)
+	assert.Equal(t, "config/registryzulu/config.yaml", m.ConfigPath())
+}
+
+func TestRegister_RejectsDuplicateModules(t *testing.T) {
+	modules.Register(modules.Module{Name: "registrytwice"})
+
+	assert.PanicsWithError(t, `modules: module "registrytwice" registered twice`, func() {
+		modules.Register(modules.Module{Name: "registrytwice"})
+	})
+}
+
+func TestRegister_RejectsInvalidNames(t *testing.T) {
+	for _, name := range []string{"", "Loyalty", "../booking", "loyalty program"} {
+		t.Run(name, func(t *testing.T) {
+			assert.Panics(t, func() {
+				modules.Register(modules.Module{Name: name})
+			})
+		})
+	}
+}
+
+func TestGet_UnknownModule(t *testing.T) {
+	_, ok := modules.Get("registryunknown")
+
+	assert.False(t, ok)
+}
+
+type loyaltySettings struct {
+	PointsPerEuro int `mapstructure:"points_per_euro"`
+}
+
+func (s *loyaltySettings) Validate() []config.Problem {
+	if s.PointsPerEuro <= 0 {
+		return []config.Problem{{Path: "points_per_euro", Message: "must be positive"}}
+	}
+	return nil
+}
+
+// loyaltyConfig loads a domain configuration declaring the loyalty section.
+func loyaltyConfig(t *testing.T, section string) *config.Config {
+	t.Helper()
+	dir := t.TempDir()
+	global := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(global, []byte("app: { name: core-api }\nhttp: { port: 4000 }\n"), 0o600))
+	_, err := config.LoadGlobalConfig(global)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "loyalty.yaml")
+	domain := "database: { host: localhost, port: 5432, name: loyalty }\n" + section
+	require.NoError(t, os.WriteFile(path, []byte(domain), 0o600))
+	cfg, err := config.ReadDomainConfig(path)
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestDecodeSettings_DecodesTheSectionOfTheModule(t *testing.T) {
+	m := modules.Module{Name: "loyalty", Settings: modules.SettingsOf[loyaltySettings]()}
+
+	settings, err := m.DecodeSettings(loyaltyConfig(t, "loyalty: { points_per_euro: 10 }\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, &loyaltySettings{PointsPerEuro: 10}, settings)
+}
+
+func TestDecodeSettings_ValidatesTheSettings(t *testing.T) {
+	m := modules.Module{Name: "loyalty", Settings: modules.SettingsOf[loyaltySettings]()}
+
+	_, err := m.DecodeSettings(loyaltyConfig(t, "loyalty: { points_per_euro: 0 }\n"))
+
+	var verr *config.ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Problems, 1)
+	assert.Equal(t, "loyalty.points_per_euro", verr.Problems[0].Path)
+}
+
+func TestDecodeSettings_ModuleWithoutSettings(t *testing.T) {
+	settings, err := modules.Module{Name: "loyalty"}.DecodeSettings(&config.Config{})
+
+	require.NoError(t, err)
+	assert.Nil(t, settings)
+}
+
+func TestEnvOptions_SupplyTheSettings(t *testing.T) {
+	env := modules.Env{Config: &config.Config{}, Settings: &loyaltySettings{PointsPerEuro: 10}}
+
+	var got *loyaltySettings
+	app := fx.New(fx.NopLogger, env.Options(), fx.Populate(&got))
+
+	require.NoError(t, app.Err())
+	assert.Equal(t, 10, got.PointsPerEuro)
+}
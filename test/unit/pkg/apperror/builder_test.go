@@ -0,0 +1,79 @@
+package apperror_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilders_DoNotModifyTheReceiver(t *testing.T) {
+	sentinel := apperror.NewPersistance("BOOKING_NOT_FOUND", "Booking not found")
+	cause := errors.New("record not found")
+
+	withErr := sentinel.WithError(cause)
+	withDetail := sentinel.WithDetail("booking_id", "b-1")
+	withValidation := sentinel.AddValidationError("email", "invalid")
+	withValidations := sentinel.AddValidationErrors([]map[string]any{{"field": "age"}})
+
+	assert.NoError(t, sentinel.Err)
+	assert.Nil(t, sentinel.Details)
+	for _, c := range []*apperror.AppError{withErr, withDetail, withValidation, withValidations} {
+		assert.NotSame(t, sentinel, c)
+		assert.Equal(t, sentinel.Code, c.Code)
+		assert.Equal(t, sentinel.Kind, c.Kind)
+	}
+	assert.Equal(t, cause, withErr.Err)
+	assert.Equal(t, map[string]any{"booking_id": "b-1"}, withDetail.Details)
+}
+
+func TestBuilders_Chained(t *testing.T) {
+	base := apperror.NewPersistance("BOOKING_STATUS_INVALID", "Invalid status transition").WithDetail("from", "PENDING")
+
+	to := base.WithDetail("to", "CANCELLED")
+	other := base.WithDetail("to", "PAID")
+
+	assert.Equal(t, map[string]any{"from": "PENDING"}, base.Details)
+	assert.Equal(t, map[string]any{"from": "PENDING", "to": "CANCELLED"}, to.Details)
+	assert.Equal(t, map[string]any{"from": "PENDING", "to": "PAID"}, other.Details)
+
+	email := apperror.ErrCodeValidation.AddValidationError("email", "invalid")
+	age := email.AddValidationError("age", "too young")
+	assert.Len(t, email.Details, 1)
+	assert.Len(t, age.Details, 2)
+}
+
+func TestIs_MatchesTheCopiesOfAnError(t *testing.T) {
+	err := fmt.Errorf("handling: %w", apperror.ErrCodeNotFound.WithDetail("id", "b-1"))
+
+	assert.ErrorIs(t, err, apperror.ErrCodeNotFound)
+	assert.NotErrorIs(t, err, apperror.ErrCodeConflict)
+}
+
+func TestBuilders_ConcurrentRequests(t *testing.T) {
+	const requests = 50
+
+	var wg sync.WaitGroup
+	results := make([]*apperror.AppError, requests)
+	for i := range requests {
+		wg.Go(func() {
+			cause := fmt.Errorf("request %d", i)
+			results[i] = apperror.ErrCodeInvalidRequest.
+				WithError(cause).
+				WithDetail("request", i).
+				AddValidationErrors([]map[string]any{{"request": i}})
+		})
+	}
+	wg.Wait()
+
+	for i, err := range results {
+		require.EqualError(t, err.Err, fmt.Sprintf("request %d", i), "each request keeps its own cause")
+		assert.Equal(t, []map[string]any{{"request": i}}, err.Details)
+	}
+	assert.NoError(t, apperror.ErrCodeInvalidRequest.Err)
+	assert.Nil(t, apperror.ErrCodeInvalidRequest.Details)
+}
@@ -0,0 +1,70 @@
+package apperror_test
+
+import (
+	"testing"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	errNotFound := apperror.NewPersistance("CATALOG_TEST_NOT_FOUND", "Seat not found")
+	errFull := apperror.NewTransient("CATALOG_TEST_FULL", "Seat map full")
+
+	apperror.Register("catalogtest",
+		apperror.Def{Err: errNotFound, Status: 404, DocURL: "https://docs.voyago.dev/errors/seat-not-found"},
+		apperror.Def{Err: errFull},
+	)
+
+	entry, ok := apperror.Lookup("CATALOG_TEST_NOT_FOUND")
+	require.True(t, ok)
+	assert.Equal(t, apperror.CatalogEntry{
+		Code:       "CATALOG_TEST_NOT_FOUND",
+		Module:     "catalogtest",
+		HttpStatus: 404,
+		GRPCCode:   "NotFound",
+		Kind:       apperror.KindPersistance,
+		Message:    "Seat not found",
+		DocURL:     "https://docs.voyago.dev/errors/seat-not-found",
+	}, entry)
+	assert.Equal(t, 404, errNotFound.GetHttpStatus(), "the status is registered")
+
+	entry, _ = apperror.Lookup("CATALOG_TEST_FULL")
+	assert.Equal(t, 503, entry.HttpStatus, "the status of its kind")
+	assert.True(t, entry.Retryable)
+
+	_, ok = apperror.Lookup("CATALOG_TEST_UNKNOWN")
+	assert.False(t, ok)
+}
+
+func TestRegister_CodeOfAnotherModule(t *testing.T) {
+	err := apperror.NewPersistance("CATALOG_TEST_OWNED", "Owned")
+	apperror.Register("catalogtest", apperror.Def{Err: err})
+
+	assert.NotPanics(t, func() { apperror.Register("catalogtest", apperror.Def{Err: err}) })
+	assert.PanicsWithValue(t,
+		`apperror: error code CATALOG_TEST_OWNED of module "other" already registered by module "catalogtest"`,
+		func() { apperror.Register("other", apperror.Def{Err: err}) })
+	assert.Panics(t, func() { apperror.Register("catalogtest", apperror.Def{}) })
+}
+
+func TestCatalog_CoreErrors(t *testing.T) {
+	entries := apperror.Catalog()
+
+	var core []string
+	for i, e := range entries {
+		if i > 0 {
+			prev := entries[i-1]
+			assert.True(t, prev.Module < e.Module || (prev.Module == e.Module && prev.Code < e.Code), "sorted by module then code")
+		}
+		if e.Module == apperror.CoreModule {
+			core = append(core, e.Code)
+		}
+	}
+	assert.Contains(t, core, apperror.CodeDbDeadlock)
+	assert.Contains(t, core, apperror.CodeNetworkAuthenticationRequired)
+
+	entry, _ := apperror.Lookup(apperror.CodeNotFound)
+	assert.Equal(t, 404, entry.HttpStatus)
+}
@@ -0,0 +1,96 @@
+package apperror_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrap(t *testing.T) {
+	assert.NoError(t, apperror.Wrap(nil, "BOOKING_NOT_SAVED", "Booking could not be saved"))
+
+	err := apperror.Wrap(apperror.NewTransient(apperror.CodeDbTimeout, "Database timeout", context.DeadlineExceeded),
+		"BOOKING_NOT_SAVED", "Booking could not be saved")
+
+	var appErr *apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "BOOKING_NOT_SAVED", appErr.Code)
+	assert.Equal(t, apperror.KindTransient, appErr.Kind, "the kind of the wrapped AppError")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	plain := apperror.Wrap(errors.New("nil map"), "BOOKING_NOT_SAVED", "Booking could not be saved")
+	require.ErrorAs(t, plain, &appErr)
+	assert.Equal(t, apperror.KindInternal, appErr.Kind)
+}
+
+func TestWrap_JoinedErrors(t *testing.T) {
+	first := apperror.NewPersistance("SEAT_TAKEN", "Seat taken")
+	err := apperror.Wrap(errors.Join(first, context.Canceled), "BOOKING_NOT_SAVED", "Booking could not be saved")
+
+	assert.ErrorIs(t, err, first)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, first, apperror.RootCause(err), "the chain follows the first joined error")
+}
+
+func TestRootCause(t *testing.T) {
+	root := errors.New("connection refused")
+	err := fmt.Errorf("saving: %w", apperror.NewTransient(apperror.CodeDbConnectionFailed, "Database connection failed", root))
+
+	assert.Equal(t, root, apperror.RootCause(err))
+	assert.Equal(t, root, apperror.RootCause(root))
+	assert.NoError(t, apperror.RootCause(nil))
+}
+
+func TestChain(t *testing.T) {
+	err := apperror.Wrap(
+		apperror.NewTransient(apperror.CodeDbTimeout, "Database timeout", context.DeadlineExceeded),
+		"BOOKING_NOT_SAVED", "Booking could not be saved")
+
+	assert.Equal(t, []string{
+		"BOOKING_NOT_SAVED: Booking could not be saved",
+		"DB_TIMEOUT: Database timeout",
+		"context deadline exceeded",
+	}, apperror.Chain(err))
+}
+
+func TestLogFields(t *testing.T) {
+	assert.Nil(t, apperror.LogFields(nil))
+	assert.Equal(t, map[string]any{"error": "boom"}, apperror.LogFields(errors.New("boom")))
+
+	fields := apperror.LogFields(apperror.NewTransient(apperror.CodeDbTimeout, "Database timeout", context.DeadlineExceeded))
+
+	assert.Equal(t, "Database timeout", fields["error"])
+	assert.Equal(t, apperror.CodeDbTimeout, fields["error_code"])
+	assert.Equal(t, "TRANSIENT", fields["error_kind"])
+	assert.Equal(t, []string{"DB_TIMEOUT: Database timeout", "context deadline exceeded"}, fields["error_chain"])
+	assert.NotContains(t, fields, "error_stack", "not captured by default")
+}
+
+func TestCaptureStacks(t *testing.T) {
+	apperror.CaptureStacks(true)
+	defer apperror.CaptureStacks(false)
+
+	inner := apperror.NewTransient(apperror.CodeDbTimeout, "Database timeout")
+	stack := inner.StackTrace()
+
+	require.NotEmpty(t, stack)
+	firstFrame, _, _ := strings.Cut(stack, "\n")
+	assert.Equal(t, "voyago/core-api/test/unit/pkg/apperror_test.TestCaptureStacks", firstFrame, "starts at the caller")
+
+	outer := apperror.Wrap(fmt.Errorf("saving: %w", inner), "BOOKING_NOT_SAVED", "Booking could not be saved")
+	assert.Equal(t, stack, apperror.StackTrace(outer), "the stack of the origin")
+	assert.Contains(t, apperror.LogFields(outer), "error_stack")
+
+	copied := apperror.ErrCodeNotFound.WithDetail("id", "b-1").StackTrace()
+	firstFrame, _, _ = strings.Cut(copied, "\n")
+	assert.Equal(t, "voyago/core-api/test/unit/pkg/apperror_test.TestCaptureStacks", firstFrame, "the copy of a package-level error")
+
+	apperror.CaptureStacks(false)
+	assert.Empty(t, apperror.NewInternal(apperror.CodeInternalError, "Internal error").StackTrace())
+}
@@ -0,0 +1,104 @@
+package apperror_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"voyago/core-api/internal/pkg/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCStatus_RoundTrip(t *testing.T) {
+	sent := apperror.ErrCodeValidation.AddValidationErrors([]map[string]any{
+		{"field": "code", "message": "Booking code is required"},
+	})
+
+	st := sent.ToGRPCStatus()
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	got := apperror.FromGRPCStatus(st)
+	require.NotNil(t, got)
+	assert.Equal(t, apperror.CodeValidation, got.Code)
+	assert.Equal(t, "Validation error", got.Message)
+	assert.Equal(t, apperror.KindPersistance, got.Kind)
+	assert.Equal(t, []map[string]any{{"field": "code", "message": "Booking code is required"}}, got.Details)
+	assert.Equal(t, http.StatusBadRequest, got.GetHttpStatus())
+	assert.ErrorIs(t, got, apperror.ErrCodeValidation)
+
+	fromErr, ok := status.FromError(got)
+	require.True(t, ok, "the status stays in the chain")
+	assert.Equal(t, codes.InvalidArgument, fromErr.Code())
+}
+
+func TestGRPCStatus_UnregisteredCodeKeepsItsStatus(t *testing.T) {
+	// As sent by a service registering SEAT_NOT_FOUND as a 404.
+	st, err := status.New(codes.NotFound, "Seat not found").WithDetails(&errdetails.ErrorInfo{
+		Reason:   "SEAT_NOT_FOUND",
+		Domain:   apperror.ErrorDomain,
+		Metadata: map[string]string{"is_retryable": "false"},
+	})
+	require.NoError(t, err)
+
+	got := apperror.FromGRPCStatus(st)
+
+	assert.Equal(t, "SEAT_NOT_FOUND", got.Code)
+	assert.Equal(t, http.StatusNotFound, got.GetHttpStatus())
+	assert.Equal(t, codes.NotFound, got.ToGRPCStatus().Code(), "forwarded unchanged")
+}
+
+func TestGRPCStatus_Retryable(t *testing.T) {
+	st := apperror.NewTransient("INVENTORY_DOWN", "Inventory unavailable").ToGRPCStatus()
+	require.Equal(t, codes.Unavailable, st.Code())
+
+	got := apperror.FromGRPCStatus(st)
+	assert.True(t, got.IsRetryable())
+	assert.Equal(t, http.StatusServiceUnavailable, got.GetHttpStatus())
+}
+
+func TestFromGRPCStatus_PlainStatus(t *testing.T) {
+	tests := []struct {
+		code       codes.Code
+		wantCode   string
+		wantKind   apperror.Kind
+		wantStatus int
+	}{
+		{codes.NotFound, apperror.CodeNotFound, apperror.KindPersistance, http.StatusNotFound},
+		{codes.PermissionDenied, apperror.CodeForbidden, apperror.KindPersistance, http.StatusForbidden},
+		{codes.Unavailable, apperror.CodeServiceUnavailable, apperror.KindTransient, http.StatusServiceUnavailable},
+		{codes.DeadlineExceeded, apperror.CodeInternalError, apperror.KindTransient, http.StatusGatewayTimeout},
+		{codes.Unknown, apperror.CodeInternalError, apperror.KindInternal, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			got := apperror.FromGRPCStatus(status.New(tt.code, "upstream failure"))
+
+			assert.Equal(t, tt.wantCode, got.Code)
+			assert.Equal(t, tt.wantKind, got.Kind)
+			assert.Equal(t, "upstream failure", got.Message)
+			assert.Equal(t, tt.wantStatus, got.GetHttpStatus())
+		})
+	}
+
+	assert.Nil(t, apperror.FromGRPCStatus(nil))
+	assert.Nil(t, apperror.FromGRPCStatus(status.New(codes.OK, "")))
+
+	plain := apperror.FromGRPCStatus(status.Convert(errors.New("boom")))
+	assert.Equal(t, apperror.KindInternal, plain.Kind)
+	assert.Equal(t, "boom", plain.Message)
+}
+
+func TestGRPCCodeFromHttpStatus_ReversesHttpStatusFromGRPCCode(t *testing.T) {
+	for _, code := range []codes.Code{
+		codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied, codes.NotFound,
+		codes.AlreadyExists, codes.FailedPrecondition, codes.ResourceExhausted, codes.Canceled,
+		codes.DeadlineExceeded, codes.Unimplemented, codes.Unavailable, codes.Internal,
+	} {
+		assert.Equal(t, code, apperror.GRPCCodeFromHttpStatus(apperror.HttpStatusFromGRPCCode(code)), code.String())
+	}
+}
@@ -0,0 +1,164 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	database "voyago/core-api/internal/infrastructure/db"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/pkg/audit"
+	"voyago/core-api/internal/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type endpoint struct {
+	URL      string `json:"url"`
+	Token    string `json:"token"`
+	IsActive bool   `json:"is_active"`
+}
+
+func newService(t *testing.T) *audit.Service {
+	t.Helper()
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), nil)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.GetDB().AutoMigrate(&audit.Entry{}))
+	return audit.NewService(db)
+}
+
+func TestNewEntry_CallerAndDiff(t *testing.T) {
+	ctx := ctxkey.SetRequestID(ctxkey.SetUserID(context.Background(), "user-1"), "req-1")
+	ctx = ctxkey.SetRoles(ctx, []string{"ops", "admin"})
+	ctx = ctxkey.SetTenantID(ctx, "tenant-1")
+	ctx = ctxkey.SetClientApp(ctx, "backoffice")
+
+	entry, err := audit.NewEntry(ctx, audit.Change{
+		Action:     "webhook.endpoint.update",
+		EntityType: "webhook_endpoint",
+		EntityID:   "ep-1",
+		Before:     endpoint{URL: "https://a.example", Token: "old", IsActive: true},
+		After:      endpoint{URL: "https://b.example", Token: "new", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, entry.ID)
+	assert.Equal(t, "user-1", entry.Actor)
+	require.NotNil(t, entry.RequestID)
+	assert.Equal(t, "req-1", *entry.RequestID)
+	require.NotNil(t, entry.TenantID)
+	assert.Equal(t, "tenant-1", *entry.TenantID)
+	require.NotNil(t, entry.ClientApp)
+	assert.Equal(t, "backoffice", *entry.ClientApp)
+	assert.Equal(t, []string{"ops", "admin"}, entry.Roles)
+	assert.Positive(t, entry.CreatedAt)
+
+	assert.Equal(t, utils.Redacted, entry.Before["token"])
+	assert.Equal(t, utils.Redacted, entry.After["token"])
+	assert.Equal(t, map[string]audit.FieldChange{
+		"url": {From: "https://a.example", To: "https://b.example"},
+		// The changed secret is listed without being disclosed.
+		"token": {From: utils.Redacted, To: utils.Redacted},
+	}, entry.Diff)
+}
+
+func TestNewEntry_CreationAndDeletion(t *testing.T) {
+	created, err := audit.NewEntry(context.Background(), audit.Change{
+		Action: "booking.create",
+		After:  endpoint{URL: "https://a.example"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, audit.SystemActor, created.Actor)
+	assert.Nil(t, created.Before)
+	assert.Nil(t, created.RequestID)
+	assert.Equal(t, audit.FieldChange{From: nil, To: "https://a.example"}, created.Diff["url"])
+
+	deleted, err := audit.NewEntry(context.Background(), audit.Change{
+		Action: "webhook.endpoint.delete",
+		Before: endpoint{URL: "https://a.example"},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, deleted.After)
+	assert.Equal(t, audit.FieldChange{From: "https://a.example", To: nil}, deleted.Diff["url"])
+}
+
+func TestNewEntry_ClientAppActor(t *testing.T) {
+	ctx := ctxkey.SetClientApp(context.Background(), "payment-gateway")
+
+	entry, err := audit.NewEntry(ctx, audit.Change{Action: "booking.payment_status.update"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "payment-gateway", entry.Actor, "a service acting on its own behalf is the actor")
+}
+
+func TestNewEntry_RejectsNonObjectSnapshot(t *testing.T) {
+	_, err := audit.NewEntry(context.Background(), audit.Change{Action: "booking.create", After: "booking"})
+	assert.Error(t, err)
+}
+
+func TestService_RecordAndFind(t *testing.T) {
+	s := newService(t)
+	ctx := ctxkey.SetTenantID(ctxkey.SetUserID(context.Background(), "user-1"), "tenant-1")
+
+	require.NoError(t, s.Record(ctx, audit.Change{
+		Action: "webhook.endpoint.create", EntityType: "webhook_endpoint", EntityID: "ep-1",
+		After: endpoint{URL: "https://a.example", IsActive: true},
+	}))
+	require.NoError(t, s.Record(ctx, audit.Change{
+		Action: "webhook.endpoint.update", EntityType: "webhook_endpoint", EntityID: "ep-1",
+		Before: endpoint{URL: "https://a.example", IsActive: true},
+		After:  endpoint{URL: "https://a.example", IsActive: false},
+	}))
+	require.NoError(t, s.Record(context.Background(), audit.Change{
+		Action: "webhook.endpoint.create", EntityType: "webhook_endpoint", EntityID: "ep-2",
+		After: endpoint{URL: "https://b.example", IsActive: true},
+	}))
+
+	entries, err := s.Find(context.Background(), audit.Filter{EntityID: "ep-1"})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	for _, e := range entries {
+		assert.Equal(t, "user-1", e.Actor)
+	}
+	var update audit.Entry
+	for _, e := range entries {
+		if e.Action == "webhook.endpoint.update" {
+			update = e
+		}
+	}
+	assert.Equal(t, map[string]audit.FieldChange{"is_active": {From: true, To: false}}, update.Diff)
+	assert.Equal(t, "https://a.example", update.Before["url"])
+
+	entries, err = s.Find(context.Background(), audit.Filter{Actor: audit.SystemActor})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "ep-2", entries[0].EntityID)
+
+	entries, err = s.Find(context.Background(), audit.Filter{TenantID: "tenant-1"})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	entries, err = s.Find(context.Background(), audit.Filter{Action: "webhook.endpoint.create", Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestService_RecordWithinTransaction(t *testing.T) {
+	db := database.NewSQLiteDatabase(t.Name(), logger.NewNoOpLogger(), nil)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, db.GetDB().AutoMigrate(&audit.Entry{}))
+	s := audit.NewService(db)
+
+	rollback := errors.New("rollback")
+	err := db.Atomic(context.Background(), func(txCtx context.Context) error {
+		require.NoError(t, s.Record(txCtx, audit.Change{Action: "booking.create", EntityType: "booking", EntityID: "b-1"}))
+		return rollback
+	})
+	require.ErrorIs(t, err, rollback)
+
+	entries, err := s.Find(context.Background(), audit.Filter{})
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the entry is rolled back with the change")
+}
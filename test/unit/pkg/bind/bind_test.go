@@ -0,0 +1,177 @@
+package bind_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/pkg/bind"
+	"voyago/core-api/internal/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+type detailRequest struct {
+	ProductID string  `json:"product_id"`
+	Qty       int     `json:"qty"`
+	Price     float64 `json:"price_per_unit"`
+}
+
+type audit struct {
+	Source string `json:"source"`
+}
+
+type createRequest struct {
+	audit
+	ID        string            `json:"-"`
+	Code      string            `json:"code"`
+	Paid      bool              `json:"paid"`
+	Amount    *float64          `json:"amount"`
+	DueAt     time.Time         `json:"due_at"`
+	Details   []detailRequest   `json:"details"`
+	Labels    map[string]string `json:"labels"`
+	Extra     any               `json:"extra"`
+	Untouched string            `json:"untouched"`
+}
+
+// parse posts body with contentType and binds it into a createRequest.
+func parse(t *testing.T, contentType string, body []byte) (createRequest, error) {
+	t.Helper()
+
+	var out createRequest
+	var bindErr error
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		bindErr = bind.Body(c, &out)
+		return nil
+	})
+
+	req := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, contentType)
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	return out, bindErr
+}
+
+func assertBound(t *testing.T, out createRequest) {
+	t.Helper()
+
+	assert.Equal(t, "BKG-001", out.Code)
+	assert.True(t, out.Paid)
+	require.NotNil(t, out.Amount)
+	assert.Equal(t, 150.5, *out.Amount)
+	assert.Equal(t, "web", out.Source)
+	assert.Equal(t, []detailRequest{
+		{ProductID: "p-1", Qty: 2, Price: 50},
+		{ProductID: "p-2", Qty: 1, Price: 50.5},
+	}, out.Details)
+}
+
+// ============================================================================
+// FORMATS
+// ============================================================================
+
+func TestBody_JSON(t *testing.T) {
+	out, err := parse(t, fiber.MIMEApplicationJSON, []byte(`{
+		"code": "BKG-001", "paid": true, "amount": 150.5, "source": "web",
+		"details": [
+			{"product_id": "p-1", "qty": 2, "price_per_unit": 50},
+			{"product_id": "p-2", "qty": 1, "price_per_unit": 50.5}
+		]
+	}`))
+
+	require.NoError(t, err)
+	assertBound(t, out)
+}
+
+func TestBody_XML(t *testing.T) {
+	out, err := parse(t, "application/xml; charset=utf-8", []byte(`<?xml version="1.0"?>
+		<request>
+			<code>BKG-001</code>
+			<paid>true</paid>
+			<amount>150.5</amount>
+			<source>web</source>
+			<due_at>2026-01-02T15:04:05Z</due_at>
+			<details>
+				<item><product_id>p-1</product_id><qty>2</qty><price_per_unit>50</price_per_unit></item>
+				<item><product_id>p-2</product_id><qty>1</qty><price_per_unit>50.5</price_per_unit></item>
+			</details>
+			<labels><channel>mobile</channel><entry key="utm source">ads</entry></labels>
+			<extra><item>x</item><item>y</item></extra>
+			<unknown>ignored</unknown>
+		</request>`))
+
+	require.NoError(t, err)
+	assertBound(t, out)
+	assert.Equal(t, time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC), out.DueAt)
+	assert.Equal(t, map[string]string{"channel": "mobile", "utm source": "ads"}, out.Labels)
+	assert.Equal(t, []any{"x", "y"}, out.Extra)
+	assert.Empty(t, out.Untouched)
+}
+
+func TestBody_XMLRoundTripsResponseEncoding(t *testing.T) {
+	amount := 150.5
+	raw, err := response.MarshalXML(map[string]any{
+		"code": "BKG-001", "paid": true, "amount": amount, "source": "web",
+		"details": []detailRequest{
+			{ProductID: "p-1", Qty: 2, Price: 50},
+			{ProductID: "p-2", Qty: 1, Price: 50.5},
+		},
+	})
+	require.NoError(t, err)
+
+	out, err := parse(t, fiber.MIMETextXML, raw)
+
+	require.NoError(t, err)
+	assertBound(t, out)
+}
+
+func TestBody_Msgpack(t *testing.T) {
+	body, err := msgpack.Marshal(map[string]any{
+		"code": "BKG-001", "paid": true, "amount": 150.5, "source": "web",
+		"details": []map[string]any{
+			{"product_id": "p-1", "qty": 2, "price_per_unit": 50},
+			{"product_id": "p-2", "qty": 1, "price_per_unit": 50.5},
+		},
+	})
+	require.NoError(t, err)
+
+	for _, contentType := range []string{response.MIMEApplicationMsgpack, response.MIMEApplicationXMsgpack} {
+		t.Run(contentType, func(t *testing.T) {
+			out, err := parse(t, contentType, body)
+
+			require.NoError(t, err)
+			assertBound(t, out)
+		})
+	}
+}
+
+func TestBody_MalformedBodies(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{name: "json", contentType: fiber.MIMEApplicationJSON, body: `{"code":`},
+		{name: "xml syntax", contentType: fiber.MIMEApplicationXML, body: `<request><code>BKG</request>`},
+		{name: "xml number", contentType: fiber.MIMEApplicationXML, body: `<request><amount>lots</amount></request>`},
+		{name: "xml bool", contentType: fiber.MIMEApplicationXML, body: `<request><paid>yes</paid></request>`},
+		{name: "msgpack", contentType: response.MIMEApplicationMsgpack, body: "\xc1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(t, tt.contentType, []byte(tt.body))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package bind_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/bind"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+type paging struct {
+	Limit  int `query:"limit"`
+	Offset int `query:"offset"`
+}
+
+type updateRequest struct {
+	paging
+	ID        string   `json:"-" params:"id"`
+	Name      string   `json:"name" sanitize:"squash"`
+	Email     string   `json:"email" sanitize:"lower"`
+	Currency  string   `json:"currency" sanitize:"upper"`
+	Secret    string   `json:"secret" sanitize:"-"`
+	Status    []string `json:"-" query:"status"`
+	Active    *bool    `json:"-" query:"active"`
+	Tenant    string   `json:"-" header:"X-Tenant-ID"`
+	Channel   string   `json:"channel" query:"channel"`
+	Untagged  string   `json:"-"`
+	Overrides string   `json:"overrides" query:"overrides" params:"overrides"`
+}
+
+// bindRequest sends a PUT to target (matching "/items/:id/:overrides?") and
+// binds it into an updateRequest.
+func bindRequest(t *testing.T, target, body string, headers map[string]string) (updateRequest, error) {
+	t.Helper()
+
+	var out updateRequest
+	var bindErr error
+	app := fiber.New()
+	app.Put("/items/:id/:overrides?", func(c *fiber.Ctx) error {
+		bindErr = bind.Request(c, &out)
+		return nil
+	})
+
+	req := httptest.NewRequest(fiber.MethodPut, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	return out, bindErr
+}
+
+func requireMalformed(t *testing.T, err error) *apperror.AppError {
+	t.Helper()
+
+	var appErr *apperror.AppError
+	require.True(t, errors.As(err, &appErr), "expected an AppError, got %v", err)
+	assert.Equal(t, apperror.CodeMalformedRequest, appErr.Code)
+	assert.Equal(t, fiber.StatusBadRequest, appErr.GetHttpStatus())
+	return appErr
+}
+
+// ============================================================================
+// BINDING
+// ============================================================================
+
+func TestRequest_BindsEverySource(t *testing.T) {
+	out, err := bindRequest(t,
+		"/items/abc-123?limit=20&offset=40&status=paid,pending&status=failed&active=true&channel=web",
+		`{"name":"Jane"}`,
+		map[string]string{"X-Tenant-ID": "tenant-1"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc-123", out.ID)
+	assert.Equal(t, "Jane", out.Name)
+	assert.Equal(t, 20, out.Limit, "embedded struct fields are bound")
+	assert.Equal(t, 40, out.Offset)
+	assert.Equal(t, []string{"paid", "pending", "failed"}, out.Status)
+	require.NotNil(t, out.Active)
+	assert.True(t, *out.Active)
+	assert.Equal(t, "tenant-1", out.Tenant)
+	assert.Equal(t, "web", out.Channel)
+}
+
+func TestRequest_WithoutBody(t *testing.T) {
+	out, err := bindRequest(t, "/items/abc-123?limit=5", "", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc-123", out.ID)
+	assert.Equal(t, 5, out.Limit)
+	assert.Nil(t, out.Active, "absent sources leave fields untouched")
+}
+
+func TestRequest_Precedence(t *testing.T) {
+	t.Run("query overrides the body", func(t *testing.T) {
+		out, err := bindRequest(t, "/items/1?channel=web", `{"channel":"mobile"}`, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "web", out.Channel)
+	})
+
+	t.Run("body is kept when the query is absent", func(t *testing.T) {
+		out, err := bindRequest(t, "/items/1", `{"channel":"mobile"}`, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "mobile", out.Channel)
+	})
+
+	t.Run("route parameters win", func(t *testing.T) {
+		out, err := bindRequest(t, "/items/1/path?overrides=query", `{"overrides":"body"}`, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "path", out.Overrides)
+	})
+}
+
+func TestRequest_OnlyTaggedFields(t *testing.T) {
+	out, err := bindRequest(t, "/items/1?untagged=x&name=query", `{"name":"body"}`, nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, out.Untagged)
+	assert.Equal(t, "body", out.Name)
+}
+
+// ============================================================================
+// ERRORS
+// ============================================================================
+
+func TestRequest_InvalidQueryValue(t *testing.T) {
+	_, err := bindRequest(t, "/items/1?limit=ten", "", nil)
+
+	appErr := requireMalformed(t, err)
+	assert.Equal(t, []map[string]string{
+		{"field": "limit", "message": "invalid query value"},
+	}, appErr.Details)
+}
+
+func TestRequest_InvalidBoolPointer(t *testing.T) {
+	_, err := bindRequest(t, "/items/1?active=maybe", "", nil)
+
+	appErr := requireMalformed(t, err)
+	assert.Equal(t, []map[string]string{
+		{"field": "active", "message": "invalid query value"},
+	}, appErr.Details)
+}
+
+func TestRequest_MalformedBody(t *testing.T) {
+	_, err := bindRequest(t, "/items/1", `{"name":`, nil)
+
+	appErr := requireMalformed(t, err)
+	assert.Equal(t, apperror.ErrCodeMalformedRequest.Message, appErr.Message)
+	assert.Nil(t, appErr.Details)
+}
+
+func TestRequest_DoesNotMutateSharedError(t *testing.T) {
+	_, err := bindRequest(t, "/items/1?limit=ten", "", nil)
+	requireMalformed(t, err)
+
+	assert.Nil(t, apperror.ErrCodeMalformedRequest.Details)
+}
+
+func TestRequest_RejectsNonStructPointer(t *testing.T) {
+	var out updateRequest
+	app := fiber.New()
+	var bindErr error
+	app.Get("/", func(c *fiber.Ctx) error {
+		bindErr = bind.Request(c, out)
+		return nil
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil), -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Error(t, bindErr)
+	var appErr *apperror.AppError
+	assert.False(t, errors.As(bindErr, &appErr), "programming errors are not client errors")
+}
+
+// ============================================================================
+// SANITIZATION
+// ============================================================================
+
+func TestRequest_Sanitizes(t *testing.T) {
+	out, err := bindRequest(t, "/items/1?channel=%20web%20",
+		`{"name":"  Jane \t  Doe ","email":" Jane@Example.COM ","currency":" idr ","secret":"  s3cret  "}`,
+		map[string]string{"X-Tenant-ID": " tenant-1 "},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Jane Doe", out.Name)
+	assert.Equal(t, "jane@example.com", out.Email)
+	assert.Equal(t, "IDR", out.Currency)
+	assert.Equal(t, "  s3cret  ", out.Secret, "sanitize:\"-\" fields are untouched")
+	assert.Equal(t, "web", out.Channel)
+	assert.Equal(t, "tenant-1", out.Tenant)
+}
+
+func TestSanitize_Nested(t *testing.T) {
+	type item struct {
+		Code string `sanitize:"upper"`
+	}
+	type request struct {
+		Note  *string
+		Items []item
+		Tags  []string `sanitize:"lower"`
+	}
+
+	note := "  hello  "
+	in := request{
+		Note:  &note,
+		Items: []item{{Code: " ab "}, {Code: "cd"}},
+		Tags:  []string{" Blue ", "RED"},
+	}
+	bind.Sanitize(&in)
+
+	assert.Equal(t, "hello", *in.Note)
+	assert.Equal(t, []item{{Code: "AB"}, {Code: "CD"}}, in.Items)
+	assert.Equal(t, []string{"blue", "red"}, in.Tags)
+}
@@ -0,0 +1,93 @@
+package breaker_test
+
+import (
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/pkg/breaker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	var states []breaker.State
+	b := breaker.New(2, time.Hour, func(s breaker.State) { states = append(states, s) })
+
+	assert.True(t, b.Allow())
+	b.Record(true)
+	assert.Equal(t, breaker.Closed, b.State(), "below the threshold")
+	assert.True(t, b.Allow())
+	b.Record(true)
+
+	assert.Equal(t, breaker.Open, b.State())
+	assert.False(t, b.Allow())
+	assert.Equal(t, []breaker.State{breaker.Open}, states)
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	b := breaker.New(2, time.Hour, nil)
+
+	b.Allow()
+	b.Record(true)
+	b.Allow()
+	b.Record(false)
+	b.Allow()
+	b.Record(true)
+
+	assert.Equal(t, breaker.Closed, b.State(), "the failures must be consecutive")
+}
+
+func TestBreaker_HalfOpenTrial(t *testing.T) {
+	var states []breaker.State
+	b := breaker.New(1, 10*time.Millisecond, func(s breaker.State) { states = append(states, s) })
+	b.Allow()
+	b.Record(true)
+	time.Sleep(15 * time.Millisecond)
+
+	assert.Equal(t, breaker.HalfOpen, b.State())
+	assert.True(t, b.Allow(), "the trial call")
+	assert.False(t, b.Allow(), "a single trial at a time")
+	b.Record(true)
+	assert.Equal(t, breaker.Open, b.State(), "a failed trial opens the circuit again")
+
+	time.Sleep(15 * time.Millisecond)
+	assert.True(t, b.Allow())
+	b.Record(false)
+	assert.Equal(t, breaker.Closed, b.State())
+	assert.True(t, b.Allow())
+
+	assert.Equal(t, []breaker.State{breaker.Open, breaker.HalfOpen, breaker.Open, breaker.HalfOpen, breaker.Closed}, states)
+}
+
+func TestBreaker_ReleaseFreesTheTrial(t *testing.T) {
+	b := breaker.New(1, 10*time.Millisecond, nil)
+	b.Allow()
+	b.Record(true)
+	time.Sleep(15 * time.Millisecond)
+
+	assert.True(t, b.Allow())
+	b.Release()
+
+	assert.True(t, b.Allow(), "a released trial says nothing: another one is let through")
+}
+
+func TestBreaker_Disabled(t *testing.T) {
+	b := breaker.New(0, time.Hour, nil)
+	for range 10 {
+		assert.True(t, b.Allow())
+		b.Record(true)
+	}
+	assert.Equal(t, breaker.Closed, b.State())
+}
+
+func TestGroup_BreakerPerKey(t *testing.T) {
+	changed := map[string]breaker.State{}
+	g := breaker.NewGroup(1, time.Hour, func(key string, s breaker.State) { changed[key] = s })
+
+	g.Get("a.example").Allow()
+	g.Get("a.example").Record(true)
+
+	assert.False(t, g.Get("a.example").Allow())
+	assert.True(t, g.Get("b.example").Allow())
+	assert.Equal(t, map[string]breaker.State{"a.example": breaker.Open}, changed)
+}
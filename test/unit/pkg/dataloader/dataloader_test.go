@@ -0,0 +1,102 @@
+package dataloader_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/pkg/dataloader"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_BatchesConcurrentLoads(t *testing.T) {
+	var calls int32
+	var gotKeys []int
+	l := dataloader.New(func(ctx context.Context, keys []int) (map[int]string, error) {
+		atomic.AddInt32(&calls, 1)
+		gotKeys = keys
+		res := make(map[int]string, len(keys))
+		for _, k := range keys {
+			if k%2 == 0 {
+				res[k] = "even"
+			}
+		}
+		return res, nil
+	}, 10*time.Millisecond, 0)
+
+	var wg sync.WaitGroup
+	results := make([]bool, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, found, err := l.Load(context.Background(), i)
+			require.NoError(t, err)
+			results[i] = found
+			if found {
+				assert.Equal(t, "even", v)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.ElementsMatch(t, []int{0, 1, 2, 3}, gotKeys)
+	assert.Equal(t, []bool{true, false, true, false}, results)
+}
+
+func TestLoader_CachesResults(t *testing.T) {
+	var calls int32
+	l := dataloader.New(func(ctx context.Context, keys []string) (map[string]int, error) {
+		atomic.AddInt32(&calls, 1)
+		return map[string]int{"a": 1}, nil
+	}, time.Millisecond, 0)
+
+	for i := 0; i < 3; i++ {
+		v, found, err := l.Load(context.Background(), "a")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, 1, v)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestLoader_DispatchesWhenBatchIsFull(t *testing.T) {
+	var calls int32
+	l := dataloader.New(func(ctx context.Context, keys []int) (map[int]int, error) {
+		atomic.AddInt32(&calls, 1)
+		assert.LessOrEqual(t, len(keys), 2)
+		return map[int]int{}, nil
+	}, time.Hour, 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, _, err := l.Load(context.Background(), i)
+			require.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestLoader_PropagatesBatchError(t *testing.T) {
+	boom := errors.New("boom")
+	l := dataloader.New(func(ctx context.Context, keys []int) (map[int]int, error) {
+		return nil, boom
+	}, time.Millisecond, 0)
+
+	_, found, err := l.Load(context.Background(), 1)
+
+	assert.ErrorIs(t, err, boom)
+	assert.False(t, found)
+}
@@ -0,0 +1,114 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder_EncodesCloudEvents(t *testing.T) {
+	enc := events.NewEncoder("voyago-core-api", tracer.NewNoOpTracer())
+	evt := eventbus.NewEvent("booking.created", "booking", map[string]any{"booking_id": "b-1"})
+
+	data, err := enc.Encode(context.Background(), evt)
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "1.0", doc["specversion"])
+	assert.Equal(t, evt.ID, doc["id"])
+	assert.Equal(t, "/voyago-core-api/booking", doc["source"])
+	assert.Equal(t, "booking.created", doc["type"])
+	assert.Equal(t, evt.OccurredAt.Format(time.RFC3339Nano), doc["time"])
+	assert.Equal(t, "application/json", doc["datacontenttype"])
+	assert.Equal(t, "urn:voyago:events:booking.created:v1", doc["dataschema"])
+	assert.EqualValues(t, 1, doc["schemaversion"])
+	assert.Equal(t, map[string]any{"booking_id": "b-1"}, doc["data"])
+	assert.NotContains(t, doc, "traceparent", "no span in the context")
+}
+
+func TestEncoder_CarriesTheSchemaVersion(t *testing.T) {
+	enc := events.NewEncoder("voyago-core-api", tracer.NewNoOpTracer())
+	evt := eventbus.NewEvent("booking.created", "booking", nil)
+	evt.SchemaVersion = 2
+
+	env, err := enc.Envelope(context.Background(), evt)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, env.SchemaVersion)
+	assert.Equal(t, "urn:voyago:events:booking.created:v2", env.DataSchema)
+}
+
+func TestEncoder_CarriesTheTraceContext(t *testing.T) {
+	trc, err := tracer.NewOTelTracer("voyago-test", "test", "127.0.0.1:1", 1, config.TraceSamplingConfig{})
+	require.NoError(t, err)
+	span, ctx := trc.StartSpan(context.Background(), "HTTP POST /api/v1/bookings")
+	defer span.Finish()
+	traceID, _, _ := trc.ExtractTraceInfo(ctx)
+
+	data, err := events.NewEncoder("voyago-core-api", trc).Encode(ctx, eventbus.NewEvent("booking.created", "booking", nil))
+	require.NoError(t, err)
+	env, err := events.Decode(data)
+	require.NoError(t, err)
+
+	assert.Contains(t, env.TraceParent, traceID)
+	consumer, cctx := trc.StartSpan(trc.Extract(context.Background(), env), "consume")
+	defer consumer.Finish()
+	got, _, _ := trc.ExtractTraceInfo(cctx)
+	assert.Equal(t, traceID, got, "the envelope is a trace carrier")
+}
+
+func TestDecode_RoundTrip(t *testing.T) {
+	enc := events.NewEncoder("voyago-core-api", tracer.NewNoOpTracer())
+	evt := eventbus.NewEvent("booking.payment_status_changed", "payment", map[string]any{"booking_id": "b-1"})
+	data, err := enc.Encode(context.Background(), evt)
+	require.NoError(t, err)
+
+	env, err := events.Decode(data)
+	require.NoError(t, err)
+	got, err := env.Event()
+	require.NoError(t, err)
+
+	assert.Equal(t, evt.ID, got.ID)
+	assert.Equal(t, evt.Type, got.Type)
+	assert.Equal(t, "payment", got.Source)
+	assert.True(t, evt.OccurredAt.Equal(got.OccurredAt))
+	assert.Equal(t, 1, got.SchemaVersion)
+	assert.Equal(t, map[string]any{"booking_id": "b-1"}, got.Payload)
+}
+
+func TestDecode_AcceptsTheLegacyBusEnvelope(t *testing.T) {
+	data := []byte(`{"id":"e-1","type":"booking.created","source":"booking","occurred_at":"2026-02-12T10:00:00Z","data":{"booking_id":"b-1"}}`)
+
+	env, err := events.Decode(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0", env.SpecVersion)
+	assert.Equal(t, 1, env.SchemaVersion)
+	got, err := env.Event()
+	require.NoError(t, err)
+	assert.Equal(t, "e-1", got.ID)
+	assert.Equal(t, "booking", got.Source)
+	assert.Equal(t, map[string]any{"booking_id": "b-1"}, got.Payload)
+}
+
+func TestDecode_RejectsInvalidEvents(t *testing.T) {
+	for name, data := range map[string]string{
+		"not json":     `{`,
+		"unknown spec": `{"specversion":"2.0","id":"e-1","source":"/app/booking","type":"booking.created"}`,
+		"missing type": `{"specversion":"1.0","id":"e-1","source":"/app/booking"}`,
+		"not an event": `{"booking_id":"b-1"}`,
+	} {
+		_, err := events.Decode([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
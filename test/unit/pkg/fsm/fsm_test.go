@@ -0,0 +1,94 @@
+package fsm_test
+
+import (
+	"errors"
+	"testing"
+
+	"voyago/core-api/internal/pkg/fsm"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type door struct {
+	State  string
+	Locked bool
+}
+
+var errLocked = errors.New("locked")
+
+func newMachine() *fsm.Machine[door, string] {
+	return fsm.New("door",
+		func(d *door) *string { return &d.State },
+		fsm.Table[string]{
+			"closed": {"open"},
+			"open":   {"closed"},
+		},
+	).Guard("open", func(d *door, _ fsm.Transition[string]) error {
+		if d.Locked {
+			return errLocked
+		}
+		return nil
+	})
+}
+
+func TestMachine_Fire_MovesAndTellsTheListeners(t *testing.T) {
+	var seen []fsm.Transition[string]
+	m := newMachine().OnTransition(func(d *door, tr fsm.Transition[string]) {
+		assert.Equal(t, tr.To, d.State)
+		seen = append(seen, tr)
+	})
+	d := &door{State: "closed"}
+
+	require.NoError(t, m.Fire(d, "open"))
+	require.NoError(t, m.Fire(d, "closed"))
+
+	assert.Equal(t, "closed", d.State)
+	assert.Equal(t, []fsm.Transition[string]{{From: "closed", To: "open"}, {From: "open", To: "closed"}}, seen)
+}
+
+func TestMachine_Fire_RefusesATransitionAbsentFromTheTable(t *testing.T) {
+	m := newMachine()
+	d := &door{State: "closed"}
+
+	err := m.Fire(d, "closed")
+
+	assert.ErrorIs(t, err, fsm.ErrTransitionNotAllowed)
+	var trErr *fsm.TransitionError[string]
+	require.ErrorAs(t, err, &trErr)
+	assert.Equal(t, "door", trErr.Machine)
+	assert.Equal(t, fsm.Transition[string]{From: "closed", To: "closed"}, trErr.Transition)
+	assert.Equal(t, "closed", d.State)
+}
+
+func TestMachine_Fire_RefusesATransitionFailingItsGuard(t *testing.T) {
+	m := newMachine()
+	d := &door{State: "closed", Locked: true}
+
+	err := m.Fire(d, "open")
+
+	assert.ErrorIs(t, err, errLocked)
+	assert.Equal(t, "closed", d.State)
+	assert.ErrorIs(t, m.Can(d, "open"), errLocked)
+
+	d.Locked = false
+	assert.NoError(t, m.Can(d, "open"))
+}
+
+func TestMachine_AllowedAndNext_IgnoreTheGuards(t *testing.T) {
+	m := newMachine()
+
+	assert.True(t, m.Allowed("closed", "open"))
+	assert.False(t, m.Allowed("closed", "closed"))
+	assert.False(t, m.Allowed("unknown", "open"))
+	assert.Equal(t, []string{"open"}, m.Next("closed"))
+	assert.Empty(t, m.Next("unknown"))
+}
+
+func TestNew_PanicsOnAStateMissingFromTheTable(t *testing.T) {
+	assert.Panics(t, func() {
+		fsm.New("door", func(d *door) *string { return &d.State }, fsm.Table[string]{
+			"closed": {"open"},
+		})
+	})
+}
@@ -0,0 +1,44 @@
+package gateway_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/pkg/gateway"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrust(t *testing.T) {
+	internal, external := netip.MustParseAddr("10.0.0.5"), netip.MustParseAddr("203.0.113.7")
+	cases := map[string]struct {
+		cfg     config.GatewayConfig
+		secret  string
+		peer    netip.Addr
+		trusted bool
+	}{
+		"nothing configured":     {config.GatewayConfig{}, "", internal, false},
+		"secret":                 {config.GatewayConfig{Secret: "s3cret"}, "s3cret", external, true},
+		"wrong secret":           {config.GatewayConfig{Secret: "s3cret"}, "secret", external, false},
+		"trusted ip":             {config.GatewayConfig{TrustedIPs: []string{"10.0.0.0/8"}}, "", internal, true},
+		"other ip":               {config.GatewayConfig{TrustedIPs: []string{"10.0.0.0/8"}}, "", external, false},
+		"secret and trusted ip":  {config.GatewayConfig{Secret: "s3cret", TrustedIPs: []string{"10.0.0.5"}}, "s3cret", internal, true},
+		"secret from another ip": {config.GatewayConfig{Secret: "s3cret", TrustedIPs: []string{"10.0.0.5"}}, "s3cret", external, false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			trust, err := gateway.New(tc.cfg)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.trusted, trust.Trusted(tc.secret, tc.peer))
+		})
+	}
+}
+
+func TestNew_InvalidTrustedIP(t *testing.T) {
+	_, err := gateway.New(config.GatewayConfig{TrustedIPs: []string{"gateway.internal"}})
+
+	assert.ErrorContains(t, err, `"gateway.internal" is neither an IP nor a CIDR`)
+}
@@ -0,0 +1,109 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/i18n"
+	"voyago/core-api/locales"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBundle(t *testing.T) *i18n.Bundle {
+	t.Helper()
+	b, err := i18n.Load(fstest.MapFS{
+		"id/core.yaml":    {Data: []byte("errors:\n  NOT_FOUND: Tidak ditemukan\nvalidation:\n  required: \"{field} wajib diisi\"\n")},
+		"id-ID/core.yaml": {Data: []byte("errors:\n  CONFLICT: Bentrok\n")},
+		"fr/core.yaml":    {Data: []byte("errors:\n  NOT_FOUND: Introuvable\n")},
+		"en/core.yaml":    {Data: []byte("errors:\n  GONE: No longer there\n")},
+	})
+	require.NoError(t, err)
+	return b
+}
+
+func TestBundle_Message_FallbackChain(t *testing.T) {
+	b := newBundle(t)
+
+	tests := []struct {
+		name    string
+		locales []string
+		key     string
+		want    string
+		found   bool
+	}{
+		{"exact locale", []string{"id-ID"}, "errors.CONFLICT", "Bentrok", true},
+		{"language of the locale", []string{"id-ID"}, "errors.NOT_FOUND", "Tidak ditemukan", true},
+		{"case and separator", []string{"ID_id"}, "errors.CONFLICT", "Bentrok", true},
+		{"next accepted locale", []string{"de", "fr"}, "errors.NOT_FOUND", "Introuvable", true},
+		{"source locale stops the chain", []string{"en", "id"}, "errors.NOT_FOUND", "Not found", false},
+		{"catalog of the source locale", []string{"en"}, "errors.GONE", "No longer there", true},
+		{"missing everywhere", []string{"id"}, "errors.LOCKED", "Not found", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := b.Message(tt.locales, tt.key, "Not found")
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+	assert.Equal(t, []string{"en", "fr", "id", "id-id"}, b.Locales())
+}
+
+func TestLoad_InvalidCatalog(t *testing.T) {
+	_, err := i18n.Load(fstest.MapFS{"id/core.yaml": {Data: []byte("errors:\n  NOT_FOUND: [a, b]\n")}})
+	assert.ErrorContains(t, err, `id/core.yaml: message "errors.NOT_FOUND" is not a string`)
+}
+
+func TestTranslate(t *testing.T) {
+	require.NoError(t, i18n.Configure(newBundle(t), config.I18nConfig{DefaultLocale: "id"}))
+	t.Cleanup(func() { require.NoError(t, i18n.Configure(newBundle(t), config.I18nConfig{})) })
+
+	ctx := ctxkey.SetLocales(context.Background(), []string{"fr"})
+	assert.Equal(t, "Introuvable", i18n.Translate(ctx, "errors.NOT_FOUND", "Not found", nil))
+	assert.Equal(t, "Code wajib diisi", i18n.Translate(ctx, "validation.required", "Code is required", map[string]string{"field": "Code"}),
+		"the default locale ends the chain")
+	assert.Equal(t, "Tidak ditemukan", i18n.Translate(context.Background(), "errors.NOT_FOUND", "Not found", nil))
+	assert.Equal(t, "Unknown", i18n.Translate(ctx, "errors.UNKNOWN", "Unknown", nil))
+
+	english := ctxkey.SetLocales(context.Background(), []string{"en-US"})
+	assert.Equal(t, "Not found", i18n.Translate(english, "errors.NOT_FOUND", "Not found", nil))
+}
+
+func TestConfigure_UnknownDefaultLocale(t *testing.T) {
+	err := i18n.Configure(newBundle(t), config.I18nConfig{DefaultLocale: "ja"})
+	assert.EqualError(t, err, `no messages for the default locale "ja", expected one of en, fr, id, id-id`)
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	assert.Equal(t, []string{"id-ID", "id", "en"}, i18n.ParseAcceptLanguage("en;q=0.8, id-ID, id;q=0.9"))
+	assert.Equal(t, []string{"id"}, i18n.ParseAcceptLanguage("*, fr;q=0, id;q=0.5, de;q=abc"))
+	assert.Empty(t, i18n.ParseAcceptLanguage(""))
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "Kode minimal 3 karakter", i18n.Format("{field} minimal {param} karakter", map[string]string{"field": "Kode", "param": "3"}))
+	assert.Equal(t, "{field} wajib diisi", i18n.Format("{field} wajib diisi", nil))
+}
+
+func TestLocales_IndonesianCatalog(t *testing.T) {
+	b, err := i18n.Load(locales.FS())
+	require.NoError(t, err)
+	require.Contains(t, b.Locales(), "id")
+
+	for _, e := range apperror.Catalog() {
+		if e.Module != apperror.CoreModule {
+			continue
+		}
+		_, found := b.Message([]string{"id"}, "errors."+e.Code, e.Message)
+		assert.True(t, found, "no Indonesian message for %s", e.Code)
+	}
+	for _, key := range []string{"required", "min", "min_string", "max", "max_string", "email", "uuid", "gt", "gte", "lt", "lte", "eq", "ne", "invalid"} {
+		_, found := b.Message([]string{"id"}, "validation."+key, "")
+		assert.True(t, found, "no Indonesian message for the rule %s", key)
+	}
+}
@@ -0,0 +1,28 @@
+package ipnet_test
+
+import (
+	"testing"
+
+	"voyago/core-api/internal/pkg/ipnet"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	list, err := ipnet.Parse([]string{"10.0.0.0/8", "192.168.1.7", "2001:db8::/32"})
+	require.NoError(t, err)
+
+	assert.True(t, list.ContainsIP("10.20.30.40"))
+	assert.True(t, list.ContainsIP("::ffff:10.0.0.1"), "IPv4 mapped in IPv6")
+	assert.True(t, list.ContainsIP("192.168.1.7"))
+	assert.False(t, list.ContainsIP("192.168.1.8"))
+	assert.True(t, list.ContainsIP("2001:db8::1"))
+	assert.False(t, list.ContainsIP("not an ip"))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := ipnet.Parse([]string{"10.0.0.0/8", "lb.internal"})
+
+	assert.ErrorContains(t, err, `"lb.internal" is neither an IP nor a CIDR`)
+}
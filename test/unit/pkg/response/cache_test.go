@@ -0,0 +1,85 @@
+package response_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachePolicy_String(t *testing.T) {
+	tests := map[string]struct {
+		policy response.CachePolicy
+		want   string
+	}{
+		"zero value": {response.CachePolicy{}, "public, max-age=0"},
+		"cdn": {response.CachePolicy{
+			MaxAge:               time.Minute,
+			SharedMaxAge:         10 * time.Minute,
+			StaleWhileRevalidate: 30 * time.Second,
+		}, "public, max-age=60, s-maxage=600, stale-while-revalidate=30"},
+		"private": {response.CachePolicy{
+			MaxAge:       30 * time.Second,
+			SharedMaxAge: time.Hour,
+			Private:      true,
+		}, "private, max-age=30"},
+		"no store": {response.NoStore, "no-store"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.String())
+		})
+	}
+}
+
+func TestCache_AppliedToSuccessfulReads(t *testing.T) {
+	policy := response.Cache(response.CachePolicy{MaxAge: 5 * time.Minute, StaleWhileRevalidate: time.Minute})
+	ok := func(c *fiber.Ctx) error {
+		return response.NewHttp(c).OK(response.Http{Message: "ok", Data: item})
+	}
+
+	app := fiber.New()
+	app.Get("/categories", policy, ok)
+	app.Post("/categories", policy, ok)
+	app.Get("/broken", policy, func(c *fiber.Ctx) error {
+		return errors.New("boom")
+	})
+	app.Get("/uncached", ok)
+
+	cacheControl := func(method, path string, headers map[string]string) (int, string) {
+		req := httptest.NewRequest(method, path, nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode, resp.Header.Get(fiber.HeaderCacheControl)
+	}
+
+	const want = "public, max-age=300, stale-while-revalidate=60"
+
+	status, value := cacheControl(fiber.MethodGet, "/categories", nil)
+	assert.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, want, value)
+
+	status, value = cacheControl(fiber.MethodGet, "/categories", map[string]string{fiber.HeaderIfNoneMatch: "*"})
+	assert.Equal(t, fiber.StatusNotModified, status)
+	assert.Equal(t, want, value, "304 responses refresh the cached policy")
+
+	_, value = cacheControl(fiber.MethodPost, "/categories", nil)
+	assert.Empty(t, value)
+
+	status, value = cacheControl(fiber.MethodGet, "/broken", nil)
+	assert.Equal(t, fiber.StatusInternalServerError, status)
+	assert.Empty(t, value, "errors are never cacheable")
+
+	_, value = cacheControl(fiber.MethodGet, "/uncached", nil)
+	assert.Empty(t, value)
+}
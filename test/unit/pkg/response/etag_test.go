@@ -0,0 +1,130 @@
+package response_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"voyago/core-api/internal/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+// newETagApp answers /items with the builder. Each request gets its own trace
+// ID when traced is set, as with the telemetry middleware.
+func newETagApp(traced bool) *fiber.App {
+	app := fiber.New()
+	var n int
+	app.Use(func(c *fiber.Ctx) error {
+		if traced {
+			n++
+			c.Locals("trace_id", "trace-"+strings.Repeat("x", n))
+		}
+		return c.Next()
+	})
+	handler := func(c *fiber.Ctx) error {
+		return response.NewHttp(c).OK(response.Http{Message: "ok", Data: item})
+	}
+	app.Get("/items", handler)
+	app.Post("/items", handler)
+	return app
+}
+
+func request(t *testing.T, app *fiber.App, method string, headers map[string]string) (*http.Response, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, "/items", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+// ============================================================================
+// ETAG
+// ============================================================================
+
+func TestOK_StrongETagWithoutTraceID(t *testing.T) {
+	app := newETagApp(false)
+
+	first, _ := request(t, app, fiber.MethodGet, nil)
+	etag := first.Header.Get(fiber.HeaderETag)
+	require.NotEmpty(t, etag)
+	assert.False(t, strings.HasPrefix(etag, "W/"), "identical bytes get a strong ETag")
+
+	second, _ := request(t, app, fiber.MethodGet, nil)
+	assert.Equal(t, etag, second.Header.Get(fiber.HeaderETag))
+}
+
+func TestOK_WeakETagIgnoresTraceID(t *testing.T) {
+	app := newETagApp(true)
+
+	first, firstBody := request(t, app, fiber.MethodGet, nil)
+	second, secondBody := request(t, app, fiber.MethodGet, nil)
+	require.NotEqual(t, firstBody, secondBody, "the trace IDs differ")
+
+	etag := first.Header.Get(fiber.HeaderETag)
+	assert.True(t, strings.HasPrefix(etag, `W/"`))
+	assert.Equal(t, etag, second.Header.Get(fiber.HeaderETag))
+}
+
+func TestOK_ETagPerFormat(t *testing.T) {
+	app := newETagApp(false)
+
+	json, _ := request(t, app, fiber.MethodGet, nil)
+	xml, _ := request(t, app, fiber.MethodGet, map[string]string{fiber.HeaderAccept: fiber.MIMEApplicationXML})
+	assert.NotEqual(t, json.Header.Get(fiber.HeaderETag), xml.Header.Get(fiber.HeaderETag))
+}
+
+func TestOK_IfNoneMatch(t *testing.T) {
+	app := newETagApp(true)
+
+	first, _ := request(t, app, fiber.MethodGet, nil)
+	etag := first.Header.Get(fiber.HeaderETag)
+
+	tests := map[string]struct {
+		ifNoneMatch string
+		want        int
+	}{
+		"same tag":     {etag, fiber.StatusNotModified},
+		"listed tag":   {`"other", ` + etag, fiber.StatusNotModified},
+		"strong form":  {strings.TrimPrefix(etag, "W/"), fiber.StatusNotModified},
+		"any":          {"*", fiber.StatusNotModified},
+		"stale tag":    {`W/"stale"`, fiber.StatusOK},
+		"no validator": {"", fiber.StatusOK},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, body := request(t, app, fiber.MethodGet, map[string]string{fiber.HeaderIfNoneMatch: tt.ifNoneMatch})
+			assert.Equal(t, tt.want, resp.StatusCode)
+			assert.Equal(t, etag, resp.Header.Get(fiber.HeaderETag))
+			if tt.want == fiber.StatusNotModified {
+				assert.Empty(t, body)
+			} else {
+				assert.NotEmpty(t, body)
+			}
+		})
+	}
+}
+
+func TestOK_NoETagForUnsafeMethods(t *testing.T) {
+	app := newETagApp(false)
+
+	resp, _ := request(t, app, fiber.MethodPost, map[string]string{fiber.HeaderIfNoneMatch: "*"})
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get(fiber.HeaderETag))
+}
@@ -0,0 +1,120 @@
+package response_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"voyago/core-api/internal/pkg/response"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+type itemResponse struct {
+	ID    string   `json:"id"`
+	Qty   int      `json:"qty"`
+	Price float64  `json:"price"`
+	Note  string   `json:"note,omitempty"`
+	Tags  []string `json:"tags"`
+}
+
+var item = itemResponse{ID: "it-1", Qty: 2, Price: 9.5, Tags: []string{"a", "b"}}
+
+// call answers GET / with the builder and returns the response.
+func call(t *testing.T, accept string) (contentType string, body []byte) {
+	t.Helper()
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return response.NewHttp(c).OK(response.Http{Message: "ok", Data: item})
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	if accept != "" {
+		req.Header.Set(fiber.HeaderAccept, accept)
+	}
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, fiber.HeaderAccept, resp.Header.Get(fiber.HeaderVary))
+
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.Header.Get(fiber.HeaderContentType), body
+}
+
+// ============================================================================
+// NEGOTIATION
+// ============================================================================
+
+func TestSend_JSONByDefault(t *testing.T) {
+	for _, accept := range []string{"", "*/*", "application/json", "text/html"} {
+		t.Run(accept, func(t *testing.T) {
+			contentType, body := call(t, accept)
+
+			assert.Equal(t, fiber.MIMEApplicationJSON, contentType)
+			var decoded map[string]any
+			require.NoError(t, json.Unmarshal(body, &decoded))
+			assert.Equal(t, true, decoded["success"])
+		})
+	}
+}
+
+func TestSend_XML(t *testing.T) {
+	contentType, body := call(t, "application/xml")
+
+	assert.Equal(t, fiber.MIMEApplicationXMLCharsetUTF8, contentType)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<response><success>true</success><message>ok</message>`+
+		`<data><id>it-1</id><qty>2</qty><price>9.5</price><tags><item>a</item><item>b</item></tags></data>`+
+		`</response>`, string(body))
+}
+
+func TestSend_Msgpack(t *testing.T) {
+	contentType, body := call(t, "application/msgpack, application/json;q=0.5")
+
+	assert.Equal(t, response.MIMEApplicationMsgpack, contentType)
+	var decoded map[string]any
+	require.NoError(t, msgpack.Unmarshal(body, &decoded))
+	assert.Equal(t, true, decoded["success"])
+
+	data := decoded["data"].(map[string]any)
+	assert.Equal(t, "it-1", data["id"])
+	assert.EqualValues(t, 2, data["qty"])
+	assert.Equal(t, 9.5, data["price"])
+	assert.NotContains(t, data, "note", "empty fields are omitted as in JSON")
+	assert.Equal(t, []any{"a", "b"}, data["tags"])
+}
+
+// ============================================================================
+// XML ENCODING
+// ============================================================================
+
+func TestMarshalXML_KeysThatAreNotXMLNames(t *testing.T) {
+	raw, err := response.MarshalXML(map[string]any{
+		"details[0].qty": "must be at least 1",
+		"xmlns":          "reserved",
+		"empty":          nil,
+	})
+
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `<entry key="details[0].qty">must be at least 1</entry>`)
+	assert.Contains(t, string(raw), `<entry key="xmlns">reserved</entry>`)
+	assert.Contains(t, string(raw), `<empty></empty>`)
+}
+
+func TestMarshalXML_EscapesText(t *testing.T) {
+	raw, err := response.MarshalXML(map[string]any{"note": `<b>"fish" & chips</b>`})
+
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `<note>&lt;b&gt;&#34;fish&#34; &amp; chips&lt;/b&gt;</note>`)
+}
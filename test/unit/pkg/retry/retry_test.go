@@ -0,0 +1,115 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/retry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTransient = apperror.NewTransient(apperror.CodeDbDeadlock, "deadlock")
+
+func fastExecutor(maxAttempts int) retry.Executor {
+	return retry.Executor{MaxAttempts: maxAttempts, BaseBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+}
+
+func TestExecutor_RetriesTransientErrors(t *testing.T) {
+	exec := fastExecutor(3)
+	var retried []int
+	exec.OnRetry = func(_ context.Context, attempt int, err error, wait time.Duration) {
+		assert.ErrorIs(t, err, errTransient)
+		assert.LessOrEqual(t, wait, 2*time.Millisecond)
+		retried = append(retried, attempt)
+	}
+
+	calls := 0
+	err := exec.Do(context.Background(), func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errTransient
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []int{1, 2}, retried)
+}
+
+func TestExecutor_StopsAtMaxAttempts(t *testing.T) {
+	calls := 0
+	err := fastExecutor(3).Do(context.Background(), func(context.Context) error {
+		calls++
+		return errTransient
+	})
+
+	assert.ErrorIs(t, err, errTransient, "the last error is returned")
+	assert.Equal(t, 3, calls)
+}
+
+func TestExecutor_DoesNotRetryPermanentErrors(t *testing.T) {
+	permanent := apperror.NewPersistance(apperror.CodeDbConflict, "duplicate data")
+	for _, want := range []error{permanent, errors.New("plain")} {
+		calls := 0
+		err := fastExecutor(3).Do(context.Background(), func(context.Context) error {
+			calls++
+			return want
+		})
+
+		assert.ErrorIs(t, err, want)
+		assert.Equal(t, 1, calls)
+	}
+}
+
+func TestExecutor_ZeroValueRunsOnce(t *testing.T) {
+	calls := 0
+	err := retry.Executor{}.Do(context.Background(), func(context.Context) error {
+		calls++
+		return errTransient
+	})
+
+	assert.ErrorIs(t, err, errTransient)
+	assert.Equal(t, 1, calls)
+}
+
+func TestExecutor_CustomRetryable(t *testing.T) {
+	exec := fastExecutor(2)
+	exec.Retryable = func(error) bool { return false }
+
+	calls := 0
+	_ = exec.Do(context.Background(), func(context.Context) error {
+		calls++
+		return errTransient
+	})
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestExecutor_StopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	exec := retry.Executor{MaxAttempts: 5, BaseBackoff: time.Hour, MaxBackoff: time.Hour}
+	exec.OnRetry = func(context.Context, int, error, time.Duration) { cancel() }
+
+	calls := 0
+	done := make(chan error)
+	go func() {
+		done <- exec.Do(ctx, func(context.Context) error {
+			calls++
+			return errTransient
+		})
+	}()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, errTransient)
+		assert.Equal(t, 1, calls)
+	case <-time.After(time.Second):
+		t.Fatal("the backoff was not interrupted")
+	}
+}
@@ -0,0 +1,48 @@
+package spec_test
+
+import (
+	"testing"
+
+	"voyago/core-api/internal/pkg/spec"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	s := spec.New(
+		spec.Where("user_id", spec.Eq, "u1"),
+		spec.In("status", "PENDING", "CONFIRMED"),
+		spec.Between("total_amount", 10, 20),
+		spec.OrderBy("created_at", spec.Desc),
+		spec.Limit(20),
+		spec.Offset(40),
+	)
+
+	assert.Equal(t, spec.Spec{
+		Filters: []spec.Filter{
+			{Field: "user_id", Op: spec.Eq, Values: []any{"u1"}},
+			{Field: "status", Op: spec.OpIn, Values: []any{"PENDING", "CONFIRMED"}},
+			{Field: "total_amount", Op: spec.OpBetween, Values: []any{10, 20}},
+		},
+		Orders: []spec.Order{{Field: "created_at", Direction: spec.Desc}},
+		Limit:  20,
+		Offset: 40,
+	}, s)
+}
+
+func TestWith_LeavesTheBaseUnchanged(t *testing.T) {
+	base := spec.New(spec.Where("user_id", spec.Eq, "u1"), spec.Where("status", spec.Ne, "CANCELLED"))
+
+	a := base.With(spec.Where("payment_status", spec.Eq, "PAID"))
+	b := base.With(spec.Where("payment_status", spec.Eq, "UNPAID"))
+
+	assert.Len(t, base.Filters, 2)
+	assert.Equal(t, []any{"PAID"}, a.Filters[2].Values)
+	assert.Equal(t, []any{"UNPAID"}, b.Filters[2].Values)
+}
+
+func TestIn_Empty(t *testing.T) {
+	s := spec.New(spec.In[string]("status"))
+
+	assert.Equal(t, []spec.Filter{{Field: "status", Op: spec.OpIn, Values: []any{}}}, s.Filters)
+}
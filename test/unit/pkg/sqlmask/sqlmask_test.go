@@ -0,0 +1,92 @@
+package sqlmask_test
+
+import (
+	"testing"
+
+	"voyago/core-api/internal/pkg/sqlmask"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObfuscate(t *testing.T) {
+	for name, tc := range map[string]struct{ query, want string }{
+		"placeholders kept": {
+			`SELECT * FROM "bookings" WHERE "bookings"."user_id" = $1 AND status = ? LIMIT $2`,
+			`SELECT * FROM "bookings" WHERE "bookings"."user_id" = $1 AND status = ? LIMIT $2`,
+		},
+		"strings": {
+			`SELECT * FROM users WHERE email = 'jane@example.com' AND name = 'O''Brien'`,
+			`SELECT * FROM users WHERE email = ? AND name = ?`,
+		},
+		"prefixed strings": {
+			`INSERT INTO t (a, b, c) VALUES (E'line\'s\n', X'1F', N'nama')`,
+			`INSERT INTO t (a, b, c) VALUES (?, ?, ?)`,
+		},
+		"dollar quoted strings": {
+			`SELECT $$it's secret$$, $tag$s3cret$tag$ FROM t`,
+			`SELECT ?, ? FROM t`,
+		},
+		"numbers": {
+			`UPDATE bookings SET total_amount = 1500000.50, fee = -2.5e3, flags = 0xFF WHERE id = 42`,
+			`UPDATE bookings SET total_amount = ?, fee = -?, flags = ? WHERE id = ?`,
+		},
+		"identifiers with digits kept": {
+			`SELECT col1, t2.v3 FROM table_2024 t2 WHERE t2.k = .5`,
+			`SELECT col1, t2.v3 FROM table_2024 t2 WHERE t2.k = ?`,
+		},
+		"quoted identifiers kept": {
+			`SELECT "user's 1" FROM "t"`,
+			`SELECT "user's 1" FROM "t"`,
+		},
+		"comments removed": {
+			"SELECT a /* user_id=42 */ FROM t -- token=abc\nWHERE b = 1",
+			"SELECT a   FROM t \nWHERE b = ?",
+		},
+		"unterminated literal": {
+			`SELECT * FROM t WHERE a = 'unterminated`,
+			`SELECT * FROM t WHERE a = ?`,
+		},
+		"gorm explained statement": {
+			`INSERT INTO "bookings" ("booking_code","user_id","payment_reference","total_amount","created_at") VALUES ('BK-001','u-42','PAY-123',150000,'2026-10-16 09:00:00.000')`,
+			`INSERT INTO "bookings" ("booking_code","user_id","payment_reference","total_amount","created_at") VALUES (?,?,?,?,?)`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, sqlmask.Obfuscate(tc.query, sqlmask.DialectPostgres))
+		})
+	}
+}
+
+func TestObfuscate_DoubleQuotedStrings(t *testing.T) {
+	for name, tc := range map[string]struct{ query, dialect, want string }{
+		"sqlite explained statement": {
+			"INSERT INTO `bookings` (`booking_code`,`user_id`,`total_amount`) VALUES (\"BK-001\",\"u-42\",150000)",
+			sqlmask.DialectSQLite,
+			"INSERT INTO `bookings` (`booking_code`,`user_id`,`total_amount`) VALUES (?,?,?)",
+		},
+		"sqlite doubled quote": {
+			`SELECT * FROM t WHERE name = "say ""hi""" AND a = 1`,
+			sqlmask.DialectSQLite,
+			`SELECT * FROM t WHERE name = ? AND a = ?`,
+		},
+		"mysql explained statement": {
+			"SELECT * FROM `users` WHERE `email` = \"jane@example.com\" AND `note` = \"it\\\"s\" LIMIT 1",
+			sqlmask.DialectMySQL,
+			"SELECT * FROM `users` WHERE `email` = ? AND `note` = ? LIMIT ?",
+		},
+		"mysql backquoted identifiers kept": {
+			"SELECT `order 2` FROM `t`",
+			sqlmask.DialectMySQL,
+			"SELECT `order 2` FROM `t`",
+		},
+		"unknown dialect": {
+			`SELECT * FROM t WHERE a = "secret"`,
+			"",
+			`SELECT * FROM t WHERE a = ?`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, sqlmask.Obfuscate(tc.query, tc.dialect))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package tenancy_test
+
+import (
+	"context"
+	"encoding/base64"
+	"runtime"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/ctxkey"
+	"voyago/core-api/internal/pkg/tenancy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// request is a request of the gateway, unless untrusted.
+type request struct {
+	headers   map[string]string
+	host      string
+	untrusted bool
+}
+
+func (r request) Header(name string) string { return r.headers[name] }
+func (r request) Host() string              { return r.host }
+func (r request) Trusted() bool             { return !r.untrusted }
+
+func jwt(payload string) string {
+	enc := base64.RawURLEncoding.EncodeToString
+	return "Bearer " + enc([]byte(`{"alg":"RS256"}`)) + "." + enc([]byte(payload)) + ".signature"
+}
+
+func TestHeaderResolver(t *testing.T) {
+	resolve := tenancy.HeaderResolver("X-Tenant-ID")
+
+	assert.Equal(t, "acme", resolve(request{headers: map[string]string{"X-Tenant-ID": " acme "}}))
+	assert.Empty(t, resolve(request{headers: map[string]string{"X-Tenant-ID": "acme\nlevel=error"}}))
+	assert.Empty(t, resolve(request{}))
+	assert.Empty(t, resolve(request{headers: map[string]string{"X-Tenant-ID": "acme"}, untrusted: true}), "set by the client")
+}
+
+func TestSubdomainResolver(t *testing.T) {
+	resolve := tenancy.SubdomainResolver("voyago.com")
+
+	tests := map[string]string{
+		"acme.voyago.com":      "acme",
+		"ACME.voyago.com:4000": "acme",
+		"voyago.com":           "",
+		"a.b.voyago.com":       "",
+		"acme.example.com":     "",
+		"acmevoyago.com":       "",
+	}
+	for host, want := range tests {
+		assert.Equal(t, want, resolve(request{host: host, untrusted: true}), host)
+	}
+}
+
+func TestClaimResolver(t *testing.T) {
+	resolve := tenancy.ClaimResolver("tenant_id")
+
+	assert.Equal(t, "acme", resolve(request{headers: map[string]string{"Authorization": jwt(`{"sub":"user-1","tenant_id":"acme"}`)}}))
+	assert.Empty(t, resolve(request{headers: map[string]string{"Authorization": jwt(`{"tenant_id":42}`)}}), "not a string")
+	assert.Empty(t, resolve(request{headers: map[string]string{"Authorization": "Bearer opaque-token"}}))
+	assert.Empty(t, resolve(request{headers: map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}}))
+	assert.Empty(t, resolve(request{headers: map[string]string{"Authorization": jwt(`{"tenant_id":"acme"}`)}, untrusted: true}), "not verified by the gateway")
+}
+
+func TestNewResolver(t *testing.T) {
+	resolve, err := tenancy.NewResolver(config.TenancyConfig{
+		Resolvers: []string{config.TenantResolverClaim, config.TenantResolverHeader},
+	})
+	require.NoError(t, err)
+
+	r := request{headers: map[string]string{tenancy.DefaultHeader: "globex", "Authorization": jwt(`{"tenant_id":"acme"}`)}}
+	assert.Equal(t, "acme", resolve(r), "the first resolver finding a tenant wins")
+	assert.Equal(t, "globex", resolve(request{headers: map[string]string{tenancy.DefaultHeader: "globex"}}))
+
+	_, err = tenancy.NewResolver(config.TenancyConfig{Resolvers: []string{"cookie"}})
+	assert.Error(t, err)
+	_, err = tenancy.NewResolver(config.TenancyConfig{Resolvers: []string{config.TenantResolverSubdomain}})
+	assert.Error(t, err, "base_domain is required")
+}
+
+func TestConfig_Overrides(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Webhook.Timeout = 10
+	cfg.Webhook.Retry.MaxAttempts = 3
+	cfg.Security.CORS.AllowedOrigins = []string{"https://a.example", "https://b.example"}
+	cfg.Tenancy.Overrides = map[string]map[string]any{
+		"acme": {
+			"webhook":  map[string]any{"retry": map[string]any{"max_attempts": 10}},
+			"security": map[string]any{"cors": map[string]any{"allowed_origins": []any{"https://acme.example"}}},
+		},
+	}
+	require.NoError(t, tenancy.ValidateOverrides(cfg))
+
+	acme := tenancy.Config(ctxkey.SetTenantID(context.Background(), "ACME"), cfg)
+	assert.Equal(t, 10, acme.Webhook.Retry.MaxAttempts)
+	assert.Equal(t, 10, acme.Webhook.Timeout, "the settings not overridden are kept")
+	assert.Equal(t, []string{"https://acme.example"}, acme.Security.CORS.AllowedOrigins)
+	assert.Same(t, acme, tenancy.Config(ctxkey.SetTenantID(context.Background(), "acme"), cfg), "cached")
+
+	assert.Equal(t, 3, cfg.Webhook.Retry.MaxAttempts, "the base configuration is unchanged")
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, cfg.Security.CORS.AllowedOrigins)
+
+	assert.Same(t, cfg, tenancy.Config(ctxkey.SetTenantID(context.Background(), "globex"), cfg))
+	assert.Same(t, cfg, tenancy.Config(context.Background(), cfg))
+}
+
+func TestConfig_ReleasedWithTheBaseConfiguration(t *testing.T) {
+	released := make(chan struct{})
+	func() {
+		cfg := &config.Config{}
+		cfg.Tenancy.Overrides = map[string]map[string]any{"acme": {"webhook": map[string]any{"timeout": 5}}}
+		acme := tenancy.Config(ctxkey.SetTenantID(context.Background(), "acme"), cfg)
+		require.NotSame(t, cfg, acme)
+		runtime.AddCleanup(acme, func(ch chan struct{}) { close(ch) }, released)
+	}()
+
+	// The base configuration is collected first, then its cached copy.
+	for range 50 {
+		runtime.GC()
+		select {
+		case <-released:
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	t.Fatal("the configuration of the tenant is kept after its base configuration was replaced")
+}
+
+func TestValidateOverrides(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Tenancy.Overrides = map[string]map[string]any{
+		"acme": {"webhook": map[string]any{"timeout": "soon"}},
+	}
+
+	assert.ErrorContains(t, tenancy.ValidateOverrides(cfg), "acme")
+}
@@ -0,0 +1,51 @@
+package uid_test
+
+import (
+	"slices"
+	"testing"
+
+	"voyago/core-api/internal/pkg/uid"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGenerator(t *testing.T) {
+	for name, length := range map[string]int{"": 36, "uuidv7": 36, "ulid": 26} {
+		g, err := uid.NewGenerator(name)
+		require.NoError(t, err, name)
+		assert.Len(t, g.NewID(), length, name)
+	}
+
+	_, err := uid.NewGenerator("uuidv4")
+	assert.ErrorContains(t, err, `unknown id generator "uuidv4"`)
+}
+
+func TestUUIDv7_IsAValidTimeOrderedUUID(t *testing.T) {
+	id, err := uuid.Parse(uid.UUIDv7.NewID())
+
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(7), id.Version())
+}
+
+func TestULID_IsAValidULID(t *testing.T) {
+	id := uid.ULID.NewID()
+
+	assert.Len(t, id, 26)
+	_, err := ulid.ParseStrict(id)
+	assert.NoError(t, err)
+}
+
+func TestGenerators_SortInGenerationOrder(t *testing.T) {
+	for name, g := range map[string]uid.Generator{"uuidv7": uid.UUIDv7, "ulid": uid.ULID} {
+		ids := make([]string, 1000)
+		for i := range ids {
+			ids[i] = g.NewID()
+		}
+
+		assert.True(t, slices.IsSorted(ids), name)
+		assert.Len(t, slices.Compact(slices.Clone(ids)), len(ids), "%s: unique", name)
+	}
+}
@@ -0,0 +1,170 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// configureMasking applies cfg for the test, then restores the defaults.
+func configureMasking(t *testing.T, cfg config.MaskingConfig) {
+	require.NoError(t, utils.ConfigureMasking(cfg))
+	t.Cleanup(func() { _ = utils.ConfigureMasking(config.MaskingConfig{}) })
+}
+
+func TestMasking_Defaults(t *testing.T) {
+	masked := utils.MaskSensitive(map[string]any{
+		"password": "s3cret",
+		"email":    "jane@example.com",
+		"nested":   map[string]any{"access_token": "abc"},
+	})
+
+	assert.Equal(t, map[string]any{
+		"password": utils.Redacted,
+		"email":    "jane@example.com",
+		"nested":   map[string]any{"access_token": utils.Redacted},
+	}, masked)
+	assert.Equal(t, utils.DefaultMaxFieldSize, utils.MaxFieldSize())
+}
+
+func TestMasking_ConfiguredKeys(t *testing.T) {
+	configureMasking(t, config.MaskingConfig{SensitiveKeys: []string{"PIN", "iban"}})
+
+	assert.True(t, utils.IsSensitiveKey("card_pin"))
+	assert.True(t, utils.IsSensitiveKey("IBAN"))
+	assert.False(t, utils.IsSensitiveKey("password"), "the configured keys replace the defaults")
+}
+
+func TestMasking_Patterns(t *testing.T) {
+	configureMasking(t, config.MaskingConfig{Patterns: []string{
+		`\b4\d{3}(?:[ -]?\d{4}){3}\b`,
+		`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+	}})
+
+	masked := utils.MaskSensitive(map[string]any{
+		"note":  "paid with 4111 1111 1111 1111 by jane@example.com",
+		"items": []any{"contact: joe@example.org"},
+		"total": 150000,
+	})
+
+	assert.Equal(t, map[string]any{
+		"note":  "paid with [REDACTED] by [REDACTED]",
+		"items": []any{"contact: [REDACTED]"},
+		"total": 150000,
+	}, masked)
+	assert.Equal(t, "refund to [REDACTED]", utils.MaskPatterns("refund to jane@example.com"))
+}
+
+func TestMasking_Limits(t *testing.T) {
+	configureMasking(t, config.MaskingConfig{MaxFieldSize: 16, MaxDepth: 1})
+
+	assert.Equal(t, 16, utils.MaxFieldSize())
+	assert.Equal(t, "[field size 17 bytes, too large to log]", utils.MaskSensitive(strings.Repeat("x", 17)))
+
+	masked := utils.MaskSensitive(map[string]any{
+		"a": map[string]any{"b": map[string]any{"password": "s3cret"}},
+	})
+	assert.Equal(t, map[string]any{
+		"a": map[string]any{"b": map[string]any{"password": "s3cret"}},
+	}, masked, "the values deeper than max_depth are left as is")
+}
+
+func TestMasking_InvalidPattern(t *testing.T) {
+	err := utils.ConfigureMasking(config.MaskingConfig{Patterns: []string{"("}})
+	assert.ErrorContains(t, err, "invalid masking pattern")
+	assert.True(t, utils.IsSensitiveKey("password"), "the rules are unchanged")
+}
+
+func TestRegisterSensitiveKeys(t *testing.T) {
+	utils.RegisterSensitiveKeys("Loyalty_Number")
+	configureMasking(t, config.MaskingConfig{SensitiveKeys: []string{"pin"}})
+
+	assert.True(t, utils.IsSensitiveKey("loyalty_number"), "registered keys survive a reconfiguration")
+	assert.True(t, utils.IsSensitiveKey("pin"))
+	assert.Equal(t, utils.Redacted, utils.MaskSensitive(map[string]any{"loyalty_number": "LN-42"}).(map[string]any)["loyalty_number"])
+}
+
+func TestMasking_Strategies(t *testing.T) {
+	configureMasking(t, config.MaskingConfig{
+		Strategies: map[string]string{
+			"card":        "redact",
+			"card_number": "LAST4",
+			"phone":       "last4",
+			"email":       "sha256",
+			"address":     "truncate",
+			"token":       "sha256",
+		},
+		TruncateLength: 6,
+	})
+
+	masked := utils.MaskSensitive(map[string]any{
+		"card_number":   "4111 1111 1111 1111",
+		"card_holder":   "Jane Doe",
+		"phone":         6281234567890,
+		"email":         "jane@example.com",
+		"address":       "Jl. Sudirman No. 1",
+		"short_address": "Jl. 1",
+		"access_token":  "abc",
+		"pin":           nil,
+	}).(map[string]any)
+
+	assert.Equal(t, "****1111", masked["card_number"], "the longest keyword wins")
+	assert.Equal(t, utils.Redacted, masked["card_holder"])
+	assert.Equal(t, "****7890", masked["phone"], "non-string values are masked in their string form")
+	assert.Regexp(t, `^sha256:[0-9a-f]{16}$`, masked["email"])
+	assert.Equal(t, masked["email"], utils.MaskField("billing_email", "jane@example.com"), "equal values give equal hashes")
+	assert.Equal(t, "Jl. Su...", masked["address"])
+	assert.Equal(t, "Jl. 1", masked["short_address"])
+	assert.Regexp(t, `^sha256:`, masked["access_token"], "a strategy wins over a sensitive key")
+	assert.Nil(t, masked["pin"])
+}
+
+func TestMasking_HashSalt(t *testing.T) {
+	configureMasking(t, config.MaskingConfig{Strategies: map[string]string{"phone": "sha256"}})
+	unsalted := utils.MaskField("phone", "6281234567890")
+
+	configureMasking(t, config.MaskingConfig{Strategies: map[string]string{"phone": "sha256"}, HashSalt: "pepper"})
+	assert.NotEqual(t, unsalted, utils.MaskField("phone", "6281234567890"))
+}
+
+func TestMasking_UnknownStrategy(t *testing.T) {
+	err := utils.ConfigureMasking(config.MaskingConfig{Strategies: map[string]string{"phone": "scramble"}})
+	assert.ErrorContains(t, err, `unknown masking strategy "scramble"`)
+}
+
+func TestRegisterMaskingStrategy(t *testing.T) {
+	utils.RegisterMaskingStrategy("Loyalty_Tier", utils.MaskTruncate)
+	utils.RegisterMaskingStrategy("member_phone", utils.MaskLast4)
+	configureMasking(t, config.MaskingConfig{Strategies: map[string]string{"member_phone": "redact"}})
+
+	assert.Equal(t, "platinum...", utils.MaskField("loyalty_tier", "platinum-plus"), "registered strategies survive a reconfiguration")
+	assert.Equal(t, utils.Redacted, utils.MaskField("member_phone", "6281234567890"), "the configured strategy wins")
+}
+
+func TestMaskConfig(t *testing.T) {
+	configureMasking(t, config.MaskingConfig{
+		SensitiveKeys: []string{"password", "secret"},
+		Patterns:      []string{`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`},
+	})
+	cfg := &config.Config{}
+	cfg.App.Name = "voyago"
+	cfg.Database = config.DatabaseConfig{Host: "db.internal", Password: "db-pass"}
+	cfg.Database.Tenants = map[string]config.DatabaseEndpointConfig{"acme": {Host: "acme.db", Password: "acme-pass"}}
+	cfg.Maintenance.Message = "Back soon, contact ops@example.com"
+
+	masked := utils.MaskConfig(cfg)
+
+	assert.Equal(t, "voyago", masked["app"].(map[string]any)["name"])
+	database := masked["database"].(map[string]any)
+	assert.Equal(t, "db.internal", database["host"])
+	assert.Equal(t, utils.Redacted, database["password"])
+	assert.Equal(t, utils.Redacted, database["tenants"].(map[string]any)["acme"].(map[string]any)["password"], "at any depth")
+	assert.Equal(t, "", masked["redis"].(map[string]any)["password"], "empty values are kept")
+	assert.Equal(t, "Back soon, contact [REDACTED]", masked["maintenance"].(map[string]any)["message"])
+	assert.Nil(t, utils.MaskConfig(nil))
+}
@@ -0,0 +1,64 @@
+package utils_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"voyago/core-api/internal/pkg/apperror"
+	"voyago/core-api/internal/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSpan struct {
+	tags   map[string]any
+	errors []error
+}
+
+func newRecordingSpan() *recordingSpan {
+	return &recordingSpan{tags: map[string]any{}}
+}
+
+func (s *recordingSpan) SetOperationName(string)         {}
+func (s *recordingSpan) Finish()                         {}
+func (s *recordingSpan) SetTag(key string, value any)    { s.tags[key] = value }
+func (s *recordingSpan) AddEvent(string, map[string]any) {}
+func (s *recordingSpan) RecordError(err error)           { s.errors = append(s.errors, err) }
+
+func TestRecordSpanError_AppError(t *testing.T) {
+	span := newRecordingSpan()
+	appErr := apperror.NewPersistance("BOOKING_NOT_FOUND", "booking not found")
+
+	utils.RecordSpanError(span, fmt.Errorf("get booking: %w", appErr))
+
+	require.Len(t, span.errors, 1)
+	assert.Equal(t, true, span.tags["error"])
+	assert.Equal(t, "get booking: booking not found", span.tags["error.message"])
+	assert.Equal(t, "*fmt.wrapError", span.tags["error.type"])
+	assert.Equal(t, "BOOKING_NOT_FOUND", span.tags["error.code"], "a wrapped AppError is detected")
+	assert.Equal(t, "PERSISTANCE", span.tags["error.kind"])
+}
+
+func TestRecordSpanError_Stack(t *testing.T) {
+	span := newRecordingSpan()
+
+	utils.RecordSpanError(span, errors.New("boom"))
+
+	stack, ok := span.tags["error.stack"].(string)
+	require.True(t, ok)
+	assert.Regexp(t, `^voyago/core-api/test/unit/pkg/utils_test\.TestRecordSpanError_Stack\n\t.+/trace_test\.go:\d+\n`, stack,
+		"the stack starts at the caller")
+	assert.NotContains(t, span.tags, "error.code")
+}
+
+func TestRecordSpanError_Nil(t *testing.T) {
+	span := newRecordingSpan()
+
+	utils.RecordSpanError(span, nil)
+	utils.RecordSpanError(nil, errors.New("boom"))
+
+	assert.Empty(t, span.tags)
+	assert.Empty(t, span.errors)
+}
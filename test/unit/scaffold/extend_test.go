@@ -0,0 +1,156 @@
+package scaffold_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"voyago/core-api/internal/scaffold"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bookingUseCaseContract = `package usecase
+
+import (
+	"context"
+)
+
+type CreateBookingUseCase interface {
+	Execute(ctx context.Context, id string) error
+}
+`
+
+const bookingRepositoryContract = `package repository
+
+type Marker interface{}
+`
+
+const invoiceEntity = "package entity\n\n" +
+	"type Invoice struct {\n" +
+	"\tID        string `gorm:\"column:id;primaryKey\"`\n" +
+	"\tNumber    string `gorm:\"column:invoice_no\"`\n" +
+	"\tAmount    float64\n" +
+	"\tLines     []InvoiceLine `gorm:\"foreignKey:InvoiceID\"`\n" +
+	"\tChecksum  []byte\n" +
+	"\tCreatedAt int64\n" +
+	"\tDeletedAt *int64\n" +
+	"\tcached    bool\n" +
+	"}\n\n" +
+	"type InvoiceLine struct {\n\tInvoiceID string\n}\n"
+
+// bookingRoot returns a repository holding the contracts of a booking module.
+func bookingRoot(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for p, content := range map[string]string{
+		"internal/modules/booking/usecase/contract.go":    bookingUseCaseContract,
+		"internal/modules/booking/repository/contract.go": bookingRepositoryContract,
+		"internal/modules/booking/entity/invoice.go":      invoiceEntity,
+	} {
+		dst := filepath.Join(root, filepath.FromSlash(p))
+		require.NoError(t, os.MkdirAll(filepath.Dir(dst), 0o755))
+		require.NoError(t, os.WriteFile(dst, []byte(content), 0o644))
+	}
+	return root
+}
+
+func filesByPath(files []scaffold.File) map[string]scaffold.File {
+	byPath := map[string]scaffold.File{}
+	for _, f := range files {
+		byPath[f.Path] = f
+	}
+	return byPath
+}
+
+func TestModule_UseCase_ExtendsTheContract(t *testing.T) {
+	root := bookingRoot(t)
+	m, err := scaffold.New("booking", "", "example.com/app", generatedAt)
+	require.NoError(t, err)
+
+	files, err := m.UseCase(root, "CancelBookingUseCase")
+
+	require.NoError(t, err)
+	byPath := filesByPath(files)
+	require.Len(t, byPath, 3)
+	contract := byPath["internal/modules/booking/usecase/contract.go"]
+	assert.True(t, contract.Replace)
+	assert.Contains(t, string(contract.Content), "type CreateBookingUseCase interface")
+	assert.Contains(t, string(contract.Content), "type CancelBookingUseCase interface {\n\tExecute(ctx context.Context, req *CancelBookingRequest) (*CancelBookingResponse, error)\n}")
+	impl := byPath["internal/modules/booking/usecase/cancel_booking.go"]
+	assert.False(t, impl.Replace)
+	assert.Contains(t, string(impl.Content), `const cancelBookingUseCaseName = "usecase:booking.cancel"`)
+	assert.Contains(t, string(impl.Content), "uc.Tracer.StartSpan(ctx, cancelBookingUseCaseName)")
+	assert.Contains(t, string(byPath["test/unit/booking/usecase/cancel_booking_test.go"].Content), "new(mocks.MockTracer)")
+}
+
+func TestModule_UseCase_NamesTheAction(t *testing.T) {
+	cases := map[string]string{
+		"CancelBooking":       "booking.cancel",
+		"ListBookings":        "booking.list",
+		"Archive":             "booking.archive",
+		"SendPaymentReminder": "booking.payment_reminder.send",
+	}
+	for name, action := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, err := scaffold.New("booking", "", "example.com/app", generatedAt)
+			require.NoError(t, err)
+
+			files, err := m.UseCase(bookingRoot(t), name)
+
+			require.NoError(t, err)
+			assert.Contains(t, string(files[0].Content), `"usecase:`+action+`"`)
+		})
+	}
+}
+
+func TestModule_UseCase_Errors(t *testing.T) {
+	cases := map[string]struct {
+		module, name, err string
+	}{
+		"declared use case": {"booking", "CreateBooking", "already declares CreateBookingUseCase"},
+		"unknown module":    {"billing", "PayInvoice", "module not found"},
+		"invalid name":      {"booking", "cancel_booking", "invalid use case name"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, err := scaffold.New(tc.module, "", "example.com/app", generatedAt)
+			require.NoError(t, err)
+
+			_, err = m.UseCase(bookingRoot(t), tc.name)
+
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestModule_Repository_ReadsTheEntityColumns(t *testing.T) {
+	root := bookingRoot(t)
+	m, err := scaffold.New("booking", "Invoice", "example.com/app", generatedAt)
+	require.NoError(t, err)
+
+	files, err := m.Repository(root)
+
+	require.NoError(t, err)
+	byPath := filesByPath(files)
+	query := string(byPath["internal/modules/booking/repository/query/invoice.go"].Content)
+	assert.Contains(t, query, "var invoiceColumns = []string{\n\t\"id\",\n\t\"invoice_no\",\n\t\"amount\",\n\t\"checksum\",\n\t\"created_at\",\n\t\"deleted_at\",\n}")
+	assert.Contains(t, query, `Where("id = ? AND deleted_at IS NULL", id)`)
+	assert.Contains(t, string(byPath["internal/modules/booking/repository/command/invoice.go"].Content), "func NewInvoiceRepository(")
+
+	contract := string(byPath["internal/modules/booking/repository/contract.go"].Content)
+	assert.Contains(t, contract, "type Marker interface{}")
+	assert.Contains(t, contract, "type InvoiceCommandRepository interface")
+	assert.Contains(t, contract, "FindByID(ctx context.Context, id string) (*entity.Invoice, error)")
+	assert.Contains(t, contract, "import (\n\t\"context\"\n\t\"example.com/app/internal/modules/booking/entity\"\n)")
+}
+
+func TestModule_Repository_NeedsTheEntity(t *testing.T) {
+	m, err := scaffold.New("booking", "Refund", "example.com/app", generatedAt)
+	require.NoError(t, err)
+
+	_, err = m.Repository(bookingRoot(t))
+
+	assert.ErrorContains(t, err, "entity Refund not found")
+}
@@ -0,0 +1,104 @@
+package scaffold_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"voyago/core-api/internal/scaffold"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repositoryRoot is the root of the repository, from the test directory.
+const repositoryRoot = "../../.."
+
+func TestMocks_AreUpToDate(t *testing.T) {
+	files, err := scaffold.Mocks(repositoryRoot, "voyago/core-api", nil)
+
+	require.NoError(t, err)
+	for _, f := range files {
+		current, err := os.ReadFile(filepath.Join(repositoryRoot, filepath.FromSlash(f.Path)))
+		require.NoError(t, err, "%s is missing: run voyago gen mocks", f.Path)
+		assert.True(t, bytes.Equal(f.Content, current), "%s is outdated: run voyago gen mocks", f.Path)
+	}
+}
+
+const demoContract = `package usecase
+
+import (
+	"context"
+	"time"
+
+	tq "voyago/core-api/internal/infrastructure/taskqueue"
+)
+
+type Report struct{}
+
+type DemoUseCase interface {
+	Run(ctx context.Context, m string, _ int, tags ...string) (rep *Report, ok bool, err error)
+	Stats(context.Context) (map[string][]Report, time.Duration)
+	Enqueue(ctx context.Context, task *tq.Task, fn func(*Report) error)
+}
+
+type notMocked interface {
+	Run()
+}
+`
+
+func demoMock(t *testing.T, contract string) (string, error) {
+	t.Helper()
+	files, err := scaffold.Mocks(repositoryRoot, "voyago/core-api", []scaffold.File{
+		{Path: "internal/modules/demo/usecase/contract.go", Content: []byte(contract)},
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		if f.Path == "test/mocks/demo_usecase.go" {
+			assert.True(t, f.Replace)
+			return string(f.Content), nil
+		}
+	}
+	t.Fatal("test/mocks/demo_usecase.go not generated")
+	return "", nil
+}
+
+func TestMocks_RendersTheMethods(t *testing.T) {
+	content, err := demoMock(t, demoContract)
+
+	require.NoError(t, err)
+	assert.Contains(t, content, "// Code generated by voyago gen mocks. DO NOT EDIT.")
+	assert.Contains(t, content, `tq "voyago/core-api/internal/infrastructure/taskqueue"`)
+	assert.Contains(t, content, "var _ usecase.DemoUseCase = (*MockDemoUseCase)(nil)")
+	assert.NotContains(t, content, "notMocked")
+
+	// Reserved and blank names are renamed, the variadic slice is recorded.
+	assert.Contains(t, content, "func (m *MockDemoUseCase) Run(ctx context.Context, p1 string, p2 int, tags ...string) (*usecase.Report, bool, error) {\n"+
+		"\targs := m.Called(ctx, p1, p2, tags)\n"+
+		"\tif f, ok := args.Get(0).(func(context.Context, string, int, ...string) (*usecase.Report, bool, error)); ok {\n"+
+		"\t\treturn f(ctx, p1, p2, tags...)\n"+
+		"\t}\n"+
+		"\tvar r0 *usecase.Report\n"+
+		"\tif v := args.Get(0); v != nil {\n"+
+		"\t\tr0 = v.(*usecase.Report)\n"+
+		"\t}\n"+
+		"\treturn r0, args.Bool(1), args.Error(2)\n}")
+	assert.Contains(t, content, "func (m *MockDemoUseCase) Stats(p0 context.Context) (map[string][]usecase.Report, time.Duration) {")
+	assert.Contains(t, content, "func (m *MockDemoUseCase) Enqueue(ctx context.Context, task *tq.Task, fn func(*usecase.Report) error) {\n"+
+		"\tm.Called(ctx, task, fn)\n}")
+}
+
+func TestMocks_RejectsDuplicateMocks(t *testing.T) {
+	_, err := demoMock(t, "package usecase\n\ntype CreateBookingUseCase interface {\n\tExecute() error\n}\n")
+
+	assert.ErrorContains(t, err, "MockCreateBookingUseCase is declared by both")
+}
+
+func TestMocks_RejectsEmbeddedInterfaces(t *testing.T) {
+	_, err := demoMock(t, "package usecase\n\nimport \"io\"\n\ntype DemoUseCase interface {\n\tio.Closer\n}\n")
+
+	assert.ErrorContains(t, err, "embedded interface io.Closer is not supported")
+}
@@ -0,0 +1,158 @@
+package scaffold_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/scaffold"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var generatedAt = time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+
+func TestNew_DerivesTheNames(t *testing.T) {
+	cases := []struct {
+		name, entity string
+		want         scaffold.Module
+	}{
+		{"loyalty", "LoyaltyReward", scaffold.Module{
+			Entity: "LoyaltyReward", Var: "loyaltyReward", Snake: "loyalty_reward", Table: "loyalty_rewards",
+			Route: "/loyalty-rewards", Code: "LOYALTY_REWARD", Label: "loyalty reward", Title: "Loyalty reward",
+		}},
+		{"billing", "", scaffold.Module{
+			Entity: "Billing", Var: "billing", Snake: "billing", Table: "billings",
+			Route: "/billings", Code: "BILLING", Label: "billing", Title: "Billing",
+		}},
+		{"catalog", "Category", scaffold.Module{
+			Entity: "Category", Var: "category", Snake: "category", Table: "categories",
+			Route: "/categories", Code: "CATEGORY", Label: "category", Title: "Category",
+		}},
+		{"access", "APIKey", scaffold.Module{
+			Entity: "APIKey", Var: "apiKey", Snake: "api_key", Table: "api_keys",
+			Route: "/api-keys", Code: "API_KEY", Label: "api key", Title: "Api key",
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := scaffold.New(tc.name, tc.entity, "example.com/app", generatedAt)
+
+			require.NoError(t, err)
+			tc.want.Name = tc.name
+			tc.want.ModulePath = "example.com/app"
+			tc.want.Version = "20261016150000"
+			tc.want.Columns = []string{"id", "name", "created_at", "updated_at"}
+			tc.want.SoftDelete = true
+			assert.Equal(t, tc.want, m)
+		})
+	}
+}
+
+func TestNew_RejectsInvalidNames(t *testing.T) {
+	for name, args := range map[string][2]string{
+		"uppercase module":   {"Loyalty", ""},
+		"underscored module": {"loyalty_program", ""},
+		"path module":        {"../booking", ""},
+		"lowercase entity":   {"loyalty", "reward"},
+		"entity with space":  {"loyalty", "Gold Reward"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := scaffold.New(args[0], args[1], "example.com/app", generatedAt)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestModule_Files_RendersTheSkeleton(t *testing.T) {
+	m, err := scaffold.New("loyalty", "Reward", "example.com/app", generatedAt)
+	require.NoError(t, err)
+
+	files, err := m.Files()
+
+	require.NoError(t, err)
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+		assert.NotContains(t, string(f.Content), "{{", f.Path)
+		assert.NotContains(t, string(f.Content), "<no value>", f.Path)
+	}
+	assert.ElementsMatch(t, []string{
+		"config/loyalty/config.example.yaml",
+		"internal/modules/loyalty/README.md",
+		"internal/modules/loyalty/module.go",
+		"internal/modules/loyalty/entity/reward.go",
+		"internal/modules/loyalty/repository/contract.go",
+		"internal/modules/loyalty/repository/command/reward.go",
+		"internal/modules/loyalty/repository/query/reward.go",
+		"internal/modules/loyalty/usecase/contract.go",
+		"internal/modules/loyalty/usecase/helper.go",
+		"internal/modules/loyalty/usecase/create_reward.go",
+		"internal/modules/loyalty/usecase/get_reward.go",
+		"internal/modules/loyalty/delivery/http/handler.go",
+		"internal/modules/loyalty/delivery/http/route.go",
+		"migrations/loyalty/20261016150000_init_domain_loyalty_database.up.sql",
+		"migrations/loyalty/20261016150000_init_domain_loyalty_database.down.sql",
+		"test/unit/loyalty/usecase/create_reward_test.go",
+		"test/integration/loyalty/create_reward_integration_test.go",
+	}, paths)
+}
+
+func TestModule_Files_UsesTheModulePath(t *testing.T) {
+	m, err := scaffold.New("loyalty", "Reward", "example.com/app", generatedAt)
+	require.NoError(t, err)
+
+	files, err := m.Files()
+
+	require.NoError(t, err)
+	for _, f := range files {
+		if f.Path == "internal/modules/loyalty/module.go" {
+			assert.Contains(t, string(f.Content), `"example.com/app/internal/modules/loyalty/usecase"`)
+			assert.True(t, strings.HasPrefix(string(f.Content), "package loyalty\n"))
+			return
+		}
+	}
+	t.Fatal("module.go not generated")
+}
+
+func TestWrite_NeverOverwrites(t *testing.T) {
+	root := t.TempDir()
+	existing := filepath.Join(root, "b.txt")
+	require.NoError(t, os.WriteFile(existing, []byte("kept"), 0o644))
+	files := []scaffold.File{
+		{Path: "dir/a.txt", Content: []byte("a")},
+		{Path: "b.txt", Content: []byte("b")},
+	}
+
+	err := scaffold.Write(root, files)
+
+	assert.ErrorIs(t, err, scaffold.ErrExists)
+	assert.NoFileExists(t, filepath.Join(root, "dir", "a.txt"))
+	content, _ := os.ReadFile(existing)
+	assert.Equal(t, "kept", string(content))
+}
+
+func TestWrite_CreatesTheDirectories(t *testing.T) {
+	root := t.TempDir()
+
+	err := scaffold.Write(root, []scaffold.File{{Path: "dir/sub/a.txt", Content: []byte("a")}})
+
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(root, "dir", "sub", "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(content))
+}
+
+func TestModulePath_ReadsGoMod(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n\ngo 1.25\n"), 0o644))
+
+	path, err := scaffold.ModulePath(root)
+
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/app", path)
+}
@@ -0,0 +1,175 @@
+package entity_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/netip"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/modules/webhook/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+func createValidEndpoint() *entity.WebhookEndpoint {
+	e := &entity.WebhookEndpoint{
+		ID:       "endpoint-id-123",
+		URL:      "https://example.com/hooks",
+		Secret:   "0123456789abcdef",
+		IsActive: true,
+	}
+	e.SetEventTypes([]string{"booking.created"})
+	return e
+}
+
+// ============================================================================
+// TEST CASES
+// ============================================================================
+
+func TestWebhookEndpoint_Validate_Success(t *testing.T) {
+	assert.NoError(t, createValidEndpoint().Validate())
+}
+
+func TestWebhookEndpoint_Validate_InvalidURL(t *testing.T) {
+	for _, u := range []string{"", "example.com/hooks", "ftp://example.com", "https://"} {
+		e := createValidEndpoint()
+		e.URL = u
+
+		assert.ErrorIs(t, e.Validate(), entity.ErrWebhookEndpointInvalidURL, u)
+	}
+}
+
+func TestWebhookEndpoint_ValidateTarget(t *testing.T) {
+	assert.NoError(t, createValidEndpoint().ValidateTarget())
+
+	for _, u := range []string{
+		"http://example.com/hooks", "https://localhost/hooks", "https://api.localhost/hooks",
+		"https://127.0.0.1/hooks", "https://169.254.169.254/latest/meta-data", "https://10.0.0.8/hooks",
+		"https://192.168.1.1/hooks", "https://[::1]/hooks", "https://[fd00::1]/hooks",
+		"https://[::ffff:127.0.0.1]/hooks", "https://0.0.0.0/hooks",
+	} {
+		e := createValidEndpoint()
+		e.URL = u
+
+		assert.ErrorIs(t, e.ValidateTarget(), entity.ErrWebhookEndpointInvalidURL, u)
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	cases := map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"169.254.169.254": false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.0.1":     false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fe80::1":         false,
+		"fd12::1":         false,
+		"::ffff:10.0.0.1": false,
+	}
+	for ip, public := range cases {
+		assert.Equal(t, public, entity.IsPublicAddr(netip.MustParseAddr(ip)), ip)
+	}
+}
+
+func TestWebhookEndpoint_Validate_EventTypesRequired(t *testing.T) {
+	e := createValidEndpoint()
+	e.SetEventTypes([]string{" ", ""})
+
+	assert.ErrorIs(t, e.Validate(), entity.ErrWebhookEventTypesRequired)
+}
+
+func TestWebhookEndpoint_Validate_SecretTooShort(t *testing.T) {
+	e := createValidEndpoint()
+	e.Secret = "short"
+
+	err := e.Validate()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "webhook secret is too short")
+}
+
+func TestWebhookEndpoint_SetEventTypes_Normalizes(t *testing.T) {
+	e := &entity.WebhookEndpoint{}
+	e.SetEventTypes([]string{" booking.created", "booking.created", "", "booking.cancelled "})
+
+	assert.Equal(t, "booking.created,booking.cancelled", e.EventTypes)
+	assert.Equal(t, []string{"booking.created", "booking.cancelled"}, e.GetEventTypes())
+}
+
+func TestWebhookEndpoint_Subscribes(t *testing.T) {
+	e := createValidEndpoint()
+	assert.True(t, e.Subscribes("booking.created"))
+	assert.False(t, e.Subscribes("booking.cancelled"))
+
+	e.SetEventTypes([]string{entity.EventTypeWildcard})
+	assert.True(t, e.Subscribes("booking.cancelled"))
+
+	e.IsActive = false
+	assert.False(t, e.Subscribes("booking.created"))
+}
+
+func TestBackoff(t *testing.T) {
+	base, max := 30*time.Second, time.Hour
+
+	assert.Equal(t, 30*time.Second, entity.Backoff(0, base, max))
+	assert.Equal(t, 30*time.Second, entity.Backoff(1, base, max))
+	assert.Equal(t, time.Minute, entity.Backoff(2, base, max))
+	assert.Equal(t, 32*time.Minute, entity.Backoff(7, base, max))
+	assert.Equal(t, time.Hour, entity.Backoff(8, base, max))
+	assert.Equal(t, time.Hour, entity.Backoff(50, base, max))
+}
+
+func TestSign(t *testing.T) {
+	payload := []byte(`{"id":"evt-1"}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(payload)))
+	expected := "t=1700000000,v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, expected, entity.Sign("secret", 1700000000, payload))
+	assert.NotEqual(t, expected, entity.Sign("other-secret", 1700000000, payload))
+}
+
+func TestWebhookDelivery_MarkAttemptFailed_SchedulesRetry(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	d := &entity.WebhookDelivery{Status: entity.DeliveryStatusPending}
+
+	d.MarkAttemptFailed(now, "timeout", 3, 30*time.Second, time.Hour)
+
+	assert.Equal(t, entity.DeliveryStatusPending, d.Status)
+	assert.Equal(t, 1, d.AttemptCount)
+	assert.Equal(t, now.Add(30*time.Second).UnixMilli(), d.NextAttemptAt)
+	assert.Equal(t, "timeout", *d.LastError)
+}
+
+func TestWebhookDelivery_MarkAttemptFailed_GivesUpAfterMaxAttempts(t *testing.T) {
+	now := time.Now()
+	d := &entity.WebhookDelivery{Status: entity.DeliveryStatusPending, AttemptCount: 2}
+
+	d.MarkAttemptFailed(now, "status 500", 3, 30*time.Second, time.Hour)
+
+	assert.Equal(t, entity.DeliveryStatusFailed, d.Status)
+	assert.Equal(t, 3, d.AttemptCount)
+}
+
+func TestWebhookDelivery_MarkSucceeded(t *testing.T) {
+	reason := "previous failure"
+	d := &entity.WebhookDelivery{Status: entity.DeliveryStatusPending, AttemptCount: 1, LastError: &reason}
+
+	d.MarkSucceeded(time.Now())
+
+	assert.Equal(t, entity.DeliveryStatusSucceeded, d.Status)
+	assert.Equal(t, 2, d.AttemptCount)
+	assert.Nil(t, d.LastError)
+}
@@ -0,0 +1,38 @@
+package sender_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/modules/webhook/sender"
+	"voyago/core-api/internal/modules/webhook/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHttpSender_RefusesInternalTargets(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+	}))
+	defer srv.Close()
+
+	s := sender.NewHttpSender(time.Second, false)
+
+	// The loopback test server stands for any internal service, e.g. a
+	// registered host name resolving to it.
+	res := s.Send(context.Background(), usecase.SendWebhookRequest{URL: srv.URL})
+	require.Error(t, res.Err)
+	assert.ErrorContains(t, res.Err, "not a public address")
+	assert.Zero(t, res.StatusCode)
+
+	res = s.Send(context.Background(), usecase.SendWebhookRequest{URL: "http://example.com/hooks"})
+	assert.ErrorContains(t, res.Err, "is not https")
+
+	assert.Zero(t, hits.Load(), "the internal target was never reached")
+}
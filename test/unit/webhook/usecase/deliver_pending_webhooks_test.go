@@ -0,0 +1,298 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"voyago/core-api/internal/infrastructure/config"
+	"voyago/core-api/internal/infrastructure/eventbus"
+	"voyago/core-api/internal/infrastructure/logger"
+	"voyago/core-api/internal/infrastructure/telemetry/tracer"
+	"voyago/core-api/internal/modules/webhook/entity"
+	"voyago/core-api/internal/modules/webhook/usecase"
+	"voyago/core-api/internal/pkg/events"
+	"voyago/core-api/internal/pkg/uid"
+	"voyago/core-api/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// TEST HELPERS
+// ============================================================================
+
+var testPolicy = usecase.DeliveryPolicy{
+	BatchSize:   10,
+	Timeout:     5 * time.Second,
+	MaxAttempts: 3,
+	BaseBackoff: 30 * time.Second,
+	MaxBackoff:  time.Hour,
+}
+
+func activeEndpoint(types ...string) entity.WebhookEndpoint {
+	e := entity.WebhookEndpoint{
+		ID:       "endpoint-1",
+		URL:      "https://example.com/hooks",
+		Secret:   "0123456789abcdef",
+		IsActive: true,
+	}
+	e.SetEventTypes(types)
+	return e
+}
+
+func setupDeliver() (*mocks.MockWebhookEndpointQueryRepository, *mocks.MockWebhookDeliveryCommandRepository, *mocks.MockWebhookSender, usecase.DeliverPendingWebhooksUseCase) {
+	endpointQry := new(mocks.MockWebhookEndpointQueryRepository)
+	deliveryCmd := new(mocks.MockWebhookDeliveryCommandRepository)
+	sender := new(mocks.MockWebhookSender)
+
+	uc := usecase.NewDeliverPendingWebhooksUseCase(
+		logger.NewNoOpLogger(),
+		tracer.NewNoOpTracer(),
+		sender,
+		testPolicy,
+		uid.UUIDv7,
+		usecase.DeliverPendingWebhooksRepositories{
+			EndpointQry: endpointQry,
+			DeliveryCmd: deliveryCmd,
+		},
+	)
+	return endpointQry, deliveryCmd, sender, uc
+}
+
+// ============================================================================
+// TEST CASES
+// ============================================================================
+
+func TestDeliverPendingWebhooks_Success_SignsAndRecordsAttempt(t *testing.T) {
+	endpointQry, deliveryCmd, sender, uc := setupDeliver()
+	endpoint := activeEndpoint("booking.created")
+	delivery := entity.WebhookDelivery{
+		ID:         "delivery-1",
+		EndpointID: endpoint.ID,
+		EventID:    "event-1",
+		EventType:  "booking.created",
+		Payload:    `{"id":"event-1"}`,
+		Status:     entity.DeliveryStatusPending,
+	}
+
+	deliveryCmd.On("ClaimDue", mock.Anything, mock.Anything, 2*testPolicy.Timeout, testPolicy.BatchSize).
+		Return([]entity.WebhookDelivery{delivery}, nil)
+	endpointQry.On("FindByID", mock.Anything, endpoint.ID).Return(&endpoint, nil)
+	sender.On("Send", mock.Anything, mock.MatchedBy(func(req usecase.SendWebhookRequest) bool {
+		return req.URL == endpoint.URL &&
+			req.Headers[entity.HeaderEvent] == "booking.created" &&
+			req.Headers[entity.HeaderDelivery] == "delivery-1" &&
+			req.Headers["Content-Type"] == events.ContentType &&
+			strings.HasPrefix(req.Headers[entity.HeaderSignature], "t=") &&
+			string(req.Body) == delivery.Payload
+	})).Return(usecase.SendWebhookResult{StatusCode: 200, Body: "ok", Duration: 20 * time.Millisecond})
+	deliveryCmd.On("CreateAttempt", mock.Anything, mock.MatchedBy(func(a *entity.WebhookDeliveryAttempt) bool {
+		return a.DeliveryID == "delivery-1" && a.AttemptNo == 1 && *a.ResponseStatus == 200 && a.Error == nil
+	})).Return(nil)
+	deliveryCmd.On("Update", mock.Anything, mock.MatchedBy(func(d *entity.WebhookDelivery) bool {
+		return d.Status == entity.DeliveryStatusSucceeded && d.AttemptCount == 1
+	})).Return(nil)
+
+	res, err := uc.Execute(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &usecase.DeliverPendingWebhooksResponse{Claimed: 1, Succeeded: 1}, res)
+	deliveryCmd.AssertExpectations(t)
+	sender.AssertExpectations(t)
+}
+
+func TestDeliverPendingWebhooks_Failure_SchedulesRetry(t *testing.T) {
+	endpointQry, deliveryCmd, sender, uc := setupDeliver()
+	endpoint := activeEndpoint("*")
+	delivery := entity.WebhookDelivery{ID: "delivery-1", EndpointID: endpoint.ID, Status: entity.DeliveryStatusPending}
+
+	deliveryCmd.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]entity.WebhookDelivery{delivery}, nil)
+	endpointQry.On("FindByID", mock.Anything, endpoint.ID).Return(&endpoint, nil)
+	sender.On("Send", mock.Anything, mock.Anything).
+		Return(usecase.SendWebhookResult{Err: errors.New("connection refused")})
+	deliveryCmd.On("CreateAttempt", mock.Anything, mock.MatchedBy(func(a *entity.WebhookDeliveryAttempt) bool {
+		return a.ResponseStatus == nil && *a.Error == "connection refused"
+	})).Return(nil)
+	deliveryCmd.On("Update", mock.Anything, mock.MatchedBy(func(d *entity.WebhookDelivery) bool {
+		return d.Status == entity.DeliveryStatusPending && d.AttemptCount == 1 && d.NextAttemptAt > time.Now().UnixMilli()
+	})).Return(nil)
+
+	res, err := uc.Execute(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Failed)
+	deliveryCmd.AssertExpectations(t)
+}
+
+func TestDeliverPendingWebhooks_DeletedEndpoint_FailsWithoutSending(t *testing.T) {
+	endpointQry, deliveryCmd, sender, uc := setupDeliver()
+	delivery := entity.WebhookDelivery{ID: "delivery-1", EndpointID: "gone", Status: entity.DeliveryStatusPending}
+
+	deliveryCmd.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]entity.WebhookDelivery{delivery}, nil)
+	endpointQry.On("FindByID", mock.Anything, "gone").Return(nil, nil)
+	deliveryCmd.On("Update", mock.Anything, mock.MatchedBy(func(d *entity.WebhookDelivery) bool {
+		return d.Status == entity.DeliveryStatusFailed
+	})).Return(nil)
+
+	res, err := uc.Execute(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Failed)
+	sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestEnqueueWebhookDeliveries_FiltersBySubscription(t *testing.T) {
+	endpointQry := new(mocks.MockWebhookEndpointQueryRepository)
+	deliveryCmd := new(mocks.MockWebhookDeliveryCommandRepository)
+	n := 0
+	ids := uid.GeneratorFunc(func() string {
+		n++
+		return fmt.Sprintf("delivery-%d", n)
+	})
+	uc := usecase.NewEnqueueWebhookDeliveriesUseCase(
+		logger.NewNoOpLogger(),
+		tracer.NewNoOpTracer(),
+		ids,
+		events.NewEncoder("voyago-test", tracer.NewNoOpTracer()),
+		usecase.EnqueueWebhookDeliveriesRepositories{EndpointQry: endpointQry, DeliveryCmd: deliveryCmd},
+	)
+
+	subscribed := activeEndpoint("booking.created")
+	wildcard := activeEndpoint("*")
+	wildcard.ID = "endpoint-2"
+	other := activeEndpoint("booking.cancelled")
+	other.ID = "endpoint-3"
+
+	evt := eventbus.NewEvent("booking.created", "booking", map[string]string{"booking_id": "b-1"})
+
+	endpointQry.On("FindActive", mock.Anything).Return([]entity.WebhookEndpoint{subscribed, wildcard, other}, nil)
+	deliveryCmd.On("CreateMany", mock.Anything, mock.MatchedBy(func(ds []entity.WebhookDelivery) bool {
+		return len(ds) == 2 &&
+			ds[0].EndpointID == "endpoint-1" && ds[1].EndpointID == "endpoint-2" &&
+			ds[0].ID == "delivery-1" && ds[1].ID == "delivery-2" &&
+			ds[0].EventID == evt.ID && ds[0].Payload == ds[1].Payload &&
+			strings.Contains(ds[0].Payload, `"specversion":"1.0"`) &&
+			strings.Contains(ds[0].Payload, `"booking_id":"b-1"`)
+	})).Return(nil)
+
+	count, err := uc.Execute(context.Background(), evt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	deliveryCmd.AssertExpectations(t)
+}
+
+func TestEnqueueWebhookDeliveries_NoSubscribers(t *testing.T) {
+	endpointQry := new(mocks.MockWebhookEndpointQueryRepository)
+	deliveryCmd := new(mocks.MockWebhookDeliveryCommandRepository)
+	uc := usecase.NewEnqueueWebhookDeliveriesUseCase(
+		logger.NewNoOpLogger(),
+		tracer.NewNoOpTracer(),
+		uid.UUIDv7,
+		events.NewEncoder("voyago-test", tracer.NewNoOpTracer()),
+		usecase.EnqueueWebhookDeliveriesRepositories{EndpointQry: endpointQry, DeliveryCmd: deliveryCmd},
+	)
+
+	endpointQry.On("FindActive", mock.Anything).Return([]entity.WebhookEndpoint{activeEndpoint("booking.cancelled")}, nil)
+
+	count, err := uc.Execute(context.Background(), eventbus.NewEvent("booking.created", "booking", nil))
+
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	deliveryCmd.AssertNotCalled(t, "CreateMany", mock.Anything, mock.Anything)
+}
+
+// linkRecordingTracer records the links of the spans started with links.
+type linkRecordingTracer struct {
+	tracer.Tracer
+	links map[string][]tracer.Link
+}
+
+func (t *linkRecordingTracer) StartSpanWithLinks(ctx context.Context, name string, links ...tracer.Link) (tracer.Span, context.Context) {
+	t.links[name] = append(t.links[name], links...)
+	return t.Tracer.StartSpan(ctx, name)
+}
+
+func TestWebhookDelivery_LinksToEnqueuingTrace(t *testing.T) {
+	trc, err := tracer.NewOTelTracer("voyago-test", "test", "127.0.0.1:1", 1, config.TraceSamplingConfig{})
+	assert.NoError(t, err)
+
+	// Enqueued while handling a request: the delivery keeps its trace context.
+	endpointQry := new(mocks.MockWebhookEndpointQueryRepository)
+	deliveryCmd := new(mocks.MockWebhookDeliveryCommandRepository)
+	enqueue := usecase.NewEnqueueWebhookDeliveriesUseCase(
+		logger.NewNoOpLogger(),
+		trc,
+		uid.UUIDv7,
+		events.NewEncoder("voyago-test", trc),
+		usecase.EnqueueWebhookDeliveriesRepositories{EndpointQry: endpointQry, DeliveryCmd: deliveryCmd},
+	)
+	var enqueued []entity.WebhookDelivery
+	endpointQry.On("FindActive", mock.Anything).Return([]entity.WebhookEndpoint{activeEndpoint("*")}, nil)
+	deliveryCmd.On("CreateMany", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		enqueued = args.Get(1).([]entity.WebhookDelivery)
+	}).Return(nil)
+
+	span, ctx := trc.StartSpan(context.Background(), "HTTP POST /api/v1/bookings")
+	requestTraceID, _, _ := trc.ExtractTraceInfo(ctx)
+	_, err = enqueue.Execute(ctx, eventbus.NewEvent("booking.created", "booking", nil))
+	span.Finish()
+	assert.NoError(t, err)
+	require.Len(t, enqueued, 1)
+	require.NotNil(t, enqueued[0].TraceContext)
+
+	// Delivered by the worker in its own trace, linked to the request.
+	links := &linkRecordingTracer{Tracer: trc, links: map[string][]tracer.Link{}}
+	endpointQry2 := new(mocks.MockWebhookEndpointQueryRepository)
+	deliveryCmd2 := new(mocks.MockWebhookDeliveryCommandRepository)
+	sender := new(mocks.MockWebhookSender)
+	deliver := usecase.NewDeliverPendingWebhooksUseCase(logger.NewNoOpLogger(), links, sender, testPolicy, uid.UUIDv7,
+		usecase.DeliverPendingWebhooksRepositories{EndpointQry: endpointQry2, DeliveryCmd: deliveryCmd2})
+
+	endpoint := activeEndpoint("*")
+	endpointQry2.On("FindByID", mock.Anything, endpoint.ID).Return(&endpoint, nil)
+	deliveryCmd2.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(enqueued, nil)
+	deliveryCmd2.On("CreateAttempt", mock.Anything, mock.Anything).Return(nil)
+	deliveryCmd2.On("Update", mock.Anything, mock.Anything).Return(nil)
+	sender.On("Send", mock.Anything, mock.Anything).Return(usecase.SendWebhookResult{StatusCode: 200})
+
+	_, err = deliver.Execute(context.Background())
+	assert.NoError(t, err)
+
+	delivered := links.links["usecase:webhook.delivery.deliver"]
+	require.Len(t, delivered, 1)
+	assert.Contains(t, delivered[0].Context.Get("traceparent"), requestTraceID)
+	assert.Equal(t, enqueued[0].EventID, delivered[0].Attributes["webhook.event_id"])
+}
+
+func TestWebhookDelivery_WithoutTraceContext(t *testing.T) {
+	endpointQry, deliveryCmd, sender, _ := setupDeliver()
+	links := &linkRecordingTracer{Tracer: tracer.NewNoOpTracer(), links: map[string][]tracer.Link{}}
+	uc := usecase.NewDeliverPendingWebhooksUseCase(logger.NewNoOpLogger(), links, sender, testPolicy, uid.UUIDv7,
+		usecase.DeliverPendingWebhooksRepositories{EndpointQry: endpointQry, DeliveryCmd: deliveryCmd})
+
+	malformed := "not json"
+	endpoint := activeEndpoint("*")
+	endpointQry.On("FindByID", mock.Anything, endpoint.ID).Return(&endpoint, nil)
+	deliveryCmd.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]entity.WebhookDelivery{
+		{ID: "delivery-1", EndpointID: endpoint.ID, EventType: "booking.created", Payload: "{}", Status: entity.DeliveryStatusPending},
+		{ID: "delivery-2", EndpointID: endpoint.ID, EventType: "booking.created", Payload: "{}", Status: entity.DeliveryStatusPending, TraceContext: &malformed},
+	}, nil)
+	deliveryCmd.On("CreateAttempt", mock.Anything, mock.Anything).Return(nil)
+	deliveryCmd.On("Update", mock.Anything, mock.Anything).Return(nil)
+	sender.On("Send", mock.Anything, mock.Anything).Return(usecase.SendWebhookResult{StatusCode: 200})
+
+	res, err := uc.Execute(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Succeeded, "a delivery without trace context is still delivered")
+	assert.Empty(t, links.links["usecase:webhook.delivery.deliver"])
+}